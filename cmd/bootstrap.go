@@ -4,35 +4,150 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 
+	baselinejson "github.com/olusolaa/infra-drift-detector/internal/adapters/baseline/jsonfile"
+	cachejson "github.com/olusolaa/infra-drift-detector/internal/adapters/cache/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/health"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/history/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/lifecycle"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/matching/identifier"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/matching/strategy"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/matching/tag"
+	prommetrics "github.com/olusolaa/infra-drift-detector/internal/adapters/metrics/prometheus"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/notify/slack"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/notify/webhook"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
 	awsshared "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure"
+	azureshared "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp"
+	gcpshared "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes"
+	k8sshared "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/amimanifest"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/manifests"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/mapping"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/pulumi"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/remote"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/s3backend"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfhcl"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfplan"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/tracing/otlp"
 	"github.com/olusolaa/infra-drift-detector/internal/config"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	"github.com/olusolaa/infra-drift-detector/internal/core/service"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/exitpolicy"
+	"github.com/olusolaa/infra-drift-detector/internal/filter"
 	"github.com/olusolaa/infra-drift-detector/internal/log"
+	csvreport "github.com/olusolaa/infra-drift-detector/internal/reporting/csv"
+	htmlreport "github.com/olusolaa/infra-drift-detector/internal/reporting/html"
 	jsonreport "github.com/olusolaa/infra-drift-detector/internal/reporting/json"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/links"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/markdown"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/ocsf"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/partition"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/progress"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/sarif"
+	templatereport "github.com/olusolaa/infra-drift-detector/internal/reporting/template"
 	"github.com/olusolaa/infra-drift-detector/internal/reporting/text"
 	"github.com/olusolaa/infra-drift-detector/internal/resources/compute"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/database"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/generic"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/identity"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/knowledge"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/network"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/serverless"
 	"github.com/olusolaa/infra-drift-detector/internal/resources/storage"
+	"github.com/olusolaa/infra-drift-detector/internal/transform"
 )
 
 type BootstrapResult struct {
 	Logger ports.Logger
-	Engine ports.DriftAnalysisEngine
+	// LogFile is the file logs are written to, or nil when logging to stderr.
+	LogFile *log.File
+	Engine  ports.DriftAnalysisEngine
+	// Scheduler runs the engine on per-kind cadences. It is only set when
+	// bootstrapping daemon mode.
+	Scheduler *service.Scheduler
+	// Health serves the daemon health endpoints. It is only set in daemon mode
+	// with 'daemon.health' configured.
+	Health *health.Server
+	// Metrics serves the daemon metrics endpoint. It is only set in daemon mode
+	// with 'daemon.metrics' configured.
+	Metrics *prommetrics.Metrics
+	// Service configures running under a service manager in daemon mode.
+	Service lifecycle.Config
+	// ExitPolicy decides the exit code of a scan from its results.
+	ExitPolicy *exitpolicy.Policy
+	// Tracing exports the spans of every run. It is nil unless 'tracing' is
+	// configured.
+	Tracing *otlp.Provider
+	// Progress shows the progress of the scan on stderr. It is nil unless
+	// requested with withProgress and not disabled by 'settings.no_progress'.
+	Progress *progress.Display
 }
 
-func bootstrap(ctx context.Context, v *viper.Viper) (*BootstrapResult, error) {
+// Close stops the progress view and flushes the spans not exported yet.
+// Commands call it before exiting.
+func (r *BootstrapResult) Close() {
+	if r.Progress != nil {
+		r.Progress.Close()
+	}
+	if r.Tracing == nil {
+		return
+	}
+	if err := r.Tracing.Close(); err != nil {
+		r.Logger.Warnf(context.Background(), "Failed to export remaining trace spans: %v", err)
+	}
+}
+
+type bootstrapOptions struct {
+	reporter  ports.Reporter
+	collector *collectingReporter
+	progress  bool
+}
+
+type bootstrapOption func(*bootstrapOptions)
+
+// withReporter replaces the configured reporter, for commands that consume the
+// results themselves instead of printing a report.
+func withReporter(reporter ports.Reporter) bootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.reporter = reporter
+	}
+}
+
+// withResultCollector keeps the reported results in collector as well, for
+// commands that act on the results after the report is printed.
+func withResultCollector(collector *collectingReporter) bootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.collector = collector
+	}
+}
+
+// withProgress shows the progress of the scan on stderr while it runs.
+func withProgress() bootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.progress = true
+	}
+}
+
+func bootstrap(ctx context.Context, v *viper.Viper, daemon bool, opts ...bootstrapOption) (*BootstrapResult, error) {
+	var options bootstrapOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	cfg, err := initConfig(ctx, v)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "FATAL: Configuration failed: %v\n", err)
@@ -45,7 +160,12 @@ func bootstrap(ctx context.Context, v *viper.Viper) (*BootstrapResult, error) {
 		return nil, err
 	}
 
-	logger, err := initLogger(ctx, cfg)
+	var display *progress.Display
+	if options.progress && !cfg.Settings.NoProgress {
+		display = progress.NewDisplay(os.Stderr)
+	}
+
+	logger, logFile, err := initLogger(ctx, cfg, display)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "FATAL: Failed to initialize logger: %v\n", err)
 		return nil, err
@@ -60,31 +180,66 @@ func bootstrap(ctx context.Context, v *viper.Viper) (*BootstrapResult, error) {
 	registry := service.NewComponentRegistry()
 	logger.Debugf(ctx, "Component registry initialized")
 
+	err = initCustomKinds(ctx, cfg, logger)
+	if err != nil {
+		logger.Errorf(ctx, err, "Failed to register custom resource kinds")
+		return nil, err
+	}
+
 	stateProvider, err := initStateProvider(ctx, cfg, registry, logger)
 	if err != nil {
 		logger.Errorf(ctx, err, "Failed to initialize state provider")
 		return nil, err
 	}
 
-	platformProvider, err := initPlatformProvider(ctx, cfg, registry, logger)
+	metrics, err := initMetrics(ctx, cfg, daemon, logger)
+	if err != nil {
+		logger.Errorf(ctx, err, "Failed to initialize metrics")
+		return nil, err
+	}
+
+	tracing, err := initTracing(ctx, cfg, logger)
+	if err != nil {
+		logger.Errorf(ctx, err, "Failed to initialize tracing")
+		return nil, err
+	}
+
+	platformProvider, err := initPlatformProvider(ctx, cfg, registry, metrics, tracing, logger)
 	if err != nil {
 		logger.Errorf(ctx, err, "Failed to initialize platform provider")
 		return nil, err
 	}
 
-	matcher, err := initMatcher(ctx, cfg, logger)
+	exitPolicy, err := exitpolicy.NewPolicy(cfg.ExitPolicy)
 	if err != nil {
-		logger.Errorf(ctx, err, "Failed to initialize matcher")
+		logger.Errorf(ctx, err, "Failed to initialize exit policy")
 		return nil, err
 	}
 
-	reporter, err := initReporter(ctx, cfg, logger)
+	matcher, err := initMatcher(ctx, cfg, logger)
 	if err != nil {
-		logger.Errorf(ctx, err, "Failed to initialize reporter")
+		logger.Errorf(ctx, err, "Failed to initialize matcher")
 		return nil, err
 	}
 
-	err = initComparers(ctx, registry, logger)
+	reporter := options.reporter
+	if reporter == nil {
+		reporter, err = initReporter(ctx, cfg, logger)
+		if err != nil {
+			logger.Errorf(ctx, err, "Failed to initialize reporter")
+			return nil, err
+		}
+	}
+	if options.collector != nil {
+		reporter = &teeReporter{reporters: []ports.Reporter{reporter, options.collector}}
+	}
+	var merger *service.MergingReporter
+	if daemon {
+		merger = service.NewMergingReporter(reporter)
+		reporter = merger
+	}
+
+	err = initComparers(ctx, cfg, registry, logger)
 	if err != nil {
 		logger.Errorf(ctx, err, "Failed to initialize comparers")
 		return nil, err
@@ -97,18 +252,98 @@ func bootstrap(ctx context.Context, v *viper.Viper) (*BootstrapResult, error) {
 
 	engine, err := initEngine(
 		ctx, cfg, registry, matcher, reporter, logger,
-		stateProvider, platformProvider, attributeOverrides,
+		stateProvider, platformProvider, attributeOverrides, metrics, tracing, display,
 	)
 	if err != nil {
 		logger.Errorf(ctx, err, "Failed to initialize engine")
 		return nil, err
 	}
 
+	result := &BootstrapResult{
+		Logger:     logger,
+		LogFile:    logFile,
+		Engine:     engine,
+		ExitPolicy: exitPolicy,
+		Tracing:    tracing,
+		Progress:   display,
+	}
+	if daemon {
+		if cfg.Daemon != nil && cfg.Daemon.Service != nil {
+			result.Service = *cfg.Daemon.Service
+		}
+		result.Scheduler, err = initScheduler(ctx, cfg, engine, merger, logger)
+		if err != nil {
+			logger.Errorf(ctx, err, "Failed to initialize scheduler")
+			return nil, err
+		}
+		result.Health, err = initHealthServer(ctx, cfg, result.Scheduler, stateProvider, platformProvider, logger)
+		if err != nil {
+			logger.Errorf(ctx, err, "Failed to initialize health server")
+			return nil, err
+		}
+		result.Metrics = metrics
+	}
+
 	logger.Infof(ctx, "Application bootstrap complete")
-	return &BootstrapResult{
-		Logger: logger,
-		Engine: engine,
-	}, nil
+	return result, nil
+}
+
+func initScheduler(ctx context.Context, cfg *config.Config, engine ports.DriftAnalysisEngine, merger *service.MergingReporter, logger ports.Logger) (*service.Scheduler, error) {
+	runner, ok := engine.(ports.KindScopedRunner)
+	if !ok {
+		return nil, errors.New(errors.CodeInternal, "engine does not support scanning a subset of kinds")
+	}
+	kinds := cfg.GetResourceKinds()
+	schedules := make([]service.KindSchedule, 0, len(kinds))
+	for _, kind := range kinds {
+		interval := cfg.GetScanIntervalForKind(kind)
+		logger.Debugf(ctx, "Daemon scanning kind '%s' every %s", kind, interval)
+		schedules = append(schedules, service.KindSchedule{Kind: kind, Interval: interval})
+	}
+	return service.NewScheduler(runner, schedules,
+		logger.WithFields(map[string]any{"component": "scheduler"}),
+		service.WithMergingReporter(merger))
+}
+
+// initMetrics creates the daemon metrics endpoint when it is configured. Scans
+// outside daemon mode are not measured.
+func initMetrics(ctx context.Context, cfg *config.Config, daemon bool, logger ports.Logger) (*prommetrics.Metrics, error) {
+	if !daemon || cfg.Daemon == nil || cfg.Daemon.Metrics == nil || cfg.Daemon.Metrics.Address == "" {
+		return nil, nil
+	}
+	logger.Debugf(ctx, "Metrics endpoint enabled on %s", cfg.Daemon.Metrics.Address)
+	return prommetrics.NewMetrics(*cfg.Daemon.Metrics, logger.WithFields(map[string]any{"component": "metrics"}))
+}
+
+// initTracing creates the OTLP span exporter when tracing is configured.
+func initTracing(ctx context.Context, cfg *config.Config, logger ports.Logger) (*otlp.Provider, error) {
+	if cfg.Tracing == nil {
+		return nil, nil
+	}
+	logger.Debugf(ctx, "Exporting trace spans to %s", cfg.Tracing.Endpoint)
+	return otlp.NewProvider(ctx, *cfg.Tracing)
+}
+
+// initHealthServer creates the daemon health endpoints when they are configured.
+// Providers that support a self-test are checked for connectivity by /readyz,
+// and readiness is lost after two of the longest scan intervals without a
+// successful scan unless 'daemon.health.max_staleness' says otherwise.
+func initHealthServer(ctx context.Context, cfg *config.Config, scheduler *service.Scheduler, stateProvider ports.StateProvider, platformProvider ports.PlatformProvider, logger ports.Logger) (*health.Server, error) {
+	if cfg.Daemon == nil || cfg.Daemon.Health == nil || cfg.Daemon.Health.Address == "" {
+		return nil, nil
+	}
+	var checks []health.ConnectivityCheck
+	if tester, ok := stateProvider.(ports.SelfTester); ok {
+		checks = append(checks, health.ConnectivityCheck{Name: stateProvider.Type(), Tester: tester})
+	}
+	if tester, ok := platformProvider.(ports.SelfTester); ok {
+		checks = append(checks, health.ConnectivityCheck{Name: platformProvider.Type(), Tester: tester})
+	}
+	logger.Debugf(ctx, "Health endpoints enabled on %s", cfg.Daemon.Health.Address)
+	return health.NewServer(*cfg.Daemon.Health, scheduler,
+		logger.WithFields(map[string]any{"component": "health"}),
+		health.WithConnectivityChecks(scheduler.Kinds(), checks...),
+		health.WithDefaultMaxStaleness(2*scheduler.LongestInterval()))
 }
 
 func initConfig(ctx context.Context, v *viper.Viper) (*config.Config, error) {
@@ -132,14 +367,28 @@ func initConfig(ctx context.Context, v *viper.Viper) (*config.Config, error) {
 	return cfg, nil
 }
 
-func initLogger(ctx context.Context, cfg *config.Config) (ports.Logger, error) {
+// initLogger creates the logger. With 'settings.log_file' set it also returns
+// the opened log file, so daemon mode can reopen it after rotation; logs
+// written to stderr go through the progress display, when there is one, so
+// they do not garble its live view.
+func initLogger(ctx context.Context, cfg *config.Config, display *progress.Display) (ports.Logger, *log.File, error) {
 	logCfg := log.Config{Level: cfg.Settings.LogLevel, Format: cfg.Settings.LogFormat}
+	var logFile *log.File
+	if cfg.Settings.LogFile != "" {
+		var err error
+		if logFile, err = log.OpenFile(cfg.Settings.LogFile); err != nil {
+			return nil, nil, err
+		}
+		logCfg.Output = logFile
+	} else if display != nil {
+		logCfg.Output = display
+	}
 	logger, err := log.NewLogger(logCfg)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeInternal, "logger initialization failed")
+		return nil, nil, errors.Wrap(err, errors.CodeInternal, "logger initialization failed")
 	}
 	logger.Infof(ctx, "Logger initialized (Level: %s, Format: %s)", cfg.Settings.LogLevel, cfg.Settings.LogFormat)
-	return logger, nil
+	return logger, logFile, nil
 }
 
 func logConfigFileUsage(ctx context.Context, v *viper.Viper, logger ports.Logger) {
@@ -172,12 +421,44 @@ func initStateProvider(ctx context.Context, cfg *config.Config, registry *servic
 		}
 	case tfhcl.ProviderTypeTFHCL:
 		provLog := logger.WithFields(map[string]any{"provider": tfhcl.ProviderTypeTFHCL})
-		stateProvider, err = tfhcl.NewProvider(*cfg.State.TFHCL, provLog)
+		tfhclCfg := *cfg.State.TFHCL
+		tfhclCfg.UnknownTolerant = cfg.Settings.UnknownTolerant
+		stateProvider, err = tfhcl.NewProvider(tfhclCfg, provLog)
 		if err == nil {
 			provLog.Infof(ctx, "Using TFHCL provider: %s (Workspace: %s)", cfg.State.TFHCL.Directory, cfg.State.TFHCL.Workspace)
 		}
+	case tfplan.ProviderTypeTFPlan:
+		provLog := logger.WithFields(map[string]any{"provider": tfplan.ProviderTypeTFPlan})
+		stateProvider, err = tfplan.NewProvider(*cfg.State.TFPlan, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using Terraform plan as desired state: %s", cfg.State.TFPlan.Path)
+		}
+	case remote.ProviderTypeRemote:
+		provLog := logger.WithFields(map[string]any{"provider": remote.ProviderTypeRemote})
+		stateProvider, err = remote.NewProvider(*cfg.State.Remote, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using remote state provider: %s (%s/%s)", cfg.State.Remote.Platform, cfg.State.Remote.Organization, cfg.State.Remote.Workspace)
+		}
+	case s3backend.ProviderTypeS3:
+		provLog := logger.WithFields(map[string]any{"provider": s3backend.ProviderTypeS3})
+		stateProvider, err = s3backend.NewProvider(ctx, *cfg.State.S3, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using S3 state backend: s3://%s/%s", cfg.State.S3.Bucket, cfg.State.S3.StateKey())
+		}
+	case pulumi.ProviderTypePulumi:
+		provLog := logger.WithFields(map[string]any{"provider": pulumi.ProviderTypePulumi})
+		stateProvider, err = pulumi.NewProvider(*cfg.State.Pulumi, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using Pulumi state provider: %s", cfg.State.Pulumi.Path)
+		}
+	case manifests.ProviderTypeManifests:
+		provLog := logger.WithFields(map[string]any{"provider": manifests.ProviderTypeManifests})
+		stateProvider, err = manifests.NewProvider(*cfg.State.Manifests, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using Kubernetes manifests state provider: %v (kustomize: %s)", cfg.State.Manifests.Paths, cfg.State.Manifests.Kustomize)
+		}
 	default:
-		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("invalid state provider type: %s", cfg.State.ProviderType), "Supported: tfstate, tfhcl")
+		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("invalid state provider type: %s", cfg.State.ProviderType), "Supported: tfstate, tfhcl, tfplan, remote, s3, pulumi, manifests")
 	}
 
 	if err != nil {
@@ -190,18 +471,50 @@ func initStateProvider(ctx context.Context, cfg *config.Config, registry *servic
 	return stateProvider, nil
 }
 
-func initPlatformProvider(ctx context.Context, cfg *config.Config, registry *service.ComponentRegistry, logger ports.Logger) (ports.PlatformProvider, error) {
+func initPlatformProvider(ctx context.Context, cfg *config.Config, registry *service.ComponentRegistry, metrics *prommetrics.Metrics, tracing *otlp.Provider, logger ports.Logger) (ports.PlatformProvider, error) {
 	var platformProvider ports.PlatformProvider
 	var err error
 
-	if cfg.Platform.AWS != nil {
+	if cfg.Platform.GCP != nil {
+		provLog := logger.WithFields(map[string]any{"provider": gcpshared.ProviderTypeGCP})
+		platformProvider, err = gcp.NewProvider(ctx, *cfg.Platform.GCP, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using GCP platform provider for project %s", cfg.Platform.GCP.Project)
+		}
+	} else if cfg.Platform.Azure != nil {
+		provLog := logger.WithFields(map[string]any{"provider": azureshared.ProviderTypeAzure})
+		platformProvider, err = azure.NewProvider(ctx, *cfg.Platform.Azure, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using Azure platform provider for subscription %s", cfg.Platform.Azure.SubscriptionID)
+		}
+	} else if cfg.Platform.Kubernetes != nil {
+		provLog := logger.WithFields(map[string]any{"provider": k8sshared.ProviderTypeKubernetes})
+		platformProvider, err = kubernetes.NewProvider(ctx, *cfg.Platform.Kubernetes, provLog)
+		if err == nil {
+			provLog.Infof(ctx, "Using Kubernetes platform provider")
+		}
+	} else if cfg.Platform.AWS != nil {
 		provLog := logger.WithFields(map[string]any{"provider": awsshared.ProviderTypeAWS})
-		platformProvider, err = aws.NewProvider(ctx, cfg, provLog)
+		var awsOpts []aws.ProviderOption
+		if metrics != nil {
+			awsOpts = append(awsOpts, aws.WithAPICallMetrics(metrics))
+		}
+		if tracing != nil {
+			awsOpts = append(awsOpts, aws.WithTracerProvider(tracing))
+		}
+		if cfg.Settings.Cache.Enabled {
+			cache, cacheErr := newAttributeCache(ctx, cfg.Settings.Cache, logger)
+			if cacheErr != nil {
+				return nil, cacheErr
+			}
+			awsOpts = append(awsOpts, aws.WithAttributeCache(cache))
+		}
+		platformProvider, err = aws.NewProvider(ctx, cfg, provLog, awsOpts...)
 		if err == nil {
 			provLog.Infof(ctx, "Using AWS platform provider")
 		}
 	} else {
-		err = errors.NewUserFacing(errors.CodeConfigValidation, "no supported platform provider configured", "Configure platform.aws section.")
+		err = errors.NewUserFacing(errors.CodeConfigValidation, "no supported platform provider configured", "Configure the platform.aws, platform.gcp, platform.azure or platform.kubernetes section.")
 	}
 
 	if err != nil {
@@ -214,6 +527,25 @@ func initPlatformProvider(ctx context.Context, cfg *config.Config, registry *ser
 	return platformProvider, nil
 }
 
+// newAttributeCache opens the on-disk attribute cache, in the user cache
+// directory unless one is configured.
+func newAttributeCache(ctx context.Context, cfg config.CacheConfig, logger ports.Logger) (ports.AttributeCache, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		var err error
+		if dir, err = cachejson.DefaultDir(); err != nil {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("cannot locate the user cache directory: %v", err), "Set settings.cache.dir.")
+		}
+	}
+	cacheLog := logger.WithFields(map[string]any{"component": "attribute_cache"})
+	store, err := cachejson.NewStore(dir, cfg.TTL, cacheLog)
+	if err != nil {
+		return nil, err
+	}
+	cacheLog.Debugf(ctx, "Caching platform resource attributes in %s", dir)
+	return store, nil
+}
+
 func initMatcher(ctx context.Context, cfg *config.Config, logger ports.Logger) (ports.Matcher, error) {
 	var matcher ports.Matcher
 	var err error
@@ -228,8 +560,22 @@ func initMatcher(ctx context.Context, cfg *config.Config, logger ports.Logger) (
 		if err == nil {
 			matchLog.Infof(ctx, "Using Tag matcher with key: %s", cfg.Settings.Matcher.Tag.TagKey)
 		}
+	case identifier.MatcherTypeIdentifier:
+		matcher = identifier.NewMatcher(logger.WithFields(map[string]any{"component": "matcher", "type": identifier.MatcherTypeIdentifier}))
+		logger.Infof(ctx, "Using identifier matcher")
+	case strategy.MatcherTypeStrategy:
+		var strategyCfg strategy.Config
+		if cfg.Settings.Matcher.Strategy != nil {
+			strategyCfg = *cfg.Settings.Matcher.Strategy
+		}
+		strategyCfg.Identity = cfg.GetIdentityAttributes()
+		matchLog := logger.WithFields(map[string]any{"component": "matcher", "type": strategy.MatcherTypeStrategy})
+		matcher, err = strategy.NewMatcher(strategyCfg, matchLog)
+		if err == nil {
+			matchLog.Infof(ctx, "Using strategy matcher")
+		}
 	default:
-		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("unsupported matcher type: %s", cfg.Settings.MatcherType), "Supported: tag")
+		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("unsupported matcher type: %s", cfg.Settings.MatcherType), "Supported: tag, identifier, strategy")
 	}
 	return matcher, err
 }
@@ -244,10 +590,15 @@ func initReporter(ctx context.Context, cfg *config.Config, logger ports.Logger)
 		if reporterCfg == nil {
 			reporterCfg = config.DefaultConfig().Settings.Reporter.Text
 		}
+		textCfg := *reporterCfg
+		if cfg.Settings.Reporter.Partition != nil {
+			// Partition files are read in editors and viewers, not terminals.
+			textCfg.NoColor = true
+		}
 		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": text.ReporterTypeText})
-		reporter, err = text.NewReporter(*reporterCfg, reportLog)
+		reporter, err = text.NewReporter(textCfg, reportLog)
 		if err == nil {
-			reportLog.Infof(ctx, "Using Text reporter (Color: %t)", !reporterCfg.NoColor)
+			reportLog.Infof(ctx, "Using Text reporter (Color: %t)", !textCfg.NoColor)
 		}
 	case jsonreport.ReporterTypeJSON:
 		reporterCfg := cfg.Settings.Reporter.JSON
@@ -259,13 +610,183 @@ func initReporter(ctx context.Context, cfg *config.Config, logger ports.Logger)
 		if err == nil {
 			reportLog.Infof(ctx, "Using JSON reporter")
 		}
+	case ocsf.ReporterTypeOCSF:
+		reporterCfg := config.DefaultConfig().Settings.Reporter.OCSF
+		if cfg.Settings.Reporter.OCSF != nil {
+			reporterCfg = cfg.Settings.Reporter.OCSF
+		}
+		ocsfCfg := *reporterCfg
+		if ocsfCfg.Region == "" && cfg.Platform.AWS != nil {
+			ocsfCfg.Region = cfg.Platform.AWS.Region
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": ocsf.ReporterTypeOCSF})
+		reporter, err = ocsf.NewReporter(ocsfCfg, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using OCSF reporter")
+		}
+	case sarif.ReporterTypeSARIF:
+		reporterCfg := config.DefaultConfig().Settings.Reporter.SARIF
+		if cfg.Settings.Reporter.SARIF != nil {
+			reporterCfg = cfg.Settings.Reporter.SARIF
+		}
+		sarifCfg := *reporterCfg
+		if sarifCfg.SourceRoot == "" && cfg.State.ProviderType == tfhcl.ProviderTypeTFHCL && cfg.State.TFHCL != nil {
+			sarifCfg.SourceRoot = filepath.ToSlash(filepath.Clean(cfg.State.TFHCL.Directory))
+		}
+		if sarifCfg.ArtifactURI == "" && cfg.State.ProviderType == tfstate.ProviderTypeTFState && cfg.State.TFState != nil {
+			sarifCfg.ArtifactURI = filepath.ToSlash(filepath.Clean(cfg.State.TFState.FilePath))
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": sarif.ReporterTypeSARIF})
+		reporter, err = sarif.NewReporter(sarifCfg, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using SARIF reporter")
+		}
+	case htmlreport.ReporterTypeHTML:
+		var htmlCfg htmlreport.Config
+		if cfg.Settings.Reporter.HTML != nil {
+			htmlCfg = *cfg.Settings.Reporter.HTML
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": htmlreport.ReporterTypeHTML})
+		reporter, err = htmlreport.NewReporter(htmlCfg, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using HTML reporter")
+		}
+	case markdown.ReporterTypeMarkdown:
+		var markdownCfg markdown.Config
+		if cfg.Settings.Reporter.Markdown != nil {
+			markdownCfg = *cfg.Settings.Reporter.Markdown
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": markdown.ReporterTypeMarkdown})
+		reporter, err = markdown.NewReporter(markdownCfg, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using Markdown reporter")
+		}
+	case csvreport.ReporterTypeCSV, csvreport.ReporterTypeTSV:
+		var csvCfg csvreport.Config
+		if cfg.Settings.Reporter.CSV != nil {
+			csvCfg = *cfg.Settings.Reporter.CSV
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": cfg.Settings.ReporterType})
+		reporter, err = csvreport.NewReporter(csvCfg, cfg.Settings.ReporterType, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using %s reporter", strings.ToUpper(cfg.Settings.ReporterType))
+		}
+	case templatereport.ReporterTypeTemplate:
+		if cfg.Settings.Reporter.Template == nil {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation, "the template reporter requires a template",
+				"Set settings.reporter_config.template.path to a Go text/template file.")
+		}
+		reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": templatereport.ReporterTypeTemplate})
+		reporter, err = templatereport.NewReporter(*cfg.Settings.Reporter.Template, reportLog)
+		if err == nil {
+			reportLog.Infof(ctx, "Using template reporter")
+		}
 	default:
-		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("unsupported reporter type: %s", cfg.Settings.ReporterType), "Supported: text")
+		err = errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("unsupported reporter type: %s", cfg.Settings.ReporterType), "Supported: text, json, ocsf, sarif, template, html, markdown, csv, tsv")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyLocalization(ctx, cfg, reporter, logger); err != nil {
+		return nil, err
+	}
+	if cfg.Settings.Reporter.Partition == nil {
+		return reporter, nil
+	}
+
+	partitionCfg := *cfg.Settings.Reporter.Partition
+	if partitionCfg.By == "" {
+		partitionCfg.By = partition.ByKind
+	}
+	reportLog := logger.WithFields(map[string]any{"component": "reporter", "type": "partition"})
+	extension := reportFileExtensions[cfg.Settings.ReporterType]
+	if cfg.Settings.ReporterType == templatereport.ReporterTypeTemplate {
+		extension = templatereport.DefaultExtension
+		if ext := cfg.Settings.Reporter.Template.Extension; ext != "" {
+			extension = ext
+		}
+	}
+	reporter, err = partition.NewReporter(partitionCfg, reporter, cfg.Settings.ReporterType, extension, reportLog)
+	if err == nil {
+		reportLog.Infof(ctx, "Writing %s report partitioned by %s to %s", cfg.Settings.ReporterType, partitionCfg.By, partitionCfg.Directory)
 	}
 	return reporter, err
 }
 
-func initComparers(ctx context.Context, registry *service.ComponentRegistry, logger ports.Logger) error {
+// timeFormattingReporter is implemented by reporters that render timestamps.
+type timeFormattingReporter interface {
+	SetTimeFormatter(f *localize.Formatter)
+}
+
+func applyLocalization(ctx context.Context, cfg *config.Config, reporter ports.Reporter, logger ports.Logger) error {
+	if cfg.Settings.Localization == nil {
+		return nil
+	}
+	formatter, err := localize.NewFormatter(*cfg.Settings.Localization)
+	if err != nil {
+		return err
+	}
+	r, ok := reporter.(timeFormattingReporter)
+	if !ok {
+		logger.Warnf(ctx, "Reporter '%s' does not support localization; timestamps stay in UTC RFC 3339", cfg.Settings.ReporterType)
+		return nil
+	}
+	r.SetTimeFormatter(formatter)
+	logger.Debugf(ctx, "Report timestamps localized (timezone: %q, locale: %q)", cfg.Settings.Localization.Timezone, cfg.Settings.Localization.Locale)
+	return nil
+}
+
+// reportFileExtensions are the file extensions of partitioned report files.
+var reportFileExtensions = map[string]string{
+	text.ReporterTypeText:         ".txt",
+	jsonreport.ReporterTypeJSON:   ".json",
+	ocsf.ReporterTypeOCSF:         ".jsonl",
+	sarif.ReporterTypeSARIF:       ".sarif",
+	htmlreport.ReporterTypeHTML:   ".html",
+	markdown.ReporterTypeMarkdown: ".md",
+	csvreport.ReporterTypeCSV:     ".csv",
+	csvreport.ReporterTypeTSV:     ".tsv",
+}
+
+func initCustomKinds(ctx context.Context, cfg *config.Config, logger ports.Logger) error {
+	builtin := map[domain.ResourceKind]bool{
+		domain.KindComputeInstance:         true,
+		domain.KindStorageBucket:           true,
+		domain.KindDatabaseInstance:        true,
+		domain.KindServerlessFunction:      true,
+		domain.KindIAMRole:                 true,
+		domain.KindIAMPolicy:               true,
+		domain.KindEncryptionKey:           true,
+		domain.KindNetworkSecurityGroup:    true,
+		domain.KindDatabaseTable:           true,
+		domain.KindCDNDistribution:         true,
+		domain.KindAutoScalingGroup:        true,
+		domain.KindLaunchTemplate:          true,
+		domain.KindElasticIP:               true,
+		domain.KindNetworkInterface:        true,
+		domain.KindContainerCluster:        true,
+		domain.KindContainerService:        true,
+		domain.KindContainerTaskDefinition: true,
+		domain.KindLoadBalancer:            true,
+		domain.KindLoadBalancerListener:    true,
+		domain.KindLoadBalancerTargetGroup: true,
+		domain.KindKubernetesDeployment:    true,
+		domain.KindKubernetesService:       true,
+		domain.KindKubernetesConfigMap:     true,
+	}
+	for _, ck := range cfg.CustomKinds {
+		if builtin[ck.Kind] {
+			return errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("custom kind '%s' conflicts with a built-in kind", ck.Kind), "Choose a different name for the custom kind.")
+		}
+		if err := mapping.RegisterCustomKind(ck.TerraformType, ck.Kind); err != nil {
+			return err
+		}
+		logger.Debugf(ctx, "Registered custom kind '%s' (terraform: %s, %s: %s)", ck.Kind, ck.TerraformType, ck.Fetcher, ck.TypeName)
+	}
+	return nil
+}
+
+func initComparers(ctx context.Context, cfg *config.Config, registry *service.ComponentRegistry, logger ports.Logger) error {
 	logger.Debugf(ctx, "Registering resource comparers")
 	var err error
 
@@ -276,6 +797,32 @@ func initComparers(ctx context.Context, registry *service.ComponentRegistry, log
 	}
 	logger.Debugf(ctx, "Registered comparer for: %s", computeComparer.Kind())
 
+	var autoScalingCfg compute.AutoScalingGroupConfig
+	if cfg.Platform.AWS != nil && cfg.Platform.AWS.AutoScaling != nil {
+		autoScalingCfg = *cfg.Platform.AWS.AutoScaling
+	}
+	autoScalingGroupComparer := compute.NewAutoScalingGroupComparer(autoScalingCfg)
+	err = registry.RegisterResourceComparer(autoScalingGroupComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register AutoScalingGroup comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", autoScalingGroupComparer.Kind())
+
+	launchTemplateComparer := compute.NewLaunchTemplateComparer()
+	err = registry.RegisterResourceComparer(launchTemplateComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register LaunchTemplate comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", launchTemplateComparer.Kind())
+
+	for _, containerComparer := range []*compute.ContainerComparer{compute.NewContainerClusterComparer(), compute.NewContainerServiceComparer(), compute.NewContainerTaskDefinitionComparer()} {
+		err = registry.RegisterResourceComparer(containerComparer)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to register %s comparer", containerComparer.Kind()))
+		}
+		logger.Debugf(ctx, "Registered comparer for: %s", containerComparer.Kind())
+	}
+
 	storageBucketComparer := storage.NewBucketComparer()
 	err = registry.RegisterResourceComparer(storageBucketComparer)
 	if err != nil {
@@ -283,6 +830,95 @@ func initComparers(ctx context.Context, registry *service.ComponentRegistry, log
 	}
 	logger.Debugf(ctx, "Registered comparer for: %s", storageBucketComparer.Kind())
 
+	databaseInstanceComparer := database.NewInstanceComparer()
+	err = registry.RegisterResourceComparer(databaseInstanceComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register DatabaseInstance comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", databaseInstanceComparer.Kind())
+
+	tableComparer := database.NewTableComparer()
+	err = registry.RegisterResourceComparer(tableComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register DatabaseTable comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", tableComparer.Kind())
+
+	lambdaComparer := serverless.NewLambdaComparer()
+	err = registry.RegisterResourceComparer(lambdaComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register ServerlessFunction comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", lambdaComparer.Kind())
+
+	for _, iamComparer := range []*identity.IAMComparer{identity.NewRoleComparer(), identity.NewPolicyComparer()} {
+		err = registry.RegisterResourceComparer(iamComparer)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to register %s comparer", iamComparer.Kind()))
+		}
+		logger.Debugf(ctx, "Registered comparer for: %s", iamComparer.Kind())
+	}
+
+	encryptionKeyComparer := identity.NewEncryptionKeyComparer()
+	err = registry.RegisterResourceComparer(encryptionKeyComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register EncryptionKey comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", encryptionKeyComparer.Kind())
+
+	securityGroupComparer := network.NewSecurityGroupComparer()
+	err = registry.RegisterResourceComparer(securityGroupComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register NetworkSecurityGroup comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", securityGroupComparer.Kind())
+
+	elasticIPComparer := network.NewElasticIPComparer()
+	err = registry.RegisterResourceComparer(elasticIPComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register ElasticIP comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", elasticIPComparer.Kind())
+
+	networkInterfaceComparer := network.NewNetworkInterfaceComparer()
+	err = registry.RegisterResourceComparer(networkInterfaceComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register NetworkInterface comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", networkInterfaceComparer.Kind())
+
+	distributionComparer := network.NewDistributionComparer()
+	err = registry.RegisterResourceComparer(distributionComparer)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to register CDNDistribution comparer")
+	}
+	logger.Debugf(ctx, "Registered comparer for: %s", distributionComparer.Kind())
+
+	for _, loadBalancerComparer := range []*network.LoadBalancerComparer{network.NewLoadBalancerComparer(), network.NewListenerComparer(), network.NewTargetGroupComparer()} {
+		err = registry.RegisterResourceComparer(loadBalancerComparer)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to register %s comparer", loadBalancerComparer.Kind()))
+		}
+		logger.Debugf(ctx, "Registered comparer for: %s", loadBalancerComparer.Kind())
+	}
+
+	// Kubernetes objects are mapped to plain attribute maps on both sides.
+	for _, kind := range []domain.ResourceKind{domain.KindKubernetesDeployment, domain.KindKubernetesService, domain.KindKubernetesConfigMap} {
+		err = registry.RegisterResourceComparer(generic.NewMapComparer(kind))
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to register %s comparer", kind))
+		}
+		logger.Debugf(ctx, "Registered comparer for: %s", kind)
+	}
+
+	for _, ck := range cfg.CustomKinds {
+		err = registry.RegisterResourceComparer(generic.NewMapComparer(ck.Kind))
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to register %s comparer", ck.Kind))
+		}
+		logger.Debugf(ctx, "Registered generic comparer for custom kind: %s", ck.Kind)
+	}
+
 	return nil
 }
 
@@ -296,6 +932,9 @@ func initEngine(
 	stateProvider ports.StateProvider,
 	platformProvider ports.PlatformProvider,
 	attributeOverrides map[domain.ResourceKind][]string,
+	metrics *prommetrics.Metrics,
+	tracing *otlp.Provider,
+	display *progress.Display,
 ) (ports.DriftAnalysisEngine, error) {
 
 	logger.Debugf(ctx, "Initializing analysis engine")
@@ -313,10 +952,148 @@ func initEngine(
 		}
 	}
 
+	transforms := make(map[domain.ResourceKind]*transform.Pipeline)
+	for _, rCfg := range cfg.Resources {
+		pipeline, err := transform.NewPipeline(rCfg.Transforms)
+		if err != nil {
+			return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, fmt.Sprintf("invalid transforms for kind '%s'", rCfg.Kind), "Check the 'transforms' section of the resource configuration.")
+		}
+		if pipeline != nil {
+			logger.Debugf(ctx, "Engine using %d attribute transforms for kind '%s'", len(rCfg.Transforms), rCfg.Kind)
+			transforms[rCfg.Kind] = pipeline
+		}
+	}
+
+	resourceFilter, err := initFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if resourceFilter != nil {
+		logger.Debugf(ctx, "Engine scoping the run with the resource filter")
+	}
+
+	kindPriorities := make(map[domain.ResourceKind]int)
+	kindConcurrency := make(map[domain.ResourceKind]int)
+	attributeParallelism := make(map[domain.ResourceKind]int)
+	normalizations := make(map[domain.ResourceKind]map[string]domain.ValueNormalization)
+	for _, kind := range cfg.GetResourceKinds() {
+		kindPriorities[kind] = cfg.GetPriorityForKind(kind)
+		if limit := cfg.GetConcurrencyForKind(kind); limit > 0 {
+			logger.Debugf(ctx, "Engine running at most %d comparison(s) of kind '%s' at once", limit, kind)
+			kindConcurrency[kind] = limit
+		}
+		if workers := cfg.GetAttributeParallelismForKind(kind); workers > 1 {
+			logger.Debugf(ctx, "Engine comparing up to %d attribute(s) of each '%s' resource at once", workers, kind)
+			attributeParallelism[kind] = workers
+		}
+		if kindNormalizations := cfg.GetNormalizationsForKind(kind); kindNormalizations != nil {
+			logger.Debugf(ctx, "Engine normalizing %d attribute(s) of kind '%s' before comparison", len(kindNormalizations), kind)
+			normalizations[kind] = kindNormalizations
+		}
+	}
+
 	engineConfig := service.EngineRunConfig{
 		ResourceKindsToProcess: cfg.GetResourceKinds(),
 		AttributesToCheck:      finalAttributesToCheck,
 		Concurrency:            cfg.Settings.Concurrency,
+		KindConcurrency:        kindConcurrency,
+		AttributeParallelism:   attributeParallelism,
+		Transforms:             transforms,
+		Normalizations:         normalizations,
+		KindPriorities:         kindPriorities,
+		StrictStateParsing:     cfg.Settings.Strict,
+		SkipSelfTest:           cfg.Settings.SkipSelfTest,
+		Explain:                cfg.Settings.Explain,
+		StreamingMatch:         cfg.Settings.StreamingMatch,
+		IgnorePlatformDefaults: cfg.Settings.IgnorePlatformDefaults,
+		AttributeGroups:        cfg.GetAttributeGroups(),
+		Filter:                 resourceFilter,
+		UnknownTolerant:        cfg.Settings.UnknownTolerant,
+	}
+	if buffers := cfg.Settings.ChannelBuffers; buffers != nil {
+		engineConfig.ChannelBuffers = service.ChannelBufferSizes{
+			Desired: buffers.Desired,
+			Actual:  buffers.Actual,
+			Compare: buffers.Compare,
+			Results: buffers.Results,
+		}
+	}
+	if cfg.History != nil {
+		engineConfig.TombstoneGracePeriod = cfg.History.TombstoneGracePeriod
+		engineConfig.DeadLetterThreshold = cfg.History.DeadLetterAfter
+	}
+
+	var engineOpts []service.EngineOption
+	if cfg.Settings.Links != nil {
+		logger.Debugf(ctx, "Engine attaching deep links to findings")
+		engineOpts = append(engineOpts, service.WithLinkBuilder(links.NewBuilder(*cfg.Settings.Links)))
+	}
+	if cfg.History != nil {
+		var storeOpts []jsonfile.StoreOption
+		if retention := cfg.History.Retention; retention != nil {
+			storeOpts = append(storeOpts, jsonfile.WithRetention(*retention))
+			if retention.ExportDirectory != "" {
+				storeOpts = append(storeOpts, jsonfile.WithExportHook(jsonfile.DirectoryExportHook(retention.ExportDirectory)))
+			}
+		}
+		store, err := jsonfile.NewStore(cfg.History.Directory, logger.WithFields(map[string]any{"component": "history"}), storeOpts...)
+		if err != nil {
+			return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, "failed to initialize history store", "Check that 'history.directory' is writable.")
+		}
+		logger.Debugf(ctx, "Engine recording run history in %s", cfg.History.Directory)
+		engineOpts = append(engineOpts, service.WithHistoryStore(store))
+	}
+	if metrics != nil {
+		engineOpts = append(engineOpts, service.WithScanMetrics(metrics))
+	}
+	if tracing != nil {
+		engineOpts = append(engineOpts, service.WithTracerProvider(tracing))
+	}
+	if display != nil {
+		engineOpts = append(engineOpts, service.WithProgressObserver(display))
+	}
+	if cfg.Settings.Baseline != "" || cfg.Settings.UpdateBaseline {
+		path := cfg.Settings.Baseline
+		if path == "" {
+			path = baselinejson.DefaultPath
+		}
+		store, err := baselinejson.NewStore(path, logger.WithFields(map[string]any{"component": "baseline"}))
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf(ctx, "Engine using baseline %s (update: %t)", path, cfg.Settings.UpdateBaseline)
+		engineOpts = append(engineOpts, service.WithBaseline(store, cfg.Settings.UpdateBaseline))
+	}
+	if platformCfg := cfg.PlatformManaged; platformCfg == nil || !platformCfg.Disabled {
+		var knowledgeCfg knowledge.Config
+		if platformCfg != nil {
+			knowledgeCfg = *platformCfg
+		}
+		engineOpts = append(engineOpts, service.WithDiffClassifier(knowledge.NewClassifier(knowledgeCfg)))
+	}
+	if cfg.GoldenAMI != nil {
+		source, err := amimanifest.NewSource(*cfg.GoldenAMI, logger.WithFields(map[string]any{"component": "golden_ami"}))
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf(ctx, "Engine checking instance images against golden AMI manifest %s", cfg.GoldenAMI.Path)
+		engineOpts = append(engineOpts, service.WithImageApprovalSource(source))
+	}
+	if cfg.Notifications != nil && cfg.Notifications.Slack != nil {
+		notifier, err := slack.NewNotifier(*cfg.Notifications.Slack, logger.WithFields(map[string]any{"component": "slack"}))
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf(ctx, "Engine sending drift notifications to Slack")
+		engineOpts = append(engineOpts, service.WithNotifier(notifier))
+	}
+	if cfg.Notifications != nil && cfg.Notifications.Webhook != nil {
+		notifier, err := webhook.NewNotifier(*cfg.Notifications.Webhook, logger.WithFields(map[string]any{"component": "webhook"}))
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf(ctx, "Engine sending results to webhook %s", cfg.Notifications.Webhook.URL)
+		engineOpts = append(engineOpts, service.WithNotifier(notifier))
 	}
 
 	engine, err := service.NewDriftAnalysisEngine(
@@ -327,6 +1104,7 @@ func initEngine(
 		engineConfig,
 		stateProvider,
 		platformProvider,
+		engineOpts...,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to initialize drift analysis engine")
@@ -334,6 +1112,18 @@ func initEngine(
 	return engine, nil
 }
 
+// initFilter builds the filter scoping runs, or nil when none is configured.
+func initFilter(cfg *config.Config) (*filter.Filter, error) {
+	if cfg.Filter == nil {
+		return nil, nil
+	}
+	f, err := filter.New(*cfg.Filter)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, "invalid resource filter", "Check the 'filter' section of the configuration and the --include-*/--exclude-* flags.")
+	}
+	return f, nil
+}
+
 func parseAttributesOverride(override string) map[domain.ResourceKind][]string {
 	if override == "" {
 		return nil
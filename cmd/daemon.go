@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/lifecycle"
+)
+
+var (
+	healthAddr   string
+	metricsAddr  string
+	scanInterval time.Duration
+)
+
+var daemonCmd = &cobra.Command{
+	Use:     "daemon",
+	Aliases: []string{"watch"},
+	Short:   "Continuously scans for drift, each resource kind on its own schedule.",
+	Long: `Daemon mode keeps running and rescans every resource kind whenever its
+scan interval elapses. Fast-changing kinds can be checked often while slow,
+expensive kinds are checked rarely. Intervals come from each resource's
+'scan_interval' setting, falling back to 'daemon.default_interval' (or
+--interval). Every report includes the latest results of all kinds, not only
+those just scanned. Each scan reads the desired state again, reusing the parsed
+state while it is unchanged.
+
+With 'daemon.health.address' (or --health-addr) set, the daemon serves /healthz
+for liveness probes and /readyz for readiness probes. /readyz fails until a scan
+succeeds, when no scan has succeeded for 'daemon.health.max_staleness', and when
+a provider cannot be reached.
+
+With 'daemon.metrics.address' (or --metrics-addr) set, the daemon serves
+Prometheus metrics on /metrics: scans and their duration, resources and
+findings per kind and status, and AWS API calls, retries and throttles.
+
+The daemon can run as a managed service. Under a systemd unit of Type=notify it
+reports readiness and shutdown and feeds the unit's watchdog. Started by the
+Windows service control manager it runs as the service named by
+'daemon.service.service_name'. 'daemon.service.pid_file' writes a pidfile, and
+with 'settings.log_file' set the log file is reopened on SIGHUP after rotation.
+Sample systemd and logrotate files are in packaging/.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("health-addr") {
+			viper.Set("daemon.health.address", healthAddr)
+		}
+		if cmd.Flags().Changed("metrics-addr") {
+			viper.Set("daemon.metrics.address", metricsAddr)
+		}
+		if cmd.Flags().Changed("interval") {
+			viper.Set("daemon.default_interval", scanInterval)
+		}
+		result, bootstrapErr := bootstrap(cmd.Context(), viper.GetViper(), true)
+		if bootstrapErr != nil {
+			printBootstrapError(bootstrapErr)
+			return bootstrapErr
+		}
+		defer result.Close()
+
+		run := func(ctx context.Context) error { return runDaemon(ctx, result) }
+		isService, runErr := lifecycle.RunService(cmd.Context(), result.Service.Name(), result.Logger, run)
+		if !isService && runErr == nil {
+			runErr = run(cmd.Context())
+		}
+		if runErr != nil {
+			printRunError(runErr)
+			return runErr
+		}
+		return nil
+	},
+}
+
+// runDaemon runs the scheduler and health endpoints until ctx is done, and
+// keeps the service manager informed: the pidfile is held while running,
+// systemd is notified of readiness, watchdog liveness and shutdown, and the log
+// file is reopened on SIGHUP.
+func runDaemon(parent context.Context, result *BootstrapResult) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	if result.Service.PIDFile != "" {
+		pidFile, err := lifecycle.AcquirePIDFile(result.Service.PIDFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := pidFile.Release(); err != nil {
+				result.Logger.Warnf(ctx, "Failed to remove pidfile: %v", err)
+			}
+		}()
+	}
+	if result.LogFile != nil {
+		lifecycle.OnReopen(ctx, func() {
+			if err := result.LogFile.Reopen(); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to reopen log file %s: %v\n", result.LogFile.Path(), err)
+				return
+			}
+			result.Logger.Infof(ctx, "Reopened log file %s", result.LogFile.Path())
+		})
+	}
+
+	healthErr := make(chan error, 1)
+	if result.Health != nil {
+		go func() {
+			healthErr <- result.Health.ListenAndServe(ctx)
+			// Without its health endpoints the daemon would be restarted by
+			// its orchestrator anyway, so stop scanning too.
+			cancel()
+		}()
+	} else {
+		healthErr <- nil
+	}
+	metricsErr := make(chan error, 1)
+	if result.Metrics != nil {
+		go func() {
+			metricsErr <- result.Metrics.ListenAndServe(ctx)
+			// Drift would go unnoticed without its metrics, so fail loudly.
+			cancel()
+		}()
+	} else {
+		metricsErr <- nil
+	}
+
+	notifier := lifecycle.NewNotifierFromEnv(result.Logger)
+	if err := notifier.Ready(); err != nil {
+		result.Logger.Warnf(ctx, "Failed to notify systemd of readiness: %v", err)
+	}
+	go notifier.RunWatchdog(ctx)
+
+	runErr := result.Scheduler.Run(ctx)
+	if err := notifier.Stopping(); err != nil {
+		result.Logger.Warnf(ctx, "Failed to notify systemd of shutdown: %v", err)
+	}
+	cancel()
+	if err := <-healthErr; err != nil && runErr == nil {
+		runErr = err
+	}
+	if err := <-metricsErr; err != nil && runErr == nil {
+		runErr = err
+	}
+	return runErr
+}
+
+func init() {
+	daemonCmd.Flags().DurationVar(&scanInterval, "interval", 0, "Rescan kinds without a 'scan_interval' this often (e.g. 30m), overriding 'daemon.default_interval'")
+	daemonCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. ':9090'), overriding 'daemon.metrics.address'")
+	daemonCmd.Flags().StringVar(&healthAddr, "health-addr", "", "Serve /healthz and /readyz on this address (e.g. ':8080'), overriding 'daemon.health.address'")
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/core/service"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/estimate"
+)
+
+// runEstimate prints the API calls and duration a scan is expected to take,
+// from the desired state alone. It sets up the providers like a scan but
+// makes no platform API calls.
+func runEstimate(ctx context.Context, v *viper.Viper) error {
+	cfg, err := initConfig(ctx, v)
+	if err != nil {
+		return err
+	}
+	logger, _, err := initLogger(ctx, cfg, nil)
+	if err != nil {
+		return err
+	}
+	if err := initServices(ctx, cfg, logger); err != nil {
+		return err
+	}
+	if err := initCustomKinds(ctx, cfg, logger); err != nil {
+		return err
+	}
+	registry := service.NewComponentRegistry()
+	stateProvider, err := initStateProvider(ctx, cfg, registry, logger)
+	if err != nil {
+		return err
+	}
+	platformProvider, err := initPlatformProvider(ctx, cfg, registry, nil, nil, logger)
+	if err != nil {
+		return err
+	}
+	estimator, ok := platformProvider.(ports.APICallEstimator)
+	if !ok {
+		return errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("the %s platform provider cannot estimate scans", platformProvider.Type()), "Scan estimates are supported with platform.aws.")
+	}
+	resourceFilter, err := initFilter(cfg)
+	if err != nil {
+		return err
+	}
+
+	est, err := estimate.Plan(ctx, stateProvider, estimator, cfg.GetResourceKinds(), estimate.Options{
+		Concurrency: cfg.Settings.Concurrency,
+		Filter:      resourceFilter,
+	})
+	if err != nil {
+		return err
+	}
+	return est.Write(os.Stdout)
+}
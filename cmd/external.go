@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/olusolaa/infra-drift-detector/internal/tfexternal"
+)
+
+var externalRequest tfexternal.Request
+
+var externalCmd = &cobra.Command{
+	Use:   "terraform-external",
+	Short: "Runs a scan as a Terraform external data source, failing the plan on drift.",
+	Long: `Terraform-external implements the protocol of Terraform's "external" data
+source, so a configuration can gate its plan on drift in the resources it
+depends on:
+
+  data "external" "drift_gate" {
+    program = ["drift-analyser", "terraform-external"]
+    query = {
+      config  = "drift.yaml"                   # Optional configuration file
+      filter  = "kind == \"database_instance\"" # Optional, syntax of the query command
+      fail_on = "warning"                      # info, warning, critical (default) or never
+    }
+  }
+
+The scan fails the data source, and with it the plan, when a selected finding
+reaches the fail_on severity or a selected resource is missing. Otherwise the
+data source returns status (clean, drifted), the drifted, missing, unmanaged
+and errors resource counts, max_severity and the selected findings as a JSON
+string in findings.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		req, err := tfexternal.ReadRequest(os.Stdin)
+		if err != nil {
+			printRunError(err)
+			return err
+		}
+		externalRequest = req
+		if req.ConfigPath != "" {
+			cfgFile = req.ConfigPath
+		}
+		return initializeConfig(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results, err := queryRunResults(cmd.Context(), viper.GetViper())
+		if err != nil {
+			printRunError(err)
+			return err
+		}
+
+		eval := tfexternal.Evaluate(externalRequest, results)
+		if err := eval.GateError(externalRequest.FailOn); err != nil {
+			printRunError(err)
+			return err
+		}
+		outputs, err := eval.Outputs()
+		if err != nil {
+			printRunError(err)
+			return err
+		}
+		return tfexternal.WriteOutputs(os.Stdout, outputs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(externalCmd)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/history/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+	"github.com/olusolaa/infra-drift-detector/internal/trend"
+)
+
+var (
+	historyResource string
+	historySince    time.Duration
+	historyLimit    int
+	historyOutput   string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Shows the drift history of a resource, or the resources that drift most often.",
+	Long: `History reads the runs recorded in the history store. With --resource it lists
+the outcome of that resource, matched by its state address or platform ID, in
+every run. Without it, it lists the resources that drifted in at least one run,
+most chronic first: by the number of runs they drifted in, then by the number
+of latest runs in a row they drifted in.
+
+Examples:
+  drift-analyser history --resource aws_instance.api
+  drift-analyser history --since 720h --limit 10 -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := runHistory(cmd, viper.GetViper(), os.Stdout)
+		if err != nil {
+			printRunError(err)
+		}
+		return err
+	},
+}
+
+func runHistory(cmd *cobra.Command, v *viper.Viper, w io.Writer) error {
+	ctx := cmd.Context()
+	if historyOutput != queryOutputTable && historyOutput != queryOutputJSON {
+		return errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("unsupported history output '%s'", historyOutput), "Supported: table, json")
+	}
+	cfg, err := initConfig(ctx, v)
+	if err != nil {
+		return err
+	}
+	if cfg.History == nil {
+		return errors.NewUserFacing(errors.CodeConfigValidation,
+			"no history store is configured to read",
+			"Set 'history.directory' in the configuration so that scans record their runs.")
+	}
+	logger, _, err := initLogger(ctx, cfg, nil)
+	if err != nil {
+		return err
+	}
+	var times *localize.Formatter
+	if cfg.Settings.Localization != nil {
+		if times, err = localize.NewFormatter(*cfg.Settings.Localization); err != nil {
+			return err
+		}
+	}
+
+	store, err := jsonfile.NewStore(cfg.History.Directory, logger.WithFields(map[string]any{"component": "history"}))
+	if err != nil {
+		return err
+	}
+	var reader ports.HistoryReader = store
+	var since time.Time
+	if historySince > 0 {
+		since = time.Now().Add(-historySince)
+	}
+	runs, err := reader.Runs(ctx, since)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return errors.NewUserFacing(errors.CodeHistoryReadError,
+			fmt.Sprintf("no runs recorded in history directory '%s'", cfg.History.Directory),
+			"Run a scan first, or widen --since.")
+	}
+
+	if historyResource != "" {
+		entries := trend.ResourceHistory(runs, historyResource)
+		if len(entries) == 0 {
+			return errors.NewUserFacing(errors.CodeHistoryReadError,
+				fmt.Sprintf("resource '%s' is not in any of the %d recorded run(s)", historyResource, len(runs)),
+				"Use the resource's state address (e.g. aws_instance.web) or its platform ID.")
+		}
+		if historyOutput == queryOutputJSON {
+			return writeHistoryJSON(w, entries)
+		}
+		return writeEntriesTable(w, entries, len(runs), times)
+	}
+
+	drifters := trend.Drifters(runs)
+	if historyLimit > 0 && len(drifters) > historyLimit {
+		drifters = drifters[:historyLimit]
+	}
+	if historyOutput == queryOutputJSON {
+		return writeHistoryJSON(w, drifters)
+	}
+	return writeDriftersTable(w, drifters, len(runs), times)
+}
+
+func writeHistoryJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to encode history")
+	}
+	return nil
+}
+
+func writeEntriesTable(w io.Writer, entries []trend.Entry, runs int, times *localize.Formatter) error {
+	// A resource can have several entries in one run, such as its drift and
+	// an unapproved image.
+	reported := make(map[time.Time]bool)
+	drifted := make(map[time.Time]bool)
+	for _, e := range entries {
+		reported[e.Time] = true
+		if trend.IsDrift(e.Status) {
+			drifted[e.Time] = true
+		}
+	}
+	fmt.Fprintf(w, "Reported in %d of %d run(s), drifted in %d.\n\n", len(reported), runs, len(drifted))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tSTATUS\tKIND\tIDENTIFIER\tSEVERITY\tATTRIBUTES")
+	for _, e := range entries {
+		details := strings.Join(e.Attributes, ", ")
+		if e.Error != "" {
+			details = strings.ReplaceAll(e.Error, "\n", " ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			times.Format(e.Time), e.Status, e.Kind, resourceLabel(e.Source, e.ID), orDash(string(e.Severity)), orDash(details))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to write history")
+	}
+	return nil
+}
+
+func writeDriftersTable(w io.Writer, drifters []trend.Drifter, runs int, times *localize.Formatter) error {
+	if len(drifters) == 0 {
+		fmt.Fprintf(w, "No resource drifted in the %d recorded run(s).\n", runs)
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DRIFTED\tSTREAK\tKIND\tIDENTIFIER\tLAST DRIFT\tATTRIBUTES")
+	for _, d := range drifters {
+		fmt.Fprintf(tw, "%d/%d\t%d\t%s\t%s\t%s\t%s\n",
+			d.DriftedRuns, d.Runs, d.Streak, d.Kind, resourceLabel(d.Source, d.ID), times.Format(d.LastDrift), orDash(strings.Join(d.Attributes, ", ")))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to write history")
+	}
+	return nil
+}
+
+func resourceLabel(source, id string) string {
+	switch {
+	case source == "":
+		return orDash(id)
+	case id == "" || id == source:
+		return source
+	default:
+		return fmt.Sprintf("%s (%s)", source, id)
+	}
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyResource, "resource", "", "Show the history of the resource with this state address or platform ID")
+	historyCmd.Flags().DurationVar(&historySince, "since", 0, "Only read runs started within this duration (e.g. 720h); all runs by default")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Show at most this many resources when listing the resources that drift most often (0 for all)")
+	historyCmd.Flags().StringVarP(&historyOutput, "output", "o", queryOutputTable, "Output format: table or json")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/history/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/query"
+)
+
+const (
+	querySourceHistory = "history"
+	querySourceRun     = "run"
+
+	queryOutputTable = "table"
+	queryOutputJSON  = "json"
+)
+
+var (
+	queryFrom   string
+	queryOutput string
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [expression]",
+	Short: "Filters drift findings with a query expression and prints the matches.",
+	Long: `Query evaluates a filter expression against every finding, one per attribute
+difference or per result without differences, and prints the matches as a
+table or as JSON for scripting. Findings come from the latest run in the
+history store (--from history) or from a fresh scan (--from run).
+
+Fields: kind, status, severity, attribute, group, source, id, provider, details, error.
+Operators: == and != (kind, status and severity ignore case and separators),
+=~ and !~ (regular expressions), and <, <=, >, >= for severity.
+Combine comparisons with &&, || and !, and group them with parentheses.
+
+Example:
+  drift-analyser query 'kind == "storage_bucket" && severity >= warning && attribute =~ "encryption"'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var expression string
+		if len(args) == 1 {
+			expression = args[0]
+		}
+		q, err := query.Parse(expression)
+		if err != nil {
+			printRunError(err)
+			return err
+		}
+		if queryOutput != queryOutputTable && queryOutput != queryOutputJSON {
+			err = errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("unsupported query output '%s'", queryOutput), "Supported: table, json")
+			printRunError(err)
+			return err
+		}
+
+		var results []domain.ComparisonResult
+		switch queryFrom {
+		case querySourceHistory:
+			results, err = queryHistoryResults(cmd.Context(), viper.GetViper())
+		case querySourceRun:
+			results, err = queryRunResults(cmd.Context(), viper.GetViper())
+		default:
+			err = errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("unsupported query source '%s'", queryFrom), "Supported: history, run")
+		}
+		if err != nil {
+			printRunError(err)
+			return err
+		}
+
+		findings := q.Filter(query.Flatten(results))
+		if queryOutput == queryOutputJSON {
+			return writeFindingsJSON(os.Stdout, findings)
+		}
+		return writeFindingsTable(os.Stdout, findings)
+	},
+}
+
+// queryHistoryResults loads the results of the latest run in the history store.
+func queryHistoryResults(ctx context.Context, v *viper.Viper) ([]domain.ComparisonResult, error) {
+	cfg, err := initConfig(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.History == nil {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+			"no history store is configured to query",
+			"Set 'history.directory' in the configuration, or use '--from run' to query a fresh scan.")
+	}
+	logger, _, err := initLogger(ctx, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	store, err := jsonfile.NewStore(cfg.History.Directory, logger.WithFields(map[string]any{"component": "history"}))
+	if err != nil {
+		return nil, err
+	}
+	run, err := store.LatestRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, errors.NewUserFacing(errors.CodeHistoryReadError,
+			fmt.Sprintf("no runs recorded in history directory '%s'", cfg.History.Directory),
+			"Run a scan first, or use '--from run' to query a fresh scan.")
+	}
+	return run.Results, nil
+}
+
+// queryRunResults runs a scan and returns its results instead of reporting them.
+func queryRunResults(ctx context.Context, v *viper.Viper) ([]domain.ComparisonResult, error) {
+	collector := &collectingReporter{}
+	result, err := bootstrap(ctx, v, false, withReporter(collector))
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+	if err := result.Engine.Run(ctx); err != nil {
+		return nil, err
+	}
+	return collector.results, nil
+}
+
+// collectingReporter keeps the results of a run in memory.
+type collectingReporter struct {
+	results []domain.ComparisonResult
+}
+
+func (r *collectingReporter) Report(_ context.Context, results []domain.ComparisonResult) error {
+	r.results = results
+	return nil
+}
+
+// teeReporter hands the results to every reporter in turn and stops at the
+// first that fails.
+type teeReporter struct {
+	reporters []ports.Reporter
+}
+
+// SetStateIssues forwards the state source issues to the reporters that render them.
+func (r *teeReporter) SetStateIssues(issues []domain.StateIssue) {
+	for _, reporter := range r.reporters {
+		if ir, ok := reporter.(ports.StateIssueReporter); ok {
+			ir.SetStateIssues(issues)
+		}
+	}
+}
+
+// SetRunAnnotations forwards the run annotations to the reporters that render them.
+func (r *teeReporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	for _, reporter := range r.reporters {
+		if ar, ok := reporter.(ports.RunAnnotationReporter); ok {
+			ar.SetRunAnnotations(annotations)
+		}
+	}
+}
+
+func (r *teeReporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	for _, reporter := range r.reporters {
+		if err := reporter.Report(ctx, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFindingsJSON(w io.Writer, findings []query.Finding) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(findings); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to encode query results")
+	}
+	return nil
+}
+
+func writeFindingsTable(w io.Writer, findings []query.Finding) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tKIND\tIDENTIFIER\tATTRIBUTE\tSEVERITY\tDETAILS")
+	for _, f := range findings {
+		identifier := f.Source
+		if identifier == "" {
+			identifier = f.ID
+		} else if f.ID != "" {
+			identifier = fmt.Sprintf("%s (%s)", f.Source, f.ID)
+		}
+		details := f.Details
+		if f.Error != "" {
+			details = f.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Status, f.Kind, orDash(identifier), orDash(f.Attribute), orDash(string(f.Severity)), strings.ReplaceAll(details, "\n", " "))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to write query results")
+	}
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	queryCmd.Flags().StringVar(&queryFrom, "from", querySourceHistory, "Where findings come from: 'history' (latest recorded run) or 'run' (a fresh scan)")
+	queryCmd.Flags().StringVarP(&queryOutput, "output", "o", queryOutputTable, "Output format: table or json")
+	rootCmd.AddCommand(queryCmd)
+}
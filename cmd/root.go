@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfplan"
 	"github.com/olusolaa/infra-drift-detector/internal/app"
 	apperrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/exitpolicy"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,6 +21,30 @@ var (
 	logLevel           string
 	logFormat          string
 	attributesOverride string
+	strict             bool
+	skipSelfTest       bool
+	explain            bool
+	unknownTolerant    bool
+	baselinePath       string
+	updateBaseline     bool
+	cacheAttributes    bool
+	cacheTTL           time.Duration
+	failOn             []string
+	noProgress         bool
+	maxDiffLines       int
+	includeTags        map[string]string
+	excludeTags        map[string]string
+	includeNames       []string
+	excludeNames       []string
+	includeID          string
+	excludeID          string
+	includeRegions     []string
+	excludeRegions     []string
+	planFile           string
+	estimateOnly       bool
+
+	// exitCode is the exit code the exit policy chose for a completed scan.
+	exitCode int
 )
 
 var rootCmd = &cobra.Command{
@@ -30,43 +57,82 @@ and reports any detected drift based on configured attributes.`,
 		return initializeConfig(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if planFile != "" {
+			viper.Set("state.provider_type", tfplan.ProviderTypeTFPlan)
+			viper.Set("state.tfplan.path", planFile)
+		}
+		if estimateOnly {
+			if err := runEstimate(cmd.Context(), viper.GetViper()); err != nil {
+				printRunError(err)
+				return err
+			}
+			return nil
+		}
 
-		result, bootstrapErr := bootstrap(cmd.Context(), viper.GetViper())
+		collector := &collectingReporter{}
+		result, bootstrapErr := bootstrap(cmd.Context(), viper.GetViper(), false, withResultCollector(collector), withProgress())
 		if bootstrapErr != nil {
-			fmt.Fprintf(os.Stderr, "ERROR: Application initialization failed: %v\n", bootstrapErr)
-			if appErr := (*apperrors.AppError)(nil); errors.As(bootstrapErr, &appErr) {
-				if appErr.IsUserFacing {
-					fmt.Fprintf(os.Stderr, "Error Details: %s\n", appErr.Message)
-					if appErr.SuggestedAction != "" {
-						fmt.Fprintf(os.Stderr, "Suggestion: %s\n", appErr.SuggestedAction)
-					}
-				}
-			}
+			printBootstrapError(bootstrapErr)
 			return bootstrapErr
 		}
+		defer result.Close()
 
 		application := app.NewApplication(result.Engine, result.Logger)
 
 		runErr := application.Run(cmd.Context())
 
 		if runErr != nil {
-			userMsg, suggestion, _ := apperrors.GetUserFacingMessage(runErr)
-			fmt.Fprintf(os.Stderr, "ERROR: %s\n", userMsg)
-			if suggestion != "" {
-				fmt.Fprintf(os.Stderr, "Suggestion: %s\n", suggestion)
-			}
+			printRunError(runErr)
 			return runErr
 		}
 
+		if code, cond := result.ExitPolicy.Evaluate(collector.results); code != 0 {
+			fmt.Fprintf(os.Stderr, "Exiting with code %d: scan found %s (fail on: %s)\n", code, cond, joinConditions(result.ExitPolicy.FailOn()))
+			exitCode = code
+		}
 		return nil
 	},
 }
 
+func printBootstrapError(err error) {
+	fmt.Fprintf(os.Stderr, "ERROR: Application initialization failed: %v\n", err)
+	if appErr := (*apperrors.AppError)(nil); errors.As(err, &appErr) {
+		if appErr.IsUserFacing {
+			fmt.Fprintf(os.Stderr, "Error Details: %s\n", appErr.Message)
+			if appErr.SuggestedAction != "" {
+				fmt.Fprintf(os.Stderr, "Suggestion: %s\n", appErr.SuggestedAction)
+			}
+		}
+	}
+}
+
+func printRunError(err error) {
+	userMsg, suggestion, _ := apperrors.GetUserFacingMessage(err)
+	fmt.Fprintf(os.Stderr, "ERROR: %s\n", userMsg)
+	if details := apperrors.GetUserFacingDetails(err); details != "" {
+		fmt.Fprintf(os.Stderr, "\n%s\n", details)
+	}
+	if suggestion != "" {
+		fmt.Fprintf(os.Stderr, "Suggestion: %s\n", suggestion)
+	}
+}
+
+func joinConditions(conds []exitpolicy.Condition) string {
+	names := make([]string, len(conds))
+	for i, cond := range conds {
+		names[i] = string(cond)
+	}
+	return strings.Join(names, ",")
+}
+
 func Execute(ctx context.Context) {
 	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
 
 func init() {
@@ -74,10 +140,50 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Override log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Override log format (text, json)")
 	rootCmd.PersistentFlags().StringVar(&attributesOverride, "attributes", "", "Override attributes to check per kind (e.g., 'ComputeInstance=instance_type,tags;StorageBucket=acl')")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Fail the run on any state parse or evaluation issue instead of reporting it")
+	rootCmd.PersistentFlags().BoolVar(&unknownTolerant, "unknown-tolerant", false, "Do not compare desired values only known after apply (e.g. HCL references to data sources) and list them as not asserted")
+	rootCmd.PersistentFlags().BoolVar(&explain, "explain", false, "Attach the normalization steps and decision path of every comparison to the findings")
+	rootCmd.PersistentFlags().BoolVar(&skipSelfTest, "skip-self-test", false, "Skip the provider connectivity and permission checks run before the scan")
+	rootCmd.PersistentFlags().StringVar(&baselinePath, "baseline", "", "Suppress findings acknowledged in this baseline file and report only new drift")
+	rootCmd.Flags().StringSliceVar(&failOn, "fail-on", nil, "Exit non-zero when the scan finds any of these conditions: drift, missing, unmanaged, error (e.g. --fail-on=drift,missing)")
+	rootCmd.Flags().StringVar(&planFile, "plan", "", "Compare the platform with the resources a Terraform plan would produce (JSON from 'terraform show -json plan.out') instead of the configured state")
+	rootCmd.Flags().BoolVar(&estimateOnly, "estimate", false, "Estimate the API calls and duration of the scan from the desired state and the configured rate limit and concurrency, without scanning")
+	rootCmd.Flags().IntVar(&maxDiffLines, "max-diff-lines", 0, "Show at most this many lines of every attribute diff in the markdown report (default 50, negative for no limit)")
+	rootCmd.Flags().BoolVar(&noProgress, "no-progress", false, "Do not show scan progress on stderr (the live view on a terminal, a periodic status line otherwise)")
+	rootCmd.PersistentFlags().BoolVar(&updateBaseline, "update-baseline", false, "Save this run's findings as the baseline instead of suppressing them (default file .idd-baseline.json)")
+	rootCmd.PersistentFlags().BoolVar(&cacheAttributes, "cache", false, "Reuse resource attributes that take several API calls to fetch, such as S3 bucket configuration, from an on-disk cache")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "How long cached attributes are reused (default 1h); drift made within this time is only reported once the entry expires")
+	rootCmd.PersistentFlags().StringToStringVar(&includeTags, "include-tag", nil, "Only scan resources with these tags; an empty value only requires the tag (e.g. --include-tag team=payments,env=prod*)")
+	rootCmd.PersistentFlags().StringToStringVar(&excludeTags, "exclude-tag", nil, "Skip resources with any of these tags (e.g. --exclude-tag owner=legacy)")
+	rootCmd.PersistentFlags().StringSliceVar(&includeNames, "include-name", nil, "Only scan resources whose name matches one of these globs (e.g. --include-name 'payments-*')")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeNames, "exclude-name", nil, "Skip resources whose name matches any of these globs")
+	rootCmd.PersistentFlags().StringVar(&includeID, "include-id", "", "Only scan resources whose platform ID matches this regular expression")
+	rootCmd.PersistentFlags().StringVar(&excludeID, "exclude-id", "", "Skip resources whose platform ID matches this regular expression")
+	rootCmd.PersistentFlags().StringSliceVar(&includeRegions, "include-region", nil, "Only scan resources in these regions")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeRegions, "exclude-region", nil, "Skip resources in these regions")
 
 	viper.BindPFlag("settings.log_level", rootCmd.PersistentFlags().Lookup("log-level"))
 	viper.BindPFlag("settings.log_format", rootCmd.PersistentFlags().Lookup("log-format"))
 	viper.BindPFlag("attributes", rootCmd.PersistentFlags().Lookup("attributes"))
+	viper.BindPFlag("settings.strict", rootCmd.PersistentFlags().Lookup("strict"))
+	viper.BindPFlag("settings.skip_self_test", rootCmd.PersistentFlags().Lookup("skip-self-test"))
+	viper.BindPFlag("settings.explain", rootCmd.PersistentFlags().Lookup("explain"))
+	viper.BindPFlag("settings.unknown_tolerant", rootCmd.PersistentFlags().Lookup("unknown-tolerant"))
+	viper.BindPFlag("settings.baseline", rootCmd.PersistentFlags().Lookup("baseline"))
+	viper.BindPFlag("settings.update_baseline", rootCmd.PersistentFlags().Lookup("update-baseline"))
+	viper.BindPFlag("settings.cache.enabled", rootCmd.PersistentFlags().Lookup("cache"))
+	viper.BindPFlag("settings.cache.ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	viper.BindPFlag("filter.include.tags", rootCmd.PersistentFlags().Lookup("include-tag"))
+	viper.BindPFlag("filter.exclude.tags", rootCmd.PersistentFlags().Lookup("exclude-tag"))
+	viper.BindPFlag("filter.include.names", rootCmd.PersistentFlags().Lookup("include-name"))
+	viper.BindPFlag("filter.exclude.names", rootCmd.PersistentFlags().Lookup("exclude-name"))
+	viper.BindPFlag("filter.include.id_pattern", rootCmd.PersistentFlags().Lookup("include-id"))
+	viper.BindPFlag("filter.exclude.id_pattern", rootCmd.PersistentFlags().Lookup("exclude-id"))
+	viper.BindPFlag("filter.include.regions", rootCmd.PersistentFlags().Lookup("include-region"))
+	viper.BindPFlag("filter.exclude.regions", rootCmd.PersistentFlags().Lookup("exclude-region"))
+	viper.BindPFlag("exit_policy.fail_on", rootCmd.Flags().Lookup("fail-on"))
+	viper.BindPFlag("settings.no_progress", rootCmd.Flags().Lookup("no-progress"))
+	viper.BindPFlag("settings.reporter_config.markdown.max_diff_lines", rootCmd.Flags().Lookup("max-diff-lines"))
 
 	viper.SetEnvPrefix("DRIFT")
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -0,0 +1,133 @@
+package jsonfile
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// DefaultPath is where the baseline is kept when no path is configured.
+	DefaultPath = ".idd-baseline.json"
+
+	baselineVersion = 1
+)
+
+// Store keeps the baseline in a single JSON document.
+type Store struct {
+	path   string
+	logger ports.Logger
+}
+
+func NewStore(path string, logger ports.Logger) (*Store, error) {
+	if path == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "baseline store requires a non-empty path")
+	}
+	return &Store{path: path, logger: logger}, nil
+}
+
+type storedBaseline struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Findings  []storedFinding `json:"findings"`
+}
+
+type storedFinding struct {
+	Fingerprint   string                  `json:"fingerprint"`
+	ResourceKind  domain.ResourceKind     `json:"resource_kind"`
+	ResourceID    string                  `json:"resource_id"`
+	Status        domain.ComparisonStatus `json:"status"`
+	AttributeName string                  `json:"attribute_name,omitempty"`
+	ExpectedValue any                     `json:"expected_value,omitempty"`
+	ActualValue   any                     `json:"actual_value,omitempty"`
+}
+
+func (s *Store) Load(ctx context.Context) (*domain.Baseline, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if stderrors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errors.CodeBaselineReadError, fmt.Sprintf("failed to read baseline '%s'", s.path))
+	}
+	var stored storedBaseline
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, errors.Wrap(err, errors.CodeBaselineReadError, fmt.Sprintf("failed to decode baseline '%s'", s.path))
+	}
+	if stored.Version != baselineVersion {
+		return nil, errors.New(errors.CodeBaselineReadError,
+			fmt.Sprintf("baseline '%s' has unsupported version %d", s.path, stored.Version))
+	}
+
+	baseline := domain.Baseline{
+		CreatedAt: stored.CreatedAt,
+		Findings:  make([]domain.BaselineFinding, 0, len(stored.Findings)),
+	}
+	for _, item := range stored.Findings {
+		baseline.Findings = append(baseline.Findings, domain.BaselineFinding{
+			Fingerprint:   item.Fingerprint,
+			ResourceKind:  item.ResourceKind,
+			ResourceID:    item.ResourceID,
+			Status:        item.Status,
+			AttributeName: item.AttributeName,
+			ExpectedValue: item.ExpectedValue,
+			ActualValue:   item.ActualValue,
+		})
+	}
+	return &baseline, nil
+}
+
+func (s *Store) Save(ctx context.Context, baseline domain.Baseline) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	stored := storedBaseline{
+		Version:   baselineVersion,
+		CreatedAt: baseline.CreatedAt,
+		Findings:  make([]storedFinding, 0, len(baseline.Findings)),
+	}
+	for _, finding := range baseline.Findings {
+		stored.Findings = append(stored.Findings, storedFinding{
+			Fingerprint:   finding.Fingerprint,
+			ResourceKind:  finding.ResourceKind,
+			ResourceID:    finding.ResourceID,
+			Status:        finding.Status,
+			AttributeName: finding.AttributeName,
+			ExpectedValue: finding.ExpectedValue,
+			ActualValue:   finding.ActualValue,
+		})
+	}
+
+	payload, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errors.CodeBaselineWriteError, "failed to encode baseline")
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return errors.Wrap(err, errors.CodeBaselineWriteError, fmt.Sprintf("failed to create baseline directory '%s'", dir))
+		}
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated baseline behind.
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o644); err != nil {
+		return errors.Wrap(err, errors.CodeBaselineWriteError, fmt.Sprintf("failed to write baseline '%s'", s.path))
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return errors.Wrap(err, errors.CodeBaselineWriteError, fmt.Sprintf("failed to commit baseline '%s'", s.path))
+	}
+	s.logger.Debugf(ctx, "Saved baseline with %d findings to %s", len(baseline.Findings), s.path)
+	return nil
+}
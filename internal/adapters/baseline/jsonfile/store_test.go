@@ -0,0 +1,63 @@
+package jsonfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "nested", DefaultPath), logger)
+	require.NoError(t, err)
+
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	results := []domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large"}},
+		},
+		{Status: domain.StatusUnmanaged, ResourceKind: domain.KindStorageBucket, ProviderAssignedID: "stray-bucket"},
+	}
+	require.NoError(t, store.Save(ctx, domain.NewBaseline(created, results)))
+
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.True(t, loaded.CreatedAt.Equal(created))
+	require.Len(t, loaded.Findings, 2)
+	assert.Equal(t, "instance_type", loaded.Findings[0].AttributeName)
+	assert.Equal(t, "stray-bucket", loaded.Findings[1].ResourceID)
+
+	remaining, suppressed := loaded.Suppress(results)
+	assert.Equal(t, 2, suppressed)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, domain.StatusNoDrift, remaining[0].Status)
+}
+
+func TestStore_LoadRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultPath)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "findings": []}`), 0o644))
+
+	store, err := NewStore(path, mocks.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = store.Load(context.Background())
+	assert.ErrorContains(t, err, "unsupported version 99")
+}
@@ -0,0 +1,178 @@
+package jsonfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// DefaultTTL is how long cached attributes are used when no TTL is set.
+	DefaultTTL = time.Hour
+
+	cacheVersion    = 1
+	entryFileSuffix = ".json"
+)
+
+// DefaultDir returns the cache directory used when none is configured, under
+// the user cache directory.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "infra-drift-detector", "attributes"), nil
+}
+
+// Store keeps the attributes of each resource in a JSON document named after
+// a hash of its key, in one directory per resource kind. Attributes can hold
+// sensitive configuration such as bucket policies, so the files are only
+// readable by their owner.
+type Store struct {
+	dir    string
+	ttl    time.Duration
+	logger ports.Logger
+	now    func() time.Time
+}
+
+// NewStore opens the cache in dir, removing the entries that have expired.
+func NewStore(dir string, ttl time.Duration, logger ports.Logger) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "attribute cache requires a non-empty directory")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("failed to create attribute cache directory '%s'", dir))
+	}
+	s := &Store{dir: dir, ttl: ttl, logger: logger, now: time.Now}
+	if removed := s.prune(); removed > 0 {
+		logger.Debugf(context.Background(), "Removed %d expired attribute cache entries", removed)
+	}
+	return s, nil
+}
+
+type storedEntry struct {
+	Version    int            `json:"version"`
+	Key        storedKey      `json:"key"`
+	StoredAt   time.Time      `json:"stored_at"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+type storedKey struct {
+	AccountID   string `json:"account_id"`
+	Region      string `json:"region"`
+	Kind        string `json:"kind"`
+	ID          string `json:"id"`
+	ContentHash string `json:"content_hash"`
+}
+
+func toStoredKey(key ports.AttributeCacheKey) storedKey {
+	return storedKey{
+		AccountID:   key.AccountID,
+		Region:      key.Region,
+		Kind:        string(key.Kind),
+		ID:          key.ID,
+		ContentHash: key.ContentHash,
+	}
+}
+
+// Get returns the attributes stored for key within the TTL. Values come back
+// as decoded from JSON, the same types desired state attributes have.
+func (s *Store) Get(ctx context.Context, key ports.AttributeCacheKey) (map[string]any, bool) {
+	path := s.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Debugf(ctx, "Failed to read attribute cache entry %s: %v", path, err)
+		}
+		return nil, false
+	}
+	var entry storedEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		s.logger.Debugf(ctx, "Ignoring corrupt attribute cache entry %s: %v", path, err)
+		return nil, false
+	}
+	if entry.Version != cacheVersion || entry.Key != toStoredKey(key) || s.expired(entry.StoredAt) {
+		return nil, false
+	}
+	return entry.Attributes, true
+}
+
+// Put stores the attributes of key, replacing any previous entry.
+func (s *Store) Put(ctx context.Context, key ports.AttributeCacheKey, attributes map[string]any) {
+	payload, err := json.Marshal(storedEntry{
+		Version:    cacheVersion,
+		Key:        toStoredKey(key),
+		StoredAt:   s.now().UTC(),
+		Attributes: attributes,
+	})
+	if err != nil {
+		s.logger.Debugf(ctx, "Not caching attributes of %s %s: %v", key.Kind, key.ID, err)
+		return
+	}
+
+	path := s.entryPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		s.logger.Warnf(ctx, "Failed to create attribute cache directory: %v", err)
+		return
+	}
+	// Write to a temporary file first so concurrent readers never see a
+	// truncated entry.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		s.logger.Warnf(ctx, "Failed to write attribute cache entry: %v", err)
+		return
+	}
+	_, writeErr := tmp.Write(payload)
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmp.Name(), path)
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmp.Name())
+		s.logger.Warnf(ctx, "Failed to write attribute cache entry: %v", writeErr)
+	}
+}
+
+func (s *Store) entryPath(key ports.AttributeCacheKey) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{key.AccountID, key.Region, string(key.Kind), key.ID, key.ContentHash}, "\x00")))
+	return filepath.Join(s.dir, string(key.Kind), hex.EncodeToString(sum[:])+entryFileSuffix)
+}
+
+func (s *Store) expired(storedAt time.Time) bool {
+	return s.now().Sub(storedAt) > s.ttl
+}
+
+// prune removes the entries that have expired, going by the modification
+// time of their files, and returns how many were removed.
+func (s *Store) prune() int {
+	removed := 0
+	_ = filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, entryFileSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !s.expired(info.ModTime()) {
+			return nil
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+		return nil
+	})
+	return removed
+}
@@ -0,0 +1,102 @@
+package jsonfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func newTestStore(t *testing.T, dir string) *Store {
+	t.Helper()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	store, err := NewStore(dir, time.Hour, logger)
+	require.NoError(t, err)
+	return store
+}
+
+func TestStore_PutAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, t.TempDir())
+	key := ports.AttributeCacheKey{AccountID: "123456789012", Region: "eu-west-1", Kind: domain.KindStorageBucket, ID: "logs", ContentHash: "abc"}
+
+	_, ok := store.Get(ctx, key)
+	assert.False(t, ok, "empty cache")
+
+	store.Put(ctx, key, map[string]any{
+		domain.KeyID:   "logs",
+		domain.KeyTags: map[string]string{"Team": "data"},
+		"versioning":   true,
+	})
+	attrs, ok := store.Get(ctx, key)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:   "logs",
+		domain.KeyTags: map[string]any{"Team": "data"},
+		"versioning":   true,
+	}, attrs, "values come back with JSON types")
+
+	recreated := key
+	recreated.ContentHash = "def"
+	_, ok = store.Get(ctx, recreated)
+	assert.False(t, ok, "a different content hash misses")
+
+	otherAccount := key
+	otherAccount.AccountID = "210987654321"
+	_, ok = store.Get(ctx, otherAccount)
+	assert.False(t, ok, "a different account misses")
+}
+
+func TestStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := newTestStore(t, dir)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	key := ports.AttributeCacheKey{Kind: domain.KindStorageBucket, ID: "logs"}
+
+	store.Put(ctx, key, map[string]any{domain.KeyID: "logs"})
+	now = now.Add(59 * time.Minute)
+	_, ok := store.Get(ctx, key)
+	assert.True(t, ok, "fresh within the TTL")
+	now = now.Add(2 * time.Minute)
+	_, ok = store.Get(ctx, key)
+	assert.False(t, ok, "expired after the TTL")
+
+	path := store.entryPath(key)
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	newTestStore(t, dir)
+	assert.NoFileExists(t, path, "expired entries are removed when the cache is opened")
+}
+
+func TestStore_CorruptEntry(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, t.TempDir())
+	key := ports.AttributeCacheKey{Kind: domain.KindStorageBucket, ID: "logs"}
+
+	path := store.entryPath(key)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	_, ok := store.Get(ctx, key)
+	assert.False(t, ok)
+
+	store.Put(ctx, key, map[string]any{domain.KeyID: "logs"})
+	_, ok = store.Get(ctx, key)
+	assert.True(t, ok, "a corrupt entry is replaced")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
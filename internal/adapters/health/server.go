@@ -0,0 +1,273 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// DefaultConnectivityInterval is how long a provider connectivity result is
+	// reused before /readyz checks the provider again.
+	DefaultConnectivityInterval = time.Minute
+
+	connectivityTimeout = 30 * time.Second
+	shutdownTimeout     = 5 * time.Second
+)
+
+// Config enables the daemon mode health endpoints.
+type Config struct {
+	// Address is the listen address of the endpoints, e.g. ":8080".
+	Address string `yaml:"address" mapstructure:"address" validate:"required"`
+	// MaxStaleness is how long after the last successful scan the daemon stops
+	// reporting ready. Zero uses twice the longest scan interval.
+	MaxStaleness time.Duration `yaml:"max_staleness" mapstructure:"max_staleness" validate:"omitempty,min=0"`
+	// ConnectivityInterval is how long a provider connectivity result is reused.
+	// Zero uses DefaultConnectivityInterval.
+	ConnectivityInterval time.Duration `yaml:"connectivity_interval" mapstructure:"connectivity_interval" validate:"omitempty,min=0"`
+}
+
+// ConnectivityCheck verifies that a provider can be reached for the scheduled kinds.
+type ConnectivityCheck struct {
+	Name   string
+	Tester ports.SelfTester
+}
+
+// Server serves the liveness (/healthz) and readiness (/readyz) endpoints of
+// daemon mode. The daemon is live while its scheduler runs, and ready once a
+// scan has succeeded recently and every provider is reachable.
+type Server struct {
+	address              string
+	status               ports.SchedulerStatusSource
+	kinds                []domain.ResourceKind
+	checks               []ConnectivityCheck
+	maxStaleness         time.Duration
+	connectivityInterval time.Duration
+	logger               ports.Logger
+	now                  func() time.Time
+
+	connMu        sync.Mutex
+	connCheckedAt time.Time
+	connResults   map[string]string
+}
+
+// ServerOption configures optional server behaviour.
+type ServerOption func(*Server)
+
+// WithConnectivityChecks adds provider checks run by /readyz for the given kinds.
+func WithConnectivityChecks(kinds []domain.ResourceKind, checks ...ConnectivityCheck) ServerOption {
+	return func(s *Server) {
+		s.kinds = kinds
+		for _, check := range checks {
+			if check.Tester != nil {
+				s.checks = append(s.checks, check)
+			}
+		}
+	}
+}
+
+// WithDefaultMaxStaleness sets the staleness limit used when the configuration
+// leaves it unset.
+func WithDefaultMaxStaleness(d time.Duration) ServerOption {
+	return func(s *Server) {
+		if s.maxStaleness == 0 {
+			s.maxStaleness = d
+		}
+	}
+}
+
+// NewServer creates the health endpoints for the scheduler status source.
+func NewServer(cfg Config, status ports.SchedulerStatusSource, logger ports.Logger, opts ...ServerOption) (*Server, error) {
+	if cfg.Address == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "health server requires a listen address")
+	}
+	if status == nil {
+		return nil, errors.New(errors.CodeInternal, "health server requires a scheduler status source")
+	}
+	s := &Server{
+		address:              cfg.Address,
+		status:               status,
+		maxStaleness:         cfg.MaxStaleness,
+		connectivityInterval: cfg.ConnectivityInterval,
+		logger:               logger,
+		now:                  time.Now,
+	}
+	if s.connectivityInterval <= 0 {
+		s.connectivityInterval = DefaultConnectivityInterval
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Handler returns the HTTP handler serving /healthz and /readyz.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	return mux
+}
+
+// ListenAndServe serves the endpoints until the context is cancelled, then
+// shuts the server down gracefully.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return errors.NewUserFacing(errors.CodeHealthServerError,
+			fmt.Sprintf("failed to listen on health address '%s': %v", s.address, err),
+			"Choose a free address for 'daemon.health.address'.")
+	}
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(listener) }()
+	s.logger.Infof(ctx, "[Health] Serving /healthz and /readyz on %s", listener.Addr())
+
+	select {
+	case err := <-serveErr:
+		return errors.Wrap(err, errors.CodeHealthServerError, "health server stopped")
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, errors.CodeHealthServerError, "failed to shut down health server")
+	}
+	if err := <-serveErr; err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, errors.CodeHealthServerError, "health server stopped")
+	}
+	return nil
+}
+
+// response is the JSON body of both endpoints.
+type response struct {
+	Status    string            `json:"status"`
+	Checks    map[string]string `json:"checks"`
+	Scheduler schedulerState    `json:"scheduler"`
+}
+
+type schedulerState struct {
+	Running             bool       `json:"running"`
+	Scanning            bool       `json:"scanning"`
+	StartedAt           *time.Time `json:"started_at,omitempty"`
+	ScanStartedAt       *time.Time `json:"scan_started_at,omitempty"`
+	LastRunAt           *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextScanAt          *time.Time `json:"next_scan_at,omitempty"`
+}
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// handleHealthz reports the daemon live unless its scheduler has stopped. A
+// scheduler that has not started yet counts as live, so the probe does not
+// race the start-up.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	st := s.status.Status()
+	checks := map[string]string{"scheduler": statusOK}
+	if !st.Running && !st.StartedAt.IsZero() {
+		checks["scheduler"] = "stopped"
+	}
+	s.write(w, st, checks)
+}
+
+// handleReadyz reports the daemon ready when the scheduler runs, a scan has
+// succeeded within the staleness limit and every provider is reachable.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	st := s.status.Status()
+	checks := map[string]string{"scheduler": statusOK, "last_success": statusOK}
+	if !st.Running {
+		checks["scheduler"] = "not running"
+	}
+
+	now := s.now()
+	switch {
+	case st.LastSuccessAt.IsZero():
+		checks["last_success"] = "no successful scan yet"
+	case s.maxStaleness > 0 && now.Sub(st.LastSuccessAt) > s.maxStaleness:
+		checks["last_success"] = fmt.Sprintf("last successful scan %s ago exceeds %s", now.Sub(st.LastSuccessAt).Round(time.Second), s.maxStaleness)
+	}
+
+	for name, result := range s.connectivity(r.Context()) {
+		checks["provider:"+name] = result
+	}
+	s.write(w, st, checks)
+}
+
+// connectivity returns the result of each provider check, rerunning the checks
+// once the previous results are older than the connectivity interval.
+func (s *Server) connectivity(ctx context.Context) map[string]string {
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	if s.connResults != nil && s.now().Sub(s.connCheckedAt) < s.connectivityInterval {
+		return s.connResults
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+	results := make(map[string]string, len(s.checks))
+	for _, check := range s.checks {
+		if err := check.Tester.SelfTest(ctx, s.kinds); err != nil {
+			s.logger.Warnf(ctx, "[Health] Connectivity check for %s failed: %v", check.Name, err)
+			results[check.Name] = err.Error()
+			continue
+		}
+		results[check.Name] = statusOK
+	}
+	if ctx.Err() == nil {
+		s.connResults = results
+		s.connCheckedAt = s.now()
+	}
+	return results
+}
+
+func (s *Server) write(w http.ResponseWriter, st domain.SchedulerStatus, checks map[string]string) {
+	resp := response{Status: statusOK, Checks: checks, Scheduler: newSchedulerState(st)}
+	code := http.StatusOK
+	for _, result := range checks {
+		if result != statusOK {
+			resp.Status = statusUnavailable
+			code = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newSchedulerState(st domain.SchedulerStatus) schedulerState {
+	return schedulerState{
+		Running:             st.Running,
+		Scanning:            st.Scanning,
+		StartedAt:           timeOrNil(st.StartedAt),
+		ScanStartedAt:       timeOrNil(st.ScanStartedAt),
+		LastRunAt:           timeOrNil(st.LastRunAt),
+		LastSuccessAt:       timeOrNil(st.LastSuccessAt),
+		LastError:           st.LastError,
+		ConsecutiveFailures: st.ConsecutiveFailures,
+		NextScanAt:          timeOrNil(st.NextScanAt),
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
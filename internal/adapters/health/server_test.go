@@ -0,0 +1,197 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type staticStatus domain.SchedulerStatus
+
+func (s *staticStatus) Status() domain.SchedulerStatus { return domain.SchedulerStatus(*s) }
+
+type countingTester struct {
+	err   error
+	calls int
+	kinds []domain.ResourceKind
+}
+
+func (t *countingTester) SelfTest(_ context.Context, kinds []domain.ResourceKind) error {
+	t.calls++
+	t.kinds = kinds
+	return t.err
+}
+
+func newTestServer(t *testing.T, status *staticStatus, opts ...ServerOption) *Server {
+	t.Helper()
+	logger := mocks.NewLogger(t)
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	s, err := NewServer(Config{Address: ":0", MaxStaleness: time.Hour}, status, logger, opts...)
+	require.NoError(t, err)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func get(t *testing.T, s *Server, path string) (int, response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var body response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	return rec.Code, body
+}
+
+func TestHealthz(t *testing.T) {
+	tests := []struct {
+		name   string
+		status staticStatus
+		want   int
+	}{
+		{"not started yet", staticStatus{}, http.StatusOK},
+		{"running", staticStatus{Running: true, StartedAt: testNow}, http.StatusOK},
+		{"failing scans keep it live", staticStatus{Running: true, StartedAt: testNow, ConsecutiveFailures: 5, LastError: "boom"}, http.StatusOK},
+		{"stopped", staticStatus{StartedAt: testNow}, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := get(t, newTestServer(t, &tt.status), "/healthz")
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     staticStatus
+		want       int
+		failedWith map[string]string
+	}{
+		{
+			name:   "recent success",
+			status: staticStatus{Running: true, LastSuccessAt: testNow.Add(-10 * time.Minute)},
+			want:   http.StatusOK,
+		},
+		{
+			name:       "no successful scan yet",
+			status:     staticStatus{Running: true, LastRunAt: testNow, LastError: "denied", ConsecutiveFailures: 1},
+			want:       http.StatusServiceUnavailable,
+			failedWith: map[string]string{"last_success": "no successful scan yet"},
+		},
+		{
+			name:       "stale success",
+			status:     staticStatus{Running: true, LastSuccessAt: testNow.Add(-2 * time.Hour)},
+			want:       http.StatusServiceUnavailable,
+			failedWith: map[string]string{"last_success": "last successful scan 2h0m0s ago exceeds 1h0m0s"},
+		},
+		{
+			name:       "scheduler stopped",
+			status:     staticStatus{StartedAt: testNow, LastSuccessAt: testNow},
+			want:       http.StatusServiceUnavailable,
+			failedWith: map[string]string{"scheduler": "not running"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := get(t, newTestServer(t, &tt.status), "/readyz")
+			assert.Equal(t, tt.want, code)
+			for check, result := range tt.failedWith {
+				assert.Equal(t, result, body.Checks[check])
+			}
+			if tt.want == http.StatusOK {
+				assert.Equal(t, statusOK, body.Status)
+			} else {
+				assert.Equal(t, statusUnavailable, body.Status)
+			}
+		})
+	}
+}
+
+func TestReadyz_ReportsSchedulerState(t *testing.T) {
+	status := staticStatus{Running: true, StartedAt: testNow.Add(-time.Hour), LastSuccessAt: testNow}
+	_, body := get(t, newTestServer(t, &status), "/readyz")
+
+	assert.True(t, body.Scheduler.Running)
+	require.NotNil(t, body.Scheduler.LastSuccessAt)
+	assert.True(t, testNow.Equal(*body.Scheduler.LastSuccessAt))
+	assert.Nil(t, body.Scheduler.NextScanAt)
+}
+
+func TestReadyz_ConnectivityChecks(t *testing.T) {
+	kinds := []domain.ResourceKind{domain.KindStorageBucket}
+	healthy := &countingTester{}
+	failing := &countingTester{err: stderrors.New("AWS self-test failed")}
+	status := staticStatus{Running: true, LastSuccessAt: testNow}
+	s := newTestServer(t, &status, WithConnectivityChecks(kinds,
+		ConnectivityCheck{Name: "tfstate", Tester: healthy},
+		ConnectivityCheck{Name: "aws", Tester: failing},
+	))
+
+	code, body := get(t, s, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusOK, body.Checks["provider:tfstate"])
+	assert.Equal(t, "AWS self-test failed", body.Checks["provider:aws"])
+	assert.Equal(t, kinds, healthy.kinds)
+
+	// Results are reused within the connectivity interval.
+	get(t, s, "/readyz")
+	assert.Equal(t, 1, failing.calls)
+
+	failing.err = nil
+	s.now = func() time.Time { return testNow.Add(DefaultConnectivityInterval) }
+	code, _ = get(t, s, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, failing.calls)
+}
+
+func TestNewServer_Validation(t *testing.T) {
+	logger := mocks.NewLogger(t)
+	_, err := NewServer(Config{}, &staticStatus{}, logger)
+	assert.Error(t, err)
+	_, err = NewServer(Config{Address: ":0"}, nil, logger)
+	assert.Error(t, err)
+}
+
+func TestWithDefaultMaxStaleness(t *testing.T) {
+	logger := mocks.NewLogger(t)
+	s, err := NewServer(Config{Address: ":0"}, &staticStatus{}, logger, WithDefaultMaxStaleness(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, s.maxStaleness)
+
+	s, err = NewServer(Config{Address: ":0", MaxStaleness: time.Minute}, &staticStatus{}, logger, WithDefaultMaxStaleness(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, s.maxStaleness)
+}
+
+func TestListenAndServe_StopsOnCancel(t *testing.T) {
+	logger := mocks.NewLogger(t)
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Return()
+	s, err := NewServer(Config{Address: "127.0.0.1:0"}, &staticStatus{}, logger)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.ListenAndServe(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ListenAndServe did not stop after cancellation")
+	}
+}
@@ -0,0 +1,118 @@
+package jsonfile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// RetentionConfig bounds the number of runs kept in the store. A run is kept
+// while it is among the latest KeepRuns runs or younger than MaxAge; with both
+// unset nothing is pruned. The latest run and runs with critical findings are
+// always kept.
+type RetentionConfig struct {
+	KeepRuns int           `yaml:"keep_runs" mapstructure:"keep_runs" validate:"omitempty,min=1"`
+	MaxAge   time.Duration `yaml:"max_age" mapstructure:"max_age" validate:"omitempty,min=0"`
+	// ExportDirectory receives a copy of every run before it is pruned.
+	ExportDirectory string `yaml:"export_directory" mapstructure:"export_directory"`
+}
+
+func (c RetentionConfig) enabled() bool {
+	return c.KeepRuns > 0 || c.MaxAge > 0
+}
+
+// ExportHook receives a run, and the file holding it, before the run is pruned.
+type ExportHook func(ctx context.Context, run domain.RunRecord, path string) error
+
+// DirectoryExportHook copies pruned run files into dir, which is created if
+// needed.
+func DirectoryExportHook(dir string) ExportHook {
+	return func(ctx context.Context, run domain.RunRecord, path string) error {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to create export directory '%s'", dir))
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeHistoryReadError, fmt.Sprintf("failed to read run record '%s'", path))
+		}
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(path)), data, 0o644); err != nil {
+			return errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to export run record '%s'", run.ID))
+		}
+		return nil
+	}
+}
+
+// Prune deletes the runs outside the retention policy, after passing each to
+// the export hooks, and returns the number of runs deleted.
+func (s *Store) Prune(ctx context.Context) (int, error) {
+	if !s.retention.enabled() {
+		return 0, nil
+	}
+	files, err := s.runFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	// The latest KeepRuns runs, and always the latest run, are never candidates.
+	keep := s.retention.KeepRuns
+	if keep < 1 {
+		keep = 1
+	}
+	if len(files) <= keep {
+		return 0, nil
+	}
+	cutoff := s.now().Add(-s.retention.MaxAge)
+
+	pruned := 0
+	for _, path := range files[:len(files)-keep] {
+		run, err := s.readRun(ctx, path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return pruned, ctx.Err()
+			}
+			s.logger.Warnf(ctx, "Keeping unreadable run record %s: %v", path, err)
+			continue
+		}
+		if s.retention.MaxAge > 0 && run.StartedAt.After(cutoff) {
+			continue
+		}
+		if hasCriticalFindings(run.Results) {
+			continue
+		}
+		if err := s.export(ctx, *run, path); err != nil {
+			s.logger.Warnf(ctx, "Keeping run record %s, export failed: %v", run.ID, err)
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return pruned, errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to delete run record '%s'", run.ID))
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		s.logger.Debugf(ctx, "Pruned %d run record(s) from history", pruned)
+	}
+	return pruned, nil
+}
+
+func (s *Store) export(ctx context.Context, run domain.RunRecord, path string) error {
+	for _, hook := range s.exportHooks {
+		if err := hook(ctx, run, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasCriticalFindings(results []domain.ComparisonResult) bool {
+	for _, res := range results {
+		if res.MaxSeverity() == domain.SeverityCritical {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package jsonfile
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+var retentionStart = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func newRetentionStore(t *testing.T, opts ...StoreOption) *Store {
+	t.Helper()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	store, err := NewStore(t.TempDir(), logger, opts...)
+	require.NoError(t, err)
+	store.now = func() time.Time { return retentionStart.Add(10 * 24 * time.Hour) }
+	return store
+}
+
+// saveDailyRuns saves one run per day starting at retentionStart. Runs whose
+// index is in critical carry a critical finding.
+func saveDailyRuns(t *testing.T, store *Store, count int, critical ...int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		run := domain.RunRecord{StartedAt: retentionStart.Add(time.Duration(i) * 24 * time.Hour)}
+		for _, c := range critical {
+			if c == i {
+				run.Results = []domain.ComparisonResult{{
+					Status: domain.StatusDrifted, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs",
+					Differences: []domain.AttributeDiff{{AttributeName: "acl", ExpectedValue: "private", ActualValue: "public-read", Severity: domain.SeverityCritical}},
+				}}
+			}
+		}
+		require.NoError(t, store.SaveRun(context.Background(), run))
+	}
+}
+
+func storedRunDays(t *testing.T, store *Store) []int {
+	t.Helper()
+	files, err := store.runFiles()
+	require.NoError(t, err)
+	days := make([]int, 0, len(files))
+	for _, path := range files {
+		run, err := store.readRun(context.Background(), path)
+		require.NoError(t, err)
+		days = append(days, int(run.StartedAt.Sub(retentionStart)/(24*time.Hour)))
+	}
+	return days
+}
+
+func TestStore_RetentionKeepRuns(t *testing.T) {
+	store := newRetentionStore(t, WithRetention(RetentionConfig{KeepRuns: 3}))
+
+	saveDailyRuns(t, store, 6)
+
+	assert.Equal(t, []int{3, 4, 5}, storedRunDays(t, store))
+}
+
+func TestStore_RetentionMaxAge(t *testing.T) {
+	store := newRetentionStore(t, WithRetention(RetentionConfig{MaxAge: 5 * 24 * time.Hour}))
+
+	// Now is day 10, so only runs after day 5 are young enough to keep.
+	saveDailyRuns(t, store, 9)
+
+	assert.Equal(t, []int{6, 7, 8}, storedRunDays(t, store))
+}
+
+func TestStore_RetentionKeepsEitherRunsOrAge(t *testing.T) {
+	store := newRetentionStore(t, WithRetention(RetentionConfig{KeepRuns: 4, MaxAge: 3 * 24 * time.Hour}))
+
+	saveDailyRuns(t, store, 9)
+
+	assert.Equal(t, []int{5, 6, 7, 8}, storedRunDays(t, store))
+}
+
+func TestStore_RetentionKeepsCriticalRuns(t *testing.T) {
+	store := newRetentionStore(t, WithRetention(RetentionConfig{KeepRuns: 2}))
+
+	saveDailyRuns(t, store, 6, 1)
+
+	assert.Equal(t, []int{1, 4, 5}, storedRunDays(t, store))
+}
+
+func TestStore_RetentionExportsBeforeDelete(t *testing.T) {
+	exportDir := filepath.Join(t.TempDir(), "archive")
+	var exported []string
+	store := newRetentionStore(t,
+		WithRetention(RetentionConfig{KeepRuns: 1}),
+		WithExportHook(func(ctx context.Context, run domain.RunRecord, path string) error {
+			exported = append(exported, run.ID)
+			return nil
+		}),
+		WithExportHook(DirectoryExportHook(exportDir)),
+	)
+
+	saveDailyRuns(t, store, 3)
+
+	assert.Equal(t, []int{2}, storedRunDays(t, store))
+	require.Len(t, exported, 2)
+	archived, err := os.ReadDir(exportDir)
+	require.NoError(t, err)
+	assert.Len(t, archived, 2)
+}
+
+func TestStore_RetentionKeepsRunsWhenExportFails(t *testing.T) {
+	store := newRetentionStore(t,
+		WithRetention(RetentionConfig{KeepRuns: 1}),
+		WithExportHook(func(ctx context.Context, run domain.RunRecord, path string) error {
+			return stderrors.New("bucket unavailable")
+		}),
+	)
+
+	saveDailyRuns(t, store, 3)
+
+	assert.Equal(t, []int{0, 1, 2}, storedRunDays(t, store))
+}
+
+func TestStore_PruneWithoutRetention(t *testing.T) {
+	store := newRetentionStore(t)
+	saveDailyRuns(t, store, 3)
+
+	pruned, err := store.Prune(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+	assert.Len(t, storedRunDays(t, store), 3)
+}
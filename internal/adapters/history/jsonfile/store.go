@@ -0,0 +1,317 @@
+package jsonfile
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	StoreTypeJSONFile = "jsonfile"
+
+	runFilePrefix = "run-"
+	runFileSuffix = ".json"
+	runIDLayout   = "20060102T150405.000000000Z"
+)
+
+// Store keeps one JSON document per run in a directory. File names sort in run
+// order, so the latest run is the last file.
+type Store struct {
+	dir         string
+	logger      ports.Logger
+	retention   RetentionConfig
+	exportHooks []ExportHook
+	now         func() time.Time
+}
+
+type StoreOption func(*Store)
+
+// WithRetention prunes runs outside the retention policy after every saved run.
+func WithRetention(retention RetentionConfig) StoreOption {
+	return func(s *Store) {
+		s.retention = retention
+	}
+}
+
+// WithExportHook runs hook on every run before it is pruned. A run whose export
+// fails is kept, so it is retried on the next pruning.
+func WithExportHook(hook ExportHook) StoreOption {
+	return func(s *Store) {
+		if hook != nil {
+			s.exportHooks = append(s.exportHooks, hook)
+		}
+	}
+}
+
+func NewStore(dir string, logger ports.Logger, opts ...StoreOption) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "history store requires a non-empty directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to create history directory '%s'", dir))
+	}
+	s := &Store{dir: dir, logger: logger, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+type storedRun struct {
+	ID         string                `json:"id"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+	Kinds      []domain.ResourceKind `json:"kinds,omitempty"`
+	Results    []storedResult        `json:"results"`
+}
+
+type storedResult struct {
+	Status             domain.ComparisonStatus `json:"status"`
+	ResourceKind       domain.ResourceKind     `json:"resource_kind"`
+	SourceIdentifier   string                  `json:"source_identifier,omitempty"`
+	ProviderType       string                  `json:"provider_type,omitempty"`
+	ProviderAssignedID string                  `json:"provider_assigned_id,omitempty"`
+	Differences        []storedDiff            `json:"differences,omitempty"`
+	ErrorMessage       string                  `json:"error_message,omitempty"`
+	DeletionWindow     *storedWindow           `json:"deletion_window,omitempty"`
+	FailureStreak      *storedStreak           `json:"failure_streak,omitempty"`
+}
+
+type storedStreak struct {
+	Runs  int       `json:"runs"`
+	Since time.Time `json:"since"`
+}
+
+type storedWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type storedDiff struct {
+	AttributeName string          `json:"attribute_name"`
+	ExpectedValue any             `json:"expected_value"`
+	ActualValue   any             `json:"actual_value"`
+	Details       string          `json:"details,omitempty"`
+	Severity      domain.Severity `json:"severity,omitempty"`
+	Group         string          `json:"group,omitempty"`
+}
+
+func (s *Store) SaveRun(ctx context.Context, run domain.RunRecord) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if run.ID == "" {
+		run.ID = run.StartedAt.UTC().Format(runIDLayout)
+	}
+
+	payload, err := json.MarshalIndent(toStoredRun(run), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errors.CodeHistoryWriteError, "failed to encode run record")
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated run behind.
+	path := filepath.Join(s.dir, runFilePrefix+run.ID+runFileSuffix)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o644); err != nil {
+		return errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to write run record '%s'", run.ID))
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return errors.Wrap(err, errors.CodeHistoryWriteError, fmt.Sprintf("failed to commit run record '%s'", run.ID))
+	}
+	s.logger.Debugf(ctx, "Saved run record %s with %d results", run.ID, len(run.Results))
+
+	if s.retention.enabled() {
+		if _, err := s.Prune(ctx); err != nil {
+			s.logger.Warnf(ctx, "Failed to prune run history: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) LatestRun(ctx context.Context) (*domain.RunRecord, error) {
+	files, err := s.runFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return s.readRun(ctx, files[len(files)-1])
+}
+
+// LatestRunOfKind reads the runs newest first until one analysed kind.
+// Unreadable run records are skipped with a warning, as in Runs.
+func (s *Store) LatestRunOfKind(ctx context.Context, kind domain.ResourceKind) (*domain.RunRecord, error) {
+	files, err := s.runFiles()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		run, err := s.readRun(ctx, files[i])
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.logger.Warnf(ctx, "Skipping unreadable run record %s: %v", files[i], err)
+			continue
+		}
+		if run.Covers(kind) {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+// Runs returns the runs started at or after since, oldest first. Unreadable run
+// records are skipped with a warning, so one corrupt file does not hide the
+// rest of the history.
+func (s *Store) Runs(ctx context.Context, since time.Time) ([]domain.RunRecord, error) {
+	files, err := s.runFiles()
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]domain.RunRecord, 0, len(files))
+	for _, path := range files {
+		run, err := s.readRun(ctx, path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.logger.Warnf(ctx, "Skipping unreadable run record %s: %v", path, err)
+			continue
+		}
+		if run.StartedAt.Before(since) {
+			continue
+		}
+		runs = append(runs, *run)
+	}
+	return runs, nil
+}
+
+// runFiles returns the run files in the store, oldest first.
+func (s *Store) runFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if stderrors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errors.CodeHistoryReadError, fmt.Sprintf("failed to read history directory '%s'", s.dir))
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, runFilePrefix) || !strings.HasSuffix(name, runFileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(s.dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *Store) readRun(ctx context.Context, path string) (*domain.RunRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeHistoryReadError, fmt.Sprintf("failed to read run record '%s'", path))
+	}
+	var stored storedRun
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, errors.Wrap(err, errors.CodeHistoryReadError, fmt.Sprintf("failed to decode run record '%s'", path))
+	}
+	run := fromStoredRun(stored)
+	return &run, nil
+}
+
+func toStoredRun(run domain.RunRecord) storedRun {
+	stored := storedRun{
+		ID:         run.ID,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		Kinds:      run.Kinds,
+		Results:    make([]storedResult, 0, len(run.Results)),
+	}
+	for _, res := range run.Results {
+		item := storedResult{
+			Status:             res.Status,
+			ResourceKind:       res.ResourceKind,
+			SourceIdentifier:   res.SourceIdentifier,
+			ProviderType:       res.ProviderType,
+			ProviderAssignedID: res.ProviderAssignedID,
+		}
+		if res.DeletionWindow != nil {
+			item.DeletionWindow = &storedWindow{From: res.DeletionWindow.From, To: res.DeletionWindow.To}
+		}
+		if res.FailureStreak != nil {
+			item.FailureStreak = &storedStreak{Runs: res.FailureStreak.Runs, Since: res.FailureStreak.Since}
+		}
+		if res.Error != nil {
+			item.ErrorMessage = res.Error.Error()
+		}
+		for _, diff := range res.Differences {
+			item.Differences = append(item.Differences, storedDiff{
+				AttributeName: diff.AttributeName,
+				ExpectedValue: diff.ExpectedValue,
+				ActualValue:   diff.ActualValue,
+				Details:       diff.Details,
+				Severity:      diff.Severity,
+				Group:         diff.Group,
+			})
+		}
+		stored.Results = append(stored.Results, item)
+	}
+	return stored
+}
+
+func fromStoredRun(stored storedRun) domain.RunRecord {
+	run := domain.RunRecord{
+		ID:         stored.ID,
+		StartedAt:  stored.StartedAt,
+		FinishedAt: stored.FinishedAt,
+		Kinds:      stored.Kinds,
+		Results:    make([]domain.ComparisonResult, 0, len(stored.Results)),
+	}
+	for _, item := range stored.Results {
+		res := domain.ComparisonResult{
+			Status:             item.Status,
+			ResourceKind:       item.ResourceKind,
+			SourceIdentifier:   item.SourceIdentifier,
+			ProviderType:       item.ProviderType,
+			ProviderAssignedID: item.ProviderAssignedID,
+		}
+		if item.DeletionWindow != nil {
+			res.DeletionWindow = &domain.TimeWindow{From: item.DeletionWindow.From, To: item.DeletionWindow.To}
+		}
+		if item.FailureStreak != nil {
+			res.FailureStreak = &domain.FailureStreak{Runs: item.FailureStreak.Runs, Since: item.FailureStreak.Since}
+		}
+		if item.ErrorMessage != "" {
+			res.Error = stderrors.New(item.ErrorMessage)
+		}
+		for _, diff := range item.Differences {
+			res.Differences = append(res.Differences, domain.AttributeDiff{
+				AttributeName: diff.AttributeName,
+				ExpectedValue: diff.ExpectedValue,
+				ActualValue:   diff.ActualValue,
+				Details:       diff.Details,
+				Severity:      diff.Severity,
+				Group:         diff.Group,
+			})
+		}
+		run.Results = append(run.Results, res)
+	}
+	return run
+}
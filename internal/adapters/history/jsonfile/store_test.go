@@ -0,0 +1,137 @@
+package jsonfile
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func TestStore_SaveAndLatestRun(t *testing.T) {
+	ctx := context.Background()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	store, err := NewStore(t.TempDir(), logger)
+	require.NoError(t, err)
+
+	latest, err := store.LatestRun(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	window := &domain.TimeWindow{From: first.Add(-time.Hour), To: first}
+
+	require.NoError(t, store.SaveRun(ctx, domain.RunRecord{
+		StartedAt: first,
+		Results: []domain.ComparisonResult{
+			{Status: domain.StatusRecentlyDeleted, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs", DeletionWindow: window},
+		},
+	}))
+	require.NoError(t, store.SaveRun(ctx, domain.RunRecord{
+		StartedAt: second,
+		Results: []domain.ComparisonResult{
+			{
+				Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web", ProviderAssignedID: "i-123",
+				Differences: []domain.AttributeDiff{{AttributeName: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large"}},
+			},
+			{
+				Status: domain.StatusDeadLettered, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.db", Error: stderrors.New("boom"),
+				FailureStreak: &domain.FailureStreak{Runs: 3, Since: first},
+			},
+		},
+	}))
+
+	latest, err = store.LatestRun(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.True(t, latest.StartedAt.Equal(second))
+	require.Len(t, latest.Results, 2)
+	assert.Equal(t, "i-123", latest.Results[0].ProviderAssignedID)
+	assert.Equal(t, "t3.large", latest.Results[0].Differences[0].ActualValue)
+	assert.EqualError(t, latest.Results[1].Error, "boom")
+	require.NotNil(t, latest.Results[1].FailureStreak)
+	assert.Equal(t, 3, latest.Results[1].FailureStreak.Runs)
+	assert.True(t, latest.Results[1].FailureStreak.Since.Equal(first))
+}
+
+func TestNewStore_EmptyDirectory(t *testing.T) {
+	_, err := NewStore("", mocks.NewLogger(t))
+	assert.Error(t, err)
+}
+
+func TestStore_Runs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Twice().Return()
+
+	store, err := NewStore(dir, logger)
+	require.NoError(t, err)
+
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		require.NoError(t, store.SaveRun(ctx, domain.RunRecord{
+			StartedAt: first.AddDate(0, 0, day),
+			Results:   []domain.ComparisonResult{{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web"}},
+		}))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run-corrupt.json"), []byte("{"), 0o644))
+
+	runs, err := store.Runs(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.True(t, runs[0].StartedAt.Equal(first))
+	assert.True(t, runs[2].StartedAt.Equal(first.AddDate(0, 0, 2)))
+
+	runs, err = store.Runs(ctx, first.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.True(t, runs[0].StartedAt.Equal(first.AddDate(0, 0, 1)))
+}
+
+func TestStore_LatestRunOfKind(t *testing.T) {
+	ctx := context.Background()
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	store, err := NewStore(t.TempDir(), logger)
+	require.NoError(t, err)
+
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SaveRun(ctx, domain.RunRecord{
+		StartedAt: first,
+		Results:   []domain.ComparisonResult{{Status: domain.StatusNoDrift, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs"}},
+	}))
+	require.NoError(t, store.SaveRun(ctx, domain.RunRecord{
+		StartedAt: first.Add(time.Hour),
+		Kinds:     []domain.ResourceKind{domain.KindComputeInstance, domain.KindIAMRole},
+		Results:   []domain.ComparisonResult{{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web"}},
+	}))
+
+	bucketRun, err := store.LatestRunOfKind(ctx, domain.KindStorageBucket)
+	require.NoError(t, err)
+	require.NotNil(t, bucketRun, "a run recorded without kinds covers the kinds of its results")
+	assert.True(t, bucketRun.StartedAt.Equal(first))
+
+	roleRun, err := store.LatestRunOfKind(ctx, domain.KindIAMRole)
+	require.NoError(t, err)
+	require.NotNil(t, roleRun, "a run covers its kinds even without results of them")
+	assert.True(t, roleRun.StartedAt.Equal(first.Add(time.Hour)))
+	assert.Equal(t, []domain.ResourceKind{domain.KindComputeInstance, domain.KindIAMRole}, roleRun.Kinds)
+
+	dbRun, err := store.LatestRunOfKind(ctx, domain.KindDatabaseInstance)
+	require.NoError(t, err)
+	assert.Nil(t, dbRun)
+}
@@ -0,0 +1,25 @@
+// Package lifecycle integrates daemon mode with native service managers:
+// systemd readiness and watchdog notifications, the Windows service control
+// manager, pidfiles and log reopening after rotation.
+package lifecycle
+
+// Config configures how the daemon runs as a managed service.
+type Config struct {
+	// PIDFile is written with the daemon's process ID while it runs, and
+	// removed on exit. A pidfile of a running process makes startup fail.
+	PIDFile string `yaml:"pid_file" mapstructure:"pid_file"`
+	// ServiceName is the name the daemon is registered under with the Windows
+	// service control manager. Defaults to DefaultServiceName.
+	ServiceName string `yaml:"service_name" mapstructure:"service_name"`
+}
+
+// DefaultServiceName is the Windows service name used when none is configured.
+const DefaultServiceName = "drift-analyser"
+
+// Name returns the configured Windows service name or the default.
+func (c Config) Name() string {
+	if c.ServiceName == "" {
+		return DefaultServiceName
+	}
+	return c.ServiceName
+}
@@ -0,0 +1,107 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// systemd notification protocol environment and messages, see sd_notify(3).
+const (
+	envNotifySocket = "NOTIFY_SOCKET"
+	envWatchdogUSec = "WATCHDOG_USEC"
+	envWatchdogPID  = "WATCHDOG_PID"
+
+	notifyReady    = "READY=1"
+	notifyStopping = "STOPPING=1"
+	notifyWatchdog = "WATCHDOG=1"
+	notifyStatus   = "STATUS="
+)
+
+// Notifier sends service state notifications to systemd. Outside a systemd
+// unit of Type=notify it does nothing.
+type Notifier struct {
+	socket   string
+	watchdog time.Duration
+	logger   ports.Logger
+}
+
+// NewNotifierFromEnv creates a notifier for the socket and watchdog interval
+// systemd passed in the environment.
+func NewNotifierFromEnv(logger ports.Logger) *Notifier {
+	n := &Notifier{socket: os.Getenv(envNotifySocket), logger: logger}
+	if usec, err := strconv.ParseInt(os.Getenv(envWatchdogUSec), 10, 64); err == nil && usec > 0 {
+		pid := os.Getenv(envWatchdogPID)
+		if pid == "" || pid == strconv.Itoa(os.Getpid()) {
+			n.watchdog = time.Duration(usec) * time.Microsecond
+		}
+	}
+	return n
+}
+
+// Enabled reports whether the process runs under a systemd unit that expects
+// notifications.
+func (n *Notifier) Enabled() bool {
+	return n.socket != ""
+}
+
+// Ready tells systemd that startup finished.
+func (n *Notifier) Ready() error {
+	return n.notify(notifyReady)
+}
+
+// Stopping tells systemd that shutdown began.
+func (n *Notifier) Stopping() error {
+	return n.notify(notifyStopping)
+}
+
+// Status sets the status line shown by systemctl status.
+func (n *Notifier) Status(status string) error {
+	return n.notify(notifyStatus + strings.ReplaceAll(status, "\n", " "))
+}
+
+// RunWatchdog keeps the systemd watchdog fed at half its interval until ctx is
+// done. It returns immediately when the unit has no watchdog.
+func (n *Notifier) RunWatchdog(ctx context.Context) {
+	if !n.Enabled() || n.watchdog <= 0 {
+		return
+	}
+	ticker := time.NewTicker(n.watchdog / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.notify(notifyWatchdog); err != nil {
+				n.logger.Warnf(ctx, "Failed to notify systemd watchdog: %v", err)
+			}
+		}
+	}
+}
+
+func (n *Notifier) notify(state string) error {
+	if !n.Enabled() {
+		return nil
+	}
+	socket := n.socket
+	// A leading '@' names a socket in the abstract namespace.
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to connect to systemd notify socket")
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to send systemd notification")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build !windows
+
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func listenNotifySocket(t *testing.T) *net.UnixConn {
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv(envNotifySocket, path)
+	return conn
+}
+
+func readNotification(t *testing.T, conn *net.UnixConn) string {
+	buf := make([]byte, 256)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestNotifier_SendsStateChanges(t *testing.T) {
+	conn := listenNotifySocket(t)
+	n := NewNotifierFromEnv(new(portsmocks.Logger))
+
+	require.True(t, n.Enabled())
+	require.NoError(t, n.Ready())
+	assert.Equal(t, "READY=1", readNotification(t, conn))
+	require.NoError(t, n.Status("scanned 3 kinds\nno drift"))
+	assert.Equal(t, "STATUS=scanned 3 kinds no drift", readNotification(t, conn))
+	require.NoError(t, n.Stopping())
+	assert.Equal(t, "STOPPING=1", readNotification(t, conn))
+}
+
+func TestNotifier_Watchdog(t *testing.T) {
+	conn := listenNotifySocket(t)
+	t.Setenv(envWatchdogUSec, "20000")
+	t.Setenv(envWatchdogPID, strconv.Itoa(os.Getpid()))
+	logger := new(portsmocks.Logger)
+	logger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	n := NewNotifierFromEnv(logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.RunWatchdog(ctx)
+		close(done)
+	}()
+
+	assert.Equal(t, "WATCHDOG=1", readNotification(t, conn))
+	cancel()
+	<-done
+}
+
+func TestNotifier_DisabledOutsideSystemd(t *testing.T) {
+	t.Setenv(envNotifySocket, "")
+	n := NewNotifierFromEnv(new(portsmocks.Logger))
+
+	assert.False(t, n.Enabled())
+	assert.NoError(t, n.Ready())
+	n.RunWatchdog(context.Background())
+}
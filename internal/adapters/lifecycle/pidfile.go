@@ -0,0 +1,74 @@
+package lifecycle
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// PIDFile holds the pidfile of the running daemon.
+type PIDFile struct {
+	path string
+}
+
+// AcquirePIDFile writes the current process ID to path. It fails when path
+// names a process that is still running, and replaces a stale pidfile left by
+// a process that exited without removing it.
+func AcquirePIDFile(path string) (*PIDFile, error) {
+	pid := os.Getpid()
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, writeErr := fmt.Fprintf(file, "%d\n", pid)
+			closeErr := file.Close()
+			if writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				_ = os.Remove(path)
+				return nil, errors.Wrap(writeErr, errors.CodeInternal, fmt.Sprintf("failed to write pidfile %s", path))
+			}
+			return &PIDFile{path: path}, nil
+		}
+		if !stderrors.Is(err, os.ErrExist) {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to create pidfile %s", path))
+		}
+
+		if owner, ok := readPID(path); ok && owner != pid && processAlive(owner) {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("pidfile %s belongs to running process %d", path, owner),
+				"Stop the other daemon instance, or configure a different daemon.service.pid_file.")
+		}
+		if err := os.Remove(path); err != nil && !stderrors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to remove stale pidfile %s", path))
+		}
+	}
+	return nil, errors.New(errors.CodeInternal, fmt.Sprintf("failed to acquire pidfile %s", path))
+}
+
+// Release removes the pidfile if it still holds the current process ID.
+func (p *PIDFile) Release() error {
+	if owner, ok := readPID(p.path); !ok || owner != os.Getpid() {
+		return nil
+	}
+	if err := os.Remove(p.path); err != nil && !stderrors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to remove pidfile %s", p.path))
+	}
+	return nil
+}
+
+func readPID(path string) (int, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
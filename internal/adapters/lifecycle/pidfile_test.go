@@ -0,0 +1,62 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func TestAcquirePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pid")
+
+	pidFile, err := AcquirePIDFile(path)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(raw))
+
+	require.NoError(t, pidFile.Release())
+	assert.NoFileExists(t, path)
+}
+
+func TestAcquirePIDFile_ReplacesStaleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pid")
+	require.NoError(t, os.WriteFile(path, []byte("not a pid\n"), 0o644))
+
+	pidFile, err := AcquirePIDFile(path)
+
+	require.NoError(t, err)
+	defer pidFile.Release()
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(raw))
+}
+
+func TestAcquirePIDFile_RunningProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pid")
+	// The parent of the test process is running and is not this process.
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getppid())), 0o644))
+
+	_, err := AcquirePIDFile(path)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
+
+func TestPIDFileRelease_KeepsForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pid")
+	pidFile, err := AcquirePIDFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getppid())), 0o644))
+
+	require.NoError(t, pidFile.Release())
+
+	assert.FileExists(t, path)
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package lifecycle
+
+import "os"
+
+// processAlive reports whether a process with the given ID exists. On Windows
+// FindProcess opens a handle to the process and fails when it does not exist.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
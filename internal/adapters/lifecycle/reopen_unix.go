@@ -0,0 +1,28 @@
+//go:build !windows
+
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// OnReopen calls reopen whenever the process receives SIGHUP, the signal log
+// rotation tools send after moving the log file, until ctx is done.
+func OnReopen(ctx context.Context, reopen func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				reopen()
+			}
+		}
+	}()
+}
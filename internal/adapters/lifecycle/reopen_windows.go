@@ -0,0 +1,9 @@
+//go:build windows
+
+package lifecycle
+
+import "context"
+
+// OnReopen does nothing on Windows, which has no SIGHUP. Files in use cannot
+// be renamed there, so log rotation tools copy and truncate them instead.
+func OnReopen(ctx context.Context, reopen func()) {}
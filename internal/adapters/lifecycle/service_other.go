@@ -0,0 +1,16 @@
+//go:build !windows
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// RunService runs run under the Windows service control manager when the
+// process was started as a Windows service, and reports whether it did. On
+// other platforms the process is never a Windows service.
+func RunService(ctx context.Context, name string, logger ports.Logger, run func(ctx context.Context) error) (bool, error) {
+	return false, nil
+}
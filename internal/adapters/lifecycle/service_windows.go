@@ -0,0 +1,70 @@
+//go:build windows
+
+package lifecycle
+
+import (
+	"context"
+
+	"golang.org/x/sys/windows/svc"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// RunService runs run under the Windows service control manager when the
+// process was started as a Windows service, and reports whether it did. Stop
+// and shutdown requests cancel the context passed to run.
+func RunService(ctx context.Context, name string, logger ports.Logger, run func(ctx context.Context) error) (bool, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return false, errors.Wrap(err, errors.CodeInternal, "failed to detect Windows service mode")
+	}
+	if !isService {
+		return false, nil
+	}
+	h := &serviceHandler{ctx: ctx, logger: logger, run: run}
+	if err := svc.Run(name, h); err != nil {
+		return true, errors.Wrap(err, errors.CodeInternal, "Windows service '"+name+"' failed")
+	}
+	return true, h.runErr
+}
+
+type serviceHandler struct {
+	ctx    context.Context
+	logger ports.Logger
+	run    func(ctx context.Context) error
+	runErr error
+}
+
+// Execute implements svc.Handler.
+func (h *serviceHandler) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	const accepted = svc.AcceptStop | svc.AcceptShutdown
+	status <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(h.ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- h.run(ctx) }()
+	status <- svc.Status{State: svc.Running, Accepts: accepted}
+
+	for {
+		select {
+		case err := <-done:
+			h.runErr = err
+			status <- svc.Status{State: svc.StopPending}
+			if err != nil {
+				return false, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				h.logger.Infof(ctx, "Windows service stop requested")
+				status <- svc.Status{State: svc.StopPending}
+				cancel()
+			}
+		}
+	}
+}
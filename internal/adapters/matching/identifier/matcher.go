@@ -0,0 +1,173 @@
+package identifier
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+const MatcherTypeIdentifier = "identifier"
+
+var _ ports.StreamingMatcher = (*Matcher)(nil)
+
+// Matcher matches desired and actual resources of the same kind whose source
+// identifiers are equal. It suits platforms whose objects are named the same
+// way in source and on the platform, such as Kubernetes objects identified by
+// "<namespace>/<name>", where no identifying tag has to be set.
+type Matcher struct {
+	logger ports.Logger
+}
+
+func NewMatcher(logger ports.Logger) *Matcher {
+	return &Matcher{logger: logger}
+}
+
+func (m *Matcher) Match(
+	ctx context.Context,
+	desired []domain.StateResource,
+	actual []domain.PlatformResource,
+) (ports.MatchingResult, error) {
+	m.logger.Debugf(ctx, "Starting identifier matching (%d desired, %d actual)", len(desired), len(actual))
+
+	result := ports.MatchingResult{
+		Matched:          make([]ports.MatchedPair, 0),
+		UnmatchedDesired: make([]domain.StateResource, 0),
+		UnmatchedActual:  make([]domain.PlatformResource, 0),
+	}
+
+	desiredByKey := make(map[indexKey][]domain.StateResource)
+	for _, res := range desired {
+		if ctx.Err() != nil {
+			return ports.MatchingResult{}, ctx.Err()
+		}
+		if meta := res.Metadata(); meta.SourceIdentifier != "" {
+			key := indexKey{kind: meta.Kind, id: meta.SourceIdentifier}
+			desiredByKey[key] = append(desiredByKey[key], res)
+		}
+	}
+	actualByKey := make(map[indexKey][]domain.PlatformResource)
+	for _, res := range actual {
+		if meta := res.Metadata(); meta.SourceIdentifier != "" {
+			key := indexKey{kind: meta.Kind, id: meta.SourceIdentifier}
+			actualByKey[key] = append(actualByKey[key], res)
+		}
+	}
+
+	// Resources sharing an identifier with more than one resource on the other
+	// side are reported as ambiguous rather than paired arbitrarily.
+	reported := make(map[indexKey]bool)
+	for _, res := range actual {
+		if ctx.Err() != nil {
+			return ports.MatchingResult{}, ctx.Err()
+		}
+		meta := res.Metadata()
+		key := indexKey{kind: meta.Kind, id: meta.SourceIdentifier}
+		des, act := desiredByKey[key], actualByKey[key]
+		switch {
+		case meta.SourceIdentifier == "" || len(des) == 0:
+			result.UnmatchedActual = append(result.UnmatchedActual, res)
+		case len(des) == 1 && len(act) == 1:
+			result.Matched = append(result.Matched, ports.MatchedPair{Desired: des[0], Actual: res})
+			m.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via identifier", meta.SourceIdentifier, meta.ProviderAssignedID)
+		case !reported[key]:
+			reported[key] = true
+			result.Ambiguous = append(result.Ambiguous, ports.AmbiguousMatch{Desired: des, Actual: act})
+			m.logger.Warnf(ctx, "Identifier '%s' (%s) matches %d desired and %d actual resources, reporting them as ambiguous", meta.SourceIdentifier, meta.Kind, len(des), len(act))
+		}
+	}
+
+	skipped := make(map[indexKey]bool)
+	for _, res := range desired {
+		meta := res.Metadata()
+		if meta.SourceIdentifier == "" {
+			m.logger.Warnf(ctx, "Desired resource of kind %s has empty SourceIdentifier, cannot match via identifier", meta.Kind)
+			result.UnmatchedDesired = append(result.UnmatchedDesired, res)
+			continue
+		}
+		key := indexKey{kind: meta.Kind, id: meta.SourceIdentifier}
+		if len(actualByKey[key]) > 0 {
+			continue
+		}
+		if skipped[key] {
+			m.logger.Errorf(ctx, nil, "Duplicate desired resource identifier '%s' (%s) found. Skipping duplicate.", meta.SourceIdentifier, meta.Kind)
+			continue
+		}
+		skipped[key] = true
+		result.UnmatchedDesired = append(result.UnmatchedDesired, res)
+	}
+
+	m.logger.Debugf(ctx, "Identifier matching finished: %d matched, %d missing, %d unmanaged, %d ambiguous", len(result.Matched), len(result.UnmatchedDesired), len(result.UnmatchedActual), len(result.Ambiguous))
+	return result, nil
+}
+
+// indexKey keys resources by kind too, since objects of different kinds may
+// share an identifier.
+type indexKey struct {
+	kind domain.ResourceKind
+	id   string
+}
+
+type index struct {
+	matcher *Matcher
+	byKey   map[indexKey]*indexEntry
+	// entries keeps the add order so unmatched resources are reported stably.
+	entries []*indexEntry
+}
+
+type indexEntry struct {
+	resource domain.StateResource
+	matched  bool
+}
+
+// NewIndex returns an empty index of desired resources keyed by kind and
+// source identifier.
+func (m *Matcher) NewIndex() ports.MatchIndex {
+	return &index{matcher: m, byKey: make(map[indexKey]*indexEntry)}
+}
+
+func (i *index) AddDesired(ctx context.Context, res domain.StateResource) {
+	meta := res.Metadata()
+	if meta.SourceIdentifier == "" {
+		i.matcher.logger.Warnf(ctx, "Desired resource of kind %s has empty SourceIdentifier, cannot match via identifier", meta.Kind)
+		i.entries = append(i.entries, &indexEntry{resource: res})
+		return
+	}
+	key := indexKey{kind: meta.Kind, id: meta.SourceIdentifier}
+	if _, exists := i.byKey[key]; exists {
+		i.matcher.logger.Errorf(ctx, nil, "Duplicate desired resource identifier '%s' (%s) found. Skipping duplicate.", meta.SourceIdentifier, meta.Kind)
+		return
+	}
+	entry := &indexEntry{resource: res}
+	i.byKey[key] = entry
+	i.entries = append(i.entries, entry)
+}
+
+func (i *index) MatchActual(ctx context.Context, res domain.PlatformResource) (domain.StateResource, bool) {
+	meta := res.Metadata()
+	if meta.SourceIdentifier == "" {
+		return nil, false
+	}
+	entry, exists := i.byKey[indexKey{kind: meta.Kind, id: meta.SourceIdentifier}]
+	if !exists {
+		return nil, false
+	}
+	if entry.matched {
+		i.matcher.logger.Errorf(ctx, nil, "Duplicate identifier '%s' found on actual resource %s (%s). Only one will be matched.",
+			meta.SourceIdentifier, meta.ProviderAssignedID, meta.Kind)
+		return nil, false
+	}
+	entry.matched = true
+	i.matcher.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via identifier", meta.SourceIdentifier, meta.ProviderAssignedID)
+	return entry.resource, true
+}
+
+func (i *index) Unmatched() []domain.StateResource {
+	unmatched := make([]domain.StateResource, 0)
+	for _, entry := range i.entries {
+		if !entry.matched {
+			unmatched = append(unmatched, entry.resource)
+		}
+	}
+	return unmatched
+}
@@ -0,0 +1,99 @@
+package identifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	domainmocks "github.com/olusolaa/infra-drift-detector/internal/core/domain/mocks"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func newTestMatcher() *Matcher {
+	logger := new(portsmocks.Logger)
+	for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+		logger.On("Debugf", args...).Maybe().Return()
+		logger.On("Warnf", args...).Maybe().Return()
+		logger.On("Errorf", append([]any{mock.Anything}, args...)...).Maybe().Return()
+	}
+	return NewMatcher(logger)
+}
+
+func desiredResource(kind domain.ResourceKind, id string) *domainmocks.StateResource {
+	res := new(domainmocks.StateResource)
+	res.On("Metadata").Return(domain.ResourceMetadata{Kind: kind, SourceIdentifier: id})
+	return res
+}
+
+func actualResource(kind domain.ResourceKind, id string) *domainmocks.PlatformResource {
+	res := new(domainmocks.PlatformResource)
+	res.On("Metadata").Return(domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id, SourceIdentifier: id})
+	return res
+}
+
+func TestMatcher_MatchesByKindAndIdentifier(t *testing.T) {
+	webDeployment := desiredResource(domain.KindKubernetesDeployment, "shop/web")
+	webService := desiredResource(domain.KindKubernetesService, "shop/web")
+	missing := desiredResource(domain.KindKubernetesConfigMap, "shop/settings")
+	actualDeployment := actualResource(domain.KindKubernetesDeployment, "shop/web")
+	actualService := actualResource(domain.KindKubernetesService, "shop/web")
+	unmanaged := actualResource(domain.KindKubernetesDeployment, "shop/debug")
+
+	result, err := newTestMatcher().Match(context.Background(),
+		[]domain.StateResource{webDeployment, webService, missing},
+		[]domain.PlatformResource{actualService, unmanaged, actualDeployment})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 2)
+	assert.Same(t, webService, result.Matched[0].Desired)
+	assert.Same(t, actualService, result.Matched[0].Actual)
+	assert.Same(t, webDeployment, result.Matched[1].Desired)
+	assert.Same(t, actualDeployment, result.Matched[1].Actual)
+	assert.Equal(t, []domain.StateResource{missing}, result.UnmatchedDesired)
+	assert.Equal(t, []domain.PlatformResource{unmanaged}, result.UnmatchedActual)
+}
+
+func TestMatcher_ReportsAmbiguousIdentifiers(t *testing.T) {
+	web := desiredResource(domain.KindKubernetesDeployment, "shop/web")
+	api := desiredResource(domain.KindKubernetesDeployment, "shop/api")
+	apiCopy := desiredResource(domain.KindKubernetesDeployment, "shop/api")
+	webA := actualResource(domain.KindKubernetesDeployment, "shop/web")
+	webB := actualResource(domain.KindKubernetesDeployment, "shop/web")
+	actualAPI := actualResource(domain.KindKubernetesDeployment, "shop/api")
+
+	result, err := newTestMatcher().Match(context.Background(),
+		[]domain.StateResource{web, api, apiCopy},
+		[]domain.PlatformResource{webA, actualAPI, webB})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Matched)
+	require.Len(t, result.Ambiguous, 2)
+	assert.Equal(t, []domain.StateResource{web}, result.Ambiguous[0].Desired)
+	assert.Equal(t, []domain.PlatformResource{webA, webB}, result.Ambiguous[0].Actual)
+	assert.Equal(t, []domain.StateResource{api, apiCopy}, result.Ambiguous[1].Desired)
+	assert.Equal(t, []domain.PlatformResource{actualAPI}, result.Ambiguous[1].Actual)
+	assert.Empty(t, result.UnmatchedDesired)
+	assert.Empty(t, result.UnmatchedActual)
+}
+
+func TestIndex_MatchesEachDesiredResourceOnce(t *testing.T) {
+	ctx := context.Background()
+	index := newTestMatcher().NewIndex()
+	web, noID := desiredResource(domain.KindKubernetesDeployment, "shop/web"), desiredResource(domain.KindKubernetesDeployment, "")
+	index.AddDesired(ctx, web)
+	index.AddDesired(ctx, noID)
+	index.AddDesired(ctx, desiredResource(domain.KindKubernetesDeployment, "shop/web")) // duplicate is skipped
+
+	matched, ok := index.MatchActual(ctx, actualResource(domain.KindKubernetesDeployment, "shop/web"))
+	require.True(t, ok)
+	assert.Same(t, web, matched)
+
+	_, ok = index.MatchActual(ctx, actualResource(domain.KindKubernetesDeployment, "shop/web"))
+	assert.False(t, ok, "a desired resource is matched at most once")
+
+	assert.Equal(t, []domain.StateResource{noID}, index.Unmatched())
+}
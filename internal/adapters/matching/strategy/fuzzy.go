@@ -0,0 +1,207 @@
+package strategy
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// DefaultFuzzyThreshold is the lowest name similarity the fuzzy strategy
+// pairs resources at when no threshold is configured.
+const DefaultFuzzyThreshold = 0.85
+
+// FuzzyStrategy pairs resources of the same kind and region whose names are
+// similar, for resources created outside the tool whose IDs are not in state
+// yet. Names are compared lower-cased with everything but letters and digits
+// removed, and their similarity, one minus the edit distance over the length
+// of the longer name, is the confidence of the pair.
+type FuzzyStrategy struct {
+	threshold float64
+}
+
+// NewFuzzyStrategy creates a fuzzy strategy pairing resources whose names are
+// at least threshold similar, or DefaultFuzzyThreshold when it is not positive.
+func NewFuzzyStrategy(threshold float64) FuzzyStrategy {
+	if threshold <= 0 {
+		threshold = DefaultFuzzyThreshold
+	}
+	return FuzzyStrategy{threshold: threshold}
+}
+
+func (FuzzyStrategy) Name() string { return StrategyFuzzy }
+
+type fuzzyCandidate struct {
+	desired, actual int
+	score           float64
+}
+
+// Match pairs the most similar names first. A desired resource whose best
+// score is shared by two actual resources, or the reverse, is ambiguous: it is
+// left unmatched and returned in a conflict with the resources it ties with.
+func (s FuzzyStrategy) Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	actualNames := make([]string, len(actual))
+	for i, res := range actual {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		attrs := actualAttributes(ctx, res)
+		name := nameTag(attrs)
+		if name == "" {
+			name = stringAttr(attrs, domain.KeyName)
+		}
+		actualNames[i] = normalizeName(name)
+	}
+
+	var candidates []fuzzyCandidate
+	for d, des := range desired {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		desMeta := des.Metadata()
+		desName := normalizeName(desiredName(des))
+		if desName == "" {
+			continue
+		}
+		for a, act := range actual {
+			actMeta := act.Metadata()
+			if actMeta.Kind != desMeta.Kind || actualNames[a] == "" {
+				continue
+			}
+			if desRegion := desiredRegion(des); desRegion != "" && actMeta.Region != "" && desRegion != actMeta.Region {
+				continue
+			}
+			if score := similarity(desName, actualNames[a]); score >= s.threshold {
+				candidates = append(candidates, fuzzyCandidate{desired: d, actual: a, score: score})
+			}
+		}
+	}
+
+	bestDesired, bestActual := bestScores(candidates)
+	ambiguousDesired, ambiguousActual := make(map[int]bool), make(map[int]bool)
+	var conflicts []ports.AmbiguousMatch
+	for _, c := range candidates {
+		if b := bestDesired[c.desired]; b.count > 1 && !ambiguousDesired[c.desired] {
+			ambiguousDesired[c.desired] = true
+			conflict := ports.AmbiguousMatch{Desired: []domain.StateResource{desired[c.desired]}}
+			for _, other := range candidates {
+				if other.desired == c.desired && other.score == b.score {
+					conflict.Actual = append(conflict.Actual, actual[other.actual])
+				}
+			}
+			conflicts = append(conflicts, conflict)
+		}
+		if b := bestActual[c.actual]; b.count > 1 && !ambiguousActual[c.actual] {
+			ambiguousActual[c.actual] = true
+			conflict := ports.AmbiguousMatch{Actual: []domain.PlatformResource{actual[c.actual]}}
+			for _, other := range candidates {
+				if other.actual == c.actual && other.score == b.score {
+					conflict.Desired = append(conflict.Desired, desired[other.desired])
+				}
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+	pairedDesired := make(map[int]bool)
+	pairedActual := make(map[int]bool)
+	pairs := make([]ports.MatchedPair, 0)
+	for _, c := range candidates {
+		if pairedDesired[c.desired] || pairedActual[c.actual] || ambiguousDesired[c.desired] || ambiguousActual[c.actual] {
+			continue
+		}
+		pairedDesired[c.desired] = true
+		pairedActual[c.actual] = true
+		pairs = append(pairs, ports.MatchedPair{Desired: desired[c.desired], Actual: actual[c.actual], Confidence: c.score})
+	}
+	return pairs, conflicts, nil
+}
+
+type bestScore struct {
+	score float64
+	count int
+}
+
+// bestScores returns the best score of each desired and actual resource and
+// how many candidates share it.
+func bestScores(candidates []fuzzyCandidate) (map[int]bestScore, map[int]bestScore) {
+	bestDesired := make(map[int]bestScore)
+	bestActual := make(map[int]bestScore)
+	record := func(m map[int]bestScore, key int, score float64) {
+		switch b := m[key]; {
+		case score > b.score:
+			m[key] = bestScore{score: score, count: 1}
+		case score == b.score:
+			m[key] = bestScore{score: score, count: b.count + 1}
+		}
+	}
+	for _, c := range candidates {
+		record(bestDesired, c.desired, c.score)
+		record(bestActual, c.actual, c.score)
+	}
+	return bestDesired, bestActual
+}
+
+// desiredName is the Name tag of a desired resource, or else the name part of
+// its source identifier, e.g. "web" for aws_instance.web.
+func desiredName(res domain.StateResource) string {
+	attrs := res.Attributes()
+	if name := nameTag(attrs); name != "" {
+		return name
+	}
+	if name := stringAttr(attrs, domain.KeyName); name != "" {
+		return name
+	}
+	address := res.Metadata().SourceIdentifier
+	if i := strings.IndexAny(address, "[\""); i >= 0 {
+		address = address[:i]
+	}
+	return address[strings.LastIndex(address, ".")+1:]
+}
+
+func desiredRegion(res domain.StateResource) string {
+	if region := res.Metadata().Region; region != "" {
+		return region
+	}
+	return stringAttr(res.Attributes(), domain.KeyRegion)
+}
+
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// similarity is one minus the Levenshtein distance of a and b over the length
+// of the longer one.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
@@ -0,0 +1,179 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const MatcherTypeStrategy = "strategy"
+
+// Names of the built-in strategies.
+const (
+	StrategyIdentity = "identity"
+	StrategyID       = "id"
+	StrategyARN      = "arn"
+	StrategyNameTag  = "name_tag"
+	StrategyFuzzy    = "fuzzy"
+)
+
+// DefaultStrategies is the priority order used when none is configured.
+var DefaultStrategies = []string{StrategyIdentity, StrategyID, StrategyARN, StrategyNameTag, StrategyFuzzy}
+
+type Config struct {
+	// Strategies lists the built-in strategies to try, in priority order.
+	Strategies []string `yaml:"strategies" mapstructure:"strategies" validate:"omitempty,dive,oneof=identity id arn name_tag fuzzy"`
+	// Identity lists, per kind, the attributes whose values together identify
+	// a resource for the identity strategy, e.g. the name of a database
+	// instance for resources imported or renamed since the state was written.
+	// Kinds not listed are left to the other strategies. It is set from the
+	// identity of each resources entry.
+	Identity map[domain.ResourceKind][]string `yaml:"-" mapstructure:"-"`
+	// FuzzyThreshold is the lowest name similarity, from 0 to 1, at which the
+	// fuzzy strategy pairs two resources.
+	FuzzyThreshold float64 `yaml:"fuzzy_threshold" mapstructure:"fuzzy_threshold" validate:"omitempty,gt=0,lte=1"`
+}
+
+var _ ports.Matcher = (*Matcher)(nil)
+
+// Matcher pairs desired and actual resources with several strategies in
+// priority order. Each strategy only sees the resources the strategies before
+// it left unmatched, and every pair records the strategy that made it and its
+// confidence. Conflicts a strategy finds are reported as ambiguous unless a
+// later strategy resolves them.
+type Matcher struct {
+	strategies []ports.MatchStrategy
+	logger     ports.Logger
+}
+
+// NewMatcher creates a matcher from the built-in strategies named in cfg, or
+// DefaultStrategies when it names none.
+func NewMatcher(cfg Config, logger ports.Logger) (*Matcher, error) {
+	names := cfg.Strategies
+	if len(names) == 0 {
+		names = DefaultStrategies
+	}
+	strategies := make([]ports.MatchStrategy, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case StrategyIdentity:
+			strategies = append(strategies, NewIdentityStrategy(cfg.Identity))
+		case StrategyID:
+			strategies = append(strategies, IDStrategy{})
+		case StrategyARN:
+			strategies = append(strategies, ARNStrategy{})
+		case StrategyNameTag:
+			strategies = append(strategies, NameTagStrategy{})
+		case StrategyFuzzy:
+			strategies = append(strategies, NewFuzzyStrategy(cfg.FuzzyThreshold))
+		default:
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("unknown matching strategy: %s", name), "Supported: identity, id, arn, name_tag, fuzzy")
+		}
+	}
+	return NewMatcherWithStrategies(logger, strategies...)
+}
+
+// NewMatcherWithStrategies creates a matcher trying the given strategies, in
+// order. It lets custom strategies be combined with the built-in ones.
+func NewMatcherWithStrategies(logger ports.Logger, strategies ...ports.MatchStrategy) (*Matcher, error) {
+	if len(strategies) == 0 {
+		return nil, errors.New(errors.CodeConfigValidation, "strategy matcher requires at least one strategy")
+	}
+	return &Matcher{strategies: strategies, logger: logger}, nil
+}
+
+func (m *Matcher) Match(
+	ctx context.Context,
+	desired []domain.StateResource,
+	actual []domain.PlatformResource,
+) (ports.MatchingResult, error) {
+	m.logger.Debugf(ctx, "Starting strategy matching (%d desired, %d actual)", len(desired), len(actual))
+
+	result := ports.MatchingResult{Matched: make([]ports.MatchedPair, 0)}
+	matchedDesired := make(map[domain.StateResource]bool)
+	matchedActual := make(map[domain.PlatformResource]bool)
+	remainingDesired, remainingActual := desired, actual
+	var conflicts []ports.AmbiguousMatch
+
+	for _, strategy := range m.strategies {
+		if ctx.Err() != nil {
+			return ports.MatchingResult{}, ctx.Err()
+		}
+		if len(remainingDesired) == 0 || len(remainingActual) == 0 {
+			break
+		}
+		pairs, found, err := strategy.Match(ctx, remainingDesired, remainingActual)
+		if err != nil {
+			return ports.MatchingResult{}, errors.Wrap(err, errors.CodeMatchingError, fmt.Sprintf("matching strategy %s failed", strategy.Name()))
+		}
+		accepted := 0
+		for _, pair := range pairs {
+			if matchedDesired[pair.Desired] || matchedActual[pair.Actual] {
+				m.logger.Warnf(ctx, "Matching strategy %s paired an already matched resource, ignoring the pair", strategy.Name())
+				continue
+			}
+			matchedDesired[pair.Desired] = true
+			matchedActual[pair.Actual] = true
+			pair.Strategy = strategy.Name()
+			result.Matched = append(result.Matched, pair)
+			accepted++
+			m.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via %s (confidence %.2f)",
+				pair.Desired.Metadata().SourceIdentifier, pair.Actual.Metadata().ProviderAssignedID, strategy.Name(), pair.Confidence)
+		}
+		m.logger.Debugf(ctx, "Matching strategy %s paired %d resources", strategy.Name(), accepted)
+		for _, conflict := range found {
+			conflict.Strategy = strategy.Name()
+			conflicts = append(conflicts, conflict)
+		}
+
+		remainingDesired = unmatched(remainingDesired, matchedDesired)
+		remainingActual = unmatched(remainingActual, matchedActual)
+	}
+
+	result.Ambiguous = m.unresolved(ctx, conflicts, matchedDesired, matchedActual)
+	result.UnmatchedDesired = unmatched(desired, matchedDesired)
+	result.UnmatchedActual = unmatched(actual, matchedActual)
+	m.logger.Debugf(ctx, "Strategy matching finished: %d matched, %d missing, %d unmanaged, %d ambiguous", len(result.Matched), len(result.UnmatchedDesired), len(result.UnmatchedActual), len(result.Ambiguous))
+	return result, nil
+}
+
+// unresolved returns the conflicts no later strategy resolved, and marks
+// their resources as matched so they are not reported as unmatched too.
+// Resources a later strategy paired or an earlier conflict holds are left out
+// of a conflict, and a conflict no longer holding several resources on one
+// side is dropped.
+func (m *Matcher) unresolved(ctx context.Context, conflicts []ports.AmbiguousMatch, matchedDesired map[domain.StateResource]bool, matchedActual map[domain.PlatformResource]bool) []ports.AmbiguousMatch {
+	var out []ports.AmbiguousMatch
+	for _, conflict := range conflicts {
+		conflict.Desired = unmatched(conflict.Desired, matchedDesired)
+		conflict.Actual = unmatched(conflict.Actual, matchedActual)
+		if len(conflict.Desired) == 0 || len(conflict.Actual) == 0 || (len(conflict.Desired) == 1 && len(conflict.Actual) == 1) {
+			continue
+		}
+		for _, res := range conflict.Desired {
+			matchedDesired[res] = true
+		}
+		for _, res := range conflict.Actual {
+			matchedActual[res] = true
+		}
+		out = append(out, conflict)
+	}
+	if len(out) > 0 {
+		m.logger.Warnf(ctx, "Strategy matching found %d ambiguous matches, their resources are not compared", len(out))
+	}
+	return out
+}
+
+// unmatched returns the resources not in matched, keeping their order.
+func unmatched[T comparable](resources []T, matched map[T]bool) []T {
+	out := make([]T, 0, len(resources))
+	for _, res := range resources {
+		if !matched[res] {
+			out = append(out, res)
+		}
+	}
+	return out
+}
@@ -0,0 +1,298 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	domainmocks "github.com/olusolaa/infra-drift-detector/internal/core/domain/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func newTestLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		logger.On("Debugf", args...).Maybe().Return()
+		logger.On("Warnf", args...).Maybe().Return()
+	}
+	return logger
+}
+
+func newTestMatcher(t *testing.T, cfg Config) *Matcher {
+	m, err := NewMatcher(cfg, newTestLogger())
+	require.NoError(t, err)
+	return m
+}
+
+func desiredResource(meta domain.ResourceMetadata, attrs map[string]any) *domainmocks.StateResource {
+	res := new(domainmocks.StateResource)
+	res.On("Metadata").Return(meta)
+	res.On("Attributes").Return(attrs)
+	return res
+}
+
+func actualResource(meta domain.ResourceMetadata, attrs map[string]any) *domainmocks.PlatformResource {
+	res := new(domainmocks.PlatformResource)
+	res.On("Metadata").Return(meta)
+	res.On("Attributes", mock.Anything).Return(attrs, nil)
+	return res
+}
+
+func instance(id, region string) domain.ResourceMetadata {
+	return domain.ResourceMetadata{Kind: domain.KindComputeInstance, ProviderAssignedID: id, Region: region}
+}
+
+func tagged(name string) map[string]any {
+	return map[string]any{domain.KeyTags: map[string]string{"Name": name}}
+}
+
+func TestMatcher_TriesStrategiesInPriorityOrder(t *testing.T) {
+	byID := desiredResource(instance("i-1", ""), tagged("web"))
+	byARN := desiredResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket}, map[string]any{domain.KeyARN: "arn:aws:s3:::assets"})
+	byName := desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.api"}, tagged("api"))
+	byFuzzy := desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.batch_worker"}, nil)
+	missing := desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.cache"}, nil)
+
+	actualByID := actualResource(instance("i-1", "eu-west-1"), tagged("api"))
+	actualByARN := actualResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket, ProviderAssignedID: "assets"}, map[string]any{domain.KeyARN: "arn:aws:s3:::assets"})
+	actualByName := actualResource(instance("i-2", "eu-west-1"), tagged("api"))
+	actualByFuzzy := actualResource(instance("i-3", "eu-west-1"), tagged("batch-worker-1"))
+	unmanaged := actualResource(instance("i-4", "eu-west-1"), tagged("bastion"))
+
+	result, err := newTestMatcher(t, Config{}).Match(context.Background(),
+		[]domain.StateResource{byID, byARN, byName, byFuzzy, missing},
+		[]domain.PlatformResource{unmanaged, actualByFuzzy, actualByName, actualByARN, actualByID})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 4)
+	expected := []struct {
+		desired  domain.StateResource
+		actual   domain.PlatformResource
+		strategy string
+	}{
+		{byID, actualByID, StrategyID},
+		{byARN, actualByARN, StrategyARN},
+		{byName, actualByName, StrategyNameTag},
+		{byFuzzy, actualByFuzzy, StrategyFuzzy},
+	}
+	for i, want := range expected {
+		assert.Same(t, want.desired, result.Matched[i].Desired, want.strategy)
+		assert.Same(t, want.actual, result.Matched[i].Actual, want.strategy)
+		assert.Equal(t, want.strategy, result.Matched[i].Strategy)
+	}
+	assert.Equal(t, 1.0, result.Matched[0].Confidence)
+	assert.Equal(t, nameTagConfidence, result.Matched[2].Confidence)
+	assert.InDelta(t, 11.0/12.0, result.Matched[3].Confidence, 1e-9, "batchworker vs batchworker1")
+	assert.Equal(t, []domain.StateResource{missing}, result.UnmatchedDesired)
+	assert.Equal(t, []domain.PlatformResource{unmanaged}, result.UnmatchedActual)
+}
+
+func TestMatcher_ConfiguredStrategiesOnly(t *testing.T) {
+	desired := desiredResource(instance("", ""), tagged("web"))
+	actual := actualResource(instance("i-1", ""), tagged("web"))
+
+	result, err := newTestMatcher(t, Config{Strategies: []string{StrategyID}}).Match(context.Background(),
+		[]domain.StateResource{desired}, []domain.PlatformResource{actual})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Matched)
+	assert.Len(t, result.UnmatchedDesired, 1)
+	assert.Len(t, result.UnmatchedActual, 1)
+}
+
+func TestMatcher_UnknownStrategy(t *testing.T) {
+	_, err := NewMatcher(Config{Strategies: []string{"guess"}}, newTestLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "guess")
+}
+
+type pairFirstStrategy struct{}
+
+func (pairFirstStrategy) Name() string { return "first" }
+
+func (pairFirstStrategy) Match(_ context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	return []ports.MatchedPair{{Desired: desired[0], Actual: actual[0], Confidence: 0.5}}, nil, nil
+}
+
+func TestMatcher_CustomStrategies(t *testing.T) {
+	first := desiredResource(instance("", ""), nil)
+	second := desiredResource(instance("i-2", ""), nil)
+	actualFirst := actualResource(instance("i-9", ""), nil)
+	actualSecond := actualResource(instance("i-2", ""), nil)
+
+	m, err := NewMatcherWithStrategies(newTestLogger(), IDStrategy{}, pairFirstStrategy{})
+	require.NoError(t, err)
+	result, err := m.Match(context.Background(),
+		[]domain.StateResource{first, second}, []domain.PlatformResource{actualFirst, actualSecond})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 2)
+	assert.Equal(t, StrategyID, result.Matched[0].Strategy)
+	assert.Same(t, first, result.Matched[1].Desired, "later strategies only see what is left")
+	assert.Same(t, actualFirst, result.Matched[1].Actual)
+	assert.Equal(t, "first", result.Matched[1].Strategy)
+	assert.Equal(t, 0.5, result.Matched[1].Confidence)
+}
+
+func TestMatchByKey_AmbiguousKeysAreNotPaired(t *testing.T) {
+	desired := desiredResource(instance("", ""), tagged("web"))
+	twinA := actualResource(instance("i-1", ""), tagged("web"))
+	twinB := actualResource(instance("i-2", ""), tagged("web"))
+
+	pairs, conflicts, err := NameTagStrategy{}.Match(context.Background(),
+		[]domain.StateResource{desired}, []domain.PlatformResource{twinA, twinB})
+	require.NoError(t, err)
+	assert.Empty(t, pairs)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, []domain.StateResource{desired}, conflicts[0].Desired)
+	assert.Equal(t, []domain.PlatformResource{twinA, twinB}, conflicts[0].Actual)
+}
+
+func TestMatcher_ReportsUnresolvedConflicts(t *testing.T) {
+	worker := desiredResource(instance("", ""), tagged("worker"))
+	web := desiredResource(instance("i-3", ""), tagged("web"))
+	workerA := actualResource(instance("i-1", ""), tagged("worker"))
+	workerB := actualResource(instance("i-2", ""), tagged("worker"))
+	webA := actualResource(instance("i-3", ""), tagged("web"))
+	webB := actualResource(instance("i-4", ""), tagged("web"))
+
+	result, err := newTestMatcher(t, Config{Strategies: []string{StrategyNameTag, StrategyID}}).Match(context.Background(),
+		[]domain.StateResource{worker, web}, []domain.PlatformResource{workerA, workerB, webA, webB})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 1, "the ID strategy resolves the web conflict")
+	assert.Same(t, web, result.Matched[0].Desired)
+	assert.Same(t, webA, result.Matched[0].Actual)
+	require.Len(t, result.Ambiguous, 1)
+	assert.Equal(t, StrategyNameTag, result.Ambiguous[0].Strategy)
+	assert.Equal(t, []domain.StateResource{worker}, result.Ambiguous[0].Desired)
+	assert.Equal(t, []domain.PlatformResource{workerA, workerB}, result.Ambiguous[0].Actual)
+	assert.Empty(t, result.UnmatchedDesired)
+	assert.Equal(t, []domain.PlatformResource{webB}, result.UnmatchedActual)
+}
+
+func TestFuzzyStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired domain.StateResource
+		actual  []domain.PlatformResource
+		want    int // index of the paired actual resource, or -1
+	}{
+		{
+			name:    "similar name",
+			desired: desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: `module.app.aws_instance.web_server["a"]`}, nil),
+			actual:  []domain.PlatformResource{actualResource(instance("i-1", ""), tagged("Web-Server"))},
+			want:    0,
+		},
+		{
+			name:    "different kind",
+			desired: desiredResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.web_server"}, nil),
+			actual:  []domain.PlatformResource{actualResource(instance("i-1", ""), tagged("web-server"))},
+			want:    -1,
+		},
+		{
+			name:    "different region",
+			desired: desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, Region: "us-east-1", SourceIdentifier: "aws_instance.web_server"}, nil),
+			actual:  []domain.PlatformResource{actualResource(instance("i-1", "eu-west-1"), tagged("web-server"))},
+			want:    -1,
+		},
+		{
+			name:    "below threshold",
+			desired: desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web"}, nil),
+			actual:  []domain.PlatformResource{actualResource(instance("i-1", ""), tagged("worker"))},
+			want:    -1,
+		},
+		{
+			name:    "tied best names",
+			desired: desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web_server"}, nil),
+			actual: []domain.PlatformResource{
+				actualResource(instance("i-1", ""), tagged("web-server-1")),
+				actualResource(instance("i-2", ""), tagged("web-server-2")),
+			},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, _, err := NewFuzzyStrategy(0).Match(context.Background(), []domain.StateResource{tt.desired}, tt.actual)
+			require.NoError(t, err)
+			if tt.want < 0 {
+				assert.Empty(t, pairs)
+				return
+			}
+			require.Len(t, pairs, 1)
+			assert.Same(t, tt.actual[tt.want], pairs[0].Actual)
+		})
+	}
+}
+
+func TestFuzzyStrategy_TiesAreConflicts(t *testing.T) {
+	desired := desiredResource(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web_server"}, nil)
+	first := actualResource(instance("i-1", ""), tagged("web-server-1"))
+	second := actualResource(instance("i-2", ""), tagged("web-server-2"))
+
+	pairs, conflicts, err := NewFuzzyStrategy(0).Match(context.Background(),
+		[]domain.StateResource{desired}, []domain.PlatformResource{first, second})
+	require.NoError(t, err)
+
+	assert.Empty(t, pairs)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, []domain.StateResource{desired}, conflicts[0].Desired)
+	assert.Equal(t, []domain.PlatformResource{first, second}, conflicts[0].Actual)
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, similarity("web", "web"))
+	assert.Equal(t, 0.0, similarity("", ""))
+	assert.InDelta(t, 2.0/3.0, similarity("web", "wet"), 1e-9)
+	assert.InDelta(t, 0.5, similarity("abcd", "ab"), 1e-9)
+}
+
+func TestIdentityStrategy(t *testing.T) {
+	strategy := NewIdentityStrategy(map[domain.ResourceKind][]string{
+		domain.KindDatabaseInstance: {domain.KeyName, domain.KeyRegion},
+	})
+	database := func(name, region string) map[string]any {
+		return map[string]any{domain.KeyName: name, domain.KeyRegion: region}
+	}
+	db := domain.ResourceMetadata{Kind: domain.KindDatabaseInstance}
+
+	renamed := desiredResource(db, database("orders", "eu-west-1"))
+	otherRegion := desiredResource(db, database("billing", "eu-west-1"))
+	incomplete := desiredResource(db, map[string]any{domain.KeyName: "users"})
+	notConfigured := desiredResource(instance("i-1", ""), tagged("web"))
+
+	actualRenamed := actualResource(domain.ResourceMetadata{Kind: domain.KindDatabaseInstance, ProviderAssignedID: "db-new"}, database("orders", "eu-west-1"))
+	actualOtherRegion := actualResource(db, database("billing", "us-east-1"))
+	actualIncomplete := actualResource(db, database("users", "eu-west-1"))
+	actualInstance := actualResource(instance("i-1", ""), tagged("web"))
+
+	pairs, _, err := strategy.Match(context.Background(),
+		[]domain.StateResource{renamed, otherRegion, incomplete, notConfigured},
+		[]domain.PlatformResource{actualInstance, actualIncomplete, actualOtherRegion, actualRenamed})
+	require.NoError(t, err)
+
+	require.Len(t, pairs, 1)
+	assert.Same(t, renamed, pairs[0].Desired)
+	assert.Same(t, actualRenamed, pairs[0].Actual)
+	assert.Equal(t, 1.0, pairs[0].Confidence)
+}
+
+func TestMatcher_IdentityBeforeID(t *testing.T) {
+	desired := desiredResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket, ProviderAssignedID: "old"}, map[string]any{domain.KeyName: "assets"})
+	byID := actualResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket, ProviderAssignedID: "old"}, map[string]any{domain.KeyName: "assets-old"})
+	byName := actualResource(domain.ResourceMetadata{Kind: domain.KindStorageBucket, ProviderAssignedID: "new"}, map[string]any{domain.KeyName: "assets"})
+
+	result, err := newTestMatcher(t, Config{Identity: map[domain.ResourceKind][]string{domain.KindStorageBucket: {domain.KeyName}}}).
+		Match(context.Background(), []domain.StateResource{desired}, []domain.PlatformResource{byID, byName})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 1)
+	assert.Same(t, byName, result.Matched[0].Actual)
+	assert.Equal(t, StrategyIdentity, result.Matched[0].Strategy)
+}
@@ -0,0 +1,185 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// nameTagConfidence is the confidence of pairs made by Name tag: names are
+// chosen by people and nothing keeps two resources from sharing one.
+const nameTagConfidence = 0.8
+
+// IDStrategy pairs resources of the same kind whose provider assigned IDs
+// are equal, the ID recorded in state being the one the platform returned.
+type IDStrategy struct{}
+
+func (IDStrategy) Name() string { return StrategyID }
+
+func (IDStrategy) Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	return matchByKey(ctx, desired, actual,
+		func(res domain.StateResource) string { return res.Metadata().ProviderAssignedID },
+		func(res domain.PlatformResource) string { return res.Metadata().ProviderAssignedID },
+		1)
+}
+
+// ARNStrategy pairs resources of the same kind whose ARNs are equal.
+type ARNStrategy struct{}
+
+func (ARNStrategy) Name() string { return StrategyARN }
+
+func (ARNStrategy) Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	return matchByKey(ctx, desired, actual,
+		func(res domain.StateResource) string { return stringAttr(res.Attributes(), domain.KeyARN) },
+		func(res domain.PlatformResource) string { return stringAttr(actualAttributes(ctx, res), domain.KeyARN) },
+		1)
+}
+
+// NameTagStrategy pairs resources of the same kind whose Name tags are equal.
+type NameTagStrategy struct{}
+
+func (NameTagStrategy) Name() string { return StrategyNameTag }
+
+func (NameTagStrategy) Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	return matchByKey(ctx, desired, actual,
+		func(res domain.StateResource) string { return nameTag(res.Attributes()) },
+		func(res domain.PlatformResource) string { return nameTag(actualAttributes(ctx, res)) },
+		nameTagConfidence)
+}
+
+// IdentityStrategy pairs resources of the same kind whose configured
+// identity attributes all have equal values.
+type IdentityStrategy struct {
+	attributes map[domain.ResourceKind][]string
+}
+
+// NewIdentityStrategy creates an identity strategy identifying the resources
+// of each kind by the given attributes.
+func NewIdentityStrategy(attributes map[domain.ResourceKind][]string) IdentityStrategy {
+	return IdentityStrategy{attributes: attributes}
+}
+
+func (IdentityStrategy) Name() string { return StrategyIdentity }
+
+func (s IdentityStrategy) Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	if len(s.attributes) == 0 {
+		return nil, nil, nil
+	}
+	return matchByKey(ctx, desired, actual,
+		func(res domain.StateResource) string {
+			return s.identity(res.Metadata().Kind, func() map[string]any { return res.Attributes() })
+		},
+		func(res domain.PlatformResource) string {
+			return s.identity(res.Metadata().Kind, func() map[string]any { return actualAttributes(ctx, res) })
+		},
+		1)
+}
+
+// identity joins the values of the identity attributes of kind, or returns
+// an empty key when the kind has none configured or a value is missing.
+func (s IdentityStrategy) identity(kind domain.ResourceKind, attributes func() map[string]any) string {
+	keys := s.attributes[kind]
+	if len(keys) == 0 {
+		return ""
+	}
+	attrs := attributes()
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, ok := attrs[key]
+		if !ok || value == nil {
+			return ""
+		}
+		formatted := fmt.Sprint(value)
+		if formatted == "" {
+			return ""
+		}
+		values = append(values, formatted)
+	}
+	return strings.Join(values, "\x00")
+}
+
+type kindKey struct {
+	kind domain.ResourceKind
+	key  string
+}
+
+// matchByKey pairs desired and actual resources of the same kind with equal,
+// non-empty keys. A key held by more than one resource on either side is
+// ambiguous: none of the resources holding it are paired, and they are
+// returned as a conflict when both sides hold it.
+func matchByKey(
+	ctx context.Context,
+	desired []domain.StateResource,
+	actual []domain.PlatformResource,
+	desiredKey func(domain.StateResource) string,
+	actualKey func(domain.PlatformResource) string,
+	confidence float64,
+) ([]ports.MatchedPair, []ports.AmbiguousMatch, error) {
+	actualByKey := make(map[kindKey][]domain.PlatformResource)
+	for _, res := range actual {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		key := kindKey{kind: res.Metadata().Kind, key: actualKey(res)}
+		if key.key == "" {
+			continue
+		}
+		actualByKey[key] = append(actualByKey[key], res)
+	}
+
+	desiredByKey := make(map[kindKey][]domain.StateResource)
+	order := make([]kindKey, 0, len(desired))
+	for _, res := range desired {
+		key := kindKey{kind: res.Metadata().Kind, key: desiredKey(res)}
+		if key.key == "" {
+			continue
+		}
+		if _, exists := desiredByKey[key]; !exists {
+			order = append(order, key)
+		}
+		desiredByKey[key] = append(desiredByKey[key], res)
+	}
+
+	pairs := make([]ports.MatchedPair, 0)
+	var conflicts []ports.AmbiguousMatch
+	for _, key := range order {
+		des, act := desiredByKey[key], actualByKey[key]
+		switch {
+		case len(act) == 0:
+		case len(des) == 1 && len(act) == 1:
+			pairs = append(pairs, ports.MatchedPair{Desired: des[0], Actual: act[0], Confidence: confidence})
+		default:
+			conflicts = append(conflicts, ports.AmbiguousMatch{Desired: des, Actual: act})
+		}
+	}
+	return pairs, conflicts, nil
+}
+
+// actualAttributes returns the attributes of an actual resource, or nil when
+// they cannot be read, so the resource is left to the next strategy.
+func actualAttributes(ctx context.Context, res domain.PlatformResource) map[string]any {
+	attrs, err := res.Attributes(ctx)
+	if err != nil {
+		return nil
+	}
+	return attrs
+}
+
+func stringAttr(attrs map[string]any, key string) string {
+	value, _ := attrs[key].(string)
+	return value
+}
+
+func nameTag(attrs map[string]any) string {
+	switch tags := attrs[domain.KeyTags].(type) {
+	case map[string]string:
+		return tags["Name"]
+	case map[string]any:
+		value, _ := tags["Name"].(string)
+		return value
+	}
+	return ""
+}
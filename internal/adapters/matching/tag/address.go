@@ -0,0 +1,79 @@
+package tag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// normalizeAddress canonicalizes the instance keys of a resource address, so
+// that the spellings used in tags match the addresses of the state: count
+// indexes and for_each keys may be written aws_instance.web[0],
+// aws_instance.web["0"], aws_instance.web[blue] or aws_instance.web['blue'],
+// including the keys of module instances.
+func normalizeAddress(addr string) string {
+	if !strings.Contains(addr, "[") {
+		return addr
+	}
+	var b strings.Builder
+	b.Grow(len(addr) + 4)
+	for {
+		open := strings.IndexByte(addr, '[')
+		if open < 0 {
+			break
+		}
+		end := closingBracket(addr, open)
+		if end < 0 {
+			break
+		}
+		b.WriteString(addr[:open])
+		b.WriteString(normalizeKey(addr[open+1 : end]))
+		addr = addr[end+1:]
+	}
+	b.WriteString(addr)
+	return b.String()
+}
+
+// closingBracket returns the index of the bracket closing the key opened at
+// open, skipping brackets inside a quoted key, or -1.
+func closingBracket(addr string, open int) int {
+	rest := addr[open+1:]
+	trimmed := strings.TrimLeft(rest, " ")
+	if trimmed == "" || (trimmed[0] != '"' && trimmed[0] != '\'') {
+		if end := strings.IndexByte(rest, ']'); end >= 0 {
+			return open + 1 + end
+		}
+		return -1
+	}
+	quote := trimmed[0]
+	start := open + 1 + len(rest) - len(trimmed)
+	for i := start + 1; i < len(addr); i++ {
+		switch addr[i] {
+		case '\\':
+			i++
+		case quote:
+			if end := strings.IndexByte(addr[i+1:], ']'); end >= 0 {
+				return i + 1 + end
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// normalizeKey renders an instance key as "[n]" for numbers and "[\"key\"]"
+// for strings. Numeric string keys are rendered as numbers, since a resource
+// uses either count or for_each, never both.
+func normalizeKey(raw string) string {
+	key := strings.TrimSpace(raw)
+	if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		if unquoted, err := strconv.Unquote(`"` + key[1:len(key)-1] + `"`); err == nil {
+			key = unquoted
+		} else {
+			key = key[1 : len(key)-1]
+		}
+	}
+	if n, err := strconv.Atoi(key); err == nil && n >= 0 {
+		return "[" + strconv.Itoa(n) + "]"
+	}
+	return "[" + strconv.Quote(key) + "]"
+}
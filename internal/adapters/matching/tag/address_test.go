@@ -0,0 +1,55 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"aws_instance.web", "aws_instance.web"},
+		{"aws_instance.web[2]", "aws_instance.web[2]"},
+		{`aws_instance.web["2"]`, "aws_instance.web[2]"},
+		{`aws_instance.web["blue"]`, `aws_instance.web["blue"]`},
+		{"aws_instance.web[blue]", `aws_instance.web["blue"]`},
+		{"aws_instance.web['blue']", `aws_instance.web["blue"]`},
+		{`aws_instance.web[ "a]b" ]`, `aws_instance.web["a]b"]`},
+		{`module.app["eu"].aws_instance.web[0]`, `module.app["eu"].aws_instance.web[0]`},
+		{"module.app[eu].aws_instance.web[00]", `module.app["eu"].aws_instance.web[0]`},
+		{"aws_instance.web[unterminated", "aws_instance.web[unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeAddress(tt.in))
+		})
+	}
+}
+
+func TestMatch_ExpandedInstances(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMatcher(t)
+	first, second := desiredResource("module.app.aws_instance.web[0]"), desiredResource(`aws_s3_bucket.logs["eu-west-1"]`)
+	web1 := desiredResource("module.app.aws_instance.web[1]")
+
+	result, err := m.Match(ctx,
+		[]domain.StateResource{first, web1, second},
+		[]domain.PlatformResource{
+			actualResource("i-0", `module.app.aws_instance.web["0"]`),
+			actualResource("logs-eu", "aws_s3_bucket.logs[eu-west-1]"),
+		})
+
+	require.NoError(t, err)
+	require.Len(t, result.Matched, 2, "count and for_each instances are matched one by one")
+	assert.Same(t, first, result.Matched[0].Desired)
+	assert.Same(t, second, result.Matched[1].Desired)
+	require.Len(t, result.UnmatchedDesired, 1)
+	assert.Same(t, web1, result.UnmatchedDesired[0])
+}
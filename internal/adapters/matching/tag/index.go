@@ -0,0 +1,77 @@
+package tag
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+var _ ports.StreamingMatcher = (*Matcher)(nil)
+
+// index matches actual resources to desired resources by source identifier as
+// they arrive. It keeps only the desired resources, so memory grows with the
+// size of the state rather than with the size of the account.
+type index struct {
+	matcher  *Matcher
+	bySource map[string]*indexEntry
+	// entries keeps the add order so unmatched resources are reported stably.
+	entries []*indexEntry
+}
+
+type indexEntry struct {
+	resource domain.StateResource
+	matched  bool
+}
+
+// NewIndex returns an empty index of desired resources keyed by source identifier.
+func (m *Matcher) NewIndex() ports.MatchIndex {
+	return &index{matcher: m, bySource: make(map[string]*indexEntry)}
+}
+
+func (i *index) AddDesired(ctx context.Context, res domain.StateResource) {
+	meta := res.Metadata()
+	sourceID := normalizeAddress(meta.SourceIdentifier)
+	if sourceID == "" {
+		i.matcher.logger.Warnf(ctx, "Desired resource of kind %s has empty SourceIdentifier, cannot match via tag", meta.Kind)
+		i.entries = append(i.entries, &indexEntry{resource: res})
+		return
+	}
+	if _, exists := i.bySource[sourceID]; exists {
+		i.matcher.logger.Errorf(ctx, nil, "Duplicate desired resource identifier '%s' found. Skipping duplicate.", sourceID)
+		return
+	}
+	entry := &indexEntry{resource: res}
+	i.bySource[sourceID] = entry
+	i.entries = append(i.entries, entry)
+}
+
+func (i *index) MatchActual(ctx context.Context, res domain.PlatformResource) (domain.StateResource, bool) {
+	sourceID, found := i.matcher.actualIdentifier(ctx, res)
+	if !found {
+		return nil, false
+	}
+	entry, exists := i.bySource[sourceID]
+	if !exists {
+		return nil, false
+	}
+	meta := res.Metadata()
+	if entry.matched {
+		i.matcher.logger.Errorf(ctx, nil, "Duplicate tag value '%s' found on actual resource %s (%s). Only one will be matched.",
+			sourceID, meta.ProviderAssignedID, meta.Kind)
+		return nil, false
+	}
+	entry.matched = true
+	i.matcher.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via tag '%s'", sourceID, meta.ProviderAssignedID, i.matcher.config.TagKey)
+	return entry.resource, true
+}
+
+func (i *index) Unmatched() []domain.StateResource {
+	unmatched := make([]domain.StateResource, 0)
+	for _, entry := range i.entries {
+		if !entry.matched {
+			unmatched = append(unmatched, entry.resource)
+		}
+	}
+	return unmatched
+}
@@ -0,0 +1,70 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	domainmocks "github.com/olusolaa/infra-drift-detector/internal/core/domain/mocks"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func newTestMatcher(t *testing.T) *Matcher {
+	t.Helper()
+	logger := new(portsmocks.Logger)
+	for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+		logger.On("Debugf", args...).Maybe().Return()
+		logger.On("Warnf", args...).Maybe().Return()
+		logger.On("Errorf", append([]any{mock.Anything}, args...)...).Maybe().Return()
+	}
+	m, err := NewMatcher(Config{TagKey: "TFResourceAddress"}, logger)
+	require.NoError(t, err)
+	return m
+}
+
+func desiredResource(sourceID string) *domainmocks.StateResource {
+	res := new(domainmocks.StateResource)
+	res.On("Metadata").Return(domain.ResourceMetadata{Kind: domain.KindComputeInstance, SourceIdentifier: sourceID})
+	return res
+}
+
+func actualResource(id, address string) *domainmocks.PlatformResource {
+	res := new(domainmocks.PlatformResource)
+	res.On("Metadata").Return(domain.ResourceMetadata{Kind: domain.KindComputeInstance, ProviderAssignedID: id})
+	tags := map[string]string{}
+	if address != "" {
+		tags["TFResourceAddress"] = address
+	}
+	res.On("Attributes", mock.Anything).Return(map[string]any{domain.KeyTags: tags}, nil)
+	return res
+}
+
+func TestIndex_MatchesActualResourcesAsTheyArrive(t *testing.T) {
+	ctx := context.Background()
+	index := newTestMatcher(t).NewIndex()
+	web, db, noID := desiredResource("aws_instance.web"), desiredResource("aws_instance.db"), desiredResource("")
+	index.AddDesired(ctx, web)
+	index.AddDesired(ctx, db)
+	index.AddDesired(ctx, noID)
+	index.AddDesired(ctx, desiredResource("aws_instance.web")) // duplicate is skipped
+
+	matched, ok := index.MatchActual(ctx, actualResource("i-1", "aws_instance.web"))
+	require.True(t, ok)
+	assert.Same(t, web, matched)
+
+	_, ok = index.MatchActual(ctx, actualResource("i-2", "aws_instance.web"))
+	assert.False(t, ok, "a desired resource is matched at most once")
+	_, ok = index.MatchActual(ctx, actualResource("i-3", "aws_instance.other"))
+	assert.False(t, ok)
+	_, ok = index.MatchActual(ctx, actualResource("i-4", ""))
+	assert.False(t, ok)
+
+	unmatched := index.Unmatched()
+	require.Len(t, unmatched, 2)
+	assert.Same(t, db, unmatched[0])
+	assert.Same(t, noID, unmatched[1])
+}
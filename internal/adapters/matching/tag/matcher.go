@@ -50,98 +50,93 @@ func (m *Matcher) Match(
 		UnmatchedActual:  make([]domain.PlatformResource, 0),
 	}
 
-	actualIndex := make(map[string]domain.PlatformResource)
-	actualProcessed := make(map[string]bool)
-
+	actualIndex := make(map[string][]domain.PlatformResource)
 	for _, res := range actual {
 		if ctx.Err() != nil {
 			return ports.MatchingResult{}, ctx.Err()
 		}
-
-		meta := res.Metadata()
-		actualProcessed[meta.ProviderAssignedID] = false
-
-		attrs, err := res.Attributes(ctx)
-		if err != nil {
-			m.logger.Debugf(ctx, "Failed to get attributes for actual resource %s (%s): %v", meta.ProviderAssignedID, meta.Kind, err)
-			continue
-		}
-		tagsVal, ok := attrs[domain.KeyTags].(map[string]string)
-		if !ok {
-			m.logger.Debugf(ctx, "Actual resource %s (%s) missing tags attribute or not a map[string]string, cannot use for tag matching", meta.ProviderAssignedID, meta.Kind)
-			continue
-		}
-
-		identifierTagValue, found := tagsVal[m.config.TagKey]
-		if !found || identifierTagValue == "" {
-			m.logger.Debugf(ctx, "Actual resource %s (%s) does not have the configured tag key '%s' or its value is empty", meta.ProviderAssignedID, meta.Kind, m.config.TagKey)
-			continue
+		if identifierTagValue, found := m.actualIdentifier(ctx, res); found {
+			actualIndex[identifierTagValue] = append(actualIndex[identifierTagValue], res)
 		}
-
-		if existing, exists := actualIndex[identifierTagValue]; exists {
-			existingMeta := existing.Metadata()
-			m.logger.Errorf(ctx, nil, "Duplicate tag value '%s' found on actual resources: %s (%s) and %s (%s). Only one will be matched.",
-				identifierTagValue, meta.ProviderAssignedID, meta.Kind, existingMeta.ProviderAssignedID, existingMeta.Kind)
-			continue
-		}
-		actualIndex[identifierTagValue] = res
 	}
 
 	m.logger.Debugf(ctx, "Built index of %d actual resources based on tag '%s'", len(actualIndex), m.config.TagKey)
 
-	desiredProcessed := make(map[string]bool)
-
+	desiredIndex := make(map[string][]domain.StateResource)
+	order := make([]string, 0, len(desired))
 	for _, desRes := range desired {
 		if ctx.Err() != nil {
 			return ports.MatchingResult{}, ctx.Err()
 		}
 
 		desMeta := desRes.Metadata()
-		sourceID := desMeta.SourceIdentifier
+		sourceID := normalizeAddress(desMeta.SourceIdentifier)
 
 		if sourceID == "" {
 			m.logger.Warnf(ctx, "Desired resource of kind %s has empty SourceIdentifier, cannot match via tag", desMeta.Kind)
 			result.UnmatchedDesired = append(result.UnmatchedDesired, desRes)
 			continue
 		}
-
-		if _, processed := desiredProcessed[sourceID]; processed {
-			m.logger.Errorf(ctx, nil, "Duplicate desired resource identifier '%s' found. Skipping duplicate.", sourceID)
-			continue
-		}
-		desiredProcessed[sourceID] = true
-
-		actualRes, found := actualIndex[sourceID]
-		if !found {
-			result.UnmatchedDesired = append(result.UnmatchedDesired, desRes)
-			continue
+		if _, seen := desiredIndex[sourceID]; !seen {
+			order = append(order, sourceID)
 		}
-
-		actualMeta := actualRes.Metadata()
-		result.Matched = append(result.Matched, ports.MatchedPair{
-			Desired: desRes,
-			Actual:  actualRes,
-		})
-		actualProcessed[actualMeta.ProviderAssignedID] = true
-
-		m.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via tag '%s'", sourceID, actualMeta.ProviderAssignedID, m.config.TagKey)
+		desiredIndex[sourceID] = append(desiredIndex[sourceID], desRes)
 	}
 
-	for _, actRes := range actualIndex {
-		actMeta := actRes.Metadata()
-		if processed, exists := actualProcessed[actMeta.ProviderAssignedID]; exists && !processed {
-			result.UnmatchedActual = append(result.UnmatchedActual, actRes)
+	// Resources sharing an identifier with more than one resource on the other
+	// side are reported as ambiguous rather than paired arbitrarily.
+	accounted := make(map[domain.PlatformResource]bool)
+	for _, sourceID := range order {
+		desRes, actRes := desiredIndex[sourceID], actualIndex[sourceID]
+		switch {
+		case len(actRes) == 0:
+			if len(desRes) > 1 {
+				m.logger.Errorf(ctx, nil, "Duplicate desired resource identifier '%s' found. Skipping duplicate.", sourceID)
+			}
+			result.UnmatchedDesired = append(result.UnmatchedDesired, desRes[0])
+		case len(desRes) == 1 && len(actRes) == 1:
+			result.Matched = append(result.Matched, ports.MatchedPair{Desired: desRes[0], Actual: actRes[0]})
+			accounted[actRes[0]] = true
+			m.logger.Debugf(ctx, "Matched desired '%s' to actual '%s' via tag '%s'", sourceID, actRes[0].Metadata().ProviderAssignedID, m.config.TagKey)
+		default:
+			result.Ambiguous = append(result.Ambiguous, ports.AmbiguousMatch{Desired: desRes, Actual: actRes})
+			for _, res := range actRes {
+				accounted[res] = true
+			}
+			m.logger.Warnf(ctx, "Tag value '%s' matches %d desired and %d actual resources, reporting them as ambiguous", sourceID, len(desRes), len(actRes))
 		}
 	}
 
-	result.UnmatchedActual = make([]domain.PlatformResource, 0)
 	for _, actRes := range actual {
-		actMeta := actRes.Metadata()
-		if processed, exists := actualProcessed[actMeta.ProviderAssignedID]; !exists || !processed {
+		if !accounted[actRes] {
 			result.UnmatchedActual = append(result.UnmatchedActual, actRes)
 		}
 	}
 
-	m.logger.Debugf(ctx, "Tag matching finished: %d matched, %d missing, %d unmanaged", len(result.Matched), len(result.UnmatchedDesired), len(result.UnmatchedActual))
+	m.logger.Debugf(ctx, "Tag matching finished: %d matched, %d missing, %d unmanaged, %d ambiguous", len(result.Matched), len(result.UnmatchedDesired), len(result.UnmatchedActual), len(result.Ambiguous))
 	return result, nil
 }
+
+// actualIdentifier returns the value of the configured tag key on an actual
+// resource, which holds the source identifier of the desired resource it was
+// created from, with its instance keys normalized.
+func (m *Matcher) actualIdentifier(ctx context.Context, res domain.PlatformResource) (string, bool) {
+	meta := res.Metadata()
+	attrs, err := res.Attributes(ctx)
+	if err != nil {
+		m.logger.Debugf(ctx, "Failed to get attributes for actual resource %s (%s): %v", meta.ProviderAssignedID, meta.Kind, err)
+		return "", false
+	}
+	tagsVal, ok := attrs[domain.KeyTags].(map[string]string)
+	if !ok {
+		m.logger.Debugf(ctx, "Actual resource %s (%s) missing tags attribute or not a map[string]string, cannot use for tag matching", meta.ProviderAssignedID, meta.Kind)
+		return "", false
+	}
+
+	identifierTagValue, found := tagsVal[m.config.TagKey]
+	if !found || identifierTagValue == "" {
+		m.logger.Debugf(ctx, "Actual resource %s (%s) does not have the configured tag key '%s' or its value is empty", meta.ProviderAssignedID, meta.Kind, m.config.TagKey)
+		return "", false
+	}
+	return normalizeAddress(identifierTagValue), true
+}
@@ -0,0 +1,34 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMatcher_ReportsAmbiguousTagValues(t *testing.T) {
+	web := desiredResource("aws_instance.web")
+	api := desiredResource("aws_instance.api")
+	webA := actualResource("i-1", "aws_instance.web")
+	webB := actualResource("i-2", "aws_instance.web")
+	actualAPI := actualResource("i-3", "aws_instance.api")
+	untagged := actualResource("i-4", "")
+
+	result, err := newTestMatcher(t).Match(context.Background(),
+		[]domain.StateResource{web, api},
+		[]domain.PlatformResource{webA, untagged, actualAPI, webB})
+	require.NoError(t, err)
+
+	require.Len(t, result.Matched, 1)
+	assert.Same(t, api, result.Matched[0].Desired)
+	assert.Same(t, actualAPI, result.Matched[0].Actual)
+	require.Len(t, result.Ambiguous, 1)
+	assert.Equal(t, []domain.StateResource{web}, result.Ambiguous[0].Desired)
+	assert.Equal(t, []domain.PlatformResource{webA, webB}, result.Ambiguous[0].Actual)
+	assert.Empty(t, result.UnmatchedDesired)
+	assert.Equal(t, []domain.PlatformResource{untagged}, result.UnmatchedActual)
+}
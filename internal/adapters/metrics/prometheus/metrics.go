@@ -0,0 +1,222 @@
+// Package prometheus exposes the scan and cloud API metrics of daemon mode on
+// a /metrics endpoint in the Prometheus exposition format.
+package prometheus
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// DefaultPath is the path of the metrics endpoint when none is configured.
+	DefaultPath = "/metrics"
+
+	namespace       = "drift_analyser"
+	shutdownTimeout = 5 * time.Second
+)
+
+// Config enables the daemon mode metrics endpoint.
+type Config struct {
+	// Address is the listen address of the endpoint, e.g. ":9090".
+	Address string `yaml:"address" mapstructure:"address" validate:"required"`
+	// Path is the path of the endpoint. Empty uses DefaultPath.
+	Path string `yaml:"path" mapstructure:"path"`
+}
+
+// Metrics records scans and cloud API calls and serves them to Prometheus.
+// Gauges describe the latest scan of each kind; counters accumulate over the
+// lifetime of the process.
+type Metrics struct {
+	address string
+	path    string
+	logger  ports.Logger
+
+	registry      *prom.Registry
+	scans         *prom.CounterVec
+	scanDuration  prom.Histogram
+	lastScan      *prom.GaugeVec
+	resources     *prom.GaugeVec
+	findings      *prom.GaugeVec
+	apiCalls      *prom.CounterVec
+	apiRetries    *prom.CounterVec
+	apiThrottles  *prom.CounterVec
+	apiCallErrors *prom.CounterVec
+}
+
+// findingStatuses are the statuses reported by the findings gauge. Every one
+// of them is set for a scanned kind, zero included, so alerts see a drop back
+// to no drift.
+var findingStatuses = []domain.ComparisonStatus{
+	domain.StatusDrifted,
+	domain.StatusMissing,
+	domain.StatusUnmanaged,
+	domain.StatusError,
+	domain.StatusRecentlyDeleted,
+	domain.StatusDeadLettered,
+	domain.StatusUnapprovedImage,
+	domain.StatusPendingDeletion,
+	domain.StatusAmbiguous,
+}
+
+func NewMetrics(cfg Config, logger ports.Logger) (*Metrics, error) {
+	if cfg.Address == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "metrics server requires a listen address")
+	}
+	path := cfg.Path
+	if path == "" {
+		path = DefaultPath
+	}
+
+	m := &Metrics{
+		address:  cfg.Address,
+		path:     path,
+		logger:   logger,
+		registry: prom.NewRegistry(),
+		scans: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace, Name: "scans_total",
+			Help: "Scans run, by outcome (success or failure).",
+		}, []string{"outcome"}),
+		scanDuration: prom.NewHistogram(prom.HistogramOpts{
+			Namespace: namespace, Name: "scan_duration_seconds",
+			Help:    "Duration of scans.",
+			Buckets: prom.ExponentialBuckets(1, 2, 12),
+		}),
+		lastScan: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace, Name: "last_scan_timestamp_seconds",
+			Help: "Unix time the latest scan of a kind finished, by outcome.",
+		}, []string{"kind", "outcome"}),
+		resources: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace, Name: "resources_scanned",
+			Help: "Resources compared in the latest successful scan of a kind.",
+		}, []string{"kind"}),
+		findings: prom.NewGaugeVec(prom.GaugeOpts{
+			Namespace: namespace, Name: "findings",
+			Help: "Resources per status in the latest successful scan of a kind.",
+		}, []string{"kind", "status"}),
+		apiCalls: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace, Name: "api_calls_total",
+			Help: "Cloud API operations called, by service and operation.",
+		}, []string{"service", "operation"}),
+		apiRetries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace, Name: "api_retries_total",
+			Help: "Retried cloud API attempts, by service and operation.",
+		}, []string{"service", "operation"}),
+		apiThrottles: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace, Name: "api_throttles_total",
+			Help: "Throttled cloud API attempts, by service and operation.",
+		}, []string{"service", "operation"}),
+		apiCallErrors: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace, Name: "api_call_errors_total",
+			Help: "Cloud API operations that failed after their last attempt, by service and operation.",
+		}, []string{"service", "operation"}),
+	}
+	m.registry.MustRegister(
+		m.scans, m.scanDuration, m.lastScan, m.resources, m.findings,
+		m.apiCalls, m.apiRetries, m.apiThrottles, m.apiCallErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m, nil
+}
+
+// ObserveScan records a scan. Per-kind gauges are only replaced by successful
+// scans, so a failing scan does not report the drift of its kinds as gone.
+func (m *Metrics) ObserveScan(kinds []domain.ResourceKind, duration time.Duration, results []domain.ComparisonResult, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	m.scans.WithLabelValues(outcome).Inc()
+	m.scanDuration.Observe(duration.Seconds())
+	finished := float64(time.Now().Unix())
+	for _, kind := range kinds {
+		m.lastScan.WithLabelValues(string(kind), outcome).Set(finished)
+	}
+	if err != nil {
+		return
+	}
+
+	counts := make(map[domain.ResourceKind]map[domain.ComparisonStatus]int, len(kinds))
+	for _, kind := range kinds {
+		counts[kind] = make(map[domain.ComparisonStatus]int)
+	}
+	for _, res := range results {
+		if byStatus, ok := counts[res.ResourceKind]; ok {
+			byStatus[res.Status]++
+		}
+	}
+	for kind, byStatus := range counts {
+		total := 0
+		for _, n := range byStatus {
+			total += n
+		}
+		m.resources.WithLabelValues(string(kind)).Set(float64(total))
+		for _, status := range findingStatuses {
+			m.findings.WithLabelValues(string(kind), string(status)).Set(float64(byStatus[status]))
+		}
+	}
+}
+
+// ObserveAPICall records a cloud API operation.
+func (m *Metrics) ObserveAPICall(service, operation string, attempts, throttles int, failed bool) {
+	m.apiCalls.WithLabelValues(service, operation).Inc()
+	if attempts > 1 {
+		m.apiRetries.WithLabelValues(service, operation).Add(float64(attempts - 1))
+	}
+	if throttles > 0 {
+		m.apiThrottles.WithLabelValues(service, operation).Add(float64(throttles))
+	}
+	if failed {
+		m.apiCallErrors.WithLabelValues(service, operation).Inc()
+	}
+}
+
+// Handler returns the HTTP handler serving the metrics endpoint.
+func (m *Metrics) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(m.path, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	return mux
+}
+
+// ListenAndServe serves the metrics until the context is cancelled, then
+// shuts the server down gracefully.
+func (m *Metrics) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", m.address)
+	if err != nil {
+		return errors.NewUserFacing(errors.CodeMetricsServerError,
+			fmt.Sprintf("failed to listen on metrics address '%s': %v", m.address, err),
+			"Choose a free address for 'daemon.metrics.address'.")
+	}
+	srv := &http.Server{Handler: m.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(listener) }()
+	m.logger.Infof(ctx, "[Metrics] Serving %s on %s", m.path, listener.Addr())
+
+	select {
+	case err := <-serveErr:
+		return errors.Wrap(err, errors.CodeMetricsServerError, "metrics server stopped")
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, errors.CodeMetricsServerError, "failed to shut down metrics server")
+	}
+	if err := <-serveErr; err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, errors.CodeMetricsServerError, "metrics server stopped")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package prometheus
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMetrics_ObserveScan(t *testing.T) {
+	m, err := NewMetrics(Config{Address: ":0"}, mocks.NewLogger(t))
+	require.NoError(t, err)
+
+	kinds := []domain.ResourceKind{domain.KindComputeInstance, domain.KindStorageBucket}
+	m.ObserveScan(kinds, 3*time.Second, []domain.ComparisonResult{
+		{ResourceKind: domain.KindComputeInstance, Status: domain.StatusDrifted},
+		{ResourceKind: domain.KindComputeInstance, Status: domain.StatusNoDrift},
+		{ResourceKind: domain.KindStorageBucket, Status: domain.StatusUnmanaged},
+	}, nil)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `drift_analyser_scans_total{outcome="success"} 1`)
+	assert.Contains(t, body, `drift_analyser_resources_scanned{kind="ComputeInstance"} 2`)
+	assert.Contains(t, body, `drift_analyser_findings{kind="ComputeInstance",status="DRIFTED"} 1`)
+	assert.Contains(t, body, `drift_analyser_findings{kind="StorageBucket",status="DRIFTED"} 0`)
+	assert.Contains(t, body, `drift_analyser_findings{kind="StorageBucket",status="UNMANAGED"} 1`)
+	assert.Contains(t, body, `drift_analyser_scan_duration_seconds_count 1`)
+
+	m.ObserveScan(kinds, time.Second, nil, stderrors.New("boom"))
+
+	body = scrape(t, m)
+	assert.Contains(t, body, `drift_analyser_scans_total{outcome="failure"} 1`)
+	assert.Contains(t, body, `drift_analyser_findings{kind="ComputeInstance",status="DRIFTED"} 1`, "a failed scan keeps the previous findings")
+}
+
+func TestMetrics_ObserveAPICall(t *testing.T) {
+	m, err := NewMetrics(Config{Address: ":0", Path: "/custom"}, mocks.NewLogger(t))
+	require.NoError(t, err)
+
+	m.ObserveAPICall("EC2", "DescribeInstances", 1, 0, false)
+	m.ObserveAPICall("EC2", "DescribeInstances", 3, 2, true)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/custom", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `drift_analyser_api_calls_total{operation="DescribeInstances",service="EC2"} 2`)
+	assert.Contains(t, body, `drift_analyser_api_retries_total{operation="DescribeInstances",service="EC2"} 2`)
+	assert.Contains(t, body, `drift_analyser_api_throttles_total{operation="DescribeInstances",service="EC2"} 2`)
+	assert.Contains(t, body, `drift_analyser_api_call_errors_total{operation="DescribeInstances",service="EC2"} 1`)
+}
@@ -0,0 +1,254 @@
+// Package slack posts a summary of every run with drift to a Slack incoming
+// webhook: the number of resources per status and the most severely drifted
+// resources, with links to them.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	NotifierTypeSlack = "slack"
+
+	// DefaultTopN is how many drifted resources a message lists when the
+	// configuration does not say.
+	DefaultTopN = 10
+	// DefaultThreshold is how many findings a run needs for a message when the
+	// configuration does not say.
+	DefaultThreshold = 1
+
+	requestTimeout = 10 * time.Second
+)
+
+// defaultTemplate renders the message sent when no template is configured,
+// in Slack's mrkdwn.
+const defaultTemplate = `:warning: *Drift detected*: {{.Findings}} finding(s) in {{.Total}} resource(s)
+{{- with .Summary}}
+Drifted: {{.Drifted}} · Missing: {{.Missing}} · Unmanaged: {{.Unmanaged}} · Errors: {{.Errors}}
+{{- end}}
+{{- range .Top}}
+• {{link .}} ({{.Kind}}{{if .Severity}}, {{.Severity}}{{end}}): {{join ", " .Attributes}}
+{{- end}}
+{{- if gt .More 0}}
+…and {{.More}} more drifted resource(s)
+{{- end}}`
+
+// Config configures the Slack notifier.
+type Config struct {
+	// WebhookURL is the incoming webhook the messages are posted to.
+	WebhookURL string `yaml:"webhook_url" mapstructure:"webhook_url"`
+	// WebhookURLEnv names an environment variable holding the webhook URL, to
+	// keep it out of the configuration file. WebhookURL takes precedence.
+	WebhookURLEnv string `yaml:"webhook_url_env" mapstructure:"webhook_url_env"`
+	// TopN is how many drifted resources a message lists. Zero uses DefaultTopN.
+	TopN int `yaml:"top_n" mapstructure:"top_n" validate:"omitempty,min=1"`
+	// Threshold is how many findings (drifted, missing, unmanaged, unapproved
+	// image or failed resources) a run needs for a message to be sent. Zero
+	// uses DefaultThreshold.
+	Threshold int `yaml:"threshold" mapstructure:"threshold" validate:"omitempty,min=1"`
+	// Template is a Go text/template rendering the message text, executed
+	// with a Message. Empty uses a built-in summary.
+	Template string `yaml:"template" mapstructure:"template"`
+}
+
+// Message is the data the message template is executed with.
+type Message struct {
+	Summary Summary
+	// Total is the number of resources in the run.
+	Total int
+	// Findings is the number of results that count towards the threshold.
+	Findings int
+	// Top are the most severely drifted resources, most severe first.
+	Top []Resource
+	// More is the number of drifted resources left out of Top.
+	More int
+}
+
+// Summary counts the results by status.
+type Summary struct {
+	Drifted          int
+	Missing          int
+	Unmanaged        int
+	Errors           int
+	UnapprovedImages int
+}
+
+// Resource is a drifted resource listed in a message.
+type Resource struct {
+	Label    string
+	Kind     domain.ResourceKind
+	Severity domain.Severity
+	// Attributes are the names of the drifted attributes.
+	Attributes []string
+	// URL links to the resource in the platform console, when a link is known.
+	URL string
+}
+
+// Notifier posts run summaries to a Slack incoming webhook.
+type Notifier struct {
+	webhookURL string
+	topN       int
+	threshold  int
+	tmpl       *texttemplate.Template
+	client     *http.Client
+	logger     ports.Logger
+}
+
+func NewNotifier(cfg Config, logger ports.Logger) (*Notifier, error) {
+	webhookURL := cfg.WebhookURL
+	if webhookURL == "" && cfg.WebhookURLEnv != "" {
+		webhookURL = os.Getenv(cfg.WebhookURLEnv)
+	}
+	if webhookURL == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, "the Slack notifier requires a webhook URL",
+			"Set notifications.slack.webhook_url, or notifications.slack.webhook_url_env to an environment variable holding it.")
+	}
+
+	source := cfg.Template
+	if source == "" {
+		source = defaultTemplate
+	}
+	tmpl, err := texttemplate.New("slack").Option("missingkey=error").Funcs(texttemplate.FuncMap{
+		"link": link,
+		"join": func(sep string, list []string) string { return strings.Join(list, sep) },
+	}).Parse(source)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, "invalid Slack message template",
+			"Fix the syntax of notifications.slack.template; see the text/template package documentation.")
+	}
+
+	n := &Notifier{
+		webhookURL: webhookURL,
+		topN:       cfg.TopN,
+		threshold:  cfg.Threshold,
+		tmpl:       tmpl,
+		client:     &http.Client{Timeout: requestTimeout},
+		logger:     logger,
+	}
+	if n.topN <= 0 {
+		n.topN = DefaultTopN
+	}
+	if n.threshold <= 0 {
+		n.threshold = DefaultThreshold
+	}
+	return n, nil
+}
+
+func (n *Notifier) Name() string { return NotifierTypeSlack }
+
+func (n *Notifier) Notify(ctx context.Context, results []domain.ComparisonResult) error {
+	msg := newMessage(results, n.topN)
+	if msg.Findings < n.threshold {
+		n.logger.Debugf(ctx, "Skipping Slack notification: %d finding(s) below the threshold of %d", msg.Findings, n.threshold)
+		return nil
+	}
+
+	var text bytes.Buffer
+	if err := n.tmpl.Execute(&text, msg); err != nil {
+		return errors.Wrap(err, errors.CodeNotificationError, "failed to render Slack message template")
+	}
+	payload, err := json.Marshal(map[string]string{"text": text.String()})
+	if err != nil {
+		return errors.Wrap(err, errors.CodeNotificationError, "failed to encode Slack message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, errors.CodeNotificationError, "failed to create Slack webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeNotificationError, "failed to post Slack message")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.New(errors.CodeNotificationError,
+			fmt.Sprintf("Slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body))))
+	}
+	n.logger.Infof(ctx, "Posted Slack notification for %d finding(s)", msg.Findings)
+	return nil
+}
+
+// newMessage summarizes the results and picks the topN most severely drifted
+// resources: highest severity first, then most drifted attributes.
+func newMessage(results []domain.ComparisonResult, topN int) Message {
+	msg := Message{Total: len(results)}
+	var drifted []domain.ComparisonResult
+	for _, res := range results {
+		switch res.Status {
+		case domain.StatusDrifted:
+			msg.Summary.Drifted++
+			drifted = append(drifted, res)
+		case domain.StatusMissing:
+			msg.Summary.Missing++
+		case domain.StatusUnmanaged:
+			msg.Summary.Unmanaged++
+		case domain.StatusError, domain.StatusDeadLettered:
+			msg.Summary.Errors++
+		case domain.StatusUnapprovedImage:
+			msg.Summary.UnapprovedImages++
+		}
+	}
+	s := msg.Summary
+	msg.Findings = s.Drifted + s.Missing + s.Unmanaged + s.Errors + s.UnapprovedImages
+
+	sort.SliceStable(drifted, func(i, j int) bool {
+		ri, rj := drifted[i].MaxSeverity().Rank(), drifted[j].MaxSeverity().Rank()
+		if ri != rj {
+			return ri > rj
+		}
+		return len(drifted[i].Differences) > len(drifted[j].Differences)
+	})
+	if len(drifted) > topN {
+		msg.More = len(drifted) - topN
+		drifted = drifted[:topN]
+	}
+	for _, res := range drifted {
+		resource := Resource{Label: label(res), Kind: res.ResourceKind, Severity: res.MaxSeverity()}
+		for _, diff := range res.Differences {
+			resource.Attributes = append(resource.Attributes, diff.AttributeName)
+		}
+		if len(res.Links) > 0 {
+			resource.URL = res.Links[0].URL
+		}
+		msg.Top = append(msg.Top, resource)
+	}
+	return msg
+}
+
+func label(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
+
+// link renders a resource as a Slack link to its console page, or as code
+// when no link is known.
+func link(r Resource) string {
+	if r.URL == "" {
+		return "`" + r.Label + "`"
+	}
+	return "<" + r.URL + "|" + escape(r.Label) + ">"
+}
+
+// escape escapes the characters Slack's mrkdwn treats as control characters.
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func testResults() []domain.ComparisonResult {
+	return []domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", Severity: domain.SeverityWarning}},
+		},
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs",
+			Differences: []domain.AttributeDiff{
+				{AttributeName: "acl", Severity: domain.SeverityCritical},
+				{AttributeName: "tags", Severity: domain.SeverityInfo},
+			},
+			Links: []domain.ResourceLink{{Name: "console", URL: "https://console.example/logs"}},
+		},
+		{Status: domain.StatusMissing, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.archive"},
+		{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.db"},
+	}
+}
+
+func newTestLogger(t *testing.T) *mocks.Logger {
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	return logger
+}
+
+func TestNotifier_PostsSummary(t *testing.T) {
+	var posted map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+	}))
+	defer server.Close()
+
+	n, err := NewNotifier(Config{WebhookURL: server.URL, TopN: 1}, newTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+
+	assert.Equal(t, ":warning: *Drift detected*: 3 finding(s) in 4 resource(s)\n"+
+		"Drifted: 2 · Missing: 1 · Unmanaged: 0 · Errors: 0\n"+
+		"• <https://console.example/logs|aws_s3_bucket.logs> (StorageBucket, critical): acl, tags\n"+
+		"…and 1 more drifted resource(s)", posted["text"])
+}
+
+func TestNotifier_Threshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no message is expected below the threshold")
+	}))
+	defer server.Close()
+
+	n, err := NewNotifier(Config{WebhookURL: server.URL, Threshold: 4}, newTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+
+	n, err = NewNotifier(Config{WebhookURL: server.URL}, newTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), testResults()[3:]), "a run without drift sends nothing")
+}
+
+func TestNotifier_CustomTemplateAndErrors(t *testing.T) {
+	var posted map[string]string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_SLACK_WEBHOOK", server.URL)
+	n, err := NewNotifier(Config{WebhookURLEnv: "TEST_SLACK_WEBHOOK", Template: "{{.Summary.Drifted}} drifted{{range .Top}} {{link .}}{{end}}"}, newTestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+	assert.Equal(t, "2 drifted <https://console.example/logs|aws_s3_bucket.logs> `aws_instance.web`", posted["text"])
+
+	status = http.StatusBadRequest
+	err = n.Notify(context.Background(), testResults())
+	assert.ErrorContains(t, err, "invalid_payload")
+
+	_, err = NewNotifier(Config{}, newTestLogger(t))
+	assert.ErrorContains(t, err, "requires a webhook URL")
+}
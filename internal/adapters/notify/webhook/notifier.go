@@ -0,0 +1,322 @@
+// Package webhook posts the results of every run as JSON to an HTTP endpoint,
+// such as an internal event bus or a SOAR tool. Requests are optionally
+// signed with HMAC-SHA256 and retried on throttling and server errors.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	NotifierTypeWebhook = "webhook"
+
+	// ModeBatch posts the results of a run in batches of BatchSize results.
+	ModeBatch = "batch"
+	// ModeEvent posts one event per resource.
+	ModeEvent = "event"
+
+	// Headers set on every request. The delivery ID stays the same across
+	// retries of a request, so receivers can drop duplicates.
+	HeaderDelivery  = "X-Drift-Delivery"
+	HeaderTimestamp = "X-Drift-Timestamp"
+	HeaderSignature = "X-Drift-Signature"
+
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = time.Second
+	defaultTimeout     = 10 * time.Second
+	maxRetryDelay      = 30 * time.Second
+)
+
+// Config configures the webhook notifier.
+type Config struct {
+	URL string `yaml:"url" mapstructure:"url" validate:"required,url"`
+	// Headers are added to every request, for example an authorization header.
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Secret signs every request with HMAC-SHA256. SecretEnv names an
+	// environment variable holding it instead; Secret takes precedence.
+	Secret    string `yaml:"secret" mapstructure:"secret"`
+	SecretEnv string `yaml:"secret_env" mapstructure:"secret_env"`
+	// Mode is ModeBatch (the default) or ModeEvent.
+	Mode string `yaml:"mode" mapstructure:"mode" validate:"omitempty,oneof=batch event"`
+	// BatchSize bounds the results per request in batch mode. Zero sends all
+	// results in one request.
+	BatchSize int `yaml:"batch_size" mapstructure:"batch_size" validate:"omitempty,min=1"`
+	// FindingsOnly leaves out resources without drift.
+	FindingsOnly bool `yaml:"findings_only" mapstructure:"findings_only"`
+	// MaxAttempts bounds the attempts of each request, including the first.
+	MaxAttempts int `yaml:"max_attempts" mapstructure:"max_attempts" validate:"omitempty,min=1"`
+	// BaseDelay is the delay before the first retry. It doubles with each retry.
+	BaseDelay time.Duration `yaml:"base_delay" mapstructure:"base_delay" validate:"omitempty,min=0"`
+	// Timeout bounds each attempt.
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout" validate:"omitempty,min=0"`
+}
+
+// BatchPayload is the body of a request in batch mode.
+type BatchPayload struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// Batch numbers the request among the requests of the run, from 1.
+	Batch   int      `json:"batch"`
+	Batches int      `json:"batches"`
+	Summary Summary  `json:"summary"`
+	Results []Result `json:"results"`
+}
+
+// EventPayload is the body of a request in event mode.
+type EventPayload struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// Type is "resource." followed by the lower-cased status, for example
+	// "resource.drifted".
+	Type   string `json:"type"`
+	Result Result `json:"result"`
+}
+
+// Summary counts the results of the whole run by status.
+type Summary struct {
+	Total    int                             `json:"total"`
+	ByStatus map[domain.ComparisonStatus]int `json:"by_status"`
+}
+
+// Result is the JSON form of a comparison result.
+type Result struct {
+	Status             domain.ComparisonStatus `json:"status"`
+	ResourceKind       domain.ResourceKind     `json:"resource_kind"`
+	SourceIdentifier   string                  `json:"source_identifier,omitempty"`
+	ProviderType       string                  `json:"provider_type,omitempty"`
+	ProviderAssignedID string                  `json:"provider_assigned_id,omitempty"`
+	Severity           domain.Severity         `json:"severity,omitempty"`
+	Differences        []Difference            `json:"differences,omitempty"`
+	ErrorMessage       string                  `json:"error_message,omitempty"`
+	Links              map[string]string       `json:"links,omitempty"`
+}
+
+type Difference struct {
+	AttributeName string          `json:"attribute_name"`
+	ExpectedValue any             `json:"expected_value"`
+	ActualValue   any             `json:"actual_value"`
+	Severity      domain.Severity `json:"severity,omitempty"`
+}
+
+// Notifier posts run results to an HTTP endpoint.
+type Notifier struct {
+	cfg    Config
+	secret []byte
+	client *http.Client
+	logger ports.Logger
+	now    func() time.Time
+}
+
+func NewNotifier(cfg Config, logger ports.Logger) (*Notifier, error) {
+	if cfg.URL == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, "the webhook notifier requires a URL",
+			"Set notifications.webhook.url.")
+	}
+	secret := cfg.Secret
+	if secret == "" && cfg.SecretEnv != "" {
+		secret = os.Getenv(cfg.SecretEnv)
+		if secret == "" {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("environment variable %s holding the webhook secret is not set", cfg.SecretEnv),
+				"Export the secret, or remove notifications.webhook.secret_env to send unsigned requests.")
+		}
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ModeBatch
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = defaultBaseDelay
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Notifier{
+		cfg:    cfg,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+func (n *Notifier) Name() string { return NotifierTypeWebhook }
+
+func (n *Notifier) Notify(ctx context.Context, results []domain.ComparisonResult) error {
+	generatedAt := n.now().UTC()
+	selected := make([]Result, 0, len(results))
+	summary := Summary{Total: len(results), ByStatus: make(map[domain.ComparisonStatus]int)}
+	for _, res := range results {
+		summary.ByStatus[res.Status]++
+		if n.cfg.FindingsOnly && res.Status == domain.StatusNoDrift {
+			continue
+		}
+		selected = append(selected, toResult(res))
+	}
+
+	var payloads []any
+	switch n.cfg.Mode {
+	case ModeEvent:
+		for _, res := range selected {
+			payloads = append(payloads, EventPayload{
+				GeneratedAt: generatedAt,
+				Type:        "resource." + strings.ToLower(string(res.Status)),
+				Result:      res,
+			})
+		}
+	default:
+		size := n.cfg.BatchSize
+		if size <= 0 || size > len(selected) {
+			size = max(len(selected), 1)
+		}
+		batches := max((len(selected)+size-1)/size, 1)
+		for i := 0; i < batches; i++ {
+			end := min((i+1)*size, len(selected))
+			payloads = append(payloads, BatchPayload{
+				GeneratedAt: generatedAt,
+				Batch:       i + 1,
+				Batches:     batches,
+				Summary:     summary,
+				Results:     selected[i*size : end],
+			})
+		}
+	}
+
+	for i, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeNotificationError, "failed to encode webhook payload")
+		}
+		if err := n.post(ctx, body); err != nil {
+			return errors.Wrap(err, errors.CodeNotificationError,
+				fmt.Sprintf("failed to deliver webhook request %d of %d", i+1, len(payloads)))
+		}
+	}
+	n.logger.Infof(ctx, "Delivered %d result(s) to webhook in %d request(s)", len(selected), len(payloads))
+	return nil
+}
+
+// post delivers one request body, retrying network errors, throttling and
+// server errors with jittered exponential backoff.
+func (n *Notifier) post(ctx context.Context, body []byte) error {
+	delivery, err := newDeliveryID()
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		retryable, err := n.send(ctx, delivery, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= n.cfg.MaxAttempts {
+			return err
+		}
+		delay := n.cfg.BaseDelay << (attempt - 1)
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		delay = delay/2 + mathrand.N(delay/2+1)
+		n.logger.Debugf(ctx, "Retrying webhook delivery %s in %s after attempt %d failed: %v", delivery, delay, attempt, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, delivery string, body []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for name, value := range n.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	timestamp := strconv.FormatInt(n.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, delivery)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if len(n.secret) > 0 {
+		req.Header.Set(HeaderSignature, Sign(n.secret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	text, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	retryable = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retryable, fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(text)))
+}
+
+// Sign returns the signature header value of a request: "sha256=" followed
+// by the hex-encoded HMAC-SHA256 of the timestamp header, a dot and the body.
+// Receivers recompute it to authenticate the request, and reject old
+// timestamps to prevent replays.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDeliveryID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+func toResult(res domain.ComparisonResult) Result {
+	out := Result{
+		Status:             res.Status,
+		ResourceKind:       res.ResourceKind,
+		SourceIdentifier:   res.SourceIdentifier,
+		ProviderType:       res.ProviderType,
+		ProviderAssignedID: res.ProviderAssignedID,
+		Severity:           res.MaxSeverity(),
+	}
+	for _, diff := range res.Differences {
+		out.Differences = append(out.Differences, Difference{
+			AttributeName: diff.AttributeName,
+			ExpectedValue: diff.ExpectedValue,
+			ActualValue:   diff.ActualValue,
+			Severity:      diff.Severity,
+		})
+	}
+	if res.Error != nil {
+		out.ErrorMessage = res.Error.Error()
+	}
+	if len(res.Links) > 0 {
+		out.Links = make(map[string]string, len(res.Links))
+		for _, link := range res.Links {
+			out.Links[link.Name] = link.URL
+		}
+	}
+	return out
+}
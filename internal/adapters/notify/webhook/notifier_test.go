@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func testResults() []domain.ComparisonResult {
+	return []domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", ExpectedValue: "t3.micro", ActualValue: "t3.large", Severity: domain.SeverityWarning}},
+			Links:       []domain.ResourceLink{{Name: "console", URL: "https://console.example/web"}},
+		},
+		{Status: domain.StatusMissing, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.archive"},
+		{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.db"},
+	}
+}
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// recorder is a webhook endpoint answering with the given status codes in
+// turn, then with 200.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []request
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request{header: req.Header.Clone(), body: body})
+	if len(r.statuses) > 0 {
+		w.WriteHeader(r.statuses[0])
+		r.statuses = r.statuses[1:]
+	}
+}
+
+func newTestNotifier(t *testing.T, cfg Config) *Notifier {
+	logger := mocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	logger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	n, err := NewNotifier(cfg, logger)
+	require.NoError(t, err)
+	n.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return n
+}
+
+func TestNotifier_BatchesAndSigns(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, Config{URL: server.URL, Secret: "s3cret", BatchSize: 2, Headers: map[string]string{"Authorization": "Bearer token"}})
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+
+	require.Len(t, rec.requests, 2)
+	var first, second BatchPayload
+	require.NoError(t, json.Unmarshal(rec.requests[0].body, &first))
+	require.NoError(t, json.Unmarshal(rec.requests[1].body, &second))
+	assert.Equal(t, 1, first.Batch)
+	assert.Equal(t, 2, first.Batches)
+	assert.Equal(t, 3, first.Summary.Total)
+	assert.Equal(t, 1, first.Summary.ByStatus[domain.StatusDrifted])
+	require.Len(t, first.Results, 2)
+	assert.Equal(t, "aws_instance.web", first.Results[0].SourceIdentifier)
+	assert.Equal(t, domain.SeverityWarning, first.Results[0].Severity)
+	assert.Equal(t, "https://console.example/web", first.Results[0].Links["console"])
+	require.Len(t, second.Results, 1)
+
+	header := rec.requests[0].header
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "1700000000", header.Get(HeaderTimestamp))
+	assert.Equal(t, Sign([]byte("s3cret"), "1700000000", rec.requests[0].body), header.Get(HeaderSignature))
+	assert.NotEqual(t, header.Get(HeaderDelivery), rec.requests[1].header.Get(HeaderDelivery))
+}
+
+func TestNotifier_EventsFindingsOnly(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, Config{URL: server.URL, Mode: ModeEvent, FindingsOnly: true})
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+
+	require.Len(t, rec.requests, 2)
+	var event EventPayload
+	require.NoError(t, json.Unmarshal(rec.requests[1].body, &event))
+	assert.Equal(t, "resource.missing", event.Type)
+	assert.Equal(t, "aws_s3_bucket.archive", event.Result.SourceIdentifier)
+	assert.Empty(t, rec.requests[1].header.Get(HeaderSignature), "requests are unsigned without a secret")
+}
+
+func TestNotifier_Retries(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	n := newTestNotifier(t, Config{URL: server.URL, BaseDelay: time.Millisecond})
+	require.NoError(t, n.Notify(context.Background(), testResults()))
+	require.Len(t, rec.requests, 3)
+	assert.Equal(t, rec.requests[0].header.Get(HeaderDelivery), rec.requests[2].header.Get(HeaderDelivery),
+		"retries keep the delivery ID")
+
+	rec.requests = nil
+	rec.statuses = []int{http.StatusBadRequest}
+	assert.Error(t, n.Notify(context.Background(), testResults()))
+	assert.Len(t, rec.requests, 1, "client errors are not retried")
+
+	rec.requests = nil
+	rec.statuses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+	assert.ErrorContains(t, n.Notify(context.Background(), testResults()), "502")
+	assert.Len(t, rec.requests, defaultMaxAttempts)
+}
@@ -0,0 +1,255 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+)
+
+// AutoScalingGroupHandler lists and fetches EC2 Auto Scaling groups. Instances
+// of a group are not part of the comparison.
+type AutoScalingGroupHandler struct {
+	stsClient         shared.STSClientInterface
+	accountID         string
+	accMu             sync.RWMutex
+	autoScalingClient AutoScalingClientInterface
+	limiter           shared.RateLimiter
+	errorHandler      shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the AutoScalingGroupHandler.
+type HandlerOption func(*AutoScalingGroupHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *AutoScalingGroupHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithAutoScalingClient provides an option to set a custom Auto Scaling client.
+func WithAutoScalingClient(client AutoScalingClientInterface) HandlerOption {
+	return func(h *AutoScalingGroupHandler) {
+		if client != nil {
+			h.autoScalingClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *AutoScalingGroupHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *AutoScalingGroupHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new AutoScalingGroupHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *AutoScalingGroupHandler {
+	h := &AutoScalingGroupHandler{
+		stsClient:         sts.NewFromConfig(cfg),
+		autoScalingClient: autoscaling.NewFromConfig(cfg),
+		limiter:           &aws_limiter.DefaultRateLimiter{},
+		errorHandler:      &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *AutoScalingGroupHandler) Kind() domain.ResourceKind {
+	return domain.KindAutoScalingGroup
+}
+
+func (h *AutoScalingGroupHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "AutoScaling: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+// ListResources lists the Auto Scaling groups of the region. Tag filters are
+// passed to DescribeAutoScalingGroups, while the ID and name filters are
+// applied to the described groups.
+func (h *AutoScalingGroupHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for AutoScaling ListResources: %v", accErr)
+	}
+
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		MaxRecords: aws.Int32(listPageSize),
+		Filters:    tagFiltersFrom(filters),
+	}
+
+	logger.Debugf(ctx, "Starting Auto Scaling group listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.autoScalingClient.DescribeAutoScalingGroups(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("AutoScaling", fmt.Sprintf("DescribeAutoScalingGroups:Page%d", pageNum), err, ctx)
+		}
+
+		for _, group := range output.AutoScalingGroups {
+			name := aws.ToString(group.AutoScalingGroupName)
+			if value, ok := filters[domain.KeyID]; ok && !containsValue(value, name) {
+				continue
+			}
+			if value, ok := filters[domain.KeyName]; ok && !containsValue(value, name) {
+				continue
+			}
+			resource, mapErr := newAutoScalingGroupResource(group, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Auto Scaling group %s, skipping", name)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending Auto Scaling group %s", name)
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	logger.Debugf(ctx, "Finished Auto Scaling group pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *AutoScalingGroupHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single Auto Scaling group %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.autoScalingClient.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []string{id}})
+	if err != nil {
+		return nil, h.errorHandler.Handle("AutoScaling", "DescribeAutoScalingGroups", err, ctx)
+	}
+	if len(output.AutoScalingGroups) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("Auto Scaling group '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for AutoScaling GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newAutoScalingGroupResource(output.AutoScalingGroups[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for Auto Scaling group %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that Auto Scaling groups can be described with a single minimal page.
+func (h *AutoScalingGroupHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.autoScalingClient.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{MaxRecords: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("AutoScaling", "DescribeAutoScalingGroups", err, ctx)
+	}
+	return nil
+}
+
+// tagFiltersFrom turns "tag:<key>" filters into DescribeAutoScalingGroups
+// filters. Comma separated values match any of the values.
+func tagFiltersFrom(genericFilters map[string]string) []autoscalingtypes.Filter {
+	var filters []autoscalingtypes.Filter
+	for key, value := range genericFilters {
+		if !strings.HasPrefix(key, domain.TagPrefix) {
+			continue
+		}
+		var values []string
+		for _, candidate := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(candidate))
+		}
+		filters = append(filters, autoscalingtypes.Filter{Name: aws.String(key), Values: values})
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		return aws.ToString(filters[i].Name) < aws.ToString(filters[j].Name)
+	})
+	return filters
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
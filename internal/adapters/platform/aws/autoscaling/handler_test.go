@@ -0,0 +1,164 @@
+package autoscaling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	autoscalingmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/autoscaling/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type AutoScalingHandlerTestSuite struct {
+	suite.Suite
+	mockAutoScaling  *autoscalingmocks.AutoScalingClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *AutoScalingGroupHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *AutoScalingHandlerTestSuite) SetupTest() {
+	s.mockAutoScaling = new(autoscalingmocks.AutoScalingClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithAutoScalingClient(s.mockAutoScaling),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *AutoScalingHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestAutoScalingHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AutoScalingHandlerTestSuite))
+}
+
+func group(name string) autoscalingtypes.AutoScalingGroup {
+	return autoscalingtypes.AutoScalingGroup{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int32(1),
+		MaxSize:              aws.Int32(3),
+		DesiredCapacity:      aws.Int32(2),
+	}
+}
+
+func (s *AutoScalingHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *AutoScalingHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindAutoScalingGroup, s.handler.Kind())
+}
+
+func (s *AutoScalingHandlerTestSuite) TestListResources_PaginatesAndFilters() {
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, mock.MatchedBy(func(in *autoscaling.DescribeAutoScalingGroupsInput) bool {
+		return in.NextToken == nil && len(in.Filters) == 1 &&
+			aws.ToString(in.Filters[0].Name) == "tag:Env" && len(in.Filters[0].Values) == 2 && in.Filters[0].Values[1] == "staging"
+	})).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+		AutoScalingGroups: []autoscalingtypes.AutoScalingGroup{group("web"), group("batch")},
+		NextToken:         aws.String("page2"),
+	}, nil).Once()
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, mock.MatchedBy(func(in *autoscaling.DescribeAutoScalingGroupsInput) bool {
+		return aws.ToString(in.NextToken) == "page2"
+	})).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+		AutoScalingGroups: []autoscalingtypes.AutoScalingGroup{group("api")},
+	}, nil).Once()
+
+	resources, err := s.collect(map[string]string{
+		domain.KeyID: "web, api",
+		"tag:Env":    "prod, staging",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("web", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("api", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	s.Equal("us-east-1", resources[0].Metadata().Region)
+	s.mockAutoScaling.AssertExpectations(s.T())
+}
+
+func (s *AutoScalingHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "AutoScaling", "DescribeAutoScalingGroups:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *AutoScalingHandlerTestSuite) TestGetResource_Success() {
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []string{"web"}}).
+		Return(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: []autoscalingtypes.AutoScalingGroup{group("web")}}, nil).Once()
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "web", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("web", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(2), attrs[domain.AutoScalingGroupDesiredCapacityKey])
+	s.mockAutoScaling.AssertExpectations(s.T())
+}
+
+func (s *AutoScalingHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, mock.Anything).
+		Return(&autoscaling.DescribeAutoScalingGroupsOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *AutoScalingHandlerTestSuite) TestProbe() {
+	s.mockAutoScaling.On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{MaxRecords: aws.Int32(probePageSize)}).
+		Return(&autoscaling.DescribeAutoScalingGroupsOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockAutoScaling.AssertExpectations(s.T())
+}
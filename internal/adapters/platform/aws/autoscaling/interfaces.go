@@ -0,0 +1,19 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+//go:generate mockery --name AutoScalingClientInterface --output ./mocks --outpkg mocks --case underscore
+
+// AutoScalingClientInterface defines the methods needed from the AWS SDK Auto
+// Scaling client. DescribeAutoScalingGroups returns the groups with their tags,
+// so no further calls are needed per group.
+type AutoScalingClientInterface interface {
+	DescribeAutoScalingGroups(ctx context.Context, params *autoscaling.DescribeAutoScalingGroupsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DescribeAutoScalingGroupsOutput, error)
+}
+
+type AutoScalingGroup = autoscalingtypes.AutoScalingGroup // Alias autoscalingtypes.AutoScalingGroup for easier use
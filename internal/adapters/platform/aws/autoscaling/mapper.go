@@ -0,0 +1,131 @@
+package autoscaling
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// defaultTerminationPolicy is what AWS reports when no termination policies
+// are configured.
+const defaultTerminationPolicy = "Default"
+
+// autoScalingGroupResource wraps an Auto Scaling group whose attributes are
+// mapped once when the resource is built.
+type autoScalingGroupResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *autoScalingGroupResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *autoScalingGroupResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newAutoScalingGroupResource(group AutoScalingGroup, region, accountID string) (domain.PlatformResource, error) {
+	name := aws.ToString(group.AutoScalingGroupName)
+	if name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create Auto Scaling group resource: missing name")
+	}
+	return &autoScalingGroupResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindAutoScalingGroup,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: name,
+			SourceIdentifier:   name,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapAutoScalingGroupToAttributes(group),
+	}, nil
+}
+
+// mapAutoScalingGroupToAttributes maps an Auto Scaling group. Subnets and
+// target groups are sorted, tags are split into the tag map and the sorted
+// keys of the tags propagated at launch.
+func mapAutoScalingGroupToAttributes(group AutoScalingGroup) map[string]any {
+	name := aws.ToString(group.AutoScalingGroupName)
+	attrs := map[string]any{
+		domain.KeyID:                                name,
+		domain.KeyName:                              name,
+		domain.KeyARN:                               aws.ToString(group.AutoScalingGroupARN),
+		domain.AutoScalingGroupMinSizeKey:           int64(aws.ToInt32(group.MinSize)),
+		domain.AutoScalingGroupMaxSizeKey:           int64(aws.ToInt32(group.MaxSize)),
+		domain.AutoScalingGroupDesiredCapacityKey:   int64(aws.ToInt32(group.DesiredCapacity)),
+		domain.AutoScalingGroupDefaultCooldownKey:   int64(aws.ToInt32(group.DefaultCooldown)),
+		domain.AutoScalingGroupHealthCheckGraceKey:  int64(aws.ToInt32(group.HealthCheckGracePeriod)),
+		domain.AutoScalingGroupCapacityRebalanceKey: aws.ToBool(group.CapacityRebalance),
+	}
+	setIfNotEmpty(attrs, domain.AutoScalingGroupHealthCheckTypeKey, aws.ToString(group.HealthCheckType))
+	setIfNotEmpty(attrs, domain.AutoScalingGroupLaunchConfigurationKey, aws.ToString(group.LaunchConfigurationName))
+	if lifetime := aws.ToInt32(group.MaxInstanceLifetime); lifetime > 0 {
+		attrs[domain.AutoScalingGroupMaxInstanceLifetimeKey] = int64(lifetime)
+	}
+
+	if template := group.LaunchTemplate; template != nil {
+		launchTemplate := map[string]any{}
+		setIfNotEmpty(launchTemplate, "id", aws.ToString(template.LaunchTemplateId))
+		setIfNotEmpty(launchTemplate, "name", aws.ToString(template.LaunchTemplateName))
+		setIfNotEmpty(launchTemplate, "version", aws.ToString(template.Version))
+		attrs[domain.AutoScalingGroupLaunchTemplateKey] = launchTemplate
+	}
+
+	var subnets []string
+	for _, subnet := range strings.Split(aws.ToString(group.VPCZoneIdentifier), ",") {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			subnets = append(subnets, subnet)
+		}
+	}
+	if len(subnets) > 0 {
+		sort.Strings(subnets)
+		attrs[domain.AutoScalingGroupSubnetsKey] = subnets
+	}
+	if len(group.TargetGroupARNs) > 0 {
+		targetGroups := append([]string(nil), group.TargetGroupARNs...)
+		sort.Strings(targetGroups)
+		attrs[domain.AutoScalingGroupTargetGroupARNsKey] = targetGroups
+	}
+	if policies := group.TerminationPolicies; len(policies) > 0 && !(len(policies) == 1 && policies[0] == defaultTerminationPolicy) {
+		attrs[domain.AutoScalingGroupTerminationPoliciesKey] = append([]string(nil), policies...)
+	}
+
+	tags := make(map[string]string, len(group.Tags))
+	var propagated []string
+	for _, tag := range group.Tags {
+		key := aws.ToString(tag.Key)
+		if key == "" {
+			continue
+		}
+		tags[key] = aws.ToString(tag.Value)
+		if aws.ToBool(tag.PropagateAtLaunch) {
+			propagated = append(propagated, key)
+		}
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	if len(propagated) > 0 {
+		sort.Strings(propagated)
+		attrs[domain.AutoScalingGroupPropagatedTagsKey] = propagated
+	}
+	return attrs
+}
+
+func setIfNotEmpty(attrs map[string]any, key, value string) {
+	if value != "" {
+		attrs[key] = value
+	}
+}
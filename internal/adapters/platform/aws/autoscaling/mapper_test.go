@@ -0,0 +1,53 @@
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapAutoScalingGroupToAttributes(t *testing.T) {
+	group := autoscalingtypes.AutoScalingGroup{
+		AutoScalingGroupName:   aws.String("web"),
+		AutoScalingGroupARN:    aws.String("arn:aws:autoscaling:us-east-1:123456789012:autoScalingGroup:uuid:autoScalingGroupName/web"),
+		MinSize:                aws.Int32(2),
+		MaxSize:                aws.Int32(10),
+		DesiredCapacity:        aws.Int32(4),
+		DefaultCooldown:        aws.Int32(300),
+		HealthCheckType:        aws.String("ELB"),
+		HealthCheckGracePeriod: aws.Int32(120),
+		LaunchTemplate: &autoscalingtypes.LaunchTemplateSpecification{
+			LaunchTemplateId:   aws.String("lt-0123456789abcdef0"),
+			LaunchTemplateName: aws.String("web"),
+			Version:            aws.String("$Latest"),
+		},
+		VPCZoneIdentifier:   aws.String("subnet-b,subnet-a"),
+		TargetGroupARNs:     []string{"arn:tg/b", "arn:tg/a"},
+		TerminationPolicies: []string{"Default"},
+		Tags: []autoscalingtypes.TagDescription{
+			{Key: aws.String("Name"), Value: aws.String("web"), PropagateAtLaunch: aws.Bool(true)},
+			{Key: aws.String("Env"), Value: aws.String("prod"), PropagateAtLaunch: aws.Bool(true)},
+			{Key: aws.String("Team"), Value: aws.String("platform"), PropagateAtLaunch: aws.Bool(false)},
+		},
+	}
+
+	attrs := mapAutoScalingGroupToAttributes(group)
+
+	assert.Equal(t, "web", attrs[domain.KeyID])
+	assert.Equal(t, int64(2), attrs[domain.AutoScalingGroupMinSizeKey])
+	assert.Equal(t, int64(10), attrs[domain.AutoScalingGroupMaxSizeKey])
+	assert.Equal(t, int64(4), attrs[domain.AutoScalingGroupDesiredCapacityKey])
+	assert.Equal(t, "ELB", attrs[domain.AutoScalingGroupHealthCheckTypeKey])
+	assert.Equal(t, int64(120), attrs[domain.AutoScalingGroupHealthCheckGraceKey])
+	assert.Equal(t, map[string]any{"id": "lt-0123456789abcdef0", "name": "web", "version": "$Latest"}, attrs[domain.AutoScalingGroupLaunchTemplateKey])
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, attrs[domain.AutoScalingGroupSubnetsKey])
+	assert.Equal(t, []string{"arn:tg/a", "arn:tg/b"}, attrs[domain.AutoScalingGroupTargetGroupARNsKey])
+	assert.NotContains(t, attrs, domain.AutoScalingGroupTerminationPoliciesKey, "the implicit Default policy is left out")
+	assert.NotContains(t, attrs, domain.AutoScalingGroupLaunchConfigurationKey)
+	assert.Equal(t, map[string]string{"Name": "web", "Env": "prod", "Team": "platform"}, attrs[domain.KeyTags])
+	assert.Equal(t, []string{"Env", "Name"}, attrs[domain.AutoScalingGroupPropagatedTagsKey])
+}
@@ -0,0 +1,23 @@
+package awsfake
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Config returns an AWS config whose STS, EC2 and S3 clients send every request
+// to the server. Credentials are static and retries are disabled so injected
+// faults surface on the first attempt; set Retryer to test retry behaviour.
+// S3 clients address buckets path-style because the endpoint is an IP address.
+func (s *Server) Config() aws.Config {
+	return aws.Config{
+		Region:       s.region,
+		BaseEndpoint: aws.String(s.srv.URL),
+		HTTPClient:   s.srv.Client(),
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDAWSFAKE", SecretAccessKey: "awsfake", Source: "awsfake"}, nil
+		}),
+		Retryer: func() aws.Retryer { return aws.NopRetryer{} },
+	}
+}
@@ -0,0 +1,1009 @@
+package awsfake
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ec2Namespace = "http://ec2.amazonaws.com/doc/2016-11-15/"
+
+	// rootDeviceName is the device of an instance's first volume.
+	rootDeviceName = "/dev/xvda"
+)
+
+var instanceStateCodes = map[string]int{
+	"pending":       0,
+	"running":       16,
+	"shutting-down": 32,
+	"terminated":    48,
+	"stopping":      64,
+	"stopped":       80,
+}
+
+type tagXML struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+func tagsXML(tags map[string]string) []tagXML {
+	out := make([]tagXML, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		out = append(out, tagXML{Key: k, Value: tags[k]})
+	}
+	return out
+}
+
+type describeInstancesResponse struct {
+	XMLName      xml.Name         `xml:"DescribeInstancesResponse"`
+	Xmlns        string           `xml:"xmlns,attr"`
+	RequestID    string           `xml:"requestId"`
+	Reservations []reservationXML `xml:"reservationSet>item"`
+	NextToken    string           `xml:"nextToken,omitempty"`
+}
+
+type reservationXML struct {
+	ReservationID string        `xml:"reservationId"`
+	OwnerID       string        `xml:"ownerId"`
+	Instances     []instanceXML `xml:"instancesSet>item"`
+}
+
+type instanceXML struct {
+	InstanceID       string           `xml:"instanceId"`
+	ImageID          string           `xml:"imageId,omitempty"`
+	StateCode        int              `xml:"instanceState>code"`
+	StateName        string           `xml:"instanceState>name"`
+	InstanceType     string           `xml:"instanceType,omitempty"`
+	KeyName          string           `xml:"keyName,omitempty"`
+	LaunchTime       string           `xml:"launchTime,omitempty"`
+	AvailabilityZone string           `xml:"placement>availabilityZone,omitempty"`
+	PlacementGroup   string           `xml:"placement>groupName,omitempty"`
+	Tenancy          string           `xml:"placement>tenancy"`
+	EBSOptimized     bool             `xml:"ebsOptimized"`
+	SubnetID         string           `xml:"subnetId,omitempty"`
+	VpcID            string           `xml:"vpcId,omitempty"`
+	PrivateIP        string           `xml:"privateIpAddress,omitempty"`
+	IAMProfileARN    string           `xml:"iamInstanceProfile>arn,omitempty"`
+	Groups           []groupRefXML    `xml:"groupSet>item"`
+	RootDeviceType   string           `xml:"rootDeviceType,omitempty"`
+	RootDeviceName   string           `xml:"rootDeviceName,omitempty"`
+	BlockDevices     []blockDeviceXML `xml:"blockDeviceMapping>item"`
+	Tags             []tagXML         `xml:"tagSet>item"`
+	HTTPTokens       string           `xml:"metadataOptions>httpTokens"`
+	HTTPEndpoint     string           `xml:"metadataOptions>httpEndpoint"`
+	HTTPHopLimit     int32            `xml:"metadataOptions>httpPutResponseHopLimit"`
+	MonitoringState  string           `xml:"monitoring>state"`
+}
+
+type groupRefXML struct {
+	GroupID   string `xml:"groupId"`
+	GroupName string `xml:"groupName,omitempty"`
+}
+
+type blockDeviceXML struct {
+	DeviceName          string `xml:"deviceName"`
+	VolumeID            string `xml:"ebs>volumeId"`
+	Status              string `xml:"ebs>status"`
+	AttachTime          string `xml:"ebs>attachTime,omitempty"`
+	DeleteOnTermination bool   `xml:"ebs>deleteOnTermination"`
+}
+
+func (s *Server) describeInstances(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.instances
+	if ids := listParam(form, "InstanceId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			inst, ok := s.instance(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidInstanceID.NotFound", Message: fmt.Sprintf("The instance ID '%s' does not exist", id)})
+				return
+			}
+			candidates = append(candidates, inst)
+		}
+	}
+
+	filters := filterParams(form)
+	var matched []Instance
+	for _, inst := range candidates {
+		ok, unsupported := matchFilters(filters, instanceFilterFields(inst), inst.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if ok {
+			matched = append(matched, inst)
+		}
+	}
+
+	start, end, next, fault := s.page(form, len(matched))
+	if fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	resp := describeInstancesResponse{Xmlns: ec2Namespace, RequestID: requestID, NextToken: next}
+	for _, inst := range matched[start:end] {
+		resp.Reservations = append(resp.Reservations, reservationXML{
+			ReservationID: "r-" + inst.ID,
+			OwnerID:       s.accountID,
+			Instances:     []instanceXML{s.instanceXML(inst)},
+		})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+func (s *Server) instance(id string) (Instance, bool) {
+	for _, inst := range s.instances {
+		if inst.ID == id {
+			return inst, true
+		}
+	}
+	return Instance{}, false
+}
+
+func instanceState(inst Instance) string {
+	if inst.State == "" {
+		return "running"
+	}
+	return inst.State
+}
+
+func instanceTenancy(inst Instance) string {
+	if inst.Tenancy == "" {
+		return "default"
+	}
+	return inst.Tenancy
+}
+
+func instanceFilterFields(inst Instance) map[string][]string {
+	return map[string][]string{
+		"instance-id":                    {inst.ID},
+		"image-id":                       {inst.ImageID},
+		"instance-type":                  {inst.InstanceType},
+		"instance-state-name":            {instanceState(inst)},
+		"subnet-id":                      {inst.SubnetID},
+		"vpc-id":                         {inst.VpcID},
+		"availability-zone":              {inst.AvailabilityZone},
+		"key-name":                       {inst.KeyName},
+		"iam-instance-profile.arn":       {inst.IAMInstanceProfileARN},
+		"instance.group-id":              inst.SecurityGroupIDs,
+		"block-device-mapping.volume-id": inst.VolumeIDs,
+	}
+}
+
+func (s *Server) instanceXML(inst Instance) instanceXML {
+	state := instanceState(inst)
+	out := instanceXML{
+		InstanceID:       inst.ID,
+		ImageID:          inst.ImageID,
+		StateCode:        instanceStateCodes[state],
+		StateName:        state,
+		InstanceType:     inst.InstanceType,
+		KeyName:          inst.KeyName,
+		LaunchTime:       formatTime(inst.LaunchTime),
+		AvailabilityZone: inst.AvailabilityZone,
+		PlacementGroup:   inst.PlacementGroup,
+		Tenancy:          instanceTenancy(inst),
+		EBSOptimized:     inst.EBSOptimized,
+		SubnetID:         inst.SubnetID,
+		VpcID:            inst.VpcID,
+		PrivateIP:        inst.PrivateIP,
+		IAMProfileARN:    inst.IAMInstanceProfileARN,
+		Tags:             tagsXML(inst.Tags),
+		HTTPTokens:       "optional",
+		HTTPEndpoint:     "enabled",
+		HTTPHopLimit:     1,
+		MonitoringState:  "disabled",
+	}
+	if inst.HTTPTokens != "" {
+		out.HTTPTokens = inst.HTTPTokens
+	}
+	if inst.DetailedMonitoring {
+		out.MonitoringState = "enabled"
+	}
+	for _, id := range inst.SecurityGroupIDs {
+		ref := groupRefXML{GroupID: id}
+		if sg, ok := s.securityGroup(id); ok {
+			ref.GroupName = sg.Name
+		}
+		out.Groups = append(out.Groups, ref)
+	}
+	if len(inst.VolumeIDs) > 0 {
+		out.RootDeviceType = "ebs"
+		out.RootDeviceName = rootDeviceName
+	}
+	for i, id := range inst.VolumeIDs {
+		out.BlockDevices = append(out.BlockDevices, blockDeviceXML{
+			DeviceName:          deviceName(i),
+			VolumeID:            id,
+			Status:              "attached",
+			AttachTime:          formatTime(inst.LaunchTime),
+			DeleteOnTermination: i == 0,
+		})
+	}
+	return out
+}
+
+// deviceName returns the device of the i-th attached volume: the root device,
+// then /dev/sdf onwards.
+func deviceName(i int) string {
+	if i == 0 {
+		return rootDeviceName
+	}
+	return fmt.Sprintf("/dev/sd%c", 'f'+i-1)
+}
+
+type describeInstanceAttributeResponse struct {
+	XMLName    xml.Name  `xml:"DescribeInstanceAttributeResponse"`
+	Xmlns      string    `xml:"xmlns,attr"`
+	RequestID  string    `xml:"requestId"`
+	InstanceID string    `xml:"instanceId"`
+	UserData   *valueXML `xml:"userData,omitempty"`
+	// DisableAPITermination is the termination protection flag.
+	DisableAPITermination *booleanValueXML `xml:"disableApiTermination,omitempty"`
+}
+
+type valueXML struct {
+	Value string `xml:"value,omitempty"`
+}
+
+type booleanValueXML struct {
+	Value bool `xml:"value"`
+}
+
+func (s *Server) describeInstanceAttribute(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := form.Get("InstanceId")
+	inst, ok := s.instance(id)
+	if !ok {
+		writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidInstanceID.NotFound", Message: fmt.Sprintf("The instance ID '%s' does not exist", id)})
+		return
+	}
+	resp := describeInstanceAttributeResponse{Xmlns: ec2Namespace, RequestID: requestID, InstanceID: id}
+	switch attr := form.Get("Attribute"); attr {
+	case "userData":
+		resp.UserData = &valueXML{}
+		if inst.UserData != "" {
+			resp.UserData.Value = base64.StdEncoding.EncodeToString([]byte(inst.UserData))
+		}
+	case "disableApiTermination":
+		resp.DisableAPITermination = &booleanValueXML{Value: inst.TerminationProtection}
+	default:
+		writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidParameterValue", Message: fmt.Sprintf("Value (%s) for parameter attribute is not supported by awsfake", attr)})
+		return
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+type describeInstanceCreditSpecificationsResponse struct {
+	XMLName   xml.Name                 `xml:"DescribeInstanceCreditSpecificationsResponse"`
+	Xmlns     string                   `xml:"xmlns,attr"`
+	RequestID string                   `xml:"requestId"`
+	Specs     []creditSpecificationXML `xml:"instanceCreditSpecificationSet>item"`
+}
+
+type creditSpecificationXML struct {
+	InstanceID string `xml:"instanceId"`
+	CPUCredits string `xml:"cpuCredits"`
+}
+
+// describeInstanceCreditSpecifications serves the credit mode of the requested
+// instances, or of every T-class instance when none are requested.
+func (s *Server) describeInstanceCreditSpecifications(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.instances
+	if ids := listParam(form, "InstanceId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			inst, ok := s.instance(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidInstanceID.NotFound", Message: fmt.Sprintf("The instance ID '%s' does not exist", id)})
+				return
+			}
+			candidates = append(candidates, inst)
+		}
+	}
+
+	resp := describeInstanceCreditSpecificationsResponse{Xmlns: ec2Namespace, RequestID: requestID}
+	for _, inst := range candidates {
+		family, _, _ := strings.Cut(inst.InstanceType, ".")
+		if len(family) < 2 || family[0] != 't' || family[1] < '0' || family[1] > '9' {
+			continue // Not a burstable instance
+		}
+		credits := inst.CPUCredits
+		if credits == "" {
+			credits = "unlimited"
+			if family == "t2" {
+				credits = "standard"
+			}
+		}
+		resp.Specs = append(resp.Specs, creditSpecificationXML{InstanceID: inst.ID, CPUCredits: credits})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+type describeVolumesResponse struct {
+	XMLName   xml.Name    `xml:"DescribeVolumesResponse"`
+	Xmlns     string      `xml:"xmlns,attr"`
+	RequestID string      `xml:"requestId"`
+	Volumes   []volumeXML `xml:"volumeSet>item"`
+	NextToken string      `xml:"nextToken,omitempty"`
+}
+
+type volumeXML struct {
+	VolumeID         string          `xml:"volumeId"`
+	Size             int32           `xml:"size"`
+	AvailabilityZone string          `xml:"availabilityZone,omitempty"`
+	Status           string          `xml:"status"`
+	Attachments      []attachmentXML `xml:"attachmentSet>item"`
+	VolumeType       string          `xml:"volumeType,omitempty"`
+	Iops             int32           `xml:"iops,omitempty"`
+	Throughput       int32           `xml:"throughput,omitempty"`
+	Encrypted        bool            `xml:"encrypted"`
+	KMSKeyID         string          `xml:"kmsKeyId,omitempty"`
+	Tags             []tagXML        `xml:"tagSet>item"`
+}
+
+type attachmentXML struct {
+	VolumeID            string `xml:"volumeId"`
+	InstanceID          string `xml:"instanceId"`
+	Device              string `xml:"device"`
+	Status              string `xml:"status"`
+	DeleteOnTermination bool   `xml:"deleteOnTermination"`
+}
+
+func (s *Server) describeVolumes(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.volumes
+	if ids := listParam(form, "VolumeId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			vol, ok := s.volume(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidVolume.NotFound", Message: fmt.Sprintf("The volume '%s' does not exist.", id)})
+				return
+			}
+			candidates = append(candidates, vol)
+		}
+	}
+
+	filters := filterParams(form)
+	var matched []volumeXML
+	for _, vol := range candidates {
+		out := s.volumeXML(vol)
+		fields := map[string][]string{
+			"volume-id":   {vol.ID},
+			"volume-type": {vol.VolumeType},
+			"encrypted":   {fmt.Sprint(vol.Encrypted)},
+		}
+		for _, a := range out.Attachments {
+			fields["attachment.instance-id"] = append(fields["attachment.instance-id"], a.InstanceID)
+		}
+		ok, unsupported := matchFilters(filters, fields, vol.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if ok {
+			matched = append(matched, out)
+		}
+	}
+
+	start, end, next, fault := s.page(form, len(matched))
+	if fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	writeXML(w, http.StatusOK, describeVolumesResponse{Xmlns: ec2Namespace, RequestID: requestID, Volumes: matched[start:end], NextToken: next})
+}
+
+func (s *Server) volume(id string) (Volume, bool) {
+	for _, vol := range s.volumes {
+		if vol.ID == id {
+			return vol, true
+		}
+	}
+	return Volume{}, false
+}
+
+func (s *Server) volumeXML(vol Volume) volumeXML {
+	out := volumeXML{
+		VolumeID:         vol.ID,
+		Size:             vol.Size,
+		AvailabilityZone: vol.AvailabilityZone,
+		Status:           "available",
+		VolumeType:       vol.VolumeType,
+		Iops:             vol.Iops,
+		Throughput:       vol.Throughput,
+		Encrypted:        vol.Encrypted,
+		KMSKeyID:         vol.KMSKeyID,
+		Tags:             tagsXML(vol.Tags),
+	}
+	for _, inst := range s.instances {
+		for i, id := range inst.VolumeIDs {
+			if id != vol.ID {
+				continue
+			}
+			out.Status = "in-use"
+			out.Attachments = append(out.Attachments, attachmentXML{
+				VolumeID:            vol.ID,
+				InstanceID:          inst.ID,
+				Device:              deviceName(i),
+				Status:              "attached",
+				DeleteOnTermination: i == 0,
+			})
+		}
+	}
+	return out
+}
+
+type describeSecurityGroupsResponse struct {
+	XMLName        xml.Name           `xml:"DescribeSecurityGroupsResponse"`
+	Xmlns          string             `xml:"xmlns,attr"`
+	RequestID      string             `xml:"requestId"`
+	SecurityGroups []securityGroupXML `xml:"securityGroupInfo>item"`
+	NextToken      string             `xml:"nextToken,omitempty"`
+}
+
+type securityGroupXML struct {
+	OwnerID          string          `xml:"ownerId"`
+	GroupID          string          `xml:"groupId"`
+	GroupName        string          `xml:"groupName"`
+	GroupDescription string          `xml:"groupDescription"`
+	VpcID            string          `xml:"vpcId,omitempty"`
+	SecurityGroupArn string          `xml:"securityGroupArn"`
+	Ingress          []permissionXML `xml:"ipPermissions>item"`
+	Egress           []permissionXML `xml:"ipPermissionsEgress>item"`
+	Tags             []tagXML        `xml:"tagSet>item"`
+}
+
+type permissionXML struct {
+	IPProtocol    string          `xml:"ipProtocol"`
+	FromPort      *int32          `xml:"fromPort,omitempty"`
+	ToPort        *int32          `xml:"toPort,omitempty"`
+	Groups        []groupPairXML  `xml:"groups>item"`
+	IPRanges      []ipRangeXML    `xml:"ipRanges>item"`
+	IPv6Ranges    []ipv6RangeXML  `xml:"ipv6Ranges>item"`
+	PrefixListIDs []prefixListXML `xml:"prefixListIds>item"`
+}
+
+type groupPairXML struct {
+	GroupID     string `xml:"groupId"`
+	UserID      string `xml:"userId"`
+	Description string `xml:"description,omitempty"`
+}
+
+type ipRangeXML struct {
+	CidrIP      string `xml:"cidrIp"`
+	Description string `xml:"description,omitempty"`
+}
+
+type ipv6RangeXML struct {
+	CidrIPv6    string `xml:"cidrIpv6"`
+	Description string `xml:"description,omitempty"`
+}
+
+type prefixListXML struct {
+	PrefixListID string `xml:"prefixListId"`
+	Description  string `xml:"description,omitempty"`
+}
+
+func (s *Server) describeSecurityGroups(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.securityGroups
+	if ids := listParam(form, "GroupId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			sg, ok := s.securityGroup(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidGroup.NotFound", Message: fmt.Sprintf("The security group '%s' does not exist", id)})
+				return
+			}
+			candidates = append(candidates, sg)
+		}
+	}
+
+	filters := filterParams(form)
+	var matched []SecurityGroup
+	for _, sg := range candidates {
+		fields := map[string][]string{
+			"group-id":    {sg.ID},
+			"group-name":  {sg.Name},
+			"description": {sg.Description},
+			"vpc-id":      {sg.VpcID},
+			"owner-id":    {s.accountID},
+		}
+		ok, unsupported := matchFilters(filters, fields, sg.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if ok {
+			matched = append(matched, sg)
+		}
+	}
+
+	start, end, next, fault := s.page(form, len(matched))
+	if fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	resp := describeSecurityGroupsResponse{Xmlns: ec2Namespace, RequestID: requestID, NextToken: next}
+	for _, sg := range matched[start:end] {
+		resp.SecurityGroups = append(resp.SecurityGroups, securityGroupXML{
+			OwnerID:          s.accountID,
+			GroupID:          sg.ID,
+			GroupName:        sg.Name,
+			GroupDescription: sg.Description,
+			VpcID:            sg.VpcID,
+			SecurityGroupArn: fmt.Sprintf("arn:aws:ec2:%s:%s:security-group/%s", s.region, s.accountID, sg.ID),
+			Ingress:          s.permissionsXML(sg.Ingress),
+			Egress:           s.permissionsXML(sg.Egress),
+			Tags:             tagsXML(sg.Tags),
+		})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+func (s *Server) securityGroup(id string) (SecurityGroup, bool) {
+	for _, sg := range s.securityGroups {
+		if sg.ID == id {
+			return sg, true
+		}
+	}
+	return SecurityGroup{}, false
+}
+
+func (s *Server) permissionsXML(rules []Rule) []permissionXML {
+	out := make([]permissionXML, 0, len(rules))
+	for _, r := range rules {
+		perm := permissionXML{IPProtocol: r.Protocol}
+		if r.Protocol != "-1" {
+			from, to := r.FromPort, r.ToPort
+			perm.FromPort, perm.ToPort = &from, &to
+		}
+		for _, id := range r.SourceGroupIDs {
+			perm.Groups = append(perm.Groups, groupPairXML{GroupID: id, UserID: s.accountID, Description: r.Description})
+		}
+		for _, cidr := range r.CIDRs {
+			perm.IPRanges = append(perm.IPRanges, ipRangeXML{CidrIP: cidr, Description: r.Description})
+		}
+		for _, cidr := range r.IPv6CIDRs {
+			perm.IPv6Ranges = append(perm.IPv6Ranges, ipv6RangeXML{CidrIPv6: cidr, Description: r.Description})
+		}
+		for _, id := range r.PrefixListIDs {
+			perm.PrefixListIDs = append(perm.PrefixListIDs, prefixListXML{PrefixListID: id, Description: r.Description})
+		}
+		out = append(out, perm)
+	}
+	return out
+}
+
+func unsupportedFilter(name string) *Fault {
+	return &Fault{Status: http.StatusBadRequest, Code: "InvalidParameterValue", Message: fmt.Sprintf("The filter '%s' is invalid", name)}
+}
+
+// formatTime formats t as an AWS timestamp, or "" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+type describeLaunchTemplatesResponse struct {
+	XMLName         xml.Name            `xml:"DescribeLaunchTemplatesResponse"`
+	Xmlns           string              `xml:"xmlns,attr"`
+	RequestID       string              `xml:"requestId"`
+	LaunchTemplates []launchTemplateXML `xml:"launchTemplates>item"`
+	NextToken       string              `xml:"nextToken,omitempty"`
+}
+
+type launchTemplateXML struct {
+	LaunchTemplateID     string   `xml:"launchTemplateId"`
+	LaunchTemplateName   string   `xml:"launchTemplateName"`
+	DefaultVersionNumber int64    `xml:"defaultVersionNumber"`
+	LatestVersionNumber  int64    `xml:"latestVersionNumber"`
+	Tags                 []tagXML `xml:"tagSet>item"`
+}
+
+func (s *Server) describeLaunchTemplates(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.launchTemplates
+	if ids := listParam(form, "LaunchTemplateId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			lt, ok := s.launchTemplate(id)
+			if !ok {
+				writeEC2Error(w, launchTemplateNotFound(id))
+				return
+			}
+			candidates = append(candidates, lt)
+		}
+	}
+
+	filters := filterParams(form)
+	var matched []LaunchTemplate
+	for _, lt := range candidates {
+		ok, unsupported := matchFilters(filters, map[string][]string{"launch-template-name": {lt.Name}}, lt.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if ok {
+			matched = append(matched, lt)
+		}
+	}
+
+	start, end, next, fault := s.page(form, len(matched))
+	if fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	resp := describeLaunchTemplatesResponse{Xmlns: ec2Namespace, RequestID: requestID, NextToken: next}
+	for _, lt := range matched[start:end] {
+		resp.LaunchTemplates = append(resp.LaunchTemplates, launchTemplateXML{
+			LaunchTemplateID:     lt.ID,
+			LaunchTemplateName:   lt.Name,
+			DefaultVersionNumber: lt.defaultVersion(),
+			LatestVersionNumber:  int64(len(lt.Versions)),
+			Tags:                 tagsXML(lt.Tags),
+		})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+type describeLaunchTemplateVersionsResponse struct {
+	XMLName   xml.Name                   `xml:"DescribeLaunchTemplateVersionsResponse"`
+	Xmlns     string                     `xml:"xmlns,attr"`
+	RequestID string                     `xml:"requestId"`
+	Versions  []launchTemplateVersionXML `xml:"launchTemplateVersionSet>item"`
+}
+
+type launchTemplateVersionXML struct {
+	LaunchTemplateID   string                `xml:"launchTemplateId"`
+	LaunchTemplateName string                `xml:"launchTemplateName"`
+	VersionNumber      int64                 `xml:"versionNumber"`
+	VersionDescription string                `xml:"versionDescription,omitempty"`
+	DefaultVersion     bool                  `xml:"defaultVersion"`
+	Data               launchTemplateDataXML `xml:"launchTemplateData"`
+}
+
+type launchTemplateDataXML struct {
+	ImageID          string                 `xml:"imageId,omitempty"`
+	InstanceType     string                 `xml:"instanceType,omitempty"`
+	KeyName          string                 `xml:"keyName,omitempty"`
+	SecurityGroupIDs []string               `xml:"securityGroupIdSet>item"`
+	UserData         string                 `xml:"userData,omitempty"`
+	MetadataOptions  *templateMetadataXML   `xml:"metadataOptions"`
+	Monitoring       *templateMonitoringXML `xml:"monitoring"`
+}
+
+type templateMetadataXML struct {
+	HTTPTokens string `xml:"httpTokens"`
+}
+
+type templateMonitoringXML struct {
+	Enabled bool `xml:"enabled"`
+}
+
+// describeLaunchTemplateVersions serves the requested versions of a single
+// template: version numbers, "$Latest" or "$Default". Each version is
+// returned once.
+func (s *Server) describeLaunchTemplateVersions(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := form.Get("LaunchTemplateId")
+	lt, ok := s.launchTemplate(id)
+	if !ok {
+		writeEC2Error(w, launchTemplateNotFound(id))
+		return
+	}
+
+	var numbers []int64
+	seen := map[int64]bool{}
+	for _, v := range listParam(form, "LaunchTemplateVersion") {
+		var number int64
+		switch v {
+		case "$Latest":
+			number = int64(len(lt.Versions))
+		case "$Default":
+			number = lt.defaultVersion()
+		default:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 1 || n > int64(len(lt.Versions)) {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidLaunchTemplateId.VersionNotFound", Message: fmt.Sprintf("Could not find launch template version %s for template %s", v, id)})
+				return
+			}
+			number = n
+		}
+		if !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+
+	resp := describeLaunchTemplateVersionsResponse{Xmlns: ec2Namespace, RequestID: requestID}
+	for _, number := range numbers {
+		version := lt.Versions[number-1]
+		data := launchTemplateDataXML{
+			ImageID:          version.ImageID,
+			InstanceType:     version.InstanceType,
+			KeyName:          version.KeyName,
+			SecurityGroupIDs: version.SecurityGroupIDs,
+		}
+		if version.UserData != "" {
+			data.UserData = base64.StdEncoding.EncodeToString([]byte(version.UserData))
+		}
+		if version.HTTPTokens != "" {
+			data.MetadataOptions = &templateMetadataXML{HTTPTokens: version.HTTPTokens}
+		}
+		if version.DetailedMonitoring {
+			data.Monitoring = &templateMonitoringXML{Enabled: true}
+		}
+		resp.Versions = append(resp.Versions, launchTemplateVersionXML{
+			LaunchTemplateID:   lt.ID,
+			LaunchTemplateName: lt.Name,
+			VersionNumber:      number,
+			VersionDescription: version.Description,
+			DefaultVersion:     number == lt.defaultVersion(),
+			Data:               data,
+		})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+func (s *Server) launchTemplate(id string) (LaunchTemplate, bool) {
+	for _, lt := range s.launchTemplates {
+		if lt.ID == id {
+			return lt, true
+		}
+	}
+	return LaunchTemplate{}, false
+}
+
+func (lt LaunchTemplate) defaultVersion() int64 {
+	if lt.DefaultVersion > 0 {
+		return lt.DefaultVersion
+	}
+	return int64(len(lt.Versions))
+}
+
+func launchTemplateNotFound(id string) *Fault {
+	return &Fault{Status: http.StatusBadRequest, Code: "InvalidLaunchTemplateId.NotFound", Message: fmt.Sprintf("The specified launch template, with template ID %s, does not exist.", id)}
+}
+
+type describeAddressesResponse struct {
+	XMLName   xml.Name     `xml:"DescribeAddressesResponse"`
+	Xmlns     string       `xml:"xmlns,attr"`
+	RequestID string       `xml:"requestId"`
+	Addresses []addressXML `xml:"addressesSet>item"`
+}
+
+type addressXML struct {
+	AllocationID       string   `xml:"allocationId"`
+	AssociationID      string   `xml:"associationId,omitempty"`
+	Domain             string   `xml:"domain"`
+	PublicIP           string   `xml:"publicIp"`
+	InstanceID         string   `xml:"instanceId,omitempty"`
+	NetworkInterfaceID string   `xml:"networkInterfaceId,omitempty"`
+	PrivateIP          string   `xml:"privateIpAddress,omitempty"`
+	Tags               []tagXML `xml:"tagSet>item"`
+}
+
+// describeAddresses serves all matching addresses in one response, as
+// DescribeAddresses does not paginate.
+func (s *Server) describeAddresses(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.addresses
+	if ids := listParam(form, "AllocationId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			addr, ok := s.address(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidAllocationID.NotFound", Message: fmt.Sprintf("The allocation ID '%s' does not exist", id)})
+				return
+			}
+			candidates = append(candidates, addr)
+		}
+	}
+
+	filters := filterParams(form)
+	resp := describeAddressesResponse{Xmlns: ec2Namespace, RequestID: requestID}
+	for _, addr := range candidates {
+		fields := map[string][]string{
+			"allocation-id":        {addr.AllocationID},
+			"public-ip":            {addr.PublicIP},
+			"instance-id":          {addr.InstanceID},
+			"network-interface-id": {addr.NetworkInterfaceID},
+			"domain":               {"vpc"},
+		}
+		ok, unsupported := matchFilters(filters, fields, addr.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if !ok {
+			continue
+		}
+		out := addressXML{
+			AllocationID:       addr.AllocationID,
+			Domain:             "vpc",
+			PublicIP:           addr.PublicIP,
+			InstanceID:         addr.InstanceID,
+			NetworkInterfaceID: addr.NetworkInterfaceID,
+			PrivateIP:          addr.PrivateIP,
+			Tags:               tagsXML(addr.Tags),
+		}
+		if addr.InstanceID != "" || addr.NetworkInterfaceID != "" {
+			out.AssociationID = "eipassoc-" + strings.TrimPrefix(addr.AllocationID, "eipalloc-")
+		}
+		resp.Addresses = append(resp.Addresses, out)
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+func (s *Server) address(id string) (Address, bool) {
+	for _, addr := range s.addresses {
+		if addr.AllocationID == id {
+			return addr, true
+		}
+	}
+	return Address{}, false
+}
+
+type describeNetworkInterfacesResponse struct {
+	XMLName           xml.Name              `xml:"DescribeNetworkInterfacesResponse"`
+	Xmlns             string                `xml:"xmlns,attr"`
+	RequestID         string                `xml:"requestId"`
+	NetworkInterfaces []networkInterfaceXML `xml:"networkInterfaceSet>item"`
+	NextToken         string                `xml:"nextToken,omitempty"`
+}
+
+type networkInterfaceXML struct {
+	NetworkInterfaceID string                `xml:"networkInterfaceId"`
+	SubnetID           string                `xml:"subnetId"`
+	VpcID              string                `xml:"vpcId"`
+	Description        string                `xml:"description"`
+	OwnerID            string                `xml:"ownerId"`
+	RequesterManaged   bool                  `xml:"requesterManaged"`
+	Status             string                `xml:"status"`
+	PrivateIP          string                `xml:"privateIpAddress,omitempty"`
+	SourceDestCheck    bool                  `xml:"sourceDestCheck"`
+	InterfaceType      string                `xml:"interfaceType"`
+	Groups             []groupRefXML         `xml:"groupSet>item"`
+	Attachment         *eniAttachmentXML     `xml:"attachment"`
+	PrivateIPs         []privateIPAddressXML `xml:"privateIpAddressesSet>item"`
+	Tags               []tagXML              `xml:"tagSet>item"`
+}
+
+type eniAttachmentXML struct {
+	AttachmentID string `xml:"attachmentId"`
+	InstanceID   string `xml:"instanceId"`
+	DeviceIndex  int32  `xml:"deviceIndex"`
+	Status       string `xml:"status"`
+}
+
+type privateIPAddressXML struct {
+	PrivateIP string `xml:"privateIpAddress"`
+	Primary   bool   `xml:"primary"`
+}
+
+func (s *Server) describeNetworkInterfaces(w http.ResponseWriter, form url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := s.networkInterfaces
+	if ids := listParam(form, "NetworkInterfaceId"); len(ids) > 0 {
+		candidates = nil
+		for _, id := range ids {
+			eni, ok := s.networkInterface(id)
+			if !ok {
+				writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidNetworkInterfaceID.NotFound", Message: fmt.Sprintf("The networkInterface ID '%s' does not exist", id)})
+				return
+			}
+			candidates = append(candidates, eni)
+		}
+	}
+
+	filters := filterParams(form)
+	var matched []NetworkInterface
+	for _, eni := range candidates {
+		fields := map[string][]string{
+			"network-interface-id":   {eni.ID},
+			"subnet-id":              {eni.SubnetID},
+			"vpc-id":                 {eni.VpcID},
+			"description":            {eni.Description},
+			"interface-type":         {"interface"},
+			"attachment.instance-id": {eni.InstanceID},
+			"group-id":               eni.SecurityGroupIDs,
+		}
+		ok, unsupported := matchFilters(filters, fields, eni.Tags)
+		if unsupported != "" {
+			writeEC2Error(w, unsupportedFilter(unsupported))
+			return
+		}
+		if ok {
+			matched = append(matched, eni)
+		}
+	}
+
+	start, end, next, fault := s.page(form, len(matched))
+	if fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	resp := describeNetworkInterfacesResponse{Xmlns: ec2Namespace, RequestID: requestID, NextToken: next}
+	for _, eni := range matched[start:end] {
+		resp.NetworkInterfaces = append(resp.NetworkInterfaces, s.networkInterfaceXML(eni))
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+func (s *Server) networkInterface(id string) (NetworkInterface, bool) {
+	for _, eni := range s.networkInterfaces {
+		if eni.ID == id {
+			return eni, true
+		}
+	}
+	return NetworkInterface{}, false
+}
+
+func (s *Server) networkInterfaceXML(eni NetworkInterface) networkInterfaceXML {
+	out := networkInterfaceXML{
+		NetworkInterfaceID: eni.ID,
+		SubnetID:           eni.SubnetID,
+		VpcID:              eni.VpcID,
+		Description:        eni.Description,
+		OwnerID:            s.accountID,
+		RequesterManaged:   eni.RequesterManaged,
+		Status:             "available",
+		SourceDestCheck:    eni.SourceDestCheck,
+		InterfaceType:      "interface",
+		Tags:               tagsXML(eni.Tags),
+	}
+	for i, ip := range eni.PrivateIPs {
+		if i == 0 {
+			out.PrivateIP = ip
+		}
+		out.PrivateIPs = append(out.PrivateIPs, privateIPAddressXML{PrivateIP: ip, Primary: i == 0})
+	}
+	for _, id := range eni.SecurityGroupIDs {
+		ref := groupRefXML{GroupID: id}
+		if sg, ok := s.securityGroup(id); ok {
+			ref.GroupName = sg.Name
+		}
+		out.Groups = append(out.Groups, ref)
+	}
+	if eni.InstanceID != "" {
+		out.Status = "in-use"
+		out.Attachment = &eniAttachmentXML{
+			AttachmentID: "eni-attach-" + strings.TrimPrefix(eni.ID, "eni-"),
+			InstanceID:   eni.InstanceID,
+			DeviceIndex:  eni.DeviceIndex,
+			Status:       "attached",
+		}
+	}
+	return out
+}
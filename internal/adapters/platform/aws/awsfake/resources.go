@@ -0,0 +1,246 @@
+package awsfake
+
+import (
+	"time"
+)
+
+// Instance is an EC2 instance served by DescribeInstances.
+type Instance struct {
+	ID           string
+	ImageID      string
+	InstanceType string
+	// State is the instance state name. Empty means "running".
+	State            string
+	SubnetID         string
+	VpcID            string
+	AvailabilityZone string
+	PlacementGroup   string
+	// Tenancy is the placement tenancy. Empty means "default".
+	Tenancy      string
+	EBSOptimized bool
+	// CPUCredits is the credit mode served by
+	// DescribeInstanceCreditSpecifications for T-class instances. Empty means
+	// the family default: "standard" for t2, "unlimited" otherwise.
+	CPUCredits string
+	PrivateIP  string
+	KeyName    string
+	// IAMInstanceProfileARN is the ARN of the attached instance profile.
+	IAMInstanceProfileARN string
+	SecurityGroupIDs      []string
+	Tags                  map[string]string
+	// UserData is returned base64 encoded by DescribeInstanceAttribute.
+	UserData string
+	// HTTPTokens is the instance metadata HttpTokens setting, "required" for
+	// IMDSv2 only. Empty means "optional".
+	HTTPTokens string
+	// DetailedMonitoring enables detailed monitoring.
+	DetailedMonitoring bool
+	// TerminationProtection is served as disableApiTermination by
+	// DescribeInstanceAttribute.
+	TerminationProtection bool
+	// VolumeIDs lists the attached EBS volumes, the first being the root
+	// device. Volumes are served by DescribeVolumes once added with AddVolumes.
+	VolumeIDs  []string
+	LaunchTime time.Time
+}
+
+// Volume is an EBS volume served by DescribeVolumes.
+type Volume struct {
+	ID               string
+	VolumeType       string
+	Size             int32
+	Iops             int32
+	Throughput       int32
+	Encrypted        bool
+	KMSKeyID         string
+	AvailabilityZone string
+	Tags             map[string]string
+}
+
+// SecurityGroup is an EC2 security group served by DescribeSecurityGroups.
+type SecurityGroup struct {
+	ID          string
+	Name        string
+	Description string
+	VpcID       string
+	Ingress     []Rule
+	Egress      []Rule
+	Tags        map[string]string
+}
+
+// Rule is one IP permission of a security group.
+type Rule struct {
+	// Protocol is the IP protocol, "-1" for all traffic.
+	Protocol       string
+	FromPort       int32
+	ToPort         int32
+	CIDRs          []string
+	IPv6CIDRs      []string
+	SourceGroupIDs []string
+	PrefixListIDs  []string
+	Description    string
+}
+
+// LaunchTemplate is an EC2 launch template served by DescribeLaunchTemplates
+// and DescribeLaunchTemplateVersions. Versions are numbered from 1 in order.
+type LaunchTemplate struct {
+	ID   string
+	Name string
+	// DefaultVersion is the default version number. Zero means the latest.
+	DefaultVersion int64
+	Versions       []LaunchTemplateVersion
+	Tags           map[string]string
+}
+
+// LaunchTemplateVersion is the data of one launch template version.
+type LaunchTemplateVersion struct {
+	Description      string
+	ImageID          string
+	InstanceType     string
+	KeyName          string
+	SecurityGroupIDs []string
+	// UserData is served base64 encoded.
+	UserData string
+	// HTTPTokens is the IMDSv2 setting, "required" or "optional". Empty
+	// leaves the metadata options unset.
+	HTTPTokens         string
+	DetailedMonitoring bool
+}
+
+// Address is an Elastic IP address served by DescribeAddresses. It is
+// associated when InstanceID or NetworkInterfaceID is set.
+type Address struct {
+	AllocationID       string
+	PublicIP           string
+	InstanceID         string
+	NetworkInterfaceID string
+	PrivateIP          string
+	Tags               map[string]string
+}
+
+// NetworkInterface is an EC2 network interface served by
+// DescribeNetworkInterfaces. It is attached when InstanceID is set.
+type NetworkInterface struct {
+	ID          string
+	SubnetID    string
+	VpcID       string
+	Description string
+	// PrivateIPs are the private addresses, the first being the primary one.
+	PrivateIPs       []string
+	SecurityGroupIDs []string
+	// SourceDestCheck disables source/destination checking when false.
+	SourceDestCheck bool
+	InstanceID      string
+	DeviceIndex     int32
+	// RequesterManaged marks an interface created by an AWS service.
+	RequesterManaged bool
+	Tags             map[string]string
+}
+
+// Bucket is an S3 bucket. Optional configurations left empty answer with the
+// error S3 returns for an unconfigured bucket, e.g. NoSuchTagSet.
+type Bucket struct {
+	Name string
+	// Region is the bucket region. Empty means the server region.
+	Region       string
+	CreationDate time.Time
+	Policy       string
+	Tags         map[string]string
+	// Versioning is "Enabled", "Suspended" or empty when never enabled.
+	Versioning string
+	// SSEAlgorithm is the default encryption, e.g. "AES256" or "aws:kms".
+	SSEAlgorithm string
+	KMSKeyID     string
+	// LoggingTarget is the target bucket of server access logging.
+	LoggingTarget       string
+	LoggingTargetPrefix string
+	// LifecycleRules holds rule ID to expiration in days.
+	LifecycleRules map[string]int32
+	// WebsiteIndexDocument enables static website hosting.
+	WebsiteIndexDocument string
+	// CORSAllowedOrigins enables a single CORS rule allowing GET requests.
+	CORSAllowedOrigins []string
+	// BlockPublicAccess enables all four Block Public Access settings. Without
+	// it the bucket has no public access block configuration.
+	BlockPublicAccess bool
+	// ObjectOwnership is the Object Ownership rule, e.g. "BucketOwnerEnforced".
+	// Empty means no ownership controls.
+	ObjectOwnership string
+	// Acceleration is "Enabled", "Suspended" or empty when never enabled.
+	Acceleration string
+	// ReplicationDestination enables a single replication rule, "all", copying
+	// every object to the destination bucket ARN under ReplicationRole.
+	ReplicationDestination string
+	ReplicationRole        string
+	// ObjectLock enables Object Lock, with a default retention when
+	// ObjectLockMode and ObjectLockDays are set.
+	ObjectLock     bool
+	ObjectLockMode string
+	ObjectLockDays int32
+	// NotificationQueue sends every ObjectCreated event for keys under
+	// "uploads/" to the SQS queue ARN, in a notification with id "uploads".
+	// Empty means no event notifications.
+	NotificationQueue string
+	// IntelligentTieringIDs, AnalyticsIDs, InventoryIDs and MetricsIDs name
+	// the configurations of each bucket configuration listing.
+	// Intelligent-Tiering configurations archive objects after 90 days,
+	// inventories list current versions daily as CSV into the bucket itself,
+	// and analytics and metrics configurations cover the whole bucket.
+	IntelligentTieringIDs []string
+	AnalyticsIDs          []string
+	InventoryIDs          []string
+	MetricsIDs            []string
+}
+
+// AddInstances adds EC2 instances in the order DescribeInstances returns them.
+func (s *Server) AddInstances(instances ...Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances = append(s.instances, instances...)
+}
+
+// AddVolumes adds EBS volumes.
+func (s *Server) AddVolumes(volumes ...Volume) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.volumes = append(s.volumes, volumes...)
+}
+
+// AddSecurityGroups adds security groups in the order DescribeSecurityGroups
+// returns them.
+func (s *Server) AddSecurityGroups(groups ...SecurityGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.securityGroups = append(s.securityGroups, groups...)
+}
+
+// AddLaunchTemplates adds launch templates in the order
+// DescribeLaunchTemplates returns them.
+func (s *Server) AddLaunchTemplates(templates ...LaunchTemplate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.launchTemplates = append(s.launchTemplates, templates...)
+}
+
+// AddAddresses adds Elastic IP addresses in the order DescribeAddresses
+// returns them.
+func (s *Server) AddAddresses(addresses ...Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addresses = append(s.addresses, addresses...)
+}
+
+// AddNetworkInterfaces adds network interfaces in the order
+// DescribeNetworkInterfaces returns them.
+func (s *Server) AddNetworkInterfaces(interfaces ...NetworkInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.networkInterfaces = append(s.networkInterfaces, interfaces...)
+}
+
+// AddBuckets adds S3 buckets in the order ListBuckets returns them.
+func (s *Server) AddBuckets(buckets ...Bucket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets = append(s.buckets, buckets...)
+}
@@ -0,0 +1,633 @@
+package awsfake
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// canonicalUserID is the canonical ID of the bucket owner.
+	canonicalUserID = "75aa57f09aa0c8caeab4f8c24e99d10f8e7faeebf76c078efc7c6caea54ba06a"
+)
+
+// s3SubResources maps the query parameter selecting a bucket sub-resource to
+// the operation reading it.
+var s3SubResources = map[string]string{
+	"location":            "GetBucketLocation",
+	"policy":              "GetBucketPolicy",
+	"tagging":             "GetBucketTagging",
+	"versioning":          "GetBucketVersioning",
+	"acl":                 "GetBucketAcl",
+	"encryption":          "GetBucketEncryption",
+	"lifecycle":           "GetBucketLifecycleConfiguration",
+	"logging":             "GetBucketLogging",
+	"website":             "GetBucketWebsite",
+	"cors":                "GetBucketCors",
+	"publicAccessBlock":   "GetPublicAccessBlock",
+	"ownershipControls":   "GetBucketOwnershipControls",
+	"accelerate":          "GetBucketAccelerateConfiguration",
+	"replication":         "GetBucketReplication",
+	"notification":        "GetBucketNotificationConfiguration",
+	"object-lock":         "GetObjectLockConfiguration",
+	"intelligent-tiering": "ListBucketIntelligentTieringConfigurations",
+	"analytics":           "ListBucketAnalyticsConfigurations",
+	"inventory":           "ListBucketInventoryConfigurations",
+	"metrics":             "ListBucketMetricsConfigurations",
+}
+
+// s3ConfigurationPageSize is the number of configurations S3 returns per page
+// of a bucket configuration listing.
+const s3ConfigurationPageSize = 100
+
+// regionAgnosticOperations answer in any region. Other bucket operations sent
+// to the wrong region get a PermanentRedirect, as S3 does.
+var regionAgnosticOperations = map[string]bool{
+	"ListBuckets":       true,
+	"GetBucketLocation": true,
+}
+
+type s3ErrorResponse struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+	BucketName string   `xml:"BucketName,omitempty"`
+	ReqID      string   `xml:"RequestId"`
+}
+
+// writeS3Error writes an S3 error. HEAD responses carry only the status, so
+// the SDK reports them by HTTP status alone.
+func writeS3Error(w http.ResponseWriter, r *http.Request, bucket string, fault *Fault) {
+	if r.Method == http.MethodHead {
+		w.Header().Set("X-Amz-Request-Id", requestID)
+		w.WriteHeader(fault.Status)
+		return
+	}
+	writeXML(w, fault.Status, s3ErrorResponse{Code: fault.Code, Message: fault.Message, BucketName: bucket, ReqID: requestID})
+}
+
+func (s *Server) serveS3(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "" && r.Method == http.MethodGet {
+		if fault := s.record("ListBuckets"); fault != nil {
+			writeS3Error(w, r, "", fault)
+			return
+		}
+		s.listBuckets(w, r.URL.Query())
+		return
+	}
+
+	bucket, key, _ := strings.Cut(path, "/")
+	operation := s3Operation(r)
+	if key != "" || operation == "" {
+		writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotImplemented, Code: "NotImplemented", Message: fmt.Sprintf("%s %s is not supported by awsfake", r.Method, r.URL.RequestURI())})
+		return
+	}
+	if fault := s.record(operation); fault != nil {
+		writeS3Error(w, r, bucket, fault)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.bucket(bucket)
+	if !ok {
+		writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchBucket", Message: "The specified bucket does not exist"})
+		return
+	}
+	region := s.bucketRegion(b)
+	if signed := signingRegion(r); signed != "" && signed != region && !regionAgnosticOperations[operation] {
+		w.Header().Set("X-Amz-Bucket-Region", region)
+		writeS3Error(w, r, bucket, &Fault{Status: http.StatusMovedPermanently, Code: "PermanentRedirect", Message: "The bucket you are attempting to access must be addressed using the specified endpoint."})
+		return
+	}
+
+	switch operation {
+	case "HeadBucket":
+		w.Header().Set("X-Amz-Bucket-Region", region)
+		w.Header().Set("X-Amz-Request-Id", requestID)
+		w.WriteHeader(http.StatusOK)
+	case "GetBucketLocation":
+		constraint := region
+		if region == "us-east-1" {
+			constraint = ""
+		}
+		writeXML(w, http.StatusOK, locationConstraintXML{Xmlns: s3Namespace, Value: constraint})
+	case "GetBucketPolicy":
+		if b.Policy == "" {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchBucketPolicy", Message: "The bucket policy does not exist"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amz-Request-Id", requestID)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(b.Policy))
+	case "GetBucketTagging":
+		if len(b.Tags) == 0 {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchTagSet", Message: "The TagSet does not exist"})
+			return
+		}
+		resp := taggingXML{Xmlns: s3Namespace}
+		for _, k := range sortedKeys(b.Tags) {
+			resp.Tags = append(resp.Tags, s3TagXML{Key: k, Value: b.Tags[k]})
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "GetBucketVersioning":
+		writeXML(w, http.StatusOK, versioningXML{Xmlns: s3Namespace, Status: b.Versioning})
+	case "GetBucketAcl":
+		writeXML(w, http.StatusOK, newOwnerACL())
+	case "GetBucketEncryption":
+		if b.SSEAlgorithm == "" {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "ServerSideEncryptionConfigurationNotFoundError", Message: "The server side encryption configuration was not found"})
+			return
+		}
+		writeXML(w, http.StatusOK, encryptionXML{Xmlns: s3Namespace, Rule: encryptionRuleXML{SSEAlgorithm: b.SSEAlgorithm, KMSMasterKeyID: b.KMSKeyID}})
+	case "GetBucketLifecycleConfiguration":
+		if len(b.LifecycleRules) == 0 {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchLifecycleConfiguration", Message: "The lifecycle configuration does not exist"})
+			return
+		}
+		resp := lifecycleXML{Xmlns: s3Namespace}
+		for _, id := range sortedKeys(b.LifecycleRules) {
+			resp.Rules = append(resp.Rules, lifecycleRuleXML{ID: id, Status: "Enabled", ExpirationDays: b.LifecycleRules[id]})
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "GetBucketLogging":
+		resp := loggingXML{Xmlns: s3Namespace}
+		if b.LoggingTarget != "" {
+			resp.Enabled = &loggingEnabledXML{TargetBucket: b.LoggingTarget, TargetPrefix: b.LoggingTargetPrefix}
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "GetBucketWebsite":
+		if b.WebsiteIndexDocument == "" {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchWebsiteConfiguration", Message: "The specified bucket does not have a website configuration"})
+			return
+		}
+		writeXML(w, http.StatusOK, websiteXML{Xmlns: s3Namespace, IndexSuffix: b.WebsiteIndexDocument})
+	case "GetBucketCors":
+		if len(b.CORSAllowedOrigins) == 0 {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchCORSConfiguration", Message: "The CORS configuration does not exist"})
+			return
+		}
+		writeXML(w, http.StatusOK, corsXML{Xmlns: s3Namespace, Rules: []corsRuleXML{{AllowedMethods: []string{http.MethodGet}, AllowedOrigins: b.CORSAllowedOrigins}}})
+	case "GetPublicAccessBlock":
+		if !b.BlockPublicAccess {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "NoSuchPublicAccessBlockConfiguration", Message: "The public access block configuration was not found"})
+			return
+		}
+		writeXML(w, http.StatusOK, publicAccessBlockXML{Xmlns: s3Namespace, BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true, RestrictPublicBuckets: true})
+	case "GetBucketOwnershipControls":
+		if b.ObjectOwnership == "" {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "OwnershipControlsNotFoundError", Message: "The bucket ownership controls were not found"})
+			return
+		}
+		writeXML(w, http.StatusOK, ownershipControlsXML{Xmlns: s3Namespace, ObjectOwnership: b.ObjectOwnership})
+	case "GetBucketAccelerateConfiguration":
+		writeXML(w, http.StatusOK, accelerateXML{Xmlns: s3Namespace, Status: b.Acceleration})
+	case "GetBucketReplication":
+		if b.ReplicationDestination == "" {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "ReplicationConfigurationNotFoundError", Message: "The replication configuration was not found"})
+			return
+		}
+		writeXML(w, http.StatusOK, replicationXML{Xmlns: s3Namespace, Role: b.ReplicationRole, Rules: []replicationRuleXML{{
+			ID: "all", Priority: 1, Status: "Enabled", DestinationBucket: b.ReplicationDestination, DeleteMarkerReplication: "Disabled",
+		}}})
+	case "GetBucketNotificationConfiguration":
+		resp := notificationXML{Xmlns: s3Namespace}
+		if b.NotificationQueue != "" {
+			resp.Queues = []queueConfigurationXML{{
+				ID: "uploads", Queue: b.NotificationQueue, Events: []string{"s3:ObjectCreated:*"},
+				FilterRules: []filterRuleXML{{Name: "Prefix", Value: "uploads/"}},
+			}}
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "GetObjectLockConfiguration":
+		if !b.ObjectLock {
+			writeS3Error(w, r, bucket, &Fault{Status: http.StatusNotFound, Code: "ObjectLockConfigurationNotFoundError", Message: "Object Lock configuration does not exist for this bucket"})
+			return
+		}
+		resp := objectLockXML{Xmlns: s3Namespace, Enabled: "Enabled"}
+		if b.ObjectLockMode != "" {
+			resp.Retention = &defaultRetentionXML{Mode: b.ObjectLockMode, Days: b.ObjectLockDays}
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "ListBucketIntelligentTieringConfigurations":
+		ids, next, fault := s.configurationPage(r.URL.Query(), b.IntelligentTieringIDs)
+		if fault != nil {
+			writeS3Error(w, r, bucket, fault)
+			return
+		}
+		resp := intelligentTieringListXML{Xmlns: s3Namespace, configurationListXML: newConfigurationList(next)}
+		for _, id := range ids {
+			resp.Configurations = append(resp.Configurations, intelligentTieringXML{ID: id, Status: "Enabled", Tierings: []tieringXML{{AccessTier: "ARCHIVE_ACCESS", Days: 90}}})
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "ListBucketAnalyticsConfigurations":
+		ids, next, fault := s.configurationPage(r.URL.Query(), b.AnalyticsIDs)
+		if fault != nil {
+			writeS3Error(w, r, bucket, fault)
+			return
+		}
+		resp := analyticsListXML{Xmlns: s3Namespace, configurationListXML: newConfigurationList(next)}
+		for _, id := range ids {
+			resp.Configurations = append(resp.Configurations, analyticsXML{ID: id})
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "ListBucketInventoryConfigurations":
+		ids, next, fault := s.configurationPage(r.URL.Query(), b.InventoryIDs)
+		if fault != nil {
+			writeS3Error(w, r, bucket, fault)
+			return
+		}
+		resp := inventoryListXML{Xmlns: s3Namespace, configurationListXML: newConfigurationList(next)}
+		for _, id := range ids {
+			resp.Configurations = append(resp.Configurations, inventoryXML{
+				ID: id, IsEnabled: true, IncludedObjectVersions: "Current", Frequency: "Daily",
+				DestinationBucket: "arn:aws:s3:::" + b.Name, DestinationFormat: "CSV",
+			})
+		}
+		writeXML(w, http.StatusOK, resp)
+	case "ListBucketMetricsConfigurations":
+		ids, next, fault := s.configurationPage(r.URL.Query(), b.MetricsIDs)
+		if fault != nil {
+			writeS3Error(w, r, bucket, fault)
+			return
+		}
+		resp := metricsListXML{Xmlns: s3Namespace, configurationListXML: newConfigurationList(next)}
+		for _, id := range ids {
+			resp.Configurations = append(resp.Configurations, metricsXML{ID: id})
+		}
+		writeXML(w, http.StatusOK, resp)
+	}
+}
+
+// configurationPage returns the ids on the page of a bucket configuration
+// listing selected by the continuation-token parameter, and the token of the
+// following page.
+func (s *Server) configurationPage(query url.Values, ids []string) (page []string, next string, fault *Fault) {
+	start := 0
+	if token := query.Get("continuation-token"); token != "" {
+		offset, err := strconv.Atoi(strings.TrimPrefix(token, "page-"))
+		if err != nil || !strings.HasPrefix(token, "page-") || offset < 0 || offset > len(ids) {
+			return nil, "", &Fault{Status: http.StatusBadRequest, Code: "InvalidArgument", Message: "The continuation token provided is incorrect"}
+		}
+		start = offset
+	}
+	size := s3ConfigurationPageSize
+	if s.pageSize > 0 && s.pageSize < size {
+		size = s.pageSize
+	}
+	end := start + size
+	if end >= len(ids) {
+		return ids[start:], "", nil
+	}
+	return ids[start:end], fmt.Sprintf("page-%d", end), nil
+}
+
+// s3Operation returns the bucket operation of r, or "" if it is not served.
+func s3Operation(r *http.Request) string {
+	switch r.Method {
+	case http.MethodHead:
+		return "HeadBucket"
+	case http.MethodGet:
+		query := r.URL.Query()
+		for param, operation := range s3SubResources {
+			if _, ok := query[param]; ok {
+				return operation
+			}
+		}
+	}
+	return ""
+}
+
+// signingRegion returns the region of the SigV4 credential scope of r.
+func signingRegion(r *http.Request) string {
+	_, scope, ok := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(scope, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+func (s *Server) bucket(name string) (Bucket, bool) {
+	for _, b := range s.buckets {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return Bucket{}, false
+}
+
+func (s *Server) bucketRegion(b Bucket) string {
+	if b.Region == "" {
+		return s.region
+	}
+	return b.Region
+}
+
+type listBucketsXML struct {
+	XMLName           xml.Name    `xml:"ListAllMyBucketsResult"`
+	Xmlns             string      `xml:"xmlns,attr"`
+	OwnerID           string      `xml:"Owner>ID"`
+	Buckets           []bucketXML `xml:"Buckets>Bucket"`
+	ContinuationToken string      `xml:"ContinuationToken,omitempty"`
+}
+
+type bucketXML struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate,omitempty"`
+	BucketRegion string `xml:"BucketRegion,omitempty"`
+}
+
+// listBuckets serves ListBuckets, paginating with max-buckets and
+// continuation-token when the caller asks for it.
+func (s *Server) listBuckets(w http.ResponseWriter, query url.Values) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buckets := s.buckets
+	start, end := 0, len(buckets)
+	if token := query.Get("continuation-token"); token != "" {
+		offset, err := strconv.Atoi(strings.TrimPrefix(token, "page-"))
+		if err != nil || offset < 0 || offset > len(buckets) {
+			writeXML(w, http.StatusBadRequest, s3ErrorResponse{Code: "InvalidArgument", Message: "The continuation token provided is incorrect", ReqID: requestID})
+			return
+		}
+		start = offset
+	}
+	// S3 only paginates when the caller sets max-buckets, so the page size
+	// option does too.
+	size := 0
+	if raw := query.Get("max-buckets"); raw != "" {
+		max, err := strconv.Atoi(raw)
+		if err != nil || max < 1 {
+			writeXML(w, http.StatusBadRequest, s3ErrorResponse{Code: "InvalidArgument", Message: "Invalid max-buckets value", ReqID: requestID})
+			return
+		}
+		size = max
+		if s.pageSize > 0 && s.pageSize < size {
+			size = s.pageSize
+		}
+	}
+
+	resp := listBucketsXML{Xmlns: s3Namespace, OwnerID: canonicalUserID}
+	if size > 0 && start+size < len(buckets) {
+		end = start + size
+		resp.ContinuationToken = fmt.Sprintf("page-%d", end)
+	}
+	for _, b := range buckets[start:end] {
+		resp.Buckets = append(resp.Buckets, bucketXML{Name: b.Name, CreationDate: formatTime(b.CreationDate), BucketRegion: s.bucketRegion(b)})
+	}
+	writeXML(w, http.StatusOK, resp)
+}
+
+type locationConstraintXML struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Value   string   `xml:",chardata"`
+}
+
+type s3TagXML struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type taggingXML struct {
+	XMLName xml.Name   `xml:"Tagging"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Tags    []s3TagXML `xml:"TagSet>Tag"`
+}
+
+type versioningXML struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+type aclXML struct {
+	XMLName xml.Name   `xml:"AccessControlPolicy"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	OwnerID string     `xml:"Owner>ID"`
+	Grants  []grantXML `xml:"AccessControlList>Grant"`
+}
+
+type grantXML struct {
+	Grantee    granteeXML `xml:"Grantee"`
+	Permission string     `xml:"Permission"`
+}
+
+type granteeXML struct {
+	XmlnsXSI string `xml:"xmlns:xsi,attr"`
+	Type     string `xml:"xsi:type,attr"`
+	ID       string `xml:"ID"`
+}
+
+// newOwnerACL returns the default ACL of a new bucket: full control for the
+// owner only.
+func newOwnerACL() aclXML {
+	return aclXML{
+		Xmlns:   s3Namespace,
+		OwnerID: canonicalUserID,
+		Grants: []grantXML{{
+			Grantee:    granteeXML{XmlnsXSI: "http://www.w3.org/2001/XMLSchema-instance", Type: "CanonicalUser", ID: canonicalUserID},
+			Permission: "FULL_CONTROL",
+		}},
+	}
+}
+
+type encryptionXML struct {
+	XMLName xml.Name          `xml:"ServerSideEncryptionConfiguration"`
+	Xmlns   string            `xml:"xmlns,attr"`
+	Rule    encryptionRuleXML `xml:"Rule"`
+}
+
+type encryptionRuleXML struct {
+	SSEAlgorithm     string `xml:"ApplyServerSideEncryptionByDefault>SSEAlgorithm"`
+	KMSMasterKeyID   string `xml:"ApplyServerSideEncryptionByDefault>KMSMasterKeyID,omitempty"`
+	BucketKeyEnabled bool   `xml:"BucketKeyEnabled"`
+}
+
+type lifecycleXML struct {
+	XMLName xml.Name           `xml:"LifecycleConfiguration"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Rules   []lifecycleRuleXML `xml:"Rule"`
+}
+
+type lifecycleRuleXML struct {
+	ID             string `xml:"ID"`
+	Prefix         string `xml:"Filter>Prefix"`
+	Status         string `xml:"Status"`
+	ExpirationDays int32  `xml:"Expiration>Days"`
+}
+
+type loggingXML struct {
+	XMLName xml.Name           `xml:"BucketLoggingStatus"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Enabled *loggingEnabledXML `xml:"LoggingEnabled,omitempty"`
+}
+
+type loggingEnabledXML struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+type websiteXML struct {
+	XMLName     xml.Name `xml:"WebsiteConfiguration"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	IndexSuffix string   `xml:"IndexDocument>Suffix"`
+}
+
+type corsXML struct {
+	XMLName xml.Name      `xml:"CORSConfiguration"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Rules   []corsRuleXML `xml:"CORSRule"`
+}
+
+type corsRuleXML struct {
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+}
+
+type publicAccessBlockXML struct {
+	XMLName               xml.Name `xml:"PublicAccessBlockConfiguration"`
+	Xmlns                 string   `xml:"xmlns,attr"`
+	BlockPublicAcls       bool     `xml:"BlockPublicAcls"`
+	IgnorePublicAcls      bool     `xml:"IgnorePublicAcls"`
+	BlockPublicPolicy     bool     `xml:"BlockPublicPolicy"`
+	RestrictPublicBuckets bool     `xml:"RestrictPublicBuckets"`
+}
+
+type ownershipControlsXML struct {
+	XMLName         xml.Name `xml:"OwnershipControls"`
+	Xmlns           string   `xml:"xmlns,attr"`
+	ObjectOwnership string   `xml:"Rule>ObjectOwnership"`
+}
+
+type accelerateXML struct {
+	XMLName xml.Name `xml:"AccelerateConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+type replicationXML struct {
+	XMLName xml.Name             `xml:"ReplicationConfiguration"`
+	Xmlns   string               `xml:"xmlns,attr"`
+	Role    string               `xml:"Role"`
+	Rules   []replicationRuleXML `xml:"Rule"`
+}
+
+type replicationRuleXML struct {
+	ID                      string `xml:"ID"`
+	Priority                int32  `xml:"Priority"`
+	Status                  string `xml:"Status"`
+	FilterPrefix            string `xml:"Filter>Prefix"`
+	DestinationBucket       string `xml:"Destination>Bucket"`
+	DeleteMarkerReplication string `xml:"DeleteMarkerReplication>Status"`
+}
+
+type notificationXML struct {
+	XMLName xml.Name                `xml:"NotificationConfiguration"`
+	Xmlns   string                  `xml:"xmlns,attr"`
+	Queues  []queueConfigurationXML `xml:"QueueConfiguration"`
+}
+
+type queueConfigurationXML struct {
+	ID          string          `xml:"Id"`
+	Queue       string          `xml:"Queue"`
+	Events      []string        `xml:"Event"`
+	FilterRules []filterRuleXML `xml:"Filter>S3Key>FilterRule"`
+}
+
+type filterRuleXML struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+type objectLockXML struct {
+	XMLName   xml.Name             `xml:"ObjectLockConfiguration"`
+	Xmlns     string               `xml:"xmlns,attr"`
+	Enabled   string               `xml:"ObjectLockEnabled"`
+	Retention *defaultRetentionXML `xml:"Rule>DefaultRetention,omitempty"`
+}
+
+type defaultRetentionXML struct {
+	Mode string `xml:"Mode"`
+	Days int32  `xml:"Days,omitempty"`
+}
+
+// configurationListXML holds the pagination fields shared by the bucket
+// configuration listings.
+type configurationListXML struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken,omitempty"`
+}
+
+func newConfigurationList(next string) configurationListXML {
+	return configurationListXML{IsTruncated: next != "", NextContinuationToken: next}
+}
+
+type intelligentTieringListXML struct {
+	XMLName xml.Name `xml:"ListBucketIntelligentTieringConfigurationsOutput"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	configurationListXML
+	Configurations []intelligentTieringXML `xml:"IntelligentTieringConfiguration"`
+}
+
+type intelligentTieringXML struct {
+	ID       string       `xml:"Id"`
+	Status   string       `xml:"Status"`
+	Tierings []tieringXML `xml:"Tiering"`
+}
+
+type tieringXML struct {
+	AccessTier string `xml:"AccessTier"`
+	Days       int32  `xml:"Days"`
+}
+
+type analyticsListXML struct {
+	XMLName xml.Name `xml:"ListBucketAnalyticsConfigurationResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	configurationListXML
+	Configurations []analyticsXML `xml:"AnalyticsConfiguration"`
+}
+
+type analyticsXML struct {
+	ID                   string   `xml:"Id"`
+	StorageClassAnalysis struct{} `xml:"StorageClassAnalysis"`
+}
+
+type inventoryListXML struct {
+	XMLName xml.Name `xml:"ListInventoryConfigurationsResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	configurationListXML
+	Configurations []inventoryXML `xml:"InventoryConfiguration"`
+}
+
+type inventoryXML struct {
+	ID                     string `xml:"Id"`
+	IsEnabled              bool   `xml:"IsEnabled"`
+	IncludedObjectVersions string `xml:"IncludedObjectVersions"`
+	Frequency              string `xml:"Schedule>Frequency"`
+	DestinationBucket      string `xml:"Destination>S3BucketDestination>Bucket"`
+	DestinationFormat      string `xml:"Destination>S3BucketDestination>Format"`
+}
+
+type metricsListXML struct {
+	XMLName xml.Name `xml:"ListMetricsConfigurationsResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	configurationListXML
+	Configurations []metricsXML `xml:"MetricsConfiguration"`
+}
+
+type metricsXML struct {
+	ID string `xml:"Id"`
+}
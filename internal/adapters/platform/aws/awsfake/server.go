@@ -0,0 +1,408 @@
+// Package awsfake is an in-process fake of the AWS APIs the platform handlers
+// call. Tests point real SDK clients at it through Config instead of stubbing
+// each client method, so requests go through the SDK serializers, paginators
+// and error deserializers exactly as they do against AWS.
+//
+// The fake covers STS GetCallerIdentity, the EC2 DescribeInstances,
+// DescribeInstanceAttribute, DescribeInstanceCreditSpecifications,
+// DescribeVolumes, DescribeSecurityGroups, DescribeLaunchTemplates,
+// DescribeLaunchTemplateVersions, DescribeAddresses and
+// DescribeNetworkInterfaces query operations, and the S3 bucket
+// operations the bucket mapper uses. Faults can be injected per operation to
+// exercise throttling and error paths.
+package awsfake
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// DefaultAccountID is the account returned by GetCallerIdentity.
+	DefaultAccountID = "123456789012"
+	// DefaultRegion is the region of Config and of buckets without one.
+	DefaultRegion = "us-east-1"
+
+	requestID = "00000000-0000-0000-0000-000000000000"
+)
+
+// Fault is an error response injected for an operation.
+type Fault struct {
+	// Status is the HTTP status code. Zero means 400.
+	Status int
+	// Code is the AWS error code, e.g. "RequestLimitExceeded" or "SlowDown".
+	Code string
+	// Message is the error message. Empty means a message derived from Code.
+	Message string
+	// Times is how many calls fail before the operation succeeds again. Zero
+	// fails every call.
+	Times int
+	// After is how many calls succeed before the fault applies, e.g. 1 to fail
+	// the second page of a listing.
+	After int
+}
+
+// Server is a fake AWS endpoint backed by in-memory resources. It is safe for
+// concurrent use by the handlers under test.
+type Server struct {
+	srv *httptest.Server
+
+	accountID string
+	region    string
+	pageSize  int
+
+	mu                sync.Mutex
+	instances         []Instance
+	volumes           []Volume
+	securityGroups    []SecurityGroup
+	launchTemplates   []LaunchTemplate
+	addresses         []Address
+	networkInterfaces []NetworkInterface
+	buckets           []Bucket
+	faults            map[string][]Fault
+	calls             map[string]int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAccountID sets the account returned by GetCallerIdentity.
+func WithAccountID(accountID string) Option {
+	return func(s *Server) {
+		if accountID != "" {
+			s.accountID = accountID
+		}
+	}
+}
+
+// WithRegion sets the region of Config and of buckets without one.
+func WithRegion(region string) Option {
+	return func(s *Server) {
+		if region != "" {
+			s.region = region
+		}
+	}
+}
+
+// WithPageSize caps the number of items returned per page by paginated
+// operations, regardless of the MaxResults the caller asks for, so tests can
+// exercise pagination with a handful of resources.
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.pageSize = n
+		}
+	}
+}
+
+// NewServer starts a fake AWS endpoint that is closed when the test ends.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	s := &Server{
+		accountID: DefaultAccountID,
+		region:    DefaultRegion,
+		faults:    make(map[string][]Fault),
+		calls:     make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL returns the base endpoint of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// AccountID returns the account returned by GetCallerIdentity.
+func (s *Server) AccountID() string {
+	return s.accountID
+}
+
+// Region returns the region of Config and of buckets without one.
+func (s *Server) Region() string {
+	return s.region
+}
+
+// Fail makes the next calls to operation return the fault. Faults queued for
+// the same operation are served in order.
+func (s *Server) Fail(operation string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[operation] = append(s.faults[operation], fault)
+}
+
+// Calls returns how many times operation was called, including failed calls.
+func (s *Server) Calls(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[operation]
+}
+
+// record counts a call to operation and returns the fault to serve, if any.
+func (s *Server) record(operation string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[operation]++
+
+	queue := s.faults[operation]
+	if len(queue) == 0 {
+		return nil
+	}
+	if queue[0].After > 0 {
+		queue[0].After--
+		return nil
+	}
+	fault := queue[0]
+	if fault.Times > 0 {
+		queue[0].Times--
+		if queue[0].Times == 0 {
+			s.faults[operation] = queue[1:]
+		}
+	}
+	if fault.Status == 0 {
+		fault.Status = http.StatusBadRequest
+	}
+	if fault.Message == "" {
+		fault.Message = fmt.Sprintf("injected %s fault", fault.Code)
+	}
+	return &fault
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.serveQuery(w, r.PostForm)
+		return
+	}
+	s.serveS3(w, r)
+}
+
+// serveQuery dispatches the awsquery and ec2query protocols, which share the
+// form encoded request format and differ only in the error envelope.
+func (s *Server) serveQuery(w http.ResponseWriter, form url.Values) {
+	action := form.Get("Action")
+	if action == "GetCallerIdentity" {
+		if fault := s.record(action); fault != nil {
+			writeQueryError(w, fault)
+			return
+		}
+		s.getCallerIdentity(w)
+		return
+	}
+
+	handler, ok := map[string]func(http.ResponseWriter, url.Values){
+		"DescribeInstances":                    s.describeInstances,
+		"DescribeInstanceAttribute":            s.describeInstanceAttribute,
+		"DescribeInstanceCreditSpecifications": s.describeInstanceCreditSpecifications,
+		"DescribeVolumes":                      s.describeVolumes,
+		"DescribeSecurityGroups":               s.describeSecurityGroups,
+		"DescribeLaunchTemplates":              s.describeLaunchTemplates,
+		"DescribeLaunchTemplateVersions":       s.describeLaunchTemplateVersions,
+		"DescribeAddresses":                    s.describeAddresses,
+		"DescribeNetworkInterfaces":            s.describeNetworkInterfaces,
+	}[action]
+	if !ok {
+		writeEC2Error(w, &Fault{Status: http.StatusBadRequest, Code: "InvalidAction", Message: fmt.Sprintf("The action %s is not valid for this web service.", action)})
+		return
+	}
+	if fault := s.record(action); fault != nil {
+		writeEC2Error(w, fault)
+		return
+	}
+	handler(w, form)
+}
+
+type stsGetCallerIdentityResponse struct {
+	XMLName xml.Name `xml:"https://sts.amazonaws.com/doc/2011-06-15/ GetCallerIdentityResponse"`
+	Arn     string   `xml:"GetCallerIdentityResult>Arn"`
+	UserID  string   `xml:"GetCallerIdentityResult>UserId"`
+	Account string   `xml:"GetCallerIdentityResult>Account"`
+	ReqID   string   `xml:"ResponseMetadata>RequestId"`
+}
+
+func (s *Server) getCallerIdentity(w http.ResponseWriter) {
+	writeXML(w, http.StatusOK, stsGetCallerIdentityResponse{
+		Arn:     fmt.Sprintf("arn:aws:iam::%s:user/awsfake", s.accountID),
+		UserID:  "AIDAAWSFAKE",
+		Account: s.accountID,
+		ReqID:   requestID,
+	})
+}
+
+type queryErrorResponse struct {
+	XMLName xml.Name `xml:"ErrorResponse"`
+	Type    string   `xml:"Error>Type"`
+	Code    string   `xml:"Error>Code"`
+	Message string   `xml:"Error>Message"`
+	ReqID   string   `xml:"RequestId"`
+}
+
+func writeQueryError(w http.ResponseWriter, fault *Fault) {
+	errType := "Sender"
+	if fault.Status >= http.StatusInternalServerError {
+		errType = "Receiver"
+	}
+	writeXML(w, fault.Status, queryErrorResponse{Type: errType, Code: fault.Code, Message: fault.Message, ReqID: requestID})
+}
+
+type ec2ErrorResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Code    string   `xml:"Errors>Error>Code"`
+	Message string   `xml:"Errors>Error>Message"`
+	ReqID   string   `xml:"RequestID"`
+}
+
+func writeEC2Error(w http.ResponseWriter, fault *Fault) {
+	writeXML(w, fault.Status, ec2ErrorResponse{Code: fault.Code, Message: fault.Message, ReqID: requestID})
+}
+
+func writeXML(w http.ResponseWriter, status int, body any) {
+	out, err := xml.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml;charset=UTF-8")
+	w.Header().Set("X-Amz-Request-Id", requestID)
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(out)
+}
+
+// page returns the bounds of the page of n items selected by the MaxResults
+// and NextToken parameters, and the token of the following page.
+func (s *Server) page(form url.Values, n int) (start, end int, next string, fault *Fault) {
+	size := n
+	if raw := form.Get("MaxResults"); raw != "" {
+		max, err := strconv.Atoi(raw)
+		if err != nil || max < 1 {
+			return 0, 0, "", &Fault{Status: http.StatusBadRequest, Code: "InvalidParameterValue", Message: fmt.Sprintf("Value (%s) for parameter maxResults is invalid.", raw)}
+		}
+		size = max
+	}
+	if s.pageSize > 0 && s.pageSize < size {
+		size = s.pageSize
+	}
+	if token := form.Get("NextToken"); token != "" {
+		offset, err := strconv.Atoi(strings.TrimPrefix(token, "page-"))
+		if err != nil || !strings.HasPrefix(token, "page-") || offset < 0 || offset > n {
+			return 0, 0, "", &Fault{Status: http.StatusBadRequest, Code: "InvalidParameterValue", Message: fmt.Sprintf("Invalid value '%s' for nextToken", token)}
+		}
+		start = offset
+	}
+	end = start + size
+	if end >= n {
+		return start, n, "", nil
+	}
+	return start, end, fmt.Sprintf("page-%d", end), nil
+}
+
+// listParam returns the values of a query list parameter such as
+// InstanceId.1, InstanceId.2.
+func listParam(form url.Values, prefix string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		v, ok := form[fmt.Sprintf("%s.%d", prefix, i)]
+		if !ok || len(v) == 0 {
+			return values
+		}
+		values = append(values, v[0])
+	}
+}
+
+// filterParams returns the Filter.N.Name and Filter.N.Value.M parameters as
+// filter name to accepted values.
+func filterParams(form url.Values) map[string][]string {
+	filters := make(map[string][]string)
+	for i := 1; ; i++ {
+		name := form.Get(fmt.Sprintf("Filter.%d.Name", i))
+		if name == "" {
+			return filters
+		}
+		filters[name] = append(filters[name], listParam(form, fmt.Sprintf("Filter.%d.Value", i))...)
+	}
+}
+
+// matchFilters reports whether the values a resource exposes under each
+// filter name satisfy every filter. Tag filters are resolved against tags.
+// It returns the name of the first filter the resource type does not support.
+func matchFilters(filters map[string][]string, fields map[string][]string, tags map[string]string) (bool, string) {
+	for name, accepted := range filters {
+		var actual []string
+		switch {
+		case strings.HasPrefix(name, "tag:"):
+			if v, ok := tags[strings.TrimPrefix(name, "tag:")]; ok {
+				actual = []string{v}
+			}
+		case name == "tag-key":
+			for k := range tags {
+				actual = append(actual, k)
+			}
+		default:
+			values, ok := fields[name]
+			if !ok {
+				return false, name
+			}
+			actual = values
+		}
+		if !anyMatch(accepted, actual) {
+			return false, ""
+		}
+	}
+	return true, ""
+}
+
+func anyMatch(accepted, actual []string) bool {
+	for _, a := range accepted {
+		for _, v := range actual {
+			if matchWildcard(a, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchWildcard matches the * wildcard EC2 filters accept.
+func matchWildcard(pattern, value string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == value
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,376 @@
+package awsfake
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorBody struct {
+	Code    string `xml:"Errors>Error>Code"`
+	Message string `xml:"Errors>Error>Message"`
+}
+
+func query(t *testing.T, s *Server, params url.Values, out any) int {
+	t.Helper()
+	resp, err := http.PostForm(s.URL(), params)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, xml.Unmarshal(body, out), string(body))
+	return resp.StatusCode
+}
+
+func s3Get(t *testing.T, s *Server, method, path, region string) (int, http.Header, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL()+path, nil)
+	require.NoError(t, err)
+	if region != "" {
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=AKIDAWSFAKE/20240101/"+region+"/s3/aws4_request, SignedHeaders=host, Signature=0")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, resp.Header, body
+}
+
+func TestGetCallerIdentity(t *testing.T) {
+	s := NewServer(t, WithAccountID("210987654321"))
+
+	var out struct {
+		Account string `xml:"GetCallerIdentityResult>Account"`
+	}
+	status := query(t, s, url.Values{"Action": {"GetCallerIdentity"}, "Version": {"2011-06-15"}}, &out)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "210987654321", out.Account)
+	assert.Equal(t, 1, s.Calls("GetCallerIdentity"))
+}
+
+func TestDescribeSecurityGroups_Paginates(t *testing.T) {
+	s := NewServer(t, WithPageSize(2))
+	s.AddSecurityGroups(
+		SecurityGroup{ID: "sg-1", Name: "web", VpcID: "vpc-1"},
+		SecurityGroup{ID: "sg-2", Name: "db", VpcID: "vpc-1"},
+		SecurityGroup{ID: "sg-3", Name: "other", VpcID: "vpc-2"},
+	)
+
+	type page struct {
+		Groups    []string `xml:"securityGroupInfo>item>groupId"`
+		NextToken string   `xml:"nextToken"`
+	}
+	params := url.Values{"Action": {"DescribeSecurityGroups"}, "MaxResults": {"1000"}}
+	var first page
+	require.Equal(t, http.StatusOK, query(t, s, params, &first))
+	assert.Equal(t, []string{"sg-1", "sg-2"}, first.Groups)
+	require.NotEmpty(t, first.NextToken)
+
+	params.Set("NextToken", first.NextToken)
+	var second page
+	require.Equal(t, http.StatusOK, query(t, s, params, &second))
+	assert.Equal(t, []string{"sg-3"}, second.Groups)
+	assert.Empty(t, second.NextToken)
+	assert.Equal(t, 2, s.Calls("DescribeSecurityGroups"))
+}
+
+func TestDescribeSecurityGroups_Filters(t *testing.T) {
+	s := NewServer(t)
+	s.AddSecurityGroups(
+		SecurityGroup{ID: "sg-1", VpcID: "vpc-1", Tags: map[string]string{"Team": "web"}},
+		SecurityGroup{ID: "sg-2", VpcID: "vpc-2", Tags: map[string]string{"Team": "web"}},
+		SecurityGroup{ID: "sg-3", VpcID: "vpc-1"},
+	)
+
+	var out struct {
+		Groups []string `xml:"securityGroupInfo>item>groupId"`
+	}
+	status := query(t, s, url.Values{
+		"Action":           {"DescribeSecurityGroups"},
+		"Filter.1.Name":    {"vpc-id"},
+		"Filter.1.Value.1": {"vpc-1"},
+		"Filter.2.Name":    {"tag:Team"},
+		"Filter.2.Value.1": {"w*"},
+	}, &out)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"sg-1"}, out.Groups)
+}
+
+func TestDescribeInstances_UnknownIDAndFilter(t *testing.T) {
+	s := NewServer(t)
+	s.AddInstances(Instance{ID: "i-1"})
+
+	var notFound errorBody
+	status := query(t, s, url.Values{"Action": {"DescribeInstances"}, "InstanceId.1": {"i-1"}, "InstanceId.2": {"i-2"}}, &notFound)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "InvalidInstanceID.NotFound", notFound.Code)
+
+	var badFilter errorBody
+	status = query(t, s, url.Values{"Action": {"DescribeInstances"}, "Filter.1.Name": {"no-such-filter"}, "Filter.1.Value.1": {"x"}}, &badFilter)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "InvalidParameterValue", badFilter.Code)
+}
+
+func TestDescribeInstances_AttachedVolumes(t *testing.T) {
+	s := NewServer(t)
+	s.AddInstances(Instance{ID: "i-1", VolumeIDs: []string{"vol-root", "vol-data"}, UserData: "#!/bin/sh"})
+	s.AddVolumes(Volume{ID: "vol-root", Size: 8}, Volume{ID: "vol-data", Size: 100})
+
+	var instances struct {
+		Devices []string `xml:"reservationSet>item>instancesSet>item>blockDeviceMapping>item>deviceName"`
+	}
+	query(t, s, url.Values{"Action": {"DescribeInstances"}}, &instances)
+	assert.Equal(t, []string{"/dev/xvda", "/dev/sdf"}, instances.Devices)
+
+	var volumes struct {
+		Instances []string `xml:"volumeSet>item>attachmentSet>item>instanceId"`
+	}
+	query(t, s, url.Values{"Action": {"DescribeVolumes"}, "VolumeId.1": {"vol-data"}}, &volumes)
+	assert.Equal(t, []string{"i-1"}, volumes.Instances)
+
+	var attr struct {
+		UserData string `xml:"userData>value"`
+	}
+	query(t, s, url.Values{"Action": {"DescribeInstanceAttribute"}, "InstanceId": {"i-1"}, "Attribute": {"userData"}}, &attr)
+	assert.Equal(t, "IyEvYmluL3No", attr.UserData)
+}
+
+func TestDescribeInstanceCreditSpecifications(t *testing.T) {
+	s := NewServer(t)
+	s.AddInstances(
+		Instance{ID: "i-t2", InstanceType: "t2.micro"},
+		Instance{ID: "i-t3", InstanceType: "t3.small", CPUCredits: "standard", Tenancy: "dedicated", PlacementGroup: "pg-1", EBSOptimized: true},
+		Instance{ID: "i-m5", InstanceType: "m5.large"},
+	)
+
+	var specs struct {
+		Instances []string `xml:"instanceCreditSpecificationSet>item>instanceId"`
+		Credits   []string `xml:"instanceCreditSpecificationSet>item>cpuCredits"`
+	}
+	query(t, s, url.Values{"Action": {"DescribeInstanceCreditSpecifications"}}, &specs)
+	assert.Equal(t, []string{"i-t2", "i-t3"}, specs.Instances, "only burstable instances have a credit mode")
+	assert.Equal(t, []string{"standard", "standard"}, specs.Credits)
+
+	var placement struct {
+		Group        string `xml:"reservationSet>item>instancesSet>item>placement>groupName"`
+		Tenancy      string `xml:"reservationSet>item>instancesSet>item>placement>tenancy"`
+		EBSOptimized bool   `xml:"reservationSet>item>instancesSet>item>ebsOptimized"`
+	}
+	query(t, s, url.Values{"Action": {"DescribeInstances"}, "InstanceId.1": {"i-t3"}}, &placement)
+	assert.Equal(t, "pg-1", placement.Group)
+	assert.Equal(t, "dedicated", placement.Tenancy)
+	assert.True(t, placement.EBSOptimized)
+}
+
+func TestFail(t *testing.T) {
+	s := NewServer(t)
+	s.Fail("DescribeSecurityGroups", Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", Times: 2})
+	params := url.Values{"Action": {"DescribeSecurityGroups"}}
+
+	for i := 0; i < 2; i++ {
+		var out errorBody
+		assert.Equal(t, http.StatusServiceUnavailable, query(t, s, params, &out))
+		assert.Equal(t, "RequestLimitExceeded", out.Code)
+	}
+	var out struct {
+		XMLName xml.Name
+	}
+	assert.Equal(t, http.StatusOK, query(t, s, params, &out))
+	assert.Equal(t, 3, s.Calls("DescribeSecurityGroups"))
+}
+
+func TestFail_QueryErrorEnvelope(t *testing.T) {
+	s := NewServer(t)
+	s.Fail("GetCallerIdentity", Fault{Status: http.StatusForbidden, Code: "ExpiredToken"})
+
+	var out struct {
+		XMLName xml.Name
+		Code    string `xml:"Error>Code"`
+	}
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusForbidden, query(t, s, url.Values{"Action": {"GetCallerIdentity"}}, &out))
+		assert.Equal(t, "ErrorResponse", out.XMLName.Local)
+		assert.Equal(t, "ExpiredToken", out.Code)
+	}
+}
+
+func TestS3Bucket(t *testing.T) {
+	s := NewServer(t)
+	s.AddBuckets(Bucket{Name: "logs", Region: "eu-west-1", Tags: map[string]string{"Env": "prod"}, Policy: `{"Version":"2012-10-17"}`})
+
+	status, header, _ := s3Get(t, s, http.MethodHead, "/logs", "us-east-1")
+	assert.Equal(t, http.StatusMovedPermanently, status)
+	assert.Equal(t, "eu-west-1", header.Get("X-Amz-Bucket-Region"))
+
+	status, _, body := s3Get(t, s, http.MethodGet, "/logs?location", "us-east-1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, string(body), ">eu-west-1</LocationConstraint>")
+
+	status, _, body = s3Get(t, s, http.MethodGet, "/logs?tagging", "eu-west-1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, string(body), "<Key>Env</Key><Value>prod</Value>")
+
+	status, _, body = s3Get(t, s, http.MethodGet, "/logs?policy", "eu-west-1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"Version":"2012-10-17"}`, string(body))
+
+	status, _, body = s3Get(t, s, http.MethodGet, "/logs?cors", "eu-west-1")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, string(body), "<Code>NoSuchCORSConfiguration</Code>")
+
+	status, _, body = s3Get(t, s, http.MethodGet, "/missing?acl", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, string(body), "<Code>NoSuchBucket</Code>")
+
+	status, _, body = s3Get(t, s, http.MethodHead, "/missing", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Empty(t, body)
+}
+
+func TestS3ListBuckets_Paginates(t *testing.T) {
+	s := NewServer(t, WithPageSize(2))
+	s.AddBuckets(Bucket{Name: "a"}, Bucket{Name: "b"}, Bucket{Name: "c"})
+
+	type page struct {
+		Buckets []string `xml:"Buckets>Bucket>Name"`
+		Token   string   `xml:"ContinuationToken"`
+	}
+	list := func(path string) page {
+		status, _, body := s3Get(t, s, http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, status)
+		var p page
+		require.NoError(t, xml.Unmarshal(body, &p))
+		return p
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, list("/?x-id=ListBuckets").Buckets, "unpaginated without max-buckets")
+
+	first := list("/?max-buckets=10")
+	assert.Equal(t, []string{"a", "b"}, first.Buckets)
+	require.NotEmpty(t, first.Token)
+	second := list("/?max-buckets=10&continuation-token=" + url.QueryEscape(first.Token))
+	assert.Equal(t, []string{"c"}, second.Buckets)
+	assert.Empty(t, second.Token)
+}
+
+func TestS3BucketConfigurations_Paginate(t *testing.T) {
+	s := NewServer(t, WithPageSize(2))
+	s.AddBuckets(Bucket{Name: "logs", MetricsIDs: []string{"a", "b", "c"}})
+
+	type page struct {
+		IDs       []string `xml:"MetricsConfiguration>Id"`
+		Truncated bool     `xml:"IsTruncated"`
+		Token     string   `xml:"NextContinuationToken"`
+	}
+	list := func(path string) page {
+		status, _, body := s3Get(t, s, http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, status)
+		var p page
+		require.NoError(t, xml.Unmarshal(body, &p))
+		return p
+	}
+
+	first := list("/logs?metrics")
+	assert.Equal(t, []string{"a", "b"}, first.IDs)
+	assert.True(t, first.Truncated)
+	second := list("/logs?metrics&continuation-token=" + url.QueryEscape(first.Token))
+	assert.Equal(t, []string{"c"}, second.IDs)
+	assert.False(t, second.Truncated)
+	assert.Empty(t, second.Token)
+
+	assert.Empty(t, list("/logs?inventory").IDs, "no configurations is an empty listing")
+	status, _, body := s3Get(t, s, http.MethodGet, "/logs?metrics&continuation-token=bogus", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), "<Code>InvalidArgument</Code>")
+}
+
+func TestDescribeLaunchTemplateVersions_ResolvesAliases(t *testing.T) {
+	s := NewServer(t)
+	s.AddLaunchTemplates(LaunchTemplate{ID: "lt-1", DefaultVersion: 1, Versions: []LaunchTemplateVersion{{ImageID: "ami-1"}, {ImageID: "ami-2"}}})
+
+	var out struct {
+		Versions []int64  `xml:"launchTemplateVersionSet>item>versionNumber"`
+		Images   []string `xml:"launchTemplateVersionSet>item>launchTemplateData>imageId"`
+	}
+	status := query(t, s, url.Values{
+		"Action":                  {"DescribeLaunchTemplateVersions"},
+		"LaunchTemplateId":        {"lt-1"},
+		"LaunchTemplateVersion.1": {"$Latest"},
+		"LaunchTemplateVersion.2": {"$Default"},
+		"LaunchTemplateVersion.3": {"2"},
+	}, &out)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []int64{2, 1}, out.Versions, "each version is returned once")
+	assert.Equal(t, []string{"ami-2", "ami-1"}, out.Images)
+
+	var notFound errorBody
+	status = query(t, s, url.Values{"Action": {"DescribeLaunchTemplateVersions"}, "LaunchTemplateId": {"lt-2"}, "LaunchTemplateVersion.1": {"$Latest"}}, &notFound)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "InvalidLaunchTemplateId.NotFound", notFound.Code)
+}
+
+func TestDescribeAddresses_Association(t *testing.T) {
+	s := NewServer(t)
+	s.AddAddresses(
+		Address{AllocationID: "eipalloc-1", PublicIP: "198.51.100.1", InstanceID: "i-1"},
+		Address{AllocationID: "eipalloc-2", PublicIP: "198.51.100.2"},
+	)
+
+	var out struct {
+		Allocations  []string `xml:"addressesSet>item>allocationId"`
+		Associations []string `xml:"addressesSet>item>associationId"`
+	}
+	status := query(t, s, url.Values{"Action": {"DescribeAddresses"}, "Filter.1.Name": {"public-ip"}, "Filter.1.Value.1": {"198.51.100.*"}}, &out)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"eipalloc-1", "eipalloc-2"}, out.Allocations)
+	assert.Equal(t, []string{"eipassoc-1"}, out.Associations, "only associated addresses have an association")
+
+	var notFound errorBody
+	status = query(t, s, url.Values{"Action": {"DescribeAddresses"}, "AllocationId.1": {"eipalloc-3"}}, &notFound)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "InvalidAllocationID.NotFound", notFound.Code)
+}
+
+func TestDescribeNetworkInterfaces_Attachment(t *testing.T) {
+	s := NewServer(t)
+	s.AddNetworkInterfaces(
+		NetworkInterface{ID: "eni-1", PrivateIPs: []string{"10.0.0.5", "10.0.0.6"}, InstanceID: "i-1", DeviceIndex: 1},
+		NetworkInterface{ID: "eni-2", PrivateIPs: []string{"10.0.0.7"}},
+	)
+
+	var out struct {
+		IDs       []string `xml:"networkInterfaceSet>item>networkInterfaceId"`
+		Primary   []string `xml:"networkInterfaceSet>item>privateIpAddress"`
+		Instances []string `xml:"networkInterfaceSet>item>attachment>instanceId"`
+		Statuses  []string `xml:"networkInterfaceSet>item>status"`
+	}
+	status := query(t, s, url.Values{"Action": {"DescribeNetworkInterfaces"}}, &out)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"eni-1", "eni-2"}, out.IDs)
+	assert.Equal(t, []string{"10.0.0.5", "10.0.0.7"}, out.Primary)
+	assert.Equal(t, []string{"i-1"}, out.Instances)
+	assert.Equal(t, []string{"in-use", "available"}, out.Statuses)
+
+	var notFound errorBody
+	status = query(t, s, url.Values{"Action": {"DescribeNetworkInterfaces"}, "NetworkInterfaceId.1": {"eni-3"}}, &notFound)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", notFound.Code)
+}
+
+func TestMatchWildcard(t *testing.T) {
+	assert.True(t, matchWildcard("*", "anything"))
+	assert.True(t, matchWildcard("web-*", "web-1"))
+	assert.True(t, matchWildcard("*-prod-*", "app-prod-1"))
+	assert.False(t, matchWildcard("ab*ba", "aba"))
+	assert.False(t, matchWildcard("web", "web-1"))
+	assert.True(t, strings.HasPrefix(deviceName(2), "/dev/sdg"))
+}
@@ -0,0 +1,146 @@
+package cloudcontrol
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	signingName   = "cloudcontrolapi"
+	targetPrefix  = "CloudApiService."
+	jsonVersion10 = "application/x-amz-json-1.0"
+)
+
+// Client is a minimal Cloud Control API client speaking the awsJson1_0 protocol,
+// signed with the credentials and HTTP client from the shared aws.Config.
+type Client struct {
+	httpClient  aws.HTTPClient
+	credentials aws.CredentialsProvider
+	region      string
+	endpoint    string
+	signer      *v4.Signer
+}
+
+// NewFromConfig creates a Cloud Control client from an AWS config.
+func NewFromConfig(cfg aws.Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	endpoint := fmt.Sprintf("https://cloudcontrolapi.%s.amazonaws.com", cfg.Region)
+	if cfg.BaseEndpoint != nil && *cfg.BaseEndpoint != "" {
+		endpoint = strings.TrimRight(*cfg.BaseEndpoint, "/")
+	}
+	return &Client{
+		httpClient:  httpClient,
+		credentials: cfg.Credentials,
+		region:      cfg.Region,
+		endpoint:    endpoint,
+		signer:      v4.NewSigner(),
+	}
+}
+
+func (c *Client) ListResources(ctx context.Context, params *ListResourcesInput) (*ListResourcesOutput, error) {
+	out := &ListResourcesOutput{}
+	if err := c.invoke(ctx, "ListResources", params, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetResource(ctx context.Context, params *GetResourceInput) (*GetResourceOutput, error) {
+	out := &GetResourceOutput{}
+	if err := c.invoke(ctx, "GetResource", params, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) invoke(ctx context.Context, operation string, input, output any) error {
+	if c.credentials == nil {
+		return &smithy.OperationError{ServiceID: "CloudControl", OperationName: operation, Err: fmt.Errorf("no AWS credentials configured")}
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return &smithy.SerializationError{Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", jsonVersion10)
+	req.Header.Set("X-Amz-Target", targetPrefix+operation)
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return &smithy.OperationError{ServiceID: "CloudControl", OperationName: operation, Err: err}
+	}
+	sum := sha256.Sum256(payload)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), signingName, c.region, time.Now()); err != nil {
+		return &smithy.OperationError{ServiceID: "CloudControl", OperationName: operation, Err: err}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &smithy.OperationError{ServiceID: "CloudControl", OperationName: operation, Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &smithy.DeserializationError{Err: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeAPIError(resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, output); err != nil {
+		return &smithy.DeserializationError{Err: err, Snapshot: body}
+	}
+	return nil
+}
+
+// decodeAPIError converts an awsJson error body into a smithy.APIError so the
+// shared AWS error handler can classify it like SDK-generated errors.
+func decodeAPIError(status int, body []byte) error {
+	var payload struct {
+		Type         string `json:"__type"`
+		Code         string `json:"code"`
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	code := payload.Type
+	if code == "" {
+		code = payload.Code
+	}
+	if idx := strings.LastIndex(code, "#"); idx >= 0 {
+		code = code[idx+1:]
+	}
+	if code == "" {
+		code = http.StatusText(status)
+	}
+	msg := payload.Message
+	if msg == "" {
+		msg = payload.MessageUpper
+	}
+
+	fault := smithy.FaultClient
+	if status >= 500 {
+		fault = smithy.FaultServer
+	}
+	return &smithy.GenericAPIError{Code: code, Message: msg, Fault: fault}
+}
@@ -0,0 +1,61 @@
+package cloudcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(endpoint string) aws.Config {
+	return aws.Config{
+		Region:       "eu-west-1",
+		BaseEndpoint: aws.String(endpoint),
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+	}
+}
+
+func TestClient_ListResources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "CloudApiService.ListResources", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256"))
+
+		var in ListResourcesInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, "AWS::SQS::Queue", in.TypeName)
+
+		_, _ = w.Write([]byte(`{"TypeName":"AWS::SQS::Queue","ResourceDescriptions":[{"Identifier":"q1","Properties":"{\"QueueName\":\"q1\"}"}]}`))
+	}))
+	defer srv.Close()
+
+	out, err := NewFromConfig(testConfig(srv.URL)).ListResources(context.Background(), &ListResourcesInput{TypeName: "AWS::SQS::Queue"})
+	require.NoError(t, err)
+	require.Len(t, out.ResourceDescriptions, 1)
+	assert.Equal(t, "q1", out.ResourceDescriptions[0].Identifier)
+	assert.Empty(t, out.NextToken)
+}
+
+func TestClient_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazon.cloudapiservice#ResourceNotFoundException","Message":"gone"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewFromConfig(testConfig(srv.URL)).GetResource(context.Background(), &GetResourceInput{TypeName: "AWS::SQS::Queue", Identifier: "q1"})
+	require.Error(t, err)
+
+	var apiErr smithy.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "ResourceNotFoundException", apiErr.ErrorCode())
+	assert.Equal(t, "gone", apiErr.ErrorMessage())
+}
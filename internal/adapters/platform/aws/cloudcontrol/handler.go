@@ -0,0 +1,248 @@
+package cloudcontrol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// FetcherCloudControl is the config value selecting the Cloud Control fetcher
+// for a user-defined kind.
+const FetcherCloudControl = "cloudcontrol"
+
+const listPageSize = 100
+
+// KindDefinition describes a user-defined kind backed by a Cloud Control resource type.
+type KindDefinition struct {
+	Kind        domain.ResourceKind
+	TypeName    string // e.g. AWS::SQS::Queue
+	PropertyMap map[string]string
+}
+
+// Handler lists and fetches resources of a single Cloud Control resource type.
+type Handler struct {
+	def          KindDefinition
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	client       CloudControlClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+	defaults     PlatformDefaultsLookup
+}
+
+// HandlerOption defines a function signature for configuring the Handler.
+type HandlerOption func(*Handler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *Handler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithCloudControlClient provides an option to set a custom Cloud Control client.
+func WithCloudControlClient(client CloudControlClientInterface) HandlerOption {
+	return func(h *Handler) {
+		if client != nil {
+			h.client = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *Handler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithPlatformDefaults provides an option to recognize the resources AWS
+// created itself with a lookup, for types whose resource model does not tell.
+func WithPlatformDefaults(lookup PlatformDefaultsLookup) HandlerOption {
+	return func(h *Handler) {
+		if lookup != nil {
+			h.defaults = lookup
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *Handler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a Cloud Control handler for the given kind definition.
+func NewHandler(cfg aws.Config, def KindDefinition, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		def:          def,
+		stsClient:    sts.NewFromConfig(cfg),
+		client:       NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) Kind() domain.ResourceKind {
+	return h.def.Kind
+}
+
+func (h *Handler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "CloudControl: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+func (h *Handler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for %s ListResources: %v", h.def.TypeName, accErr)
+	}
+
+	defaults := h.platformDefaults(ctx, logger)
+
+	logger.Debugf(ctx, "Starting Cloud Control listing for type %s", h.def.TypeName)
+	input := &ListResourcesInput{TypeName: h.def.TypeName, MaxResults: listPageSize}
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.client.ListResources(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("CloudControl", fmt.Sprintf("ListResources:%s:Page%d", h.def.TypeName, pageNum), err, ctx)
+		}
+
+		for _, desc := range output.ResourceDescriptions {
+			resource, mapErr := newCloudControlResource(desc, h.def, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to map %s resource %s, skipping", h.def.TypeName, desc.Identifier)
+				continue
+			}
+			if reason, ok := defaults[desc.Identifier]; ok {
+				resource.meta.PlatformDefault = reason
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	logger.Debugf(ctx, "Finished Cloud Control listing for type %s (%d pages)", h.def.TypeName, pageNum)
+	return nil
+}
+
+func (h *Handler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.client.GetResource(ctx, &GetResourceInput{TypeName: h.def.TypeName, Identifier: id})
+	if err != nil {
+		return nil, aws_errors.HandleAWSError(h.def.TypeName, id, err, ctx)
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for %s GetResource: %v", h.def.TypeName, accErr)
+	}
+
+	desc := output.ResourceDescription
+	if desc.Identifier == "" {
+		desc.Identifier = id
+	}
+	resource, err := newCloudControlResource(desc, h.def, cfg.Region, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if reason, ok := h.platformDefaults(ctx, logger)[desc.Identifier]; ok {
+		resource.meta.PlatformDefault = reason
+	}
+	return resource, nil
+}
+
+// platformDefaults asks the lookup, if any, which resources of the type AWS
+// created. Failures only cost the recognition, so they are logged and ignored.
+func (h *Handler) platformDefaults(ctx context.Context, logger ports.Logger) map[string]string {
+	if h.defaults == nil {
+		return nil
+	}
+	defaults, err := h.defaults.PlatformDefaults(ctx, h.def.TypeName)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to look up AWS default resources of type %s, they are reported like any other: %v", h.def.TypeName, err)
+		return nil
+	}
+	return defaults
+}
+
+// Probe verifies that the resource type can be listed by requesting a single resource.
+func (h *Handler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.client.ListResources(ctx, &ListResourcesInput{TypeName: h.def.TypeName, MaxResults: 1}); err != nil {
+		return h.errorHandler.Handle("CloudControl", "ListResources:"+h.def.TypeName, err, ctx)
+	}
+	return nil
+}
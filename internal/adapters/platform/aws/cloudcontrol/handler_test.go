@@ -0,0 +1,234 @@
+package cloudcontrol
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const queueType = "AWS::SQS::Queue"
+
+// fakeClient serves ListResources from pages, one per call, and GetResource
+// from resources keyed by identifier.
+type fakeClient struct {
+	pages      [][]ResourceDescription
+	resources  map[string]ResourceDescription
+	listErr    error
+	listInputs []ListResourcesInput
+}
+
+func (c *fakeClient) ListResources(_ context.Context, params *ListResourcesInput) (*ListResourcesOutput, error) {
+	c.listInputs = append(c.listInputs, *params)
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	page, _ := strconv.Atoi(params.NextToken)
+	out := &ListResourcesOutput{TypeName: params.TypeName}
+	if page < len(c.pages) {
+		out.ResourceDescriptions = c.pages[page]
+	}
+	if page+1 < len(c.pages) {
+		out.NextToken = strconv.Itoa(page + 1)
+	}
+	return out, nil
+}
+
+func (c *fakeClient) GetResource(_ context.Context, params *GetResourceInput) (*GetResourceOutput, error) {
+	desc, ok := c.resources[params.Identifier]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException", Message: "gone", Fault: smithy.FaultClient}
+	}
+	return &GetResourceOutput{TypeName: params.TypeName, ResourceDescription: desc}, nil
+}
+
+type fakeDefaults struct {
+	defaults map[string]string
+	err      error
+}
+
+func (d fakeDefaults) PlatformDefaults(context.Context, string) (map[string]string, error) {
+	return d.defaults, d.err
+}
+
+func newLogger(t *testing.T) *portsmocks.Logger {
+	logger := portsmocks.NewLogger(t)
+	for _, method := range []string{"Debugf", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 5; args = append(args, mock.Anything) {
+			logger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+		logger.On("Errorf", args...).Maybe().Return()
+	}
+	return logger
+}
+
+func newTestHandler(t *testing.T, client *fakeClient, opts ...HandlerOption) *Handler {
+	t.Helper()
+	stsClient := sharedmocks.NewSTSClientInterface(t)
+	stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil).Maybe()
+	limiter := sharedmocks.NewRateLimiter(t)
+	limiter.On("Wait", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	def := KindDefinition{Kind: "Queue", TypeName: queueType, PropertyMap: map[string]string{"visibility_timeout_seconds": "VisibilityTimeout"}}
+	opts = append([]HandlerOption{
+		WithSTSClient(stsClient),
+		WithCloudControlClient(client),
+		WithRateLimiter(limiter),
+	}, opts...)
+	return NewHandler(aws.Config{Region: "eu-west-1"}, def, opts...)
+}
+
+func collect(t *testing.T, h *Handler) ([]domain.PlatformResource, error) {
+	t.Helper()
+	out := make(chan domain.PlatformResource, 10)
+	err := h.ListResources(context.Background(), aws.Config{Region: "eu-west-1"}, nil, newLogger(t), out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func TestHandler_ListResources_FollowsPages(t *testing.T) {
+	client := &fakeClient{pages: [][]ResourceDescription{
+		{{Identifier: "https://sqs/orders", Properties: `{"QueueName": "orders", "VisibilityTimeout": 30}`}},
+		{{Identifier: "https://sqs/invoices", Properties: `{"QueueName": "invoices"}`}},
+	}}
+	h := newTestHandler(t, client)
+
+	resources, err := collect(t, h)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	require.Len(t, client.listInputs, 2)
+	assert.Equal(t, ListResourcesInput{TypeName: queueType, MaxResults: listPageSize}, client.listInputs[0])
+	assert.Equal(t, "1", client.listInputs[1].NextToken)
+
+	meta := resources[0].Metadata()
+	assert.Equal(t, domain.ResourceKind("Queue"), meta.Kind)
+	assert.Equal(t, "https://sqs/orders", meta.ProviderAssignedID)
+	assert.Equal(t, "123456789012", meta.AccountID)
+	assert.Equal(t, "eu-west-1", meta.Region)
+	attrs, err := resources[0].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "orders", attrs["queue_name"])
+	assert.Equal(t, float64(30), attrs["visibility_timeout_seconds"])
+	assert.Equal(t, "https://sqs/orders", attrs[domain.KeyID])
+}
+
+func TestHandler_ListResources_SkipsUnmappableResources(t *testing.T) {
+	client := &fakeClient{pages: [][]ResourceDescription{{
+		{Identifier: "broken", Properties: "{not json"},
+		{Identifier: "orders", Properties: `{"QueueName": "orders"}`},
+	}}}
+
+	resources, err := collect(t, newTestHandler(t, client))
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "orders", resources[0].Metadata().ProviderAssignedID)
+}
+
+func TestHandler_ListResources_MarksPlatformDefaults(t *testing.T) {
+	client := &fakeClient{pages: [][]ResourceDescription{{
+		{Identifier: "orders", Properties: `{}`},
+		{Identifier: "aws-managed", Properties: `{}`},
+	}}}
+	h := newTestHandler(t, client, WithPlatformDefaults(fakeDefaults{defaults: map[string]string{"aws-managed": "created by AWS"}}))
+
+	resources, err := collect(t, h)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Empty(t, resources[0].Metadata().PlatformDefault)
+	assert.Equal(t, "created by AWS", resources[1].Metadata().PlatformDefault)
+}
+
+func TestHandler_ListResources_IgnoresFailedDefaultsLookup(t *testing.T) {
+	client := &fakeClient{pages: [][]ResourceDescription{{{Identifier: "orders", Properties: `{}`}}}}
+	h := newTestHandler(t, client, WithPlatformDefaults(fakeDefaults{err: stderrors.New("denied")}))
+
+	resources, err := collect(t, h)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Empty(t, resources[0].Metadata().PlatformDefault)
+}
+
+func TestHandler_ListResources_APIError(t *testing.T) {
+	client := &fakeClient{listErr: &smithy.GenericAPIError{Code: "ThrottlingException", Message: "slow down"}}
+
+	resources, err := collect(t, newTestHandler(t, client))
+
+	require.Error(t, err)
+	assert.True(t, idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	assert.Empty(t, resources)
+}
+
+func TestHandler_ListResources_WithoutAccountID(t *testing.T) {
+	client := &fakeClient{pages: [][]ResourceDescription{{{Identifier: "orders", Properties: `{}`}}}}
+	stsClient := sharedmocks.NewSTSClientInterface(t)
+	stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).Return(nil, stderrors.New("no identity"))
+	h := newTestHandler(t, client, WithSTSClient(stsClient))
+
+	resources, err := collect(t, h)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Empty(t, resources[0].Metadata().AccountID)
+}
+
+func TestHandler_GetResource(t *testing.T) {
+	client := &fakeClient{resources: map[string]ResourceDescription{
+		// Cloud Control may leave the identifier out of a single resource.
+		"orders": {Properties: `{"QueueName": "orders", "Tags": [{"Key": "Env", "Value": "prod"}]}`},
+	}}
+	h := newTestHandler(t, client)
+
+	resource, err := h.GetResource(context.Background(), aws.Config{Region: "eu-west-1"}, "orders", newLogger(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, "orders", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func TestHandler_GetResource_NotFound(t *testing.T) {
+	h := newTestHandler(t, &fakeClient{})
+
+	_, err := h.GetResource(context.Background(), aws.Config{Region: "eu-west-1"}, "missing", newLogger(t))
+
+	require.Error(t, err)
+	assert.True(t, idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func TestHandler_Probe(t *testing.T) {
+	client := &fakeClient{}
+	h := newTestHandler(t, client)
+
+	require.NoError(t, h.Probe(context.Background(), aws.Config{Region: "eu-west-1"}, newLogger(t)))
+	require.Len(t, client.listInputs, 1)
+	assert.Equal(t, int32(1), client.listInputs[0].MaxResults)
+
+	client.listErr = &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}
+	err := h.Probe(context.Background(), aws.Config{Region: "eu-west-1"}, newLogger(t))
+	require.Error(t, err)
+	assert.True(t, idderrors.Is(err, idderrors.CodePlatformAuthError), "got %v", err)
+}
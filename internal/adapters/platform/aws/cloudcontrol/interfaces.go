@@ -0,0 +1,48 @@
+package cloudcontrol
+
+import (
+	"context"
+)
+
+// CloudControlClientInterface defines the Cloud Control API operations used by the handler.
+type CloudControlClientInterface interface {
+	ListResources(ctx context.Context, params *ListResourcesInput) (*ListResourcesOutput, error)
+	GetResource(ctx context.Context, params *GetResourceInput) (*GetResourceOutput, error)
+}
+
+// PlatformDefaultsLookup finds the resources of a type that AWS created itself
+// when the resource model does not say so.
+type PlatformDefaultsLookup interface {
+	// PlatformDefaults returns the identifiers of the resources of typeName
+	// that AWS created, mapped to the reason, e.g. "default VPC".
+	PlatformDefaults(ctx context.Context, typeName string) (map[string]string, error)
+}
+
+// ResourceDescription is a single resource as returned by Cloud Control. Properties
+// is the resource model serialized as a JSON document.
+type ResourceDescription struct {
+	Identifier string `json:"Identifier"`
+	Properties string `json:"Properties"`
+}
+
+type ListResourcesInput struct {
+	TypeName   string `json:"TypeName"`
+	NextToken  string `json:"NextToken,omitempty"`
+	MaxResults int32  `json:"MaxResults,omitempty"`
+}
+
+type ListResourcesOutput struct {
+	TypeName             string                `json:"TypeName"`
+	ResourceDescriptions []ResourceDescription `json:"ResourceDescriptions"`
+	NextToken            string                `json:"NextToken"`
+}
+
+type GetResourceInput struct {
+	TypeName   string `json:"TypeName"`
+	Identifier string `json:"Identifier"`
+}
+
+type GetResourceOutput struct {
+	TypeName            string              `json:"TypeName"`
+	ResourceDescription ResourceDescription `json:"ResourceDescription"`
+}
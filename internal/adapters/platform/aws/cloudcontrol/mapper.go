@@ -0,0 +1,171 @@
+package cloudcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type cloudControlResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *cloudControlResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *cloudControlResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newCloudControlResource(desc ResourceDescription, def KindDefinition, region, accountID string) (*cloudControlResource, error) {
+	attrs, err := MapProperties(desc.Properties, def.PropertyMap)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to map Cloud Control properties for %s '%s'", def.TypeName, desc.Identifier))
+	}
+	attrs[domain.KeyID] = desc.Identifier
+	if _, ok := attrs[domain.KeyRegion]; !ok && region != "" {
+		attrs[domain.KeyRegion] = region
+	}
+
+	return &cloudControlResource{
+		meta: domain.ResourceMetadata{
+			Kind:               def.Kind,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: desc.Identifier,
+			Region:             region,
+			AccountID:          accountID,
+			PlatformDefault:    platformDefault(def.TypeName, attrs),
+		},
+		attrs: attrs,
+	}, nil
+}
+
+// platformDefault recognizes the resources AWS creates itself from the mapped
+// properties of the types whose resource model tells.
+func platformDefault(typeName string, attrs map[string]any) string {
+	str := func(key string) string {
+		s, _ := attrs[key].(string)
+		return s
+	}
+	switch typeName {
+	case "AWS::EC2::SecurityGroup":
+		return shared.SecurityGroupDefault(str("group_name"))
+	case "AWS::IAM::Role":
+		return shared.RoleDefault(str("role_name"), str("path"))
+	}
+	return ""
+}
+
+// MapProperties converts a Cloud Control resource model (CloudFormation-style
+// PascalCase properties) into Terraform-style snake_case attributes. Tags given
+// as a list of Key/Value pairs become a map. propertyMap entries override the
+// automatic naming: attribute name -> top-level property name.
+func MapProperties(properties string, propertyMap map[string]string) (map[string]any, error) {
+	raw := map[string]any{}
+	if properties != "" {
+		if err := json.Unmarshal([]byte(properties), &raw); err != nil {
+			return nil, err
+		}
+	}
+
+	attrs := make(map[string]any, len(raw))
+	for k, v := range raw {
+		if strings.EqualFold(k, "Tags") {
+			if tags, ok := tagListToMap(v); ok {
+				attrs[domain.KeyTags] = tags
+				if name, hasName := tags["Name"]; hasName {
+					attrs[domain.KeyName] = name
+				}
+				continue
+			}
+		}
+		attrs[ToSnakeCase(k)] = snakeCaseKeys(v)
+	}
+	for attrName, propName := range propertyMap {
+		if v, ok := raw[propName]; ok {
+			attrs[attrName] = snakeCaseKeys(v)
+		}
+	}
+	return attrs, nil
+}
+
+func tagListToMap(v any) (map[string]string, bool) {
+	switch typed := v.(type) {
+	case []any:
+		tags := make(map[string]string, len(typed))
+		for _, item := range typed {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			key, _ := m["Key"].(string)
+			if key == "" {
+				return nil, false
+			}
+			tags[key] = fmt.Sprint(m["Value"])
+		}
+		return tags, true
+	case map[string]any:
+		tags := make(map[string]string, len(typed))
+		for k, val := range typed {
+			tags[k] = fmt.Sprint(val)
+		}
+		return tags, true
+	}
+	return nil, false
+}
+
+func snakeCaseKeys(v any) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for k, val := range typed {
+			out[ToSnakeCase(k)] = snakeCaseKeys(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, val := range typed {
+			out[i] = snakeCaseKeys(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// ToSnakeCase converts PascalCase/camelCase identifiers to snake_case, keeping
+// acronyms together (e.g. "VpcId" -> "vpc_id", "KMSMasterKeyID" -> "kms_master_key_id").
+func ToSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
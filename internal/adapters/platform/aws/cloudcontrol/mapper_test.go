@@ -0,0 +1,68 @@
+package cloudcontrol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	testCases := map[string]string{
+		"QueueName":          "queue_name",
+		"VpcId":              "vpc_id",
+		"KMSMasterKeyID":     "kms_master_key_id",
+		"VisibilityTimeout":  "visibility_timeout",
+		"Ipv6CidrBlock":      "ipv6_cidr_block",
+		"already_snake_case": "already_snake_case",
+		"":                   "",
+	}
+	for in, want := range testCases {
+		assert.Equal(t, want, ToSnakeCase(in), in)
+	}
+}
+
+func TestMapProperties(t *testing.T) {
+	props := `{
+	  "QueueName": "orders",
+	  "VisibilityTimeout": 30,
+	  "RedrivePolicy": {"MaxReceiveCount": 5},
+	  "Tags": [{"Key": "Name", "Value": "orders-queue"}, {"Key": "Env", "Value": "prod"}]
+	}`
+
+	attrs, err := MapProperties(props, map[string]string{"visibility_timeout_seconds": "VisibilityTimeout"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", attrs["queue_name"])
+	assert.Equal(t, float64(30), attrs["visibility_timeout_seconds"])
+	assert.Equal(t, map[string]any{"max_receive_count": float64(5)}, attrs["redrive_policy"])
+	assert.Equal(t, map[string]string{"Name": "orders-queue", "Env": "prod"}, attrs[domain.KeyTags])
+	assert.Equal(t, "orders-queue", attrs[domain.KeyName])
+
+	_, err = MapProperties("{not json", nil)
+	assert.Error(t, err)
+}
+
+func TestNewCloudControlResource_PlatformDefault(t *testing.T) {
+	testCases := []struct {
+		name       string
+		typeName   string
+		properties string
+		want       string
+	}{
+		{"default security group", "AWS::EC2::SecurityGroup", `{"GroupName": "default", "GroupId": "sg-1"}`, "default security group"},
+		{"other security group", "AWS::EC2::SecurityGroup", `{"GroupName": "web", "GroupId": "sg-2"}`, ""},
+		{"service-linked role", "AWS::IAM::Role", `{"RoleName": "AWSServiceRoleForECS", "Path": "/aws-service-role/ecs.amazonaws.com/"}`, "service-linked role"},
+		{"other type", "AWS::SQS::Queue", `{"QueueName": "default"}`, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			def := KindDefinition{Kind: "Custom", TypeName: tc.typeName}
+			resource, err := newCloudControlResource(ResourceDescription{Identifier: "id", Properties: tc.properties}, def, "us-east-1", "123456789012")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, resource.Metadata().PlatformDefault)
+		})
+	}
+}
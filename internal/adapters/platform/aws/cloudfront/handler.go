@@ -0,0 +1,299 @@
+package cloudfront
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+)
+
+// DistributionHandler lists and fetches CloudFront distributions. CloudFront is
+// a global service, so the handler does not depend on the configured region.
+type DistributionHandler struct {
+	stsClient        shared.STSClientInterface
+	accountID        string
+	accMu            sync.RWMutex
+	cloudFrontClient CloudFrontClientInterface
+	limiter          shared.RateLimiter
+	errorHandler     shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the DistributionHandler.
+type HandlerOption func(*DistributionHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *DistributionHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithCloudFrontClient provides an option to set a custom CloudFront client.
+func WithCloudFrontClient(client CloudFrontClientInterface) HandlerOption {
+	return func(h *DistributionHandler) {
+		if client != nil {
+			h.cloudFrontClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *DistributionHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *DistributionHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new DistributionHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *DistributionHandler {
+	h := &DistributionHandler{
+		stsClient:        sts.NewFromConfig(cfg),
+		cloudFrontClient: cloudfront.NewFromConfig(cfg),
+		limiter:          &aws_limiter.DefaultRateLimiter{},
+		errorHandler:     &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *DistributionHandler) Kind() domain.ResourceKind {
+	return domain.KindCDNDistribution
+}
+
+func (h *DistributionHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "CloudFront: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+// ListResources lists the distributions of the account. The ID and enabled
+// filters are applied to the summaries, before a distribution is fetched in
+// full, and tag filters after listing its tags.
+func (h *DistributionHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for CloudFront ListResources: %v", accErr)
+	}
+
+	input := &cloudfront.ListDistributionsInput{MaxItems: aws.Int32(listPageSize)}
+	tagFilters := tagFiltersFrom(filters)
+
+	logger.Debugf(ctx, "Starting CloudFront distribution listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.cloudFrontClient.ListDistributions(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("CloudFront", fmt.Sprintf("ListDistributions:Page%d", pageNum), err, ctx)
+		}
+		list := output.DistributionList
+		if list == nil {
+			break
+		}
+
+		for _, summary := range list.Items {
+			id := aws.ToString(summary.Id)
+			if value, ok := filters[domain.KeyID]; ok && !containsValue(value, id) {
+				continue
+			}
+			if value, ok := filters[domain.DistributionEnabledKey]; ok && !containsValue(value, fmt.Sprintf("%t", aws.ToBool(summary.Enabled))) {
+				continue
+			}
+			tags, err := h.listTags(ctx, summary.ARN, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tags, tagFilters) {
+				continue
+			}
+			distribution, err := h.getDistribution(ctx, id, logger)
+			if err != nil {
+				return err
+			}
+			resource, err := newDistributionResource(distribution, tags, accountID)
+			if err != nil {
+				return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for CloudFront distribution %s", id))
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending CloudFront distribution %s", id)
+				return ctx.Err()
+			}
+		}
+
+		if !aws.ToBool(list.IsTruncated) || aws.ToString(list.NextMarker) == "" {
+			break
+		}
+		input.Marker = list.NextMarker
+	}
+
+	logger.Debugf(ctx, "Finished CloudFront pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *DistributionHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single CloudFront distribution %s", id)
+	distribution, err := h.getDistribution(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for CloudFront GetResource: %v", accErr)
+	}
+
+	tags, err := h.listTags(ctx, distribution.ARN, logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := newDistributionResource(distribution, tags, accountID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for CloudFront distribution %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that distributions can be listed with a single minimal page.
+func (h *DistributionHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.cloudFrontClient.ListDistributions(ctx, &cloudfront.ListDistributionsInput{MaxItems: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("CloudFront", "ListDistributions", err, ctx)
+	}
+	return nil
+}
+
+func (h *DistributionHandler) getDistribution(ctx context.Context, id string, logger ports.Logger) (Distribution, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return Distribution{}, err
+	}
+	output, err := h.cloudFrontClient.GetDistribution(ctx, &cloudfront.GetDistributionInput{Id: aws.String(id)})
+	if err != nil {
+		return Distribution{}, h.errorHandler.Handle("CloudFront", "GetDistribution", err, ctx)
+	}
+	if output.Distribution == nil || output.Distribution.DistributionConfig == nil {
+		return Distribution{}, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("CloudFront distribution '%s' not found (empty response)", id))
+	}
+	return *output.Distribution, nil
+}
+
+func (h *DistributionHandler) listTags(ctx context.Context, arn *string, logger ports.Logger) (map[string]string, error) {
+	if aws.ToString(arn) == "" {
+		return nil, nil
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.cloudFrontClient.ListTagsForResource(ctx, &cloudfront.ListTagsForResourceInput{Resource: arn})
+	if err != nil {
+		return nil, h.errorHandler.Handle("CloudFront", "ListTagsForResource", err, ctx)
+	}
+	tags := make(map[string]string)
+	if output.Tags != nil {
+		for _, tag := range output.Tags.Items {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+	}
+	return tags, nil
+}
+
+func tagFiltersFrom(genericFilters map[string]string) map[string]string {
+	tagFilters := make(map[string]string)
+	for key, value := range genericFilters {
+		if strings.HasPrefix(key, domain.TagPrefix) {
+			tagFilters[strings.TrimPrefix(key, domain.TagPrefix)] = value
+		}
+	}
+	return tagFilters
+}
+
+func matchesTagFilters(tags map[string]string, tagFilters map[string]string) bool {
+	for key, value := range tagFilters {
+		actual, ok := tags[key]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package cloudfront
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	cloudfrontmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/cloudfront/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type DistributionHandlerTestSuite struct {
+	suite.Suite
+	mockCloudFront   *cloudfrontmocks.CloudFrontClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *DistributionHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *DistributionHandlerTestSuite) SetupTest() {
+	s.mockCloudFront = new(cloudfrontmocks.CloudFrontClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "eu-west-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithCloudFrontClient(s.mockCloudFront),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *DistributionHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestDistributionHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DistributionHandlerTestSuite))
+}
+
+func distributionARN(id string) string {
+	return "arn:aws:cloudfront::123456789012:distribution/" + id
+}
+
+func summary(id string, enabled bool) cloudfronttypes.DistributionSummary {
+	return cloudfronttypes.DistributionSummary{Id: aws.String(id), ARN: aws.String(distributionARN(id)), Enabled: aws.Bool(enabled)}
+}
+
+func (s *DistributionHandlerTestSuite) expectTags(id string, tags map[string]string) {
+	items := make([]cloudfronttypes.Tag, 0, len(tags))
+	for k, v := range tags {
+		items = append(items, cloudfronttypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	s.mockCloudFront.On("ListTagsForResource", mock.Anything, &cloudfront.ListTagsForResourceInput{Resource: aws.String(distributionARN(id))}).
+		Return(&cloudfront.ListTagsForResourceOutput{Tags: &cloudfronttypes.Tags{Items: items}}, nil).Once()
+}
+
+func (s *DistributionHandlerTestSuite) expectDistribution(id string) {
+	s.mockCloudFront.On("GetDistribution", mock.Anything, &cloudfront.GetDistributionInput{Id: aws.String(id)}).
+		Return(&cloudfront.GetDistributionOutput{Distribution: &cloudfronttypes.Distribution{
+			Id:  aws.String(id),
+			ARN: aws.String(distributionARN(id)),
+			DistributionConfig: &cloudfronttypes.DistributionConfig{
+				Enabled: aws.Bool(true),
+				Aliases: &cloudfronttypes.Aliases{Quantity: aws.Int32(1), Items: []string{id + ".example.com"}},
+			},
+		}}, nil).Once()
+}
+
+func (s *DistributionHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *DistributionHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindCDNDistribution, s.handler.Kind())
+}
+
+func (s *DistributionHandlerTestSuite) TestListResources_Paginates() {
+	s.mockCloudFront.On("ListDistributions", mock.Anything, mock.MatchedBy(func(in *cloudfront.ListDistributionsInput) bool {
+		return in.Marker == nil
+	})).Return(&cloudfront.ListDistributionsOutput{DistributionList: &cloudfronttypes.DistributionList{
+		Items:       []cloudfronttypes.DistributionSummary{summary("EWEB", true)},
+		IsTruncated: aws.Bool(true),
+		NextMarker:  aws.String("EWEB"),
+	}}, nil).Once()
+	s.mockCloudFront.On("ListDistributions", mock.Anything, mock.MatchedBy(func(in *cloudfront.ListDistributionsInput) bool {
+		return aws.ToString(in.Marker) == "EWEB"
+	})).Return(&cloudfront.ListDistributionsOutput{DistributionList: &cloudfronttypes.DistributionList{
+		Items:       []cloudfronttypes.DistributionSummary{summary("EDOCS", true)},
+		IsTruncated: aws.Bool(false),
+	}}, nil).Once()
+	s.expectTags("EWEB", map[string]string{"Env": "prod"})
+	s.expectTags("EDOCS", nil)
+	s.expectDistribution("EWEB")
+	s.expectDistribution("EDOCS")
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("EWEB", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("EDOCS", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	s.Empty(resources[0].Metadata().Region, "CloudFront is global")
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"EWEB.example.com"}, attrs[domain.DistributionAliasesKey])
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	s.mockCloudFront.AssertExpectations(s.T())
+}
+
+func (s *DistributionHandlerTestSuite) TestListResources_Filters() {
+	s.mockCloudFront.On("ListDistributions", mock.Anything, mock.Anything).Return(&cloudfront.ListDistributionsOutput{DistributionList: &cloudfronttypes.DistributionList{
+		Items: []cloudfronttypes.DistributionSummary{summary("EWEB", true), summary("EDEV", true), summary("EOLD", false), summary("EOTHER", true)},
+	}}, nil).Once()
+	s.expectTags("EWEB", map[string]string{"Env": "prod"})
+	s.expectTags("EDEV", map[string]string{"Env": "dev"})
+	s.expectDistribution("EWEB")
+
+	resources, err := s.collect(map[string]string{
+		domain.KeyID:                  "EWEB, EDEV, EOLD",
+		domain.DistributionEnabledKey: "true",
+		"tag:Env":                     "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("EWEB", resources[0].Metadata().ProviderAssignedID)
+	// EOTHER is excluded by ID and EOLD by its enabled flag before their tags
+	// are listed, and EDEV by tag before it is fetched.
+	s.mockCloudFront.AssertNotCalled(s.T(), "ListTagsForResource", mock.Anything, &cloudfront.ListTagsForResourceInput{Resource: aws.String(distributionARN("EOLD"))})
+	s.mockCloudFront.AssertNotCalled(s.T(), "GetDistribution", mock.Anything, &cloudfront.GetDistributionInput{Id: aws.String("EDEV")})
+	s.mockCloudFront.AssertExpectations(s.T())
+}
+
+func (s *DistributionHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockCloudFront.On("ListDistributions", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "CloudFront", "ListDistributions:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *DistributionHandlerTestSuite) TestGetResource_Success() {
+	s.expectDistribution("EWEB")
+	s.expectTags("EWEB", map[string]string{"Env": "prod"})
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "EWEB", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("EWEB", resource.Metadata().ProviderAssignedID)
+	s.Equal(domain.KindCDNDistribution, resource.Metadata().Kind)
+	s.mockCloudFront.AssertExpectations(s.T())
+}
+
+func (s *DistributionHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockCloudFront.On("GetDistribution", mock.Anything, mock.Anything).
+		Return(&cloudfront.GetDistributionOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "EMISSING", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *DistributionHandlerTestSuite) TestProbe() {
+	s.mockCloudFront.On("ListDistributions", mock.Anything, &cloudfront.ListDistributionsInput{MaxItems: aws.Int32(probePageSize)}).
+		Return(&cloudfront.ListDistributionsOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockCloudFront.AssertExpectations(s.T())
+}
@@ -0,0 +1,22 @@
+package cloudfront
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+)
+
+//go:generate mockery --name CloudFrontClientInterface --output ./mocks --outpkg mocks --case underscore
+
+// CloudFrontClientInterface defines the methods needed from the AWS SDK
+// CloudFront client. The distribution summaries returned by ListDistributions
+// lack the logging settings and default root object, so each distribution is
+// fetched in full with GetDistribution.
+type CloudFrontClientInterface interface {
+	ListDistributions(ctx context.Context, params *cloudfront.ListDistributionsInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsOutput, error)
+	GetDistribution(ctx context.Context, params *cloudfront.GetDistributionInput, optFns ...func(*cloudfront.Options)) (*cloudfront.GetDistributionOutput, error)
+	ListTagsForResource(ctx context.Context, params *cloudfront.ListTagsForResourceInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListTagsForResourceOutput, error)
+}
+
+type Distribution = cloudfronttypes.Distribution // Alias cloudfronttypes.Distribution for easier use
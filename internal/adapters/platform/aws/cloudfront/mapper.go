@@ -0,0 +1,386 @@
+package cloudfront
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// distributionResource wraps a fetched distribution, which is mapped once when
+// the resource is built. CloudFront is global, so resources carry no region.
+type distributionResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newDistributionResource(distribution Distribution, tags map[string]string, accountID string) (domain.PlatformResource, error) {
+	id := aws.ToString(distribution.Id)
+	if id == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create CloudFront resource: missing distribution ID")
+	}
+
+	return &distributionResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindCDNDistribution,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: id,
+			SourceIdentifier:   id,
+			AccountID:          accountID,
+		},
+		attrs: mapDistributionToAttributes(distribution, tags),
+	}, nil
+}
+
+func (r *distributionResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *distributionResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+// mapDistributionToAttributes maps a distribution to the shape of the Terraform
+// aws_cloudfront_distribution blocks. Nested blocks only hold their non-empty
+// fields, sets are sorted and the ordered cache behaviors keep their
+// precedence, so that both sides can be compared structurally.
+func mapDistributionToAttributes(distribution Distribution, tags map[string]string) map[string]any {
+	cfg := distribution.DistributionConfig
+	id := aws.ToString(distribution.Id)
+	attrs := map[string]any{
+		domain.KeyID:                      id,
+		domain.DistributionEnabledKey:     aws.ToBool(cfg.Enabled),
+		domain.DistributionIPv6EnabledKey: aws.ToBool(cfg.IsIPV6Enabled),
+		domain.DistributionPriceClassKey:  string(cfg.PriceClass),
+		domain.DistributionHTTPVersionKey: string(cfg.HttpVersion),
+	}
+	if distribution.ARN != nil {
+		attrs[domain.KeyARN] = *distribution.ARN
+	}
+	setIfNotEmpty(attrs, domain.DistributionCommentKey, aws.ToString(cfg.Comment))
+	setIfNotEmpty(attrs, domain.DistributionDefaultRootObjectKey, aws.ToString(cfg.DefaultRootObject))
+	setIfNotEmpty(attrs, domain.DistributionWebACLIDKey, aws.ToString(cfg.WebACLId))
+
+	if cfg.Aliases != nil && len(cfg.Aliases.Items) > 0 {
+		attrs[domain.DistributionAliasesKey] = sortedStrings(cfg.Aliases.Items)
+	}
+
+	if cfg.Origins != nil && len(cfg.Origins.Items) > 0 {
+		origins := make([]any, 0, len(cfg.Origins.Items))
+		for _, origin := range cfg.Origins.Items {
+			origins = append(origins, originAttributes(origin))
+		}
+		sortByField(origins, "origin_id")
+		attrs[domain.DistributionOriginsKey] = origins
+	}
+
+	if cfg.OriginGroups != nil && len(cfg.OriginGroups.Items) > 0 {
+		groups := make([]any, 0, len(cfg.OriginGroups.Items))
+		for _, group := range cfg.OriginGroups.Items {
+			groups = append(groups, originGroupAttributes(group))
+		}
+		sortByField(groups, "origin_id")
+		attrs[domain.DistributionOriginGroupsKey] = groups
+	}
+
+	if behavior := cfg.DefaultCacheBehavior; behavior != nil {
+		attrs[domain.DistributionDefaultCacheBehaviorKey] = cacheBehaviorAttributes(cacheBehavior{
+			targetOriginID:          behavior.TargetOriginId,
+			viewerProtocolPolicy:    string(behavior.ViewerProtocolPolicy),
+			allowedMethods:          behavior.AllowedMethods,
+			compress:                behavior.Compress,
+			smoothStreaming:         behavior.SmoothStreaming,
+			cachePolicyID:           behavior.CachePolicyId,
+			originRequestPolicyID:   behavior.OriginRequestPolicyId,
+			responseHeadersPolicyID: behavior.ResponseHeadersPolicyId,
+			realtimeLogConfigARN:    behavior.RealtimeLogConfigArn,
+			fieldLevelEncryptionID:  behavior.FieldLevelEncryptionId,
+			minTTL:                  behavior.MinTTL,
+			defaultTTL:              behavior.DefaultTTL,
+			maxTTL:                  behavior.MaxTTL,
+			trustedKeyGroups:        behavior.TrustedKeyGroups,
+			functions:               behavior.FunctionAssociations,
+			lambdas:                 behavior.LambdaFunctionAssociations,
+		})
+	}
+
+	if cfg.CacheBehaviors != nil && len(cfg.CacheBehaviors.Items) > 0 {
+		behaviors := make([]any, 0, len(cfg.CacheBehaviors.Items))
+		for _, behavior := range cfg.CacheBehaviors.Items {
+			mapped := cacheBehaviorAttributes(cacheBehavior{
+				targetOriginID:          behavior.TargetOriginId,
+				viewerProtocolPolicy:    string(behavior.ViewerProtocolPolicy),
+				allowedMethods:          behavior.AllowedMethods,
+				compress:                behavior.Compress,
+				smoothStreaming:         behavior.SmoothStreaming,
+				cachePolicyID:           behavior.CachePolicyId,
+				originRequestPolicyID:   behavior.OriginRequestPolicyId,
+				responseHeadersPolicyID: behavior.ResponseHeadersPolicyId,
+				realtimeLogConfigARN:    behavior.RealtimeLogConfigArn,
+				fieldLevelEncryptionID:  behavior.FieldLevelEncryptionId,
+				minTTL:                  behavior.MinTTL,
+				defaultTTL:              behavior.DefaultTTL,
+				maxTTL:                  behavior.MaxTTL,
+				trustedKeyGroups:        behavior.TrustedKeyGroups,
+				functions:               behavior.FunctionAssociations,
+				lambdas:                 behavior.LambdaFunctionAssociations,
+			})
+			mapped["path_pattern"] = aws.ToString(behavior.PathPattern)
+			behaviors = append(behaviors, mapped)
+		}
+		attrs[domain.DistributionOrderedCacheBehaviorsKey] = behaviors
+	}
+
+	if cfg.CustomErrorResponses != nil && len(cfg.CustomErrorResponses.Items) > 0 {
+		responses := make([]any, 0, len(cfg.CustomErrorResponses.Items))
+		for _, response := range cfg.CustomErrorResponses.Items {
+			responses = append(responses, errorResponseAttributes(response))
+		}
+		sort.SliceStable(responses, func(i, j int) bool {
+			return responses[i].(map[string]any)["error_code"].(int64) < responses[j].(map[string]any)["error_code"].(int64)
+		})
+		attrs[domain.DistributionCustomErrorResponsesKey] = responses
+	}
+
+	if cert := cfg.ViewerCertificate; cert != nil {
+		viewer := map[string]any{}
+		setIfTrue(viewer, "cloudfront_default_certificate", cert.CloudFrontDefaultCertificate)
+		setIfNotEmpty(viewer, "acm_certificate_arn", aws.ToString(cert.ACMCertificateArn))
+		setIfNotEmpty(viewer, "iam_certificate_id", aws.ToString(cert.IAMCertificateId))
+		setIfNotEmpty(viewer, "ssl_support_method", string(cert.SSLSupportMethod))
+		attrs[domain.DistributionViewerCertificateKey] = viewer
+		setIfNotEmpty(attrs, domain.KeyMinimumProtocolVersion, string(cert.MinimumProtocolVersion))
+	}
+
+	if logging := cfg.Logging; logging != nil && aws.ToBool(logging.Enabled) {
+		config := map[string]any{}
+		setIfNotEmpty(config, "bucket", aws.ToString(logging.Bucket))
+		setIfNotEmpty(config, "prefix", aws.ToString(logging.Prefix))
+		setIfTrue(config, "include_cookies", logging.IncludeCookies)
+		attrs[domain.DistributionLoggingKey] = config
+	}
+
+	if cfg.Restrictions != nil && cfg.Restrictions.GeoRestriction != nil {
+		geo := cfg.Restrictions.GeoRestriction
+		restriction := map[string]any{"restriction_type": string(geo.RestrictionType)}
+		if len(geo.Items) > 0 {
+			restriction["locations"] = sortedStrings(geo.Items)
+		}
+		attrs[domain.DistributionGeoRestrictionKey] = restriction
+	}
+
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+
+	return attrs
+}
+
+func originAttributes(origin cloudfronttypes.Origin) map[string]any {
+	mapped := map[string]any{"origin_id": aws.ToString(origin.Id)}
+	setIfNotEmpty(mapped, "domain_name", aws.ToString(origin.DomainName))
+	setIfNotEmpty(mapped, "origin_path", aws.ToString(origin.OriginPath))
+	setIfNotEmpty(mapped, "origin_access_control_id", aws.ToString(origin.OriginAccessControlId))
+	setIfPositive(mapped, "connection_attempts", origin.ConnectionAttempts)
+	setIfPositive(mapped, "connection_timeout", origin.ConnectionTimeout)
+
+	if origin.CustomHeaders != nil && len(origin.CustomHeaders.Items) > 0 {
+		headers := make([]any, 0, len(origin.CustomHeaders.Items))
+		for _, header := range origin.CustomHeaders.Items {
+			headers = append(headers, map[string]any{
+				"name":  aws.ToString(header.HeaderName),
+				"value": aws.ToString(header.HeaderValue),
+			})
+		}
+		sortByField(headers, "name")
+		mapped["custom_header"] = headers
+	}
+
+	if custom := origin.CustomOriginConfig; custom != nil {
+		config := map[string]any{}
+		setIfPositive(config, "http_port", custom.HTTPPort)
+		setIfPositive(config, "https_port", custom.HTTPSPort)
+		setIfPositive(config, "origin_keepalive_timeout", custom.OriginKeepaliveTimeout)
+		setIfPositive(config, "origin_read_timeout", custom.OriginReadTimeout)
+		setIfNotEmpty(config, "origin_protocol_policy", string(custom.OriginProtocolPolicy))
+		if custom.OriginSslProtocols != nil && len(custom.OriginSslProtocols.Items) > 0 {
+			protocols := make([]string, 0, len(custom.OriginSslProtocols.Items))
+			for _, protocol := range custom.OriginSslProtocols.Items {
+				protocols = append(protocols, string(protocol))
+			}
+			config["origin_ssl_protocols"] = sortedStrings(protocols)
+		}
+		mapped["custom_origin_config"] = config
+	}
+
+	// S3 origins accessed through an origin access control report an empty
+	// origin access identity, which Terraform records as an empty block.
+	if s3 := origin.S3OriginConfig; s3 != nil && aws.ToString(s3.OriginAccessIdentity) != "" {
+		mapped["s3_origin_config"] = map[string]any{"origin_access_identity": aws.ToString(s3.OriginAccessIdentity)}
+	}
+
+	if shield := origin.OriginShield; shield != nil && aws.ToBool(shield.Enabled) {
+		mapped["origin_shield"] = map[string]any{"enabled": true, "origin_shield_region": aws.ToString(shield.OriginShieldRegion)}
+	}
+	return mapped
+}
+
+func originGroupAttributes(group cloudfronttypes.OriginGroup) map[string]any {
+	mapped := map[string]any{"origin_id": aws.ToString(group.Id)}
+	if criteria := group.FailoverCriteria; criteria != nil && criteria.StatusCodes != nil && len(criteria.StatusCodes.Items) > 0 {
+		codes := make([]int64, 0, len(criteria.StatusCodes.Items))
+		for _, code := range criteria.StatusCodes.Items {
+			codes = append(codes, int64(code))
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+		mapped["status_codes"] = codes
+	}
+	if group.Members != nil && len(group.Members.Items) > 0 {
+		members := make([]string, 0, len(group.Members.Items))
+		for _, member := range group.Members.Items {
+			members = append(members, aws.ToString(member.OriginId))
+		}
+		mapped["members"] = members
+	}
+	return mapped
+}
+
+// cacheBehavior holds the fields shared by the default and the ordered cache
+// behaviors, which the SDK models as distinct types.
+type cacheBehavior struct {
+	targetOriginID          *string
+	viewerProtocolPolicy    string
+	allowedMethods          *cloudfronttypes.AllowedMethods
+	compress                *bool
+	smoothStreaming         *bool
+	cachePolicyID           *string
+	originRequestPolicyID   *string
+	responseHeadersPolicyID *string
+	realtimeLogConfigARN    *string
+	fieldLevelEncryptionID  *string
+	minTTL                  *int64
+	defaultTTL              *int64
+	maxTTL                  *int64
+	trustedKeyGroups        *cloudfronttypes.TrustedKeyGroups
+	functions               *cloudfronttypes.FunctionAssociations
+	lambdas                 *cloudfronttypes.LambdaFunctionAssociations
+}
+
+func cacheBehaviorAttributes(behavior cacheBehavior) map[string]any {
+	mapped := map[string]any{
+		"target_origin_id":       aws.ToString(behavior.targetOriginID),
+		"viewer_protocol_policy": behavior.viewerProtocolPolicy,
+	}
+	if methods := behavior.allowedMethods; methods != nil {
+		mapped["allowed_methods"] = methodNames(methods.Items)
+		if methods.CachedMethods != nil {
+			mapped["cached_methods"] = methodNames(methods.CachedMethods.Items)
+		}
+	}
+	setIfTrue(mapped, "compress", behavior.compress)
+	setIfTrue(mapped, "smooth_streaming", behavior.smoothStreaming)
+	setIfNotEmpty(mapped, "cache_policy_id", aws.ToString(behavior.cachePolicyID))
+	setIfNotEmpty(mapped, "origin_request_policy_id", aws.ToString(behavior.originRequestPolicyID))
+	setIfNotEmpty(mapped, "response_headers_policy_id", aws.ToString(behavior.responseHeadersPolicyID))
+	setIfNotEmpty(mapped, "realtime_log_config_arn", aws.ToString(behavior.realtimeLogConfigARN))
+	setIfNotEmpty(mapped, "field_level_encryption_id", aws.ToString(behavior.fieldLevelEncryptionID))
+
+	// The TTLs of a cache policy apply instead of the legacy ones.
+	if aws.ToString(behavior.cachePolicyID) == "" {
+		mapped["min_ttl"] = aws.ToInt64(behavior.minTTL)
+		mapped["default_ttl"] = aws.ToInt64(behavior.defaultTTL)
+		mapped["max_ttl"] = aws.ToInt64(behavior.maxTTL)
+	}
+
+	if groups := behavior.trustedKeyGroups; groups != nil && aws.ToBool(groups.Enabled) && len(groups.Items) > 0 {
+		mapped["trusted_key_groups"] = sortedStrings(groups.Items)
+	}
+
+	if behavior.functions != nil && len(behavior.functions.Items) > 0 {
+		functions := make([]any, 0, len(behavior.functions.Items))
+		for _, function := range behavior.functions.Items {
+			functions = append(functions, map[string]any{
+				"event_type":   string(function.EventType),
+				"function_arn": aws.ToString(function.FunctionARN),
+			})
+		}
+		sortByField(functions, "event_type")
+		mapped["function_association"] = functions
+	}
+
+	if behavior.lambdas != nil && len(behavior.lambdas.Items) > 0 {
+		lambdas := make([]any, 0, len(behavior.lambdas.Items))
+		for _, lambda := range behavior.lambdas.Items {
+			association := map[string]any{
+				"event_type": string(lambda.EventType),
+				"lambda_arn": aws.ToString(lambda.LambdaFunctionARN),
+			}
+			setIfTrue(association, "include_body", lambda.IncludeBody)
+			lambdas = append(lambdas, association)
+		}
+		sortByField(lambdas, "event_type")
+		mapped["lambda_function_association"] = lambdas
+	}
+	return mapped
+}
+
+func errorResponseAttributes(response cloudfronttypes.CustomErrorResponse) map[string]any {
+	mapped := map[string]any{"error_code": int64(aws.ToInt32(response.ErrorCode))}
+	if ttl := aws.ToInt64(response.ErrorCachingMinTTL); ttl > 0 {
+		mapped["error_caching_min_ttl"] = ttl
+	}
+	// The API reports the response code as a string, Terraform as a number.
+	if code, err := strconv.ParseInt(aws.ToString(response.ResponseCode), 10, 64); err == nil && code > 0 {
+		mapped["response_code"] = code
+	}
+	setIfNotEmpty(mapped, "response_page_path", aws.ToString(response.ResponsePagePath))
+	return mapped
+}
+
+func methodNames(methods []cloudfronttypes.Method) []string {
+	names := make([]string, 0, len(methods))
+	for _, method := range methods {
+		names = append(names, string(method))
+	}
+	return sortedStrings(names)
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortByField(items []any, field string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].(map[string]any)[field].(string) < items[j].(map[string]any)[field].(string)
+	})
+}
+
+func setIfNotEmpty(attrs map[string]any, key, value string) {
+	if value != "" {
+		attrs[key] = value
+	}
+}
+
+func setIfTrue(attrs map[string]any, key string, value *bool) {
+	if aws.ToBool(value) {
+		attrs[key] = true
+	}
+}
+
+func setIfPositive(attrs map[string]any, key string, value *int32) {
+	if v := aws.ToInt32(value); v > 0 {
+		attrs[key] = int64(v)
+	}
+}
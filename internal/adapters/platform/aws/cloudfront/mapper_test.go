@@ -0,0 +1,194 @@
+package cloudfront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func testDistribution() Distribution {
+	return Distribution{
+		Id:  aws.String("E2QWRUHEXAMPLE"),
+		ARN: aws.String("arn:aws:cloudfront::123456789012:distribution/E2QWRUHEXAMPLE"),
+		DistributionConfig: &cloudfronttypes.DistributionConfig{
+			Enabled:           aws.Bool(true),
+			IsIPV6Enabled:     aws.Bool(true),
+			Comment:           aws.String("shop"),
+			DefaultRootObject: aws.String("index.html"),
+			PriceClass:        cloudfronttypes.PriceClassPriceClass100,
+			HttpVersion:       cloudfronttypes.HttpVersionHttp2,
+			Aliases:           &cloudfronttypes.Aliases{Quantity: aws.Int32(2), Items: []string{"www.example.com", "example.com"}},
+			Origins: &cloudfronttypes.Origins{Quantity: aws.Int32(2), Items: []cloudfronttypes.Origin{
+				{
+					Id:                 aws.String("web"),
+					DomainName:         aws.String("web.example.com"),
+					ConnectionAttempts: aws.Int32(3),
+					ConnectionTimeout:  aws.Int32(10),
+					CustomHeaders: &cloudfronttypes.CustomHeaders{Quantity: aws.Int32(2), Items: []cloudfronttypes.OriginCustomHeader{
+						{HeaderName: aws.String("X-Secret"), HeaderValue: aws.String("s")},
+						{HeaderName: aws.String("X-Origin"), HeaderValue: aws.String("cdn")},
+					}},
+					CustomOriginConfig: &cloudfronttypes.CustomOriginConfig{
+						HTTPPort:             aws.Int32(80),
+						HTTPSPort:            aws.Int32(443),
+						OriginProtocolPolicy: cloudfronttypes.OriginProtocolPolicyHttpsOnly,
+						OriginSslProtocols: &cloudfronttypes.OriginSslProtocols{Quantity: aws.Int32(2), Items: []cloudfronttypes.SslProtocol{
+							cloudfronttypes.SslProtocolTLSv12, cloudfronttypes.SslProtocolTLSv11,
+						}},
+					},
+				},
+				{
+					Id:                    aws.String("assets"),
+					DomainName:            aws.String("assets.s3.amazonaws.com"),
+					OriginAccessControlId: aws.String("E3OAC"),
+					S3OriginConfig:        &cloudfronttypes.S3OriginConfig{OriginAccessIdentity: aws.String("")},
+				},
+			}},
+			DefaultCacheBehavior: &cloudfronttypes.DefaultCacheBehavior{
+				TargetOriginId:       aws.String("web"),
+				ViewerProtocolPolicy: cloudfronttypes.ViewerProtocolPolicyRedirectToHttps,
+				CachePolicyId:        aws.String("658327ea"),
+				Compress:             aws.Bool(true),
+				MinTTL:               aws.Int64(0),
+				AllowedMethods: &cloudfronttypes.AllowedMethods{
+					Quantity: aws.Int32(2),
+					Items:    []cloudfronttypes.Method{cloudfronttypes.MethodHead, cloudfronttypes.MethodGet},
+					CachedMethods: &cloudfronttypes.CachedMethods{
+						Quantity: aws.Int32(2),
+						Items:    []cloudfronttypes.Method{cloudfronttypes.MethodHead, cloudfronttypes.MethodGet},
+					},
+				},
+				FunctionAssociations: &cloudfronttypes.FunctionAssociations{Quantity: aws.Int32(1), Items: []cloudfronttypes.FunctionAssociation{
+					{EventType: cloudfronttypes.EventTypeViewerRequest, FunctionARN: aws.String("arn:aws:cloudfront::123456789012:function/rewrite")},
+				}},
+			},
+			CacheBehaviors: &cloudfronttypes.CacheBehaviors{Quantity: aws.Int32(2), Items: []cloudfronttypes.CacheBehavior{
+				{PathPattern: aws.String("/static/*"), TargetOriginId: aws.String("assets"), ViewerProtocolPolicy: cloudfronttypes.ViewerProtocolPolicyHttpsOnly, MinTTL: aws.Int64(0), DefaultTTL: aws.Int64(86400), MaxTTL: aws.Int64(31536000)},
+				{PathPattern: aws.String("/*"), TargetOriginId: aws.String("web"), ViewerProtocolPolicy: cloudfronttypes.ViewerProtocolPolicyAllowAll, CachePolicyId: aws.String("4135ea2d")},
+			}},
+			CustomErrorResponses: &cloudfronttypes.CustomErrorResponses{Quantity: aws.Int32(2), Items: []cloudfronttypes.CustomErrorResponse{
+				{ErrorCode: aws.Int32(404), ResponseCode: aws.String("200"), ResponsePagePath: aws.String("/index.html"), ErrorCachingMinTTL: aws.Int64(10)},
+				{ErrorCode: aws.Int32(403), ResponseCode: aws.String(""), ErrorCachingMinTTL: aws.Int64(10)},
+			}},
+			ViewerCertificate: &cloudfronttypes.ViewerCertificate{
+				ACMCertificateArn:      aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abc"),
+				SSLSupportMethod:       cloudfronttypes.SSLSupportMethodSniOnly,
+				MinimumProtocolVersion: cloudfronttypes.MinimumProtocolVersionTLSv122021,
+			},
+			Logging: &cloudfronttypes.LoggingConfig{
+				Enabled:        aws.Bool(true),
+				Bucket:         aws.String("logs.s3.amazonaws.com"),
+				Prefix:         aws.String("cdn/"),
+				IncludeCookies: aws.Bool(false),
+			},
+			Restrictions: &cloudfronttypes.Restrictions{GeoRestriction: &cloudfronttypes.GeoRestriction{
+				RestrictionType: cloudfronttypes.GeoRestrictionTypeBlacklist,
+				Quantity:        aws.Int32(2),
+				Items:           []string{"RU", "KP"},
+			}},
+		},
+	}
+}
+
+func TestMapDistributionToAttributes(t *testing.T) {
+	attrs := mapDistributionToAttributes(testDistribution(), map[string]string{"Env": "prod"})
+
+	assert.Equal(t, "E2QWRUHEXAMPLE", attrs[domain.KeyID])
+	assert.Equal(t, true, attrs[domain.DistributionEnabledKey])
+	assert.Equal(t, "PriceClass_100", attrs[domain.DistributionPriceClassKey])
+	assert.Equal(t, "index.html", attrs[domain.DistributionDefaultRootObjectKey])
+	assert.NotContains(t, attrs, domain.DistributionWebACLIDKey)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, attrs[domain.DistributionAliasesKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+
+	assert.Equal(t, []any{
+		map[string]any{"origin_id": "assets", "domain_name": "assets.s3.amazonaws.com", "origin_access_control_id": "E3OAC"},
+		map[string]any{
+			"origin_id":           "web",
+			"domain_name":         "web.example.com",
+			"connection_attempts": int64(3),
+			"connection_timeout":  int64(10),
+			"custom_header": []any{
+				map[string]any{"name": "X-Origin", "value": "cdn"},
+				map[string]any{"name": "X-Secret", "value": "s"},
+			},
+			"custom_origin_config": map[string]any{
+				"http_port":              int64(80),
+				"https_port":             int64(443),
+				"origin_protocol_policy": "https-only",
+				"origin_ssl_protocols":   []string{"TLSv1.1", "TLSv1.2"},
+			},
+		},
+	}, attrs[domain.DistributionOriginsKey])
+
+	assert.Equal(t, map[string]any{
+		"target_origin_id":       "web",
+		"viewer_protocol_policy": "redirect-to-https",
+		"allowed_methods":        []string{"GET", "HEAD"},
+		"cached_methods":         []string{"GET", "HEAD"},
+		"compress":               true,
+		"cache_policy_id":        "658327ea",
+		"function_association": []any{
+			map[string]any{"event_type": "viewer-request", "function_arn": "arn:aws:cloudfront::123456789012:function/rewrite"},
+		},
+	}, attrs[domain.DistributionDefaultCacheBehaviorKey], "TTLs are left out for behaviors with a cache policy")
+
+	behaviors, ok := attrs[domain.DistributionOrderedCacheBehaviorsKey].([]any)
+	require.True(t, ok)
+	require.Len(t, behaviors, 2)
+	assert.Equal(t, "/static/*", behaviors[0].(map[string]any)["path_pattern"], "ordered behaviors keep their precedence")
+	assert.Equal(t, int64(86400), behaviors[0].(map[string]any)["default_ttl"])
+	assert.Equal(t, "/*", behaviors[1].(map[string]any)["path_pattern"])
+
+	assert.Equal(t, []any{
+		map[string]any{"error_code": int64(403), "error_caching_min_ttl": int64(10)},
+		map[string]any{"error_code": int64(404), "error_caching_min_ttl": int64(10), "response_code": int64(200), "response_page_path": "/index.html"},
+	}, attrs[domain.DistributionCustomErrorResponsesKey])
+
+	assert.Equal(t, map[string]any{
+		"acm_certificate_arn": "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+		"ssl_support_method":  "sni-only",
+	}, attrs[domain.DistributionViewerCertificateKey])
+	assert.Equal(t, "TLSv1.2_2021", attrs[domain.KeyMinimumProtocolVersion])
+	assert.Equal(t, map[string]any{"bucket": "logs.s3.amazonaws.com", "prefix": "cdn/"}, attrs[domain.DistributionLoggingKey])
+	assert.Equal(t, map[string]any{"restriction_type": "blacklist", "locations": []string{"KP", "RU"}}, attrs[domain.DistributionGeoRestrictionKey])
+}
+
+func TestMapDistributionToAttributes_LoggingDisabled(t *testing.T) {
+	distribution := testDistribution()
+	distribution.DistributionConfig.Logging = &cloudfronttypes.LoggingConfig{Enabled: aws.Bool(false), Bucket: aws.String("")}
+
+	attrs := mapDistributionToAttributes(distribution, nil)
+
+	assert.NotContains(t, attrs, domain.DistributionLoggingKey)
+	assert.NotContains(t, attrs, domain.KeyTags)
+}
+
+func TestNewDistributionResource(t *testing.T) {
+	resource, err := newDistributionResource(testDistribution(), nil, "123456789012")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ResourceMetadata{
+		Kind:               domain.KindCDNDistribution,
+		ProviderType:       "aws",
+		ProviderAssignedID: "E2QWRUHEXAMPLE",
+		SourceIdentifier:   "E2QWRUHEXAMPLE",
+		AccountID:          "123456789012",
+	}, resource.Metadata())
+
+	attrs, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+	attrs[domain.KeyID] = "changed"
+	again, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "E2QWRUHEXAMPLE", again[domain.KeyID], "Attributes returns a copy")
+
+	_, err = newDistributionResource(Distribution{DistributionConfig: &cloudfronttypes.DistributionConfig{}}, nil, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	awstypes "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/config"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// credentialSource is one entry of the prioritized credential list, with its own
+// handlers since handlers build their API clients from the config they are given.
+type credentialSource struct {
+	name      string
+	cfg       aws.Config
+	handlers  map[domain.ResourceKind]AWSResourceHandler
+	stsClient awstypes.STSClientInterface
+}
+
+// failoverState tracks which credential source is in use. Once the provider has
+// failed over it stays on the fallback for the rest of its lifetime.
+type failoverState struct {
+	mu          sync.Mutex
+	active      int
+	annotations []domain.RunAnnotation
+}
+
+// loadFallbackConfig builds the AWS config of a fallback credential source in the
+// primary's region: the named profile, the assumed role, or the role assumed
+// with the profile's credentials when both are set.
+func loadFallbackConfig(ctx context.Context, primary aws.Config, loadOpts []func(*awsconfig.LoadOptions) error, cred config.AWSCredentialConfig) (aws.Config, error) {
+	opts := append([]func(*awsconfig.LoadOptions) error{}, loadOpts...)
+	opts = append(opts, awsconfig.WithRegion(primary.Region))
+	if cred.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(cred.Profile))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, errors.WrapUserFacing(err, errors.CodePlatformAuthError,
+			fmt.Sprintf("failed to load fallback AWS credentials '%s'", credentialName(cred)),
+			"Check the profiles and roles listed under platform.aws.fallback_credentials.")
+	}
+	if cred.RoleARN != "" {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), cred.RoleARN))
+	}
+	return cfg, nil
+}
+
+func credentialName(cred config.AWSCredentialConfig) string {
+	switch {
+	case cred.Profile != "" && cred.RoleARN != "":
+		return fmt.Sprintf("role %s via profile %s", cred.RoleARN, cred.Profile)
+	case cred.RoleARN != "":
+		return "role " + cred.RoleARN
+	default:
+		return "profile " + cred.Profile
+	}
+}
+
+// addFallback appends a credential source tried after the primary and the
+// fallbacks added before it.
+func (p *Provider) addFallback(name string, cfg aws.Config, handlers ...AWSResourceHandler) {
+	src := credentialSource{
+		name:      name,
+		cfg:       cfg,
+		handlers:  make(map[domain.ResourceKind]AWSResourceHandler, len(handlers)),
+		stsClient: sts.NewFromConfig(cfg),
+	}
+	for _, handler := range handlers {
+		src.handlers[handler.Kind()] = handler
+	}
+	p.fallbacks = append(p.fallbacks, src)
+}
+
+// source returns the credential source at idx; zero is the primary.
+func (p *Provider) source(idx int) credentialSource {
+	if idx == 0 {
+		return credentialSource{name: p.primaryName, cfg: p.awsConfig, handlers: p.handlers, stsClient: p.stsClient}
+	}
+	return p.fallbacks[idx-1]
+}
+
+// activeSource returns the credential source currently in use and its index.
+func (p *Provider) activeSource() (int, credentialSource) {
+	p.failover.mu.Lock()
+	idx := p.failover.active
+	p.failover.mu.Unlock()
+	return idx, p.source(idx)
+}
+
+// failoverFrom switches away from the credential source at index failed after
+// an authentication error and returns the index to retry with. If another
+// caller already switched, the current source is returned without moving
+// further. It returns false when no fallback is left.
+func (p *Provider) failoverFrom(ctx context.Context, failed int, kind domain.ResourceKind, cause error) (int, bool) {
+	p.failover.mu.Lock()
+	defer p.failover.mu.Unlock()
+	if p.failover.active > failed {
+		return p.failover.active, true
+	}
+	if failed >= len(p.fallbacks) {
+		return failed, false
+	}
+	from, to := p.source(failed).name, p.source(failed+1).name
+	p.failover.active = failed + 1
+	message := fmt.Sprintf("Switched from %s to %s after an authentication error on %s: %v", from, to, kind, cause)
+	p.failover.annotations = append(p.failover.annotations, domain.RunAnnotation{
+		Source:  awstypes.ProviderTypeAWS,
+		Message: message,
+		Time:    time.Now(),
+	})
+	p.logger.Warnf(ctx, "AWS credential failover: %s", message)
+	return p.failover.active, true
+}
+
+// RunAnnotations returns the credential failovers that happened so far.
+func (p *Provider) RunAnnotations() []domain.RunAnnotation {
+	p.failover.mu.Lock()
+	defer p.failover.mu.Unlock()
+	return append([]domain.RunAnnotation(nil), p.failover.annotations...)
+}
+
+// listWithFailover lists one kind with the active credential source and, on an
+// authentication error, lists it again with the next source. Resources already
+// sent by the failed attempt are not sent twice.
+func (p *Provider) listWithFailover(
+	ctx context.Context,
+	kind domain.ResourceKind,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	idx, src := p.activeSource()
+	if len(p.fallbacks) == 0 {
+		return src.handlers[kind].ListResources(ctx, src.cfg, filters, logger, out)
+	}
+
+	sent := make(map[string]struct{})
+	for {
+		handler, found := src.handlers[kind]
+		if !found {
+			return errors.New(errors.CodeNotImplemented, fmt.Sprintf("resource kind '%s' not supported with %s", kind, src.name))
+		}
+		err := p.listOnce(ctx, idx, kind, handler, src.cfg, filters, logger, out, sent)
+		if err == nil || ctx.Err() != nil || !aws_errors.IsAuthError(err) {
+			return err
+		}
+		next, ok := p.failoverFrom(ctx, idx, kind, err)
+		if !ok {
+			return err
+		}
+		logger.Infof(ctx, "Retrying listing of %s with %s", kind, p.source(next).name)
+		idx, src = next, p.source(next)
+	}
+}
+
+// listOnce runs one listing attempt, forwarding resources not sent before and
+// wrapping them so their lazily fetched attributes fail over as well.
+func (p *Provider) listOnce(
+	ctx context.Context,
+	idx int,
+	kind domain.ResourceKind,
+	handler AWSResourceHandler,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+	sent map[string]struct{},
+) error {
+	attempt := make(chan domain.PlatformResource)
+	forwarded := make(chan error, 1)
+	go func() {
+		var sendErr error
+		for res := range attempt {
+			if sendErr != nil {
+				continue
+			}
+			id := res.Metadata().ProviderAssignedID
+			if _, dup := sent[id]; dup && id != "" {
+				continue
+			}
+			select {
+			case out <- &failoverResource{PlatformResource: res, provider: p, kind: kind, source: idx, logger: logger}:
+				sent[id] = struct{}{}
+			case <-ctx.Done():
+				sendErr = ctx.Err()
+			}
+		}
+		forwarded <- sendErr
+	}()
+
+	err := handler.ListResources(ctx, cfg, filters, logger, attempt)
+	close(attempt)
+	if sendErr := <-forwarded; err == nil {
+		err = sendErr
+	}
+	return err
+}
+
+// failoverResource refetches a resource with the next credential source when
+// its attributes cannot be read with the credentials it was listed with.
+type failoverResource struct {
+	domain.PlatformResource
+	provider *Provider
+	kind     domain.ResourceKind
+	source   int
+	logger   ports.Logger
+}
+
+func (r *failoverResource) Attributes(ctx context.Context) (map[string]any, error) {
+	attrs, err := r.PlatformResource.Attributes(ctx)
+	idx := r.source
+	for err != nil && ctx.Err() == nil && aws_errors.IsAuthError(err) {
+		next, ok := r.provider.failoverFrom(ctx, idx, r.kind, err)
+		if !ok {
+			return nil, err
+		}
+		idx = next
+		src := r.provider.source(idx)
+		handler, found := src.handlers[r.kind]
+		if !found {
+			return nil, err
+		}
+		var fresh domain.PlatformResource
+		fresh, err = handler.GetResource(ctx, src.cfg, r.Metadata().ProviderAssignedID, r.logger)
+		if err == nil {
+			attrs, err = fresh.Attributes(ctx)
+		}
+	}
+	return attrs, err
+}
@@ -0,0 +1,351 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+)
+
+type DynamoDBHandler struct {
+	stsClient      shared.STSClientInterface
+	accountID      string
+	accMu          sync.RWMutex
+	dynamoDBClient DynamoDBClientInterface
+	limiter        shared.RateLimiter
+	errorHandler   shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the DynamoDBHandler.
+type HandlerOption func(*DynamoDBHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *DynamoDBHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithDynamoDBClient provides an option to set a custom DynamoDB client.
+func WithDynamoDBClient(client DynamoDBClientInterface) HandlerOption {
+	return func(h *DynamoDBHandler) {
+		if client != nil {
+			h.dynamoDBClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *DynamoDBHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *DynamoDBHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new DynamoDBHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *DynamoDBHandler {
+	h := &DynamoDBHandler{
+		stsClient:      sts.NewFromConfig(cfg),
+		dynamoDBClient: dynamodb.NewFromConfig(cfg),
+		limiter:        &aws_limiter.DefaultRateLimiter{},
+		errorHandler:   &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *DynamoDBHandler) Kind() domain.ResourceKind {
+	return domain.KindDatabaseTable
+}
+
+func (h *DynamoDBHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "DynamoDB: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+// ListResources lists the tables of the region. ListTables only returns names,
+// so the ID and name filters are applied before describing a table, the billing mode
+// filter after describing it and tag filters after listing its tags. TTL and
+// point-in-time recovery are only fetched for tables that pass every filter.
+func (h *DynamoDBHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for DynamoDB ListResources: %v", accErr)
+	}
+
+	input := &dynamodb.ListTablesInput{Limit: aws.Int32(listPageSize)}
+	tagFilters := tagFiltersFrom(filters)
+
+	logger.Debugf(ctx, "Starting DynamoDB table listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.dynamoDBClient.ListTables(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("DynamoDB", fmt.Sprintf("ListTables:Page%d", pageNum), err, ctx)
+		}
+
+		for _, name := range output.TableNames {
+			if !matchesNameFilters(name, filters) {
+				continue
+			}
+			table, err := h.describeTable(ctx, name, logger)
+			if err != nil {
+				return err
+			}
+			if value, ok := filters[domain.TableBillingModeKey]; ok && !containsValue(value, billingMode(table)) {
+				continue
+			}
+			tags, err := h.listTags(ctx, table, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tags, tagFilters) {
+				continue
+			}
+			resource, err := h.newResource(ctx, table, tags, cfg.Region, accountID, logger)
+			if err != nil {
+				return err
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending DynamoDB table %s", name)
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.LastEvaluatedTableName) == "" {
+			break
+		}
+		input.ExclusiveStartTableName = output.LastEvaluatedTableName
+	}
+
+	logger.Debugf(ctx, "Finished DynamoDB pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *DynamoDBHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single DynamoDB table %s", id)
+	table, err := h.describeTable(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for DynamoDB GetResource: %v", accErr)
+	}
+
+	tags, err := h.listTags(ctx, table, logger)
+	if err != nil {
+		return nil, err
+	}
+	return h.newResource(ctx, table, tags, cfg.Region, accountID, logger)
+}
+
+// EstimateCalls returns the calls listing the given number of tables takes:
+// the ListTables pages, then DescribeTable, ListTagsOfResource,
+// DescribeTimeToLive and DescribeContinuousBackups for every table.
+func (h *DynamoDBHandler) EstimateCalls(resources int) (listCalls, detailCalls int) {
+	return shared.Pages(resources, listPageSize), resources * 4
+}
+
+// Probe verifies that tables can be listed with a single minimal page.
+func (h *DynamoDBHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.dynamoDBClient.ListTables(ctx, &dynamodb.ListTablesInput{Limit: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("DynamoDB", "ListTables", err, ctx)
+	}
+	return nil
+}
+
+func (h *DynamoDBHandler) describeTable(ctx context.Context, name string, logger ports.Logger) (TableDescription, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return TableDescription{}, err
+	}
+	output, err := h.dynamoDBClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(name)})
+	if err != nil {
+		return TableDescription{}, h.errorHandler.Handle("DynamoDB", "DescribeTable", err, ctx)
+	}
+	if output.Table == nil {
+		return TableDescription{}, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("DynamoDB table '%s' not found (empty response)", name))
+	}
+	return *output.Table, nil
+}
+
+func (h *DynamoDBHandler) listTags(ctx context.Context, table TableDescription, logger ports.Logger) (map[string]string, error) {
+	if table.TableArn == nil {
+		return nil, nil
+	}
+	tags := make(map[string]string)
+	input := &dynamodb.ListTagsOfResourceInput{ResourceArn: table.TableArn}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.dynamoDBClient.ListTagsOfResource(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("DynamoDB", "ListTagsOfResource", err, ctx)
+		}
+		for _, tag := range output.Tags {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return tags, nil
+}
+
+// newResource fetches the TTL and point-in-time recovery settings of a
+// described table and wraps it.
+func (h *DynamoDBHandler) newResource(
+	ctx context.Context,
+	table TableDescription,
+	tags map[string]string,
+	region, accountID string,
+	logger ports.Logger,
+) (domain.PlatformResource, error) {
+	name := aws.ToString(table.TableName)
+
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	ttl, err := h.dynamoDBClient.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: table.TableName})
+	if err != nil {
+		return nil, h.errorHandler.Handle("DynamoDB", "DescribeTimeToLive", err, ctx)
+	}
+
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	backups, err := h.dynamoDBClient.DescribeContinuousBackups(ctx, &dynamodb.DescribeContinuousBackupsInput{TableName: table.TableName})
+	if err != nil {
+		return nil, h.errorHandler.Handle("DynamoDB", "DescribeContinuousBackups", err, ctx)
+	}
+
+	resource, mapErr := newTableResource(tableDetails{
+		table:   table,
+		tags:    tags,
+		ttl:     ttl.TimeToLiveDescription,
+		backups: backups.ContinuousBackupsDescription,
+	}, region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for DynamoDB table %s", name))
+	}
+	return resource, nil
+}
+
+// matchesNameFilters applies the ID and name filters, which both match the
+// table name.
+func matchesNameFilters(name string, filters map[string]string) bool {
+	for _, key := range []string{domain.KeyID, domain.KeyName} {
+		if value, ok := filters[key]; ok && !containsValue(value, name) {
+			return false
+		}
+	}
+	return true
+}
+
+func tagFiltersFrom(genericFilters map[string]string) map[string]string {
+	tagFilters := make(map[string]string)
+	for key, value := range genericFilters {
+		if strings.HasPrefix(key, domain.TagPrefix) {
+			tagFilters[strings.TrimPrefix(key, domain.TagPrefix)] = value
+		}
+	}
+	return tagFilters
+}
+
+func matchesTagFilters(tags map[string]string, tagFilters map[string]string) bool {
+	for key, value := range tagFilters {
+		actual, ok := tags[key]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
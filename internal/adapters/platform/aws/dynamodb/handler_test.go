@@ -0,0 +1,222 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	dynamodbmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/dynamodb/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type DynamoDBHandlerTestSuite struct {
+	suite.Suite
+	mockDynamoDB     *dynamodbmocks.DynamoDBClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *DynamoDBHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *DynamoDBHandlerTestSuite) SetupTest() {
+	s.mockDynamoDB = new(dynamodbmocks.DynamoDBClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithDynamoDBClient(s.mockDynamoDB),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *DynamoDBHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestDynamoDBHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DynamoDBHandlerTestSuite))
+}
+
+func tableARN(name string) string {
+	return "arn:aws:dynamodb:us-east-1:123456789012:table/" + name
+}
+
+// expectTable sets up the describe, tag, TTL and backup calls of one table.
+func (s *DynamoDBHandlerTestSuite) expectTable(name string, mode dynamodbtypes.BillingMode, tags map[string]string) {
+	s.mockDynamoDB.On("DescribeTable", mock.Anything, &dynamodb.DescribeTableInput{TableName: aws.String(name)}).
+		Return(&dynamodb.DescribeTableOutput{Table: &dynamodbtypes.TableDescription{
+			TableName:          aws.String(name),
+			TableArn:           aws.String(tableARN(name)),
+			BillingModeSummary: &dynamodbtypes.BillingModeSummary{BillingMode: mode},
+		}}, nil).Once()
+
+	tagList := make([]dynamodbtypes.Tag, 0, len(tags))
+	for k, v := range tags {
+		tagList = append(tagList, dynamodbtypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	s.mockDynamoDB.On("ListTagsOfResource", mock.Anything, &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(tableARN(name))}).
+		Return(&dynamodb.ListTagsOfResourceOutput{Tags: tagList}, nil).Once()
+}
+
+func (s *DynamoDBHandlerTestSuite) expectSettings(name string) {
+	s.mockDynamoDB.On("DescribeTimeToLive", mock.Anything, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(name)}).
+		Return(&dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: &dynamodbtypes.TimeToLiveDescription{
+			TimeToLiveStatus: dynamodbtypes.TimeToLiveStatusEnabled,
+			AttributeName:    aws.String("expires_at"),
+		}}, nil).Once()
+	s.mockDynamoDB.On("DescribeContinuousBackups", mock.Anything, &dynamodb.DescribeContinuousBackupsInput{TableName: aws.String(name)}).
+		Return(&dynamodb.DescribeContinuousBackupsOutput{ContinuousBackupsDescription: &dynamodbtypes.ContinuousBackupsDescription{
+			PointInTimeRecoveryDescription: &dynamodbtypes.PointInTimeRecoveryDescription{
+				PointInTimeRecoveryStatus: dynamodbtypes.PointInTimeRecoveryStatusEnabled,
+			},
+		}}, nil).Once()
+}
+
+func (s *DynamoDBHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *DynamoDBHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindDatabaseTable, s.handler.Kind())
+}
+
+func (s *DynamoDBHandlerTestSuite) TestListResources_Paginates() {
+	s.mockDynamoDB.On("ListTables", mock.Anything, mock.MatchedBy(func(in *dynamodb.ListTablesInput) bool {
+		return in.ExclusiveStartTableName == nil
+	})).Return(&dynamodb.ListTablesOutput{
+		TableNames:             []string{"orders"},
+		LastEvaluatedTableName: aws.String("orders"),
+	}, nil).Once()
+	s.mockDynamoDB.On("ListTables", mock.Anything, mock.MatchedBy(func(in *dynamodb.ListTablesInput) bool {
+		return aws.ToString(in.ExclusiveStartTableName) == "orders"
+	})).Return(&dynamodb.ListTablesOutput{TableNames: []string{"sessions"}}, nil).Once()
+	s.expectTable("orders", dynamodbtypes.BillingModePayPerRequest, map[string]string{"Env": "prod"})
+	s.expectTable("sessions", dynamodbtypes.BillingModeProvisioned, nil)
+	s.expectSettings("orders")
+	s.expectSettings("sessions")
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("orders", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("sessions", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("PAY_PER_REQUEST", attrs[domain.TableBillingModeKey])
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	s.Equal(true, attrs[domain.KeyPointInTimeRecovery])
+	s.Equal(map[string]any{"enabled": true, "attribute_name": "expires_at"}, attrs[domain.TableTTLKey])
+	s.mockDynamoDB.AssertExpectations(s.T())
+}
+
+func (s *DynamoDBHandlerTestSuite) TestListResources_Filters() {
+	s.mockDynamoDB.On("ListTables", mock.Anything, mock.Anything).Return(&dynamodb.ListTablesOutput{
+		TableNames: []string{"orders", "orders-dev", "sessions", "audit"},
+	}, nil).Once()
+	s.expectTable("orders", dynamodbtypes.BillingModePayPerRequest, map[string]string{"Env": "prod"})
+	s.expectTable("orders-dev", dynamodbtypes.BillingModePayPerRequest, map[string]string{"Env": "dev"})
+	s.mockDynamoDB.On("DescribeTable", mock.Anything, &dynamodb.DescribeTableInput{TableName: aws.String("sessions")}).
+		Return(&dynamodb.DescribeTableOutput{Table: &dynamodbtypes.TableDescription{
+			TableName: aws.String("sessions"),
+			TableArn:  aws.String(tableARN("sessions")),
+		}}, nil).Once()
+	s.expectSettings("orders")
+
+	resources, err := s.collect(map[string]string{
+		domain.KeyID:               "orders, orders-dev, sessions",
+		domain.TableBillingModeKey: "PAY_PER_REQUEST",
+		"tag:Env":                  "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("orders", resources[0].Metadata().ProviderAssignedID)
+	// audit is excluded by name before it is described, sessions by billing
+	// mode before its tags are listed, and orders-dev by tag before its
+	// settings are fetched.
+	s.mockDynamoDB.AssertNotCalled(s.T(), "DescribeTable", mock.Anything, &dynamodb.DescribeTableInput{TableName: aws.String("audit")})
+	s.mockDynamoDB.AssertNotCalled(s.T(), "ListTagsOfResource", mock.Anything, &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(tableARN("sessions"))})
+	s.mockDynamoDB.AssertNotCalled(s.T(), "DescribeTimeToLive", mock.Anything, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("orders-dev")})
+	s.mockDynamoDB.AssertExpectations(s.T())
+}
+
+func (s *DynamoDBHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockDynamoDB.On("ListTables", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "DynamoDB", "ListTables:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *DynamoDBHandlerTestSuite) TestGetResource_Success() {
+	s.expectTable("orders", dynamodbtypes.BillingModePayPerRequest, map[string]string{"Env": "prod"})
+	s.expectSettings("orders")
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "orders", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("orders", resource.Metadata().ProviderAssignedID)
+	s.Equal(domain.KindDatabaseTable, resource.Metadata().Kind)
+	s.mockDynamoDB.AssertExpectations(s.T())
+}
+
+func (s *DynamoDBHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockDynamoDB.On("DescribeTable", mock.Anything, mock.Anything).
+		Return(&dynamodb.DescribeTableOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *DynamoDBHandlerTestSuite) TestProbe() {
+	s.mockDynamoDB.On("ListTables", mock.Anything, &dynamodb.ListTablesInput{Limit: aws.Int32(probePageSize)}).
+		Return(&dynamodb.ListTablesOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockDynamoDB.AssertExpectations(s.T())
+}
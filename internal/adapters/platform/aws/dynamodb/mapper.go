@@ -0,0 +1,231 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// tableDetails gathers the responses that together describe a table.
+type tableDetails struct {
+	table   TableDescription
+	tags    map[string]string
+	ttl     *dynamodbtypes.TimeToLiveDescription
+	backups *dynamodbtypes.ContinuousBackupsDescription
+}
+
+// tableResource wraps a described table, which is mapped once when the
+// resource is built.
+type tableResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newTableResource(details tableDetails, region, accountID string) (domain.PlatformResource, error) {
+	name := aws.ToString(details.table.TableName)
+	if name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create DynamoDB resource: missing table name")
+	}
+
+	return &tableResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindDatabaseTable,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: name,
+			SourceIdentifier:   name,
+			AccountID:          accountID,
+			Region:             region,
+			PendingDeletion:    tablePendingDeletion(details.table),
+		},
+		attrs: mapTableToAttributes(details),
+	}, nil
+}
+
+// tablePendingDeletionStates are the table states on the way out: being deleted,
+// or archived after its KMS key became inaccessible.
+var tablePendingDeletionStates = map[dynamodbtypes.TableStatus]bool{
+	dynamodbtypes.TableStatusDeleting:  true,
+	dynamodbtypes.TableStatusArchiving: true,
+	dynamodbtypes.TableStatusArchived:  true,
+}
+
+// tablePendingDeletion reports a table that is being deleted or archived.
+func tablePendingDeletion(table TableDescription) *domain.PendingDeletion {
+	if tablePendingDeletionStates[table.TableStatus] {
+		return &domain.PendingDeletion{State: string(table.TableStatus)}
+	}
+	return nil
+}
+
+func (r *tableResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *tableResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+// billingMode returns the table's billing mode. Tables created as provisioned
+// and never switched report no billing mode summary.
+func billingMode(table TableDescription) string {
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != "" {
+		return string(table.BillingModeSummary.BillingMode)
+	}
+	return string(dynamodbtypes.BillingModeProvisioned)
+}
+
+func mapTableToAttributes(details tableDetails) map[string]any {
+	table := details.table
+	name := aws.ToString(table.TableName)
+	attrs := map[string]any{
+		domain.KeyID:               name,
+		domain.KeyName:             name,
+		domain.TableBillingModeKey: billingMode(table),
+	}
+	if table.TableArn != nil {
+		attrs[domain.KeyARN] = *table.TableArn
+	}
+
+	hashKey, rangeKey := keySchema(table.KeySchema)
+	attrs[domain.TableHashKeyKey] = hashKey
+	if rangeKey != "" {
+		attrs[domain.TableRangeKeyKey] = rangeKey
+	}
+
+	readCapacity, writeCapacity := capacity(table.ProvisionedThroughput)
+	attrs[domain.TableReadCapacityKey] = readCapacity
+	attrs[domain.TableWriteCapacityKey] = writeCapacity
+
+	if len(table.AttributeDefinitions) > 0 {
+		definitions := make([]any, 0, len(table.AttributeDefinitions))
+		for _, definition := range table.AttributeDefinitions {
+			definitions = append(definitions, map[string]any{
+				"name": aws.ToString(definition.AttributeName),
+				"type": string(definition.AttributeType),
+			})
+		}
+		sortByName(definitions)
+		attrs[domain.TableAttributesKey] = definitions
+	}
+
+	if len(table.GlobalSecondaryIndexes) > 0 {
+		indexes := make([]any, 0, len(table.GlobalSecondaryIndexes))
+		for _, gsi := range table.GlobalSecondaryIndexes {
+			index := indexAttributes(gsi.IndexName, gsi.KeySchema, gsi.Projection)
+			index["read_capacity"], index["write_capacity"] = capacity(gsi.ProvisionedThroughput)
+			indexes = append(indexes, index)
+		}
+		sortByName(indexes)
+		attrs[domain.TableGlobalSecondaryIndexesKey] = indexes
+	}
+
+	if len(table.LocalSecondaryIndexes) > 0 {
+		indexes := make([]any, 0, len(table.LocalSecondaryIndexes))
+		for _, lsi := range table.LocalSecondaryIndexes {
+			// Local indexes share the table's hash key; Terraform only records the range key.
+			index := indexAttributes(lsi.IndexName, lsi.KeySchema, lsi.Projection)
+			delete(index, "hash_key")
+			indexes = append(indexes, index)
+		}
+		sortByName(indexes)
+		attrs[domain.TableLocalSecondaryIndexesKey] = indexes
+	}
+
+	streamEnabled := table.StreamSpecification != nil && aws.ToBool(table.StreamSpecification.StreamEnabled)
+	attrs[domain.TableStreamEnabledKey] = streamEnabled
+	if streamEnabled && table.StreamSpecification.StreamViewType != "" {
+		attrs[domain.TableStreamViewTypeKey] = string(table.StreamSpecification.StreamViewType)
+	}
+
+	// Without an SSE description the table uses the default AWS owned key,
+	// which Terraform records as encryption not enabled.
+	sse := map[string]any{"enabled": false}
+	if desc := table.SSEDescription; desc != nil && desc.Status == dynamodbtypes.SSEStatusEnabled {
+		sse["enabled"] = true
+		sse["kms_key_arn"] = aws.ToString(desc.KMSMasterKeyArn)
+	}
+	attrs[domain.TableServerSideEncryptionKey] = sse
+
+	if table.TableClassSummary != nil && table.TableClassSummary.TableClass != "" {
+		attrs[domain.TableClassKey] = string(table.TableClassSummary.TableClass)
+	} else {
+		attrs[domain.TableClassKey] = string(dynamodbtypes.TableClassStandard)
+	}
+	attrs[domain.TableDeletionProtectionKey] = aws.ToBool(table.DeletionProtectionEnabled)
+
+	ttl := map[string]any{"enabled": false}
+	if details.ttl != nil {
+		ttl["enabled"] = details.ttl.TimeToLiveStatus == dynamodbtypes.TimeToLiveStatusEnabled
+		if details.ttl.AttributeName != nil {
+			ttl["attribute_name"] = *details.ttl.AttributeName
+		}
+	}
+	attrs[domain.TableTTLKey] = ttl
+
+	pitr := false
+	if details.backups != nil && details.backups.PointInTimeRecoveryDescription != nil {
+		pitr = details.backups.PointInTimeRecoveryDescription.PointInTimeRecoveryStatus == dynamodbtypes.PointInTimeRecoveryStatusEnabled
+	}
+	attrs[domain.KeyPointInTimeRecovery] = pitr
+
+	if len(details.tags) > 0 {
+		attrs[domain.KeyTags] = details.tags
+	}
+
+	return attrs
+}
+
+func keySchema(elements []dynamodbtypes.KeySchemaElement) (hashKey, rangeKey string) {
+	for _, element := range elements {
+		switch element.KeyType {
+		case dynamodbtypes.KeyTypeHash:
+			hashKey = aws.ToString(element.AttributeName)
+		case dynamodbtypes.KeyTypeRange:
+			rangeKey = aws.ToString(element.AttributeName)
+		}
+	}
+	return hashKey, rangeKey
+}
+
+func capacity(throughput *dynamodbtypes.ProvisionedThroughputDescription) (read, write int64) {
+	if throughput == nil {
+		return 0, 0
+	}
+	return aws.ToInt64(throughput.ReadCapacityUnits), aws.ToInt64(throughput.WriteCapacityUnits)
+}
+
+// indexAttributes maps the parts shared by global and local secondary indexes
+// to the shape of the Terraform index blocks.
+func indexAttributes(name *string, schema []dynamodbtypes.KeySchemaElement, projection *dynamodbtypes.Projection) map[string]any {
+	index := map[string]any{"name": aws.ToString(name)}
+	hashKey, rangeKey := keySchema(schema)
+	index["hash_key"] = hashKey
+	if rangeKey != "" {
+		index["range_key"] = rangeKey
+	}
+	if projection != nil {
+		index["projection_type"] = string(projection.ProjectionType)
+		if len(projection.NonKeyAttributes) > 0 {
+			nonKey := append([]string(nil), projection.NonKeyAttributes...)
+			sort.Strings(nonKey)
+			index["non_key_attributes"] = nonKey
+		}
+	}
+	return index
+}
+
+func sortByName(items []any) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].(map[string]any)["name"].(string) < items[j].(map[string]any)["name"].(string)
+	})
+}
@@ -0,0 +1,166 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func keyElement(name string, keyType dynamodbtypes.KeyType) dynamodbtypes.KeySchemaElement {
+	return dynamodbtypes.KeySchemaElement{AttributeName: aws.String(name), KeyType: keyType}
+}
+
+func TestMapTableToAttributes(t *testing.T) {
+	table := dynamodbtypes.TableDescription{
+		TableName:          aws.String("orders"),
+		TableArn:           aws.String("arn:aws:dynamodb:us-east-1:123456789012:table/orders"),
+		BillingModeSummary: &dynamodbtypes.BillingModeSummary{BillingMode: dynamodbtypes.BillingModeProvisioned},
+		ProvisionedThroughput: &dynamodbtypes.ProvisionedThroughputDescription{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		KeySchema: []dynamodbtypes.KeySchemaElement{
+			keyElement("pk", dynamodbtypes.KeyTypeHash),
+			keyElement("sk", dynamodbtypes.KeyTypeRange),
+		},
+		AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
+			{AttributeName: aws.String("sk"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+			{AttributeName: aws.String("customer"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+			{AttributeName: aws.String("pk"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexes: []dynamodbtypes.GlobalSecondaryIndexDescription{
+			{
+				IndexName: aws.String("by-status"),
+				KeySchema: []dynamodbtypes.KeySchemaElement{keyElement("status", dynamodbtypes.KeyTypeHash)},
+				Projection: &dynamodbtypes.Projection{
+					ProjectionType:   dynamodbtypes.ProjectionTypeInclude,
+					NonKeyAttributes: []string{"total", "created_at"},
+				},
+				ProvisionedThroughput: &dynamodbtypes.ProvisionedThroughputDescription{
+					ReadCapacityUnits:  aws.Int64(1),
+					WriteCapacityUnits: aws.Int64(1),
+				},
+			},
+			{
+				IndexName:  aws.String("by-customer"),
+				KeySchema:  []dynamodbtypes.KeySchemaElement{keyElement("customer", dynamodbtypes.KeyTypeHash), keyElement("sk", dynamodbtypes.KeyTypeRange)},
+				Projection: &dynamodbtypes.Projection{ProjectionType: dynamodbtypes.ProjectionTypeAll},
+			},
+		},
+		LocalSecondaryIndexes: []dynamodbtypes.LocalSecondaryIndexDescription{{
+			IndexName:  aws.String("by-created"),
+			KeySchema:  []dynamodbtypes.KeySchemaElement{keyElement("pk", dynamodbtypes.KeyTypeHash), keyElement("created_at", dynamodbtypes.KeyTypeRange)},
+			Projection: &dynamodbtypes.Projection{ProjectionType: dynamodbtypes.ProjectionTypeKeysOnly},
+		}},
+		StreamSpecification: &dynamodbtypes.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: dynamodbtypes.StreamViewTypeNewAndOldImages,
+		},
+		SSEDescription: &dynamodbtypes.SSEDescription{
+			Status:          dynamodbtypes.SSEStatusEnabled,
+			SSEType:         dynamodbtypes.SSETypeKms,
+			KMSMasterKeyArn: aws.String("arn:aws:kms:us-east-1:123456789012:key/abc"),
+		},
+		TableClassSummary:         &dynamodbtypes.TableClassSummary{TableClass: dynamodbtypes.TableClassStandardInfrequentAccess},
+		DeletionProtectionEnabled: aws.Bool(true),
+	}
+
+	attrs := mapTableToAttributes(tableDetails{
+		table: table,
+		tags:  map[string]string{"Env": "prod"},
+		ttl: &dynamodbtypes.TimeToLiveDescription{
+			TimeToLiveStatus: dynamodbtypes.TimeToLiveStatusEnabled,
+			AttributeName:    aws.String("expires_at"),
+		},
+		backups: &dynamodbtypes.ContinuousBackupsDescription{
+			PointInTimeRecoveryDescription: &dynamodbtypes.PointInTimeRecoveryDescription{
+				PointInTimeRecoveryStatus: dynamodbtypes.PointInTimeRecoveryStatusDisabled,
+			},
+		},
+	})
+
+	assert.Equal(t, "orders", attrs[domain.KeyID])
+	assert.Equal(t, "orders", attrs[domain.KeyName])
+	assert.Equal(t, "PROVISIONED", attrs[domain.TableBillingModeKey])
+	assert.Equal(t, int64(5), attrs[domain.TableReadCapacityKey])
+	assert.Equal(t, int64(10), attrs[domain.TableWriteCapacityKey])
+	assert.Equal(t, "pk", attrs[domain.TableHashKeyKey])
+	assert.Equal(t, "sk", attrs[domain.TableRangeKeyKey])
+	assert.Equal(t, []any{
+		map[string]any{"name": "customer", "type": "S"},
+		map[string]any{"name": "pk", "type": "S"},
+		map[string]any{"name": "sk", "type": "S"},
+	}, attrs[domain.TableAttributesKey])
+	assert.Equal(t, []any{
+		map[string]any{"name": "by-customer", "hash_key": "customer", "range_key": "sk", "projection_type": "ALL", "read_capacity": int64(0), "write_capacity": int64(0)},
+		map[string]any{"name": "by-status", "hash_key": "status", "projection_type": "INCLUDE", "non_key_attributes": []string{"created_at", "total"}, "read_capacity": int64(1), "write_capacity": int64(1)},
+	}, attrs[domain.TableGlobalSecondaryIndexesKey])
+	assert.Equal(t, []any{
+		map[string]any{"name": "by-created", "range_key": "created_at", "projection_type": "KEYS_ONLY"},
+	}, attrs[domain.TableLocalSecondaryIndexesKey])
+	assert.Equal(t, true, attrs[domain.TableStreamEnabledKey])
+	assert.Equal(t, "NEW_AND_OLD_IMAGES", attrs[domain.TableStreamViewTypeKey])
+	assert.Equal(t, map[string]any{"enabled": true, "kms_key_arn": "arn:aws:kms:us-east-1:123456789012:key/abc"}, attrs[domain.TableServerSideEncryptionKey])
+	assert.Equal(t, "STANDARD_INFREQUENT_ACCESS", attrs[domain.TableClassKey])
+	assert.Equal(t, true, attrs[domain.TableDeletionProtectionKey])
+	assert.Equal(t, map[string]any{"enabled": true, "attribute_name": "expires_at"}, attrs[domain.TableTTLKey])
+	assert.Equal(t, false, attrs[domain.KeyPointInTimeRecovery])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func TestMapTableToAttributes_Defaults(t *testing.T) {
+	attrs := mapTableToAttributes(tableDetails{table: dynamodbtypes.TableDescription{TableName: aws.String("orders")}})
+
+	assert.Equal(t, "PROVISIONED", attrs[domain.TableBillingModeKey])
+	assert.Equal(t, "STANDARD", attrs[domain.TableClassKey])
+	assert.Equal(t, false, attrs[domain.TableStreamEnabledKey])
+	assert.NotContains(t, attrs, domain.TableStreamViewTypeKey)
+	assert.Equal(t, map[string]any{"enabled": false}, attrs[domain.TableServerSideEncryptionKey])
+	assert.Equal(t, map[string]any{"enabled": false}, attrs[domain.TableTTLKey])
+	assert.Equal(t, false, attrs[domain.KeyPointInTimeRecovery])
+	assert.NotContains(t, attrs, domain.TableGlobalSecondaryIndexesKey)
+	assert.NotContains(t, attrs, domain.KeyTags)
+}
+
+func TestNewTableResource(t *testing.T) {
+	res, err := newTableResource(tableDetails{table: dynamodbtypes.TableDescription{TableName: aws.String("orders")}}, "eu-west-1", "123456789012")
+	require.NoError(t, err)
+
+	meta := res.Metadata()
+	assert.Equal(t, domain.KindDatabaseTable, meta.Kind)
+	assert.Equal(t, "orders", meta.ProviderAssignedID)
+	assert.Equal(t, "eu-west-1", meta.Region)
+
+	attrs, err := res.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "orders", attrs[domain.KeyName])
+
+	assert.Nil(t, meta.PendingDeletion)
+
+	_, err = newTableResource(tableDetails{}, "eu-west-1", "")
+	assert.Error(t, err)
+}
+
+func TestTablePendingDeletion(t *testing.T) {
+	testCases := []struct {
+		status dynamodbtypes.TableStatus
+		want   *domain.PendingDeletion
+	}{
+		{dynamodbtypes.TableStatusActive, nil},
+		{dynamodbtypes.TableStatusUpdating, nil},
+		{dynamodbtypes.TableStatusDeleting, &domain.PendingDeletion{State: "DELETING"}},
+		{dynamodbtypes.TableStatusArchived, &domain.PendingDeletion{State: "ARCHIVED"}},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			table := dynamodbtypes.TableDescription{TableName: aws.String("orders"), TableStatus: tc.status}
+			assert.Equal(t, tc.want, tablePendingDeletion(table))
+		})
+	}
+}
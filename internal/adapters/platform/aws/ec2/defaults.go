@@ -0,0 +1,93 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+)
+
+// Cloud Control type names whose AWS-created resources DefaultsLookup finds.
+const (
+	typeNameVPC        = "AWS::EC2::VPC"
+	typeNameRouteTable = "AWS::EC2::RouteTable"
+)
+
+// DefaultsClientInterface lists the VPCs and route tables AWS creates itself.
+type DefaultsClientInterface interface {
+	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
+	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+}
+
+// DefaultsLookup finds the default VPC and the main route tables of a region.
+// The resource models Cloud Control returns for these types do not say whether
+// AWS created the resource, so kinds backed by them ask EC2 instead.
+type DefaultsLookup struct {
+	client DefaultsClientInterface
+}
+
+// NewDefaultsLookup creates a lookup using an EC2 client built from cfg.
+func NewDefaultsLookup(cfg aws.Config) *DefaultsLookup {
+	return &DefaultsLookup{client: ec2.NewFromConfig(cfg)}
+}
+
+// NewDefaultsLookupWithClient creates a lookup using the given client.
+func NewDefaultsLookupWithClient(client DefaultsClientInterface) *DefaultsLookup {
+	return &DefaultsLookup{client: client}
+}
+
+// PlatformDefaults returns the identifiers of the resources of a Cloud Control
+// type that AWS created, mapped to the reason. Types it knows nothing about
+// have none.
+func (l *DefaultsLookup) PlatformDefaults(ctx context.Context, typeName string) (map[string]string, error) {
+	switch typeName {
+	case typeNameVPC:
+		return l.defaultVPCs(ctx)
+	case typeNameRouteTable:
+		return l.mainRouteTables(ctx)
+	}
+	return nil, nil
+}
+
+func (l *DefaultsLookup) defaultVPCs(ctx context.Context) (map[string]string, error) {
+	defaults := make(map[string]string)
+	input := &ec2.DescribeVpcsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("is-default"), Values: []string{"true"}}},
+	}
+	for {
+		output, err := l.client.DescribeVpcs(ctx, input)
+		if err != nil {
+			return nil, aws_errors.HandleAWSError("EC2 Default VPC", "is-default", err, ctx)
+		}
+		for _, vpc := range output.Vpcs {
+			defaults[aws.ToString(vpc.VpcId)] = shared.DefaultVPC
+		}
+		if aws.ToString(output.NextToken) == "" {
+			return defaults, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (l *DefaultsLookup) mainRouteTables(ctx context.Context) (map[string]string, error) {
+	defaults := make(map[string]string)
+	input := &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("association.main"), Values: []string{"true"}}},
+	}
+	for {
+		output, err := l.client.DescribeRouteTables(ctx, input)
+		if err != nil {
+			return nil, aws_errors.HandleAWSError("EC2 Main Route Table", "association.main", err, ctx)
+		}
+		for _, table := range output.RouteTables {
+			defaults[aws.ToString(table.RouteTableId)] = shared.MainRouteTable
+		}
+		if aws.ToString(output.NextToken) == "" {
+			return defaults, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
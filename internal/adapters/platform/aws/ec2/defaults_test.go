@@ -0,0 +1,72 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDefaultsClient serves one page per entry and records the filters it was
+// called with.
+type fakeDefaultsClient struct {
+	vpcPages   [][]ec2types.Vpc
+	tablePages [][]ec2types.RouteTable
+	filters    []ec2types.Filter
+}
+
+func (c *fakeDefaultsClient) DescribeVpcs(_ context.Context, in *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	c.filters = in.Filters
+	page, next := nextPage(in.NextToken, len(c.vpcPages))
+	return &ec2.DescribeVpcsOutput{Vpcs: c.vpcPages[page], NextToken: next}, nil
+}
+
+func (c *fakeDefaultsClient) DescribeRouteTables(_ context.Context, in *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	c.filters = in.Filters
+	page, next := nextPage(in.NextToken, len(c.tablePages))
+	return &ec2.DescribeRouteTablesOutput{RouteTables: c.tablePages[page], NextToken: next}, nil
+}
+
+// nextPage turns a token holding the page number into the page to serve and
+// the token of the following page.
+func nextPage(token *string, pages int) (int, *string) {
+	page := len(aws.ToString(token))
+	if page+1 >= pages {
+		return page, nil
+	}
+	return page, aws.String(aws.ToString(token) + ".")
+}
+
+func TestDefaultsLookup_DefaultVPCs(t *testing.T) {
+	client := &fakeDefaultsClient{vpcPages: [][]ec2types.Vpc{
+		{{VpcId: aws.String("vpc-1")}},
+		{{VpcId: aws.String("vpc-2")}},
+	}}
+
+	defaults, err := NewDefaultsLookupWithClient(client).PlatformDefaults(context.Background(), "AWS::EC2::VPC")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"vpc-1": "default VPC", "vpc-2": "default VPC"}, defaults)
+	assert.Equal(t, []ec2types.Filter{{Name: aws.String("is-default"), Values: []string{"true"}}}, client.filters)
+}
+
+func TestDefaultsLookup_MainRouteTables(t *testing.T) {
+	client := &fakeDefaultsClient{tablePages: [][]ec2types.RouteTable{{{RouteTableId: aws.String("rtb-1")}}}}
+
+	defaults, err := NewDefaultsLookupWithClient(client).PlatformDefaults(context.Background(), "AWS::EC2::RouteTable")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rtb-1": "main route table"}, defaults)
+	assert.Equal(t, []ec2types.Filter{{Name: aws.String("association.main"), Values: []string{"true"}}}, client.filters)
+}
+
+func TestDefaultsLookup_OtherTypes(t *testing.T) {
+	defaults, err := NewDefaultsLookupWithClient(&fakeDefaultsClient{}).PlatformDefaults(context.Background(), "AWS::SQS::Queue")
+
+	require.NoError(t, err)
+	assert.Empty(t, defaults)
+}
@@ -0,0 +1,107 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// ElasticIPHandler lists Elastic IP addresses. It shares the clients, limiter
+// and account ID lookup of the instance handler.
+type ElasticIPHandler struct {
+	*EC2Handler
+}
+
+// NewElasticIPHandler creates a new ElasticIPHandler with the given AWS config
+// and the same options as the instance handler.
+func NewElasticIPHandler(cfg aws.Config, opts ...HandlerOption) *ElasticIPHandler {
+	return &ElasticIPHandler{EC2Handler: NewHandler(cfg, opts...)}
+}
+
+func (h *ElasticIPHandler) Kind() domain.ResourceKind {
+	return domain.KindElasticIP
+}
+
+// ListResources lists the addresses in a single call; DescribeAddresses does
+// not paginate.
+func (h *ElasticIPHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for Elastic IP ListResources: %v", accErr)
+	}
+
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	output, err := h.ec2Client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{Filters: BuildElasticIPFilters(filters)})
+	if err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeAddresses", err, ctx)
+	}
+
+	for _, address := range output.Addresses {
+		resource, mapErr := newElasticIPResource(address, cfg.Region, accountID)
+		if mapErr != nil {
+			logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Elastic IP %s, skipping", aws.ToString(address.PublicIp))
+			continue
+		}
+		select {
+		case out <- resource:
+		case <-ctx.Done():
+			logger.Warnf(ctx, "Context cancelled while sending Elastic IP %s", aws.ToString(address.AllocationId))
+			return ctx.Err()
+		}
+	}
+
+	logger.Debugf(ctx, "Finished Elastic IP listing (%d addresses).", len(output.Addresses))
+	return nil
+}
+
+func (h *ElasticIPHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Describing single Elastic IP %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.ec2Client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{AllocationIds: []string{id}})
+	if err != nil {
+		return nil, h.errorHandler.Handle("EC2", "DescribeAddresses", err, ctx)
+	}
+	if len(output.Addresses) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("Elastic IP with allocation ID '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for Elastic IP GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newElasticIPResource(output.Addresses[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for Elastic IP %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that addresses can be described. DescribeAddresses has no
+// page size, so the probe asks for a single unlikely public IP.
+func (h *ElasticIPHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	input := &ec2.DescribeAddressesInput{Filters: BuildElasticIPFilters(map[string]string{domain.ElasticIPPublicIPKey: "192.0.2.1"})}
+	if _, err := h.ec2Client.DescribeAddresses(ctx, input); err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeAddresses", err, ctx)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// ElasticIPHandlerTestSuite runs the handler with real SDK clients against
+// the awsfake server.
+type ElasticIPHandlerTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *ElasticIPHandler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *ElasticIPHandlerTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T())
+	s.fake.AddAddresses(
+		awsfake.Address{AllocationID: "eipalloc-1", PublicIP: "198.51.100.1", InstanceID: "i-1", NetworkInterfaceID: "eni-1", PrivateIP: "10.0.0.5", Tags: map[string]string{"Team": "web"}},
+		awsfake.Address{AllocationID: "eipalloc-2", PublicIP: "198.51.100.2"},
+	)
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+
+	s.handler = NewElasticIPHandler(s.fake.Config())
+}
+
+func (s *ElasticIPHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestElasticIPHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ElasticIPHandlerTestSuite))
+}
+
+func (s *ElasticIPHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *ElasticIPHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindElasticIP, s.handler.Kind())
+}
+
+func (s *ElasticIPHandlerTestSuite) TestListResources() {
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("eipalloc-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal(awsfake.DefaultAccountID, resources[0].Metadata().AccountID)
+	s.Equal(domain.KindElasticIP, resources[0].Metadata().Kind)
+	s.Equal(1, s.fake.Calls("DescribeAddresses"))
+}
+
+func (s *ElasticIPHandlerTestSuite) TestListResources_PassesFilters() {
+	resources, err := s.collect(map[string]string{domain.ElasticIPInstanceKey: "i-1"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("eipalloc-1", resources[0].Metadata().ProviderAssignedID)
+}
+
+func (s *ElasticIPHandlerTestSuite) TestListResources_APIError() {
+	s.fake.Fail("DescribeAddresses", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", Times: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Empty(resources)
+}
+
+func (s *ElasticIPHandlerTestSuite) TestGetResource_MapsAssociation() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "eipalloc-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("198.51.100.1", attrs[domain.ElasticIPPublicIPKey])
+	s.Equal("vpc", attrs[domain.ElasticIPDomainKey])
+	s.Equal("eipassoc-1", attrs[domain.ElasticIPAssociationIDKey])
+	s.Equal("i-1", attrs[domain.ElasticIPInstanceKey])
+	s.Equal("eni-1", attrs[domain.ElasticIPNetworkInterfaceKey])
+	s.Equal("10.0.0.5", attrs[domain.ElasticIPPrivateIPKey])
+	s.Equal(map[string]string{"Team": "web"}, attrs[domain.KeyTags])
+}
+
+func (s *ElasticIPHandlerTestSuite) TestGetResource_NotFound() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "eipalloc-missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func (s *ElasticIPHandlerTestSuite) TestProbe() {
+	s.NoError(s.handler.Probe(s.ctx, s.fake.Config(), s.mockLogger))
+	s.Equal(1, s.fake.Calls("DescribeAddresses"))
+}
@@ -0,0 +1,84 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	iddErrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// elasticIPResource wraps a described Elastic IP. DescribeAddresses returns
+// the association and tags, so attributes are mapped once when the resource
+// is built.
+type elasticIPResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newElasticIPResource(address Address, region, accountID string) (domain.PlatformResource, error) {
+	allocationID := aws.ToString(address.AllocationId)
+	if allocationID == "" {
+		return nil, iddErrors.New(iddErrors.CodeInternal, "failed to create Elastic IP resource: missing allocation ID")
+	}
+	return &elasticIPResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindElasticIP,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: allocationID,
+			SourceIdentifier:   allocationID,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapElasticIPToAttributes(address, region, accountID),
+	}, nil
+}
+
+func (r *elasticIPResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *elasticIPResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+// mapElasticIPToAttributes maps an address. The association attributes are
+// left out when the address is not associated.
+func mapElasticIPToAttributes(address Address, region, accountID string) map[string]any {
+	allocationID := aws.ToString(address.AllocationId)
+	attrs := map[string]any{
+		domain.KeyID:                allocationID,
+		domain.ElasticIPPublicIPKey: aws.ToString(address.PublicIp),
+		domain.ElasticIPDomainKey:   string(address.Domain),
+	}
+	if accountID != "" {
+		attrs[domain.KeyARN] = fmt.Sprintf("arn:aws:ec2:%s:%s:elastic-ip/%s", region, accountID, allocationID)
+	}
+	for key, value := range map[string]*string{
+		domain.ElasticIPAssociationIDKey:    address.AssociationId,
+		domain.ElasticIPInstanceKey:         address.InstanceId,
+		domain.ElasticIPNetworkInterfaceKey: address.NetworkInterfaceId,
+		domain.ElasticIPPrivateIPKey:        address.PrivateIpAddress,
+	} {
+		if v := aws.ToString(value); v != "" {
+			attrs[key] = v
+		}
+	}
+	if len(address.Tags) > 0 {
+		tags := make(map[string]string, len(address.Tags))
+		for _, tag := range address.Tags {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
@@ -0,0 +1,52 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestNewElasticIPResource_MissingAllocationID(t *testing.T) {
+	_, err := newElasticIPResource(ec2types.Address{PublicIp: aws.String("198.51.100.1")}, "us-east-1", "123456789012")
+	assert.Error(t, err)
+}
+
+func TestMapElasticIPToAttributes(t *testing.T) {
+	address := ec2types.Address{
+		AllocationId:       aws.String("eipalloc-1"),
+		AssociationId:      aws.String("eipassoc-1"),
+		Domain:             ec2types.DomainTypeVpc,
+		PublicIp:           aws.String("198.51.100.1"),
+		InstanceId:         aws.String("i-1"),
+		NetworkInterfaceId: aws.String("eni-1"),
+		PrivateIpAddress:   aws.String("10.0.0.5"),
+		Tags:               []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("nat")}},
+	}
+
+	attrs := mapElasticIPToAttributes(address, "us-east-1", "123456789012")
+
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                        "eipalloc-1",
+		domain.KeyARN:                       "arn:aws:ec2:us-east-1:123456789012:elastic-ip/eipalloc-1",
+		domain.ElasticIPPublicIPKey:         "198.51.100.1",
+		domain.ElasticIPDomainKey:           "vpc",
+		domain.ElasticIPAssociationIDKey:    "eipassoc-1",
+		domain.ElasticIPInstanceKey:         "i-1",
+		domain.ElasticIPNetworkInterfaceKey: "eni-1",
+		domain.ElasticIPPrivateIPKey:        "10.0.0.5",
+		domain.KeyTags:                      map[string]string{"Name": "nat"},
+	}, attrs)
+}
+
+func TestMapElasticIPToAttributes_Unassociated(t *testing.T) {
+	attrs := mapElasticIPToAttributes(ec2types.Address{AllocationId: aws.String("eipalloc-1"), PublicIp: aws.String("198.51.100.1"), Domain: ec2types.DomainTypeVpc}, "us-east-1", "")
+
+	assert.NotContains(t, attrs, domain.KeyARN, "no ARN without an account ID")
+	for _, key := range []string{domain.ElasticIPAssociationIDKey, domain.ElasticIPInstanceKey, domain.ElasticIPNetworkInterfaceKey, domain.ElasticIPPrivateIPKey} {
+		assert.NotContains(t, attrs, key)
+	}
+}
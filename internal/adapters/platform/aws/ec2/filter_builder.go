@@ -19,6 +19,29 @@ var ec2FilterNameMap = map[string]string{
 	domain.ComputeIAMInstanceProfileKey: "iam-instance-profile.arn", // Note: Uses ARN for filtering
 }
 
+var securityGroupFilterNameMap = map[string]string{
+	domain.KeyID:                 "group-id",
+	domain.KeyName:               "group-name",
+	domain.SecurityGroupVPCIDKey: "vpc-id",
+}
+
+var launchTemplateFilterNameMap = map[string]string{
+	domain.KeyName: "launch-template-name",
+}
+
+var elasticIPFilterNameMap = map[string]string{
+	domain.ElasticIPPublicIPKey:         "public-ip",
+	domain.ElasticIPInstanceKey:         "instance-id",
+	domain.ElasticIPNetworkInterfaceKey: "network-interface-id",
+}
+
+var networkInterfaceFilterNameMap = map[string]string{
+	domain.KeyID:                       "network-interface-id",
+	domain.NetworkInterfaceSubnetIDKey: "subnet-id",
+	domain.NetworkInterfaceTypeKey:     "interface-type",
+	domain.SecurityGroupVPCIDKey:       "vpc-id",
+}
+
 var multiValueFilters = map[string]struct{}{
 	"instance-id":       {},
 	"image-id":          {},
@@ -106,3 +129,50 @@ func SplitFilterValue(value string) []string {
 	}
 	return trimmedParts
 }
+
+// BuildSecurityGroupFilters translates generic filters into DescribeSecurityGroups
+// filters. Comma separated values match any of the values.
+func BuildSecurityGroupFilters(genericFilters map[string]string) []types.Filter {
+	return buildMappedFilters(genericFilters, securityGroupFilterNameMap)
+}
+
+// BuildLaunchTemplateFilters translates generic filters into
+// DescribeLaunchTemplates filters. Comma separated values match any of the
+// values.
+func BuildLaunchTemplateFilters(genericFilters map[string]string) []types.Filter {
+	return buildMappedFilters(genericFilters, launchTemplateFilterNameMap)
+}
+
+// BuildElasticIPFilters translates generic filters into DescribeAddresses
+// filters. Comma separated values match any of the values.
+func BuildElasticIPFilters(genericFilters map[string]string) []types.Filter {
+	return buildMappedFilters(genericFilters, elasticIPFilterNameMap)
+}
+
+// BuildNetworkInterfaceFilters translates generic filters into
+// DescribeNetworkInterfaces filters. Comma separated values match any of the
+// values.
+func BuildNetworkInterfaceFilters(genericFilters map[string]string) []types.Filter {
+	return buildMappedFilters(genericFilters, networkInterfaceFilterNameMap)
+}
+
+// buildMappedFilters translates tag filters and the generic filters named in
+// nameMap, skipping any other filter.
+func buildMappedFilters(genericFilters map[string]string, nameMap map[string]string) []types.Filter {
+	ec2Filters := make([]types.Filter, 0, len(genericFilters))
+	for key, value := range genericFilters {
+		var filterName string
+		if strings.HasPrefix(key, domain.TagPrefix) {
+			filterName = awsTagFilterPrefix + strings.TrimPrefix(key, domain.TagPrefix)
+		} else if mappedName, ok := nameMap[key]; ok {
+			filterName = mappedName
+		} else {
+			continue
+		}
+		ec2Filters = append(ec2Filters, types.Filter{
+			Name:   &filterName,
+			Values: SplitFilterValue(value),
+		})
+	}
+	return ec2Filters
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
 
@@ -18,6 +19,13 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	// instanceIDBatchSize is the most instance IDs DescribeInstances accepts.
+	instanceIDBatchSize = 1000
+	// filterValueBatchSize is the most values EC2 accepts in one filter.
+	filterValueBatchSize = 200
+)
+
 type EC2Handler struct {
 	stsClient        shared.STSClientInterface
 	accountID        string
@@ -155,7 +163,16 @@ func (h *EC2Handler) ListResources(
 	pageNum := 0
 	concurrencyLimit := 10
 	sem := make(chan struct{}, concurrencyLimit)
-	g, childCtx := errgroup.WithContext(ctx)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g, childCtx := errgroup.WithContext(cancelCtx)
+	// abort stops the workers of the pages already fetched and waits for them,
+	// so none of them sends on out after ListResources returns.
+	abort := func(err error) error {
+		cancel()
+		_ = g.Wait()
+		return err
+	}
 
 	logger.Debugf(ctx, "Starting EC2 instance listing with pagination")
 
@@ -163,21 +180,22 @@ func (h *EC2Handler) ListResources(
 		select {
 		case <-childCtx.Done():
 			logger.Warnf(childCtx, "Context cancelled during pagination loop")
-			return childCtx.Err()
+			return abort(childCtx.Err())
 		default:
 		}
 		currentPageNum := pageNum + 1
 		logger.Debugf(childCtx, "Fetching EC2 instances page %d", currentPageNum)
 
 		if err := h.limiter.Wait(childCtx, logger); err != nil {
-			return err
+			return abort(err)
 		}
 		output, err := paginator.NextPage(childCtx)
 		if err != nil {
-			return h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeInstances:Page%d", currentPageNum), err, childCtx)
+			return abort(h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeInstances:Page%d", currentPageNum), err, childCtx))
 		}
 		pageNum = currentPageNum
 
+		volumes := h.prefetchVolumes(childCtx, output.Reservations, logger)
 		for _, reservation := range output.Reservations {
 			for _, instance := range reservation.Instances {
 				instance := instance
@@ -188,12 +206,13 @@ func (h *EC2Handler) ListResources(
 					case <-childCtx.Done():
 						return childCtx.Err()
 					default:
-						resource, mapErr := newEc2InstanceResource(
+						resource, mapErr := newPrefetchedEc2InstanceResource(
 							instance,
 							cfg.Region,
 							accountID,
 							logger,
 							client,
+							volumes,
 						)
 						if mapErr != nil {
 							logger.Errorf(childCtx, mapErr, "Failed to create resource wrapper for instance %s, skipping", aws.ToString(instance.InstanceId))
@@ -267,5 +286,129 @@ func (h *EC2Handler) GetResource(ctx context.Context, cfg aws.Config, id string,
 	return resource, nil
 }
 
+// GetResources describes the given instances in batches of up to
+// instanceIDBatchSize IDs. DescribeInstances rejects a whole batch when one of
+// its IDs does not exist, so such a batch is described again with an
+// instance-id filter, which skips unknown IDs.
+func (h *EC2Handler) GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error) {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for EC2 GetResources: %v", accErr)
+	}
+
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	for _, batch := range shared.ChunkIDs(ids, instanceIDBatchSize) {
+		logger.Debugf(ctx, "Describing batch of %d instances", len(batch))
+		err := h.describeInstances(ctx, cfg, &ec2.DescribeInstancesInput{InstanceIds: batch}, accountID, logger, resources)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, errors.CodeResourceNotFound) {
+			return nil, err
+		}
+		logger.Debugf(ctx, "Batch contains unknown instance IDs, describing it by filter")
+		for _, filterBatch := range shared.ChunkIDs(batch, filterValueBatchSize) {
+			input := &ec2.DescribeInstancesInput{
+				Filters: []ec2types.Filter{{Name: aws.String("instance-id"), Values: filterBatch}},
+			}
+			if err := h.describeInstances(ctx, cfg, input, accountID, logger, resources); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resources, nil
+}
+
+// describeInstances describes every page of input and adds the instances to
+// resources, keyed by instance ID.
+func (h *EC2Handler) describeInstances(
+	ctx context.Context,
+	cfg aws.Config,
+	input *ec2.DescribeInstancesInput,
+	accountID string,
+	logger ports.Logger,
+	resources map[string]domain.PlatformResource,
+) error {
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.ec2Client.DescribeInstances(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("EC2", "DescribeInstances", err, ctx)
+		}
+		volumes := h.prefetchVolumes(ctx, output.Reservations, logger)
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				id := aws.ToString(instance.InstanceId)
+				resource, mapErr := newPrefetchedEc2InstanceResource(instance, cfg.Region, accountID, logger, h.ec2Client, volumes)
+				if mapErr != nil {
+					return errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for instance %s", id))
+				}
+				resources[id] = resource
+			}
+		}
+		if aws.ToString(output.NextToken) == "" {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// prefetchVolumes describes the EBS volumes attached to the instances of a
+// page with one DescribeVolumes call per filterValueBatchSize volumes, instead
+// of one call per instance when attributes are built. A volume-id filter is
+// used so volumes deleted in the meantime are skipped rather than failing the
+// batch. On error it returns nil and the instances describe their own volumes.
+func (h *EC2Handler) prefetchVolumes(ctx context.Context, reservations []ec2types.Reservation, logger ports.Logger) map[string]ec2types.Volume {
+	var volumeIDs []string
+	for _, reservation := range reservations {
+		for _, instance := range reservation.Instances {
+			volumeIDs = append(volumeIDs, blockDeviceVolumeIDs(instance.BlockDeviceMappings)...)
+		}
+	}
+	if len(volumeIDs) == 0 {
+		return nil
+	}
+
+	volumes := make(map[string]ec2types.Volume, len(volumeIDs))
+	for _, batch := range shared.ChunkIDs(volumeIDs, filterValueBatchSize) {
+		logger.Debugf(ctx, "Prefetching batch of %d EBS volumes", len(batch))
+		input := &ec2.DescribeVolumesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("volume-id"), Values: batch}},
+		}
+		for {
+			if err := h.limiter.Wait(ctx, logger); err != nil {
+				logger.Warnf(ctx, "Skipping EBS volume prefetch: %v", err)
+				return nil
+			}
+			output, err := h.ec2Client.DescribeVolumes(ctx, input)
+			if err != nil {
+				logger.Warnf(ctx, "Skipping EBS volume prefetch, volumes are described per instance: %v", h.errorHandler.Handle("EC2", "DescribeVolumes", err, ctx))
+				return nil
+			}
+			for _, vol := range output.Volumes {
+				volumes[aws.ToString(vol.VolumeId)] = vol
+			}
+			if aws.ToString(output.NextToken) == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return volumes
+}
+
 type DescribeInstanceAttributeInput = ec2.DescribeInstanceAttributeInput
 type DescribeVolumesInput = ec2.DescribeVolumesInput
+
+// Probe verifies that instances can be described with a single minimal page.
+func (h *EC2Handler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{MaxResults: aws.Int32(5)}); err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeInstances", err, ctx)
+	}
+	return nil
+}
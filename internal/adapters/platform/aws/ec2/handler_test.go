@@ -18,6 +18,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/retry"
 	// Import mocks for EC2 interfaces
 	ec2mocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/ec2/mocks"
 	// Import mocks for shared interfaces
@@ -195,71 +197,6 @@ func (s *EC2HandlerTestSuite) TestGetAccountID_ErrorNoAccountInResponse() {
 
 // --- ListResources Tests ---
 
-func (s *EC2HandlerTestSuite) TestListResources_Success() {
-	accountID := "555666777888"
-	instanceID1 := "i-123"
-	instanceID2 := "i-456"
-
-	// Mock getAccountID success
-	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil).Once() // For getAccountID
-	s.mockSTS.On("GetCallerIdentity", mock.Anything, mock.AnythingOfType("*sts.GetCallerIdentityInput")).
-		Return(&sts.GetCallerIdentityOutput{Account: aws.String(accountID)}, nil).Once()
-
-	// Mock paginator setup
-	instance1 := ec2types.Instance{InstanceId: aws.String(instanceID1)}
-	instance2 := ec2types.Instance{InstanceId: aws.String(instanceID2)}
-	page1Output := &ec2.DescribeInstancesOutput{
-		Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{instance1}}},
-	}
-	page2Output := &ec2.DescribeInstancesOutput{
-		Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{instance2}}},
-	}
-
-	s.mockPaginator.On("HasMorePages").Return(true).Once()                   // First call
-	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil).Once() // For page 1
-	s.mockPaginator.On("NextPage", mock.Anything).Return(page1Output, nil).Once()
-
-	s.mockPaginator.On("HasMorePages").Return(true).Once()                   // Second call
-	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil).Once() // For page 2
-	s.mockPaginator.On("NextPage", mock.Anything).Return(page2Output, nil).Once()
-
-	s.mockPaginator.On("HasMorePages").Return(false).Once() // Final call
-
-	// We need to mock the attribute/volume calls made inside newEc2InstanceResource
-	s.mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.AnythingOfType("*ec2.DescribeInstanceAttributeInput")).Maybe().Return(&ec2.DescribeInstanceAttributeOutput{}, nil)
-	s.mockEC2.On("DescribeVolumes", mock.Anything, mock.AnythingOfType("*ec2.DescribeVolumesInput")).Maybe().Return(&ec2.DescribeVolumesOutput{}, nil)
-
-	outChan := make(chan domain.PlatformResource, 5)
-	err := s.handler.ListResources(s.ctx, s.awsConfig, nil, s.mockLogger, outChan)
-	s.NoError(err)
-
-	results := []domain.PlatformResource{}
-	for res := range outChan {
-		results = append(results, res)
-	}
-
-	s.Len(results, 2)
-	found1, found2 := false, false
-	for _, res := range results {
-		meta := res.Metadata()
-		s.Equal(domain.KindComputeInstance, meta.Kind)
-		s.Equal(accountID, meta.AccountID)
-
-		if meta.ProviderAssignedID == instanceID1 {
-			found1 = true
-		} else if meta.ProviderAssignedID == instanceID2 {
-			found2 = true
-		}
-	}
-	s.True(found1, "Instance 1 not found")
-	s.True(found2, "Instance 2 not found")
-
-	s.mockLimiter.AssertExpectations(s.T()) // Wait called 3 times (getAcc, page1, page2)
-	s.mockSTS.AssertExpectations(s.T())
-	s.mockPaginator.AssertExpectations(s.T())
-	s.mockErrorHandler.AssertNotCalled(s.T(), "Handle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
-}
-
 func (s *EC2HandlerTestSuite) TestListResources_PaginationError() {
 	accountID := "555666777888"
 	pageErr := errors.New("pagination failed")
@@ -490,3 +427,207 @@ func (s *EC2HandlerTestSuite) TestGetResource_AccountIDError() {
 func (s *EC2HandlerTestSuite) TestNewEc2InstanceResourcePlaceholder() {
 	s.T().Skip("Mapping logic tested separately in mapper_test.go")
 }
+
+// EC2HandlerFakeTestSuite runs the handler with real SDK clients against the
+// awsfake server.
+type EC2HandlerFakeTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *EC2Handler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *EC2HandlerFakeTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T(), awsfake.WithPageSize(2))
+	s.fake.AddInstances(
+		awsfake.Instance{
+			ID:           "i-1",
+			InstanceType: "t3.micro",
+			Tags:         map[string]string{"Name": "web"},
+			UserData:     "#!/bin/sh\necho hello",
+			VolumeIDs:    []string{"vol-1"},
+			HTTPTokens:   "required",
+
+			DetailedMonitoring:    true,
+			TerminationProtection: true,
+		},
+		awsfake.Instance{ID: "i-2", State: "stopped"},
+		awsfake.Instance{ID: "i-3", State: "terminated"},
+		awsfake.Instance{ID: "i-4"},
+	)
+	s.fake.AddVolumes(awsfake.Volume{ID: "vol-1", VolumeType: "gp3", Size: 20, Encrypted: true})
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+	s.mockLogger.On("WithFields", mock.Anything).Maybe().Return(s.mockLogger)
+
+	s.handler = NewHandler(s.fake.Config())
+}
+
+func (s *EC2HandlerFakeTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestEC2HandlerFakeTestSuite(t *testing.T) {
+	suite.Run(t, new(EC2HandlerFakeTestSuite))
+}
+
+func (s *EC2HandlerFakeTestSuite) collect(filters map[string]string) (map[string]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), filters, s.mockLogger, out)
+	close(out)
+	resources := make(map[string]domain.PlatformResource)
+	for res := range out {
+		resources[res.Metadata().ProviderAssignedID] = res
+	}
+	return resources, err
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_Paginates() {
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Len(resources, 3, "terminated instances are excluded by default")
+	s.NotContains(resources, "i-3")
+	s.Equal(awsfake.DefaultAccountID, resources["i-1"].Metadata().AccountID)
+	s.Equal(domain.KindComputeInstance, resources["i-1"].Metadata().Kind)
+	s.Equal(2, s.fake.Calls("DescribeInstances"))
+	s.Equal(1, s.fake.Calls("GetCallerIdentity"))
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_PassesFilters() {
+	resources, err := s.collect(map[string]string{"instance-state-name": "stopped"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Contains(resources, "i-2")
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_ThrottledPage() {
+	s.fake.Fail("DescribeInstances", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", After: 1})
+
+	_, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Contains(err.Error(), "DescribeInstances:Page2")
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_RetriesThrottledPage() {
+	cfg := s.fake.Config()
+	cfg.Retryer = retry.NewRetryer(retry.Config{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxBackoff: 5 * time.Millisecond}, s.mockLogger)
+	s.handler = NewHandler(cfg)
+	s.fake.Fail("DescribeInstances", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", Times: 2, After: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Len(resources, 3)
+	s.Equal(4, s.fake.Calls("DescribeInstances"), "the throttled second page is retried twice")
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_PrefetchesVolumesPerPage() {
+	s.fake.AddInstances(awsfake.Instance{ID: "i-5", VolumeIDs: []string{"vol-2", "vol-3"}})
+	s.fake.AddVolumes(
+		awsfake.Volume{ID: "vol-2", VolumeType: "gp3", Size: 8},
+		awsfake.Volume{ID: "vol-3", VolumeType: "io2", Size: 100, Iops: 3000},
+	)
+
+	resources, err := s.collect(nil)
+	s.Require().NoError(err)
+	s.Require().Len(resources, 4)
+	s.Equal(2, s.fake.Calls("DescribeVolumes"), "one prefetch per page with volumes")
+
+	for _, id := range []string{"i-1", "i-5"} {
+		_, err := resources[id].Attributes(s.ctx)
+		s.Require().NoError(err)
+	}
+	s.Equal(2, s.fake.Calls("DescribeVolumes"), "building attributes uses the prefetched volumes")
+
+	attrs, err := resources["i-5"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	bdms, ok := attrs["block_device_mappings"].([]map[string]any)
+	s.Require().True(ok)
+	s.Require().Len(bdms, 2)
+	s.Equal(aws.Int32(100), bdms[1]["ebs"].(map[string]any)["size"])
+}
+
+func (s *EC2HandlerFakeTestSuite) TestListResources_FailedPrefetchFallsBackToInstance() {
+	s.fake.Fail("DescribeVolumes", awsfake.Fault{Code: "UnauthorizedOperation", Times: 1})
+
+	resources, err := s.collect(nil)
+	s.Require().NoError(err, "a failed prefetch does not fail the listing")
+
+	attrs, err := resources["i-1"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	bdms, ok := attrs["block_device_mappings"].([]map[string]any)
+	s.Require().True(ok)
+	s.Equal(aws.Int32(20), bdms[0]["ebs"].(map[string]any)["size"])
+	s.Equal(2, s.fake.Calls("DescribeVolumes"), "the instance describes its own volumes")
+}
+
+func (s *EC2HandlerFakeTestSuite) TestGetResource_FetchesAdditionalAttributes() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "i-1", s.mockLogger)
+	s.Require().NoError(err)
+
+	attrs, err := resource.Attributes(s.ctx)
+
+	s.Require().NoError(err)
+	s.Equal("web", attrs[domain.KeyName])
+	s.Equal("t3.micro", attrs["instance_type"])
+	s.Equal("#!/bin/sh\necho hello", attrs["user_data"])
+	s.Equal("unlimited", attrs[domain.ComputeCreditSpecificationKey])
+	s.Equal("default", attrs[domain.ComputeTenancyKey])
+	s.Equal(map[string]any{
+		"http_tokens":                 "required",
+		"http_endpoint":               "enabled",
+		"http_put_response_hop_limit": int64(1),
+	}, attrs[domain.ComputeMetadataOptionsKey])
+	s.Equal(true, attrs[domain.ComputeMonitoringKey])
+	s.Equal(true, attrs[domain.ComputeDeletionProtectionKey])
+	s.Equal(2, s.fake.Calls("DescribeInstanceAttribute"), "user data and termination protection")
+	s.Equal(1, s.fake.Calls("DescribeInstanceCreditSpecifications"))
+	bdms, ok := attrs["block_device_mappings"].([]map[string]any)
+	s.Require().True(ok)
+	s.Require().Len(bdms, 1)
+	ebs, ok := bdms[0]["ebs"].(map[string]any)
+	s.Require().True(ok)
+	s.Equal(aws.Int32(20), ebs["size"])
+	s.Equal(aws.Bool(true), ebs["encrypted"])
+}
+
+func (s *EC2HandlerFakeTestSuite) TestGetResource_NotFound() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "i-missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func (s *EC2HandlerFakeTestSuite) TestGetResources_Success() {
+	resources, err := s.handler.GetResources(s.ctx, s.fake.Config(), []string{"i-1", "i-2", "i-1"}, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("i-2", resources["i-2"].Metadata().ProviderAssignedID)
+	s.Equal(awsfake.DefaultAccountID, resources["i-1"].Metadata().AccountID)
+}
+
+func (s *EC2HandlerFakeTestSuite) TestGetResources_UnknownIDFallsBackToFilter() {
+	resources, err := s.handler.GetResources(s.ctx, s.fake.Config(), []string{"i-1", "i-missing"}, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Contains(resources, "i-1")
+	s.Equal(2, s.fake.Calls("DescribeInstances"), "the rejected batch is described again by filter")
+}
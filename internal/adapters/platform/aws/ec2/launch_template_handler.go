@@ -0,0 +1,159 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const launchTemplatePageSize = 200
+
+// launchTemplateVersions are the versions described for each template: the
+// latest, which holds the data Terraform tracks, and the default, which is
+// what launches without a version use.
+var launchTemplateVersions = []string{"$Latest", "$Default"}
+
+// LaunchTemplateHandler lists EC2 launch templates. It shares the clients,
+// limiter and account ID lookup of the instance handler.
+type LaunchTemplateHandler struct {
+	*EC2Handler
+}
+
+// NewLaunchTemplateHandler creates a new LaunchTemplateHandler with the given
+// AWS config and the same options as the instance handler.
+func NewLaunchTemplateHandler(cfg aws.Config, opts ...HandlerOption) *LaunchTemplateHandler {
+	return &LaunchTemplateHandler{EC2Handler: NewHandler(cfg, opts...)}
+}
+
+func (h *LaunchTemplateHandler) Kind() domain.ResourceKind {
+	return domain.KindLaunchTemplate
+}
+
+func (h *LaunchTemplateHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for launch template ListResources: %v", accErr)
+	}
+
+	input := &ec2.DescribeLaunchTemplatesInput{
+		Filters:    BuildLaunchTemplateFilters(filters),
+		MaxResults: aws.Int32(launchTemplatePageSize),
+	}
+
+	logger.Debugf(ctx, "Starting launch template listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.ec2Client.DescribeLaunchTemplates(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeLaunchTemplates:Page%d", pageNum), err, ctx)
+		}
+
+		for _, template := range output.LaunchTemplates {
+			templateID := aws.ToString(template.LaunchTemplateId)
+			versions, err := h.describeVersions(ctx, templateID, logger)
+			if errors.Is(err, errors.CodeResourceNotFound) {
+				logger.Warnf(ctx, "Launch template %s was deleted while listing, skipping", templateID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			resource, mapErr := newLaunchTemplateResource(template, versions, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for launch template %s, skipping", templateID)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending launch template %s", templateID)
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	logger.Debugf(ctx, "Finished launch template pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *LaunchTemplateHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Describing single launch template %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.ec2Client.DescribeLaunchTemplates(ctx, &ec2.DescribeLaunchTemplatesInput{LaunchTemplateIds: []string{id}})
+	if err != nil {
+		return nil, h.errorHandler.Handle("EC2", "DescribeLaunchTemplates", err, ctx)
+	}
+	if len(output.LaunchTemplates) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("launch template with ID '%s' not found (empty response)", id))
+	}
+
+	versions, err := h.describeVersions(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for launch template GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newLaunchTemplateResource(output.LaunchTemplates[0], versions, cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for launch template %s", id))
+	}
+	return resource, nil
+}
+
+// describeVersions describes the latest and default versions of a template
+// in one call. When both are the same version it is returned once.
+func (h *LaunchTemplateHandler) describeVersions(ctx context.Context, templateID string, logger ports.Logger) ([]ec2types.LaunchTemplateVersion, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.ec2Client.DescribeLaunchTemplateVersions(ctx, &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(templateID),
+		Versions:         launchTemplateVersions,
+	})
+	if err != nil {
+		return nil, h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeLaunchTemplateVersions:%s", templateID), err, ctx)
+	}
+	return output.LaunchTemplateVersions, nil
+}
+
+// Probe verifies that launch templates can be described with a single minimal page.
+func (h *LaunchTemplateHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ec2Client.DescribeLaunchTemplates(ctx, &ec2.DescribeLaunchTemplatesInput{MaxResults: aws.Int32(5)}); err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeLaunchTemplates", err, ctx)
+	}
+	return nil
+}
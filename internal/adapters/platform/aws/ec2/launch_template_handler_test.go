@@ -0,0 +1,161 @@
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// LaunchTemplateHandlerTestSuite runs the handler with real SDK clients
+// against the awsfake server.
+type LaunchTemplateHandlerTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *LaunchTemplateHandler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *LaunchTemplateHandlerTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T(), awsfake.WithPageSize(1))
+	s.fake.AddLaunchTemplates(
+		awsfake.LaunchTemplate{
+			ID:             "lt-1",
+			Name:           "web",
+			DefaultVersion: 1,
+			Versions: []awsfake.LaunchTemplateVersion{
+				{ImageID: "ami-1", InstanceType: "t3.micro"},
+				{Description: "m6i", ImageID: "ami-2", InstanceType: "m6i.large", SecurityGroupIDs: []string{"sg-1"}, UserData: "#!/bin/sh", HTTPTokens: "required", DetailedMonitoring: true},
+			},
+			Tags: map[string]string{"Team": "web"},
+		},
+		awsfake.LaunchTemplate{ID: "lt-2", Name: "batch", Versions: []awsfake.LaunchTemplateVersion{{ImageID: "ami-3"}}},
+		awsfake.LaunchTemplate{ID: "lt-3", Name: "workers", Versions: []awsfake.LaunchTemplateVersion{{ImageID: "ami-4"}}, Tags: map[string]string{"Team": "web"}},
+	)
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+
+	s.handler = NewLaunchTemplateHandler(s.fake.Config())
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestLaunchTemplateHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LaunchTemplateHandlerTestSuite))
+}
+
+func (s *LaunchTemplateHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindLaunchTemplate, s.handler.Kind())
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestListResources_Paginates() {
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 3)
+	s.Equal("lt-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("lt-3", resources[2].Metadata().ProviderAssignedID)
+	s.Equal(awsfake.DefaultAccountID, resources[0].Metadata().AccountID)
+	s.Equal(domain.KindLaunchTemplate, resources[0].Metadata().Kind)
+	s.Equal(3, s.fake.Calls("DescribeLaunchTemplates"))
+	s.Equal(3, s.fake.Calls("DescribeLaunchTemplateVersions"), "one call per template for both versions")
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestListResources_PassesFilters() {
+	resources, err := s.collect(map[string]string{domain.TagPrefix + "Team": "web"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("lt-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("lt-3", resources[1].Metadata().ProviderAssignedID)
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestListResources_APIError() {
+	s.fake.Fail("DescribeLaunchTemplates", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", Times: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Contains(err.Error(), "DescribeLaunchTemplates:Page1")
+	s.Empty(resources)
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestListResources_SkipsTemplateDeletedWhileListing() {
+	s.fake.Fail("DescribeLaunchTemplateVersions", awsfake.Fault{Status: http.StatusBadRequest, Code: "InvalidLaunchTemplateId.NotFound", Times: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("lt-2", resources[0].Metadata().ProviderAssignedID)
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestGetResource_MapsLatestAndDefaultVersions() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "lt-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("lt-1", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("web", attrs[domain.KeyName])
+	s.Equal(int64(1), attrs[domain.LaunchTemplateDefaultVersionKey])
+	s.Equal(int64(2), attrs[domain.LaunchTemplateLatestVersionKey])
+	s.Equal("m6i", attrs[domain.LaunchTemplateDescriptionKey])
+	s.Equal("ami-2", attrs[domain.ComputeImageIDKey])
+	s.Equal("m6i.large", attrs[domain.ComputeInstanceTypeKey])
+	s.Equal([]string{"sg-1"}, attrs[domain.ComputeSecurityGroupsKey])
+	s.Equal("IyEvYmluL3No", attrs[domain.ComputeUserDataKey])
+	s.Equal(map[string]any{"http_tokens": "required"}, attrs[domain.ComputeMetadataOptionsKey])
+	s.Equal(true, attrs[domain.ComputeMonitoringKey])
+	s.Equal(map[string]string{"Team": "web"}, attrs[domain.KeyTags])
+	s.Equal(map[string]any{
+		domain.ComputeImageIDKey:      "ami-1",
+		domain.ComputeInstanceTypeKey: "t3.micro",
+	}, attrs[domain.LaunchTemplateDefaultVersionDataKey])
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestGetResource_NotFound() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "lt-missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func (s *LaunchTemplateHandlerTestSuite) TestProbe() {
+	s.NoError(s.handler.Probe(s.ctx, s.fake.Config(), s.mockLogger))
+	s.Equal(1, s.fake.Calls("DescribeLaunchTemplates"))
+}
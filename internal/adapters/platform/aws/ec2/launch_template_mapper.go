@@ -0,0 +1,240 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	iddErrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// launchTemplateResource wraps a described launch template and the versions
+// fetched for it. Attributes are mapped once when the resource is built.
+type launchTemplateResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+// newLaunchTemplateResource builds the resource from the template and its
+// latest and default versions. A version missing from versions, for example
+// one deleted between the two calls, leaves its template data out.
+func newLaunchTemplateResource(template LaunchTemplate, versions []ec2types.LaunchTemplateVersion, region, accountID string) (domain.PlatformResource, error) {
+	templateID := aws.ToString(template.LaunchTemplateId)
+	if templateID == "" {
+		return nil, iddErrors.New(iddErrors.CodeInternal, "failed to create launch template resource: missing template ID")
+	}
+	return &launchTemplateResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindLaunchTemplate,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: templateID,
+			SourceIdentifier:   templateID,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapLaunchTemplateToAttributes(template, versions, region, accountID),
+	}, nil
+}
+
+func (r *launchTemplateResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *launchTemplateResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapLaunchTemplateToAttributes(template LaunchTemplate, versions []ec2types.LaunchTemplateVersion, region, accountID string) map[string]any {
+	templateID := aws.ToString(template.LaunchTemplateId)
+	defaultVersion := aws.ToInt64(template.DefaultVersionNumber)
+	latestVersion := aws.ToInt64(template.LatestVersionNumber)
+
+	attrs := map[string]any{
+		domain.KeyID:                           templateID,
+		domain.KeyName:                         aws.ToString(template.LaunchTemplateName),
+		domain.LaunchTemplateDefaultVersionKey: defaultVersion,
+		domain.LaunchTemplateLatestVersionKey:  latestVersion,
+	}
+	if accountID != "" {
+		attrs[domain.KeyARN] = fmt.Sprintf("arn:aws:ec2:%s:%s:launch-template/%s", region, accountID, templateID)
+	}
+	if len(template.Tags) > 0 {
+		tags := make(map[string]string, len(template.Tags))
+		for _, tag := range template.Tags {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		attrs[domain.KeyTags] = tags
+	}
+
+	for _, version := range versions {
+		if aws.ToInt64(version.VersionNumber) != latestVersion {
+			continue
+		}
+		for k, v := range mapLaunchTemplateVersion(version) {
+			attrs[k] = v
+		}
+	}
+	if defaultVersion != latestVersion {
+		for _, version := range versions {
+			if aws.ToInt64(version.VersionNumber) == defaultVersion {
+				attrs[domain.LaunchTemplateDefaultVersionDataKey] = mapLaunchTemplateVersion(version)
+			}
+		}
+	}
+	return attrs
+}
+
+// mapLaunchTemplateVersion maps the description and template data of a
+// version. Settings the version does not specify are left out, since
+// launches then take the AMI or account default.
+func mapLaunchTemplateVersion(version ec2types.LaunchTemplateVersion) map[string]any {
+	attrs := map[string]any{}
+	if description := aws.ToString(version.VersionDescription); description != "" {
+		attrs[domain.LaunchTemplateDescriptionKey] = description
+	}
+	data := version.LaunchTemplateData
+	if data == nil {
+		return attrs
+	}
+
+	if imageID := aws.ToString(data.ImageId); imageID != "" {
+		attrs[domain.ComputeImageIDKey] = imageID
+	}
+	if data.InstanceType != "" {
+		attrs[domain.ComputeInstanceTypeKey] = string(data.InstanceType)
+	}
+	if keyName := aws.ToString(data.KeyName); keyName != "" {
+		attrs[domain.LaunchTemplateKeyNameKey] = keyName
+	}
+	if len(data.SecurityGroupIds) > 0 {
+		attrs[domain.ComputeSecurityGroupsKey] = append([]string(nil), data.SecurityGroupIds...)
+	}
+	if userData := aws.ToString(data.UserData); userData != "" {
+		attrs[domain.ComputeUserDataKey] = userData
+	}
+	if profile := data.IamInstanceProfile; profile != nil {
+		spec := map[string]any{}
+		if arn := aws.ToString(profile.Arn); arn != "" {
+			spec["arn"] = arn
+		}
+		if name := aws.ToString(profile.Name); name != "" {
+			spec["name"] = name
+		}
+		if len(spec) > 0 {
+			attrs[domain.ComputeIAMInstanceProfileKey] = spec
+		}
+	}
+	if opts := mapLaunchTemplateMetadataOptions(data.MetadataOptions); len(opts) > 0 {
+		attrs[domain.ComputeMetadataOptionsKey] = opts
+	}
+	if data.Monitoring != nil {
+		attrs[domain.ComputeMonitoringKey] = aws.ToBool(data.Monitoring.Enabled)
+	}
+	if data.EbsOptimized != nil {
+		attrs[domain.ComputeEBSOptimizedKey] = *data.EbsOptimized
+	}
+	if mappings := mapLaunchTemplateBlockDevices(data.BlockDeviceMappings); len(mappings) > 0 {
+		attrs[domain.LaunchTemplateBlockDeviceMappingsKey] = mappings
+	}
+	if specs := mapLaunchTemplateTagSpecifications(data.TagSpecifications); len(specs) > 0 {
+		attrs[domain.LaunchTemplateTagSpecificationsKey] = specs
+	}
+	return attrs
+}
+
+// mapLaunchTemplateMetadataOptions is mapMetadataOptions for the metadata
+// settings of a launch template, which only holds the ones it specifies.
+func mapLaunchTemplateMetadataOptions(opts *ec2types.LaunchTemplateInstanceMetadataOptions) map[string]any {
+	if opts == nil {
+		return nil
+	}
+	m := map[string]any{}
+	for key, value := range map[string]string{
+		"http_tokens":            string(opts.HttpTokens),
+		"http_endpoint":          string(opts.HttpEndpoint),
+		"http_protocol_ipv6":     string(opts.HttpProtocolIpv6),
+		"instance_metadata_tags": string(opts.InstanceMetadataTags),
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	if opts.HttpPutResponseHopLimit != nil {
+		m["http_put_response_hop_limit"] = int64(*opts.HttpPutResponseHopLimit)
+	}
+	return m
+}
+
+func mapLaunchTemplateBlockDevices(bdms []ec2types.LaunchTemplateBlockDeviceMapping) []any {
+	mappings := make([]map[string]any, 0, len(bdms))
+	for _, bdm := range bdms {
+		mapping := map[string]any{"device_name": aws.ToString(bdm.DeviceName)}
+		if noDevice := aws.ToString(bdm.NoDevice); noDevice != "" {
+			mapping["no_device"] = noDevice
+		}
+		if virtualName := aws.ToString(bdm.VirtualName); virtualName != "" {
+			mapping["virtual_name"] = virtualName
+		}
+		if ebs := bdm.Ebs; ebs != nil {
+			volume := map[string]any{}
+			if ebs.DeleteOnTermination != nil {
+				volume["delete_on_termination"] = *ebs.DeleteOnTermination
+			}
+			if ebs.Encrypted != nil {
+				volume["encrypted"] = *ebs.Encrypted
+			}
+			for key, value := range map[string]*int32{"iops": ebs.Iops, "throughput": ebs.Throughput, "volume_size": ebs.VolumeSize} {
+				if value != nil && *value > 0 {
+					volume[key] = int64(*value)
+				}
+			}
+			for key, value := range map[string]string{"kms_key_id": aws.ToString(ebs.KmsKeyId), "snapshot_id": aws.ToString(ebs.SnapshotId), "volume_type": string(ebs.VolumeType)} {
+				if value != "" {
+					volume[key] = value
+				}
+			}
+			mapping["ebs"] = volume
+		}
+		mappings = append(mappings, mapping)
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i]["device_name"].(string) < mappings[j]["device_name"].(string)
+	})
+	out := make([]any, 0, len(mappings))
+	for _, mapping := range mappings {
+		out = append(out, mapping)
+	}
+	return out
+}
+
+func mapLaunchTemplateTagSpecifications(specs []ec2types.LaunchTemplateTagSpecification) []any {
+	specs = append([]ec2types.LaunchTemplateTagSpecification(nil), specs...)
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].ResourceType < specs[j].ResourceType
+	})
+	out := make([]any, 0, len(specs))
+	for _, spec := range specs {
+		tags := make(map[string]string, len(spec.Tags))
+		for _, tag := range spec.Tags {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		out = append(out, map[string]any{
+			"resource_type": string(spec.ResourceType),
+			"tags":          tags,
+		})
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestNewLaunchTemplateResource_MissingID(t *testing.T) {
+	_, err := newLaunchTemplateResource(ec2types.LaunchTemplate{}, nil, "us-east-1", "123456789012")
+	assert.Error(t, err)
+}
+
+func TestMapLaunchTemplateToAttributes(t *testing.T) {
+	template := ec2types.LaunchTemplate{
+		LaunchTemplateId:     aws.String("lt-1"),
+		LaunchTemplateName:   aws.String("web"),
+		DefaultVersionNumber: aws.Int64(2),
+		LatestVersionNumber:  aws.Int64(3),
+		Tags:                 []ec2types.Tag{{Key: aws.String("Team"), Value: aws.String("platform")}},
+	}
+	versions := []ec2types.LaunchTemplateVersion{
+		{
+			VersionNumber:      aws.Int64(3),
+			VersionDescription: aws.String("m6i"),
+			LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{
+				ImageId:            aws.String("ami-2"),
+				InstanceType:       ec2types.InstanceTypeM6iLarge,
+				KeyName:            aws.String("ops"),
+				SecurityGroupIds:   []string{"sg-1"},
+				UserData:           aws.String("IyEvYmluL3No"),
+				IamInstanceProfile: &ec2types.LaunchTemplateIamInstanceProfileSpecification{Name: aws.String("web")},
+				MetadataOptions: &ec2types.LaunchTemplateInstanceMetadataOptions{
+					HttpTokens:              ec2types.LaunchTemplateHttpTokensStateRequired,
+					HttpPutResponseHopLimit: aws.Int32(2),
+				},
+				Monitoring:   &ec2types.LaunchTemplatesMonitoring{Enabled: aws.Bool(true)},
+				EbsOptimized: aws.Bool(true),
+				BlockDeviceMappings: []ec2types.LaunchTemplateBlockDeviceMapping{
+					{DeviceName: aws.String("/dev/xvdb"), Ebs: &ec2types.LaunchTemplateEbsBlockDevice{VolumeSize: aws.Int32(100), VolumeType: ec2types.VolumeTypeGp3}},
+					{DeviceName: aws.String("/dev/xvda"), Ebs: &ec2types.LaunchTemplateEbsBlockDevice{Encrypted: aws.Bool(true), Iops: aws.Int32(0)}},
+				},
+				TagSpecifications: []ec2types.LaunchTemplateTagSpecification{
+					{ResourceType: ec2types.ResourceTypeVolume, Tags: []ec2types.Tag{{Key: aws.String("Backup"), Value: aws.String("daily")}}},
+					{ResourceType: ec2types.ResourceTypeInstance, Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("web")}}},
+				},
+			},
+		},
+		{
+			VersionNumber:      aws.Int64(2),
+			LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-1"), InstanceType: ec2types.InstanceTypeT3Micro},
+		},
+	}
+
+	resource, err := newLaunchTemplateResource(template, versions, "us-east-1", "123456789012")
+	require.NoError(t, err)
+	assert.Equal(t, domain.KindLaunchTemplate, resource.Metadata().Kind)
+	attrs, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "lt-1", attrs[domain.KeyID])
+	assert.Equal(t, "web", attrs[domain.KeyName])
+	assert.Equal(t, "arn:aws:ec2:us-east-1:123456789012:launch-template/lt-1", attrs[domain.KeyARN])
+	assert.Equal(t, map[string]string{"Team": "platform"}, attrs[domain.KeyTags])
+	assert.Equal(t, int64(2), attrs[domain.LaunchTemplateDefaultVersionKey])
+	assert.Equal(t, int64(3), attrs[domain.LaunchTemplateLatestVersionKey])
+
+	assert.Equal(t, "m6i", attrs[domain.LaunchTemplateDescriptionKey])
+	assert.Equal(t, "ami-2", attrs[domain.ComputeImageIDKey], "the template data is that of the latest version")
+	assert.Equal(t, "m6i.large", attrs[domain.ComputeInstanceTypeKey])
+	assert.Equal(t, "ops", attrs[domain.LaunchTemplateKeyNameKey])
+	assert.Equal(t, []string{"sg-1"}, attrs[domain.ComputeSecurityGroupsKey])
+	assert.Equal(t, "IyEvYmluL3No", attrs[domain.ComputeUserDataKey])
+	assert.Equal(t, map[string]any{"name": "web"}, attrs[domain.ComputeIAMInstanceProfileKey])
+	assert.Equal(t, map[string]any{"http_tokens": "required", "http_put_response_hop_limit": int64(2)}, attrs[domain.ComputeMetadataOptionsKey])
+	assert.Equal(t, true, attrs[domain.ComputeMonitoringKey])
+	assert.Equal(t, true, attrs[domain.ComputeEBSOptimizedKey])
+	assert.Equal(t, []any{
+		map[string]any{"device_name": "/dev/xvda", "ebs": map[string]any{"encrypted": true}},
+		map[string]any{"device_name": "/dev/xvdb", "ebs": map[string]any{"volume_size": int64(100), "volume_type": "gp3"}},
+	}, attrs[domain.LaunchTemplateBlockDeviceMappingsKey])
+	assert.Equal(t, []any{
+		map[string]any{"resource_type": "instance", "tags": map[string]string{"Name": "web"}},
+		map[string]any{"resource_type": "volume", "tags": map[string]string{"Backup": "daily"}},
+	}, attrs[domain.LaunchTemplateTagSpecificationsKey])
+
+	assert.Equal(t, map[string]any{
+		domain.ComputeImageIDKey:      "ami-1",
+		domain.ComputeInstanceTypeKey: "t3.micro",
+	}, attrs[domain.LaunchTemplateDefaultVersionDataKey])
+}
+
+func TestMapLaunchTemplateToAttributes_DefaultIsLatest(t *testing.T) {
+	template := ec2types.LaunchTemplate{
+		LaunchTemplateId:     aws.String("lt-1"),
+		DefaultVersionNumber: aws.Int64(1),
+		LatestVersionNumber:  aws.Int64(1),
+	}
+	versions := []ec2types.LaunchTemplateVersion{
+		{VersionNumber: aws.Int64(1), LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-1")}},
+	}
+
+	attrs := mapLaunchTemplateToAttributes(template, versions, "us-east-1", "")
+
+	assert.Equal(t, "ami-1", attrs[domain.ComputeImageIDKey])
+	assert.NotContains(t, attrs, domain.LaunchTemplateDefaultVersionDataKey)
+	assert.NotContains(t, attrs, domain.KeyARN, "no ARN without the account ID")
+	assert.NotContains(t, attrs, domain.ComputeMonitoringKey)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,9 @@ type ec2InstanceResource struct {
 	builtAttrs      map[string]any
 	fetchErr        error
 	attributesBuilt bool
+	// volumes holds the EBS volumes prefetched for the page the instance was
+	// listed in. Nil means the volumes are described when attributes are built.
+	volumes map[string]ec2types.Volume
 }
 
 func newEc2InstanceResource(
@@ -37,6 +41,19 @@ func newEc2InstanceResource(
 	logger ports.Logger,
 	client EC2ClientInterface,
 ) (domain.PlatformResource, error) {
+	return newPrefetchedEc2InstanceResource(instance, region, accountID, logger, client, nil)
+}
+
+// newPrefetchedEc2InstanceResource is newEc2InstanceResource for an instance
+// whose volumes were described together with those of other instances.
+func newPrefetchedEc2InstanceResource(
+	instance Instance,
+	region string,
+	accountID string,
+	logger ports.Logger,
+	client EC2ClientInterface,
+	volumes map[string]ec2types.Volume,
+) (domain.PlatformResource, error) {
 
 	meta := domain.ResourceMetadata{
 		Kind:               domain.KindComputeInstance,
@@ -45,6 +62,7 @@ func newEc2InstanceResource(
 		SourceIdentifier:   aws.ToString(instance.InstanceId),
 		AccountID:          accountID,
 		Region:             region,
+		PendingDeletion:    instancePendingDeletion(instance),
 	}
 
 	if meta.ProviderAssignedID == "" {
@@ -56,6 +74,7 @@ func newEc2InstanceResource(
 		rawInstance: instance,
 		logger:      logger.WithFields(map[string]any{"instance_id": meta.ProviderAssignedID}),
 		ec2Client:   client,
+		volumes:     volumes,
 	}, nil
 }
 
@@ -115,6 +134,8 @@ func (r *ec2InstanceResource) fetchAndMapAdditionalAttributes(ctx context.Contex
 	var userDataFetched bool
 	var fetchedVolumes map[string]ec2types.Volume
 	var volumesFetched bool
+	var fetchedCPUCredits string
+	var terminationProtection *bool
 
 	addError := func(err error) {
 		errMu.Lock()
@@ -122,7 +143,7 @@ func (r *ec2InstanceResource) fetchAndMapAdditionalAttributes(ctx context.Contex
 		errMu.Unlock()
 	}
 
-	wg.Add(2)
+	wg.Add(4)
 
 	go func() {
 		defer wg.Done()
@@ -156,17 +177,17 @@ func (r *ec2InstanceResource) fetchAndMapAdditionalAttributes(ctx context.Contex
 
 	go func() {
 		defer wg.Done()
-		volumeIDs := make([]string, 0)
-		for _, bdm := range instanceBDMs {
-			if bdm.Ebs != nil && bdm.Ebs.VolumeId != nil {
-				volumeIDs = append(volumeIDs, *bdm.Ebs.VolumeId)
-			}
-		}
-
+		volumeIDs := blockDeviceVolumeIDs(instanceBDMs)
 		if len(volumeIDs) == 0 {
 			return // No EBS volumes attached
 		}
 
+		if prefetched, ok := r.prefetchedVolumes(volumeIDs); ok {
+			fetchedVolumes = prefetched
+			volumesFetched = true
+			return
+		}
+
 		if err := aws_limiter.Wait(ctx, r.logger); err != nil {
 			addError(iddErrors.Wrap(err, iddErrors.CodePlatformAPIError, "rate limit error before EBS volume fetch"))
 			return
@@ -189,8 +210,65 @@ func (r *ec2InstanceResource) fetchAndMapAdditionalAttributes(ctx context.Contex
 		}
 	}()
 
+	go func() {
+		defer wg.Done()
+		if !isBurstableInstanceType(r.rawInstance.InstanceType) {
+			return // Only T-class instances have a CPU credit mode
+		}
+
+		if err := aws_limiter.Wait(ctx, r.logger); err != nil {
+			addError(iddErrors.Wrap(err, iddErrors.CodePlatformAPIError, "rate limit error before credit specification fetch"))
+			return
+		}
+		creditInput := &ec2.DescribeInstanceCreditSpecificationsInput{InstanceIds: []string{instanceID}}
+		output, err := r.ec2Client.DescribeInstanceCreditSpecifications(ctx, creditInput)
+		if err != nil {
+			wrappedErr := aws_errors.HandleAWSError("EC2 Credit Specification", instanceID, err, ctx)
+			r.logger.Warnf(ctx, "Failed to fetch credit specification: %v", wrappedErr)
+			addError(wrappedErr)
+			return
+		}
+		if output != nil {
+			for _, spec := range output.InstanceCreditSpecifications {
+				if aws.ToString(spec.InstanceId) == instanceID {
+					fetchedCPUCredits = aws.ToString(spec.CpuCredits)
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := aws_limiter.Wait(ctx, r.logger); err != nil {
+			addError(iddErrors.Wrap(err, iddErrors.CodePlatformAPIError, "rate limit error before termination protection fetch"))
+			return
+		}
+		protectionInput := &ec2.DescribeInstanceAttributeInput{
+			InstanceId: aws.String(instanceID),
+			Attribute:  ec2types.InstanceAttributeNameDisableApiTermination,
+		}
+		output, err := r.ec2Client.DescribeInstanceAttribute(ctx, protectionInput)
+		if err != nil {
+			wrappedErr := aws_errors.HandleAWSError("EC2 Termination Protection", instanceID, err, ctx)
+			r.logger.Warnf(ctx, "Failed to fetch termination protection: %v", wrappedErr)
+			addError(wrappedErr)
+			return
+		}
+		if output != nil && output.DisableApiTermination != nil {
+			terminationProtection = output.DisableApiTermination.Value
+		}
+	}()
+
 	wg.Wait()
 
+	if terminationProtection != nil {
+		r.builtAttrs[domain.ComputeDeletionProtectionKey] = *terminationProtection
+	}
+
+	if fetchedCPUCredits != "" {
+		r.builtAttrs[domain.ComputeCreditSpecificationKey] = fetchedCPUCredits
+	}
+
 	if userDataFetched {
 		r.builtAttrs["user_data"] = fetchedUserData
 	}
@@ -237,6 +315,36 @@ func (r *ec2InstanceResource) fetchAndMapAdditionalAttributes(ctx context.Contex
 	return nil
 }
 
+// prefetchedVolumes returns the prefetched volumes with the given IDs, or
+// false when any of them was not prefetched, e.g. a volume attached after the
+// page was listed.
+func (r *ec2InstanceResource) prefetchedVolumes(volumeIDs []string) (map[string]ec2types.Volume, bool) {
+	if r.volumes == nil {
+		return nil, false
+	}
+	volumes := make(map[string]ec2types.Volume, len(volumeIDs))
+	for _, id := range volumeIDs {
+		vol, ok := r.volumes[id]
+		if !ok {
+			return nil, false
+		}
+		volumes[id] = vol
+	}
+	return volumes, true
+}
+
+// blockDeviceVolumeIDs returns the IDs of the EBS volumes in an instance's
+// block device mappings.
+func blockDeviceVolumeIDs(bdms []ec2types.InstanceBlockDeviceMapping) []string {
+	volumeIDs := make([]string, 0, len(bdms))
+	for _, bdm := range bdms {
+		if bdm.Ebs != nil && bdm.Ebs.VolumeId != nil {
+			volumeIDs = append(volumeIDs, *bdm.Ebs.VolumeId)
+		}
+	}
+	return volumeIDs
+}
+
 func mapInstanceToAttributes(instance Instance, logger ports.Logger) map[string]any {
 	attrs := map[string]any{}
 
@@ -273,6 +381,20 @@ func mapInstanceToAttributes(instance Instance, logger ports.Logger) map[string]
 	if instance.VpcId != nil {
 		attrs["vpc_id"] = *instance.VpcId
 	}
+	if instance.Placement != nil {
+		if instance.Placement.AvailabilityZone != nil {
+			attrs[domain.ComputeAvailabilityZoneKey] = *instance.Placement.AvailabilityZone
+		}
+		if aws.ToString(instance.Placement.GroupName) != "" {
+			attrs[domain.ComputePlacementGroupKey] = *instance.Placement.GroupName
+		}
+		if instance.Placement.Tenancy != "" {
+			attrs[domain.ComputeTenancyKey] = string(instance.Placement.Tenancy)
+		}
+	}
+	if instance.EbsOptimized != nil {
+		attrs[domain.ComputeEBSOptimizedKey] = *instance.EbsOptimized
+	}
 	if instance.Architecture != "" {
 		attrs["architecture"] = string(instance.Architecture)
 	}
@@ -322,6 +444,14 @@ func mapInstanceToAttributes(instance Instance, logger ports.Logger) map[string]
 	if instance.HibernationOptions != nil && instance.HibernationOptions.Configured != nil {
 		attrs["hibernation_enabled"] = *instance.HibernationOptions.Configured
 	}
+	if instance.MetadataOptions != nil {
+		attrs[domain.ComputeMetadataOptionsKey] = mapMetadataOptions(instance.MetadataOptions)
+	}
+	if instance.Monitoring != nil && instance.Monitoring.State != "" {
+		// A pending state is detailed monitoring being enabled.
+		state := instance.Monitoring.State
+		attrs[domain.ComputeMonitoringKey] = state == ec2types.MonitoringStateEnabled || state == ec2types.MonitoringStatePending
+	}
 
 	if len(instance.SecurityGroups) > 0 {
 		sgs := make([]map[string]string, len(instance.SecurityGroups))
@@ -375,3 +505,45 @@ func mapInstanceToAttributes(instance Instance, logger ports.Logger) map[string]
 
 	return attrs
 }
+
+// mapMetadataOptions maps the instance metadata service settings, leaving
+// out those AWS did not report.
+func mapMetadataOptions(opts *ec2types.InstanceMetadataOptionsResponse) map[string]any {
+	m := map[string]any{}
+	for key, value := range map[string]string{
+		"http_tokens":            string(opts.HttpTokens),
+		"http_endpoint":          string(opts.HttpEndpoint),
+		"http_protocol_ipv6":     string(opts.HttpProtocolIpv6),
+		"instance_metadata_tags": string(opts.InstanceMetadataTags),
+	} {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	if opts.HttpPutResponseHopLimit != nil {
+		m["http_put_response_hop_limit"] = int64(*opts.HttpPutResponseHopLimit)
+	}
+	return m
+}
+
+// isBurstableInstanceType reports whether the instance type belongs to a
+// T-class (burstable performance) family, e.g. t2.micro or t4g.large, but not
+// trn1.2xlarge.
+func isBurstableInstanceType(instanceType ec2types.InstanceType) bool {
+	family, _, _ := strings.Cut(string(instanceType), ".")
+	return len(family) >= 2 && family[0] == 't' && family[1] >= '0' && family[1] <= '9'
+}
+
+// instancePendingDeletion reports an instance that is shutting down for
+// termination or already terminated. Terminated instances stay visible for
+// about an hour and are only listed when the state filter asks for them.
+func instancePendingDeletion(instance Instance) *domain.PendingDeletion {
+	if instance.State == nil {
+		return nil
+	}
+	switch instance.State.Name {
+	case ec2types.InstanceStateNameShuttingDown, ec2types.InstanceStateNameTerminated:
+		return &domain.PendingDeletion{State: string(instance.State.Name)}
+	}
+	return nil
+}
@@ -106,8 +106,21 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 			{VolumeId: aws.String(ebsVolID), VolumeType: ec2types.VolumeTypeIo2, Size: aws.Int32(50), Iops: aws.Int32(5000), Encrypted: aws.Bool(true)},
 		},
 	}
+	describeCreditsOutput := &ec2.DescribeInstanceCreditSpecificationsOutput{
+		InstanceCreditSpecifications: []ec2types.InstanceCreditSpecification{{InstanceId: aws.String(instanceID), CpuCredits: aws.String("unlimited")}},
+	}
+	describeProtectionOutput := &ec2.DescribeInstanceAttributeOutput{
+		DisableApiTermination: &ec2types.AttributeBooleanValue{Value: aws.Bool(true)},
+	}
+	userDataInput := mock.MatchedBy(func(i *ec2.DescribeInstanceAttributeInput) bool {
+		return i.Attribute == ec2types.InstanceAttributeNameUserData
+	})
+	terminationProtectionInput := mock.MatchedBy(func(i *ec2.DescribeInstanceAttributeInput) bool {
+		return i.Attribute == ec2types.InstanceAttributeNameDisableApiTermination
+	})
 	userDataAPIErr := errors.New("failed to access EC2 UserData")
 	volumesAPIErr := errors.New("failed to access EC2 EBS Volumes")
+	creditsAPIErr := errors.New("failed to access EC2 credit specifications")
 	tests := []struct {
 		name                 string
 		setupMocks           func(mockEC2 *ec2mocks.EC2ClientInterface) // Updated mock type
@@ -118,18 +131,24 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 		{
 			name: "success first call",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.AnythingOfType("*ec2.DescribeInstanceAttributeInput"), mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.MatchedBy(func(i *ec2.DescribeVolumesInput) bool {
 					return assert.ElementsMatch(t, []string{rootVolID, ebsVolID}, i.VolumeIds)
 				}), mock.Anything).Return(describeVolumesOutput, nil).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.MatchedBy(func(i *ec2.DescribeInstanceCreditSpecificationsInput) bool {
+					return assert.Equal(t, []string{instanceID}, i.InstanceIds)
+				}), mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"user_data":        userDataDecoded,
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"user_data":                          userDataDecoded,
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": int32(20), "encrypted": false, "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": int32(50), "iops": int32(5000), "throughput": (*int32)(nil), "encrypted": true, "kms_key_id": (*string)(nil)}},
@@ -142,15 +161,19 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 		{
 			name: "user data fetch fails",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.Anything, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(describeVolumesOutput, nil).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{ // Base attributes are still mapped
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": int32(20), "encrypted": false, "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": int32(50), "iops": int32(5000), "throughput": (*int32)(nil), "encrypted": true, "kms_key_id": (*string)(nil)}},
@@ -164,16 +187,20 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 		{
 			name: "volumes fetch fails",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.Anything, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(nil, volumesAPIErr).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{ // Base + UserData are mapped
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"user_data":        userDataDecoded,
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"user_data":                          userDataDecoded,
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{ // EBS details remain nil
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": (*int32)(nil), "encrypted": (*bool)(nil), "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": (*int32)(nil), "encrypted": (*bool)(nil), "kms_key_id": (*string)(nil)}},
@@ -187,15 +214,19 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 		{
 			name: "both fetches fail",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.Anything, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(nil, volumesAPIErr).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{ // Only base attributes
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": (*int32)(nil), "encrypted": (*bool)(nil), "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": (*int32)(nil), "encrypted": (*bool)(nil), "kms_key_id": (*string)(nil)}},
@@ -206,41 +237,75 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 				mockEC2.AssertExpectations(t)
 			},
 		},
+		{
+			name: "credit specification fetch fails",
+			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
+				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(describeVolumesOutput, nil).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(nil, creditsAPIErr).Once()
+			},
+			expectedAttributes: map[string]any{ // Everything except the credit mode
+				domain.KeyID:                        instanceID,
+				domain.KeyName:                      "LazyLoader",
+				domain.ComputeDeletionProtectionKey: true,
+				"instance_type":                     string(ec2types.InstanceTypeT3Small),
+				domain.KeyTags:                      map[string]string{"Name": "LazyLoader"},
+				"user_data":                         userDataDecoded,
+				"root_device_name":                  rootDeviceName,
+				"block_device_mappings": []map[string]any{
+					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": int32(20), "encrypted": false, "kms_key_id": (*string)(nil)}},
+					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": int32(50), "iops": int32(5000), "throughput": (*int32)(nil), "encrypted": true, "kms_key_id": (*string)(nil)}},
+				},
+			},
+			expectedErrSubstring: "failed to access EC2 credit specifications",
+			verifyMocks: func(t *testing.T, mockEC2 *ec2mocks.EC2ClientInterface) {
+				mockEC2.AssertExpectations(t)
+			},
+		},
 		{
 			name: "second call uses cache (success)",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.Anything, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(describeUserDataOutput, nil).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(describeVolumesOutput, nil).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"user_data":        userDataDecoded,
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"user_data":                          userDataDecoded,
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": int32(20), "encrypted": false, "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": int32(50), "iops": int32(5000), "throughput": (*int32)(nil), "encrypted": true, "kms_key_id": (*string)(nil)}},
 				},
 			},
 			verifyMocks: func(t *testing.T, mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.AssertNumberOfCalls(t, "DescribeInstanceAttribute", 1)
+				mockEC2.AssertNumberOfCalls(t, "DescribeInstanceAttribute", 2)
 				mockEC2.AssertNumberOfCalls(t, "DescribeVolumes", 1)
 			},
 		},
 		{
 			name: "second call uses cache (error)",
 			setupMocks: func(mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.On("DescribeInstanceAttribute", mock.Anything, mock.Anything, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, userDataInput, mock.Anything).Return(nil, userDataAPIErr).Once()
+				mockEC2.On("DescribeInstanceAttribute", mock.Anything, terminationProtectionInput, mock.Anything).Return(describeProtectionOutput, nil).Once()
 				mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).Return(describeVolumesOutput, nil).Once()
+				mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).Return(describeCreditsOutput, nil).Once()
 			},
 			expectedAttributes: map[string]any{
-				domain.KeyID:       instanceID,
-				domain.KeyName:     "LazyLoader",
-				"instance_type":    string(ec2types.InstanceTypeT3Small),
-				domain.KeyTags:     map[string]string{"Name": "LazyLoader"},
-				"root_device_name": rootDeviceName,
+				domain.KeyID:                         instanceID,
+				domain.KeyName:                       "LazyLoader",
+				domain.ComputeDeletionProtectionKey:  true,
+				"instance_type":                      string(ec2types.InstanceTypeT3Small),
+				domain.ComputeCreditSpecificationKey: "unlimited",
+				domain.KeyTags:                       map[string]string{"Name": "LazyLoader"},
+				"root_device_name":                   rootDeviceName,
 				"block_device_mappings": []map[string]any{
 					{"device_name": rootDeviceName, "ebs": map[string]any{"volume_id": rootVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": true, "size": int32(20), "encrypted": false, "kms_key_id": (*string)(nil)}},
 					{"device_name": ebsDeviceName, "ebs": map[string]any{"volume_id": ebsVolID, "status": "", "attach_time": "0001-01-01T00:00:00Z", "delete_on_termination": false, "size": int32(50), "iops": int32(5000), "throughput": (*int32)(nil), "encrypted": true, "kms_key_id": (*string)(nil)}},
@@ -248,7 +313,7 @@ func TestEC2InstanceResource_Attributes_LazyLoading(t *testing.T) {
 			},
 			expectedErrSubstring: "failed to access EC2 UserData",
 			verifyMocks: func(t *testing.T, mockEC2 *ec2mocks.EC2ClientInterface) {
-				mockEC2.AssertNumberOfCalls(t, "DescribeInstanceAttribute", 1)
+				mockEC2.AssertNumberOfCalls(t, "DescribeInstanceAttribute", 2)
 				mockEC2.AssertNumberOfCalls(t, "DescribeVolumes", 1)
 			},
 		},
@@ -360,6 +425,8 @@ func TestEC2InstanceResource_Attributes_ContextCancellation(t *testing.T) {
 	// Mock DescribeVolumes to also be potentially slow
 	mockEC2.On("DescribeVolumes", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, context.Canceled).Maybe() // Return canceled or let context handle it
+	mockEC2.On("DescribeInstanceCreditSpecifications", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, context.Canceled).Maybe()
 
 	// Call Attributes with the cancelable context
 	_, err := res.Attributes(ctx)
@@ -397,6 +464,26 @@ func TestEC2InstanceResource_Metadata(t *testing.T) {
 	assert.Equal(t, region, meta.Region)
 	// Name is not part of metadata, it's an attribute derived from tags
 	// assert.Equal(t, nameTag, meta.Name)
+	assert.Nil(t, meta.PendingDeletion)
+}
+
+func TestInstancePendingDeletion(t *testing.T) {
+	testCases := []struct {
+		state ec2types.InstanceStateName
+		want  *domain.PendingDeletion
+	}{
+		{ec2types.InstanceStateNameRunning, nil},
+		{ec2types.InstanceStateNameStopped, nil},
+		{ec2types.InstanceStateNameShuttingDown, &domain.PendingDeletion{State: "shutting-down"}},
+		{ec2types.InstanceStateNameTerminated, &domain.PendingDeletion{State: "terminated"}},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.state), func(t *testing.T) {
+			instance := Instance{InstanceId: aws.String("i-1"), State: &ec2types.InstanceState{Name: tc.state}}
+			assert.Equal(t, tc.want, instancePendingDeletion(instance))
+		})
+	}
+	assert.Nil(t, instancePendingDeletion(Instance{InstanceId: aws.String("i-1")}))
 }
 
 // --- Mapping Helper Tests ---
@@ -406,27 +493,34 @@ func TestMapBaseInstanceAttributes(t *testing.T) {
 	instanceID := "i-mapbase"
 
 	instance := Instance{
-		InstanceId:          aws.String(instanceID),
-		ImageId:             aws.String("ami-123"),
-		InstanceType:        ec2types.InstanceTypeT2Micro,
-		KeyName:             aws.String("my-key"),
-		LaunchTime:          aws.Time(launchTime),
-		PrivateDnsName:      aws.String("ip-10-0-1-10.internal"),
-		PrivateIpAddress:    aws.String("10.0.1.10"),
-		PublicDnsName:       aws.String("ec2-54-0-0-1.compute-1.amazonaws.com"),
-		PublicIpAddress:     aws.String("54.0.0.1"),
-		SubnetId:            aws.String("subnet-abc"),
-		VpcId:               aws.String("vpc-xyz"),
-		Architecture:        ec2types.ArchitectureValuesX8664,
-		RootDeviceName:      aws.String("/dev/sda1"),
-		RootDeviceType:      ec2types.DeviceTypeEbs,
-		State:               &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
-		EnaSupport:          aws.Bool(true),
-		Hypervisor:          ec2types.HypervisorTypeXen,
-		IamInstanceProfile:  &ec2types.IamInstanceProfile{Arn: aws.String("arn:aws:iam::111:instance-profile/role")},
-		VirtualizationType:  ec2types.VirtualizationTypeHvm,
-		CpuOptions:          &ec2types.CpuOptions{CoreCount: aws.Int32(2), ThreadsPerCore: aws.Int32(1)},
-		HibernationOptions:  &ec2types.HibernationOptions{Configured: aws.Bool(false)},
+		InstanceId:         aws.String(instanceID),
+		ImageId:            aws.String("ami-123"),
+		InstanceType:       ec2types.InstanceTypeT2Micro,
+		KeyName:            aws.String("my-key"),
+		LaunchTime:         aws.Time(launchTime),
+		PrivateDnsName:     aws.String("ip-10-0-1-10.internal"),
+		PrivateIpAddress:   aws.String("10.0.1.10"),
+		PublicDnsName:      aws.String("ec2-54-0-0-1.compute-1.amazonaws.com"),
+		PublicIpAddress:    aws.String("54.0.0.1"),
+		SubnetId:           aws.String("subnet-abc"),
+		VpcId:              aws.String("vpc-xyz"),
+		Architecture:       ec2types.ArchitectureValuesX8664,
+		RootDeviceName:     aws.String("/dev/sda1"),
+		RootDeviceType:     ec2types.DeviceTypeEbs,
+		State:              &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+		EnaSupport:         aws.Bool(true),
+		Hypervisor:         ec2types.HypervisorTypeXen,
+		IamInstanceProfile: &ec2types.IamInstanceProfile{Arn: aws.String("arn:aws:iam::111:instance-profile/role")},
+		Placement:          &ec2types.Placement{AvailabilityZone: aws.String("us-east-1a"), GroupName: aws.String("pg-cluster"), Tenancy: ec2types.TenancyDedicated},
+		EbsOptimized:       aws.Bool(true),
+		VirtualizationType: ec2types.VirtualizationTypeHvm,
+		CpuOptions:         &ec2types.CpuOptions{CoreCount: aws.Int32(2), ThreadsPerCore: aws.Int32(1)},
+		HibernationOptions: &ec2types.HibernationOptions{Configured: aws.Bool(false)},
+		MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{
+			HttpTokens: ec2types.HttpTokensStateRequired, HttpEndpoint: ec2types.InstanceMetadataEndpointStateEnabled,
+			HttpPutResponseHopLimit: aws.Int32(2), InstanceMetadataTags: ec2types.InstanceMetadataTagsStateDisabled,
+		},
+		Monitoring:          &ec2types.Monitoring{State: ec2types.MonitoringStatePending},
 		SecurityGroups:      []ec2types.GroupIdentifier{{GroupId: aws.String("sg-1"), GroupName: aws.String("web-sg")}},
 		BlockDeviceMappings: []ec2types.InstanceBlockDeviceMapping{ /* Tested separately */ },
 		Tags:                []ec2types.Tag{{Key: aws.String("Env"), Value: aws.String("dev")}},
@@ -448,6 +542,10 @@ func TestMapBaseInstanceAttributes(t *testing.T) {
 	assert.Equal(t, "54.0.0.1", attrs["public_ip_address"])
 	assert.Equal(t, "subnet-abc", attrs["subnet_id"])
 	assert.Equal(t, "vpc-xyz", attrs["vpc_id"])
+	assert.Equal(t, "us-east-1a", attrs[domain.ComputeAvailabilityZoneKey])
+	assert.Equal(t, "pg-cluster", attrs[domain.ComputePlacementGroupKey])
+	assert.Equal(t, string(ec2types.TenancyDedicated), attrs[domain.ComputeTenancyKey])
+	assert.Equal(t, true, attrs[domain.ComputeEBSOptimizedKey])
 	assert.Equal(t, string(ec2types.ArchitectureValuesX8664), attrs["architecture"])
 	assert.Equal(t, "/dev/sda1", attrs["root_device_name"])
 	assert.Equal(t, string(ec2types.DeviceTypeEbs), attrs["root_device_type"])
@@ -458,6 +556,13 @@ func TestMapBaseInstanceAttributes(t *testing.T) {
 	assert.Equal(t, string(ec2types.VirtualizationTypeHvm), attrs["virtualization_type"])
 	assert.Equal(t, map[string]any{"core_count": int32(2), "threads_per_core": int32(1)}, attrs["cpu_options"])
 	assert.False(t, attrs["hibernation_enabled"].(bool))
+	assert.Equal(t, map[string]any{
+		"http_tokens":                 "required",
+		"http_endpoint":               "enabled",
+		"http_put_response_hop_limit": int64(2),
+		"instance_metadata_tags":      "disabled",
+	}, attrs[domain.ComputeMetadataOptionsKey])
+	assert.Equal(t, true, attrs[domain.ComputeMonitoringKey], "pending is monitoring being enabled")
 	assert.Equal(t, []map[string]string{{"id": "sg-1", "name": "web-sg"}}, attrs["security_groups"])
 	assert.Equal(t, map[string]string{"Env": "dev"}, attrs[domain.KeyTags])
 	assert.Equal(t, instanceID, attrs[domain.KeyName]) // Defaults to ID as no Name tag
@@ -481,4 +586,12 @@ func TestMapBaseInstanceAttributes_WithNameTag(t *testing.T) {
 	assert.Equal(t, map[string]string{"Name": name, "Other": "Value"}, attrs[domain.KeyTags])
 }
 
+func TestIsBurstableInstanceType(t *testing.T) {
+	assert.True(t, isBurstableInstanceType(ec2types.InstanceTypeT2Micro))
+	assert.True(t, isBurstableInstanceType("t4g.large"))
+	assert.False(t, isBurstableInstanceType(ec2types.InstanceTypeM5Large))
+	assert.False(t, isBurstableInstanceType("trn1.2xlarge"))
+	assert.False(t, isBurstableInstanceType(""))
+}
+
 // ... (rest of the mapping tests) ...
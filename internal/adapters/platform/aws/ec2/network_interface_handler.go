@@ -0,0 +1,171 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const networkInterfacePageSize = 1000
+
+// NetworkInterfaceHandler lists EC2 network interfaces. Interfaces that AWS
+// services create and manage on your behalf (requester-managed, such as those
+// of load balancers, NAT gateways and Lambda functions) cannot be managed in
+// Terraform and are left out of listings. It shares the clients, limiter and
+// account ID lookup of the instance handler.
+type NetworkInterfaceHandler struct {
+	*EC2Handler
+}
+
+// NewNetworkInterfaceHandler creates a new NetworkInterfaceHandler with the
+// given AWS config and the same options as the instance handler.
+func NewNetworkInterfaceHandler(cfg aws.Config, opts ...HandlerOption) *NetworkInterfaceHandler {
+	return &NetworkInterfaceHandler{EC2Handler: NewHandler(cfg, opts...)}
+}
+
+func (h *NetworkInterfaceHandler) Kind() domain.ResourceKind {
+	return domain.KindNetworkInterface
+}
+
+func (h *NetworkInterfaceHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for network interface ListResources: %v", accErr)
+	}
+
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters:    BuildNetworkInterfaceFilters(filters),
+		MaxResults: aws.Int32(networkInterfacePageSize),
+	}
+
+	logger.Debugf(ctx, "Starting network interface listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.ec2Client.DescribeNetworkInterfaces(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeNetworkInterfaces:Page%d", pageNum), err, ctx)
+		}
+
+		for _, eni := range output.NetworkInterfaces {
+			if aws.ToBool(eni.RequesterManaged) {
+				continue
+			}
+			resource, mapErr := newNetworkInterfaceResource(eni, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for network interface %s, skipping", aws.ToString(eni.NetworkInterfaceId))
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending network interface %s", aws.ToString(eni.NetworkInterfaceId))
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	logger.Debugf(ctx, "Finished network interface pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *NetworkInterfaceHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Describing single network interface %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []string{id}})
+	if err != nil {
+		return nil, h.errorHandler.Handle("EC2", "DescribeNetworkInterfaces", err, ctx)
+	}
+	if len(output.NetworkInterfaces) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("network interface with ID '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for network interface GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newNetworkInterfaceResource(output.NetworkInterfaces[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for network interface %s", id))
+	}
+	return resource, nil
+}
+
+// GetResources describes the given network interfaces with a
+// network-interface-id filter, which unlike NetworkInterfaceIds skips IDs that
+// do not exist.
+func (h *NetworkInterfaceHandler) GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error) {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for network interface GetResources: %v", accErr)
+	}
+
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	for _, batch := range shared.ChunkIDs(ids, filterValueBatchSize) {
+		logger.Debugf(ctx, "Describing batch of %d network interfaces", len(batch))
+		input := &ec2.DescribeNetworkInterfacesInput{
+			Filters: []ec2types.Filter{{Name: aws.String("network-interface-id"), Values: batch}},
+		}
+		for {
+			if err := h.limiter.Wait(ctx, logger); err != nil {
+				return nil, err
+			}
+			output, err := h.ec2Client.DescribeNetworkInterfaces(ctx, input)
+			if err != nil {
+				return nil, h.errorHandler.Handle("EC2", "DescribeNetworkInterfaces", err, ctx)
+			}
+			for _, eni := range output.NetworkInterfaces {
+				id := aws.ToString(eni.NetworkInterfaceId)
+				resource, mapErr := newNetworkInterfaceResource(eni, cfg.Region, accountID)
+				if mapErr != nil {
+					return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for network interface %s", id))
+				}
+				resources[id] = resource
+			}
+			if aws.ToString(output.NextToken) == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return resources, nil
+}
+
+// Probe verifies that network interfaces can be described with a single minimal page.
+func (h *NetworkInterfaceHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{MaxResults: aws.Int32(5)}); err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeNetworkInterfaces", err, ctx)
+	}
+	return nil
+}
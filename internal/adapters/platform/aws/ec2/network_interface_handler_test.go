@@ -0,0 +1,138 @@
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// NetworkInterfaceHandlerTestSuite runs the handler with real SDK clients
+// against the awsfake server.
+type NetworkInterfaceHandlerTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *NetworkInterfaceHandler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T(), awsfake.WithPageSize(1))
+	s.fake.AddNetworkInterfaces(
+		awsfake.NetworkInterface{ID: "eni-1", SubnetID: "subnet-1", VpcID: "vpc-1", PrivateIPs: []string{"10.0.0.5"}, SecurityGroupIDs: []string{"sg-1"}, SourceDestCheck: true, InstanceID: "i-1", DeviceIndex: 1},
+		awsfake.NetworkInterface{ID: "eni-2", SubnetID: "subnet-1", VpcID: "vpc-1", PrivateIPs: []string{"10.0.0.6"}, Description: "ELB app/web", RequesterManaged: true},
+		awsfake.NetworkInterface{ID: "eni-3", SubnetID: "subnet-2", VpcID: "vpc-2", PrivateIPs: []string{"10.1.0.5"}},
+	)
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+
+	s.handler = NewNetworkInterfaceHandler(s.fake.Config())
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestNetworkInterfaceHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NetworkInterfaceHandlerTestSuite))
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindNetworkInterface, s.handler.Kind())
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestListResources_PaginatesAndSkipsRequesterManaged() {
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("eni-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("eni-3", resources[1].Metadata().ProviderAssignedID)
+	s.Equal(awsfake.DefaultAccountID, resources[0].Metadata().AccountID)
+	s.Equal(3, s.fake.Calls("DescribeNetworkInterfaces"))
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestListResources_PassesFilters() {
+	resources, err := s.collect(map[string]string{domain.NetworkInterfaceSubnetIDKey: "subnet-2"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("eni-3", resources[0].Metadata().ProviderAssignedID)
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestListResources_APIError() {
+	s.fake.Fail("DescribeNetworkInterfaces", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", After: 1, Times: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Contains(err.Error(), "DescribeNetworkInterfaces:Page2")
+	s.Len(resources, 1)
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestGetResource_MapsAttachment() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "eni-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("subnet-1", attrs[domain.NetworkInterfaceSubnetIDKey])
+	s.Equal("10.0.0.5", attrs[domain.NetworkInterfacePrivateIPKey])
+	s.Equal([]string{"10.0.0.5"}, attrs[domain.NetworkInterfacePrivateIPsKey])
+	s.Equal([]string{"sg-1"}, attrs[domain.NetworkInterfaceSecurityGroupsKey])
+	s.Equal(true, attrs[domain.NetworkInterfaceSourceDestCheckKey])
+	s.Equal(map[string]any{"instance": "i-1", "device_index": int64(1)}, attrs[domain.NetworkInterfaceAttachmentKey])
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestGetResource_NotFound() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "eni-missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestGetResources_SkipsMissing() {
+	resources, err := s.handler.GetResources(s.ctx, s.fake.Config(), []string{"eni-1", "eni-3", "eni-missing"}, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Len(resources, 2)
+	s.Contains(resources, "eni-1")
+	s.Contains(resources, "eni-3")
+}
+
+func (s *NetworkInterfaceHandlerTestSuite) TestProbe() {
+	s.NoError(s.handler.Probe(s.ctx, s.fake.Config(), s.mockLogger))
+	s.Equal(1, s.fake.Calls("DescribeNetworkInterfaces"))
+}
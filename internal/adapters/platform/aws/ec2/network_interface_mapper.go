@@ -0,0 +1,109 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	iddErrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// networkInterfaceResource wraps a described network interface.
+// DescribeNetworkInterfaces returns the attachment, security groups and tags,
+// so attributes are mapped once when the resource is built.
+type networkInterfaceResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newNetworkInterfaceResource(eni NetworkInterface, region, accountID string) (domain.PlatformResource, error) {
+	eniID := aws.ToString(eni.NetworkInterfaceId)
+	if eniID == "" {
+		return nil, iddErrors.New(iddErrors.CodeInternal, "failed to create network interface resource: missing network interface ID")
+	}
+	return &networkInterfaceResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindNetworkInterface,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: eniID,
+			SourceIdentifier:   eniID,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapNetworkInterfaceToAttributes(eni, region, accountID),
+	}, nil
+}
+
+func (r *networkInterfaceResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *networkInterfaceResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+// mapNetworkInterfaceToAttributes maps a network interface. The attachment is
+// left out when the interface is detached or being detached.
+func mapNetworkInterfaceToAttributes(eni NetworkInterface, region, accountID string) map[string]any {
+	eniID := aws.ToString(eni.NetworkInterfaceId)
+	attrs := map[string]any{
+		domain.KeyID:                              eniID,
+		domain.NetworkInterfaceSubnetIDKey:        aws.ToString(eni.SubnetId),
+		domain.NetworkInterfacePrivateIPKey:       aws.ToString(eni.PrivateIpAddress),
+		domain.NetworkInterfaceSourceDestCheckKey: aws.ToBool(eni.SourceDestCheck),
+		domain.NetworkInterfaceTypeKey:            string(eni.InterfaceType),
+	}
+	if accountID != "" {
+		attrs[domain.KeyARN] = fmt.Sprintf("arn:aws:ec2:%s:%s:network-interface/%s", region, accountID, eniID)
+	}
+	if description := aws.ToString(eni.Description); description != "" {
+		attrs[domain.NetworkInterfaceDescriptionKey] = description
+	}
+
+	privateIPs := make([]string, 0, len(eni.PrivateIpAddresses))
+	for _, ip := range eni.PrivateIpAddresses {
+		if addr := aws.ToString(ip.PrivateIpAddress); addr != "" {
+			privateIPs = append(privateIPs, addr)
+		}
+	}
+	sort.Strings(privateIPs)
+	if len(privateIPs) > 0 {
+		attrs[domain.NetworkInterfacePrivateIPsKey] = privateIPs
+	}
+
+	groups := make([]string, 0, len(eni.Groups))
+	for _, group := range eni.Groups {
+		groups = append(groups, aws.ToString(group.GroupId))
+	}
+	sort.Strings(groups)
+	if len(groups) > 0 {
+		attrs[domain.NetworkInterfaceSecurityGroupsKey] = groups
+	}
+
+	if a := eni.Attachment; a != nil && aws.ToString(a.InstanceId) != "" && a.Status != ec2types.AttachmentStatusDetaching && a.Status != ec2types.AttachmentStatusDetached {
+		attrs[domain.NetworkInterfaceAttachmentKey] = map[string]any{
+			"instance":     aws.ToString(a.InstanceId),
+			"device_index": int64(aws.ToInt32(a.DeviceIndex)),
+		}
+	}
+
+	if len(eni.TagSet) > 0 {
+		tags := make(map[string]string, len(eni.TagSet))
+		for _, tag := range eni.TagSet {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
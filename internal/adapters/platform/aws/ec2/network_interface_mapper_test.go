@@ -0,0 +1,61 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestNewNetworkInterfaceResource_MissingID(t *testing.T) {
+	_, err := newNetworkInterfaceResource(ec2types.NetworkInterface{}, "us-east-1", "123456789012")
+	assert.Error(t, err)
+}
+
+func TestMapNetworkInterfaceToAttributes(t *testing.T) {
+	eni := ec2types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		SubnetId:           aws.String("subnet-1"),
+		Description:        aws.String("proxy"),
+		PrivateIpAddress:   aws.String("10.0.0.6"),
+		PrivateIpAddresses: []ec2types.NetworkInterfacePrivateIpAddress{
+			{PrivateIpAddress: aws.String("10.0.0.6"), Primary: aws.Bool(true)},
+			{PrivateIpAddress: aws.String("10.0.0.5")},
+		},
+		Groups:          []ec2types.GroupIdentifier{{GroupId: aws.String("sg-2")}, {GroupId: aws.String("sg-1")}},
+		SourceDestCheck: aws.Bool(false),
+		InterfaceType:   ec2types.NetworkInterfaceTypeInterface,
+		Attachment:      &ec2types.NetworkInterfaceAttachment{InstanceId: aws.String("i-1"), DeviceIndex: aws.Int32(1), Status: ec2types.AttachmentStatusAttached},
+		TagSet:          []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("proxy")}},
+	}
+
+	attrs := mapNetworkInterfaceToAttributes(eni, "us-east-1", "123456789012")
+
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                              "eni-1",
+		domain.KeyARN:                             "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-1",
+		domain.NetworkInterfaceSubnetIDKey:        "subnet-1",
+		domain.NetworkInterfaceDescriptionKey:     "proxy",
+		domain.NetworkInterfacePrivateIPKey:       "10.0.0.6",
+		domain.NetworkInterfacePrivateIPsKey:      []string{"10.0.0.5", "10.0.0.6"},
+		domain.NetworkInterfaceSecurityGroupsKey:  []string{"sg-1", "sg-2"},
+		domain.NetworkInterfaceSourceDestCheckKey: false,
+		domain.NetworkInterfaceTypeKey:            "interface",
+		domain.NetworkInterfaceAttachmentKey:      map[string]any{"instance": "i-1", "device_index": int64(1)},
+		domain.KeyTags:                            map[string]string{"Name": "proxy"},
+	}, attrs)
+}
+
+func TestMapNetworkInterfaceToAttributes_DetachingIsUnattached(t *testing.T) {
+	eni := ec2types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		Attachment:         &ec2types.NetworkInterfaceAttachment{InstanceId: aws.String("i-1"), Status: ec2types.AttachmentStatusDetaching},
+	}
+
+	attrs := mapNetworkInterfaceToAttributes(eni, "us-east-1", "123456789012")
+
+	assert.NotContains(t, attrs, domain.NetworkInterfaceAttachmentKey)
+}
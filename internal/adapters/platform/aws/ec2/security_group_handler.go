@@ -0,0 +1,164 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const securityGroupPageSize = 1000
+
+// SecurityGroupHandler lists EC2 security groups. It shares the clients,
+// limiter and account ID lookup of the instance handler.
+type SecurityGroupHandler struct {
+	*EC2Handler
+}
+
+// NewSecurityGroupHandler creates a new SecurityGroupHandler with the given AWS
+// config and the same options as the instance handler.
+func NewSecurityGroupHandler(cfg aws.Config, opts ...HandlerOption) *SecurityGroupHandler {
+	return &SecurityGroupHandler{EC2Handler: NewHandler(cfg, opts...)}
+}
+
+func (h *SecurityGroupHandler) Kind() domain.ResourceKind {
+	return domain.KindNetworkSecurityGroup
+}
+
+func (h *SecurityGroupHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for security group ListResources: %v", accErr)
+	}
+
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters:    BuildSecurityGroupFilters(filters),
+		MaxResults: aws.Int32(securityGroupPageSize),
+	}
+
+	logger.Debugf(ctx, "Starting security group listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.ec2Client.DescribeSecurityGroups(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("EC2", fmt.Sprintf("DescribeSecurityGroups:Page%d", pageNum), err, ctx)
+		}
+
+		for _, group := range output.SecurityGroups {
+			resource, mapErr := newSecurityGroupResource(group, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for security group %s, skipping", aws.ToString(group.GroupId))
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending security group %s", aws.ToString(group.GroupId))
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	logger.Debugf(ctx, "Finished security group pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *SecurityGroupHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Describing single security group %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: []string{id}})
+	if err != nil {
+		return nil, h.errorHandler.Handle("EC2", "DescribeSecurityGroups", err, ctx)
+	}
+	if len(output.SecurityGroups) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("security group with ID '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for security group GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newSecurityGroupResource(output.SecurityGroups[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for security group %s", id))
+	}
+	return resource, nil
+}
+
+// GetResources describes the given security groups with a group-id filter,
+// which unlike GroupIds skips IDs that do not exist.
+func (h *SecurityGroupHandler) GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error) {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for security group GetResources: %v", accErr)
+	}
+
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	for _, batch := range shared.ChunkIDs(ids, filterValueBatchSize) {
+		logger.Debugf(ctx, "Describing batch of %d security groups", len(batch))
+		input := &ec2.DescribeSecurityGroupsInput{
+			Filters: []ec2types.Filter{{Name: aws.String("group-id"), Values: batch}},
+		}
+		for {
+			if err := h.limiter.Wait(ctx, logger); err != nil {
+				return nil, err
+			}
+			output, err := h.ec2Client.DescribeSecurityGroups(ctx, input)
+			if err != nil {
+				return nil, h.errorHandler.Handle("EC2", "DescribeSecurityGroups", err, ctx)
+			}
+			for _, sg := range output.SecurityGroups {
+				id := aws.ToString(sg.GroupId)
+				resource, mapErr := newSecurityGroupResource(sg, cfg.Region, accountID)
+				if mapErr != nil {
+					return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for security group %s", id))
+				}
+				resources[id] = resource
+			}
+			if aws.ToString(output.NextToken) == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return resources, nil
+}
+
+// Probe verifies that security groups can be described with a single minimal page.
+func (h *SecurityGroupHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{MaxResults: aws.Int32(5)}); err != nil {
+		return h.errorHandler.Handle("EC2", "DescribeSecurityGroups", err, ctx)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// SecurityGroupHandlerTestSuite runs the handler with real SDK clients against
+// the awsfake server.
+type SecurityGroupHandlerTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *SecurityGroupHandler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *SecurityGroupHandlerTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T(), awsfake.WithPageSize(1))
+	s.fake.AddSecurityGroups(
+		awsfake.SecurityGroup{ID: "sg-1", Name: "group-sg-1", VpcID: "vpc-1", Ingress: []awsfake.Rule{{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDRs: []string{"0.0.0.0/0"}}}},
+		awsfake.SecurityGroup{ID: "sg-2", Name: "group-sg-2", VpcID: "vpc-2"},
+		awsfake.SecurityGroup{ID: "sg-3", Name: "group-sg-3", VpcID: "vpc-3"},
+	)
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+
+	s.handler = NewSecurityGroupHandler(s.fake.Config())
+}
+
+func (s *SecurityGroupHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestSecurityGroupHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SecurityGroupHandlerTestSuite))
+}
+
+func (s *SecurityGroupHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindNetworkSecurityGroup, s.handler.Kind())
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestListResources_Paginates() {
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 3)
+	s.Equal("sg-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("sg-3", resources[2].Metadata().ProviderAssignedID)
+	s.Equal(awsfake.DefaultAccountID, resources[0].Metadata().AccountID)
+	s.Equal(domain.KindNetworkSecurityGroup, resources[0].Metadata().Kind)
+	s.Equal(3, s.fake.Calls("DescribeSecurityGroups"))
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestListResources_PassesFilters() {
+	resources, err := s.collect(map[string]string{domain.SecurityGroupVPCIDKey: "vpc-1, vpc-3"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("sg-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("sg-3", resources[1].Metadata().ProviderAssignedID)
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestListResources_APIError() {
+	s.fake.Fail("DescribeSecurityGroups", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "RequestLimitExceeded", Times: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Contains(err.Error(), "DescribeSecurityGroups:Page1")
+	s.Empty(resources)
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestListResources_FailsMidPagination() {
+	s.fake.Fail("DescribeSecurityGroups", awsfake.Fault{Status: http.StatusForbidden, Code: "UnauthorizedOperation", After: 1})
+
+	resources, err := s.collect(nil)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformAuthError), "got %v", err)
+	s.Contains(err.Error(), "DescribeSecurityGroups:Page2")
+	s.Len(resources, 1, "the first page is still sent")
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestGetResource_Success() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "sg-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("sg-1", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("group-sg-1", attrs[domain.KeyName])
+	s.Equal("vpc-1", attrs[domain.SecurityGroupVPCIDKey])
+	s.NotEmpty(attrs[domain.SecurityGroupIngressKey])
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestGetResource_NotFound() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "sg-missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestGetResources_FiltersByGroupID() {
+	resources, err := s.handler.GetResources(s.ctx, s.fake.Config(), []string{"sg-1", "sg-2", "sg-missing", "sg-1"}, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("sg-1", resources["sg-1"].Metadata().ProviderAssignedID)
+	s.Equal("sg-2", resources["sg-2"].Metadata().ProviderAssignedID)
+	s.Equal(2, s.fake.Calls("DescribeSecurityGroups"), "follows the next token")
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestGetResources_APIError() {
+	s.fake.Fail("DescribeSecurityGroups", awsfake.Fault{Status: http.StatusInternalServerError, Code: "InternalError"})
+
+	resources, err := s.handler.GetResources(s.ctx, s.fake.Config(), []string{"sg-1"}, s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformAPIError), "got %v", err)
+	s.Nil(resources)
+}
+
+func (s *SecurityGroupHandlerTestSuite) TestProbe() {
+	s.NoError(s.handler.Probe(s.ctx, s.fake.Config(), s.mockLogger))
+	s.Equal(1, s.fake.Calls("DescribeSecurityGroups"))
+}
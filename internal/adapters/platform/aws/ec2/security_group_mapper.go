@@ -0,0 +1,119 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	iddErrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// securityGroupResource wraps a described security group. DescribeSecurityGroups
+// returns the rules and tags, so attributes are mapped once when the resource
+// is built.
+type securityGroupResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newSecurityGroupResource(group SecurityGroup, region, accountID string) (domain.PlatformResource, error) {
+	groupID := aws.ToString(group.GroupId)
+	if groupID == "" {
+		return nil, iddErrors.New(iddErrors.CodeInternal, "failed to create security group resource: missing group ID")
+	}
+	return &securityGroupResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindNetworkSecurityGroup,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: groupID,
+			SourceIdentifier:   groupID,
+			AccountID:          accountID,
+			Region:             region,
+			PlatformDefault:    shared.SecurityGroupDefault(aws.ToString(group.GroupName)),
+		},
+		attrs: mapSecurityGroupToAttributes(group),
+	}, nil
+}
+
+func (r *securityGroupResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *securityGroupResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapSecurityGroupToAttributes(group SecurityGroup) map[string]any {
+	groupID := aws.ToString(group.GroupId)
+	attrs := map[string]any{
+		domain.KeyID:                       groupID,
+		domain.KeyName:                     aws.ToString(group.GroupName),
+		domain.SecurityGroupDescriptionKey: aws.ToString(group.Description),
+		domain.SecurityGroupVPCIDKey:       aws.ToString(group.VpcId),
+	}
+	if group.SecurityGroupArn != nil {
+		attrs[domain.KeyARN] = *group.SecurityGroupArn
+	}
+	if group.OwnerId != nil {
+		attrs["owner_id"] = *group.OwnerId
+	}
+	if rules := flattenIPPermissions(group.IpPermissions, groupID); len(rules) > 0 {
+		attrs[domain.SecurityGroupIngressKey] = rules
+	}
+	if rules := flattenIPPermissions(group.IpPermissionsEgress, groupID); len(rules) > 0 {
+		attrs[domain.SecurityGroupEgressKey] = rules
+	}
+	if len(group.Tags) > 0 {
+		tags := make(map[string]string, len(group.Tags))
+		for _, tag := range group.Tags {
+			if tag.Key != nil {
+				tags[*tag.Key] = aws.ToString(tag.Value)
+			}
+		}
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
+
+// flattenIPPermissions flattens permissions to one rule per source, the shape
+// the Terraform mapping produces. A reference to the group itself becomes a
+// "self" rule. Ports are left unset when EC2 omits them (all protocols).
+func flattenIPPermissions(permissions []ec2types.IpPermission, groupID string) []any {
+	var rules []any
+	for _, permission := range permissions {
+		newRule := func(key string, value any) map[string]any {
+			rule := map[string]any{"protocol": aws.ToString(permission.IpProtocol), key: value}
+			if permission.FromPort != nil {
+				rule["from_port"] = *permission.FromPort
+			}
+			if permission.ToPort != nil {
+				rule["to_port"] = *permission.ToPort
+			}
+			return rule
+		}
+		for _, r := range permission.IpRanges {
+			rules = append(rules, newRule("cidr_block", aws.ToString(r.CidrIp)))
+		}
+		for _, r := range permission.Ipv6Ranges {
+			rules = append(rules, newRule("ipv6_cidr_block", aws.ToString(r.CidrIpv6)))
+		}
+		for _, p := range permission.PrefixListIds {
+			rules = append(rules, newRule("prefix_list_id", aws.ToString(p.PrefixListId)))
+		}
+		for _, pair := range permission.UserIdGroupPairs {
+			if aws.ToString(pair.GroupId) == groupID {
+				rules = append(rules, newRule("self", true))
+				continue
+			}
+			rules = append(rules, newRule("security_group", aws.ToString(pair.GroupId)))
+		}
+	}
+	return rules
+}
@@ -0,0 +1,95 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestNewSecurityGroupResource_MissingID(t *testing.T) {
+	_, err := newSecurityGroupResource(ec2types.SecurityGroup{}, "us-east-1", "123456789012")
+	assert.Error(t, err)
+}
+
+func TestMapSecurityGroupToAttributes(t *testing.T) {
+	group := ec2types.SecurityGroup{
+		GroupId:          aws.String("sg-1"),
+		GroupName:        aws.String("web"),
+		Description:      aws.String("web tier"),
+		VpcId:            aws.String("vpc-1"),
+		OwnerId:          aws.String("123456789012"),
+		SecurityGroupArn: aws.String("arn:aws:ec2:us-east-1:123456789012:security-group/sg-1"),
+		Tags:             []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("web")}},
+		IpPermissions: []ec2types.IpPermission{
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(443),
+				ToPort:     aws.Int32(443),
+				IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("10.0.0.0/16")}, {CidrIp: aws.String("10.1.0.0/16")}},
+				Ipv6Ranges: []ec2types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+			},
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(8080),
+				ToPort:     aws.Int32(8080),
+				UserIdGroupPairs: []ec2types.UserIdGroupPair{
+					{GroupId: aws.String("sg-1")},
+					{GroupId: aws.String("sg-2")},
+				},
+				PrefixListIds: []ec2types.PrefixListId{{PrefixListId: aws.String("pl-1")}},
+			},
+		},
+		IpPermissionsEgress: []ec2types.IpPermission{
+			{IpProtocol: aws.String("-1"), IpRanges: []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+		},
+	}
+
+	resource, err := newSecurityGroupResource(group, "us-east-1", "123456789012")
+	require.NoError(t, err)
+	attrs, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "sg-1", attrs[domain.KeyID])
+	assert.Equal(t, "web", attrs[domain.KeyName])
+	assert.Equal(t, "web tier", attrs[domain.SecurityGroupDescriptionKey])
+	assert.Equal(t, "vpc-1", attrs[domain.SecurityGroupVPCIDKey])
+	assert.Equal(t, "arn:aws:ec2:us-east-1:123456789012:security-group/sg-1", attrs[domain.KeyARN])
+	assert.Equal(t, map[string]string{"Name": "web"}, attrs[domain.KeyTags])
+	assert.Equal(t, []any{
+		map[string]any{"protocol": "tcp", "from_port": int32(443), "to_port": int32(443), "cidr_block": "10.0.0.0/16"},
+		map[string]any{"protocol": "tcp", "from_port": int32(443), "to_port": int32(443), "cidr_block": "10.1.0.0/16"},
+		map[string]any{"protocol": "tcp", "from_port": int32(443), "to_port": int32(443), "ipv6_cidr_block": "::/0"},
+		map[string]any{"protocol": "tcp", "from_port": int32(8080), "to_port": int32(8080), "prefix_list_id": "pl-1"},
+		map[string]any{"protocol": "tcp", "from_port": int32(8080), "to_port": int32(8080), "self": true},
+		map[string]any{"protocol": "tcp", "from_port": int32(8080), "to_port": int32(8080), "security_group": "sg-2"},
+	}, attrs[domain.SecurityGroupIngressKey])
+	assert.Equal(t, []any{
+		map[string]any{"protocol": "-1", "cidr_block": "0.0.0.0/0"},
+	}, attrs[domain.SecurityGroupEgressKey])
+}
+
+func TestMapSecurityGroupToAttributes_NoRules(t *testing.T) {
+	attrs := mapSecurityGroupToAttributes(ec2types.SecurityGroup{GroupId: aws.String("sg-1")})
+
+	assert.NotContains(t, attrs, domain.SecurityGroupIngressKey)
+	assert.NotContains(t, attrs, domain.SecurityGroupEgressKey)
+	assert.NotContains(t, attrs, domain.KeyTags)
+}
+
+func TestNewSecurityGroupResource_PlatformDefault(t *testing.T) {
+	group := ec2types.SecurityGroup{GroupId: aws.String("sg-1"), GroupName: aws.String("default"), VpcId: aws.String("vpc-1")}
+	resource, err := newSecurityGroupResource(group, "us-east-1", "123456789012")
+	require.NoError(t, err)
+	assert.Equal(t, "default security group", resource.Metadata().PlatformDefault)
+
+	group.GroupName = aws.String("web")
+	resource, err = newSecurityGroupResource(group, "us-east-1", "123456789012")
+	require.NoError(t, err)
+	assert.Empty(t, resource.Metadata().PlatformDefault)
+}
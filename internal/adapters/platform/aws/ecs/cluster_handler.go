@@ -0,0 +1,133 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// clusterFields are included when describing clusters, which omits them by default.
+var clusterFields = []ecstypes.ClusterField{ecstypes.ClusterFieldTags, ecstypes.ClusterFieldSettings}
+
+// ClusterHandler lists ECS clusters together with their settings and tags.
+type ClusterHandler struct {
+	*baseHandler
+}
+
+// NewClusterHandler creates a new ClusterHandler with the given AWS config and optional configurations.
+func NewClusterHandler(cfg aws.Config, opts ...HandlerOption) *ClusterHandler {
+	return &ClusterHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *ClusterHandler) Kind() domain.ResourceKind {
+	return domain.KindContainerCluster
+}
+
+// ListResources lists the clusters of the region, describing them in batches.
+// The ID, name and tag filters are applied to the described clusters.
+func (h *ClusterHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS cluster ListResources: %v", accErr)
+	}
+
+	arns, err := h.clusterARNs(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(ctx, "Describing %d ECS clusters", len(arns))
+	for start := 0; start < len(arns); start += maxDescribeClusters {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		end := min(start+maxDescribeClusters, len(arns))
+		clusters, err := h.describeClusters(ctx, arns[start:end], logger)
+		if err != nil {
+			return err
+		}
+		for _, cluster := range clusters {
+			arn := aws.ToString(cluster.ClusterArn)
+			if !matchesNameFilters(filters, arn, aws.ToString(cluster.ClusterName)) || !matchesTagFilters(tagsToMap(cluster.Tags), filters) {
+				continue
+			}
+			resource, mapErr := newClusterResource(cluster, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ECS cluster %s, skipping", arn)
+				continue
+			}
+			if err := h.send(ctx, resource, out, logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Debugf(ctx, "Finished ECS cluster listing.")
+	return nil
+}
+
+// GetResource fetches a cluster by ARN or name.
+func (h *ClusterHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ECS cluster %s", id)
+	clusters, err := h.describeClusters(ctx, []string{id}, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		return nil, notFound("cluster", id)
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS cluster GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newClusterResource(clusters[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ECS cluster %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that clusters can be listed with a single minimal page.
+func (h *ClusterHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ecsClient.ListClusters(ctx, &ecs.ListClustersInput{MaxResults: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("ECS", "ListClusters", err, ctx)
+	}
+	return nil
+}
+
+// describeClusters describes the given clusters. Inactive clusters, which ECS
+// keeps describing for a while after deletion, are left out.
+func (h *ClusterHandler) describeClusters(ctx context.Context, clusters []string, logger ports.Logger) ([]Cluster, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.ecsClient.DescribeClusters(ctx, &ecs.DescribeClustersInput{Clusters: clusters, Include: clusterFields})
+	if err != nil {
+		return nil, h.errorHandler.Handle("ECS", "DescribeClusters", err, ctx)
+	}
+	active := make([]Cluster, 0, len(output.Clusters))
+	for _, cluster := range output.Clusters {
+		if aws.ToString(cluster.Status) == inactiveStatus {
+			continue
+		}
+		active = append(active, cluster)
+	}
+	return active, nil
+}
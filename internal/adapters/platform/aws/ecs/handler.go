@@ -0,0 +1,216 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+	// maxDescribeClusters and maxDescribeServices are the number of clusters
+	// and services DescribeClusters and DescribeServices accept per call.
+	maxDescribeClusters = 100
+	maxDescribeServices = 10
+)
+
+// baseHandler holds the clients shared by the cluster, service and task
+// definition handlers.
+type baseHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	ecsClient    ECSClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the ECS handlers.
+type HandlerOption func(*baseHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithECSClient provides an option to set a custom ECS client.
+func WithECSClient(client ECSClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.ecsClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *baseHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *baseHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+func newBaseHandler(cfg aws.Config, opts []HandlerOption) *baseHandler {
+	h := &baseHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		ecsClient:    ecs.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *baseHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "ECS: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+func (h *baseHandler) send(ctx context.Context, resource domain.PlatformResource, out chan<- domain.PlatformResource, logger ports.Logger) error {
+	select {
+	case out <- resource:
+		return nil
+	case <-ctx.Done():
+		logger.Warnf(ctx, "Context cancelled while sending ECS resource %s", resource.Metadata().ProviderAssignedID)
+		return ctx.Err()
+	}
+}
+
+// clusterARNs returns the ARNs of the clusters of the region.
+func (h *baseHandler) clusterARNs(ctx context.Context, logger ports.Logger) ([]string, error) {
+	var arns []string
+	input := &ecs.ListClustersInput{MaxResults: aws.Int32(listPageSize)}
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.ecsClient.ListClusters(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ECS", "ListClusters", err, ctx)
+		}
+		arns = append(arns, output.ClusterArns...)
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return arns, nil
+}
+
+// tagsToMap flattens ECS tags by key.
+func tagsToMap(tags []ecstypes.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		if tag.Key != nil {
+			result[*tag.Key] = aws.ToString(tag.Value)
+		}
+	}
+	return result
+}
+
+// matchesNameFilters applies the ID and name filters to a cluster, service or
+// task definition family. Comma separated values match any of the values.
+func matchesNameFilters(filters map[string]string, id, name string) bool {
+	if value, ok := filters[domain.KeyID]; ok && !containsValue(value, id) {
+		return false
+	}
+	if value, ok := filters[domain.KeyName]; ok && !containsValue(value, name) {
+		return false
+	}
+	return true
+}
+
+func matchesTagFilters(tags map[string]string, filters map[string]string) bool {
+	for key, value := range filters {
+		if !strings.HasPrefix(key, domain.TagPrefix) {
+			continue
+		}
+		actual, ok := tags[strings.TrimPrefix(key, domain.TagPrefix)]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
+
+// splitValues splits a comma separated filter value, dropping empty values.
+func splitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func notFound(kind, id string) error {
+	return errors.New(errors.CodeResourceNotFound, fmt.Sprintf("ECS %s '%s' not found (empty response)", kind, id))
+}
@@ -0,0 +1,248 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	ecsmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/ecs/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	testClusterARN = "arn:aws:ecs:us-east-1:123456789012:cluster/app"
+	testServiceARN = "arn:aws:ecs:us-east-1:123456789012:service/app/web"
+)
+
+type ECSHandlerTestSuite struct {
+	suite.Suite
+	mockECS          *ecsmocks.ECSClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	opts             []HandlerOption
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *ECSHandlerTestSuite) SetupTest() {
+	s.mockECS = new(ecsmocks.ECSClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.opts = []HandlerOption{
+		WithSTSClient(s.mockSTS),
+		WithECSClient(s.mockECS),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	}
+}
+
+func (s *ECSHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestECSHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ECSHandlerTestSuite))
+}
+
+func (s *ECSHandlerTestSuite) collect(list func(out chan<- domain.PlatformResource) error) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := list(out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *ECSHandlerTestSuite) TestKinds() {
+	s.Equal(domain.KindContainerCluster, NewClusterHandler(s.awsConfig, s.opts...).Kind())
+	s.Equal(domain.KindContainerService, NewServiceHandler(s.awsConfig, s.opts...).Kind())
+	s.Equal(domain.KindContainerTaskDefinition, NewTaskDefinitionHandler(s.awsConfig, s.opts...).Kind())
+}
+
+func (s *ECSHandlerTestSuite) TestClusterListResources_SkipsInactiveAndFilters() {
+	handler := NewClusterHandler(s.awsConfig, s.opts...)
+	s.mockECS.On("ListClusters", mock.Anything, mock.MatchedBy(func(in *ecs.ListClustersInput) bool { return in.NextToken == nil })).
+		Return(&ecs.ListClustersOutput{ClusterArns: []string{testClusterARN}, NextToken: aws.String("page2")}, nil).Once()
+	s.mockECS.On("ListClusters", mock.Anything, mock.MatchedBy(func(in *ecs.ListClustersInput) bool { return aws.ToString(in.NextToken) == "page2" })).
+		Return(&ecs.ListClustersOutput{ClusterArns: []string{"arn:aws:ecs:us-east-1:123456789012:cluster/old", "arn:aws:ecs:us-east-1:123456789012:cluster/batch"}}, nil).Once()
+	s.mockECS.On("DescribeClusters", mock.Anything, mock.MatchedBy(func(in *ecs.DescribeClustersInput) bool {
+		return len(in.Clusters) == 3 && len(in.Include) == 2
+	})).Return(&ecs.DescribeClustersOutput{Clusters: []ecstypes.Cluster{
+		{
+			ClusterArn:  aws.String(testClusterARN),
+			ClusterName: aws.String("app"),
+			Status:      aws.String("ACTIVE"),
+			Settings:    []ecstypes.ClusterSetting{{Name: ecstypes.ClusterSettingNameContainerInsights, Value: aws.String("enabled")}},
+			Tags:        []ecstypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}},
+		},
+		{ClusterArn: aws.String("arn:aws:ecs:us-east-1:123456789012:cluster/old"), ClusterName: aws.String("old"), Status: aws.String(inactiveStatus)},
+		{ClusterArn: aws.String("arn:aws:ecs:us-east-1:123456789012:cluster/batch"), ClusterName: aws.String("batch"), Status: aws.String("ACTIVE")},
+	}}, nil).Once()
+
+	resources, err := s.collect(func(out chan<- domain.PlatformResource) error {
+		return handler.ListResources(s.ctx, s.awsConfig, map[string]string{"tag:Env": "prod"}, s.mockLogger, out)
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal(testClusterARN, resources[0].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"containerInsights": "enabled"}, attrs[domain.ContainerClusterSettingsKey])
+	s.mockECS.AssertExpectations(s.T())
+}
+
+func (s *ECSHandlerTestSuite) TestClusterListResources_APIError() {
+	handler := NewClusterHandler(s.awsConfig, s.opts...)
+	apiErr := errors.New("access denied")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockECS.On("ListClusters", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "ECS", "ListClusters", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(func(out chan<- domain.PlatformResource) error {
+		return handler.ListResources(s.ctx, s.awsConfig, nil, s.mockLogger, out)
+	})
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *ECSHandlerTestSuite) TestServiceListResources_ClusterFilter() {
+	handler := NewServiceHandler(s.awsConfig, s.opts...)
+	s.mockECS.On("ListServices", mock.Anything, mock.MatchedBy(func(in *ecs.ListServicesInput) bool { return aws.ToString(in.Cluster) == "app" })).
+		Return(&ecs.ListServicesOutput{ServiceArns: []string{testServiceARN, "arn:aws:ecs:us-east-1:123456789012:service/app/worker"}}, nil).Once()
+	s.mockECS.On("DescribeServices", mock.Anything, mock.MatchedBy(func(in *ecs.DescribeServicesInput) bool {
+		return aws.ToString(in.Cluster) == "app" && len(in.Services) == 2 && len(in.Include) == 1
+	})).Return(&ecs.DescribeServicesOutput{Services: []ecstypes.Service{
+		{
+			ServiceArn:     aws.String(testServiceARN),
+			ServiceName:    aws.String("web"),
+			ClusterArn:     aws.String(testClusterARN),
+			TaskDefinition: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web:7"),
+			DesiredCount:   3,
+			Status:         aws.String("ACTIVE"),
+		},
+		{
+			ServiceArn:  aws.String("arn:aws:ecs:us-east-1:123456789012:service/app/worker"),
+			ServiceName: aws.String("worker"),
+			Status:      aws.String(inactiveStatus),
+		},
+	}}, nil).Once()
+
+	resources, err := s.collect(func(out chan<- domain.PlatformResource) error {
+		return handler.ListResources(s.ctx, s.awsConfig, map[string]string{domain.ContainerServiceClusterKey: "app"}, s.mockLogger, out)
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal(testServiceARN, resources[0].Metadata().ProviderAssignedID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("app", attrs[domain.ContainerServiceClusterKey])
+	s.Equal("web:7", attrs[domain.ContainerServiceTaskDefinitionKey])
+	s.Equal(int64(3), attrs[domain.ContainerServiceDesiredCountKey])
+	s.mockECS.AssertNotCalled(s.T(), "ListClusters", mock.Anything, mock.Anything)
+}
+
+func (s *ECSHandlerTestSuite) TestServiceGetResource_UsesClusterFromARN() {
+	handler := NewServiceHandler(s.awsConfig, s.opts...)
+	s.mockECS.On("DescribeServices", mock.Anything, mock.MatchedBy(func(in *ecs.DescribeServicesInput) bool {
+		return aws.ToString(in.Cluster) == "app" && len(in.Services) == 1 && in.Services[0] == testServiceARN
+	})).Return(&ecs.DescribeServicesOutput{Services: []ecstypes.Service{
+		{ServiceArn: aws.String(testServiceARN), ServiceName: aws.String("web"), Status: aws.String("ACTIVE")},
+	}}, nil).Once()
+
+	resource, err := handler.GetResource(s.ctx, s.awsConfig, testServiceARN, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal(testServiceARN, resource.Metadata().ProviderAssignedID)
+	s.mockECS.AssertExpectations(s.T())
+}
+
+func (s *ECSHandlerTestSuite) TestServiceGetResource_EmptyResponse() {
+	handler := NewServiceHandler(s.awsConfig, s.opts...)
+	s.mockECS.On("DescribeServices", mock.Anything, mock.Anything).Return(&ecs.DescribeServicesOutput{}, nil).Once()
+
+	_, err := handler.GetResource(s.ctx, s.awsConfig, testServiceARN, s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *ECSHandlerTestSuite) TestTaskDefinitionListResources_DescribesLatestRevision() {
+	handler := NewTaskDefinitionHandler(s.awsConfig, s.opts...)
+	s.mockECS.On("ListTaskDefinitionFamilies", mock.Anything, mock.MatchedBy(func(in *ecs.ListTaskDefinitionFamiliesInput) bool {
+		return in.Status == ecstypes.TaskDefinitionFamilyStatusActive
+	})).Return(&ecs.ListTaskDefinitionFamiliesOutput{Families: []string{"web", "worker"}}, nil).Once()
+	s.mockECS.On("DescribeTaskDefinition", mock.Anything, mock.MatchedBy(func(in *ecs.DescribeTaskDefinitionInput) bool {
+		return aws.ToString(in.TaskDefinition) == "web" && len(in.Include) == 1
+	})).Return(&ecs.DescribeTaskDefinitionOutput{
+		TaskDefinition: &ecstypes.TaskDefinition{
+			Family:            aws.String("web"),
+			Revision:          7,
+			TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web:7"),
+			ContainerDefinitions: []ecstypes.ContainerDefinition{
+				{Name: aws.String("web"), Image: aws.String("nginx:1.27")},
+			},
+		},
+		Tags: []ecstypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}},
+	}, nil).Once()
+
+	resources, err := s.collect(func(out chan<- domain.PlatformResource) error {
+		return handler.ListResources(s.ctx, s.awsConfig, map[string]string{domain.KeyName: "web"}, s.mockLogger, out)
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("web", resources[0].Metadata().ProviderAssignedID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(7), attrs[domain.TaskDefinitionRevisionKey])
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	s.mockECS.AssertNotCalled(s.T(), "DescribeTaskDefinition", mock.Anything, mock.MatchedBy(func(in *ecs.DescribeTaskDefinitionInput) bool {
+		return aws.ToString(in.TaskDefinition) == "worker"
+	}))
+}
+
+func (s *ECSHandlerTestSuite) TestProbe() {
+	s.mockECS.On("ListClusters", mock.Anything, &ecs.ListClustersInput{MaxResults: aws.Int32(probePageSize)}).Return(&ecs.ListClustersOutput{}, nil).Once()
+	s.mockECS.On("ListTaskDefinitionFamilies", mock.Anything, &ecs.ListTaskDefinitionFamiliesInput{
+		Status:     ecstypes.TaskDefinitionFamilyStatusActive,
+		MaxResults: aws.Int32(probePageSize),
+	}).Return(&ecs.ListTaskDefinitionFamiliesOutput{}, nil).Once()
+
+	s.NoError(NewClusterHandler(s.awsConfig, s.opts...).Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.NoError(NewTaskDefinitionHandler(s.awsConfig, s.opts...).Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockECS.AssertExpectations(s.T())
+}
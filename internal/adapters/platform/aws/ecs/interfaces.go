@@ -0,0 +1,27 @@
+package ecs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+//go:generate mockery --name ECSClientInterface --output ./mocks --outpkg mocks --case underscore
+
+// ECSClientInterface defines the methods needed from the AWS SDK ECS client.
+// The list operations only return ARNs or family names, so clusters, services
+// and task definitions are described after listing, in batches where the API
+// allows it.
+type ECSClientInterface interface {
+	ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error)
+	DescribeClusters(ctx context.Context, params *ecs.DescribeClustersInput, optFns ...func(*ecs.Options)) (*ecs.DescribeClustersOutput, error)
+	ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error)
+	DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error)
+	ListTaskDefinitionFamilies(ctx context.Context, params *ecs.ListTaskDefinitionFamiliesInput, optFns ...func(*ecs.Options)) (*ecs.ListTaskDefinitionFamiliesOutput, error)
+	DescribeTaskDefinition(ctx context.Context, params *ecs.DescribeTaskDefinitionInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error)
+}
+
+type Cluster = ecstypes.Cluster               // Alias ecstypes.Cluster for easier use
+type Service = ecstypes.Service               // Alias ecstypes.Service for easier use
+type TaskDefinition = ecstypes.TaskDefinition // Alias ecstypes.TaskDefinition for easier use
@@ -0,0 +1,367 @@
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// ecsResource wraps an ECS cluster, service or task definition whose
+// attributes are mapped once when the resource is built.
+type ecsResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *ecsResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *ecsResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newClusterResource(cluster Cluster, region, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(cluster.ClusterArn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ECS cluster resource: missing cluster ARN")
+	}
+	return &ecsResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindContainerCluster,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   arn,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapClusterToAttributes(cluster),
+	}, nil
+}
+
+func newServiceResource(service Service, region, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(service.ServiceArn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ECS service resource: missing service ARN")
+	}
+	return &ecsResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindContainerService,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   arn,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapServiceToAttributes(service),
+	}, nil
+}
+
+// newTaskDefinitionResource wraps the latest revision of a task definition
+// family. The family is its ID, as in Terraform state.
+func newTaskDefinitionResource(taskDef TaskDefinition, tags map[string]string, region, accountID string) (domain.PlatformResource, error) {
+	family := aws.ToString(taskDef.Family)
+	if family == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ECS task definition resource: missing family")
+	}
+	attrs, err := mapTaskDefinitionToAttributes(taskDef, tags)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to map ECS task definition "+family)
+	}
+	return &ecsResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindContainerTaskDefinition,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: family,
+			SourceIdentifier:   aws.ToString(taskDef.TaskDefinitionArn),
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: attrs,
+	}, nil
+}
+
+// mapClusterToAttributes maps a cluster with its settings as a map of setting
+// name to value.
+func mapClusterToAttributes(cluster Cluster) map[string]any {
+	arn := aws.ToString(cluster.ClusterArn)
+	attrs := map[string]any{
+		domain.KeyID:   arn,
+		domain.KeyARN:  arn,
+		domain.KeyName: aws.ToString(cluster.ClusterName),
+	}
+	if len(cluster.Settings) > 0 {
+		settings := make(map[string]any, len(cluster.Settings))
+		for _, setting := range cluster.Settings {
+			settings[string(setting.Name)] = aws.ToString(setting.Value)
+		}
+		attrs[domain.ContainerClusterSettingsKey] = settings
+	}
+	if tags := tagsToMap(cluster.Tags); len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
+
+// mapServiceToAttributes maps a service. The cluster and task definition are
+// reduced from ARNs to the cluster name and "family:revision", and the
+// capacity provider strategy and load balancers are sorted.
+func mapServiceToAttributes(service Service) map[string]any {
+	arn := aws.ToString(service.ServiceArn)
+	attrs := map[string]any{
+		domain.KeyID:                                 arn,
+		domain.KeyARN:                                arn,
+		domain.KeyName:                               aws.ToString(service.ServiceName),
+		domain.ContainerServiceClusterKey:            clusterName(aws.ToString(service.ClusterArn)),
+		domain.ContainerServiceTaskDefinitionKey:     taskDefinitionRef(aws.ToString(service.TaskDefinition)),
+		domain.ContainerServiceDesiredCountKey:       int64(service.DesiredCount),
+		domain.ContainerServiceSchedulingStrategyKey: string(service.SchedulingStrategy),
+		domain.ContainerServiceExecuteCommandKey:     service.EnableExecuteCommand,
+	}
+	if service.LaunchType != "" {
+		attrs[domain.ContainerServiceLaunchTypeKey] = string(service.LaunchType)
+	}
+	if version := aws.ToString(service.PlatformVersion); version != "" {
+		attrs[domain.ContainerServicePlatformVersionKey] = version
+	}
+	if service.PropagateTags != "" {
+		attrs[domain.ContainerServicePropagateTagsKey] = string(service.PropagateTags)
+	}
+	if grace := aws.ToInt32(service.HealthCheckGracePeriodSeconds); grace > 0 {
+		attrs[domain.ContainerServiceHealthCheckGraceKey] = int64(grace)
+	}
+	if deployment := service.DeploymentConfiguration; deployment != nil {
+		if percent := deployment.MinimumHealthyPercent; percent != nil {
+			attrs[domain.ContainerServiceMinHealthyPercentKey] = int64(*percent)
+		}
+		if percent := deployment.MaximumPercent; percent != nil {
+			attrs[domain.ContainerServiceMaxPercentKey] = int64(*percent)
+		}
+	}
+	if network := service.NetworkConfiguration; network != nil && network.AwsvpcConfiguration != nil {
+		vpc := network.AwsvpcConfiguration
+		attrs[domain.ContainerServiceNetworkConfigurationKey] = map[string]any{
+			"subnets":          sortedStrings(vpc.Subnets),
+			"security_groups":  sortedStrings(vpc.SecurityGroups),
+			"assign_public_ip": vpc.AssignPublicIp == ecstypes.AssignPublicIpEnabled,
+		}
+	}
+	if len(service.CapacityProviderStrategy) > 0 {
+		strategy := make([]any, 0, len(service.CapacityProviderStrategy))
+		for _, item := range service.CapacityProviderStrategy {
+			strategy = append(strategy, map[string]any{
+				"capacity_provider": aws.ToString(item.CapacityProvider),
+				"weight":            int64(item.Weight),
+				"base":              int64(item.Base),
+			})
+		}
+		sortByField(strategy, "capacity_provider")
+		attrs[domain.ContainerServiceCapacityProvidersKey] = strategy
+	}
+	if len(service.LoadBalancers) > 0 {
+		loadBalancers := make([]any, 0, len(service.LoadBalancers))
+		for _, lb := range service.LoadBalancers {
+			block := map[string]any{
+				"container_name": aws.ToString(lb.ContainerName),
+				"container_port": int64(aws.ToInt32(lb.ContainerPort)),
+			}
+			if targetGroup := aws.ToString(lb.TargetGroupArn); targetGroup != "" {
+				block["target_group_arn"] = targetGroup
+			}
+			if elbName := aws.ToString(lb.LoadBalancerName); elbName != "" {
+				block["elb_name"] = elbName
+			}
+			loadBalancers = append(loadBalancers, block)
+		}
+		sortByField(loadBalancers, "target_group_arn")
+		attrs[domain.ContainerServiceLoadBalancersKey] = loadBalancers
+	}
+	if tags := tagsToMap(service.Tags); len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
+
+// mapTaskDefinitionToAttributes maps a task definition revision. The
+// container definitions are rendered as the JSON document Terraform records,
+// which the comparer normalizes.
+func mapTaskDefinitionToAttributes(taskDef TaskDefinition, tags map[string]string) (map[string]any, error) {
+	containers, err := containerDefinitionsJSON(taskDef.ContainerDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	family := aws.ToString(taskDef.Family)
+	attrs := map[string]any{
+		domain.KeyID:                     family,
+		domain.KeyName:                   family,
+		domain.KeyARN:                    aws.ToString(taskDef.TaskDefinitionArn),
+		domain.TaskDefinitionRevisionKey: int64(taskDef.Revision),
+		domain.TaskDefinitionContainerDefinitionsKey: containers,
+	}
+	if cpu := aws.ToString(taskDef.Cpu); cpu != "" {
+		attrs[domain.TaskDefinitionCPUKey] = cpu
+	}
+	if memory := aws.ToString(taskDef.Memory); memory != "" {
+		attrs[domain.TaskDefinitionMemoryKey] = memory
+	}
+	if taskDef.NetworkMode != "" {
+		attrs[domain.TaskDefinitionNetworkModeKey] = string(taskDef.NetworkMode)
+	}
+	if len(taskDef.RequiresCompatibilities) > 0 {
+		compatibilities := make([]string, 0, len(taskDef.RequiresCompatibilities))
+		for _, compatibility := range taskDef.RequiresCompatibilities {
+			compatibilities = append(compatibilities, string(compatibility))
+		}
+		attrs[domain.TaskDefinitionCompatibilitiesKey] = sortedStrings(compatibilities)
+	}
+	if role := aws.ToString(taskDef.ExecutionRoleArn); role != "" {
+		attrs[domain.TaskDefinitionExecutionRoleKey] = role
+	}
+	if role := aws.ToString(taskDef.TaskRoleArn); role != "" {
+		attrs[domain.TaskDefinitionTaskRoleKey] = role
+	}
+	if platform := taskDef.RuntimePlatform; platform != nil {
+		runtimePlatform := map[string]any{}
+		if platform.OperatingSystemFamily != "" {
+			runtimePlatform["operating_system_family"] = string(platform.OperatingSystemFamily)
+		}
+		if platform.CpuArchitecture != "" {
+			runtimePlatform["cpu_architecture"] = string(platform.CpuArchitecture)
+		}
+		if len(runtimePlatform) > 0 {
+			attrs[domain.TaskDefinitionRuntimePlatformKey] = runtimePlatform
+		}
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs, nil
+}
+
+// clusterName returns the cluster name of a cluster ARN, or the value itself
+// when it is already a name.
+func clusterName(cluster string) string {
+	if _, name, found := strings.Cut(cluster, ":cluster/"); found {
+		return name
+	}
+	return cluster
+}
+
+// taskDefinitionRef returns the "family:revision" of a task definition ARN, or
+// the value itself when it is not an ARN.
+func taskDefinitionRef(taskDefinition string) string {
+	if _, ref, found := strings.Cut(taskDefinition, ":task-definition/"); found {
+		return ref
+	}
+	return taskDefinition
+}
+
+// containerDefinitionsJSON renders container definitions with the field names
+// of the ECS API, which are the SDK field names starting in lower case.
+func containerDefinitionsJSON(definitions []ecstypes.ContainerDefinition) (string, error) {
+	if len(definitions) == 0 {
+		return "", nil
+	}
+	document := make([]any, 0, len(definitions))
+	for _, definition := range definitions {
+		document = append(document, documentValue(reflect.ValueOf(definition)))
+	}
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// documentValue converts an SDK value to plain maps, slices and scalars.
+// Nil pointers, slices and maps and unset enums become nil and are left out
+// of structs.
+func documentValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return documentValue(value.Elem())
+	case reflect.Struct:
+		fields := make(map[string]any, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if converted := documentValue(value.Field(i)); converted != nil {
+				fields[lowerFirst(field.Name)] = converted
+			}
+		}
+		return fields
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+		items := make([]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, documentValue(value.Index(i)))
+		}
+		return items
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		entries := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			entries[iter.Key().String()] = documentValue(iter.Value())
+		}
+		return entries
+	case reflect.String:
+		if value.Len() == 0 {
+			return nil
+		}
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint()
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	default:
+		return nil
+	}
+}
+
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortByField(blocks []any, field string) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		valueI, _ := blocks[i].(map[string]any)[field].(string)
+		valueJ, _ := blocks[j].(map[string]any)[field].(string)
+		return valueI < valueJ
+	})
+}
@@ -0,0 +1,125 @@
+package ecs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapServiceToAttributes(t *testing.T) {
+	service := ecstypes.Service{
+		ServiceArn:                    aws.String("arn:aws:ecs:us-east-1:123456789012:service/app/web"),
+		ServiceName:                   aws.String("web"),
+		ClusterArn:                    aws.String("arn:aws:ecs:us-east-1:123456789012:cluster/app"),
+		TaskDefinition:                aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web:7"),
+		DesiredCount:                  2,
+		SchedulingStrategy:            ecstypes.SchedulingStrategyReplica,
+		PropagateTags:                 ecstypes.PropagateTagsService,
+		HealthCheckGracePeriodSeconds: aws.Int32(60),
+		DeploymentConfiguration: &ecstypes.DeploymentConfiguration{
+			MinimumHealthyPercent: aws.Int32(100),
+			MaximumPercent:        aws.Int32(200),
+		},
+		NetworkConfiguration: &ecstypes.NetworkConfiguration{AwsvpcConfiguration: &ecstypes.AwsVpcConfiguration{
+			Subnets:        []string{"subnet-b", "subnet-a"},
+			SecurityGroups: []string{"sg-1"},
+			AssignPublicIp: ecstypes.AssignPublicIpDisabled,
+		}},
+		CapacityProviderStrategy: []ecstypes.CapacityProviderStrategyItem{
+			{CapacityProvider: aws.String("FARGATE_SPOT"), Weight: 3},
+			{CapacityProvider: aws.String("FARGATE"), Weight: 1, Base: 1},
+		},
+		LoadBalancers: []ecstypes.LoadBalancer{{
+			TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/web/abc"),
+			ContainerName:  aws.String("web"),
+			ContainerPort:  aws.Int32(8080),
+		}},
+	}
+
+	attrs := mapServiceToAttributes(service)
+
+	assert.Equal(t, "app", attrs[domain.ContainerServiceClusterKey])
+	assert.Equal(t, "web:7", attrs[domain.ContainerServiceTaskDefinitionKey])
+	assert.Equal(t, int64(2), attrs[domain.ContainerServiceDesiredCountKey])
+	assert.Equal(t, "REPLICA", attrs[domain.ContainerServiceSchedulingStrategyKey])
+	assert.Equal(t, "SERVICE", attrs[domain.ContainerServicePropagateTagsKey])
+	assert.Equal(t, int64(60), attrs[domain.ContainerServiceHealthCheckGraceKey])
+	assert.Equal(t, int64(100), attrs[domain.ContainerServiceMinHealthyPercentKey])
+	assert.Equal(t, int64(200), attrs[domain.ContainerServiceMaxPercentKey])
+	assert.Equal(t, map[string]any{
+		"subnets":          []string{"subnet-a", "subnet-b"},
+		"security_groups":  []string{"sg-1"},
+		"assign_public_ip": false,
+	}, attrs[domain.ContainerServiceNetworkConfigurationKey])
+	assert.Equal(t, []any{
+		map[string]any{"capacity_provider": "FARGATE", "weight": int64(1), "base": int64(1)},
+		map[string]any{"capacity_provider": "FARGATE_SPOT", "weight": int64(3), "base": int64(0)},
+	}, attrs[domain.ContainerServiceCapacityProvidersKey])
+	assert.Equal(t, []any{map[string]any{
+		"target_group_arn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/web/abc",
+		"container_name":   "web",
+		"container_port":   int64(8080),
+	}}, attrs[domain.ContainerServiceLoadBalancersKey])
+	assert.NotContains(t, attrs, domain.ContainerServiceLaunchTypeKey)
+}
+
+func TestMapTaskDefinitionToAttributes(t *testing.T) {
+	taskDef := ecstypes.TaskDefinition{
+		Family:                  aws.String("web"),
+		Revision:                7,
+		TaskDefinitionArn:       aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web:7"),
+		Cpu:                     aws.String("256"),
+		Memory:                  aws.String("512"),
+		NetworkMode:             ecstypes.NetworkModeAwsvpc,
+		RequiresCompatibilities: []ecstypes.Compatibility{ecstypes.CompatibilityFargate, ecstypes.CompatibilityEc2},
+		ExecutionRoleArn:        aws.String("arn:aws:iam::123456789012:role/exec"),
+		RuntimePlatform: &ecstypes.RuntimePlatform{
+			OperatingSystemFamily: ecstypes.OSFamilyLinux,
+			CpuArchitecture:       ecstypes.CPUArchitectureArm64,
+		},
+		ContainerDefinitions: []ecstypes.ContainerDefinition{{
+			Name:      aws.String("web"),
+			Image:     aws.String("nginx:1.27"),
+			Essential: aws.Bool(true),
+			PortMappings: []ecstypes.PortMapping{
+				{ContainerPort: aws.Int32(80), Protocol: ecstypes.TransportProtocolTcp},
+			},
+			Environment:  []ecstypes.KeyValuePair{{Name: aws.String("MODE"), Value: aws.String("prod")}},
+			DockerLabels: map[string]string{"team": "edge"},
+		}},
+	}
+
+	attrs, err := mapTaskDefinitionToAttributes(taskDef, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "web", attrs[domain.KeyID])
+	assert.Equal(t, int64(7), attrs[domain.TaskDefinitionRevisionKey])
+	assert.Equal(t, "256", attrs[domain.TaskDefinitionCPUKey])
+	assert.Equal(t, "awsvpc", attrs[domain.TaskDefinitionNetworkModeKey])
+	assert.Equal(t, []string{"EC2", "FARGATE"}, attrs[domain.TaskDefinitionCompatibilitiesKey])
+	assert.Equal(t, map[string]any{"operating_system_family": "LINUX", "cpu_architecture": "ARM64"}, attrs[domain.TaskDefinitionRuntimePlatformKey])
+	assert.NotContains(t, attrs, domain.KeyTags)
+
+	var containers []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(attrs[domain.TaskDefinitionContainerDefinitionsKey].(string)), &containers))
+	require.Len(t, containers, 1)
+	assert.Equal(t, "web", containers[0]["name"])
+	assert.Equal(t, "nginx:1.27", containers[0]["image"])
+	assert.Equal(t, true, containers[0]["essential"])
+	assert.Equal(t, []any{map[string]any{"containerPort": float64(80), "protocol": "tcp"}}, containers[0]["portMappings"])
+	assert.Equal(t, []any{map[string]any{"name": "MODE", "value": "prod"}}, containers[0]["environment"])
+	assert.Equal(t, map[string]any{"team": "edge"}, containers[0]["dockerLabels"])
+	assert.NotContains(t, containers[0], "memory", "nil pointers are left out")
+}
+
+func TestClusterFromServiceARN(t *testing.T) {
+	assert.Equal(t, "app", clusterFromServiceARN("arn:aws:ecs:us-east-1:123456789012:service/app/web"))
+	assert.Equal(t, defaultCluster, clusterFromServiceARN("arn:aws:ecs:us-east-1:123456789012:service/web"))
+	assert.Equal(t, defaultCluster, clusterFromServiceARN("web"))
+}
@@ -0,0 +1,191 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// defaultCluster is the cluster ECS assumes when none is given.
+	defaultCluster = "default"
+	// inactiveStatus marks deleted clusters and services that ECS still describes.
+	inactiveStatus = "INACTIVE"
+)
+
+// ServiceHandler lists the services of ECS clusters together with their tags.
+// Running tasks and deployments are not part of the comparison.
+type ServiceHandler struct {
+	*baseHandler
+}
+
+// NewServiceHandler creates a new ServiceHandler with the given AWS config and optional configurations.
+func NewServiceHandler(cfg aws.Config, opts ...HandlerOption) *ServiceHandler {
+	return &ServiceHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *ServiceHandler) Kind() domain.ResourceKind {
+	return domain.KindContainerService
+}
+
+// ListResources lists the services of the clusters named by the cluster
+// filter, or of every cluster of the region when the filter is not set.
+// Services are described in batches and the ID, name and tag filters are
+// applied to the described services.
+func (h *ServiceHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS service ListResources: %v", accErr)
+	}
+
+	clusters := splitValues(filters[domain.ContainerServiceClusterKey])
+	if len(clusters) == 0 {
+		arns, err := h.clusterARNs(ctx, logger)
+		if err != nil {
+			return err
+		}
+		clusters = arns
+	}
+
+	logger.Debugf(ctx, "Listing ECS services of %d clusters", len(clusters))
+	for _, cluster := range clusters {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		arns, err := h.serviceARNs(ctx, cluster, logger)
+		if err != nil {
+			return err
+		}
+		for start := 0; start < len(arns); start += maxDescribeServices {
+			end := min(start+maxDescribeServices, len(arns))
+			services, err := h.describeServices(ctx, cluster, arns[start:end], logger)
+			if err != nil {
+				return err
+			}
+			for _, service := range services {
+				arn := aws.ToString(service.ServiceArn)
+				if !matchesNameFilters(filters, arn, aws.ToString(service.ServiceName)) || !matchesTagFilters(tagsToMap(service.Tags), filters) {
+					continue
+				}
+				resource, mapErr := newServiceResource(service, cfg.Region, accountID)
+				if mapErr != nil {
+					logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ECS service %s, skipping", arn)
+					continue
+				}
+				if err := h.send(ctx, resource, out, logger); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	logger.Debugf(ctx, "Finished ECS service listing.")
+	return nil
+}
+
+// GetResource fetches a service by ARN. The cluster is taken from the ARN, or
+// is the default cluster for ARNs in the old format without a cluster name.
+func (h *ServiceHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ECS service %s", id)
+	services, err := h.describeServices(ctx, clusterFromServiceARN(id), []string{id}, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, notFound("service", id)
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS service GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newServiceResource(services[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ECS service %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that clusters, whose services are listed, can be listed with
+// a single minimal page.
+func (h *ServiceHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.ecsClient.ListClusters(ctx, &ecs.ListClustersInput{MaxResults: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("ECS", "ListClusters", err, ctx)
+	}
+	return nil
+}
+
+func (h *ServiceHandler) serviceARNs(ctx context.Context, cluster string, logger ports.Logger) ([]string, error) {
+	var arns []string
+	input := &ecs.ListServicesInput{Cluster: aws.String(cluster), MaxResults: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.ecsClient.ListServices(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ECS", "ListServices", err, ctx)
+		}
+		arns = append(arns, output.ServiceArns...)
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return arns, nil
+}
+
+// describeServices describes services of a cluster with their tags. Inactive
+// services are left out.
+func (h *ServiceHandler) describeServices(ctx context.Context, cluster string, services []string, logger ports.Logger) ([]Service, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.ecsClient.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: services,
+		Include:  []ecstypes.ServiceField{ecstypes.ServiceFieldTags},
+	})
+	if err != nil {
+		return nil, h.errorHandler.Handle("ECS", "DescribeServices", err, ctx)
+	}
+	active := make([]Service, 0, len(output.Services))
+	for _, service := range output.Services {
+		if aws.ToString(service.Status) == inactiveStatus {
+			continue
+		}
+		active = append(active, service)
+	}
+	return active, nil
+}
+
+// clusterFromServiceARN returns the cluster of a service ARN in the format
+// arn:aws:ecs:<region>:<account>:service/<cluster>/<service>.
+func clusterFromServiceARN(arn string) string {
+	_, resource, found := strings.Cut(arn, ":service/")
+	if !found {
+		return defaultCluster
+	}
+	cluster, _, hasService := strings.Cut(resource, "/")
+	if !hasService || cluster == "" {
+		return defaultCluster
+	}
+	return cluster
+}
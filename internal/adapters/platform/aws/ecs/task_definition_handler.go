@@ -0,0 +1,164 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// TaskDefinitionHandler lists task definition families with their latest
+// active revision. Terraform identifies an aws_ecs_task_definition by its
+// family, so a revision registered outside Terraform shows as drift.
+type TaskDefinitionHandler struct {
+	*baseHandler
+}
+
+// NewTaskDefinitionHandler creates a new TaskDefinitionHandler with the given AWS config and optional configurations.
+func NewTaskDefinitionHandler(cfg aws.Config, opts ...HandlerOption) *TaskDefinitionHandler {
+	return &TaskDefinitionHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *TaskDefinitionHandler) Kind() domain.ResourceKind {
+	return domain.KindContainerTaskDefinition
+}
+
+// ListResources lists the active task definition families and describes the
+// latest revision of those matching the ID and name filters, which both match
+// the family. Tag filters are applied to the described task definitions.
+func (h *TaskDefinitionHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS task definition ListResources: %v", accErr)
+	}
+
+	families, err := h.families(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(ctx, "Found %d active ECS task definition families", len(families))
+	for _, family := range families {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !matchesNameFilters(filters, family, family) {
+			continue
+		}
+		taskDef, tags, err := h.describeTaskDefinition(ctx, family, logger)
+		if err != nil {
+			if errors.Is(err, errors.CodeResourceNotFound) {
+				logger.Debugf(ctx, "ECS task definition family %s disappeared during listing, skipping", family)
+				continue
+			}
+			return err
+		}
+		if !matchesTagFilters(tags, filters) {
+			continue
+		}
+		resource, mapErr := newTaskDefinitionResource(*taskDef, tags, cfg.Region, accountID)
+		if mapErr != nil {
+			logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ECS task definition %s, skipping", family)
+			continue
+		}
+		if err := h.send(ctx, resource, out, logger); err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf(ctx, "Finished ECS task definition listing.")
+	return nil
+}
+
+// GetResource fetches the latest active revision of a family. A
+// "family:revision" or task definition ARN fetches that revision instead.
+func (h *TaskDefinitionHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ECS task definition %s", id)
+	taskDef, tags, err := h.describeTaskDefinition(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ECS task definition GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newTaskDefinitionResource(*taskDef, tags, cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ECS task definition %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that task definition families can be listed with a single
+// minimal page.
+func (h *TaskDefinitionHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	input := &ecs.ListTaskDefinitionFamiliesInput{
+		Status:     ecstypes.TaskDefinitionFamilyStatusActive,
+		MaxResults: aws.Int32(probePageSize),
+	}
+	if _, err := h.ecsClient.ListTaskDefinitionFamilies(ctx, input); err != nil {
+		return h.errorHandler.Handle("ECS", "ListTaskDefinitionFamilies", err, ctx)
+	}
+	return nil
+}
+
+func (h *TaskDefinitionHandler) families(ctx context.Context, logger ports.Logger) ([]string, error) {
+	var families []string
+	input := &ecs.ListTaskDefinitionFamiliesInput{
+		Status:     ecstypes.TaskDefinitionFamilyStatusActive,
+		MaxResults: aws.Int32(listPageSize),
+	}
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.ecsClient.ListTaskDefinitionFamilies(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ECS", "ListTaskDefinitionFamilies", err, ctx)
+		}
+		families = append(families, output.Families...)
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return families, nil
+}
+
+// describeTaskDefinition describes a task definition with its tags, which
+// DescribeTaskDefinition returns beside the task definition.
+func (h *TaskDefinitionHandler) describeTaskDefinition(ctx context.Context, taskDefinition string, logger ports.Logger) (*TaskDefinition, map[string]string, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, nil, err
+	}
+	output, err := h.ecsClient.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(taskDefinition),
+		Include:        []ecstypes.TaskDefinitionField{ecstypes.TaskDefinitionFieldTags},
+	})
+	if err != nil {
+		return nil, nil, h.errorHandler.Handle("ECS", "DescribeTaskDefinition", err, ctx)
+	}
+	if output == nil || output.TaskDefinition == nil {
+		return nil, nil, notFound("task definition", taskDefinition)
+	}
+	return output.TaskDefinition, tagsToMap(output.Tags), nil
+}
@@ -0,0 +1,205 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+	// maxTagResources is the number of ARNs DescribeTags accepts per call.
+	maxTagResources = 20
+)
+
+// baseHandler holds the clients shared by the load balancer, listener and
+// target group handlers.
+type baseHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	elbClient    ELBv2ClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the ELBv2 handlers.
+type HandlerOption func(*baseHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithELBv2Client provides an option to set a custom Elastic Load Balancing v2 client.
+func WithELBv2Client(client ELBv2ClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.elbClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *baseHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *baseHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+func newBaseHandler(cfg aws.Config, opts []HandlerOption) *baseHandler {
+	h := &baseHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		elbClient:    elasticloadbalancingv2.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *baseHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "ELBv2: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+func (h *baseHandler) send(ctx context.Context, resource domain.PlatformResource, out chan<- domain.PlatformResource, logger ports.Logger) error {
+	select {
+	case out <- resource:
+		return nil
+	case <-ctx.Done():
+		logger.Warnf(ctx, "Context cancelled while sending ELBv2 resource %s", resource.Metadata().ProviderAssignedID)
+		return ctx.Err()
+	}
+}
+
+// describeTags returns the tags of the given resources by ARN. Resources
+// without tags are present with an empty map.
+func (h *baseHandler) describeTags(ctx context.Context, arns []string, logger ports.Logger) (map[string]map[string]string, error) {
+	tagsByARN := make(map[string]map[string]string, len(arns))
+	for start := 0; start < len(arns); start += maxTagResources {
+		end := min(start+maxTagResources, len(arns))
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.elbClient.DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: arns[start:end]})
+		if err != nil {
+			return nil, h.errorHandler.Handle("ELBv2", "DescribeTags", err, ctx)
+		}
+		for _, description := range output.TagDescriptions {
+			tags := make(map[string]string, len(description.Tags))
+			for _, tag := range description.Tags {
+				if tag.Key != nil {
+					tags[*tag.Key] = aws.ToString(tag.Value)
+				}
+			}
+			tagsByARN[aws.ToString(description.ResourceArn)] = tags
+		}
+	}
+	return tagsByARN, nil
+}
+
+// attributesToMap flattens load balancer or target group attributes by key.
+func attributesToMap[T any](attributes []T, keyValue func(T) (*string, *string)) map[string]string {
+	result := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		key, value := keyValue(attribute)
+		if key != nil {
+			result[*key] = aws.ToString(value)
+		}
+	}
+	return result
+}
+
+// matchesNameFilters applies the ID and name filters to a load balancer or
+// target group. Comma separated values match any of the values.
+func matchesNameFilters(filters map[string]string, arn, name string) bool {
+	if value, ok := filters[domain.KeyID]; ok && !containsValue(value, arn) {
+		return false
+	}
+	if value, ok := filters[domain.KeyName]; ok && !containsValue(value, name) {
+		return false
+	}
+	return true
+}
+
+func matchesTagFilters(tags map[string]string, filters map[string]string) bool {
+	for key, value := range filters {
+		if !strings.HasPrefix(key, domain.TagPrefix) {
+			continue
+		}
+		actual, ok := tags[strings.TrimPrefix(key, domain.TagPrefix)]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
+
+func notFound(kind, id string) error {
+	return errors.New(errors.CodeResourceNotFound, fmt.Sprintf("ELBv2 %s '%s' not found (empty response)", kind, id))
+}
@@ -0,0 +1,241 @@
+package elbv2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	elbv2mocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/elbv2/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type ELBv2HandlerTestSuite struct {
+	suite.Suite
+	mockELB          *elbv2mocks.ELBv2ClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	opts             []HandlerOption
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *ELBv2HandlerTestSuite) SetupTest() {
+	s.mockELB = new(elbv2mocks.ELBv2ClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.opts = []HandlerOption{
+		WithSTSClient(s.mockSTS),
+		WithELBv2Client(s.mockELB),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	}
+}
+
+func (s *ELBv2HandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestELBv2HandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ELBv2HandlerTestSuite))
+}
+
+func lbARN(name string) string {
+	return "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/" + name + "/50dc6c495c0c9188"
+}
+
+func (s *ELBv2HandlerTestSuite) expectTags(tagsByARN map[string]map[string]string, arns ...string) {
+	descriptions := make([]elbv2types.TagDescription, 0, len(arns))
+	for _, arn := range arns {
+		tags := make([]elbv2types.Tag, 0, len(tagsByARN[arn]))
+		for k, v := range tagsByARN[arn] {
+			tags = append(tags, elbv2types.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		descriptions = append(descriptions, elbv2types.TagDescription{ResourceArn: aws.String(arn), Tags: tags})
+	}
+	s.mockELB.On("DescribeTags", mock.Anything, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: arns}).
+		Return(&elasticloadbalancingv2.DescribeTagsOutput{TagDescriptions: descriptions}, nil).Once()
+}
+
+func (s *ELBv2HandlerTestSuite) expectLoadBalancerAttributes(arn string) {
+	s.mockELB.On("DescribeLoadBalancerAttributes", mock.Anything, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(arn)}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancerAttributesOutput{Attributes: []elbv2types.LoadBalancerAttribute{
+			{Key: aws.String(attrDeletionProtection), Value: aws.String("true")},
+		}}, nil).Once()
+}
+
+type listFunc func(context.Context, aws.Config, map[string]string, ports.Logger, chan<- domain.PlatformResource) error
+
+func (s *ELBv2HandlerTestSuite) collect(list listFunc, filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := list(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *ELBv2HandlerTestSuite) TestKinds() {
+	s.Equal(domain.KindLoadBalancer, NewLoadBalancerHandler(s.awsConfig, s.opts...).Kind())
+	s.Equal(domain.KindLoadBalancerListener, NewListenerHandler(s.awsConfig, s.opts...).Kind())
+	s.Equal(domain.KindLoadBalancerTargetGroup, NewTargetGroupHandler(s.awsConfig, s.opts...).Kind())
+}
+
+func (s *ELBv2HandlerTestSuite) TestLoadBalancers_PaginatesAndFilters() {
+	s.mockELB.On("DescribeLoadBalancers", mock.Anything, mock.MatchedBy(func(in *elasticloadbalancingv2.DescribeLoadBalancersInput) bool {
+		return in.Marker == nil
+	})).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []elbv2types.LoadBalancer{
+			{LoadBalancerArn: aws.String(lbARN("web")), LoadBalancerName: aws.String("web"), Type: elbv2types.LoadBalancerTypeEnumApplication},
+			{LoadBalancerArn: aws.String(lbARN("edge")), LoadBalancerName: aws.String("edge"), Type: elbv2types.LoadBalancerTypeEnumNetwork},
+		},
+		NextMarker: aws.String("page2"),
+	}, nil).Once()
+	s.mockELB.On("DescribeLoadBalancers", mock.Anything, mock.MatchedBy(func(in *elasticloadbalancingv2.DescribeLoadBalancersInput) bool {
+		return aws.ToString(in.Marker) == "page2"
+	})).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []elbv2types.LoadBalancer{
+			{LoadBalancerArn: aws.String(lbARN("web-dev")), LoadBalancerName: aws.String("web-dev"), Type: elbv2types.LoadBalancerTypeEnumApplication},
+		},
+	}, nil).Once()
+	tags := map[string]map[string]string{
+		lbARN("web"):     {"Env": "prod"},
+		lbARN("web-dev"): {"Env": "dev"},
+	}
+	s.expectTags(tags, lbARN("web"))
+	s.expectTags(tags, lbARN("web-dev"))
+	s.expectLoadBalancerAttributes(lbARN("web"))
+
+	handler := NewLoadBalancerHandler(s.awsConfig, s.opts...)
+	resources, err := s.collect(handler.ListResources, map[string]string{
+		domain.LoadBalancerTypeKey: "application",
+		"tag:Env":                  "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal(lbARN("web"), resources[0].Metadata().ProviderAssignedID)
+	s.Equal("web", resources[0].Metadata().SourceIdentifier)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(true, attrs[domain.LoadBalancerDeletionProtectionKey])
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	// edge is excluded by type before its tags are listed, and web-dev by tag
+	// before its attributes are described.
+	s.mockELB.AssertNotCalled(s.T(), "DescribeLoadBalancerAttributes", mock.Anything, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(lbARN("web-dev"))})
+	s.mockELB.AssertExpectations(s.T())
+}
+
+func (s *ELBv2HandlerTestSuite) TestLoadBalancers_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockELB.On("DescribeLoadBalancers", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "ELBv2", "DescribeLoadBalancers:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	handler := NewLoadBalancerHandler(s.awsConfig, s.opts...)
+	resources, err := s.collect(handler.ListResources, nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *ELBv2HandlerTestSuite) TestListeners_WithRules() {
+	listenerARN := "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	s.mockELB.On("DescribeListeners", mock.Anything, mock.MatchedBy(func(in *elasticloadbalancingv2.DescribeListenersInput) bool {
+		return aws.ToString(in.LoadBalancerArn) == lbARN("web")
+	})).Return(&elasticloadbalancingv2.DescribeListenersOutput{Listeners: []elbv2types.Listener{
+		{ListenerArn: aws.String(listenerARN), LoadBalancerArn: aws.String(lbARN("web")), Port: aws.Int32(443), Protocol: elbv2types.ProtocolEnumHttps},
+	}}, nil).Once()
+	s.expectTags(nil, listenerARN)
+	s.mockELB.On("DescribeRules", mock.Anything, mock.MatchedBy(func(in *elasticloadbalancingv2.DescribeRulesInput) bool {
+		return aws.ToString(in.ListenerArn) == listenerARN
+	})).Return(&elasticloadbalancingv2.DescribeRulesOutput{Rules: []elbv2types.Rule{
+		{IsDefault: aws.Bool(true), Priority: aws.String("default")},
+		{Priority: aws.String("10"), Actions: []elbv2types.Action{{Type: elbv2types.ActionTypeEnumForward, TargetGroupArn: aws.String("arn:tg/api")}}},
+	}}, nil).Once()
+
+	handler := NewListenerHandler(s.awsConfig, s.opts...)
+	resources, err := s.collect(handler.ListResources, map[string]string{domain.ListenerLoadBalancerARNKey: lbARN("web")})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal(listenerARN, resources[0].Metadata().ProviderAssignedID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Len(attrs[domain.ListenerRulesKey], 1)
+	s.mockELB.AssertNotCalled(s.T(), "DescribeLoadBalancers", mock.Anything, mock.Anything)
+	s.mockELB.AssertExpectations(s.T())
+}
+
+func (s *ELBv2HandlerTestSuite) TestTargetGroups_GetResourceByName() {
+	groupARN := "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/73e2d6bc24d8a067"
+	s.mockELB.On("DescribeTargetGroups", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupsInput{Names: []string{"api"}}).
+		Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{TargetGroups: []elbv2types.TargetGroup{
+			{TargetGroupArn: aws.String(groupARN), TargetGroupName: aws.String("api"), TargetType: elbv2types.TargetTypeEnumIp},
+		}}, nil).Once()
+	s.expectTags(nil, groupARN)
+	s.mockELB.On("DescribeTargetGroupAttributes", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupAttributesInput{TargetGroupArn: aws.String(groupARN)}).
+		Return(&elasticloadbalancingv2.DescribeTargetGroupAttributesOutput{Attributes: []elbv2types.TargetGroupAttribute{
+			{Key: aws.String(attrDeregistrationDelay), Value: aws.String("30")},
+		}}, nil).Once()
+
+	handler := NewTargetGroupHandler(s.awsConfig, s.opts...)
+	resource, err := handler.GetResource(s.ctx, s.awsConfig, "api", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal(groupARN, resource.Metadata().ProviderAssignedID)
+	s.Equal(domain.KindLoadBalancerTargetGroup, resource.Metadata().Kind)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(30), attrs[domain.TargetGroupDeregistrationDelayKey])
+	s.mockELB.AssertExpectations(s.T())
+}
+
+func (s *ELBv2HandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockELB.On("DescribeLoadBalancers", mock.Anything, mock.Anything).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{}, nil).Once()
+
+	_, err := NewLoadBalancerHandler(s.awsConfig, s.opts...).GetResource(s.ctx, s.awsConfig, lbARN("missing"), s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *ELBv2HandlerTestSuite) TestProbe() {
+	s.mockELB.On("DescribeTargetGroups", mock.Anything, &elasticloadbalancingv2.DescribeTargetGroupsInput{PageSize: aws.Int32(probePageSize)}).
+		Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{}, nil).Once()
+
+	s.NoError(NewTargetGroupHandler(s.awsConfig, s.opts...).Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockELB.AssertExpectations(s.T())
+}
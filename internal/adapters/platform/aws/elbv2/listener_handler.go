@@ -0,0 +1,230 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// ListenerHandler lists the listeners of all load balancers together with
+// their tags and, for HTTP and HTTPS listeners, their rules.
+type ListenerHandler struct {
+	*baseHandler
+}
+
+// NewListenerHandler creates a new ListenerHandler with the given AWS config and optional configurations.
+func NewListenerHandler(cfg aws.Config, opts ...HandlerOption) *ListenerHandler {
+	return &ListenerHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *ListenerHandler) Kind() domain.ResourceKind {
+	return domain.KindLoadBalancerListener
+}
+
+// ListResources lists the listeners of the load balancers named by the
+// load_balancer_arn filter, or of every load balancer of the region when the
+// filter is not set. Rules are only described for listeners passing the ID
+// and tag filters.
+func (h *ListenerHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 listener ListResources: %v", accErr)
+	}
+
+	lbARNs, err := h.loadBalancerARNs(ctx, filters, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf(ctx, "Listing ELBv2 listeners of %d load balancers", len(lbARNs))
+	for _, lbARN := range lbARNs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		listeners, err := h.describeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbARN)}, logger)
+		if err != nil {
+			return err
+		}
+
+		var candidates []Listener
+		var arns []string
+		for _, listener := range listeners {
+			arn := aws.ToString(listener.ListenerArn)
+			if value, ok := filters[domain.KeyID]; ok && !containsValue(value, arn) {
+				continue
+			}
+			candidates = append(candidates, listener)
+			arns = append(arns, arn)
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		tagsByARN, err := h.describeTags(ctx, arns, logger)
+		if err != nil {
+			return err
+		}
+		for _, listener := range candidates {
+			arn := aws.ToString(listener.ListenerArn)
+			tags := tagsByARN[arn]
+			if !matchesTagFilters(tags, filters) {
+				continue
+			}
+			rules, err := h.listenerRules(ctx, listener, logger)
+			if err != nil {
+				return err
+			}
+			resource, mapErr := newListenerResource(listener, rules, tags, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ELBv2 listener %s, skipping", arn)
+				continue
+			}
+			if err := h.send(ctx, resource, out, logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Debugf(ctx, "Finished ELBv2 listener listing.")
+	return nil
+}
+
+func (h *ListenerHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ELBv2 listener %s", id)
+	listeners, err := h.describeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{ListenerArns: []string{id}}, logger)
+	if err != nil {
+		return nil, err
+	}
+	if len(listeners) == 0 {
+		return nil, notFound("listener", id)
+	}
+	listener := listeners[0]
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 listener GetResource: %v", accErr)
+	}
+
+	tagsByARN, err := h.describeTags(ctx, []string{id}, logger)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := h.listenerRules(ctx, listener, logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, mapErr := newListenerResource(listener, rules, tagsByARN[id], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ELBv2 listener %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that load balancers, whose listeners are listed, can be
+// described with a single minimal page.
+func (h *ListenerHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.elbClient.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{PageSize: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("ELBv2", "DescribeLoadBalancers", err, ctx)
+	}
+	return nil
+}
+
+// loadBalancerARNs returns the ARNs of the load balancers whose listeners are
+// listed: those of the load_balancer_arn filter, or all of the region.
+func (h *ListenerHandler) loadBalancerARNs(ctx context.Context, filters map[string]string, logger ports.Logger) ([]string, error) {
+	if value, ok := filters[domain.ListenerLoadBalancerARNKey]; ok && value != "" {
+		var arns []string
+		for _, arn := range strings.Split(value, ",") {
+			if arn = strings.TrimSpace(arn); arn != "" {
+				arns = append(arns, arn)
+			}
+		}
+		return arns, nil
+	}
+
+	var arns []string
+	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{PageSize: aws.Int32(listPageSize)}
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.elbClient.DescribeLoadBalancers(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ELBv2", fmt.Sprintf("DescribeLoadBalancers:Page%d", pageNum), err, ctx)
+		}
+		for _, lb := range output.LoadBalancers {
+			arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+		}
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+	return arns, nil
+}
+
+func (h *ListenerHandler) describeListeners(ctx context.Context, input *elasticloadbalancingv2.DescribeListenersInput, logger ports.Logger) ([]Listener, error) {
+	var listeners []Listener
+	input.PageSize = aws.Int32(listPageSize)
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.elbClient.DescribeListeners(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ELBv2", "DescribeListeners", err, ctx)
+		}
+		listeners = append(listeners, output.Listeners...)
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+	return listeners, nil
+}
+
+// listenerRules returns the rules of HTTP and HTTPS listeners, the only
+// listeners with rules besides the default one.
+func (h *ListenerHandler) listenerRules(ctx context.Context, listener Listener, logger ports.Logger) ([]Rule, error) {
+	if listener.Protocol != elbv2types.ProtocolEnumHttp && listener.Protocol != elbv2types.ProtocolEnumHttps {
+		return nil, nil
+	}
+	var rules []Rule
+	input := &elasticloadbalancingv2.DescribeRulesInput{ListenerArn: listener.ListenerArn, PageSize: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.elbClient.DescribeRules(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("ELBv2", "DescribeRules", err, ctx)
+		}
+		rules = append(rules, output.Rules...)
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+	return rules, nil
+}
@@ -0,0 +1,176 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// LoadBalancerHandler lists application, network and gateway load balancers
+// together with their tags and attributes.
+type LoadBalancerHandler struct {
+	*baseHandler
+}
+
+// NewLoadBalancerHandler creates a new LoadBalancerHandler with the given AWS config and optional configurations.
+func NewLoadBalancerHandler(cfg aws.Config, opts ...HandlerOption) *LoadBalancerHandler {
+	return &LoadBalancerHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *LoadBalancerHandler) Kind() domain.ResourceKind {
+	return domain.KindLoadBalancer
+}
+
+// ListResources lists the load balancers of the region. The ID, name and type
+// filters are applied to the described load balancers, tag filters after
+// listing the tags of a page, and attributes are only fetched for the load
+// balancers that pass all filters.
+func (h *LoadBalancerHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 load balancer ListResources: %v", accErr)
+	}
+
+	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{PageSize: aws.Int32(listPageSize)}
+
+	logger.Debugf(ctx, "Starting ELBv2 load balancer listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.elbClient.DescribeLoadBalancers(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("ELBv2", fmt.Sprintf("DescribeLoadBalancers:Page%d", pageNum), err, ctx)
+		}
+
+		var candidates []LoadBalancer
+		var arns []string
+		for _, lb := range output.LoadBalancers {
+			arn := aws.ToString(lb.LoadBalancerArn)
+			if !matchesNameFilters(filters, arn, aws.ToString(lb.LoadBalancerName)) {
+				continue
+			}
+			if value, ok := filters[domain.LoadBalancerTypeKey]; ok && !containsValue(value, string(lb.Type)) {
+				continue
+			}
+			candidates = append(candidates, lb)
+			arns = append(arns, arn)
+		}
+
+		if len(candidates) > 0 {
+			tagsByARN, err := h.describeTags(ctx, arns, logger)
+			if err != nil {
+				return err
+			}
+			for _, lb := range candidates {
+				arn := aws.ToString(lb.LoadBalancerArn)
+				tags := tagsByARN[arn]
+				if !matchesTagFilters(tags, filters) {
+					continue
+				}
+				attributes, err := h.loadBalancerAttributes(ctx, arn, logger)
+				if err != nil {
+					return err
+				}
+				resource, mapErr := newLoadBalancerResource(lb, attributes, tags, cfg.Region, accountID)
+				if mapErr != nil {
+					logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ELBv2 load balancer %s, skipping", arn)
+					continue
+				}
+				if err := h.send(ctx, resource, out, logger); err != nil {
+					return err
+				}
+			}
+		}
+
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	logger.Debugf(ctx, "Finished ELBv2 load balancer pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a load balancer by ARN, or by name when id is not an ARN.
+func (h *LoadBalancerHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ELBv2 load balancer %s", id)
+	input := &elasticloadbalancingv2.DescribeLoadBalancersInput{}
+	if strings.HasPrefix(id, "arn:") {
+		input.LoadBalancerArns = []string{id}
+	} else {
+		input.Names = []string{id}
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.elbClient.DescribeLoadBalancers(ctx, input)
+	if err != nil {
+		return nil, h.errorHandler.Handle("ELBv2", "DescribeLoadBalancers", err, ctx)
+	}
+	if len(output.LoadBalancers) == 0 {
+		return nil, notFound("load balancer", id)
+	}
+	lb := output.LoadBalancers[0]
+	arn := aws.ToString(lb.LoadBalancerArn)
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 load balancer GetResource: %v", accErr)
+	}
+
+	tagsByARN, err := h.describeTags(ctx, []string{arn}, logger)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := h.loadBalancerAttributes(ctx, arn, logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, mapErr := newLoadBalancerResource(lb, attributes, tagsByARN[arn], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ELBv2 load balancer %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that load balancers can be described with a single minimal page.
+func (h *LoadBalancerHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.elbClient.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{PageSize: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("ELBv2", "DescribeLoadBalancers", err, ctx)
+	}
+	return nil
+}
+
+func (h *LoadBalancerHandler) loadBalancerAttributes(ctx context.Context, arn string, logger ports.Logger) (map[string]string, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.elbClient.DescribeLoadBalancerAttributes(ctx, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(arn)})
+	if err != nil {
+		return nil, h.errorHandler.Handle("ELBv2", "DescribeLoadBalancerAttributes", err, ctx)
+	}
+	return attributesToMap(output.Attributes, func(a elbv2types.LoadBalancerAttribute) (*string, *string) { return a.Key, a.Value }), nil
+}
@@ -0,0 +1,436 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Attribute keys of DescribeLoadBalancerAttributes and DescribeTargetGroupAttributes.
+const (
+	attrDeletionProtection  = "deletion_protection.enabled"
+	attrIdleTimeout         = "idle_timeout.timeout_seconds"
+	attrHTTP2               = "routing.http2.enabled"
+	attrDropInvalidHeaders  = "routing.http.drop_invalid_header_fields.enabled"
+	attrCrossZone           = "load_balancing.cross_zone.enabled"
+	attrAccessLogsEnabled   = "access_logs.s3.enabled"
+	attrAccessLogsBucket    = "access_logs.s3.bucket"
+	attrAccessLogsPrefix    = "access_logs.s3.prefix"
+	attrDeregistrationDelay = "deregistration_delay.timeout_seconds"
+	attrSlowStart           = "slow_start.duration_seconds"
+	attrAlgorithm           = "load_balancing.algorithm.type"
+	attrStickinessEnabled   = "stickiness.enabled"
+	attrStickinessType      = "stickiness.type"
+	attrLBCookieDuration    = "stickiness.lb_cookie.duration_seconds"
+	attrAppCookieDuration   = "stickiness.app_cookie.duration_seconds"
+	attrAppCookieName       = "stickiness.app_cookie.cookie_name"
+)
+
+// elbResource wraps a load balancer, listener or target group whose attributes
+// are mapped once when the resource is built.
+type elbResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *elbResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *elbResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newLoadBalancerResource(lb LoadBalancer, attributes, tags map[string]string, region, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(lb.LoadBalancerArn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ELBv2 load balancer resource: missing ARN")
+	}
+	return &elbResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindLoadBalancer,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   aws.ToString(lb.LoadBalancerName),
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapLoadBalancerToAttributes(lb, attributes, tags),
+	}, nil
+}
+
+func newListenerResource(listener Listener, rules []Rule, tags map[string]string, region, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(listener.ListenerArn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ELBv2 listener resource: missing ARN")
+	}
+	return &elbResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindLoadBalancerListener,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   arn,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapListenerToAttributes(listener, rules, tags),
+	}, nil
+}
+
+func newTargetGroupResource(group TargetGroup, attributes, tags map[string]string, region, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(group.TargetGroupArn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create ELBv2 target group resource: missing ARN")
+	}
+	return &elbResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindLoadBalancerTargetGroup,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   aws.ToString(group.TargetGroupName),
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapTargetGroupToAttributes(group, attributes, tags),
+	}, nil
+}
+
+// mapLoadBalancerToAttributes maps a load balancer and its attributes. Only
+// the attributes the load balancer's type supports are returned by AWS, so
+// the others are absent.
+func mapLoadBalancerToAttributes(lb LoadBalancer, attributes, tags map[string]string) map[string]any {
+	arn := aws.ToString(lb.LoadBalancerArn)
+	attrs := map[string]any{
+		domain.KeyID:                        arn,
+		domain.KeyARN:                       arn,
+		domain.KeyName:                      aws.ToString(lb.LoadBalancerName),
+		domain.LoadBalancerInternalKey:      lb.Scheme == elbv2types.LoadBalancerSchemeEnumInternal,
+		domain.LoadBalancerTypeKey:          string(lb.Type),
+		domain.LoadBalancerIPAddressTypeKey: string(lb.IpAddressType),
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	if len(lb.SecurityGroups) > 0 {
+		attrs[domain.LoadBalancerSecurityGroupsKey] = sortedStrings(lb.SecurityGroups)
+	}
+	subnets := make([]string, 0, len(lb.AvailabilityZones))
+	for _, zone := range lb.AvailabilityZones {
+		if zone.SubnetId != nil {
+			subnets = append(subnets, *zone.SubnetId)
+		}
+	}
+	if len(subnets) > 0 {
+		attrs[domain.LoadBalancerSubnetsKey] = sortedStrings(subnets)
+	}
+
+	setBoolAttribute(attrs, domain.LoadBalancerDeletionProtectionKey, attributes, attrDeletionProtection)
+	setBoolAttribute(attrs, domain.LoadBalancerHTTP2Key, attributes, attrHTTP2)
+	setBoolAttribute(attrs, domain.LoadBalancerDropInvalidHeadersKey, attributes, attrDropInvalidHeaders)
+	setBoolAttribute(attrs, domain.LoadBalancerCrossZoneKey, attributes, attrCrossZone)
+	setIntAttribute(attrs, domain.LoadBalancerIdleTimeoutKey, attributes, attrIdleTimeout)
+	if attributes[attrAccessLogsEnabled] == "true" {
+		accessLogs := map[string]any{"bucket": attributes[attrAccessLogsBucket]}
+		setIfNotEmpty(accessLogs, "prefix", attributes[attrAccessLogsPrefix])
+		attrs[domain.LoadBalancerAccessLogsKey] = accessLogs
+	}
+	return attrs
+}
+
+// mapListenerToAttributes maps a listener with its non-default rules sorted by
+// priority. Actions keep their order and conditions are sorted, so that rules
+// compare structurally against the Terraform aws_lb_listener_rule blocks.
+func mapListenerToAttributes(listener Listener, rules []Rule, tags map[string]string) map[string]any {
+	arn := aws.ToString(listener.ListenerArn)
+	attrs := map[string]any{
+		domain.KeyID:                      arn,
+		domain.KeyARN:                     arn,
+		domain.ListenerLoadBalancerARNKey: aws.ToString(listener.LoadBalancerArn),
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	setIfPositive(attrs, domain.ListenerPortKey, listener.Port)
+	setIfNotEmpty(attrs, domain.ListenerProtocolKey, string(listener.Protocol))
+	setIfNotEmpty(attrs, domain.KeySSLPolicy, aws.ToString(listener.SslPolicy))
+	for _, certificate := range listener.Certificates {
+		if arn := aws.ToString(certificate.CertificateArn); arn != "" {
+			attrs[domain.ListenerCertificateARNKey] = arn
+			break
+		}
+	}
+	if len(listener.AlpnPolicy) > 0 && listener.AlpnPolicy[0] != "None" {
+		setIfNotEmpty(attrs, domain.ListenerALPNPolicyKey, listener.AlpnPolicy[0])
+	}
+	if actions := actionsAttributes(listener.DefaultActions); len(actions) > 0 {
+		attrs[domain.ListenerDefaultActionsKey] = actions
+	}
+
+	ruleAttrs := make([]any, 0, len(rules))
+	for _, rule := range rules {
+		if aws.ToBool(rule.IsDefault) {
+			continue
+		}
+		priority, err := strconv.ParseInt(aws.ToString(rule.Priority), 10, 64)
+		if err != nil {
+			continue
+		}
+		ruleAttrs = append(ruleAttrs, map[string]any{
+			"priority":   priority,
+			"conditions": conditionsAttributes(rule.Conditions),
+			"actions":    actionsAttributes(rule.Actions),
+		})
+	}
+	if len(ruleAttrs) > 0 {
+		sort.SliceStable(ruleAttrs, func(i, j int) bool {
+			return ruleAttrs[i].(map[string]any)["priority"].(int64) < ruleAttrs[j].(map[string]any)["priority"].(int64)
+		})
+		attrs[domain.ListenerRulesKey] = ruleAttrs
+	}
+	return attrs
+}
+
+// mapTargetGroupToAttributes maps a target group and its attributes. Health
+// check fields and attributes that do not apply to the group's protocol or
+// target type are left out.
+func mapTargetGroupToAttributes(group TargetGroup, attributes, tags map[string]string) map[string]any {
+	arn := aws.ToString(group.TargetGroupArn)
+	attrs := map[string]any{
+		domain.KeyID:                    arn,
+		domain.KeyARN:                   arn,
+		domain.KeyName:                  aws.ToString(group.TargetGroupName),
+		domain.TargetGroupTargetTypeKey: string(group.TargetType),
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	setIfPositive(attrs, domain.TargetGroupPortKey, group.Port)
+	setIfNotEmpty(attrs, domain.TargetGroupProtocolKey, string(group.Protocol))
+	setIfNotEmpty(attrs, domain.TargetGroupProtocolVersionKey, aws.ToString(group.ProtocolVersion))
+	setIfNotEmpty(attrs, domain.TargetGroupVPCIDKey, aws.ToString(group.VpcId))
+
+	healthCheck := map[string]any{"enabled": aws.ToBool(group.HealthCheckEnabled)}
+	setIfNotEmpty(healthCheck, "path", aws.ToString(group.HealthCheckPath))
+	setIfNotEmpty(healthCheck, "port", aws.ToString(group.HealthCheckPort))
+	setIfNotEmpty(healthCheck, "protocol", string(group.HealthCheckProtocol))
+	if group.Matcher != nil {
+		setIfNotEmpty(healthCheck, "matcher", aws.ToString(group.Matcher.HttpCode))
+		setIfNotEmpty(healthCheck, "matcher", aws.ToString(group.Matcher.GrpcCode))
+	}
+	setIfPositive(healthCheck, "interval", group.HealthCheckIntervalSeconds)
+	setIfPositive(healthCheck, "timeout", group.HealthCheckTimeoutSeconds)
+	setIfPositive(healthCheck, "healthy_threshold", group.HealthyThresholdCount)
+	setIfPositive(healthCheck, "unhealthy_threshold", group.UnhealthyThresholdCount)
+	attrs[domain.TargetGroupHealthCheckKey] = healthCheck
+
+	setIntAttribute(attrs, domain.TargetGroupDeregistrationDelayKey, attributes, attrDeregistrationDelay)
+	if slowStart, err := strconv.ParseInt(attributes[attrSlowStart], 10, 64); err == nil && slowStart > 0 {
+		attrs[domain.TargetGroupSlowStartKey] = slowStart
+	}
+	setIfNotEmpty(attrs, domain.TargetGroupAlgorithmKey, attributes[attrAlgorithm])
+	if attributes[attrStickinessEnabled] == "true" {
+		stickiness := map[string]any{"type": attributes[attrStickinessType]}
+		switch attributes[attrStickinessType] {
+		case "lb_cookie":
+			setIntAttribute(stickiness, "cookie_duration", attributes, attrLBCookieDuration)
+		case "app_cookie":
+			setIntAttribute(stickiness, "cookie_duration", attributes, attrAppCookieDuration)
+			setIfNotEmpty(stickiness, "cookie_name", attributes[attrAppCookieName])
+		}
+		attrs[domain.TargetGroupStickinessKey] = stickiness
+	}
+	return attrs
+}
+
+// actionsAttributes maps actions in their order of execution. Each action is
+// flattened to its type and the non-empty settings of that type.
+func actionsAttributes(actions []elbv2types.Action) []any {
+	ordered := append([]elbv2types.Action(nil), actions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return actionOrder(ordered[i]) < actionOrder(ordered[j])
+	})
+
+	result := make([]any, 0, len(ordered))
+	for _, action := range ordered {
+		attrs := map[string]any{"type": string(action.Type)}
+		switch action.Type {
+		case elbv2types.ActionTypeEnumForward:
+			forwardAttributes(action, attrs)
+		case elbv2types.ActionTypeEnumRedirect:
+			if cfg := action.RedirectConfig; cfg != nil {
+				setIfNotEmpty(attrs, "status_code", string(cfg.StatusCode))
+				setIfNotEmpty(attrs, "host", aws.ToString(cfg.Host))
+				setIfNotEmpty(attrs, "path", aws.ToString(cfg.Path))
+				setIfNotEmpty(attrs, "port", aws.ToString(cfg.Port))
+				setIfNotEmpty(attrs, "protocol", aws.ToString(cfg.Protocol))
+				setIfNotEmpty(attrs, "query", aws.ToString(cfg.Query))
+			}
+		case elbv2types.ActionTypeEnumFixedResponse:
+			if cfg := action.FixedResponseConfig; cfg != nil {
+				setIfNotEmpty(attrs, "status_code", aws.ToString(cfg.StatusCode))
+				setIfNotEmpty(attrs, "content_type", aws.ToString(cfg.ContentType))
+				setIfNotEmpty(attrs, "message_body", aws.ToString(cfg.MessageBody))
+			}
+		case elbv2types.ActionTypeEnumAuthenticateCognito:
+			if cfg := action.AuthenticateCognitoConfig; cfg != nil {
+				setIfNotEmpty(attrs, "user_pool_arn", aws.ToString(cfg.UserPoolArn))
+				setIfNotEmpty(attrs, "user_pool_client_id", aws.ToString(cfg.UserPoolClientId))
+				setIfNotEmpty(attrs, "user_pool_domain", aws.ToString(cfg.UserPoolDomain))
+				authenticationAttributes(attrs, string(cfg.OnUnauthenticatedRequest), cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			}
+		case elbv2types.ActionTypeEnumAuthenticateOidc:
+			if cfg := action.AuthenticateOidcConfig; cfg != nil {
+				setIfNotEmpty(attrs, "issuer", aws.ToString(cfg.Issuer))
+				setIfNotEmpty(attrs, "client_id", aws.ToString(cfg.ClientId))
+				setIfNotEmpty(attrs, "authorization_endpoint", aws.ToString(cfg.AuthorizationEndpoint))
+				setIfNotEmpty(attrs, "token_endpoint", aws.ToString(cfg.TokenEndpoint))
+				setIfNotEmpty(attrs, "user_info_endpoint", aws.ToString(cfg.UserInfoEndpoint))
+				authenticationAttributes(attrs, string(cfg.OnUnauthenticatedRequest), cfg.Scope, cfg.SessionCookieName, cfg.SessionTimeout)
+			}
+		}
+		result = append(result, attrs)
+	}
+	return result
+}
+
+func actionOrder(action elbv2types.Action) int32 {
+	if action.Order == nil {
+		return 1<<31 - 1
+	}
+	return *action.Order
+}
+
+// forwardAttributes sets the target groups of a forward action, sorted by ARN.
+// Weights are only kept when traffic is split over several groups, and the
+// stickiness duration only when group stickiness is on.
+func forwardAttributes(action elbv2types.Action, attrs map[string]any) {
+	var tuples []elbv2types.TargetGroupTuple
+	if action.ForwardConfig != nil {
+		tuples = action.ForwardConfig.TargetGroups
+	}
+	if len(tuples) == 0 && action.TargetGroupArn != nil {
+		tuples = []elbv2types.TargetGroupTuple{{TargetGroupArn: action.TargetGroupArn}}
+	}
+	groups := make([]any, 0, len(tuples))
+	for _, tuple := range tuples {
+		group := map[string]any{"arn": aws.ToString(tuple.TargetGroupArn)}
+		if len(tuples) > 1 && tuple.Weight != nil {
+			group["weight"] = int64(*tuple.Weight)
+		}
+		groups = append(groups, group)
+	}
+	sortByField(groups, "arn")
+	attrs["target_groups"] = groups
+
+	if action.ForwardConfig != nil && action.ForwardConfig.TargetGroupStickinessConfig != nil {
+		stickiness := action.ForwardConfig.TargetGroupStickinessConfig
+		if aws.ToBool(stickiness.Enabled) {
+			attrs["stickiness_duration"] = int64(aws.ToInt32(stickiness.DurationSeconds))
+		}
+	}
+}
+
+func authenticationAttributes(attrs map[string]any, onUnauthenticated string, scope, cookieName *string, timeout *int64) {
+	setIfNotEmpty(attrs, "on_unauthenticated_request", onUnauthenticated)
+	setIfNotEmpty(attrs, "scope", aws.ToString(scope))
+	setIfNotEmpty(attrs, "session_cookie_name", aws.ToString(cookieName))
+	if v := aws.ToInt64(timeout); v > 0 {
+		attrs["session_timeout"] = v
+	}
+}
+
+// conditionsAttributes maps rule conditions to maps with the condition's
+// "field" and sorted "values", sorted by field and header name. Query string
+// pairs are written as "key=value", or "value" when the key is empty.
+func conditionsAttributes(conditions []elbv2types.RuleCondition) []any {
+	result := make([]any, 0, len(conditions))
+	for _, condition := range conditions {
+		field := aws.ToString(condition.Field)
+		values := condition.Values
+		attrs := map[string]any{"field": field}
+		switch {
+		case condition.HostHeaderConfig != nil:
+			values = condition.HostHeaderConfig.Values
+		case condition.PathPatternConfig != nil:
+			values = condition.PathPatternConfig.Values
+		case condition.HttpRequestMethodConfig != nil:
+			values = condition.HttpRequestMethodConfig.Values
+		case condition.SourceIpConfig != nil:
+			values = condition.SourceIpConfig.Values
+		case condition.HttpHeaderConfig != nil:
+			attrs["http_header_name"] = aws.ToString(condition.HttpHeaderConfig.HttpHeaderName)
+			values = condition.HttpHeaderConfig.Values
+		case condition.QueryStringConfig != nil:
+			values = make([]string, 0, len(condition.QueryStringConfig.Values))
+			for _, pair := range condition.QueryStringConfig.Values {
+				values = append(values, queryStringValue(aws.ToString(pair.Key), aws.ToString(pair.Value)))
+			}
+		}
+		attrs["values"] = sortedStrings(values)
+		result = append(result, attrs)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return conditionSortKey(result[i]) < conditionSortKey(result[j])
+	})
+	return result
+}
+
+func queryStringValue(key, value string) string {
+	if key == "" {
+		return value
+	}
+	return key + "=" + value
+}
+
+func conditionSortKey(condition any) string {
+	attrs := condition.(map[string]any)
+	return fmt.Sprintf("%v\x00%v", attrs["field"], attrs["http_header_name"])
+}
+
+func setBoolAttribute(attrs map[string]any, key string, attributes map[string]string, name string) {
+	if value, err := strconv.ParseBool(attributes[name]); err == nil {
+		attrs[key] = value
+	}
+}
+
+func setIntAttribute(attrs map[string]any, key string, attributes map[string]string, name string) {
+	if value, err := strconv.ParseInt(attributes[name], 10, 64); err == nil {
+		attrs[key] = value
+	}
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortByField(items []any, field string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].(map[string]any)[field].(string) < items[j].(map[string]any)[field].(string)
+	})
+}
+
+func setIfNotEmpty(attrs map[string]any, key, value string) {
+	if value != "" {
+		attrs[key] = value
+	}
+}
+
+func setIfPositive(attrs map[string]any, key string, value *int32) {
+	if v := aws.ToInt32(value); v > 0 {
+		attrs[key] = int64(v)
+	}
+}
@@ -0,0 +1,178 @@
+package elbv2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapLoadBalancerToAttributes(t *testing.T) {
+	lb := elbv2types.LoadBalancer{
+		LoadBalancerArn:  aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188"),
+		LoadBalancerName: aws.String("web"),
+		Scheme:           elbv2types.LoadBalancerSchemeEnumInternetFacing,
+		Type:             elbv2types.LoadBalancerTypeEnumApplication,
+		IpAddressType:    elbv2types.IpAddressTypeIpv4,
+		SecurityGroups:   []string{"sg-2", "sg-1"},
+		AvailabilityZones: []elbv2types.AvailabilityZone{
+			{ZoneName: aws.String("us-east-1b"), SubnetId: aws.String("subnet-b")},
+			{ZoneName: aws.String("us-east-1a"), SubnetId: aws.String("subnet-a")},
+		},
+	}
+	attributes := map[string]string{
+		attrDeletionProtection: "true",
+		attrIdleTimeout:        "120",
+		attrHTTP2:              "true",
+		attrAccessLogsEnabled:  "true",
+		attrAccessLogsBucket:   "logs",
+		attrAccessLogsPrefix:   "",
+	}
+
+	attrs := mapLoadBalancerToAttributes(lb, attributes, map[string]string{"Env": "prod"})
+
+	assert.Equal(t, false, attrs[domain.LoadBalancerInternalKey])
+	assert.Equal(t, "application", attrs[domain.LoadBalancerTypeKey])
+	assert.Equal(t, []string{"sg-1", "sg-2"}, attrs[domain.LoadBalancerSecurityGroupsKey])
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, attrs[domain.LoadBalancerSubnetsKey])
+	assert.Equal(t, true, attrs[domain.LoadBalancerDeletionProtectionKey])
+	assert.Equal(t, int64(120), attrs[domain.LoadBalancerIdleTimeoutKey])
+	assert.Equal(t, map[string]any{"bucket": "logs"}, attrs[domain.LoadBalancerAccessLogsKey])
+	assert.NotContains(t, attrs, domain.LoadBalancerCrossZoneKey, "attributes AWS does not return are absent")
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func TestMapListenerToAttributes(t *testing.T) {
+	listener := elbv2types.Listener{
+		ListenerArn:     aws.String("arn:listener"),
+		LoadBalancerArn: aws.String("arn:lb"),
+		Port:            aws.Int32(443),
+		Protocol:        elbv2types.ProtocolEnumHttps,
+		SslPolicy:       aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+		Certificates:    []elbv2types.Certificate{{CertificateArn: aws.String("arn:cert")}},
+		AlpnPolicy:      []string{"None"},
+		DefaultActions: []elbv2types.Action{
+			{
+				Type:  elbv2types.ActionTypeEnumForward,
+				Order: aws.Int32(2),
+				ForwardConfig: &elbv2types.ForwardActionConfig{
+					TargetGroups: []elbv2types.TargetGroupTuple{
+						{TargetGroupArn: aws.String("arn:tg/green"), Weight: aws.Int32(20)},
+						{TargetGroupArn: aws.String("arn:tg/blue"), Weight: aws.Int32(80)},
+					},
+					TargetGroupStickinessConfig: &elbv2types.TargetGroupStickinessConfig{Enabled: aws.Bool(false)},
+				},
+			},
+			{
+				Type:  elbv2types.ActionTypeEnumAuthenticateOidc,
+				Order: aws.Int32(1),
+				AuthenticateOidcConfig: &elbv2types.AuthenticateOidcActionConfig{
+					Issuer:         aws.String("https://idp.example.com"),
+					ClientId:       aws.String("web"),
+					Scope:          aws.String("openid"),
+					SessionTimeout: aws.Int64(3600),
+				},
+			},
+		},
+	}
+	rules := []elbv2types.Rule{
+		{IsDefault: aws.Bool(true), Priority: aws.String("default")},
+		{
+			Priority: aws.String("20"),
+			Conditions: []elbv2types.RuleCondition{
+				{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2types.PathPatternConditionConfig{Values: []string{"/b/*"}}},
+			},
+			Actions: []elbv2types.Action{{Type: elbv2types.ActionTypeEnumForward, TargetGroupArn: aws.String("arn:tg/api")}},
+		},
+		{
+			Priority: aws.String("10"),
+			Conditions: []elbv2types.RuleCondition{
+				{Field: aws.String("query-string"), QueryStringConfig: &elbv2types.QueryStringConditionConfig{Values: []elbv2types.QueryStringKeyValuePair{
+					{Key: aws.String("v"), Value: aws.String("2")},
+					{Value: aws.String("beta")},
+				}}},
+				{Field: aws.String("path-pattern"), PathPatternConfig: &elbv2types.PathPatternConditionConfig{Values: []string{"/a/*"}}},
+			},
+			Actions: []elbv2types.Action{{
+				Type:                elbv2types.ActionTypeEnumFixedResponse,
+				FixedResponseConfig: &elbv2types.FixedResponseActionConfig{StatusCode: aws.String("404"), ContentType: aws.String("text/plain")},
+			}},
+		},
+	}
+
+	attrs := mapListenerToAttributes(listener, rules, nil)
+
+	assert.Equal(t, int64(443), attrs[domain.ListenerPortKey])
+	assert.Equal(t, "HTTPS", attrs[domain.ListenerProtocolKey])
+	assert.Equal(t, "arn:cert", attrs[domain.ListenerCertificateARNKey])
+	assert.NotContains(t, attrs, domain.ListenerALPNPolicyKey, "the None ALPN policy means no policy")
+	assert.NotContains(t, attrs, domain.KeyTags)
+	assert.Equal(t, []any{
+		map[string]any{"type": "authenticate-oidc", "issuer": "https://idp.example.com", "client_id": "web", "scope": "openid", "session_timeout": int64(3600)},
+		map[string]any{"type": "forward", "target_groups": []any{
+			map[string]any{"arn": "arn:tg/blue", "weight": int64(80)},
+			map[string]any{"arn": "arn:tg/green", "weight": int64(20)},
+		}},
+	}, attrs[domain.ListenerDefaultActionsKey], "actions are kept in their order of execution")
+	assert.Equal(t, []any{
+		map[string]any{
+			"priority": int64(10),
+			"conditions": []any{
+				map[string]any{"field": "path-pattern", "values": []string{"/a/*"}},
+				map[string]any{"field": "query-string", "values": []string{"beta", "v=2"}},
+			},
+			"actions": []any{map[string]any{"type": "fixed-response", "status_code": "404", "content_type": "text/plain"}},
+		},
+		map[string]any{
+			"priority":   int64(20),
+			"conditions": []any{map[string]any{"field": "path-pattern", "values": []string{"/b/*"}}},
+			"actions":    []any{map[string]any{"type": "forward", "target_groups": []any{map[string]any{"arn": "arn:tg/api"}}}},
+		},
+	}, attrs[domain.ListenerRulesKey], "the default rule is skipped and rules are sorted by priority")
+}
+
+func TestMapTargetGroupToAttributes(t *testing.T) {
+	group := elbv2types.TargetGroup{
+		TargetGroupArn:             aws.String("arn:tg/api"),
+		TargetGroupName:            aws.String("api"),
+		Port:                       aws.Int32(8080),
+		Protocol:                   elbv2types.ProtocolEnumHttp,
+		ProtocolVersion:            aws.String("HTTP1"),
+		TargetType:                 elbv2types.TargetTypeEnumIp,
+		VpcId:                      aws.String("vpc-1"),
+		HealthCheckEnabled:         aws.Bool(true),
+		HealthCheckPath:            aws.String("/healthz"),
+		HealthCheckPort:            aws.String("traffic-port"),
+		HealthCheckProtocol:        elbv2types.ProtocolEnumHttp,
+		HealthCheckIntervalSeconds: aws.Int32(15),
+		HealthCheckTimeoutSeconds:  aws.Int32(5),
+		HealthyThresholdCount:      aws.Int32(3),
+		Matcher:                    &elbv2types.Matcher{HttpCode: aws.String("200-299")},
+	}
+	attributes := map[string]string{
+		attrDeregistrationDelay: "30",
+		attrSlowStart:           "0",
+		attrAlgorithm:           "round_robin",
+		attrStickinessEnabled:   "true",
+		attrStickinessType:      "app_cookie",
+		attrAppCookieDuration:   "86400",
+		attrAppCookieName:       "session",
+		attrLBCookieDuration:    "3600",
+	}
+
+	attrs := mapTargetGroupToAttributes(group, attributes, nil)
+
+	assert.Equal(t, int64(8080), attrs[domain.TargetGroupPortKey])
+	assert.Equal(t, "ip", attrs[domain.TargetGroupTargetTypeKey])
+	assert.Equal(t, int64(30), attrs[domain.TargetGroupDeregistrationDelayKey])
+	assert.NotContains(t, attrs, domain.TargetGroupSlowStartKey, "a zero slow start means off")
+	assert.Equal(t, "round_robin", attrs[domain.TargetGroupAlgorithmKey])
+	assert.Equal(t, map[string]any{
+		"enabled": true, "path": "/healthz", "port": "traffic-port", "protocol": "HTTP", "matcher": "200-299",
+		"interval": int64(15), "timeout": int64(5), "healthy_threshold": int64(3),
+	}, attrs[domain.TargetGroupHealthCheckKey])
+	assert.Equal(t, map[string]any{"type": "app_cookie", "cookie_duration": int64(86400), "cookie_name": "session"}, attrs[domain.TargetGroupStickinessKey])
+}
@@ -0,0 +1,173 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// TargetGroupHandler lists target groups together with their tags and
+// attributes. Registered targets are not part of the comparison.
+type TargetGroupHandler struct {
+	*baseHandler
+}
+
+// NewTargetGroupHandler creates a new TargetGroupHandler with the given AWS config and optional configurations.
+func NewTargetGroupHandler(cfg aws.Config, opts ...HandlerOption) *TargetGroupHandler {
+	return &TargetGroupHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *TargetGroupHandler) Kind() domain.ResourceKind {
+	return domain.KindLoadBalancerTargetGroup
+}
+
+// ListResources lists the target groups of the region. The ID and name filters
+// are applied to the described target groups, tag filters after listing the
+// tags of a page, and attributes are only fetched for the target groups that
+// pass all filters.
+func (h *TargetGroupHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 target group ListResources: %v", accErr)
+	}
+
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{PageSize: aws.Int32(listPageSize)}
+
+	logger.Debugf(ctx, "Starting ELBv2 target group listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.elbClient.DescribeTargetGroups(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("ELBv2", fmt.Sprintf("DescribeTargetGroups:Page%d", pageNum), err, ctx)
+		}
+
+		var candidates []TargetGroup
+		var arns []string
+		for _, group := range output.TargetGroups {
+			arn := aws.ToString(group.TargetGroupArn)
+			if !matchesNameFilters(filters, arn, aws.ToString(group.TargetGroupName)) {
+				continue
+			}
+			candidates = append(candidates, group)
+			arns = append(arns, arn)
+		}
+
+		if len(candidates) > 0 {
+			tagsByARN, err := h.describeTags(ctx, arns, logger)
+			if err != nil {
+				return err
+			}
+			for _, group := range candidates {
+				arn := aws.ToString(group.TargetGroupArn)
+				tags := tagsByARN[arn]
+				if !matchesTagFilters(tags, filters) {
+					continue
+				}
+				attributes, err := h.targetGroupAttributes(ctx, arn, logger)
+				if err != nil {
+					return err
+				}
+				resource, mapErr := newTargetGroupResource(group, attributes, tags, cfg.Region, accountID)
+				if mapErr != nil {
+					logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for ELBv2 target group %s, skipping", arn)
+					continue
+				}
+				if err := h.send(ctx, resource, out, logger); err != nil {
+					return err
+				}
+			}
+		}
+
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	logger.Debugf(ctx, "Finished ELBv2 target group pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a target group by ARN, or by name when id is not an ARN.
+func (h *TargetGroupHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single ELBv2 target group %s", id)
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{}
+	if strings.HasPrefix(id, "arn:") {
+		input.TargetGroupArns = []string{id}
+	} else {
+		input.Names = []string{id}
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.elbClient.DescribeTargetGroups(ctx, input)
+	if err != nil {
+		return nil, h.errorHandler.Handle("ELBv2", "DescribeTargetGroups", err, ctx)
+	}
+	if len(output.TargetGroups) == 0 {
+		return nil, notFound("target group", id)
+	}
+	group := output.TargetGroups[0]
+	arn := aws.ToString(group.TargetGroupArn)
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for ELBv2 target group GetResource: %v", accErr)
+	}
+
+	tagsByARN, err := h.describeTags(ctx, []string{arn}, logger)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := h.targetGroupAttributes(ctx, arn, logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, mapErr := newTargetGroupResource(group, attributes, tagsByARN[arn], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for ELBv2 target group %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that target groups can be described with a single minimal page.
+func (h *TargetGroupHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.elbClient.DescribeTargetGroups(ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{PageSize: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("ELBv2", "DescribeTargetGroups", err, ctx)
+	}
+	return nil
+}
+
+func (h *TargetGroupHandler) targetGroupAttributes(ctx context.Context, arn string, logger ports.Logger) (map[string]string, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.elbClient.DescribeTargetGroupAttributes(ctx, &elasticloadbalancingv2.DescribeTargetGroupAttributesInput{TargetGroupArn: aws.String(arn)})
+	if err != nil {
+		return nil, h.errorHandler.Handle("ELBv2", "DescribeTargetGroupAttributes", err, ctx)
+	}
+	return attributesToMap(output.Attributes, func(a elbv2types.TargetGroupAttribute) (*string, *string) { return a.Key, a.Value }), nil
+}
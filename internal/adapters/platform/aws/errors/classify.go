@@ -0,0 +1,177 @@
+package errors
+
+import (
+	"context"
+	stderrs "errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// errorCodeClasses maps AWS API error codes to the application error code every
+// handler reports them as. Codes missing from the table are classified by
+// their suffix, HTTP status and message, in that order.
+var errorCodeClasses = map[string]errors.Code{
+	// Authentication and authorization
+	"AuthFailure":                 errors.CodePlatformAuthError,
+	"UnauthorizedOperation":       errors.CodePlatformAuthError,
+	"UnauthorizedException":       errors.CodePlatformAuthError,
+	"AccessDenied":                errors.CodePlatformAuthError,
+	"AccessDeniedException":       errors.CodePlatformAuthError,
+	"AllAccessDisabled":           errors.CodePlatformAuthError,
+	"ExpiredToken":                errors.CodePlatformAuthError,
+	"ExpiredTokenException":       errors.CodePlatformAuthError,
+	"InvalidClientTokenId":        errors.CodePlatformAuthError,
+	"InvalidAccessKeyId":          errors.CodePlatformAuthError,
+	"InvalidToken":                errors.CodePlatformAuthError,
+	"InvalidSignatureException":   errors.CodePlatformAuthError,
+	"MissingAuthenticationToken":  errors.CodePlatformAuthError,
+	"UnrecognizedClientException": errors.CodePlatformAuthError,
+	"SignatureDoesNotMatch":       errors.CodePlatformAuthError,
+
+	// Throttling
+	"Throttling":                             errors.CodePlatformThrottled,
+	"ThrottlingException":                    errors.CodePlatformThrottled,
+	"ThrottledException":                     errors.CodePlatformThrottled,
+	"RequestThrottled":                       errors.CodePlatformThrottled,
+	"RequestThrottledException":              errors.CodePlatformThrottled,
+	"RequestLimitExceeded":                   errors.CodePlatformThrottled,
+	"TooManyRequestsException":               errors.CodePlatformThrottled,
+	"SlowDown":                               errors.CodePlatformThrottled,
+	"BandwidthLimitExceeded":                 errors.CodePlatformThrottled,
+	"PriorRequestNotComplete":                errors.CodePlatformThrottled,
+	"EC2ThrottledException":                  errors.CodePlatformThrottled,
+	"ProvisionedThroughputExceededException": errors.CodePlatformThrottled,
+
+	// Missing resources not covered by the NotFound suffixes
+	"InvalidInstanceID.Malformed": errors.CodeResourceNotFound,
+	"NoSuchBucket":                errors.CodeResourceNotFound,
+	"NoSuchKey":                   errors.CodeResourceNotFound,
+	"NoSuchEntity":                errors.CodeResourceNotFound,
+	"NoSuchEntityException":       errors.CodeResourceNotFound,
+	"NoSuchDistribution":          errors.CodeResourceNotFound,
+}
+
+// notFoundCodeSuffixes cover the per-resource not-found codes of EC2
+// ("InvalidGroup.NotFound"), RDS ("DBInstanceNotFound", "DBInstanceNotFoundFault")
+// and most other services ("ResourceNotFoundException").
+var notFoundCodeSuffixes = []string{"NotFound", "NotFoundException", "NotFoundFault"}
+
+// httpStatusClasses classifies errors by HTTP status when the service returned
+// no error code, as S3 does for HEAD requests.
+var httpStatusClasses = map[int]errors.Code{
+	http.StatusUnauthorized:    errors.CodePlatformAuthError,
+	http.StatusForbidden:       errors.CodePlatformAuthError,
+	http.StatusNotFound:        errors.CodeResourceNotFound,
+	http.StatusTooManyRequests: errors.CodePlatformThrottled,
+}
+
+// Message fragments used when an error carries neither an error code nor an
+// HTTP status, e.g. errors built by mocks or wrapped as plain text.
+var (
+	authMessageFragments     = []string{"AuthFailure", "UnauthorizedOperation", "AccessDenied", "ExpiredToken", "InvalidClientTokenId", "InvalidAccessKeyId", "UnrecognizedClientException", "SignatureDoesNotMatch"}
+	throttleMessageFragments = []string{"Throttling", "Rate exceeded", "RequestLimitExceeded", "TooManyRequests", "SlowDown"}
+	notFoundMessageFragments = []string{"NotFound", "not found", "not exist", "NoSuchKey", "NoSuchBucket", "NoSuchEntity"}
+)
+
+// Classify maps an AWS error to the application error code shared by all
+// handlers: CodePlatformAuthError, CodePlatformThrottled, CodeResourceNotFound,
+// or CodePlatformAPIError for everything else, including cancellation. Errors
+// already mapped to an application error keep their code.
+func Classify(err error) errors.Code {
+	if err == nil {
+		return errors.CodeUnknown
+	}
+	var appErr *errors.AppError
+	if stderrs.As(err, &appErr) {
+		return appErr.Code
+	}
+	return classifyAPIError(err)
+}
+
+// IsThrottleError reports whether err is an AWS throttling error, either already
+// mapped to CodePlatformThrottled or still a raw API error.
+func IsThrottleError(err error) bool {
+	return hasMappedCode(err, errors.CodePlatformThrottled) || (err != nil && classifyAPIError(err) == errors.CodePlatformThrottled)
+}
+
+// hasMappedCode reports whether any application error in err's chain has code.
+func hasMappedCode(err error, code errors.Code) bool {
+	for e := err; e != nil; e = stderrs.Unwrap(e) {
+		if appErr, ok := e.(*errors.AppError); ok && appErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyAPIError classifies a raw AWS error, ignoring application errors it
+// may be wrapped in.
+func classifyAPIError(err error) errors.Code {
+	if stderrs.Is(err, context.Canceled) || stderrs.Is(err, context.DeadlineExceeded) {
+		return errors.CodePlatformAPIError
+	}
+	if code := apiErrorCode(err); code != "" {
+		if class := classifyErrorCode(code); class != "" {
+			return class
+		}
+	}
+	var statusErr interface{ HTTPStatusCode() int }
+	if stderrs.As(err, &statusErr) {
+		if class, ok := httpStatusClasses[statusErr.HTTPStatusCode()]; ok {
+			return class
+		}
+	}
+	return classifyMessage(err.Error())
+}
+
+// apiErrorCode returns the AWS error code of err, if it carries one.
+func apiErrorCode(err error) string {
+	var apiErr smithy.APIError
+	if stderrs.As(err, &apiErr) && apiErr != nil {
+		return apiErr.ErrorCode()
+	}
+	var codeErr interface{ ErrorCode() string }
+	if stderrs.As(err, &codeErr) && codeErr != nil {
+		return codeErr.ErrorCode()
+	}
+	return ""
+}
+
+// classifyErrorCode returns the class of an AWS error code, or "" when the code
+// is not known.
+func classifyErrorCode(code string) errors.Code {
+	if class, ok := errorCodeClasses[code]; ok {
+		return class
+	}
+	for _, suffix := range notFoundCodeSuffixes {
+		if strings.HasSuffix(code, suffix) {
+			return errors.CodeResourceNotFound
+		}
+	}
+	return ""
+}
+
+func classifyMessage(msg string) errors.Code {
+	switch {
+	case containsAny(msg, authMessageFragments):
+		return errors.CodePlatformAuthError
+	case containsAny(msg, throttleMessageFragments):
+		return errors.CodePlatformThrottled
+	case containsAny(msg, notFoundMessageFragments):
+		return errors.CodeResourceNotFound
+	default:
+		return errors.CodePlatformAPIError
+	}
+}
+
+func containsAny(s string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(s, fragment) {
+			return true
+		}
+	}
+	return false
+}
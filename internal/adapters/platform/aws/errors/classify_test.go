@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockStatusError carries only an HTTP status, like S3 responses to HEAD requests.
+type mockStatusError struct {
+	status int
+}
+
+func (m *mockStatusError) Error() string {
+	return fmt.Sprintf("http response error StatusCode: %d", m.status)
+}
+
+func (m *mockStatusError) HTTPStatusCode() int {
+	return m.status
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected errors.Code
+	}{
+		{name: "nil", err: nil, expected: errors.CodeUnknown},
+		{name: "mapped error keeps its code", err: fmt.Errorf("list: %w", errors.New(errors.CodeResourceNotFound, "gone")), expected: errors.CodeResourceNotFound},
+		{name: "context canceled", err: fmt.Errorf("operation error: %w", context.Canceled), expected: errors.CodePlatformAPIError},
+
+		{name: "EC2 auth failure", err: &mockAPIError{errorCode: "AuthFailure"}, expected: errors.CodePlatformAuthError},
+		{name: "EC2 unauthorized operation", err: &mockAPIError{errorCode: "UnauthorizedOperation"}, expected: errors.CodePlatformAuthError},
+		{name: "S3 access denied", err: &mockAPIError{errorCode: "AccessDenied"}, expected: errors.CodePlatformAuthError},
+		{name: "IAM access denied", err: &mockAPIError{errorCode: "AccessDeniedException"}, expected: errors.CodePlatformAuthError},
+		{name: "STS expired token", err: &mockAPIError{errorCode: "ExpiredToken"}, expected: errors.CodePlatformAuthError},
+		{name: "invalid client token", err: &mockAPIError{errorCode: "InvalidClientTokenId"}, expected: errors.CodePlatformAuthError},
+		{name: "DynamoDB unrecognized client", err: &mockAPIError{errorCode: "UnrecognizedClientException"}, expected: errors.CodePlatformAuthError},
+
+		{name: "query API throttling", err: &mockAPIError{errorCode: "Throttling"}, expected: errors.CodePlatformThrottled},
+		{name: "JSON API throttling", err: &mockAPIError{errorCode: "ThrottlingException"}, expected: errors.CodePlatformThrottled},
+		{name: "EC2 request limit", err: &mockAPIError{errorCode: "RequestLimitExceeded"}, expected: errors.CodePlatformThrottled},
+		{name: "Lambda too many requests", err: &mockAPIError{errorCode: "TooManyRequestsException"}, expected: errors.CodePlatformThrottled},
+		{name: "S3 slow down", err: &mockAPIError{errorCode: "SlowDown"}, expected: errors.CodePlatformThrottled},
+		{name: "DynamoDB throughput exceeded", err: &mockAPIError{errorCode: "ProvisionedThroughputExceededException"}, expected: errors.CodePlatformThrottled},
+
+		{name: "EC2 instance not found", err: &mockAPIError{errorCode: "InvalidInstanceID.NotFound"}, expected: errors.CodeResourceNotFound},
+		{name: "EC2 malformed instance ID", err: &mockAPIError{errorCode: "InvalidInstanceID.Malformed"}, expected: errors.CodeResourceNotFound},
+		{name: "EC2 security group not found", err: &mockAPIError{errorCode: "InvalidGroup.NotFound"}, expected: errors.CodeResourceNotFound},
+		{name: "S3 no such bucket", err: &mockAPIError{errorCode: "NoSuchBucket"}, expected: errors.CodeResourceNotFound},
+		{name: "RDS instance not found", err: &mockAPIError{errorCode: "DBInstanceNotFound"}, expected: errors.CodeResourceNotFound},
+		{name: "RDS instance not found fault", err: &mockAPIError{errorCode: "DBInstanceNotFoundFault"}, expected: errors.CodeResourceNotFound},
+		{name: "IAM no such entity", err: &mockAPIError{errorCode: "NoSuchEntity", errorMsg: "The role with name app cannot be found."}, expected: errors.CodeResourceNotFound},
+		{name: "CloudFront no such distribution", err: &mockAPIError{errorCode: "NoSuchDistribution"}, expected: errors.CodeResourceNotFound},
+		{name: "Lambda resource not found", err: &mockAPIError{errorCode: "ResourceNotFoundException"}, expected: errors.CodeResourceNotFound},
+		{name: "mock error code", err: &MockErrorWithCode{Code: "ResourceNotFoundException"}, expected: errors.CodeResourceNotFound},
+
+		{name: "unknown code", err: &mockAPIError{errorCode: "InvalidParameterValue", errorMsg: "bad filter"}, expected: errors.CodePlatformAPIError},
+		{name: "HTTP 403", err: &mockStatusError{status: 403}, expected: errors.CodePlatformAuthError},
+		{name: "HTTP 404", err: &mockStatusError{status: 404}, expected: errors.CodeResourceNotFound},
+		{name: "HTTP 429", err: &mockStatusError{status: 429}, expected: errors.CodePlatformThrottled},
+		{name: "HTTP 500", err: &mockStatusError{status: 500}, expected: errors.CodePlatformAPIError},
+		{name: "throttling in message", err: fmt.Errorf("operation error Lambda: ListFunctions, Rate exceeded"), expected: errors.CodePlatformThrottled},
+		{name: "wrapped API error", err: fmt.Errorf("operation error EC2: DescribeInstances: %w", &mockAPIError{errorCode: "RequestLimitExceeded"}), expected: errors.CodePlatformThrottled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Classify(tt.err))
+		})
+	}
+}
+
+func TestHandleAWSError_UsesClassification(t *testing.T) {
+	handler := &DefaultErrorHandler{}
+	for _, code := range []string{"Throttling", "NoSuchEntity", "AccessDeniedException", "InvalidParameterValue"} {
+		raw := &mockAPIError{errorCode: code, errorMsg: code}
+		t.Run(code, func(t *testing.T) {
+			err := handler.Handle("IAM", "GetRole", raw, context.Background())
+
+			assert.True(t, errors.Is(err, Classify(raw)))
+		})
+	}
+}
+
+func TestIsThrottleError(t *testing.T) {
+	assert.False(t, IsThrottleError(nil))
+	assert.True(t, IsThrottleError(&mockAPIError{errorCode: "Throttling"}))
+	assert.True(t, IsThrottleError(errors.New(errors.CodePlatformThrottled, "throttled")))
+	assert.False(t, IsThrottleError(&mockAPIError{errorCode: "AccessDenied"}))
+}
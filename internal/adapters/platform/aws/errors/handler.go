@@ -2,11 +2,8 @@ package errors
 
 import (
 	"context"
-	stderrs "errors"
 	"fmt"
-	"strings"
 
-	"github.com/aws/smithy-go"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
 )
 
@@ -33,84 +30,39 @@ func HandleAWSError(resourceType string, resourceID string, err error, ctx conte
 			fmt.Sprintf("context canceled during AWS %s API call", resourceType))
 	}
 
-	// Get error message for string matching
-	errMsg := err.Error()
-
-	// Authentication/authorization errors
-	if strings.Contains(errMsg, "AuthFailure") ||
-		strings.Contains(errMsg, "UnauthorizedOperation") ||
-		strings.Contains(errMsg, "AccessDenied") {
+	switch Classify(err) {
+	case errors.CodePlatformAuthError:
 		return errors.Wrap(err, errors.CodePlatformAuthError,
 			fmt.Sprintf("AWS authentication error accessing %s %s", resourceType, resourceID))
-	}
-
-	// Resource not found errors - use both error types and message content
-	if isNotFoundError(err, errMsg) {
+	case errors.CodePlatformThrottled:
+		return errors.Wrap(err, errors.CodePlatformThrottled,
+			fmt.Sprintf("AWS throttled requests for %s '%s'", resourceType, resourceID))
+	case errors.CodeResourceNotFound:
 		return errors.Wrap(err, errors.CodeResourceNotFound,
 			fmt.Sprintf("%s '%s' not found", resourceType, resourceID))
+	default:
+		return errors.Wrap(err, errors.CodePlatformAPIError,
+			fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
 	}
-
-	// Fall back to generic platform API error
-	return errors.Wrap(err, errors.CodePlatformAPIError,
-		fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
 }
 
 // isNotFoundError checks if the error indicates a resource was not found
 func isNotFoundError(err error, errMsg string) bool {
-	// Check common not found strings in error messages
-	if strings.Contains(errMsg, "NotFound") ||
-		strings.Contains(errMsg, "not found") ||
-		strings.Contains(errMsg, "not exist") ||
-		strings.Contains(errMsg, "NoSuchKey") ||
-		strings.Contains(errMsg, "NoSuchBucket") {
+	if code := apiErrorCode(err); code != "" && isNotFoundErrorCode(code) {
 		return true
 	}
-
-	// Try to extract error code using AWS API error interface
-	var notFoundCode bool
-
-	// First try using type assertion for mock tests
-	if mockErr, ok := err.(interface{ ErrorCode() string }); ok && mockErr != nil {
-		code := mockErr.ErrorCode()
-		return isNotFoundErrorCode(code)
-	}
-
-	// Then try using the smithy.APIError interface
-	if !notFoundCode {
-		var apiErr smithy.APIError
-		if stderrs.As(err, &apiErr) && apiErr != nil {
-			code := apiErr.ErrorCode()
-			return isNotFoundErrorCode(code)
-		}
-	}
-
-	return false
+	return containsAny(errMsg, notFoundMessageFragments)
 }
 
 // isNotFoundErrorCode checks if an error code indicates a resource was not found
 func isNotFoundErrorCode(code string) bool {
-	notFoundCodes := []string{
-		// EC2
-		"InvalidInstanceID.NotFound",
-		"InvalidInstanceID.Malformed",
-
-		// S3
-		"NoSuchBucket",
-		"NoSuchKey",
-
-		// Generic
-		"ResourceNotFoundException",
-		"EntityNotFoundException",
-		"NotFoundException",
-	}
-
-	for _, nfCode := range notFoundCodes {
-		if code == nfCode {
-			return true
-		}
-	}
+	return classifyErrorCode(code) == errors.CodeResourceNotFound
+}
 
-	return false
+// IsAuthError reports whether err is an AWS authentication or authorization
+// failure, either already mapped to CodePlatformAuthError or still a raw API error.
+func IsAuthError(err error) bool {
+	return hasMappedCode(err, errors.CodePlatformAuthError) || (err != nil && classifyAPIError(err) == errors.CodePlatformAuthError)
 }
 
 // DefaultErrorHandler implements the shared aws.ErrorHandler interface.
@@ -259,3 +259,25 @@ func canceledContext() context.Context {
 	cancel()
 	return ctx
 }
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "mapped auth error", err: errors.Wrap(fmt.Errorf("denied"), errors.CodePlatformAuthError, "auth failed"), expected: true},
+		{name: "auth error wrapped by API error", err: fmt.Errorf("list failed: %w", errors.New(errors.CodePlatformAuthError, "auth failed")), expected: true},
+		{name: "API error with expired token code", err: &mockAPIError{errorCode: "ExpiredToken", errorMsg: "token expired"}, expected: true},
+		{name: "access denied in message", err: fmt.Errorf("operation error S3: ListBuckets, AccessDenied: Access Denied"), expected: true},
+		{name: "throttling", err: &mockAPIError{errorCode: "Throttling", errorMsg: "Rate exceeded"}, expected: false},
+		{name: "not found", err: errors.New(errors.CodeResourceNotFound, "bucket not found"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsAuthError(tt.err))
+		})
+	}
+}
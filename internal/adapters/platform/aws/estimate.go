@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// defaultEstimatePageSize is the page size assumed for kinds whose handler
+// has no call model.
+const defaultEstimatePageSize = 100
+
+// EstimateAPICalls estimates the calls listing the given number of resources
+// of kind takes, from the call model of its handler. Kinds without one are
+// assumed to take a list call per page and a detail call per resource.
+func (p *Provider) EstimateAPICalls(kind domain.ResourceKind, resources int) ports.APICallEstimate {
+	if estimator, ok := p.handlers[kind].(CallEstimator); ok {
+		listCalls, detailCalls := estimator.EstimateCalls(resources)
+		return ports.APICallEstimate{ListCalls: listCalls, DetailCalls: detailCalls, Modeled: true}
+	}
+	return ports.APICallEstimate{
+		ListCalls:   shared.Pages(resources, defaultEstimatePageSize),
+		DetailCalls: resources,
+	}
+}
+
+// RequestsPerSecond returns the rate all AWS API calls are limited to.
+func (p *Provider) RequestsPerSecond() int {
+	return p.rps
+}
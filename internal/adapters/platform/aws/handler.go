@@ -24,3 +24,22 @@ type AWSResourceHandler interface {
 		logger ports.Logger,
 	) (domain.PlatformResource, error)
 }
+
+// BatchGetter is implemented by handlers whose API can describe several
+// resources in one call. IDs that do not exist are left out of the result.
+type BatchGetter interface {
+	GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error)
+}
+
+// HandlerProber is implemented by handlers that can verify their permissions
+// with a single cheap read call, used by the startup self-test.
+type HandlerProber interface {
+	Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error
+}
+
+// CallEstimator is implemented by handlers that can tell how many API calls
+// listing a number of resources takes, used to estimate a scan before running
+// it.
+type CallEstimator interface {
+	EstimateCalls(resources int) (listCalls, detailCalls int)
+}
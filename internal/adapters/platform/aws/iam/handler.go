@@ -0,0 +1,200 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+)
+
+// baseHandler holds the clients shared by the role and policy handlers. IAM is
+// a global service, so neither handler depends on the configured region.
+type baseHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	iamClient    IAMClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the IAM handlers.
+type HandlerOption func(*baseHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithIAMClient provides an option to set a custom IAM client.
+func WithIAMClient(client IAMClientInterface) HandlerOption {
+	return func(h *baseHandler) {
+		if client != nil {
+			h.iamClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *baseHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *baseHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+func newBaseHandler(cfg aws.Config, opts []HandlerOption) *baseHandler {
+	h := &baseHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		iamClient:    iam.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *baseHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "IAM: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+func (h *baseHandler) send(ctx context.Context, resource domain.PlatformResource, out chan<- domain.PlatformResource, logger ports.Logger) error {
+	select {
+	case out <- resource:
+		return nil
+	case <-ctx.Done():
+		logger.Warnf(ctx, "Context cancelled while sending IAM resource %s", resource.Metadata().ProviderAssignedID)
+		return ctx.Err()
+	}
+}
+
+// decodePolicyDocument decodes a policy document as returned by IAM, which
+// URL-encodes documents in its responses.
+func decodePolicyDocument(document *string) (string, error) {
+	if document == nil {
+		return "", nil
+	}
+	decoded, err := url.QueryUnescape(*document)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodePlatformAPIError, "IAM: failed to decode policy document")
+	}
+	return decoded, nil
+}
+
+func tagsToMap(tags []iamtypes.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		if tag.Key != nil {
+			result[*tag.Key] = aws.ToString(tag.Value)
+		}
+	}
+	return result
+}
+
+// matchesNameFilters applies the ID and name filters to a role or policy.
+// Comma separated values match any of the values.
+func matchesNameFilters(filters map[string]string, id, name string) bool {
+	if value, ok := filters[domain.KeyID]; ok && !containsValue(value, id) {
+		return false
+	}
+	if value, ok := filters[domain.KeyName]; ok && !containsValue(value, name) {
+		return false
+	}
+	return true
+}
+
+func matchesTagFilters(tags map[string]string, filters map[string]string) bool {
+	for key, value := range filters {
+		if !strings.HasPrefix(key, domain.TagPrefix) {
+			continue
+		}
+		actual, ok := tags[strings.TrimPrefix(key, domain.TagPrefix)]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
+
+func pathPrefix(filters map[string]string) *string {
+	if prefix, ok := filters[domain.IAMPathKey]; ok && prefix != "" {
+		return aws.String(prefix)
+	}
+	return nil
+}
+
+func notFound(kind, id string) error {
+	return errors.New(errors.CodeResourceNotFound, fmt.Sprintf("IAM %s '%s' not found (empty response)", kind, id))
+}
@@ -0,0 +1,267 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	iammocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/iam/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const testPolicyDocument = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+
+type IAMHandlerTestSuite struct {
+	suite.Suite
+	mockIAM          *iammocks.IAMClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	roleHandler      *RoleHandler
+	policyHandler    *PolicyHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *IAMHandlerTestSuite) SetupTest() {
+	s.mockIAM = new(iammocks.IAMClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	opts := []HandlerOption{
+		WithSTSClient(s.mockSTS),
+		WithIAMClient(s.mockIAM),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	}
+	s.roleHandler = NewRoleHandler(s.awsConfig, opts...)
+	s.policyHandler = NewPolicyHandler(s.awsConfig, opts...)
+}
+
+func (s *IAMHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestIAMHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(IAMHandlerTestSuite))
+}
+
+func iamRole(name, path string) iamtypes.Role {
+	return iamtypes.Role{
+		RoleName:                 aws.String(name),
+		Arn:                      aws.String("arn:aws:iam::123456789012:role" + path + name),
+		Path:                     aws.String(path),
+		AssumeRolePolicyDocument: aws.String(url.QueryEscape(testPolicyDocument)),
+	}
+}
+
+func (s *IAMHandlerTestSuite) expectRole(role iamtypes.Role, inline map[string]string, attached []string) {
+	name := aws.ToString(role.RoleName)
+	s.mockIAM.On("GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String(name)}).
+		Return(&iam.GetRoleOutput{Role: &role}, nil).Once()
+
+	var names []string
+	for policyName, document := range inline {
+		names = append(names, policyName)
+		s.mockIAM.On("GetRolePolicy", mock.Anything, &iam.GetRolePolicyInput{RoleName: aws.String(name), PolicyName: aws.String(policyName)}).
+			Return(&iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.QueryEscape(document))}, nil).Once()
+	}
+	s.mockIAM.On("ListRolePolicies", mock.Anything, mock.MatchedBy(func(in *iam.ListRolePoliciesInput) bool {
+		return aws.ToString(in.RoleName) == name
+	})).Return(&iam.ListRolePoliciesOutput{PolicyNames: names}, nil).Once()
+
+	var policies []iamtypes.AttachedPolicy
+	for _, arn := range attached {
+		policies = append(policies, iamtypes.AttachedPolicy{PolicyArn: aws.String(arn)})
+	}
+	s.mockIAM.On("ListAttachedRolePolicies", mock.Anything, mock.MatchedBy(func(in *iam.ListAttachedRolePoliciesInput) bool {
+		return aws.ToString(in.RoleName) == name
+	})).Return(&iam.ListAttachedRolePoliciesOutput{AttachedPolicies: policies}, nil).Once()
+}
+
+func collect(ctx context.Context, list func(context.Context, chan<- domain.PlatformResource) error) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := list(ctx, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *IAMHandlerTestSuite) listRoles(filters map[string]string) ([]domain.PlatformResource, error) {
+	return collect(s.ctx, func(ctx context.Context, out chan<- domain.PlatformResource) error {
+		return s.roleHandler.ListResources(ctx, s.awsConfig, filters, s.mockLogger, out)
+	})
+}
+
+func (s *IAMHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindIAMRole, s.roleHandler.Kind())
+	s.Equal(domain.KindIAMPolicy, s.policyHandler.Kind())
+}
+
+func (s *IAMHandlerTestSuite) TestListRoles_PaginatesAndFetchesPolicies() {
+	s.mockIAM.On("ListRoles", mock.Anything, mock.MatchedBy(func(in *iam.ListRolesInput) bool {
+		return in.Marker == nil
+	})).Return(&iam.ListRolesOutput{
+		Roles:       []iamtypes.Role{iamRole("orders-api", "/")},
+		IsTruncated: true,
+		Marker:      aws.String("page-2"),
+	}, nil).Once()
+	s.mockIAM.On("ListRoles", mock.Anything, mock.MatchedBy(func(in *iam.ListRolesInput) bool {
+		return aws.ToString(in.Marker) == "page-2"
+	})).Return(&iam.ListRolesOutput{
+		Roles: []iamtypes.Role{iamRole("AWSServiceRoleForSupport", serviceLinkedRolePath), iamRole("billing", "/")},
+	}, nil).Once()
+	s.expectRole(iamRole("orders-api", "/"), map[string]string{"read-orders": testPolicyDocument}, []string{
+		"arn:aws:iam::aws:policy/ReadOnlyAccess",
+		"arn:aws:iam::aws:policy/AWSLambdaExecute",
+	})
+	s.expectRole(iamRole("billing", "/"), nil, nil)
+
+	resources, err := s.listRoles(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("orders-api", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("billing", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(testPolicyDocument, attrs[domain.IAMAssumeRolePolicyKey])
+	s.Equal(map[string]string{"read-orders": testPolicyDocument}, attrs[domain.IAMInlinePoliciesKey])
+	s.Equal([]string{
+		"arn:aws:iam::aws:policy/AWSLambdaExecute",
+		"arn:aws:iam::aws:policy/ReadOnlyAccess",
+	}, attrs[domain.IAMManagedPolicyARNsKey])
+	// Service-linked roles are skipped without being fetched.
+	s.mockIAM.AssertNotCalled(s.T(), "GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("AWSServiceRoleForSupport")})
+	s.mockIAM.AssertExpectations(s.T())
+}
+
+func (s *IAMHandlerTestSuite) TestListRoles_Filters() {
+	s.mockIAM.On("ListRoles", mock.Anything, mock.Anything).Return(&iam.ListRolesOutput{
+		Roles: []iamtypes.Role{iamRole("orders-api", "/"), iamRole("orders-worker", "/"), iamRole("billing", "/")},
+	}, nil).Once()
+	prod := iamRole("orders-api", "/")
+	prod.Tags = []iamtypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}}
+	s.expectRole(prod, nil, nil)
+	dev := iamRole("orders-worker", "/")
+	dev.Tags = []iamtypes.Tag{{Key: aws.String("Env"), Value: aws.String("dev")}}
+	s.mockIAM.On("GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("orders-worker")}).
+		Return(&iam.GetRoleOutput{Role: &dev}, nil).Once()
+
+	resources, err := s.listRoles(map[string]string{
+		domain.KeyID: "orders-api, orders-worker",
+		"tag:Env":    "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("orders-api", resources[0].Metadata().ProviderAssignedID)
+	// The tag filter excludes orders-worker before its policies are listed.
+	s.mockIAM.AssertNotCalled(s.T(), "ListRolePolicies", mock.Anything, mock.MatchedBy(func(in *iam.ListRolePoliciesInput) bool {
+		return aws.ToString(in.RoleName) == "orders-worker"
+	}))
+	s.mockIAM.AssertNotCalled(s.T(), "GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("billing")})
+}
+
+func (s *IAMHandlerTestSuite) TestListRoles_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockIAM.On("ListRoles", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "IAM", "ListRoles:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.listRoles(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *IAMHandlerTestSuite) TestGetRole_EmptyResponse() {
+	s.mockIAM.On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{}, nil).Once()
+
+	_, err := s.roleHandler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *IAMHandlerTestSuite) TestListPolicies_FetchesDefaultVersion() {
+	arn := "arn:aws:iam::123456789012:policy/read-orders"
+	s.mockIAM.On("ListPolicies", mock.Anything, mock.MatchedBy(func(in *iam.ListPoliciesInput) bool {
+		return in.Scope == iamtypes.PolicyScopeTypeLocal
+	})).Return(&iam.ListPoliciesOutput{
+		Policies: []iamtypes.Policy{
+			{Arn: aws.String(arn), PolicyName: aws.String("read-orders")},
+			{Arn: aws.String("arn:aws:iam::123456789012:policy/other"), PolicyName: aws.String("other")},
+		},
+	}, nil).Once()
+	s.mockIAM.On("GetPolicy", mock.Anything, &iam.GetPolicyInput{PolicyArn: aws.String(arn)}).Return(&iam.GetPolicyOutput{
+		Policy: &iamtypes.Policy{
+			Arn:              aws.String(arn),
+			PolicyName:       aws.String("read-orders"),
+			Path:             aws.String("/"),
+			DefaultVersionId: aws.String("v3"),
+			Tags:             []iamtypes.Tag{{Key: aws.String("Team"), Value: aws.String("orders")}},
+		},
+	}, nil).Once()
+	s.mockIAM.On("GetPolicyVersion", mock.Anything, &iam.GetPolicyVersionInput{PolicyArn: aws.String(arn), VersionId: aws.String("v3")}).
+		Return(&iam.GetPolicyVersionOutput{
+			PolicyVersion: &iamtypes.PolicyVersion{Document: aws.String(url.QueryEscape(testPolicyDocument))},
+		}, nil).Once()
+
+	resources, err := collect(s.ctx, func(ctx context.Context, out chan<- domain.PlatformResource) error {
+		return s.policyHandler.ListResources(ctx, s.awsConfig, map[string]string{domain.KeyName: "read-orders"}, s.mockLogger, out)
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal(arn, resources[0].Metadata().ProviderAssignedID)
+	s.Equal("read-orders", resources[0].Metadata().SourceIdentifier)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(testPolicyDocument, attrs[domain.IAMPolicyDocumentKey])
+	s.Equal(map[string]string{"Team": "orders"}, attrs[domain.KeyTags])
+	s.mockIAM.AssertExpectations(s.T())
+}
+
+func (s *IAMHandlerTestSuite) TestProbe() {
+	s.mockIAM.On("ListRoles", mock.Anything, &iam.ListRolesInput{MaxItems: aws.Int32(probePageSize)}).
+		Return(&iam.ListRolesOutput{}, nil).Once()
+	s.mockIAM.On("ListPolicies", mock.Anything, &iam.ListPoliciesInput{Scope: iamtypes.PolicyScopeTypeLocal, MaxItems: aws.Int32(probePageSize)}).
+		Return(&iam.ListPoliciesOutput{}, nil).Once()
+
+	s.NoError(s.roleHandler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.NoError(s.policyHandler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockIAM.AssertExpectations(s.T())
+}
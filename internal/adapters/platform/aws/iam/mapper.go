@@ -0,0 +1,123 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// iamResource wraps a role or policy whose attributes are mapped once when the
+// resource is built. IAM is global, so resources carry no region.
+type iamResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *iamResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *iamResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newRoleResource(role Role, inline map[string]string, attached []string, accountID string) (domain.PlatformResource, error) {
+	name := aws.ToString(role.RoleName)
+	if name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create IAM role resource: missing role name")
+	}
+	return &iamResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindIAMRole,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: name,
+			SourceIdentifier:   name,
+			AccountID:          accountID,
+			PlatformDefault:    shared.RoleDefault(name, aws.ToString(role.Path)),
+		},
+		attrs: mapRoleToAttributes(role, inline, attached),
+	}, nil
+}
+
+func newPolicyResource(policy Policy, document string, accountID string) (domain.PlatformResource, error) {
+	arn := aws.ToString(policy.Arn)
+	if arn == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create IAM policy resource: missing policy ARN")
+	}
+	return &iamResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindIAMPolicy,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: arn,
+			SourceIdentifier:   aws.ToString(policy.PolicyName),
+			AccountID:          accountID,
+		},
+		attrs: mapPolicyToAttributes(policy, document),
+	}, nil
+}
+
+// mapRoleToAttributes maps a role. Description and permissions boundary are
+// always set, empty when unset, to match the empty strings Terraform records.
+func mapRoleToAttributes(role Role, inline map[string]string, attached []string) map[string]any {
+	attrs := map[string]any{
+		domain.KeyID:                     aws.ToString(role.RoleName),
+		domain.KeyName:                   aws.ToString(role.RoleName),
+		domain.KeyARN:                    aws.ToString(role.Arn),
+		domain.IAMPathKey:                aws.ToString(role.Path),
+		domain.IAMDescriptionKey:         aws.ToString(role.Description),
+		domain.IAMPermissionsBoundaryKey: "",
+	}
+	if role.MaxSessionDuration != nil {
+		attrs[domain.IAMMaxSessionDurationKey] = *role.MaxSessionDuration
+	}
+	if role.PermissionsBoundary != nil {
+		attrs[domain.IAMPermissionsBoundaryKey] = aws.ToString(role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	// A document that fails to decode is kept as returned so it still shows as drift.
+	if trust, err := decodePolicyDocument(role.AssumeRolePolicyDocument); err == nil {
+		attrs[domain.IAMAssumeRolePolicyKey] = trust
+	} else {
+		attrs[domain.IAMAssumeRolePolicyKey] = aws.ToString(role.AssumeRolePolicyDocument)
+	}
+	if len(inline) > 0 {
+		attrs[domain.IAMInlinePoliciesKey] = inline
+	}
+	if len(attached) > 0 {
+		attrs[domain.IAMManagedPolicyARNsKey] = attached
+	}
+	if tags := tagsToMap(role.Tags); len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
+
+func mapPolicyToAttributes(policy Policy, document string) map[string]any {
+	attrs := map[string]any{
+		domain.KeyID:             aws.ToString(policy.Arn),
+		domain.KeyARN:            aws.ToString(policy.Arn),
+		domain.KeyName:           aws.ToString(policy.PolicyName),
+		domain.IAMPathKey:        aws.ToString(policy.Path),
+		domain.IAMDescriptionKey: aws.ToString(policy.Description),
+	}
+	if document != "" {
+		attrs[domain.IAMPolicyDocumentKey] = document
+	}
+	if policy.DefaultVersionId != nil {
+		attrs["default_version_id"] = *policy.DefaultVersionId
+	}
+	if policy.AttachmentCount != nil {
+		attrs["attachment_count"] = *policy.AttachmentCount
+	}
+	if tags := tagsToMap(policy.Tags); len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+	}
+	return attrs
+}
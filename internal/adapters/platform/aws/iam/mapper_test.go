@@ -0,0 +1,80 @@
+package iam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapRoleToAttributes(t *testing.T) {
+	role := iamtypes.Role{
+		RoleName:                 aws.String("orders-api"),
+		Arn:                      aws.String("arn:aws:iam::123456789012:role/orders-api"),
+		Path:                     aws.String("/"),
+		MaxSessionDuration:       aws.Int32(3600),
+		AssumeRolePolicyDocument: aws.String(url.QueryEscape(testPolicyDocument)),
+		PermissionsBoundary: &iamtypes.AttachedPermissionsBoundary{
+			PermissionsBoundaryArn: aws.String("arn:aws:iam::123456789012:policy/boundary"),
+		},
+		Tags: []iamtypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}},
+	}
+
+	attrs := mapRoleToAttributes(role, map[string]string{"read-orders": testPolicyDocument}, []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"})
+
+	assert.Equal(t, "orders-api", attrs[domain.KeyID])
+	assert.Equal(t, "orders-api", attrs[domain.KeyName])
+	assert.Equal(t, int32(3600), attrs[domain.IAMMaxSessionDurationKey])
+	assert.Equal(t, testPolicyDocument, attrs[domain.IAMAssumeRolePolicyKey])
+	assert.Equal(t, "arn:aws:iam::123456789012:policy/boundary", attrs[domain.IAMPermissionsBoundaryKey])
+	assert.Equal(t, "", attrs[domain.IAMDescriptionKey])
+	assert.Equal(t, map[string]string{"read-orders": testPolicyDocument}, attrs[domain.IAMInlinePoliciesKey])
+	assert.Equal(t, []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"}, attrs[domain.IAMManagedPolicyARNsKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func TestMapRoleToAttributes_NoPolicies(t *testing.T) {
+	attrs := mapRoleToAttributes(iamtypes.Role{RoleName: aws.String("bare")}, nil, nil)
+
+	assert.Equal(t, "", attrs[domain.IAMPermissionsBoundaryKey])
+	assert.NotContains(t, attrs, domain.IAMInlinePoliciesKey)
+	assert.NotContains(t, attrs, domain.IAMManagedPolicyARNsKey)
+	assert.NotContains(t, attrs, domain.KeyTags)
+}
+
+func TestNewRoleResource_MissingName(t *testing.T) {
+	_, err := newRoleResource(iamtypes.Role{}, nil, nil, "123456789012")
+	require.Error(t, err)
+}
+
+func TestNewRoleResource_PlatformDefault(t *testing.T) {
+	testCases := []struct {
+		name, roleName, path, want string
+	}{
+		{"service-linked role", "AWSServiceRoleForRDS", "/aws-service-role/rds.amazonaws.com/", "service-linked role"},
+		{"service-linked name on another path", "AWSServiceRoleForSupport", "/", "service-linked role"},
+		{"identity center role", "AWSReservedSSO_Admin_0123456789abcdef", "/aws-reserved/sso.amazonaws.com/", "AWS reserved role"},
+		{"account role", "orders-api", "/", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource, err := newRoleResource(iamtypes.Role{RoleName: aws.String(tc.roleName), Path: aws.String(tc.path)}, nil, nil, "123456789012")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, resource.Metadata().PlatformDefault)
+		})
+	}
+}
+
+func TestDecodePolicyDocument(t *testing.T) {
+	decoded, err := decodePolicyDocument(aws.String("%7B%22Version%22%3A%222012-10-17%22%7D"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"Version":"2012-10-17"}`, decoded)
+
+	_, err = decodePolicyDocument(aws.String("%zz"))
+	require.Error(t, err)
+}
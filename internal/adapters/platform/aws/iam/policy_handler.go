@@ -0,0 +1,170 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// PolicyHandler lists the customer managed policies of the account with the
+// document of their default version. AWS managed policies are not listed.
+type PolicyHandler struct {
+	*baseHandler
+}
+
+// NewPolicyHandler creates a new PolicyHandler with the given AWS config and optional configurations.
+func NewPolicyHandler(cfg aws.Config, opts ...HandlerOption) *PolicyHandler {
+	return &PolicyHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *PolicyHandler) Kind() domain.ResourceKind {
+	return domain.KindIAMPolicy
+}
+
+// ListResources lists the customer managed policies. ListPolicies omits tags
+// and descriptions, so every policy passing the name filters is fetched with
+// GetPolicy, and its document only once the tag filters pass.
+func (h *PolicyHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for IAM policy ListResources: %v", accErr)
+	}
+
+	input := &iam.ListPoliciesInput{
+		Scope:      iamtypes.PolicyScopeTypeLocal,
+		MaxItems:   aws.Int32(listPageSize),
+		PathPrefix: pathPrefix(filters),
+	}
+
+	logger.Debugf(ctx, "Starting IAM policy listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.iamClient.ListPolicies(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("IAM", fmt.Sprintf("ListPolicies:Page%d", pageNum), err, ctx)
+		}
+
+		for _, listed := range output.Policies {
+			arn := aws.ToString(listed.Arn)
+			if !matchesNameFilters(filters, arn, aws.ToString(listed.PolicyName)) {
+				continue
+			}
+			policy, err := h.getPolicy(ctx, arn, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tagsToMap(policy.Tags), filters) {
+				continue
+			}
+			document, err := h.defaultVersionDocument(ctx, policy, logger)
+			if err != nil {
+				return err
+			}
+			resource, mapErr := newPolicyResource(policy, document, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for IAM policy %s, skipping", arn)
+				continue
+			}
+			if err := h.send(ctx, resource, out, logger); err != nil {
+				return err
+			}
+		}
+
+		if !output.IsTruncated || aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+
+	logger.Debugf(ctx, "Finished IAM policy pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a policy by ARN.
+func (h *PolicyHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single IAM policy %s", id)
+	policy, err := h.getPolicy(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+	document, err := h.defaultVersionDocument(ctx, policy, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for IAM policy GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newPolicyResource(policy, document, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for IAM policy %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that customer managed policies can be listed with a single minimal page.
+func (h *PolicyHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	input := &iam.ListPoliciesInput{Scope: iamtypes.PolicyScopeTypeLocal, MaxItems: aws.Int32(probePageSize)}
+	if _, err := h.iamClient.ListPolicies(ctx, input); err != nil {
+		return h.errorHandler.Handle("IAM", "ListPolicies", err, ctx)
+	}
+	return nil
+}
+
+func (h *PolicyHandler) getPolicy(ctx context.Context, arn string, logger ports.Logger) (Policy, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return Policy{}, err
+	}
+	output, err := h.iamClient.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(arn)})
+	if err != nil {
+		return Policy{}, h.errorHandler.Handle("IAM", "GetPolicy", err, ctx)
+	}
+	if output.Policy == nil {
+		return Policy{}, notFound("policy", arn)
+	}
+	return *output.Policy, nil
+}
+
+func (h *PolicyHandler) defaultVersionDocument(ctx context.Context, policy Policy, logger ports.Logger) (string, error) {
+	if policy.DefaultVersionId == nil {
+		return "", nil
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", err
+	}
+	output, err := h.iamClient.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: policy.Arn,
+		VersionId: policy.DefaultVersionId,
+	})
+	if err != nil {
+		return "", h.errorHandler.Handle("IAM", "GetPolicyVersion", err, ctx)
+	}
+	if output.PolicyVersion == nil {
+		return "", nil
+	}
+	return decodePolicyDocument(output.PolicyVersion.Document)
+}
@@ -0,0 +1,231 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// serviceLinkedRolePath is the path of roles created and owned by AWS services.
+// They cannot be managed by Terraform, so they are not listed unless a path
+// filter asks for them.
+const serviceLinkedRolePath = "/aws-service-role/"
+
+// RoleHandler lists IAM roles together with their inline policies and the
+// ARNs of their attached managed policies.
+type RoleHandler struct {
+	*baseHandler
+}
+
+// NewRoleHandler creates a new RoleHandler with the given AWS config and optional configurations.
+func NewRoleHandler(cfg aws.Config, opts ...HandlerOption) *RoleHandler {
+	return &RoleHandler{baseHandler: newBaseHandler(cfg, opts)}
+}
+
+func (h *RoleHandler) Kind() domain.ResourceKind {
+	return domain.KindIAMRole
+}
+
+// ListResources lists the roles of the account. ListRoles omits tags and
+// permissions boundaries, so every role passing the name filters is fetched
+// with GetRole, and its policies are only listed once the tag filters pass.
+func (h *RoleHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for IAM role ListResources: %v", accErr)
+	}
+
+	input := &iam.ListRolesInput{MaxItems: aws.Int32(listPageSize), PathPrefix: pathPrefix(filters)}
+	_, pathFiltered := filters[domain.IAMPathKey]
+
+	logger.Debugf(ctx, "Starting IAM role listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.iamClient.ListRoles(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("IAM", fmt.Sprintf("ListRoles:Page%d", pageNum), err, ctx)
+		}
+
+		for _, listed := range output.Roles {
+			name := aws.ToString(listed.RoleName)
+			if !pathFiltered && strings.HasPrefix(aws.ToString(listed.Path), serviceLinkedRolePath) {
+				continue
+			}
+			if !matchesNameFilters(filters, name, name) {
+				continue
+			}
+			role, err := h.getRole(ctx, name, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tagsToMap(role.Tags), filters) {
+				continue
+			}
+			inline, attached, err := h.rolePolicies(ctx, name, logger)
+			if err != nil {
+				return err
+			}
+			resource, mapErr := newRoleResource(role, inline, attached, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for IAM role %s, skipping", name)
+				continue
+			}
+			if err := h.send(ctx, resource, out, logger); err != nil {
+				return err
+			}
+		}
+
+		if !output.IsTruncated || aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+
+	logger.Debugf(ctx, "Finished IAM role pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *RoleHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single IAM role %s", id)
+	role, err := h.getRole(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for IAM role GetResource: %v", accErr)
+	}
+
+	inline, attached, err := h.rolePolicies(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, mapErr := newRoleResource(role, inline, attached, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for IAM role %s", id))
+	}
+	return resource, nil
+}
+
+// Probe verifies that roles can be listed with a single minimal page.
+func (h *RoleHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.iamClient.ListRoles(ctx, &iam.ListRolesInput{MaxItems: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("IAM", "ListRoles", err, ctx)
+	}
+	return nil
+}
+
+func (h *RoleHandler) getRole(ctx context.Context, name string, logger ports.Logger) (Role, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return Role{}, err
+	}
+	output, err := h.iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(name)})
+	if err != nil {
+		return Role{}, h.errorHandler.Handle("IAM", "GetRole", err, ctx)
+	}
+	if output.Role == nil {
+		return Role{}, notFound("role", name)
+	}
+	return *output.Role, nil
+}
+
+// rolePolicies returns the role's inline policy documents by name and the
+// sorted ARNs of its attached managed policies.
+func (h *RoleHandler) rolePolicies(ctx context.Context, roleName string, logger ports.Logger) (map[string]string, []string, error) {
+	inline, err := h.inlinePolicies(ctx, roleName, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	attached, err := h.attachedPolicyARNs(ctx, roleName, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inline, attached, nil
+}
+
+// inlinePolicies returns the decoded documents of the role's inline policies by name.
+func (h *RoleHandler) inlinePolicies(ctx context.Context, roleName string, logger ports.Logger) (map[string]string, error) {
+	var names []string
+	input := &iam.ListRolePoliciesInput{RoleName: aws.String(roleName), MaxItems: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.iamClient.ListRolePolicies(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("IAM", "ListRolePolicies", err, ctx)
+		}
+		names = append(names, output.PolicyNames...)
+		if !output.IsTruncated || aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+
+	policies := make(map[string]string, len(names))
+	for _, policyName := range names {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.iamClient.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(policyName)})
+		if err != nil {
+			return nil, h.errorHandler.Handle("IAM", "GetRolePolicy", err, ctx)
+		}
+		document, err := decodePolicyDocument(output.PolicyDocument)
+		if err != nil {
+			return nil, err
+		}
+		policies[policyName] = document
+	}
+	return policies, nil
+}
+
+// attachedPolicyARNs returns the sorted ARNs of the managed policies attached to the role.
+func (h *RoleHandler) attachedPolicyARNs(ctx context.Context, roleName string, logger ports.Logger) ([]string, error) {
+	var arns []string
+	input := &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName), MaxItems: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.iamClient.ListAttachedRolePolicies(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("IAM", "ListAttachedRolePolicies", err, ctx)
+		}
+		for _, policy := range output.AttachedPolicies {
+			if policy.PolicyArn != nil {
+				arns = append(arns, *policy.PolicyArn)
+			}
+		}
+		if !output.IsTruncated || aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+	sort.Strings(arns)
+	return arns, nil
+}
@@ -0,0 +1,403 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 100
+	probePageSize = 1
+	// defaultPolicyName is the only key policy name KMS supports.
+	defaultPolicyName = "default"
+	// awsManagedAliasPrefix marks the aliases of AWS managed keys.
+	awsManagedAliasPrefix = "alias/aws/"
+)
+
+// KeyHandler lists and fetches customer managed KMS keys together with their
+// policy, rotation status, tags and aliases. AWS managed keys are skipped, as
+// they cannot be managed by Terraform.
+type KeyHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	kmsClient    KMSClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the KeyHandler.
+type HandlerOption func(*KeyHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *KeyHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithKMSClient provides an option to set a custom KMS client.
+func WithKMSClient(client KMSClientInterface) HandlerOption {
+	return func(h *KeyHandler) {
+		if client != nil {
+			h.kmsClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *KeyHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *KeyHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new KeyHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *KeyHandler {
+	h := &KeyHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		kmsClient:    kms.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *KeyHandler) Kind() domain.ResourceKind {
+	return domain.KindEncryptionKey
+}
+
+func (h *KeyHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "KMS: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+// ListResources lists the customer managed keys of the region. The aliases of
+// the region are listed once up front, so the name filter can match a key by
+// any of its aliases. The ID and name filters are applied before a key is
+// described, tag filters before its policy and rotation status are fetched.
+func (h *KeyHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for KMS ListResources: %v", accErr)
+	}
+
+	aliasesByKey, err := h.listAliases(ctx, nil, logger)
+	if err != nil {
+		return err
+	}
+
+	input := &kms.ListKeysInput{Limit: aws.Int32(listPageSize)}
+
+	logger.Debugf(ctx, "Starting KMS key listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.kmsClient.ListKeys(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("KMS", fmt.Sprintf("ListKeys:Page%d", pageNum), err, ctx)
+		}
+
+		for _, entry := range output.Keys {
+			keyID := aws.ToString(entry.KeyId)
+			if !matchesNameFilters(filters, keyID, aws.ToString(entry.KeyArn), aliasesByKey[keyID]) {
+				continue
+			}
+			metadata, err := h.describeKey(ctx, keyID, logger)
+			if err != nil {
+				return err
+			}
+			if metadata.KeyManager != kmstypes.KeyManagerTypeCustomer {
+				continue
+			}
+			tags, err := h.listTags(ctx, keyID, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tags, filters) {
+				continue
+			}
+			details, err := h.keyDetails(ctx, metadata, tags, aliasesByKey[keyID], logger)
+			if err != nil {
+				return err
+			}
+			resource, mapErr := newKeyResource(details, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for KMS key %s, skipping", keyID)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending KMS key %s", keyID)
+				return ctx.Err()
+			}
+		}
+
+		if !output.Truncated || aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	logger.Debugf(ctx, "Finished KMS key pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a key by key ID, key ARN or alias name.
+func (h *KeyHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single KMS key %s", id)
+	metadata, err := h.describeKey(ctx, id, logger)
+	if err != nil {
+		return nil, err
+	}
+	keyID := aws.ToString(metadata.KeyId)
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for KMS GetResource: %v", accErr)
+	}
+
+	tags, err := h.listTags(ctx, keyID, logger)
+	if err != nil {
+		return nil, err
+	}
+	aliasesByKey, err := h.listAliases(ctx, aws.String(keyID), logger)
+	if err != nil {
+		return nil, err
+	}
+	details, err := h.keyDetails(ctx, metadata, tags, aliasesByKey[keyID], logger)
+	if err != nil {
+		return nil, err
+	}
+	resource, mapErr := newKeyResource(details, cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for KMS key %s", id))
+	}
+	return resource, nil
+}
+
+// EstimateCalls returns the calls listing the given number of keys takes: the
+// ListAliases and ListKeys pages, then DescribeKey, ListResourceTags,
+// GetKeyPolicy and GetKeyRotationStatus for every key.
+func (h *KeyHandler) EstimateCalls(resources int) (listCalls, detailCalls int) {
+	return 2 * shared.Pages(resources, listPageSize), resources * 4
+}
+
+// Probe verifies that keys can be listed with a single minimal page.
+func (h *KeyHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.kmsClient.ListKeys(ctx, &kms.ListKeysInput{Limit: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("KMS", "ListKeys", err, ctx)
+	}
+	return nil
+}
+
+func (h *KeyHandler) describeKey(ctx context.Context, keyID string, logger ports.Logger) (KeyMetadata, error) {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return KeyMetadata{}, err
+	}
+	output, err := h.kmsClient.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return KeyMetadata{}, h.errorHandler.Handle("KMS", "DescribeKey", err, ctx)
+	}
+	if output.KeyMetadata == nil {
+		return KeyMetadata{}, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("KMS key '%s' not found (empty response)", keyID))
+	}
+	return *output.KeyMetadata, nil
+}
+
+// keyDetails fetches the policy and, for keys that support automatic
+// rotation, the rotation status of a described key.
+func (h *KeyHandler) keyDetails(ctx context.Context, metadata KeyMetadata, tags map[string]string, aliases []string, logger ports.Logger) (keyDetails, error) {
+	details := keyDetails{metadata: metadata, tags: tags, aliases: aliases}
+	keyID := metadata.KeyId
+
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return keyDetails{}, err
+	}
+	policy, err := h.kmsClient.GetKeyPolicy(ctx, &kms.GetKeyPolicyInput{KeyId: keyID, PolicyName: aws.String(defaultPolicyName)})
+	if err != nil {
+		return keyDetails{}, h.errorHandler.Handle("KMS", "GetKeyPolicy", err, ctx)
+	}
+	details.policy = aws.ToString(policy.Policy)
+
+	if !supportsRotation(metadata) {
+		return details, nil
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return keyDetails{}, err
+	}
+	rotation, err := h.kmsClient.GetKeyRotationStatus(ctx, &kms.GetKeyRotationStatusInput{KeyId: keyID})
+	if err != nil {
+		return keyDetails{}, h.errorHandler.Handle("KMS", "GetKeyRotationStatus", err, ctx)
+	}
+	details.rotation = rotation
+	return details, nil
+}
+
+func (h *KeyHandler) listTags(ctx context.Context, keyID string, logger ports.Logger) (map[string]string, error) {
+	tags := make(map[string]string)
+	input := &kms.ListResourceTagsInput{KeyId: aws.String(keyID), Limit: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.kmsClient.ListResourceTags(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("KMS", "ListResourceTags", err, ctx)
+		}
+		for _, tag := range output.Tags {
+			if tag.TagKey != nil {
+				tags[*tag.TagKey] = aws.ToString(tag.TagValue)
+			}
+		}
+		if !output.Truncated || aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+	return tags, nil
+}
+
+// listAliases returns the alias names by target key ID, for all keys of the
+// region when keyID is nil. Aliases of AWS managed keys are left out.
+func (h *KeyHandler) listAliases(ctx context.Context, keyID *string, logger ports.Logger) (map[string][]string, error) {
+	aliasesByKey := make(map[string][]string)
+	input := &kms.ListAliasesInput{KeyId: keyID, Limit: aws.Int32(listPageSize)}
+	for {
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return nil, err
+		}
+		output, err := h.kmsClient.ListAliases(ctx, input)
+		if err != nil {
+			return nil, h.errorHandler.Handle("KMS", "ListAliases", err, ctx)
+		}
+		for _, alias := range output.Aliases {
+			name, target := aws.ToString(alias.AliasName), aws.ToString(alias.TargetKeyId)
+			if target == "" || strings.HasPrefix(name, awsManagedAliasPrefix) {
+				continue
+			}
+			aliasesByKey[target] = append(aliasesByKey[target], name)
+		}
+		if !output.Truncated || aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+	return aliasesByKey, nil
+}
+
+// supportsRotation reports whether automatic rotation applies to a key. KMS
+// only rotates symmetric encryption keys with key material it generated.
+func supportsRotation(metadata KeyMetadata) bool {
+	return metadata.KeySpec == kmstypes.KeySpecSymmetricDefault && metadata.Origin == kmstypes.OriginTypeAwsKms
+}
+
+// matchesNameFilters applies the ID filter to the key ID or ARN and the name
+// filter to the aliases of a key. Comma separated values match any of the values.
+func matchesNameFilters(filters map[string]string, keyID, arn string, aliases []string) bool {
+	if value, ok := filters[domain.KeyID]; ok && !containsValue(value, keyID) && !containsValue(value, arn) {
+		return false
+	}
+	if value, ok := filters[domain.KeyName]; ok {
+		for _, alias := range aliases {
+			if containsValue(value, alias) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTagFilters(tags map[string]string, filters map[string]string) bool {
+	for key, value := range filters {
+		if !strings.HasPrefix(key, domain.TagPrefix) {
+			continue
+		}
+		actual, ok := tags[strings.TrimPrefix(key, domain.TagPrefix)]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
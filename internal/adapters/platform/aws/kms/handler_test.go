@@ -0,0 +1,212 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	kmsmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/kms/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type KMSHandlerTestSuite struct {
+	suite.Suite
+	mockKMS          *kmsmocks.KMSClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *KeyHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *KMSHandlerTestSuite) SetupTest() {
+	s.mockKMS = new(kmsmocks.KMSClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithKMSClient(s.mockKMS),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *KMSHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestKMSHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(KMSHandlerTestSuite))
+}
+
+func keyMetadata(keyID string, manager kmstypes.KeyManagerType) *kmstypes.KeyMetadata {
+	return &kmstypes.KeyMetadata{
+		KeyId:      aws.String(keyID),
+		Arn:        aws.String("arn:aws:kms:us-east-1:123456789012:key/" + keyID),
+		Enabled:    true,
+		KeyManager: manager,
+		KeyUsage:   kmstypes.KeyUsageTypeEncryptDecrypt,
+		KeySpec:    kmstypes.KeySpecSymmetricDefault,
+		Origin:     kmstypes.OriginTypeAwsKms,
+	}
+}
+
+func (s *KMSHandlerTestSuite) expectKey(keyID string, tags []kmstypes.Tag) {
+	s.mockKMS.On("DescribeKey", mock.Anything, &kms.DescribeKeyInput{KeyId: aws.String(keyID)}).
+		Return(&kms.DescribeKeyOutput{KeyMetadata: keyMetadata(keyID, kmstypes.KeyManagerTypeCustomer)}, nil).Once()
+	s.mockKMS.On("ListResourceTags", mock.Anything, mock.MatchedBy(func(in *kms.ListResourceTagsInput) bool {
+		return aws.ToString(in.KeyId) == keyID
+	})).Return(&kms.ListResourceTagsOutput{Tags: tags}, nil).Once()
+}
+
+func (s *KMSHandlerTestSuite) expectDetails(keyID string) {
+	s.mockKMS.On("GetKeyPolicy", mock.Anything, &kms.GetKeyPolicyInput{KeyId: aws.String(keyID), PolicyName: aws.String("default")}).
+		Return(&kms.GetKeyPolicyOutput{Policy: aws.String(`{"Version":"2012-10-17","Statement":[]}`)}, nil).Once()
+	s.mockKMS.On("GetKeyRotationStatus", mock.Anything, &kms.GetKeyRotationStatusInput{KeyId: aws.String(keyID)}).
+		Return(&kms.GetKeyRotationStatusOutput{KeyRotationEnabled: true, RotationPeriodInDays: aws.Int32(365)}, nil).Once()
+}
+
+func (s *KMSHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *KMSHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindEncryptionKey, s.handler.Kind())
+}
+
+func (s *KMSHandlerTestSuite) TestListResources_FiltersAndSkipsAWSManagedKeys() {
+	s.mockKMS.On("ListAliases", mock.Anything, mock.MatchedBy(func(in *kms.ListAliasesInput) bool { return in.KeyId == nil })).
+		Return(&kms.ListAliasesOutput{Aliases: []kmstypes.AliasListEntry{
+			{AliasName: aws.String("alias/app"), TargetKeyId: aws.String("key-1")},
+			{AliasName: aws.String("alias/aws/s3"), TargetKeyId: aws.String("key-aws")},
+			{AliasName: aws.String("alias/unused")},
+		}}, nil).Once()
+	s.mockKMS.On("ListKeys", mock.Anything, mock.MatchedBy(func(in *kms.ListKeysInput) bool { return in.Marker == nil })).
+		Return(&kms.ListKeysOutput{
+			Keys:       []kmstypes.KeyListEntry{{KeyId: aws.String("key-1")}, {KeyId: aws.String("key-aws")}},
+			NextMarker: aws.String("page2"),
+			Truncated:  true,
+		}, nil).Once()
+	s.mockKMS.On("ListKeys", mock.Anything, mock.MatchedBy(func(in *kms.ListKeysInput) bool { return aws.ToString(in.Marker) == "page2" })).
+		Return(&kms.ListKeysOutput{Keys: []kmstypes.KeyListEntry{{KeyId: aws.String("key-2")}}}, nil).Once()
+
+	s.expectKey("key-1", []kmstypes.Tag{{TagKey: aws.String("Env"), TagValue: aws.String("prod")}})
+	s.expectDetails("key-1")
+	s.mockKMS.On("DescribeKey", mock.Anything, &kms.DescribeKeyInput{KeyId: aws.String("key-aws")}).
+		Return(&kms.DescribeKeyOutput{KeyMetadata: keyMetadata("key-aws", kmstypes.KeyManagerTypeAws)}, nil).Once()
+	s.expectKey("key-2", []kmstypes.Tag{{TagKey: aws.String("Env"), TagValue: aws.String("dev")}})
+
+	resources, err := s.collect(map[string]string{"tag:Env": "prod"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("key-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"alias/app"}, attrs[domain.EncryptionKeyAliasesKey])
+	s.Equal(true, attrs[domain.EncryptionKeyRotationKey])
+	s.mockKMS.AssertExpectations(s.T())
+}
+
+func (s *KMSHandlerTestSuite) TestListResources_NameFilterMatchesAliases() {
+	s.mockKMS.On("ListAliases", mock.Anything, mock.Anything).
+		Return(&kms.ListAliasesOutput{Aliases: []kmstypes.AliasListEntry{
+			{AliasName: aws.String("alias/app"), TargetKeyId: aws.String("key-1")},
+		}}, nil).Once()
+	s.mockKMS.On("ListKeys", mock.Anything, mock.Anything).
+		Return(&kms.ListKeysOutput{Keys: []kmstypes.KeyListEntry{{KeyId: aws.String("key-1")}, {KeyId: aws.String("key-2")}}}, nil).Once()
+	s.expectKey("key-1", nil)
+	s.expectDetails("key-1")
+
+	resources, err := s.collect(map[string]string{domain.KeyName: "alias/app"})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("key-1", resources[0].Metadata().ProviderAssignedID)
+	s.mockKMS.AssertNotCalled(s.T(), "DescribeKey", mock.Anything, &kms.DescribeKeyInput{KeyId: aws.String("key-2")})
+}
+
+func (s *KMSHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("access denied")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockKMS.On("ListAliases", mock.Anything, mock.Anything).Return(&kms.ListAliasesOutput{}, nil).Once()
+	s.mockKMS.On("ListKeys", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "KMS", "ListKeys:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *KMSHandlerTestSuite) TestGetResource_Success() {
+	s.expectKey("key-1", nil)
+	s.expectDetails("key-1")
+	s.mockKMS.On("ListAliases", mock.Anything, mock.MatchedBy(func(in *kms.ListAliasesInput) bool { return aws.ToString(in.KeyId) == "key-1" })).
+		Return(&kms.ListAliasesOutput{Aliases: []kmstypes.AliasListEntry{
+			{AliasName: aws.String("alias/b"), TargetKeyId: aws.String("key-1")},
+			{AliasName: aws.String("alias/a"), TargetKeyId: aws.String("key-1")},
+		}}, nil).Once()
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "key-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("key-1", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal([]string{"alias/a", "alias/b"}, attrs[domain.EncryptionKeyAliasesKey])
+	s.Equal(int64(365), attrs[domain.EncryptionKeyRotationPeriodKey])
+	s.mockKMS.AssertExpectations(s.T())
+}
+
+func (s *KMSHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockKMS.On("DescribeKey", mock.Anything, mock.Anything).Return(&kms.DescribeKeyOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *KMSHandlerTestSuite) TestProbe() {
+	s.mockKMS.On("ListKeys", mock.Anything, &kms.ListKeysInput{Limit: aws.Int32(probePageSize)}).Return(&kms.ListKeysOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockKMS.AssertExpectations(s.T())
+}
@@ -0,0 +1,99 @@
+package kms
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// keyDetails collects what is fetched for a key besides its metadata. The
+// rotation status is nil for keys that do not support automatic rotation.
+type keyDetails struct {
+	metadata KeyMetadata
+	policy   string
+	rotation *kms.GetKeyRotationStatusOutput
+	tags     map[string]string
+	aliases  []string
+}
+
+// keyResource wraps a KMS key whose attributes are mapped once when the
+// resource is built.
+type keyResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *keyResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *keyResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func newKeyResource(details keyDetails, region, accountID string) (domain.PlatformResource, error) {
+	keyID := aws.ToString(details.metadata.KeyId)
+	if keyID == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create KMS key resource: missing key ID")
+	}
+	if accountID == "" {
+		accountID = aws.ToString(details.metadata.AWSAccountId)
+	}
+	return &keyResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindEncryptionKey,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: keyID,
+			SourceIdentifier:   aws.ToString(details.metadata.Arn),
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapKeyToAttributes(details),
+	}, nil
+}
+
+// mapKeyToAttributes maps a key with its policy, rotation status, tags and
+// sorted aliases. The deletion window is only set for keys pending deletion.
+func mapKeyToAttributes(details keyDetails) map[string]any {
+	metadata := details.metadata
+	attrs := map[string]any{
+		domain.KeyID:                       aws.ToString(metadata.KeyId),
+		domain.KeyARN:                      aws.ToString(metadata.Arn),
+		domain.EncryptionKeyDescriptionKey: aws.ToString(metadata.Description),
+		domain.EncryptionKeyEnabledKey:     metadata.Enabled,
+		domain.EncryptionKeyUsageKey:       string(metadata.KeyUsage),
+		domain.EncryptionKeySpecKey:        string(metadata.KeySpec),
+		domain.EncryptionKeyMultiRegionKey: aws.ToBool(metadata.MultiRegion),
+	}
+	if details.policy != "" {
+		attrs[domain.EncryptionKeyPolicyKey] = details.policy
+	}
+	if rotation := details.rotation; rotation != nil {
+		attrs[domain.EncryptionKeyRotationKey] = rotation.KeyRotationEnabled
+		if period := aws.ToInt32(rotation.RotationPeriodInDays); rotation.KeyRotationEnabled && period > 0 {
+			attrs[domain.EncryptionKeyRotationPeriodKey] = int64(period)
+		}
+	}
+	if window := metadata.PendingDeletionWindowInDays; window != nil {
+		attrs[domain.EncryptionKeyDeletionWindowKey] = int64(*window)
+	}
+	if len(details.aliases) > 0 {
+		aliases := append([]string(nil), details.aliases...)
+		sort.Strings(aliases)
+		attrs[domain.EncryptionKeyAliasesKey] = aliases
+	}
+	if len(details.tags) > 0 {
+		attrs[domain.KeyTags] = details.tags
+	}
+	return attrs
+}
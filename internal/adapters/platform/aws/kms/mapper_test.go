@@ -0,0 +1,64 @@
+package kms
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapKeyToAttributes(t *testing.T) {
+	details := keyDetails{
+		metadata: kmstypes.KeyMetadata{
+			KeyId:       aws.String("1234abcd-12ab-34cd-56ef-1234567890ab"),
+			Arn:         aws.String("arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+			Description: aws.String("application data"),
+			Enabled:     true,
+			KeyUsage:    kmstypes.KeyUsageTypeEncryptDecrypt,
+			KeySpec:     kmstypes.KeySpecSymmetricDefault,
+			MultiRegion: aws.Bool(false),
+		},
+		policy:   `{"Version":"2012-10-17","Statement":[]}`,
+		rotation: &kms.GetKeyRotationStatusOutput{KeyRotationEnabled: true, RotationPeriodInDays: aws.Int32(180)},
+		tags:     map[string]string{"Env": "prod"},
+		aliases:  []string{"alias/b", "alias/a"},
+	}
+
+	attrs := mapKeyToAttributes(details)
+
+	assert.Equal(t, "1234abcd-12ab-34cd-56ef-1234567890ab", attrs[domain.KeyID])
+	assert.Equal(t, "application data", attrs[domain.EncryptionKeyDescriptionKey])
+	assert.Equal(t, true, attrs[domain.EncryptionKeyEnabledKey])
+	assert.Equal(t, "ENCRYPT_DECRYPT", attrs[domain.EncryptionKeyUsageKey])
+	assert.Equal(t, "SYMMETRIC_DEFAULT", attrs[domain.EncryptionKeySpecKey])
+	assert.Equal(t, false, attrs[domain.EncryptionKeyMultiRegionKey])
+	assert.Equal(t, details.policy, attrs[domain.EncryptionKeyPolicyKey])
+	assert.Equal(t, true, attrs[domain.EncryptionKeyRotationKey])
+	assert.Equal(t, int64(180), attrs[domain.EncryptionKeyRotationPeriodKey])
+	assert.Equal(t, []string{"alias/a", "alias/b"}, attrs[domain.EncryptionKeyAliasesKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	assert.NotContains(t, attrs, domain.EncryptionKeyDeletionWindowKey, "the window is only reported for keys pending deletion")
+}
+
+func TestMapKeyToAttributes_PendingDeletionWithoutRotation(t *testing.T) {
+	details := keyDetails{
+		metadata: kmstypes.KeyMetadata{
+			KeyId:                       aws.String("key-1"),
+			KeyState:                    kmstypes.KeyStatePendingDeletion,
+			KeySpec:                     kmstypes.KeySpecRsa2048,
+			PendingDeletionWindowInDays: aws.Int32(7),
+		},
+	}
+
+	attrs := mapKeyToAttributes(details)
+
+	assert.Equal(t, int64(7), attrs[domain.EncryptionKeyDeletionWindowKey])
+	assert.Equal(t, false, attrs[domain.EncryptionKeyEnabledKey])
+	assert.NotContains(t, attrs, domain.EncryptionKeyRotationKey)
+	assert.NotContains(t, attrs, domain.EncryptionKeyPolicyKey)
+	assert.NotContains(t, attrs, domain.EncryptionKeyAliasesKey)
+}
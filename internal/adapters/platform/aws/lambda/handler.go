@@ -0,0 +1,287 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize  = 50
+	probePageSize = 1
+)
+
+type LambdaHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	lambdaClient LambdaClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the LambdaHandler.
+type HandlerOption func(*LambdaHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *LambdaHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithLambdaClient provides an option to set a custom Lambda client.
+func WithLambdaClient(client LambdaClientInterface) HandlerOption {
+	return func(h *LambdaHandler) {
+		if client != nil {
+			h.lambdaClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *LambdaHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *LambdaHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new LambdaHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *LambdaHandler {
+	h := &LambdaHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		lambdaClient: lambda.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *LambdaHandler) Kind() domain.ResourceKind {
+	return domain.KindServerlessFunction
+}
+
+func (h *LambdaHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "Lambda: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+// ListResources lists the functions of the region. Lambda cannot filter
+// server side, so filters are applied after listing: ID and runtime filters
+// before the per-function tag lookup, tag and name filters after it.
+func (h *LambdaHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for Lambda ListResources: %v", accErr)
+	}
+
+	input := &lambda.ListFunctionsInput{MaxItems: aws.Int32(listPageSize)}
+	tagFilters := tagFiltersFrom(filters)
+
+	logger.Debugf(ctx, "Starting Lambda function listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.lambdaClient.ListFunctions(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("Lambda", fmt.Sprintf("ListFunctions:Page%d", pageNum), err, ctx)
+		}
+
+		for _, function := range output.Functions {
+			if !matchesAttributeFilters(function, filters) {
+				continue
+			}
+			tags, err := h.listTags(ctx, function, logger)
+			if err != nil {
+				return err
+			}
+			if !matchesTagFilters(tags, tagFilters) {
+				continue
+			}
+			resource, mapErr := newFunctionResource(function, tags, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Lambda function %s, skipping", aws.ToString(function.FunctionName))
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending Lambda function %s", aws.ToString(function.FunctionName))
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.NextMarker) == "" {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	logger.Debugf(ctx, "Finished Lambda pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *LambdaHandler) listTags(ctx context.Context, function FunctionConfiguration, logger ports.Logger) (map[string]string, error) {
+	if function.FunctionArn == nil {
+		return nil, nil
+	}
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+	output, err := h.lambdaClient.ListTags(ctx, &lambda.ListTagsInput{Resource: function.FunctionArn})
+	if err != nil {
+		return nil, h.errorHandler.Handle("Lambda", "ListTags", err, ctx)
+	}
+	return output.Tags, nil
+}
+
+func (h *LambdaHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single Lambda function %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.lambdaClient.GetFunction(ctx, &lambda.GetFunctionInput{FunctionName: aws.String(id)})
+	if err != nil {
+		return nil, h.errorHandler.Handle("Lambda", "GetFunction", err, ctx)
+	}
+	if output.Configuration == nil {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("Lambda function '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for Lambda GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newFunctionResource(*output.Configuration, output.Tags, cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for Lambda function %s", id))
+	}
+	return resource, nil
+}
+
+// EstimateCalls returns the calls listing the given number of functions
+// takes: the ListFunctions pages, then ListTags for every function.
+func (h *LambdaHandler) EstimateCalls(resources int) (listCalls, detailCalls int) {
+	return shared.Pages(resources, listPageSize), resources
+}
+
+// Probe verifies that functions can be listed with a single minimal page.
+func (h *LambdaHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.lambdaClient.ListFunctions(ctx, &lambda.ListFunctionsInput{MaxItems: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("Lambda", "ListFunctions", err, ctx)
+	}
+	return nil
+}
+
+// matchesAttributeFilters applies the ID and runtime filters. Comma separated
+// values match any of the values.
+func matchesAttributeFilters(function FunctionConfiguration, filters map[string]string) bool {
+	if value, ok := filters[domain.KeyID]; ok && !containsValue(value, aws.ToString(function.FunctionName)) {
+		return false
+	}
+	if value, ok := filters[domain.FunctionRuntimeKey]; ok && !containsValue(value, string(function.Runtime)) {
+		return false
+	}
+	return true
+}
+
+func tagFiltersFrom(genericFilters map[string]string) map[string]string {
+	tagFilters := make(map[string]string)
+	for key, value := range genericFilters {
+		if strings.HasPrefix(key, domain.TagPrefix) {
+			tagFilters[strings.TrimPrefix(key, domain.TagPrefix)] = value
+		} else if key == domain.KeyName {
+			tagFilters["Name"] = value
+		}
+	}
+	return tagFilters
+}
+
+func matchesTagFilters(tags map[string]string, tagFilters map[string]string) bool {
+	for key, value := range tagFilters {
+		actual, ok := tags[key]
+		if !ok || !containsValue(value, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(filterValue, actual string) bool {
+	for _, candidate := range strings.Split(filterValue, ",") {
+		if strings.TrimSpace(candidate) == actual {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	lambdamocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/lambda/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type LambdaHandlerTestSuite struct {
+	suite.Suite
+	mockLambda       *lambdamocks.LambdaClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *LambdaHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *LambdaHandlerTestSuite) SetupTest() {
+	s.mockLambda = new(lambdamocks.LambdaClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithLambdaClient(s.mockLambda),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *LambdaHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestLambdaHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LambdaHandlerTestSuite))
+}
+
+func lambdaFunction(name string, runtime lambdatypes.Runtime) lambdatypes.FunctionConfiguration {
+	return lambdatypes.FunctionConfiguration{
+		FunctionName: aws.String(name),
+		FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:" + name),
+		Runtime:      runtime,
+		MemorySize:   aws.Int32(128),
+	}
+}
+
+func (s *LambdaHandlerTestSuite) expectTags(name string, tags map[string]string) {
+	s.mockLambda.On("ListTags", mock.Anything, &lambda.ListTagsInput{
+		Resource: aws.String("arn:aws:lambda:us-east-1:123456789012:function:" + name),
+	}).Return(&lambda.ListTagsOutput{Tags: tags}, nil).Once()
+}
+
+func (s *LambdaHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *LambdaHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindServerlessFunction, s.handler.Kind())
+}
+
+func (s *LambdaHandlerTestSuite) TestListResources_Paginates() {
+	s.mockLambda.On("ListFunctions", mock.Anything, mock.MatchedBy(func(in *lambda.ListFunctionsInput) bool {
+		return in.Marker == nil
+	})).Return(&lambda.ListFunctionsOutput{
+		Functions:  []lambdatypes.FunctionConfiguration{lambdaFunction("fn-1", lambdatypes.RuntimeNodejs20x)},
+		NextMarker: aws.String("page-2"),
+	}, nil).Once()
+	s.mockLambda.On("ListFunctions", mock.Anything, mock.MatchedBy(func(in *lambda.ListFunctionsInput) bool {
+		return aws.ToString(in.Marker) == "page-2"
+	})).Return(&lambda.ListFunctionsOutput{
+		Functions: []lambdatypes.FunctionConfiguration{lambdaFunction("fn-2", lambdatypes.RuntimeNodejs20x)},
+	}, nil).Once()
+	s.expectTags("fn-1", map[string]string{"Env": "prod"})
+	s.expectTags("fn-2", nil)
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("fn-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("fn-2", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	attrs, err := resources[0].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	s.mockLambda.AssertExpectations(s.T())
+}
+
+func (s *LambdaHandlerTestSuite) TestListResources_Filters() {
+	s.mockLambda.On("ListFunctions", mock.Anything, mock.Anything).Return(&lambda.ListFunctionsOutput{
+		Functions: []lambdatypes.FunctionConfiguration{
+			lambdaFunction("fn-prod", lambdatypes.RuntimePython312),
+			lambdaFunction("fn-dev", lambdatypes.RuntimePython312),
+			lambdaFunction("fn-node", lambdatypes.RuntimeNodejs20x),
+		},
+	}, nil).Once()
+	s.expectTags("fn-prod", map[string]string{"Env": "prod"})
+	s.expectTags("fn-dev", map[string]string{"Env": "dev"})
+
+	resources, err := s.collect(map[string]string{
+		domain.FunctionRuntimeKey: "python3.12, python3.11",
+		"tag:Env":                 "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("fn-prod", resources[0].Metadata().ProviderAssignedID)
+	// The runtime filter excludes fn-node before its tags are listed.
+	s.mockLambda.AssertNotCalled(s.T(), "ListTags", mock.Anything, &lambda.ListTagsInput{
+		Resource: aws.String("arn:aws:lambda:us-east-1:123456789012:function:fn-node"),
+	})
+}
+
+func (s *LambdaHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockLambda.On("ListFunctions", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "Lambda", "ListFunctions:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *LambdaHandlerTestSuite) TestGetResource_Success() {
+	config := lambdaFunction("fn-1", lambdatypes.RuntimeNodejs20x)
+	s.mockLambda.On("GetFunction", mock.Anything, &lambda.GetFunctionInput{FunctionName: aws.String("fn-1")}).
+		Return(&lambda.GetFunctionOutput{Configuration: &config, Tags: map[string]string{"Env": "prod"}}, nil).Once()
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "fn-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("fn-1", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(int32(128), attrs[domain.FunctionMemorySizeKey])
+	s.Equal(map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func (s *LambdaHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockLambda.On("GetFunction", mock.Anything, mock.Anything).
+		Return(&lambda.GetFunctionOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *LambdaHandlerTestSuite) TestProbe() {
+	s.mockLambda.On("ListFunctions", mock.Anything, &lambda.ListFunctionsInput{MaxItems: aws.Int32(probePageSize)}).
+		Return(&lambda.ListFunctionsOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockLambda.AssertExpectations(s.T())
+}
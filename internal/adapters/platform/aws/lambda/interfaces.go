@@ -0,0 +1,20 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+//go:generate mockery --name LambdaClientInterface --output ./mocks --outpkg mocks --case underscore
+
+// LambdaClientInterface defines the methods needed from the AWS SDK Lambda client.
+// ListFunctions does not return tags, so they are listed per function.
+type LambdaClientInterface interface {
+	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+	GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error)
+	ListTags(ctx context.Context, params *lambda.ListTagsInput, optFns ...func(*lambda.Options)) (*lambda.ListTagsOutput, error)
+}
+
+type FunctionConfiguration = lambdatypes.FunctionConfiguration // Alias lambdatypes.FunctionConfiguration for easier use
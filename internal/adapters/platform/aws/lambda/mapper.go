@@ -0,0 +1,128 @@
+package lambda
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// functionResource wraps a listed function and its tags, which are mapped once
+// when the resource is built.
+type functionResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newFunctionResource(function FunctionConfiguration, tags map[string]string, region, accountID string) (domain.PlatformResource, error) {
+	name := aws.ToString(function.FunctionName)
+	if name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create Lambda resource: missing function name")
+	}
+
+	return &functionResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindServerlessFunction,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: name,
+			SourceIdentifier:   name,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		attrs: mapFunctionToAttributes(function, tags),
+	}, nil
+}
+
+func (r *functionResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *functionResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapFunctionToAttributes(function FunctionConfiguration, tags map[string]string) map[string]any {
+	attrs := map[string]any{}
+
+	setString := func(key string, v *string) {
+		if v != nil {
+			attrs[key] = *v
+		}
+	}
+	setInt := func(key string, v *int32) {
+		if v != nil {
+			attrs[key] = *v
+		}
+	}
+
+	setString(domain.KeyID, function.FunctionName)
+	setString(domain.KeyARN, function.FunctionArn)
+	setString(domain.FunctionHandlerKey, function.Handler)
+	setInt(domain.FunctionMemorySizeKey, function.MemorySize)
+	setInt(domain.FunctionTimeoutKey, function.Timeout)
+	setString(domain.FunctionRoleKey, function.Role)
+	setString(domain.FunctionDescriptionKey, function.Description)
+	setString(domain.FunctionKMSKeyARNKey, function.KMSKeyArn)
+	setString("version", function.Version)
+	setString("last_modified", function.LastModified)
+
+	if function.Runtime != "" {
+		attrs[domain.FunctionRuntimeKey] = string(function.Runtime)
+	}
+	if function.PackageType != "" {
+		attrs[domain.FunctionPackageTypeKey] = string(function.PackageType)
+	}
+	if len(function.Architectures) > 0 {
+		architectures := make([]string, len(function.Architectures))
+		for i, arch := range function.Architectures {
+			architectures[i] = string(arch)
+		}
+		attrs[domain.FunctionArchitecturesKey] = architectures
+	}
+	if function.TracingConfig != nil && function.TracingConfig.Mode != "" {
+		attrs[domain.FunctionTracingModeKey] = string(function.TracingConfig.Mode)
+	}
+	if function.EphemeralStorage != nil {
+		setInt(domain.FunctionEphemeralStorageKey, function.EphemeralStorage.Size)
+	}
+	if function.Environment != nil && len(function.Environment.Variables) > 0 {
+		attrs[domain.FunctionEnvironmentKey] = function.Environment.Variables
+	}
+	if len(function.Layers) > 0 {
+		layers := make([]string, 0, len(function.Layers))
+		for _, layer := range function.Layers {
+			if layer.Arn != nil {
+				layers = append(layers, *layer.Arn)
+			}
+		}
+		attrs[domain.FunctionLayersKey] = layers
+	}
+	// A function outside a VPC reports an empty VpcConfig rather than none.
+	if vpc := function.VpcConfig; vpc != nil && (len(vpc.SubnetIds) > 0 || len(vpc.SecurityGroupIds) > 0) {
+		subnets := append([]string(nil), vpc.SubnetIds...)
+		securityGroups := append([]string(nil), vpc.SecurityGroupIds...)
+		sort.Strings(subnets)
+		sort.Strings(securityGroups)
+		attrs[domain.FunctionVPCConfigKey] = map[string]any{"subnet_ids": subnets, "security_group_ids": securityGroups}
+	}
+
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+		if name, ok := tags["Name"]; ok {
+			attrs[domain.KeyName] = name
+		}
+	}
+	if _, ok := attrs[domain.KeyName]; !ok {
+		attrs[domain.KeyName] = attrs[domain.KeyID]
+	}
+
+	return attrs
+}
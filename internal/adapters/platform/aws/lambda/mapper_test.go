@@ -0,0 +1,86 @@
+package lambda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapFunctionToAttributes(t *testing.T) {
+	function := lambdatypes.FunctionConfiguration{
+		FunctionName:     aws.String("orders-api"),
+		FunctionArn:      aws.String("arn:aws:lambda:us-east-1:123456789012:function:orders-api"),
+		Runtime:          lambdatypes.RuntimePython312,
+		Handler:          aws.String("app.handler"),
+		MemorySize:       aws.Int32(256),
+		Timeout:          aws.Int32(30),
+		Role:             aws.String("arn:aws:iam::123456789012:role/orders-api"),
+		PackageType:      lambdatypes.PackageTypeZip,
+		Architectures:    []lambdatypes.Architecture{lambdatypes.ArchitectureArm64},
+		TracingConfig:    &lambdatypes.TracingConfigResponse{Mode: lambdatypes.TracingModeActive},
+		EphemeralStorage: &lambdatypes.EphemeralStorage{Size: aws.Int32(512)},
+		Environment:      &lambdatypes.EnvironmentResponse{Variables: map[string]string{"STAGE": "prod"}},
+		Layers:           []lambdatypes.Layer{{Arn: aws.String("arn:aws:lambda:us-east-1:123456789012:layer:deps:3")}},
+		VpcConfig: &lambdatypes.VpcConfigResponse{
+			SubnetIds:        []string{"subnet-b", "subnet-a"},
+			SecurityGroupIds: []string{"sg-1"},
+			VpcId:            aws.String("vpc-1"),
+		},
+	}
+
+	attrs := mapFunctionToAttributes(function, map[string]string{"Env": "prod"})
+
+	assert.Equal(t, "orders-api", attrs[domain.KeyID])
+	assert.Equal(t, "orders-api", attrs[domain.KeyName])
+	assert.Equal(t, "python3.12", attrs[domain.FunctionRuntimeKey])
+	assert.Equal(t, "app.handler", attrs[domain.FunctionHandlerKey])
+	assert.Equal(t, int32(256), attrs[domain.FunctionMemorySizeKey])
+	assert.Equal(t, int32(30), attrs[domain.FunctionTimeoutKey])
+	assert.Equal(t, "Zip", attrs[domain.FunctionPackageTypeKey])
+	assert.Equal(t, []string{"arm64"}, attrs[domain.FunctionArchitecturesKey])
+	assert.Equal(t, "Active", attrs[domain.FunctionTracingModeKey])
+	assert.Equal(t, int32(512), attrs[domain.FunctionEphemeralStorageKey])
+	assert.Equal(t, map[string]string{"STAGE": "prod"}, attrs[domain.FunctionEnvironmentKey])
+	assert.Equal(t, []string{"arn:aws:lambda:us-east-1:123456789012:layer:deps:3"}, attrs[domain.FunctionLayersKey])
+	assert.Equal(t, map[string]any{
+		"subnet_ids":         []string{"subnet-a", "subnet-b"},
+		"security_group_ids": []string{"sg-1"},
+	}, attrs[domain.FunctionVPCConfigKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+}
+
+func TestMapFunctionToAttributes_NoVPC(t *testing.T) {
+	attrs := mapFunctionToAttributes(lambdatypes.FunctionConfiguration{
+		FunctionName: aws.String("orders-api"),
+		VpcConfig:    &lambdatypes.VpcConfigResponse{SubnetIds: []string{}, SecurityGroupIds: []string{}},
+		Environment:  &lambdatypes.EnvironmentResponse{},
+	}, nil)
+
+	assert.NotContains(t, attrs, domain.FunctionVPCConfigKey)
+	assert.NotContains(t, attrs, domain.FunctionEnvironmentKey)
+	assert.NotContains(t, attrs, domain.KeyTags)
+}
+
+func TestNewFunctionResource(t *testing.T) {
+	res, err := newFunctionResource(lambdatypes.FunctionConfiguration{FunctionName: aws.String("orders-api")},
+		map[string]string{"Name": "Orders API"}, "eu-west-1", "123456789012")
+	require.NoError(t, err)
+
+	meta := res.Metadata()
+	assert.Equal(t, domain.KindServerlessFunction, meta.Kind)
+	assert.Equal(t, "orders-api", meta.ProviderAssignedID)
+	assert.Equal(t, "eu-west-1", meta.Region)
+
+	attrs, err := res.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Orders API", attrs[domain.KeyName])
+
+	_, err = newFunctionResource(lambdatypes.FunctionConfiguration{}, nil, "eu-west-1", "")
+	assert.Error(t, err)
+}
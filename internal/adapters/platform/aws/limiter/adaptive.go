@@ -0,0 +1,183 @@
+package limiter
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultAdaptiveMinRPS   = 1
+	defaultAdaptiveRecovery = 30 * time.Second
+	// throttleCooldown is how long after a service's rate was shrunk further
+	// throttles are attributed to the same burst of calls and ignored, so that
+	// concurrent calls throttled together halve the rate only once.
+	throttleCooldown = time.Second
+)
+
+// AdaptiveConfig configures adaptive rate limiting, which keeps one token
+// bucket per AWS service and shrinks a service's rate when it throttles.
+type AdaptiveConfig struct {
+	// MinRPS is the floor a throttled service's rate shrinks to.
+	MinRPS int `yaml:"min_rps" mapstructure:"min_rps" validate:"omitempty,min=1"`
+	// Recovery is how long a throttled service takes to climb back to its full
+	// rate when it is not throttled again.
+	Recovery time.Duration `yaml:"recovery" mapstructure:"recovery" validate:"omitempty,min=0"`
+	// ServiceRPS sets the full rate of individual services, keyed by SDK
+	// service ID such as "EC2", "S3" or "STS". Other services use api_rps.
+	ServiceRPS map[string]int `yaml:"service_rps" mapstructure:"service_rps" validate:"omitempty,dive,min=1,max=100"`
+}
+
+// AdaptiveLimiter rate limits AWS API calls per service. A service that
+// throttles has its rate halved, down to MinRPS, and recovers linearly to its
+// full rate over the recovery period, so S3 returning SlowDown does not slow
+// down EC2 or STS calls.
+type AdaptiveLimiter struct {
+	fullRPS    int
+	minRPS     int
+	recovery   time.Duration
+	serviceRPS map[string]int
+	logger     ports.Logger
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*serviceBucket
+}
+
+// NewAdaptiveLimiter creates an adaptive limiter whose services run at fullRPS
+// unless cfg sets a rate for them.
+func NewAdaptiveLimiter(fullRPS int, cfg AdaptiveConfig, logger ports.Logger) *AdaptiveLimiter {
+	if fullRPS <= 0 {
+		fullRPS = defaultRateLimitRPS
+	}
+	l := &AdaptiveLimiter{
+		fullRPS:    fullRPS,
+		minRPS:     cfg.MinRPS,
+		recovery:   cfg.Recovery,
+		serviceRPS: make(map[string]int, len(cfg.ServiceRPS)),
+		logger:     logger,
+		now:        time.Now,
+		buckets:    make(map[string]*serviceBucket),
+	}
+	if l.minRPS <= 0 {
+		l.minRPS = defaultAdaptiveMinRPS
+	}
+	if l.recovery <= 0 {
+		l.recovery = defaultAdaptiveRecovery
+	}
+	for service, rps := range cfg.ServiceRPS {
+		l.serviceRPS[strings.ToLower(service)] = rps
+	}
+	return l
+}
+
+// Wait blocks until the bucket of service allows another call.
+func (l *AdaptiveLimiter) Wait(ctx context.Context, service string) error {
+	b := l.bucket(service)
+	b.recover(l.now())
+	return b.limiter.Wait(ctx)
+}
+
+// Throttled shrinks the rate of service after it returned a throttling error.
+func (l *AdaptiveLimiter) Throttled(service string) {
+	b := l.bucket(service)
+	if rps, shrunk := b.throttle(l.now()); shrunk {
+		l.logger.Warnf(context.Background(), "AWS %s throttled requests, reducing its rate to %.1f RPS", b.service, rps)
+	}
+}
+
+// Rate returns the current rate of service in requests per second.
+func (l *AdaptiveLimiter) Rate(service string) float64 {
+	b := l.bucket(service)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current(l.now())
+}
+
+func (l *AdaptiveLimiter) bucket(service string) *serviceBucket {
+	key := strings.ToLower(service)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	full := l.fullRPS
+	if rps, ok := l.serviceRPS[key]; ok {
+		full = rps
+	}
+	b := &serviceBucket{
+		service:  service,
+		full:     float64(full),
+		min:      math.Min(float64(l.minRPS), float64(full)),
+		recovery: l.recovery,
+		limiter:  rate.NewLimiter(rate.Limit(full), full),
+	}
+	l.buckets[key] = b
+	return b
+}
+
+// serviceBucket is the token bucket of one service.
+type serviceBucket struct {
+	service  string
+	full     float64
+	min      float64
+	recovery time.Duration
+	limiter  *rate.Limiter
+
+	mu sync.Mutex
+	// throttledAt is when the rate was last shrunk, and throttledRPS the rate
+	// it was shrunk to.
+	throttledAt  time.Time
+	throttledRPS float64
+}
+
+// current returns the rate at now: the throttled rate, climbing linearly back
+// to the full rate over the recovery period. Callers hold b.mu.
+func (b *serviceBucket) current(now time.Time) float64 {
+	if b.throttledAt.IsZero() {
+		return b.full
+	}
+	elapsed := now.Sub(b.throttledAt)
+	if elapsed >= b.recovery {
+		return b.full
+	}
+	return b.throttledRPS + (b.full-b.throttledRPS)*float64(elapsed)/float64(b.recovery)
+}
+
+// recover applies the recovered rate to the token bucket.
+func (b *serviceBucket) recover(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.throttledAt.IsZero() {
+		return
+	}
+	b.apply(now, b.current(now))
+	if now.Sub(b.throttledAt) >= b.recovery {
+		b.throttledAt = time.Time{}
+	}
+}
+
+// throttle halves the rate, unless it was already halved within the cooldown,
+// and returns the new rate.
+func (b *serviceBucket) throttle(now time.Time) (float64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.throttledAt.IsZero() && now.Sub(b.throttledAt) < throttleCooldown {
+		return 0, false
+	}
+	rps := math.Max(b.min, b.current(now)/2)
+	b.throttledAt, b.throttledRPS = now, rps
+	b.apply(now, rps)
+	return rps, true
+}
+
+// apply sets the token bucket to rps, with a burst of one second of calls.
+func (b *serviceBucket) apply(now time.Time, rps float64) {
+	b.limiter.SetLimitAt(now, rate.Limit(rps))
+	b.limiter.SetBurstAt(now, int(math.Max(1, math.Ceil(rps))))
+}
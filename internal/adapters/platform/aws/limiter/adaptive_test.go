@@ -0,0 +1,81 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+)
+
+func newTestAdaptiveLimiter(t *testing.T, cfg AdaptiveConfig) (*AdaptiveLimiter, *time.Time) {
+	t.Helper()
+	logger := portsmocks.NewLogger(t)
+	logger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Maybe().Return()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	l := NewAdaptiveLimiter(20, cfg, logger)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestAdaptiveLimiter_ServiceRates(t *testing.T) {
+	l, _ := newTestAdaptiveLimiter(t, AdaptiveConfig{ServiceRPS: map[string]int{"s3": 50}})
+
+	assert.Equal(t, 20.0, l.Rate("EC2"))
+	assert.Equal(t, 50.0, l.Rate("S3"), "service rates are matched case-insensitively")
+}
+
+func TestAdaptiveLimiter_ThrottleShrinksOnlyThatService(t *testing.T) {
+	l, now := newTestAdaptiveLimiter(t, AdaptiveConfig{})
+
+	l.Throttled("S3")
+
+	assert.Equal(t, 10.0, l.Rate("S3"))
+	assert.Equal(t, 20.0, l.Rate("EC2"))
+
+	l.Throttled("S3")
+	assert.Equal(t, 10.0, l.Rate("S3"), "throttles within the cooldown count once")
+
+	*now = now.Add(throttleCooldown)
+	l.Throttled("S3")
+	assert.InDelta(t, 5.1667, l.Rate("S3"), 0.001, "halves the partly recovered rate")
+}
+
+func TestAdaptiveLimiter_ThrottleStopsAtMinRPS(t *testing.T) {
+	l, now := newTestAdaptiveLimiter(t, AdaptiveConfig{MinRPS: 4})
+
+	for i := 0; i < 5; i++ {
+		*now = now.Add(throttleCooldown)
+		l.Throttled("EC2")
+	}
+
+	assert.Equal(t, 4.0, l.Rate("EC2"))
+}
+
+func TestAdaptiveLimiter_Recovers(t *testing.T) {
+	l, now := newTestAdaptiveLimiter(t, AdaptiveConfig{Recovery: 10 * time.Second})
+
+	l.Throttled("STS")
+	*now = now.Add(5 * time.Second)
+	assert.Equal(t, 15.0, l.Rate("STS"), "recovers linearly")
+
+	*now = now.Add(5 * time.Second)
+	assert.Equal(t, 20.0, l.Rate("STS"))
+
+	require.NoError(t, l.Wait(context.Background(), "STS"))
+	b := l.bucket("STS")
+	assert.True(t, b.throttledAt.IsZero(), "a recovered bucket forgets the throttle")
+	assert.Equal(t, 20, b.limiter.Burst())
+}
+
+func TestAdaptiveLimiter_WaitHonoursContext(t *testing.T) {
+	l, _ := newTestAdaptiveLimiter(t, AdaptiveConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Error(t, l.Wait(ctx, "EC2"))
+}
@@ -0,0 +1,32 @@
+package limiter
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+)
+
+// adaptiveMiddlewareID identifies the adaptive limiter on a client's stack.
+const adaptiveMiddlewareID = "AdaptiveRateLimit"
+
+// AddToStack registers the limiter on an SDK client's middleware stack, for use
+// in aws.Config.APIOptions. It runs after the retry middleware, so every
+// attempt, retries included, waits for the bucket of its service and every
+// throttled attempt shrinks that bucket's rate.
+func (l *AdaptiveLimiter) AddToStack(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc(adaptiveMiddlewareID, l.handleFinalize), "Retry", middleware.After)
+}
+
+func (l *AdaptiveLimiter) handleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	service := awsmiddleware.GetServiceID(ctx)
+	if err := l.Wait(ctx, service); err != nil {
+		return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+	}
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if err != nil && aws_errors.IsThrottleError(err) {
+		l.Throttled(service)
+	}
+	return out, metadata, err
+}
@@ -0,0 +1,32 @@
+package limiter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+)
+
+func TestAdaptiveLimiter_Middleware(t *testing.T) {
+	fake := awsfake.NewServer(t)
+	l, _ := newTestAdaptiveLimiter(t, AdaptiveConfig{})
+	cfg := fake.Config()
+	cfg.APIOptions = append(cfg.APIOptions, l.AddToStack)
+	client := sts.NewFromConfig(cfg)
+
+	_, err := client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, l.Rate("STS"))
+
+	fake.Fail("GetCallerIdentity", awsfake.Fault{Status: http.StatusBadRequest, Code: "Throttling", Times: 1})
+	_, err = client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	require.Error(t, err)
+
+	assert.Equal(t, 10.0, l.Rate("STS"), "the throttled service is slowed down")
+	assert.Equal(t, 20.0, l.Rate("EC2"), "other services keep their rate")
+}
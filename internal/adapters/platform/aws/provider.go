@@ -6,14 +6,29 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	aws_retry "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/retry"
 	awstypes "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/autoscaling"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/cloudcontrol"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/cloudfront"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/dynamodb"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/ec2"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/ecs"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/elbv2"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/iam"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/kms"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/lambda"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/rds"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/s3"
 	"github.com/olusolaa/infra-drift-detector/internal/config"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
@@ -36,13 +51,62 @@ const (
 type Provider struct {
 	awsConfig aws.Config
 	handlers  map[domain.ResourceKind]AWSResourceHandler
+	stsClient awstypes.STSClientInterface
 	logger    ports.Logger
+	// primaryName describes the primary credentials in failover annotations.
+	primaryName string
+	// fallbacks are tried in order when the active credentials fail with an
+	// authentication error.
+	fallbacks []credentialSource
+	failover  failoverState
+	// rps is the rate all API calls are limited to.
+	rps int
 }
 
-func NewProvider(ctx context.Context, appCfg *config.Config, logger ports.Logger) (*Provider, error) {
+// defaultCredentialsName describes credentials from the default SDK chain.
+const defaultCredentialsName = "default credentials"
+
+type providerOptions struct {
+	apiMetrics     ports.APICallMetrics
+	tracerProvider trace.TracerProvider
+	attributeCache ports.AttributeCache
+}
+
+// ProviderOption configures optional provider behaviour.
+type ProviderOption func(*providerOptions)
+
+// WithAPICallMetrics records every AWS API operation, with its retries and
+// throttles, in metrics.
+func WithAPICallMetrics(metrics ports.APICallMetrics) ProviderOption {
+	return func(o *providerOptions) {
+		o.apiMetrics = metrics
+	}
+}
+
+// WithTracerProvider records a span for every AWS API operation, as a child
+// of the span in the context of the call.
+func WithTracerProvider(provider trace.TracerProvider) ProviderOption {
+	return func(o *providerOptions) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithAttributeCache reuses resource attributes that take several API calls
+// per resource to fetch, such as those of S3 buckets, across runs.
+func WithAttributeCache(cache ports.AttributeCache) ProviderOption {
+	return func(o *providerOptions) {
+		o.attributeCache = cache
+	}
+}
+
+func NewProvider(ctx context.Context, appCfg *config.Config, logger ports.Logger, opts ...ProviderOption) (*Provider, error) {
 	if logger == nil {
 		return nil, errors.New(errors.CodeConfigValidation, "logger cannot be nil for AWS Provider")
 	}
+	var options providerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	awsPlatformCfg := appCfg.Platform.AWS
 	if awsPlatformCfg == nil {
@@ -73,9 +137,25 @@ func NewProvider(ctx context.Context, appCfg *config.Config, logger ports.Logger
 		},
 	}
 
-	loadOpts := []func(*awsconfig.LoadOptions) error{
-		awsconfig.WithHTTPClient(httpClient),
+	var retryCfg aws_retry.Config
+	if awsPlatformCfg.Retry != nil {
+		retryCfg = *awsPlatformCfg.Retry
+	}
+	retryer := aws_retry.NewRetryer(retryCfg, logger)
+
+	baseLoadOpts := []func(*awsconfig.LoadOptions) error{awsconfig.WithHTTPClient(httpClient), awsconfig.WithRetryer(retryer)}
+	if adaptiveCfg := awsPlatformCfg.AdaptiveRateLimit; adaptiveCfg != nil {
+		adaptive := aws_limiter.NewAdaptiveLimiter(effectiveRPS, *adaptiveCfg, logger)
+		baseLoadOpts = append(baseLoadOpts, awsconfig.WithAPIOptions([]func(*middleware.Stack) error{adaptive.AddToStack}))
+		logger.Infof(ctx, "Adaptive per-service AWS API rate limiting enabled")
+	}
+	if options.apiMetrics != nil {
+		baseLoadOpts = append(baseLoadOpts, awsconfig.WithAPIOptions([]func(*middleware.Stack) error{aws_retry.MetricsMiddleware(options.apiMetrics)}))
 	}
+	if options.tracerProvider != nil {
+		baseLoadOpts = append(baseLoadOpts, awsconfig.WithAPIOptions([]func(*middleware.Stack) error{aws_retry.TracingMiddleware(options.tracerProvider)}))
+	}
+	loadOpts := append([]func(*awsconfig.LoadOptions) error{}, baseLoadOpts...)
 	var specifiedRegion, specifiedProfile string
 
 	if awsPlatformCfg.Region != "" {
@@ -83,8 +163,10 @@ func NewProvider(ctx context.Context, appCfg *config.Config, logger ports.Logger
 		loadOpts = append(loadOpts, awsconfig.WithRegion(specifiedRegion))
 		logger.Debugf(ctx, "AWS config: Using specified region", "region", specifiedRegion)
 	}
+	primaryName := defaultCredentialsName
 	if awsPlatformCfg.Profile != "" {
 		specifiedProfile = awsPlatformCfg.Profile
+		primaryName = "profile " + specifiedProfile
 		loadOpts = append(loadOpts, awsconfig.WithSharedConfigProfile(specifiedProfile))
 		logger.Debugf(ctx, "AWS config: Using specified profile", "profile", specifiedProfile)
 	}
@@ -100,22 +182,68 @@ func NewProvider(ctx context.Context, appCfg *config.Config, logger ports.Logger
 	logger.Infof(ctx, "AWS provider configured successfully", "region", awsCfg.Region, "profile_source", specifiedProfile, "region_source", specifiedRegion)
 
 	p := &Provider{
-		awsConfig: awsCfg,
-		handlers:  make(map[domain.ResourceKind]AWSResourceHandler),
-		logger:    logger,
+		awsConfig:   awsCfg,
+		handlers:    make(map[domain.ResourceKind]AWSResourceHandler),
+		stsClient:   sts.NewFromConfig(awsCfg),
+		logger:      logger,
+		primaryName: primaryName,
+		rps:         effectiveRPS,
 	}
 
-	p.registerHandler(ec2.NewHandler(awsCfg))
-	p.registerHandler(s3.NewHandler(awsCfg))
+	for _, handler := range newHandlers(awsCfg, appCfg, awsPlatformCfg, options.attributeCache) {
+		p.registerHandler(handler)
+	}
 
 	if len(p.handlers) == 0 {
 		return nil, errors.New(errors.CodeInternal, "no AWS resource handlers were registered")
 	}
 
+	for _, cred := range awsPlatformCfg.FallbackCredentials {
+		fallbackCfg, err := loadFallbackConfig(ctx, awsCfg, baseLoadOpts, cred)
+		if err != nil {
+			return nil, err
+		}
+		p.addFallback(credentialName(cred), fallbackCfg, newHandlers(fallbackCfg, appCfg, awsPlatformCfg, options.attributeCache)...)
+		logger.Infof(ctx, "AWS provider registered fallback credentials", "source", credentialName(cred))
+	}
+
 	logger.Infof(ctx, "AWS provider initialized", "handlers", p.getSupportedKinds())
 	return p, nil
 }
 
+// newHandlers creates the resource handlers for one credential source.
+func newHandlers(cfg aws.Config, appCfg *config.Config, awsPlatformCfg *config.AWSPlatformConfig, cache ports.AttributeCache) []AWSResourceHandler {
+	handlers := []AWSResourceHandler{ec2.NewHandler(cfg), ec2.NewSecurityGroupHandler(cfg), ec2.NewLaunchTemplateHandler(cfg), ec2.NewElasticIPHandler(cfg), ec2.NewNetworkInterfaceHandler(cfg), autoscaling.NewHandler(cfg)}
+	var s3Opts []s3.HandlerOption
+	if awsPlatformCfg.S3 != nil {
+		s3Opts = append(s3Opts, s3.WithConfig(*awsPlatformCfg.S3))
+	}
+	if cache != nil {
+		s3Opts = append(s3Opts, s3.WithAttributeCache(cache))
+	}
+	handlers = append(handlers, s3.NewHandler(cfg, s3Opts...))
+	handlers = append(handlers, rds.NewHandler(cfg))
+	handlers = append(handlers, dynamodb.NewHandler(cfg))
+	handlers = append(handlers, cloudfront.NewHandler(cfg))
+	handlers = append(handlers, ecs.NewClusterHandler(cfg), ecs.NewServiceHandler(cfg), ecs.NewTaskDefinitionHandler(cfg))
+	handlers = append(handlers, elbv2.NewLoadBalancerHandler(cfg), elbv2.NewListenerHandler(cfg), elbv2.NewTargetGroupHandler(cfg))
+	handlers = append(handlers, lambda.NewHandler(cfg))
+	handlers = append(handlers, iam.NewRoleHandler(cfg), iam.NewPolicyHandler(cfg))
+	handlers = append(handlers, kms.NewHandler(cfg))
+	defaults := ec2.NewDefaultsLookup(cfg)
+	for _, ck := range appCfg.CustomKinds {
+		if ck.Fetcher != cloudcontrol.FetcherCloudControl {
+			continue
+		}
+		handlers = append(handlers, cloudcontrol.NewHandler(cfg, cloudcontrol.KindDefinition{
+			Kind:        ck.Kind,
+			TypeName:    ck.TypeName,
+			PropertyMap: ck.PropertyMap,
+		}, cloudcontrol.WithPlatformDefaults(defaults)))
+	}
+	return handlers
+}
+
 func (p *Provider) registerHandler(handler AWSResourceHandler) {
 	if handler != nil {
 		kind := handler.Kind()
@@ -154,7 +282,7 @@ func (p *Provider) ListResources(
 	p.logger.Debugf(ctx, "Initiating AWS ListResources", "requested_kinds", requestedKinds)
 
 	for _, kind := range requestedKinds {
-		handler, found := p.handlers[kind]
+		_, found := p.handlers[kind]
 		if !found {
 			p.logger.Warnf(childCtx, "Resource kind not supported by AWS provider, skipping", "kind", kind)
 			continue
@@ -162,13 +290,12 @@ func (p *Provider) ListResources(
 		foundHandler = true
 
 		currentKind := kind
-		currentHandler := handler
 		currentFilters := filters
 
 		g.Go(func() error {
 			handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": currentKind})
 			handlerLogger.Debugf(childCtx, "Starting ListResources via handler")
-			err := currentHandler.ListResources(childCtx, p.awsConfig, currentFilters, handlerLogger, out)
+			err := p.listWithFailover(childCtx, currentKind, currentFilters, handlerLogger, out)
 			if err != nil {
 				handlerLogger.Errorf(childCtx, err, "Handler ListResources failed")
 				if err == context.Canceled || err == context.DeadlineExceeded {
@@ -206,7 +333,8 @@ func (p *Provider) ListResources(
 
 func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id string) (domain.PlatformResource, error) {
 	p.logger.Debugf(ctx, "Getting AWS resource", "kind", kind, "id", id)
-	handler, found := p.handlers[kind]
+	_, src := p.activeSource()
+	handler, found := src.handlers[kind]
 	if !found {
 		err := errors.New(errors.CodeNotImplemented, fmt.Sprintf("resource kind '%s' not supported by AWS provider", kind))
 		p.logger.Errorf(ctx, err, "Unsupported kind requested")
@@ -214,7 +342,7 @@ func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id
 	}
 
 	handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": kind, "resource_id": id})
-	resource, err := handler.GetResource(ctx, p.awsConfig, id, handlerLogger)
+	resource, err := handler.GetResource(ctx, src.cfg, id, handlerLogger)
 	if err != nil {
 		handlerLogger.Errorf(ctx, err, "Handler GetResource failed")
 		if err == context.Canceled || err == context.DeadlineExceeded {
@@ -233,11 +361,74 @@ func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id
 	return resource, nil
 }
 
+// getResourcesConcurrency bounds the concurrent GetResource calls made for
+// handlers that cannot describe several resources in one call.
+const getResourcesConcurrency = 8
+
+// GetResources fetches the given resources with the handler's batched API when
+// it has one, and with concurrent GetResource calls otherwise. IDs that do not
+// exist are left out of the result.
+func (p *Provider) GetResources(ctx context.Context, kind domain.ResourceKind, ids []string) (map[string]domain.PlatformResource, error) {
+	p.logger.Debugf(ctx, "Getting AWS resources", "kind", kind, "count", len(ids))
+	_, src := p.activeSource()
+	handler, found := src.handlers[kind]
+	if !found {
+		err := errors.New(errors.CodeNotImplemented, fmt.Sprintf("resource kind '%s' not supported by AWS provider", kind))
+		p.logger.Errorf(ctx, err, "Unsupported kind requested")
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return map[string]domain.PlatformResource{}, nil
+	}
+
+	handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": kind})
+	if batcher, ok := handler.(BatchGetter); ok {
+		resources, err := batcher.GetResources(ctx, src.cfg, ids, handlerLogger)
+		if err != nil {
+			handlerLogger.Errorf(ctx, err, "Handler GetResources failed")
+			if err == context.Canceled || err == context.DeadlineExceeded {
+				return nil, err
+			}
+			return nil, errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to get %d resource(s) of kind '%s'", len(ids), kind))
+		}
+		return resources, nil
+	}
+
+	var mu sync.Mutex
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	g, childCtx := errgroup.WithContext(ctx)
+	g.SetLimit(getResourcesConcurrency)
+	for _, id := range awstypes.UniqueIDs(ids) {
+		g.Go(func() error {
+			resource, err := handler.GetResource(childCtx, src.cfg, id, handlerLogger)
+			if err != nil {
+				if errors.Is(err, errors.CodeResourceNotFound) {
+					return nil
+				}
+				if err == context.Canceled || err == context.DeadlineExceeded {
+					return err
+				}
+				return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to get resource '%s' of kind '%s'", id, kind))
+			}
+			mu.Lock()
+			resources[id] = resource
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		handlerLogger.Errorf(ctx, err, "Handler GetResource failed")
+		return nil, err
+	}
+	return resources, nil
+}
+
 func NewProviderWithHandlers(cfg aws.Config, logger ports.Logger, handlers ...AWSResourceHandler) *Provider {
 	p := &Provider{
-		awsConfig: cfg,
-		handlers:  make(map[domain.ResourceKind]AWSResourceHandler),
-		logger:    logger,
+		awsConfig:   cfg,
+		handlers:    make(map[domain.ResourceKind]AWSResourceHandler),
+		logger:      logger,
+		primaryName: defaultCredentialsName,
 	}
 	for _, handler := range handlers {
 		p.registerHandler(handler)
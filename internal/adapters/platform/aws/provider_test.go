@@ -9,11 +9,13 @@ import (
 	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	awstypes "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
 	"github.com/olusolaa/infra-drift-detector/internal/config"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
@@ -391,3 +393,299 @@ func TestProviderGetResource(t *testing.T) {
 		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
+
+type mockBatchHandler struct {
+	MockAWSResourceHandler
+}
+
+func (m *mockBatchHandler) GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error) {
+	args := m.Called(ctx, cfg, ids, logger)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.PlatformResource), args.Error(1)
+}
+
+func TestProviderGetResources(t *testing.T) {
+	ctx := context.Background()
+	resA := &mockPlatformResource{id: "r-a", kind: domain.KindComputeInstance}
+	resB := &mockPlatformResource{id: "r-b", kind: domain.KindComputeInstance}
+	notFoundErr := internalerrors.New(internalerrors.CodeResourceNotFound, "not found by handler")
+
+	t.Run("batch handler", func(t *testing.T) {
+		_, _, _, mockLogger := setupProviderTest(t)
+		handler := new(mockBatchHandler)
+		handler.On("Kind").Maybe().Return(domain.KindComputeInstance)
+		provider := NewProviderWithHandlers(aws.Config{Region: "us-east-1"}, mockLogger, handler)
+		ids := []string{"r-a", "r-b", "r-missing"}
+		handler.On("GetResources", mock.Anything, mock.Anything, ids, mock.Anything).
+			Return(map[string]domain.PlatformResource{"r-a": resA, "r-b": resB}, nil).Once()
+
+		res, err := provider.GetResources(ctx, domain.KindComputeInstance, ids)
+
+		require.NoError(t, err)
+		assert.Len(t, res, 2)
+		handler.AssertExpectations(t)
+		handler.AssertNotCalled(t, "GetResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("batch handler error", func(t *testing.T) {
+		_, _, _, mockLogger := setupProviderTest(t)
+		handler := new(mockBatchHandler)
+		handler.On("Kind").Maybe().Return(domain.KindComputeInstance)
+		provider := NewProviderWithHandlers(aws.Config{Region: "us-east-1"}, mockLogger, handler)
+		testErr := errors.New("batch failed")
+		handler.On("GetResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, testErr).Once()
+
+		res, err := provider.GetResources(ctx, domain.KindComputeInstance, []string{"r-a"})
+
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, testErr)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodePlatformAPIError))
+	})
+
+	t.Run("falls back to concurrent GetResource", func(t *testing.T) {
+		provider, handlerEC2, _, _ := setupProviderTest(t)
+		handlerEC2.On("GetResource", mock.Anything, mock.Anything, "r-a", mock.Anything).Return(resA, nil).Once()
+		handlerEC2.On("GetResource", mock.Anything, mock.Anything, "r-b", mock.Anything).Return(resB, nil).Once()
+		handlerEC2.On("GetResource", mock.Anything, mock.Anything, "r-missing", mock.Anything).Return(nil, notFoundErr).Once()
+
+		res, err := provider.GetResources(ctx, domain.KindComputeInstance, []string{"r-a", "r-b", "r-a", "r-missing"})
+
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, resA, res["r-a"])
+		assert.Equal(t, resB, res["r-b"])
+		handlerEC2.AssertExpectations(t)
+	})
+
+	t.Run("fallback error", func(t *testing.T) {
+		provider, handlerEC2, _, _ := setupProviderTest(t)
+		testErr := errors.New("handler failed")
+		handlerEC2.On("GetResource", mock.Anything, mock.Anything, "r-a", mock.Anything).Return(nil, testErr).Once()
+
+		res, err := provider.GetResources(ctx, domain.KindComputeInstance, []string{"r-a"})
+
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodePlatformAPIError))
+	})
+
+	t.Run("empty ids", func(t *testing.T) {
+		provider, handlerEC2, _, _ := setupProviderTest(t)
+
+		res, err := provider.GetResources(ctx, domain.KindComputeInstance, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, res)
+		handlerEC2.AssertNotCalled(t, "GetResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		provider, _, _, _ := setupProviderTest(t)
+
+		res, err := provider.GetResources(ctx, domain.ResourceKind("unsupported"), []string{"r-a"})
+
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodeNotImplemented))
+	})
+}
+
+type mockProbingHandler struct {
+	MockAWSResourceHandler
+}
+
+func (m *mockProbingHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	args := m.Called(ctx, cfg, logger)
+	return args.Error(0)
+}
+
+func setupSelfTestProvider(t *testing.T) (*Provider, *mockProbingHandler, *mockProbingHandler, *sharedmocks.STSClientInterface) {
+	mockLogger := new(portsmocks.Logger)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger).Maybe()
+
+	handlerEC2 := new(mockProbingHandler)
+	handlerEC2.On("Kind").Maybe().Return(domain.KindComputeInstance)
+	handlerS3 := new(mockProbingHandler)
+	handlerS3.On("Kind").Maybe().Return(domain.KindStorageBucket)
+
+	provider := NewProviderWithHandlers(aws.Config{Region: "us-east-1"}, mockLogger, handlerEC2, handlerS3)
+	stsClient := new(sharedmocks.STSClientInterface)
+	provider.stsClient = stsClient
+	return provider, handlerEC2, handlerS3, stsClient
+}
+
+func TestProviderSelfTest(t *testing.T) {
+	ctx := context.Background()
+	kinds := []domain.ResourceKind{domain.KindComputeInstance, domain.KindStorageBucket}
+	identity := &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		provider, handlerEC2, handlerS3, stsClient := setupSelfTestProvider(t)
+		stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).Return(identity, nil).Once()
+		handlerEC2.On("Probe", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		handlerS3.On("Probe", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := provider.SelfTest(ctx, kinds)
+
+		require.NoError(t, err)
+		stsClient.AssertExpectations(t)
+		handlerEC2.AssertExpectations(t)
+		handlerS3.AssertExpectations(t)
+	})
+
+	t.Run("identity failure stops before probes", func(t *testing.T) {
+		provider, handlerEC2, handlerS3, stsClient := setupSelfTestProvider(t)
+		stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).Return(nil, errors.New("dial tcp: i/o timeout")).Once()
+
+		err := provider.SelfTest(ctx, kinds)
+
+		require.Error(t, err)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodeSelfTestFailed))
+		assert.Contains(t, internalerrors.GetUserFacingDetails(err), "STS identity")
+		handlerEC2.AssertNotCalled(t, "Probe", mock.Anything, mock.Anything, mock.Anything)
+		handlerS3.AssertNotCalled(t, "Probe", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("per-kind diagnostics", func(t *testing.T) {
+		provider, handlerEC2, handlerS3, stsClient := setupSelfTestProvider(t)
+		stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).Return(identity, nil).Once()
+		denied := internalerrors.Wrap(errors.New("AccessDenied: not authorized"), internalerrors.CodePlatformAuthError, "AWS authentication error accessing S3 ListBuckets")
+		handlerEC2.On("Probe", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		handlerS3.On("Probe", mock.Anything, mock.Anything, mock.Anything).Return(denied).Once()
+
+		err := provider.SelfTest(ctx, kinds)
+
+		require.Error(t, err)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodeSelfTestFailed))
+		msg, _, ok := internalerrors.GetUserFacingMessage(err)
+		require.True(t, ok)
+		assert.Equal(t, "AWS self-test failed for 1 of 2 check(s)", msg)
+		details := internalerrors.GetUserFacingDetails(err)
+		assert.Contains(t, details, "StorageBucket (us-east-1): missing permissions or invalid credentials")
+		assert.NotContains(t, details, string(domain.KindComputeInstance))
+	})
+}
+
+type authFailingResource struct {
+	mockPlatformResource
+}
+
+func (m *authFailingResource) Attributes(ctx context.Context) (map[string]any, error) {
+	return nil, internalerrors.New(internalerrors.CodePlatformAuthError, "ExpiredToken: session expired")
+}
+
+func setupFailoverProvider(t *testing.T) (*Provider, *MockAWSResourceHandler, *MockAWSResourceHandler) {
+	_, primary, _, mockLogger := setupProviderTest(t)
+	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	provider := NewProviderWithHandlers(aws.Config{Region: "us-east-1"}, mockLogger, primary)
+	fallback := new(MockAWSResourceHandler)
+	fallback.On("Kind").Maybe().Return(domain.KindComputeInstance)
+	provider.addFallback("profile replica", aws.Config{Region: "us-east-1"}, fallback)
+	return provider, primary, fallback
+}
+
+func TestProviderCredentialFailover(t *testing.T) {
+	ctx := context.Background()
+	kinds := []domain.ResourceKind{domain.KindComputeInstance}
+	authErr := internalerrors.New(internalerrors.CodePlatformAuthError, "AWS authentication error accessing EC2 DescribeInstances")
+
+	t.Run("retries remaining listing with the fallback", func(t *testing.T) {
+		provider, primary, fallback := setupFailoverProvider(t)
+		res1 := &mockPlatformResource{id: "i-1", kind: domain.KindComputeInstance}
+		res2 := &mockPlatformResource{id: "i-2", kind: domain.KindComputeInstance}
+		primary.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(authErr, []domain.PlatformResource{res1}).Once()
+		fallback.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, []domain.PlatformResource{res1, res2}).Once()
+
+		outChan := make(chan domain.PlatformResource, 5)
+		err := provider.ListResources(ctx, kinds, nil, outChan)
+		close(outChan)
+
+		require.NoError(t, err)
+		var ids []string
+		for res := range outChan {
+			ids = append(ids, res.Metadata().ProviderAssignedID)
+		}
+		assert.Equal(t, []string{"i-1", "i-2"}, ids)
+		annotations := provider.RunAnnotations()
+		require.Len(t, annotations, 1)
+		assert.Contains(t, annotations[0].Message, "from default credentials to profile replica")
+		primary.AssertExpectations(t)
+		fallback.AssertExpectations(t)
+	})
+
+	t.Run("fails when every source is rejected", func(t *testing.T) {
+		provider, primary, fallback := setupFailoverProvider(t)
+		primary.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(authErr, nil).Once()
+		fallback.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(authErr, nil).Once()
+
+		outChan := make(chan domain.PlatformResource, 5)
+		err := provider.ListResources(ctx, kinds, nil, outChan)
+		close(outChan)
+
+		require.Error(t, err)
+		assert.True(t, internalerrors.Is(err, internalerrors.CodePlatformAuthError))
+		assert.Len(t, provider.RunAnnotations(), 1)
+	})
+
+	t.Run("does not fail over on other errors", func(t *testing.T) {
+		provider, primary, fallback := setupFailoverProvider(t)
+		primary.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("throttled"), nil).Once()
+
+		outChan := make(chan domain.PlatformResource, 5)
+		err := provider.ListResources(ctx, kinds, nil, outChan)
+		close(outChan)
+
+		require.Error(t, err)
+		assert.Empty(t, provider.RunAnnotations())
+		fallback.AssertNotCalled(t, "ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refetches attributes with the fallback", func(t *testing.T) {
+		provider, primary, fallback := setupFailoverProvider(t)
+		stale := &authFailingResource{mockPlatformResource{id: "i-1", kind: domain.KindComputeInstance}}
+		fresh := &mockPlatformResource{id: "i-1", kind: domain.KindComputeInstance}
+		primary.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, []domain.PlatformResource{stale}).Once()
+		fallback.On("GetResource", mock.Anything, mock.Anything, "i-1", mock.Anything).Return(fresh, nil).Once()
+
+		outChan := make(chan domain.PlatformResource, 5)
+		require.NoError(t, provider.ListResources(ctx, kinds, nil, outChan))
+		close(outChan)
+
+		listed := <-outChan
+		attrs, err := listed.Attributes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "i-1", attrs["id"])
+		assert.Len(t, provider.RunAnnotations(), 1)
+		fallback.AssertExpectations(t)
+	})
+}
+
+type mockEstimatingHandler struct {
+	MockAWSResourceHandler
+}
+
+func (m *mockEstimatingHandler) EstimateCalls(resources int) (listCalls, detailCalls int) {
+	return 1, resources * 10
+}
+
+func TestProviderEstimateAPICalls(t *testing.T) {
+	_, handlerEC2, _, mockLogger := setupProviderTest(t)
+	handlerS3 := new(mockEstimatingHandler)
+	handlerS3.On("Kind").Maybe().Return(domain.KindStorageBucket)
+	provider := NewProviderWithHandlers(aws.Config{Region: "us-east-1"}, mockLogger, handlerEC2, handlerS3)
+
+	assert.Equal(t, ports.APICallEstimate{ListCalls: 1, DetailCalls: 250, Modeled: true},
+		provider.EstimateAPICalls(domain.KindStorageBucket, 25))
+	assert.Equal(t, ports.APICallEstimate{ListCalls: 3, DetailCalls: 250},
+		provider.EstimateAPICalls(domain.KindComputeInstance, 250), "handlers without a call model get the default")
+	assert.Equal(t, ports.APICallEstimate{ListCalls: 1},
+		provider.EstimateAPICalls(domain.KindComputeInstance, 0))
+}
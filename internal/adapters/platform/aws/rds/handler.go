@@ -0,0 +1,335 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	listPageSize = 100
+	// filterValueBatchSize bounds the IDs passed in one db-instance-id filter.
+	filterValueBatchSize = 100
+	// probePageSize is the smallest page DescribeDBInstances accepts.
+	probePageSize = 20
+)
+
+// rdsFilterNameMap maps generic filter keys to DescribeDBInstances filter names.
+// RDS cannot filter by tag server side; tag filters are applied after listing.
+var rdsFilterNameMap = map[string]string{
+	domain.KeyID:             "db-instance-id",
+	domain.DatabaseEngineKey: "engine",
+}
+
+type RDSHandler struct {
+	stsClient    shared.STSClientInterface
+	accountID    string
+	accMu        sync.RWMutex
+	rdsClient    RDSClientInterface
+	limiter      shared.RateLimiter
+	errorHandler shared.ErrorHandler
+}
+
+// HandlerOption defines a function signature for configuring the RDSHandler.
+type HandlerOption func(*RDSHandler)
+
+// WithSTSClient provides an option to set a custom STS client.
+func WithSTSClient(client shared.STSClientInterface) HandlerOption {
+	return func(h *RDSHandler) {
+		if client != nil {
+			h.stsClient = client
+		}
+	}
+}
+
+// WithRDSClient provides an option to set a custom RDS client.
+func WithRDSClient(client RDSClientInterface) HandlerOption {
+	return func(h *RDSHandler) {
+		if client != nil {
+			h.rdsClient = client
+		}
+	}
+}
+
+// WithRateLimiter provides an option to set a custom rate limiter.
+func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
+	return func(h *RDSHandler) {
+		if limiter != nil {
+			h.limiter = limiter
+		}
+	}
+}
+
+// WithErrorHandler provides an option to set a custom error handler.
+func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
+	return func(h *RDSHandler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
+
+// NewHandler creates a new RDSHandler with the given AWS config and optional configurations.
+func NewHandler(cfg aws.Config, opts ...HandlerOption) *RDSHandler {
+	h := &RDSHandler{
+		stsClient:    sts.NewFromConfig(cfg),
+		rdsClient:    rds.NewFromConfig(cfg),
+		limiter:      &aws_limiter.DefaultRateLimiter{},
+		errorHandler: &aws_errors.DefaultErrorHandler{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *RDSHandler) Kind() domain.ResourceKind {
+	return domain.KindDatabaseInstance
+}
+
+func (h *RDSHandler) getAccountID(ctx context.Context, logger ports.Logger) (string, error) {
+	h.accMu.RLock()
+	if h.accountID != "" {
+		accID := h.accountID
+		h.accMu.RUnlock()
+		return accID, nil
+	}
+	h.accMu.RUnlock()
+
+	h.accMu.Lock()
+	defer h.accMu.Unlock()
+
+	if h.accountID != "" {
+		return h.accountID, nil
+	}
+
+	logger.Debugf(ctx, "Fetching AWS Account ID")
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	output, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", h.errorHandler.Handle("STS", "GetCallerIdentity", err, ctx)
+	}
+	if output.Account == nil {
+		return "", errors.New(errors.CodePlatformAPIError, "RDS: AWS caller identity response did not contain Account ID")
+	}
+	h.accountID = aws.ToString(output.Account)
+	return h.accountID, nil
+}
+
+func (h *RDSHandler) ListResources(
+	ctx context.Context,
+	cfg aws.Config,
+	filters map[string]string,
+	logger ports.Logger,
+	out chan<- domain.PlatformResource,
+) error {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for RDS ListResources: %v", accErr)
+	}
+
+	input := &rds.DescribeDBInstancesInput{
+		Filters:    BuildRDSFilters(filters),
+		MaxRecords: aws.Int32(listPageSize),
+	}
+	tagFilters := tagFiltersFrom(filters)
+
+	logger.Debugf(ctx, "Starting RDS DB instance listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		if err := h.limiter.Wait(ctx, logger); err != nil {
+			return err
+		}
+		output, err := h.rdsClient.DescribeDBInstances(ctx, input)
+		if err != nil {
+			return h.errorHandler.Handle("RDS", fmt.Sprintf("DescribeDBInstances:Page%d", pageNum), err, ctx)
+		}
+
+		for _, instance := range output.DBInstances {
+			if !matchesTagFilters(instance.TagList, tagFilters) {
+				continue
+			}
+			resource, mapErr := newDBInstanceResource(instance, cfg.Region, accountID)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for DB instance %s, skipping", aws.ToString(instance.DBInstanceIdentifier))
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending DB instance %s", aws.ToString(instance.DBInstanceIdentifier))
+				return ctx.Err()
+			}
+		}
+
+		if aws.ToString(output.Marker) == "" {
+			break
+		}
+		input.Marker = output.Marker
+	}
+
+	logger.Debugf(ctx, "Finished RDS pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+func (h *RDSHandler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Describing single DB instance %s", id)
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return nil, err
+	}
+
+	output, err := h.rdsClient.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(id)})
+	if err != nil {
+		return nil, h.errorHandler.Handle("RDS", "DescribeDBInstances", err, ctx)
+	}
+	if len(output.DBInstances) == 0 {
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("RDS DB instance '%s' not found (empty response)", id))
+	}
+
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for RDS GetResource: %v", accErr)
+	}
+
+	resource, mapErr := newDBInstanceResource(output.DBInstances[0], cfg.Region, accountID)
+	if mapErr != nil {
+		return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for DB instance %s", id))
+	}
+	return resource, nil
+}
+
+// GetResources describes the given DB instances with a db-instance-id filter,
+// which unlike DBInstanceIdentifier accepts several IDs and skips unknown ones.
+func (h *RDSHandler) GetResources(ctx context.Context, cfg aws.Config, ids []string, logger ports.Logger) (map[string]domain.PlatformResource, error) {
+	accountID, accErr := h.getAccountID(ctx, logger)
+	if accErr != nil {
+		logger.Warnf(ctx, "Proceeding without AWS Account ID for RDS GetResources: %v", accErr)
+	}
+
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	for _, batch := range shared.ChunkIDs(ids, filterValueBatchSize) {
+		logger.Debugf(ctx, "Describing batch of %d DB instances", len(batch))
+		input := &rds.DescribeDBInstancesInput{
+			Filters:    []rdstypes.Filter{{Name: aws.String("db-instance-id"), Values: batch}},
+			MaxRecords: aws.Int32(listPageSize),
+		}
+		for {
+			if err := h.limiter.Wait(ctx, logger); err != nil {
+				return nil, err
+			}
+			output, err := h.rdsClient.DescribeDBInstances(ctx, input)
+			if err != nil {
+				return nil, h.errorHandler.Handle("RDS", "DescribeDBInstances", err, ctx)
+			}
+			for _, inst := range output.DBInstances {
+				id := aws.ToString(inst.DBInstanceIdentifier)
+				resource, mapErr := newDBInstanceResource(inst, cfg.Region, accountID)
+				if mapErr != nil {
+					return nil, errors.Wrap(mapErr, errors.CodeInternal, fmt.Sprintf("failed to create resource wrapper for DB instance %s", id))
+				}
+				resources[id] = resource
+			}
+			if aws.ToString(output.Marker) == "" {
+				break
+			}
+			input.Marker = output.Marker
+		}
+	}
+	return resources, nil
+}
+
+// Probe verifies that DB instances can be described with a single minimal page.
+func (h *RDSHandler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return err
+	}
+	if _, err := h.rdsClient.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{MaxRecords: aws.Int32(probePageSize)}); err != nil {
+		return h.errorHandler.Handle("RDS", "DescribeDBInstances", err, ctx)
+	}
+	return nil
+}
+
+// BuildRDSFilters converts the generic filters RDS can evaluate server side into
+// DescribeDBInstances filters. Comma separated values match any of the values.
+func BuildRDSFilters(genericFilters map[string]string) []rdstypes.Filter {
+	rdsFilters := make([]rdstypes.Filter, 0, len(genericFilters))
+	for key, value := range genericFilters {
+		filterName, ok := rdsFilterNameMap[key]
+		if !ok {
+			continue
+		}
+		rdsFilters = append(rdsFilters, rdstypes.Filter{
+			Name:   aws.String(filterName),
+			Values: splitFilterValue(value),
+		})
+	}
+	return rdsFilters
+}
+
+func tagFiltersFrom(genericFilters map[string]string) map[string]string {
+	tagFilters := make(map[string]string)
+	for key, value := range genericFilters {
+		if strings.HasPrefix(key, domain.TagPrefix) {
+			tagFilters[strings.TrimPrefix(key, domain.TagPrefix)] = value
+		} else if key == domain.KeyName {
+			tagFilters["Name"] = value
+		}
+	}
+	return tagFilters
+}
+
+func matchesTagFilters(tags []rdstypes.Tag, tagFilters map[string]string) bool {
+	if len(tagFilters) == 0 {
+		return true
+	}
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	for key, value := range tagFilters {
+		actual, ok := tagMap[key]
+		if !ok {
+			return false
+		}
+		matched := false
+		for _, candidate := range splitFilterValue(value) {
+			if candidate == actual {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func splitFilterValue(value string) []string {
+	parts := strings.Split(value, ",")
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if trimmed := strings.TrimSpace(p); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
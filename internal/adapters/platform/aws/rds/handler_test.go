@@ -0,0 +1,211 @@
+package rds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	rdsmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/rds/mocks"
+	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type RDSHandlerTestSuite struct {
+	suite.Suite
+	mockRDS          *rdsmocks.RDSClientInterface
+	mockSTS          *sharedmocks.STSClientInterface
+	mockLimiter      *sharedmocks.RateLimiter
+	mockErrorHandler *sharedmocks.ErrorHandler
+	mockLogger       *portsmocks.Logger
+	awsConfig        aws.Config
+	handler          *RDSHandler
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func (s *RDSHandlerTestSuite) SetupTest() {
+	s.mockRDS = new(rdsmocks.RDSClientInterface)
+	s.mockSTS = new(sharedmocks.STSClientInterface)
+	s.mockLimiter = new(sharedmocks.RateLimiter)
+	s.mockErrorHandler = new(sharedmocks.ErrorHandler)
+	s.mockLogger = new(portsmocks.Logger)
+
+	s.awsConfig = aws.Config{Region: "us-east-1"}
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string")).Maybe().Return()
+	s.mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Warnf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+	s.mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Maybe().Return(nil)
+	s.mockSTS.On("GetCallerIdentity", mock.Anything, &sts.GetCallerIdentityInput{}).Maybe().
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+
+	s.handler = NewHandler(s.awsConfig,
+		WithSTSClient(s.mockSTS),
+		WithRDSClient(s.mockRDS),
+		WithRateLimiter(s.mockLimiter),
+		WithErrorHandler(s.mockErrorHandler),
+	)
+}
+
+func (s *RDSHandlerTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestRDSHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RDSHandlerTestSuite))
+}
+
+func dbInstance(id string, tags ...rdstypes.Tag) rdstypes.DBInstance {
+	return rdstypes.DBInstance{
+		DBInstanceIdentifier: aws.String(id),
+		DBInstanceClass:      aws.String("db.t3.micro"),
+		Engine:               aws.String("postgres"),
+		TagList:              tags,
+	}
+}
+
+func (s *RDSHandlerTestSuite) collect(filters map[string]string) ([]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.awsConfig, filters, s.mockLogger, out)
+	close(out)
+	var resources []domain.PlatformResource
+	for res := range out {
+		resources = append(resources, res)
+	}
+	return resources, err
+}
+
+func (s *RDSHandlerTestSuite) TestKind() {
+	s.Equal(domain.KindDatabaseInstance, s.handler.Kind())
+}
+
+func (s *RDSHandlerTestSuite) TestListResources_Paginates() {
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return in.Marker == nil
+	})).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{dbInstance("db-1")},
+		Marker:      aws.String("page-2"),
+	}, nil).Once()
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return aws.ToString(in.Marker) == "page-2"
+	})).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{dbInstance("db-2")},
+	}, nil).Once()
+
+	resources, err := s.collect(nil)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("db-1", resources[0].Metadata().ProviderAssignedID)
+	s.Equal("db-2", resources[1].Metadata().ProviderAssignedID)
+	s.Equal("123456789012", resources[0].Metadata().AccountID)
+	s.Equal("us-east-1", resources[0].Metadata().Region)
+	s.mockRDS.AssertExpectations(s.T())
+}
+
+func (s *RDSHandlerTestSuite) TestListResources_Filters() {
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return len(in.Filters) == 1 && aws.ToString(in.Filters[0].Name) == "engine" &&
+			len(in.Filters[0].Values) == 2
+	})).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{
+			dbInstance("db-prod", rdstypes.Tag{Key: aws.String("Env"), Value: aws.String("prod")}),
+			dbInstance("db-dev", rdstypes.Tag{Key: aws.String("Env"), Value: aws.String("dev")}),
+			dbInstance("db-untagged"),
+		},
+	}, nil).Once()
+
+	resources, err := s.collect(map[string]string{
+		domain.DatabaseEngineKey: "postgres, mysql",
+		"tag:Env":                "prod",
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Equal("db-prod", resources[0].Metadata().ProviderAssignedID)
+}
+
+func (s *RDSHandlerTestSuite) TestListResources_APIError() {
+	apiErr := errors.New("throttled")
+	handledErr := idderrors.New(idderrors.CodePlatformAPIError, "handled")
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+	s.mockErrorHandler.On("Handle", "RDS", "DescribeDBInstances:Page1", apiErr, mock.Anything).Return(handledErr).Once()
+
+	resources, err := s.collect(nil)
+
+	s.ErrorIs(err, handledErr)
+	s.Empty(resources)
+}
+
+func (s *RDSHandlerTestSuite) TestGetResource_Success() {
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String("db-1")}).
+		Return(&rds.DescribeDBInstancesOutput{DBInstances: []rdstypes.DBInstance{dbInstance("db-1")}}, nil).Once()
+
+	resource, err := s.handler.GetResource(s.ctx, s.awsConfig, "db-1", s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Equal("db-1", resource.Metadata().ProviderAssignedID)
+	attrs, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("db.t3.micro", attrs[domain.DatabaseInstanceClassKey])
+}
+
+func (s *RDSHandlerTestSuite) TestGetResource_EmptyResponse() {
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.Anything).
+		Return(&rds.DescribeDBInstancesOutput{}, nil).Once()
+
+	_, err := s.handler.GetResource(s.ctx, s.awsConfig, "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound))
+}
+
+func (s *RDSHandlerTestSuite) TestGetResources_BatchesByFilter() {
+	ids := make([]string, filterValueBatchSize+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("db-%d", i)
+	}
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return len(in.Filters) == 1 && aws.ToString(in.Filters[0].Name) == "db-instance-id" &&
+			len(in.Filters[0].Values) == filterValueBatchSize
+	})).Return(&rds.DescribeDBInstancesOutput{DBInstances: []rdstypes.DBInstance{dbInstance("db-0")}}, nil).Once()
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return len(in.Filters) == 1 && len(in.Filters[0].Values) == 1 && in.Marker == nil
+	})).Return(&rds.DescribeDBInstancesOutput{
+		DBInstances: []rdstypes.DBInstance{dbInstance(ids[filterValueBatchSize])},
+		Marker:      aws.String("page-2"),
+	}, nil).Once()
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, mock.MatchedBy(func(in *rds.DescribeDBInstancesInput) bool {
+		return aws.ToString(in.Marker) == "page-2"
+	})).Return(&rds.DescribeDBInstancesOutput{}, nil).Once()
+
+	resources, err := s.handler.GetResources(s.ctx, s.awsConfig, ids, s.mockLogger)
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Contains(resources, "db-0")
+	s.Contains(resources, ids[filterValueBatchSize])
+	s.mockRDS.AssertExpectations(s.T())
+}
+
+func (s *RDSHandlerTestSuite) TestProbe() {
+	s.mockRDS.On("DescribeDBInstances", mock.Anything, &rds.DescribeDBInstancesInput{MaxRecords: aws.Int32(probePageSize)}).
+		Return(&rds.DescribeDBInstancesOutput{}, nil).Once()
+
+	s.NoError(s.handler.Probe(s.ctx, s.awsConfig, s.mockLogger))
+	s.mockRDS.AssertExpectations(s.T())
+}
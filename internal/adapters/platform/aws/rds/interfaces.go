@@ -0,0 +1,18 @@
+package rds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+//go:generate mockery --name RDSClientInterface --output ./mocks --outpkg mocks --case underscore
+
+// RDSClientInterface defines the methods needed from the AWS SDK RDS client.
+// DescribeDBInstances returns tags inline, so no further calls are needed per instance.
+type RDSClientInterface interface {
+	DescribeDBInstances(ctx context.Context, params *rds.DescribeDBInstancesInput, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error)
+}
+
+type DBInstance = rdstypes.DBInstance // Alias rdstypes.DBInstance for easier use
@@ -0,0 +1,146 @@
+package rds
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// dbInstanceResource wraps a described DB instance. DescribeDBInstances returns
+// every compared attribute, so they are mapped once when the resource is built.
+type dbInstanceResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newDBInstanceResource(instance DBInstance, region, accountID string) (domain.PlatformResource, error) {
+	identifier := aws.ToString(instance.DBInstanceIdentifier)
+	if identifier == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create RDS resource: missing DB instance identifier")
+	}
+
+	return &dbInstanceResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindDatabaseInstance,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: identifier,
+			SourceIdentifier:   identifier,
+			AccountID:          accountID,
+			Region:             region,
+			PendingDeletion:    dbInstancePendingDeletion(instance),
+		},
+		attrs: mapDBInstanceToAttributes(instance),
+	}, nil
+}
+
+// dbInstancePendingDeletion reports a DB instance that is being deleted.
+func dbInstancePendingDeletion(instance DBInstance) *domain.PendingDeletion {
+	if status := aws.ToString(instance.DBInstanceStatus); status == "deleting" {
+		return &domain.PendingDeletion{State: status}
+	}
+	return nil
+}
+
+func (r *dbInstanceResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *dbInstanceResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapDBInstanceToAttributes(instance DBInstance) map[string]any {
+	attrs := map[string]any{}
+
+	setString := func(key string, v *string) {
+		if v != nil {
+			attrs[key] = *v
+		}
+	}
+	setInt := func(key string, v *int32) {
+		if v != nil {
+			attrs[key] = *v
+		}
+	}
+	setBool := func(key string, v *bool) {
+		if v != nil {
+			attrs[key] = *v
+		}
+	}
+
+	setString(domain.KeyID, instance.DBInstanceIdentifier)
+	setString(domain.KeyARN, instance.DBInstanceArn)
+	setString("resource_id", instance.DbiResourceId)
+	setString("status", instance.DBInstanceStatus)
+	setString(domain.DatabaseInstanceClassKey, instance.DBInstanceClass)
+	setString(domain.DatabaseEngineKey, instance.Engine)
+	setString(domain.DatabaseEngineVersionKey, instance.EngineVersion)
+	setInt(domain.DatabaseAllocatedStorageKey, instance.AllocatedStorage)
+	setInt(domain.DatabaseMaxAllocatedStorageKey, instance.MaxAllocatedStorage)
+	setString(domain.DatabaseStorageTypeKey, instance.StorageType)
+	setInt(domain.DatabaseIOPSKey, instance.Iops)
+	setBool(domain.DatabaseMultiAZKey, instance.MultiAZ)
+	setString(domain.DatabaseAvailabilityZoneKey, instance.AvailabilityZone)
+	setBool(domain.DatabasePubliclyAccessibleKey, instance.PubliclyAccessible)
+	setBool(domain.DatabaseStorageEncryptedKey, instance.StorageEncrypted)
+	setString(domain.DatabaseKMSKeyIDKey, instance.KmsKeyId)
+	setInt(domain.KeyBackupRetentionPeriod, instance.BackupRetentionPeriod)
+	setString(domain.KeyBackupWindow, instance.PreferredBackupWindow)
+	setString(domain.DatabaseMaintenanceWindowKey, instance.PreferredMaintenanceWindow)
+	setBool(domain.DatabaseDeletionProtectionKey, instance.DeletionProtection)
+	setBool(domain.DatabaseAutoMinorVersionUpgradeKey, instance.AutoMinorVersionUpgrade)
+	setString(domain.DatabaseUsernameKey, instance.MasterUsername)
+	setBool(domain.DatabaseIAMAuthenticationKey, instance.IAMDatabaseAuthenticationEnabled)
+	setBool(domain.DatabasePerformanceInsightsKey, instance.PerformanceInsightsEnabled)
+	setString(domain.DatabaseCACertIdentifierKey, instance.CACertificateIdentifier)
+
+	if instance.DbInstancePort != nil && *instance.DbInstancePort != 0 {
+		attrs[domain.DatabasePortKey] = *instance.DbInstancePort
+	} else if instance.Endpoint != nil && instance.Endpoint.Port != nil {
+		attrs[domain.DatabasePortKey] = *instance.Endpoint.Port
+	}
+	if instance.Endpoint != nil && instance.Endpoint.Address != nil {
+		attrs["address"] = *instance.Endpoint.Address
+	}
+
+	if len(instance.VpcSecurityGroups) > 0 {
+		sgIDs := make([]string, 0, len(instance.VpcSecurityGroups))
+		for _, sg := range instance.VpcSecurityGroups {
+			if sg.VpcSecurityGroupId != nil {
+				sgIDs = append(sgIDs, *sg.VpcSecurityGroupId)
+			}
+		}
+		attrs[domain.DatabaseSecurityGroupsKey] = sgIDs
+	}
+	if instance.DBSubnetGroup != nil && instance.DBSubnetGroup.DBSubnetGroupName != nil {
+		attrs[domain.DatabaseSubnetGroupKey] = *instance.DBSubnetGroup.DBSubnetGroupName
+	}
+	// Terraform manages a single parameter group per instance.
+	if len(instance.DBParameterGroups) > 0 && instance.DBParameterGroups[0].DBParameterGroupName != nil {
+		attrs[domain.DatabaseParameterGroupKey] = *instance.DBParameterGroups[0].DBParameterGroupName
+	}
+
+	tags := map[string]string{}
+	for _, tag := range instance.TagList {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if len(tags) > 0 {
+		attrs[domain.KeyTags] = tags
+		if name, ok := tags["Name"]; ok {
+			attrs[domain.KeyName] = name
+		}
+	}
+	if _, ok := attrs[domain.KeyName]; !ok {
+		attrs[domain.KeyName] = attrs[domain.KeyID]
+	}
+
+	return attrs
+}
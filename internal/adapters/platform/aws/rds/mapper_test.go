@@ -0,0 +1,88 @@
+package rds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestMapDBInstanceToAttributes(t *testing.T) {
+	instance := rdstypes.DBInstance{
+		DBInstanceIdentifier:  aws.String("orders-db"),
+		DBInstanceArn:         aws.String("arn:aws:rds:us-east-1:123456789012:db:orders-db"),
+		DBInstanceClass:       aws.String("db.m6g.large"),
+		Engine:                aws.String("postgres"),
+		EngineVersion:         aws.String("15.4"),
+		AllocatedStorage:      aws.Int32(100),
+		StorageType:           aws.String("gp3"),
+		MultiAZ:               aws.Bool(true),
+		PubliclyAccessible:    aws.Bool(false),
+		StorageEncrypted:      aws.Bool(true),
+		BackupRetentionPeriod: aws.Int32(7),
+		PreferredBackupWindow: aws.String("03:00-04:00"),
+		Endpoint:              &rdstypes.Endpoint{Address: aws.String("orders-db.abc.us-east-1.rds.amazonaws.com"), Port: aws.Int32(5432)},
+		VpcSecurityGroups: []rdstypes.VpcSecurityGroupMembership{
+			{VpcSecurityGroupId: aws.String("sg-1")},
+			{VpcSecurityGroupId: aws.String("sg-2")},
+		},
+		DBSubnetGroup:     &rdstypes.DBSubnetGroup{DBSubnetGroupName: aws.String("private")},
+		DBParameterGroups: []rdstypes.DBParameterGroupStatus{{DBParameterGroupName: aws.String("pg15-custom")}},
+		TagList:           []rdstypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}},
+	}
+
+	attrs := mapDBInstanceToAttributes(instance)
+
+	assert.Equal(t, "orders-db", attrs[domain.KeyID])
+	assert.Equal(t, "orders-db", attrs[domain.KeyName])
+	assert.Equal(t, "db.m6g.large", attrs[domain.DatabaseInstanceClassKey])
+	assert.Equal(t, "15.4", attrs[domain.DatabaseEngineVersionKey])
+	assert.Equal(t, int32(100), attrs[domain.DatabaseAllocatedStorageKey])
+	assert.Equal(t, true, attrs[domain.DatabaseMultiAZKey])
+	assert.Equal(t, false, attrs[domain.DatabasePubliclyAccessibleKey])
+	assert.Equal(t, int32(7), attrs[domain.KeyBackupRetentionPeriod])
+	assert.Equal(t, "03:00-04:00", attrs[domain.KeyBackupWindow])
+	assert.Equal(t, int32(5432), attrs[domain.DatabasePortKey])
+	assert.Equal(t, []string{"sg-1", "sg-2"}, attrs[domain.DatabaseSecurityGroupsKey])
+	assert.Equal(t, "private", attrs[domain.DatabaseSubnetGroupKey])
+	assert.Equal(t, "pg15-custom", attrs[domain.DatabaseParameterGroupKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, attrs[domain.KeyTags])
+	assert.NotContains(t, attrs, domain.DatabaseIOPSKey)
+}
+
+func TestNewDBInstanceResource(t *testing.T) {
+	res, err := newDBInstanceResource(rdstypes.DBInstance{
+		DBInstanceIdentifier: aws.String("orders-db"),
+		TagList:              []rdstypes.Tag{{Key: aws.String("Name"), Value: aws.String("Orders")}},
+	}, "eu-west-1", "123456789012")
+	require.NoError(t, err)
+
+	meta := res.Metadata()
+	assert.Equal(t, domain.KindDatabaseInstance, meta.Kind)
+	assert.Equal(t, "orders-db", meta.ProviderAssignedID)
+	assert.Equal(t, "eu-west-1", meta.Region)
+
+	attrs, err := res.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Orders", attrs[domain.KeyName])
+
+	assert.Nil(t, meta.PendingDeletion)
+
+	_, err = newDBInstanceResource(rdstypes.DBInstance{}, "eu-west-1", "")
+	assert.Error(t, err)
+}
+
+func TestNewDBInstanceResource_Deleting(t *testing.T) {
+	res, err := newDBInstanceResource(rdstypes.DBInstance{
+		DBInstanceIdentifier: aws.String("orders-db"),
+		DBInstanceStatus:     aws.String("deleting"),
+	}, "eu-west-1", "123456789012")
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.PendingDeletion{State: "deleting"}, res.Metadata().PendingDeletion)
+}
@@ -0,0 +1,40 @@
+package retry
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	sdkretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// metricsMiddlewareID identifies the API call metrics on a client's stack.
+const metricsMiddlewareID = "APICallMetrics"
+
+// MetricsMiddleware returns an aws.Config.APIOptions entry that records every
+// API operation in metrics. It wraps the retry middleware, so an operation is
+// recorded once with the attempts it took and how many of them were
+// throttled.
+func MetricsMiddleware(metrics ports.APICallMetrics) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		mw := middleware.FinalizeMiddlewareFunc(metricsMiddlewareID, func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleFinalize(ctx, in)
+			attempts, throttles := 1, 0
+			if results, ok := sdkretry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+				attempts = len(results.Results)
+				for _, attempt := range results.Results {
+					if attempt.Err != nil && aws_errors.IsThrottleError(attempt.Err) {
+						throttles++
+					}
+				}
+			} else if err != nil && aws_errors.IsThrottleError(err) {
+				throttles = 1
+			}
+			metrics.ObserveAPICall(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), attempts, throttles, err != nil)
+			return out, metadata, err
+		})
+		return stack.Finalize.Insert(mw, "Retry", middleware.Before)
+	}
+}
@@ -0,0 +1,49 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+)
+
+type apiCall struct {
+	service, operation  string
+	attempts, throttles int
+	failed              bool
+}
+
+type recordingMetrics struct {
+	calls []apiCall
+}
+
+func (m *recordingMetrics) ObserveAPICall(service, operation string, attempts, throttles int, failed bool) {
+	m.calls = append(m.calls, apiCall{service, operation, attempts, throttles, failed})
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	fake := awsfake.NewServer(t)
+	metrics := &recordingMetrics{}
+	cfg := fake.Config()
+	cfg.Retryer = NewRetryer(Config{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxBackoff: time.Millisecond}, nil)
+	cfg.APIOptions = append(cfg.APIOptions, MetricsMiddleware(metrics))
+	client := sts.NewFromConfig(cfg)
+
+	_, err := client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	require.NoError(t, err)
+
+	fake.Fail("GetCallerIdentity", awsfake.Fault{Status: http.StatusBadRequest, Code: "Throttling", Times: 2})
+	_, err = client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []apiCall{
+		{"STS", "GetCallerIdentity", 1, 0, false},
+		{"STS", "GetCallerIdentity", 3, 2, false},
+	}, metrics.calls)
+}
@@ -0,0 +1,103 @@
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	sdkretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+const (
+	DefaultMaxAttempts = 5
+	DefaultBaseDelay   = 200 * time.Millisecond
+	DefaultMaxBackoff  = 20 * time.Second
+)
+
+// Config configures how AWS API calls failing with a throttling or other
+// transient error are retried.
+type Config struct {
+	// MaxAttempts is the number of attempts per API call, including the first.
+	// One disables retries.
+	MaxAttempts int `yaml:"max_attempts" mapstructure:"max_attempts" validate:"omitempty,min=1,max=20"`
+	// BaseDelay is the backoff ceiling of the first retry. It doubles with each
+	// further retry up to MaxBackoff.
+	BaseDelay time.Duration `yaml:"base_delay" mapstructure:"base_delay" validate:"omitempty,min=0"`
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration `yaml:"max_backoff" mapstructure:"max_backoff" validate:"omitempty,min=0"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = DefaultBaseDelay
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.BaseDelay > c.MaxBackoff {
+		c.BaseDelay = c.MaxBackoff
+	}
+	return c
+}
+
+// NewRetryer returns a Retryer factory for aws.Config, shared by every client
+// built from the config. On top of the SDK's standard retryable errors it
+// retries every error the handlers classify as throttling, and it has no retry
+// quota, so a burst of throttles across many resources doesn't exhaust the
+// retries of the ones that follow.
+func NewRetryer(cfg Config, logger ports.Logger) func() aws.Retryer {
+	cfg = cfg.withDefaults()
+	return func() aws.Retryer {
+		return sdkretry.NewStandard(func(o *sdkretry.StandardOptions) {
+			o.MaxAttempts = cfg.MaxAttempts
+			o.MaxBackoff = cfg.MaxBackoff
+			o.Backoff = &jitteredBackoff{base: cfg.BaseDelay, max: cfg.MaxBackoff, logger: logger, jitter: randomJitter}
+			o.Retryables = append(o.Retryables, sdkretry.IsErrorRetryableFunc(isThrottle))
+			o.RateLimiter = ratelimit.None
+		})
+	}
+}
+
+// isThrottle marks throttling errors the SDK doesn't know as retryable and
+// leaves every other error to the standard checks.
+func isThrottle(err error) aws.Ternary {
+	if aws_errors.IsThrottleError(err) {
+		return aws.TrueTernary
+	}
+	return aws.UnknownTernary
+}
+
+// jitteredBackoff waits between half and all of an exponentially growing
+// ceiling, so clients throttled at the same moment don't retry in lockstep.
+type jitteredBackoff struct {
+	base   time.Duration
+	max    time.Duration
+	logger ports.Logger
+	// jitter returns a random duration in [0, d).
+	jitter func(d time.Duration) time.Duration
+}
+
+// BackoffDelay returns the delay before retrying attempt, the 1-based number
+// of the attempt that failed with err.
+func (b *jitteredBackoff) BackoffDelay(attempt int, err error) (time.Duration, error) {
+	ceiling := b.max
+	if shift := attempt - 1; shift < 32 && b.base<<shift > 0 && b.base<<shift < b.max {
+		ceiling = b.base << shift
+	}
+	delay := ceiling/2 + b.jitter(ceiling/2+1)
+	if b.logger != nil {
+		b.logger.Debugf(context.Background(), "Retrying AWS API call after attempt %d failed, waiting %s: %v", attempt, delay, err)
+	}
+	return delay, nil
+}
+
+func randomJitter(d time.Duration) time.Duration {
+	return time.Duration(rand.Int64N(int64(d)))
+}
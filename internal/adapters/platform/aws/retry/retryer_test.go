@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{}.withDefaults()
+	assert.Equal(t, DefaultMaxAttempts, cfg.MaxAttempts)
+	assert.Equal(t, DefaultBaseDelay, cfg.BaseDelay)
+	assert.Equal(t, DefaultMaxBackoff, cfg.MaxBackoff)
+
+	cfg = Config{MaxAttempts: 1, BaseDelay: time.Minute, MaxBackoff: time.Second}.withDefaults()
+	assert.Equal(t, 1, cfg.MaxAttempts)
+	assert.Equal(t, time.Second, cfg.BaseDelay, "the base delay is capped by the max backoff")
+}
+
+func TestNewRetryer_ClassifiesErrors(t *testing.T) {
+	retryer := NewRetryer(Config{MaxAttempts: 3}, nil)()
+	assert.Equal(t, 3, retryer.MaxAttempts())
+
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"SDK throttle code", &smithy.GenericAPIError{Code: "ThrottlingException"}, true},
+		{"S3 slow down", &smithy.GenericAPIError{Code: "SlowDown"}, true},
+		{"throttle code unknown to the SDK", &smithy.GenericAPIError{Code: "EC2ThrottledException"}, true},
+		{"mapped throttle error", idderrors.New(idderrors.CodePlatformThrottled, "throttled"), true},
+		{"throttle message", errors.New("Rate exceeded"), true},
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDenied"}, false},
+		{"not found", &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.retryable, retryer.IsErrorRetryable(tc.err))
+		})
+	}
+}
+
+func TestJitteredBackoff_BackoffDelay(t *testing.T) {
+	maxJitter := func(d time.Duration) time.Duration { return d - 1 }
+	noJitter := func(time.Duration) time.Duration { return 0 }
+
+	testCases := []struct {
+		name    string
+		attempt int
+		jitter  func(time.Duration) time.Duration
+		want    time.Duration
+	}{
+		{"first retry without jitter", 1, noJitter, 50 * time.Millisecond},
+		{"first retry with full jitter", 1, maxJitter, 100 * time.Millisecond},
+		{"third retry doubles twice", 3, maxJitter, 400 * time.Millisecond},
+		{"capped at max backoff", 6, maxJitter, time.Second},
+		{"large attempt does not overflow", 100, noJitter, 500 * time.Millisecond},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &jitteredBackoff{base: 100 * time.Millisecond, max: time.Second, jitter: tc.jitter}
+
+			delay, err := b.BackoffDelay(tc.attempt, errors.New("Throttling"))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, delay)
+		})
+	}
+}
+
+func TestRandomJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := randomJitter(10 * time.Millisecond)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, 10*time.Millisecond)
+	}
+}
@@ -0,0 +1,65 @@
+package retry
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	sdkretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+)
+
+const (
+	// tracingMiddlewareID identifies the API call spans on a client's stack.
+	tracingMiddlewareID = "APICallTracing"
+	// tracerName is the instrumentation scope of the API call spans.
+	tracerName = "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws"
+)
+
+// TracingMiddleware returns an aws.Config.APIOptions entry that records a
+// client span for every API operation, named after the service and
+// operation. Like MetricsMiddleware it wraps the retry middleware, so the
+// span covers all attempts and the backoff between them, and records how many
+// attempts were made and how many of them were throttled.
+func TracingMiddleware(provider trace.TracerProvider) func(*middleware.Stack) error {
+	tracer := provider.Tracer(tracerName)
+	return func(stack *middleware.Stack) error {
+		mw := middleware.FinalizeMiddlewareFunc(tracingMiddlewareID, func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			ctx, span := tracer.Start(ctx, service+"."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("rpc.system", "aws-api"),
+					attribute.String("rpc.service", service),
+					attribute.String("rpc.method", operation),
+					attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+				))
+			defer span.End()
+
+			out, metadata, err := next.HandleFinalize(ctx, in)
+			attempts, throttles := 1, 0
+			if results, ok := sdkretry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+				attempts = len(results.Results)
+				for _, attempt := range results.Results {
+					if attempt.Err != nil && aws_errors.IsThrottleError(attempt.Err) {
+						throttles++
+					}
+				}
+			}
+			span.SetAttributes(attribute.Int("aws.attempts", attempts), attribute.Int("aws.throttles", throttles))
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+				span.SetAttributes(attribute.String("aws.request_id", requestID))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return out, metadata, err
+		})
+		return stack.Finalize.Insert(mw, "Retry", middleware.Before)
+	}
+}
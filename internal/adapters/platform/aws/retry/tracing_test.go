@@ -0,0 +1,48 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	fake := awsfake.NewServer(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	cfg := fake.Config()
+	cfg.Retryer = NewRetryer(Config{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxBackoff: time.Millisecond}, nil)
+	cfg.APIOptions = append(cfg.APIOptions, TracingMiddleware(provider))
+	client := sts.NewFromConfig(cfg)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "list_actual")
+	fake.Fail("GetCallerIdentity", awsfake.Fault{Status: http.StatusBadRequest, Code: "Throttling", Times: 2})
+	_, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	require.NoError(t, err)
+	fake.Fail("GetCallerIdentity", awsfake.Fault{Status: http.StatusBadRequest, Code: "AccessDenied", Times: 1})
+	_, err = client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	throttled, failed := spans[0], spans[1]
+	assert.Equal(t, "STS.GetCallerIdentity", throttled.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), throttled.Parent().SpanID(), "API call spans are children of the caller's span")
+	assert.Contains(t, throttled.Attributes(), attribute.Int("aws.attempts", 3))
+	assert.Contains(t, throttled.Attributes(), attribute.Int("aws.throttles", 2))
+	assert.Equal(t, codes.Unset, throttled.Status().Code)
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Attributes(), attribute.Int("aws.attempts", 1))
+}
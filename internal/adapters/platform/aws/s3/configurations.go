@@ -0,0 +1,176 @@
+package s3
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// The bucket configuration listings (Intelligent-Tiering archive, storage
+// class analysis, inventory and request metrics) map to lists of
+// configurations sorted by id. Filters become "prefix" and "tags" whatever
+// their shape, and unset settings are omitted.
+
+func mapIntelligentTieringConfigurations(configs []s3types.IntelligentTieringConfiguration) []map[string]any {
+	out := make([]map[string]any, 0, len(configs))
+	for _, cfg := range configs {
+		m := map[string]any{
+			"id":     aws.ToString(cfg.Id),
+			"status": string(cfg.Status),
+		}
+		if f := cfg.Filter; f != nil {
+			var andPrefix *string
+			var andTags []s3types.Tag
+			if f.And != nil {
+				andPrefix, andTags = f.And.Prefix, f.And.Tags
+			}
+			addConfigurationFilter(m, f.Prefix, f.Tag, andPrefix, andTags)
+		}
+		tierings := make([]map[string]any, 0, len(cfg.Tierings))
+		for _, t := range cfg.Tierings {
+			tierings = append(tierings, map[string]any{
+				"access_tier": string(t.AccessTier),
+				"days":        int64(aws.ToInt32(t.Days)),
+			})
+		}
+		sort.Slice(tierings, func(i, j int) bool {
+			return tierings[i]["access_tier"].(string) < tierings[j]["access_tier"].(string)
+		})
+		m["tierings"] = tierings
+		out = append(out, m)
+	}
+	return sortConfigurations(out)
+}
+
+func mapAnalyticsConfigurations(configs []s3types.AnalyticsConfiguration) []map[string]any {
+	out := make([]map[string]any, 0, len(configs))
+	for _, cfg := range configs {
+		m := map[string]any{"id": aws.ToString(cfg.Id)}
+		switch f := cfg.Filter.(type) {
+		case *s3types.AnalyticsFilterMemberPrefix:
+			addConfigurationFilter(m, &f.Value, nil, nil, nil)
+		case *s3types.AnalyticsFilterMemberTag:
+			addConfigurationFilter(m, nil, &f.Value, nil, nil)
+		case *s3types.AnalyticsFilterMemberAnd:
+			addConfigurationFilter(m, nil, nil, f.Value.Prefix, f.Value.Tags)
+		}
+		if sca := cfg.StorageClassAnalysis; sca != nil && sca.DataExport != nil &&
+			sca.DataExport.Destination != nil && sca.DataExport.Destination.S3BucketDestination != nil {
+			d := sca.DataExport.Destination.S3BucketDestination
+			export := map[string]any{
+				"bucket": aws.ToString(d.Bucket),
+				"format": string(d.Format),
+			}
+			if account := aws.ToString(d.BucketAccountId); account != "" {
+				export["account"] = account
+			}
+			if prefix := aws.ToString(d.Prefix); prefix != "" {
+				export["prefix"] = prefix
+			}
+			m["export"] = export
+		}
+		out = append(out, m)
+	}
+	return sortConfigurations(out)
+}
+
+func mapInventoryConfigurations(configs []s3types.InventoryConfiguration) []map[string]any {
+	out := make([]map[string]any, 0, len(configs))
+	for _, cfg := range configs {
+		m := map[string]any{
+			"id":                       aws.ToString(cfg.Id),
+			"enabled":                  aws.ToBool(cfg.IsEnabled),
+			"included_object_versions": string(cfg.IncludedObjectVersions),
+		}
+		if cfg.Schedule != nil {
+			m["frequency"] = string(cfg.Schedule.Frequency)
+		}
+		if cfg.Filter != nil {
+			addConfigurationFilter(m, cfg.Filter.Prefix, nil, nil, nil)
+		}
+		if len(cfg.OptionalFields) > 0 {
+			fields := make([]string, 0, len(cfg.OptionalFields))
+			for _, f := range cfg.OptionalFields {
+				fields = append(fields, string(f))
+			}
+			sort.Strings(fields)
+			m["optional_fields"] = fields
+		}
+		if cfg.Destination != nil && cfg.Destination.S3BucketDestination != nil {
+			d := cfg.Destination.S3BucketDestination
+			dest := map[string]any{
+				"bucket": aws.ToString(d.Bucket),
+				"format": string(d.Format),
+			}
+			if account := aws.ToString(d.AccountId); account != "" {
+				dest["account"] = account
+			}
+			if prefix := aws.ToString(d.Prefix); prefix != "" {
+				dest["prefix"] = prefix
+			}
+			if enc := d.Encryption; enc != nil {
+				switch {
+				case enc.SSEKMS != nil:
+					dest["encryption"] = "SSE-KMS"
+					dest["kms_key_id"] = aws.ToString(enc.SSEKMS.KeyId)
+				case enc.SSES3 != nil:
+					dest["encryption"] = "SSE-S3"
+				}
+			}
+			m["destination"] = dest
+		}
+		out = append(out, m)
+	}
+	return sortConfigurations(out)
+}
+
+func mapMetricsConfigurations(configs []s3types.MetricsConfiguration) []map[string]any {
+	out := make([]map[string]any, 0, len(configs))
+	for _, cfg := range configs {
+		m := map[string]any{"id": aws.ToString(cfg.Id)}
+		switch f := cfg.Filter.(type) {
+		case *s3types.MetricsFilterMemberPrefix:
+			addConfigurationFilter(m, &f.Value, nil, nil, nil)
+		case *s3types.MetricsFilterMemberTag:
+			addConfigurationFilter(m, nil, &f.Value, nil, nil)
+		case *s3types.MetricsFilterMemberAccessPointArn:
+			m["access_point"] = f.Value
+		case *s3types.MetricsFilterMemberAnd:
+			addConfigurationFilter(m, nil, nil, f.Value.Prefix, f.Value.Tags)
+			if ap := aws.ToString(f.Value.AccessPointArn); ap != "" {
+				m["access_point"] = ap
+			}
+		}
+		out = append(out, m)
+	}
+	return sortConfigurations(out)
+}
+
+// addConfigurationFilter sets "prefix" and "tags" from a filter holding a
+// prefix, a single tag, or both under an and operator.
+func addConfigurationFilter(m map[string]any, prefix *string, tag *s3types.Tag, andPrefix *string, andTags []s3types.Tag) {
+	if andPrefix != nil {
+		prefix = andPrefix
+	}
+	if p := aws.ToString(prefix); p != "" {
+		m["prefix"] = p
+	}
+	tags := map[string]string{}
+	if tag != nil {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	for _, t := range andTags {
+		tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	if len(tags) > 0 {
+		m["tags"] = tags
+	}
+}
+
+func sortConfigurations(configs []map[string]any) []map[string]any {
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i]["id"].(string) < configs[j]["id"].(string)
+	})
+	return configs
+}
@@ -0,0 +1,109 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// enrichmentTier orders the per-bucket attribute calls. Tiers are fetched one
+// after another, so security-relevant configuration is retrieved before
+// anything else competes for the rate limiter.
+type enrichmentTier int
+
+const (
+	// tierCritical holds the policy, encryption, ACL and public access block
+	// calls.
+	tierCritical enrichmentTier = iota
+	// tierStandard holds tags, versioning, lifecycle, logging, object
+	// ownership, replication, Object Lock and event notifications.
+	tierStandard
+	// tierOptional holds website, CORS, transfer acceleration and the
+	// Intelligent-Tiering, analytics, inventory and metrics configuration
+	// listings, which are skipped once the enrichment budget is spent.
+	tierOptional
+)
+
+var enrichmentTiers = []enrichmentTier{tierCritical, tierStandard, tierOptional}
+
+// bucketSubCall is a single attribute call made while enriching a bucket.
+type bucketSubCall struct {
+	name string
+	tier enrichmentTier
+	attr string // domain attribute filled from the call's output
+	call func(context.Context) error
+}
+
+// runBucketSubCalls runs the calls tier by tier, each tier concurrently. With a
+// positive budget, optional calls only get the time left since start: they are
+// skipped when none is left and abandoned when they run past it. Skipped calls
+// are recorded on input so their attributes are not reported as drift.
+func runBucketSubCalls(
+	ctx context.Context,
+	start time.Time,
+	budget time.Duration,
+	calls []bucketSubCall,
+	input *s3BucketAttributesInput,
+	mu *sync.Mutex,
+	logger ports.Logger,
+) error {
+	for _, tier := range enrichmentTiers {
+		tierCalls := make([]bucketSubCall, 0, len(calls))
+		for _, c := range calls {
+			if c.tier == tier {
+				tierCalls = append(tierCalls, c)
+			}
+		}
+		if len(tierCalls) == 0 {
+			continue
+		}
+
+		tierCtx := ctx
+		if tier == tierOptional && budget > 0 {
+			remaining := budget - time.Since(start)
+			if remaining <= 0 {
+				logger.Debugf(ctx, "Enrichment budget of %s spent, skipping %d optional attribute calls", budget, len(tierCalls))
+				for _, c := range tierCalls {
+					input.SkippedAttributes = append(input.SkippedAttributes, c.attr)
+				}
+				continue
+			}
+			var cancel context.CancelFunc
+			tierCtx, cancel = context.WithTimeout(ctx, remaining)
+			defer cancel()
+		}
+
+		g, childCtx := errgroup.WithContext(tierCtx)
+		for _, c := range tierCalls {
+			g.Go(func() error {
+				err := aws_limiter.Wait(childCtx, logger)
+				if err == nil {
+					err = c.call(childCtx)
+				}
+				if err == nil {
+					return nil
+				}
+				if tier == tierOptional && ctx.Err() == nil && errors.Is(tierCtx.Err(), context.DeadlineExceeded) {
+					logger.Debugf(ctx, "Enrichment budget exceeded during %s, skipping it", c.name)
+					mu.Lock()
+					input.SkippedAttributes = append(input.SkippedAttributes, c.attr)
+					mu.Unlock()
+					return nil
+				}
+				return err
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
+	}
+	sort.Strings(input.SkippedAttributes)
+	return nil
+}
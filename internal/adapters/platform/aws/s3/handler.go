@@ -4,13 +4,18 @@ package s3
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"fmt"
+	"slices"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
@@ -19,9 +24,31 @@ import (
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+	"golang.org/x/sync/errgroup"
 )
 
+// DefaultListConcurrency is the number of buckets built at once while
+// listing when Config.ListConcurrency is not set.
+const DefaultListConcurrency = 8
+
+// Config holds the S3 handler settings.
+type Config struct {
+	// EnrichmentBudget bounds the time spent fetching one bucket's attributes.
+	// Policy, encryption and ACL are always fetched first; website and CORS are
+	// skipped once the budget is spent. Zero disables the budget.
+	EnrichmentBudget time.Duration `yaml:"enrichment_budget" mapstructure:"enrichment_budget" validate:"omitempty,min=0"`
+	// ListConcurrency caps the buckets whose attributes are fetched at once
+	// while listing. Their calls still go through the rate limiter. Zero uses
+	// DefaultListConcurrency.
+	ListConcurrency int `yaml:"list_concurrency" mapstructure:"list_concurrency" validate:"omitempty,min=1"`
+	// Regions restricts listing to the buckets in these regions. Buckets in
+	// other regions are skipped once their region is known, before any of
+	// their configuration is fetched. Empty lists buckets in every region.
+	Regions []string `yaml:"regions" mapstructure:"regions" validate:"omitempty,dive,required"`
+}
+
 type S3Handler struct {
+	config       Config
 	stsClient    shared.STSClientInterface
 	accountID    string
 	accMu        sync.RWMutex
@@ -30,6 +57,7 @@ type S3Handler struct {
 	builder      S3ResourceBuilder
 	limiter      shared.RateLimiter
 	errorHandler shared.ErrorHandler
+	cache        ports.AttributeCache
 }
 
 // HandlerOption defines a function signature for configuring the S3Handler.
@@ -71,6 +99,13 @@ func WithRateLimiter(limiter shared.RateLimiter) HandlerOption {
 	}
 }
 
+// WithConfig provides an option to set the handler configuration.
+func WithConfig(cfg Config) HandlerOption {
+	return func(h *S3Handler) {
+		h.config = cfg
+	}
+}
+
 // WithErrorHandler provides an option to set a custom error handler.
 func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
 	return func(h *S3Handler) {
@@ -80,6 +115,14 @@ func WithErrorHandler(handler shared.ErrorHandler) HandlerOption {
 	}
 }
 
+// WithAttributeCache reuses the attributes of listed buckets fetched by an
+// earlier run, skipping the per-bucket API calls while the entry is fresh.
+func WithAttributeCache(cache ports.AttributeCache) HandlerOption {
+	return func(h *S3Handler) {
+		h.cache = cache
+	}
+}
+
 // NewHandler creates a new S3Handler with the given AWS config and optional configurations.
 func NewHandler(cfg aws.Config, opts ...HandlerOption) *S3Handler {
 	s3Factory := func(c aws.Config) S3ClientInterface {
@@ -92,13 +135,15 @@ func NewHandler(cfg aws.Config, opts ...HandlerOption) *S3Handler {
 
 	h.stsClient = sts.NewFromConfig(cfg)
 	h.s3Client = s3.NewFromConfig(cfg)
-	h.builder = NewDefaultS3ResourceBuilder(s3Factory)
 	h.limiter = &aws_limiter.DefaultRateLimiter{}
 	h.errorHandler = &aws_errors.DefaultErrorHandler{}
 
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.builder == nil {
+		h.builder = NewDefaultS3ResourceBuilder(s3Factory, WithEnrichmentBudget(h.config.EnrichmentBudget))
+	}
 
 	return h
 }
@@ -120,7 +165,7 @@ func (h *S3Handler) getAccountID(ctx context.Context, logger ports.Logger) (stri
 	}
 
 	if err := h.limiter.Wait(ctx, logger); err != nil {
-		return "", err
+		return "", h.errorHandler.Handle("Limiter", "Wait", err, ctx)
 	}
 	out, err := h.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
@@ -149,7 +194,7 @@ func (h *S3Handler) ListResources(
 	}
 
 	if err := h.limiter.Wait(ctx, logger); err != nil {
-		return err
+		return h.errorHandler.Handle("Limiter", "Wait", err, ctx)
 	}
 
 	listOutput, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
@@ -157,42 +202,141 @@ func (h *S3Handler) ListResources(
 		return h.errorHandler.Handle("S3", "ListBuckets", err, ctx)
 	}
 
+	// Buckets are built by a bounded pool of workers and sent as each build
+	// completes, so the order of the output is not the order of the listing.
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(h.listConcurrency())
 	for _, bucket := range listOutput.Buckets {
-		select {
-		case <-ctx.Done():
-			logger.Warnf(ctx, "Context cancelled during S3 bucket processing")
-			return ctx.Err()
-		default:
-			// Continue processing
-		}
-
 		bucketName := aws.ToString(bucket.Name)
 		if bucketName == "" {
 			continue
 		}
+		if gCtx.Err() != nil {
+			break
+		}
 
-		res, buildErr := h.builder.Build(ctx, bucketName, accountID, cfg, logger)
-		if buildErr != nil {
-			logger.Warnf(ctx, "Error building S3 resource for bucket %s: %v", bucketName, buildErr)
+		// ListBuckets returns the region of each bucket when it can, which saves
+		// resolving the location of buckets that are skipped anyway.
+		if region := aws.ToString(bucket.BucketRegion); region != "" && !h.inListedRegions(region) {
+			logger.Debugf(ctx, "Skipping S3 bucket %s in region %s", bucketName, region)
 			continue
 		}
 
-		select {
-		case out <- res:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+		g.Go(func() error {
+			res, buildErr := h.buildListedBucket(gCtx, bucket, accountID, cfg, logger)
+			if buildErr != nil {
+				logger.Warnf(ctx, "Error building S3 resource for bucket %s: %v", bucketName, buildErr)
+				return gCtx.Err()
+			}
+			if region := res.Metadata().Region; !h.inListedRegions(region) {
+				logger.Debugf(ctx, "Skipping S3 bucket %s in region %s", bucketName, region)
+				return nil
+			}
+
+			select {
+			case out <- res:
+				return nil
+			case <-gCtx.Done():
+				return gCtx.Err()
+			}
+		})
 	}
 
+	if err := g.Wait(); err != nil {
+		logger.Warnf(ctx, "Context cancelled during S3 bucket processing")
+		return err
+	}
+	if ctx.Err() != nil {
+		logger.Warnf(ctx, "Context cancelled during S3 bucket processing")
+		return ctx.Err()
+	}
 	return nil
 }
 
+// inListedRegions reports whether buckets in region are listed.
+func (h *S3Handler) inListedRegions(region string) bool {
+	return len(h.config.Regions) == 0 || slices.Contains(h.config.Regions, region)
+}
+
+// listConcurrency returns the number of buckets built at once while listing.
+func (h *S3Handler) listConcurrency() int {
+	if h.config.ListConcurrency > 0 {
+		return h.config.ListConcurrency
+	}
+	return DefaultListConcurrency
+}
+
+// buildListedBucket builds the resource of a listed bucket, from the attribute
+// cache when it holds a fresh entry for the bucket. Otherwise the attributes
+// are cached once they are fetched, if they are complete.
+func (h *S3Handler) buildListedBucket(ctx context.Context, bucket s3types.Bucket, accountID string, cfg aws.Config, logger ports.Logger) (domain.PlatformResource, error) {
+	bucketName := aws.ToString(bucket.Name)
+	if h.cache == nil || accountID == "" {
+		return h.builder.Build(ctx, bucketName, accountID, cfg, logger)
+	}
+
+	key := ports.AttributeCacheKey{
+		AccountID:   accountID,
+		Region:      cfg.Region,
+		Kind:        domain.KindStorageBucket,
+		ID:          bucketName,
+		ContentHash: bucketContentHash(bucket),
+	}
+	if attrs, ok := h.cache.Get(ctx, key); ok {
+		logger.Debugf(ctx, "Using cached attributes of S3 bucket %s", bucketName)
+		return newCachedS3BucketResource(bucketName, accountID, attrs), nil
+	}
+
+	res, err := h.builder.Build(ctx, bucketName, accountID, cfg, logger)
+	if err != nil {
+		return res, err
+	}
+	return &cachingBucketResource{PlatformResource: res, cache: h.cache, key: key}, nil
+}
+
+// cachingBucketResource puts the attributes of a bucket in the cache the
+// first time they are fetched without error and without skipped attributes.
+type cachingBucketResource struct {
+	domain.PlatformResource
+	cache ports.AttributeCache
+	key   ports.AttributeCacheKey
+	once  sync.Once
+}
+
+func (r *cachingBucketResource) Attributes(ctx context.Context) (map[string]any, error) {
+	attrs, err := r.PlatformResource.Attributes(ctx)
+	if err != nil {
+		return attrs, err
+	}
+	if _, partial := attrs[domain.KeySkippedAttributes]; !partial {
+		r.once.Do(func() { r.cache.Put(ctx, r.key, attrs) })
+	}
+	return attrs, nil
+}
+
+// bucketContentHash digests what ListBuckets returns for a bucket, so a bucket
+// deleted and created again under the same name misses the cache, and the
+// attribute layout version, so entries cached by an older release miss it too.
+func bucketContentHash(bucket s3types.Bucket) string {
+	sum := sha256.New()
+	sum.Write([]byte(strconv.Itoa(bucketAttributesVersion)))
+	sum.Write([]byte{0})
+	sum.Write([]byte(aws.ToString(bucket.Name)))
+	sum.Write([]byte{0})
+	if bucket.CreationDate != nil {
+		sum.Write([]byte(bucket.CreationDate.UTC().Format(time.RFC3339Nano)))
+	}
+	sum.Write([]byte{0})
+	sum.Write([]byte(aws.ToString(bucket.BucketRegion)))
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
 func (h *S3Handler) GetResource(ctx context.Context, cfg aws.Config, id string, logger ports.Logger) (domain.PlatformResource, error) {
 	bucketName := id
 	client := h.s3Client
 
 	if err := h.limiter.Wait(ctx, logger); err != nil {
-		return nil, err
+		return nil, h.errorHandler.Handle("Limiter", "Wait", err, ctx)
 	}
 
 	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucketName)})
@@ -208,7 +352,7 @@ func (h *S3Handler) GetResource(ctx context.Context, cfg aws.Config, id string,
 	accountID, accountErr := h.getAccountID(ctx, logger)
 	if accountErr != nil {
 		logger.Warnf(ctx, "Failed to get account ID needed for S3 GetResource: %v", accountErr)
-		return nil, fmt.Errorf("failed to get AWS account ID: %w", accountErr)
+		return nil, accountErr
 	}
 
 	resource, buildErr := h.builder.Build(ctx, bucketName, accountID, cfg, logger)
@@ -218,3 +362,21 @@ func (h *S3Handler) GetResource(ctx context.Context, cfg aws.Config, id string,
 
 	return resource, nil
 }
+
+// EstimateCalls returns the calls listing the given number of buckets takes:
+// a single ListBuckets call, then the location and the configuration calls
+// of every bucket.
+func (h *S3Handler) EstimateCalls(resources int) (listCalls, detailCalls int) {
+	return 1, resources * (1 + bucketConfigurationCalls)
+}
+
+// Probe verifies that buckets can be listed by requesting a single bucket.
+func (h *S3Handler) Probe(ctx context.Context, cfg aws.Config, logger ports.Logger) error {
+	if err := h.limiter.Wait(ctx, logger); err != nil {
+		return h.errorHandler.Handle("Limiter", "Wait", err, ctx)
+	}
+	if _, err := h.s3Client.ListBuckets(ctx, &s3.ListBucketsInput{MaxBuckets: aws.Int32(1)}); err != nil {
+		return h.errorHandler.Handle("S3", "ListBuckets", err, ctx)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,10 +21,13 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	cachejson "github.com/olusolaa/infra-drift-detector/internal/adapters/cache/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/awsfake"
 	s3mocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/s3/mocks"
 	sharedmocks "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared/mocks"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	domainmocks "github.com/olusolaa/infra-drift-detector/internal/core/domain/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
 )
 
@@ -118,16 +122,18 @@ func (s *S3HandlerTestSuite) TestGetAccountID_SuccessCached() {
 
 func (s *S3HandlerTestSuite) TestGetAccountID_LimiterError() {
 	limiterErr := errors.New("rate limit exceeded")
+	wrappedErr := idderrors.New(idderrors.CodePlatformAPIError, "wrapped limiter error")
 	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(limiterErr).Once()
+	s.mockErrorHandler.On("Handle", "Limiter", "Wait", limiterErr, mock.Anything).Return(wrappedErr).Once()
 
 	accID, err := s.handler.getAccountID(s.ctx, s.mockLogger)
 
-	s.ErrorIs(err, limiterErr)
+	s.ErrorIs(err, wrappedErr)
 	s.Empty(accID)
 	s.Empty(s.handler.accountID)
 	s.mockLimiter.AssertExpectations(s.T())
+	s.mockErrorHandler.AssertExpectations(s.T())
 	s.mockSTS.AssertNotCalled(s.T(), "GetCallerIdentity", mock.Anything, mock.Anything)
-	s.mockErrorHandler.AssertNotCalled(s.T(), "Handle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *S3HandlerTestSuite) TestGetAccountID_STSError() {
@@ -232,6 +238,83 @@ func (s *S3HandlerTestSuite) TestListResources_Success() {
 	s.mockErrorHandler.AssertNotCalled(s.T(), "Handle", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
+func (s *S3HandlerTestSuite) TestListResources_RestrictedToRegions_ResolvedRegion() {
+	accountID := "555666777888"
+	s.handler.config.Regions = []string{"us-west-2"}
+	s.handler.accountID = accountID
+	s.mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil).Once()
+	s.mockS3.On("ListBuckets", mock.Anything, &s3.ListBucketsInput{}).Return(&s3.ListBucketsOutput{
+		Buckets: []types.Bucket{{Name: aws.String("west")}, {Name: aws.String("east")}},
+	}, nil).Once()
+
+	for name, region := range map[string]string{"west": "us-west-2", "east": "us-east-1"} {
+		res := new(domainmocks.PlatformResource)
+		res.On("Metadata").Return(domain.ResourceMetadata{ProviderAssignedID: name, AccountID: accountID, Kind: domain.KindStorageBucket, Region: region})
+		s.mockBuilder.On("Build", mock.Anything, name, accountID, mock.AnythingOfType("aws.Config"), s.mockLogger).Return(res, nil).Once()
+	}
+
+	out := make(chan domain.PlatformResource, 2)
+	s.Require().NoError(s.handler.ListResources(s.ctx, s.awsConfig, nil, s.mockLogger, out))
+	close(out)
+
+	s.Require().Len(out, 1, "buckets listed without a region are skipped once it is resolved")
+	s.Equal("west", (<-out).Metadata().ProviderAssignedID)
+}
+
+func (s *S3HandlerTestSuite) TestListResources_AttributeCache() {
+	accountID := "555666777888"
+	bucketName := "cached-bucket"
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store, err := cachejson.NewStore(s.T().TempDir(), time.Hour, s.mockLogger)
+	s.Require().NoError(err)
+	s.handler.cache = store
+	s.handler.accountID = accountID
+	s.mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil)
+
+	listBuckets := func(createdAt time.Time) []domain.PlatformResource {
+		s.mockS3.On("ListBuckets", mock.Anything, &s3.ListBucketsInput{}).Return(&s3.ListBucketsOutput{
+			Buckets: []types.Bucket{{Name: aws.String(bucketName), CreationDate: aws.Time(createdAt)}},
+		}, nil).Once()
+		out := make(chan domain.PlatformResource, 1)
+		s.Require().NoError(s.handler.ListResources(s.ctx, s.awsConfig, nil, s.mockLogger, out))
+		close(out)
+		var results []domain.PlatformResource
+		for res := range out {
+			results = append(results, res)
+		}
+		s.Require().Len(results, 1)
+		return results
+	}
+
+	built := new(domainmocks.PlatformResource)
+	built.On("Metadata").Return(domain.ResourceMetadata{ProviderAssignedID: bucketName, AccountID: accountID, Kind: domain.KindStorageBucket, Region: "eu-west-1"})
+	built.On("Attributes", mock.Anything).Return(map[string]any{
+		domain.KeyID:     bucketName,
+		domain.KeyRegion: "eu-west-1",
+		domain.KeyTags:   map[string]string{"Team": "data"},
+	}, nil)
+	s.mockBuilder.On("Build", mock.Anything, bucketName, accountID, mock.AnythingOfType("aws.Config"), s.mockLogger).
+		Return(built, nil).Twice()
+
+	cold := listBuckets(created)[0]
+	s.Equal(bucketName, cold.Metadata().ProviderAssignedID, "a cold cache builds the bucket")
+	_, err = cold.Attributes(s.ctx)
+	s.Require().NoError(err, "fetched attributes are cached")
+
+	cached := listBuckets(created)[0]
+	s.Equal("eu-west-1", cached.Metadata().Region)
+	attrs, err := cached.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"Team": "data"}, attrs[domain.KeyTags])
+
+	// A recreated bucket misses the cache.
+	listBuckets(created.Add(time.Hour))
+	s.mockBuilder.AssertNumberOfCalls(s.T(), "Build", 2)
+	s.mockBuilder.AssertExpectations(s.T())
+}
+
 func (s *S3HandlerTestSuite) TestListResources_Empty() {
 	accountID := "555666777888"
 	s.mockLimiter.On("Wait", mock.Anything, s.mockLogger).Return(nil).Once()
@@ -657,3 +740,275 @@ func (s *S3HandlerTestSuite) newMockAPIError(code int, message string) error {
 		},
 	}
 }
+
+// S3HandlerFakeTestSuite runs the handler and the default bucket builder with
+// real SDK clients against the awsfake server.
+type S3HandlerFakeTestSuite struct {
+	suite.Suite
+	fake       *awsfake.Server
+	mockLogger *portsmocks.Logger
+	handler    *S3Handler
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+func (s *S3HandlerFakeTestSuite) SetupTest() {
+	s.fake = awsfake.NewServer(s.T(), awsfake.WithPageSize(2))
+	s.fake.AddBuckets(
+		awsfake.Bucket{
+			Name:         "assets",
+			Tags:         map[string]string{"Name": "web-assets", "Env": "prod"},
+			Versioning:   "Enabled",
+			SSEAlgorithm: "AES256",
+			Policy:       `{"Version":"2012-10-17","Statement":[]}`,
+
+			BlockPublicAccess: true,
+			ObjectOwnership:   "BucketOwnerEnforced",
+			Acceleration:      "Enabled",
+			NotificationQueue: "arn:aws:sqs:us-east-1:123456789012:uploads",
+		},
+		awsfake.Bucket{
+			Name:           "logs",
+			Region:         "eu-west-1",
+			LifecycleRules: map[string]int32{"expire": 30},
+
+			ReplicationDestination: "arn:aws:s3:::logs-replica",
+			ReplicationRole:        "arn:aws:iam::123456789012:role/replication",
+			ObjectLock:             true,
+			ObjectLockMode:         "COMPLIANCE",
+			ObjectLockDays:         365,
+
+			InventoryIDs: []string{"daily-a", "daily-b", "daily-c"},
+			MetricsIDs:   []string{"EntireBucket"},
+		},
+	)
+	s.mockLogger = new(portsmocks.Logger)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	// The default rate limiter and the handler log with varying argument counts.
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for args := []any{mock.Anything, mock.AnythingOfType("string")}; len(args) <= 6; args = append(args, mock.Anything) {
+			s.mockLogger.On(method, args...).Maybe().Return()
+		}
+	}
+	for args := []any{mock.Anything, mock.Anything, mock.AnythingOfType("string")}; len(args) <= 7; args = append(args, mock.Anything) {
+		s.mockLogger.On("Errorf", args...).Maybe().Return()
+	}
+	s.mockLogger.On("WithFields", mock.Anything).Maybe().Return(s.mockLogger)
+
+	s.handler = NewHandler(s.fake.Config())
+}
+
+func (s *S3HandlerFakeTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func TestS3HandlerFakeTestSuite(t *testing.T) {
+	suite.Run(t, new(S3HandlerFakeTestSuite))
+}
+
+func (s *S3HandlerFakeTestSuite) collect() (map[string]domain.PlatformResource, error) {
+	out := make(chan domain.PlatformResource, 10)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), nil, s.mockLogger, out)
+	close(out)
+	resources := make(map[string]domain.PlatformResource)
+	for res := range out {
+		resources[res.Metadata().ProviderAssignedID] = res
+	}
+	return resources, err
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_BuildsBuckets() {
+	resources, err := s.collect()
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal(awsfake.DefaultAccountID, resources["assets"].Metadata().AccountID)
+
+	assets, err := resources["assets"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("us-east-1", assets[domain.KeyRegion])
+	s.Equal("web-assets", assets[domain.KeyName])
+	s.Equal(map[string]string{"Name": "web-assets", "Env": "prod"}, assets[domain.KeyTags])
+	s.Equal(true, assets[domain.StorageBucketVersioningKey])
+	s.NotEmpty(assets[domain.StorageBucketEncryptionKey])
+	s.NotEmpty(assets[domain.StorageBucketACLKey])
+	s.Contains(assets[domain.StorageBucketPolicyKey], "2012-10-17")
+	s.NotContains(assets, domain.StorageBucketWebsiteKey)
+	s.Equal(map[string]any{
+		"block_public_acls":       true,
+		"block_public_policy":     true,
+		"ignore_public_acls":      true,
+		"restrict_public_buckets": true,
+	}, assets[domain.StorageBucketPublicAccessBlockKey])
+	s.Equal("BucketOwnerEnforced", assets[domain.StorageBucketObjectOwnershipKey])
+	s.Equal("Enabled", assets[domain.StorageBucketAccelerationStatusKey])
+	s.Equal(map[string]any{
+		"queues": []map[string]any{{
+			"id":            "uploads",
+			"arn":           "arn:aws:sqs:us-east-1:123456789012:uploads",
+			"events":        []string{"s3:ObjectCreated:*"},
+			"filter_prefix": "uploads/",
+		}},
+	}, assets[domain.StorageBucketNotificationKey])
+
+	logs, err := resources["logs"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal("eu-west-1", logs[domain.KeyRegion], "sub-resources are read through a client in the bucket region")
+	s.NotEmpty(logs[domain.StorageBucketLifecycleRulesKey])
+	s.NotContains(logs, domain.KeyTags)
+	s.NotContains(logs, domain.StorageBucketPublicAccessBlockKey, "a missing configuration is not an error")
+	s.NotContains(logs, domain.StorageBucketObjectOwnershipKey)
+	s.NotContains(logs, domain.StorageBucketAccelerationStatusKey)
+	s.Equal(map[string]any{
+		"role": "arn:aws:iam::123456789012:role/replication",
+		"rules": []map[string]any{{
+			"id":                        "all",
+			"priority":                  int64(1),
+			"status":                    "Enabled",
+			"destination":               map[string]any{"bucket": "arn:aws:s3:::logs-replica"},
+			"delete_marker_replication": "Disabled",
+		}},
+	}, logs[domain.StorageBucketReplicationKey])
+	s.Equal(map[string]any{
+		"enabled":           true,
+		"default_retention": map[string]any{"mode": "COMPLIANCE", "days": int64(365)},
+	}, logs[domain.StorageBucketObjectLockKey])
+	s.NotContains(assets, domain.StorageBucketReplicationKey)
+	s.NotContains(assets, domain.StorageBucketObjectLockKey)
+	s.NotContains(logs, domain.StorageBucketNotificationKey, "a bucket without notifications has no attribute")
+
+	inventories, ok := logs[domain.StorageBucketInventoryKey].([]map[string]any)
+	s.Require().True(ok)
+	s.Require().Len(inventories, 3, "every page of the listing is fetched")
+	s.Equal(map[string]any{
+		"id":                       "daily-c",
+		"enabled":                  true,
+		"included_object_versions": "Current",
+		"frequency":                "Daily",
+		"destination":              map[string]any{"bucket": "arn:aws:s3:::logs", "format": "CSV"},
+	}, inventories[2])
+	s.Equal([]map[string]any{{"id": "EntireBucket"}}, logs[domain.StorageBucketMetricsKey])
+	s.NotContains(logs, domain.StorageBucketIntelligentTieringKey)
+	s.NotContains(assets, domain.StorageBucketInventoryKey)
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_ListBucketsThrottled() {
+	s.fake.Fail("ListBuckets", awsfake.Fault{Status: http.StatusServiceUnavailable, Code: "SlowDown"})
+
+	resources, err := s.collect()
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformThrottled), "got %v", err)
+	s.Empty(resources)
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_SkipsBucketThatFailsToBuild() {
+	// One worker builds the buckets in listing order, so the fault hits the first.
+	s.handler = NewHandler(s.fake.Config(), WithConfig(Config{ListConcurrency: 1}))
+	s.fake.Fail("GetBucketLocation", awsfake.Fault{Status: http.StatusNotFound, Code: "NoSuchBucket", Times: 1})
+
+	resources, err := s.collect()
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Contains(resources, "logs")
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_FetchesConfigurationOnFirstUse() {
+	resources, err := s.collect()
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2)
+	s.Equal("eu-west-1", resources["logs"].Metadata().Region, "the region is resolved while listing")
+	s.Equal(2, s.fake.Calls("GetBucketLocation"))
+	s.Zero(s.fake.Calls("GetBucketPolicy"), "no configuration is fetched for buckets never compared")
+
+	_, err = resources["assets"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	_, err = resources["assets"].Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(1, s.fake.Calls("GetBucketPolicy"))
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_ConfigurationErrorOnFirstUse() {
+	s.fake.Fail("GetBucketAcl", awsfake.Fault{Status: http.StatusForbidden, Code: "AccessDenied"})
+
+	resources, err := s.collect()
+	s.Require().NoError(err)
+	s.Require().Len(resources, 2, "buckets are listed before their configuration is read")
+
+	_, err = resources["assets"].Attributes(s.ctx)
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodePlatformAuthError), "got %v", err)
+}
+
+// countingBuilder builds buckets without fetching their configuration and
+// records the most builds in flight at once.
+type countingBuilder struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (b *countingBuilder) Build(ctx context.Context, bucketName, accountID string, cfg aws.Config, logger ports.Logger) (domain.PlatformResource, error) {
+	b.mu.Lock()
+	b.inFlight++
+	b.peak = max(b.peak, b.inFlight)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.inFlight--
+		b.mu.Unlock()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	return newCachedS3BucketResource(bucketName, accountID, map[string]any{domain.KeyRegion: cfg.Region}), nil
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_BoundedConcurrency() {
+	for i := range 10 {
+		s.fake.AddBuckets(awsfake.Bucket{Name: fmt.Sprintf("bulk-%02d", i)})
+	}
+	builder := &countingBuilder{}
+	s.handler = NewHandler(s.fake.Config(), WithS3Builder(builder), WithConfig(Config{ListConcurrency: 3}))
+
+	out := make(chan domain.PlatformResource, 20)
+	err := s.handler.ListResources(s.ctx, s.fake.Config(), nil, s.mockLogger, out)
+	close(out)
+
+	s.Require().NoError(err)
+	s.Len(out, 12)
+	s.Equal(3, builder.peak, "builds run concurrently up to the configured limit")
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_DefaultConcurrency() {
+	s.Equal(DefaultListConcurrency, s.handler.listConcurrency())
+	s.Equal(2, NewHandler(s.fake.Config(), WithConfig(Config{ListConcurrency: 2})).listConcurrency())
+}
+
+func (s *S3HandlerFakeTestSuite) TestListResources_RestrictedToRegions() {
+	s.handler = NewHandler(s.fake.Config(), WithConfig(Config{Regions: []string{"eu-west-1"}}))
+
+	resources, err := s.collect()
+
+	s.Require().NoError(err)
+	s.Require().Len(resources, 1)
+	s.Contains(resources, "logs")
+	s.Equal(1, s.fake.Calls("GetBucketLocation"), "the listed region of other buckets is enough to skip them")
+}
+
+func (s *S3HandlerFakeTestSuite) TestGetResource_OtherRegion() {
+	resource, err := s.handler.GetResource(s.ctx, s.fake.Config(), "logs", s.mockLogger)
+
+	s.Require().NoError(err, "the HeadBucket redirect is tolerated")
+	s.Equal("logs", resource.Metadata().ProviderAssignedID)
+	s.Equal(1, s.fake.Calls("HeadBucket"))
+}
+
+func (s *S3HandlerFakeTestSuite) TestGetResource_NoSuchBucket() {
+	_, err := s.handler.GetResource(s.ctx, s.fake.Config(), "missing", s.mockLogger)
+
+	s.Require().Error(err)
+	s.True(idderrors.Is(err, idderrors.CodeResourceNotFound), "got %v", err)
+	s.Zero(s.fake.Calls("GetBucketLocation"))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/shared"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +16,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
-	"golang.org/x/sync/errgroup"
 
 	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
 	aws_limiter "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
@@ -24,6 +24,9 @@ import (
 	idderrors "github.com/olusolaa/infra-drift-detector/internal/errors"
 )
 
+// s3BucketResource is a bucket whose region is resolved when it is built. Its
+// configuration is fetched on the first call to Attributes, so buckets that
+// are never compared, unmatched or filtered out, cost no configuration calls.
 type s3BucketResource struct {
 	mu              sync.RWMutex
 	meta            domain.ResourceMetadata
@@ -32,22 +35,44 @@ type s3BucketResource struct {
 	builtAttrs      map[string]any
 	fetchErr        error
 	attributesBuilt bool
+	// s3ClientFactory and budget fetch the configuration. A resource without
+	// a factory cannot fetch it and must be built with its attributes.
+	s3ClientFactory func(aws.Config) S3ClientInterface
+	budget          time.Duration
+}
+
+// BuilderOption defines a function signature for configuring the default S3 resource builder.
+type BuilderOption func(*defaultS3ResourceBuilder)
+
+// WithEnrichmentBudget bounds the time spent fetching each bucket's attributes.
+// Optional attributes are skipped once the budget is spent.
+func WithEnrichmentBudget(budget time.Duration) BuilderOption {
+	return func(b *defaultS3ResourceBuilder) {
+		if budget > 0 {
+			b.enrichmentBudget = budget
+		}
+	}
 }
 
 // NewDefaultS3ResourceBuilder creates a new default S3 resource builder.
-func NewDefaultS3ResourceBuilder(s3Factory func(aws.Config) S3ClientInterface) S3ResourceBuilder {
-	return &defaultS3ResourceBuilder{
+func NewDefaultS3ResourceBuilder(s3Factory func(aws.Config) S3ClientInterface, opts ...BuilderOption) S3ResourceBuilder {
+	b := &defaultS3ResourceBuilder{
 		s3ClientFactory: s3Factory,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // defaultS3ResourceBuilder implements the S3ResourceBuilder interface.
 type defaultS3ResourceBuilder struct {
-	s3ClientFactory func(aws.Config) S3ClientInterface
+	s3ClientFactory  func(aws.Config) S3ClientInterface
+	enrichmentBudget time.Duration
 }
 
 func (b *defaultS3ResourceBuilder) Build(ctx context.Context, bucketName, accountID string, cfg aws.Config, logger ports.Logger) (domain.PlatformResource, error) {
-	resource := buildS3BucketResource(ctx, bucketName, accountID, cfg, logger, b.s3ClientFactory)
+	resource := buildS3BucketResource(ctx, bucketName, accountID, cfg, logger, b.s3ClientFactory, b.enrichmentBudget)
 	return resource, resource.fetchErr
 }
 
@@ -55,10 +80,28 @@ func (r *s3BucketResource) Metadata() domain.ResourceMetadata { return r.meta }
 
 func (r *s3BucketResource) Attributes(ctx context.Context) (map[string]any, error) {
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-	if !r.attributesBuilt {
+	if r.attributesBuilt {
+		attrs, fetchErr := r.copyAttributeMap(r.builtAttrs), r.fetchErr
+		r.mu.RUnlock()
+		return attrs, fetchErr
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.attributesBuilt {
+		return r.copyAttributeMap(r.builtAttrs), r.fetchErr
+	}
+	if r.s3ClientFactory == nil {
 		return nil, idderrors.New(idderrors.CodeInternal, fmt.Sprintf("S3 resource %s attributes accessed before build", r.meta.ProviderAssignedID))
 	}
+
+	data, err := fetchBucketConfiguration(ctx, r.meta.ProviderAssignedID, r.meta.Region, r.awsConfig, r.parentLogger, r.s3ClientFactory, r.budget)
+	r.fetchErr = err
+	if err == nil {
+		r.builtAttrs = mapAPIDataToDomainAttrs(data, r.parentLogger)
+	}
+	r.attributesBuilt = true
 	return r.copyAttributeMap(r.builtAttrs), r.fetchErr
 }
 
@@ -73,12 +116,16 @@ func (r *s3BucketResource) copyAttributeMap(src map[string]any) map[string]any {
 	return dst
 }
 
+// buildS3BucketResource resolves the region of the bucket and returns its
+// resource, which fetches the configuration on first use. A bucket whose
+// region cannot be resolved is returned built, with the error.
 func buildS3BucketResource(
 	ctx context.Context,
 	bucketName, accountID string,
 	cfg aws.Config,
 	logger ports.Logger,
 	s3Factory func(aws.Config) S3ClientInterface,
+	budget time.Duration,
 ) *s3BucketResource {
 	logger = logger.WithFields(map[string]any{"bucket_name": bucketName})
 	resource := &s3BucketResource{
@@ -92,22 +139,40 @@ func buildS3BucketResource(
 			AccountID:          accountID,
 			Region:             "unknown",
 		},
+		s3ClientFactory: s3Factory,
+		budget:          budget,
 	}
-	data, err := fetchAllBucketAttributes(ctx, bucketName, cfg, logger, s3Factory)
-
-	resource.mu.Lock()
-	resource.fetchErr = err
-	if err == nil && data != nil {
-		resource.meta.Region = data.Region
-		resource.builtAttrs = mapAPIDataToDomainAttrs(data, logger)
-	} else if err == nil && data == nil {
-		resource.fetchErr = idderrors.New(idderrors.CodeInternal, "fetchAllBucketAttributes returned nil data without error")
+	region, err := resolveBucketRegion(ctx, bucketName, cfg, logger, s3Factory)
+	if err != nil {
+		resource.fetchErr = err
+		resource.attributesBuilt = true
+		return resource
 	}
-	resource.attributesBuilt = true
-	resource.mu.Unlock()
+	resource.meta.Region = region
 	return resource
 }
 
+// newCachedS3BucketResource creates the resource of a bucket from the
+// attributes an earlier run fetched and cached.
+func newCachedS3BucketResource(bucketName, accountID string, attrs map[string]any) *s3BucketResource {
+	region, _ := attrs[domain.KeyRegion].(string)
+	if region == "" {
+		region = "unknown"
+	}
+	return &s3BucketResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindStorageBucket,
+			ProviderType:       shared.ProviderTypeAWS,
+			ProviderAssignedID: bucketName,
+			SourceIdentifier:   bucketName,
+			AccountID:          accountID,
+			Region:             region,
+		},
+		builtAttrs:      attrs,
+		attributesBuilt: true,
+	}
+}
+
 type s3BucketAttributesInput struct {
 	BucketName       string
 	Region           string
@@ -120,189 +185,369 @@ type s3BucketAttributesInput struct {
 	CorsOutput       *s3.GetBucketCorsOutput
 	PolicyOutput     *s3.GetBucketPolicyOutput
 	EncryptionOutput *s3.GetBucketEncryptionOutput
+	// PublicAccessBlockOutput, OwnershipOutput and AccelerateOutput are nil
+	// when the bucket has no such configuration.
+	PublicAccessBlockOutput *s3.GetPublicAccessBlockOutput
+	OwnershipOutput         *s3.GetBucketOwnershipControlsOutput
+	AccelerateOutput        *s3.GetBucketAccelerateConfigurationOutput
+	// ReplicationOutput and ObjectLockOutput are nil when the bucket does not
+	// replicate or has Object Lock disabled.
+	ReplicationOutput  *s3.GetBucketReplicationOutput
+	ObjectLockOutput   *s3.GetObjectLockConfigurationOutput
+	NotificationOutput *s3.GetBucketNotificationConfigurationOutput
+	// The configuration lists hold the configurations of every page of their
+	// listing.
+	IntelligentTieringConfigurations []s3types.IntelligentTieringConfiguration
+	AnalyticsConfigurations          []s3types.AnalyticsConfiguration
+	InventoryConfigurations          []s3types.InventoryConfiguration
+	MetricsConfigurations            []s3types.MetricsConfiguration
+	// SkippedAttributes lists attributes left unfetched because the enrichment
+	// budget ran out.
+	SkippedAttributes []string
 }
 
-func fetchAllBucketAttributes(
+// bucketAttributesVersion is bumped whenever the attributes mapped from a
+// bucket change, so cached attributes without them are fetched again.
+const bucketAttributesVersion = 4
+
+// bucketConfigurationCalls is the number of calls fetchBucketConfiguration
+// makes per bucket, counting the first page of each configuration listing.
+const bucketConfigurationCalls = 19
+
+// resolveBucketRegion returns the region of a bucket from GetBucketLocation,
+// or from HeadBucket when the location cannot be read with the credentials.
+func resolveBucketRegion(
 	ctx context.Context,
 	bucketName string,
 	cfg aws.Config,
 	logger ports.Logger,
 	s3Factory func(aws.Config) S3ClientInterface,
-) (*s3BucketAttributesInput, error) {
-	input := &s3BucketAttributesInput{BucketName: bucketName}
-
+) (string, error) {
 	baseClient := s3Factory(cfg)
 
 	if err := aws_limiter.Wait(ctx, logger); err != nil {
-		return nil, idderrors.Wrap(err, idderrors.CodePlatformAPIError, "rate limit error before GetBucketLocation")
+		return "", aws_errors.HandleAWSError("Limiter", "Wait", err, ctx)
 	}
 	loc, err := baseClient.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &bucketName})
 	if err != nil {
-		var apiErr smithy.APIError
-		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDenied" {
-			if headErr := aws_limiter.Wait(ctx, logger); headErr != nil {
-				return nil, headErr
-			}
-			head, headErr := baseClient.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &bucketName})
-			if headErr == nil && head != nil && head.BucketRegion != nil {
-				input.Region = *head.BucketRegion
-			} else {
-				return nil, aws_errors.HandleAWSError("S3 bucket", bucketName, err, ctx)
-			}
-		} else if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucket" {
-			// If the bucket doesn't exist, return a clear error
-			return nil, aws_errors.HandleAWSError("S3 bucket", bucketName, err, ctx)
-		} else {
-			return nil, aws_errors.HandleAWSError("S3 bucket", bucketName, err, ctx)
+		if aws_errors.Classify(err) != idderrors.CodePlatformAuthError {
+			return "", aws_errors.HandleAWSError("S3 bucket", bucketName, err, ctx)
 		}
-	} else {
-		if loc.LocationConstraint == "" {
-			input.Region = "us-east-1"
-		} else {
-			input.Region = string(loc.LocationConstraint)
+		if waitErr := aws_limiter.Wait(ctx, logger); waitErr != nil {
+			return "", aws_errors.HandleAWSError("Limiter", "Wait", waitErr, ctx)
 		}
+		head, headErr := baseClient.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &bucketName})
+		if headErr != nil || head == nil || head.BucketRegion == nil {
+			return "", aws_errors.HandleAWSError("S3 bucket", bucketName, err, ctx)
+		}
+		return *head.BucketRegion, nil
+	}
+	if loc.LocationConstraint == "" {
+		return "us-east-1", nil
 	}
+	return string(loc.LocationConstraint), nil
+}
+
+// fetchBucketConfiguration fetches the configuration of a bucket through a
+// client in its region.
+func fetchBucketConfiguration(
+	ctx context.Context,
+	bucketName, region string,
+	cfg aws.Config,
+	logger ports.Logger,
+	s3Factory func(aws.Config) S3ClientInterface,
+	budget time.Duration,
+) (*s3BucketAttributesInput, error) {
+	start := time.Now()
+	input := &s3BucketAttributesInput{BucketName: bucketName, Region: region}
 
 	regionalCfg := cfg.Copy()
-	regionalCfg.Region = input.Region
+	regionalCfg.Region = region
 	client := s3Factory(regionalCfg)
 
-	g, childCtx := errgroup.WithContext(ctx)
 	var mu sync.Mutex
-
-	run := func(name string, call func(context.Context) error) {
-		g.Go(func() error {
-			if err := aws_limiter.Wait(childCtx, logger); err != nil {
-				return err
+	calls := []bucketSubCall{
+		{name: "GetBucketPolicy", tier: tierCritical, attr: domain.StorageBucketPolicyKey, call: func(c context.Context) error {
+			out, err := client.GetBucketPolicy(c, &s3.GetBucketPolicyInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.PolicyOutput = out
+				mu.Unlock()
+				return nil
 			}
-			if err := call(childCtx); err != nil {
-				return err
+			if isS3NotFoundError(err, "NoSuchBucketPolicy") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketEncryption", tier: tierCritical, attr: domain.StorageBucketEncryptionKey, call: func(c context.Context) error {
+			out, err := client.GetBucketEncryption(c, &s3.GetBucketEncryptionInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.EncryptionOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "ServerSideEncryptionConfigurationNotFoundError") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketAcl", tier: tierCritical, attr: domain.StorageBucketACLKey, call: func(c context.Context) error {
+			out, err := client.GetBucketAcl(c, &s3.GetBucketAclInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.AclOutput = out
+				mu.Unlock()
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetPublicAccessBlock", tier: tierCritical, attr: domain.StorageBucketPublicAccessBlockKey, call: func(c context.Context) error {
+			out, err := client.GetPublicAccessBlock(c, &s3.GetPublicAccessBlockInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.PublicAccessBlockOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "NoSuchPublicAccessBlockConfiguration") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketTagging", tier: tierStandard, attr: domain.KeyTags, call: func(c context.Context) error {
+			out, err := client.GetBucketTagging(c, &s3.GetBucketTaggingInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.TaggingOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "NoSuchTagSet") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketVersioning", tier: tierStandard, attr: domain.StorageBucketVersioningKey, call: func(c context.Context) error {
+			out, err := client.GetBucketVersioning(c, &s3.GetBucketVersioningInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.VersioningOutput = out
+				mu.Unlock()
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketLifecycleConfiguration", tier: tierStandard, attr: domain.StorageBucketLifecycleRulesKey, call: func(c context.Context) error {
+			out, err := client.GetBucketLifecycleConfiguration(c, &s3.GetBucketLifecycleConfigurationInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.LifecycleOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "NoSuchLifecycleConfiguration") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketLogging", tier: tierStandard, attr: domain.StorageBucketLoggingKey, call: func(c context.Context) error {
+			out, err := client.GetBucketLogging(c, &s3.GetBucketLoggingInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.LoggingOutput = out
+				mu.Unlock()
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketOwnershipControls", tier: tierStandard, attr: domain.StorageBucketObjectOwnershipKey, call: func(c context.Context) error {
+			out, err := client.GetBucketOwnershipControls(c, &s3.GetBucketOwnershipControlsInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.OwnershipOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "OwnershipControlsNotFoundError") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketReplication", tier: tierStandard, attr: domain.StorageBucketReplicationKey, call: func(c context.Context) error {
+			out, err := client.GetBucketReplication(c, &s3.GetBucketReplicationInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.ReplicationOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "ReplicationConfigurationNotFoundError") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetObjectLockConfiguration", tier: tierStandard, attr: domain.StorageBucketObjectLockKey, call: func(c context.Context) error {
+			out, err := client.GetObjectLockConfiguration(c, &s3.GetObjectLockConfigurationInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.ObjectLockOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "ObjectLockConfigurationNotFoundError") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketNotificationConfiguration", tier: tierStandard, attr: domain.StorageBucketNotificationKey, call: func(c context.Context) error {
+			out, err := client.GetBucketNotificationConfiguration(c, &s3.GetBucketNotificationConfigurationInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.NotificationOutput = out
+				mu.Unlock()
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketWebsite", tier: tierOptional, attr: domain.StorageBucketWebsiteKey, call: func(c context.Context) error {
+			out, err := client.GetBucketWebsite(c, &s3.GetBucketWebsiteInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.WebsiteOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "NoSuchWebsiteConfiguration") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketCors", tier: tierOptional, attr: domain.StorageBucketCorsRulesKey, call: func(c context.Context) error {
+			out, err := client.GetBucketCors(c, &s3.GetBucketCorsInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.CorsOutput = out
+				mu.Unlock()
+				return nil
+			}
+			if isS3NotFoundError(err, "NoSuchCORSConfiguration") {
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "GetBucketAccelerateConfiguration", tier: tierOptional, attr: domain.StorageBucketAccelerationStatusKey, call: func(c context.Context) error {
+			out, err := client.GetBucketAccelerateConfiguration(c, &s3.GetBucketAccelerateConfigurationInput{Bucket: &bucketName})
+			if err == nil {
+				mu.Lock()
+				input.AccelerateOutput = out
+				mu.Unlock()
+				return nil
+			}
+			return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+		}},
+		{name: "ListBucketIntelligentTieringConfigurations", tier: tierOptional, attr: domain.StorageBucketIntelligentTieringKey, call: func(c context.Context) error {
+			configs, err := listAllConfigurations(c, logger, func(c context.Context, token *string) ([]s3types.IntelligentTieringConfiguration, *string, error) {
+				out, err := client.ListBucketIntelligentTieringConfigurations(c, &s3.ListBucketIntelligentTieringConfigurationsInput{Bucket: &bucketName, ContinuationToken: token})
+				if err != nil || out == nil {
+					return nil, nil, err
+				}
+				return out.IntelligentTieringConfigurationList, nextPageToken(out.IsTruncated, out.NextContinuationToken), nil
+			})
+			if err != nil {
+				return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
 			}
-			return nil
-		})
-	}
-
-	run("GetBucketTagging", func(c context.Context) error {
-		out, err := client.GetBucketTagging(c, &s3.GetBucketTaggingInput{Bucket: &bucketName})
-		if err == nil {
-			mu.Lock()
-			input.TaggingOutput = out
-			mu.Unlock()
-			return nil
-		}
-		if isS3NotFoundError(err, "NoSuchTagSet") {
-			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketVersioning", func(c context.Context) error {
-		out, err := client.GetBucketVersioning(c, &s3.GetBucketVersioningInput{Bucket: &bucketName})
-		if err == nil {
-			mu.Lock()
-			input.VersioningOutput = out
-			mu.Unlock()
-			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketLifecycleConfiguration", func(c context.Context) error {
-		out, err := client.GetBucketLifecycleConfiguration(c, &s3.GetBucketLifecycleConfigurationInput{Bucket: &bucketName})
-		if err == nil {
 			mu.Lock()
-			input.LifecycleOutput = out
+			input.IntelligentTieringConfigurations = configs
 			mu.Unlock()
 			return nil
-		}
-		if isS3NotFoundError(err, "NoSuchLifecycleConfiguration") {
-			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketAcl", func(c context.Context) error {
-		out, err := client.GetBucketAcl(c, &s3.GetBucketAclInput{Bucket: &bucketName})
-		if err == nil {
+		}},
+		{name: "ListBucketAnalyticsConfigurations", tier: tierOptional, attr: domain.StorageBucketAnalyticsKey, call: func(c context.Context) error {
+			configs, err := listAllConfigurations(c, logger, func(c context.Context, token *string) ([]s3types.AnalyticsConfiguration, *string, error) {
+				out, err := client.ListBucketAnalyticsConfigurations(c, &s3.ListBucketAnalyticsConfigurationsInput{Bucket: &bucketName, ContinuationToken: token})
+				if err != nil || out == nil {
+					return nil, nil, err
+				}
+				return out.AnalyticsConfigurationList, nextPageToken(out.IsTruncated, out.NextContinuationToken), nil
+			})
+			if err != nil {
+				return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+			}
 			mu.Lock()
-			input.AclOutput = out
+			input.AnalyticsConfigurations = configs
 			mu.Unlock()
 			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketLogging", func(c context.Context) error {
-		out, err := client.GetBucketLogging(c, &s3.GetBucketLoggingInput{Bucket: &bucketName})
-		if err == nil {
+		}},
+		{name: "ListBucketInventoryConfigurations", tier: tierOptional, attr: domain.StorageBucketInventoryKey, call: func(c context.Context) error {
+			configs, err := listAllConfigurations(c, logger, func(c context.Context, token *string) ([]s3types.InventoryConfiguration, *string, error) {
+				out, err := client.ListBucketInventoryConfigurations(c, &s3.ListBucketInventoryConfigurationsInput{Bucket: &bucketName, ContinuationToken: token})
+				if err != nil || out == nil {
+					return nil, nil, err
+				}
+				return out.InventoryConfigurationList, nextPageToken(out.IsTruncated, out.NextContinuationToken), nil
+			})
+			if err != nil {
+				return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+			}
 			mu.Lock()
-			input.LoggingOutput = out
+			input.InventoryConfigurations = configs
 			mu.Unlock()
 			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketWebsite", func(c context.Context) error {
-		out, err := client.GetBucketWebsite(c, &s3.GetBucketWebsiteInput{Bucket: &bucketName})
-		if err == nil {
+		}},
+		{name: "ListBucketMetricsConfigurations", tier: tierOptional, attr: domain.StorageBucketMetricsKey, call: func(c context.Context) error {
+			configs, err := listAllConfigurations(c, logger, func(c context.Context, token *string) ([]s3types.MetricsConfiguration, *string, error) {
+				out, err := client.ListBucketMetricsConfigurations(c, &s3.ListBucketMetricsConfigurationsInput{Bucket: &bucketName, ContinuationToken: token})
+				if err != nil || out == nil {
+					return nil, nil, err
+				}
+				return out.MetricsConfigurationList, nextPageToken(out.IsTruncated, out.NextContinuationToken), nil
+			})
+			if err != nil {
+				return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
+			}
 			mu.Lock()
-			input.WebsiteOutput = out
+			input.MetricsConfigurations = configs
 			mu.Unlock()
 			return nil
-		}
-		if isS3NotFoundError(err, "NoSuchWebsiteConfiguration") {
-			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
+		}},
+	}
 
-	run("GetBucketCors", func(c context.Context) error {
-		out, err := client.GetBucketCors(c, &s3.GetBucketCorsInput{Bucket: &bucketName})
-		if err == nil {
-			mu.Lock()
-			input.CorsOutput = out
-			mu.Unlock()
-			return nil
-		}
-		if isS3NotFoundError(err, "NoSuchCORSConfiguration") {
-			return nil
-		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
+	if err := runBucketSubCalls(ctx, start, budget, calls, input, &mu, logger); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
 
-	run("GetBucketPolicy", func(c context.Context) error {
-		out, err := client.GetBucketPolicy(c, &s3.GetBucketPolicyInput{Bucket: &bucketName})
-		if err == nil {
-			mu.Lock()
-			input.PolicyOutput = out
-			mu.Unlock()
-			return nil
-		}
-		if isS3NotFoundError(err, "NoSuchBucketPolicy") {
-			return nil
+// listAllConfigurations pages through a bucket configuration listing until a
+// page comes back without a continuation token, waiting on the rate limiter
+// before each page after the first.
+func listAllConfigurations[T any](ctx context.Context, logger ports.Logger, listPage func(context.Context, *string) ([]T, *string, error)) ([]T, error) {
+	var items []T
+	var token *string
+	for {
+		page, next, err := listPage(ctx, token)
+		if err != nil {
+			return nil, err
 		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
-
-	run("GetBucketEncryption", func(c context.Context) error {
-		out, err := client.GetBucketEncryption(c, &s3.GetBucketEncryptionInput{Bucket: &bucketName})
-		if err == nil {
-			mu.Lock()
-			input.EncryptionOutput = out
-			mu.Unlock()
-			return nil
+		items = append(items, page...)
+		if next == nil {
+			return items, nil
 		}
-		if isS3NotFoundError(err, "ServerSideEncryptionConfigurationNotFoundError") {
-			return nil
+		token = next
+		if err := aws_limiter.Wait(ctx, logger); err != nil {
+			return nil, err
 		}
-		return aws_errors.HandleAWSError("S3 bucket", bucketName, err, c)
-	})
+	}
+}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+// nextPageToken returns the continuation token of a truncated page, or nil
+// for the last page.
+func nextPageToken(truncated *bool, token *string) *string {
+	if !aws.ToBool(truncated) || aws.ToString(token) == "" {
+		return nil
 	}
-	return input, nil
+	return token
 }
 
 func mapAPIDataToDomainAttrs(in *s3BucketAttributesInput, logger ports.Logger) map[string]any {
@@ -315,6 +560,9 @@ func mapAPIDataToDomainAttrs(in *s3BucketAttributesInput, logger ports.Logger) m
 		domain.KeyRegion: in.Region,
 		domain.KeyARN:    fmt.Sprintf("arn:aws:s3:::%s", in.BucketName),
 	}
+	if len(in.SkippedAttributes) > 0 {
+		attrs[domain.KeySkippedAttributes] = append([]string(nil), in.SkippedAttributes...)
+	}
 
 	tags := map[string]string{}
 	if in.TaggingOutput != nil {
@@ -388,9 +636,202 @@ func mapAPIDataToDomainAttrs(in *s3BucketAttributesInput, logger ports.Logger) m
 		}
 	}
 
+	if in.PublicAccessBlockOutput != nil && in.PublicAccessBlockOutput.PublicAccessBlockConfiguration != nil {
+		attrs[domain.StorageBucketPublicAccessBlockKey] = mapPublicAccessBlock(in.PublicAccessBlockOutput.PublicAccessBlockConfiguration)
+	}
+
+	if in.OwnershipOutput != nil && in.OwnershipOutput.OwnershipControls != nil {
+		for _, rule := range in.OwnershipOutput.OwnershipControls.Rules {
+			if rule.ObjectOwnership != "" {
+				attrs[domain.StorageBucketObjectOwnershipKey] = string(rule.ObjectOwnership)
+				break
+			}
+		}
+	}
+
+	if in.AccelerateOutput != nil && in.AccelerateOutput.Status != "" {
+		attrs[domain.StorageBucketAccelerationStatusKey] = string(in.AccelerateOutput.Status)
+	}
+
+	if in.ReplicationOutput != nil && in.ReplicationOutput.ReplicationConfiguration != nil {
+		attrs[domain.StorageBucketReplicationKey] = mapReplication(in.ReplicationOutput.ReplicationConfiguration)
+	}
+
+	if in.ObjectLockOutput != nil && in.ObjectLockOutput.ObjectLockConfiguration != nil {
+		attrs[domain.StorageBucketObjectLockKey] = mapObjectLock(in.ObjectLockOutput.ObjectLockConfiguration)
+	}
+
+	if in.NotificationOutput != nil {
+		if m := mapNotifications(in.NotificationOutput); m != nil {
+			attrs[domain.StorageBucketNotificationKey] = m
+		}
+	}
+
+	if len(in.IntelligentTieringConfigurations) > 0 {
+		attrs[domain.StorageBucketIntelligentTieringKey] = mapIntelligentTieringConfigurations(in.IntelligentTieringConfigurations)
+	}
+	if len(in.AnalyticsConfigurations) > 0 {
+		attrs[domain.StorageBucketAnalyticsKey] = mapAnalyticsConfigurations(in.AnalyticsConfigurations)
+	}
+	if len(in.InventoryConfigurations) > 0 {
+		attrs[domain.StorageBucketInventoryKey] = mapInventoryConfigurations(in.InventoryConfigurations)
+	}
+	if len(in.MetricsConfigurations) > 0 {
+		attrs[domain.StorageBucketMetricsKey] = mapMetricsConfigurations(in.MetricsConfigurations)
+	}
+
 	return attrs
 }
 
+// mapPublicAccessBlock maps the Block Public Access settings to booleans,
+// with unset settings as false like S3 applies them.
+func mapPublicAccessBlock(cfg *s3types.PublicAccessBlockConfiguration) map[string]any {
+	return map[string]any{
+		"block_public_acls":       aws.ToBool(cfg.BlockPublicAcls),
+		"block_public_policy":     aws.ToBool(cfg.BlockPublicPolicy),
+		"ignore_public_acls":      aws.ToBool(cfg.IgnorePublicAcls),
+		"restrict_public_buckets": aws.ToBool(cfg.RestrictPublicBuckets),
+	}
+}
+
+// mapReplication maps the replication role and rules. The filter prefix and
+// the legacy rule prefix both become "prefix", and settings left unset,
+// including a zero priority, are omitted so they match Terraform state, which
+// holds them as empty values.
+func mapReplication(cfg *s3types.ReplicationConfiguration) map[string]any {
+	rules := make([]map[string]any, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		m := map[string]any{"status": string(rule.Status)}
+		if id := aws.ToString(rule.ID); id != "" {
+			m["id"] = id
+		}
+		if priority := aws.ToInt32(rule.Priority); priority > 0 {
+			m["priority"] = int64(priority)
+		}
+		prefix := aws.ToString(rule.Prefix)
+		if rule.Filter != nil {
+			tags := map[string]string{}
+			if rule.Filter.Prefix != nil {
+				prefix = *rule.Filter.Prefix
+			}
+			if rule.Filter.Tag != nil {
+				tags[aws.ToString(rule.Filter.Tag.Key)] = aws.ToString(rule.Filter.Tag.Value)
+			}
+			if and := rule.Filter.And; and != nil {
+				if and.Prefix != nil {
+					prefix = *and.Prefix
+				}
+				for _, t := range and.Tags {
+					tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+				}
+			}
+			if len(tags) > 0 {
+				m["tags"] = tags
+			}
+		}
+		if prefix != "" {
+			m["prefix"] = prefix
+		}
+		if d := rule.Destination; d != nil {
+			dest := map[string]any{"bucket": aws.ToString(d.Bucket)}
+			if d.StorageClass != "" {
+				dest["storage_class"] = string(d.StorageClass)
+			}
+			if account := aws.ToString(d.Account); account != "" {
+				dest["account"] = account
+			}
+			m["destination"] = dest
+		}
+		if rule.DeleteMarkerReplication != nil && rule.DeleteMarkerReplication.Status != "" {
+			m["delete_marker_replication"] = string(rule.DeleteMarkerReplication.Status)
+		}
+		rules = append(rules, m)
+	}
+	out := map[string]any{"rules": rules}
+	if role := aws.ToString(cfg.Role); role != "" {
+		out["role"] = role
+	}
+	return out
+}
+
+// mapObjectLock maps Object Lock to whether it is enabled and the default
+// retention applied to new objects, if any.
+func mapObjectLock(cfg *s3types.ObjectLockConfiguration) map[string]any {
+	out := map[string]any{"enabled": cfg.ObjectLockEnabled == s3types.ObjectLockEnabledEnabled}
+	if cfg.Rule == nil || cfg.Rule.DefaultRetention == nil {
+		return out
+	}
+	retention := map[string]any{}
+	if mode := cfg.Rule.DefaultRetention.Mode; mode != "" {
+		retention["mode"] = string(mode)
+	}
+	if days := aws.ToInt32(cfg.Rule.DefaultRetention.Days); days > 0 {
+		retention["days"] = int64(days)
+	}
+	if years := aws.ToInt32(cfg.Rule.DefaultRetention.Years); years > 0 {
+		retention["years"] = int64(years)
+	}
+	if len(retention) > 0 {
+		out["default_retention"] = retention
+	}
+	return out
+}
+
+// mapNotifications maps the event notifications to lists of Lambda function,
+// queue and topic targets sorted by ARN, each with its sorted events and key
+// filter, or nil when the bucket sends no notifications.
+func mapNotifications(out *s3.GetBucketNotificationConfigurationOutput) map[string]any {
+	m := map[string]any{}
+	if out.EventBridgeConfiguration != nil {
+		m["eventbridge"] = true
+	}
+	lambdas := make([]map[string]any, 0, len(out.LambdaFunctionConfigurations))
+	for _, cfg := range out.LambdaFunctionConfigurations {
+		lambdas = append(lambdas, mapNotificationTarget(cfg.Id, cfg.LambdaFunctionArn, cfg.Events, cfg.Filter))
+	}
+	queues := make([]map[string]any, 0, len(out.QueueConfigurations))
+	for _, cfg := range out.QueueConfigurations {
+		queues = append(queues, mapNotificationTarget(cfg.Id, cfg.QueueArn, cfg.Events, cfg.Filter))
+	}
+	topics := make([]map[string]any, 0, len(out.TopicConfigurations))
+	for _, cfg := range out.TopicConfigurations {
+		topics = append(topics, mapNotificationTarget(cfg.Id, cfg.TopicArn, cfg.Events, cfg.Filter))
+	}
+	for key, targets := range map[string][]map[string]any{"lambda_functions": lambdas, "queues": queues, "topics": topics} {
+		if len(targets) == 0 {
+			continue
+		}
+		sort.SliceStable(targets, func(i, j int) bool {
+			return targets[i]["arn"].(string) < targets[j]["arn"].(string)
+		})
+		m[key] = targets
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func mapNotificationTarget(id, arn *string, events []s3types.Event, filter *s3types.NotificationConfigurationFilter) map[string]any {
+	names := make([]string, 0, len(events))
+	for _, e := range events {
+		names = append(names, string(e))
+	}
+	sort.Strings(names)
+	target := map[string]any{"arn": aws.ToString(arn), "events": names}
+	if v := aws.ToString(id); v != "" {
+		target["id"] = v
+	}
+	if filter != nil && filter.Key != nil {
+		for _, rule := range filter.Key.FilterRules {
+			if value := aws.ToString(rule.Value); value != "" {
+				target["filter_"+strings.ToLower(string(rule.Name))] = value
+			}
+		}
+	}
+	return target
+}
+
 func mapLifecycleRules(rules []s3types.LifecycleRule) []map[string]any {
 	result := make([]map[string]any, 0, len(rules))
 	for _, rule := range rules {
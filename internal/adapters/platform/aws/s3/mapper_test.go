@@ -74,6 +74,16 @@ func TestS3ResourceTestSuite(t *testing.T) {
 	suite.Run(t, new(S3ResourceTestSuite))
 }
 
+// fetchAllBucketAttributes resolves the region of a bucket and fetches its
+// configuration, as a bucket resource does across build and first use.
+func fetchAllBucketAttributes(ctx context.Context, bucketName string, cfg aws.Config, logger ports.Logger, s3Factory func(aws.Config) S3ClientInterface, budget time.Duration) (*s3BucketAttributesInput, error) {
+	region, err := resolveBucketRegion(ctx, bucketName, cfg, logger, s3Factory)
+	if err != nil {
+		return nil, err
+	}
+	return fetchBucketConfiguration(ctx, bucketName, region, cfg, logger, s3Factory, budget)
+}
+
 // --- Helper Methods ---
 
 func (s *S3ResourceTestSuite) mockGetBucketLocationSuccess(bucketName, region string) {
@@ -286,6 +296,44 @@ func (s *S3ResourceTestSuite) mockGetEncryptionNotFound(bucketName string) {
 	})).Return(nil, err).Maybe()
 }
 
+func (s *S3ResourceTestSuite) mockGetAccessConfigNotFound(bucketName string) {
+	// Use Maybe() as they run concurrently
+	s.mockS3.On("GetPublicAccessBlock", mock.Anything, mock.MatchedBy(func(input *s3.GetPublicAccessBlockInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(nil, &smithy.GenericAPIError{Code: "NoSuchPublicAccessBlockConfiguration"}).Maybe()
+	s.mockS3.On("GetBucketOwnershipControls", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketOwnershipControlsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(nil, &smithy.GenericAPIError{Code: "OwnershipControlsNotFoundError"}).Maybe()
+	s.mockS3.On("GetBucketAccelerateConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketAccelerateConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketAccelerateConfigurationOutput{}, nil).Maybe()
+	s.mockS3.On("GetBucketReplication", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketReplicationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(nil, &smithy.GenericAPIError{Code: "ReplicationConfigurationNotFoundError"}).Maybe()
+	s.mockS3.On("GetObjectLockConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectLockConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(nil, &smithy.GenericAPIError{Code: "ObjectLockConfigurationNotFoundError"}).Maybe()
+	s.mockS3.On("GetBucketNotificationConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketNotificationConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketNotificationConfigurationOutput{}, nil).Maybe()
+}
+
+func (s *S3ResourceTestSuite) mockListConfigurationsEmpty(bucketName string) {
+	// Use Maybe() as they run concurrently
+	s.mockS3.On("ListBucketIntelligentTieringConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketIntelligentTieringConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketIntelligentTieringConfigurationsOutput{}, nil).Maybe()
+	s.mockS3.On("ListBucketAnalyticsConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketAnalyticsConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketAnalyticsConfigurationsOutput{}, nil).Maybe()
+	s.mockS3.On("ListBucketInventoryConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketInventoryConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketInventoryConfigurationsOutput{}, nil).Maybe()
+	s.mockS3.On("ListBucketMetricsConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketMetricsConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketMetricsConfigurationsOutput{}, nil).Maybe()
+}
+
 func (s *S3ResourceTestSuite) mockGetAllAttributesSuccess(bucketName, region string) {
 	s.mockGetTaggingSuccess(bucketName, map[string]string{"Name": "test-bucket-name", "Env": "test"})
 	s.mockS3.On("GetBucketAcl", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketAclInput) bool {
@@ -368,6 +416,55 @@ func (s *S3ResourceTestSuite) mockGetAllAttributesSuccess(bucketName, region str
 			},
 		},
 	}, nil).Maybe()
+	s.mockS3.On("GetPublicAccessBlock", mock.Anything, mock.MatchedBy(func(input *s3.GetPublicAccessBlockInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+		BlockPublicAcls: aws.Bool(true), BlockPublicPolicy: aws.Bool(true), IgnorePublicAcls: aws.Bool(true), RestrictPublicBuckets: aws.Bool(true),
+	}}, nil).Maybe()
+	s.mockS3.On("GetBucketOwnershipControls", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketOwnershipControlsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketOwnershipControlsOutput{OwnershipControls: &s3types.OwnershipControls{
+		Rules: []s3types.OwnershipControlsRule{{ObjectOwnership: s3types.ObjectOwnershipBucketOwnerEnforced}},
+	}}, nil).Maybe()
+	s.mockS3.On("GetBucketAccelerateConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketAccelerateConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketAccelerateConfigurationOutput{Status: s3types.BucketAccelerateStatusEnabled}, nil).Maybe()
+	s.mockS3.On("GetBucketReplication", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketReplicationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketReplicationOutput{ReplicationConfiguration: &s3types.ReplicationConfiguration{
+		Role:  aws.String("arn:aws:iam::123456789012:role/replication"),
+		Rules: []s3types.ReplicationRule{{ID: aws.String("all"), Status: s3types.ReplicationRuleStatusEnabled, Destination: &s3types.Destination{Bucket: aws.String("arn:aws:s3:::replica")}}},
+	}}, nil).Maybe()
+	s.mockS3.On("GetObjectLockConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectLockConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetObjectLockConfigurationOutput{ObjectLockConfiguration: &s3types.ObjectLockConfiguration{ObjectLockEnabled: s3types.ObjectLockEnabledEnabled}}, nil).Maybe()
+	s.mockS3.On("GetBucketNotificationConfiguration", mock.Anything, mock.MatchedBy(func(input *s3.GetBucketNotificationConfigurationInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.GetBucketNotificationConfigurationOutput{QueueConfigurations: []s3types.QueueConfiguration{{
+		QueueArn: aws.String("arn:aws:sqs:us-east-1:123456789012:uploads"), Events: []s3types.Event{"s3:ObjectCreated:*"},
+	}}}, nil).Maybe()
+	s.mockS3.On("ListBucketIntelligentTieringConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketIntelligentTieringConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName && input.ContinuationToken == nil
+	})).Return(&s3.ListBucketIntelligentTieringConfigurationsOutput{
+		IntelligentTieringConfigurationList: []s3types.IntelligentTieringConfiguration{{Id: aws.String("archive"), Status: s3types.IntelligentTieringStatusEnabled}},
+		IsTruncated:                         aws.Bool(true),
+		NextContinuationToken:               aws.String("page-2"),
+	}, nil).Maybe()
+	s.mockS3.On("ListBucketIntelligentTieringConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketIntelligentTieringConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName && aws.ToString(input.ContinuationToken) == "page-2"
+	})).Return(&s3.ListBucketIntelligentTieringConfigurationsOutput{
+		IntelligentTieringConfigurationList: []s3types.IntelligentTieringConfiguration{{Id: aws.String("deep-archive"), Status: s3types.IntelligentTieringStatusDisabled}},
+		IsTruncated:                         aws.Bool(false),
+	}, nil).Maybe()
+	s.mockS3.On("ListBucketAnalyticsConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketAnalyticsConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketAnalyticsConfigurationsOutput{AnalyticsConfigurationList: []s3types.AnalyticsConfiguration{{Id: aws.String("all")}}}, nil).Maybe()
+	s.mockS3.On("ListBucketInventoryConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketInventoryConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketInventoryConfigurationsOutput{InventoryConfigurationList: []s3types.InventoryConfiguration{{Id: aws.String("weekly"), IsEnabled: aws.Bool(true)}}}, nil).Maybe()
+	s.mockS3.On("ListBucketMetricsConfigurations", mock.Anything, mock.MatchedBy(func(input *s3.ListBucketMetricsConfigurationsInput) bool {
+		return aws.ToString(input.Bucket) == bucketName
+	})).Return(&s3.ListBucketMetricsConfigurationsOutput{MetricsConfigurationList: []s3types.MetricsConfiguration{{Id: aws.String("EntireBucket")}}}, nil).Maybe()
 }
 
 func (s *S3ResourceTestSuite) mockGetAllAttributesMinimal(bucketName, region string) {
@@ -386,6 +483,8 @@ func (s *S3ResourceTestSuite) mockGetAllAttributesMinimal(bucketName, region str
 	s.mockGetCorsNotFound(bucketName)
 	s.mockGetPolicyNotFound(bucketName)
 	s.mockGetEncryptionNotFound(bucketName)
+	s.mockGetAccessConfigNotFound(bucketName)
+	s.mockListConfigurationsEmpty(bucketName)
 }
 
 // --- Test Cases ---
@@ -420,7 +519,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_Success_UsEast1() {
 		},
 	}, nil).Maybe()
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().NoError(err)
 	s.Require().NotNil(input)
@@ -435,6 +534,11 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_Success_UsEast1() {
 	s.NotNil(input.CorsOutput)
 	s.NotNil(input.PolicyOutput)
 	s.NotNil(input.EncryptionOutput)
+	s.Require().Len(input.IntelligentTieringConfigurations, 2, "both pages are listed")
+	s.Equal("deep-archive", aws.ToString(input.IntelligentTieringConfigurations[1].Id))
+	s.Len(input.AnalyticsConfigurations, 1)
+	s.Len(input.InventoryConfigurations, 1)
+	s.Len(input.MetricsConfigurations, 1)
 
 	s.mockS3.AssertExpectations(s.T())
 }
@@ -457,7 +561,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_Success_OtherRegion()
 	s.mockS3.On("GetBucketPolicy", mock.Anything, mock.Anything).Return(&s3.GetBucketPolicyOutput{Policy: aws.String(`{"Version": "2012-10-17"}`)}, nil).Maybe()
 	s.mockS3.On("GetBucketEncryption", mock.Anything, mock.Anything).Return(&s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{ /* ... */ }}, nil).Maybe()
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().NoError(err)
 	s.Require().NotNil(input)
@@ -492,7 +596,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_LocationFallback() {
 	s.mockS3.On("GetBucketPolicy", mock.Anything, mock.Anything).Return(&s3.GetBucketPolicyOutput{Policy: aws.String(`{"Version": "2012-10-17"}`)}, nil).Maybe()
 	s.mockS3.On("GetBucketEncryption", mock.Anything, mock.Anything).Return(&s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{ /* ... */ }}, nil).Maybe()
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().NoError(err)
 	s.Require().NotNil(input)
@@ -513,7 +617,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_LocationFallbackFails
 	s.mockGetBucketLocationError(bucketName, accessDeniedErr)
 	s.mockHeadBucketError(bucketName, headBucketErr) // HeadBucket also fails
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().Error(err)
 	s.Nil(input)
@@ -530,7 +634,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_LocationErrorNonAcces
 	s.mockGetBucketLocationError(bucketName, locationErr)
 	// HeadBucket should not be called
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().Error(err)
 	s.Nil(input)
@@ -556,8 +660,10 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_ConcurrentError() {
 	s.mockGetCorsNotFound(bucketName)
 	s.mockGetPolicyNotFound(bucketName)
 	s.mockGetEncryptionNotFound(bucketName)
+	s.mockGetAccessConfigNotFound(bucketName)
+	s.mockListConfigurationsEmpty(bucketName)
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().Error(err)
 	s.Nil(input)
@@ -581,6 +687,8 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_PartialNotFound() {
 	s.mockGetCorsNotFound(bucketName)           // Not found
 	s.mockGetPolicyNotFound(bucketName)         // Not found
 	s.mockGetEncryptionNotFound(bucketName)     // Not found
+	s.mockGetAccessConfigNotFound(bucketName)   // Not found
+	s.mockListConfigurationsEmpty(bucketName)
 
 	// Explicitly use Maybe() for the mocks set up by the helper
 	s.mockGetAllAttributesMinimal(bucketName, region)
@@ -596,7 +704,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_PartialNotFound() {
 	s.mockS3.On("GetBucketPolicy", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}).Maybe()
 	s.mockS3.On("GetBucketEncryption", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"}).Maybe()
 
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	s.Require().NoError(err)
 	s.Require().NotNil(input)
@@ -615,6 +723,47 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_PartialNotFound() {
 	s.mockS3.AssertExpectations(s.T())
 }
 
+func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_BudgetSpentSkipsOptional() {
+	bucketName := "budget-bucket"
+	region := "eu-west-1"
+
+	s.mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	s.mockGetBucketLocationSuccess(bucketName, region)
+	s.mockGetPolicyNotFound(bucketName)
+	s.mockGetEncryptionSuccessAES(bucketName)
+	s.mockGetAclSuccess(bucketName)
+	s.mockGetTaggingSuccess(bucketName, map[string]string{"Env": "test"})
+	s.mockGetVersioningSuccess(bucketName, s3types.BucketVersioningStatusEnabled)
+	s.mockGetLifecycleNotFound(bucketName)
+	s.mockGetLoggingSuccess(bucketName, "", "")
+	s.mockGetAccessConfigNotFound(bucketName)
+	s.mockListConfigurationsEmpty(bucketName)
+
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, time.Nanosecond)
+
+	s.Require().NoError(err)
+	s.Require().NotNil(input)
+	s.NotNil(input.EncryptionOutput, "critical attributes are always fetched")
+	s.NotNil(input.VersioningOutput, "standard attributes are always fetched")
+	s.Nil(input.WebsiteOutput)
+	s.Nil(input.CorsOutput)
+	s.Nil(input.PublicAccessBlockOutput, "a missing public access block is no configuration")
+	s.Equal([]string{
+		iddomain.StorageBucketAccelerationStatusKey,
+		iddomain.StorageBucketAnalyticsKey,
+		iddomain.StorageBucketCorsRulesKey,
+		iddomain.StorageBucketIntelligentTieringKey,
+		iddomain.StorageBucketInventoryKey,
+		iddomain.StorageBucketMetricsKey,
+		iddomain.StorageBucketWebsiteKey,
+	}, input.SkippedAttributes)
+	s.mockS3.AssertNotCalled(s.T(), "GetBucketWebsite", mock.Anything, mock.Anything)
+	s.mockS3.AssertNotCalled(s.T(), "GetBucketCors", mock.Anything, mock.Anything)
+
+	attrs := mapAPIDataToDomainAttrs(input, s.mockLogger)
+	s.Equal(input.SkippedAttributes, attrs[iddomain.KeySkippedAttributes])
+}
+
 func (s *S3ResourceTestSuite) TestBuildS3BucketResource_Success() {
 	bucketName := "test-bucket"
 	accountID := "123456789012"
@@ -628,11 +777,11 @@ func (s *S3ResourceTestSuite) TestBuildS3BucketResource_Success() {
 	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
 
 	// Build the resource with the factory function
-	builtResource := buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory)
+	builtResource := buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory, 0)
 
 	// Validate the resource
 	s.Require().NotNil(builtResource)
-	s.True(builtResource.attributesBuilt)
+	s.False(builtResource.attributesBuilt, "the configuration is fetched on first use")
 	s.NoError(builtResource.fetchErr)
 
 	meta := builtResource.Metadata()
@@ -645,6 +794,7 @@ func (s *S3ResourceTestSuite) TestBuildS3BucketResource_Success() {
 	attrs, err := builtResource.Attributes(s.ctx)
 	s.NoError(err)
 	s.NotNil(attrs)
+	s.True(builtResource.attributesBuilt)
 	s.Equal(bucketName, attrs[iddomain.KeyID])
 	s.Equal("test-bucket-name", attrs[iddomain.KeyName]) // From Name tag
 	s.Equal(region, attrs[iddomain.KeyRegion])
@@ -668,6 +818,26 @@ func (s *S3ResourceTestSuite) TestBuildS3BucketResource_Success() {
 	s.mockS3.AssertExpectations(s.T())
 }
 
+func (s *S3ResourceTestSuite) TestBuildS3BucketResource_FetchesConfigurationOnce() {
+	bucketName := "lazy-bucket"
+	region := "eu-west-1"
+	s.mockGetBucketLocationSuccess(bucketName, region)
+	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
+
+	resource := buildS3BucketResource(s.ctx, bucketName, "123456789012", s.awsConfig, s.mockLogger, mockFactory, 0)
+
+	s.Equal(region, resource.Metadata().Region)
+	s.mockS3.AssertNumberOfCalls(s.T(), "GetBucketLocation", 1)
+	s.mockS3.AssertNotCalled(s.T(), "GetBucketPolicy", mock.Anything, mock.Anything)
+
+	s.mockGetAllAttributesSuccess(bucketName, region)
+	_, err := resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	_, err = resource.Attributes(s.ctx)
+	s.Require().NoError(err)
+	s.mockS3.AssertNumberOfCalls(s.T(), "GetBucketPolicy", 1)
+}
+
 // Renamed from TestBuildS3BucketResource_FetchError
 func (s *S3ResourceTestSuite) TestBuildS3BucketResource_FetchAttributesError() {
 	bucketName := "fetch-error-bucket"
@@ -685,7 +855,7 @@ func (s *S3ResourceTestSuite) TestBuildS3BucketResource_FetchAttributesError() {
 	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
 
 	// Build the resource
-	resource := buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory)
+	resource := buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory, 0)
 
 	// Verify the resource has the expected properties after an error
 	s.Require().NotNil(resource)
@@ -736,7 +906,7 @@ func (s *S3ResourceTestSuite) TestBuildS3BucketResource_FetchReturnsNilDataNoErr
 	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
 
 	// Directly test the resource building part after a successful fetch
-	_ = buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory)
+	_ = buildS3BucketResource(s.ctx, bucketName, accountID, s.awsConfig, s.mockLogger, mockFactory, 0)
 
 	// Because fetchAllBucketAttributes *actually* returns data on success,
 	// we won't hit the `err == nil && data == nil` case in buildS3BucketResource.
@@ -819,6 +989,8 @@ func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_Minimal() {
 		// All other fields are nil or empty
 		VersioningOutput: &s3.GetBucketVersioningOutput{}, // No status
 		LoggingOutput:    &s3.GetBucketLoggingOutput{},    // No LoggingEnabled
+		// No targets configured
+		NotificationOutput: &s3.GetBucketNotificationConfigurationOutput{},
 	}
 
 	attrs := mapAPIDataToDomainAttrs(input, s.mockLogger)
@@ -838,6 +1010,7 @@ func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_Minimal() {
 	s.NotContains(attrs, iddomain.StorageBucketWebsiteKey)
 	s.NotContains(attrs, iddomain.StorageBucketLoggingKey)
 	s.NotContains(attrs, iddomain.StorageBucketLifecycleRulesKey)
+	s.NotContains(attrs, iddomain.StorageBucketNotificationKey)
 }
 
 func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_Full() {
@@ -902,6 +1075,95 @@ func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_Full() {
 				}},
 			},
 		},
+		PublicAccessBlockOutput: &s3.GetPublicAccessBlockOutput{
+			PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+				BlockPublicAcls: aws.Bool(true), BlockPublicPolicy: aws.Bool(true), IgnorePublicAcls: aws.Bool(false),
+				// RestrictPublicBuckets is nil, should default to false
+			},
+		},
+		OwnershipOutput: &s3.GetBucketOwnershipControlsOutput{
+			OwnershipControls: &s3types.OwnershipControls{
+				Rules: []s3types.OwnershipControlsRule{{ObjectOwnership: s3types.ObjectOwnershipBucketOwnerEnforced}},
+			},
+		},
+		AccelerateOutput: &s3.GetBucketAccelerateConfigurationOutput{Status: s3types.BucketAccelerateStatusSuspended},
+		ReplicationOutput: &s3.GetBucketReplicationOutput{
+			ReplicationConfiguration: &s3types.ReplicationConfiguration{
+				Role: aws.String("arn:aws:iam::123456789012:role/replication"),
+				Rules: []s3types.ReplicationRule{{
+					ID:       aws.String("logs"),
+					Priority: aws.Int32(2),
+					Status:   s3types.ReplicationRuleStatusEnabled,
+					Filter: &s3types.ReplicationRuleFilter{And: &s3types.ReplicationRuleAndOperator{
+						Prefix: aws.String("logs/"),
+						Tags:   []s3types.Tag{{Key: aws.String("Replicate"), Value: aws.String("yes")}},
+					}},
+					Destination:             &s3types.Destination{Bucket: aws.String("arn:aws:s3:::replica"), StorageClass: s3types.StorageClassStandardIa},
+					DeleteMarkerReplication: &s3types.DeleteMarkerReplication{Status: s3types.DeleteMarkerReplicationStatusDisabled},
+				}},
+			},
+		},
+		ObjectLockOutput: &s3.GetObjectLockConfigurationOutput{
+			ObjectLockConfiguration: &s3types.ObjectLockConfiguration{
+				ObjectLockEnabled: s3types.ObjectLockEnabledEnabled,
+				Rule: &s3types.ObjectLockRule{DefaultRetention: &s3types.DefaultRetention{
+					Mode: s3types.ObjectLockRetentionModeGovernance, Days: aws.Int32(30),
+				}},
+			},
+		},
+		NotificationOutput: &s3.GetBucketNotificationConfigurationOutput{
+			EventBridgeConfiguration: &s3types.EventBridgeConfiguration{},
+			LambdaFunctionConfigurations: []s3types.LambdaFunctionConfiguration{{
+				Id:                aws.String("thumbnails"),
+				LambdaFunctionArn: aws.String("arn:aws:lambda:us-east-1:123456789012:function:thumbnails"),
+				Events:            []s3types.Event{"s3:ObjectRemoved:*", "s3:ObjectCreated:Put"},
+				Filter: &s3types.NotificationConfigurationFilter{Key: &s3types.S3KeyFilter{FilterRules: []s3types.FilterRule{
+					{Name: "Prefix", Value: aws.String("images/")},
+					{Name: "Suffix", Value: aws.String(".jpg")},
+				}}},
+			}},
+			TopicConfigurations: []s3types.TopicConfiguration{
+				{TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:uploads"), Events: []s3types.Event{"s3:ObjectCreated:*"}},
+				{TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:audit"), Events: []s3types.Event{"s3:ObjectRemoved:*"}},
+			},
+		},
+		IntelligentTieringConfigurations: []s3types.IntelligentTieringConfiguration{{
+			Id:     aws.String("archive"),
+			Status: s3types.IntelligentTieringStatusEnabled,
+			Filter: &s3types.IntelligentTieringFilter{And: &s3types.IntelligentTieringAndOperator{
+				Prefix: aws.String("cold/"),
+				Tags:   []s3types.Tag{{Key: aws.String("tier"), Value: aws.String("archive")}},
+			}},
+			Tierings: []s3types.Tiering{
+				{AccessTier: s3types.IntelligentTieringAccessTierDeepArchiveAccess, Days: aws.Int32(180)},
+				{AccessTier: s3types.IntelligentTieringAccessTierArchiveAccess, Days: aws.Int32(90)},
+			},
+		}},
+		AnalyticsConfigurations: []s3types.AnalyticsConfiguration{{
+			Id:     aws.String("logs"),
+			Filter: &s3types.AnalyticsFilterMemberPrefix{Value: "logs/"},
+			StorageClassAnalysis: &s3types.StorageClassAnalysis{DataExport: &s3types.StorageClassAnalysisDataExport{
+				OutputSchemaVersion: s3types.StorageClassAnalysisSchemaVersionV1,
+				Destination: &s3types.AnalyticsExportDestination{S3BucketDestination: &s3types.AnalyticsS3BucketDestination{
+					Bucket: aws.String("arn:aws:s3:::analytics"), Format: s3types.AnalyticsS3ExportFileFormatCsv,
+				}},
+			}},
+		}},
+		InventoryConfigurations: []s3types.InventoryConfiguration{{
+			Id:                     aws.String("weekly"),
+			IsEnabled:              aws.Bool(true),
+			IncludedObjectVersions: s3types.InventoryIncludedObjectVersionsCurrent,
+			Schedule:               &s3types.InventorySchedule{Frequency: s3types.InventoryFrequencyWeekly},
+			OptionalFields:         []s3types.InventoryOptionalField{s3types.InventoryOptionalFieldStorageClass, s3types.InventoryOptionalFieldSize},
+			Destination: &s3types.InventoryDestination{S3BucketDestination: &s3types.InventoryS3BucketDestination{
+				Bucket: aws.String("arn:aws:s3:::inventory"), Format: s3types.InventoryFormatParquet,
+				Encryption: &s3types.InventoryEncryption{SSEKMS: &s3types.SSEKMS{KeyId: aws.String(kmsKey)}},
+			}},
+		}},
+		MetricsConfigurations: []s3types.MetricsConfiguration{
+			{Id: aws.String("tagged"), Filter: &s3types.MetricsFilterMemberTag{Value: s3types.Tag{Key: aws.String("team"), Value: aws.String("data")}}},
+			{Id: aws.String("EntireBucket")},
+		},
 	}
 
 	attrs := mapAPIDataToDomainAttrs(input, s.mockLogger)
@@ -986,6 +1248,82 @@ func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_Full() {
 	applyMap := encRule["apply_server_side_encryption_by_default"].(map[string]any)
 	s.Equal(string(s3types.ServerSideEncryptionAwsKms), applyMap["sse_algorithm"])
 	s.Equal(kmsKey, applyMap["kms_master_key_id"])
+
+	// Public access block, ownership and acceleration
+	s.Equal(map[string]any{
+		"block_public_acls":       true,
+		"block_public_policy":     true,
+		"ignore_public_acls":      false,
+		"restrict_public_buckets": false,
+	}, attrs[iddomain.StorageBucketPublicAccessBlockKey])
+	s.Equal("BucketOwnerEnforced", attrs[iddomain.StorageBucketObjectOwnershipKey])
+	s.Equal("Suspended", attrs[iddomain.StorageBucketAccelerationStatusKey])
+
+	// Replication and Object Lock
+	s.Equal(map[string]any{
+		"role": "arn:aws:iam::123456789012:role/replication",
+		"rules": []map[string]any{{
+			"id":                        "logs",
+			"priority":                  int64(2),
+			"status":                    "Enabled",
+			"prefix":                    "logs/",
+			"tags":                      map[string]string{"Replicate": "yes"},
+			"destination":               map[string]any{"bucket": "arn:aws:s3:::replica", "storage_class": "STANDARD_IA"},
+			"delete_marker_replication": "Disabled",
+		}},
+	}, attrs[iddomain.StorageBucketReplicationKey])
+	s.Equal(map[string]any{
+		"enabled":           true,
+		"default_retention": map[string]any{"mode": "GOVERNANCE", "days": int64(30)},
+	}, attrs[iddomain.StorageBucketObjectLockKey])
+
+	// Event notifications
+	s.Equal(map[string]any{
+		"eventbridge": true,
+		"lambda_functions": []map[string]any{{
+			"id":            "thumbnails",
+			"arn":           "arn:aws:lambda:us-east-1:123456789012:function:thumbnails",
+			"events":        []string{"s3:ObjectCreated:Put", "s3:ObjectRemoved:*"},
+			"filter_prefix": "images/",
+			"filter_suffix": ".jpg",
+		}},
+		"topics": []map[string]any{
+			{"arn": "arn:aws:sns:us-east-1:123456789012:audit", "events": []string{"s3:ObjectRemoved:*"}},
+			{"arn": "arn:aws:sns:us-east-1:123456789012:uploads", "events": []string{"s3:ObjectCreated:*"}},
+		},
+	}, attrs[iddomain.StorageBucketNotificationKey], "targets sorted by ARN, events sorted")
+
+	// Configuration listings
+	s.Equal([]map[string]any{{
+		"id":     "archive",
+		"status": "Enabled",
+		"prefix": "cold/",
+		"tags":   map[string]string{"tier": "archive"},
+		"tierings": []map[string]any{
+			{"access_tier": "ARCHIVE_ACCESS", "days": int64(90)},
+			{"access_tier": "DEEP_ARCHIVE_ACCESS", "days": int64(180)},
+		},
+	}}, attrs[iddomain.StorageBucketIntelligentTieringKey])
+	s.Equal([]map[string]any{{
+		"id":     "logs",
+		"prefix": "logs/",
+		"export": map[string]any{"bucket": "arn:aws:s3:::analytics", "format": "CSV"},
+	}}, attrs[iddomain.StorageBucketAnalyticsKey])
+	s.Equal([]map[string]any{{
+		"id":                       "weekly",
+		"enabled":                  true,
+		"included_object_versions": "Current",
+		"frequency":                "Weekly",
+		"optional_fields":          []string{"Size", "StorageClass"},
+		"destination": map[string]any{
+			"bucket": "arn:aws:s3:::inventory", "format": "Parquet",
+			"encryption": "SSE-KMS", "kms_key_id": kmsKey,
+		},
+	}}, attrs[iddomain.StorageBucketInventoryKey])
+	s.Equal([]map[string]any{
+		{"id": "EntireBucket"},
+		{"id": "tagged", "tags": map[string]string{"team": "data"}},
+	}, attrs[iddomain.StorageBucketMetricsKey], "sorted by id")
 }
 
 func (s *S3ResourceTestSuite) TestMapAPIDataToDomainAttrs_EncryptionAES() {
@@ -1457,7 +1795,7 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_RegionDetectedFromLoc
 
 	// We call fetchAll directly here to isolate the region detection logic
 	// (buildS3BucketResource adds extra layers)
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 })
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, func(c aws.Config) S3ClientInterface { return s.mockS3 }, 0)
 
 	// Validate key expectations - region should be detected correctly
 	s.Require().NoError(err)
@@ -1504,10 +1842,12 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_ContextCanceled() {
 			},
 		},
 	}, nil).Maybe()
+	s.mockGetAccessConfigNotFound(bucketName)
+	s.mockListConfigurationsEmpty(bucketName)
 	s.mockS3.On("ListBucketIntelligentTieringConfigurations", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 
 	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
-	input, err := fetchAllBucketAttributes(ctx, bucketName, s.awsConfig, s.mockLogger, mockFactory)
+	input, err := fetchAllBucketAttributes(ctx, bucketName, s.awsConfig, s.mockLogger, mockFactory, 0)
 
 	s.Require().Error(err)
 	s.ErrorIs(err, context.Canceled) // Expect context.Canceled error
@@ -1545,10 +1885,12 @@ func (s *S3ResourceTestSuite) TestFetchAllBucketAttributes_RateLimitError() {
 	s.mockS3.On("GetBucketWebsite", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "NoSuchWebsiteConfiguration"}).Maybe()
 	s.mockS3.On("GetBucketCors", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "NoSuchCORSConfiguration"}).Maybe()
 	s.mockS3.On("GetBucketPolicy", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}).Maybe()
+	s.mockGetAccessConfigNotFound(bucketName)
+	s.mockListConfigurationsEmpty(bucketName)
 	s.mockS3.On("ListBucketIntelligentTieringConfigurations", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 
 	mockFactory := func(c aws.Config) S3ClientInterface { return s.mockS3 }
-	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, mockFactory)
+	input, err := fetchAllBucketAttributes(s.ctx, bucketName, s.awsConfig, s.mockLogger, mockFactory, 0)
 
 	s.Require().Error(err)
 	s.ErrorIs(err, rateLimitErr) // Expect the specific rate limit error
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	aws_errors "github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// selfTestFailure is a failed self-test check, keyed by what was being checked.
+type selfTestFailure struct {
+	check string
+	err   error
+}
+
+// SelfTest checks the caller identity and then issues one cheap list call per
+// requested kind, so that broken connectivity or missing permissions are
+// reported up front instead of halfway through a long scan. All checks run
+// even if one fails; the returned error lists every failure.
+//
+// The checks use the active credential source. When its identity check fails
+// with an authentication error, the provider fails over to the next source.
+func (p *Provider) SelfTest(ctx context.Context, kinds []domain.ResourceKind) error {
+	idx, src := p.activeSource()
+	for src.stsClient != nil {
+		p.logger.Debugf(ctx, "AWS self-test: checking caller identity with %s", src.name)
+		_, err := src.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err == nil {
+			break
+		}
+		handled := (&aws_errors.DefaultErrorHandler{}).Handle("STS", "GetCallerIdentity", err, ctx)
+		next, ok := idx, false
+		if aws_errors.IsAuthError(handled) {
+			next, ok = p.failoverFrom(ctx, idx, "STS identity", handled)
+		}
+		if !ok {
+			return selfTestError([]selfTestFailure{{check: "STS identity", err: handled}}, 1)
+		}
+		idx, src = next, p.source(next)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures []selfTestFailure
+		checks   int
+	)
+	for _, kind := range kinds {
+		handler, found := src.handlers[kind]
+		if !found {
+			continue
+		}
+		prober, ok := handler.(HandlerProber)
+		if !ok {
+			p.logger.Debugf(ctx, "AWS self-test: handler for kind %s has no probe, skipping", kind)
+			continue
+		}
+		checks++
+		wg.Add(1)
+		go func(kind domain.ResourceKind, prober HandlerProber) {
+			defer wg.Done()
+			handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": kind})
+			handlerLogger.Debugf(ctx, "AWS self-test: probing kind %s in %s", kind, src.cfg.Region)
+			if err := prober.Probe(ctx, src.cfg, handlerLogger); err != nil {
+				mu.Lock()
+				failures = append(failures, selfTestFailure{check: fmt.Sprintf("%s (%s)", kind, src.cfg.Region), err: err})
+				mu.Unlock()
+			}
+		}(kind, prober)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if len(failures) > 0 {
+		return selfTestError(failures, checks)
+	}
+	p.logger.Infof(ctx, "AWS self-test passed for %d kind(s)", checks)
+	return nil
+}
+
+// selfTestError builds a user-facing error whose details hold one diagnostic
+// line per failed check.
+func selfTestError(failures []selfTestFailure, checks int) error {
+	sort.Slice(failures, func(i, j int) bool { return failures[i].check < failures[j].check })
+
+	var details strings.Builder
+	for _, f := range failures {
+		reason := "connectivity or API error"
+		if errors.Is(f.err, errors.CodePlatformAuthError) {
+			reason = "missing permissions or invalid credentials"
+		}
+		fmt.Fprintf(&details, "  - %s: %s: %v\n", f.check, reason, f.err)
+	}
+
+	err := errors.NewUserFacing(errors.CodeSelfTestFailed,
+		fmt.Sprintf("AWS self-test failed for %d of %d check(s)", len(failures), checks),
+		"Check network access, credentials and the IAM permissions for the kinds listed in the error details, or pass --skip-self-test to run anyway")
+	err.InternalDetails = strings.TrimSuffix(details.String(), "\n")
+	return err
+}
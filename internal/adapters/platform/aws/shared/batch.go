@@ -0,0 +1,28 @@
+package shared
+
+// UniqueIDs returns ids without empty and duplicate IDs, keeping their order.
+func UniqueIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// ChunkIDs splits the unique ids into batches of at most size IDs, for APIs
+// that describe a bounded number of resources per call.
+func ChunkIDs(ids []string, size int) [][]string {
+	unique := UniqueIDs(ids)
+	var chunks [][]string
+	for size > 0 && len(unique) > 0 {
+		n := min(size, len(unique))
+		chunks = append(chunks, unique[:n:n])
+		unique = unique[n:]
+	}
+	return chunks
+}
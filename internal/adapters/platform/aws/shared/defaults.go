@@ -0,0 +1,36 @@
+package shared
+
+import "strings"
+
+// Reasons recorded in domain.ResourceMetadata.PlatformDefault for resources
+// AWS creates on its own in every account or region.
+const (
+	DefaultSecurityGroup = "default security group"
+	ServiceLinkedRole    = "service-linked role"
+	ReservedRole         = "AWS reserved role"
+	DefaultVPC           = "default VPC"
+	MainRouteTable       = "main route table"
+)
+
+// SecurityGroupDefault returns DefaultSecurityGroup for the group AWS creates in
+// every VPC, whose name no other group can use, or an empty string.
+func SecurityGroupDefault(groupName string) string {
+	if groupName == "default" {
+		return DefaultSecurityGroup
+	}
+	return ""
+}
+
+// RoleDefault returns why a role was created by AWS rather than by the account:
+// service-linked roles (AWSServiceRole*, path /aws-service-role/) and roles
+// reserved for IAM Identity Center (path /aws-reserved/). It returns an empty
+// string for other roles.
+func RoleDefault(roleName, path string) string {
+	switch {
+	case strings.HasPrefix(path, "/aws-service-role/"), strings.HasPrefix(roleName, "AWSServiceRole"):
+		return ServiceLinkedRole
+	case strings.HasPrefix(path, "/aws-reserved/"):
+		return ReservedRole
+	}
+	return ""
+}
@@ -0,0 +1,10 @@
+package shared
+
+// Pages returns the number of list calls of size items each needed to list n
+// resources. Listing always takes at least one call.
+func Pages(n, size int) int {
+	if n <= 0 || size <= 0 {
+		return 1
+	}
+	return (n + size - 1) / size
+}
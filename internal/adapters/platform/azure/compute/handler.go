@@ -0,0 +1,117 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	apiVersion       = "2024-07-01"
+	resourceProvider = "Microsoft.Compute"
+	resourceType     = "virtualMachines"
+)
+
+// VirtualMachineHandler lists and fetches the virtual machines of a subscription.
+type VirtualMachineHandler struct {
+	client         *restapi.Client
+	subscriptionID string
+	endpoint       string
+}
+
+// HandlerOption defines a function signature for configuring the VirtualMachineHandler.
+type HandlerOption func(*VirtualMachineHandler)
+
+// WithEndpoint provides an option to set the Azure Resource Manager base URL.
+func WithEndpoint(endpoint string) HandlerOption {
+	return func(h *VirtualMachineHandler) {
+		if endpoint != "" {
+			h.endpoint = strings.TrimRight(endpoint, "/")
+		}
+	}
+}
+
+// NewHandler creates a new VirtualMachineHandler for the VMs of subscriptionID.
+func NewHandler(client *restapi.Client, subscriptionID string, opts ...HandlerOption) *VirtualMachineHandler {
+	h := &VirtualMachineHandler{client: client, subscriptionID: subscriptionID, endpoint: shared.DefaultEndpoint}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *VirtualMachineHandler) Kind() domain.ResourceKind {
+	return domain.KindComputeInstance
+}
+
+type listResponse struct {
+	Value    []VirtualMachine `json:"value"`
+	NextLink string           `json:"nextLink"`
+}
+
+// ListResources lists the VMs of every resource group in the subscription. Tag
+// filters ("tag:<key>") are applied after listing.
+func (h *VirtualMachineHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	next := fmt.Sprintf("%s/subscriptions/%s/providers/%s/%s?api-version=%s",
+		h.endpoint, url.PathEscape(h.subscriptionID), resourceProvider, resourceType, apiVersion)
+
+	logger.Debugf(ctx, "Starting Azure VM listing with pagination")
+	pageNum := 0
+	for next != "" {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		var page listResponse
+		if err := h.client.GetJSON(ctx, next, &page); err != nil {
+			return shared.HandleError(ctx, "Azure VMs", fmt.Sprintf("list:Page%d", pageNum), err)
+		}
+
+		for _, vm := range page.Value {
+			if !shared.MatchesTagFilters(vm.Tags, filters) {
+				continue
+			}
+			resource, mapErr := newVirtualMachineResource(vm)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Azure VM %s, skipping", vm.Name)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending Azure VM %s", vm.Name)
+				return ctx.Err()
+			}
+		}
+		next = page.NextLink
+	}
+
+	logger.Debugf(ctx, "Finished Azure VM pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a VM by the resource ID Terraform records.
+func (h *VirtualMachineHandler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	resourceID, err := shared.ParseResourceID(id)
+	if err != nil || !strings.EqualFold(resourceID.Provider, resourceProvider) || !strings.EqualFold(resourceID.Type, resourceType) {
+		return nil, errors.New(errors.CodeResourceNotFound,
+			fmt.Sprintf("invalid Azure VM ID '%s', expected /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Compute/virtualMachines/<name>", id))
+	}
+	logger.Debugf(ctx, "Getting single Azure VM %s", id)
+
+	var vm VirtualMachine
+	endpoint := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/%s/%s/%s?api-version=%s",
+		h.endpoint, url.PathEscape(resourceID.SubscriptionID), url.PathEscape(resourceID.ResourceGroup),
+		resourceProvider, resourceType, url.PathEscape(resourceID.Name), apiVersion)
+	if err := h.client.GetJSON(ctx, endpoint, &vm); err != nil {
+		return nil, shared.HandleError(ctx, "Azure VM", id, err)
+	}
+	return newVirtualMachineResource(vm)
+}
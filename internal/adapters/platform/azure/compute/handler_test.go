@@ -0,0 +1,165 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	vmPath = "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/web-1"
+	vmJSON = `{
+	"id": "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/web-1",
+	"name": "web-1",
+	"location": "westeurope",
+	"zones": ["2"],
+	"tags": {"env": "prod"},
+	"properties": {"vmId": "0f1e", "hardwareProfile": {"vmSize": "Standard_B2s"}}
+}`
+)
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 4; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(4)...).Maybe().Return()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func newTestHandler(t *testing.T, mux *http.ServeMux) (*VirtualMachineHandler, string) {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := shared.NewClient(restapi.StaticTokenSource("token"), restapi.WithRetryBaseDelay(time.Millisecond))
+	return NewHandler(client, "sub-1", WithEndpoint(srv.URL)), srv.URL
+}
+
+func collect(t *testing.T, h *VirtualMachineHandler, filters map[string]string) []domain.PlatformResource {
+	t.Helper()
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, h.ListResources(context.Background(), filters, newMockLogger(), out))
+	close(out)
+	var resources []domain.PlatformResource
+	for r := range out {
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+func TestVirtualMachineHandler_ListResources(t *testing.T) {
+	mux := http.NewServeMux()
+	var baseURL string
+	mux.HandleFunc("/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		if r.URL.Query().Get("$skiptoken") == "" {
+			_, _ = w.Write([]byte(`{"value":[` + vmJSON + `],"nextLink":"` + baseURL + `/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines?api-version=` + apiVersion + `&$skiptoken=p2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":[{"id":"/subscriptions/sub-1/resourceGroups/BATCH/providers/Microsoft.Compute/virtualMachines/batch-1","name":"batch-1","location":"North Europe","properties":{"hardwareProfile":{"vmSize":"Standard_D4s_v5"}}}]}`))
+	})
+	h, srvURL := newTestHandler(t, mux)
+	baseURL = srvURL
+
+	resources := collect(t, h, nil)
+
+	require.Len(t, resources, 2)
+	byName := map[string]domain.PlatformResource{}
+	for _, r := range resources {
+		byName[r.Metadata().SourceIdentifier] = r
+	}
+
+	meta := byName["web-1"].Metadata()
+	assert.Equal(t, domain.KindComputeInstance, meta.Kind)
+	assert.Equal(t, shared.ProviderTypeAzure, meta.ProviderType)
+	assert.Equal(t, vmPath, meta.ProviderAssignedID)
+	assert.Equal(t, "westeurope", meta.Region)
+	assert.Equal(t, "sub-1", meta.AccountID)
+
+	attrs, err := byName["web-1"].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                      vmPath,
+		domain.KeyName:                    "web-1",
+		domain.ComputeInstanceTypeKey:     "Standard_B2s",
+		domain.ComputeAvailabilityZoneKey: "2",
+		domain.KeyRegion:                  "westeurope",
+		domain.KeyTags:                    map[string]string{"env": "prod"},
+	}, attrs)
+
+	batchAttrs, err := byName["batch-1"].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "northeurope", batchAttrs[domain.KeyRegion])
+	assert.Equal(t, map[string]string{}, batchAttrs[domain.KeyTags])
+	assert.NotContains(t, batchAttrs, domain.ComputeAvailabilityZoneKey)
+}
+
+func TestVirtualMachineHandler_ListResources_TagFilter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value":[` + vmJSON + `,{"id":"/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/web-2","name":"web-2","location":"westeurope","tags":{"env":"dev"}}]}`))
+	})
+	h, _ := newTestHandler(t, mux)
+
+	resources := collect(t, h, map[string]string{"tag:env": "prod"})
+
+	require.Len(t, resources, 1)
+	assert.Equal(t, "web-1", resources[0].Metadata().SourceIdentifier)
+}
+
+func TestVirtualMachineHandler_ListResources_AuthorizationFailed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed","message":"does not have authorization to perform action 'Microsoft.Compute/virtualMachines/read'"}}`))
+	})
+	h, _ := newTestHandler(t, mux)
+
+	err := h.ListResources(context.Background(), nil, newMockLogger(), make(chan domain.PlatformResource, 1))
+
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
+
+func TestVirtualMachineHandler_GetResource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(vmPath, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(vmJSON))
+	})
+	mux.HandleFunc("/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"ResourceNotFound","message":"not found"}}`))
+	})
+	h, _ := newTestHandler(t, mux)
+
+	resource, err := h.GetResource(context.Background(), vmPath, newMockLogger())
+	require.NoError(t, err)
+	assert.Equal(t, vmPath, resource.Metadata().ProviderAssignedID)
+
+	_, err = h.GetResource(context.Background(), "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/gone", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+
+	_, err = h.GetResource(context.Background(), "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/web1", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
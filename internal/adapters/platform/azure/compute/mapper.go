@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// VirtualMachine is the part of an Azure virtual machine resource the detector uses.
+type VirtualMachine struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Location   string            `json:"location"`
+	Zones      []string          `json:"zones"`
+	Tags       map[string]string `json:"tags"`
+	Properties struct {
+		VMID            string `json:"vmId"`
+		HardwareProfile struct {
+			VMSize string `json:"vmSize"`
+		} `json:"hardwareProfile"`
+	} `json:"properties"`
+}
+
+// virtualMachineResource wraps an Azure VM. The VM resource holds every
+// compared attribute, so they are mapped once when it is built.
+type virtualMachineResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newVirtualMachineResource(vm VirtualMachine) (domain.PlatformResource, error) {
+	resourceID, err := shared.ParseResourceID(vm.ID)
+	if err != nil || vm.Name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create Azure VM resource: missing or invalid VM ID or name")
+	}
+	id := resourceID.String()
+
+	return &virtualMachineResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindComputeInstance,
+			ProviderType:       shared.ProviderTypeAzure,
+			ProviderAssignedID: id,
+			SourceIdentifier:   vm.Name,
+			AccountID:          resourceID.SubscriptionID,
+			Region:             shared.NormalizeLocation(vm.Location),
+		},
+		attrs: mapVirtualMachineToAttributes(vm, id),
+	}, nil
+}
+
+func (r *virtualMachineResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *virtualMachineResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapVirtualMachineToAttributes(vm VirtualMachine, id string) map[string]any {
+	attrs := map[string]any{
+		domain.KeyID:                  id,
+		domain.KeyName:                vm.Name,
+		domain.ComputeInstanceTypeKey: vm.Properties.HardwareProfile.VMSize,
+		domain.KeyRegion:              shared.NormalizeLocation(vm.Location),
+		domain.KeyTags:                shared.Tags(vm.Tags),
+	}
+	// A zonal VM is pinned to exactly one zone; regional VMs have none.
+	if len(vm.Zones) > 0 {
+		attrs[domain.ComputeAvailabilityZoneKey] = vm.Zones[0]
+	}
+	return attrs
+}
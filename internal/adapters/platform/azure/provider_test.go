@@ -0,0 +1,134 @@
+package azure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/config"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type fakeResource struct {
+	meta domain.ResourceMetadata
+}
+
+func (r *fakeResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r *fakeResource) Attributes(context.Context) (map[string]any, error) {
+	return map[string]any{domain.KeyID: r.meta.ProviderAssignedID}, nil
+}
+
+// fakeHandler serves the resources it holds, keyed by ID.
+type fakeHandler struct {
+	kind      domain.ResourceKind
+	resources map[string]domain.PlatformResource
+	listErr   error
+}
+
+func (h *fakeHandler) Kind() domain.ResourceKind { return h.kind }
+
+func (h *fakeHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	if h.listErr != nil {
+		return h.listErr
+	}
+	for _, r := range h.resources {
+		out <- r
+	}
+	return nil
+}
+
+func (h *fakeHandler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	if r, ok := h.resources[id]; ok {
+		return r, nil
+	}
+	return nil, errors.New(errors.CodeResourceNotFound, "not found")
+}
+
+func newFakeHandler(kind domain.ResourceKind, ids ...string) *fakeHandler {
+	h := &fakeHandler{kind: kind, resources: map[string]domain.PlatformResource{}}
+	for _, id := range ids {
+		h.resources[id] = &fakeResource{meta: domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id}}
+	}
+	return h
+}
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 6; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(3)...).Maybe().Return()
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func TestProvider_ListResources(t *testing.T) {
+	p := NewProviderWithHandlers("sub-1", newMockLogger(),
+		newFakeHandler(domain.KindComputeInstance, "i-1", "i-2"),
+		newFakeHandler(domain.KindStorageBucket, "b-1"))
+	assert.Equal(t, shared.ProviderTypeAzure, p.Type())
+
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, p.ListResources(context.Background(), []domain.ResourceKind{domain.KindComputeInstance, domain.KindStorageBucket, domain.KindIAMRole}, nil, out))
+	close(out)
+
+	var ids []string
+	for r := range out {
+		ids = append(ids, r.Metadata().ProviderAssignedID)
+	}
+	assert.ElementsMatch(t, []string{"i-1", "i-2", "b-1"}, ids)
+}
+
+func TestProvider_ListResources_Errors(t *testing.T) {
+	failing := newFakeHandler(domain.KindComputeInstance)
+	failing.listErr = errors.New(errors.CodePlatformAuthError, "denied")
+	p := NewProviderWithHandlers("sub-1", newMockLogger(), failing)
+
+	err := p.ListResources(context.Background(), []domain.ResourceKind{domain.KindComputeInstance}, nil, make(chan domain.PlatformResource, 1))
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+
+	err = p.ListResources(context.Background(), []domain.ResourceKind{domain.KindIAMRole}, nil, make(chan domain.PlatformResource, 1))
+	assert.True(t, errors.Is(err, errors.CodeNotImplemented))
+}
+
+func TestProvider_GetResources(t *testing.T) {
+	p := NewProviderWithHandlers("sub-1", newMockLogger(), newFakeHandler(domain.KindStorageBucket, "b-1", "b-2"))
+
+	resources, err := p.GetResources(context.Background(), domain.KindStorageBucket, []string{"b-1", "b-2", "missing"})
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Contains(t, resources, "b-1")
+	assert.Contains(t, resources, "b-2")
+
+	_, err = p.GetResource(context.Background(), domain.KindStorageBucket, "missing")
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+
+	_, err = p.GetResources(context.Background(), domain.KindIAMRole, []string{"r"})
+	assert.True(t, errors.Is(err, errors.CodeNotImplemented))
+}
+
+func TestNewProvider_RequiresSubscription(t *testing.T) {
+	_, err := NewProvider(context.Background(), config.AzurePlatformConfig{AccessToken: "token"}, newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+
+	p, err := NewProvider(context.Background(), config.AzurePlatformConfig{SubscriptionID: "sub-1", AccessToken: "token"}, newMockLogger())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{string(domain.KindComputeInstance), string(domain.KindStorageBucket)}, p.getSupportedKinds())
+}
@@ -0,0 +1,224 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// ManagementResource is the Azure Resource Manager audience tokens are
+	// requested for.
+	ManagementResource = "https://management.azure.com/"
+
+	defaultAuthorityHost = "https://login.microsoftonline.com"
+	defaultIMDSEndpoint  = "http://169.254.169.254/metadata/identity/oauth2/token"
+	jwtAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	// tokenExpiryMargin is how long before its expiry a token is refreshed, so
+	// that it does not expire while a request is in flight.
+	tokenExpiryMargin = 2 * time.Minute
+)
+
+// CredentialsOptions override the identity FindCredentials authenticates as.
+type CredentialsOptions struct {
+	// AccessToken is used as is when set.
+	AccessToken string
+	// TenantID overrides AZURE_TENANT_ID.
+	TenantID string
+	// ClientID overrides AZURE_CLIENT_ID: the application of a service
+	// principal or workload identity, or a user-assigned managed identity.
+	ClientID string
+	// HTTPClient requests tokens. It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// FindCredentials returns a token source using, in order: the configured access
+// token, a service principal secret (AZURE_CLIENT_SECRET), a federated workload identity token
+// (AZURE_FEDERATED_TOKEN_FILE, as set up on AKS) and, failing those, the
+// managed identity of the host the detector runs on.
+func FindCredentials(opts CredentialsOptions) (restapi.TokenSource, string, error) {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if opts.AccessToken != "" {
+		return restapi.StaticTokenSource(opts.AccessToken), "configured access token", nil
+	}
+	tenantID := firstNonEmpty(opts.TenantID, os.Getenv("AZURE_TENANT_ID"))
+	clientID := firstNonEmpty(opts.ClientID, os.Getenv("AZURE_CLIENT_ID"))
+	authorityHost := strings.TrimRight(firstNonEmpty(os.Getenv("AZURE_AUTHORITY_HOST"), defaultAuthorityHost), "/")
+
+	if secret := os.Getenv("AZURE_CLIENT_SECRET"); secret != "" {
+		if tenantID == "" || clientID == "" {
+			return nil, "", errors.NewUserFacing(errors.CodePlatformAuthError,
+				"AZURE_CLIENT_SECRET is set without a tenant and client ID",
+				"Set AZURE_TENANT_ID and AZURE_CLIENT_ID, or platform.azure.tenant_id and client_id.")
+		}
+		return restapi.NewCachingTokenSource(&clientCredentialsTokenSource{
+			tokenURL: tokenURL(authorityHost, tenantID), clientID: clientID, httpClient: httpClient,
+			credential: func() (url.Values, error) { return url.Values{"client_secret": {secret}}, nil },
+		}, tokenExpiryMargin), "service principal " + clientID, nil
+	}
+
+	if tokenFile := os.Getenv("AZURE_FEDERATED_TOKEN_FILE"); tokenFile != "" && tenantID != "" && clientID != "" {
+		return restapi.NewCachingTokenSource(&clientCredentialsTokenSource{
+			tokenURL: tokenURL(authorityHost, tenantID), clientID: clientID, httpClient: httpClient,
+			// The token file is rotated by the platform, so it is read for every request.
+			credential: func() (url.Values, error) {
+				assertion, err := os.ReadFile(tokenFile)
+				if err != nil {
+					return nil, err
+				}
+				return url.Values{
+					"client_assertion_type": {jwtAssertionType},
+					"client_assertion":      {strings.TrimSpace(string(assertion))},
+				}, nil
+			},
+		}, tokenExpiryMargin), "workload identity " + clientID, nil
+	}
+
+	mi := &managedIdentityTokenSource{clientID: clientID, httpClient: httpClient, endpoint: defaultIMDSEndpoint}
+	if endpoint, header := os.Getenv("IDENTITY_ENDPOINT"), os.Getenv("IDENTITY_HEADER"); endpoint != "" && header != "" {
+		mi.endpoint, mi.identityHeader = endpoint, header
+	}
+	return restapi.NewCachingTokenSource(mi, tokenExpiryMargin), "managed identity", nil
+}
+
+func tokenURL(authorityHost, tenantID string) string {
+	return fmt.Sprintf("%s/%s/oauth2/v2.0/token", authorityHost, url.PathEscape(tenantID))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clientCredentialsTokenSource requests tokens for an application with the
+// client credentials grant, authenticating with a secret or a client assertion.
+type clientCredentialsTokenSource struct {
+	tokenURL   string
+	clientID   string
+	credential func() (url.Values, error)
+	httpClient *http.Client
+}
+
+func (s *clientCredentialsTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	form, err := s.credential()
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError, "failed to read Azure workload identity token",
+			"Check AZURE_FEDERATED_TOKEN_FILE.")
+	}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", s.clientID)
+	form.Set("scope", ManagementResource+".default")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create Azure token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doTokenRequest(s.httpClient, req)
+}
+
+// managedIdentityTokenSource requests the token of the managed identity of the
+// host from the instance metadata service or, on App Service and Functions,
+// from the identity endpoint of the platform.
+type managedIdentityTokenSource struct {
+	endpoint       string
+	identityHeader string
+	clientID       string
+	httpClient     *http.Client
+}
+
+func (s *managedIdentityTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	query := url.Values{"resource": {ManagementResource}}
+	if s.identityHeader != "" {
+		query.Set("api-version", "2019-08-01")
+	} else {
+		query.Set("api-version", "2018-02-01")
+	}
+	if s.clientID != "" {
+		query.Set("client_id", s.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create Azure managed identity token request")
+	}
+	if s.identityHeader != "" {
+		req.Header.Set("X-IDENTITY-HEADER", s.identityHeader)
+	} else {
+		req.Header.Set("Metadata", "true")
+	}
+	return doTokenRequest(s.httpClient, req)
+}
+
+// flexibleInt decodes the numbers of token responses, which the managed
+// identity endpoints return as strings.
+type flexibleInt int64
+
+func (f *flexibleInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexibleInt(n)
+	return nil
+}
+
+type tokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   flexibleInt `json:"expires_in"`
+	ExpiresOn   flexibleInt `json:"expires_on"`
+}
+
+func (r tokenResponse) token(now time.Time) *restapi.Token {
+	token := &restapi.Token{AccessToken: r.AccessToken}
+	switch {
+	case r.ExpiresOn > 0:
+		token.Expiry = time.Unix(int64(r.ExpiresOn), 0)
+	case r.ExpiresIn > 0:
+		token.Expiry = now.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return token
+}
+
+func doTokenRequest(httpClient *http.Client, req *http.Request) (*restapi.Token, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError, "failed to obtain an Azure access token",
+			"Set AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET, or run the detector with a managed identity.")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodePlatformAuthError, "failed to read Azure token response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewUserFacing(errors.CodePlatformAuthError,
+			fmt.Sprintf("Azure token request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+			"Check that the Azure credentials are valid and the identity exists.")
+	}
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil || tr.AccessToken == "" {
+		return nil, errors.New(errors.CodePlatformAuthError, "Azure token response did not contain an access token")
+	}
+	return tr.token(time.Now()), nil
+}
@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// clearAzureEnv unsets the variables FindCredentials reads for the duration of a test.
+func clearAzureEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_FEDERATED_TOKEN_FILE", "AZURE_AUTHORITY_HOST", "IDENTITY_ENDPOINT", "IDENTITY_HEADER"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestFindCredentials_ServicePrincipal(t *testing.T) {
+	clearAzureEnv(t)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/tenant-1/oauth2/v2.0/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "app-1", r.PostForm.Get("client_id"))
+		assert.Equal(t, "s3cret", r.PostForm.Get("client_secret"))
+		assert.Equal(t, ManagementResource+".default", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token":"sp-token","expires_in":3599}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AZURE_AUTHORITY_HOST", srv.URL)
+	t.Setenv("AZURE_TENANT_ID", "tenant-1")
+	t.Setenv("AZURE_CLIENT_SECRET", "s3cret")
+
+	ts, source, err := FindCredentials(CredentialsOptions{ClientID: "app-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "service principal app-1", source)
+
+	for range 2 {
+		token, err := ts.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "sp-token", token.AccessToken)
+	}
+	assert.Equal(t, int32(1), requests.Load(), "the token is cached until it nears expiry")
+}
+
+func TestFindCredentials_ServicePrincipalNeedsTenantAndClient(t *testing.T) {
+	clearAzureEnv(t)
+	t.Setenv("AZURE_CLIENT_SECRET", "s3cret")
+
+	_, _, err := FindCredentials(CredentialsOptions{})
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
+
+func TestFindCredentials_WorkloadIdentity(t *testing.T) {
+	clearAzureEnv(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-jwt\n"), 0o600))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, jwtAssertionType, r.PostForm.Get("client_assertion_type"))
+		assert.Equal(t, "federated-jwt", r.PostForm.Get("client_assertion"))
+		_, _ = w.Write([]byte(`{"access_token":"wi-token","expires_in":3599}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AZURE_AUTHORITY_HOST", srv.URL)
+	t.Setenv("AZURE_TENANT_ID", "tenant-1")
+	t.Setenv("AZURE_CLIENT_ID", "app-1")
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+
+	ts, source, err := FindCredentials(CredentialsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "workload identity app-1", source)
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "wi-token", token.AccessToken)
+}
+
+func TestFindCredentials_ManagedIdentity(t *testing.T) {
+	clearAzureEnv(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "header-1", r.Header.Get("X-IDENTITY-HEADER"))
+		assert.Equal(t, ManagementResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, "mi-1", r.URL.Query().Get("client_id"))
+		_, _ = w.Write([]byte(`{"access_token":"mi-token","expires_on":"4102444800"}`))
+	}))
+	defer srv.Close()
+	t.Setenv("IDENTITY_ENDPOINT", srv.URL)
+	t.Setenv("IDENTITY_HEADER", "header-1")
+
+	ts, source, err := FindCredentials(CredentialsOptions{ClientID: "mi-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "managed identity", source)
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "mi-token", token.AccessToken)
+	assert.Equal(t, time.Unix(4102444800, 0), token.Expiry)
+}
+
+func TestFindCredentials_ConfiguredAccessToken(t *testing.T) {
+	clearAzureEnv(t)
+	t.Setenv("AZURE_CLIENT_SECRET", "s3cret")
+
+	ts, source, err := FindCredentials(CredentialsOptions{AccessToken: "static"})
+	require.NoError(t, err)
+	assert.Equal(t, "configured access token", source)
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "static", token.AccessToken)
+}
+
+func TestTokenRequestFailure(t *testing.T) {
+	clearAzureEnv(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+	}))
+	defer srv.Close()
+	t.Setenv("AZURE_AUTHORITY_HOST", srv.URL)
+	t.Setenv("AZURE_TENANT_ID", "tenant-1")
+	t.Setenv("AZURE_CLIENT_ID", "app-1")
+	t.Setenv("AZURE_CLIENT_SECRET", "wrong")
+
+	ts, _, err := FindCredentials(CredentialsOptions{})
+	require.NoError(t, err)
+	_, err = ts.Token(context.Background())
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
+
+func TestParseResourceID(t *testing.T) {
+	id, err := ParseResourceID("/subscriptions/sub-1/resourcegroups/WEB/providers/Microsoft.Compute/virtualMachines/web-1")
+	require.NoError(t, err)
+	assert.Equal(t, ResourceID{SubscriptionID: "sub-1", ResourceGroup: "WEB", Provider: "Microsoft.Compute", Type: "virtualMachines", Name: "web-1"}, id)
+	assert.Equal(t, "/subscriptions/sub-1/resourceGroups/WEB/providers/Microsoft.Compute/virtualMachines/web-1", id.String())
+
+	_, err = ParseResourceID("/subscriptions/sub-1/resourceGroups/web")
+	assert.Error(t, err)
+}
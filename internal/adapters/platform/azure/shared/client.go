@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// DefaultEndpoint is the Azure Resource Manager endpoint of the public cloud.
+const DefaultEndpoint = "https://management.azure.com"
+
+// APIError is an error response of Azure Resource Manager.
+type APIError struct {
+	StatusCode int
+	// ErrorCode is the ARM error code, e.g. AuthorizationFailed.
+	ErrorCode string
+	Message   string
+	// RetryAfter is the delay the Retry-After header asks for, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("azure: %d %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+	}
+	return fmt.Sprintf("azure: %d: %s", e.StatusCode, e.Message)
+}
+
+// Code classifies the error into an application error code.
+func (e *APIError) Code() errors.Code {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return errors.CodePlatformAuthError
+	case e.StatusCode == http.StatusNotFound:
+		return errors.CodeResourceNotFound
+	case e.throttled():
+		return errors.CodePlatformThrottled
+	default:
+		return errors.CodePlatformAPIError
+	}
+}
+
+// throttled reports ARM and resource provider request limits, which are
+// returned as 429 with a Retry-After header.
+func (e *APIError) throttled() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.ErrorCode == "TooManyRequests"
+}
+
+// Retryable reports throttled and server errors as retryable, after the
+// Retry-After delay if the response had one.
+func (e *APIError) Retryable() (bool, time.Duration) {
+	return e.throttled() || e.StatusCode >= http.StatusInternalServerError, e.RetryAfter
+}
+
+func decodeAPIError(resp *http.Response, body []byte) error {
+	var payload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode, RetryAfter: restapi.RetryAfter(resp)}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Error.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+	apiErr.ErrorCode = payload.Error.Code
+	apiErr.Message = payload.Error.Message
+	return apiErr
+}
+
+// NewClient creates a client for the Azure Resource Manager REST API
+// authenticating with tokens and returning error responses as *APIError.
+func NewClient(tokens restapi.TokenSource, opts ...restapi.Option) *restapi.Client {
+	return restapi.NewClient(tokens, decodeAPIError, opts...)
+}
+
+// HandleError maps an error of an Azure API call to an application error, like
+// the AWS adapters do for SDK errors.
+func HandleError(ctx context.Context, resourceType, resourceID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil || err == context.Canceled || err == context.DeadlineExceeded {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("context canceled during Azure %s API call", resourceType))
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+	switch code := apiErr.Code(); code {
+	case errors.CodePlatformAuthError:
+		return errors.Wrap(err, code, fmt.Sprintf("Azure authorization error accessing %s %s", resourceType, resourceID))
+	case errors.CodePlatformThrottled:
+		return errors.Wrap(err, code, fmt.Sprintf("Azure throttled requests for %s '%s'", resourceType, resourceID))
+	case errors.CodeResourceNotFound:
+		return errors.Wrap(err, code, fmt.Sprintf("%s '%s' not found", resourceType, resourceID))
+	default:
+		return errors.Wrap(err, code, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+}
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+const ProviderTypeAzure = "azure"
+
+// ResourceID is a parsed Azure Resource Manager resource ID:
+// /subscriptions/<sub>/resourceGroups/<group>/providers/<namespace>/<type>/<name>.
+type ResourceID struct {
+	SubscriptionID string
+	ResourceGroup  string
+	Provider       string
+	Type           string
+	Name           string
+}
+
+// ParseResourceID parses the ID of a top-level resource.
+func ParseResourceID(id string) (ResourceID, error) {
+	parts := strings.Split(strings.Trim(id, "/"), "/")
+	if len(parts) != 8 || !strings.EqualFold(parts[0], "subscriptions") ||
+		!strings.EqualFold(parts[2], "resourceGroups") || !strings.EqualFold(parts[4], "providers") {
+		return ResourceID{}, fmt.Errorf("'%s' is not an Azure resource ID", id)
+	}
+	return ResourceID{
+		SubscriptionID: parts[1],
+		ResourceGroup:  parts[3],
+		Provider:       parts[5],
+		Type:           parts[6],
+		Name:           parts[7],
+	}, nil
+}
+
+// String returns the ID in its canonical form.
+func (r ResourceID) String() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/%s/%s", r.SubscriptionID, r.ResourceGroup, r.Provider, r.Type, r.Name)
+}
+
+// NormalizeLocation lower-cases a location and removes its spaces, turning a
+// display name such as "West Europe" into the region name "westeurope".
+func NormalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+// Tags returns the tags of a resource, never nil.
+func Tags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return map[string]string{}
+	}
+	return tags
+}
+
+// MatchesTagFilters reports whether tags satisfy the "tag:<key>" filters.
+func MatchesTagFilters(tags map[string]string, filters map[string]string) bool {
+	for k, v := range filters {
+		key, ok := strings.CutPrefix(k, domain.TagPrefix)
+		if !ok {
+			continue
+		}
+		if tags[key] != v {
+			return false
+		}
+	}
+	return true
+}
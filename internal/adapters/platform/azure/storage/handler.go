@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	apiVersion       = "2023-05-01"
+	resourceProvider = "Microsoft.Storage"
+	resourceType     = "storageAccounts"
+)
+
+// AccountHandler lists and fetches the storage accounts of a subscription.
+type AccountHandler struct {
+	client         *restapi.Client
+	subscriptionID string
+	endpoint       string
+}
+
+// HandlerOption defines a function signature for configuring the AccountHandler.
+type HandlerOption func(*AccountHandler)
+
+// WithEndpoint provides an option to set the Azure Resource Manager base URL.
+func WithEndpoint(endpoint string) HandlerOption {
+	return func(h *AccountHandler) {
+		if endpoint != "" {
+			h.endpoint = strings.TrimRight(endpoint, "/")
+		}
+	}
+}
+
+// NewHandler creates a new AccountHandler for the storage accounts of subscriptionID.
+func NewHandler(client *restapi.Client, subscriptionID string, opts ...HandlerOption) *AccountHandler {
+	h := &AccountHandler{client: client, subscriptionID: subscriptionID, endpoint: shared.DefaultEndpoint}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *AccountHandler) Kind() domain.ResourceKind {
+	return domain.KindStorageBucket
+}
+
+type listResponse struct {
+	Value    []Account `json:"value"`
+	NextLink string    `json:"nextLink"`
+}
+
+// ListResources lists the storage accounts of the subscription. Tag filters
+// ("tag:<key>") are applied after listing.
+func (h *AccountHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	next := fmt.Sprintf("%s/subscriptions/%s/providers/%s/%s?api-version=%s",
+		h.endpoint, url.PathEscape(h.subscriptionID), resourceProvider, resourceType, apiVersion)
+
+	logger.Debugf(ctx, "Starting Azure storage account listing with pagination")
+	pageNum := 0
+	for next != "" {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		var page listResponse
+		if err := h.client.GetJSON(ctx, next, &page); err != nil {
+			return shared.HandleError(ctx, "Azure storage accounts", fmt.Sprintf("list:Page%d", pageNum), err)
+		}
+
+		for _, account := range page.Value {
+			if !shared.MatchesTagFilters(account.Tags, filters) {
+				continue
+			}
+			resource, mapErr := newAccountResource(account)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Azure storage account %s, skipping", account.Name)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending Azure storage account %s", account.Name)
+				return ctx.Err()
+			}
+		}
+		next = page.NextLink
+	}
+
+	logger.Debugf(ctx, "Finished Azure storage account pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a storage account by the resource ID Terraform records.
+func (h *AccountHandler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	resourceID, err := shared.ParseResourceID(id)
+	if err != nil || !strings.EqualFold(resourceID.Provider, resourceProvider) || !strings.EqualFold(resourceID.Type, resourceType) {
+		return nil, errors.New(errors.CodeResourceNotFound,
+			fmt.Sprintf("invalid Azure storage account ID '%s', expected /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Storage/storageAccounts/<name>", id))
+	}
+	logger.Debugf(ctx, "Getting single Azure storage account %s", id)
+
+	var account Account
+	endpoint := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/%s/%s/%s?api-version=%s",
+		h.endpoint, url.PathEscape(resourceID.SubscriptionID), url.PathEscape(resourceID.ResourceGroup),
+		resourceProvider, resourceType, url.PathEscape(resourceID.Name), apiVersion)
+	if err := h.client.GetJSON(ctx, endpoint, &account); err != nil {
+		return nil, shared.HandleError(ctx, "Azure storage account", id, err)
+	}
+	return newAccountResource(account)
+}
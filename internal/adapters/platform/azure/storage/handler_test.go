@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	accountPath = "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/acmeassets"
+	accountJSON = `{
+	"id": "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/acmeassets",
+	"name": "acmeassets",
+	"location": "westeurope",
+	"kind": "StorageV2",
+	"tags": {"env": "prod"},
+	"sku": {"name": "Standard_GRS", "tier": "Standard"},
+	"properties": {"accessTier": "Hot", "supportsHttpsTrafficOnly": true, "minimumTlsVersion": "TLS1_2", "allowBlobPublicAccess": false}
+}`
+)
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 4; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(4)...).Maybe().Return()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func newTestHandler(t *testing.T, mux *http.ServeMux) *AccountHandler {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := shared.NewClient(restapi.StaticTokenSource("token"), restapi.WithRetryBaseDelay(time.Millisecond))
+	return NewHandler(client, "sub-1", WithEndpoint(srv.URL))
+}
+
+func TestAccountHandler_ListResources(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subscriptions/sub-1/providers/Microsoft.Storage/storageAccounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		_, _ = w.Write([]byte(`{"value":[` + accountJSON + `,{"id":"/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/acmelogs","name":"acmelogs","location":"westeurope","kind":"Storage","tags":{"env":"dev"},"sku":{"name":"Standard_LRS","tier":"Standard"}}]}`))
+	})
+
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, newTestHandler(t, mux).ListResources(context.Background(), map[string]string{"tag:env": "prod"}, newMockLogger(), out))
+	close(out)
+
+	var resources []domain.PlatformResource
+	for r := range out {
+		resources = append(resources, r)
+	}
+	require.Len(t, resources, 1)
+
+	meta := resources[0].Metadata()
+	assert.Equal(t, domain.KindStorageBucket, meta.Kind)
+	assert.Equal(t, shared.ProviderTypeAzure, meta.ProviderType)
+	assert.Equal(t, accountPath, meta.ProviderAssignedID)
+	assert.Equal(t, "acmeassets", meta.SourceIdentifier)
+	assert.Equal(t, "sub-1", meta.AccountID)
+
+	attrs, err := resources[0].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                          accountPath,
+		domain.KeyName:                        "acmeassets",
+		domain.KeyRegion:                      "westeurope",
+		domain.StorageAccountTierKey:          "Standard",
+		domain.StorageAccountReplicationKey:   "GRS",
+		domain.StorageAccountKindKey:          "StorageV2",
+		domain.StorageAccountAccessTierKey:    "Hot",
+		domain.StorageAccountHTTPSOnlyKey:     true,
+		domain.StorageAccountMinTLSVersionKey: "TLS1_2",
+		domain.StorageAccountPublicNestedKey:  false,
+		domain.KeyTags:                        map[string]string{"env": "prod"},
+	}, attrs)
+}
+
+func TestAccountHandler_GetResource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(accountPath, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(accountJSON))
+	})
+	mux.HandleFunc("/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"ResourceNotFound","message":"not found"}}`))
+	})
+	h := newTestHandler(t, mux)
+
+	resource, err := h.GetResource(context.Background(), accountPath, newMockLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "acmeassets", resource.Metadata().SourceIdentifier)
+
+	_, err = h.GetResource(context.Background(), "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/gone", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+
+	_, err = h.GetResource(context.Background(), "acmeassets", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
+
+func TestReplicationType(t *testing.T) {
+	assert.Equal(t, "GRS", replicationType("Standard_GRS"))
+	assert.Equal(t, "ZRS", replicationType("Premium_ZRS"))
+	assert.Equal(t, "LRS", replicationType("LRS"))
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/azure/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Account is the part of an Azure storage account resource the detector uses.
+type Account struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Location string            `json:"location"`
+	Kind     string            `json:"kind"`
+	Tags     map[string]string `json:"tags"`
+	SKU      struct {
+		// Name combines the tier and the replication, e.g. Standard_GRS.
+		Name string `json:"name"`
+		Tier string `json:"tier"`
+	} `json:"sku"`
+	Properties struct {
+		AccessTier               string `json:"accessTier"`
+		SupportsHTTPSTrafficOnly *bool  `json:"supportsHttpsTrafficOnly"`
+		MinimumTLSVersion        string `json:"minimumTlsVersion"`
+		AllowBlobPublicAccess    *bool  `json:"allowBlobPublicAccess"`
+	} `json:"properties"`
+}
+
+// accountResource wraps an Azure storage account. The account resource holds
+// every compared attribute, so they are mapped once when it is built.
+type accountResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newAccountResource(account Account) (domain.PlatformResource, error) {
+	resourceID, err := shared.ParseResourceID(account.ID)
+	if err != nil || account.Name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create Azure storage account resource: missing or invalid account ID or name")
+	}
+	id := resourceID.String()
+
+	return &accountResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindStorageBucket,
+			ProviderType:       shared.ProviderTypeAzure,
+			ProviderAssignedID: id,
+			SourceIdentifier:   account.Name,
+			AccountID:          resourceID.SubscriptionID,
+			Region:             shared.NormalizeLocation(account.Location),
+		},
+		attrs: mapAccountToAttributes(account, id),
+	}, nil
+}
+
+func (r *accountResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *accountResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapAccountToAttributes(account Account, id string) map[string]any {
+	attrs := map[string]any{
+		domain.KeyID:                        id,
+		domain.KeyName:                      account.Name,
+		domain.KeyRegion:                    shared.NormalizeLocation(account.Location),
+		domain.StorageAccountTierKey:        account.SKU.Tier,
+		domain.StorageAccountReplicationKey: replicationType(account.SKU.Name),
+		domain.StorageAccountKindKey:        account.Kind,
+		domain.KeyTags:                      shared.Tags(account.Tags),
+	}
+	props := account.Properties
+	// Only blob storage capable accounts have an access tier.
+	if props.AccessTier != "" {
+		attrs[domain.StorageAccountAccessTierKey] = props.AccessTier
+	}
+	if props.SupportsHTTPSTrafficOnly != nil {
+		attrs[domain.StorageAccountHTTPSOnlyKey] = *props.SupportsHTTPSTrafficOnly
+	}
+	if props.MinimumTLSVersion != "" {
+		attrs[domain.StorageAccountMinTLSVersionKey] = props.MinimumTLSVersion
+	}
+	if props.AllowBlobPublicAccess != nil {
+		attrs[domain.StorageAccountPublicNestedKey] = *props.AllowBlobPublicAccess
+	}
+	return attrs
+}
+
+// replicationType returns the replication part of a SKU name, e.g. "GRS" for
+// "Standard_GRS", which is how Terraform records account_replication_type.
+func replicationType(skuName string) string {
+	if _, replication, ok := strings.Cut(skuName, "_"); ok {
+		return replication
+	}
+	return skuName
+}
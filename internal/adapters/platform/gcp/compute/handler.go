@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	defaultEndpoint = "https://compute.googleapis.com/compute/v1"
+	listPageSize    = 500
+)
+
+// InstanceHandler lists and fetches the Compute Engine instances of a project.
+type InstanceHandler struct {
+	client   *restapi.Client
+	project  string
+	endpoint string
+}
+
+// HandlerOption defines a function signature for configuring the InstanceHandler.
+type HandlerOption func(*InstanceHandler)
+
+// WithEndpoint provides an option to set the Compute Engine API base URL.
+func WithEndpoint(endpoint string) HandlerOption {
+	return func(h *InstanceHandler) {
+		if endpoint != "" {
+			h.endpoint = strings.TrimRight(endpoint, "/")
+		}
+	}
+}
+
+// NewHandler creates a new InstanceHandler for the instances of project.
+func NewHandler(client *restapi.Client, project string, opts ...HandlerOption) *InstanceHandler {
+	h := &InstanceHandler{client: client, project: project, endpoint: defaultEndpoint}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *InstanceHandler) Kind() domain.ResourceKind {
+	return domain.KindComputeInstance
+}
+
+type aggregatedListResponse struct {
+	Items map[string]struct {
+		Instances []Instance `json:"instances"`
+	} `json:"items"`
+	NextPageToken string   `json:"nextPageToken"`
+	Unreachables  []string `json:"unreachables"`
+}
+
+// ListResources lists the instances of every zone with aggregatedList. Label
+// filters ("tag:<key>") are applied after listing.
+func (h *InstanceHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	query := url.Values{
+		"maxResults":           {fmt.Sprint(listPageSize)},
+		"returnPartialSuccess": {"true"},
+	}
+
+	logger.Debugf(ctx, "Starting GCE instance listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		var page aggregatedListResponse
+		endpoint := fmt.Sprintf("%s/projects/%s/aggregated/instances?%s", h.endpoint, url.PathEscape(h.project), query.Encode())
+		if err := h.client.GetJSON(ctx, endpoint, &page); err != nil {
+			return shared.HandleError(ctx, "GCE instances", fmt.Sprintf("aggregatedList:Page%d", pageNum), err)
+		}
+		for _, scope := range page.Unreachables {
+			logger.Warnf(ctx, "GCE zone %s was unreachable, its instances are not listed", scope)
+		}
+
+		for _, scoped := range page.Items {
+			for _, instance := range scoped.Instances {
+				if !shared.MatchesLabelFilters(instance.Labels, filters) {
+					continue
+				}
+				resource, mapErr := newInstanceResource(instance, h.project)
+				if mapErr != nil {
+					logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for GCE instance %s, skipping", instance.Name)
+					continue
+				}
+				select {
+				case out <- resource:
+				case <-ctx.Done():
+					logger.Warnf(ctx, "Context cancelled while sending GCE instance %s", instance.Name)
+					return ctx.Err()
+				}
+			}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		query.Set("pageToken", page.NextPageToken)
+	}
+
+	logger.Debugf(ctx, "Finished GCE pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches an instance by the ID Terraform records,
+// projects/<project>/zones/<zone>/instances/<name>, or by <zone>/<name>.
+func (h *InstanceHandler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	project, zone, name, err := h.parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf(ctx, "Getting single GCE instance %s", id)
+
+	var instance Instance
+	endpoint := fmt.Sprintf("%s/projects/%s/zones/%s/instances/%s", h.endpoint, url.PathEscape(project), url.PathEscape(zone), url.PathEscape(name))
+	if err := h.client.GetJSON(ctx, endpoint, &instance); err != nil {
+		return nil, shared.HandleError(ctx, "GCE instance", id, err)
+	}
+	return newInstanceResource(instance, project)
+}
+
+func (h *InstanceHandler) parseID(id string) (project, zone, name string, err error) {
+	parts := strings.Split(strings.TrimPrefix(id, "/"), "/")
+	switch {
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "zones" && parts[4] == "instances":
+		return parts[1], parts[3], parts[5], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return h.project, parts[0], parts[1], nil
+	}
+	return "", "", "", errors.New(errors.CodeResourceNotFound,
+		fmt.Sprintf("invalid GCE instance ID '%s', expected projects/<project>/zones/<zone>/instances/<name> or <zone>/<name>", id))
+}
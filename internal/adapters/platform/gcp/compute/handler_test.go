@@ -0,0 +1,162 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const instanceJSON = `{
+	"id": "4567",
+	"name": "web-1",
+	"zone": "https://www.googleapis.com/compute/v1/projects/acme/zones/us-central1-a",
+	"machineType": "https://www.googleapis.com/compute/v1/projects/acme/zones/us-central1-a/machineTypes/e2-medium",
+	"status": "RUNNING",
+	"labels": {"env": "prod", "goog-terraform-provisioned": "true"},
+	"deletionProtection": true,
+	"tags": {"items": ["web", "http-server"]}
+}`
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 4; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(4)...).Maybe().Return()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func newTestHandler(t *testing.T, mux *http.ServeMux) *InstanceHandler {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := shared.NewClient(restapi.StaticTokenSource("token"), restapi.WithRetryBaseDelay(time.Millisecond))
+	return NewHandler(client, "acme", WithEndpoint(srv.URL))
+}
+
+func collect(t *testing.T, h *InstanceHandler, filters map[string]string) []domain.PlatformResource {
+	t.Helper()
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, h.ListResources(context.Background(), filters, newMockLogger(), out))
+	close(out)
+	var resources []domain.PlatformResource
+	for r := range out {
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+func TestInstanceHandler_ListResources(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/acme/aggregated/instances", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("returnPartialSuccess"))
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"items":{"zones/us-central1-a":{"instances":[` + instanceJSON + `]},"zones/europe-west1-b":{"warning":{"code":"NO_RESULTS_ON_PAGE"}}},"nextPageToken":"p2","unreachables":["zones/asia-east1-a"]}`))
+			return
+		}
+		assert.Equal(t, "p2", r.URL.Query().Get("pageToken"))
+		_, _ = w.Write([]byte(`{"items":{"zones/europe-west1-b":{"instances":[{"name":"batch-1","zone":"zones/europe-west1-b","machineType":"zones/europe-west1-b/machineTypes/n2-standard-4","labels":{"env":"dev"}}]}}}`))
+	})
+
+	resources := collect(t, newTestHandler(t, mux), nil)
+
+	require.Len(t, resources, 2)
+	byName := map[string]domain.PlatformResource{}
+	for _, r := range resources {
+		byName[r.Metadata().SourceIdentifier] = r
+	}
+
+	meta := byName["web-1"].Metadata()
+	assert.Equal(t, domain.KindComputeInstance, meta.Kind)
+	assert.Equal(t, shared.ProviderTypeGCP, meta.ProviderType)
+	assert.Equal(t, "projects/acme/zones/us-central1-a/instances/web-1", meta.ProviderAssignedID)
+	assert.Equal(t, "us-central1", meta.Region)
+	assert.Equal(t, "acme", meta.AccountID)
+
+	attrs, err := byName["web-1"].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                        "projects/acme/zones/us-central1-a/instances/web-1",
+		domain.KeyName:                      "web-1",
+		domain.ComputeInstanceTypeKey:       "e2-medium",
+		domain.ComputeAvailabilityZoneKey:   "us-central1-a",
+		domain.ComputeDeletionProtectionKey: true,
+		domain.KeyTags:                      map[string]string{"env": "prod"},
+		domain.ComputeNetworkTagsKey:        []string{"http-server", "web"},
+	}, attrs)
+
+	batchAttrs, err := byName["batch-1"].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "n2-standard-4", batchAttrs[domain.ComputeInstanceTypeKey])
+	assert.NotContains(t, batchAttrs, domain.ComputeNetworkTagsKey)
+}
+
+func TestInstanceHandler_ListResources_LabelFilter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/acme/aggregated/instances", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":{"zones/us-central1-a":{"instances":[` + instanceJSON + `,{"name":"web-2","zone":"zones/us-central1-a","labels":{"env":"dev"}}]}}}`))
+	})
+
+	resources := collect(t, newTestHandler(t, mux), map[string]string{"tag:env": "prod"})
+
+	require.Len(t, resources, 1)
+	assert.Equal(t, "web-1", resources[0].Metadata().SourceIdentifier)
+}
+
+func TestInstanceHandler_ListResources_PermissionDenied(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/acme/aggregated/instances", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"Required 'compute.instances.list' permission","status":"PERMISSION_DENIED"}}`))
+	})
+
+	err := newTestHandler(t, mux).ListResources(context.Background(), nil, newMockLogger(), make(chan domain.PlatformResource, 1))
+
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
+
+func TestInstanceHandler_GetResource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/acme/zones/us-central1-a/instances/web-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(instanceJSON))
+	})
+	mux.HandleFunc("/projects/acme/zones/us-central1-a/instances/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+	})
+	h := newTestHandler(t, mux)
+
+	for _, id := range []string{"projects/acme/zones/us-central1-a/instances/web-1", "us-central1-a/web-1"} {
+		resource, err := h.GetResource(context.Background(), id, newMockLogger())
+		require.NoError(t, err, id)
+		assert.Equal(t, "projects/acme/zones/us-central1-a/instances/web-1", resource.Metadata().ProviderAssignedID)
+	}
+
+	_, err := h.GetResource(context.Background(), "us-central1-a/gone", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+
+	_, err = h.GetResource(context.Background(), "web-1", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
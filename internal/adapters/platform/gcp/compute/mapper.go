@@ -0,0 +1,87 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Instance is the part of a Compute Engine instance resource the detector uses.
+type Instance struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	Zone               string            `json:"zone"`
+	MachineType        string            `json:"machineType"`
+	Status             string            `json:"status"`
+	Labels             map[string]string `json:"labels"`
+	DeletionProtection bool              `json:"deletionProtection"`
+	Tags               struct {
+		Items []string `json:"items"`
+	} `json:"tags"`
+}
+
+// instanceResource wraps a Compute Engine instance. The instance resource holds
+// every compared attribute, so they are mapped once when it is built.
+type instanceResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+// instanceID returns the ID Terraform records for a google_compute_instance.
+func instanceID(project, zone, name string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/instances/%s", project, zone, name)
+}
+
+func newInstanceResource(instance Instance, project string) (domain.PlatformResource, error) {
+	if instance.Name == "" || instance.Zone == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create GCE resource: missing instance name or zone")
+	}
+	zone := shared.LastSegment(instance.Zone)
+	id := instanceID(project, zone, instance.Name)
+
+	return &instanceResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindComputeInstance,
+			ProviderType:       shared.ProviderTypeGCP,
+			ProviderAssignedID: id,
+			SourceIdentifier:   instance.Name,
+			AccountID:          project,
+			Region:             shared.ZoneRegion(zone),
+		},
+		attrs: mapInstanceToAttributes(instance, id, zone),
+	}, nil
+}
+
+func (r *instanceResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *instanceResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapInstanceToAttributes(instance Instance, id, zone string) map[string]any {
+	attrs := map[string]any{
+		domain.KeyID:                        id,
+		domain.KeyName:                      instance.Name,
+		domain.ComputeInstanceTypeKey:       shared.LastSegment(instance.MachineType),
+		domain.ComputeAvailabilityZoneKey:   zone,
+		domain.ComputeDeletionProtectionKey: instance.DeletionProtection,
+		domain.KeyTags:                      shared.UserLabels(instance.Labels),
+	}
+	if len(instance.Tags.Items) > 0 {
+		networkTags := append([]string(nil), instance.Tags.Items...)
+		sort.Strings(networkTags)
+		attrs[domain.ComputeNetworkTagsKey] = networkTags
+	}
+	return attrs
+}
@@ -0,0 +1,25 @@
+package gcp
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// ResourceHandler lists and fetches the resources of one kind in the
+// provider's project.
+type ResourceHandler interface {
+	Kind() domain.ResourceKind
+	ListResources(
+		ctx context.Context,
+		filters map[string]string,
+		logger ports.Logger,
+		out chan<- domain.PlatformResource,
+	) error
+	GetResource(
+		ctx context.Context,
+		id string,
+		logger ports.Logger,
+	) (domain.PlatformResource, error)
+}
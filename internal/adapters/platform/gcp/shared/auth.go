@@ -0,0 +1,302 @@
+package shared
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// ReadOnlyScope is the OAuth2 scope requested for API access; the detector
+	// never modifies resources.
+	ReadOnlyScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+
+	defaultTokenURI    = "https://oauth2.googleapis.com/token"
+	defaultMetadataURL = "http://metadata.google.internal"
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	// tokenExpiryMargin is how long before its expiry a token is refreshed, so
+	// that it does not expire while a request is in flight.
+	tokenExpiryMargin = time.Minute
+	jwtLifetime       = time.Hour
+)
+
+// CredentialsOptions selects where FindCredentials looks for credentials.
+type CredentialsOptions struct {
+	// AccessToken is used as is when set.
+	AccessToken string
+	// CredentialsFile is a service account key or the application default
+	// credentials written by `gcloud auth application-default login`.
+	CredentialsFile string
+	// HTTPClient exchanges credentials for tokens. It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// FindCredentials returns a token source using, in order: the configured access
+// token, the configured credentials file, the GOOGLE_OAUTH_ACCESS_TOKEN and
+// GOOGLE_APPLICATION_CREDENTIALS environment variables, the gcloud application
+// default credentials file and, failing those, the metadata server of the
+// Compute Engine, GKE or Cloud Run host the detector runs on.
+func FindCredentials(opts CredentialsOptions) (restapi.TokenSource, string, error) {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	if opts.AccessToken != "" {
+		return restapi.StaticTokenSource(opts.AccessToken), "configured access token", nil
+	}
+	if opts.CredentialsFile != "" {
+		ts, err := credentialsFromFile(opts.CredentialsFile, httpClient)
+		return ts, opts.CredentialsFile, err
+	}
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return restapi.StaticTokenSource(token), "GOOGLE_OAUTH_ACCESS_TOKEN", nil
+	}
+	if file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); file != "" {
+		ts, err := credentialsFromFile(file, httpClient)
+		return ts, file, err
+	}
+	if file := wellKnownCredentialsFile(); file != "" {
+		if _, err := os.Stat(file); err == nil {
+			ts, err := credentialsFromFile(file, httpClient)
+			return ts, file, err
+		}
+	}
+	metadataURL := defaultMetadataURL
+	if host := os.Getenv("GCE_METADATA_HOST"); host != "" {
+		metadataURL = "http://" + host
+	}
+	return restapi.NewCachingTokenSource(&metadataTokenSource{baseURL: metadataURL, httpClient: httpClient}, tokenExpiryMargin), "metadata server", nil
+}
+
+// wellKnownCredentialsFile is where gcloud writes application default credentials.
+func wellKnownCredentialsFile() string {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+}
+
+type credentialsFile struct {
+	Type string `json:"type"`
+
+	// Service account keys.
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+
+	// Authorized user credentials.
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func credentialsFromFile(path string, httpClient *http.Client) (restapi.TokenSource, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError,
+			fmt.Sprintf("failed to read GCP credentials file '%s'", path),
+			"Check platform.gcp.credentials_file or GOOGLE_APPLICATION_CREDENTIALS.")
+	}
+	return CredentialsFromJSON(content, httpClient)
+}
+
+// CredentialsFromJSON creates a token source from a service account key or
+// authorized user credentials file.
+func CredentialsFromJSON(content []byte, httpClient *http.Client) (restapi.TokenSource, error) {
+	var f credentialsFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError, "failed to parse GCP credentials file",
+			"Use a service account key or the credentials written by 'gcloud auth application-default login'.")
+	}
+	tokenURI := f.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+
+	switch f.Type {
+	case "service_account":
+		key, err := parsePrivateKey(f.PrivateKey)
+		if err != nil {
+			return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError, "invalid private key in GCP service account key",
+				"Create a new JSON key for the service account.")
+		}
+		return restapi.NewCachingTokenSource(&serviceAccountTokenSource{
+			email: f.ClientEmail, keyID: f.PrivateKeyID, key: key, tokenURI: tokenURI, httpClient: httpClient,
+		}, tokenExpiryMargin), nil
+	case "authorized_user":
+		return restapi.NewCachingTokenSource(&authorizedUserTokenSource{
+			clientID: f.ClientID, clientSecret: f.ClientSecret, refreshToken: f.RefreshToken, tokenURI: tokenURI, httpClient: httpClient,
+		}, tokenExpiryMargin), nil
+	default:
+		return nil, errors.NewUserFacing(errors.CodePlatformAuthError, fmt.Sprintf("unsupported GCP credentials type '%s'", f.Type),
+			"Use a service account key or the credentials written by 'gcloud auth application-default login'.")
+	}
+}
+
+func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is a %T, not an RSA key", parsed)
+	}
+	return key, nil
+}
+
+// serviceAccountTokenSource exchanges a JWT signed with a service account key
+// for an access token.
+type serviceAccountTokenSource struct {
+	email      string
+	keyID      string
+	key        *rsa.PrivateKey
+	tokenURI   string
+	httpClient *http.Client
+}
+
+func (s *serviceAccountTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	assertion, err := s.signedJWT(time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodePlatformAuthError, "failed to sign GCP service account token request")
+	}
+	return exchangeToken(ctx, s.httpClient, s.tokenURI, url.Values{
+		"grant_type": {jwtBearerGrantType},
+		"assertion":  {assertion},
+	})
+}
+
+func (s *serviceAccountTokenSource) signedJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": s.keyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss":   s.email,
+		"scope": ReadOnlyScope,
+		"aud":   s.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(jwtLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// authorizedUserTokenSource refreshes the access token of a user who ran
+// `gcloud auth application-default login`.
+type authorizedUserTokenSource struct {
+	clientID     string
+	clientSecret string
+	refreshToken string
+	tokenURI     string
+	httpClient   *http.Client
+}
+
+func (s *authorizedUserTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	return exchangeToken(ctx, s.httpClient, s.tokenURI, url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+		"refresh_token": {s.refreshToken},
+	})
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (r tokenResponse) token(now time.Time) *restapi.Token {
+	token := &restapi.Token{AccessToken: r.AccessToken}
+	if r.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return token
+}
+
+func exchangeToken(ctx context.Context, httpClient *http.Client, tokenURI string, form url.Values) (*restapi.Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create GCP token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doTokenRequest(httpClient, req)
+}
+
+// metadataTokenSource fetches the token of the service account attached to the
+// host from the metadata server.
+type metadataTokenSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func (s *metadataTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	endpoint := s.baseURL + "/computeMetadata/v1/instance/service-accounts/default/token?scopes=" + url.QueryEscape(ReadOnlyScope)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create GCP metadata token request")
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	return doTokenRequest(s.httpClient, req)
+}
+
+func doTokenRequest(httpClient *http.Client, req *http.Request) (*restapi.Token, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError, "failed to obtain a GCP access token",
+			"Set platform.gcp.credentials_file, GOOGLE_APPLICATION_CREDENTIALS or run 'gcloud auth application-default login'.")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodePlatformAuthError, "failed to read GCP token response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewUserFacing(errors.CodePlatformAuthError,
+			fmt.Sprintf("GCP token request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+			"Check that the GCP credentials are valid and not revoked.")
+	}
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil || tr.AccessToken == "" {
+		return nil, errors.New(errors.CodePlatformAuthError, "GCP token response did not contain an access token")
+	}
+	return tr.token(time.Now()), nil
+}
@@ -0,0 +1,147 @@
+package shared
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func serviceAccountKey(t *testing.T, tokenURI string) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	content, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "drift@acme.iam.gserviceaccount.com",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      tokenURI,
+	})
+	require.NoError(t, err)
+	return content, key
+}
+
+func TestCredentialsFromJSON_ServiceAccount(t *testing.T) {
+	var key *rsa.PrivateKey
+	var exchanges atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges.Add(1)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, jwtBearerGrantType, r.PostForm.Get("grant_type"))
+
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		require.Len(t, parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sum[:], signature))
+
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+		assert.Equal(t, "drift@acme.iam.gserviceaccount.com", claims["iss"])
+		assert.Equal(t, ReadOnlyScope, claims["scope"])
+
+		_, _ = w.Write([]byte(`{"access_token":"sa-token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	var content []byte
+	content, key = serviceAccountKey(t, srv.URL)
+	ts, err := CredentialsFromJSON(content, srv.Client())
+	require.NoError(t, err)
+
+	for range 2 {
+		token, err := ts.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "sa-token", token.AccessToken)
+	}
+	assert.Equal(t, int32(1), exchanges.Load(), "the token is reused until it expires")
+}
+
+func TestCredentialsFromJSON_AuthorizedUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "refresh-1", r.PostForm.Get("refresh_token"))
+		_, _ = w.Write([]byte(`{"access_token":"user-token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	content := `{"type":"authorized_user","client_id":"id","client_secret":"secret","refresh_token":"refresh-1","token_uri":"` + srv.URL + `"}`
+	ts, err := CredentialsFromJSON([]byte(content), srv.Client())
+	require.NoError(t, err)
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "user-token", token.AccessToken)
+}
+
+func TestCredentialsFromJSON_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"not json":          `{`,
+		"unsupported type":  `{"type":"external_account"}`,
+		"invalid key bytes": `{"type":"service_account","private_key":"not a key"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := CredentialsFromJSON([]byte(content), http.DefaultClient)
+			assert.True(t, errors.Is(err, errors.CodePlatformAuthError), "got %v", err)
+		})
+	}
+}
+
+func TestCredentialsFromJSON_RejectedExchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer srv.Close()
+
+	content, _ := serviceAccountKey(t, srv.URL)
+	ts, err := CredentialsFromJSON(content, srv.Client())
+	require.NoError(t, err)
+
+	_, err = ts.Token(context.Background())
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+	assert.Contains(t, err.Error(), "invalid_grant")
+}
+
+func TestFindCredentials_Precedence(t *testing.T) {
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "env-token")
+
+	ts, source, err := FindCredentials(CredentialsOptions{AccessToken: "configured"})
+	require.NoError(t, err)
+	assert.Equal(t, "configured access token", source)
+	token, _ := ts.Token(context.Background())
+	assert.Equal(t, "configured", token.AccessToken)
+
+	ts, source, err = FindCredentials(CredentialsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "GOOGLE_OAUTH_ACCESS_TOKEN", source)
+	token, _ = ts.Token(context.Background())
+	assert.Equal(t, "env-token", token.AccessToken)
+}
+
+func TestFindCredentials_MissingFile(t *testing.T) {
+	_, _, err := FindCredentials(CredentialsOptions{CredentialsFile: t.TempDir() + "/missing.json"})
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
@@ -0,0 +1,118 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// maxRetryDelay bounds the backoff between attempts; Google APIs do not send
+// Retry-After.
+const maxRetryDelay = 10 * time.Second
+
+// APIError is an error response of a Google REST API.
+type APIError struct {
+	StatusCode int
+	// Status is the canonical error code, e.g. PERMISSION_DENIED.
+	Status string
+	// Reason is the reason of the first error detail, e.g. rateLimitExceeded.
+	Reason  string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Status != "" {
+		return fmt.Sprintf("googleapi: %d %s: %s", e.StatusCode, e.Status, e.Message)
+	}
+	return fmt.Sprintf("googleapi: %d: %s", e.StatusCode, e.Message)
+}
+
+// Code classifies the error into an application error code.
+func (e *APIError) Code() errors.Code {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden && !e.throttled():
+		return errors.CodePlatformAuthError
+	case e.StatusCode == http.StatusNotFound:
+		return errors.CodeResourceNotFound
+	case e.throttled():
+		return errors.CodePlatformThrottled
+	default:
+		return errors.CodePlatformAPIError
+	}
+}
+
+// throttled reports quota errors, which GCP returns as 429 or, for some
+// per-user quotas, as 403 with a rate limit reason.
+func (e *APIError) throttled() bool {
+	switch e.Reason {
+	case "rateLimitExceeded", "userRateLimitExceeded", "quotaExceeded":
+		return true
+	}
+	return e.StatusCode == http.StatusTooManyRequests || e.Status == "RESOURCE_EXHAUSTED"
+}
+
+// Retryable reports throttled and server errors as retryable, with
+// exponential backoff.
+func (e *APIError) Retryable() (bool, time.Duration) {
+	return e.throttled() || e.StatusCode >= http.StatusInternalServerError, 0
+}
+
+func decodeAPIError(resp *http.Response, body []byte) error {
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+	apiErr.Status = payload.Error.Status
+	apiErr.Message = payload.Error.Message
+	if len(payload.Error.Errors) > 0 {
+		apiErr.Reason = payload.Error.Errors[0].Reason
+	}
+	return apiErr
+}
+
+// NewClient creates a client for Google JSON REST APIs authenticating with
+// tokens and returning error responses as *APIError.
+func NewClient(tokens restapi.TokenSource, opts ...restapi.Option) *restapi.Client {
+	opts = append([]restapi.Option{restapi.WithMaxRetryDelay(maxRetryDelay)}, opts...)
+	return restapi.NewClient(tokens, decodeAPIError, opts...)
+}
+
+// HandleError maps an error of a GCP API call to an application error, like
+// the AWS adapters do for SDK errors.
+func HandleError(ctx context.Context, resourceType, resourceID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil || err == context.Canceled || err == context.DeadlineExceeded {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("context canceled during GCP %s API call", resourceType))
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+	switch code := apiErr.Code(); code {
+	case errors.CodePlatformAuthError:
+		return errors.Wrap(err, code, fmt.Sprintf("GCP authentication error accessing %s %s", resourceType, resourceID))
+	case errors.CodePlatformThrottled:
+		return errors.Wrap(err, code, fmt.Sprintf("GCP throttled requests for %s '%s'", resourceType, resourceID))
+	case errors.CodeResourceNotFound:
+		return errors.Wrap(err, code, fmt.Sprintf("%s '%s' not found", resourceType, resourceID))
+	default:
+		return errors.Wrap(err, code, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+}
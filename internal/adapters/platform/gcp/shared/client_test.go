@@ -0,0 +1,95 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestClient() *restapi.Client {
+	return NewClient(restapi.StaticTokenSource("test-token"), restapi.WithRetryBaseDelay(time.Millisecond), restapi.WithRequestsPerSecond(1000))
+}
+
+func TestClient_GetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"name":"web-1"}`))
+	}))
+	defer srv.Close()
+
+	var out struct{ Name string }
+	require.NoError(t, newTestClient().GetJSON(context.Background(), srv.URL, &out))
+	assert.Equal(t, "web-1", out.Name)
+}
+
+func TestClient_APIErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     int
+		body       string
+		wantCode   errors.Code
+		wantStatus string
+	}{
+		{"permission denied", http.StatusForbidden, `{"error":{"code":403,"message":"denied","status":"PERMISSION_DENIED","errors":[{"reason":"forbidden"}]}}`, errors.CodePlatformAuthError, "PERMISSION_DENIED"},
+		{"not found", http.StatusNotFound, `{"error":{"code":404,"message":"gone","errors":[{"reason":"notFound"}]}}`, errors.CodeResourceNotFound, ""},
+		{"user rate limit", http.StatusForbidden, `{"error":{"code":403,"message":"slow down","errors":[{"reason":"userRateLimitExceeded"}]}}`, errors.CodePlatformThrottled, ""},
+		{"bad request", http.StatusBadRequest, `not json`, errors.CodePlatformAPIError, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			err := newTestClient().GetJSON(context.Background(), srv.URL, &struct{}{})
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			assert.Equal(t, tc.wantStatus, apiErr.Status)
+			assert.Equal(t, tc.wantCode, apiErr.Code())
+			assert.True(t, errors.Is(HandleError(context.Background(), "GCE instance", "web-1", err), tc.wantCode))
+		})
+	}
+}
+
+func TestClient_RetriesThrottledAndServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	require.NoError(t, newTestClient().GetJSON(context.Background(), srv.URL, &struct{}{}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	require.Error(t, newTestClient().GetJSON(context.Background(), srv.URL, &struct{}{}))
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,59 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+const ProviderTypeGCP = "gcp"
+
+// systemLabelPrefix marks labels GCP or the Terraform google provider add on
+// their own, such as goog-terraform-provisioned.
+const systemLabelPrefix = "goog-"
+
+// UserLabels returns the labels of a resource without the system labels, which
+// Terraform keeps out of the labels it records in state.
+func UserLabels(labels map[string]string) map[string]string {
+	userLabels := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if strings.HasPrefix(k, systemLabelPrefix) {
+			continue
+		}
+		userLabels[k] = v
+	}
+	return userLabels
+}
+
+// LastSegment returns the resource name at the end of a GCP resource URL, e.g.
+// "e2-medium" for ".../zones/us-central1-a/machineTypes/e2-medium".
+func LastSegment(url string) string {
+	if i := strings.LastIndex(url, "/"); i >= 0 {
+		return url[i+1:]
+	}
+	return url
+}
+
+// ZoneRegion returns the region of a zone, e.g. "us-central1" for
+// "us-central1-a".
+func ZoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// MatchesLabelFilters reports whether labels satisfy the "tag:<key>" filters,
+// which GCP resources apply to their labels.
+func MatchesLabelFilters(labels map[string]string, filters map[string]string) bool {
+	for k, v := range filters {
+		key, ok := strings.CutPrefix(k, domain.TagPrefix)
+		if !ok {
+			continue
+		}
+		if labels[key] != v {
+			return false
+		}
+	}
+	return true
+}
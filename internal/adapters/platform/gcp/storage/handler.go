@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+const (
+	defaultEndpoint = "https://storage.googleapis.com/storage/v1"
+	listPageSize    = 1000
+)
+
+// BucketHandler lists and fetches the Cloud Storage buckets of a project.
+type BucketHandler struct {
+	client   *restapi.Client
+	project  string
+	endpoint string
+}
+
+// HandlerOption defines a function signature for configuring the BucketHandler.
+type HandlerOption func(*BucketHandler)
+
+// WithEndpoint provides an option to set the Cloud Storage JSON API base URL.
+func WithEndpoint(endpoint string) HandlerOption {
+	return func(h *BucketHandler) {
+		if endpoint != "" {
+			h.endpoint = strings.TrimRight(endpoint, "/")
+		}
+	}
+}
+
+// NewHandler creates a new BucketHandler for the buckets of project.
+func NewHandler(client *restapi.Client, project string, opts ...HandlerOption) *BucketHandler {
+	h := &BucketHandler{client: client, project: project, endpoint: defaultEndpoint}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *BucketHandler) Kind() domain.ResourceKind {
+	return domain.KindStorageBucket
+}
+
+type listResponse struct {
+	Items         []Bucket `json:"items"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+// ListResources lists the buckets of the project. Label filters ("tag:<key>")
+// are applied after listing.
+func (h *BucketHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	query := url.Values{
+		"project":    {h.project},
+		"maxResults": {fmt.Sprint(listPageSize)},
+		"projection": {"full"},
+	}
+
+	logger.Debugf(ctx, "Starting GCS bucket listing with pagination")
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		var page listResponse
+		if err := h.client.GetJSON(ctx, h.endpoint+"/b?"+query.Encode(), &page); err != nil {
+			return shared.HandleError(ctx, "GCS buckets", fmt.Sprintf("list:Page%d", pageNum), err)
+		}
+
+		for _, bucket := range page.Items {
+			if !shared.MatchesLabelFilters(bucket.Labels, filters) {
+				continue
+			}
+			resource, mapErr := newBucketResource(bucket, h.project)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for GCS bucket %s, skipping", bucket.Name)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending GCS bucket %s", bucket.Name)
+				return ctx.Err()
+			}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		query.Set("pageToken", page.NextPageToken)
+	}
+
+	logger.Debugf(ctx, "Finished GCS pagination and processing (%d pages).", pageNum)
+	return nil
+}
+
+// GetResource fetches a bucket by name, which is also the ID Terraform records.
+func (h *BucketHandler) GetResource(ctx context.Context, name string, logger ports.Logger) (domain.PlatformResource, error) {
+	logger.Debugf(ctx, "Getting single GCS bucket %s", name)
+	var bucket Bucket
+	if err := h.client.GetJSON(ctx, h.endpoint+"/b/"+url.PathEscape(name)+"?projection=full", &bucket); err != nil {
+		return nil, shared.HandleError(ctx, "GCS bucket", name, err)
+	}
+	return newBucketResource(bucket, h.project)
+}
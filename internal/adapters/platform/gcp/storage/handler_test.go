@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const bucketJSON = `{
+	"name": "acme-assets",
+	"location": "US-CENTRAL1",
+	"storageClass": "STANDARD",
+	"labels": {"team": "web", "goog-terraform-provisioned": "true"},
+	"versioning": {"enabled": true},
+	"iamConfiguration": {"uniformBucketLevelAccess": {"enabled": true}}
+}`
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 4; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(4)...).Maybe().Return()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func newTestHandler(t *testing.T, mux *http.ServeMux) *BucketHandler {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client := shared.NewClient(restapi.StaticTokenSource("token"), restapi.WithRetryBaseDelay(time.Millisecond))
+	return NewHandler(client, "acme", WithEndpoint(srv.URL))
+}
+
+func TestBucketHandler_ListResources(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "acme", r.URL.Query().Get("project"))
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"items":[` + bucketJSON + `],"nextPageToken":"p2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"acme-logs","location":"EU","storageClass":"NEARLINE","labels":{"team":"ops"}}]}`))
+	})
+	h := newTestHandler(t, mux)
+
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, h.ListResources(context.Background(), map[string]string{"tag:team": "web"}, newMockLogger(), out))
+	close(out)
+
+	var resources []domain.PlatformResource
+	for r := range out {
+		resources = append(resources, r)
+	}
+	require.Len(t, resources, 1, "the label filter drops acme-logs")
+
+	meta := resources[0].Metadata()
+	assert.Equal(t, domain.KindStorageBucket, meta.Kind)
+	assert.Equal(t, shared.ProviderTypeGCP, meta.ProviderType)
+	assert.Equal(t, "acme-assets", meta.ProviderAssignedID)
+	assert.Equal(t, "us-central1", meta.Region)
+
+	attrs, err := resources[0].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                         "acme-assets",
+		domain.KeyName:                       "acme-assets",
+		domain.StorageBucketLocationKey:      "US-CENTRAL1",
+		domain.StorageBucketStorageClassKey:  "STANDARD",
+		domain.StorageBucketVersioningKey:    true,
+		domain.StorageBucketUniformAccessKey: true,
+		domain.KeyTags:                       map[string]string{"team": "web"},
+	}, attrs)
+}
+
+func TestBucketHandler_GetResource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/b/acme-logs", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"acme-logs","location":"EU","storageClass":"NEARLINE"}`))
+	})
+	mux.HandleFunc("/b/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"The specified bucket does not exist."}}`))
+	})
+	h := newTestHandler(t, mux)
+
+	resource, err := h.GetResource(context.Background(), "acme-logs", newMockLogger())
+	require.NoError(t, err)
+	attrs, err := resource.Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, false, attrs[domain.StorageBucketVersioningKey])
+	assert.Equal(t, false, attrs[domain.StorageBucketUniformAccessKey])
+	assert.Equal(t, map[string]string{}, attrs[domain.KeyTags])
+
+	_, err = h.GetResource(context.Background(), "gone", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
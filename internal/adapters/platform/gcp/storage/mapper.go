@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/gcp/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Bucket is the part of a Cloud Storage bucket resource the detector uses.
+type Bucket struct {
+	Name         string            `json:"name"`
+	Location     string            `json:"location"`
+	StorageClass string            `json:"storageClass"`
+	Labels       map[string]string `json:"labels"`
+	Versioning   *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"versioning"`
+	IAMConfiguration *struct {
+		UniformBucketLevelAccess *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"uniformBucketLevelAccess"`
+	} `json:"iamConfiguration"`
+}
+
+// bucketResource wraps a Cloud Storage bucket. The bucket resource holds every
+// compared attribute, so they are mapped once when it is built.
+type bucketResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newBucketResource(bucket Bucket, project string) (domain.PlatformResource, error) {
+	if bucket.Name == "" {
+		return nil, errors.New(errors.CodeInternal, "failed to create GCS resource: missing bucket name")
+	}
+
+	return &bucketResource{
+		meta: domain.ResourceMetadata{
+			Kind:               domain.KindStorageBucket,
+			ProviderType:       shared.ProviderTypeGCP,
+			ProviderAssignedID: bucket.Name,
+			SourceIdentifier:   bucket.Name,
+			AccountID:          project,
+			Region:             strings.ToLower(bucket.Location),
+		},
+		attrs: mapBucketToAttributes(bucket),
+	}, nil
+}
+
+func (r *bucketResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *bucketResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
+
+func mapBucketToAttributes(bucket Bucket) map[string]any {
+	uniformAccess := false
+	if iam := bucket.IAMConfiguration; iam != nil && iam.UniformBucketLevelAccess != nil {
+		uniformAccess = iam.UniformBucketLevelAccess.Enabled
+	}
+	return map[string]any{
+		domain.KeyID:                         bucket.Name,
+		domain.KeyName:                       bucket.Name,
+		domain.StorageBucketLocationKey:      strings.ToUpper(bucket.Location),
+		domain.StorageBucketStorageClassKey:  bucket.StorageClass,
+		domain.StorageBucketVersioningKey:    bucket.Versioning != nil && bucket.Versioning.Enabled,
+		domain.StorageBucketUniformAccessKey: uniformAccess,
+		domain.KeyTags:                       shared.UserLabels(bucket.Labels),
+	}
+}
@@ -0,0 +1,182 @@
+package objects
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// listPageSize is the number of objects requested per list call.
+const listPageSize = 500
+
+// apiResource locates the objects of a kind in the Kubernetes API.
+type apiResource struct {
+	kind domain.ResourceKind
+	// apiVersion and objectKind are set on listed objects, which the API
+	// returns without them.
+	apiVersion string
+	objectKind string
+	// basePath is the group version path, e.g. /apis/apps/v1.
+	basePath string
+	// plural is the resource name in paths, e.g. deployments.
+	plural string
+}
+
+var (
+	deployments = apiResource{kind: domain.KindKubernetesDeployment, apiVersion: "apps/v1", objectKind: "Deployment", basePath: "/apis/apps/v1", plural: "deployments"}
+	services    = apiResource{kind: domain.KindKubernetesService, apiVersion: "v1", objectKind: "Service", basePath: "/api/v1", plural: "services"}
+	configMaps  = apiResource{kind: domain.KindKubernetesConfigMap, apiVersion: "v1", objectKind: "ConfigMap", basePath: "/api/v1", plural: "configmaps"}
+)
+
+// Handler lists and fetches the objects of one namespaced kind.
+type Handler struct {
+	client     *restapi.Client
+	resource   apiResource
+	cluster    string
+	namespaces []string
+}
+
+// HandlerOption defines a function signature for configuring the Handler.
+type HandlerOption func(*Handler)
+
+// WithNamespaces provides an option to restrict listing to the given
+// namespaces instead of the whole cluster.
+func WithNamespaces(namespaces []string) HandlerOption {
+	return func(h *Handler) {
+		h.namespaces = namespaces
+	}
+}
+
+// NewDeploymentHandler creates a Handler for the Deployments of cluster.
+func NewDeploymentHandler(client *restapi.Client, cluster string, opts ...HandlerOption) *Handler {
+	return newHandler(client, deployments, cluster, opts)
+}
+
+// NewServiceHandler creates a Handler for the Services of cluster.
+func NewServiceHandler(client *restapi.Client, cluster string, opts ...HandlerOption) *Handler {
+	return newHandler(client, services, cluster, opts)
+}
+
+// NewConfigMapHandler creates a Handler for the ConfigMaps of cluster.
+func NewConfigMapHandler(client *restapi.Client, cluster string, opts ...HandlerOption) *Handler {
+	return newHandler(client, configMaps, cluster, opts)
+}
+
+func newHandler(client *restapi.Client, resource apiResource, cluster string, opts []HandlerOption) *Handler {
+	h := &Handler{client: client, resource: resource, cluster: cluster}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) Kind() domain.ResourceKind {
+	return h.resource.kind
+}
+
+type listResponse struct {
+	Items    []shared.Object `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+// ListResources lists the objects of the configured namespaces, or of the
+// whole cluster. A "namespace" filter narrows the listing to one namespace and
+// "label:<key>" filters are sent as a label selector.
+func (h *Handler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	namespaces := h.namespaces
+	if ns, ok := filters["namespace"]; ok {
+		namespaces = []string{ns}
+	}
+	if len(namespaces) == 0 {
+		return h.list(ctx, h.resource.basePath+"/"+h.resource.plural, filters, logger, out)
+	}
+	for _, ns := range namespaces {
+		path := fmt.Sprintf("%s/namespaces/%s/%s", h.resource.basePath, url.PathEscape(ns), h.resource.plural)
+		if err := h.list(ctx, path, filters, logger, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) list(ctx context.Context, path string, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	query := url.Values{"limit": {fmt.Sprint(listPageSize)}}
+	if selector := labelSelector(filters); selector != "" {
+		query.Set("labelSelector", selector)
+	}
+
+	logger.Debugf(ctx, "Starting Kubernetes %s listing with pagination", h.resource.plural)
+	pageNum := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		pageNum++
+		var page listResponse
+		if err := h.client.GetJSON(ctx, path+"?"+query.Encode(), &page); err != nil {
+			return shared.HandleError(ctx, h.resource.plural, fmt.Sprintf("list:Page%d", pageNum), err)
+		}
+
+		for _, obj := range page.Items {
+			obj.APIVersion, obj.Kind = h.resource.apiVersion, h.resource.objectKind
+			resource, mapErr := newObjectResource(obj, h.cluster)
+			if mapErr != nil {
+				logger.Errorf(ctx, mapErr, "Failed to create resource wrapper for Kubernetes %s %s, skipping", h.resource.objectKind, obj.Metadata.Name)
+				continue
+			}
+			select {
+			case out <- resource:
+			case <-ctx.Done():
+				logger.Warnf(ctx, "Context cancelled while sending Kubernetes %s %s", h.resource.objectKind, obj.Metadata.Name)
+				return ctx.Err()
+			}
+		}
+		if page.Metadata.Continue == "" {
+			break
+		}
+		query.Set("continue", page.Metadata.Continue)
+	}
+
+	logger.Debugf(ctx, "Finished Kubernetes %s pagination and processing (%d pages).", h.resource.plural, pageNum)
+	return nil
+}
+
+// GetResource fetches an object by its "<namespace>/<name>" key.
+func (h *Handler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	namespace, name, ok := strings.Cut(id, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, errors.New(errors.CodeResourceNotFound,
+			fmt.Sprintf("invalid Kubernetes %s ID '%s', expected <namespace>/<name>", h.resource.objectKind, id))
+	}
+	logger.Debugf(ctx, "Getting single Kubernetes %s %s", h.resource.objectKind, id)
+
+	var obj shared.Object
+	path := fmt.Sprintf("%s/namespaces/%s/%s/%s", h.resource.basePath, url.PathEscape(namespace), h.resource.plural, url.PathEscape(name))
+	if err := h.client.GetJSON(ctx, path, &obj); err != nil {
+		return nil, shared.HandleError(ctx, h.resource.objectKind, id, err)
+	}
+	obj.APIVersion, obj.Kind = h.resource.apiVersion, h.resource.objectKind
+	return newObjectResource(obj, h.cluster)
+}
+
+// labelSelector builds an equality label selector from "label:<key>" filters.
+func labelSelector(filters map[string]string) string {
+	var terms []string
+	for key, value := range filters {
+		if labelKey, ok := strings.CutPrefix(key, "label:"); ok {
+			terms = append(terms, labelKey+"="+value)
+		}
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,154 @@
+package objects
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const deploymentJSON = `{
+	"metadata": {"name": "web", "namespace": "shop", "uid": "6f1c", "labels": {"app": "web"}},
+	"spec": {
+		"replicas": 3,
+		"selector": {"matchLabels": {"app": "web"}},
+		"strategy": {"type": "Recreate"},
+		"template": {"spec": {"containers": [{"name": "web", "image": "nginx:1.27"}]}}
+	}
+}`
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 6; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(5)...).Maybe().Return()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *restapi.Client {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return shared.NewClient(&shared.RESTConfig{Server: srv.URL, Tokens: restapi.StaticTokenSource("token")}, restapi.WithRetryBaseDelay(time.Millisecond))
+}
+
+func collect(t *testing.T, h *Handler, filters map[string]string) []domain.PlatformResource {
+	t.Helper()
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, h.ListResources(context.Background(), filters, newMockLogger(), out))
+	close(out)
+	var resources []domain.PlatformResource
+	for r := range out {
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+func TestHandler_ListDeployments(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apis/apps/v1/deployments", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "500", r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("continue") == "" {
+			_, _ = w.Write([]byte(`{"items":[` + deploymentJSON + `],"metadata":{"continue":"p2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"metadata":{"name":"worker","namespace":"jobs"},"spec":{"template":{"spec":{"containers":[{"name":"worker","image":"worker:2"}]}}}}],"metadata":{}}`))
+	})
+
+	resources := collect(t, NewDeploymentHandler(newTestClient(t, mux), "dev"), nil)
+
+	require.Len(t, resources, 2)
+	meta := resources[0].Metadata()
+	assert.Equal(t, domain.KindKubernetesDeployment, meta.Kind)
+	assert.Equal(t, shared.ProviderTypeKubernetes, meta.ProviderType)
+	assert.Equal(t, "shop/web", meta.ProviderAssignedID)
+	assert.Equal(t, "shop/web", meta.SourceIdentifier)
+	assert.Equal(t, "dev", meta.AccountID)
+
+	attrs, err := resources[0].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, attrs[domain.KubernetesReplicasKey])
+	assert.Equal(t, "Recreate", attrs[domain.KubernetesStrategyKey])
+	assert.Equal(t, map[string]string{"app": "web"}, attrs[domain.KeyTags])
+
+	workerAttrs, err := resources[1].Attributes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, workerAttrs[domain.KubernetesReplicasKey], "replicas default to 1")
+}
+
+func TestHandler_ListNamespacesAndLabelSelector(t *testing.T) {
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/namespaces/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "app=web,tier=front", r.URL.Query().Get("labelSelector"))
+		_, _ = w.Write([]byte(`{"items":[{"metadata":{"name":"settings"},"data":{"a":"b"}}],"metadata":{}}`))
+	})
+	client := newTestClient(t, mux)
+	filters := map[string]string{"label:tier": "front", "label:app": "web"}
+
+	resources := collect(t, NewConfigMapHandler(client, "dev", WithNamespaces([]string{"shop", "jobs"})), filters)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, []string{"/api/v1/namespaces/shop/configmaps", "/api/v1/namespaces/jobs/configmaps"}, paths)
+
+	paths = nil
+	filters["namespace"] = "ops"
+	collect(t, NewConfigMapHandler(client, "dev", WithNamespaces([]string{"shop"})), filters)
+	assert.Equal(t, []string{"/api/v1/namespaces/ops/configmaps"}, paths, "a namespace filter overrides the configured namespaces")
+}
+
+func TestHandler_ListForbidden(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/services", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"kind":"Status","reason":"Forbidden","message":"services is forbidden: User \"drift\" cannot list resource \"services\""}`))
+	})
+
+	err := NewServiceHandler(newTestClient(t, mux), "dev").ListResources(context.Background(), nil, newMockLogger(), make(chan domain.PlatformResource, 1))
+
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
+
+func TestHandler_GetResource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apis/apps/v1/namespaces/shop/deployments/web", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(deploymentJSON))
+	})
+	mux.HandleFunc("/apis/apps/v1/namespaces/shop/deployments/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","reason":"NotFound","message":"not found"}`))
+	})
+	h := NewDeploymentHandler(newTestClient(t, mux), "dev")
+
+	resource, err := h.GetResource(context.Background(), "shop/web", newMockLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "shop/web", resource.Metadata().ProviderAssignedID)
+
+	_, err = h.GetResource(context.Background(), "shop/gone", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+
+	_, err = h.GetResource(context.Background(), "web", newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
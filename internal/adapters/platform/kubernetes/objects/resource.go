@@ -0,0 +1,47 @@
+package objects
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// objectResource wraps a Kubernetes object. The object holds every compared
+// attribute, so they are mapped once when it is built.
+type objectResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func newObjectResource(obj shared.Object, cluster string) (domain.PlatformResource, error) {
+	kind, _ := obj.ResourceKind()
+	attrs, err := obj.Attributes(shared.DefaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+	key := obj.Key(shared.DefaultNamespace)
+	return &objectResource{
+		meta: domain.ResourceMetadata{
+			Kind:               kind,
+			ProviderType:       shared.ProviderTypeKubernetes,
+			ProviderAssignedID: key,
+			SourceIdentifier:   key,
+			AccountID:          cluster,
+		},
+		attrs: attrs,
+	}, nil
+}
+
+func (r *objectResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *objectResource) Attributes(ctx context.Context) (map[string]any, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup, nil
+}
@@ -0,0 +1,157 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/objects"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/config"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// getResourcesConcurrency bounds the concurrent GetResource calls of GetResources.
+const getResourcesConcurrency = 8
+
+// Provider reads the Deployments, Services and ConfigMaps of a Kubernetes
+// cluster through its API server.
+type Provider struct {
+	cluster  string
+	handlers map[domain.ResourceKind]ResourceHandler
+	logger   ports.Logger
+}
+
+func NewProvider(ctx context.Context, cfg config.KubernetesPlatformConfig, logger ports.Logger) (*Provider, error) {
+	if logger == nil {
+		return nil, errors.New(errors.CodeConfigValidation, "logger cannot be nil for Kubernetes Provider")
+	}
+
+	restCfg, source, err := shared.LoadConfig(shared.ConfigOptions{Kubeconfig: cfg.Kubeconfig, Context: cfg.Context})
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof(ctx, "Kubernetes provider configured", "server", restCfg.Server, "credentials", source)
+
+	client := shared.NewClient(restCfg, restapi.WithRequestsPerSecond(cfg.APIRequestsPerSecond))
+	opts := []objects.HandlerOption{objects.WithNamespaces(cfg.Namespaces)}
+	p := NewProviderWithHandlers(restCfg.Cluster, logger,
+		objects.NewDeploymentHandler(client, restCfg.Cluster, opts...),
+		objects.NewServiceHandler(client, restCfg.Cluster, opts...),
+		objects.NewConfigMapHandler(client, restCfg.Cluster, opts...),
+	)
+	logger.Infof(ctx, "Kubernetes provider initialized", "handlers", p.getSupportedKinds())
+	return p, nil
+}
+
+func NewProviderWithHandlers(cluster string, logger ports.Logger, handlers ...ResourceHandler) *Provider {
+	p := &Provider{
+		cluster:  cluster,
+		handlers: make(map[domain.ResourceKind]ResourceHandler),
+		logger:   logger,
+	}
+	for _, handler := range handlers {
+		if handler != nil {
+			p.handlers[handler.Kind()] = handler
+			p.logger.Debugf(context.Background(), "Registered Kubernetes handler", "kind", handler.Kind())
+		}
+	}
+	return p
+}
+
+func (p *Provider) getSupportedKinds() []string {
+	kinds := make([]string, 0, len(p.handlers))
+	for k := range p.handlers {
+		kinds = append(kinds, string(k))
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func (p *Provider) Type() string {
+	return shared.ProviderTypeKubernetes
+}
+
+func (p *Provider) ListResources(
+	ctx context.Context,
+	requestedKinds []domain.ResourceKind,
+	filters map[string]string,
+	out chan<- domain.PlatformResource,
+) error {
+	g, childCtx := errgroup.WithContext(ctx)
+	foundHandler := false
+
+	p.logger.Debugf(ctx, "Initiating Kubernetes ListResources", "requested_kinds", requestedKinds)
+
+	for _, kind := range requestedKinds {
+		handler, found := p.handlers[kind]
+		if !found {
+			p.logger.Warnf(childCtx, "Resource kind not supported by Kubernetes provider, skipping", "kind", kind)
+			continue
+		}
+		foundHandler = true
+
+		g.Go(func() error {
+			handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": kind})
+			handlerLogger.Debugf(childCtx, "Starting ListResources via handler")
+			if err := handler.ListResources(childCtx, filters, handlerLogger, out); err != nil {
+				handlerLogger.Errorf(childCtx, err, "Handler ListResources failed")
+				if err == context.Canceled || err == context.DeadlineExceeded {
+					return err
+				}
+				return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("handler for kind '%s' failed", kind))
+			}
+			handlerLogger.Debugf(childCtx, "Handler ListResources finished successfully")
+			return nil
+		})
+	}
+
+	if !foundHandler && len(requestedKinds) > 0 {
+		return errors.New(errors.CodeNotImplemented, "no supported resource kinds found for Kubernetes provider among requested kinds")
+	}
+
+	if err := g.Wait(); err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			p.logger.Warnf(ctx, "Kubernetes ListResources operation cancelled or timed out", "error", err)
+		} else {
+			p.logger.Errorf(ctx, err, "Error occurred during Kubernetes ListResources execution")
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id string) (domain.PlatformResource, error) {
+	handler, found := p.handlers[kind]
+	if !found {
+		return nil, errors.New(errors.CodeNotImplemented, fmt.Sprintf("resource kind '%s' not supported by Kubernetes provider", kind))
+	}
+
+	handlerLogger := p.logger.WithFields(map[string]any{"resource_kind": kind, "resource_id": id})
+	resource, err := handler.GetResource(ctx, id, handlerLogger)
+	if err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded || errors.Is(err, errors.CodeResourceNotFound) {
+			return nil, err
+		}
+		handlerLogger.Errorf(ctx, err, "Handler GetResource failed")
+		return nil, errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to get resource '%s' of kind '%s'", id, kind))
+	}
+	return resource, nil
+}
+
+// GetResources fetches the given objects with concurrent GetResource calls.
+// IDs that do not exist are left out of the result.
+func (p *Provider) GetResources(ctx context.Context, kind domain.ResourceKind, ids []string) (map[string]domain.PlatformResource, error) {
+	if _, found := p.handlers[kind]; !found {
+		return nil, errors.New(errors.CodeNotImplemented, fmt.Sprintf("resource kind '%s' not supported by Kubernetes provider", kind))
+	}
+
+	return restapi.GetResources(ctx, ids, getResourcesConcurrency, func(ctx context.Context, id string) (domain.PlatformResource, error) {
+		return p.GetResource(ctx, kind, id)
+	})
+}
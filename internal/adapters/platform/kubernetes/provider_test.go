@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/config"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type fakeResource struct {
+	meta domain.ResourceMetadata
+}
+
+func (r *fakeResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r *fakeResource) Attributes(context.Context) (map[string]any, error) {
+	return map[string]any{domain.KeyID: r.meta.ProviderAssignedID}, nil
+}
+
+// fakeHandler serves the resources it holds, keyed by ID.
+type fakeHandler struct {
+	kind      domain.ResourceKind
+	resources map[string]domain.PlatformResource
+	listErr   error
+}
+
+func (h *fakeHandler) Kind() domain.ResourceKind { return h.kind }
+
+func (h *fakeHandler) ListResources(ctx context.Context, filters map[string]string, logger ports.Logger, out chan<- domain.PlatformResource) error {
+	if h.listErr != nil {
+		return h.listErr
+	}
+	for _, r := range h.resources {
+		out <- r
+	}
+	return nil
+}
+
+func (h *fakeHandler) GetResource(ctx context.Context, id string, logger ports.Logger) (domain.PlatformResource, error) {
+	if r, ok := h.resources[id]; ok {
+		return r, nil
+	}
+	return nil, errors.New(errors.CodeResourceNotFound, "not found")
+}
+
+func newFakeHandler(kind domain.ResourceKind, ids ...string) *fakeHandler {
+	h := &fakeHandler{kind: kind, resources: map[string]domain.PlatformResource{}}
+	for _, id := range ids {
+		h.resources[id] = &fakeResource{meta: domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id}}
+	}
+	return h
+}
+
+func newMockLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf"} {
+		for n := 2; n <= 6; n++ {
+			logger.On(method, anything(n)...).Maybe().Return()
+		}
+	}
+	logger.On("Errorf", anything(3)...).Maybe().Return()
+	logger.On("WithFields", mock.Anything).Return(logger).Maybe()
+	return logger
+}
+
+func anything(n int) mock.Arguments {
+	args := make(mock.Arguments, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func TestProvider_ListResources(t *testing.T) {
+	p := NewProviderWithHandlers("dev", newMockLogger(),
+		newFakeHandler(domain.KindKubernetesDeployment, "shop/web", "shop/worker"),
+		newFakeHandler(domain.KindKubernetesService, "shop/web"))
+	assert.Equal(t, shared.ProviderTypeKubernetes, p.Type())
+
+	out := make(chan domain.PlatformResource, 10)
+	require.NoError(t, p.ListResources(context.Background(), []domain.ResourceKind{domain.KindKubernetesDeployment, domain.KindKubernetesService, domain.KindComputeInstance}, nil, out))
+	close(out)
+
+	var ids []string
+	for r := range out {
+		ids = append(ids, string(r.Metadata().Kind)+" "+r.Metadata().ProviderAssignedID)
+	}
+	assert.ElementsMatch(t, []string{"KubernetesDeployment shop/web", "KubernetesDeployment shop/worker", "KubernetesService shop/web"}, ids)
+
+	err := p.ListResources(context.Background(), []domain.ResourceKind{domain.KindComputeInstance}, nil, make(chan domain.PlatformResource, 1))
+	assert.True(t, errors.Is(err, errors.CodeNotImplemented))
+}
+
+func TestProvider_GetResources(t *testing.T) {
+	p := NewProviderWithHandlers("dev", newMockLogger(), newFakeHandler(domain.KindKubernetesConfigMap, "shop/a", "shop/b"))
+
+	resources, err := p.GetResources(context.Background(), domain.KindKubernetesConfigMap, []string{"shop/a", "shop/b", "shop/missing"})
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+
+	_, err = p.GetResource(context.Background(), domain.KindKubernetesConfigMap, "shop/missing")
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
+
+func TestNewProvider_FromKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(`current-context: dev
+clusters: [{name: dev, cluster: {server: "https://127.0.0.1:6443"}}]
+contexts: [{name: dev, context: {cluster: dev, user: dev}}]
+users: [{name: dev, user: {token: t}}]
+`), 0o600))
+
+	p, err := NewProvider(context.Background(), config.KubernetesPlatformConfig{Kubeconfig: path}, newMockLogger())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"KubernetesConfigMap", "KubernetesDeployment", "KubernetesService"}, p.getSupportedKinds())
+
+	_, err = NewProvider(context.Background(), config.KubernetesPlatformConfig{Kubeconfig: path, Context: "prod"}, newMockLogger())
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
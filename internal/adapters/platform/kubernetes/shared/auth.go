@@ -0,0 +1,331 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// tokenExpiryMargin is how long before its expiry a token is refreshed, so
+	// that it does not expire while a request is in flight.
+	tokenExpiryMargin = 30 * time.Second
+	// tokenFileRefresh is how long a token read from a file is reused. Projected
+	// service account tokens are rotated in place.
+	tokenFileRefresh         = time.Minute
+	execCredentialAPIVersion = "client.authentication.k8s.io/v1"
+)
+
+// serviceAccountDir holds the token and CA bundle mounted into pods.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// RESTConfig describes how to reach and authenticate with a cluster's API server.
+type RESTConfig struct {
+	Server string
+	// Cluster names the kubeconfig context, or "in-cluster", in logs and
+	// resource metadata.
+	Cluster string
+	// Namespace is the default namespace of the context.
+	Namespace string
+	TLS       *tls.Config
+	// Tokens is nil when the client authenticates with a certificate only.
+	Tokens restapi.TokenSource
+}
+
+// ConfigOptions select the kubeconfig and context LoadConfig uses.
+type ConfigOptions struct {
+	// Kubeconfig is the path of a kubeconfig file. It overrides KUBECONFIG and
+	// ~/.kube/config.
+	Kubeconfig string
+	// Context overrides the current context of the kubeconfig.
+	Context string
+}
+
+// LoadConfig finds the cluster to connect to, in order: the configured
+// kubeconfig, the first file in KUBECONFIG, ~/.kube/config, and the service
+// account of the pod the detector runs in. The returned string describes the
+// source for logs.
+func LoadConfig(opts ConfigOptions) (*RESTConfig, string, error) {
+	path := opts.Kubeconfig
+	if path == "" {
+		path = defaultKubeconfigPath()
+	}
+	if path != "" {
+		cfg, err := loadKubeconfig(path, opts.Context)
+		if err != nil {
+			return nil, "", err
+		}
+		return cfg, fmt.Sprintf("kubeconfig %s (context %s)", path, cfg.Cluster), nil
+	}
+
+	if host := os.Getenv("KUBERNETES_SERVICE_HOST"); host != "" {
+		cfg, err := inClusterConfig(host, os.Getenv("KUBERNETES_SERVICE_PORT"))
+		if err != nil {
+			return nil, "", err
+		}
+		return cfg, "in-cluster service account", nil
+	}
+	return nil, "", errors.NewUserFacing(errors.CodePlatformAuthError, "no Kubernetes credentials found",
+		"Set platform.kubernetes.kubeconfig or KUBECONFIG, or run the detector in a pod with a service account.")
+}
+
+func defaultKubeconfigPath() string {
+	for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		path := filepath.Join(home, ".kube", "config")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string       `yaml:"name"`
+		User kubeUserInfo `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+type kubeUserInfo struct {
+	Token                 string      `yaml:"token"`
+	TokenFile             string      `yaml:"tokenFile"`
+	ClientCertificate     string      `yaml:"client-certificate"`
+	ClientCertificateData string      `yaml:"client-certificate-data"`
+	ClientKey             string      `yaml:"client-key"`
+	ClientKeyData         string      `yaml:"client-key-data"`
+	Exec                  *execConfig `yaml:"exec"`
+}
+
+// execConfig runs a credential plugin, such as `aws eks get-token` or
+// gke-gcloud-auth-plugin, that prints an ExecCredential.
+type execConfig struct {
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+	Env     []struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"env"`
+}
+
+func loadKubeconfig(path, contextName string) (*RESTConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, fmt.Sprintf("failed to read kubeconfig %s", path), "Check platform.kubernetes.kubeconfig.")
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(raw, &kc); err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, fmt.Sprintf("failed to parse kubeconfig %s", path), "Check that the file is a valid kubeconfig.")
+	}
+	dir := filepath.Dir(path)
+
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+	ctxIdx := -1
+	for i, c := range kc.Contexts {
+		if c.Name == contextName {
+			ctxIdx = i
+		}
+	}
+	if ctxIdx < 0 {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, fmt.Sprintf("context '%s' not found in kubeconfig %s", contextName, path), "Set platform.kubernetes.context to one of the contexts of the kubeconfig.")
+	}
+	kctx := kc.Contexts[ctxIdx].Context
+
+	cfg := &RESTConfig{Cluster: contextName, Namespace: kctx.Namespace, TLS: &tls.Config{MinVersion: tls.VersionTLS12}}
+	clusterFound := false
+	for _, c := range kc.Clusters {
+		if c.Name != kctx.Cluster {
+			continue
+		}
+		clusterFound = true
+		cfg.Server = strings.TrimRight(c.Cluster.Server, "/")
+		cfg.TLS.ServerName = c.Cluster.TLSServerName
+		cfg.TLS.InsecureSkipVerify = c.Cluster.InsecureSkipTLSVerify
+		ca, err := dataOrFile(c.Cluster.CertificateAuthorityData, c.Cluster.CertificateAuthority, dir)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("failed to read the CA of cluster '%s'", c.Name))
+		}
+		if len(ca) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("invalid CA certificate for cluster '%s'", c.Name))
+			}
+			cfg.TLS.RootCAs = pool
+		}
+	}
+	if !clusterFound || cfg.Server == "" {
+		return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("cluster '%s' of context '%s' not found or has no server", kctx.Cluster, contextName))
+	}
+
+	for _, u := range kc.Users {
+		if u.Name == kctx.User {
+			if err := applyUser(cfg, u.User, dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func applyUser(cfg *RESTConfig, user kubeUserInfo, dir string) error {
+	cert, err := dataOrFile(user.ClientCertificateData, user.ClientCertificate, dir)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeConfigValidation, "failed to read the kubeconfig client certificate")
+	}
+	key, err := dataOrFile(user.ClientKeyData, user.ClientKey, dir)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeConfigValidation, "failed to read the kubeconfig client key")
+	}
+	if len(cert) > 0 {
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeConfigValidation, "invalid kubeconfig client certificate or key")
+		}
+		cfg.TLS.Certificates = []tls.Certificate{pair}
+	}
+
+	switch {
+	case user.Token != "":
+		cfg.Tokens = restapi.StaticTokenSource(user.Token)
+	case user.TokenFile != "":
+		cfg.Tokens = restapi.NewCachingTokenSource(&fileTokenSource{path: resolvePath(user.TokenFile, dir)}, tokenExpiryMargin)
+	case user.Exec != nil:
+		cfg.Tokens = restapi.NewCachingTokenSource(&execTokenSource{config: *user.Exec, server: cfg.Server}, tokenExpiryMargin)
+	}
+	return nil
+}
+
+func inClusterConfig(host, port string) (*RESTConfig, error) {
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodePlatformAuthError, "failed to read the in-cluster CA bundle")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New(errors.CodePlatformAuthError, "invalid in-cluster CA bundle")
+	}
+	namespace, _ := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if port == "" {
+		port = "443"
+	}
+	return &RESTConfig{
+		Server:    "https://" + net.JoinHostPort(host, port),
+		Cluster:   "in-cluster",
+		Namespace: strings.TrimSpace(string(namespace)),
+		TLS:       &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool},
+		Tokens:    restapi.NewCachingTokenSource(&fileTokenSource{path: filepath.Join(serviceAccountDir, "token")}, tokenExpiryMargin),
+	}, nil
+}
+
+// dataOrFile returns base64 encoded inline data, or else the content of a file
+// relative to the kubeconfig directory.
+func dataOrFile(data, path, dir string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(resolvePath(path, dir))
+}
+
+func resolvePath(path, dir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// fileTokenSource reads a bearer token from a file, such as a projected
+// service account token.
+type fileTokenSource struct {
+	path string
+}
+
+func (s *fileTokenSource) Token(context.Context) (*restapi.Token, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodePlatformAuthError, fmt.Sprintf("failed to read Kubernetes token file %s", s.path))
+	}
+	return &restapi.Token{AccessToken: strings.TrimSpace(string(raw)), Expiry: time.Now().Add(tokenFileRefresh)}, nil
+}
+
+// execTokenSource runs a kubeconfig credential plugin and reads the token of
+// the ExecCredential it prints.
+type execTokenSource struct {
+	config execConfig
+	server string
+}
+
+func (s *execTokenSource) Token(ctx context.Context) (*restapi.Token, error) {
+	cmd := exec.CommandContext(ctx, s.config.Command, s.config.Args...)
+	cmd.Env = os.Environ()
+	for _, e := range s.config.Env {
+		cmd.Env = append(cmd.Env, e.Name+"="+e.Value)
+	}
+	info, _ := json.Marshal(map[string]any{
+		"apiVersion": execCredentialAPIVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]any{"interactive": false, "cluster": map[string]any{"server": s.server}},
+	})
+	cmd.Env = append(cmd.Env, "KUBERNETES_EXEC_INFO="+string(info))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodePlatformAuthError,
+			fmt.Sprintf("kubeconfig credential plugin '%s' failed: %s", s.config.Command, strings.TrimSpace(stderr.String())),
+			"Check that the credential plugin is installed and logged in.")
+	}
+	var cred struct {
+		Status struct {
+			Token               string    `json:"token"`
+			ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(out, &cred); err != nil || cred.Status.Token == "" {
+		return nil, errors.New(errors.CodePlatformAuthError, fmt.Sprintf("kubeconfig credential plugin '%s' did not print an ExecCredential with a token", s.config.Command))
+	}
+	return &restapi.Token{AccessToken: cred.Status.Token, Expiry: cred.Status.ExpirationTimestamp}, nil
+}
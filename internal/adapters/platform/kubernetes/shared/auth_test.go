@@ -0,0 +1,151 @@
+package shared
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// clearKubernetesEnv keeps the tests from picking up the kubeconfig or
+// in-cluster environment of the machine running them.
+func clearKubernetesEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("KUBECONFIG", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("HOME", t.TempDir())
+}
+
+func newTLSServer(t *testing.T, wantToken string) (*httptest.Server, string) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+wantToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","reason":"Unauthorized","message":"Unauthorized","code":401}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	return srv, base64.StdEncoding.EncodeToString(ca)
+}
+
+func writeKubeconfig(t *testing.T, server, caData, user string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	content := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev-cluster
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: dev
+  context: {cluster: dev-cluster, user: dev-user, namespace: shop}
+- name: other
+  context: {cluster: missing, user: dev-user}
+users:
+- name: dev-user
+  user:
+%s
+`, server, caData, user)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig_KubeconfigToken(t *testing.T) {
+	clearKubernetesEnv(t)
+	srv, ca := newTLSServer(t, "dev-token")
+	path := writeKubeconfig(t, srv.URL, ca, "    token: dev-token")
+
+	cfg, source, err := LoadConfig(ConfigOptions{Kubeconfig: path})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("kubeconfig %s (context dev)", path), source)
+	assert.Equal(t, "dev", cfg.Cluster)
+	assert.Equal(t, "shop", cfg.Namespace)
+
+	require.NoError(t, NewClient(cfg).GetJSON(context.Background(), "/api/v1/configmaps", &struct{}{}),
+		"the client trusts the cluster CA and sends the token")
+}
+
+func TestLoadConfig_KUBECONFIGEnv(t *testing.T) {
+	clearKubernetesEnv(t)
+	srv, ca := newTLSServer(t, "dev-token")
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing")+string(os.PathListSeparator)+writeKubeconfig(t, srv.URL, ca, "    token: dev-token"))
+
+	cfg, _, err := LoadConfig(ConfigOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL, cfg.Server)
+}
+
+func TestLoadConfig_ExecPlugin(t *testing.T) {
+	clearKubernetesEnv(t)
+	srv, ca := newTLSServer(t, "exec-token")
+	plugin := filepath.Join(t.TempDir(), "get-token")
+	require.NoError(t, os.WriteFile(plugin, []byte(`#!/bin/sh
+echo '{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","status":{"token":"exec-token","expirationTimestamp":"2099-01-01T00:00:00Z"}}'
+`), 0o700))
+	path := writeKubeconfig(t, srv.URL, ca, "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: "+plugin)
+
+	cfg, _, err := LoadConfig(ConfigOptions{Kubeconfig: path})
+	require.NoError(t, err)
+	require.NoError(t, NewClient(cfg).GetJSON(context.Background(), "/api/v1/configmaps", &struct{}{}))
+}
+
+func TestLoadConfig_InvalidContext(t *testing.T) {
+	clearKubernetesEnv(t)
+	path := writeKubeconfig(t, "https://example.invalid", "", "    token: t")
+
+	_, _, err := LoadConfig(ConfigOptions{Kubeconfig: path, Context: "prod"})
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+
+	_, _, err = LoadConfig(ConfigOptions{Kubeconfig: path, Context: "other"})
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation), "the cluster of the context must exist")
+}
+
+func TestLoadConfig_InCluster(t *testing.T) {
+	clearKubernetesEnv(t)
+	srv, ca := newTLSServer(t, "sa-token")
+	caPEM, err := base64.StdEncoding.DecodeString(ca)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), caPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("sa-token\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "namespace"), []byte("drift"), 0o600))
+	original := serviceAccountDir
+	serviceAccountDir = dir
+	t.Cleanup(func() { serviceAccountDir = original })
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	t.Setenv("KUBERNETES_SERVICE_HOST", u.Hostname())
+	t.Setenv("KUBERNETES_SERVICE_PORT", u.Port())
+
+	cfg, source, err := LoadConfig(ConfigOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "in-cluster service account", source)
+	assert.Equal(t, "drift", cfg.Namespace)
+	require.NoError(t, NewClient(cfg).GetJSON(context.Background(), "/api/v1/configmaps", &struct{}{}))
+}
+
+func TestLoadConfig_NoCredentials(t *testing.T) {
+	clearKubernetesEnv(t)
+
+	_, _, err := LoadConfig(ConfigOptions{})
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+}
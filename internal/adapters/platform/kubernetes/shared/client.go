@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// APIError is a Status response of the Kubernetes API server.
+type APIError struct {
+	StatusCode int
+	// Reason is the Status reason, e.g. Forbidden or NotFound.
+	Reason  string
+	Message string
+	// RetryAfter is the delay the Retry-After header asks for, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("kubernetes: %d %s: %s", e.StatusCode, e.Reason, e.Message)
+	}
+	return fmt.Sprintf("kubernetes: %d: %s", e.StatusCode, e.Message)
+}
+
+// Code classifies the error into an application error code.
+func (e *APIError) Code() errors.Code {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.CodePlatformAuthError
+	case http.StatusNotFound:
+		return errors.CodeResourceNotFound
+	case http.StatusTooManyRequests:
+		return errors.CodePlatformThrottled
+	default:
+		return errors.CodePlatformAPIError
+	}
+}
+
+// Retryable reports throttled and server errors as retryable, after the
+// Retry-After delay if the response had one.
+func (e *APIError) Retryable() (bool, time.Duration) {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError, e.RetryAfter
+}
+
+func decodeAPIError(resp *http.Response, body []byte) error {
+	var status struct {
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode, RetryAfter: restapi.RetryAfter(resp)}
+	if err := json.Unmarshal(body, &status); err != nil || status.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+	apiErr.Reason = status.Reason
+	apiErr.Message = status.Message
+	return apiErr
+}
+
+// NewClient creates a client for the read-only Kubernetes API calls the
+// detector makes. It resolves API paths against the server of cfg,
+// authenticates with its TLS settings and bearer tokens, and returns Status
+// responses as *APIError. A restapi.WithHTTPClient option replaces the HTTP
+// client built from the TLS settings.
+func NewClient(cfg *RESTConfig, opts ...restapi.Option) *restapi.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg.TLS
+	opts = append([]restapi.Option{
+		restapi.WithBaseURL(cfg.Server),
+		restapi.WithHTTPClient(&http.Client{Timeout: defaultHTTPTimeout, Transport: transport}),
+	}, opts...)
+	return restapi.NewClient(cfg.Tokens, decodeAPIError, opts...)
+}
+
+func HandleError(ctx context.Context, resourceType, resourceID string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil || err == context.Canceled || err == context.DeadlineExceeded {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("context canceled during Kubernetes %s API call", resourceType))
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return errors.Wrap(err, errors.CodePlatformAPIError, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+	switch code := apiErr.Code(); code {
+	case errors.CodePlatformAuthError:
+		return errors.Wrap(err, code, fmt.Sprintf("Kubernetes authorization error accessing %s %s", resourceType, resourceID))
+	case errors.CodePlatformThrottled:
+		return errors.Wrap(err, code, fmt.Sprintf("Kubernetes API server throttled requests for %s '%s'", resourceType, resourceID))
+	case errors.CodeResourceNotFound:
+		return errors.Wrap(err, code, fmt.Sprintf("%s '%s' not found", resourceType, resourceID))
+	default:
+		return errors.Wrap(err, code, fmt.Sprintf("failed to access %s '%s'", resourceType, resourceID))
+	}
+}
@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/restapi"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestClient(server string) *restapi.Client {
+	return NewClient(&RESTConfig{Server: server, Tokens: restapi.StaticTokenSource("test-token")},
+		restapi.WithRetryBaseDelay(time.Millisecond), restapi.WithRequestsPerSecond(1000))
+}
+
+func TestClient_APIErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     int
+		body       string
+		wantCode   errors.Code
+		wantReason string
+	}{
+		{"forbidden", http.StatusForbidden, `{"kind":"Status","reason":"Forbidden","message":"deployments.apps is forbidden"}`, errors.CodePlatformAuthError, "Forbidden"},
+		{"not found", http.StatusNotFound, `{"kind":"Status","reason":"NotFound","message":"deployments.apps \"web\" not found"}`, errors.CodeResourceNotFound, "NotFound"},
+		{"bad request", http.StatusBadRequest, `not json`, errors.CodePlatformAPIError, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			err := newTestClient(srv.URL).GetJSON(context.Background(), "/apis/apps/v1/deployments", &struct{}{})
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			assert.Equal(t, tc.wantReason, apiErr.Reason)
+			assert.Equal(t, tc.wantCode, apiErr.Code())
+			assert.True(t, errors.Is(HandleError(context.Background(), "Deployment", "default/web", err), tc.wantCode))
+		})
+	}
+}
+
+func TestClient_RetriesThrottledAndServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	require.NoError(t, newTestClient(srv.URL).GetJSON(context.Background(), "/api/v1/services", &struct{}{}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	require.Error(t, newTestClient(srv.URL).GetJSON(context.Background(), "/api/v1/services", &struct{}{}))
+	assert.Equal(t, int32(1), calls.Load())
+}
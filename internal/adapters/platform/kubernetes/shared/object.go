@@ -0,0 +1,328 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Object is the part of a Kubernetes object the detector uses. Objects read
+// from the API and from manifests are decoded into it and mapped to attributes
+// by the same code, so defaults the API server applies are applied to both.
+type Object struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Spec       json.RawMessage   `json:"spec"`
+	Data       map[string]string `json:"data"`
+	BinaryData map[string]string `json:"binaryData"`
+	// Items holds the objects of a List.
+	Items []json.RawMessage `json:"items"`
+}
+
+type ObjectMeta struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels"`
+}
+
+// ResourceKind returns the domain kind of an object, or false for objects the
+// detector does not compare.
+func (o Object) ResourceKind() (domain.ResourceKind, bool) {
+	group, _, hasGroup := strings.Cut(o.APIVersion, "/")
+	switch {
+	case o.Kind == "Deployment" && hasGroup && group == "apps":
+		return domain.KindKubernetesDeployment, true
+	case o.Kind == "Service" && o.APIVersion == "v1":
+		return domain.KindKubernetesService, true
+	case o.Kind == "ConfigMap" && o.APIVersion == "v1":
+		return domain.KindKubernetesConfigMap, true
+	default:
+		return "", false
+	}
+}
+
+// Key returns the "<namespace>/<name>" key of the object, placing it in
+// defaultNamespace when it does not set a namespace.
+func (o Object) Key(defaultNamespace string) string {
+	return ObjectKey(o.namespace(defaultNamespace), o.Metadata.Name)
+}
+
+func (o Object) namespace(defaultNamespace string) string {
+	if o.Metadata.Namespace != "" {
+		return o.Metadata.Namespace
+	}
+	return defaultNamespace
+}
+
+type deploymentSpec struct {
+	Replicas *int `json:"replicas"`
+	Selector struct {
+		MatchLabels map[string]string `json:"matchLabels"`
+	} `json:"selector"`
+	Strategy struct {
+		Type string `json:"type"`
+	} `json:"strategy"`
+	Template struct {
+		Spec struct {
+			ServiceAccountName string      `json:"serviceAccountName"`
+			Containers         []container `json:"containers"`
+		} `json:"spec"`
+	} `json:"template"`
+}
+
+type container struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Ports []struct {
+		ContainerPort int    `json:"containerPort"`
+		Protocol      string `json:"protocol"`
+	} `json:"ports"`
+	Env []struct {
+		Name      string          `json:"name"`
+		Value     string          `json:"value"`
+		ValueFrom json.RawMessage `json:"valueFrom"`
+	} `json:"env"`
+	Resources struct {
+		Limits   map[string]quantity `json:"limits"`
+		Requests map[string]quantity `json:"requests"`
+	} `json:"resources"`
+}
+
+type serviceSpec struct {
+	Type     string            `json:"type"`
+	Selector map[string]string `json:"selector"`
+	Ports    []struct {
+		Name       string      `json:"name"`
+		Port       int         `json:"port"`
+		TargetPort intOrString `json:"targetPort"`
+		Protocol   string      `json:"protocol"`
+	} `json:"ports"`
+}
+
+// intOrString holds a port given as a number or a name.
+type intOrString struct {
+	value any
+}
+
+func (v *intOrString) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v.value = s
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	v.value = n
+	return nil
+}
+
+// quantity is a resource quantity, which manifests may write as a number.
+type quantity string
+
+func (q *quantity) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*q = quantity(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*q = quantity(n.String())
+	return nil
+}
+
+// Attributes maps an object of a compared kind to its domain attributes.
+// defaultNamespace is used for objects that do not set a namespace.
+func (o Object) Attributes(defaultNamespace string) (map[string]any, error) {
+	kind, ok := o.ResourceKind()
+	if !ok {
+		return nil, errors.New(errors.CodeMappingError, fmt.Sprintf("unsupported Kubernetes object %s %s", o.APIVersion, o.Kind))
+	}
+	if o.Metadata.Name == "" {
+		return nil, errors.New(errors.CodeMappingError, fmt.Sprintf("Kubernetes %s has no metadata.name", o.Kind))
+	}
+	namespace := o.namespace(defaultNamespace)
+	attrs := map[string]any{
+		domain.KeyID:                  ObjectKey(namespace, o.Metadata.Name),
+		domain.KeyName:                o.Metadata.Name,
+		domain.KubernetesNamespaceKey: namespace,
+		domain.KeyTags:                copyMap(o.Metadata.Labels),
+	}
+
+	var err error
+	switch kind {
+	case domain.KindKubernetesDeployment:
+		err = o.mapDeployment(attrs)
+	case domain.KindKubernetesService:
+		err = o.mapService(attrs)
+	case domain.KindKubernetesConfigMap:
+		attrs[domain.KubernetesDataKey] = copyMap(o.Data)
+		if len(o.BinaryData) > 0 {
+			attrs[domain.KubernetesBinaryDataKey] = copyMap(o.BinaryData)
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to decode spec of Kubernetes %s %s", o.Kind, attrs[domain.KeyID]))
+	}
+	return attrs, nil
+}
+
+func (o Object) mapDeployment(attrs map[string]any) error {
+	var spec deploymentSpec
+	if err := decodeSpec(o.Spec, &spec); err != nil {
+		return err
+	}
+	replicas := 1
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+	strategy := spec.Strategy.Type
+	if strategy == "" {
+		strategy = "RollingUpdate"
+	}
+	attrs[domain.KubernetesReplicasKey] = replicas
+	attrs[domain.KubernetesStrategyKey] = strategy
+	attrs[domain.KubernetesSelectorKey] = copyMap(spec.Selector.MatchLabels)
+	if sa := spec.Template.Spec.ServiceAccountName; sa != "" {
+		attrs[domain.KubernetesServiceAccountKey] = sa
+	}
+
+	containers := make([]map[string]any, 0, len(spec.Template.Spec.Containers))
+	for _, c := range spec.Template.Spec.Containers {
+		containers = append(containers, mapContainer(c))
+	}
+	attrs[domain.KubernetesContainersKey] = containers
+	return nil
+}
+
+func mapContainer(c container) map[string]any {
+	out := map[string]any{"name": c.Name, "image": c.Image}
+	if len(c.Ports) > 0 {
+		ports := make([]map[string]any, 0, len(c.Ports))
+		for _, p := range c.Ports {
+			ports = append(ports, map[string]any{"container_port": p.ContainerPort, "protocol": defaultProtocol(p.Protocol)})
+		}
+		out["ports"] = ports
+	}
+	// Values taken from config maps, secrets or fields are not known from the
+	// manifest, so only plain values are compared.
+	env := make(map[string]string)
+	for _, e := range c.Env {
+		if len(e.ValueFrom) == 0 {
+			env[e.Name] = e.Value
+		}
+	}
+	if len(env) > 0 {
+		out["env"] = env
+	}
+	resources := make(map[string]any)
+	if len(c.Resources.Limits) > 0 {
+		resources["limits"] = canonicalQuantities(c.Resources.Limits)
+	}
+	if len(c.Resources.Requests) > 0 {
+		resources["requests"] = canonicalQuantities(c.Resources.Requests)
+	}
+	if len(resources) > 0 {
+		out["resources"] = resources
+	}
+	return out
+}
+
+func (o Object) mapService(attrs map[string]any) error {
+	var spec serviceSpec
+	if err := decodeSpec(o.Spec, &spec); err != nil {
+		return err
+	}
+	serviceType := spec.Type
+	if serviceType == "" {
+		serviceType = "ClusterIP"
+	}
+	attrs[domain.KubernetesServiceTypeKey] = serviceType
+	attrs[domain.KubernetesSelectorKey] = copyMap(spec.Selector)
+
+	// Cluster IPs and node ports are assigned by the cluster and not compared.
+	ports := make([]map[string]any, 0, len(spec.Ports))
+	for _, p := range spec.Ports {
+		target := p.TargetPort.value
+		if target == nil {
+			target = p.Port
+		}
+		ports = append(ports, map[string]any{
+			"name":        p.Name,
+			"port":        p.Port,
+			"target_port": target,
+			"protocol":    defaultProtocol(p.Protocol),
+		})
+	}
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i]["port"] != ports[j]["port"] {
+			return ports[i]["port"].(int) < ports[j]["port"].(int)
+		}
+		return ports[i]["protocol"].(string) < ports[j]["protocol"].(string)
+	})
+	attrs[domain.KubernetesPortsKey] = ports
+	return nil
+}
+
+func decodeSpec(raw json.RawMessage, out any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func defaultProtocol(protocol string) string {
+	if protocol == "" {
+		return "TCP"
+	}
+	return protocol
+}
+
+func canonicalQuantities(in map[string]quantity) map[string]string {
+	out := make(map[string]string, len(in))
+	for name, q := range in {
+		out[name] = canonicalQuantity(string(q))
+	}
+	return out
+}
+
+// canonicalQuantity rewrites decimal quantities the way the API server stores
+// them, e.g. "0.5" as "500m" and "1000m" as "1". Quantities with binary or
+// decimal suffixes other than "m" are returned as is.
+func canonicalQuantity(q string) string {
+	millis, ok := parseMillis(q)
+	if !ok {
+		return q
+	}
+	if millis%1000 == 0 {
+		return strconv.FormatInt(millis/1000, 10)
+	}
+	return strconv.FormatInt(millis, 10) + "m"
+}
+
+func parseMillis(q string) (int64, bool) {
+	if value, ok := strings.CutSuffix(q, "m"); ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		return n, err == nil
+	}
+	whole, frac, _ := strings.Cut(q, ".")
+	if len(frac) > 3 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(whole+frac+strings.Repeat("0", 3-len(frac)), 10, 64)
+	return n, err == nil && whole != ""
+}
@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func decodeObject(t *testing.T, raw string) Object {
+	t.Helper()
+	var obj Object
+	require.NoError(t, json.Unmarshal([]byte(raw), &obj))
+	return obj
+}
+
+func TestObject_DeploymentAttributes(t *testing.T) {
+	obj := decodeObject(t, `{
+		"apiVersion": "apps/v1", "kind": "Deployment",
+		"metadata": {"name": "web", "labels": {"app": "web"}},
+		"spec": {
+			"selector": {"matchLabels": {"app": "web"}},
+			"template": {"spec": {"containers": [{
+				"name": "web", "image": "nginx:1.27",
+				"ports": [{"containerPort": 80}],
+				"env": [{"name": "MODE", "value": "prod"}, {"name": "SECRET", "valueFrom": {"secretKeyRef": {"name": "s", "key": "k"}}}],
+				"resources": {"limits": {"cpu": 1, "memory": "256Mi"}, "requests": {"cpu": "0.25"}}
+			}]}}
+		}
+	}`)
+
+	kind, ok := obj.ResourceKind()
+	require.True(t, ok)
+	assert.Equal(t, domain.KindKubernetesDeployment, kind)
+
+	attrs, err := obj.Attributes("apps")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		domain.KeyID:                  "apps/web",
+		domain.KeyName:                "web",
+		domain.KubernetesNamespaceKey: "apps",
+		domain.KeyTags:                map[string]string{"app": "web"},
+		domain.KubernetesReplicasKey:  1,
+		domain.KubernetesStrategyKey:  "RollingUpdate",
+		domain.KubernetesSelectorKey:  map[string]string{"app": "web"},
+		domain.KubernetesContainersKey: []map[string]any{{
+			"name":  "web",
+			"image": "nginx:1.27",
+			"ports": []map[string]any{{"container_port": 80, "protocol": "TCP"}},
+			"env":   map[string]string{"MODE": "prod"},
+			"resources": map[string]any{
+				"limits":   map[string]string{"cpu": "1", "memory": "256Mi"},
+				"requests": map[string]string{"cpu": "250m"},
+			},
+		}},
+	}, attrs)
+}
+
+func TestObject_ServiceAttributes(t *testing.T) {
+	obj := decodeObject(t, `{
+		"apiVersion": "v1", "kind": "Service",
+		"metadata": {"name": "web", "namespace": "shop"},
+		"spec": {
+			"type": "NodePort", "clusterIP": "10.0.0.12",
+			"selector": {"app": "web"},
+			"ports": [{"name": "https", "port": 443, "targetPort": "https", "nodePort": 30443}, {"name": "http", "port": 80}]
+		}
+	}`)
+
+	attrs, err := obj.Attributes(DefaultNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, "shop/web", attrs[domain.KeyID])
+	assert.Equal(t, "NodePort", attrs[domain.KubernetesServiceTypeKey])
+	assert.Equal(t, []map[string]any{
+		{"name": "http", "port": 80, "target_port": 80, "protocol": "TCP"},
+		{"name": "https", "port": 443, "target_port": "https", "protocol": "TCP"},
+	}, attrs[domain.KubernetesPortsKey])
+}
+
+func TestObject_ConfigMapAttributes(t *testing.T) {
+	obj := decodeObject(t, `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "settings"}, "data": {"LOG_LEVEL": "info"}}`)
+
+	attrs, err := obj.Attributes(DefaultNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info"}, attrs[domain.KubernetesDataKey])
+	assert.NotContains(t, attrs, domain.KubernetesBinaryDataKey)
+}
+
+func TestObject_UnsupportedKinds(t *testing.T) {
+	for _, raw := range []string{
+		`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s"}}`,
+		`{"apiVersion": "example.com/v1", "kind": "Service", "metadata": {"name": "s"}}`,
+	} {
+		obj := decodeObject(t, raw)
+		_, ok := obj.ResourceKind()
+		assert.False(t, ok, raw)
+		_, err := obj.Attributes(DefaultNamespace)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestCanonicalQuantity(t *testing.T) {
+	testCases := map[string]string{
+		"1":     "1",
+		"1000m": "1",
+		"0.5":   "500m",
+		"1.5":   "1500m",
+		"250m":  "250m",
+		"256Mi": "256Mi",
+		"1e3":   "1e3",
+		"0.001": "1m",
+	}
+	for in, want := range testCases {
+		assert.Equal(t, want, canonicalQuantity(in), in)
+	}
+}
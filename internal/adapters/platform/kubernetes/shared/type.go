@@ -0,0 +1,21 @@
+package shared
+
+const ProviderTypeKubernetes = "kubernetes"
+
+// DefaultNamespace is the namespace of namespaced objects that do not set one.
+const DefaultNamespace = "default"
+
+// ObjectKey returns the "<namespace>/<name>" key that identifies an object of
+// one kind in a cluster, both in manifests and on the platform.
+func ObjectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// copyMap returns a copy of a string map, such as labels, never nil.
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
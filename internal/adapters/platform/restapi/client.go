@@ -0,0 +1,186 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRequestsPerSecond = 20
+	defaultMaxAttempts       = 4
+	defaultBaseDelay         = 500 * time.Millisecond
+	defaultMaxRetryDelay     = 30 * time.Second
+)
+
+// ErrorDecoder turns an error response into the API error of a provider.
+type ErrorDecoder func(resp *http.Response, body []byte) error
+
+// RetryableError is implemented by the API errors of a provider so the client
+// can tell which error responses to retry.
+type RetryableError interface {
+	error
+	// Retryable reports whether the request may be retried and how long the
+	// server asked to wait first, or zero to back off exponentially.
+	Retryable() (bool, time.Duration)
+}
+
+// RetryAfter returns the delay the Retry-After header of resp asks for, or
+// zero when it has none in seconds.
+func RetryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// Client is a minimal client for read-only JSON REST APIs. It authenticates
+// requests with a token source, rate limits them and retries throttled and
+// server errors, waiting as long as the server asks or with jittered
+// exponential backoff.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	tokens      TokenSource
+	decodeError ErrorDecoder
+	limiter     *rate.Limiter
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// Option defines a function signature for configuring the Client.
+type Option func(*Client)
+
+// WithHTTPClient provides an option to set a custom HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL provides an option to resolve the paths given to GetJSON
+// against a server, e.g. a Kubernetes API server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithRequestsPerSecond provides an option to set the request rate limit.
+func WithRequestsPerSecond(rps int) Option {
+	return func(c *Client) {
+		if rps > 0 {
+			c.limiter = rate.NewLimiter(rate.Limit(rps), rps)
+		}
+	}
+}
+
+// WithRetryBaseDelay provides an option to set the first retry delay, which
+// doubles with every attempt.
+func WithRetryBaseDelay(delay time.Duration) Option {
+	return func(c *Client) {
+		if delay > 0 {
+			c.baseDelay = delay
+		}
+	}
+}
+
+// WithMaxRetryDelay provides an option to bound the delay between attempts,
+// including the delays servers ask for.
+func WithMaxRetryDelay(delay time.Duration) Option {
+	return func(c *Client) {
+		if delay > 0 {
+			c.maxDelay = delay
+		}
+	}
+}
+
+// NewClient creates a client sending the tokens of tokens, or no
+// Authorization header when tokens is nil, and decoding error responses with
+// decodeError.
+func NewClient(tokens TokenSource, decodeError ErrorDecoder, opts ...Option) *Client {
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		tokens:      tokens,
+		decodeError: decodeError,
+		limiter:     rate.NewLimiter(defaultRequestsPerSecond, defaultRequestsPerSecond),
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDelay:    defaultMaxRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetJSON fetches url, relative to the base URL if one is set, and decodes the
+// JSON response into out. Error responses are returned as decoded by the
+// client's ErrorDecoder.
+func (c *Client) GetJSON(ctx context.Context, url string, out any) error {
+	for attempt := 1; ; attempt++ {
+		err := c.getJSON(ctx, c.baseURL+url, out)
+		var apiErr RetryableError
+		if !stderrors.As(err, &apiErr) || attempt >= c.maxAttempts {
+			return err
+		}
+		retry, delay := apiErr.Retryable()
+		if !retry {
+			return err
+		}
+		if delay == 0 {
+			delay = c.baseDelay << (attempt - 1)
+			delay = delay/2 + rand.N(delay/2+1)
+		}
+		if delay > c.maxDelay {
+			delay = c.maxDelay
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, out any) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if c.tokens != nil {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return c.decodeError(resp, body)
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,157 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testError is the API error of a fake provider that retries server errors
+// after the Retry-After delay.
+type testError struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (e *testError) Error() string { return fmt.Sprintf("status %d", e.status) }
+
+func (e *testError) Retryable() (bool, time.Duration) {
+	return e.status >= http.StatusInternalServerError, e.retryAfter
+}
+
+func decodeTestError(resp *http.Response, _ []byte) error {
+	return &testError{status: resp.StatusCode, retryAfter: RetryAfter(resp)}
+}
+
+func newTestClient(tokens TokenSource, opts ...Option) *Client {
+	opts = append([]Option{WithRetryBaseDelay(time.Millisecond), WithRequestsPerSecond(1000)}, opts...)
+	return NewClient(tokens, decodeTestError, opts...)
+}
+
+func TestClient_GetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/items/web-1", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		_, _ = w.Write([]byte(`{"name":"web-1"}`))
+	}))
+	defer srv.Close()
+
+	var out struct{ Name string }
+	client := newTestClient(StaticTokenSource("test-token"), WithBaseURL(srv.URL))
+	require.NoError(t, client.GetJSON(context.Background(), "/v1/items/web-1", &out))
+	assert.Equal(t, "web-1", out.Name)
+}
+
+func TestClient_WithoutTokenSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, newTestClient(nil).GetJSON(context.Background(), srv.URL, &struct{}{}))
+}
+
+func TestClient_RetriesRetryableErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, newTestClient(nil).GetJSON(context.Background(), srv.URL, &struct{}{}))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := newTestClient(nil).GetJSON(context.Background(), srv.URL, &struct{}{})
+
+	var apiErr *testError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.status)
+	assert.Equal(t, int32(defaultMaxAttempts), calls.Load())
+}
+
+func TestClient_DoesNotRetryOtherErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	require.Error(t, newTestClient(nil).GetJSON(context.Background(), srv.URL, &struct{}{}))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_WaitsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := newTestClient(nil).GetJSON(ctx, srv.URL, &struct{}{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the client waits out Retry-After instead of its own backoff")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_CapsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(nil, WithMaxRetryDelay(time.Millisecond))
+	require.NoError(t, client.GetJSON(context.Background(), srv.URL, &struct{}{}))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"0", 0},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("Retry-After", tc.header)
+			assert.Equal(t, tc.want, RetryAfter(resp))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package restapi
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// GetResources fetches the resources with the given IDs through get, at most
+// concurrency at a time, for APIs without a batch lookup. IDs that do not
+// exist are left out of the result; any other error fails the lookup.
+func GetResources(
+	ctx context.Context,
+	ids []string,
+	concurrency int,
+	get func(ctx context.Context, id string) (domain.PlatformResource, error),
+) (map[string]domain.PlatformResource, error) {
+	var mu sync.Mutex
+	resources := make(map[string]domain.PlatformResource, len(ids))
+	g, childCtx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for _, id := range ids {
+		g.Go(func() error {
+			resource, err := get(childCtx, id)
+			if err != nil {
+				if errors.Is(err, errors.CodeResourceNotFound) {
+					return nil
+				}
+				return err
+			}
+			mu.Lock()
+			resources[id] = resource
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
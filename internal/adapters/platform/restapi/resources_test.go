@@ -0,0 +1,49 @@
+package restapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type testResource string
+
+func (r testResource) Metadata() domain.ResourceMetadata {
+	return domain.ResourceMetadata{ProviderAssignedID: string(r)}
+}
+
+func (r testResource) Attributes(context.Context) (map[string]any, error) { return nil, nil }
+
+func TestGetResources_SkipsMissingResources(t *testing.T) {
+	get := func(_ context.Context, id string) (domain.PlatformResource, error) {
+		if id == "gone" {
+			return nil, errors.New(errors.CodeResourceNotFound, "gone")
+		}
+		return testResource(id), nil
+	}
+
+	resources, err := GetResources(context.Background(), []string{"web-1", "gone", "web-2"}, 2, get)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.PlatformResource{"web-1": testResource("web-1"), "web-2": testResource("web-2")}, resources)
+}
+
+func TestGetResources_FailsOnOtherErrors(t *testing.T) {
+	get := func(_ context.Context, id string) (domain.PlatformResource, error) {
+		if id == "denied" {
+			return nil, errors.New(errors.CodePlatformAuthError, "denied")
+		}
+		return testResource(id), nil
+	}
+
+	resources, err := GetResources(context.Background(), []string{"web-1", "denied"}, 1, get)
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+	assert.Nil(t, resources)
+}
@@ -0,0 +1,65 @@
+// Package restapi holds the pieces shared by the platform providers that read
+// a REST API without a vendor SDK: bearer token caching, a rate limited JSON
+// client that retries throttled and server errors, and the concurrent lookup
+// of resources by ID.
+package restapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Token is a bearer token.
+type Token struct {
+	AccessToken string
+	// Expiry is when the token expires, or zero when it is not known.
+	Expiry time.Time
+}
+
+// valid reports whether the token can still be used for a request sent now,
+// leaving margin before it expires.
+func (t *Token) valid(now time.Time, margin time.Duration) bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || now.Add(margin).Before(t.Expiry))
+}
+
+// TokenSource supplies the bearer tokens sent with API requests.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// StaticTokenSource returns the same bearer token for every request.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token(context.Context) (*Token, error) {
+	return &Token{AccessToken: string(s)}, nil
+}
+
+// CachingTokenSource reuses a token until shortly before it expires.
+type CachingTokenSource struct {
+	mu     sync.Mutex
+	src    TokenSource
+	margin time.Duration
+	token  *Token
+	now    func() time.Time
+}
+
+// NewCachingTokenSource caches the tokens of src, refreshing a token margin
+// before its expiry so that it does not expire while a request is in flight.
+func NewCachingTokenSource(src TokenSource, margin time.Duration) *CachingTokenSource {
+	return &CachingTokenSource{src: src, margin: margin, now: time.Now}
+}
+
+func (c *CachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.valid(c.now(), c.margin) {
+		return c.token, nil
+	}
+	token, err := c.src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	return token, nil
+}
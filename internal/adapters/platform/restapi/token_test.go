@@ -0,0 +1,45 @@
+package restapi
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tokenSourceFunc func(context.Context) (*Token, error)
+
+func (f tokenSourceFunc) Token(ctx context.Context) (*Token, error) { return f(ctx) }
+
+func TestCachingTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	var issued atomic.Int32
+	src := tokenSourceFunc(func(context.Context) (*Token, error) {
+		issued.Add(1)
+		return &Token{AccessToken: "t", Expiry: time.Unix(1000, 0)}, nil
+	})
+	ts := NewCachingTokenSource(src, time.Minute)
+
+	ts.now = func() time.Time { return time.Unix(900, 0) }
+	_, _ = ts.Token(context.Background())
+	_, _ = ts.Token(context.Background())
+	assert.Equal(t, int32(1), issued.Load())
+
+	ts.now = func() time.Time { return time.Unix(990, 0) }
+	_, _ = ts.Token(context.Background())
+	assert.Equal(t, int32(2), issued.Load(), "a token expiring within the margin is refreshed")
+}
+
+func TestCachingTokenSource_KeepsTokensWithoutExpiry(t *testing.T) {
+	var issued atomic.Int32
+	src := tokenSourceFunc(func(context.Context) (*Token, error) {
+		issued.Add(1)
+		return &Token{AccessToken: "t"}, nil
+	})
+	ts := NewCachingTokenSource(src, time.Minute)
+
+	_, _ = ts.Token(context.Background())
+	_, _ = ts.Token(context.Background())
+	assert.Equal(t, int32(1), issued.Load())
+}
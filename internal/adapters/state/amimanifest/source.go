@@ -0,0 +1,127 @@
+package amimanifest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const SourceTypeAMIManifest = "golden-ami-manifest"
+
+// DefaultRoleTag is the instance tag naming the role an instance plays.
+const DefaultRoleTag = "Role"
+
+// DefaultRole is the manifest role applied to instances whose role has no
+// entry of its own, or that have no role tag.
+const DefaultRole = "default"
+
+type Config struct {
+	// Path is the manifest file, in YAML or JSON.
+	Path string `yaml:"path" mapstructure:"path" validate:"required"`
+	// RoleTag is the instance tag whose value selects the manifest role.
+	RoleTag string `yaml:"role_tag" mapstructure:"role_tag"`
+}
+
+// Manifest lists the approved AMI IDs per region and role:
+//
+//	regions:
+//	  us-east-1:
+//	    default: [ami-0a1b2c3d]
+//	    web: [ami-0e4f5a6b, ami-0c7d8e9f]
+type Manifest struct {
+	Regions map[string]map[string][]string `yaml:"regions" json:"regions"`
+}
+
+// Source is an image approval source backed by a golden AMI manifest, such as
+// one published by an EC2 Image Builder pipeline. The manifest is read once, on
+// first use.
+type Source struct {
+	path    string
+	roleTag string
+	logger  ports.Logger
+
+	once     sync.Once
+	manifest *Manifest
+	loadErr  error
+}
+
+func NewSource(cfg Config, logger ports.Logger) (*Source, error) {
+	if cfg.Path == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, "golden AMI manifest path is required",
+			"Set 'golden_ami.path' to the manifest file.")
+	}
+	roleTag := cfg.RoleTag
+	if roleTag == "" {
+		roleTag = DefaultRoleTag
+	}
+	return &Source{
+		path:    cfg.Path,
+		roleTag: roleTag,
+		logger:  logger.WithFields(map[string]any{"source": SourceTypeAMIManifest, "manifest": cfg.Path}),
+	}, nil
+}
+
+func (s *Source) Type() string { return SourceTypeAMIManifest }
+
+// ApprovedImages returns the AMIs approved for an instance in region with the
+// given tags: those of its role, or of the default role when its role is not
+// listed. It returns false when the manifest lists neither for the region.
+func (s *Source) ApprovedImages(ctx context.Context, region string, tags map[string]string) ([]string, bool, error) {
+	if ctx.Err() != nil {
+		return nil, false, ctx.Err()
+	}
+	manifest, err := s.load()
+	if err != nil {
+		return nil, false, err
+	}
+	roles, ok := manifest.Regions[region]
+	if !ok {
+		return nil, false, nil
+	}
+	if role := tags[s.roleTag]; role != "" {
+		if images, ok := roles[role]; ok {
+			return images, true, nil
+		}
+	}
+	images, ok := roles[DefaultRole]
+	return images, ok, nil
+}
+
+func (s *Source) load() (*Manifest, error) {
+	s.once.Do(func() {
+		s.manifest, s.loadErr = readManifest(s.path)
+		if s.loadErr == nil {
+			s.logger.Debugf(context.Background(), "Loaded golden AMI manifest covering %d region(s)", len(s.manifest.Regions))
+		}
+	})
+	return s.manifest, s.loadErr
+}
+
+// readManifest parses a manifest file. JSON manifests are read by the YAML
+// decoder as well, since JSON is valid YAML.
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeStateReadError,
+			fmt.Sprintf("failed to read golden AMI manifest '%s'", path),
+			"Check that 'golden_ami.path' points to a readable file.")
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeStateParseError,
+			fmt.Sprintf("failed to parse golden AMI manifest '%s'", path),
+			"The manifest must map regions to roles to lists of AMI IDs under a top-level 'regions' key.")
+	}
+	if len(manifest.Regions) == 0 {
+		return nil, errors.NewUserFacing(errors.CodeStateParseError,
+			fmt.Sprintf("golden AMI manifest '%s' lists no regions", path),
+			"Add approved AMI IDs under the top-level 'regions' key.")
+	}
+	return &manifest, nil
+}
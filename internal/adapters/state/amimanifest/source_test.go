@@ -0,0 +1,110 @@
+package amimanifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const testManifest = `
+regions:
+  us-east-1:
+    default: [ami-base]
+    web: [ami-web-1, ami-web-2]
+  eu-west-1:
+    web: [ami-web-eu]
+`
+
+func newTestSource(t *testing.T, content string, cfg Config) *Source {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	cfg.Path = path
+
+	logger := new(portsmocks.Logger)
+	logger.On("WithFields", mock.Anything).Return(logger)
+	logger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	source, err := NewSource(cfg, logger)
+	require.NoError(t, err)
+	return source
+}
+
+func TestApprovedImages(t *testing.T) {
+	source := newTestSource(t, testManifest, Config{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		region      string
+		tags        map[string]string
+		wantImages  []string
+		wantCovered bool
+	}{
+		{"role listed", "us-east-1", map[string]string{"Role": "web"}, []string{"ami-web-1", "ami-web-2"}, true},
+		{"role falls back to default", "us-east-1", map[string]string{"Role": "batch"}, []string{"ami-base"}, true},
+		{"no role tag uses default", "us-east-1", nil, []string{"ami-base"}, true},
+		{"region without default", "eu-west-1", map[string]string{"Role": "db"}, nil, false},
+		{"region not listed", "ap-south-1", map[string]string{"Role": "web"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images, covered, err := source.ApprovedImages(ctx, tt.region, tt.tags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCovered, covered)
+			assert.Equal(t, tt.wantImages, images)
+		})
+	}
+}
+
+func TestApprovedImages_CustomRoleTag(t *testing.T) {
+	source := newTestSource(t, testManifest, Config{RoleTag: "tier"})
+
+	images, covered, err := source.ApprovedImages(context.Background(), "us-east-1", map[string]string{"tier": "web", "Role": "batch"})
+
+	require.NoError(t, err)
+	assert.True(t, covered)
+	assert.Equal(t, []string{"ami-web-1", "ami-web-2"}, images)
+}
+
+func TestApprovedImages_JSONManifest(t *testing.T) {
+	source := newTestSource(t, `{"regions":{"us-east-1":{"default":["ami-json"]}}}`, Config{})
+
+	images, covered, err := source.ApprovedImages(context.Background(), "us-east-1", nil)
+
+	require.NoError(t, err)
+	assert.True(t, covered)
+	assert.Equal(t, []string{"ami-json"}, images)
+}
+
+func TestApprovedImages_InvalidManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"malformed", "regions: [unclosed"},
+		{"no regions", "images: []"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newTestSource(t, tt.content, Config{})
+			_, _, err := source.ApprovedImages(context.Background(), "us-east-1", nil)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.CodeStateParseError))
+		})
+	}
+}
+
+func TestNewSource_RequiresPath(t *testing.T) {
+	_, err := NewSource(Config{}, new(portsmocks.Logger))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
@@ -0,0 +1,174 @@
+// Package decrypt reads state that is encrypted at rest: sops files, whose
+// values are encrypted with a data key held by age, AWS KMS or PGP keys, and
+// whole files encrypted with age or GPG. Decryption happens in memory only.
+package decrypt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	ageIntro         = "age-encryption.org/v1\n"
+	pgpArmorHeader   = "-----BEGIN PGP MESSAGE-----"
+	defaultGPGBinary = "gpg"
+)
+
+// Config selects the keys used to decrypt state. Everything is optional: by
+// default the keys sops itself would use are tried.
+type Config struct {
+	// AgeKeyFile is a file of age identities. Defaults to the SOPS_AGE_KEY and
+	// SOPS_AGE_KEY_FILE environment variables, then sops' age/keys.txt in the
+	// user configuration directory.
+	AgeKeyFile string `yaml:"age_key_file" mapstructure:"age_key_file"`
+	// GPGCommand is the gpg binary decrypting PGP messages with the keys of
+	// the local keyring and agent. Defaults to SOPS_GPG_EXEC, then gpg.
+	GPGCommand string `yaml:"gpg_command" mapstructure:"gpg_command"`
+}
+
+// Decrypter detects encrypted state and decrypts it.
+type Decrypter struct {
+	cfg    Config
+	logger ports.Logger
+}
+
+// New creates a Decrypter. Keys are only loaded once encrypted state is seen.
+func New(cfg Config, logger ports.Logger) *Decrypter {
+	return &Decrypter{cfg: cfg, logger: logger}
+}
+
+// Decrypt returns the plaintext of raw when it is a sops file or an age or
+// PGP message, and raw itself otherwise.
+func (d *Decrypter) Decrypt(ctx context.Context, raw []byte) ([]byte, error) {
+	var (
+		plain  []byte
+		err    error
+		format string
+	)
+	switch {
+	case isSopsFile(raw):
+		format = "sops"
+		d.logger.Debugf(ctx, "State is sops-encrypted, decrypting in memory")
+		plain, err = d.decryptSops(ctx, raw)
+	case isAgeMessage(raw):
+		format = "age"
+		d.logger.Debugf(ctx, "State is age-encrypted, decrypting in memory")
+		plain, err = d.decryptAge(raw)
+	case isPGPMessage(raw):
+		format = "PGP"
+		d.logger.Debugf(ctx, "State is PGP-encrypted, decrypting in memory")
+		plain, err = d.decryptPGP(ctx, raw)
+	default:
+		return raw, nil
+	}
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeStateDecryptError,
+			fmt.Sprintf("failed to decrypt %s-encrypted state", format),
+			"Make sure a key that can decrypt the state is available: an age identity (SOPS_AGE_KEY_FILE), AWS credentials allowed to use the KMS key, or a PGP key in the gpg keyring.")
+	}
+	return plain, nil
+}
+
+func isAgeMessage(raw []byte) bool {
+	return bytes.HasPrefix(raw, []byte(ageIntro)) || bytes.HasPrefix(bytes.TrimSpace(raw), []byte(armor.Header))
+}
+
+// isPGPMessage reports whether raw is an armored PGP message or a binary one
+// starting with a public-key or symmetric-key encrypted session key packet.
+func isPGPMessage(raw []byte) bool {
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte(pgpArmorHeader)) {
+		return true
+	}
+	if len(raw) == 0 || raw[0]&0x80 == 0 {
+		return false
+	}
+	var tag byte
+	if raw[0]&0x40 != 0 {
+		tag = raw[0] & 0x3f
+	} else {
+		tag = (raw[0] >> 2) & 0x0f
+	}
+	return tag == 1 || tag == 3
+}
+
+// decryptAge decrypts an age message, armored or binary.
+func (d *Decrypter) decryptAge(message []byte) ([]byte, error) {
+	identities, err := d.ageIdentities()
+	if err != nil {
+		return nil, err
+	}
+	var src io.Reader = bytes.NewReader(message)
+	if bytes.HasPrefix(bytes.TrimSpace(message), []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(message)))
+	}
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// ageIdentities loads the configured age identities, falling back to the
+// locations sops reads them from.
+func (d *Decrypter) ageIdentities() ([]age.Identity, error) {
+	if d.cfg.AgeKeyFile != "" {
+		return readAgeIdentities(d.cfg.AgeKeyFile)
+	}
+	if key := os.Getenv("SOPS_AGE_KEY"); key != "" {
+		return age.ParseIdentities(strings.NewReader(key))
+	}
+	if path := os.Getenv("SOPS_AGE_KEY_FILE"); path != "" {
+		return readAgeIdentities(path)
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("no age identity configured: %w", err)
+	}
+	return readAgeIdentities(filepath.Join(configDir, "sops", "age", "keys.txt"))
+}
+
+func readAgeIdentities(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read age identities: %w", err)
+	}
+	defer f.Close()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identities in %s: %w", path, err)
+	}
+	return identities, nil
+}
+
+// decryptPGP decrypts a PGP message with gpg, which uses the keys of the
+// local keyring and agent.
+func (d *Decrypter) decryptPGP(ctx context.Context, message []byte) ([]byte, error) {
+	binary := d.cfg.GPGCommand
+	if binary == "" {
+		binary = os.Getenv("SOPS_GPG_EXEC")
+	}
+	if binary == "" {
+		binary = defaultGPGBinary
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, "--batch", "--quiet", "--use-agent", "--decrypt")
+	cmd.Stdin = bytes.NewReader(message)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s --decrypt failed: %w: %s", binary, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,173 @@
+package decrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestDecrypter(t *testing.T, identity *age.X25519Identity) *Decrypter {
+	t.Helper()
+	logger := portsmocks.NewLogger(t)
+	logger.On("Debugf", mock.Anything, mock.Anything).Return().Maybe()
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0600))
+	return New(Config{AgeKeyFile: keyFile}, logger)
+}
+
+// ageEncrypt encrypts plaintext to recipient, armored like sops stores it or
+// binary like the age CLI writes it by default.
+func ageEncrypt(t *testing.T, recipient age.Recipient, plaintext []byte, armored bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var dst io.WriteCloser = nopCloser{&buf}
+	if armored {
+		dst = armor.NewWriter(&buf)
+	}
+	w, err := age.Encrypt(dst, recipient)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, dst.Close())
+	return buf.Bytes()
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// sopsEncrypt encrypts a value the way sops does, authenticated with its path.
+func sopsEncrypt(t *testing.T, dataKey []byte, plaintext, valueType, path string) string {
+	t.Helper()
+	block, err := aes.NewCipher(dataKey)
+	require.NoError(t, err)
+	iv := make([]byte, 32)
+	_, err = rand.Read(iv)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	require.NoError(t, err)
+	sealed := gcm.Seal(nil, iv, []byte(plaintext), []byte(path))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	enc := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]", enc(data), enc(iv), enc(tag), valueType)
+}
+
+func TestDecrypter_Sops(t *testing.T) {
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	dataKey := make([]byte, 32)
+	_, err = rand.Read(dataKey)
+	require.NoError(t, err)
+
+	sopsFile := func(bucket string) []byte {
+		doc := map[string]any{
+			"version": sopsEncrypt(t, dataKey, "4", "int", "version:"),
+			"lineage": "plain-lineage",
+			"resources": []any{map[string]any{
+				"type": sopsEncrypt(t, dataKey, "aws_s3_bucket", "str", "resources:type:"),
+				"instances": []any{map[string]any{
+					"attributes": map[string]any{
+						"bucket":        bucket,
+						"force_destroy": sopsEncrypt(t, dataKey, "true", "bool", "resources:instances:attributes:force_destroy:"),
+					},
+				}},
+			}},
+			"sops": map[string]any{
+				"age":          []any{map[string]any{"recipient": identity.Recipient().String(), "enc": string(ageEncrypt(t, identity.Recipient(), dataKey, true))}},
+				"lastmodified": "2024-06-01T12:00:00Z",
+				"mac":          sopsEncrypt(t, dataKey, "MAC", "str", "2024-06-01T12:00:00Z"),
+				"version":      "3.9.0",
+			},
+		}
+		raw, err := json.Marshal(doc)
+		require.NoError(t, err)
+		return raw
+	}
+
+	t.Run("decrypts values", func(t *testing.T) {
+		raw := sopsFile(sopsEncrypt(t, dataKey, "logs", "str", "resources:instances:attributes:bucket:"))
+		plain, err := newTestDecrypter(t, identity).Decrypt(ctx, raw)
+		require.NoError(t, err)
+
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(plain, &doc))
+		assert.NotContains(t, doc, "sops")
+		assert.Equal(t, float64(4), doc["version"])
+		assert.Equal(t, "plain-lineage", doc["lineage"])
+		resource := doc["resources"].([]any)[0].(map[string]any)
+		assert.Equal(t, "aws_s3_bucket", resource["type"])
+		attrs := resource["instances"].([]any)[0].(map[string]any)["attributes"].(map[string]any)
+		assert.Equal(t, "logs", attrs["bucket"])
+		assert.Equal(t, true, attrs["force_destroy"])
+	})
+
+	t.Run("value moved to another path", func(t *testing.T) {
+		raw := sopsFile(sopsEncrypt(t, dataKey, "logs", "str", "lineage:"))
+		_, err := newTestDecrypter(t, identity).Decrypt(ctx, raw)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodeStateDecryptError))
+		assert.Contains(t, err.Error(), "resources.instances.attributes.bucket")
+	})
+
+	t.Run("no matching key", func(t *testing.T) {
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		raw := sopsFile("logs")
+		_, err = newTestDecrypter(t, other).Decrypt(ctx, raw)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodeStateDecryptError))
+		_, suggestion, userFacing := errors.GetUserFacingMessage(err)
+		assert.True(t, userFacing)
+		assert.Contains(t, suggestion, "SOPS_AGE_KEY_FILE")
+	})
+}
+
+func TestDecrypter_WholeFile(t *testing.T) {
+	ctx := context.Background()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	state := []byte(`{"version":4,"resources":[]}`)
+
+	t.Run("plain state is unchanged", func(t *testing.T) {
+		plain, err := newTestDecrypter(t, identity).Decrypt(ctx, state)
+		require.NoError(t, err)
+		assert.Equal(t, state, plain)
+	})
+
+	for _, armored := range []bool{false, true} {
+		t.Run(fmt.Sprintf("age armored=%t", armored), func(t *testing.T) {
+			plain, err := newTestDecrypter(t, identity).Decrypt(ctx, ageEncrypt(t, identity.Recipient(), state, armored))
+			require.NoError(t, err)
+			assert.Equal(t, state, plain)
+		})
+	}
+}
+
+func TestIsPGPMessage(t *testing.T) {
+	assert.True(t, isPGPMessage([]byte("-----BEGIN PGP MESSAGE-----\n\nhQEMA...")))
+	assert.True(t, isPGPMessage([]byte{0x85, 0x01, 0x0c}), "old format public-key session key packet")
+	assert.True(t, isPGPMessage([]byte{0xc1, 0x01}), "new format public-key session key packet")
+	assert.True(t, isPGPMessage([]byte{0x8c, 0x0d}), "symmetric-key session key packet")
+	assert.False(t, isPGPMessage([]byte(`{"version":4}`)))
+	assert.False(t, isPGPMessage(nil))
+}
@@ -0,0 +1,256 @@
+package decrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// sopsValue matches a value encrypted by sops.
+var sopsValue = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.*),tag:(.*),type:(.*)\]$`)
+
+// sopsMetadata is the "sops" object of an encrypted file: the data key,
+// encrypted once per master key.
+type sopsMetadata struct {
+	KeyGroups []sopsKeyGroup `json:"key_groups"`
+	sopsKeyGroup
+	ShamirThreshold int    `json:"shamir_threshold"`
+	Version         string `json:"version"`
+}
+
+type sopsKeyGroup struct {
+	KMS []sopsKMSKey `json:"kms"`
+	Age []sopsAgeKey `json:"age"`
+	PGP []sopsPGPKey `json:"pgp"`
+}
+
+type sopsKMSKey struct {
+	ARN        string            `json:"arn"`
+	Role       string            `json:"role"`
+	Context    map[string]string `json:"context"`
+	AWSProfile string            `json:"aws_profile"`
+	Enc        string            `json:"enc"`
+}
+
+type sopsAgeKey struct {
+	Recipient string `json:"recipient"`
+	Enc       string `json:"enc"`
+}
+
+type sopsPGPKey struct {
+	Fingerprint string `json:"fp"`
+	Enc         string `json:"enc"`
+}
+
+// isSopsFile reports whether raw is a JSON document with sops metadata.
+func isSopsFile(raw []byte) bool {
+	if !bytes.Contains(raw, []byte(`"sops"`)) || !bytes.Contains(raw, []byte("ENC[AES256_GCM,")) {
+		return false
+	}
+	var doc struct {
+		Sops *sopsMetadata `json:"sops"`
+	}
+	return json.Unmarshal(raw, &doc) == nil && doc.Sops != nil && doc.Sops.Version != ""
+}
+
+// decryptSops decrypts every encrypted value of a sops JSON file and returns
+// the document without its metadata. Files encrypted as binary by sops hold
+// the whole document in their "data" value, which is returned as is.
+func (d *Decrypter) decryptSops(ctx context.Context, raw []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	metaRaw, err := json.Marshal(doc["sops"])
+	if err != nil {
+		return nil, err
+	}
+	var meta sopsMetadata
+	if err := json.Unmarshal(metaRaw, &meta); err != nil {
+		return nil, fmt.Errorf("invalid sops metadata: %w", err)
+	}
+	delete(doc, "sops")
+
+	dataKey, err := d.sopsDataKey(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := decryptSopsTree(doc, nil, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := plain.(map[string]any)["data"].(string); ok && len(doc) == 1 {
+		return []byte(data), nil
+	}
+	return json.Marshal(plain)
+}
+
+// sopsDataKey decrypts the data key with the first master key that works.
+// Keys that need no network access are tried first.
+func (d *Decrypter) sopsDataKey(ctx context.Context, meta sopsMetadata) ([]byte, error) {
+	group := meta.sopsKeyGroup
+	switch {
+	case len(meta.KeyGroups) > 1:
+		return nil, fmt.Errorf("files split across %d key groups (Shamir secret sharing) are not supported", len(meta.KeyGroups))
+	case len(meta.KeyGroups) == 1:
+		group = meta.KeyGroups[0]
+	}
+
+	var failures []string
+	for _, key := range group.Age {
+		dataKey, err := d.decryptAge([]byte(key.Enc))
+		if err == nil {
+			return dataKey, nil
+		}
+		failures = append(failures, fmt.Sprintf("age %s: %v", key.Recipient, err))
+	}
+	for _, key := range group.KMS {
+		dataKey, err := decryptKMS(ctx, key)
+		if err == nil {
+			return dataKey, nil
+		}
+		failures = append(failures, fmt.Sprintf("kms %s: %v", key.ARN, err))
+	}
+	for _, key := range group.PGP {
+		dataKey, err := d.decryptPGP(ctx, []byte(key.Enc))
+		if err == nil {
+			return dataKey, nil
+		}
+		failures = append(failures, fmt.Sprintf("pgp %s: %v", key.Fingerprint, err))
+	}
+	if len(failures) == 0 {
+		return nil, fmt.Errorf("the file has no age, AWS KMS or PGP master key")
+	}
+	return nil, fmt.Errorf("no master key could decrypt the data key:\n  %s", strings.Join(failures, "\n  "))
+}
+
+// decryptKMS decrypts the data key with AWS KMS, in the region of the key and
+// with the key's profile and role, as sops does.
+func decryptKMS(ctx context.Context, key sopsKMSKey) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(key.Enc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted data key: %w", err)
+	}
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if parts := strings.Split(key.ARN, ":"); len(parts) > 3 && parts[3] != "" {
+		opts = append(opts, awsconfig.WithRegion(parts[3]))
+	}
+	if key.AWSProfile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(key.AWSProfile))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if key.Role != "" {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), key.Role))
+	}
+	out, err := kms.NewFromConfig(cfg).Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob:    ciphertext,
+		EncryptionContext: key.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
+
+// decryptSopsTree decrypts the encrypted values of a document. sops
+// authenticates each value with the path of keys leading to it, list indexes
+// excluded.
+func decryptSopsTree(node any, path []string, dataKey []byte) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, child := range v {
+			plain, err := decryptSopsTree(child, append(path[:len(path):len(path)], key), dataKey)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = plain
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			plain, err := decryptSopsTree(child, path, dataKey)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = plain
+		}
+		return out, nil
+	case string:
+		if !sopsValue.MatchString(v) {
+			return v, nil
+		}
+		plain, err := decryptSopsValue(v, strings.Join(path, ":")+":", dataKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", strings.Join(path, "."), err)
+		}
+		return plain, nil
+	default:
+		return v, nil
+	}
+}
+
+// decryptSopsValue decrypts a single ENC[AES256_GCM,...] value and converts
+// it back to the type it was encrypted from.
+func decryptSopsValue(value, additionalData string, dataKey []byte) (any, error) {
+	m := sopsValue.FindStringSubmatch(value)
+	data, err := base64.StdEncoding.DecodeString(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	iv, err := base64.StdEncoding.DecodeString(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid iv: %w", err)
+	}
+	tag, err := base64.StdEncoding.DecodeString(m[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag: %w", err)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed; the value or its path was altered, or the data key is wrong")
+	}
+
+	switch m[4] {
+	case "str", "bytes":
+		return string(plain), nil
+	case "int":
+		i, err := strconv.Atoi(string(plain))
+		return i, err
+	case "float":
+		f, err := strconv.ParseFloat(string(plain), 64)
+		return f, err
+	case "bool":
+		b, err := strconv.ParseBool(string(plain))
+		return b, err
+	default:
+		return nil, fmt.Errorf("unknown value type %q", m[4])
+	}
+}
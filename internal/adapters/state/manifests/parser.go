@@ -0,0 +1,70 @@
+package manifests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+)
+
+// document is one object of a manifest stream and the line it starts on.
+type document struct {
+	object shared.Object
+	line   int
+}
+
+// parseManifests decodes a stream of YAML or JSON documents, expanding List
+// objects into their items. Empty documents are skipped.
+func parseManifests(raw []byte) ([]document, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	var docs []document
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if err == io.EOF {
+				return docs, nil
+			}
+			return nil, err
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		line := node.Content[0].Line
+
+		var value map[string]any
+		if err := node.Decode(&value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		obj, err := toObject(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(obj.Items) == 0 {
+			docs = append(docs, document{object: obj, line: line})
+			continue
+		}
+		for i, rawItem := range obj.Items {
+			var item shared.Object
+			if err := json.Unmarshal(rawItem, &item); err != nil {
+				return nil, fmt.Errorf("line %d: item %d: %w", line, i, err)
+			}
+			docs = append(docs, document{object: item, line: line})
+		}
+	}
+}
+
+// toObject converts a decoded YAML document to an Object through JSON, the
+// form the API server would receive.
+func toObject(value map[string]any) (shared.Object, error) {
+	var obj shared.Object
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return obj, err
+	}
+	err = json.Unmarshal(encoded, &obj)
+	return obj, err
+}
@@ -0,0 +1,246 @@
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const ProviderTypeManifests = "manifests"
+
+// defaultKustomizeCommand renders a kustomization directory, which is appended
+// as the last argument.
+var defaultKustomizeCommand = []string{"kustomize", "build"}
+
+// Config points at the Kubernetes manifests that describe the desired state of
+// a cluster: YAML or JSON files, directories of them, or a kustomization.
+type Config struct {
+	// Paths are manifest files or directories, which are read recursively for
+	// .yaml, .yml and .json files.
+	Paths []string `yaml:"paths" mapstructure:"paths" validate:"required_without=Kustomize,dive,required"`
+	// Kustomize is a kustomization directory whose rendered output is read.
+	Kustomize string `yaml:"kustomize" mapstructure:"kustomize"`
+	// KustomizeCommand renders the kustomization, "kustomize build" unless set,
+	// e.g. ["kubectl", "kustomize"].
+	KustomizeCommand []string `yaml:"kustomize_command" mapstructure:"kustomize_command"`
+	// DefaultNamespace is the namespace of objects that do not set one,
+	// "default" unless set.
+	DefaultNamespace string `yaml:"default_namespace" mapstructure:"default_namespace"`
+}
+
+// Provider reads desired state from Kubernetes manifests. Deployments,
+// Services and ConfigMaps are mapped to domain resources identified by
+// "<namespace>/<name>"; other objects are ignored.
+type Provider struct {
+	cfg    Config
+	logger ports.Logger
+
+	mu        sync.Mutex
+	loaded    bool
+	loadErr   error
+	resources map[domain.ResourceKind][]domain.StateResource
+}
+
+func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
+	if len(cfg.Paths) == 0 && cfg.Kustomize == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, "manifests state provider requires manifest paths or a kustomization", "Set state.manifests.paths or state.manifests.kustomize.")
+	}
+	if cfg.DefaultNamespace == "" {
+		cfg.DefaultNamespace = shared.DefaultNamespace
+	}
+	if len(cfg.KustomizeCommand) == 0 {
+		cfg.KustomizeCommand = defaultKustomizeCommand
+	}
+	return &Provider{
+		cfg:    cfg,
+		logger: logger.WithFields(map[string]any{"provider": ProviderTypeManifests}),
+	}, nil
+}
+
+func (p *Provider) Type() string { return ProviderTypeManifests }
+
+func (p *Provider) ListResources(ctx context.Context, kind domain.ResourceKind) ([]domain.StateResource, error) {
+	resources, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resources[kind], nil
+}
+
+// GetResource returns the object of kind with the "<namespace>/<name>" identifier.
+func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, identifier string) (domain.StateResource, error) {
+	resources, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range resources[kind] {
+		if res.Metadata().SourceIdentifier == identifier {
+			return res, nil
+		}
+	}
+	return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("%s '%s' not found in manifests", kind, identifier))
+}
+
+// load reads and maps the manifests on first use and caches the result.
+func (p *Provider) load(ctx context.Context) (map[domain.ResourceKind][]domain.StateResource, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.loaded {
+		return p.resources, p.loadErr
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	p.resources = make(map[domain.ResourceKind][]domain.StateResource)
+	seen := make(map[string]string)
+	add := func(source string, docs []document) error {
+		for _, doc := range docs {
+			res, ok, err := p.newResource(source, doc)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				p.logger.Debugf(ctx, "Ignoring Kubernetes %s %s in %s:%d, kind not compared", doc.object.APIVersion, doc.object.Kind, source, doc.line)
+				continue
+			}
+			meta := res.Metadata()
+			key := string(meta.Kind) + " " + meta.SourceIdentifier
+			if first, dup := seen[key]; dup {
+				return errors.NewUserFacing(errors.CodeStateParseError,
+					fmt.Sprintf("%s '%s' is declared twice, in %s and %s:%d", meta.Kind, meta.SourceIdentifier, first, source, doc.line),
+					"Remove one of the declarations.")
+			}
+			seen[key] = fmt.Sprintf("%s:%d", source, doc.line)
+			p.resources[meta.Kind] = append(p.resources[meta.Kind], res)
+		}
+		return nil
+	}
+
+	p.loadErr = p.readPaths(add)
+	if p.loadErr == nil && p.cfg.Kustomize != "" {
+		p.loadErr = p.readKustomization(ctx, add)
+	}
+	p.loaded = true
+	if p.loadErr != nil {
+		p.resources = nil
+	}
+	return p.resources, p.loadErr
+}
+
+func (p *Provider) readPaths(add func(source string, docs []document) error) error {
+	for _, root := range p.cfg.Paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return errors.WrapUserFacing(err, errors.CodeStateReadError, fmt.Sprintf("failed to read manifests at %s", root), "Check state.manifests.paths.")
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if d.IsDir() || !isManifestFile(path) {
+				return nil
+			}
+			source := path
+			if info.IsDir() {
+				if rel, relErr := filepath.Rel(root, path); relErr == nil {
+					source = rel
+				}
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to read manifest %s", path))
+			}
+			docs, err := parseManifests(raw)
+			if err != nil {
+				return errors.WrapUserFacing(err, errors.CodeStateParseError, fmt.Sprintf("invalid manifest %s", path), "")
+			}
+			return add(source, docs)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Provider) readKustomization(ctx context.Context, add func(source string, docs []document) error) error {
+	command := p.cfg.KustomizeCommand
+	args := append(append([]string{}, command[1:]...), p.cfg.Kustomize)
+	cmd := exec.CommandContext(ctx, command[0], args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.WrapUserFacing(err, errors.CodeStateReadError,
+			fmt.Sprintf("'%s %s' failed: %s", strings.Join(command, " "), p.cfg.Kustomize, strings.TrimSpace(stderr.String())),
+			"Check state.manifests.kustomize and that the kustomize command is installed.")
+	}
+	docs, err := parseManifests(out)
+	if err != nil {
+		return errors.WrapUserFacing(err, errors.CodeStateParseError, fmt.Sprintf("invalid kustomize output for %s", p.cfg.Kustomize), "")
+	}
+	// Lines of the rendered output do not point into any file.
+	for i := range docs {
+		docs[i].line = 0
+	}
+	return add(p.cfg.Kustomize, docs)
+}
+
+func (p *Provider) newResource(source string, doc document) (domain.StateResource, bool, error) {
+	kind, ok := doc.object.ResourceKind()
+	if !ok {
+		return nil, false, nil
+	}
+	attrs, err := doc.object.Attributes(p.cfg.DefaultNamespace)
+	if err != nil {
+		return nil, false, errors.Wrap(err, errors.CodeStateParseError, fmt.Sprintf("failed to map %s:%d", source, doc.line))
+	}
+	key := doc.object.Key(p.cfg.DefaultNamespace)
+	return &manifestResource{
+		meta: domain.ResourceMetadata{
+			Kind:               kind,
+			ProviderType:       shared.ProviderTypeKubernetes,
+			ProviderAssignedID: key,
+			SourceIdentifier:   key,
+			SourceFile:         source,
+			SourceLine:         doc.line,
+		},
+		attrs: attrs,
+	}, true, nil
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+type manifestResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r *manifestResource) Metadata() domain.ResourceMetadata { return r.meta }
+
+func (r *manifestResource) Attributes() map[string]any {
+	dup := make(map[string]any, len(r.attrs))
+	for k, v := range r.attrs {
+		dup[k] = v
+	}
+	return dup
+}
@@ -0,0 +1,111 @@
+package manifests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/kubernetes/shared"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	logger.On("WithFields", mock.Anything).Maybe().Return(logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf", "Errorf"} {
+		args := []any{mock.Anything, mock.Anything}
+		for len(args) <= 8 {
+			logger.On(method, args...).Maybe().Return()
+			args = append(args, mock.Anything)
+		}
+	}
+	return logger
+}
+
+func newTestProvider(t *testing.T, cfg Config) *Provider {
+	t.Helper()
+	p, err := NewProvider(cfg, newTestLogger())
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewProviderRequiresManifests(t *testing.T) {
+	_, err := NewProvider(Config{}, newTestLogger())
+
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
+
+func TestListResources_Directory(t *testing.T) {
+	p := newTestProvider(t, Config{Paths: []string{filepath.Join("testdata", "app")}, DefaultNamespace: "web"})
+	assert.Equal(t, ProviderTypeManifests, p.Type())
+
+	deployments, err := p.ListResources(context.Background(), domain.KindKubernetesDeployment)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	meta := deployments[0].Metadata()
+	assert.Equal(t, domain.ResourceMetadata{
+		Kind:               domain.KindKubernetesDeployment,
+		ProviderType:       shared.ProviderTypeKubernetes,
+		ProviderAssignedID: "web/web",
+		SourceIdentifier:   "web/web",
+		SourceFile:         filepath.Join("base", "deployment.yaml"),
+		SourceLine:         2,
+	}, meta)
+	assert.Equal(t, 2, deployments[0].Attributes()[domain.KubernetesReplicasKey])
+
+	services, err := p.ListResources(context.Background(), domain.KindKubernetesService)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, 24, services[0].Metadata().SourceLine)
+
+	configMaps, err := p.ListResources(context.Background(), domain.KindKubernetesConfigMap)
+	require.NoError(t, err)
+	require.Len(t, configMaps, 1, "List items are expanded and unsupported kinds ignored")
+	assert.Equal(t, "shop/settings", configMaps[0].Metadata().SourceIdentifier)
+
+	res, err := p.GetResource(context.Background(), domain.KindKubernetesService, "web/web")
+	require.NoError(t, err)
+	assert.Equal(t, "ClusterIP", res.Attributes()[domain.KubernetesServiceTypeKey])
+
+	_, err = p.GetResource(context.Background(), domain.KindKubernetesService, "default/web")
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
+
+func TestListResources_Kustomize(t *testing.T) {
+	// cat stands in for kustomize build, printing the file it is given.
+	p := newTestProvider(t, Config{
+		Kustomize:        filepath.Join("testdata", "app", "base", "deployment.yaml"),
+		KustomizeCommand: []string{"cat"},
+	})
+
+	deployments, err := p.ListResources(context.Background(), domain.KindKubernetesDeployment)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "default/web", deployments[0].Metadata().SourceIdentifier)
+	assert.Zero(t, deployments[0].Metadata().SourceLine)
+}
+
+func TestListResources_Errors(t *testing.T) {
+	dir := t.TempDir()
+	cm := "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: settings}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(cm), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yml"), []byte(cm), 0o600))
+
+	_, err := newTestProvider(t, Config{Paths: []string{dir}}).ListResources(context.Background(), domain.KindKubernetesConfigMap)
+	assert.True(t, errors.Is(err, errors.CodeStateParseError), "duplicate objects are rejected")
+	assert.ErrorContains(t, err, "declared twice")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yml"), []byte("kind: [unclosed"), 0o600))
+	_, err = newTestProvider(t, Config{Paths: []string{dir}}).ListResources(context.Background(), domain.KindKubernetesConfigMap)
+	assert.True(t, errors.Is(err, errors.CodeStateParseError))
+
+	_, err = newTestProvider(t, Config{Paths: []string{filepath.Join(dir, "missing")}}).ListResources(context.Background(), domain.KindKubernetesConfigMap)
+	assert.True(t, errors.Is(err, errors.CodeStateReadError))
+}
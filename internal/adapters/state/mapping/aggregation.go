@@ -0,0 +1,386 @@
+package mapping
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// ResourceLookup resolves the raw attributes of another managed resource in the
+// same state by Terraform type and ID.
+type ResourceLookup func(tfType, id string) (map[string]any, bool)
+
+// AggregationRule describes a Terraform resource type that configures part of a
+// parent resource (split-resource providers) and is folded into the parent's
+// desired attributes so it compares against the single platform resource.
+type AggregationRule struct {
+	// TFType is the related Terraform resource type, e.g. aws_s3_bucket_policy.
+	TFType string
+	// ParentRefKey is the attribute on the related resource holding the parent's ID.
+	ParentRefKey string
+	// Merge copies the related resource's normalized attributes into target.
+	Merge func(raw map[string]any, target map[string]any, lookup ResourceLookup) error
+}
+
+var storageBucketAggregationRules = []AggregationRule{
+	{TFType: "aws_s3_bucket_versioning", ParentRefKey: "bucket", Merge: mergeAttribute("versioning_configuration", domain.StorageBucketVersioningKey, normalizeVersioningConfiguration)},
+	{TFType: "aws_s3_bucket_lifecycle_configuration", ParentRefKey: "bucket", Merge: mergeAttribute("rule", domain.StorageBucketLifecycleRulesKey, normalizeRuleList)},
+	{TFType: "aws_s3_bucket_server_side_encryption_configuration", ParentRefKey: "bucket", Merge: mergeAttribute("rule", domain.StorageBucketEncryptionKey, normalizeS3EncryptionRules)},
+	{TFType: "aws_s3_bucket_cors_configuration", ParentRefKey: "bucket", Merge: mergeAttribute("cors_rule", domain.StorageBucketCorsRulesKey, normalizeRuleList)},
+	{TFType: "aws_s3_bucket_policy", ParentRefKey: "bucket", Merge: mergeAttribute("policy", domain.StorageBucketPolicyKey, passThrough)},
+	{TFType: "aws_s3_bucket_acl", ParentRefKey: "bucket", Merge: mergeS3BucketACL},
+	{TFType: "aws_s3_bucket_logging", ParentRefKey: "bucket", Merge: mergeS3BucketLogging},
+	{TFType: "aws_s3_bucket_website_configuration", ParentRefKey: "bucket", Merge: mergeS3BucketWebsite},
+	{TFType: "aws_s3_bucket_public_access_block", ParentRefKey: "bucket", Merge: mergeS3BucketPublicAccessBlock},
+	{TFType: "aws_s3_bucket_ownership_controls", ParentRefKey: "bucket", Merge: mergeS3BucketOwnershipControls},
+	{TFType: "aws_s3_bucket_replication_configuration", ParentRefKey: "bucket", Merge: mergeS3BucketBlock(domain.StorageBucketReplicationKey, normalizeS3Replication)},
+	{TFType: "aws_s3_bucket_object_lock_configuration", ParentRefKey: "bucket", Merge: mergeS3BucketBlock(domain.StorageBucketObjectLockKey, normalizeS3ObjectLock)},
+	{TFType: "aws_s3_bucket_notification", ParentRefKey: "bucket", Merge: mergeS3BucketBlock(domain.StorageBucketNotificationKey, normalizeS3Notification)},
+	{TFType: "aws_s3_bucket_accelerate_configuration", ParentRefKey: "bucket", Merge: mergeAttribute("status", domain.StorageBucketAccelerationStatusKey, passThrough)},
+	{TFType: "aws_s3_bucket_intelligent_tiering_configuration", ParentRefKey: "bucket", Merge: mergeS3BucketConfiguration(domain.StorageBucketIntelligentTieringKey, normalizeS3IntelligentTiering)},
+	{TFType: "aws_s3_bucket_analytics_configuration", ParentRefKey: "bucket", Merge: mergeS3BucketConfiguration(domain.StorageBucketAnalyticsKey, normalizeS3Analytics)},
+	{TFType: "aws_s3_bucket_inventory", ParentRefKey: "bucket", Merge: mergeS3BucketConfiguration(domain.StorageBucketInventoryKey, normalizeS3Inventory)},
+	{TFType: "aws_s3_bucket_metric", ParentRefKey: "bucket", Merge: mergeS3BucketConfiguration(domain.StorageBucketMetricsKey, normalizeS3Metric)},
+}
+
+var computeInstanceAggregationRules = []AggregationRule{
+	{TFType: "aws_volume_attachment", ParentRefKey: "instance_id", Merge: mergeVolumeAttachment},
+}
+
+var listenerAggregationRules = []AggregationRule{
+	{TFType: "aws_lb_listener_rule", ParentRefKey: "listener_arn", Merge: mergeListenerRule},
+	{TFType: "aws_alb_listener_rule", ParentRefKey: "listener_arn", Merge: mergeListenerRule},
+}
+
+var kmsKeyAggregationRules = []AggregationRule{
+	{TFType: "aws_kms_alias", ParentRefKey: "target_key_id", Merge: mergeKMSAlias},
+}
+
+var elasticIPAggregationRules = []AggregationRule{
+	{TFType: "aws_eip_association", ParentRefKey: "allocation_id", Merge: mergeEIPAssociation},
+}
+
+var networkInterfaceAggregationRules = []AggregationRule{
+	{TFType: "aws_network_interface_attachment", ParentRefKey: "network_interface_id", Merge: mergeENIAttachment},
+	{TFType: "aws_network_interface_sg_attachment", ParentRefKey: "network_interface_id", Merge: mergeENISecurityGroup},
+}
+
+// AggregationRulesForKind returns the split-resource rules for a kind, or nil
+// when the kind has no related resources to aggregate.
+func AggregationRulesForKind(kind domain.ResourceKind) []AggregationRule {
+	switch kind {
+	case domain.KindStorageBucket:
+		return storageBucketAggregationRules
+	case domain.KindComputeInstance:
+		return computeInstanceAggregationRules
+	case domain.KindLoadBalancerListener:
+		return listenerAggregationRules
+	case domain.KindEncryptionKey:
+		return kmsKeyAggregationRules
+	case domain.KindElasticIP:
+		return elasticIPAggregationRules
+	case domain.KindNetworkInterface:
+		return networkInterfaceAggregationRules
+	default:
+		return nil
+	}
+}
+
+func mergeAttribute(tfKey, domainKey string, normalize func(any) (any, error)) func(map[string]any, map[string]any, ResourceLookup) error {
+	return func(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+		rawValue, exists := raw[tfKey]
+		if !exists || rawValue == nil {
+			return nil
+		}
+		normalizedValue, err := normalize(rawValue)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to normalize related attribute '%s'", tfKey))
+		}
+		if normalizedValue != nil {
+			target[domainKey] = normalizedValue
+		}
+		return nil
+	}
+}
+
+func normalizeRuleList(v any) (any, error) {
+	rules, err := normalizeGenericSliceOfMaps(v)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func passThrough(v any) (any, error) {
+	if s, ok := v.(string); ok && s == "" {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func mergeS3BucketACL(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	if canned, ok := raw["acl"].(string); ok && canned != "" {
+		target[domain.StorageBucketACLKey] = canned
+		return nil
+	}
+	policy, err := normalizeSingleBlockMap(raw["access_control_policy"])
+	if err != nil || policy == nil {
+		return err
+	}
+	grantsRaw, ok := policy["grant"].([]any)
+	if !ok || len(grantsRaw) == 0 {
+		return nil
+	}
+	grants := make([]any, 0, len(grantsRaw))
+	for i, g := range grantsRaw {
+		grantMap, ok := g.(map[string]any)
+		if !ok {
+			return fmt.Errorf("acl grant at index %d is not a map, got %T", i, g)
+		}
+		out := map[string]any{}
+		copyIfPresentMap(grantMap, out, "permission")
+		grantee, err := normalizeSingleBlockMap(grantMap["grantee"])
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{"type", "id", "uri"} {
+			if v, ok := grantee[key].(string); ok && v != "" {
+				out[key] = v
+			}
+		}
+		grants = append(grants, out)
+	}
+	target[domain.StorageBucketACLKey] = grants
+	return nil
+}
+
+func mergeS3BucketLogging(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	bucket, _ := raw["target_bucket"].(string)
+	if bucket == "" {
+		return nil
+	}
+	prefix, _ := raw["target_prefix"].(string)
+	target[domain.StorageBucketLoggingKey] = map[string]any{
+		"target_bucket": bucket,
+		"target_prefix": prefix,
+	}
+	return nil
+}
+
+func mergeS3BucketWebsite(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	website := map[string]any{}
+	if index, err := normalizeSingleBlockMap(raw["index_document"]); err != nil {
+		return err
+	} else if suffix, ok := index["suffix"].(string); ok && suffix != "" {
+		website["index_document"] = suffix
+	}
+	if errDoc, err := normalizeSingleBlockMap(raw["error_document"]); err != nil {
+		return err
+	} else if key, ok := errDoc["key"].(string); ok && key != "" {
+		website["error_document"] = key
+	}
+	if redirect, err := normalizeSingleBlockMap(raw["redirect_all_requests_to"]); err != nil {
+		return err
+	} else if len(redirect) > 0 {
+		redirectMap := map[string]any{}
+		copyIfPresentMap(redirect, redirectMap, "host_name")
+		copyIfPresentMap(redirect, redirectMap, "protocol")
+		if len(redirectMap) > 0 {
+			website["redirect_all_requests_to"] = redirectMap
+		}
+	}
+	if len(website) > 0 {
+		target[domain.StorageBucketWebsiteKey] = website
+	}
+	return nil
+}
+
+// mergeS3BucketBlock maps a related resource whose attributes form a single
+// bucket attribute, replacing the deprecated inline block of the bucket.
+func mergeS3BucketBlock(domainKey string, normalize func(any) (map[string]any, error)) func(map[string]any, map[string]any, ResourceLookup) error {
+	return func(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+		normalized, err := normalize(raw)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to normalize related attribute '%s'", domainKey))
+		}
+		if normalized != nil {
+			target[domainKey] = normalized
+		}
+		return nil
+	}
+}
+
+// mergeS3BucketConfiguration adds a related resource holding one of several
+// configurations of the same kind (inventories, metrics, ...) to the bucket's
+// list of them, which is kept sorted by id.
+func mergeS3BucketConfiguration(domainKey string, normalize func(map[string]any) (map[string]any, error)) func(map[string]any, map[string]any, ResourceLookup) error {
+	return func(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+		cfg, err := normalize(raw)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to normalize related attribute '%s'", domainKey))
+		}
+		if cfg == nil {
+			return nil
+		}
+		configs, _ := target[domainKey].([]any)
+		configs = append(configs, cfg)
+		sortBlocksByField(configs, "id")
+		target[domainKey] = configs
+		return nil
+	}
+}
+
+// mergeS3BucketPublicAccessBlock maps the four Block Public Access settings,
+// which the resource holds as top-level booleans.
+func mergeS3BucketPublicAccessBlock(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	settings := map[string]any{}
+	for _, key := range []string{"block_public_acls", "block_public_policy", "ignore_public_acls", "restrict_public_buckets"} {
+		enabled, _ := raw[key].(bool)
+		settings[key] = enabled
+	}
+	target[domain.StorageBucketPublicAccessBlockKey] = settings
+	return nil
+}
+
+func mergeS3BucketOwnershipControls(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	rule, err := normalizeSingleBlockMap(raw["rule"])
+	if err != nil || rule == nil {
+		return err
+	}
+	if ownership, ok := rule["object_ownership"].(string); ok && ownership != "" {
+		target[domain.StorageBucketObjectOwnershipKey] = ownership
+	}
+	return nil
+}
+
+// mergeVolumeAttachment appends the attached aws_ebs_volume as an EBS block device,
+// using the same shape as inline ebs_block_device blocks.
+func mergeVolumeAttachment(raw map[string]any, target map[string]any, lookup ResourceLookup) error {
+	volumeID, _ := raw["volume_id"].(string)
+	if volumeID == "" {
+		return nil
+	}
+	device := map[string]any{}
+	if volume, ok := lookup("aws_ebs_volume", volumeID); ok {
+		deviceRaw := map[string]any{
+			"volume_type": volume["type"],
+			"volume_size": volume["size"],
+			"iops":        volume["iops"],
+			"throughput":  volume["throughput"],
+			"encrypted":   volume["encrypted"],
+			"kms_key_id":  volume["kms_key_id"],
+			"snapshot_id": volume["snapshot_id"],
+		}
+		normalized, err := normalizeBlockDeviceMap(deviceRaw, false)
+		if err != nil {
+			return err
+		}
+		device = normalized
+	} else {
+		device["delete_on_termination"] = false
+	}
+	copyIfPresentMap(raw, device, "device_name")
+	device["volume_id"] = volumeID
+
+	existing, _ := target[domain.ComputeEBSBlockDevicesKey].([]any)
+	target[domain.ComputeEBSBlockDevicesKey] = append(existing, device)
+	return nil
+}
+
+// mergeListenerRule adds an aws_lb_listener_rule to the listener's rules, which
+// are kept sorted by priority.
+func mergeListenerRule(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	rule := map[string]any{}
+	if err := normalizeNumericField(raw, rule, "priority"); err != nil {
+		return err
+	}
+	if _, ok := rule["priority"].(int64); !ok {
+		return fmt.Errorf("listener rule has no integer priority")
+	}
+	conditions, err := normalizeLBConditions(raw["condition"])
+	if err != nil {
+		return fmt.Errorf("condition: %w", err)
+	}
+	rule["conditions"] = conditions
+	actions, err := normalizeLBActions(raw["action"])
+	if err != nil {
+		return fmt.Errorf("action: %w", err)
+	}
+	if actions == nil {
+		actions = []any{}
+	}
+	rule["actions"] = actions
+
+	rules, _ := target[domain.ListenerRulesKey].([]any)
+	rules = append(rules, rule)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].(map[string]any)["priority"].(int64) < rules[j].(map[string]any)["priority"].(int64)
+	})
+	target[domain.ListenerRulesKey] = rules
+	return nil
+}
+
+// mergeKMSAlias adds the name of an aws_kms_alias to the key's aliases, which
+// are kept sorted. Only aliases referencing the key by ID are matched, the
+// form Terraform stores when target_key_id is set from aws_kms_key.key_id.
+func mergeKMSAlias(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	name, _ := raw["name"].(string)
+	if name == "" {
+		return fmt.Errorf("kms alias has no name")
+	}
+	aliases, _ := target[domain.EncryptionKeyAliasesKey].([]string)
+	aliases = append(aliases, name)
+	sort.Strings(aliases)
+	target[domain.EncryptionKeyAliasesKey] = aliases
+	return nil
+}
+
+// mergeEIPAssociation maps an aws_eip_association onto the association
+// attributes of the address, which the aws_eip only holds once refreshed.
+func mergeEIPAssociation(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	for tfKey, domainKey := range map[string]string{
+		"id":                   domain.ElasticIPAssociationIDKey,
+		"instance_id":          domain.ElasticIPInstanceKey,
+		"network_interface_id": domain.ElasticIPNetworkInterfaceKey,
+		"private_ip_address":   domain.ElasticIPPrivateIPKey,
+	} {
+		if value, _ := raw[tfKey].(string); value != "" {
+			target[domainKey] = value
+		}
+	}
+	return nil
+}
+
+// mergeENIAttachment maps an aws_network_interface_attachment onto the
+// attachment of the interface, in the shape of the inline attachment block.
+func mergeENIAttachment(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	instance, _ := raw["instance_id"].(string)
+	if instance == "" {
+		return fmt.Errorf("network interface attachment has no instance_id")
+	}
+	attachment := map[string]any{"instance": instance}
+	if err := normalizeNumericField(raw, attachment, "device_index"); err != nil {
+		return err
+	}
+	target[domain.NetworkInterfaceAttachmentKey] = attachment
+	return nil
+}
+
+// mergeENISecurityGroup adds the group of an
+// aws_network_interface_sg_attachment to the interface's security groups.
+func mergeENISecurityGroup(raw map[string]any, target map[string]any, _ ResourceLookup) error {
+	groupID, _ := raw["security_group_id"].(string)
+	if groupID == "" {
+		return fmt.Errorf("network interface security group attachment has no security_group_id")
+	}
+	groups, _ := target[domain.NetworkInterfaceSecurityGroupsKey].([]string)
+	for _, existing := range groups {
+		if existing == groupID {
+			return nil
+		}
+	}
+	target[domain.NetworkInterfaceSecurityGroupsKey] = append(groups, groupID)
+	return nil
+}
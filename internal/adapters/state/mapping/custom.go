@@ -0,0 +1,88 @@
+package mapping
+
+import (
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+type customKindPattern struct {
+	pattern string
+	kind    domain.ResourceKind
+}
+
+var customKinds = struct {
+	mu       sync.RWMutex
+	patterns []customKindPattern
+	kinds    map[domain.ResourceKind]struct{}
+}{kinds: make(map[domain.ResourceKind]struct{})}
+
+// RegisterCustomKind maps Terraform types matching tfTypePattern (a path.Match
+// glob such as "aws_sqs_queue" or "aws_sqs_*") to a user-defined kind declared
+// in config. Built-in Terraform types always take precedence.
+func RegisterCustomKind(tfTypePattern string, kind domain.ResourceKind) error {
+	if tfTypePattern == "" || kind == "" {
+		return errors.New(errors.CodeConfigValidation, "custom kind requires both a terraform type pattern and a kind name")
+	}
+	if _, err := path.Match(tfTypePattern, ""); err != nil {
+		return errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("invalid terraform type pattern %q for custom kind %s", tfTypePattern, kind))
+	}
+
+	customKinds.mu.Lock()
+	defer customKinds.mu.Unlock()
+	customKinds.patterns = append(customKinds.patterns, customKindPattern{pattern: tfTypePattern, kind: kind})
+	customKinds.kinds[kind] = struct{}{}
+	return nil
+}
+
+// IsCustomKind reports whether kind was registered from config.
+func IsCustomKind(kind domain.ResourceKind) bool {
+	customKinds.mu.RLock()
+	defer customKinds.mu.RUnlock()
+	_, ok := customKinds.kinds[kind]
+	return ok
+}
+
+func matchCustomKind(tfType string) (domain.ResourceKind, bool) {
+	customKinds.mu.RLock()
+	defer customKinds.mu.RUnlock()
+	for _, p := range customKinds.patterns {
+		if ok, _ := path.Match(p.pattern, tfType); ok {
+			return p.kind, true
+		}
+	}
+	return "", false
+}
+
+// copyCustomAttributes copies raw attributes verbatim for user-defined kinds,
+// normalizing only tags and the derived name so matching and tag comparison work.
+func copyCustomAttributes(rawAttrs map[string]any, targetAttrs map[string]any) error {
+	for k, v := range rawAttrs {
+		if v == nil {
+			continue
+		}
+		if k == "tags_all" {
+			continue
+		}
+		if k == domain.KeyTags {
+			tags, err := normalizeTags(v)
+			if err != nil {
+				return errors.Wrap(err, errors.CodeMappingError, "failed to normalize tags for custom kind")
+			}
+			targetAttrs[domain.KeyTags] = tags
+			continue
+		}
+		targetAttrs[k] = v
+	}
+	if _, exists := targetAttrs[domain.KeyName]; !exists {
+		if tags, ok := targetAttrs[domain.KeyTags].(map[string]string); ok {
+			if nameVal, nameOk := tags["Name"]; nameOk {
+				targetAttrs[domain.KeyName] = nameVal
+			}
+		}
+	}
+	return nil
+}
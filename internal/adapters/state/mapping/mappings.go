@@ -2,6 +2,7 @@ package mapping
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -10,14 +11,50 @@ import (
 )
 
 var tfTypeToDomainKindMap = map[string]domain.ResourceKind{
-	"aws_instance":    domain.KindComputeInstance,
-	"aws_s3_bucket":   domain.KindStorageBucket,
-	"aws_db_instance": domain.KindDatabaseInstance,
+	"aws_instance":        domain.KindComputeInstance,
+	"aws_s3_bucket":       domain.KindStorageBucket,
+	"aws_db_instance":     domain.KindDatabaseInstance,
+	"aws_lambda_function": domain.KindServerlessFunction,
+	"aws_iam_role":        domain.KindIAMRole,
+	"aws_iam_policy":      domain.KindIAMPolicy,
+	"aws_security_group":  domain.KindNetworkSecurityGroup,
+	"aws_dynamodb_table":  domain.KindDatabaseTable,
+
+	"aws_cloudfront_distribution": domain.KindCDNDistribution,
+
+	"aws_autoscaling_group": domain.KindAutoScalingGroup,
+	"aws_launch_template":   domain.KindLaunchTemplate,
+
+	"aws_kms_key": domain.KindEncryptionKey,
+
+	"aws_eip":               domain.KindElasticIP,
+	"aws_network_interface": domain.KindNetworkInterface,
+
+	"aws_ecs_cluster":         domain.KindContainerCluster,
+	"aws_ecs_service":         domain.KindContainerService,
+	"aws_ecs_task_definition": domain.KindContainerTaskDefinition,
+
+	"aws_lb":               domain.KindLoadBalancer,
+	"aws_alb":              domain.KindLoadBalancer,
+	"aws_lb_listener":      domain.KindLoadBalancerListener,
+	"aws_alb_listener":     domain.KindLoadBalancerListener,
+	"aws_lb_target_group":  domain.KindLoadBalancerTargetGroup,
+	"aws_alb_target_group": domain.KindLoadBalancerTargetGroup,
+
+	"google_compute_instance": domain.KindComputeInstance,
+	"google_storage_bucket":   domain.KindStorageBucket,
+
+	"azurerm_linux_virtual_machine":   domain.KindComputeInstance,
+	"azurerm_windows_virtual_machine": domain.KindComputeInstance,
+	"azurerm_storage_account":         domain.KindStorageBucket,
 }
 
 func MapTfTypeToDomainKind(tfType string) (domain.ResourceKind, error) {
 	kind, exists := tfTypeToDomainKindMap[tfType]
 	if !exists {
+		if custom, ok := matchCustomKind(tfType); ok {
+			return custom, nil
+		}
 		return "", errors.New(errors.CodeNotImplemented, fmt.Sprintf("unsupported Terraform resource type: %s", tfType))
 	}
 	return kind, nil
@@ -26,18 +63,25 @@ func MapTfTypeToDomainKind(tfType string) (domain.ResourceKind, error) {
 type attributeMapDefinition map[string]string
 
 var computeInstanceAttrMap = attributeMapDefinition{
-	"instance_type":          domain.ComputeInstanceTypeKey,
-	"ami":                    domain.ComputeImageIDKey,
-	"subnet_id":              domain.ComputeSubnetIDKey,
-	"vpc_security_group_ids": domain.ComputeSecurityGroupsKey,
-	"iam_instance_profile":   domain.ComputeIAMInstanceProfileKey,
-	"user_data":              domain.ComputeUserDataKey,
-	"availability_zone":      domain.ComputeAvailabilityZoneKey,
-	"root_block_device":      domain.ComputeRootBlockDeviceKey,
-	"ebs_block_device":       domain.ComputeEBSBlockDevicesKey,
-	"tags":                   domain.KeyTags,
-	"id":                     domain.KeyID,
-	"arn":                    domain.KeyARN,
+	"instance_type":           domain.ComputeInstanceTypeKey,
+	"ami":                     domain.ComputeImageIDKey,
+	"subnet_id":               domain.ComputeSubnetIDKey,
+	"vpc_security_group_ids":  domain.ComputeSecurityGroupsKey,
+	"iam_instance_profile":    domain.ComputeIAMInstanceProfileKey,
+	"user_data":               domain.ComputeUserDataKey,
+	"availability_zone":       domain.ComputeAvailabilityZoneKey,
+	"placement_group":         domain.ComputePlacementGroupKey,
+	"tenancy":                 domain.ComputeTenancyKey,
+	"ebs_optimized":           domain.ComputeEBSOptimizedKey,
+	"credit_specification":    domain.ComputeCreditSpecificationKey,
+	"metadata_options":        domain.ComputeMetadataOptionsKey,
+	"monitoring":              domain.ComputeMonitoringKey,
+	"disable_api_termination": domain.ComputeDeletionProtectionKey,
+	"root_block_device":       domain.ComputeRootBlockDeviceKey,
+	"ebs_block_device":        domain.ComputeEBSBlockDevicesKey,
+	"tags":                    domain.KeyTags,
+	"id":                      domain.KeyID,
+	"arn":                     domain.KeyARN,
 }
 
 var s3BucketAttrMap = attributeMapDefinition{
@@ -49,18 +93,470 @@ var s3BucketAttrMap = attributeMapDefinition{
 	"lifecycle_rule":                       domain.StorageBucketLifecycleRulesKey,
 	"policy":                               domain.StorageBucketPolicyKey,
 	"server_side_encryption_configuration": domain.StorageBucketEncryptionKey,
+	"acceleration_status":                  domain.StorageBucketAccelerationStatusKey,
+	"replication_configuration":            domain.StorageBucketReplicationKey,
+	"object_lock_configuration":            domain.StorageBucketObjectLockKey,
 	"tags":                                 domain.KeyTags,
 	"id":                                   domain.KeyID,
 	"arn":                                  domain.KeyARN,
 	"region":                               domain.KeyRegion,
 }
 
+// dbInstanceAttrMap maps aws_db_instance attributes. The instance identifier is
+// used as the ID since it is what the RDS API addresses instances by; the
+// Terraform "id" holds the DbiResourceId in recent provider versions.
+var dbInstanceAttrMap = attributeMapDefinition{
+	"identifier":                          domain.KeyID,
+	"arn":                                 domain.KeyARN,
+	"tags":                                domain.KeyTags,
+	"instance_class":                      domain.DatabaseInstanceClassKey,
+	"engine":                              domain.DatabaseEngineKey,
+	"engine_version":                      domain.DatabaseEngineVersionKey,
+	"allocated_storage":                   domain.DatabaseAllocatedStorageKey,
+	"max_allocated_storage":               domain.DatabaseMaxAllocatedStorageKey,
+	"storage_type":                        domain.DatabaseStorageTypeKey,
+	"iops":                                domain.DatabaseIOPSKey,
+	"multi_az":                            domain.DatabaseMultiAZKey,
+	"availability_zone":                   domain.DatabaseAvailabilityZoneKey,
+	"publicly_accessible":                 domain.DatabasePubliclyAccessibleKey,
+	"storage_encrypted":                   domain.DatabaseStorageEncryptedKey,
+	"kms_key_id":                          domain.DatabaseKMSKeyIDKey,
+	"vpc_security_group_ids":              domain.DatabaseSecurityGroupsKey,
+	"db_subnet_group_name":                domain.DatabaseSubnetGroupKey,
+	"parameter_group_name":                domain.DatabaseParameterGroupKey,
+	"backup_retention_period":             domain.KeyBackupRetentionPeriod,
+	"backup_window":                       domain.KeyBackupWindow,
+	"maintenance_window":                  domain.DatabaseMaintenanceWindowKey,
+	"deletion_protection":                 domain.DatabaseDeletionProtectionKey,
+	"auto_minor_version_upgrade":          domain.DatabaseAutoMinorVersionUpgradeKey,
+	"port":                                domain.DatabasePortKey,
+	"username":                            domain.DatabaseUsernameKey,
+	"iam_database_authentication_enabled": domain.DatabaseIAMAuthenticationKey,
+	"performance_insights_enabled":        domain.DatabasePerformanceInsightsKey,
+	"ca_cert_identifier":                  domain.DatabaseCACertIdentifierKey,
+}
+
+// lambdaFunctionAttrMap maps aws_lambda_function attributes. The function name
+// is used as the ID, matching the Terraform "id".
+var lambdaFunctionAttrMap = attributeMapDefinition{
+	"function_name":     domain.KeyID,
+	"arn":               domain.KeyARN,
+	"tags":              domain.KeyTags,
+	"runtime":           domain.FunctionRuntimeKey,
+	"handler":           domain.FunctionHandlerKey,
+	"memory_size":       domain.FunctionMemorySizeKey,
+	"timeout":           domain.FunctionTimeoutKey,
+	"role":              domain.FunctionRoleKey,
+	"description":       domain.FunctionDescriptionKey,
+	"architectures":     domain.FunctionArchitecturesKey,
+	"package_type":      domain.FunctionPackageTypeKey,
+	"kms_key_arn":       domain.FunctionKMSKeyARNKey,
+	"tracing_config":    domain.FunctionTracingModeKey,
+	"ephemeral_storage": domain.FunctionEphemeralStorageKey,
+	"environment":       domain.FunctionEnvironmentKey,
+	"layers":            domain.FunctionLayersKey,
+	"vpc_config":        domain.FunctionVPCConfigKey,
+}
+
+// iamRoleAttrMap maps aws_iam_role attributes. The role name is used as the ID,
+// matching the Terraform "id". Terraform refreshes inline_policy and
+// managed_policy_arns from IAM, so they also cover policies managed through
+// separate aws_iam_role_policy and aws_iam_role_policy_attachment resources.
+var iamRoleAttrMap = attributeMapDefinition{
+	"name":                 domain.KeyID,
+	"arn":                  domain.KeyARN,
+	"tags":                 domain.KeyTags,
+	"path":                 domain.IAMPathKey,
+	"description":          domain.IAMDescriptionKey,
+	"max_session_duration": domain.IAMMaxSessionDurationKey,
+	"permissions_boundary": domain.IAMPermissionsBoundaryKey,
+	"assume_role_policy":   domain.IAMAssumeRolePolicyKey,
+	"inline_policy":        domain.IAMInlinePoliciesKey,
+	"managed_policy_arns":  domain.IAMManagedPolicyARNsKey,
+}
+
+// iamPolicyAttrMap maps aws_iam_policy attributes. The policy ARN is used as
+// the ID, matching the Terraform "id" and the IAM API.
+var iamPolicyAttrMap = attributeMapDefinition{
+	"id":          domain.KeyID,
+	"arn":         domain.KeyARN,
+	"name":        domain.KeyName,
+	"tags":        domain.KeyTags,
+	"path":        domain.IAMPathKey,
+	"description": domain.IAMDescriptionKey,
+	"policy":      domain.IAMPolicyDocumentKey,
+}
+
+// securityGroupAttrMap maps aws_security_group attributes. Terraform refreshes
+// ingress and egress from EC2, so they also cover rules managed through
+// separate aws_security_group_rule resources.
+var securityGroupAttrMap = attributeMapDefinition{
+	"id":          domain.KeyID,
+	"arn":         domain.KeyARN,
+	"name":        domain.KeyName,
+	"tags":        domain.KeyTags,
+	"description": domain.SecurityGroupDescriptionKey,
+	"vpc_id":      domain.SecurityGroupVPCIDKey,
+	"ingress":     domain.SecurityGroupIngressKey,
+	"egress":      domain.SecurityGroupEgressKey,
+}
+
+// dynamodbTableAttrMap maps aws_dynamodb_table attributes. The table name is
+// used as the ID, matching the Terraform "id".
+var dynamodbTableAttrMap = attributeMapDefinition{
+	"name":                        domain.KeyID,
+	"arn":                         domain.KeyARN,
+	"tags":                        domain.KeyTags,
+	"billing_mode":                domain.TableBillingModeKey,
+	"read_capacity":               domain.TableReadCapacityKey,
+	"write_capacity":              domain.TableWriteCapacityKey,
+	"hash_key":                    domain.TableHashKeyKey,
+	"range_key":                   domain.TableRangeKeyKey,
+	"attribute":                   domain.TableAttributesKey,
+	"global_secondary_index":      domain.TableGlobalSecondaryIndexesKey,
+	"local_secondary_index":       domain.TableLocalSecondaryIndexesKey,
+	"ttl":                         domain.TableTTLKey,
+	"stream_enabled":              domain.TableStreamEnabledKey,
+	"stream_view_type":            domain.TableStreamViewTypeKey,
+	"point_in_time_recovery":      domain.KeyPointInTimeRecovery,
+	"server_side_encryption":      domain.TableServerSideEncryptionKey,
+	"table_class":                 domain.TableClassKey,
+	"deletion_protection_enabled": domain.TableDeletionProtectionKey,
+}
+
+// cloudfrontDistributionAttrMap maps aws_cloudfront_distribution attributes.
+// The minimum protocol version of the viewer_certificate block is lifted out to
+// KeyMinimumProtocolVersion after the mapping.
+var cloudfrontDistributionAttrMap = attributeMapDefinition{
+	"id":                     domain.KeyID,
+	"arn":                    domain.KeyARN,
+	"tags":                   domain.KeyTags,
+	"enabled":                domain.DistributionEnabledKey,
+	"comment":                domain.DistributionCommentKey,
+	"price_class":            domain.DistributionPriceClassKey,
+	"http_version":           domain.DistributionHTTPVersionKey,
+	"is_ipv6_enabled":        domain.DistributionIPv6EnabledKey,
+	"default_root_object":    domain.DistributionDefaultRootObjectKey,
+	"web_acl_id":             domain.DistributionWebACLIDKey,
+	"aliases":                domain.DistributionAliasesKey,
+	"origin":                 domain.DistributionOriginsKey,
+	"origin_group":           domain.DistributionOriginGroupsKey,
+	"default_cache_behavior": domain.DistributionDefaultCacheBehaviorKey,
+	"ordered_cache_behavior": domain.DistributionOrderedCacheBehaviorsKey,
+	"custom_error_response":  domain.DistributionCustomErrorResponsesKey,
+	"viewer_certificate":     domain.DistributionViewerCertificateKey,
+	"logging_config":         domain.DistributionLoggingKey,
+	"restrictions":           domain.DistributionGeoRestrictionKey,
+}
+
+// autoScalingGroupAttrMap maps aws_autoscaling_group attributes. The ID is the
+// group name. Tag blocks are mapped separately, see normalizeASGTags.
+var autoScalingGroupAttrMap = attributeMapDefinition{
+	"id":                        domain.KeyID,
+	"arn":                       domain.KeyARN,
+	"name":                      domain.KeyName,
+	"min_size":                  domain.AutoScalingGroupMinSizeKey,
+	"max_size":                  domain.AutoScalingGroupMaxSizeKey,
+	"desired_capacity":          domain.AutoScalingGroupDesiredCapacityKey,
+	"launch_configuration":      domain.AutoScalingGroupLaunchConfigurationKey,
+	"launch_template":           domain.AutoScalingGroupLaunchTemplateKey,
+	"vpc_zone_identifier":       domain.AutoScalingGroupSubnetsKey,
+	"target_group_arns":         domain.AutoScalingGroupTargetGroupARNsKey,
+	"termination_policies":      domain.AutoScalingGroupTerminationPoliciesKey,
+	"health_check_type":         domain.AutoScalingGroupHealthCheckTypeKey,
+	"health_check_grace_period": domain.AutoScalingGroupHealthCheckGraceKey,
+	"default_cooldown":          domain.AutoScalingGroupDefaultCooldownKey,
+	"capacity_rebalance":        domain.AutoScalingGroupCapacityRebalanceKey,
+	"max_instance_lifetime":     domain.AutoScalingGroupMaxInstanceLifetimeKey,
+}
+
+// launchTemplateAttrMap maps aws_launch_template attributes. The ID is the
+// template ID. Blocks whose shape differs from the aws_instance attributes
+// under the same keys are mapped separately, see normalizeLaunchTemplateData.
+var launchTemplateAttrMap = attributeMapDefinition{
+	"id":                     domain.KeyID,
+	"arn":                    domain.KeyARN,
+	"name":                   domain.KeyName,
+	"tags":                   domain.KeyTags,
+	"description":            domain.LaunchTemplateDescriptionKey,
+	"default_version":        domain.LaunchTemplateDefaultVersionKey,
+	"latest_version":         domain.LaunchTemplateLatestVersionKey,
+	"image_id":               domain.ComputeImageIDKey,
+	"instance_type":          domain.ComputeInstanceTypeKey,
+	"key_name":               domain.LaunchTemplateKeyNameKey,
+	"vpc_security_group_ids": domain.ComputeSecurityGroupsKey,
+	"user_data":              domain.ComputeUserDataKey,
+	"metadata_options":       domain.ComputeMetadataOptionsKey,
+}
+
+// elasticIPAttrMap maps aws_eip attributes. The ID is the allocation ID.
+// Associations made with separate aws_eip_association resources are
+// aggregated into the address, see elasticIPAggregationRules.
+var elasticIPAttrMap = attributeMapDefinition{
+	"id":                domain.KeyID,
+	"arn":               domain.KeyARN,
+	"tags":              domain.KeyTags,
+	"public_ip":         domain.ElasticIPPublicIPKey,
+	"domain":            domain.ElasticIPDomainKey,
+	"instance":          domain.ElasticIPInstanceKey,
+	"network_interface": domain.ElasticIPNetworkInterfaceKey,
+	"private_ip":        domain.ElasticIPPrivateIPKey,
+	"association_id":    domain.ElasticIPAssociationIDKey,
+}
+
+// networkInterfaceAttrMap maps aws_network_interface attributes. The ID is
+// the interface ID. Attachments and security groups managed with separate
+// resources are aggregated into the interface, see
+// networkInterfaceAggregationRules.
+var networkInterfaceAttrMap = attributeMapDefinition{
+	"id":                domain.KeyID,
+	"arn":               domain.KeyARN,
+	"tags":              domain.KeyTags,
+	"subnet_id":         domain.NetworkInterfaceSubnetIDKey,
+	"description":       domain.NetworkInterfaceDescriptionKey,
+	"private_ip":        domain.NetworkInterfacePrivateIPKey,
+	"private_ips":       domain.NetworkInterfacePrivateIPsKey,
+	"security_groups":   domain.NetworkInterfaceSecurityGroupsKey,
+	"source_dest_check": domain.NetworkInterfaceSourceDestCheckKey,
+	"interface_type":    domain.NetworkInterfaceTypeKey,
+	"attachment":        domain.NetworkInterfaceAttachmentKey,
+}
+
+// encryptionKeyAttrMap maps aws_kms_key attributes. The ID is the key ID.
+// Aliases are managed through separate aws_kms_alias resources, which are
+// aggregated into the key, see kmsKeyAggregationRules.
+var encryptionKeyAttrMap = attributeMapDefinition{
+	"id":                       domain.KeyID,
+	"arn":                      domain.KeyARN,
+	"tags":                     domain.KeyTags,
+	"description":              domain.EncryptionKeyDescriptionKey,
+	"is_enabled":               domain.EncryptionKeyEnabledKey,
+	"key_usage":                domain.EncryptionKeyUsageKey,
+	"customer_master_key_spec": domain.EncryptionKeySpecKey,
+	"multi_region":             domain.EncryptionKeyMultiRegionKey,
+	"policy":                   domain.EncryptionKeyPolicyKey,
+	"enable_key_rotation":      domain.EncryptionKeyRotationKey,
+	"rotation_period_in_days":  domain.EncryptionKeyRotationPeriodKey,
+	"deletion_window_in_days":  domain.EncryptionKeyDeletionWindowKey,
+}
+
+// containerClusterAttrMap maps aws_ecs_cluster attributes. The ID is the
+// cluster ARN.
+var containerClusterAttrMap = attributeMapDefinition{
+	"id":      domain.KeyID,
+	"arn":     domain.KeyARN,
+	"name":    domain.KeyName,
+	"tags":    domain.KeyTags,
+	"setting": domain.ContainerClusterSettingsKey,
+}
+
+// containerServiceAttrMap maps aws_ecs_service attributes. The ID is the
+// service ARN.
+var containerServiceAttrMap = attributeMapDefinition{
+	"id":                                 domain.KeyID,
+	"name":                               domain.KeyName,
+	"tags":                               domain.KeyTags,
+	"cluster":                            domain.ContainerServiceClusterKey,
+	"task_definition":                    domain.ContainerServiceTaskDefinitionKey,
+	"desired_count":                      domain.ContainerServiceDesiredCountKey,
+	"launch_type":                        domain.ContainerServiceLaunchTypeKey,
+	"platform_version":                   domain.ContainerServicePlatformVersionKey,
+	"scheduling_strategy":                domain.ContainerServiceSchedulingStrategyKey,
+	"propagate_tags":                     domain.ContainerServicePropagateTagsKey,
+	"enable_execute_command":             domain.ContainerServiceExecuteCommandKey,
+	"health_check_grace_period_seconds":  domain.ContainerServiceHealthCheckGraceKey,
+	"deployment_minimum_healthy_percent": domain.ContainerServiceMinHealthyPercentKey,
+	"deployment_maximum_percent":         domain.ContainerServiceMaxPercentKey,
+	"network_configuration":              domain.ContainerServiceNetworkConfigurationKey,
+	"capacity_provider_strategy":         domain.ContainerServiceCapacityProvidersKey,
+	"load_balancer":                      domain.ContainerServiceLoadBalancersKey,
+}
+
+// taskDefinitionAttrMap maps aws_ecs_task_definition attributes. The ID is
+// the family, which is also used as the name.
+var taskDefinitionAttrMap = attributeMapDefinition{
+	"id":                       domain.KeyID,
+	"family":                   domain.KeyName,
+	"arn":                      domain.KeyARN,
+	"tags":                     domain.KeyTags,
+	"revision":                 domain.TaskDefinitionRevisionKey,
+	"container_definitions":    domain.TaskDefinitionContainerDefinitionsKey,
+	"cpu":                      domain.TaskDefinitionCPUKey,
+	"memory":                   domain.TaskDefinitionMemoryKey,
+	"network_mode":             domain.TaskDefinitionNetworkModeKey,
+	"requires_compatibilities": domain.TaskDefinitionCompatibilitiesKey,
+	"execution_role_arn":       domain.TaskDefinitionExecutionRoleKey,
+	"task_role_arn":            domain.TaskDefinitionTaskRoleKey,
+	"runtime_platform":         domain.TaskDefinitionRuntimePlatformKey,
+}
+
+// loadBalancerAttrMap maps aws_lb attributes. The ID is the load balancer ARN.
+var loadBalancerAttrMap = attributeMapDefinition{
+	"id":                               domain.KeyID,
+	"arn":                              domain.KeyARN,
+	"name":                             domain.KeyName,
+	"tags":                             domain.KeyTags,
+	"internal":                         domain.LoadBalancerInternalKey,
+	"load_balancer_type":               domain.LoadBalancerTypeKey,
+	"ip_address_type":                  domain.LoadBalancerIPAddressTypeKey,
+	"security_groups":                  domain.LoadBalancerSecurityGroupsKey,
+	"subnets":                          domain.LoadBalancerSubnetsKey,
+	"idle_timeout":                     domain.LoadBalancerIdleTimeoutKey,
+	"enable_deletion_protection":       domain.LoadBalancerDeletionProtectionKey,
+	"enable_http2":                     domain.LoadBalancerHTTP2Key,
+	"enable_cross_zone_load_balancing": domain.LoadBalancerCrossZoneKey,
+	"drop_invalid_header_fields":       domain.LoadBalancerDropInvalidHeadersKey,
+	"access_logs":                      domain.LoadBalancerAccessLogsKey,
+}
+
+// applicationLoadBalancerOnlyKeys are recorded by Terraform for every load
+// balancer type but only exist on application load balancers.
+var applicationLoadBalancerOnlyKeys = []string{
+	domain.LoadBalancerIdleTimeoutKey,
+	domain.LoadBalancerHTTP2Key,
+	domain.LoadBalancerDropInvalidHeadersKey,
+}
+
+// listenerAttrMap maps aws_lb_listener attributes. Rules defined as
+// aws_lb_listener_rule resources are aggregated into ListenerRulesKey.
+var listenerAttrMap = attributeMapDefinition{
+	"id":                domain.KeyID,
+	"arn":               domain.KeyARN,
+	"tags":              domain.KeyTags,
+	"load_balancer_arn": domain.ListenerLoadBalancerARNKey,
+	"port":              domain.ListenerPortKey,
+	"protocol":          domain.ListenerProtocolKey,
+	"ssl_policy":        domain.KeySSLPolicy,
+	"certificate_arn":   domain.ListenerCertificateARNKey,
+	"alpn_policy":       domain.ListenerALPNPolicyKey,
+	"default_action":    domain.ListenerDefaultActionsKey,
+}
+
+// targetGroupAttrMap maps aws_lb_target_group attributes. The ID is the
+// target group ARN.
+var targetGroupAttrMap = attributeMapDefinition{
+	"id":                            domain.KeyID,
+	"arn":                           domain.KeyARN,
+	"name":                          domain.KeyName,
+	"tags":                          domain.KeyTags,
+	"port":                          domain.TargetGroupPortKey,
+	"protocol":                      domain.TargetGroupProtocolKey,
+	"protocol_version":              domain.TargetGroupProtocolVersionKey,
+	"target_type":                   domain.TargetGroupTargetTypeKey,
+	"vpc_id":                        domain.TargetGroupVPCIDKey,
+	"health_check":                  domain.TargetGroupHealthCheckKey,
+	"deregistration_delay":          domain.TargetGroupDeregistrationDelayKey,
+	"slow_start":                    domain.TargetGroupSlowStartKey,
+	"load_balancing_algorithm_type": domain.TargetGroupAlgorithmKey,
+	"stickiness":                    domain.TargetGroupStickinessKey,
+}
+
+// googleComputeInstanceAttrMap maps google_compute_instance attributes. GCE
+// labels take the place of tags, so that tag matching works across platforms,
+// and the instance's network tags are kept apart.
+var googleComputeInstanceAttrMap = attributeMapDefinition{
+	"name":                domain.KeyName,
+	"machine_type":        domain.ComputeInstanceTypeKey,
+	"zone":                domain.ComputeAvailabilityZoneKey,
+	"labels":              domain.KeyTags,
+	"tags":                domain.ComputeNetworkTagsKey,
+	"deletion_protection": domain.ComputeDeletionProtectionKey,
+	"id":                  domain.KeyID,
+}
+
+// googleStorageBucketAttrMap maps google_storage_bucket attributes. The bucket
+// name is its ID.
+var googleStorageBucketAttrMap = attributeMapDefinition{
+	"name":                        domain.KeyName,
+	"location":                    domain.StorageBucketLocationKey,
+	"storage_class":               domain.StorageBucketStorageClassKey,
+	"versioning":                  domain.StorageBucketVersioningKey,
+	"uniform_bucket_level_access": domain.StorageBucketUniformAccessKey,
+	"labels":                      domain.KeyTags,
+	"id":                          domain.KeyID,
+}
+
+// azureVirtualMachineAttrMap maps azurerm_linux_virtual_machine and
+// azurerm_windows_virtual_machine attributes.
+var azureVirtualMachineAttrMap = attributeMapDefinition{
+	"name":     domain.KeyName,
+	"size":     domain.ComputeInstanceTypeKey,
+	"zone":     domain.ComputeAvailabilityZoneKey,
+	"location": domain.KeyRegion,
+	"tags":     domain.KeyTags,
+	"id":       domain.KeyID,
+}
+
+// azureStorageAccountAttrMap maps azurerm_storage_account attributes. Version
+// 3 of the azurerm provider names the HTTPS setting enable_https_traffic_only.
+var azureStorageAccountAttrMap = attributeMapDefinition{
+	"name":                            domain.KeyName,
+	"location":                        domain.KeyRegion,
+	"account_tier":                    domain.StorageAccountTierKey,
+	"account_replication_type":        domain.StorageAccountReplicationKey,
+	"account_kind":                    domain.StorageAccountKindKey,
+	"access_tier":                     domain.StorageAccountAccessTierKey,
+	"https_traffic_only_enabled":      domain.StorageAccountHTTPSOnlyKey,
+	"enable_https_traffic_only":       domain.StorageAccountHTTPSOnlyKey,
+	"min_tls_version":                 domain.StorageAccountMinTLSVersionKey,
+	"allow_nested_items_to_be_public": domain.StorageAccountPublicNestedKey,
+	"tags":                            domain.KeyTags,
+	"id":                              domain.KeyID,
+}
+
+// tfTypeAttrMaps holds the attribute maps of Terraform types whose attributes
+// differ from those of the AWS type defining their kind's attribute map.
+var tfTypeAttrMaps = map[string]attributeMapDefinition{
+	"google_compute_instance": googleComputeInstanceAttrMap,
+	"google_storage_bucket":   googleStorageBucketAttrMap,
+
+	"azurerm_linux_virtual_machine":   azureVirtualMachineAttrMap,
+	"azurerm_windows_virtual_machine": azureVirtualMachineAttrMap,
+	"azurerm_storage_account":         azureStorageAccountAttrMap,
+}
+
 func getAttributeMapForKind(kind domain.ResourceKind) attributeMapDefinition {
 	switch kind {
 	case domain.KindComputeInstance:
 		return computeInstanceAttrMap
 	case domain.KindStorageBucket:
 		return s3BucketAttrMap
+	case domain.KindDatabaseInstance:
+		return dbInstanceAttrMap
+	case domain.KindServerlessFunction:
+		return lambdaFunctionAttrMap
+	case domain.KindIAMRole:
+		return iamRoleAttrMap
+	case domain.KindIAMPolicy:
+		return iamPolicyAttrMap
+	case domain.KindNetworkSecurityGroup:
+		return securityGroupAttrMap
+	case domain.KindDatabaseTable:
+		return dynamodbTableAttrMap
+	case domain.KindCDNDistribution:
+		return cloudfrontDistributionAttrMap
+	case domain.KindAutoScalingGroup:
+		return autoScalingGroupAttrMap
+	case domain.KindLaunchTemplate:
+		return launchTemplateAttrMap
+	case domain.KindElasticIP:
+		return elasticIPAttrMap
+	case domain.KindNetworkInterface:
+		return networkInterfaceAttrMap
+	case domain.KindEncryptionKey:
+		return encryptionKeyAttrMap
+	case domain.KindContainerCluster:
+		return containerClusterAttrMap
+	case domain.KindContainerService:
+		return containerServiceAttrMap
+	case domain.KindContainerTaskDefinition:
+		return taskDefinitionAttrMap
+	case domain.KindLoadBalancer:
+		return loadBalancerAttrMap
+	case domain.KindLoadBalancerListener:
+		return listenerAttrMap
+	case domain.KindLoadBalancerTargetGroup:
+		return targetGroupAttrMap
 
 	default:
 		return nil
@@ -68,8 +564,48 @@ func getAttributeMapForKind(kind domain.ResourceKind) attributeMapDefinition {
 }
 
 func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]any, targetAttrs map[string]any) error {
-	attrMap := getAttributeMapForKind(kind)
+	return NormalizeAndCopyTypeAttributes("", kind, rawAttrs, targetAttrs)
+}
+
+// DomainAttributeKeys returns, sorted, the domain attribute keys that the given
+// Terraform attributes of a resource are normalized into. Attributes without a
+// mapping are left out.
+func DomainAttributeKeys(tfType string, kind domain.ResourceKind, tfKeys []string) []string {
+	attrMap, ok := tfTypeAttrMaps[tfType]
+	if !ok {
+		attrMap = getAttributeMapForKind(kind)
+	}
+	var keys []string
+	for _, tfKey := range tfKeys {
+		if attrMap == nil {
+			if IsCustomKind(kind) && tfKey != "tags_all" {
+				keys = append(keys, tfKey)
+			}
+			continue
+		}
+		if domainKey, ok := attrMap[tfKey]; ok {
+			keys = append(keys, domainKey)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NormalizeAndCopyTypeAttributes is NormalizeAndCopyAttributes for a resource of
+// the given Terraform type, which selects the attribute map of types such as
+// google_compute_instance that do not share the attributes of their kind.
+func NormalizeAndCopyTypeAttributes(tfType string, kind domain.ResourceKind, rawAttrs map[string]any, targetAttrs map[string]any) error {
+	attrMap, ok := tfTypeAttrMaps[tfType]
+	if !ok {
+		attrMap = getAttributeMapForKind(kind)
+	}
 	if attrMap == nil {
+		if IsCustomKind(kind) {
+			if rawAttrs == nil {
+				return nil
+			}
+			return copyCustomAttributes(rawAttrs, targetAttrs)
+		}
 		return errors.New(errors.CodeNotImplemented, fmt.Sprintf("no attribute mapping defined for kind: %s", kind))
 	}
 
@@ -97,6 +633,10 @@ func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]an
 			normalizedValue, err = normalizeVersioning(rawValue)
 		case domain.StorageBucketEncryptionKey:
 			normalizedValue, err = normalizeS3Encryption(rawValue)
+		case domain.StorageBucketReplicationKey:
+			normalizedValue, err = normalizeS3Replication(rawValue)
+		case domain.StorageBucketObjectLockKey:
+			normalizedValue, err = normalizeS3ObjectLock(rawValue)
 		case domain.StorageBucketLoggingKey, domain.StorageBucketWebsiteKey:
 			normalizedValue, err = normalizeSingleBlockMap(rawValue)
 		case domain.StorageBucketLifecycleRulesKey, domain.StorageBucketCorsRulesKey:
@@ -108,8 +648,102 @@ func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]an
 			} else {
 				normalizedValue, err = normalizeGenericSliceOfMaps(rawValue)
 			}
-		case domain.ComputeSecurityGroupsKey:
+		case domain.ComputeSecurityGroupsKey, domain.DatabaseSecurityGroupsKey, domain.FunctionArchitecturesKey, domain.FunctionLayersKey:
 			normalizedValue, err = normalizeStringSlice(rawValue)
+		case domain.IAMManagedPolicyARNsKey, domain.ComputeNetworkTagsKey, domain.DistributionAliasesKey, domain.LoadBalancerSubnetsKey,
+			domain.AutoScalingGroupSubnetsKey, domain.AutoScalingGroupTargetGroupARNsKey, domain.TaskDefinitionCompatibilitiesKey,
+			domain.NetworkInterfacePrivateIPsKey:
+			normalizedValue, err = normalizeSortedStringSlice(rawValue)
+		case domain.StorageBucketLocationKey:
+			normalizedValue, err = normalizeUpperString(rawValue)
+		case domain.KeyRegion:
+			normalizedValue, err = normalizeRegion(rawValue)
+		case domain.IAMInlinePoliciesKey:
+			normalizedValue, err = normalizeIAMInlinePolicies(rawValue)
+		case domain.SecurityGroupIngressKey, domain.SecurityGroupEgressKey:
+			normalizedValue, err = normalizeSecurityGroupRules(rawValue)
+		case domain.FunctionEnvironmentKey:
+			normalizedValue, err = normalizeLambdaEnvironment(rawValue)
+		case domain.FunctionVPCConfigKey:
+			normalizedValue, err = normalizeLambdaVPCConfig(rawValue)
+		case domain.FunctionTracingModeKey:
+			normalizedValue, err = normalizeBlockField(rawValue, "mode")
+		case domain.FunctionEphemeralStorageKey:
+			normalizedValue, err = normalizeBlockField(rawValue, "size")
+		case domain.TableAttributesKey:
+			normalizedValue, err = normalizeDynamoDBAttributes(rawValue)
+		case domain.TableGlobalSecondaryIndexesKey, domain.TableLocalSecondaryIndexesKey:
+			normalizedValue, err = normalizeDynamoDBIndexes(rawValue)
+		case domain.TableTTLKey:
+			normalizedValue, err = normalizeDynamoDBTTL(rawValue)
+		case domain.TableServerSideEncryptionKey:
+			normalizedValue, err = normalizeDynamoDBEncryption(rawValue)
+		case domain.KeyPointInTimeRecovery:
+			normalizedValue, err = normalizeBlockField(rawValue, "enabled")
+		case domain.ComputeCreditSpecificationKey:
+			normalizedValue, err = normalizeBlockField(rawValue, "cpu_credits")
+		case domain.ComputeMetadataOptionsKey:
+			normalizedValue, err = normalizeEC2MetadataOptions(rawValue)
+		case domain.DistributionOriginsKey:
+			normalizedValue, err = normalizeCloudFrontOrigins(rawValue)
+		case domain.DistributionOriginGroupsKey:
+			normalizedValue, err = normalizeCloudFrontOriginGroups(rawValue)
+		case domain.DistributionDefaultCacheBehaviorKey:
+			normalizedValue, err = normalizeCloudFrontDefaultCacheBehavior(rawValue)
+		case domain.DistributionOrderedCacheBehaviorsKey:
+			normalizedValue, err = normalizeCloudFrontOrderedCacheBehaviors(rawValue)
+		case domain.DistributionCustomErrorResponsesKey:
+			normalizedValue, err = normalizeCloudFrontErrorResponses(rawValue)
+		case domain.DistributionViewerCertificateKey:
+			normalizedValue, err = normalizeCloudFrontViewerCertificate(rawValue)
+		case domain.DistributionLoggingKey:
+			normalizedValue, err = normalizeCloudFrontLogging(rawValue)
+		case domain.DistributionGeoRestrictionKey:
+			normalizedValue, err = normalizeCloudFrontGeoRestriction(rawValue)
+		case domain.LoadBalancerAccessLogsKey:
+			normalizedValue, err = normalizeLBAccessLogs(rawValue)
+		case domain.ListenerALPNPolicyKey:
+			normalizedValue, err = normalizeALPNPolicy(rawValue)
+		case domain.ListenerDefaultActionsKey:
+			normalizedValue, err = normalizeLBActions(rawValue)
+		case domain.TargetGroupHealthCheckKey:
+			normalizedValue, err = normalizeLBHealthCheck(rawValue)
+		case domain.TargetGroupDeregistrationDelayKey:
+			normalizedValue, err = normalizeNumber(rawValue)
+		case domain.AutoScalingGroupMinSizeKey, domain.AutoScalingGroupMaxSizeKey, domain.AutoScalingGroupDesiredCapacityKey,
+			domain.AutoScalingGroupHealthCheckGraceKey, domain.AutoScalingGroupDefaultCooldownKey,
+			domain.LaunchTemplateDefaultVersionKey, domain.LaunchTemplateLatestVersionKey:
+			normalizedValue, err = normalizeNumber(rawValue)
+		case domain.AutoScalingGroupMaxInstanceLifetimeKey:
+			normalizedValue, err = normalizePositiveNumber(rawValue)
+		case domain.AutoScalingGroupLaunchTemplateKey:
+			normalizedValue, err = normalizeASGLaunchTemplate(rawValue)
+		case domain.AutoScalingGroupTerminationPoliciesKey:
+			normalizedValue, err = normalizeTerminationPolicies(rawValue)
+		case domain.TargetGroupSlowStartKey, domain.EncryptionKeyRotationPeriodKey, domain.EncryptionKeyDeletionWindowKey,
+			domain.ContainerServiceHealthCheckGraceKey:
+			normalizedValue, err = normalizePositiveNumber(rawValue)
+		case domain.ContainerServiceDesiredCountKey, domain.ContainerServiceMinHealthyPercentKey, domain.ContainerServiceMaxPercentKey,
+			domain.TaskDefinitionRevisionKey:
+			normalizedValue, err = normalizeNumber(rawValue)
+		case domain.ContainerClusterSettingsKey:
+			normalizedValue, err = normalizeECSClusterSettings(rawValue)
+		case domain.ContainerServiceClusterKey:
+			normalizedValue, err = normalizeECSClusterName(rawValue)
+		case domain.ContainerServiceTaskDefinitionKey:
+			normalizedValue, err = normalizeECSTaskDefinitionRef(rawValue)
+		case domain.ContainerServiceNetworkConfigurationKey:
+			normalizedValue, err = normalizeECSNetworkConfiguration(rawValue)
+		case domain.ContainerServiceCapacityProvidersKey:
+			normalizedValue, err = normalizeECSCapacityProviders(rawValue)
+		case domain.ContainerServiceLoadBalancersKey:
+			normalizedValue, err = normalizeECSLoadBalancers(rawValue)
+		case domain.TaskDefinitionRuntimePlatformKey:
+			normalizedValue, err = normalizeECSRuntimePlatform(rawValue)
+		case domain.TargetGroupStickinessKey:
+			normalizedValue, err = normalizeLBStickiness(rawValue)
+		case domain.NetworkInterfaceAttachmentKey:
+			normalizedValue, err = normalizeENIAttachment(rawValue)
 		default:
 			normalizedValue = rawValue
 			err = nil
@@ -124,6 +758,38 @@ func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]an
 		}
 	}
 
+	if kind == domain.KindCDNDistribution {
+		if block, _ := normalizeSingleBlockMap(rawAttrs["viewer_certificate"]); block != nil {
+			if version, _ := block["minimum_protocol_version"].(string); version != "" {
+				targetAttrs[domain.KeyMinimumProtocolVersion] = version
+			}
+		}
+	}
+
+	if kind == domain.KindAutoScalingGroup {
+		if err := normalizeASGTags(rawAttrs["tag"], targetAttrs); err != nil {
+			return errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to normalize attribute 'tag' for kind '%s'", kind))
+		}
+	}
+
+	if kind == domain.KindLaunchTemplate {
+		if err := normalizeLaunchTemplateData(rawAttrs, targetAttrs); err != nil {
+			return errors.Wrap(err, errors.CodeMappingError, fmt.Sprintf("failed to normalize launch template data for kind '%s'", kind))
+		}
+	}
+
+	if kind == domain.KindElasticIP || kind == domain.KindNetworkInterface {
+		normalizeEC2NetworkingAttributes(kind, targetAttrs)
+	}
+
+	if kind == domain.KindLoadBalancer {
+		if lbType, _ := targetAttrs[domain.LoadBalancerTypeKey].(string); lbType != "" && lbType != "application" {
+			for _, key := range applicationLoadBalancerOnlyKeys {
+				delete(targetAttrs, key)
+			}
+		}
+	}
+
 	if _, exists := targetAttrs[domain.KeyName]; !exists {
 		if tags, ok := targetAttrs[domain.KeyTags].(map[string]string); ok {
 			if nameVal, nameOk := tags["Name"]; nameOk {
@@ -132,7 +798,7 @@ func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]an
 		}
 	}
 
-	if kind == domain.KindStorageBucket {
+	if kind == domain.KindStorageBucket || kind == domain.KindDatabaseInstance || kind == domain.KindServerlessFunction || kind == domain.KindIAMRole || kind == domain.KindDatabaseTable {
 		if idVal, ok := targetAttrs[domain.KeyID]; ok {
 			if _, nameExists := targetAttrs[domain.KeyName]; !nameExists {
 				targetAttrs[domain.KeyName] = idVal
@@ -143,6 +809,42 @@ func NormalizeAndCopyAttributes(kind domain.ResourceKind, rawAttrs map[string]an
 	return nil
 }
 
+// normalizeEC2NetworkingAttributes drops the empty strings Terraform stores
+// for unassociated Elastic IPs and interfaces without a description, which
+// EC2 leaves out, and gives interfaces without an interface_type the
+// "interface" type EC2 reports for them.
+func normalizeEC2NetworkingAttributes(kind domain.ResourceKind, targetAttrs map[string]any) {
+	for key, value := range targetAttrs {
+		if s, ok := value.(string); ok && s == "" {
+			delete(targetAttrs, key)
+		}
+	}
+	if kind == domain.KindNetworkInterface {
+		if _, ok := targetAttrs[domain.NetworkInterfaceTypeKey]; !ok {
+			targetAttrs[domain.NetworkInterfaceTypeKey] = "interface"
+		}
+	}
+}
+
+// normalizeENIAttachment flattens the attachment block of a network interface
+// to the instance and device index. A block without an instance is no
+// attachment.
+func normalizeENIAttachment(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	attachment := map[string]any{}
+	copyNonEmptyStrings(block, attachment, "instance")
+	if _, ok := attachment["instance"]; !ok {
+		return nil, nil
+	}
+	if err := normalizeNumericField(block, attachment, "device_index"); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
 func normalizeTags(rawVal any) (map[string]string, error) {
 	tagsMap, ok := rawVal.(map[string]any)
 	if !ok {
@@ -194,6 +896,22 @@ func normalizeVersioning(rawVal any) (bool, error) {
 	return enabledBool, nil
 }
 
+func normalizeVersioningConfiguration(rawVal any) (any, error) {
+	list, ok := rawVal.([]any)
+	if !ok || len(list) == 0 {
+		return nil, nil
+	}
+	blockMap, ok := list[0].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("versioning_configuration item is not a map, got %T", list[0])
+	}
+	status, _ := blockMap["status"].(string)
+	if status == "" {
+		return nil, nil
+	}
+	return strings.EqualFold(status, "Enabled"), nil
+}
+
 func normalizeSingleBlockDevice(rawVal any, isRoot bool) (map[string]any, error) {
 	list, ok := rawVal.([]any)
 	if !ok || len(list) == 0 {
@@ -295,6 +1013,367 @@ func normalizeS3Encryption(rawVal any) (map[string]any, error) {
 	return resultMap, nil
 }
 
+// normalizeS3Replication maps a replication configuration, inline on
+// aws_s3_bucket (a single block with "rules") or the attributes of
+// aws_s3_bucket_replication_configuration (with "rule"), to the role and rules
+// the S3 API returns. The filter prefix and tags are folded into the rule.
+func normalizeS3Replication(rawVal any) (map[string]any, error) {
+	cfg, ok := rawVal.(map[string]any)
+	if !ok {
+		block, err := normalizeSingleBlockMap(rawVal)
+		if err != nil || block == nil {
+			return nil, err
+		}
+		cfg = block
+	}
+	rulesRaw, hasRules := cfg["rules"]
+	if !hasRules {
+		rulesRaw = cfg["rule"]
+	}
+	rawRules, err := normalizeGenericSliceOfMaps(rulesRaw)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawRules) == 0 {
+		return nil, nil
+	}
+
+	rules := make([]any, 0, len(rawRules))
+	for _, r := range rawRules {
+		raw := r.(map[string]any)
+		rule := map[string]any{}
+		copyNonEmptyStrings(raw, rule, "id", "status", "prefix")
+		priority, err := normalizePositiveNumber(raw["priority"])
+		if err != nil {
+			return nil, err
+		}
+		if priority != nil {
+			rule["priority"] = priority
+		}
+		filter, err := normalizeSingleBlockMap(raw["filter"])
+		if err != nil {
+			return nil, err
+		}
+		tags := map[string]string{}
+		if and, err := normalizeSingleBlockMap(filter["and"]); err != nil {
+			return nil, err
+		} else if and != nil {
+			filter = and
+		}
+		if prefix, _ := filter["prefix"].(string); prefix != "" {
+			rule["prefix"] = prefix
+		}
+		if tag, err := normalizeSingleBlockMap(filter["tag"]); err != nil {
+			return nil, err
+		} else if key, _ := tag["key"].(string); key != "" {
+			tags[key], _ = tag["value"].(string)
+		}
+		if rawTags, ok := filter["tags"]; ok && rawTags != nil {
+			filterTags, err := normalizeTags(rawTags)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range filterTags {
+				tags[k] = v
+			}
+		}
+		if len(tags) > 0 {
+			rule["tags"] = tags
+		}
+
+		dest, err := normalizeSingleBlockMap(raw["destination"])
+		if err != nil {
+			return nil, err
+		}
+		if dest != nil {
+			destination := map[string]any{}
+			copyNonEmptyStrings(dest, destination, "bucket", "storage_class", "account")
+			if account, _ := dest["account_id"].(string); account != "" {
+				destination["account"] = account
+			}
+			rule["destination"] = destination
+		}
+		if status, _ := raw["delete_marker_replication_status"].(string); status != "" {
+			rule["delete_marker_replication"] = status
+		} else if status, err := normalizeBlockField(raw["delete_marker_replication"], "status"); err != nil {
+			return nil, err
+		} else if s, _ := status.(string); s != "" {
+			rule["delete_marker_replication"] = s
+		}
+		rules = append(rules, rule)
+	}
+
+	result := map[string]any{"rules": rules}
+	copyNonEmptyStrings(cfg, result, "role")
+	return result, nil
+}
+
+// normalizeS3ObjectLock maps an Object Lock configuration, inline on
+// aws_s3_bucket (a single block) or the attributes of
+// aws_s3_bucket_object_lock_configuration, to whether it is enabled and its
+// default retention. Both default object_lock_enabled to "Enabled".
+func normalizeS3ObjectLock(rawVal any) (map[string]any, error) {
+	cfg, ok := rawVal.(map[string]any)
+	if !ok {
+		block, err := normalizeSingleBlockMap(rawVal)
+		if err != nil || block == nil {
+			return nil, err
+		}
+		cfg = block
+	}
+	status, _ := cfg["object_lock_enabled"].(string)
+	result := map[string]any{"enabled": status == "" || status == "Enabled"}
+
+	rule, err := normalizeSingleBlockMap(cfg["rule"])
+	if err != nil {
+		return nil, err
+	}
+	retention, err := normalizeSingleBlockMap(rule["default_retention"])
+	if err != nil {
+		return nil, err
+	}
+	if retention != nil {
+		normalized := map[string]any{}
+		copyNonEmptyStrings(retention, normalized, "mode")
+		for _, key := range []string{"days", "years"} {
+			value, err := normalizePositiveNumber(retention[key])
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				normalized[key] = value
+			}
+		}
+		if len(normalized) > 0 {
+			result["default_retention"] = normalized
+		}
+	}
+	return result, nil
+}
+
+// s3NotificationTargets maps the target blocks of aws_s3_bucket_notification
+// to the domain list each is held in, with the attribute holding the target
+// ARN.
+var s3NotificationTargets = []struct{ block, arnKey, domainKey string }{
+	{"lambda_function", "lambda_function_arn", "lambda_functions"},
+	{"queue", "queue_arn", "queues"},
+	{"topic", "topic_arn", "topics"},
+}
+
+// normalizeS3Notification maps an aws_s3_bucket_notification to the Lambda
+// function, queue and topic targets the S3 API returns, each sorted by ARN
+// with its events sorted, and whether events go to EventBridge.
+func normalizeS3Notification(rawVal any) (map[string]any, error) {
+	cfg, ok := rawVal.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %T", rawVal)
+	}
+	result := map[string]any{}
+	if eventbridge, _ := cfg["eventbridge"].(bool); eventbridge {
+		result["eventbridge"] = true
+	}
+	for _, t := range s3NotificationTargets {
+		blocks, err := normalizeGenericSliceOfMaps(cfg[t.block])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.block, err)
+		}
+		targets := make([]any, 0, len(blocks))
+		for _, b := range blocks {
+			block := b.(map[string]any)
+			events, err := normalizeSortedStringSlice(block["events"])
+			if err != nil {
+				return nil, fmt.Errorf("%s events: %w", t.block, err)
+			}
+			target := map[string]any{"arn": block[t.arnKey], "events": events}
+			copyNonEmptyStrings(block, target, "id", "filter_prefix", "filter_suffix")
+			targets = append(targets, target)
+		}
+		if len(targets) > 0 {
+			sortBlocksByField(targets, "arn")
+			result[t.domainKey] = targets
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// normalizeS3IntelligentTiering maps an
+// aws_s3_bucket_intelligent_tiering_configuration to a configuration keyed by
+// its name, with the tierings sorted by access tier. Status defaults to
+// "Enabled" as in the provider.
+func normalizeS3IntelligentTiering(raw map[string]any) (map[string]any, error) {
+	cfg, err := newS3BucketConfiguration(raw)
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	cfg["status"] = "Enabled"
+	copyNonEmptyStrings(raw, cfg, "status")
+
+	blocks, err := normalizeGenericSliceOfMaps(raw["tiering"])
+	if err != nil {
+		return nil, err
+	}
+	tierings := make([]any, 0, len(blocks))
+	for _, b := range blocks {
+		block := b.(map[string]any)
+		tiering := map[string]any{}
+		copyNonEmptyStrings(block, tiering, "access_tier")
+		if err := normalizeNumericField(block, tiering, "days"); err != nil {
+			return nil, err
+		}
+		tierings = append(tierings, tiering)
+	}
+	sortBlocksByField(tierings, "access_tier")
+	cfg["tierings"] = tierings
+	return cfg, nil
+}
+
+// normalizeS3Analytics maps an aws_s3_bucket_analytics_configuration to a
+// configuration keyed by its name, flattening the nested data export
+// destination to "export".
+func normalizeS3Analytics(raw map[string]any) (map[string]any, error) {
+	cfg, err := newS3BucketConfiguration(raw)
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	dest, err := normalizeNestedBlock(raw["storage_class_analysis"], "data_export", "destination", "s3_bucket_destination")
+	if err != nil {
+		return nil, err
+	}
+	if dest != nil {
+		export := map[string]any{"format": "CSV"}
+		copyNonEmptyStrings(dest, export, "format", "prefix")
+		if bucket, _ := dest["bucket_arn"].(string); bucket != "" {
+			export["bucket"] = bucket
+		}
+		if account, _ := dest["bucket_account_id"].(string); account != "" {
+			export["account"] = account
+		}
+		cfg["export"] = export
+	}
+	return cfg, nil
+}
+
+// normalizeS3Inventory maps an aws_s3_bucket_inventory to a configuration
+// keyed by its name, with the optional fields sorted and the destination
+// bucket and its encryption flattened.
+func normalizeS3Inventory(raw map[string]any) (map[string]any, error) {
+	cfg, err := newS3BucketConfiguration(raw)
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	enabled, ok := raw["enabled"].(bool)
+	cfg["enabled"] = enabled || !ok
+	copyNonEmptyStrings(raw, cfg, "included_object_versions")
+	if frequency, err := normalizeBlockField(raw["schedule"], "frequency"); err != nil {
+		return nil, err
+	} else if f, _ := frequency.(string); f != "" {
+		cfg["frequency"] = f
+	}
+	fields, err := normalizeSortedStringSlice(raw["optional_fields"])
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		cfg["optional_fields"] = fields
+	}
+
+	bucket, err := normalizeNestedBlock(raw["destination"], "bucket")
+	if err != nil {
+		return nil, err
+	}
+	if bucket != nil {
+		dest := map[string]any{}
+		copyNonEmptyStrings(bucket, dest, "format", "prefix")
+		if arn, _ := bucket["bucket_arn"].(string); arn != "" {
+			dest["bucket"] = arn
+		}
+		if account, _ := bucket["account_id"].(string); account != "" {
+			dest["account"] = account
+		}
+		encryption, err := normalizeSingleBlockMap(bucket["encryption"])
+		if err != nil {
+			return nil, err
+		}
+		if kms, err := normalizeSingleBlockMap(encryption["sse_kms"]); err != nil {
+			return nil, err
+		} else if kms != nil {
+			dest["encryption"] = "SSE-KMS"
+			dest["kms_key_id"], _ = kms["key_id"].(string)
+		} else if list, _ := encryption["sse_s3"].([]any); len(list) > 0 {
+			dest["encryption"] = "SSE-S3"
+		}
+		cfg["destination"] = dest
+	}
+	return cfg, nil
+}
+
+// normalizeS3Metric maps an aws_s3_bucket_metric to a configuration keyed by
+// its name.
+func normalizeS3Metric(raw map[string]any) (map[string]any, error) {
+	cfg, err := newS3BucketConfiguration(raw)
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	filter, err := normalizeSingleBlockMap(raw["filter"])
+	if err != nil {
+		return nil, err
+	}
+	if accessPoint, _ := filter["access_point"].(string); accessPoint != "" {
+		cfg["access_point"] = accessPoint
+	}
+	return cfg, nil
+}
+
+// newS3BucketConfiguration starts a bucket configuration from the name and
+// filter shared by the configuration resources: the name becomes "id" and
+// the filter "prefix" and "tags". Resources without a name are skipped.
+func newS3BucketConfiguration(raw map[string]any) (map[string]any, error) {
+	name, _ := raw["name"].(string)
+	if name == "" {
+		return nil, nil
+	}
+	cfg := map[string]any{"id": name}
+	filter, err := normalizeSingleBlockMap(raw["filter"])
+	if err != nil {
+		return nil, err
+	}
+	copyNonEmptyStrings(filter, cfg, "prefix")
+	if rawTags, ok := filter["tags"]; ok && rawTags != nil {
+		tags, err := normalizeTags(rawTags)
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) > 0 {
+			cfg["tags"] = tags
+		}
+	}
+	return cfg, nil
+}
+
+// normalizeNestedBlock descends through single-item blocks, returning the
+// innermost one or nil when any level is absent.
+func normalizeNestedBlock(rawVal any, path ...string) (map[string]any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	for _, key := range path {
+		if err != nil || block == nil {
+			return nil, err
+		}
+		block, err = normalizeSingleBlockMap(block[key])
+	}
+	return block, err
+}
+
+func normalizeS3EncryptionRules(rawVal any) (any, error) {
+	ruleMap, err := normalizeS3Encryption(rawVal)
+	if err != nil || ruleMap == nil {
+		return nil, err
+	}
+	return map[string]any{"rule": []any{ruleMap}}, nil
+}
+
 func normalizeSingleBlockMap(rawVal any) (map[string]any, error) {
 	list, ok := rawVal.([]any)
 	if !ok || len(list) == 0 {
@@ -342,6 +1421,1160 @@ func normalizeStringSlice(rawVal any) ([]string, error) {
 	return resultSlice, nil
 }
 
+// normalizeLambdaEnvironment flattens the environment block to its variables.
+func normalizeLambdaEnvironment(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	vars, ok := block["variables"].(map[string]any)
+	if !ok || len(vars) == 0 {
+		return nil, nil
+	}
+	return normalizeTags(vars)
+}
+
+// normalizeLambdaVPCConfig keeps the subnet and security group IDs of the
+// vpc_config block, sorted, and drops the computed vpc_id. A block without
+// subnets or security groups means the function is not attached to a VPC.
+func normalizeLambdaVPCConfig(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	subnets, err := normalizeStringSlice(block["subnet_ids"])
+	if err != nil {
+		return nil, fmt.Errorf("subnet_ids: %w", err)
+	}
+	securityGroups, err := normalizeStringSlice(block["security_group_ids"])
+	if err != nil {
+		return nil, fmt.Errorf("security_group_ids: %w", err)
+	}
+	if len(subnets) == 0 && len(securityGroups) == 0 {
+		return nil, nil
+	}
+	sort.Strings(subnets)
+	sort.Strings(securityGroups)
+	return map[string]any{"subnet_ids": subnets, "security_group_ids": securityGroups}, nil
+}
+
+// normalizeUpperString upper-cases values the platform reports in upper case
+// but Terraform accepts in any case, such as GCS bucket locations.
+func normalizeUpperString(rawVal any) (any, error) {
+	str, ok := rawVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", rawVal)
+	}
+	return strings.ToUpper(str), nil
+}
+
+// normalizeRegion lower-cases a region and removes its spaces, turning the
+// display names Azure accepts, such as "West Europe", into region names.
+func normalizeRegion(rawVal any) (any, error) {
+	str, ok := rawVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", rawVal)
+	}
+	return strings.ToLower(strings.ReplaceAll(str, " ", "")), nil
+}
+
+// normalizeSortedStringSlice is normalizeStringSlice for sets, whose order in
+// the state carries no meaning.
+func normalizeSortedStringSlice(rawVal any) ([]string, error) {
+	list, err := normalizeStringSlice(rawVal)
+	if err != nil || list == nil {
+		return list, err
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// normalizeEC2MetadataOptions flattens the metadata_options block to the
+// instance metadata settings EC2 reports, dropping empty values.
+func normalizeEC2MetadataOptions(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	options := map[string]any{}
+	copyNonEmptyStrings(block, options, "http_tokens", "http_endpoint", "http_protocol_ipv6", "instance_metadata_tags")
+	if err := copyPositiveNumbers(block, options, "http_put_response_hop_limit"); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// normalizeIAMInlinePolicies turns the inline_policy blocks into a map of policy
+// name to document. Terraform records a single empty block for roles without
+// inline policies, which is dropped.
+func normalizeIAMInlinePolicies(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	policies := make(map[string]string, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		name, _ := block["name"].(string)
+		if name == "" {
+			continue
+		}
+		document, ok := block["policy"].(string)
+		if !ok {
+			return nil, fmt.Errorf("inline_policy '%s' at index %d has no policy document", name, i)
+		}
+		policies[name] = document
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return policies, nil
+}
+
+// securityGroupRuleSources maps the source lists of a Terraform rule block to
+// the key each source gets in a flattened rule.
+var securityGroupRuleSources = []struct{ tfKey, ruleKey string }{
+	{"cidr_blocks", "cidr_block"},
+	{"ipv6_cidr_blocks", "ipv6_cidr_block"},
+	{"prefix_list_ids", "prefix_list_id"},
+	{"security_groups", "security_group"},
+}
+
+// normalizeSecurityGroupRules flattens ingress or egress blocks to one rule per
+// source, the shape the EC2 adapter produces. Descriptions are dropped.
+func normalizeSecurityGroupRules(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	rules := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		base := map[string]any{"protocol": block["protocol"], "from_port": block["from_port"], "to_port": block["to_port"]}
+		newRule := func(key string, value any) map[string]any {
+			rule := make(map[string]any, len(base)+1)
+			for k, v := range base {
+				rule[k] = v
+			}
+			rule[key] = value
+			return rule
+		}
+		for _, source := range securityGroupRuleSources {
+			values, err := normalizeStringSlice(block[source.tfKey])
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d, %s: %w", i, source.tfKey, err)
+			}
+			for _, value := range values {
+				rules = append(rules, newRule(source.ruleKey, value))
+			}
+		}
+		if self, _ := block["self"].(bool); self {
+			rules = append(rules, newRule("self", true))
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+// normalizeDynamoDBAttributes keeps the name and type of each attribute block,
+// sorted by name since Terraform stores them as a set.
+func normalizeDynamoDBAttributes(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	attributes := make([]any, 0, len(blocks))
+	for _, item := range blocks {
+		block := item.(map[string]any)
+		attributes = append(attributes, map[string]any{"name": block["name"], "type": block["type"]})
+	}
+	sortBlocksByName(attributes)
+	return attributes, nil
+}
+
+// normalizeDynamoDBIndexes normalizes global or local secondary index blocks to
+// the shape the DynamoDB adapter produces: sorted by name, numeric capacities,
+// sorted non-key attributes and without empty optional fields.
+func normalizeDynamoDBIndexes(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	indexes := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		index := make(map[string]any)
+		for _, key := range []string{"name", "hash_key", "range_key", "projection_type"} {
+			if value, _ := block[key].(string); value != "" {
+				index[key] = value
+			}
+		}
+		for _, key := range []string{"read_capacity", "write_capacity"} {
+			if err := normalizeNumericField(block, index, key); err != nil {
+				return nil, fmt.Errorf("index at index %d: %w", i, err)
+			}
+		}
+		nonKey, err := normalizeSortedStringSlice(block["non_key_attributes"])
+		if err != nil {
+			return nil, fmt.Errorf("index at index %d, non_key_attributes: %w", i, err)
+		}
+		if len(nonKey) > 0 {
+			index["non_key_attributes"] = nonKey
+		}
+		indexes = append(indexes, index)
+	}
+	sortBlocksByName(indexes)
+	return indexes, nil
+}
+
+// normalizeDynamoDBTTL turns the ttl block into {enabled, attribute_name}.
+func normalizeDynamoDBTTL(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ttl := map[string]any{"enabled": false}
+	if err := normalizeBoolField(block, ttl, "enabled"); err != nil {
+		return nil, err
+	}
+	if name, _ := block["attribute_name"].(string); name != "" {
+		ttl["attribute_name"] = name
+	}
+	return ttl, nil
+}
+
+// normalizeDynamoDBEncryption turns the server_side_encryption block into
+// {enabled, kms_key_arn}. Without the block the table uses the AWS owned key.
+func normalizeDynamoDBEncryption(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	sse := map[string]any{"enabled": false}
+	if err := normalizeBoolField(block, sse, "enabled"); err != nil {
+		return nil, err
+	}
+	if arn, _ := block["kms_key_arn"].(string); arn != "" {
+		sse["kms_key_arn"] = arn
+	}
+	return sse, nil
+}
+
+func sortBlocksByName(blocks []any) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		nameI, _ := blocks[i].(map[string]any)["name"].(string)
+		nameJ, _ := blocks[j].(map[string]any)["name"].(string)
+		return nameI < nameJ
+	})
+}
+
+// normalizeCloudFrontOrigins normalizes the origin blocks to the shape the
+// CloudFront adapter produces: sorted by origin_id, without empty fields, with
+// sorted custom headers and SSL protocols.
+func normalizeCloudFrontOrigins(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	origins := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		origin := make(map[string]any)
+		copyNonEmptyStrings(block, origin, "origin_id", "domain_name", "origin_path", "origin_access_control_id")
+		if err := copyPositiveNumbers(block, origin, "connection_attempts", "connection_timeout"); err != nil {
+			return nil, fmt.Errorf("origin at index %d: %w", i, err)
+		}
+
+		headers, err := normalizeGenericSliceOfMaps(block["custom_header"])
+		if err != nil {
+			return nil, fmt.Errorf("origin at index %d, custom_header: %w", i, err)
+		}
+		if len(headers) > 0 {
+			for j, header := range headers {
+				h := header.(map[string]any)
+				headers[j] = map[string]any{"name": h["name"], "value": h["value"]}
+			}
+			sortBlocksByName(headers)
+			origin["custom_header"] = headers
+		}
+
+		custom, err := normalizeSingleBlockMap(block["custom_origin_config"])
+		if err != nil {
+			return nil, fmt.Errorf("origin at index %d, custom_origin_config: %w", i, err)
+		}
+		if custom != nil {
+			config := make(map[string]any)
+			copyNonEmptyStrings(custom, config, "origin_protocol_policy")
+			if err := copyPositiveNumbers(custom, config, "http_port", "https_port", "origin_keepalive_timeout", "origin_read_timeout"); err != nil {
+				return nil, fmt.Errorf("origin at index %d, custom_origin_config: %w", i, err)
+			}
+			protocols, err := normalizeSortedStringSlice(custom["origin_ssl_protocols"])
+			if err != nil {
+				return nil, fmt.Errorf("origin at index %d, origin_ssl_protocols: %w", i, err)
+			}
+			if len(protocols) > 0 {
+				config["origin_ssl_protocols"] = protocols
+			}
+			origin["custom_origin_config"] = config
+		}
+
+		if s3, _ := normalizeSingleBlockMap(block["s3_origin_config"]); s3 != nil {
+			if identity, _ := s3["origin_access_identity"].(string); identity != "" {
+				origin["s3_origin_config"] = map[string]any{"origin_access_identity": identity}
+			}
+		}
+		if shield, _ := normalizeSingleBlockMap(block["origin_shield"]); shield != nil {
+			if enabled, _ := shield["enabled"].(bool); enabled {
+				origin["origin_shield"] = map[string]any{"enabled": true, "origin_shield_region": shield["origin_shield_region"]}
+			}
+		}
+		origins = append(origins, origin)
+	}
+	sortBlocksByField(origins, "origin_id")
+	return origins, nil
+}
+
+// normalizeCloudFrontOriginGroups turns the origin_group blocks into maps with
+// sorted "status_codes" and the "members" in failover order.
+func normalizeCloudFrontOriginGroups(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	groups := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		group := make(map[string]any)
+		copyNonEmptyStrings(block, group, "origin_id")
+		if criteria, _ := normalizeSingleBlockMap(block["failover_criteria"]); criteria != nil {
+			codes, _ := criteria["status_codes"].([]any)
+			statusCodes := make([]int64, 0, len(codes))
+			for _, code := range codes {
+				normalized := make(map[string]any)
+				if err := normalizeNumericField(map[string]any{"status_code": code}, normalized, "status_code"); err != nil {
+					return nil, fmt.Errorf("origin_group at index %d: %w", i, err)
+				}
+				if value, ok := normalized["status_code"].(int64); ok {
+					statusCodes = append(statusCodes, value)
+				}
+			}
+			if len(statusCodes) > 0 {
+				sort.Slice(statusCodes, func(a, b int) bool { return statusCodes[a] < statusCodes[b] })
+				group["status_codes"] = statusCodes
+			}
+		}
+		members, err := normalizeGenericSliceOfMaps(block["member"])
+		if err != nil {
+			return nil, fmt.Errorf("origin_group at index %d, member: %w", i, err)
+		}
+		if len(members) > 0 {
+			ids := make([]string, 0, len(members))
+			for _, member := range members {
+				id, _ := member.(map[string]any)["origin_id"].(string)
+				ids = append(ids, id)
+			}
+			group["members"] = ids
+		}
+		groups = append(groups, group)
+	}
+	sortBlocksByField(groups, "origin_id")
+	return groups, nil
+}
+
+func normalizeCloudFrontDefaultCacheBehavior(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return normalizeCloudFrontCacheBehavior(block)
+}
+
+// normalizeCloudFrontOrderedCacheBehaviors keeps the ordered_cache_behavior
+// blocks in their order, which is their precedence.
+func normalizeCloudFrontOrderedCacheBehaviors(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	behaviors := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		behavior, err := normalizeCloudFrontCacheBehavior(block)
+		if err != nil {
+			return nil, fmt.Errorf("ordered_cache_behavior at index %d: %w", i, err)
+		}
+		behavior["path_pattern"], _ = block["path_pattern"].(string)
+		behaviors = append(behaviors, behavior)
+	}
+	return behaviors, nil
+}
+
+// normalizeCloudFrontCacheBehavior normalizes a cache behavior block. The
+// legacy TTLs only apply, and are only kept, when no cache policy is set;
+// Terraform records them as zero otherwise.
+func normalizeCloudFrontCacheBehavior(block map[string]any) (map[string]any, error) {
+	behavior := map[string]any{
+		"target_origin_id":       block["target_origin_id"],
+		"viewer_protocol_policy": block["viewer_protocol_policy"],
+	}
+	copyNonEmptyStrings(block, behavior, "cache_policy_id", "origin_request_policy_id", "response_headers_policy_id", "realtime_log_config_arn", "field_level_encryption_id")
+	copyTrueBools(block, behavior, "compress", "smooth_streaming")
+	for _, key := range []string{"allowed_methods", "cached_methods"} {
+		methods, err := normalizeSortedStringSlice(block[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if len(methods) > 0 {
+			behavior[key] = methods
+		}
+	}
+	if _, hasPolicy := behavior["cache_policy_id"]; !hasPolicy {
+		for _, key := range []string{"min_ttl", "default_ttl", "max_ttl"} {
+			behavior[key] = int64(0)
+			if err := normalizeNumericField(block, behavior, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	keyGroups, err := normalizeSortedStringSlice(block["trusted_key_groups"])
+	if err != nil {
+		return nil, fmt.Errorf("trusted_key_groups: %w", err)
+	}
+	if len(keyGroups) > 0 {
+		behavior["trusted_key_groups"] = keyGroups
+	}
+
+	functions, err := normalizeGenericSliceOfMaps(block["function_association"])
+	if err != nil {
+		return nil, fmt.Errorf("function_association: %w", err)
+	}
+	if len(functions) > 0 {
+		for i, item := range functions {
+			function := item.(map[string]any)
+			functions[i] = map[string]any{"event_type": function["event_type"], "function_arn": function["function_arn"]}
+		}
+		sortBlocksByField(functions, "event_type")
+		behavior["function_association"] = functions
+	}
+
+	lambdas, err := normalizeGenericSliceOfMaps(block["lambda_function_association"])
+	if err != nil {
+		return nil, fmt.Errorf("lambda_function_association: %w", err)
+	}
+	if len(lambdas) > 0 {
+		for i, item := range lambdas {
+			lambda := item.(map[string]any)
+			association := map[string]any{"event_type": lambda["event_type"], "lambda_arn": lambda["lambda_arn"]}
+			copyTrueBools(lambda, association, "include_body")
+			lambdas[i] = association
+		}
+		sortBlocksByField(lambdas, "event_type")
+		behavior["lambda_function_association"] = lambdas
+	}
+	return behavior, nil
+}
+
+// normalizeCloudFrontErrorResponses sorts the custom_error_response blocks by
+// error code. Terraform versions before 5 record the response code as a string.
+func normalizeCloudFrontErrorResponses(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	responses := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		response := map[string]any{"error_code": int64(0)}
+		if err := normalizeNumericField(block, response, "error_code"); err != nil {
+			return nil, fmt.Errorf("custom_error_response at index %d: %w", i, err)
+		}
+		if err := copyPositiveNumbers(block, response, "error_caching_min_ttl", "response_code"); err != nil {
+			return nil, fmt.Errorf("custom_error_response at index %d: %w", i, err)
+		}
+		copyNonEmptyStrings(block, response, "response_page_path")
+		responses = append(responses, response)
+	}
+	sort.SliceStable(responses, func(i, j int) bool {
+		codeI, _ := responses[i].(map[string]any)["error_code"].(int64)
+		codeJ, _ := responses[j].(map[string]any)["error_code"].(int64)
+		return codeI < codeJ
+	})
+	return responses, nil
+}
+
+// normalizeCloudFrontViewerCertificate keeps the certificate source and SSL
+// support method; the minimum protocol version is mapped on its own.
+func normalizeCloudFrontViewerCertificate(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	certificate := make(map[string]any)
+	copyTrueBools(block, certificate, "cloudfront_default_certificate")
+	copyNonEmptyStrings(block, certificate, "acm_certificate_arn", "iam_certificate_id", "ssl_support_method")
+	return certificate, nil
+}
+
+func normalizeCloudFrontLogging(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	logging := make(map[string]any)
+	copyNonEmptyStrings(block, logging, "bucket", "prefix")
+	copyTrueBools(block, logging, "include_cookies")
+	return logging, nil
+}
+
+// normalizeCloudFrontGeoRestriction flattens restrictions.geo_restriction to
+// {restriction_type, locations}.
+func normalizeCloudFrontGeoRestriction(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	geo, err := normalizeSingleBlockMap(block["geo_restriction"])
+	if err != nil || geo == nil {
+		return nil, err
+	}
+	restriction := map[string]any{"restriction_type": geo["restriction_type"]}
+	locations, err := normalizeSortedStringSlice(geo["locations"])
+	if err != nil {
+		return nil, fmt.Errorf("locations: %w", err)
+	}
+	if len(locations) > 0 {
+		restriction["locations"] = locations
+	}
+	return restriction, nil
+}
+
+// normalizeLBAccessLogs keeps the bucket and prefix of enabled access logs.
+func normalizeLBAccessLogs(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if enabled, _ := block["enabled"].(bool); !enabled {
+		return nil, nil
+	}
+	accessLogs := map[string]any{"bucket": block["bucket"]}
+	copyNonEmptyStrings(block, accessLogs, "prefix")
+	return accessLogs, nil
+}
+
+// normalizeALPNPolicy drops the "None" policy, which AWS reports as no policy.
+func normalizeALPNPolicy(rawVal any) (any, error) {
+	policy, ok := rawVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", rawVal)
+	}
+	if policy == "" || policy == "None" {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// normalizeLBActions turns default_action or action blocks into flat actions
+// in their order of execution, each with its "type" and the non-empty settings
+// of that type's nested block.
+func normalizeLBActions(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || blocks == nil {
+		return nil, err
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return actionBlockOrder(blocks[i].(map[string]any)) < actionBlockOrder(blocks[j].(map[string]any))
+	})
+	actions := make([]any, 0, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		actionType, _ := block["type"].(string)
+		action := map[string]any{"type": actionType}
+		var nested map[string]any
+		switch actionType {
+		case "forward":
+			if err := normalizeLBForward(block, action); err != nil {
+				return nil, fmt.Errorf("action at index %d: %w", i, err)
+			}
+		case "redirect":
+			if nested, err = normalizeSingleBlockMap(block["redirect"]); err == nil {
+				copyNonEmptyStrings(nested, action, "status_code", "host", "path", "port", "protocol", "query")
+			}
+		case "fixed-response":
+			if nested, err = normalizeSingleBlockMap(block["fixed_response"]); err == nil {
+				copyNonEmptyStrings(nested, action, "status_code", "content_type", "message_body")
+			}
+		case "authenticate-cognito":
+			if nested, err = normalizeSingleBlockMap(block["authenticate_cognito"]); err == nil {
+				copyNonEmptyStrings(nested, action, "user_pool_arn", "user_pool_client_id", "user_pool_domain", "on_unauthenticated_request", "scope", "session_cookie_name")
+				err = copyPositiveNumbers(nested, action, "session_timeout")
+			}
+		case "authenticate-oidc":
+			if nested, err = normalizeSingleBlockMap(block["authenticate_oidc"]); err == nil {
+				copyNonEmptyStrings(nested, action, "issuer", "client_id", "authorization_endpoint", "token_endpoint", "user_info_endpoint", "on_unauthenticated_request", "scope", "session_cookie_name")
+				err = copyPositiveNumbers(nested, action, "session_timeout")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("action at index %d: %w", i, err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func actionBlockOrder(block map[string]any) int64 {
+	order := map[string]any{}
+	if err := normalizeNumericField(block, order, "order"); err == nil {
+		if value, ok := order["order"].(int64); ok && value > 0 {
+			return value
+		}
+	}
+	return 1<<31 - 1
+}
+
+// normalizeLBForward sets the target groups of a forward action, sorted by
+// ARN, from its forward block or, without one, its target_group_arn. Weights
+// are only kept when traffic is split over several groups.
+func normalizeLBForward(block, action map[string]any) error {
+	groups := make([]any, 0)
+	forward, err := normalizeSingleBlockMap(block["forward"])
+	if err != nil {
+		return err
+	}
+	if forward != nil {
+		targets, err := normalizeGenericSliceOfMaps(forward["target_group"])
+		if err != nil {
+			return fmt.Errorf("target_group: %w", err)
+		}
+		for _, item := range targets {
+			target := item.(map[string]any)
+			group := map[string]any{"arn": target["arn"]}
+			if len(targets) > 1 {
+				if err := normalizeNumericField(target, group, "weight"); err != nil {
+					return err
+				}
+			}
+			groups = append(groups, group)
+		}
+		if stickiness, err := normalizeSingleBlockMap(forward["stickiness"]); err != nil {
+			return err
+		} else if enabled, _ := stickiness["enabled"].(bool); enabled {
+			duration := map[string]any{}
+			if err := normalizeNumericField(stickiness, duration, "duration"); err != nil {
+				return err
+			}
+			action["stickiness_duration"] = duration["duration"]
+		}
+	}
+	if arn, _ := block["target_group_arn"].(string); len(groups) == 0 && arn != "" {
+		groups = append(groups, map[string]any{"arn": arn})
+	}
+	sortBlocksByField(groups, "arn")
+	action["target_groups"] = groups
+	return nil
+}
+
+// lbConditionFields maps the nested blocks of aws_lb_listener_rule conditions
+// to the condition field names AWS reports.
+var lbConditionFields = []struct{ block, field string }{
+	{"host_header", "host-header"},
+	{"path_pattern", "path-pattern"},
+	{"http_header", "http-header"},
+	{"http_request_method", "http-request-method"},
+	{"query_string", "query-string"},
+	{"source_ip", "source-ip"},
+}
+
+// normalizeLBConditions turns condition blocks into maps with the condition's
+// "field" and sorted "values", sorted by field and header name. Query string
+// pairs are written as "key=value", or "value" without a key.
+func normalizeLBConditions(rawVal any) ([]any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil {
+		return nil, err
+	}
+	conditions := make([]any, 0, len(blocks))
+	for _, item := range blocks {
+		block := item.(map[string]any)
+		for _, f := range lbConditionFields {
+			nested, err := normalizeGenericSliceOfMaps(block[f.block])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.block, err)
+			}
+			if len(nested) == 0 {
+				continue
+			}
+			condition := map[string]any{"field": f.field}
+			var values []string
+			if f.block == "query_string" {
+				for _, pair := range nested {
+					key, _ := pair.(map[string]any)["key"].(string)
+					value, _ := pair.(map[string]any)["value"].(string)
+					if key != "" {
+						value = key + "=" + value
+					}
+					values = append(values, value)
+				}
+				sort.Strings(values)
+			} else {
+				first := nested[0].(map[string]any)
+				if values, err = normalizeSortedStringSlice(first["values"]); err != nil {
+					return nil, fmt.Errorf("%s values: %w", f.block, err)
+				}
+				if f.block == "http_header" {
+					condition["http_header_name"] = first["http_header_name"]
+				}
+			}
+			if values == nil {
+				values = []string{}
+			}
+			condition["values"] = values
+			conditions = append(conditions, condition)
+		}
+	}
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditionSortKey(conditions[i]) < conditionSortKey(conditions[j])
+	})
+	return conditions, nil
+}
+
+func conditionSortKey(condition any) string {
+	attrs := condition.(map[string]any)
+	return fmt.Sprintf("%v\x00%v", attrs["field"], attrs["http_header_name"])
+}
+
+// normalizeLBHealthCheck keeps the set fields of the health_check block.
+func normalizeLBHealthCheck(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	healthCheck := map[string]any{"enabled": false}
+	if err := normalizeBoolField(block, healthCheck, "enabled"); err != nil {
+		return nil, err
+	}
+	copyNonEmptyStrings(block, healthCheck, "path", "port", "protocol", "matcher")
+	if err := copyPositiveNumbers(block, healthCheck, "interval", "timeout", "healthy_threshold", "unhealthy_threshold"); err != nil {
+		return nil, err
+	}
+	return healthCheck, nil
+}
+
+// normalizeLBStickiness keeps the type of enabled stickiness and, for cookie
+// based stickiness, the cookie duration and application cookie name.
+func normalizeLBStickiness(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if enabled, _ := block["enabled"].(bool); !enabled {
+		return nil, nil
+	}
+	stickinessType, _ := block["type"].(string)
+	stickiness := map[string]any{"type": stickinessType}
+	switch stickinessType {
+	case "lb_cookie":
+		err = normalizeNumericField(block, stickiness, "cookie_duration")
+	case "app_cookie":
+		err = normalizeNumericField(block, stickiness, "cookie_duration")
+		copyNonEmptyStrings(block, stickiness, "cookie_name")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stickiness, nil
+}
+
+// normalizeASGLaunchTemplate keeps the set fields of the launch_template block.
+func normalizeASGLaunchTemplate(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	launchTemplate := map[string]any{}
+	copyNonEmptyStrings(block, launchTemplate, "id", "name", "version")
+	return launchTemplate, nil
+}
+
+// normalizeTerminationPolicies drops the implicit ["Default"] policy list,
+// which AWS reports for groups without termination policies.
+func normalizeTerminationPolicies(rawVal any) (any, error) {
+	policies, err := normalizeStringSlice(rawVal)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 || (len(policies) == 1 && policies[0] == "Default") {
+		return nil, nil
+	}
+	return policies, nil
+}
+
+// normalizeASGTags maps the tag blocks of an Auto Scaling group to the tag
+// map and the sorted keys of the tags propagated at launch.
+func normalizeASGTags(rawVal any, targetAttrs map[string]any) error {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return err
+	}
+	tags := make(map[string]string, len(blocks))
+	var propagated []string
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		key, _ := block["key"].(string)
+		if key == "" {
+			return fmt.Errorf("tag at index %d has no key", i)
+		}
+		tags[key], _ = block["value"].(string)
+		flags := map[string]any{}
+		if err := normalizeBoolField(block, flags, "propagate_at_launch"); err != nil {
+			return err
+		}
+		if propagate, _ := flags["propagate_at_launch"].(bool); propagate {
+			propagated = append(propagated, key)
+		}
+	}
+	targetAttrs[domain.KeyTags] = tags
+	if len(propagated) > 0 {
+		sort.Strings(propagated)
+		targetAttrs[domain.AutoScalingGroupPropagatedTagsKey] = propagated
+	}
+	return nil
+}
+
+// launchTemplateOptionalKeys are the launch template settings Terraform
+// records as an empty string or list when the template does not specify them,
+// which EC2 leaves out.
+var launchTemplateOptionalKeys = []string{
+	domain.LaunchTemplateDescriptionKey, domain.ComputeImageIDKey, domain.ComputeInstanceTypeKey,
+	domain.LaunchTemplateKeyNameKey, domain.ComputeUserDataKey, domain.ComputeSecurityGroupsKey,
+}
+
+// normalizeLaunchTemplateData drops the settings the template leaves
+// unspecified and maps the blocks of the template data to the shape the EC2
+// launch template mapper produces: the instance profile and monitoring blocks
+// are flattened, ebs_optimized ("true", "false" or "") becomes a bool, and
+// block device mappings and tag specifications are sorted.
+func normalizeLaunchTemplateData(rawAttrs, targetAttrs map[string]any) error {
+	for _, key := range launchTemplateOptionalKeys {
+		switch v := targetAttrs[key].(type) {
+		case string:
+			if v == "" {
+				delete(targetAttrs, key)
+			}
+		case []string:
+			if len(v) == 0 {
+				delete(targetAttrs, key)
+			}
+		}
+	}
+	if opts, ok := targetAttrs[domain.ComputeMetadataOptionsKey].(map[string]any); ok && len(opts) == 0 {
+		delete(targetAttrs, domain.ComputeMetadataOptionsKey)
+	}
+
+	if block, err := normalizeSingleBlockMap(rawAttrs["iam_instance_profile"]); err != nil {
+		return err
+	} else if block != nil {
+		profile := map[string]any{}
+		copyNonEmptyStrings(block, profile, "arn", "name")
+		if len(profile) > 0 {
+			targetAttrs[domain.ComputeIAMInstanceProfileKey] = profile
+		}
+	}
+	if block, err := normalizeSingleBlockMap(rawAttrs["monitoring"]); err != nil {
+		return err
+	} else if block != nil {
+		enabled, _ := block["enabled"].(bool)
+		targetAttrs[domain.ComputeMonitoringKey] = enabled
+	}
+	if value := rawAttrs["ebs_optimized"]; value != nil && value != "" {
+		flags := map[string]any{}
+		if err := normalizeBoolField(map[string]any{"ebs_optimized": value}, flags, "ebs_optimized"); err != nil {
+			return err
+		}
+		targetAttrs[domain.ComputeEBSOptimizedKey] = flags["ebs_optimized"]
+	}
+
+	mappings, err := normalizeLaunchTemplateBlockDevices(rawAttrs["block_device_mappings"])
+	if err != nil {
+		return err
+	}
+	if len(mappings) > 0 {
+		targetAttrs[domain.LaunchTemplateBlockDeviceMappingsKey] = mappings
+	}
+	specs, err := normalizeLaunchTemplateTagSpecifications(rawAttrs["tag_specifications"])
+	if err != nil {
+		return err
+	}
+	if len(specs) > 0 {
+		targetAttrs[domain.LaunchTemplateTagSpecificationsKey] = specs
+	}
+	return nil
+}
+
+func normalizeLaunchTemplateBlockDevices(rawVal any) ([]any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	out := make([]any, 0, len(blocks))
+	for _, raw := range blocks {
+		block, _ := raw.(map[string]any)
+		mapping := map[string]any{}
+		copyNonEmptyStrings(block, mapping, "device_name", "no_device", "virtual_name")
+		ebsBlock, err := normalizeSingleBlockMap(block["ebs"])
+		if err != nil {
+			return nil, err
+		}
+		if ebsBlock != nil {
+			volume := map[string]any{}
+			for _, key := range []string{"delete_on_termination", "encrypted"} {
+				if value := ebsBlock[key]; value == nil || value == "" {
+					continue
+				}
+				if err := normalizeBoolField(ebsBlock, volume, key); err != nil {
+					return nil, err
+				}
+			}
+			copyNonEmptyStrings(ebsBlock, volume, "kms_key_id", "snapshot_id", "volume_type")
+			if err := copyPositiveNumbers(ebsBlock, volume, "iops", "throughput", "volume_size"); err != nil {
+				return nil, err
+			}
+			mapping["ebs"] = volume
+		}
+		out = append(out, mapping)
+	}
+	sortBlocksByField(out, "device_name")
+	return out, nil
+}
+
+func normalizeLaunchTemplateTagSpecifications(rawVal any) ([]any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	out := make([]any, 0, len(blocks))
+	for _, raw := range blocks {
+		block, _ := raw.(map[string]any)
+		tags := map[string]string{}
+		if block["tags"] != nil {
+			if tags, err = normalizeTags(block["tags"]); err != nil {
+				return nil, err
+			}
+		}
+		resourceType, _ := block["resource_type"].(string)
+		out = append(out, map[string]any{"resource_type": resourceType, "tags": tags})
+	}
+	sortBlocksByField(out, "resource_type")
+	return out, nil
+}
+
+// normalizeECSClusterSettings maps the setting blocks of a cluster to a map of
+// setting name to value.
+func normalizeECSClusterSettings(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	settings := make(map[string]any, len(blocks))
+	for i, item := range blocks {
+		block := item.(map[string]any)
+		name, _ := block["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("setting at index %d has no name", i)
+		}
+		settings[name], _ = block["value"].(string)
+	}
+	return settings, nil
+}
+
+// normalizeECSClusterName reduces a cluster ARN to the cluster name.
+func normalizeECSClusterName(rawVal any) (any, error) {
+	cluster, ok := rawVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string for cluster, got %T", rawVal)
+	}
+	if _, name, found := strings.Cut(cluster, ":cluster/"); found {
+		return name, nil
+	}
+	return cluster, nil
+}
+
+// normalizeECSTaskDefinitionRef reduces a task definition ARN to
+// "family:revision". A bare family is kept as is.
+func normalizeECSTaskDefinitionRef(rawVal any) (any, error) {
+	taskDefinition, ok := rawVal.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string for task_definition, got %T", rawVal)
+	}
+	if _, ref, found := strings.Cut(taskDefinition, ":task-definition/"); found {
+		return ref, nil
+	}
+	return taskDefinition, nil
+}
+
+// normalizeECSNetworkConfiguration keeps the sorted subnets and security
+// groups of the network_configuration block and whether a public IP is
+// assigned.
+func normalizeECSNetworkConfiguration(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	subnets, err := normalizeSortedStringSlice(block["subnets"])
+	if err != nil {
+		return nil, err
+	}
+	securityGroups, err := normalizeSortedStringSlice(block["security_groups"])
+	if err != nil {
+		return nil, err
+	}
+	network := map[string]any{
+		"subnets":          subnets,
+		"security_groups":  securityGroups,
+		"assign_public_ip": false,
+	}
+	if err := normalizeBoolField(block, network, "assign_public_ip"); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// normalizeECSCapacityProviders keeps the provider, weight and base of each
+// capacity_provider_strategy block, sorted by provider.
+func normalizeECSCapacityProviders(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	strategy := make([]any, 0, len(blocks))
+	for _, item := range blocks {
+		block := item.(map[string]any)
+		provider, _ := block["capacity_provider"].(string)
+		normalized := map[string]any{"capacity_provider": provider, "weight": int64(0), "base": int64(0)}
+		for _, key := range []string{"weight", "base"} {
+			if err := normalizeNumericField(block, normalized, key); err != nil {
+				return nil, err
+			}
+		}
+		strategy = append(strategy, normalized)
+	}
+	sortBlocksByField(strategy, "capacity_provider")
+	return strategy, nil
+}
+
+// normalizeECSLoadBalancers keeps the target group or classic load balancer,
+// container name and port of each load_balancer block, sorted by target
+// group.
+func normalizeECSLoadBalancers(rawVal any) (any, error) {
+	blocks, err := normalizeGenericSliceOfMaps(rawVal)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	loadBalancers := make([]any, 0, len(blocks))
+	for _, item := range blocks {
+		block := item.(map[string]any)
+		normalized := map[string]any{"container_port": int64(0)}
+		normalized["container_name"], _ = block["container_name"].(string)
+		copyNonEmptyStrings(block, normalized, "target_group_arn", "elb_name")
+		if err := normalizeNumericField(block, normalized, "container_port"); err != nil {
+			return nil, err
+		}
+		loadBalancers = append(loadBalancers, normalized)
+	}
+	sortBlocksByField(loadBalancers, "target_group_arn")
+	return loadBalancers, nil
+}
+
+// normalizeECSRuntimePlatform keeps the set fields of the runtime_platform
+// block.
+func normalizeECSRuntimePlatform(rawVal any) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	platform := map[string]any{}
+	copyNonEmptyStrings(block, platform, "operating_system_family", "cpu_architecture")
+	if len(platform) == 0 {
+		return nil, nil
+	}
+	return platform, nil
+}
+
+// normalizeNumber converts a number Terraform records as a string, such as a
+// target group's deregistration_delay, to an int64.
+func normalizeNumber(rawVal any) (any, error) {
+	result := map[string]any{}
+	if err := normalizeNumericField(map[string]any{"value": rawVal}, result, "value"); err != nil {
+		return nil, err
+	}
+	return result["value"], nil
+}
+
+// normalizePositiveNumber is normalizeNumber for settings where zero means off.
+func normalizePositiveNumber(rawVal any) (any, error) {
+	value, err := normalizeNumber(rawVal)
+	if err != nil {
+		return nil, err
+	}
+	if number, ok := value.(int64); ok && number <= 0 {
+		return nil, nil
+	}
+	return value, nil
+}
+
+func copyNonEmptyStrings(src, dest map[string]any, keys ...string) {
+	for _, key := range keys {
+		if value, _ := src[key].(string); value != "" {
+			dest[key] = value
+		}
+	}
+}
+
+func copyTrueBools(src, dest map[string]any, keys ...string) {
+	for _, key := range keys {
+		if value, _ := src[key].(bool); value {
+			dest[key] = true
+		}
+	}
+}
+
+// copyPositiveNumbers copies the numeric fields as int64, leaving out zeros
+// and empty strings.
+func copyPositiveNumbers(src, dest map[string]any, keys ...string) error {
+	for _, key := range keys {
+		if value, ok := src[key].(string); ok && value == "" {
+			continue
+		}
+		if err := normalizeNumericField(src, dest, key); err != nil {
+			return err
+		}
+		if value, ok := dest[key].(int64); ok && value <= 0 {
+			delete(dest, key)
+		}
+	}
+	return nil
+}
+
+func sortBlocksByField(blocks []any, field string) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		valueI, _ := blocks[i].(map[string]any)[field].(string)
+		valueJ, _ := blocks[j].(map[string]any)[field].(string)
+		return valueI < valueJ
+	})
+}
+
+// normalizeBlockField returns a single field of a single-item block.
+func normalizeBlockField(rawVal any, field string) (any, error) {
+	block, err := normalizeSingleBlockMap(rawVal)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return block[field], nil
+}
+
 func copyIfPresentMap(src, dest map[string]any, key string) {
 	if val, ok := src[key]; ok && val != nil {
 		dest[key] = val
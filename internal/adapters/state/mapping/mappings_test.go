@@ -19,6 +19,10 @@ func TestMapTfTypeToDomainKind(t *testing.T) {
 		{"aws_instance", "aws_instance", domain.KindComputeInstance, false},
 		{"aws_s3_bucket", "aws_s3_bucket", domain.KindStorageBucket, false},
 		{"aws_db_instance", "aws_db_instance", domain.KindDatabaseInstance, false},
+		{"google_compute_instance", "google_compute_instance", domain.KindComputeInstance, false},
+		{"google_storage_bucket", "google_storage_bucket", domain.KindStorageBucket, false},
+		{"azurerm_linux_virtual_machine", "azurerm_linux_virtual_machine", domain.KindComputeInstance, false},
+		{"azurerm_storage_account", "azurerm_storage_account", domain.KindStorageBucket, false},
 		{"unsupported_type", "aws_vpc", "", true},
 		{"empty_type", "", "", true},
 	}
@@ -49,6 +53,10 @@ func TestNormalizeAndCopyAttributes_ComputeInstance(t *testing.T) {
 			"ami":                    "ami-abc",
 			"subnet_id":              "subnet-abc",
 			"availability_zone":      "us-east-1a",
+			"placement_group":        "pg-cluster",
+			"tenancy":                "dedicated",
+			"ebs_optimized":          true,
+			"credit_specification":   []any{map[string]any{"cpu_credits": "unlimited"}},
 			"vpc_security_group_ids": []any{"sg-abc", "sg-def"},
 			"iam_instance_profile":   "profile-name",
 			"user_data":              "some data",
@@ -75,6 +83,10 @@ func TestNormalizeAndCopyAttributes_ComputeInstance(t *testing.T) {
 		assert.Equal(t, "ami-abc", targetAttrs[domain.ComputeImageIDKey])
 		assert.Equal(t, "subnet-abc", targetAttrs[domain.ComputeSubnetIDKey])
 		assert.Equal(t, "us-east-1a", targetAttrs[domain.ComputeAvailabilityZoneKey])
+		assert.Equal(t, "pg-cluster", targetAttrs[domain.ComputePlacementGroupKey])
+		assert.Equal(t, "dedicated", targetAttrs[domain.ComputeTenancyKey])
+		assert.Equal(t, true, targetAttrs[domain.ComputeEBSOptimizedKey])
+		assert.Equal(t, "unlimited", targetAttrs[domain.ComputeCreditSpecificationKey])
 		assert.Equal(t, "profile-name", targetAttrs[domain.ComputeIAMInstanceProfileKey])
 		assert.Equal(t, "some data", targetAttrs[domain.ComputeUserDataKey])
 		assert.Equal(t, []string{"sg-abc", "sg-def"}, targetAttrs[domain.ComputeSecurityGroupsKey])
@@ -268,9 +280,882 @@ func TestNormalizeAndCopyAttributes_S3Bucket(t *testing.T) {
 	})
 }
 
+func TestNormalizeAndCopyAttributes_DBInstance(t *testing.T) {
+	kind := domain.KindDatabaseInstance
+
+	t.Run("Full Attributes", func(t *testing.T) {
+		rawAttrs := map[string]any{
+			"id":                      "db-ABCDEFGHIJKL",
+			"identifier":              "orders-db",
+			"arn":                     "arn:aws:rds:us-east-1:123456789012:db:orders-db",
+			"instance_class":          "db.t3.medium",
+			"engine":                  "postgres",
+			"engine_version":          "15",
+			"allocated_storage":       100.0,
+			"multi_az":                true,
+			"backup_retention_period": 7.0,
+			"backup_window":           "03:00-04:00",
+			"vpc_security_group_ids":  []any{"sg-b", "sg-a"},
+			"tags":                    map[string]any{"Env": "prod"},
+		}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+
+		assert.Equal(t, "orders-db", targetAttrs[domain.KeyID])
+		assert.Equal(t, "orders-db", targetAttrs[domain.KeyName])
+		assert.Equal(t, "db.t3.medium", targetAttrs[domain.DatabaseInstanceClassKey])
+		assert.Equal(t, "15", targetAttrs[domain.DatabaseEngineVersionKey])
+		assert.Equal(t, 100.0, targetAttrs[domain.DatabaseAllocatedStorageKey])
+		assert.Equal(t, true, targetAttrs[domain.DatabaseMultiAZKey])
+		assert.Equal(t, 7.0, targetAttrs[domain.KeyBackupRetentionPeriod])
+		assert.Equal(t, "03:00-04:00", targetAttrs[domain.KeyBackupWindow])
+		assert.ElementsMatch(t, []string{"sg-a", "sg-b"}, targetAttrs[domain.DatabaseSecurityGroupsKey])
+		assert.Equal(t, map[string]string{"Env": "prod"}, targetAttrs[domain.KeyTags])
+	})
+
+	t.Run("Name From Tag", func(t *testing.T) {
+		rawAttrs := map[string]any{"identifier": "orders-db", "tags": map[string]any{"Name": "Orders"}}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+		assert.Equal(t, "Orders", targetAttrs[domain.KeyName])
+	})
+}
+
+func TestNormalizeAndCopyAttributes_LambdaFunction(t *testing.T) {
+	kind := domain.KindServerlessFunction
+
+	t.Run("Full Attributes", func(t *testing.T) {
+		rawAttrs := map[string]any{
+			"id":                "orders-api",
+			"function_name":     "orders-api",
+			"arn":               "arn:aws:lambda:us-east-1:123456789012:function:orders-api",
+			"runtime":           "python3.12",
+			"handler":           "app.handler",
+			"memory_size":       256.0,
+			"timeout":           30.0,
+			"architectures":     []any{"arm64"},
+			"layers":            []any{"arn:aws:lambda:us-east-1:123456789012:layer:deps:3"},
+			"environment":       []any{map[string]any{"variables": map[string]any{"STAGE": "prod"}}},
+			"tracing_config":    []any{map[string]any{"mode": "Active"}},
+			"ephemeral_storage": []any{map[string]any{"size": 512.0}},
+			"vpc_config": []any{map[string]any{
+				"subnet_ids":         []any{"subnet-b", "subnet-a"},
+				"security_group_ids": []any{"sg-1"},
+				"vpc_id":             "vpc-1",
+			}},
+		}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+
+		assert.Equal(t, "orders-api", targetAttrs[domain.KeyID])
+		assert.Equal(t, "orders-api", targetAttrs[domain.KeyName])
+		assert.Equal(t, "python3.12", targetAttrs[domain.FunctionRuntimeKey])
+		assert.Equal(t, 256.0, targetAttrs[domain.FunctionMemorySizeKey])
+		assert.Equal(t, []string{"arm64"}, targetAttrs[domain.FunctionArchitecturesKey])
+		assert.Equal(t, []string{"arn:aws:lambda:us-east-1:123456789012:layer:deps:3"}, targetAttrs[domain.FunctionLayersKey])
+		assert.Equal(t, map[string]string{"STAGE": "prod"}, targetAttrs[domain.FunctionEnvironmentKey])
+		assert.Equal(t, "Active", targetAttrs[domain.FunctionTracingModeKey])
+		assert.Equal(t, 512.0, targetAttrs[domain.FunctionEphemeralStorageKey])
+		assert.Equal(t, map[string]any{
+			"subnet_ids":         []string{"subnet-a", "subnet-b"},
+			"security_group_ids": []string{"sg-1"},
+		}, targetAttrs[domain.FunctionVPCConfigKey])
+	})
+
+	t.Run("Empty Blocks", func(t *testing.T) {
+		rawAttrs := map[string]any{
+			"function_name": "orders-api",
+			"environment":   []any{},
+			"vpc_config":    []any{map[string]any{"subnet_ids": []any{}, "security_group_ids": []any{}, "vpc_id": ""}},
+		}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+		assert.NotContains(t, targetAttrs, domain.FunctionEnvironmentKey)
+		assert.NotContains(t, targetAttrs, domain.FunctionVPCConfigKey)
+	})
+}
+
+func TestNormalizeAndCopyAttributes_IAMRole(t *testing.T) {
+	kind := domain.KindIAMRole
+	trustPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"lambda.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+
+	t.Run("Full Attributes", func(t *testing.T) {
+		rawAttrs := map[string]any{
+			"id":                   "orders-api",
+			"name":                 "orders-api",
+			"arn":                  "arn:aws:iam::123456789012:role/orders-api",
+			"path":                 "/",
+			"max_session_duration": 3600.0,
+			"assume_role_policy":   trustPolicy,
+			"inline_policy": []any{
+				map[string]any{"name": "read-orders", "policy": `{"Statement":[]}`},
+			},
+			"managed_policy_arns": []any{
+				"arn:aws:iam::aws:policy/ReadOnlyAccess",
+				"arn:aws:iam::aws:policy/AWSLambdaExecute",
+			},
+		}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+
+		assert.Equal(t, "orders-api", targetAttrs[domain.KeyID])
+		assert.Equal(t, "orders-api", targetAttrs[domain.KeyName])
+		assert.Equal(t, trustPolicy, targetAttrs[domain.IAMAssumeRolePolicyKey])
+		assert.Equal(t, 3600.0, targetAttrs[domain.IAMMaxSessionDurationKey])
+		assert.Equal(t, map[string]string{"read-orders": `{"Statement":[]}`}, targetAttrs[domain.IAMInlinePoliciesKey])
+		assert.Equal(t, []string{
+			"arn:aws:iam::aws:policy/AWSLambdaExecute",
+			"arn:aws:iam::aws:policy/ReadOnlyAccess",
+		}, targetAttrs[domain.IAMManagedPolicyARNsKey])
+	})
+
+	t.Run("Empty Inline Policy Block", func(t *testing.T) {
+		rawAttrs := map[string]any{
+			"name":          "orders-api",
+			"inline_policy": []any{map[string]any{"name": "", "policy": ""}},
+		}
+		targetAttrs := make(map[string]any)
+		err := NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs)
+		require.NoError(t, err)
+		assert.NotContains(t, targetAttrs, domain.IAMInlinePoliciesKey)
+	})
+}
+
+func TestNormalizeAndCopyAttributes_IAMPolicy(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":     "arn:aws:iam::123456789012:policy/read-orders",
+		"arn":    "arn:aws:iam::123456789012:policy/read-orders",
+		"name":   "read-orders",
+		"path":   "/",
+		"policy": `{"Version":"2012-10-17","Statement":[]}`,
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindIAMPolicy, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "arn:aws:iam::123456789012:policy/read-orders", targetAttrs[domain.KeyID])
+	assert.Equal(t, "read-orders", targetAttrs[domain.KeyName])
+	assert.Equal(t, `{"Version":"2012-10-17","Statement":[]}`, targetAttrs[domain.IAMPolicyDocumentKey])
+}
+
+func TestNormalizeAndCopyAttributes_SecurityGroup(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":     "sg-123",
+		"name":   "web",
+		"vpc_id": "vpc-1",
+		"ingress": []any{
+			map[string]any{
+				"protocol":         "tcp",
+				"from_port":        443.0,
+				"to_port":          443.0,
+				"cidr_blocks":      []any{"10.0.0.0/8", "192.168.0.0/16"},
+				"ipv6_cidr_blocks": []any{},
+				"prefix_list_ids":  []any{},
+				"security_groups":  []any{"sg-lb"},
+				"self":             true,
+				"description":      "https",
+			},
+		},
+		"egress": []any{},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindNetworkSecurityGroup, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sg-123", targetAttrs[domain.KeyID])
+	assert.Equal(t, "web", targetAttrs[domain.KeyName])
+	base := func(key string, value any) map[string]any {
+		return map[string]any{"protocol": "tcp", "from_port": 443.0, "to_port": 443.0, key: value}
+	}
+	assert.Equal(t, []any{
+		base("cidr_block", "10.0.0.0/8"),
+		base("cidr_block", "192.168.0.0/16"),
+		base("security_group", "sg-lb"),
+		base("self", true),
+	}, targetAttrs[domain.SecurityGroupIngressKey])
+	assert.NotContains(t, targetAttrs, domain.SecurityGroupEgressKey)
+}
+
+func TestNormalizeAndCopyAttributes_DynamoDBTable(t *testing.T) {
+	rawAttrs := map[string]any{
+		"name":           "orders",
+		"arn":            "arn:aws:dynamodb:us-east-1:123456789012:table/orders",
+		"billing_mode":   "PROVISIONED",
+		"read_capacity":  5.0,
+		"write_capacity": 10.0,
+		"hash_key":       "pk",
+		"range_key":      "",
+		"attribute": []any{
+			map[string]any{"name": "status", "type": "S"},
+			map[string]any{"name": "pk", "type": "S"},
+		},
+		"global_secondary_index": []any{
+			map[string]any{
+				"name":               "by-status",
+				"hash_key":           "status",
+				"range_key":          "",
+				"projection_type":    "INCLUDE",
+				"non_key_attributes": []any{"total", "created_at"},
+				"read_capacity":      1.0,
+				"write_capacity":     1.0,
+			},
+			map[string]any{"name": "by-customer", "hash_key": "customer", "projection_type": "ALL", "read_capacity": 0.0, "write_capacity": 0.0},
+		},
+		"local_secondary_index":  []any{},
+		"ttl":                    []any{map[string]any{"enabled": true, "attribute_name": "expires_at"}},
+		"point_in_time_recovery": []any{map[string]any{"enabled": true}},
+		"server_side_encryption": []any{},
+		"stream_enabled":         false,
+		"stream_view_type":       "",
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindDatabaseTable, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", targetAttrs[domain.KeyID])
+	assert.Equal(t, "orders", targetAttrs[domain.KeyName])
+	assert.Equal(t, []any{
+		map[string]any{"name": "pk", "type": "S"},
+		map[string]any{"name": "status", "type": "S"},
+	}, targetAttrs[domain.TableAttributesKey])
+	assert.Equal(t, []any{
+		map[string]any{"name": "by-customer", "hash_key": "customer", "projection_type": "ALL", "read_capacity": int64(0), "write_capacity": int64(0)},
+		map[string]any{"name": "by-status", "hash_key": "status", "projection_type": "INCLUDE", "non_key_attributes": []string{"created_at", "total"}, "read_capacity": int64(1), "write_capacity": int64(1)},
+	}, targetAttrs[domain.TableGlobalSecondaryIndexesKey])
+	assert.NotContains(t, targetAttrs, domain.TableLocalSecondaryIndexesKey)
+	assert.Equal(t, map[string]any{"enabled": true, "attribute_name": "expires_at"}, targetAttrs[domain.TableTTLKey])
+	assert.Equal(t, true, targetAttrs[domain.KeyPointInTimeRecovery])
+	assert.NotContains(t, targetAttrs, domain.TableServerSideEncryptionKey)
+}
+
+func TestNormalizeAndCopyTypeAttributes_GoogleComputeInstance(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                  "projects/acme/zones/us-central1-a/instances/web-1",
+		"name":                "web-1",
+		"machine_type":        "e2-medium",
+		"zone":                "us-central1-a",
+		"labels":              map[string]any{"env": "prod"},
+		"tags":                []any{"web", "http-server"},
+		"deletion_protection": true,
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("google_compute_instance", domain.KindComputeInstance, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "projects/acme/zones/us-central1-a/instances/web-1", targetAttrs[domain.KeyID])
+	assert.Equal(t, "web-1", targetAttrs[domain.KeyName])
+	assert.Equal(t, "e2-medium", targetAttrs[domain.ComputeInstanceTypeKey])
+	assert.Equal(t, "us-central1-a", targetAttrs[domain.ComputeAvailabilityZoneKey])
+	assert.Equal(t, map[string]string{"env": "prod"}, targetAttrs[domain.KeyTags])
+	assert.Equal(t, []string{"http-server", "web"}, targetAttrs[domain.ComputeNetworkTagsKey])
+	assert.Equal(t, true, targetAttrs[domain.ComputeDeletionProtectionKey])
+}
+
+func TestNormalizeAndCopyTypeAttributes_GoogleStorageBucket(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                          "acme-assets",
+		"name":                        "acme-assets",
+		"location":                    "us-central1",
+		"storage_class":               "STANDARD",
+		"versioning":                  []any{map[string]any{"enabled": true}},
+		"uniform_bucket_level_access": true,
+		"labels":                      map[string]any{"team": "web"},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("google_storage_bucket", domain.KindStorageBucket, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme-assets", targetAttrs[domain.KeyName])
+	assert.Equal(t, "US-CENTRAL1", targetAttrs[domain.StorageBucketLocationKey])
+	assert.Equal(t, "STANDARD", targetAttrs[domain.StorageBucketStorageClassKey])
+	assert.Equal(t, true, targetAttrs[domain.StorageBucketVersioningKey])
+	assert.Equal(t, true, targetAttrs[domain.StorageBucketUniformAccessKey])
+	assert.Equal(t, map[string]string{"team": "web"}, targetAttrs[domain.KeyTags])
+	assert.NotContains(t, targetAttrs, domain.KeyRegion)
+}
+
+func TestNormalizeAndCopyTypeAttributes_AzureVirtualMachine(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":       "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Compute/virtualMachines/web-1",
+		"name":     "web-1",
+		"size":     "Standard_B2s",
+		"zone":     "2",
+		"location": "West Europe",
+		"tags":     map[string]any{"env": "prod"},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("azurerm_windows_virtual_machine", domain.KindComputeInstance, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web-1", targetAttrs[domain.KeyName])
+	assert.Equal(t, "Standard_B2s", targetAttrs[domain.ComputeInstanceTypeKey])
+	assert.Equal(t, "2", targetAttrs[domain.ComputeAvailabilityZoneKey])
+	assert.Equal(t, "westeurope", targetAttrs[domain.KeyRegion])
+	assert.Equal(t, map[string]string{"env": "prod"}, targetAttrs[domain.KeyTags])
+}
+
+func TestNormalizeAndCopyTypeAttributes_AzureStorageAccount(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                        "/subscriptions/sub-1/resourceGroups/web/providers/Microsoft.Storage/storageAccounts/acmeassets",
+		"name":                      "acmeassets",
+		"location":                  "westeurope",
+		"account_tier":              "Standard",
+		"account_replication_type":  "GRS",
+		"account_kind":              "StorageV2",
+		"access_tier":               "Hot",
+		"enable_https_traffic_only": true,
+		"min_tls_version":           "TLS1_2",
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("azurerm_storage_account", domain.KindStorageBucket, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acmeassets", targetAttrs[domain.KeyName])
+	assert.Equal(t, "westeurope", targetAttrs[domain.KeyRegion])
+	assert.Equal(t, "Standard", targetAttrs[domain.StorageAccountTierKey])
+	assert.Equal(t, "GRS", targetAttrs[domain.StorageAccountReplicationKey])
+	assert.Equal(t, "StorageV2", targetAttrs[domain.StorageAccountKindKey])
+	assert.Equal(t, "Hot", targetAttrs[domain.StorageAccountAccessTierKey])
+	assert.Equal(t, true, targetAttrs[domain.StorageAccountHTTPSOnlyKey], "the azurerm v3 attribute name is mapped too")
+	assert.Equal(t, "TLS1_2", targetAttrs[domain.StorageAccountMinTLSVersionKey])
+}
+
+func TestNormalizeAndCopyAttributes_CloudFrontDistribution(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                  "E2QWRUHEXAMPLE",
+		"enabled":             true,
+		"comment":             "",
+		"aliases":             []any{"www.example.com", "example.com"},
+		"default_root_object": "index.html",
+		"origin": []any{
+			map[string]any{
+				"origin_id":                "web",
+				"domain_name":              "web.example.com",
+				"origin_path":              "",
+				"origin_access_control_id": "",
+				"connection_attempts":      3.0,
+				"connection_timeout":       10.0,
+				"custom_header": []any{
+					map[string]any{"name": "X-Secret", "value": "s"},
+					map[string]any{"name": "X-Origin", "value": "cdn"},
+				},
+				"custom_origin_config": []any{map[string]any{
+					"http_port":                80.0,
+					"https_port":               443.0,
+					"origin_protocol_policy":   "https-only",
+					"origin_ssl_protocols":     []any{"TLSv1.2", "TLSv1.1"},
+					"origin_keepalive_timeout": 0.0,
+				}},
+				"s3_origin_config": []any{},
+				"origin_shield":    []any{},
+			},
+			map[string]any{
+				"origin_id":                "assets",
+				"domain_name":              "assets.s3.amazonaws.com",
+				"origin_access_control_id": "E3OAC",
+				"s3_origin_config":         []any{map[string]any{"origin_access_identity": ""}},
+			},
+		},
+		"default_cache_behavior": []any{map[string]any{
+			"target_origin_id":       "web",
+			"viewer_protocol_policy": "redirect-to-https",
+			"allowed_methods":        []any{"HEAD", "GET"},
+			"cached_methods":         []any{"HEAD", "GET"},
+			"cache_policy_id":        "658327ea",
+			"compress":               true,
+			"smooth_streaming":       false,
+			"min_ttl":                0.0,
+			"default_ttl":            0.0,
+			"max_ttl":                0.0,
+			"trusted_key_groups":     []any{},
+			"function_association": []any{
+				map[string]any{"event_type": "viewer-request", "function_arn": "arn:aws:cloudfront::123456789012:function/rewrite"},
+			},
+		}},
+		"ordered_cache_behavior": []any{
+			map[string]any{"path_pattern": "/static/*", "target_origin_id": "assets", "viewer_protocol_policy": "https-only", "min_ttl": 0.0, "default_ttl": 86400.0, "max_ttl": 31536000.0},
+			map[string]any{"path_pattern": "/*", "target_origin_id": "web", "viewer_protocol_policy": "allow-all", "cache_policy_id": "4135ea2d"},
+		},
+		"custom_error_response": []any{
+			map[string]any{"error_code": 404.0, "response_code": 200.0, "response_page_path": "/index.html", "error_caching_min_ttl": 10.0},
+			map[string]any{"error_code": 403.0, "response_code": "", "response_page_path": "", "error_caching_min_ttl": 10.0},
+		},
+		"viewer_certificate": []any{map[string]any{
+			"acm_certificate_arn":            "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			"cloudfront_default_certificate": false,
+			"iam_certificate_id":             "",
+			"ssl_support_method":             "sni-only",
+			"minimum_protocol_version":       "TLSv1.2_2021",
+		}},
+		"logging_config": []any{map[string]any{"bucket": "logs.s3.amazonaws.com", "prefix": "cdn/", "include_cookies": false}},
+		"restrictions": []any{map[string]any{
+			"geo_restriction": []any{map[string]any{"restriction_type": "blacklist", "locations": []any{"RU", "KP"}}},
+		}},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindCDNDistribution, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "E2QWRUHEXAMPLE", targetAttrs[domain.KeyID])
+	assert.Equal(t, []string{"example.com", "www.example.com"}, targetAttrs[domain.DistributionAliasesKey])
+	assert.Equal(t, []any{
+		map[string]any{"origin_id": "assets", "domain_name": "assets.s3.amazonaws.com", "origin_access_control_id": "E3OAC"},
+		map[string]any{
+			"origin_id":           "web",
+			"domain_name":         "web.example.com",
+			"connection_attempts": int64(3),
+			"connection_timeout":  int64(10),
+			"custom_header": []any{
+				map[string]any{"name": "X-Origin", "value": "cdn"},
+				map[string]any{"name": "X-Secret", "value": "s"},
+			},
+			"custom_origin_config": map[string]any{
+				"http_port":              int64(80),
+				"https_port":             int64(443),
+				"origin_protocol_policy": "https-only",
+				"origin_ssl_protocols":   []string{"TLSv1.1", "TLSv1.2"},
+			},
+		},
+	}, targetAttrs[domain.DistributionOriginsKey])
+	assert.Equal(t, map[string]any{
+		"target_origin_id":       "web",
+		"viewer_protocol_policy": "redirect-to-https",
+		"allowed_methods":        []string{"GET", "HEAD"},
+		"cached_methods":         []string{"GET", "HEAD"},
+		"compress":               true,
+		"cache_policy_id":        "658327ea",
+		"function_association": []any{
+			map[string]any{"event_type": "viewer-request", "function_arn": "arn:aws:cloudfront::123456789012:function/rewrite"},
+		},
+	}, targetAttrs[domain.DistributionDefaultCacheBehaviorKey])
+	assert.Equal(t, []any{
+		map[string]any{"path_pattern": "/static/*", "target_origin_id": "assets", "viewer_protocol_policy": "https-only", "min_ttl": int64(0), "default_ttl": int64(86400), "max_ttl": int64(31536000)},
+		map[string]any{"path_pattern": "/*", "target_origin_id": "web", "viewer_protocol_policy": "allow-all", "cache_policy_id": "4135ea2d"},
+	}, targetAttrs[domain.DistributionOrderedCacheBehaviorsKey], "ordered cache behaviors keep their precedence")
+	assert.Equal(t, []any{
+		map[string]any{"error_code": int64(403), "error_caching_min_ttl": int64(10)},
+		map[string]any{"error_code": int64(404), "error_caching_min_ttl": int64(10), "response_code": int64(200), "response_page_path": "/index.html"},
+	}, targetAttrs[domain.DistributionCustomErrorResponsesKey])
+	assert.Equal(t, map[string]any{
+		"acm_certificate_arn": "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+		"ssl_support_method":  "sni-only",
+	}, targetAttrs[domain.DistributionViewerCertificateKey])
+	assert.Equal(t, "TLSv1.2_2021", targetAttrs[domain.KeyMinimumProtocolVersion])
+	assert.Equal(t, map[string]any{"bucket": "logs.s3.amazonaws.com", "prefix": "cdn/"}, targetAttrs[domain.DistributionLoggingKey])
+	assert.Equal(t, map[string]any{"restriction_type": "blacklist", "locations": []string{"KP", "RU"}}, targetAttrs[domain.DistributionGeoRestrictionKey])
+}
+
+func TestNormalizeAndCopyAttributes_AutoScalingGroup(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                    "web",
+		"name":                  "web",
+		"min_size":              2.0,
+		"max_size":              10.0,
+		"desired_capacity":      4.0,
+		"max_instance_lifetime": 0.0,
+		"launch_configuration":  "",
+		"launch_template":       []any{map[string]any{"id": "lt-0123456789abcdef0", "name": "web", "version": "$Latest"}},
+		"vpc_zone_identifier":   []any{"subnet-b", "subnet-a"},
+		"termination_policies":  []any{"Default"},
+		"tag": []any{
+			map[string]any{"key": "Name", "value": "web", "propagate_at_launch": true},
+			map[string]any{"key": "Team", "value": "platform", "propagate_at_launch": false},
+		},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindAutoScalingGroup, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), targetAttrs[domain.AutoScalingGroupDesiredCapacityKey])
+	assert.Equal(t, int64(10), targetAttrs[domain.AutoScalingGroupMaxSizeKey])
+	assert.NotContains(t, targetAttrs, domain.AutoScalingGroupMaxInstanceLifetimeKey, "a zero lifetime means no limit")
+	assert.Equal(t, map[string]any{"id": "lt-0123456789abcdef0", "name": "web", "version": "$Latest"}, targetAttrs[domain.AutoScalingGroupLaunchTemplateKey])
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, targetAttrs[domain.AutoScalingGroupSubnetsKey])
+	assert.NotContains(t, targetAttrs, domain.AutoScalingGroupTerminationPoliciesKey, "the implicit Default policy is left out")
+	assert.Equal(t, map[string]string{"Name": "web", "Team": "platform"}, targetAttrs[domain.KeyTags])
+	assert.Equal(t, []string{"Name"}, targetAttrs[domain.AutoScalingGroupPropagatedTagsKey])
+}
+
+func TestNormalizeAndCopyAttributes_LaunchTemplate(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                     "lt-0123456789abcdef0",
+		"name":                   "web",
+		"description":            "",
+		"default_version":        2.0,
+		"latest_version":         3.0,
+		"image_id":               "ami-2",
+		"instance_type":          "m6i.large",
+		"key_name":               "",
+		"vpc_security_group_ids": []any{"sg-1"},
+		"user_data":              "IyEvYmluL3No",
+		"ebs_optimized":          "true",
+		"iam_instance_profile":   []any{map[string]any{"arn": "", "name": "web"}},
+		"monitoring":             []any{map[string]any{"enabled": true}},
+		"metadata_options":       []any{map[string]any{"http_tokens": "required", "http_endpoint": "", "http_put_response_hop_limit": 2.0}},
+		"block_device_mappings": []any{
+			map[string]any{"device_name": "/dev/xvdb", "no_device": "", "ebs": []any{map[string]any{"volume_size": 100.0, "volume_type": "gp3", "encrypted": "", "iops": 0.0}}},
+			map[string]any{"device_name": "/dev/xvda", "ebs": []any{map[string]any{"encrypted": "true", "delete_on_termination": "false"}}},
+		},
+		"tag_specifications": []any{
+			map[string]any{"resource_type": "volume", "tags": map[string]any{"Backup": "daily"}},
+			map[string]any{"resource_type": "instance", "tags": map[string]any{"Name": "web"}},
+		},
+		"tags": map[string]any{"Team": "platform"},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("aws_launch_template", domain.KindLaunchTemplate, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), targetAttrs[domain.LaunchTemplateDefaultVersionKey])
+	assert.Equal(t, int64(3), targetAttrs[domain.LaunchTemplateLatestVersionKey])
+	assert.NotContains(t, targetAttrs, domain.LaunchTemplateDescriptionKey, "an empty description is unset")
+	assert.NotContains(t, targetAttrs, domain.LaunchTemplateKeyNameKey)
+	assert.Equal(t, "ami-2", targetAttrs[domain.ComputeImageIDKey])
+	assert.Equal(t, []string{"sg-1"}, targetAttrs[domain.ComputeSecurityGroupsKey])
+	assert.Equal(t, true, targetAttrs[domain.ComputeEBSOptimizedKey])
+	assert.Equal(t, map[string]any{"name": "web"}, targetAttrs[domain.ComputeIAMInstanceProfileKey])
+	assert.Equal(t, true, targetAttrs[domain.ComputeMonitoringKey])
+	assert.Equal(t, map[string]any{"http_tokens": "required", "http_put_response_hop_limit": int64(2)}, targetAttrs[domain.ComputeMetadataOptionsKey])
+	assert.Equal(t, []any{
+		map[string]any{"device_name": "/dev/xvda", "ebs": map[string]any{"encrypted": true, "delete_on_termination": false}},
+		map[string]any{"device_name": "/dev/xvdb", "ebs": map[string]any{"volume_size": int64(100), "volume_type": "gp3"}},
+	}, targetAttrs[domain.LaunchTemplateBlockDeviceMappingsKey])
+	assert.Equal(t, []any{
+		map[string]any{"resource_type": "instance", "tags": map[string]string{"Name": "web"}},
+		map[string]any{"resource_type": "volume", "tags": map[string]string{"Backup": "daily"}},
+	}, targetAttrs[domain.LaunchTemplateTagSpecificationsKey])
+	assert.Equal(t, map[string]string{"Team": "platform"}, targetAttrs[domain.KeyTags])
+
+	kind, err := MapTfTypeToDomainKind("aws_launch_template")
+	require.NoError(t, err)
+	assert.Equal(t, domain.KindLaunchTemplate, kind)
+}
+
+func TestNormalizeAndCopyAttributes_ElasticIP(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                "eipalloc-1",
+		"public_ip":         "198.51.100.1",
+		"domain":            "vpc",
+		"instance":          "",
+		"network_interface": "",
+		"private_ip":        "",
+		"association_id":    "",
+		"tags":              map[string]any{"Name": "nat"},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("aws_eip", domain.KindElasticIP, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "198.51.100.1", targetAttrs[domain.ElasticIPPublicIPKey])
+	assert.Equal(t, "vpc", targetAttrs[domain.ElasticIPDomainKey])
+	assert.NotContains(t, targetAttrs, domain.ElasticIPInstanceKey, "an unassociated address has no instance")
+	assert.NotContains(t, targetAttrs, domain.ElasticIPAssociationIDKey)
+
+	require.NoError(t, mergeEIPAssociation(map[string]any{
+		"id":                   "eipassoc-1",
+		"allocation_id":        "eipalloc-1",
+		"instance_id":          "i-1",
+		"network_interface_id": "eni-1",
+		"private_ip_address":   "10.0.0.5",
+	}, targetAttrs, nil))
+	assert.Equal(t, "eipassoc-1", targetAttrs[domain.ElasticIPAssociationIDKey])
+	assert.Equal(t, "i-1", targetAttrs[domain.ElasticIPInstanceKey])
+	assert.Equal(t, "eni-1", targetAttrs[domain.ElasticIPNetworkInterfaceKey])
+	assert.Equal(t, "10.0.0.5", targetAttrs[domain.ElasticIPPrivateIPKey])
+
+	kind, err := MapTfTypeToDomainKind("aws_eip")
+	require.NoError(t, err)
+	assert.Equal(t, domain.KindElasticIP, kind)
+}
+
+func TestNormalizeAndCopyAttributes_NetworkInterface(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                "eni-1",
+		"subnet_id":         "subnet-1",
+		"description":       "",
+		"private_ip":        "10.0.0.6",
+		"private_ips":       []any{"10.0.0.6", "10.0.0.5"},
+		"security_groups":   []any{"sg-1"},
+		"source_dest_check": false,
+		"interface_type":    "",
+		"attachment":        []any{map[string]any{"instance": "i-1", "device_index": 1.0, "attachment_id": "eni-attach-1"}},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyTypeAttributes("aws_network_interface", domain.KindNetworkInterface, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.NotContains(t, targetAttrs, domain.NetworkInterfaceDescriptionKey)
+	assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, targetAttrs[domain.NetworkInterfacePrivateIPsKey])
+	assert.Equal(t, false, targetAttrs[domain.NetworkInterfaceSourceDestCheckKey])
+	assert.Equal(t, "interface", targetAttrs[domain.NetworkInterfaceTypeKey], "an unset type is the default interface type")
+	assert.Equal(t, map[string]any{"instance": "i-1", "device_index": int64(1)}, targetAttrs[domain.NetworkInterfaceAttachmentKey])
+
+	require.NoError(t, mergeENISecurityGroup(map[string]any{"network_interface_id": "eni-1", "security_group_id": "sg-2"}, targetAttrs, nil))
+	assert.Equal(t, []string{"sg-1", "sg-2"}, targetAttrs[domain.NetworkInterfaceSecurityGroupsKey])
+
+	detached := make(map[string]any)
+	require.NoError(t, NormalizeAndCopyTypeAttributes("aws_network_interface", domain.KindNetworkInterface, map[string]any{"id": "eni-2", "attachment": []any{}}, detached))
+	assert.NotContains(t, detached, domain.NetworkInterfaceAttachmentKey)
+	require.NoError(t, mergeENIAttachment(map[string]any{"network_interface_id": "eni-2", "instance_id": "i-2", "device_index": 2.0}, detached, nil))
+	assert.Equal(t, map[string]any{"instance": "i-2", "device_index": int64(2)}, detached[domain.NetworkInterfaceAttachmentKey])
+}
+
+func TestNormalizeAndCopyAttributes_LoadBalancer(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                               "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/edge/50dc6c495c0c9188",
+		"name":                             "edge",
+		"internal":                         false,
+		"load_balancer_type":               "network",
+		"ip_address_type":                  "ipv4",
+		"subnets":                          []any{"subnet-b", "subnet-a"},
+		"idle_timeout":                     60.0,
+		"enable_http2":                     true,
+		"drop_invalid_header_fields":       false,
+		"enable_deletion_protection":       true,
+		"enable_cross_zone_load_balancing": false,
+		"access_logs":                      []any{map[string]any{"bucket": "logs", "prefix": "", "enabled": false}},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindLoadBalancer, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, targetAttrs[domain.LoadBalancerSubnetsKey])
+	assert.Equal(t, true, targetAttrs[domain.LoadBalancerDeletionProtectionKey])
+	assert.NotContains(t, targetAttrs, domain.LoadBalancerIdleTimeoutKey, "application-only settings are dropped for network load balancers")
+	assert.NotContains(t, targetAttrs, domain.LoadBalancerHTTP2Key)
+	assert.NotContains(t, targetAttrs, domain.LoadBalancerAccessLogsKey, "disabled access logs are absent")
+}
+
+func TestNormalizeAndCopyAttributes_LoadBalancerListener(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/web/50dc6c495c0c9188/f2f7dc8efc522ab2",
+		"load_balancer_arn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188",
+		"port":              443.0,
+		"protocol":          "HTTPS",
+		"ssl_policy":        "ELBSecurityPolicy-TLS13-1-2-2021-06",
+		"certificate_arn":   "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+		"alpn_policy":       "None",
+		"default_action": []any{
+			map[string]any{
+				"type":             "forward",
+				"order":            2.0,
+				"target_group_arn": "",
+				"forward": []any{map[string]any{
+					"target_group": []any{
+						map[string]any{"arn": "arn:tg/green", "weight": 20.0},
+						map[string]any{"arn": "arn:tg/blue", "weight": 80.0},
+					},
+					"stickiness": []any{map[string]any{"enabled": false, "duration": 0.0}},
+				}},
+			},
+			map[string]any{
+				"type":  "authenticate-oidc",
+				"order": 1.0,
+				"authenticate_oidc": []any{map[string]any{
+					"issuer":          "https://idp.example.com",
+					"client_id":       "web",
+					"scope":           "openid",
+					"session_timeout": 3600.0,
+				}},
+			},
+		},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindLoadBalancerListener, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 443, targetAttrs[domain.ListenerPortKey])
+	assert.Equal(t, "ELBSecurityPolicy-TLS13-1-2-2021-06", targetAttrs[domain.KeySSLPolicy])
+	assert.NotContains(t, targetAttrs, domain.ListenerALPNPolicyKey, "the None ALPN policy means no policy")
+	assert.Equal(t, []any{
+		map[string]any{"type": "authenticate-oidc", "issuer": "https://idp.example.com", "client_id": "web", "scope": "openid", "session_timeout": int64(3600)},
+		map[string]any{"type": "forward", "target_groups": []any{
+			map[string]any{"arn": "arn:tg/blue", "weight": int64(80)},
+			map[string]any{"arn": "arn:tg/green", "weight": int64(20)},
+		}},
+	}, targetAttrs[domain.ListenerDefaultActionsKey], "actions are kept in their order of execution")
+}
+
+func TestMergeListenerRule(t *testing.T) {
+	target := map[string]any{}
+	rule := func(priority float64, path string) map[string]any {
+		return map[string]any{
+			"listener_arn": "arn:listener",
+			"priority":     priority,
+			"condition": []any{
+				map[string]any{"path_pattern": []any{map[string]any{"values": []any{path}}}},
+				map[string]any{"query_string": []any{map[string]any{"key": "v", "value": "2"}, map[string]any{"key": "", "value": "beta"}}},
+			},
+			"action": []any{map[string]any{"type": "forward", "target_group_arn": "arn:tg/api"}},
+		}
+	}
+	require.NoError(t, mergeListenerRule(rule(20, "/b/*"), target, nil))
+	require.NoError(t, mergeListenerRule(rule(10, "/a/*"), target, nil))
+
+	rules := target[domain.ListenerRulesKey].([]any)
+	require.Len(t, rules, 2)
+	assert.Equal(t, map[string]any{
+		"priority": int64(10),
+		"conditions": []any{
+			map[string]any{"field": "path-pattern", "values": []string{"/a/*"}},
+			map[string]any{"field": "query-string", "values": []string{"beta", "v=2"}},
+		},
+		"actions": []any{map[string]any{"type": "forward", "target_groups": []any{map[string]any{"arn": "arn:tg/api"}}}},
+	}, rules[0], "rules are sorted by priority")
+	assert.Equal(t, int64(20), rules[1].(map[string]any)["priority"])
+}
+
+func TestNormalizeAndCopyAttributes_LoadBalancerTargetGroup(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                   "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/73e2d6bc24d8a067",
+		"name":                 "api",
+		"port":                 8080.0,
+		"protocol":             "HTTP",
+		"target_type":          "ip",
+		"deregistration_delay": "30",
+		"slow_start":           0.0,
+		"health_check": []any{map[string]any{
+			"enabled":             true,
+			"path":                "/healthz",
+			"port":                "traffic-port",
+			"protocol":            "HTTP",
+			"matcher":             "200-299",
+			"interval":            15.0,
+			"timeout":             5.0,
+			"healthy_threshold":   3.0,
+			"unhealthy_threshold": 0.0,
+		}},
+		"stickiness": []any{map[string]any{"enabled": true, "type": "app_cookie", "cookie_duration": 86400.0, "cookie_name": "session"}},
+	}
+	targetAttrs := make(map[string]any)
+	err := NormalizeAndCopyAttributes(domain.KindLoadBalancerTargetGroup, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(30), targetAttrs[domain.TargetGroupDeregistrationDelayKey], "the string deregistration delay is converted")
+	assert.NotContains(t, targetAttrs, domain.TargetGroupSlowStartKey, "a zero slow start means off")
+	assert.Equal(t, map[string]any{
+		"enabled": true, "path": "/healthz", "port": "traffic-port", "protocol": "HTTP", "matcher": "200-299",
+		"interval": int64(15), "timeout": int64(5), "healthy_threshold": int64(3),
+	}, targetAttrs[domain.TargetGroupHealthCheckKey])
+	assert.Equal(t, map[string]any{"type": "app_cookie", "cookie_duration": int64(86400), "cookie_name": "session"}, targetAttrs[domain.TargetGroupStickinessKey])
+}
+
 func TestNormalizeAndCopyAttributes_UnsupportedKind(t *testing.T) {
 	targetAttrs := make(map[string]any)
 	err := NormalizeAndCopyAttributes("aws_vpc", map[string]any{"id": "vpc-123"}, targetAttrs)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no attribute mapping defined for kind")
 }
+
+func TestNormalizeAndCopyAttributes_EncryptionKey(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                       "1234abcd-12ab-34cd-56ef-1234567890ab",
+		"arn":                      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		"description":              "application data",
+		"is_enabled":               true,
+		"key_usage":                "ENCRYPT_DECRYPT",
+		"customer_master_key_spec": "SYMMETRIC_DEFAULT",
+		"multi_region":             false,
+		"policy":                   `{"Version":"2012-10-17","Statement":[]}`,
+		"enable_key_rotation":      true,
+		"rotation_period_in_days":  365.0,
+		"deletion_window_in_days":  "30",
+		"tags":                     map[string]any{"Env": "prod"},
+	}
+	targetAttrs := make(map[string]any)
+
+	err := NormalizeAndCopyTypeAttributes("aws_kms_key", domain.KindEncryptionKey, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1234abcd-12ab-34cd-56ef-1234567890ab", targetAttrs[domain.KeyID])
+	assert.Equal(t, true, targetAttrs[domain.EncryptionKeyRotationKey])
+	assert.Equal(t, int64(365), targetAttrs[domain.EncryptionKeyRotationPeriodKey])
+	assert.Equal(t, int64(30), targetAttrs[domain.EncryptionKeyDeletionWindowKey])
+	assert.Equal(t, rawAttrs["policy"], targetAttrs[domain.EncryptionKeyPolicyKey])
+	assert.Equal(t, map[string]string{"Env": "prod"}, targetAttrs[domain.KeyTags])
+
+	require.NoError(t, mergeKMSAlias(map[string]any{"name": "alias/b", "target_key_id": rawAttrs["id"]}, targetAttrs, nil))
+	require.NoError(t, mergeKMSAlias(map[string]any{"name": "alias/a", "target_key_id": rawAttrs["id"]}, targetAttrs, nil))
+	assert.Equal(t, []string{"alias/a", "alias/b"}, targetAttrs[domain.EncryptionKeyAliasesKey])
+}
+
+func TestNormalizeAndCopyAttributes_ContainerService(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                                 "arn:aws:ecs:us-east-1:123456789012:service/app/web",
+		"name":                               "web",
+		"cluster":                            "arn:aws:ecs:us-east-1:123456789012:cluster/app",
+		"task_definition":                    "arn:aws:ecs:us-east-1:123456789012:task-definition/web:7",
+		"desired_count":                      2.0,
+		"launch_type":                        "FARGATE",
+		"health_check_grace_period_seconds":  0.0,
+		"deployment_minimum_healthy_percent": 100.0,
+		"deployment_maximum_percent":         200.0,
+		"network_configuration": []any{map[string]any{
+			"subnets":          []any{"subnet-b", "subnet-a"},
+			"security_groups":  []any{"sg-1"},
+			"assign_public_ip": true,
+		}},
+		"capacity_provider_strategy": []any{
+			map[string]any{"capacity_provider": "FARGATE_SPOT", "weight": 3.0, "base": 0.0},
+			map[string]any{"capacity_provider": "FARGATE", "weight": 1.0, "base": 1.0},
+		},
+		"load_balancer": []any{map[string]any{
+			"target_group_arn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/web/abc",
+			"elb_name":         "",
+			"container_name":   "web",
+			"container_port":   8080.0,
+		}},
+	}
+	targetAttrs := make(map[string]any)
+
+	err := NormalizeAndCopyTypeAttributes("aws_ecs_service", domain.KindContainerService, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "app", targetAttrs[domain.ContainerServiceClusterKey])
+	assert.Equal(t, "web:7", targetAttrs[domain.ContainerServiceTaskDefinitionKey])
+	assert.Equal(t, int64(2), targetAttrs[domain.ContainerServiceDesiredCountKey])
+	assert.Equal(t, int64(100), targetAttrs[domain.ContainerServiceMinHealthyPercentKey])
+	assert.NotContains(t, targetAttrs, domain.ContainerServiceHealthCheckGraceKey)
+	assert.Equal(t, map[string]any{
+		"subnets":          []string{"subnet-a", "subnet-b"},
+		"security_groups":  []string{"sg-1"},
+		"assign_public_ip": true,
+	}, targetAttrs[domain.ContainerServiceNetworkConfigurationKey])
+	assert.Equal(t, []any{
+		map[string]any{"capacity_provider": "FARGATE", "weight": int64(1), "base": int64(1)},
+		map[string]any{"capacity_provider": "FARGATE_SPOT", "weight": int64(3), "base": int64(0)},
+	}, targetAttrs[domain.ContainerServiceCapacityProvidersKey])
+	assert.Equal(t, []any{map[string]any{
+		"target_group_arn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/web/abc",
+		"container_name":   "web",
+		"container_port":   int64(8080),
+	}}, targetAttrs[domain.ContainerServiceLoadBalancersKey])
+}
+
+func TestNormalizeAndCopyAttributes_ContainerTaskDefinition(t *testing.T) {
+	rawAttrs := map[string]any{
+		"id":                       "web",
+		"family":                   "web",
+		"revision":                 7.0,
+		"container_definitions":    `[{"name":"web","image":"nginx:1.27"}]`,
+		"cpu":                      "256",
+		"memory":                   "512",
+		"requires_compatibilities": []any{"FARGATE", "EC2"},
+		"runtime_platform":         []any{map[string]any{"operating_system_family": "LINUX", "cpu_architecture": ""}},
+	}
+	targetAttrs := make(map[string]any)
+
+	err := NormalizeAndCopyTypeAttributes("aws_ecs_task_definition", domain.KindContainerTaskDefinition, rawAttrs, targetAttrs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web", targetAttrs[domain.KeyName])
+	assert.Equal(t, int64(7), targetAttrs[domain.TaskDefinitionRevisionKey])
+	assert.Equal(t, rawAttrs["container_definitions"], targetAttrs[domain.TaskDefinitionContainerDefinitionsKey])
+	assert.Equal(t, []string{"EC2", "FARGATE"}, targetAttrs[domain.TaskDefinitionCompatibilitiesKey])
+	assert.Equal(t, map[string]any{"operating_system_family": "LINUX"}, targetAttrs[domain.TaskDefinitionRuntimePlatformKey])
+}
@@ -0,0 +1,347 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// supportedDeploymentVersion is the deployment format written by current
+// Pulumi CLIs, both by `pulumi stack export` and to state backends.
+const supportedDeploymentVersion = 3
+
+const (
+	// secretSignature marks an object holding a secret value.
+	secretSignature = "4dabf18193072939515e22adb298388d"
+	// assetSignature marks an object holding an asset or archive.
+	assetSignature = "0def7320c3a5731c473e5ecbe6d01bc7"
+	// unknownValue stands in for outputs that were not known when the
+	// deployment was saved.
+	unknownValue = "04da6b54-80e4-46f7-96ec-b56ff0331ba9"
+
+	stackType   = "pulumi:pulumi:Stack"
+	awsPackage  = "aws"
+	awsProvider = "registry.terraform.io/hashicorp/aws"
+	v2Suffix    = "V2"
+)
+
+type (
+	// export is the document written by `pulumi stack export`. A checkpoint
+	// read from a state backend holds the same deployment under
+	// checkpoint.latest.
+	export struct {
+		Version    int         `json:"version"`
+		Deployment *deployment `json:"deployment"`
+		Checkpoint *struct {
+			Latest *deployment `json:"latest"`
+		} `json:"checkpoint"`
+	}
+
+	deployment struct {
+		Resources []resource `json:"resources"`
+	}
+
+	resource struct {
+		URN     string         `json:"urn"`
+		Custom  bool           `json:"custom"`
+		Delete  bool           `json:"delete"`
+		ID      string         `json:"id"`
+		Type    string         `json:"type"`
+		Outputs map[string]any `json:"outputs"`
+		Parent  string         `json:"parent"`
+	}
+)
+
+// tfTypes maps the module and resource of a Pulumi AWS type token
+// ("aws:<module>/<resource>:<Resource>") to the Terraform type it is bridged
+// from. The "V2" suffix of Pulumi's renamed resources is dropped before the
+// lookup. Related resources are included so they are aggregated into their
+// parents as they are for Terraform state.
+var tfTypes = map[string]string{
+	"ec2/instance":                               "aws_instance",
+	"ec2/securityGroup":                          "aws_security_group",
+	"ec2/volumeAttachment":                       "aws_volume_attachment",
+	"ebs/volume":                                 "aws_ebs_volume",
+	"s3/analyticsConfiguration":                  "aws_s3_bucket_analytics_configuration",
+	"s3/bucket":                                  "aws_s3_bucket",
+	"s3/bucketAccelerateConfiguration":           "aws_s3_bucket_accelerate_configuration",
+	"s3/bucketAcl":                               "aws_s3_bucket_acl",
+	"s3/bucketCorsConfiguration":                 "aws_s3_bucket_cors_configuration",
+	"s3/bucketIntelligentTieringConfiguration":   "aws_s3_bucket_intelligent_tiering_configuration",
+	"s3/bucketLifecycleConfiguration":            "aws_s3_bucket_lifecycle_configuration",
+	"s3/bucketLogging":                           "aws_s3_bucket_logging",
+	"s3/bucketMetric":                            "aws_s3_bucket_metric",
+	"s3/bucketNotification":                      "aws_s3_bucket_notification",
+	"s3/bucketObjectLockConfiguration":           "aws_s3_bucket_object_lock_configuration",
+	"s3/bucketOwnershipControls":                 "aws_s3_bucket_ownership_controls",
+	"s3/bucketPolicy":                            "aws_s3_bucket_policy",
+	"s3/bucketPublicAccessBlock":                 "aws_s3_bucket_public_access_block",
+	"s3/bucketReplicationConfig":                 "aws_s3_bucket_replication_configuration",
+	"s3/bucketServerSideEncryptionConfiguration": "aws_s3_bucket_server_side_encryption_configuration",
+	"s3/bucketVersioning":                        "aws_s3_bucket_versioning",
+	"s3/bucketWebsiteConfiguration":              "aws_s3_bucket_website_configuration",
+	"s3/inventory":                               "aws_s3_bucket_inventory",
+	"rds/instance":                               "aws_db_instance",
+	"lambda/function":                            "aws_lambda_function",
+	"iam/role":                                   "aws_iam_role",
+	"iam/policy":                                 "aws_iam_policy",
+	"dynamodb/table":                             "aws_dynamodb_table",
+}
+
+// attributeRenames maps snake_cased Pulumi output names that differ from the
+// Terraform attribute names, mostly where Pulumi pluralises repeated blocks.
+var attributeRenames = map[string]map[string]string{
+	"aws_instance": {
+		"ebs_block_devices": "ebs_block_device",
+	},
+	"aws_s3_bucket": {
+		"versionings":                           "versioning",
+		"loggings":                              "logging",
+		"websites":                              "website",
+		"cors_rules":                            "cors_rule",
+		"lifecycle_rules":                       "lifecycle_rule",
+		"grants":                                "grant",
+		"server_side_encryption_configurations": "server_side_encryption_configuration",
+	},
+	"aws_s3_bucket_cors_configuration":                   {"cors_rules": "cors_rule"},
+	"aws_s3_bucket_intelligent_tiering_configuration":    {"tierings": "tiering"},
+	"aws_s3_bucket_lifecycle_configuration":              {"rules": "rule"},
+	"aws_s3_bucket_notification":                         {"lambda_functions": "lambda_function", "queues": "queue", "topics": "topic"},
+	"aws_s3_bucket_replication_configuration":            {"rules": "rule"},
+	"aws_s3_bucket_server_side_encryption_configuration": {"rules": "rule"},
+	"aws_lambda_function": {
+		"name": "function_name",
+	},
+	"aws_iam_role": {
+		"inline_policies": "inline_policy",
+	},
+	"aws_dynamodb_table": {
+		"attributes":               "attribute",
+		"global_secondary_indexes": "global_secondary_index",
+		"local_secondary_indexes":  "local_secondary_index",
+	},
+}
+
+// userKeyedAttributes hold maps keyed by user-chosen names, which are kept as
+// they are instead of being converted like nested blocks.
+var userKeyedAttributes = map[string]bool{
+	"tags":      true,
+	"tags_all":  true,
+	"variables": true,
+}
+
+// parseExport decodes a Pulumi stack export or checkpoint.
+func parseExport(raw []byte) (*deployment, error) {
+	if len(raw) == 0 {
+		return nil, errors.NewUserFacing(errors.CodeStateParseError, "Pulumi stack export is empty", "")
+	}
+	var doc export
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeStateParseError, "invalid JSON in Pulumi stack export", "")
+	}
+	if doc.Version != supportedDeploymentVersion {
+		return nil, errors.NewUserFacing(errors.CodeUnsupportedStateVersion,
+			fmt.Sprintf("unsupported Pulumi deployment version %d (only %d supported)", doc.Version, supportedDeploymentVersion),
+			"Re-export the stack with a current Pulumi CLI: pulumi stack export --file <path>")
+	}
+	switch {
+	case doc.Deployment != nil:
+		return doc.Deployment, nil
+	case doc.Checkpoint != nil && doc.Checkpoint.Latest != nil:
+		return doc.Checkpoint.Latest, nil
+	default:
+		return &deployment{}, nil
+	}
+}
+
+// toTerraformState converts the AWS resources of a deployment into the
+// Terraform state they would have if managed by Terraform, so they are mapped
+// and aggregated by the tfstate adapter. Resources of components are placed in
+// a module named after the component, keeping their addresses distinct.
+func toTerraformState(d *deployment) *tfstate.State {
+	byURN := make(map[string]*resource, len(d.Resources))
+	for i := range d.Resources {
+		byURN[d.Resources[i].URN] = &d.Resources[i]
+	}
+
+	state := &tfstate.State{Version: 4}
+	for i := range d.Resources {
+		res := &d.Resources[i]
+		if !res.Custom || res.Delete {
+			continue
+		}
+		tfType, ok := terraformType(res.Type)
+		if !ok {
+			continue
+		}
+		attrs, _ := convertValue(res.Outputs).(map[string]any)
+		if attrs == nil {
+			attrs = make(map[string]any)
+		}
+		renameAttributes(tfType, attrs)
+		if _, exists := attrs["id"]; !exists && res.ID != "" {
+			attrs["id"] = res.ID
+		}
+		state.Resources = append(state.Resources, tfstate.Resource{
+			Module:    modulePath(res, byURN),
+			Mode:      "managed",
+			Type:      tfType,
+			Name:      urnName(res.URN),
+			Provider:  awsProvider,
+			Instances: []tfstate.Instance{{Attributes: attrs}},
+		})
+	}
+	return state
+}
+
+// terraformType returns the Terraform type a Pulumi AWS type token is bridged
+// from.
+func terraformType(token string) (string, bool) {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 || parts[0] != awsPackage {
+		return "", false
+	}
+	tfType, ok := tfTypes[strings.TrimSuffix(parts[1], v2Suffix)]
+	return tfType, ok
+}
+
+func renameAttributes(tfType string, attrs map[string]any) {
+	for from, to := range attributeRenames[tfType] {
+		if val, ok := attrs[from]; ok {
+			delete(attrs, from)
+			attrs[to] = val
+		}
+	}
+}
+
+// convertValue snake_cases the keys of Pulumi outputs and restores the shape of
+// Terraform state: blocks limited to one item, which Pulumi flattens into an
+// object, become single-item lists again. Secrets are unwrapped, while assets
+// and unknown values are dropped.
+func convertValue(v any) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		if _, ok := typed[secretSignature]; ok {
+			return secretValue(typed)
+		}
+		if _, ok := typed[assetSignature]; ok {
+			return nil
+		}
+		out := make(map[string]any, len(typed))
+		for k, val := range typed {
+			key := snakeCase(k)
+			if userKeyedAttributes[key] {
+				out[key] = convertUserKeyed(val)
+				continue
+			}
+			converted := convertValue(val)
+			if converted == nil {
+				continue
+			}
+			if block, isBlock := converted.(map[string]any); isBlock {
+				converted = []any{block}
+			}
+			out[key] = converted
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(typed))
+		for _, val := range typed {
+			if converted := convertValue(val); converted != nil {
+				out = append(out, converted)
+			}
+		}
+		return out
+	case string:
+		if typed == unknownValue {
+			return nil
+		}
+		return typed
+	default:
+		return v
+	}
+}
+
+// convertUserKeyed keeps the keys of a user-keyed map as they are, only
+// unwrapping secret values.
+func convertUserKeyed(v any) any {
+	entries, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	out := make(map[string]any, len(entries))
+	for k, val := range entries {
+		if secret, isMap := val.(map[string]any); isMap {
+			if _, isSecret := secret[secretSignature]; isSecret {
+				val = secretValue(secret)
+			}
+		}
+		if val != nil {
+			out[k] = val
+		}
+	}
+	return out
+}
+
+// secretValue returns the plaintext of a secret. Encrypted secrets of an
+// export made without --show-secrets cannot be compared and are dropped.
+func secretValue(secret map[string]any) any {
+	plaintext, ok := secret["plaintext"].(string)
+	if !ok {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal([]byte(plaintext), &value); err != nil {
+		return nil
+	}
+	return convertValue(value)
+}
+
+// urnName returns the resource name, the last part of a URN of the form
+// urn:pulumi:<stack>::<project>::<type>::<name>.
+func urnName(urn string) string {
+	if idx := strings.LastIndex(urn, "::"); idx >= 0 {
+		return urn[idx+2:]
+	}
+	return urn
+}
+
+// modulePath builds a Terraform-style module path from the component
+// resources a resource is nested in.
+func modulePath(res *resource, byURN map[string]*resource) string {
+	var modules []string
+	for parent := byURN[res.Parent]; parent != nil && parent.Type != stackType; parent = byURN[parent.Parent] {
+		if parent.Custom {
+			continue
+		}
+		modules = append([]string{"module." + urnName(parent.URN)}, modules...)
+	}
+	return strings.Join(modules, ".")
+}
+
+// snakeCase converts a camelCase output name to snake_case, keeping acronyms
+// together (e.g. "vpcSecurityGroupIds" -> "vpc_security_group_ids").
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const ProviderTypePulumi = "pulumi"
+
+// Config points at a Pulumi stack export, as written by
+// `pulumi stack export --show-secrets --file <path>`.
+type Config struct {
+	Path               string                `yaml:"path" mapstructure:"path" validate:"required"`
+	DisableAggregation []domain.ResourceKind `yaml:"disable_aggregation" mapstructure:"disable_aggregation"`
+}
+
+// Provider reads desired state from a Pulumi stack export. The AWS resources
+// are converted to the Terraform state they are bridged from and mapped like a
+// local state file.
+type Provider struct {
+	*tfstate.Provider
+}
+
+func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
+	if cfg.Path == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "pulumi state provider requires the path of a stack export")
+	}
+	plog := logger.WithFields(map[string]any{"provider": ProviderTypePulumi})
+	return NewProviderWithFetcher(cfg.Path, func(context.Context) ([]byte, error) {
+		return os.ReadFile(cfg.Path)
+	}, cfg, plog), nil
+}
+
+// NewProviderWithFetcher creates a provider that reads the stack export through
+// fetch, e.g. from a Pulumi state backend. source names the origin of the
+// export in logs and errors.
+func NewProviderWithFetcher(source string, fetch tfstate.StateFetcher, cfg Config, logger ports.Logger) *Provider {
+	convert := func(ctx context.Context) ([]byte, error) {
+		raw, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d, err := parseExport(raw)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(toTerraformState(d))
+	}
+	return &Provider{
+		Provider: tfstate.NewProviderWithFetcher(source, convert, tfstate.Config{DisableAggregation: cfg.DisableAggregation}, logger),
+	}
+}
+
+func (p *Provider) Type() string { return ProviderTypePulumi }
@@ -0,0 +1,168 @@
+package pulumi
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	logger.On("WithFields", mock.Anything).Maybe().Return(logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf", "Errorf"} {
+		args := []any{mock.Anything, mock.Anything}
+		for len(args) <= 8 {
+			logger.On(method, args...).Maybe().Return()
+			args = append(args, mock.Anything)
+		}
+	}
+	return logger
+}
+
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := NewProvider(Config{Path: filepath.Join("testdata", "stack.json")}, newTestLogger())
+	require.NoError(t, err)
+	return p
+}
+
+func staticExport(data string) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) { return []byte(data), nil }
+}
+
+func TestNewProviderRequiresPath(t *testing.T) {
+	_, err := NewProvider(Config{}, newTestLogger())
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
+
+func TestListResources_ComputeInstance(t *testing.T) {
+	p := newTestProvider(t)
+	assert.Equal(t, ProviderTypePulumi, p.Type())
+
+	resources, err := p.ListResources(context.Background(), domain.KindComputeInstance)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1, "pending deletes are skipped")
+	meta := resources[0].Metadata()
+	assert.Equal(t, "i-0123456789abcdef0", meta.ProviderAssignedID)
+	assert.Equal(t, "module.frontend.aws_instance.server", meta.SourceIdentifier)
+	assert.Equal(t, "aws", meta.ProviderType)
+
+	attrs := resources[0].Attributes()
+	assert.Equal(t, "t3.micro", attrs[domain.ComputeInstanceTypeKey])
+	assert.Equal(t, "ami-0abcdef1234567890", attrs[domain.ComputeImageIDKey])
+	assert.Equal(t, []string{"sg-0123456789abcdef0"}, attrs[domain.ComputeSecurityGroupsKey])
+	assert.Equal(t, map[string]string{"Name": "frontend", "Environment": "prod"}, attrs[domain.KeyTags])
+	assert.NotContains(t, attrs, domain.ComputeUserDataKey, "unknown outputs are dropped")
+	root, ok := attrs[domain.ComputeRootBlockDeviceKey].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "gp3", root["volume_type"])
+	assert.Equal(t, true, root["encrypted"])
+}
+
+func TestListResources_AggregatesBucketResources(t *testing.T) {
+	p := newTestProvider(t)
+
+	resources, err := p.ListResources(context.Background(), domain.KindStorageBucket)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "aws_s3_bucket.assets", resources[0].Metadata().SourceIdentifier)
+	attrs := resources[0].Attributes()
+	assert.Equal(t, "web-assets-prod", attrs[domain.KeyID])
+	assert.Equal(t, true, attrs[domain.StorageBucketVersioningKey])
+}
+
+func TestListResources_ServerlessFunction(t *testing.T) {
+	p := newTestProvider(t)
+
+	resources, err := p.ListResources(context.Background(), domain.KindServerlessFunction)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	attrs := resources[0].Attributes()
+	assert.Equal(t, "resize-images", attrs[domain.KeyID])
+	assert.Equal(t, "Active", attrs[domain.FunctionTracingModeKey])
+	assert.Equal(t, map[string]string{"BUCKET": "web-assets-prod", "API_KEY": "s3cr3t"}, attrs[domain.FunctionEnvironmentKey])
+}
+
+func TestListResources_SecurityGroupRules(t *testing.T) {
+	p := newTestProvider(t)
+
+	resources, err := p.ListResources(context.Background(), domain.KindNetworkSecurityGroup)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	attrs := resources[0].Attributes()
+	assert.Equal(t, "vpc-0a1b2c3d", attrs[domain.SecurityGroupVPCIDKey])
+	assert.NotEmpty(t, attrs[domain.SecurityGroupIngressKey])
+}
+
+func TestGetResource(t *testing.T) {
+	p := newTestProvider(t)
+
+	res, err := p.GetResource(context.Background(), domain.KindComputeInstance, "module.frontend.aws_instance.server")
+	require.NoError(t, err)
+	assert.Equal(t, "i-0123456789abcdef0", res.Metadata().ProviderAssignedID)
+
+	_, err = p.GetResource(context.Background(), domain.KindComputeInstance, "aws_instance.old")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+}
+
+func TestReadsCheckpoint(t *testing.T) {
+	checkpoint := `{"version":3,"checkpoint":{"stack":"prod","latest":{"resources":[
+		{"urn":"urn:pulumi:prod::db::aws:dynamodb/table:Table::orders","custom":true,"id":"orders",
+		 "type":"aws:dynamodb/table:Table","outputs":{"name":"orders","billingMode":"PAY_PER_REQUEST","hashKey":"pk",
+		 "attributes":[{"name":"pk","type":"S"}],"pointInTimeRecovery":{"enabled":true}}}]}}}`
+	p := NewProviderWithFetcher("test", staticExport(checkpoint), Config{}, newTestLogger())
+
+	resources, err := p.ListResources(context.Background(), domain.KindDatabaseTable)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	attrs := resources[0].Attributes()
+	assert.Equal(t, "orders", attrs[domain.KeyID])
+	assert.Equal(t, "PAY_PER_REQUEST", attrs[domain.TableBillingModeKey])
+	assert.Equal(t, true, attrs[domain.KeyPointInTimeRecovery])
+	assert.NotEmpty(t, attrs[domain.TableAttributesKey])
+}
+
+func TestInvalidExports(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		code errors.Code
+	}{
+		{name: "empty", data: "", code: errors.CodeStateParseError},
+		{name: "invalid JSON", data: "{", code: errors.CodeStateParseError},
+		{name: "unsupported version", data: `{"version":1,"deployment":{"resources":[]}}`, code: errors.CodeUnsupportedStateVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProviderWithFetcher("test", staticExport(tt.data), Config{}, newTestLogger())
+
+			_, err := p.ListResources(context.Background(), domain.KindComputeInstance)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.code), "got %v", err)
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert.Equal(t, "vpc_security_group_ids", snakeCase("vpcSecurityGroupIds"))
+	assert.Equal(t, "ipv6_cidr_blocks", snakeCase("ipv6CidrBlocks"))
+	assert.Equal(t, "kms_key_arn", snakeCase("kmsKeyArn"))
+	assert.Equal(t, "ttl", snakeCase("ttl"))
+}
@@ -0,0 +1,163 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	discoveryPath      = "/.well-known/terraform.json"
+	defaultAPIBasePath = "/api/v2/"
+	jsonAPIContentType = "application/vnd.api+json"
+	defaultHTTPTimeout = 60 * time.Second
+)
+
+// client speaks the subset of the Terraform Cloud/Enterprise API v2 used by the
+// "remote" backend for reading state. Spacelift (external state access), Scalr,
+// env0 and Terraform Cloud all implement it.
+type client struct {
+	httpClient *http.Client
+	baseURL    string // scheme://host, no trailing slash
+	token      string
+}
+
+func newClient(hostname, token string, httpClient *http.Client) *client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	baseURL := hostname
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return &client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// fetchCurrentState downloads the current state snapshot of a workspace.
+func (c *client) fetchCurrentState(ctx context.Context, organization, workspace string) ([]byte, error) {
+	apiBase, err := c.discoverAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	wsURL := fmt.Sprintf("%sorganizations/%s/workspaces/%s", apiBase, url.PathEscape(organization), url.PathEscape(workspace))
+	if err := c.getJSON(ctx, wsURL, &ws); err != nil {
+		return nil, err
+	}
+	if ws.Data.ID == "" {
+		return nil, errors.New(errors.CodeStateReadError, fmt.Sprintf("workspace '%s/%s' response did not contain an ID", organization, workspace))
+	}
+
+	var sv struct {
+		Data struct {
+			Attributes struct {
+				DownloadURL string `json:"hosted-state-download-url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	svURL := fmt.Sprintf("%sworkspaces/%s/current-state-version", apiBase, url.PathEscape(ws.Data.ID))
+	if err := c.getJSON(ctx, svURL, &sv); err != nil {
+		return nil, err
+	}
+	if sv.Data.Attributes.DownloadURL == "" {
+		return nil, errors.NewUserFacing(errors.CodeStateReadError,
+			fmt.Sprintf("workspace '%s/%s' has no downloadable state version", organization, workspace),
+			"Ensure the stack has been applied at least once and external state access is enabled.")
+	}
+
+	return c.get(ctx, c.resolve(sv.Data.Attributes.DownloadURL), "")
+}
+
+// discoverAPI resolves the tfe.v2 service URL via Terraform remote service discovery.
+func (c *client) discoverAPI(ctx context.Context) (string, error) {
+	var services map[string]any
+	if err := c.getJSON(ctx, c.baseURL+discoveryPath, &services); err != nil {
+		if errors.Is(err, errors.CodeResourceNotFound) {
+			return c.baseURL + defaultAPIBasePath, nil
+		}
+		return "", err
+	}
+	base, _ := services["tfe.v2"].(string)
+	if base == "" {
+		base = defaultAPIBasePath
+	}
+	base = c.resolve(base)
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base, nil
+}
+
+// resolve turns a host-relative path into an absolute URL.
+func (c *client) resolve(ref string) string {
+	if strings.Contains(ref, "://") {
+		return ref
+	}
+	if !strings.HasPrefix(ref, "/") {
+		ref = "/" + ref
+	}
+	return c.baseURL + ref
+}
+
+func (c *client) getJSON(ctx context.Context, target string, out any) error {
+	body, err := c.get(ctx, target, jsonAPIContentType)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("invalid JSON response from %s", target))
+	}
+	return nil
+}
+
+func (c *client) get(ctx context.Context, target, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to build request for %s", target))
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("request to %s failed", target))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to read response from %s", target))
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, errors.NewUserFacing(errors.CodePlatformAuthError,
+			fmt.Sprintf("access to %s denied (HTTP %d)", target, resp.StatusCode),
+			"Check that the API token is valid and has read access to the workspace state.")
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("%s not found (HTTP 404)", target))
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, errors.New(errors.CodeStateReadError, fmt.Sprintf("unexpected HTTP %d from %s", resp.StatusCode, target))
+	}
+	return body, nil
+}
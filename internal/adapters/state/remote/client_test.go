@@ -0,0 +1,80 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const testState = `{"version":4,"resources":[]}`
+
+func newTestServer(t *testing.T, token string) *httptest.Server {
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tfe.v2":"/api/v2/"}`))
+	})
+	mux.HandleFunc("/api/v2/organizations/acme/workspaces/network", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			_, _ = w.Write([]byte(`{"data":{"id":"ws-123"}}`))
+		}
+	})
+	mux.HandleFunc("/api/v2/workspaces/ws-123/current-state-version", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			_, _ = w.Write([]byte(`{"data":{"attributes":{"hosted-state-download-url":"` + srv.URL + `/state/sv-1"}}}`))
+		}
+	})
+	mux.HandleFunc("/state/sv-1", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			_, _ = w.Write([]byte(testState))
+		}
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_FetchCurrentState(t *testing.T) {
+	srv := newTestServer(t, "secret")
+
+	c := newClient(srv.URL, "secret", srv.Client())
+	raw, err := c.fetchCurrentState(context.Background(), "acme", "network")
+	require.NoError(t, err)
+	assert.JSONEq(t, testState, string(raw))
+}
+
+func TestClient_FetchCurrentState_Errors(t *testing.T) {
+	srv := newTestServer(t, "secret")
+
+	t.Run("bad token", func(t *testing.T) {
+		c := newClient(srv.URL, "wrong", srv.Client())
+		_, err := c.fetchCurrentState(context.Background(), "acme", "network")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodePlatformAuthError))
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		c := newClient(srv.URL, "secret", srv.Client())
+		_, err := c.fetchCurrentState(context.Background(), "acme", "missing")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodeResourceNotFound))
+	})
+}
+
+func TestTokenEnvForHost(t *testing.T) {
+	assert.Equal(t, "TF_TOKEN_spacelift_io", tokenEnvForHost("spacelift.io"))
+	assert.Equal(t, "TF_TOKEN_my__org_scalr_io", tokenEnvForHost("https://my-org.scalr.io/"))
+}
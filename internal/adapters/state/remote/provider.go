@@ -0,0 +1,102 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const ProviderTypeRemote = "remote"
+
+const (
+	PlatformSpacelift = "spacelift"
+	PlatformScalr     = "scalr"
+	PlatformEnv0      = "env0"
+	PlatformTFC       = "tfc"
+)
+
+// defaultHostnames are the remote backend hostnames of the hosted platforms.
+// Scalr is account-specific and must be configured explicitly.
+var defaultHostnames = map[string]string{
+	PlatformSpacelift: "spacelift.io",
+	PlatformEnv0:      "backend.api.env0.com",
+	PlatformTFC:       "app.terraform.io",
+}
+
+// Config selects a workspace on a TACOS platform whose state is read through the
+// Terraform remote backend API. For Spacelift, Organization is the account name
+// and Workspace the stack ID; external state access must be enabled on the stack.
+type Config struct {
+	Platform     string `yaml:"platform" mapstructure:"platform" validate:"required,oneof=spacelift scalr env0 tfc"`
+	Hostname     string `yaml:"hostname" mapstructure:"hostname"`
+	Organization string `yaml:"organization" mapstructure:"organization" validate:"required"`
+	Workspace    string `yaml:"workspace" mapstructure:"workspace" validate:"required"`
+	// TokenEnv names the environment variable holding the API token. Defaults to
+	// Terraform's TF_TOKEN_<hostname> convention.
+	TokenEnv           string                `yaml:"token_env" mapstructure:"token_env"`
+	DisableAggregation []domain.ResourceKind `yaml:"disable_aggregation" mapstructure:"disable_aggregation"`
+}
+
+// Provider reads desired state from a remote workspace snapshot and maps it like
+// a local state file.
+type Provider struct {
+	*tfstate.Provider
+}
+
+func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
+	hostname := cfg.Hostname
+	if hostname == "" {
+		hostname = defaultHostnames[cfg.Platform]
+	}
+	if hostname == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("remote state platform '%s' requires a hostname", cfg.Platform),
+			"Set state.remote.hostname, e.g. <account>.scalr.io.")
+	}
+	if cfg.Organization == "" || cfg.Workspace == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "remote state provider requires an organization and a workspace")
+	}
+
+	tokenEnv := cfg.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = tokenEnvForHost(hostname)
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("no API token found in environment variable %s", tokenEnv),
+			fmt.Sprintf("Export %s with a %s API token that can read the workspace state.", tokenEnv, cfg.Platform))
+	}
+
+	c := newClient(hostname, token, nil)
+	source := fmt.Sprintf("%s:%s/%s", cfg.Platform, cfg.Organization, cfg.Workspace)
+	fetch := func(ctx context.Context) ([]byte, error) {
+		return c.fetchCurrentState(ctx, cfg.Organization, cfg.Workspace)
+	}
+
+	plog := logger.WithFields(map[string]any{"provider": ProviderTypeRemote, "platform": cfg.Platform})
+	return &Provider{
+		Provider: tfstate.NewProviderWithFetcher(source, fetch, tfstate.Config{DisableAggregation: cfg.DisableAggregation}, plog),
+	}, nil
+}
+
+func (p *Provider) Type() string { return ProviderTypeRemote }
+
+// tokenEnvForHost follows Terraform's credential variable naming: dots become
+// underscores and dashes become double underscores.
+func tokenEnvForHost(hostname string) string {
+	host := hostname
+	if idx := strings.Index(host, "://"); idx >= 0 {
+		host = host[idx+3:]
+	}
+	host = strings.TrimRight(host, "/")
+	host = strings.ReplaceAll(host, "-", "__")
+	host = strings.ReplaceAll(host, ".", "_")
+	return "TF_TOKEN_" + host
+}
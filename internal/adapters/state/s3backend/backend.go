@@ -0,0 +1,161 @@
+package s3backend
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	stderrs "errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// lockIDAttribute is the partition key of Terraform's DynamoDB lock table.
+	lockIDAttribute = "LockID"
+	// lockInfoAttribute holds the JSON lock info while the state is locked.
+	lockInfoAttribute = "Info"
+	// digestAttribute holds the MD5 of the last state Terraform wrote.
+	digestAttribute = "Digest"
+	// digestSuffix marks the lock table item holding the state digest.
+	digestSuffix = "-md5"
+)
+
+// S3ClientInterface is the subset of the S3 API needed to read a state object.
+type S3ClientInterface interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// DynamoDBClientInterface is the subset of the DynamoDB API needed to read the
+// lock and digest items Terraform keeps in its lock table.
+type DynamoDBClientInterface interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+}
+
+// lockInfo is the part of Terraform's lock info reported when the state is locked.
+type lockInfo struct {
+	ID        string `json:"ID"`
+	Operation string `json:"Operation"`
+	Who       string `json:"Who"`
+	Created   string `json:"Created"`
+}
+
+// backend reads a state snapshot the way Terraform's s3 backend stores it.
+type backend struct {
+	bucket    string
+	key       string
+	lockTable string
+	s3Client  S3ClientInterface
+	ddbClient DynamoDBClientInterface
+	logger    ports.Logger
+}
+
+// fetchState downloads the state object. When a lock table is configured, a
+// held lock is logged, since the state may change while it is read, and the
+// object is checked against the digest Terraform recorded so that a stale
+// read is not taken for the current state.
+func (b *backend) fetchState(ctx context.Context) ([]byte, error) {
+	if b.lockTable != "" {
+		b.warnIfLocked(ctx)
+	}
+
+	out, err := b.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if stderrs.As(err, &noSuchKey) {
+			return nil, errors.WrapUserFacing(err, errors.CodeStateReadError,
+				fmt.Sprintf("state object s3://%s/%s does not exist", b.bucket, b.key),
+				"Check state.s3.bucket, key, workspace and workspace_key_prefix against the backend configuration.")
+		}
+		return nil, errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to read state object s3://%s/%s", b.bucket, b.key))
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to read state object s3://%s/%s", b.bucket, b.key))
+	}
+
+	if b.lockTable != "" {
+		if err := b.verifyDigest(ctx, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// lockID is the lock table key Terraform uses for the state object.
+func (b *backend) lockID() string {
+	return path.Join(b.bucket, b.key)
+}
+
+func (b *backend) warnIfLocked(ctx context.Context) {
+	item, err := b.getLockItem(ctx, b.lockID())
+	if err != nil {
+		b.logger.Warnf(ctx, "Could not read state lock from table %s: %v", b.lockTable, err)
+		return
+	}
+	raw, ok := stringAttribute(item, lockInfoAttribute)
+	if !ok {
+		return
+	}
+	var info lockInfo
+	if err := json.Unmarshal([]byte(raw), &info); err != nil {
+		b.logger.Warnf(ctx, "State s3://%s/%s is locked; reading it anyway", b.bucket, b.key)
+		return
+	}
+	b.logger.Warnf(ctx, "State s3://%s/%s is locked by %s for %s since %s (lock %s); reading it anyway",
+		b.bucket, b.key, info.Who, info.Operation, info.Created, info.ID)
+}
+
+func (b *backend) verifyDigest(ctx context.Context, data []byte) error {
+	item, err := b.getLockItem(ctx, b.lockID()+digestSuffix)
+	if err != nil {
+		b.logger.Warnf(ctx, "Could not read state digest from table %s, skipping verification: %v", b.lockTable, err)
+		return nil
+	}
+	expected, ok := stringAttribute(item, digestAttribute)
+	if !ok || expected == "" {
+		return nil
+	}
+	sum := md5.Sum(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return errors.NewUserFacing(errors.CodeStateReadError,
+			fmt.Sprintf("state object s3://%s/%s does not match the digest in lock table %s (got %s, want %s)", b.bucket, b.key, b.lockTable, actual, expected),
+			"The state is being written or the object was changed outside Terraform. Retry once the apply finishes.")
+	}
+	return nil
+}
+
+func (b *backend) getLockItem(ctx context.Context, lockID string) (map[string]ddbtypes.AttributeValue, error) {
+	out, err := b.ddbClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(b.lockTable),
+		Key:            map[string]ddbtypes.AttributeValue{lockIDAttribute: &ddbtypes.AttributeValueMemberS{Value: lockID}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Item, nil
+}
+
+func stringAttribute(item map[string]ddbtypes.AttributeValue, name string) (string, bool) {
+	value, ok := item[name].(*ddbtypes.AttributeValueMemberS)
+	if !ok {
+		return "", false
+	}
+	return value.Value, true
+}
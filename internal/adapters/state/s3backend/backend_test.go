@@ -0,0 +1,154 @@
+package s3backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const testState = `{"version":4,"resources":[]}`
+
+type fakeS3 struct {
+	objects map[string]string
+	keys    []string
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	key := aws.ToString(params.Bucket) + "/" + aws.ToString(params.Key)
+	f.keys = append(f.keys, key)
+	body, ok := f.objects[key]
+	if !ok {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+}
+
+type fakeLockTable struct {
+	items map[string]map[string]ddbtypes.AttributeValue
+}
+
+func (f *fakeLockTable) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	id := params.Key[lockIDAttribute].(*ddbtypes.AttributeValueMemberS).Value
+	return &dynamodb.GetItemOutput{Item: f.items[id]}, nil
+}
+
+func digestOf(data string) string {
+	sum := md5.Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func newTestLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	logger.On("WithFields", mock.Anything).Maybe().Return(logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf", "Errorf"} {
+		args := []any{mock.Anything, mock.Anything}
+		for len(args) <= 8 {
+			logger.On(method, args...).Maybe().Return()
+			args = append(args, mock.Anything)
+		}
+	}
+	return logger
+}
+
+func TestConfigStateKey(t *testing.T) {
+	assert.Equal(t, "network/terraform.tfstate", Config{Key: "network/terraform.tfstate"}.StateKey())
+	assert.Equal(t, "network/terraform.tfstate", Config{Key: "network/terraform.tfstate", Workspace: "default"}.StateKey())
+	assert.Equal(t, "env:/staging/network/terraform.tfstate", Config{Key: "network/terraform.tfstate", Workspace: "staging"}.StateKey())
+	assert.Equal(t, "workspaces/staging/terraform.tfstate",
+		Config{Key: "terraform.tfstate", Workspace: "staging", WorkspaceKeyPrefix: "workspaces"}.StateKey())
+}
+
+func TestFetchState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads object", func(t *testing.T) {
+		s3Client := &fakeS3{objects: map[string]string{"states/app.tfstate": testState}}
+		b := &backend{bucket: "states", key: "app.tfstate", s3Client: s3Client, logger: newTestLogger()}
+
+		data, err := b.fetchState(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, testState, string(data))
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		b := &backend{bucket: "states", key: "app.tfstate", s3Client: &fakeS3{}, logger: newTestLogger()}
+
+		_, err := b.fetchState(ctx)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodeStateReadError))
+	})
+
+	t.Run("matching digest", func(t *testing.T) {
+		table := &fakeLockTable{items: map[string]map[string]ddbtypes.AttributeValue{
+			"states/app.tfstate-md5": {digestAttribute: &ddbtypes.AttributeValueMemberS{Value: digestOf(testState)}},
+		}}
+		b := &backend{bucket: "states", key: "app.tfstate", lockTable: "locks",
+			s3Client: &fakeS3{objects: map[string]string{"states/app.tfstate": testState}}, ddbClient: table, logger: newTestLogger()}
+
+		data, err := b.fetchState(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, testState, string(data))
+	})
+
+	t.Run("stale digest", func(t *testing.T) {
+		table := &fakeLockTable{items: map[string]map[string]ddbtypes.AttributeValue{
+			"states/app.tfstate-md5": {digestAttribute: &ddbtypes.AttributeValueMemberS{Value: digestOf("newer state")}},
+		}}
+		b := &backend{bucket: "states", key: "app.tfstate", lockTable: "locks",
+			s3Client: &fakeS3{objects: map[string]string{"states/app.tfstate": testState}}, ddbClient: table, logger: newTestLogger()}
+
+		_, err := b.fetchState(ctx)
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.CodeStateReadError))
+	})
+
+	t.Run("locked state is read with a warning", func(t *testing.T) {
+		logger := new(portsmocks.Logger)
+		logger.On("Warnf", mock.Anything, mock.MatchedBy(func(format string) bool {
+			return format == "State s3://%s/%s is locked by %s for %s since %s (lock %s); reading it anyway"
+		}), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Once()
+		table := &fakeLockTable{items: map[string]map[string]ddbtypes.AttributeValue{
+			"states/app.tfstate": {lockInfoAttribute: &ddbtypes.AttributeValueMemberS{
+				Value: `{"ID":"lock-1","Operation":"OperationTypeApply","Who":"ci@runner","Created":"2026-01-01T00:00:00Z"}`,
+			}},
+		}}
+		b := &backend{bucket: "states", key: "app.tfstate", lockTable: "locks",
+			s3Client: &fakeS3{objects: map[string]string{"states/app.tfstate": testState}}, ddbClient: table, logger: logger}
+
+		_, err := b.fetchState(ctx)
+
+		require.NoError(t, err)
+		logger.AssertExpectations(t)
+	})
+}
+
+func TestNewProviderWithClientsReadsWorkspaceState(t *testing.T) {
+	s3Client := &fakeS3{objects: map[string]string{"states/env:/staging/app.tfstate": testState}}
+	provider := NewProviderWithClients(Config{Bucket: "states", Key: "app.tfstate", Workspace: "staging"}, s3Client, nil, newTestLogger())
+
+	assert.Equal(t, ProviderTypeS3, provider.Type())
+	_, err := provider.ListResources(context.Background(), domain.KindComputeInstance)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"states/env:/staging/app.tfstate"}, s3Client.keys)
+}
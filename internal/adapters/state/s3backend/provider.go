@@ -0,0 +1,111 @@
+package s3backend
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const ProviderTypeS3 = "s3"
+
+const (
+	// DefaultWorkspace is the workspace whose state is stored at Key itself.
+	DefaultWorkspace = "default"
+	// DefaultWorkspaceKeyPrefix is the s3 backend's default prefix of
+	// non-default workspace states.
+	DefaultWorkspaceKeyPrefix = "env:"
+)
+
+// Config mirrors the settings of Terraform's s3 backend block, so state is read
+// from the bucket directly instead of from a downloaded copy.
+type Config struct {
+	Bucket  string `yaml:"bucket" mapstructure:"bucket" validate:"required"`
+	Key     string `yaml:"key" mapstructure:"key" validate:"required"`
+	Region  string `yaml:"region" mapstructure:"region"`
+	Profile string `yaml:"profile" mapstructure:"profile"`
+	// Workspace selects a non-default workspace, whose state is stored at
+	// <workspace_key_prefix>/<workspace>/<key>.
+	Workspace          string `yaml:"workspace" mapstructure:"workspace"`
+	WorkspaceKeyPrefix string `yaml:"workspace_key_prefix" mapstructure:"workspace_key_prefix"`
+	// DynamoDBTable is the backend's lock table. When set, a held lock is
+	// reported and the state is checked against the digest Terraform recorded.
+	DynamoDBTable      string                `yaml:"dynamodb_table" mapstructure:"dynamodb_table"`
+	DisableAggregation []domain.ResourceKind `yaml:"disable_aggregation" mapstructure:"disable_aggregation"`
+}
+
+// StateKey returns the object key of the configured workspace's state.
+func (c Config) StateKey() string {
+	if c.Workspace == "" || c.Workspace == DefaultWorkspace {
+		return c.Key
+	}
+	prefix := c.WorkspaceKeyPrefix
+	if prefix == "" {
+		prefix = DefaultWorkspaceKeyPrefix
+	}
+	return path.Join(prefix, c.Workspace, c.Key)
+}
+
+// Provider reads desired state from an s3 backend and maps it like a local
+// state file.
+type Provider struct {
+	*tfstate.Provider
+}
+
+func NewProvider(ctx context.Context, cfg Config, logger ports.Logger) (*Provider, error) {
+	if cfg.Bucket == "" || cfg.Key == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "s3 state provider requires a bucket and a key")
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(cfg.Profile))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation,
+			"failed to load AWS configuration for the s3 state backend",
+			"Check state.s3.region and state.s3.profile, or the AWS environment variables.")
+	}
+
+	var ddbClient DynamoDBClientInterface
+	if cfg.DynamoDBTable != "" {
+		ddbClient = dynamodb.NewFromConfig(awsCfg)
+	}
+	return NewProviderWithClients(cfg, s3.NewFromConfig(awsCfg), ddbClient, logger), nil
+}
+
+// NewProviderWithClients creates a provider that uses the given clients. The
+// DynamoDB client is only used when a lock table is configured.
+func NewProviderWithClients(cfg Config, s3Client S3ClientInterface, ddbClient DynamoDBClientInterface, logger ports.Logger) *Provider {
+	key := cfg.StateKey()
+	plog := logger.WithFields(map[string]any{"provider": ProviderTypeS3})
+	b := &backend{
+		bucket:    cfg.Bucket,
+		key:       key,
+		lockTable: cfg.DynamoDBTable,
+		s3Client:  s3Client,
+		ddbClient: ddbClient,
+		logger:    plog,
+	}
+	if ddbClient == nil {
+		b.lockTable = ""
+	}
+	source := fmt.Sprintf("s3://%s/%s", cfg.Bucket, key)
+	return &Provider{
+		Provider: tfstate.NewProviderWithFetcher(source, b.fetchState, tfstate.Config{DisableAggregation: cfg.DisableAggregation}, plog),
+	}
+}
+
+func (p *Provider) Type() string { return ProviderTypeS3 }
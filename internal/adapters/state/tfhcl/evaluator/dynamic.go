@@ -0,0 +1,81 @@
+package evaluator
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var dynamicBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "for_each", Required: true},
+		{Name: "iterator"},
+		{Name: "labels"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "content"},
+	},
+}
+
+// evaluateDynamicBlock expands a dynamic block into one evaluated content block
+// per element of its for_each collection, with the iterator variable (named
+// after the generated block type unless set by iterator) exposing the key and
+// value of the element. known is false when the for_each value is only known
+// after apply, in which case no blocks are generated.
+func evaluateDynamicBlock(ctx context.Context, block *hcl.Block, evalCtx *hcl.EvalContext, logger ports.Logger) (generated []EvaluatedResource, known bool, diags hcl.Diagnostics) {
+	defRange := block.DefRange
+	if len(block.Labels) != 1 {
+		return nil, true, diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid dynamic block", Detail: "A dynamic block requires exactly one label: the type of block to generate.", Subject: &defRange})
+	}
+	blockType := block.Labels[0]
+
+	content, contentDiags := block.Body.Content(dynamicBlockSchema)
+	diags = append(diags, contentDiags...)
+	if DiagsHasFatalErrors(contentDiags) {
+		return nil, true, diags
+	}
+	if len(content.Blocks) != 1 {
+		return nil, true, diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid dynamic block", Detail: "A dynamic block requires exactly one content block.", Subject: &defRange})
+	}
+
+	iteratorName := blockType
+	if attr, ok := content.Attributes["iterator"]; ok {
+		iteratorName = hcl.ExprAsKeyword(attr.Expr)
+		if iteratorName == "" {
+			return nil, true, diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid dynamic iterator name", Detail: "The iterator argument must be a single identifier.", Subject: attr.Expr.Range().Ptr()})
+		}
+	}
+
+	forEachAttr := content.Attributes["for_each"]
+	forEach, forEachDiags := forEachAttr.Expr.Value(evalCtx)
+	diags = append(diags, forEachDiags...)
+	if DiagsHasFatalErrors(forEachDiags) {
+		return nil, true, diags
+	}
+	if !forEach.IsWhollyKnown() {
+		logger.Debugf(ctx, "for_each of dynamic %q block is only known after apply", blockType)
+		return nil, false, diags
+	}
+	if forEach.IsNull() || !forEach.CanIterateElements() {
+		return nil, true, diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid dynamic for_each value", Detail: "Cannot use a " + forEach.Type().FriendlyName() + " value in for_each. A collection or structural value is required.", Subject: forEachAttr.Expr.Range().Ptr()})
+	}
+
+	for it := forEach.ElementIterator(); it.Next(); {
+		if err := ctx.Err(); err != nil {
+			return generated, true, diags
+		}
+		key, val := it.Element()
+		iterCtx := evalCtx.NewChild()
+		iterCtx.Variables = map[string]cty.Value{
+			iteratorName: cty.ObjectVal(map[string]cty.Value{"key": key, "value": val}),
+		}
+		evaluated, blockDiags := EvaluateBlock(ctx, content.Blocks[0], iterCtx, logger)
+		diags = append(diags, filterUnsupportedDiags(blockDiags)...)
+		if evaluated != nil {
+			generated = append(generated, evaluated)
+		}
+	}
+	return generated, true, diags
+}
@@ -1,16 +1,24 @@
 package evaluator
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/hashicorp/hcl/v2"
+	apperrors "github.com/olusolaa/infra-drift-detector/internal/errors"
 )
 
+const diagnosticsWrapWidth = 100
+
+// HCLDiagnosticsError carries the diagnostics of a failed HCL operation. When
+// Files is set, Details renders each diagnostic with its source snippet and the
+// values of the variables referenced by the failing expression.
 type HCLDiagnosticsError struct {
 	Operation string
 	FilePath  string
 	Address   string
 	Diags     hcl.Diagnostics
+	Files     map[string]*hcl.File
 }
 
 func (e *HCLDiagnosticsError) Error() string {
@@ -28,6 +36,28 @@ func (e *HCLDiagnosticsError) Error() string {
 	return fmt.Sprintf("HCL %s error(s) in %s: %s", op, subj, e.Diags.Error())
 }
 
+// Details renders every diagnostic as "file:line:col", followed by the offending
+// source lines and the resolved values of the expression's variables.
+func (e *HCLDiagnosticsError) Details() string {
+	var buf bytes.Buffer
+	writer := hcl.NewDiagnosticTextWriter(&buf, e.Files, diagnosticsWrapWidth, false)
+	for _, diag := range e.Diags {
+		if diag.Subject != nil {
+			fmt.Fprintf(&buf, "%s:%d:%d\n", diag.Subject.Filename, diag.Subject.Start.Line, diag.Subject.Start.Column)
+		}
+		_ = writer.WriteDiagnostic(diag)
+	}
+	return buf.String()
+}
+
+// WrapDiagnostics wraps a diagnostics error into a user-facing AppError whose
+// InternalDetails hold the rendered source snippets.
+func WrapDiagnostics(diagErr *HCLDiagnosticsError, code apperrors.Code, message string) *apperrors.AppError {
+	appErr := apperrors.WrapUserFacing(diagErr, code, message, "Fix the HCL errors at the locations shown in the error details")
+	appErr.InternalDetails = diagErr.Details()
+	return appErr
+}
+
 type ValueConversionError struct {
 	AttributeName string
 	Err           error
@@ -11,6 +11,10 @@ import (
 
 type EvaluatedResource map[string]any
 
+// UnknownValue stands in for an attribute whose value is only known after
+// apply, such as a reference to a data source or to another resource.
+type UnknownValue struct{}
+
 func EvaluateBlock(
 	ctx context.Context,
 	block *hcl.Block,
@@ -31,7 +35,7 @@ func EvaluateBlock(
 	evaluatedContent := make(EvaluatedResource)
 	var allDiags hcl.Diagnostics
 
-	attrs, attrDiags := block.Body.JustAttributes()
+	attrs, attrDiags := blockAttributes(block.Body)
 	allDiags = append(allDiags, attrDiags...)
 	if DiagsHasFatalErrors(allDiags) {
 		blockLogger.Errorf(ctx, &HCLDiagnosticsError{Diags: allDiags}, "Fatal errors parsing attributes, stopping evaluation")
@@ -52,8 +56,9 @@ func EvaluateBlock(
 			attrLogger.Errorf(ctx, &HCLDiagnosticsError{Diags: valEvalDiags}, "Failed evaluation") // Log original
 			continue
 		}
-		if !val.IsKnown() {
-			attrLogger.Warnf(ctx, "Attribute evaluated to an unknown value, skipping")
+		if !val.IsWhollyKnown() {
+			attrLogger.Debugf(ctx, "Attribute evaluated to a value only known after apply")
+			evaluatedContent[name] = UnknownValue{}
 			continue
 		}
 
@@ -83,6 +88,23 @@ func EvaluateBlock(
 				continue
 			}
 
+			if nestedSyntaxBlock.Type == "dynamic" {
+				expanded, known, dynamicDiags := evaluateDynamicBlock(ctx, hclNestedBlock, evalCtx, blockLogger)
+				allDiags = append(allDiags, dynamicDiags...)
+				if DiagsHasFatalErrors(dynamicDiags) {
+					blockLogger.Errorf(ctx, &HCLDiagnosticsError{Diags: dynamicDiags}, "Skipping dynamic block due to fatal errors within it")
+					continue
+				}
+				if !known {
+					evaluatedContent[hclNestedBlock.Labels[0]] = UnknownValue{}
+					continue
+				}
+				for _, generated := range expanded {
+					appendNestedBlock(evaluatedContent, hclNestedBlock.Labels[0], generated)
+				}
+				continue
+			}
+
 			evaluatedNested, blockDiags := EvaluateBlock(ctx, hclNestedBlock, evalCtx, blockLogger)
 			filteredBlockDiags := filterUnsupportedDiags(blockDiags) // Filter nested block diags
 			allDiags = append(allDiags, filteredBlockDiags...)
@@ -98,16 +120,7 @@ func EvaluateBlock(
 				continue
 			}
 
-			key := nestedSyntaxBlock.Type
-			if existingValue, exists := evaluatedContent[key]; exists {
-				if slice, ok := existingValue.([]any); ok {
-					evaluatedContent[key] = append(slice, evaluatedNested)
-				} else {
-					evaluatedContent[key] = []any{existingValue, evaluatedNested}
-				}
-			} else {
-				evaluatedContent[key] = []any{evaluatedNested}
-			}
+			appendNestedBlock(evaluatedContent, nestedSyntaxBlock.Type, evaluatedNested)
 		}
 	}
 
@@ -122,6 +135,36 @@ func EvaluateBlock(
 	return evaluatedContent, allDiags
 }
 
+// blockAttributes returns the attributes of a block body. Syntax bodies are
+// read directly, as JustAttributes rejects bodies that also contain nested
+// blocks.
+func blockAttributes(body hcl.Body) (hcl.Attributes, hcl.Diagnostics) {
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return body.JustAttributes()
+	}
+	attrs := make(hcl.Attributes, len(syntaxBody.Attributes))
+	for name, attr := range syntaxBody.Attributes {
+		attrs[name] = attr.AsHCLAttribute()
+	}
+	return attrs, nil
+}
+
+// appendNestedBlock adds an evaluated nested block, as a plain map, to the list
+// stored under its block type.
+func appendNestedBlock(content EvaluatedResource, key string, evaluated EvaluatedResource) {
+	nested := map[string]any(evaluated)
+	if existingValue, exists := content[key]; exists {
+		if slice, ok := existingValue.([]any); ok {
+			content[key] = append(slice, nested)
+		} else {
+			content[key] = []any{existingValue, nested}
+		}
+	} else {
+		content[key] = []any{nested}
+	}
+}
+
 func filterUnsupportedDiags(diags hcl.Diagnostics) hcl.Diagnostics {
 	if len(diags) == 0 {
 		return diags
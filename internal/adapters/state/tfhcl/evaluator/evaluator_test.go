@@ -115,6 +115,52 @@ func TestEvaluateBlock(t *testing.T) {
 		assert.Equal(t, "yes", evaluated["known"])
 	})
 
+	t.Run("Nested and Dynamic Blocks", func(t *testing.T) {
+		block := parseTestResourceBlock(t, `
+            resource "t" "example" {
+                name = "web"
+                root_block_device { volume_size = 20 }
+                dynamic "ingress" {
+                    for_each = { http = 80, https = 443 }
+                    content {
+                        description = ingress.key
+                        port        = ingress.value
+                    }
+                }
+                dynamic "tag" {
+                    for_each = ["a", "b"]
+                    iterator = t
+                    content { value = "${t.value}-${var.region}" }
+                }
+            }
+        `)
+		evaluated, diags := EvaluateBlock(ctx, block, evalCtx, mockLogger)
+		require.False(t, DiagsHasFatalErrors(diags), diags.Error())
+		assert.Equal(t, "web", evaluated["name"])
+		assert.Equal(t, []any{map[string]any{"volume_size": float64(20)}}, evaluated["root_block_device"])
+		assert.Equal(t, []any{
+			map[string]any{"description": "http", "port": float64(80)},
+			map[string]any{"description": "https", "port": float64(443)},
+		}, evaluated["ingress"])
+		assert.Equal(t, []any{
+			map[string]any{"value": "a-us-east-1"},
+			map[string]any{"value": "b-us-east-1"},
+		}, evaluated["tag"])
+	})
+
+	t.Run("For Expressions", func(t *testing.T) {
+		block := parseTestResourceBlock(t, `
+            resource "t" "example" {
+                names = [for n in ["a", "b"] : upper(n)]
+                tags  = merge({ for k, v in { env = "dev" } : k => "${v}-${local.az}" }, { team = "core" })
+            }
+        `)
+		evaluated, diags := EvaluateBlock(ctx, block, evalCtx, mockLogger)
+		require.False(t, DiagsHasFatalErrors(diags), diags.Error())
+		assert.Equal(t, []any{"A", "B"}, evaluated["names"])
+		assert.Equal(t, map[string]any{"env": "dev-a", "team": "core"}, evaluated["tags"])
+	})
+
 	t.Run("Context Cancellation During Evaluation", func(t *testing.T) {
 		block := parseTestResourceBlock(t, `
             resource "t" "example" {
@@ -0,0 +1,110 @@
+package evaluator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FileFunctions returns the Terraform functions reading files. Relative paths
+// are resolved against baseDir, the directory Terraform runs in.
+func FileFunctions(baseDir string) map[string]function.Function {
+	return map[string]function.Function{
+		"file":         makeFileFunc(baseDir),
+		"fileexists":   makeFileExistsFunc(baseDir),
+		"templatefile": makeTemplateFileFunc(baseDir),
+	}
+}
+
+func makeFileFunc(baseDir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "path", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			src, err := readFile(baseDir, args[0].AsString())
+			if err != nil {
+				return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+			}
+			return cty.StringVal(src), nil
+		},
+	})
+}
+
+func makeFileExistsFunc(baseDir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "path", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			info, err := os.Stat(resolvePath(baseDir, args[0].AsString()))
+			if err != nil {
+				if os.IsNotExist(err) {
+					return cty.False, nil
+				}
+				return cty.UnknownVal(cty.Bool), function.NewArgError(0, err)
+			}
+			return cty.BoolVal(info.Mode().IsRegular()), nil
+		},
+	})
+}
+
+// makeTemplateFileFunc renders a template file with the given variables, like
+// Terraform's templatefile. Templates can call every function except
+// templatefile itself.
+func makeTemplateFileFunc(baseDir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+			{Name: "vars", Type: cty.DynamicPseudoType},
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path := args[0].AsString()
+			src, err := readFile(baseDir, path)
+			if err != nil {
+				return cty.DynamicVal, function.NewArgError(0, err)
+			}
+			vars := args[1]
+			if vars.IsNull() || !(vars.Type().IsObjectType() || vars.Type().IsMapType()) {
+				return cty.DynamicVal, function.NewArgErrorf(1, "invalid vars value: must be a map or object")
+			}
+
+			expr, diags := hclsyntax.ParseTemplate([]byte(src), resolvePath(baseDir, path), hcl.Pos{Line: 1, Column: 1})
+			if diags.HasErrors() {
+				return cty.DynamicVal, function.NewArgError(0, diags)
+			}
+			funcs := StandardFunctions()
+			funcs["file"] = makeFileFunc(baseDir)
+			funcs["fileexists"] = makeFileExistsFunc(baseDir)
+			evalCtx := &hcl.EvalContext{Variables: vars.AsValueMap(), Functions: funcs}
+			val, diags := expr.Value(evalCtx)
+			if diags.HasErrors() {
+				return cty.DynamicVal, diags
+			}
+			return val, nil
+		},
+	})
+}
+
+func readFile(baseDir, path string) (string, error) {
+	src, err := os.ReadFile(resolvePath(baseDir, path))
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(src) {
+		return "", fmt.Errorf("contents of %s are not valid UTF-8", path)
+	}
+	return string(src), nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) || baseDir == "" {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
@@ -0,0 +1,90 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func evalTestExpr(t *testing.T, baseDir, src string) (cty.Value, hcl.Diagnostics) {
+	t.Helper()
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diags.HasErrors(), diags.Error())
+	functions := StandardFunctions()
+	for name, fn := range FileFunctions(baseDir) {
+		functions[name] = fn
+	}
+	return expr.Value(&hcl.EvalContext{Functions: functions})
+}
+
+func TestStandardFunctions_Network(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{`cidrsubnet("10.0.0.0/16", 8, 2)`, "10.0.2.0/24"},
+		{`cidrsubnet("10.1.0.0/16", 4, 15)`, "10.1.240.0/20"},
+		{`cidrsubnet("fd00:fd12:3456:7890::/56", 16, 162)`, "fd00:fd12:3456:7800:a200::/72"},
+		{`cidrhost("10.12.112.0/20", 16)`, "10.12.112.16"},
+		{`cidrhost("10.12.112.0/20", -2)`, "10.12.127.254"},
+		{`cidrnetmask("172.16.0.0/12")`, "255.240.0.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			val, diags := evalTestExpr(t, "", tc.expr)
+			require.False(t, diags.HasErrors(), diags.Error())
+			assert.Equal(t, tc.expected, val.AsString())
+		})
+	}
+
+	for _, expr := range []string{`cidrsubnet("10.0.0.0/30", 4, 0)`, `cidrsubnet("10.0.0.0/16", 2, 4)`, `cidrhost("10.0.0.0/30", 4)`, `cidrnetmask("fd00::/8")`} {
+		_, diags := evalTestExpr(t, "", expr)
+		assert.True(t, diags.HasErrors(), expr)
+	}
+}
+
+func TestStandardFunctions_Conversions(t *testing.T) {
+	val, diags := evalTestExpr(t, "", `base64decode(base64encode(tostring(42)))`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, "42", val.AsString())
+
+	val, diags = evalTestExpr(t, "", `try(lookup({}, "missing"), "fallback")`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, "fallback", val.AsString())
+
+	val, diags = evalTestExpr(t, "", `jsonencode({ ports = [80, 443] })`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, `{"ports":[80,443]}`, val.AsString())
+}
+
+func TestFileFunctions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user_data.sh.tpl"), []byte(`#!/bin/sh
+echo ${upper(name)}
+%{ for p in ports ~}
+open ${p}
+%{ endfor ~}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.json"), []byte(`{"Version":"2012-10-17"}`), 0644))
+
+	val, diags := evalTestExpr(t, dir, `templatefile("user_data.sh.tpl", { name = "web", ports = [80, 443] })`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, "#!/bin/sh\necho WEB\nopen 80\nopen 443\n", val.AsString())
+
+	val, diags = evalTestExpr(t, dir, `jsondecode(file("policy.json")).Version`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, "2012-10-17", val.AsString())
+
+	val, diags = evalTestExpr(t, dir, `fileexists("missing.txt")`)
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.False(t, val.True())
+
+	_, diags = evalTestExpr(t, dir, `templatefile("user_data.sh.tpl", { name = "web" })`)
+	assert.True(t, diags.HasErrors(), "templates referencing missing variables must fail")
+}
@@ -0,0 +1,174 @@
+package evaluator
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type localDefinition struct {
+	name string
+	attr *hclsyntax.Attribute
+	deps []string
+}
+
+// evaluateLocals evaluates all locals blocks in dependency order. Locals are
+// grouped into waves where every local only references locals from earlier
+// waves; the locals of a wave are evaluated concurrently against a context that
+// holds everything evaluated so far. Reference cycles are reported as errors.
+func evaluateLocals(ctx context.Context, files map[string]*hcl.File, baseCtx *hcl.EvalContext, logger ports.Logger) (map[string]cty.Value, hcl.Diagnostics) {
+	defs, diags := collectLocalDefinitions(files)
+	evaluated := make(map[string]cty.Value, len(defs))
+	if len(defs) == 0 || DiagsHasFatalErrors(diags) {
+		return evaluated, diags
+	}
+
+	resolved := make(map[string]bool, len(defs))
+	pending := make([]*localDefinition, 0, len(defs))
+	for _, def := range defs {
+		pending = append(pending, def)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].name < pending[j].name })
+
+	workers := runtime.GOMAXPROCS(0)
+	wave := 0
+	for len(pending) > 0 {
+		if ctx.Err() != nil {
+			return evaluated, diags
+		}
+
+		var ready, blocked []*localDefinition
+		for _, def := range pending {
+			if depsResolved(def, defs, resolved) {
+				ready = append(ready, def)
+			} else {
+				blocked = append(blocked, def)
+			}
+		}
+		if len(ready) == 0 {
+			for _, def := range blocked {
+				nameRange := def.attr.NameRange
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Cycle in local values",
+					Detail:   "Local value " + def.name + " depends on itself through: " + strings.Join(def.deps, ", "),
+					Subject:  &nameRange,
+				})
+			}
+			return evaluated, diags
+		}
+
+		wave++
+		evalCtx := &hcl.EvalContext{Variables: make(map[string]cty.Value, len(baseCtx.Variables)), Functions: baseCtx.Functions}
+		for k, v := range baseCtx.Variables {
+			evalCtx.Variables[k] = v
+		}
+		evalCtx.Variables["local"] = cty.ObjectVal(copyValues(evaluated))
+
+		values := make([]cty.Value, len(ready))
+		valueDiags := make([]hcl.Diagnostics, len(ready))
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, workers)
+		for i, def := range ready {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, def *localDefinition) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				values[i], valueDiags[i] = def.attr.Expr.Value(evalCtx)
+			}(i, def)
+		}
+		wg.Wait()
+
+		for i, def := range ready {
+			diags = append(diags, valueDiags[i]...)
+			if !DiagsHasFatalErrors(valueDiags[i]) {
+				evaluated[def.name] = values[i]
+			}
+			resolved[def.name] = true
+		}
+		logger.Debugf(ctx, "Evaluated %d locals in wave %d", len(ready), wave)
+		pending = blocked
+	}
+	return evaluated, diags
+}
+
+// collectLocalDefinitions gathers local values from all files, recording the
+// other locals each one references and flagging duplicate definitions.
+func collectLocalDefinitions(files map[string]*hcl.File) (map[string]*localDefinition, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	defs := make(map[string]*localDefinition)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		syntaxBody, ok := files[path].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range syntaxBody.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			for name, attr := range block.Body.Attributes {
+				attrNameRange := attr.NameRange
+				if existing, exists := defs[name]; exists {
+					diags = append(diags, &hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Duplicate local value definition", Detail: "Local value " + name + " was already defined at " + existing.attr.NameRange.String(), Subject: &attrNameRange})
+					continue
+				}
+				defs[name] = &localDefinition{name: name, attr: attr, deps: localReferences(attr.Expr)}
+			}
+		}
+	}
+	return defs, diags
+}
+
+// localReferences returns the names of the locals an expression refers to.
+func localReferences(expr hclsyntax.Expression) []string {
+	seen := make(map[string]bool)
+	var deps []string
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		attr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok || seen[attr.Name] {
+			continue
+		}
+		seen[attr.Name] = true
+		deps = append(deps, attr.Name)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// depsResolved reports whether every local the definition refers to has been
+// evaluated (or has failed). References to undefined locals do not block; they
+// surface as evaluation errors instead.
+func depsResolved(def *localDefinition, defs map[string]*localDefinition, resolved map[string]bool) bool {
+	for _, dep := range def.deps {
+		if _, defined := defs[dep]; defined && !resolved[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func copyValues(in map[string]cty.Value) map[string]cty.Value {
+	out := make(map[string]cty.Value, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -16,7 +16,12 @@ import (
 type Module struct {
 	logger      ports.Logger
 	path        string
+	rootPath    string
+	address     string
 	workspace   string
+	files       map[string]*hcl.File
+	children    []*Module
+	options     loadOptions
 	inputVars   map[string]cty.Value
 	variables   map[string]*VariableDefinition
 	locals      map[string]cty.Value
@@ -41,21 +46,58 @@ func LoadModule(
 	varFilePaths []string,
 	workspaceName string,
 	logger ports.Logger,
+	opts ...LoadOption,
+) (map[string]*hcl.File, *Module, error) {
+	call := &moduleCall{rootPath: dirPath}
+	for _, opt := range opts {
+		opt(&call.options)
+	}
+	return loadModule(ctx, hclparse.NewParser(), dirPath, varFilePaths, workspaceName, call, logger)
+}
+
+// LoadOption configures how LoadModule evaluates a module.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	unknownReferences bool
+}
+
+// WithUnknownReferences makes references to values only known after apply,
+// i.e. to data sources, resources and module outputs, evaluate to unknown
+// values instead of failing evaluation.
+func WithUnknownReferences() LoadOption {
+	return func(o *loadOptions) {
+		o.unknownReferences = true
+	}
+}
+
+// loadModule loads the module in dirPath as instantiated by call, followed by
+// the local modules it calls.
+func loadModule(
+	ctx context.Context,
+	parser *hclparse.Parser,
+	dirPath string,
+	varFilePaths []string,
+	workspaceName string,
+	call *moduleCall,
+	logger ports.Logger,
 ) (map[string]*hcl.File, *Module, error) {
 
 	logger = logger.WithFields(map[string]any{"component": "hcl_module_loader", "module_path": dirPath})
+	if call.address != "" {
+		logger = logger.WithFields(map[string]any{"module_address": call.address})
+	}
 	logger.Debugf(ctx, "Loading HCL module...")
 
-	parser := hclparse.NewParser()
 	files, parseDiags, err := parseHCLFiles(ctx, parser, dirPath, logger)
 	if err != nil {
 		if err == context.Canceled || err == context.DeadlineExceeded {
 			return files, nil, err
 		}
-		return files, nil, apperrors.Wrap(&HCLDiagnosticsError{Operation: "parsing", FilePath: dirPath, Diags: parseDiags}, apperrors.CodeStateParseError, err.Error())
+		return files, nil, WrapDiagnostics(&HCLDiagnosticsError{Operation: "parsing", FilePath: dirPath, Diags: parseDiags, Files: files}, apperrors.CodeStateParseError, err.Error())
 	}
 	if DiagsHasFatalErrors(parseDiags) {
-		return files, nil, apperrors.Wrap(&HCLDiagnosticsError{Operation: "parsing", FilePath: dirPath, Diags: parseDiags}, apperrors.CodeStateParseError, "fatal parsing errors")
+		return files, nil, WrapDiagnostics(&HCLDiagnosticsError{Operation: "parsing", FilePath: dirPath, Diags: parseDiags, Files: files}, apperrors.CodeStateParseError, "fatal parsing errors")
 	}
 	if len(files) == 0 {
 		return files, nil, apperrors.New(apperrors.CodeStateParseError, "no HCL files found")
@@ -67,7 +109,11 @@ func LoadModule(
 	mod := &Module{
 		logger:    logger,
 		path:      dirPath,
+		rootPath:  call.rootPath,
+		address:   call.address,
 		workspace: workspaceName,
+		files:     files,
+		options:   call.options,
 		initDiags: parseDiags,
 		variables: make(map[string]*VariableDefinition),
 	}
@@ -117,15 +163,19 @@ func LoadModule(
 	}
 	mod.initDiags = append(mod.initDiags, varDefDiags...)
 	if DiagsHasFatalErrors(mod.initDiags) {
-		return files, mod, apperrors.Wrap(&HCLDiagnosticsError{Operation: "decoding variables", FilePath: dirPath, Diags: mod.initDiags}, apperrors.CodeStateParseError, "fatal errors decoding variable blocks")
+		return files, mod, WrapDiagnostics(&HCLDiagnosticsError{Operation: "decoding variables", FilePath: dirPath, Diags: mod.initDiags, Files: files}, apperrors.CodeStateParseError, "fatal errors decoding variable blocks")
 	}
 	logger.Debugf(ctx, "Decoded %d variable definitions", len(mod.variables))
 
 	var mergeDiags hcl.Diagnostics
-	mod.inputVars, mergeDiags = mergeVariablesAndDefaults(ctx, parser, mod.variables, varFilePaths, logger) // Pass decoded definitions
+	if call.address == "" {
+		mod.inputVars, mergeDiags = mergeVariablesAndDefaults(ctx, parser, mod.variables, varFilePaths, logger) // Pass decoded definitions
+	} else {
+		mod.inputVars, mergeDiags = mergeModuleInputs(mod.variables, call)
+	}
 	mod.initDiags = append(mod.initDiags, mergeDiags...)
 	if DiagsHasFatalErrors(mod.initDiags) {
-		return files, mod, apperrors.Wrap(&HCLDiagnosticsError{Operation: "merging variables", FilePath: dirPath, Diags: mod.initDiags}, apperrors.CodeStateParseError, "fatal errors processing variable values")
+		return files, mod, WrapDiagnostics(&HCLDiagnosticsError{Operation: "merging variables", FilePath: dirPath, Diags: mod.initDiags, Files: files}, apperrors.CodeStateParseError, "fatal errors processing variable values")
 	}
 	logger.Debugf(ctx, "Final input variable count: %d", len(mod.inputVars))
 	if err := ctx.Err(); err != nil {
@@ -134,56 +184,20 @@ func LoadModule(
 
 	mod.initDiags = append(mod.initDiags, mod.buildInitialContext(ctx)...)
 	if DiagsHasFatalErrors(mod.initDiags) {
-		return files, mod, apperrors.Wrap(&HCLDiagnosticsError{Operation: "building initial context", FilePath: dirPath, Diags: mod.initDiags}, apperrors.CodeStateParseError, "fatal errors building initial context")
+		return files, mod, WrapDiagnostics(&HCLDiagnosticsError{Operation: "building initial context", FilePath: dirPath, Diags: mod.initDiags, Files: files}, apperrors.CodeStateParseError, "fatal errors building initial context")
 	}
 	if err := ctx.Err(); err != nil {
 		return files, mod, err
 	}
 
 	logger.Debugf(ctx, "Evaluating locals blocks...")
-	var localsDiags hcl.Diagnostics
-	definedLocals := make(map[string]string)
-	evaluatedLocals := make(map[string]cty.Value)
-
-	for _, file := range files {
-		if err := ctx.Err(); err != nil {
-			return files, mod, err
-		}
-		syntaxBody, ok := file.Body.(*hclsyntax.Body)
-		if !ok {
-			continue
-		}
-
-		for _, block := range syntaxBody.Blocks {
-			if block.Type != "locals" {
-				continue
-			}
-			if err := ctx.Err(); err != nil {
-				return files, mod, err
-			}
-
-			for name, attr := range block.Body.Attributes {
-				if err := ctx.Err(); err != nil {
-					return files, mod, err
-				}
-				attrNameRange := attr.NameRange
-				if definedAtStr, exists := definedLocals[name]; exists {
-					localsDiags = append(localsDiags, &hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Duplicate local value definition", Detail: "Local value " + name + " was already defined at " + definedAtStr, Subject: &attrNameRange}) // Use stored range
-					continue
-				}
-				definedLocals[name] = ""
-
-				val, valDiags := attr.Expr.Value(mod.evalContext)
-				localsDiags = append(localsDiags, valDiags...)
-				if !DiagsHasFatalErrors(valDiags) {
-					evaluatedLocals[name] = val
-				}
-			}
-		}
+	evaluatedLocals, localsDiags := evaluateLocals(ctx, files, mod.evalContext, logger)
+	if err := ctx.Err(); err != nil {
+		return files, mod, err
 	}
 	mod.initDiags = append(mod.initDiags, localsDiags...)
 	if DiagsHasFatalErrors(mod.initDiags) {
-		return files, mod, apperrors.Wrap(&HCLDiagnosticsError{Operation: "evaluating locals", FilePath: dirPath, Diags: mod.initDiags}, apperrors.CodeStateParseError, "fatal errors evaluating locals")
+		return files, mod, WrapDiagnostics(&HCLDiagnosticsError{Operation: "evaluating locals", FilePath: dirPath, Diags: mod.initDiags, Files: files}, apperrors.CodeStateParseError, "fatal errors evaluating locals")
 	}
 
 	if len(evaluatedLocals) > 0 {
@@ -205,6 +219,11 @@ func LoadModule(
 		return files, mod, err
 	}
 
+	mod.initDiags = append(mod.initDiags, mod.loadChildren(ctx, parser, call)...)
+	if err := ctx.Err(); err != nil {
+		return files, mod, err
+	}
+
 	// --- Final Check ---
 	if len(mod.initDiags) > 0 {
 		logger.Warnf(ctx, "Non-fatal diagnostics during module load:\n%s", mod.initDiags.Error())
@@ -213,6 +232,11 @@ func LoadModule(
 	return files, mod, nil
 }
 
+// Diagnostics returns the non-fatal diagnostics collected while loading the module.
+func (m *Module) Diagnostics() hcl.Diagnostics {
+	return m.initDiags
+}
+
 func (m *Module) EvalContext() *hcl.EvalContext {
 	m.evalMutex.RLock()
 	defer m.evalMutex.RUnlock()
@@ -318,20 +342,50 @@ func (m *Module) buildInitialContext(ctx context.Context) hcl.Diagnostics {
 		diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagWarning, Summary: "Failed to get module directory absolute path", Detail: err.Error()})
 		modulePath = m.path
 	}
+	rootPath, err := filepath.Abs(m.rootPath)
+	if err != nil {
+		rootPath = modulePath
+	}
 
-	m.evalContext = &hcl.EvalContext{
-		Variables: map[string]cty.Value{
-			"var":       cty.ObjectVal(m.inputVars),
-			"path":      cty.ObjectVal(map[string]cty.Value{"module": cty.StringVal(modulePath), "root": cty.StringVal(modulePath), "cwd": cty.StringVal(cwd)}),
-			"terraform": cty.ObjectVal(map[string]cty.Value{"workspace": cty.StringVal(m.workspace)}),
-			"local":     cty.EmptyObjectVal,
-		},
-		Functions: StandardFunctions(),
+	variables := map[string]cty.Value{
+		"var":       cty.ObjectVal(m.inputVars),
+		"path":      cty.ObjectVal(map[string]cty.Value{"module": cty.StringVal(modulePath), "root": cty.StringVal(rootPath), "cwd": cty.StringVal(cwd)}),
+		"terraform": cty.ObjectVal(map[string]cty.Value{"workspace": cty.StringVal(m.workspace)}),
+		"local":     cty.EmptyObjectVal,
+	}
+	if m.options.unknownReferences {
+		for _, name := range m.unknownReferenceRoots() {
+			variables[name] = cty.DynamicVal
+		}
 	}
+	functions := StandardFunctions()
+	for name, fn := range FileFunctions(rootPath) {
+		functions[name] = fn
+	}
+	m.evalContext = &hcl.EvalContext{Variables: variables, Functions: functions}
 	m.logger.Debugf(ctx, "Initial context built")
 	return diags
 }
 
+// unknownReferenceRoots returns the names expressions use to refer to values
+// only known after apply: data sources, module outputs, the count and
+// for_each iterators and the resource types declared in the module.
+func (m *Module) unknownReferenceRoots() []string {
+	roots := []string{"data", "module", "count", "each", "self"}
+	for _, file := range m.files {
+		syntaxBody, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range syntaxBody.Blocks {
+			if block.Type == "resource" && len(block.Labels) == 2 {
+				roots = append(roots, block.Labels[0])
+			}
+		}
+	}
+	return roots
+}
+
 func syntaxBlockToHclBlock(syntaxBlock *hclsyntax.Block, parentBody hcl.Body) *hcl.Block {
 	schema := &hcl.BodySchema{Blocks: []hcl.BlockHeaderSchema{{Type: syntaxBlock.Type, LabelNames: syntaxBlock.Labels}}}
 	content, _, _ := parentBody.PartialContent(schema) // Ignore diags for this helper
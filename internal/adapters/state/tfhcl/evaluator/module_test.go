@@ -4,7 +4,9 @@ package evaluator
 
 import (
 	"context"
+	"github.com/hashicorp/hcl/v2"
 	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	apperrors "github.com/olusolaa/infra-drift-detector/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -103,6 +105,7 @@ func TestLoadModule_Locals(t *testing.T) {
 	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe() // Adjust arg count
 	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()  // Adjust arg count
 	mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
 	ctx := context.Background()
 
 	t.Run("Simple Locals", func(t *testing.T) {
@@ -139,6 +142,52 @@ func TestLoadModule_Locals(t *testing.T) {
 		assert.Contains(t, err.Error(), "Unsupported attribute")
 	})
 
+	t.Run("Locals Evaluation Error Details", func(t *testing.T) {
+		dir := t.TempDir()
+		createTestFile(t, dir, "vars.tf", `variable "env" { default = "dev" }`)
+		localsPath := createTestFile(t, dir, "locals.tf", `locals {
+  count = var.env + 1
+}`)
+		_, _, err := LoadModule(ctx, dir, nil, "default", mockLogger)
+		require.Error(t, err)
+
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.True(t, appErr.IsUserFacing)
+		assert.Contains(t, appErr.InternalDetails, localsPath+":2:11")
+		assert.Contains(t, appErr.InternalDetails, "count = var.env + 1")
+		assert.Contains(t, appErr.InternalDetails, `with var.env as "dev"`)
+	})
+
+	t.Run("Locals Referencing Locals", func(t *testing.T) {
+		dir := t.TempDir()
+		createTestFile(t, dir, "vars.tf", `variable "env" { default = "dev" }`)
+		createTestFile(t, dir, "a.tf", `locals { full_name = "${local.prefix}-${local.suffix}" }`)
+		createTestFile(t, dir, "b.tf", `locals {
+  prefix = "app-${var.env}"
+  suffix = upper(local.region)
+  region = "eu"
+}`)
+		_, mod, err := LoadModule(ctx, dir, nil, "default", mockLogger)
+		require.NoError(t, err)
+		require.NotNil(t, mod)
+		locals := mod.EvalContext().Variables["local"]
+		assert.Equal(t, "app-dev-EU", locals.GetAttr("full_name").AsString())
+		assert.Equal(t, "eu", locals.GetAttr("region").AsString())
+	})
+
+	t.Run("Locals Cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		createTestFile(t, dir, "locals.tf", `locals {
+  a = local.b
+  b = local.a
+  c = "fine"
+}`)
+		_, _, err := LoadModule(ctx, dir, nil, "default", mockLogger)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "Cycle in local values")
+	})
+
 	t.Run("Duplicate Locals", func(t *testing.T) {
 		dir := t.TempDir()
 		createTestFile(t, dir, "locals1.tf", `locals { name = "a" }`)
@@ -149,3 +198,146 @@ func TestLoadModule_Locals(t *testing.T) {
 		assert.ErrorContains(t, err, "Duplicate local value definition")
 	})
 }
+
+func TestLoadModule_ModuleCalls(t *testing.T) {
+	mockLogger := portsmocks.NewLogger(t)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger).Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	ctx := context.Background()
+
+	writeModule := func(t *testing.T, dir, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		createTestFile(t, dir, "main.tf", content)
+	}
+
+	t.Run("Input Propagation", func(t *testing.T) {
+		root := t.TempDir()
+		writeModule(t, root, `
+variable "env" { default = "prod" }
+module "web" {
+  source = "./modules/web"
+  name   = "web-${var.env}"
+  vpc_id = aws_vpc.main.id
+}
+module "registry" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}`)
+		writeModule(t, filepath.Join(root, "modules", "web"), `
+variable "name" {}
+variable "vpc_id" {}
+variable "size" { default = "t3.micro" }
+locals { full_name = "${var.name}-${var.size}" }
+module "logs" {
+  source = "../logs"
+  prefix = local.full_name
+}
+resource "aws_instance" "this" { instance_type = var.size }`)
+		writeModule(t, filepath.Join(root, "modules", "logs"), `
+variable "prefix" {}
+resource "aws_s3_bucket" "this" { bucket = "${var.prefix}-logs" }`)
+
+		_, mod, err := LoadModule(ctx, root, nil, "default", mockLogger)
+		require.NoError(t, err)
+
+		modules := mod.Modules()
+		require.Len(t, modules, 3)
+		assert.Equal(t, "", modules[0].Address())
+		assert.Equal(t, "module.web", modules[1].Address())
+		assert.Equal(t, "module.web.module.logs", modules[2].Address())
+
+		webVars := modules[1].EvalContext().Variables["var"]
+		assert.Equal(t, "web-prod", webVars.GetAttr("name").AsString())
+		assert.False(t, webVars.GetAttr("vpc_id").IsKnown(), "inputs referencing resources are unknown")
+		assert.Equal(t, "web-prod-t3.micro-logs", evaluatedAttr(t, modules[2], "aws_s3_bucket", "bucket"))
+
+		rootPath, err := filepath.Abs(root)
+		require.NoError(t, err)
+		assert.Equal(t, rootPath, modules[2].EvalContext().Variables["path"].GetAttr("root").AsString())
+
+		require.Len(t, mod.Diagnostics(), 1)
+		assert.Equal(t, "Module call not expanded", mod.Diagnostics()[0].Summary)
+
+		resolved, address := mod.ResolveAddress("module.web.module.logs.aws_s3_bucket.this")
+		assert.Same(t, modules[2], resolved)
+		assert.Equal(t, "aws_s3_bucket.this", address)
+		assert.Equal(t, "module.web.aws_instance.this", modules[1].ResourceAddress("aws_instance", "this"))
+		resolved, _ = mod.ResolveAddress("module.registry.aws_vpc.this")
+		assert.Nil(t, resolved)
+	})
+
+	t.Run("Missing Required Input", func(t *testing.T) {
+		root := t.TempDir()
+		writeModule(t, root, `module "web" { source = "./web" }`)
+		writeModule(t, filepath.Join(root, "web"), `variable "name" {}`)
+
+		_, mod, err := LoadModule(ctx, root, nil, "default", mockLogger)
+		require.NoError(t, err, "a module that fails to load must not fail the configuration")
+		assert.Len(t, mod.Modules(), 1)
+		require.True(t, DiagsHasFatalErrors(mod.Diagnostics()))
+		assert.Contains(t, mod.Diagnostics().Error(), "Missing required variable")
+	})
+
+	t.Run("Call Cycle", func(t *testing.T) {
+		root := t.TempDir()
+		writeModule(t, root, `module "a" { source = "./a" }`)
+		writeModule(t, filepath.Join(root, "a"), `module "back" { source = "../" }`)
+
+		_, mod, err := LoadModule(ctx, root, nil, "default", mockLogger)
+		require.NoError(t, err)
+		modules := mod.Modules()
+		require.Len(t, modules, 2)
+		assert.Contains(t, modules[1].Diagnostics().Error(), "Module call cycle")
+	})
+}
+
+func TestLoadModule_UnknownReferences(t *testing.T) {
+	mockLogger := portsmocks.NewLogger(t)
+	mockLogger.On("WithFields", mock.Anything).Return(mockLogger).Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	createTestFile(t, dir, "main.tf", `
+locals { vpc_id = aws_vpc.main.id }
+resource "aws_vpc" "main" { cidr_block = "10.0.0.0/16" }
+resource "aws_instance" "web" {
+  ami           = data.aws_ami.base.id
+  instance_type = "t3.micro"
+  tags          = { Name = "web", Vpc = local.vpc_id }
+}`)
+
+	_, _, err := LoadModule(ctx, dir, nil, "default", mockLogger)
+	require.Error(t, err, "references to other resources fail by default")
+
+	_, mod, err := LoadModule(ctx, dir, nil, "default", mockLogger, WithUnknownReferences())
+	require.NoError(t, err)
+	assert.Equal(t, UnknownValue{}, evaluatedAttr(t, mod, "aws_instance", "ami"))
+	assert.Equal(t, "t3.micro", evaluatedAttr(t, mod, "aws_instance", "instance_type"))
+	assert.Equal(t, UnknownValue{}, evaluatedAttr(t, mod, "aws_instance", "tags"), "partially known values are unknown as a whole")
+}
+
+func evaluatedAttr(t *testing.T, mod *Module, resourceType, attr string) any {
+	t.Helper()
+	var blocks []*hcl.Block
+	for _, file := range mod.Files() {
+		content, _, _ := file.Body.PartialContent(&hcl.BodySchema{Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}}})
+		for _, block := range content.Blocks {
+			if block.Labels[0] == resourceType {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	require.Len(t, blocks, 1)
+	attrs, diags := EvaluateBlock(context.Background(), blocks[0], mod.EvalContext(), mod.logger)
+	require.False(t, DiagsHasFatalErrors(diags), diags.Error())
+	return attrs[attr]
+}
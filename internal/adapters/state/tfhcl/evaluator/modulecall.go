@@ -0,0 +1,301 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleCallMetaArguments are the module block arguments that are not input
+// variables of the called module.
+var moduleCallMetaArguments = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"providers":  true,
+	"depends_on": true,
+	"count":      true,
+	"for_each":   true,
+}
+
+// moduleCall describes how a module is instantiated: the root module by the
+// configured var files, a child module by the arguments of a module block.
+type moduleCall struct {
+	// address is the module address, such as "module.network", or empty for
+	// the root module.
+	address string
+	// rootPath is the directory of the root module, exposed as path.root.
+	rootPath string
+	// inputs are the evaluated arguments of the module block.
+	inputs map[string]cty.Value
+	// declRange is where the module block is declared.
+	declRange hcl.Range
+	// ancestors are the absolute directories of the calling modules, used to
+	// detect module call cycles.
+	ancestors []string
+	// options are the load options of the root module, applied to every
+	// module it calls.
+	options loadOptions
+}
+
+// Files returns the parsed files of the module, keyed by path.
+func (m *Module) Files() map[string]*hcl.File {
+	return m.files
+}
+
+// Address returns the module address, such as "module.network", or an empty
+// string for the root module.
+func (m *Module) Address() string {
+	return m.address
+}
+
+// ResourceAddress returns the address of a resource declared in the module.
+func (m *Module) ResourceAddress(resourceType, name string) string {
+	address := resourceType + "." + name
+	if m.address != "" {
+		address = m.address + "." + address
+	}
+	return address
+}
+
+// Modules returns the module followed by the local modules it calls,
+// depth-first in module name order.
+func (m *Module) Modules() []*Module {
+	modules := []*Module{m}
+	for _, child := range m.children {
+		modules = append(modules, child.Modules()...)
+	}
+	return modules
+}
+
+// ResolveAddress returns the module declaring the resource at address along
+// with the resource address within that module, or a nil module when address
+// names a module that is not called.
+func (m *Module) ResolveAddress(address string) (*Module, string) {
+	moduleNames, resourceAddress := SplitModuleAddress(address)
+	mod := m
+	for _, name := range moduleNames {
+		var next *Module
+		for _, child := range mod.children {
+			if child.address == mod.childAddress(name) {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, resourceAddress
+		}
+		mod = next
+	}
+	return mod, resourceAddress
+}
+
+// SplitModuleAddress splits a resource address such as
+// "module.network.aws_vpc.main" into the names of the modules declaring it
+// and the resource address within the innermost module.
+func SplitModuleAddress(address string) ([]string, string) {
+	var moduleNames []string
+	for strings.HasPrefix(address, "module.") {
+		parts := strings.SplitN(address, ".", 3)
+		if len(parts) < 3 {
+			break
+		}
+		moduleNames = append(moduleNames, parts[1])
+		address = parts[2]
+	}
+	return moduleNames, address
+}
+
+func (m *Module) childAddress(name string) string {
+	if m.address == "" {
+		return "module." + name
+	}
+	return m.address + ".module." + name
+}
+
+// loadChildren loads the modules called with a local source, passing the
+// module block arguments, evaluated in this module, as their input
+// variables. Calls that cannot be expanded are reported as diagnostics and
+// leave the rest of the configuration intact.
+func (m *Module) loadChildren(ctx context.Context, parser *hclparse.Parser, call *moduleCall) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	absPath, err := filepath.Abs(m.path)
+	if err != nil {
+		absPath = m.path
+	}
+	ancestors := append(append([]string(nil), call.ancestors...), absPath)
+	declared := make(map[string]hcl.Range)
+
+	for _, block := range m.moduleBlocks() {
+		if err := ctx.Err(); err != nil {
+			return diags
+		}
+		defRange := block.DefRange
+		if len(block.Labels) != 1 {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid module block", Detail: "Module block requires exactly one label (the name).", Subject: &defRange})
+			continue
+		}
+		name := block.Labels[0]
+		if prev, exists := declared[name]; exists {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Duplicate module call", Detail: "Module " + name + " was already called at " + prev.String(), Subject: &defRange})
+			continue
+		}
+		declared[name] = defRange
+
+		attrs, attrDiags := block.Body.JustAttributes()
+		diags = append(diags, filterUnsupportedDiags(attrDiags)...)
+		source, sourceDiags := moduleSource(attrs, defRange)
+		diags = append(diags, sourceDiags...)
+		if source == "" {
+			continue
+		}
+		if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagWarning, Summary: "Module call not expanded", Detail: fmt.Sprintf("Module %s has the non-local source %q; only local modules are evaluated, so its resources are not part of the desired state.", name, source), Subject: &defRange})
+			continue
+		}
+		if _, ok := attrs["count"]; ok {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagWarning, Summary: "Module call not expanded", Detail: "Module " + name + " uses count, which is not supported for module calls.", Subject: &defRange})
+			continue
+		}
+		if _, ok := attrs["for_each"]; ok {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagWarning, Summary: "Module call not expanded", Detail: "Module " + name + " uses for_each, which is not supported for module calls.", Subject: &defRange})
+			continue
+		}
+
+		childPath := filepath.Join(m.path, source)
+		absChildPath, err := filepath.Abs(childPath)
+		if err != nil {
+			absChildPath = childPath
+		}
+		if containsString(ancestors, absChildPath) {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Module call cycle", Detail: fmt.Sprintf("Module %s calls %s, which is already being loaded by a calling module.", name, source), Subject: &defRange})
+			continue
+		}
+
+		inputs := m.evaluateModuleInputs(ctx, name, attrs)
+		childCall := &moduleCall{
+			address:   m.childAddress(name),
+			rootPath:  call.rootPath,
+			inputs:    inputs,
+			declRange: defRange,
+			ancestors: ancestors,
+			options:   call.options,
+		}
+		_, child, err := loadModule(ctx, parser, childPath, nil, m.workspace, childCall, m.logger)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return diags
+			}
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Failed to load module", Detail: fmt.Sprintf("Module %s (%s) was skipped: %v", name, source, err), Subject: &defRange})
+			continue
+		}
+		m.children = append(m.children, child)
+	}
+
+	sort.Slice(m.children, func(i, j int) bool { return m.children[i].address < m.children[j].address })
+	return diags
+}
+
+// moduleBlocks returns the module blocks declared in the module's files.
+func (m *Module) moduleBlocks() []*hcl.Block {
+	var blocks []*hcl.Block
+	for _, file := range m.files {
+		syntaxBody, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range syntaxBody.Blocks {
+			if block.Type != "module" {
+				continue
+			}
+			if hclBlock := syntaxBlockToHclBlock(block, file.Body); hclBlock != nil {
+				blocks = append(blocks, hclBlock)
+			}
+		}
+	}
+	return blocks
+}
+
+func moduleSource(attrs hcl.Attributes, defRange hcl.Range) (string, hcl.Diagnostics) {
+	attr, ok := attrs["source"]
+	if !ok {
+		return "", hcl.Diagnostics{{Severity: hcl.DiagError, Summary: "Missing module source", Detail: "Module block requires a source argument.", Subject: &defRange}}
+	}
+	val, diags := attr.Expr.Value(nil)
+	if DiagsHasFatalErrors(diags) || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return "", append(diags, &hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Invalid module source", Detail: "The source argument must be a literal string.", Subject: attr.Expr.Range().Ptr()})
+	}
+	return val.AsString(), diags
+}
+
+// evaluateModuleInputs evaluates the module block arguments in this module.
+// Arguments referencing values only known after apply, such as attributes of
+// other resources, are passed as unknown so the attributes depending on them
+// are skipped rather than the whole module.
+func (m *Module) evaluateModuleInputs(ctx context.Context, name string, attrs hcl.Attributes) map[string]cty.Value {
+	evalCtx := m.EvalContext()
+	inputs := make(map[string]cty.Value)
+	for argName, attr := range attrs {
+		if moduleCallMetaArguments[argName] {
+			continue
+		}
+		val, valDiags := attr.Expr.Value(evalCtx)
+		if DiagsHasFatalErrors(valDiags) {
+			m.logger.Debugf(ctx, "Module %s input %s not evaluated, passing an unknown value: %s", name, argName, valDiags.Error())
+			val = cty.DynamicVal
+		}
+		inputs[argName] = val
+	}
+	return inputs
+}
+
+// mergeModuleInputs resolves the input variables of a child module from the
+// arguments of its module block, falling back to the variable defaults.
+func mergeModuleInputs(definitions map[string]*VariableDefinition, call *moduleCall) (map[string]cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	finalVars := make(map[string]cty.Value)
+
+	names := make([]string, 0, len(call.inputs))
+	for name := range call.inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, defined := definitions[name]; !defined {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagWarning, Summary: "Unsupported module argument", Detail: "Argument " + name + " is set by " + call.address + " but not defined as a variable of the module.", Subject: call.declRange.Ptr()})
+		}
+	}
+
+	for name, def := range definitions {
+		var finalVal cty.Value
+		var convDiags hcl.Diagnostics
+		if val, ok := call.inputs[name]; ok {
+			finalVal, convDiags = convertVarType(val, def.Type, def.DeclRange)
+		} else if !def.Default.IsNull() && def.Default.IsKnown() {
+			finalVal, convDiags = convertVarType(def.Default, def.Type, def.DeclRange)
+		} else {
+			diags = diags.Append(&hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Missing required variable", Detail: "Variable " + name + " has no default value and is not set by " + call.address + ".", Subject: &def.DeclRange})
+			continue
+		}
+		diags = append(diags, convDiags...)
+		if !DiagsHasFatalErrors(convDiags) {
+			finalVars[name] = finalVal
+		}
+	}
+	return finalVars, diags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
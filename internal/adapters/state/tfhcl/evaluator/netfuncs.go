@@ -0,0 +1,111 @@
+package evaluator
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// CidrSubnetFunc calculates a subnet address within a network prefix, like
+// Terraform's cidrsubnet.
+var CidrSubnetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "newbits", Type: cty.Number},
+		{Name: "netnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "invalid CIDR expression: %s", err)
+		}
+		newbits, err := smallInt(args[1])
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		netnum := bigInt(args[2])
+
+		ones, bits := network.Mask.Size()
+		newPrefix := ones + newbits
+		if newbits < 0 || newPrefix > bits {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "insufficient address space to extend prefix of %d by %d", ones, newbits)
+		}
+		if netnum.Sign() < 0 || netnum.Cmp(new(big.Int).Lsh(big.NewInt(1), uint(newbits))) >= 0 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(2, "prefix extension of %d does not accommodate a subnet numbered %s", newbits, netnum)
+		}
+
+		ip := addToIP(network.IP, new(big.Int).Lsh(netnum, uint(bits-newPrefix)))
+		subnet := &net.IPNet{IP: ip, Mask: net.CIDRMask(newPrefix, bits)}
+		return cty.StringVal(subnet.String()), nil
+	},
+})
+
+// CidrHostFunc calculates a host address within a network prefix, like
+// Terraform's cidrhost. Negative host numbers count back from the end of the
+// range.
+var CidrHostFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "hostnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "invalid CIDR expression: %s", err)
+		}
+		hostnum := bigInt(args[1])
+
+		ones, bits := network.Mask.Size()
+		hosts := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+		if hostnum.Sign() < 0 {
+			hostnum.Add(hostnum, hosts)
+		}
+		if hostnum.Sign() < 0 || hostnum.Cmp(hosts) >= 0 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "prefix of %d does not accommodate a host numbered %s", ones, bigInt(args[1]))
+		}
+		return cty.StringVal(addToIP(network.IP, hostnum).String()), nil
+	},
+})
+
+// CidrNetmaskFunc converts an IPv4 prefix into a dotted subnet mask, like
+// Terraform's cidrnetmask.
+var CidrNetmaskFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "prefix", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "invalid CIDR expression: %s", err)
+		}
+		if network.IP.To4() == nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "IPv6 addresses cannot have a netmask: %s", args[0].AsString())
+		}
+		return cty.StringVal(net.IP(network.Mask).String()), nil
+	},
+})
+
+// addToIP returns ip plus offset, keeping the length of ip.
+func addToIP(ip net.IP, offset *big.Int) net.IP {
+	sum := new(big.Int).Add(new(big.Int).SetBytes(ip), offset)
+	out := make(net.IP, len(ip))
+	sum.FillBytes(out)
+	return out
+}
+
+func bigInt(val cty.Value) *big.Int {
+	i, _ := val.AsBigFloat().Int(nil)
+	return i
+}
+
+func smallInt(val cty.Value) (int, error) {
+	i := bigInt(val)
+	if !i.IsInt64() || i.Int64() > 128 || i.Int64() < -128 {
+		return 0, fmt.Errorf("value %s is out of range", i)
+	}
+	return int(i.Int64()), nil
+}
@@ -34,18 +34,20 @@ func MapEvaluatedHCLToDomain(
 		evaluatedAttrs = make(evaluator.EvaluatedResource)
 	}
 
-	targetAttrs := make(map[string]any)
-	err := mapping.NormalizeAndCopyAttributes(kind, evaluatedAttrs, targetAttrs)
-	if err != nil {
-		return nil, apperrors.Wrap(err, apperrors.CodeMappingError, fmt.Sprintf("failed normalizing evaluated HCL attributes for %s", address))
-	}
-
 	tfResourceType := ""
-	parts := strings.SplitN(address, ".", 2)
+	_, resourceAddress := evaluator.SplitModuleAddress(address)
+	parts := strings.SplitN(resourceAddress, ".", 2)
 	if len(parts) == 2 {
 		tfResourceType = parts[0]
 	}
 
+	knownAttrs, unknownAttrs := splitUnknownAttributes(evaluatedAttrs)
+	targetAttrs := make(map[string]any)
+	err := mapping.NormalizeAndCopyTypeAttributes(tfResourceType, kind, knownAttrs, targetAttrs)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeMappingError, fmt.Sprintf("failed normalizing evaluated HCL attributes for %s", address))
+	}
+
 	providerType := ""
 	providerParts := strings.SplitN(tfResourceType, "_", 2)
 	if len(providerParts) > 0 {
@@ -57,6 +59,9 @@ func MapEvaluatedHCLToDomain(
 		ProviderType:     providerType,
 		SourceIdentifier: address,
 	}
+	if len(unknownAttrs) > 0 {
+		meta.UnknownAttributes = mapping.DomainAttributeKeys(tfResourceType, kind, unknownAttrs)
+	}
 
 	if _, exists := targetAttrs[domain.KeyID]; !exists {
 		targetAttrs[domain.KeyID] = nil
@@ -70,3 +75,38 @@ func MapEvaluatedHCLToDomain(
 		attr: targetAttrs,
 	}, nil
 }
+
+// splitUnknownAttributes separates the attributes whose values, or part of
+// them, are only known after apply from the known ones.
+func splitUnknownAttributes(attrs evaluator.EvaluatedResource) (map[string]any, []string) {
+	known := make(map[string]any, len(attrs))
+	var unknown []string
+	for name, value := range attrs {
+		if containsUnknown(value) {
+			unknown = append(unknown, name)
+			continue
+		}
+		known[name] = value
+	}
+	return known, unknown
+}
+
+func containsUnknown(value any) bool {
+	switch v := value.(type) {
+	case evaluator.UnknownValue:
+		return true
+	case map[string]any:
+		for _, elem := range v {
+			if containsUnknown(elem) {
+				return true
+			}
+		}
+	case []any:
+		for _, elem := range v {
+			if containsUnknown(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
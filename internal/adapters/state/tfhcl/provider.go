@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/hcl/v2"
@@ -17,18 +19,28 @@ import (
 const ProviderTypeTFHCL = "tfhcl"
 
 type Provider struct {
-	config      Config
-	logger      ports.Logger
-	initOnce    sync.Once
-	initErr     error
-	module      *evaluator.Module
-	parsedFiles map[string]*hcl.File
+	config Config
+	logger ports.Logger
+	// initMu guards the module, which is loaded on first use and again after
+	// Refresh.
+	initMu    sync.Mutex
+	loaded    bool
+	initErr   error
+	module    *evaluator.Module
+	evalCache sync.Map // address -> evaluatedBlock
+	issuesMu  sync.Mutex
+	issues    []domain.StateIssue
+	issueKeys map[string]struct{}
 }
 
 type Config struct {
 	Directory string   `yaml:"directory" mapstructure:"directory" validate:"required,dir"`
 	VarFiles  []string `yaml:"var_files" mapstructure:"var_files" validate:"omitempty,dive,file"`
 	Workspace string   `yaml:"workspace" mapstructure:"workspace" validate:"required"`
+	// UnknownTolerant evaluates references to values only known after apply,
+	// such as data sources, to unknown values instead of skipping the
+	// resource. It is set from settings.unknown_tolerant.
+	UnknownTolerant bool `yaml:"-" mapstructure:"-"`
 }
 
 func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
@@ -56,44 +68,97 @@ func (p *Provider) Type() string {
 	return ProviderTypeTFHCL
 }
 
-func (p *Provider) ensureInitialized(ctx context.Context) error {
-	p.initOnce.Do(func() {
-		p.logger.Infof(ctx, "Initializing HCL provider...")
-		p.parsedFiles, p.module, p.initErr = evaluator.LoadModule(ctx, p.config.Directory, p.config.VarFiles, p.config.Workspace, p.logger)
-		if p.initErr != nil {
-			p.logger.Errorf(ctx, p.initErr, "HCL provider initialization failed")
-		} else {
-			p.logger.Infof(ctx, "HCL provider initialized successfully")
+// Refresh makes the next listing load the configuration again, so edits to
+// the HCL files since the last scan are seen. Evaluated blocks and recorded
+// issues of the previous load are dropped with it.
+func (p *Provider) Refresh(ctx context.Context) {
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+	p.loaded = false
+	p.module = nil
+	p.initErr = nil
+	p.evalCache.Clear()
+
+	p.issuesMu.Lock()
+	defer p.issuesMu.Unlock()
+	p.issues = nil
+	p.issueKeys = nil
+}
+
+// ensureInitialized loads the configuration unless it is already loaded and
+// returns the loaded module.
+func (p *Provider) ensureInitialized(ctx context.Context) (*evaluator.Module, error) {
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+	if p.loaded {
+		return p.module, p.initErr
+	}
+	p.loaded = true
+
+	p.logger.Infof(ctx, "Initializing HCL provider...")
+	var opts []evaluator.LoadOption
+	if p.config.UnknownTolerant {
+		opts = append(opts, evaluator.WithUnknownReferences())
+	}
+	_, p.module, p.initErr = evaluator.LoadModule(ctx, p.config.Directory, p.config.VarFiles, p.config.Workspace, p.logger, opts...)
+	if p.initErr != nil {
+		p.logger.Errorf(ctx, p.initErr, "HCL provider initialization failed")
+	} else {
+		p.logger.Infof(ctx, "HCL provider initialized successfully")
+		for _, mod := range p.module.Modules() {
+			p.recordIssues(mod.Address(), mod.Diagnostics(), false)
 		}
-	})
-	return p.initErr
+	}
+	return p.module, p.initErr
 }
 
 func (p *Provider) ListResources(ctx context.Context, kind domain.ResourceKind) ([]domain.StateResource, error) {
-	if err := p.ensureInitialized(ctx); err != nil {
+	module, err := p.ensureInitialized(ctx)
+	if err != nil {
 		return nil, apperrors.Wrap(err, apperrors.CodeStateReadError, "HCL provider initialization failed")
 	}
-	if p.module == nil || p.parsedFiles == nil {
-		return nil, apperrors.New(apperrors.CodeInternal, "HCL provider not properly initialized (nil module or file map)")
+	if module == nil {
+		return nil, apperrors.New(apperrors.CodeInternal, "HCL provider not properly initialized (nil module)")
+	}
+
+	var domainResources []domain.StateResource
+	for _, mod := range module.Modules() {
+		resources, err := p.listModuleResources(ctx, mod, kind)
+		if err != nil {
+			return nil, err
+		}
+		domainResources = append(domainResources, resources...)
 	}
 
+	p.logger.Debugf(ctx, "Successfully evaluated and mapped %d HCL resources for kind '%s'", len(domainResources), kind)
+	return domainResources, nil
+}
+
+// listModuleResources evaluates and maps the resources of kind declared in one
+// module of the configuration.
+func (p *Provider) listModuleResources(ctx context.Context, mod *evaluator.Module, kind domain.ResourceKind) ([]domain.StateResource, error) {
+	files := mod.Files()
 	p.logger.Debugf(ctx, "Finding HCL resource blocks for kind '%s'", kind)
-	resourceBlocks, findDiags := evaluator.FindResourceBlocksOfType(p.parsedFiles, kind)
+	resourceBlocks, findDiags := evaluator.FindResourceBlocksOfType(files, kind)
 	if evaluator.DiagsHasFatalErrors(findDiags) {
-		err := apperrors.Wrap(&evaluator.HCLDiagnosticsError{Diags: findDiags}, apperrors.CodeStateParseError, "Fatal error finding HCL blocks")
+		err := evaluator.WrapDiagnostics(&evaluator.HCLDiagnosticsError{Diags: findDiags, Files: files}, apperrors.CodeStateParseError, "Fatal error finding HCL blocks")
 		p.logger.Errorf(ctx, err, "Cannot proceed with listing kind %s", kind)
 		return nil, err
 	}
 	if len(findDiags) > 0 {
 		p.logger.Warnf(ctx, "Non-fatal diagnostics finding blocks for %s:\n%s", kind, findDiags.Error())
+		p.recordIssues(mod.Address(), findDiags, false)
 	}
 
 	domainResources := make([]domain.StateResource, 0, len(resourceBlocks))
 	p.logger.Debugf(ctx, "Found %d potential HCL blocks for kind '%s', evaluating...", len(resourceBlocks), kind)
 
-	evalCtx := p.module.EvalContext()
+	evaluated := p.evaluateBlocks(ctx, mod, resourceBlocks)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	for _, block := range resourceBlocks {
+	for i, block := range resourceBlocks {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
@@ -101,16 +166,18 @@ func (p *Provider) ListResources(ctx context.Context, kind domain.ResourceKind)
 			p.logger.Warnf(ctx, "Skipping resource block with unexpected labels: %v", block.Labels)
 			continue
 		}
-		address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+		address := mod.ResourceAddress(block.Labels[0], block.Labels[1])
 		blockLogger := p.logger.WithFields(map[string]any{"hcl_address": address})
 
-		evaluatedAttrs, evalDiags := evaluator.EvaluateBlock(ctx, block, evalCtx, blockLogger)
+		evaluatedAttrs, evalDiags := evaluated[i].attrs, evaluated[i].diags
 		if evaluator.DiagsHasFatalErrors(evalDiags) {
-			blockLogger.Errorf(ctx, &evaluator.HCLDiagnosticsError{Diags: evalDiags}, "Errors evaluating HCL block, skipping resource")
+			blockLogger.Errorf(ctx, evaluator.WrapDiagnostics(&evaluator.HCLDiagnosticsError{Address: address, Diags: evalDiags, Files: files}, apperrors.CodeStateParseError, "Errors evaluating HCL block"), "Errors evaluating HCL block, skipping resource")
+			p.recordIssues(address, evalDiags, true)
 			continue
 		}
 		if len(evalDiags) > 0 {
 			blockLogger.Warnf(ctx, "Non-fatal diagnostics evaluating HCL block:\n%s", evalDiags.Error())
+			p.recordIssues(address, evalDiags, false)
 		}
 
 		mappedRes, mapErr := MapEvaluatedHCLToDomain(kind, address, evaluatedAttrs)
@@ -118,32 +185,38 @@ func (p *Provider) ListResources(ctx context.Context, kind domain.ResourceKind)
 			blockLogger.Errorf(ctx, mapErr, "Failed to map evaluated HCL resource, skipping")
 			continue
 		}
+		p.setSourceLocation(mappedRes, block.DefRange)
 		domainResources = append(domainResources, mappedRes)
 	}
-
-	p.logger.Debugf(ctx, "Successfully evaluated and mapped %d HCL resources for kind '%s'", len(domainResources), kind)
 	return domainResources, nil
 }
 
 func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, identifier string) (domain.StateResource, error) {
-	if err := p.ensureInitialized(ctx); err != nil {
+	module, err := p.ensureInitialized(ctx)
+	if err != nil {
 		return nil, apperrors.Wrap(err, apperrors.CodeStateReadError, "HCL provider initialization failed")
 	}
-	if p.module == nil || p.parsedFiles == nil {
+	if module == nil {
 		return nil, apperrors.New(apperrors.CodeInternal, "HCL provider not properly initialized")
 	}
 
 	resLogger := p.logger.WithFields(map[string]any{"hcl_address": identifier, "resource_kind": kind})
 	resLogger.Debugf(ctx, "Finding specific HCL resource block")
 
-	block, findDiags := evaluator.FindSpecificResourceBlock(p.parsedFiles, identifier)
+	mod, resourceAddress := module.ResolveAddress(identifier)
+	if mod == nil {
+		return nil, apperrors.New(apperrors.CodeResourceNotFound, fmt.Sprintf("resource '%s' not found: its module is not called by the HCL configuration", identifier))
+	}
+	files := mod.Files()
+	block, findDiags := evaluator.FindSpecificResourceBlock(files, resourceAddress)
 	if evaluator.DiagsHasFatalErrors(findDiags) {
-		err := apperrors.Wrap(&evaluator.HCLDiagnosticsError{Diags: findDiags}, apperrors.CodeStateParseError, "Fatal error finding specific HCL block")
+		err := evaluator.WrapDiagnostics(&evaluator.HCLDiagnosticsError{Diags: findDiags, Files: files}, apperrors.CodeStateParseError, "Fatal error finding specific HCL block")
 		resLogger.Errorf(ctx, err, "Cannot proceed with GetResource")
 		return nil, err
 	}
 	if len(findDiags) > 0 {
 		resLogger.Warnf(ctx, "Non-fatal diagnostics finding specific resource block:\n%s", findDiags.Error())
+		p.recordIssues(identifier, findDiags, false)
 	}
 	if block == nil {
 		return nil, apperrors.New(apperrors.CodeResourceNotFound, fmt.Sprintf("resource '%s' not found in HCL files", identifier))
@@ -158,15 +231,15 @@ func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id
 	}
 
 	resLogger.Debugf(ctx, "Evaluating found HCL resource block")
-	evalCtx := p.module.EvalContext()
-	evaluatedAttrs, evalDiags := evaluator.EvaluateBlock(ctx, block, evalCtx, resLogger)
+	evaluatedAttrs, evalDiags := p.evaluateBlock(ctx, identifier, block, mod.EvalContext(), resLogger)
 	if evaluator.DiagsHasFatalErrors(evalDiags) {
-		err := apperrors.Wrap(&evaluator.HCLDiagnosticsError{Address: identifier, Diags: evalDiags}, apperrors.CodeStateParseError, "Errors evaluating target HCL block")
+		err := evaluator.WrapDiagnostics(&evaluator.HCLDiagnosticsError{Address: identifier, Diags: evalDiags, Files: files}, apperrors.CodeStateParseError, "Errors evaluating target HCL block")
 		resLogger.Errorf(ctx, err, "Cannot return resource due to evaluation errors")
 		return nil, err
 	}
 	if len(evalDiags) > 0 {
 		resLogger.Warnf(ctx, "Non-fatal diagnostics evaluating HCL block:\n%s", evalDiags.Error())
+		p.recordIssues(identifier, evalDiags, false)
 	}
 
 	resLogger.Debugf(ctx, "Mapping evaluated HCL resource")
@@ -174,6 +247,120 @@ func (p *Provider) GetResource(ctx context.Context, kind domain.ResourceKind, id
 	if mapErr != nil {
 		return nil, apperrors.Wrap(mapErr, apperrors.CodeInternal, "failed to map evaluated HCL resource")
 	}
+	p.setSourceLocation(mappedRes, block.DefRange)
 
 	return mappedRes, nil
 }
+
+// evaluatedBlock is a cached evaluation result for a resource block.
+type evaluatedBlock struct {
+	attrs evaluator.EvaluatedResource
+	diags hcl.Diagnostics
+}
+
+// evaluateBlocks evaluates resource blocks of a module concurrently. Resource
+// bodies only reference variables and locals, which are fully evaluated when
+// the module loads, so blocks are independent of each other. Results keep
+// block order.
+func (p *Provider) evaluateBlocks(ctx context.Context, mod *evaluator.Module, blocks []*hcl.Block) []evaluatedBlock {
+	evalCtx := mod.EvalContext()
+	results := make([]evaluatedBlock, len(blocks))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+
+	for i, block := range blocks {
+		if len(block.Labels) != 2 {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, block *hcl.Block) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			address := mod.ResourceAddress(block.Labels[0], block.Labels[1])
+			blockLogger := p.logger.WithFields(map[string]any{"hcl_address": address})
+			attrs, diags := p.evaluateBlock(ctx, address, block, evalCtx, blockLogger)
+			results[i] = evaluatedBlock{attrs: attrs, diags: diags}
+		}(i, block)
+	}
+	wg.Wait()
+	return results
+}
+
+// evaluateBlock evaluates a resource block once and caches the result by
+// address, so repeated listing and lookups reuse the evaluation.
+func (p *Provider) evaluateBlock(ctx context.Context, address string, block *hcl.Block, evalCtx *hcl.EvalContext, logger ports.Logger) (evaluator.EvaluatedResource, hcl.Diagnostics) {
+	if cached, ok := p.evalCache.Load(address); ok {
+		entry := cached.(evaluatedBlock)
+		return entry.attrs, entry.diags
+	}
+	attrs, diags := evaluator.EvaluateBlock(ctx, block, evalCtx, logger)
+	if ctx.Err() == nil {
+		p.evalCache.Store(address, evaluatedBlock{attrs: attrs, diags: diags})
+	}
+	return attrs, diags
+}
+
+// StateIssues returns the parse and evaluation issues that did not stop listing:
+// module load warnings and resource blocks skipped because they failed to evaluate.
+func (p *Provider) StateIssues() []domain.StateIssue {
+	p.issuesMu.Lock()
+	defer p.issuesMu.Unlock()
+	issues := make([]domain.StateIssue, len(p.issues))
+	copy(issues, p.issues)
+	return issues
+}
+
+// recordIssues converts diagnostics into state issues, ignoring ones already
+// recorded by an earlier listing or lookup.
+func (p *Provider) recordIssues(address string, diags hcl.Diagnostics, skipped bool) {
+	p.issuesMu.Lock()
+	defer p.issuesMu.Unlock()
+	if p.issueKeys == nil {
+		p.issueKeys = make(map[string]struct{})
+	}
+	for _, diag := range diags {
+		issue := domain.StateIssue{
+			Severity: domain.SeverityWarning,
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+			Address:  address,
+			Skipped:  skipped,
+		}
+		if diag.Severity == hcl.DiagError {
+			issue.Severity = domain.SeverityCritical
+		}
+		if diag.Subject != nil {
+			issue.Location = fmt.Sprintf("%s:%d:%d", p.relativePath(diag.Subject.Filename), diag.Subject.Start.Line, diag.Subject.Start.Column)
+		}
+		key := strings.Join([]string{issue.Address, issue.Location, issue.Summary, issue.Detail}, "\x00")
+		if _, seen := p.issueKeys[key]; seen {
+			continue
+		}
+		p.issueKeys[key] = struct{}{}
+		p.issues = append(p.issues, issue)
+	}
+}
+
+// relativePath returns file relative to the configured directory, using forward
+// slashes, or file unchanged when it lies outside the directory.
+func (p *Provider) relativePath(file string) string {
+	if rel, err := filepath.Rel(p.config.Directory, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return filepath.ToSlash(file)
+}
+
+// setSourceLocation records where the resource block is declared, relative to
+// the configured directory, so findings can link back to the source.
+func (p *Provider) setSourceLocation(res domain.StateResource, rng hcl.Range) {
+	hclRes, ok := res.(*tfHCLResource)
+	if !ok || rng.Filename == "" {
+		return
+	}
+	hclRes.meta.SourceFile = p.relativePath(rng.Filename)
+	hclRes.meta.SourceLine = rng.Start.Line
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfhcl"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	apperrors "github.com/olusolaa/infra-drift-detector/internal/errors"
 )
 
@@ -167,6 +168,13 @@ func TestTFHCLProvider_ListResources(t *testing.T) {
 		require.NoError(t, err) // ListResources itself shouldn't fail, just skip the bad resource
 		require.Len(t, resources, 1)
 		assert.Equal(t, "aws_instance.good", resources[0].Metadata().SourceIdentifier)
+
+		issues := tp.provider.StateIssues()
+		require.Len(t, issues, 1)
+		assert.Equal(t, "aws_instance.bad", issues[0].Address)
+		assert.True(t, issues[0].Skipped)
+		assert.Equal(t, domain.SeverityCritical, issues[0].Severity)
+		assert.Contains(t, issues[0].Location, "main.tf:3:")
 	})
 
 	t.Run("Context Cancellation During Init", func(t *testing.T) {
@@ -269,3 +277,50 @@ func TestTFHCLProvider_GetResource(t *testing.T) {
 		assert.ErrorIs(t, err, context.DeadlineExceeded)
 	})
 }
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(context.Context, string, ...any)        {}
+func (nopLogger) Infof(context.Context, string, ...any)         {}
+func (nopLogger) Warnf(context.Context, string, ...any)         {}
+func (nopLogger) Errorf(context.Context, error, string, ...any) {}
+func (l nopLogger) WithFields(map[string]any) ports.Logger      { return l }
+
+func TestTFHCLProvider_Refresh(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	createTestHCLFile(t, dir, "main.tf", `
+        resource "aws_instance" "web" { instance_type = "t2.micro" }
+        resource "aws_instance" "app" { instance_type = var.nope }
+    `)
+	p, err := tfhcl.NewProvider(tfhcl.Config{Directory: dir}, nopLogger{})
+	require.NoError(t, err)
+
+	resources, err := p.ListResources(ctx, domain.KindComputeInstance)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "t2.micro", resources[0].Attributes()[domain.ComputeInstanceTypeKey])
+	require.Len(t, p.StateIssues(), 1)
+
+	createTestHCLFile(t, dir, "main.tf", `
+        resource "aws_instance" "web" { instance_type = "t3.large" }
+        resource "aws_instance" "app" { instance_type = "t3.micro" }
+    `)
+
+	resources, err = p.ListResources(ctx, domain.KindComputeInstance)
+	require.NoError(t, err)
+	require.Len(t, resources, 1, "without Refresh the loaded configuration is reused")
+
+	p.Refresh(ctx)
+	resources, err = p.ListResources(ctx, domain.KindComputeInstance)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	types := map[string]any{}
+	for _, res := range resources {
+		types[res.Metadata().SourceIdentifier] = res.Attributes()[domain.ComputeInstanceTypeKey]
+	}
+	assert.Equal(t, map[string]any{"aws_instance.web": "t3.large", "aws_instance.app": "t3.micro"}, types)
+	assert.Empty(t, p.StateIssues(), "issues of the previous load are dropped")
+
+	var _ ports.StateRefresher = p
+}
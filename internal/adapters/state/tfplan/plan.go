@@ -0,0 +1,112 @@
+package tfplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// supportedFormatMajor is the major version of the JSON plan format written by
+// `terraform show -json` since Terraform 0.12. Minor versions only add fields.
+const supportedFormatMajor = "1"
+
+// zipSignature starts a binary plan file, which is a zip archive.
+var zipSignature = []byte("PK\x03\x04")
+
+type (
+	// plan is the part of the JSON plan representation the provider reads.
+	plan struct {
+		FormatVersion string         `json:"format_version"`
+		PlannedValues *plannedValues `json:"planned_values"`
+	}
+
+	// plannedValues holds the resources as they will be once the plan is
+	// applied. Values only known after apply are left out.
+	plannedValues struct {
+		RootModule module `json:"root_module"`
+	}
+
+	module struct {
+		Address      string            `json:"address"`
+		Resources    []plannedResource `json:"resources"`
+		ChildModules []module          `json:"child_modules"`
+	}
+
+	plannedResource struct {
+		Address      string         `json:"address"`
+		Mode         string         `json:"mode"`
+		Type         string         `json:"type"`
+		Name         string         `json:"name"`
+		Index        any            `json:"index"`
+		ProviderName string         `json:"provider_name"`
+		Values       map[string]any `json:"values"`
+	}
+)
+
+// parsePlan decodes the JSON representation of a saved plan.
+func parsePlan(raw []byte) (*plan, error) {
+	if len(raw) == 0 {
+		return nil, errors.NewUserFacing(errors.CodeStateParseError, "Terraform plan file is empty", "")
+	}
+	if bytes.HasPrefix(raw, zipSignature) {
+		return nil, errors.NewUserFacing(errors.CodeStateParseError, "Terraform plan file is a binary plan",
+			"Convert it to JSON first: terraform show -json plan.out > plan.json")
+	}
+	var p plan
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeStateParseError, "invalid JSON in Terraform plan", "")
+	}
+	if major, _, _ := strings.Cut(p.FormatVersion, "."); major != supportedFormatMajor {
+		return nil, errors.NewUserFacing(errors.CodeUnsupportedStateVersion,
+			fmt.Sprintf("unsupported Terraform plan format version %q (only %s.x supported)", p.FormatVersion, supportedFormatMajor),
+			"Render the plan with `terraform show -json` of a current Terraform release.")
+	}
+	return &p, nil
+}
+
+// toTerraformState converts the planned values into the Terraform state the
+// plan would leave behind, so they are mapped and aggregated by the tfstate
+// adapter. Resources the plan destroys are absent from the planned values, and
+// resources it creates have no ID yet, so they are matched by address only.
+func toTerraformState(p *plan) *tfstate.State {
+	state := &tfstate.State{Version: 4}
+	if p.PlannedValues == nil {
+		return state
+	}
+	byAddress := make(map[string]int)
+	var walk func(m *module)
+	walk = func(m *module) {
+		for i := range m.Resources {
+			res := &m.Resources[i]
+			attrs := res.Values
+			if attrs == nil {
+				attrs = make(map[string]any)
+			}
+			// Instances of a resource expanded with count or for_each share
+			// its address without the index.
+			key := m.Address + "\x00" + res.Mode + "\x00" + res.Type + "\x00" + res.Name
+			idx, seen := byAddress[key]
+			if !seen {
+				idx = len(state.Resources)
+				byAddress[key] = idx
+				state.Resources = append(state.Resources, tfstate.Resource{
+					Module:   m.Address,
+					Mode:     res.Mode,
+					Type:     res.Type,
+					Name:     res.Name,
+					Provider: res.ProviderName,
+				})
+			}
+			state.Resources[idx].Instances = append(state.Resources[idx].Instances, tfstate.Instance{IndexKey: res.Index, Attributes: attrs})
+		}
+		for i := range m.ChildModules {
+			walk(&m.ChildModules[i])
+		}
+	}
+	walk(&p.PlannedValues.RootModule)
+	return state
+}
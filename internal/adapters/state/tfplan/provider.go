@@ -0,0 +1,60 @@
+package tfplan
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const ProviderTypeTFPlan = "tfplan"
+
+// Config points at the JSON representation of a saved Terraform plan, as
+// written by `terraform show -json plan.out > plan.json`.
+type Config struct {
+	Path               string                `yaml:"path" mapstructure:"path" validate:"required"`
+	DisableAggregation []domain.ResourceKind `yaml:"disable_aggregation" mapstructure:"disable_aggregation"`
+}
+
+// Provider reads desired state from a Terraform plan: the resources as they
+// will be once the plan is applied. Comparing them with the platform before
+// applying shows what the apply would change and catches drift it would
+// silently revert.
+type Provider struct {
+	*tfstate.Provider
+}
+
+func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
+	if cfg.Path == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "tfplan state provider requires the path of a JSON plan")
+	}
+	plog := logger.WithFields(map[string]any{"provider": ProviderTypeTFPlan})
+	return NewProviderWithFetcher(cfg.Path, func(context.Context) ([]byte, error) {
+		return os.ReadFile(cfg.Path)
+	}, cfg, plog), nil
+}
+
+// NewProviderWithFetcher creates a provider that reads the JSON plan through
+// fetch. source names the origin of the plan in logs and errors.
+func NewProviderWithFetcher(source string, fetch tfstate.StateFetcher, cfg Config, logger ports.Logger) *Provider {
+	convert := func(ctx context.Context) ([]byte, error) {
+		raw, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p, err := parsePlan(raw)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(toTerraformState(p))
+	}
+	return &Provider{
+		Provider: tfstate.NewProviderWithFetcher(source, convert, tfstate.Config{DisableAggregation: cfg.DisableAggregation}, logger),
+	}
+}
+
+func (p *Provider) Type() string { return ProviderTypeTFPlan }
@@ -0,0 +1,109 @@
+package tfplan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	portsmocks "github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func newTestLogger() *portsmocks.Logger {
+	logger := new(portsmocks.Logger)
+	logger.On("WithFields", mock.Anything).Maybe().Return(logger)
+	for _, method := range []string{"Debugf", "Infof", "Warnf", "Errorf"} {
+		args := []any{mock.Anything, mock.Anything}
+		for len(args) <= 8 {
+			logger.On(method, args...).Maybe().Return()
+			args = append(args, mock.Anything)
+		}
+	}
+	return logger
+}
+
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := NewProvider(Config{Path: filepath.Join("testdata", "plan.json")}, newTestLogger())
+	require.NoError(t, err)
+	return p
+}
+
+func staticPlan(data string) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) { return []byte(data), nil }
+}
+
+func TestNewProviderRequiresPath(t *testing.T) {
+	_, err := NewProvider(Config{}, newTestLogger())
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+}
+
+func TestListResources_PlannedInstances(t *testing.T) {
+	p := newTestProvider(t)
+	assert.Equal(t, ProviderTypeTFPlan, p.Type())
+
+	resources, err := p.ListResources(context.Background(), domain.KindComputeInstance)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 2, "count instances are listed, destroyed resources are not")
+	updated := resources[0]
+	assert.Equal(t, "i-0123456789abcdef0", updated.Metadata().ProviderAssignedID)
+	assert.Equal(t, "aws_instance.web[0]", updated.Metadata().SourceIdentifier)
+	assert.Equal(t, "aws", updated.Metadata().ProviderType)
+	assert.Equal(t, "t3.large", updated.Attributes()[domain.ComputeInstanceTypeKey])
+
+	created := resources[1]
+	assert.Empty(t, created.Metadata().ProviderAssignedID, "resources the plan creates have no ID yet")
+	assert.Equal(t, map[string]string{"Name": "web-1", "TFResourceAddress": "aws_instance.web[1]"}, created.Attributes()[domain.KeyTags])
+}
+
+func TestListResources_ChildModules(t *testing.T) {
+	p := newTestProvider(t)
+
+	resources, err := p.ListResources(context.Background(), domain.KindStorageBucket)
+
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "module.assets.aws_s3_bucket.this", resources[0].Metadata().SourceIdentifier)
+	assert.Equal(t, "assets-bucket", resources[0].Attributes()[domain.KeyID])
+}
+
+func TestListResources_NoPlannedValues(t *testing.T) {
+	p := NewProviderWithFetcher("test", staticPlan(`{"format_version":"1.0"}`), Config{}, newTestLogger())
+
+	resources, err := p.ListResources(context.Background(), domain.KindComputeInstance)
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestInvalidPlans(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		code errors.Code
+	}{
+		{name: "empty", data: "", code: errors.CodeStateParseError},
+		{name: "binary plan", data: "PK\x03\x04tfplan", code: errors.CodeStateParseError},
+		{name: "invalid JSON", data: "{", code: errors.CodeStateParseError},
+		{name: "unsupported format", data: `{"format_version":"2.0","planned_values":{}}`, code: errors.CodeUnsupportedStateVersion},
+		{name: "missing format", data: `{"planned_values":{}}`, code: errors.CodeUnsupportedStateVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProviderWithFetcher("test", staticPlan(tt.data), Config{}, newTestLogger())
+
+			_, err := p.ListResources(context.Background(), domain.KindComputeInstance)
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.code), "got %v", err)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package tfstate
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/mapping"
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// aggregator folds split-out managed resources (aws_s3_bucket_policy,
+// aws_volume_attachment, ...) into the composite desired resource of their parent.
+type aggregator struct {
+	byType   map[string][]*Resource
+	disabled map[domain.ResourceKind]bool
+}
+
+func newAggregator(state *State, disabledKinds []domain.ResourceKind) *aggregator {
+	a := &aggregator{
+		byType:   make(map[string][]*Resource),
+		disabled: make(map[domain.ResourceKind]bool, len(disabledKinds)),
+	}
+	for _, k := range disabledKinds {
+		a.disabled[k] = true
+	}
+	if state == nil {
+		return a
+	}
+	for i := range state.Resources {
+		r := &state.Resources[i]
+		if r.Mode != "managed" {
+			continue
+		}
+		a.byType[r.Type] = append(a.byType[r.Type], r)
+	}
+	return a
+}
+
+// aggregate merges every related resource that references parentID into targetAttrs.
+func (a *aggregator) aggregate(kind domain.ResourceKind, parentID string, targetAttrs map[string]any, logger ports.Logger) {
+	if a == nil || parentID == "" || a.disabled[kind] {
+		return
+	}
+	for _, rule := range mapping.AggregationRulesForKind(kind) {
+		for _, related := range a.byType[rule.TFType] {
+			for _, inst := range related.Instances {
+				if inst.Attributes == nil {
+					continue
+				}
+				if ref, _ := inst.Attributes[rule.ParentRefKey].(string); ref != parentID {
+					continue
+				}
+				if err := rule.Merge(inst.Attributes, targetAttrs, a.lookup); err != nil {
+					logger.Warnf(nil, "failed merging related resource %s: %v", buildResourceAddress(related), err)
+					continue
+				}
+				logger.Debugf(nil, "merged related resource %s", buildResourceAddress(related))
+			}
+		}
+	}
+}
+
+func (a *aggregator) lookup(tfType, id string) (map[string]any, bool) {
+	for _, r := range a.byType[tfType] {
+		for _, inst := range r.Instances {
+			if inst.Attributes == nil {
+				continue
+			}
+			if instID, _ := inst.Attributes["id"].(string); instID == id {
+				return inst.Attributes, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -29,7 +29,7 @@ func mapRawInstanceToDomain(
 	res *Resource,
 	inst *Instance,
 	logger ports.Logger,
-	state *State,
+	agg *aggregator,
 ) (domain.StateResource, error) {
 	if res == nil || inst == nil {
 		return nil, errors.New(errors.CodeInternal, "nil terraform state resource/instance")
@@ -53,23 +53,21 @@ func mapRawInstanceToDomain(
 	}
 
 	targetAttrs := make(map[string]any)
-	if err := mapping.NormalizeAndCopyAttributes(kind, rawAttrs, targetAttrs); err != nil {
+	if err := mapping.NormalizeAndCopyTypeAttributes(res.Type, kind, rawAttrs, targetAttrs); err != nil {
 		return nil, errors.Wrap(err, errors.CodeMappingError,
 			fmt.Sprintf("normalising attributes for %s.%s", res.Type, res.Name))
 	}
 
-	if state != nil && (kind == domain.KindStorageBucket) {
-		processRelatedResources(state, res, kind, targetAttrs, logger)
-	}
-
 	var providerAssignedID string
 	if id, ok := targetAttrs[domain.KeyID].(string); ok {
 		providerAssignedID = id
 	}
 
+	agg.aggregate(kind, providerAssignedID, targetAttrs, log)
+
 	providerType, _ := mapProviderToType(res.Provider)
 
-	address := buildResourceAddress(res)
+	address := buildInstanceAddress(res, inst)
 
 	meta := domain.ResourceMetadata{
 		Kind:               kind,
@@ -82,37 +80,6 @@ func mapRawInstanceToDomain(
 	return &tfStateResource{meta: meta, attr: targetAttrs}, nil
 }
 
-func processRelatedResources(state *State, baseResource *Resource, kind domain.ResourceKind, targetAttrs map[string]any, logger ports.Logger) {
-	relatedResources := FindRelatedResources(state, baseResource)
-	if len(relatedResources) == 0 {
-		return
-	}
-
-	switch kind {
-	case domain.KindStorageBucket:
-		processS3RelatedResources(relatedResources, targetAttrs, logger)
-	}
-}
-
-func processS3RelatedResources(relatedResources map[string][]*Resource, targetAttrs map[string]any, logger ports.Logger) {
-	if lifecycleConfigs, ok := relatedResources["lifecycle_configuration"]; ok && len(lifecycleConfigs) > 0 {
-		for _, lifecycleRes := range lifecycleConfigs {
-			if len(lifecycleRes.Instances) == 0 || lifecycleRes.Instances[0].Attributes == nil {
-				continue
-			}
-
-			rulesAttr, exists := lifecycleRes.Instances[0].Attributes["rule"]
-			if !exists {
-				continue
-			}
-
-			targetAttrs[domain.StorageBucketLifecycleRulesKey] = rulesAttr
-			logger.Debugf(nil, "merged lifecycle rules from related resource %s", lifecycleRes.Type+"."+lifecycleRes.Name)
-		}
-	}
-
-}
-
 func mapProviderToType(addr string) (string, error) {
 	if addr == "" {
 		return "unknown", errors.New(errors.CodeInternal, "provider address is empty")
@@ -15,6 +15,7 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 	log := portsmocks.NewLogger(t)
 	log.On("WithFields", mock.Anything).Maybe().Return(log)
 	log.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
 
 	t.Run("EC2 instance", func(t *testing.T) {
 		j := `{
@@ -28,6 +29,9 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 		      "id":"i-123abc456def",
 		      "ami":"ami-abc",
 		      "instance_type":"t2.small",
+		      "monitoring":true,
+		      "disable_api_termination":true,
+		      "metadata_options":[{"http_endpoint":"enabled","http_put_response_hop_limit":2,"http_tokens":"required","http_protocol_ipv6":"","instance_metadata_tags":"disabled"}],
 		      "tags":{"Name":"MappedInstance","Env":"Test"}
 		    }
 		  }]
@@ -35,7 +39,7 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 		var r Resource
 		require.NoError(t, json.Unmarshal([]byte(j), &r))
 
-		out, err := mapRawInstanceToDomain(&r, &r.Instances[0], log)
+		out, err := mapRawInstanceToDomain(&r, &r.Instances[0], log, nil)
 		require.NoError(t, err)
 
 		meta := out.Metadata()
@@ -50,6 +54,14 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 		assert.Equal(t, "ami-abc", a[domain.ComputeImageIDKey])
 		assert.Equal(t, "MappedInstance", a[domain.KeyName])
 		assert.Equal(t, map[string]string{"Name": "MappedInstance", "Env": "Test"}, a[domain.KeyTags])
+		assert.Equal(t, true, a[domain.ComputeMonitoringKey])
+		assert.Equal(t, true, a[domain.ComputeDeletionProtectionKey])
+		assert.Equal(t, map[string]any{
+			"http_endpoint":               "enabled",
+			"http_put_response_hop_limit": int64(2),
+			"http_tokens":                 "required",
+			"instance_metadata_tags":      "disabled",
+		}, a[domain.ComputeMetadataOptionsKey])
 	})
 
 	t.Run("S3 bucket", func(t *testing.T) {
@@ -71,7 +83,7 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 		var r Resource
 		require.NoError(t, json.Unmarshal([]byte(j), &r))
 
-		out, err := mapRawInstanceToDomain(&r, &r.Instances[0], log)
+		out, err := mapRawInstanceToDomain(&r, &r.Instances[0], log, nil)
 		require.NoError(t, err)
 
 		meta := out.Metadata()
@@ -86,8 +98,187 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 		assert.Equal(t, "my-log-bucket", a[domain.KeyName])
 	})
 
+	t.Run("S3 bucket with split resources", func(t *testing.T) {
+		j := `{
+		  "version": 4,
+		  "resources": [
+		    {"mode":"managed","type":"aws_s3_bucket","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"id":"data-bucket","bucket":"data-bucket"}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_versioning","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","versioning_configuration":[{"status":"Enabled"}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_versioning","name":"other","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"other-bucket","versioning_configuration":[{"status":"Suspended"}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_server_side_encryption_configuration","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","rule":[{"apply_server_side_encryption_by_default":[{"sse_algorithm":"aws:kms"}],"bucket_key_enabled":true}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_lifecycle_configuration","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","rule":[{"id":"expire","status":"Enabled"}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_policy","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","policy":"{\"Version\":\"2012-10-17\"}"}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_acl","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","acl":"private"}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_public_access_block","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","block_public_acls":true,"block_public_policy":true,"ignore_public_acls":true,"restrict_public_buckets":false}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_ownership_controls","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","rule":[{"object_ownership":"BucketOwnerEnforced"}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_accelerate_configuration","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","status":"Enabled"}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_replication_configuration","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","role":"arn:aws:iam::123456789012:role/replication","rule":[
+		       {"id":"logs","priority":2,"status":"Enabled","prefix":"",
+		        "filter":[{"prefix":"","tag":[],"and":[{"prefix":"logs/","tags":{"Replicate":"yes"}}]}],
+		        "destination":[{"bucket":"arn:aws:s3:::replica","storage_class":"STANDARD_IA","account":""}],
+		        "delete_marker_replication":[{"status":"Disabled"}]}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_object_lock_configuration","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","object_lock_enabled":"Enabled","rule":[{"default_retention":[{"mode":"GOVERNANCE","days":30,"years":0}]}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_notification","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","eventbridge":false,"lambda_function":[],"topic":[],"queue":[
+		       {"id":"uploads","queue_arn":"arn:aws:sqs:us-east-1:123456789012:uploads","events":["s3:ObjectRemoved:*","s3:ObjectCreated:*"],"filter_prefix":"uploads/","filter_suffix":""}]}}]}
+		  ]
+		}`
+		var state State
+		require.NoError(t, json.Unmarshal([]byte(j), &state))
+		r := &state.Resources[0]
+
+		out, err := mapRawInstanceToDomain(r, &r.Instances[0], log, newAggregator(&state, nil))
+		require.NoError(t, err)
+
+		a := out.Attributes()
+		assert.Equal(t, true, a[domain.StorageBucketVersioningKey])
+		assert.Equal(t, map[string]any{
+			"rule": []any{map[string]any{
+				"apply_server_side_encryption_by_default": map[string]any{"sse_algorithm": "aws:kms"},
+				"bucket_key_enabled":                      true,
+			}},
+		}, a[domain.StorageBucketEncryptionKey])
+		assert.Equal(t, []any{map[string]any{"id": "expire", "status": "Enabled"}}, a[domain.StorageBucketLifecycleRulesKey])
+		assert.Equal(t, `{"Version":"2012-10-17"}`, a[domain.StorageBucketPolicyKey])
+		assert.Equal(t, "private", a[domain.StorageBucketACLKey])
+		assert.Equal(t, map[string]any{
+			"block_public_acls":       true,
+			"block_public_policy":     true,
+			"ignore_public_acls":      true,
+			"restrict_public_buckets": false,
+		}, a[domain.StorageBucketPublicAccessBlockKey])
+		assert.Equal(t, "BucketOwnerEnforced", a[domain.StorageBucketObjectOwnershipKey])
+		assert.Equal(t, "Enabled", a[domain.StorageBucketAccelerationStatusKey])
+		assert.Equal(t, map[string]any{
+			"role": "arn:aws:iam::123456789012:role/replication",
+			"rules": []any{map[string]any{
+				"id":                        "logs",
+				"priority":                  int64(2),
+				"status":                    "Enabled",
+				"prefix":                    "logs/",
+				"tags":                      map[string]string{"Replicate": "yes"},
+				"destination":               map[string]any{"bucket": "arn:aws:s3:::replica", "storage_class": "STANDARD_IA"},
+				"delete_marker_replication": "Disabled",
+			}},
+		}, a[domain.StorageBucketReplicationKey])
+		assert.Equal(t, map[string]any{
+			"enabled":           true,
+			"default_retention": map[string]any{"mode": "GOVERNANCE", "days": int64(30)},
+		}, a[domain.StorageBucketObjectLockKey])
+		assert.Equal(t, map[string]any{
+			"queues": []any{map[string]any{
+				"id":            "uploads",
+				"arn":           "arn:aws:sqs:us-east-1:123456789012:uploads",
+				"events":        []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+				"filter_prefix": "uploads/",
+			}},
+		}, a[domain.StorageBucketNotificationKey])
+
+		disabled, err := mapRawInstanceToDomain(r, &r.Instances[0], log, newAggregator(&state, []domain.ResourceKind{domain.KindStorageBucket}))
+		require.NoError(t, err)
+		assert.NotContains(t, disabled.Attributes(), domain.StorageBucketVersioningKey)
+	})
+
+	t.Run("S3 bucket with configuration resources", func(t *testing.T) {
+		j := `{
+		  "version": 4,
+		  "resources": [
+		    {"mode":"managed","type":"aws_s3_bucket","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"id":"data-bucket","bucket":"data-bucket"}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_inventory","name":"weekly","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","name":"weekly","enabled":true,"included_object_versions":"All",
+		       "schedule":[{"frequency":"Weekly"}],"optional_fields":["StorageClass","Size"],"filter":[],
+		       "destination":[{"bucket":[{"bucket_arn":"arn:aws:s3:::inventory","format":"ORC","prefix":"","account_id":"","encryption":[{"sse_kms":[],"sse_s3":[{}]}]}]}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_inventory","name":"daily","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","name":"daily","enabled":false,"included_object_versions":"Current",
+		       "schedule":[{"frequency":"Daily"}],"optional_fields":[],"filter":[{"prefix":"logs/"}],
+		       "destination":[{"bucket":[{"bucket_arn":"arn:aws:s3:::inventory","format":"CSV","encryption":[]}]}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_intelligent_tiering_configuration","name":"archive","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","name":"archive","status":"Enabled","filter":[{"prefix":"cold/","tags":{"tier":"archive"}}],
+		       "tiering":[{"access_tier":"DEEP_ARCHIVE_ACCESS","days":180},{"access_tier":"ARCHIVE_ACCESS","days":90}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_analytics_configuration","name":"logs","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","name":"logs","filter":[{"prefix":"logs/","tags":null}],
+		       "storage_class_analysis":[{"data_export":[{"output_schema_version":"V_1","destination":[{"s3_bucket_destination":[{"bucket_arn":"arn:aws:s3:::analytics","format":"CSV","bucket_account_id":"","prefix":""}]}]}]}]}}]},
+		    {"mode":"managed","type":"aws_s3_bucket_metric","name":"all","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"bucket":"data-bucket","name":"EntireBucket","filter":[]}}]}
+		  ]
+		}`
+		var state State
+		require.NoError(t, json.Unmarshal([]byte(j), &state))
+		r := &state.Resources[0]
+
+		out, err := mapRawInstanceToDomain(r, &r.Instances[0], log, newAggregator(&state, nil))
+		require.NoError(t, err)
+
+		a := out.Attributes()
+		assert.Equal(t, []any{
+			map[string]any{
+				"id": "daily", "enabled": false, "included_object_versions": "Current", "frequency": "Daily", "prefix": "logs/",
+				"destination": map[string]any{"bucket": "arn:aws:s3:::inventory", "format": "CSV"},
+			},
+			map[string]any{
+				"id": "weekly", "enabled": true, "included_object_versions": "All", "frequency": "Weekly",
+				"optional_fields": []string{"Size", "StorageClass"},
+				"destination":     map[string]any{"bucket": "arn:aws:s3:::inventory", "format": "ORC", "encryption": "SSE-S3"},
+			},
+		}, a[domain.StorageBucketInventoryKey], "inventories are sorted by id")
+		assert.Equal(t, []any{map[string]any{
+			"id": "archive", "status": "Enabled", "prefix": "cold/", "tags": map[string]string{"tier": "archive"},
+			"tierings": []any{
+				map[string]any{"access_tier": "ARCHIVE_ACCESS", "days": int64(90)},
+				map[string]any{"access_tier": "DEEP_ARCHIVE_ACCESS", "days": int64(180)},
+			},
+		}}, a[domain.StorageBucketIntelligentTieringKey])
+		assert.Equal(t, []any{map[string]any{
+			"id": "logs", "prefix": "logs/",
+			"export": map[string]any{"bucket": "arn:aws:s3:::analytics", "format": "CSV"},
+		}}, a[domain.StorageBucketAnalyticsKey])
+		assert.Equal(t, []any{map[string]any{"id": "EntireBucket"}}, a[domain.StorageBucketMetricsKey])
+	})
+
+	t.Run("EC2 instance with volume attachments", func(t *testing.T) {
+		j := `{
+		  "version": 4,
+		  "resources": [
+		    {"mode":"managed","type":"aws_instance","name":"web","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"id":"i-123"}}]},
+		    {"mode":"managed","type":"aws_ebs_volume","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"id":"vol-1","size":50,"type":"gp3","encrypted":true}}]},
+		    {"mode":"managed","type":"aws_volume_attachment","name":"data","provider":"registry.terraform.io/hashicorp/aws",
+		     "instances":[{"attributes":{"instance_id":"i-123","volume_id":"vol-1","device_name":"/dev/sdf"}}]}
+		  ]
+		}`
+		var state State
+		require.NoError(t, json.Unmarshal([]byte(j), &state))
+		r := &state.Resources[0]
+
+		out, err := mapRawInstanceToDomain(r, &r.Instances[0], log, newAggregator(&state, nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{map[string]any{
+			"device_name":           "/dev/sdf",
+			"volume_id":             "vol-1",
+			"volume_size":           int64(50),
+			"volume_type":           "gp3",
+			"encrypted":             true,
+			"delete_on_termination": false,
+		}}, out.Attributes()[domain.ComputeEBSBlockDevicesKey])
+	})
+
 	t.Run("nil input", func(t *testing.T) {
-		_, err := mapRawInstanceToDomain(nil, nil, log)
+		_, err := mapRawInstanceToDomain(nil, nil, log, nil)
 		assert.Error(t, err)
 	})
 
@@ -101,7 +292,7 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 				Attributes: map[string]any{"id": "vpc-123"},
 			}},
 		}
-		_, err := mapRawInstanceToDomain(r, &r.Instances[0], log)
+		_, err := mapRawInstanceToDomain(r, &r.Instances[0], log, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported")
 	})
@@ -116,7 +307,7 @@ func TestMapRawInstanceToDomain(t *testing.T) {
 				Attributes: nil,
 			}},
 		}
-		out, err := mapRawInstanceToDomain(r, &r.Instances[0], log)
+		out, err := mapRawInstanceToDomain(r, &r.Instances[0], log, nil)
 		require.NoError(t, err)
 		assert.NotNil(t, out)
 		assert.Empty(t, out.Attributes())
@@ -2,12 +2,15 @@ package tfstate
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/decrypt"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/mapping"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
@@ -33,6 +36,9 @@ type (
 	}
 
 	Instance struct {
+		// IndexKey is the count index (a number) or for_each key (a string)
+		// of an instance of a resource with count or for_each, nil otherwise.
+		IndexKey      any            `json:"index_key,omitempty"`
 		SchemaVersion int            `json:"schema_version"`
 		Attributes    map[string]any `json:"attributes"`
 		Private       string         `json:"private"`
@@ -40,24 +46,59 @@ type (
 	}
 )
 
+// StateFetcher returns the raw JSON of a Terraform state snapshot.
+type StateFetcher func(ctx context.Context) ([]byte, error)
+
 type stateParser struct {
-	filePath   string
+	source     string
+	fetch      StateFetcher
 	stateCache *State
 	parseErr   error
 	mutex      sync.RWMutex
 	logger     ports.Logger
+	// decrypter decrypts state encrypted at rest. Nil reads state as is.
+	decrypter *decrypt.Decrypter
+
+	// digest is the hash of the raw state behind stateCache, so a refresh
+	// that fetches unchanged state keeps the parsed state.
+	digest [sha256.Size]byte
+	// stale makes the next parse fetch the state again.
+	stale bool
 }
 
 func newStateParser(path string, logger ports.Logger) *stateParser {
 	return &stateParser{
-		filePath: path,
-		logger:   logger.WithFields(map[string]any{"component": "tfstate_parser", "file_path": path}),
+		source: path,
+		fetch: func(context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+		logger: logger.WithFields(map[string]any{"component": "tfstate_parser", "file_path": path}),
+	}
+}
+
+func newFetchingStateParser(source string, fetch StateFetcher, logger ports.Logger) *stateParser {
+	return &stateParser{
+		source: source,
+		fetch:  fetch,
+		logger: logger.WithFields(map[string]any{"component": "tfstate_parser", "state_source": source}),
 	}
 }
 
+// refresh makes the next parse fetch the state again. Failures are retried
+// too.
+func (sp *stateParser) refresh() {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	sp.stale = true
+}
+
+func (sp *stateParser) cached() bool {
+	return !sp.stale && (sp.stateCache != nil || sp.parseErr != nil)
+}
+
 func (sp *stateParser) parseAndCache(ctx context.Context) (*State, error) {
 	sp.mutex.RLock()
-	if sp.stateCache != nil || sp.parseErr != nil {
+	if sp.cached() {
 		defer sp.mutex.RUnlock()
 		return sp.stateCache, sp.parseErr
 	}
@@ -66,16 +107,20 @@ func (sp *stateParser) parseAndCache(ctx context.Context) (*State, error) {
 	sp.mutex.Lock()
 	defer sp.mutex.Unlock()
 
-	if sp.stateCache != nil || sp.parseErr != nil {
+	if sp.cached() {
 		return sp.stateCache, sp.parseErr
 	}
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	raw, err := os.ReadFile(sp.filePath)
+	previous := sp.stateCache
+	sp.stale = false
+	sp.stateCache, sp.parseErr = nil, nil
+
+	raw, err := sp.fetch(ctx)
 	if err != nil {
-		sp.parseErr = errors.Wrap(err, errors.CodeStateReadError, "failed to read state file")
+		sp.parseErr = errors.Wrap(err, errors.CodeStateReadError, fmt.Sprintf("failed to read state from %s", sp.source))
 		return nil, sp.parseErr
 	}
 	if len(raw) == 0 {
@@ -83,6 +128,20 @@ func (sp *stateParser) parseAndCache(ctx context.Context) (*State, error) {
 		return nil, sp.parseErr
 	}
 
+	digest := sha256.Sum256(raw)
+	if previous != nil && digest == sp.digest {
+		sp.logger.Debugf(ctx, "State from %s is unchanged, reusing the parsed state", sp.source)
+		sp.stateCache = previous
+		return sp.stateCache, nil
+	}
+
+	if sp.decrypter != nil {
+		if raw, err = sp.decrypter.Decrypt(ctx, raw); err != nil {
+			sp.parseErr = err
+			return nil, sp.parseErr
+		}
+	}
+
 	var state State
 	if err := json.Unmarshal(raw, &state); err != nil {
 		sp.parseErr = errors.WrapUserFacing(err, errors.CodeStateParseError, "invalid JSON in state", "")
@@ -97,6 +156,7 @@ func (sp *stateParser) parseAndCache(ctx context.Context) (*State, error) {
 	}
 
 	sp.stateCache = &state
+	sp.digest = digest
 	return sp.stateCache, nil
 }
 
@@ -122,9 +182,11 @@ func findResourcesInState(
 	return out, nil
 }
 
-func findSpecificResource(state *State, kind domain.ResourceKind, identifier string, _ ports.Logger) (*Resource, error) {
+// findSpecificResource finds the instance with the given address. A resource
+// address without instance key finds the first instance of the resource.
+func findSpecificResource(state *State, kind domain.ResourceKind, identifier string, _ ports.Logger) (*Resource, *Instance, error) {
 	if state == nil {
-		return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("state is nil, resource '%s' not found", identifier))
+		return nil, nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("state is nil, resource '%s' not found", identifier))
 	}
 
 	for i := range state.Resources {
@@ -132,19 +194,38 @@ func findSpecificResource(state *State, kind domain.ResourceKind, identifier str
 		if r.Mode != "managed" {
 			continue
 		}
-		if buildResourceAddress(r) != identifier {
+		inst := findInstance(r, identifier)
+		if inst == nil {
 			continue
 		}
 		k, err := mapping.MapTfTypeToDomainKind(r.Type)
 		if err != nil {
-			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("unmappable resource type %q", r.Type))
+			return nil, nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("unmappable resource type %q", r.Type))
 		}
 		if k != kind {
-			return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("resource '%s' found, but it has kind '%s', expected '%s'", identifier, k, kind))
+			return nil, nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("resource '%s' found, but it has kind '%s', expected '%s'", identifier, k, kind))
+		}
+		return r, inst, nil
+	}
+	return nil, nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("resource '%s' of kind '%s' not found", identifier, kind))
+}
+
+// findInstance returns the instance of r with the given address, or its first
+// instance when the address is the resource address.
+func findInstance(r *Resource, identifier string) *Instance {
+	base := buildResourceAddress(r)
+	if !strings.HasPrefix(identifier, base) {
+		return nil
+	}
+	for i := range r.Instances {
+		if buildInstanceAddress(r, &r.Instances[i]) == identifier {
+			return &r.Instances[i]
 		}
-		return r, nil
 	}
-	return nil, errors.New(errors.CodeResourceNotFound, fmt.Sprintf("resource '%s' of kind '%s' not found", identifier, kind))
+	if identifier == base && len(r.Instances) > 0 {
+		return &r.Instances[0]
+	}
+	return nil
 }
 
 func buildResourceAddress(r *Resource) string {
@@ -154,51 +235,27 @@ func buildResourceAddress(r *Resource) string {
 	return r.Type + "." + r.Name
 }
 
-func FindRelatedResources(state *State, baseResource *Resource) map[string][]*Resource {
-	if state == nil || baseResource == nil || len(baseResource.Instances) == 0 {
-		return nil
-	}
-
-	related := make(map[string][]*Resource)
-	baseType := baseResource.Type
-	baseName := baseResource.Name
-	basePrefix := strings.Split(baseType, "_")[0]
-
-	var baseID string
-	if len(baseResource.Instances) > 0 && baseResource.Instances[0].Attributes != nil {
-		if id, ok := baseResource.Instances[0].Attributes["id"].(string); ok {
-			baseID = id
-		}
-	}
-
-	for i := range state.Resources {
-		res := &state.Resources[i]
-		if res.Type == baseType && res.Name == baseName {
-			continue
-		}
-
-		relationType := ""
-
-		if strings.HasPrefix(res.Type, baseType+"_") {
-			relationType = strings.TrimPrefix(res.Type, baseType+"_")
-		} else if strings.HasPrefix(res.Type, basePrefix+"_") &&
-			len(res.Instances) > 0 &&
-			res.Instances[0].Attributes != nil {
-
-			for attrKey, attrVal := range res.Instances[0].Attributes {
-				if (attrKey == basePrefix+"_bucket" || attrKey == "bucket") && baseID != "" {
-					if bucketRef, ok := attrVal.(string); ok && bucketRef == baseID {
-						relationType = strings.TrimPrefix(res.Type, basePrefix+"_")
-						break
-					}
-				}
-			}
-		}
+// buildInstanceAddress returns the full address of an instance, as Terraform
+// prints it: the resource address followed by the count index or for_each
+// key, e.g. module.app.aws_instance.web[2] or aws_instance.web["blue"].
+// Module instance keys are part of the module path already.
+func buildInstanceAddress(r *Resource, inst *Instance) string {
+	return buildResourceAddress(r) + formatIndexKey(inst.IndexKey)
+}
 
-		if relationType != "" {
-			related[relationType] = append(related[relationType], res)
-		}
+// formatIndexKey renders an instance key as an address suffix. Numbers decode
+// from JSON as float64 and are printed as integers.
+func formatIndexKey(key any) string {
+	switch k := key.(type) {
+	case nil:
+		return ""
+	case string:
+		return "[" + strconv.Quote(k) + "]"
+	case float64:
+		return "[" + strconv.FormatFloat(k, 'f', -1, 64) + "]"
+	case int:
+		return "[" + strconv.Itoa(k) + "]"
+	default:
+		return fmt.Sprintf("[%v]", k)
 	}
-
-	return related
 }
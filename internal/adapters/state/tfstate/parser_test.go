@@ -152,6 +152,40 @@ func TestStateParser_ParseAndCache(t *testing.T) {
 	})
 }
 
+func TestStateParser_Refresh(t *testing.T) {
+	mockLogger := portsmocks.NewLogger(t)
+	mockLogger.On("WithFields", mock.Anything).Maybe().Return(mockLogger)
+	mockLogger.On("Debugf", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Maybe().Return()
+
+	ctx := context.Background()
+	raw := []byte(`{"version": 4, "resources": []}`)
+	fetches := 0
+	p := newFetchingStateParser("test", func(context.Context) ([]byte, error) {
+		fetches++
+		return raw, nil
+	}, mockLogger)
+
+	first, err := p.parseAndCache(ctx)
+	require.NoError(t, err)
+
+	p.refresh()
+	unchanged, err := p.parseAndCache(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, unchanged, "unchanged state keeps the parsed state")
+	assert.Equal(t, 2, fetches)
+
+	raw = []byte(`{"version": 5, "resources": []}`)
+	cached, err := p.parseAndCache(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, cached.Version, "state is only fetched again after a refresh")
+
+	p.refresh()
+	changed, err := p.parseAndCache(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, changed.Version)
+	assert.Equal(t, 3, fetches)
+}
+
 // ----------------------------------------------------------------------------
 // findResourcesInState
 // ----------------------------------------------------------------------------
@@ -201,7 +235,7 @@ func TestFindSpecificResource(t *testing.T) {
 	st := loadRawState(t, filepath.Join("testdata", "nested_modules_raw.tfstate"))
 
 	t.Run("root resource", func(t *testing.T) {
-		r, err := findSpecificResource(st, domain.KindComputeInstance, "aws_instance.root_ec2", mockLogger)
+		r, _, err := findSpecificResource(st, domain.KindComputeInstance, "aws_instance.root_ec2", mockLogger)
 		require.NoError(t, err)
 		require.NotNil(t, r)
 		assert.Equal(t, "", r.Module)
@@ -210,7 +244,7 @@ func TestFindSpecificResource(t *testing.T) {
 	})
 
 	t.Run("nested resource", func(t *testing.T) {
-		r, err := findSpecificResource(st, domain.KindComputeInstance, "module.nested.aws_instance.child_ec2", mockLogger)
+		r, _, err := findSpecificResource(st, domain.KindComputeInstance, "module.nested.aws_instance.child_ec2", mockLogger)
 		require.NoError(t, err)
 		require.NotNil(t, r)
 		assert.Equal(t, "module.nested", r.Module)
@@ -218,7 +252,7 @@ func TestFindSpecificResource(t *testing.T) {
 	})
 
 	t.Run("identifier not found", func(t *testing.T) {
-		r, err := findSpecificResource(st, domain.KindComputeInstance, "aws_instance.nope", mockLogger)
+		r, _, err := findSpecificResource(st, domain.KindComputeInstance, "aws_instance.nope", mockLogger)
 		require.Error(t, err)
 		assert.Nil(t, r)
 		var appErr *errors.AppError
@@ -227,7 +261,7 @@ func TestFindSpecificResource(t *testing.T) {
 	})
 
 	t.Run("found but wrong kind", func(t *testing.T) {
-		r, err := findSpecificResource(st, domain.KindStorageBucket, "aws_instance.root_ec2", mockLogger)
+		r, _, err := findSpecificResource(st, domain.KindStorageBucket, "aws_instance.root_ec2", mockLogger)
 		require.Error(t, err)
 		assert.Nil(t, r)
 		var appErr *errors.AppError
@@ -236,7 +270,7 @@ func TestFindSpecificResource(t *testing.T) {
 	})
 
 	t.Run("nil state", func(t *testing.T) {
-		_, err := findSpecificResource(nil, domain.KindComputeInstance, "anything", mockLogger)
+		_, _, err := findSpecificResource(nil, domain.KindComputeInstance, "anything", mockLogger)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "state is nil")
 	})
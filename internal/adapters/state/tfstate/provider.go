@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/decrypt"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
@@ -12,12 +13,20 @@ import (
 const ProviderTypeTFState = "tfstate"
 
 type Provider struct {
-	parser *stateParser
-	logger ports.Logger
+	parser        *stateParser
+	logger        ports.Logger
+	disabledKinds []domain.ResourceKind
 }
 
 type Config struct {
 	FilePath string `yaml:"path" mapstructure:"path" validate:"required"`
+	// DisableAggregation lists kinds whose split-out related resources
+	// (e.g. aws_s3_bucket_policy) should not be merged into the parent.
+	DisableAggregation []domain.ResourceKind `yaml:"disable_aggregation" mapstructure:"disable_aggregation"`
+	// Decryption selects the keys for state encrypted at rest with sops, age
+	// or GPG. Encrypted state is detected and decrypted in memory; plain state
+	// is read as is.
+	Decryption decrypt.Config `yaml:"decryption" mapstructure:"decryption"`
 }
 
 func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
@@ -32,14 +41,40 @@ func NewProvider(cfg Config, logger ports.Logger) (*Provider, error) {
 		"state_file": filePath,
 	})
 
+	parser := newStateParser(filePath, plog)
+	parser.decrypter = decrypt.New(cfg.Decryption, plog)
 	return &Provider{
-		parser: newStateParser(filePath, plog),
-		logger: plog,
+		parser:        parser,
+		logger:        plog,
+		disabledKinds: cfg.DisableAggregation,
 	}, nil
 }
 
+// NewProviderWithFetcher creates a provider that reads the state snapshot through
+// fetch instead of from a local file, for remote state sources. source names the
+// origin of the state in logs and errors.
+func NewProviderWithFetcher(source string, fetch StateFetcher, cfg Config, logger ports.Logger) *Provider {
+	plog := logger.WithFields(map[string]any{
+		"provider":     ProviderTypeTFState,
+		"state_source": source,
+	})
+	parser := newFetchingStateParser(source, fetch, plog)
+	parser.decrypter = decrypt.New(cfg.Decryption, plog)
+	return &Provider{
+		parser:        parser,
+		logger:        plog,
+		disabledKinds: cfg.DisableAggregation,
+	}
+}
+
 func (p *Provider) Type() string { return ProviderTypeTFState }
 
+// Refresh makes the next listing fetch the state again. The parsed state is
+// kept when the fetched state is unchanged.
+func (p *Provider) Refresh(ctx context.Context) {
+	p.parser.refresh()
+}
+
 func (p *Provider) ListResources(
 	ctx context.Context,
 	kind domain.ResourceKind,
@@ -62,9 +97,10 @@ func (p *Provider) ListResources(
 		return nil, err
 	}
 
+	agg := newAggregator(state, p.disabledKinds)
 	for _, tfRes := range resources {
 		for _, inst := range tfRes.Instances {
-			mappedResource, err := mapRawInstanceToDomain(tfRes, &inst, p.logger, state)
+			mappedResource, err := mapRawInstanceToDomain(tfRes, &inst, p.logger, agg)
 			if err != nil {
 				return nil, errors.Wrap(err, errors.CodeStateProviderError,
 					fmt.Sprintf("parsing resource %s [%s.%s]",
@@ -92,15 +128,10 @@ func (p *Provider) GetResource(
 			"parsing terraform state file for GetResource")
 	}
 
-	res, err := findSpecificResource(state, kind, identifier, p.logger)
+	res, inst, err := findSpecificResource(state, kind, identifier, p.logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(res.Instances) == 0 {
-		return nil, errors.New(errors.CodeResourceNotFound,
-			fmt.Sprintf("resource '%s.%s' has no instances", res.Type, res.Name))
-	}
-
-	return mapRawInstanceToDomain(res, &res.Instances[0], p.logger, state)
+	return mapRawInstanceToDomain(res, inst, p.logger, newAggregator(state, p.disabledKinds))
 }
@@ -178,3 +178,42 @@ func TestProvider_GetResource(t *testing.T) {
 		require.ErrorIs(t, err, context.Canceled)
 	})
 }
+
+func TestProvider_ExpandedInstances(t *testing.T) {
+	mockLogger := portsmocks.NewLogger(t)
+	mockLogger.On("WithFields", mock.Anything).Maybe().Return(mockLogger)
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe().Return()
+	ctx := context.Background()
+	cfg := tfstate.Config{FilePath: filepath.Join("testdata", "expanded_instances.tfstate")}
+	p, _ := tfstate.NewProvider(cfg, mockLogger)
+
+	t.Run("List", func(t *testing.T) {
+		resources, err := p.ListResources(ctx, domain.KindComputeInstance)
+		require.NoError(t, err)
+		require.Len(t, resources, 3)
+		assert.Equal(t, "module.app.aws_instance.web[0]", resources[0].Metadata().SourceIdentifier)
+		assert.Equal(t, "module.app.aws_instance.web[1]", resources[1].Metadata().SourceIdentifier)
+		assert.Equal(t, `module.regional["eu"].aws_instance.worker["blue"]`, resources[2].Metadata().SourceIdentifier)
+	})
+
+	t.Run("Get Instance", func(t *testing.T) {
+		res, err := p.GetResource(ctx, domain.KindComputeInstance, "module.app.aws_instance.web[1]")
+		require.NoError(t, err)
+		assert.Equal(t, "i-web1", res.Metadata().ProviderAssignedID)
+		assert.Equal(t, "t3.small", res.Attributes()[domain.ComputeInstanceTypeKey])
+	})
+
+	t.Run("Get Resource Address", func(t *testing.T) {
+		res, err := p.GetResource(ctx, domain.KindComputeInstance, "module.app.aws_instance.web")
+		require.NoError(t, err)
+		assert.Equal(t, "i-web0", res.Metadata().ProviderAssignedID, "the first instance is returned")
+	})
+
+	t.Run("Unknown Key", func(t *testing.T) {
+		_, err := p.GetResource(ctx, domain.KindComputeInstance, "module.app.aws_instance.web[5]")
+		require.Error(t, err)
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, errors.CodeResourceNotFound, appErr.Code)
+	})
+}
@@ -0,0 +1,83 @@
+// Package otlp exports the spans of the engine and the platform API calls
+// to an OpenTelemetry collector over OTLP/HTTP.
+package otlp
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// DefaultServiceName is the service.name of the exported spans when the
+// configuration does not set one.
+const DefaultServiceName = "drift-analyser"
+
+// shutdownTimeout bounds flushing the remaining spans on exit.
+const shutdownTimeout = 5 * time.Second
+
+// Config configures span export.
+type Config struct {
+	// Endpoint is the OTLP/HTTP endpoint of the collector, for example
+	// http://localhost:4318. An http:// endpoint is used without TLS. Empty
+	// falls back to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, then
+	// to https://localhost:4318.
+	Endpoint string `yaml:"endpoint" mapstructure:"endpoint" validate:"omitempty,url"`
+	// Headers are sent with every export request, for example an API key.
+	Headers     map[string]string `yaml:"headers" mapstructure:"headers"`
+	ServiceName string            `yaml:"service_name" mapstructure:"service_name"`
+	// SampleRatio is the fraction of runs traced, between 0 and 1. Zero traces
+	// every run.
+	SampleRatio float64 `yaml:"sample_ratio" mapstructure:"sample_ratio" validate:"omitempty,min=0,max=1"`
+}
+
+// Provider is a tracer provider exporting spans in batches.
+type Provider struct {
+	*sdktrace.TracerProvider
+}
+
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, "failed to create OTLP trace exporter",
+			"Check tracing.endpoint, which must be a URL such as http://localhost:4318.")
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to describe the trace resource")
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if cfg.SampleRatio > 0 && cfg.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	}
+	return &Provider{TracerProvider: sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+	)}, nil
+}
+
+// Close flushes the spans not exported yet and stops the exporter.
+func (p *Provider) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return p.Shutdown(ctx)
+}
@@ -0,0 +1,36 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_ExportsSpansOnClose(t *testing.T) {
+	exported := make(chan http.Header, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.URL.Path == "/v1/traces" {
+			exported <- r.Header.Clone()
+		}
+	}))
+	defer collector.Close()
+
+	provider, err := NewProvider(context.Background(), Config{Endpoint: collector.URL, Headers: map[string]string{"X-Api-Key": "secret"}})
+	require.NoError(t, err)
+	_, span := provider.Tracer("test").Start(context.Background(), "drift.run")
+	span.End()
+	require.NoError(t, provider.Close())
+
+	select {
+	case header := <-exported:
+		assert.Equal(t, "secret", header.Get("X-Api-Key"))
+	default:
+		t.Fatal("spans were not exported on close")
+	}
+}
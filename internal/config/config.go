@@ -1,13 +1,47 @@
 package config
 
 import (
+	"sort"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/health"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/history/jsonfile"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/lifecycle"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/matching/strategy"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/matching/tag"
+	prommetrics "github.com/olusolaa/infra-drift-detector/internal/adapters/metrics/prometheus"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/notify/slack"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/notify/webhook"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/limiter"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/retry"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/platform/aws/s3"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/amimanifest"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/manifests"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/pulumi"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/remote"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/s3backend"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfhcl"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfplan"
 	"github.com/olusolaa/infra-drift-detector/internal/adapters/state/tfstate"
+	"github.com/olusolaa/infra-drift-detector/internal/adapters/tracing/otlp"
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/exitpolicy"
+	"github.com/olusolaa/infra-drift-detector/internal/filter"
 	"github.com/olusolaa/infra-drift-detector/internal/log"
+	csvreport "github.com/olusolaa/infra-drift-detector/internal/reporting/csv"
+	htmlreport "github.com/olusolaa/infra-drift-detector/internal/reporting/html"
 	"github.com/olusolaa/infra-drift-detector/internal/reporting/json"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/links"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/markdown"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/ocsf"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/partition"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/sarif"
+	templatereport "github.com/olusolaa/infra-drift-detector/internal/reporting/template"
 	"github.com/olusolaa/infra-drift-detector/internal/reporting/text"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/compute"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/knowledge"
+	"github.com/olusolaa/infra-drift-detector/internal/transform"
 )
 
 type Config struct {
@@ -15,47 +49,301 @@ type Config struct {
 	State     StateConfig      `yaml:"state" mapstructure:"state" validate:"required"`
 	Platform  PlatformConfig   `yaml:"platform" mapstructure:"platform" validate:"required"`
 	Resources []ResourceConfig `yaml:"resources" mapstructure:"resources" validate:"required,min=1,dive"`
+	// CustomKinds declares additional resource kinds backed by a generic fetcher
+	// and comparer, without a dedicated handler.
+	CustomKinds []CustomKindConfig `yaml:"custom_kinds" mapstructure:"custom_kinds" validate:"omitempty,dive"`
+	// History enables the run history store, which later runs use as a reference.
+	History *HistoryConfig `yaml:"history,omitempty" mapstructure:"history,omitempty"`
+	// Daemon configures the long-running mode that scans kinds on a schedule.
+	Daemon *DaemonConfig `yaml:"daemon,omitempty" mapstructure:"daemon,omitempty"`
+	// AttributeGroups lets reports summarize drift per group (e.g. "3 security
+	// drifts") instead of listing raw attribute names only.
+	AttributeGroups []AttributeGroupConfig `yaml:"attribute_groups" mapstructure:"attribute_groups" validate:"omitempty,dive"`
+	// GoldenAMI reports compute instances running images not approved by a
+	// golden AMI manifest.
+	GoldenAMI *amimanifest.Config `yaml:"golden_ami,omitempty" mapstructure:"golden_ami,omitempty"`
+	// PlatformManaged tunes how differences caused by AWS-initiated changes,
+	// such as certificate renewals, are recognized and reported with info severity.
+	PlatformManaged *knowledge.Config `yaml:"platform_managed,omitempty" mapstructure:"platform_managed,omitempty"`
+	// ExitPolicy fails a scan with a distinct exit code per kind of finding,
+	// so CI pipelines can gate on the findings they care about.
+	ExitPolicy *exitpolicy.Config `yaml:"exit_policy,omitempty" mapstructure:"exit_policy,omitempty"`
+	// Notifications sends the results of every run to chat channels and HTTP
+	// endpoints.
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty" mapstructure:"notifications,omitempty"`
+	// Tracing exports OpenTelemetry spans of every run, its pipeline stages and
+	// the platform API calls, to find where a scan spends its time.
+	Tracing *otlp.Config `yaml:"tracing,omitempty" mapstructure:"tracing,omitempty"`
+	// Filter scopes runs to the resources matching tag selectors, name globs,
+	// an ID pattern and regions, e.g. the resources of one service or team.
+	Filter *filter.Config `yaml:"filter,omitempty" mapstructure:"filter,omitempty"`
 }
 
 type SettingsConfig struct {
-	LogLevel     log.Level       `yaml:"log_level" mapstructure:"log_level" validate:"required,oneof=debug info warn error"`
-	LogFormat    log.Format      `yaml:"log_format" mapstructure:"log_format" validate:"required,oneof=text json"`
+	LogLevel  log.Level  `yaml:"log_level" mapstructure:"log_level" validate:"required,oneof=debug info warn error"`
+	LogFormat log.Format `yaml:"log_format" mapstructure:"log_format" validate:"required,oneof=text json"`
+	// LogFile writes logs to this file instead of stderr. In daemon mode the
+	// file is reopened on SIGHUP, so it can be rotated with logrotate.
+	LogFile      string          `yaml:"log_file" mapstructure:"log_file"`
 	Concurrency  int             `yaml:"concurrency" mapstructure:"concurrency" validate:"required,min=1"`
-	MatcherType  string          `yaml:"matcher" mapstructure:"matcher" validate:"required,oneof=tag"`
-	ReporterType string          `yaml:"reporter" mapstructure:"reporter" validate:"required,oneof=text"`
+	MatcherType  string          `yaml:"matcher" mapstructure:"matcher" validate:"required,oneof=tag identifier strategy"`
+	ReporterType string          `yaml:"reporter" mapstructure:"reporter" validate:"required,oneof=text json ocsf sarif template html markdown csv tsv"`
 	Matcher      MatcherConfigs  `yaml:"matcher_config" mapstructure:"matcher_config" validate:"required"`
 	Reporter     ReporterConfigs `yaml:"reporter_config" mapstructure:"reporter_config"`
+	Links        *links.Config   `yaml:"links,omitempty" mapstructure:"links,omitempty"`
+	// Strict fails the run on any state parse or evaluation issue. When false,
+	// issues are reported in a state source issues section instead.
+	Strict bool `yaml:"strict" mapstructure:"strict"`
+	// ChannelBuffers bounds the buffers between pipeline stages, trading memory
+	// for throughput on very large accounts.
+	ChannelBuffers *ChannelBufferConfig `yaml:"channel_buffers,omitempty" mapstructure:"channel_buffers,omitempty"`
+	// SkipSelfTest disables the provider connectivity and permission checks run
+	// before the scan.
+	SkipSelfTest bool `yaml:"skip_self_test" mapstructure:"skip_self_test"`
+	// NoProgress disables the progress view a scan shows on stderr while it
+	// runs.
+	NoProgress bool `yaml:"no_progress" mapstructure:"no_progress"`
+	// Explain attaches the normalization steps and decision path of every
+	// comparison to the findings.
+	Explain bool `yaml:"explain" mapstructure:"explain"`
+	// StreamingMatch matches platform resources as they are listed instead of
+	// collecting them all first, bounding memory on very large accounts.
+	StreamingMatch bool `yaml:"streaming_match" mapstructure:"streaming_match"`
+	// IgnorePlatformDefaults leaves resources the cloud provider creates on its
+	// own, such as default VPCs and service-linked roles, out of the unmanaged
+	// resources.
+	IgnorePlatformDefaults bool `yaml:"ignore_platform_defaults" mapstructure:"ignore_platform_defaults"`
+	// UnknownTolerant treats desired values only known after apply, such as
+	// references to data sources in HCL, as not asserted: they are left out of
+	// the comparison and listed in the result instead of reported as drift.
+	UnknownTolerant bool `yaml:"unknown_tolerant" mapstructure:"unknown_tolerant"`
+	// Localization renders report timestamps in a team's timezone and date
+	// format instead of UTC RFC 3339.
+	Localization *localize.Config `yaml:"localization,omitempty" mapstructure:"localization,omitempty"`
+	// Baseline is the file of acknowledged findings. Findings in it are
+	// suppressed so that only drift found since then is reported.
+	Baseline string `yaml:"baseline" mapstructure:"baseline"`
+	// UpdateBaseline saves the run's findings as the baseline instead of
+	// suppressing them.
+	UpdateBaseline bool `yaml:"update_baseline" mapstructure:"update_baseline"`
+	// Cache keeps resource attributes that take several API calls per
+	// resource to fetch on disk, so repeat runs skip those calls.
+	Cache CacheConfig `yaml:"cache" mapstructure:"cache"`
+}
+
+// CacheConfig configures the on-disk attribute cache. Cached attributes are
+// compared as they were when fetched, so drift made within the TTL is only
+// reported once the entry expires.
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Dir defaults to infra-drift-detector/attributes in the user cache
+	// directory.
+	Dir string `yaml:"dir" mapstructure:"dir"`
+	// TTL is how long cached attributes are used. Defaults to one hour.
+	TTL time.Duration `yaml:"ttl" mapstructure:"ttl" validate:"omitempty,min=0"`
+}
+
+type ChannelBufferConfig struct {
+	Desired int `yaml:"desired" mapstructure:"desired" validate:"omitempty,min=1"`
+	Actual  int `yaml:"actual" mapstructure:"actual" validate:"omitempty,min=1"`
+	Compare int `yaml:"compare" mapstructure:"compare" validate:"omitempty,min=1"`
+	Results int `yaml:"results" mapstructure:"results" validate:"omitempty,min=1"`
 }
 
 type StateConfig struct {
-	ProviderType string          `yaml:"provider_type" mapstructure:"provider_type" validate:"required,oneof=tfstate tfhcl"`
+	ProviderType string          `yaml:"provider_type" mapstructure:"provider_type" validate:"required,oneof=tfstate tfhcl tfplan remote s3 pulumi manifests"`
 	TFState      *tfstate.Config `yaml:"tfstate,omitempty" mapstructure:"tfstate,omitempty" validate:"required_if=ProviderType tfstate"`
 	TFHCL        *tfhcl.Config   `yaml:"tfhcl,omitempty" mapstructure:"tfhcl,omitempty" validate:"required_if=ProviderType tfhcl"`
+	// TFPlan reads the resources a pending Terraform plan would produce.
+	TFPlan *tfplan.Config `yaml:"tfplan,omitempty" mapstructure:"tfplan,omitempty" validate:"required_if=ProviderType tfplan"`
+	Remote *remote.Config `yaml:"remote,omitempty" mapstructure:"remote,omitempty" validate:"required_if=ProviderType remote"`
+	// S3 reads the state directly from a Terraform s3 backend.
+	S3 *s3backend.Config `yaml:"s3,omitempty" mapstructure:"s3,omitempty" validate:"required_if=ProviderType s3"`
+	// Pulumi reads the AWS resources of a Pulumi stack export.
+	Pulumi *pulumi.Config `yaml:"pulumi,omitempty" mapstructure:"pulumi,omitempty" validate:"required_if=ProviderType pulumi"`
+	// Manifests reads Kubernetes manifests or kustomize output.
+	Manifests *manifests.Config `yaml:"manifests,omitempty" mapstructure:"manifests,omitempty" validate:"required_if=ProviderType manifests"`
 }
 
 type PlatformConfig struct {
 	AWS *AWSPlatformConfig `yaml:"aws,omitempty" mapstructure:"aws,omitempty"`
+	// GCP selects the Google Cloud provider instead of AWS when set.
+	GCP *GCPPlatformConfig `yaml:"gcp,omitempty" mapstructure:"gcp,omitempty"`
+	// Azure selects the Azure provider instead of AWS when set.
+	Azure *AzurePlatformConfig `yaml:"azure,omitempty" mapstructure:"azure,omitempty"`
+	// Kubernetes selects the Kubernetes provider instead of AWS when set.
+	Kubernetes *KubernetesPlatformConfig `yaml:"kubernetes,omitempty" mapstructure:"kubernetes,omitempty"`
+}
+
+// KubernetesPlatformConfig configures the Kubernetes provider. Without a
+// kubeconfig path it uses KUBECONFIG, ~/.kube/config or, inside a pod, the
+// pod's service account.
+type KubernetesPlatformConfig struct {
+	Kubeconfig string `yaml:"kubeconfig" mapstructure:"kubeconfig"`
+	// Context overrides the current context of the kubeconfig.
+	Context string `yaml:"context" mapstructure:"context"`
+	// Namespaces restricts listing to the given namespaces. Empty lists the
+	// whole cluster.
+	Namespaces           []string `yaml:"namespaces" mapstructure:"namespaces" validate:"omitempty,dive,required"`
+	APIRequestsPerSecond int      `yaml:"api_rps" mapstructure:"api_rps" validate:"omitempty,min=1,max=100"`
+}
+
+// AzurePlatformConfig configures the Azure provider. Without an access token it
+// authenticates with the AZURE_* environment variables of a service principal
+// or workload identity, or else with the managed identity of its host.
+type AzurePlatformConfig struct {
+	SubscriptionID string `yaml:"subscription_id" mapstructure:"subscription_id" validate:"required"`
+	// TenantID and ClientID override AZURE_TENANT_ID and AZURE_CLIENT_ID.
+	TenantID string `yaml:"tenant_id" mapstructure:"tenant_id"`
+	ClientID string `yaml:"client_id" mapstructure:"client_id"`
+	// AccessToken is an Azure Resource Manager access token used as is, e.g.
+	// the output of 'az account get-access-token'.
+	AccessToken          string `yaml:"access_token" mapstructure:"access_token"`
+	APIRequestsPerSecond int    `yaml:"api_rps" mapstructure:"api_rps" validate:"omitempty,min=1,max=100"`
+}
+
+// GCPPlatformConfig configures the Google Cloud provider. Without an access
+// token or credentials file the provider uses application default credentials.
+type GCPPlatformConfig struct {
+	Project string `yaml:"project" mapstructure:"project" validate:"required"`
+	// CredentialsFile is a service account key or the credentials written by
+	// 'gcloud auth application-default login'.
+	CredentialsFile string `yaml:"credentials_file" mapstructure:"credentials_file"`
+	// AccessToken is an OAuth2 access token used as is, e.g. the output of
+	// 'gcloud auth print-access-token'.
+	AccessToken          string `yaml:"access_token" mapstructure:"access_token"`
+	APIRequestsPerSecond int    `yaml:"api_rps" mapstructure:"api_rps" validate:"omitempty,min=1,max=100"`
 }
 
 type AWSPlatformConfig struct {
 	APIRequestsPerSecond int    `yaml:"api_rps" mapstructure:"api_rps" validate:"omitempty,min=1,max=100"`
 	Region               string `yaml:"region" mapstructure:"region" validate:"required"`
 	Profile              string `yaml:"profile" mapstructure:"profile" validate:"required"`
+	// S3 configures bucket attribute fetching.
+	S3 *s3.Config `yaml:"s3,omitempty" mapstructure:"s3,omitempty"`
+	// AutoScaling configures how Auto Scaling groups are compared, e.g. whether
+	// desired capacity changes made by scaling policies are reported.
+	AutoScaling *compute.AutoScalingGroupConfig `yaml:"autoscaling,omitempty" mapstructure:"autoscaling,omitempty"`
+	// Retry configures how throttled and transient API errors are retried with
+	// jittered exponential backoff before a resource is reported as failed.
+	Retry *retry.Config `yaml:"retry,omitempty" mapstructure:"retry,omitempty"`
+	// AdaptiveRateLimit rate limits each AWS service separately, shrinking a
+	// service's rate when it throttles and recovering it gradually. api_rps
+	// still caps all calls together and is the default rate of each service.
+	AdaptiveRateLimit *limiter.AdaptiveConfig `yaml:"adaptive_rate_limit,omitempty" mapstructure:"adaptive_rate_limit,omitempty"`
+	// FallbackCredentials are tried in order when the active credentials fail
+	// with an authentication error, e.g. a read-only replica role used when the
+	// primary profile's session expires mid-run.
+	FallbackCredentials []AWSCredentialConfig `yaml:"fallback_credentials" mapstructure:"fallback_credentials" validate:"omitempty,dive"`
+}
+
+// AWSCredentialConfig is a credential source: a shared config profile, a role
+// to assume, or a role assumed with the profile's credentials.
+type AWSCredentialConfig struct {
+	Profile string `yaml:"profile" mapstructure:"profile" validate:"required_without=RoleARN"`
+	RoleARN string `yaml:"role_arn" mapstructure:"role_arn" validate:"required_without=Profile"`
 }
 
 type ResourceConfig struct {
 	Kind            domain.ResourceKind `yaml:"kind" mapstructure:"kind" validate:"required"`
 	PlatformFilters map[string]string   `yaml:"platform_filters" mapstructure:"platform_filters"`
 	Attributes      []string            `yaml:"attributes" mapstructure:"attributes" validate:"required,min=1,dive,required"`
+	Transforms      []transform.Rule    `yaml:"transforms" mapstructure:"transforms" validate:"omitempty,dive"`
+	// Normalize relaxes how string values of individual attributes are compared.
+	Normalize []AttributeNormalizationConfig `yaml:"normalize" mapstructure:"normalize" validate:"omitempty,dive"`
+	// Priority overrides the built-in priority of the kind. Higher priority kinds
+	// are listed, compared and reported first.
+	Priority *int `yaml:"priority,omitempty" mapstructure:"priority" validate:"omitempty"`
+	// ScanInterval is how often the kind is scanned in daemon mode. Zero falls
+	// back to daemon.default_interval.
+	ScanInterval time.Duration `yaml:"scan_interval,omitempty" mapstructure:"scan_interval" validate:"omitempty,min=0"`
+	// Concurrency caps the comparisons of the kind running at once. Zero means
+	// the kind may use every worker of settings.concurrency.
+	Concurrency int `yaml:"concurrency,omitempty" mapstructure:"concurrency" validate:"omitempty,min=1"`
+	// AttributeParallelism is how many attributes of one resource are compared
+	// at once. Zero or one compares them one at a time.
+	AttributeParallelism int `yaml:"attribute_parallelism,omitempty" mapstructure:"attribute_parallelism" validate:"omitempty,min=1,max=32"`
+	// Identity lists the attributes whose values together identify a resource
+	// of the kind to the identity strategy of the strategy matcher.
+	Identity []string `yaml:"identity,omitempty" mapstructure:"identity" validate:"omitempty,dive,required"`
+}
+
+// AttributeNormalizationConfig normalizes the string values of an attribute,
+// including strings nested in lists and maps, before they are compared.
+type AttributeNormalizationConfig struct {
+	Attribute          string `yaml:"attribute" mapstructure:"attribute" validate:"required"`
+	CaseInsensitive    bool   `yaml:"case_insensitive" mapstructure:"case_insensitive"`
+	TrimWhitespace     bool   `yaml:"trim_whitespace" mapstructure:"trim_whitespace"`
+	CollapseWhitespace bool   `yaml:"collapse_whitespace" mapstructure:"collapse_whitespace"`
+}
+
+type CustomKindConfig struct {
+	Kind          domain.ResourceKind `yaml:"kind" mapstructure:"kind" validate:"required"`
+	TerraformType string              `yaml:"terraform_type" mapstructure:"terraform_type" validate:"required"`
+	Fetcher       string              `yaml:"fetcher" mapstructure:"fetcher" validate:"required,oneof=cloudcontrol"`
+	TypeName      string              `yaml:"type_name" mapstructure:"type_name" validate:"required"`
+	PropertyMap   map[string]string   `yaml:"property_map" mapstructure:"property_map"`
+}
+
+type HistoryConfig struct {
+	Directory            string        `yaml:"directory" mapstructure:"directory" validate:"required"`
+	TombstoneGracePeriod time.Duration `yaml:"tombstone_grace_period" mapstructure:"tombstone_grace_period" validate:"omitempty,min=0"`
+	// DeadLetterAfter is the number of consecutive failed runs after which a
+	// resource is reported in the dead-letter list instead of as an error.
+	DeadLetterAfter int `yaml:"dead_letter_after" mapstructure:"dead_letter_after" validate:"omitempty,min=1"`
+	// Retention prunes old runs so the store does not grow without bound.
+	Retention *jsonfile.RetentionConfig `yaml:"retention,omitempty" mapstructure:"retention,omitempty"`
+}
+
+type AttributeGroupConfig struct {
+	Name       string   `yaml:"name" mapstructure:"name" validate:"required"`
+	Attributes []string `yaml:"attributes" mapstructure:"attributes" validate:"required,min=1"`
+}
+
+// DefaultScanInterval is the daemon mode scan interval of kinds without one.
+const DefaultScanInterval = time.Hour
+
+type DaemonConfig struct {
+	DefaultInterval time.Duration `yaml:"default_interval" mapstructure:"default_interval" validate:"omitempty,min=0"`
+	// Health serves /healthz and /readyz for liveness and readiness probes.
+	Health *health.Config `yaml:"health,omitempty" mapstructure:"health,omitempty"`
+	// Service configures running the daemon under systemd or as a Windows service.
+	Service *lifecycle.Config `yaml:"service,omitempty" mapstructure:"service,omitempty"`
+	// Metrics serves scan and cloud API metrics for Prometheus to scrape.
+	Metrics *prommetrics.Config `yaml:"metrics,omitempty" mapstructure:"metrics,omitempty"`
+}
+
+type NotificationsConfig struct {
+	// Slack posts a summary to an incoming webhook after every run with drift.
+	Slack *slack.Config `yaml:"slack,omitempty" mapstructure:"slack,omitempty"`
+	// Webhook posts the results as JSON to an HTTP endpoint after every run.
+	Webhook *webhook.Config `yaml:"webhook,omitempty" mapstructure:"webhook,omitempty"`
 }
 
 type MatcherConfigs struct {
 	Tag *tag.Config `yaml:"tag,omitempty" mapstructure:"tag,omitempty" validate:"required_if=../MatcherType tag"`
+	// Strategy configures the strategy matcher, which tries several matching
+	// strategies in priority order. Without it the default order is used.
+	Strategy *strategy.Config `yaml:"strategy,omitempty" mapstructure:"strategy,omitempty"`
 }
 
 type ReporterConfigs struct {
-	Text *text.Config `yaml:"text,omitempty" mapstructure:"text,omitempty"`
-	JSON *json.Config `yaml:"json,omitempty" mapstructure:"json,omitempty"`
+	Text  *text.Config  `yaml:"text,omitempty" mapstructure:"text,omitempty"`
+	JSON  *json.Config  `yaml:"json,omitempty" mapstructure:"json,omitempty"`
+	OCSF  *ocsf.Config  `yaml:"ocsf,omitempty" mapstructure:"ocsf,omitempty"`
+	SARIF *sarif.Config `yaml:"sarif,omitempty" mapstructure:"sarif,omitempty"`
+	// HTML renders a standalone HTML page for attaching to change tickets.
+	HTML *htmlreport.Config `yaml:"html,omitempty" mapstructure:"html,omitempty"`
+	// Markdown renders the report for posting as a pull request comment.
+	Markdown *markdown.Config `yaml:"markdown,omitempty" mapstructure:"markdown,omitempty"`
+	// CSV configures the csv and tsv reporters, which write one row per
+	// attribute difference for spreadsheets and BI tools.
+	CSV *csvreport.Config `yaml:"csv,omitempty" mapstructure:"csv,omitempty"`
+	// Template renders the report through a user-supplied Go text/template.
+	Template *templatereport.Config `yaml:"template,omitempty" mapstructure:"template,omitempty"`
+	// Partition splits the report into files of bounded size in a directory,
+	// with an index, instead of writing one report to stdout.
+	Partition *partition.Config `yaml:"partition,omitempty" mapstructure:"partition,omitempty"`
 }
 
 type TFHCLConfig struct {
@@ -76,9 +364,12 @@ func DefaultConfig() *Config {
 				Tag: &tag.Config{TagKey: "TFResourceAddress"},
 			},
 			Reporter: ReporterConfigs{
-				Text: &text.Config{NoColor: false},
-				JSON: &json.Config{},
+				Text:  &text.Config{NoColor: false},
+				JSON:  &json.Config{},
+				OCSF:  &ocsf.Config{},
+				SARIF: &sarif.Config{},
 			},
+			Links: &links.Config{},
 		},
 		State: StateConfig{
 			ProviderType: tfstate.ProviderTypeTFState,
@@ -131,5 +422,111 @@ func (c *Config) GetResourceKinds() []domain.ResourceKind {
 	for k := range kindsMap {
 		kinds = append(kinds, k)
 	}
+	sort.Slice(kinds, func(i, j int) bool {
+		pi, pj := c.GetPriorityForKind(kinds[i]), c.GetPriorityForKind(kinds[j])
+		if pi != pj {
+			return pi > pj
+		}
+		return kinds[i] < kinds[j]
+	})
 	return kinds
 }
+
+// GetScanIntervalForKind returns how often the kind is scanned in daemon mode.
+func (c *Config) GetScanIntervalForKind(kind domain.ResourceKind) time.Duration {
+	for _, rc := range c.Resources {
+		if rc.Kind == kind && rc.ScanInterval > 0 {
+			return rc.ScanInterval
+		}
+	}
+	if c.Daemon != nil && c.Daemon.DefaultInterval > 0 {
+		return c.Daemon.DefaultInterval
+	}
+	return DefaultScanInterval
+}
+
+// GetAttributeGroups maps each grouped attribute to its group. An attribute
+// listed in several groups belongs to the first one.
+func (c *Config) GetAttributeGroups() map[string]string {
+	if len(c.AttributeGroups) == 0 {
+		return nil
+	}
+	groups := make(map[string]string)
+	for _, group := range c.AttributeGroups {
+		for _, attr := range group.Attributes {
+			if _, exists := groups[attr]; !exists {
+				groups[attr] = group.Name
+			}
+		}
+	}
+	return groups
+}
+
+// GetNormalizationsForKind returns the value normalizations of the kind's
+// attributes, or nil when none are configured.
+func (c *Config) GetNormalizationsForKind(kind domain.ResourceKind) map[string]domain.ValueNormalization {
+	var normalizations map[string]domain.ValueNormalization
+	for _, rc := range c.Resources {
+		if rc.Kind != kind {
+			continue
+		}
+		for _, n := range rc.Normalize {
+			if normalizations == nil {
+				normalizations = make(map[string]domain.ValueNormalization)
+			}
+			normalizations[n.Attribute] = domain.ValueNormalization{
+				CaseInsensitive:    n.CaseInsensitive,
+				TrimWhitespace:     n.TrimWhitespace,
+				CollapseWhitespace: n.CollapseWhitespace,
+			}
+		}
+	}
+	return normalizations
+}
+
+// GetIdentityAttributes returns the identity attributes of every kind that
+// configures some, or nil when none does.
+func (c *Config) GetIdentityAttributes() map[domain.ResourceKind][]string {
+	var identity map[domain.ResourceKind][]string
+	for _, rc := range c.Resources {
+		if len(rc.Identity) == 0 {
+			continue
+		}
+		if identity == nil {
+			identity = make(map[domain.ResourceKind][]string)
+		}
+		identity[rc.Kind] = rc.Identity
+	}
+	return identity
+}
+
+func (c *Config) GetPriorityForKind(kind domain.ResourceKind) int {
+	for _, rc := range c.Resources {
+		if rc.Kind == kind && rc.Priority != nil {
+			return *rc.Priority
+		}
+	}
+	return domain.DefaultKindPriority(kind)
+}
+
+// GetConcurrencyForKind returns the configured comparison concurrency of a
+// kind, or 0 when the kind is not limited.
+func (c *Config) GetConcurrencyForKind(kind domain.ResourceKind) int {
+	for _, rc := range c.Resources {
+		if rc.Kind == kind {
+			return rc.Concurrency
+		}
+	}
+	return 0
+}
+
+// GetAttributeParallelismForKind returns how many attributes of a resource of
+// the kind are compared at once, or 0 when they are compared one at a time.
+func (c *Config) GetAttributeParallelismForKind(kind domain.ResourceKind) int {
+	for _, rc := range c.Resources {
+		if rc.Kind == kind {
+			return rc.AttributeParallelism
+		}
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+type attributeParallelismKey struct{}
+
+// WithAttributeParallelism returns a context allowing comparers to compare up
+// to n attributes of a single resource at once. Values below 2 leave the
+// comparison sequential.
+func WithAttributeParallelism(ctx context.Context, n int) context.Context {
+	if n <= 1 {
+		return ctx
+	}
+	return context.WithValue(ctx, attributeParallelismKey{}, n)
+}
+
+// AttributeParallelismFrom returns how many attributes of a resource may be
+// compared at once, or 1 when the context does not allow parallelism.
+func AttributeParallelismFrom(ctx context.Context) int {
+	if ctx == nil {
+		return 1
+	}
+	if n, ok := ctx.Value(attributeParallelismKey{}).(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
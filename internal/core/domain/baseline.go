@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Baseline is a snapshot of the findings of a run that were acknowledged.
+// Later runs suppress the findings it contains and only report new drift.
+type Baseline struct {
+	CreatedAt time.Time
+	Findings  []BaselineFinding
+}
+
+// BaselineFinding is one acknowledged finding: a difference in one attribute
+// of a drifted resource, or a resource-level finding such as a missing or
+// unmanaged resource, for which AttributeName is empty. The fields besides
+// the fingerprint only make the baseline file readable.
+type BaselineFinding struct {
+	Fingerprint   string
+	ResourceKind  ResourceKind
+	ResourceID    string
+	Status        ComparisonStatus
+	AttributeName string
+	ExpectedValue any
+	ActualValue   any
+}
+
+// baselinedStatuses are the resource-level findings a baseline can suppress.
+// Errors are left out since they say nothing about the infrastructure.
+var baselinedStatuses = map[ComparisonStatus]bool{
+	StatusMissing:         true,
+	StatusUnmanaged:       true,
+	StatusRecentlyDeleted: true,
+	StatusUnapprovedImage: true,
+	StatusPendingDeletion: true,
+}
+
+// NewBaseline snapshots the findings of the given results: every difference
+// of drifted resources and every missing, unmanaged, recently deleted,
+// pending deletion or unapproved image result.
+func NewBaseline(createdAt time.Time, results []ComparisonResult) Baseline {
+	baseline := Baseline{CreatedAt: createdAt}
+	seen := make(map[string]bool)
+	add := func(finding BaselineFinding) {
+		if !seen[finding.Fingerprint] {
+			seen[finding.Fingerprint] = true
+			baseline.Findings = append(baseline.Findings, finding)
+		}
+	}
+	for _, res := range results {
+		switch {
+		case res.Status == StatusDrifted:
+			for _, diff := range res.Differences {
+				add(BaselineFinding{
+					Fingerprint:   res.DiffFingerprint(diff),
+					ResourceKind:  res.ResourceKind,
+					ResourceID:    res.resourceID(),
+					Status:        res.Status,
+					AttributeName: diff.AttributeName,
+					ExpectedValue: diff.ExpectedValue,
+					ActualValue:   diff.ActualValue,
+				})
+			}
+		case baselinedStatuses[res.Status]:
+			add(BaselineFinding{
+				Fingerprint:  res.Fingerprint(),
+				ResourceKind: res.ResourceKind,
+				ResourceID:   res.resourceID(),
+				Status:       res.Status,
+			})
+		}
+	}
+	return baseline
+}
+
+// Suppress removes the findings contained in the baseline from the results
+// and returns the remaining results with the number of findings suppressed.
+// A drifted result whose differences are all suppressed is reported as not
+// drifted; suppressed resource-level findings are left out. The given results
+// are not modified.
+func (b Baseline) Suppress(results []ComparisonResult) ([]ComparisonResult, int) {
+	known := make(map[string]bool, len(b.Findings))
+	for _, finding := range b.Findings {
+		known[finding.Fingerprint] = true
+	}
+
+	remaining := make([]ComparisonResult, 0, len(results))
+	suppressed := 0
+	for _, res := range results {
+		switch {
+		case res.Status == StatusDrifted:
+			var diffs []AttributeDiff
+			for _, diff := range res.Differences {
+				if known[res.DiffFingerprint(diff)] {
+					suppressed++
+					continue
+				}
+				diffs = append(diffs, diff)
+			}
+			res.Differences = diffs
+			if len(diffs) == 0 {
+				res.Status = StatusNoDrift
+			}
+		case baselinedStatuses[res.Status] && known[res.Fingerprint()]:
+			suppressed++
+			continue
+		}
+		remaining = append(remaining, res)
+	}
+	return remaining, suppressed
+}
+
+// Fingerprint identifies a resource-level finding across runs by the kind,
+// the resource and the status of the result.
+func (r ComparisonResult) Fingerprint() string {
+	return fingerprint(string(r.ResourceKind), r.resourceID(), string(r.Status))
+}
+
+// DiffFingerprint identifies a difference in one attribute of the result's
+// resource across runs. The expected and actual values are part of it, so a
+// difference that changes again is reported as new drift.
+func (r ComparisonResult) DiffFingerprint(diff AttributeDiff) string {
+	return fingerprint(string(r.ResourceKind), r.resourceID(), diff.AttributeName,
+		fingerprintValue(diff.ExpectedValue), fingerprintValue(diff.ActualValue))
+}
+
+// resourceID is the source identifier of the resource, which survives the
+// resource being replaced, or its platform ID for unmanaged resources.
+func (r ComparisonResult) resourceID() string {
+	if r.SourceIdentifier != "" {
+		return r.SourceIdentifier
+	}
+	return r.ProviderAssignedID
+}
+
+func fingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// fingerprintValue renders a value as JSON, which sorts map keys and renders
+// numbers of any type alike, so that the same value hashes the same in every
+// run.
+func fingerprintValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%T:%v", value, value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// ResourceSide tells which side of the comparison a listed resource is from.
+type ResourceSide string
+
+const (
+	SideDesired ResourceSide = "desired"
+	SideActual  ResourceSide = "actual"
+)
+
+// ResourceListedEvent is published for every resource listed from the
+// desired state source or the platform.
+type ResourceListedEvent struct {
+	Side     ResourceSide
+	Metadata ResourceMetadata
+}
+
+// MatchCompletedEvent is published for every matching result. A run has one,
+// or one per batch when matching is streamed; only the last batch carries the
+// unmatched desired resources.
+type MatchCompletedEvent struct {
+	Matched          int
+	UnmatchedDesired int
+	UnmatchedActual  int
+}
+
+// RunCompletedEvent is published when a run ends, successfully or not, after
+// its results are reported.
+type RunCompletedEvent struct {
+	Kinds     []ResourceKind
+	StartedAt time.Time
+	Duration  time.Duration
+	// Results are all results of the run, before any baseline suppression.
+	Results []ComparisonResult
+	Err     error
+}
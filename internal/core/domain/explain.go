@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ComparisonTrace explains how a comparison reached its verdict: the steps
+// applied to the whole resource pair and the decision path for each attribute.
+type ComparisonTrace struct {
+	Steps      []string
+	Attributes []AttributeTrace
+}
+
+// AttributeTrace records how one attribute was compared.
+type AttributeTrace struct {
+	Attribute string
+	// Comparer is the compare function that decided the attribute.
+	Comparer string
+	// Steps are the normalization steps and decisions, in order.
+	Steps []string
+	Equal bool
+	// Reason is the difference details or, when equal, why the values match.
+	Reason string
+	// Skipped is true when the attribute was not compared at all.
+	Skipped bool
+}
+
+// Explanation collects a ComparisonTrace while a comparison runs. It travels in
+// the context so comparers and helpers can record steps without changing their
+// signatures. All methods are no-ops on a nil Explanation, which is what
+// ExplanationFrom returns when explain mode is off.
+type Explanation struct {
+	mu      sync.Mutex
+	trace   ComparisonTrace
+	current *AttributeTrace
+}
+
+type explanationKey struct{}
+
+func NewExplanation() *Explanation {
+	return &Explanation{}
+}
+
+// WithExplanation returns a context carrying the explanation.
+func WithExplanation(ctx context.Context, x *Explanation) context.Context {
+	return context.WithValue(ctx, explanationKey{}, x)
+}
+
+// ExplanationFrom returns the explanation carried by the context, or nil.
+func ExplanationFrom(ctx context.Context) *Explanation {
+	if ctx == nil {
+		return nil
+	}
+	x, _ := ctx.Value(explanationKey{}).(*Explanation)
+	return x
+}
+
+// StartAttribute opens the trace of an attribute; later steps are attached to it
+// until FinishAttribute is called.
+func (x *Explanation) StartAttribute(attribute, comparer string) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.current = &AttributeTrace{Attribute: attribute, Comparer: comparer}
+}
+
+// Step records a step of the current attribute, or of the whole comparison when
+// no attribute is open.
+func (x *Explanation) Step(format string, args ...any) {
+	if x == nil {
+		return
+	}
+	step := fmt.Sprintf(format, args...)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.current != nil {
+		x.current.Steps = append(x.current.Steps, step)
+		return
+	}
+	x.trace.Steps = append(x.trace.Steps, step)
+}
+
+// FinishAttribute closes the trace of the current attribute with its verdict.
+func (x *Explanation) FinishAttribute(equal bool, reason string) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.current == nil {
+		return
+	}
+	x.current.Equal = equal
+	x.current.Reason = reason
+	x.trace.Attributes = append(x.trace.Attributes, *x.current)
+	x.current = nil
+}
+
+// SkipAttribute records an attribute that was not compared.
+func (x *Explanation) SkipAttribute(attribute, reason string) {
+	if x == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.trace.Attributes = append(x.trace.Attributes, AttributeTrace{Attribute: attribute, Skipped: true, Equal: true, Reason: reason})
+}
+
+// Trace returns the collected trace.
+func (x *Explanation) Trace() *ComparisonTrace {
+	if x == nil {
+		return nil
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	out := ComparisonTrace{
+		Steps:      append([]string(nil), x.trace.Steps...),
+		Attributes: append([]AttributeTrace(nil), x.trace.Attributes...),
+	}
+	return &out
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// RunRecord is the persisted outcome of a single drift analysis run.
+type RunRecord struct {
+	ID         string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Kinds are the resource kinds the run analysed. Runs recorded before kinds
+	// were tracked leave it empty.
+	Kinds   []ResourceKind
+	Results []ComparisonResult
+}
+
+// Covers reports whether the run analysed kind. A run without recorded kinds
+// covers the kinds of its results.
+func (r RunRecord) Covers(kind ResourceKind) bool {
+	if len(r.Kinds) > 0 {
+		for _, k := range r.Kinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+	for _, res := range r.Results {
+		if res.ResourceKind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,9 @@ const (
 	KeyTags   = "tags"
 	KeyRegion = "region"
 	TagPrefix = "tag:"
+	// KeySkippedAttributes lists the attributes a platform adapter left unfetched,
+	// e.g. when an enrichment budget ran out. Comparers do not report drift for them.
+	KeySkippedAttributes = "_skipped_attributes"
 
 	ComputeInstanceTypeKey       = "instance_type"
 	ComputeImageIDKey            = "image_id"
@@ -17,6 +20,27 @@ const (
 	ComputeEBSBlockDevicesKey    = "ebs_block_devices"
 	ComputeUserDataKey           = "user_data"
 	ComputeAvailabilityZoneKey   = "availability_zone"
+	ComputePlacementGroupKey     = "placement_group"
+	ComputeTenancyKey            = "tenancy"
+	ComputeEBSOptimizedKey       = "ebs_optimized"
+	// ComputeCreditSpecificationKey holds the CPU credit mode of a burstable
+	// (T-class) instance: "standard" or "unlimited".
+	ComputeCreditSpecificationKey = "credit_specification"
+	// ComputeNetworkTagsKey holds the network tags of a GCE instance, which
+	// select the firewall rules and routes applying to it, as a sorted list.
+	ComputeNetworkTagsKey = "network_tags"
+	// ComputeDeletionProtectionKey is whether the instance is protected from
+	// deletion: GCE deletion protection, or EC2 termination protection
+	// (disableApiTermination).
+	ComputeDeletionProtectionKey = "deletion_protection"
+	// ComputeMetadataOptionsKey holds the EC2 instance metadata service
+	// settings: http_tokens ("required" enforces IMDSv2), http_endpoint,
+	// http_put_response_hop_limit, http_protocol_ipv6 and
+	// instance_metadata_tags.
+	ComputeMetadataOptionsKey = "metadata_options"
+	// ComputeMonitoringKey is whether EC2 detailed (one-minute) monitoring is
+	// enabled.
+	ComputeMonitoringKey = "monitoring"
 
 	StorageBucketACLKey            = "acl"
 	StorageBucketVersioningKey     = "versioning_enabled"
@@ -26,4 +50,394 @@ const (
 	StorageBucketCorsRulesKey      = "cors_rules"
 	StorageBucketPolicyKey         = "policy"
 	StorageBucketEncryptionKey     = "server_side_encryption_configuration"
+	// StorageBucketSecureTransportKey is derived from the bucket policy: true when
+	// the policy denies requests made without TLS (aws:SecureTransport = false).
+	StorageBucketSecureTransportKey = "secure_transport_enforced"
+	// StorageBucketReplicationKey holds the replication role and rules of an S3
+	// bucket, the rules matched by id.
+	StorageBucketReplicationKey = "replication_configuration"
+	// StorageBucketObjectLockKey holds whether S3 Object Lock is enabled and
+	// its default retention (mode and days or years).
+	StorageBucketObjectLockKey = "object_lock_configuration"
+	// StorageBucketPublicAccessBlockKey holds the four S3 Block Public Access
+	// settings of a bucket (block_public_acls, block_public_policy,
+	// ignore_public_acls, restrict_public_buckets) as booleans.
+	StorageBucketPublicAccessBlockKey = "public_access_block"
+	// StorageBucketObjectOwnershipKey is the S3 Object Ownership setting, e.g.
+	// BucketOwnerEnforced, which disables ACLs.
+	StorageBucketObjectOwnershipKey = "object_ownership"
+	// StorageBucketAccelerationStatusKey is the S3 Transfer Acceleration
+	// status: Enabled or Suspended.
+	StorageBucketAccelerationStatusKey = "acceleration_status"
+	// StorageBucketNotificationKey holds the S3 event notifications of a
+	// bucket: the Lambda function, SQS queue and SNS topic targets, and whether
+	// events are sent to EventBridge.
+	StorageBucketNotificationKey = "notification_configuration"
+	// The S3 bucket configurations below are lists of configurations, each
+	// identified by its "id" and compared as a set keyed by it.
+	StorageBucketIntelligentTieringKey = "intelligent_tiering_configurations"
+	StorageBucketAnalyticsKey          = "analytics_configurations"
+	StorageBucketInventoryKey          = "inventory_configurations"
+	StorageBucketMetricsKey            = "metrics_configurations"
+	// StorageBucketLocationKey holds the upper-case location of a GCS bucket:
+	// a region, dual-region or multi-region such as "US".
+	StorageBucketLocationKey      = "location"
+	StorageBucketStorageClassKey  = "storage_class"
+	StorageBucketUniformAccessKey = "uniform_bucket_level_access"
+	// Settings of Azure storage accounts, which the StorageBucket kind covers.
+	StorageAccountTierKey          = "account_tier"
+	StorageAccountReplicationKey   = "account_replication_type"
+	StorageAccountKindKey          = "account_kind"
+	StorageAccountAccessTierKey    = "access_tier"
+	StorageAccountHTTPSOnlyKey     = "https_traffic_only_enabled"
+	StorageAccountMinTLSVersionKey = "min_tls_version"
+	StorageAccountPublicNestedKey  = "allow_nested_items_to_be_public"
+
+	// Kubernetes object attributes. Labels are held in KeyTags.
+	KubernetesNamespaceKey = "namespace"
+	// KubernetesReplicasKey defaults to 1 and KubernetesStrategyKey to
+	// "RollingUpdate", as the API server does.
+	KubernetesReplicasKey       = "replicas"
+	KubernetesStrategyKey       = "strategy"
+	KubernetesSelectorKey       = "selector"
+	KubernetesServiceAccountKey = "service_account_name"
+	// KubernetesContainersKey holds the pod template containers in order, as
+	// maps with "name", "image" and, when set, "ports" (maps with
+	// "container_port" and "protocol"), "env" (a map of plain values) and
+	// "resources" (maps "limits" and "requests").
+	KubernetesContainersKey = "containers"
+	// KubernetesServiceTypeKey defaults to "ClusterIP".
+	KubernetesServiceTypeKey = "type"
+	// KubernetesPortsKey holds service ports sorted by port and protocol, as
+	// maps with "name", "port", "target_port" and "protocol".
+	KubernetesPortsKey      = "ports"
+	KubernetesDataKey       = "data"
+	KubernetesBinaryDataKey = "binary_data"
+
+	DatabaseInstanceClassKey           = "instance_class"
+	DatabaseEngineKey                  = "engine"
+	DatabaseEngineVersionKey           = "engine_version"
+	DatabaseAllocatedStorageKey        = "allocated_storage"
+	DatabaseMaxAllocatedStorageKey     = "max_allocated_storage"
+	DatabaseStorageTypeKey             = "storage_type"
+	DatabaseIOPSKey                    = "iops"
+	DatabaseMultiAZKey                 = "multi_az"
+	DatabaseAvailabilityZoneKey        = "availability_zone"
+	DatabasePubliclyAccessibleKey      = "publicly_accessible"
+	DatabaseStorageEncryptedKey        = "storage_encrypted"
+	DatabaseKMSKeyIDKey                = "kms_key_id"
+	DatabaseSecurityGroupsKey          = "vpc_security_group_ids"
+	DatabaseSubnetGroupKey             = "db_subnet_group_name"
+	DatabaseParameterGroupKey          = "parameter_group_name"
+	DatabaseMaintenanceWindowKey       = "maintenance_window"
+	DatabaseDeletionProtectionKey      = "deletion_protection"
+	DatabaseAutoMinorVersionUpgradeKey = "auto_minor_version_upgrade"
+	DatabasePortKey                    = "port"
+	DatabaseUsernameKey                = "username"
+	DatabaseIAMAuthenticationKey       = "iam_database_authentication_enabled"
+	DatabasePerformanceInsightsKey     = "performance_insights_enabled"
+	DatabaseCACertIdentifierKey        = "ca_cert_identifier"
+
+	FunctionRuntimeKey          = "runtime"
+	FunctionHandlerKey          = "handler"
+	FunctionMemorySizeKey       = "memory_size"
+	FunctionTimeoutKey          = "timeout"
+	FunctionRoleKey             = "role"
+	FunctionDescriptionKey      = "description"
+	FunctionArchitecturesKey    = "architectures"
+	FunctionPackageTypeKey      = "package_type"
+	FunctionKMSKeyARNKey        = "kms_key_arn"
+	FunctionTracingModeKey      = "tracing_mode"
+	FunctionEphemeralStorageKey = "ephemeral_storage"
+	// FunctionEnvironmentKey holds the function's environment variables as a
+	// map[string]string.
+	FunctionEnvironmentKey = "environment"
+	// FunctionLayersKey holds the ARNs of the function's layers, in order.
+	FunctionLayersKey = "layers"
+	// FunctionVPCConfigKey holds the subnet and security group IDs of the
+	// function's VPC attachment, each as a sorted []string.
+	FunctionVPCConfigKey = "vpc_config"
+
+	IAMPathKey                = "path"
+	IAMDescriptionKey         = "description"
+	IAMMaxSessionDurationKey  = "max_session_duration"
+	IAMPermissionsBoundaryKey = "permissions_boundary"
+	// IAMAssumeRolePolicyKey holds the role's trust policy as a JSON document.
+	IAMAssumeRolePolicyKey = "assume_role_policy"
+	// IAMInlinePoliciesKey holds the role's inline policies as a
+	// map[string]string of policy name to JSON document.
+	IAMInlinePoliciesKey = "inline_policy"
+	// IAMManagedPolicyARNsKey holds the ARNs of the managed policies attached
+	// to the role, as a sorted []string.
+	IAMManagedPolicyARNsKey = "managed_policy_arns"
+	// IAMPolicyDocumentKey holds the default version of a managed policy as a
+	// JSON document.
+	IAMPolicyDocumentKey = "policy"
+
+	SecurityGroupDescriptionKey = "description"
+	SecurityGroupVPCIDKey       = "vpc_id"
+	// SecurityGroupIngressKey and SecurityGroupEgressKey hold the group's rules
+	// flattened to one map per source: "protocol", "from_port", "to_port" and
+	// exactly one of "cidr_block", "ipv6_cidr_block", "prefix_list_id",
+	// "security_group" or "self".
+	SecurityGroupIngressKey = "ingress"
+	SecurityGroupEgressKey  = "egress"
+
+	TableBillingModeKey        = "billing_mode"
+	TableReadCapacityKey       = "read_capacity"
+	TableWriteCapacityKey      = "write_capacity"
+	TableHashKeyKey            = "hash_key"
+	TableRangeKeyKey           = "range_key"
+	TableStreamEnabledKey      = "stream_enabled"
+	TableStreamViewTypeKey     = "stream_view_type"
+	TableClassKey              = "table_class"
+	TableDeletionProtectionKey = "deletion_protection_enabled"
+	// TableAttributesKey holds the key attribute definitions as a list of maps
+	// with "name" and "type", sorted by name.
+	TableAttributesKey = "attribute"
+	// TableGlobalSecondaryIndexesKey and TableLocalSecondaryIndexesKey hold the
+	// indexes as lists of maps sorted by "name", with "hash_key", "range_key",
+	// "projection_type", sorted "non_key_attributes" and, for global indexes,
+	// "read_capacity" and "write_capacity".
+	TableGlobalSecondaryIndexesKey = "global_secondary_index"
+	TableLocalSecondaryIndexesKey  = "local_secondary_index"
+	// TableTTLKey holds the time to live setting as a map with "attribute_name"
+	// and "enabled".
+	TableTTLKey = "ttl"
+	// TableServerSideEncryptionKey holds the encryption at rest setting as a map
+	// with "enabled" (false for the default AWS owned key) and "kms_key_arn".
+	TableServerSideEncryptionKey = "server_side_encryption"
+
+	DistributionEnabledKey           = "enabled"
+	DistributionCommentKey           = "comment"
+	DistributionPriceClassKey        = "price_class"
+	DistributionHTTPVersionKey       = "http_version"
+	DistributionIPv6EnabledKey       = "is_ipv6_enabled"
+	DistributionDefaultRootObjectKey = "default_root_object"
+	DistributionWebACLIDKey          = "web_acl_id"
+	// DistributionAliasesKey holds the alternate domain names as a sorted []string.
+	DistributionAliasesKey = "aliases"
+	// DistributionOriginsKey holds the origins as a list of maps sorted by
+	// "origin_id". Sets nested in an origin, such as "custom_header" and the
+	// "origin_ssl_protocols" of its "custom_origin_config", are sorted too.
+	DistributionOriginsKey = "origin"
+	// DistributionOriginGroupsKey holds the failover origin groups as a list of
+	// maps sorted by "origin_id", with sorted "status_codes" and the "members"
+	// in failover order.
+	DistributionOriginGroupsKey = "origin_group"
+	// DistributionDefaultCacheBehaviorKey holds the default cache behavior as
+	// a map. DistributionOrderedCacheBehaviorsKey holds the other behaviors
+	// in precedence order, each with its "path_pattern". Methods, key groups
+	// and function associations are sorted, and the TTLs are left out for
+	// behaviors that use a cache policy.
+	DistributionDefaultCacheBehaviorKey  = "default_cache_behavior"
+	DistributionOrderedCacheBehaviorsKey = "ordered_cache_behavior"
+	// DistributionCustomErrorResponsesKey holds the custom error responses as
+	// a list of maps sorted by "error_code".
+	DistributionCustomErrorResponsesKey = "custom_error_response"
+	// DistributionViewerCertificateKey holds the viewer certificate as a map
+	// with "cloudfront_default_certificate", "acm_certificate_arn",
+	// "iam_certificate_id" and "ssl_support_method". Its minimum protocol
+	// version is kept under KeyMinimumProtocolVersion.
+	DistributionViewerCertificateKey = "viewer_certificate"
+	// DistributionLoggingKey holds the access log settings as a map with
+	// "bucket", "prefix" and "include_cookies", absent when logging is off.
+	DistributionLoggingKey = "logging_config"
+	// DistributionGeoRestrictionKey holds the geo restriction as a map with
+	// "restriction_type" and sorted "locations".
+	DistributionGeoRestrictionKey = "geo_restriction"
+
+	LoadBalancerInternalKey      = "internal"
+	LoadBalancerTypeKey          = "load_balancer_type"
+	LoadBalancerIPAddressTypeKey = "ip_address_type"
+	// LoadBalancerSecurityGroupsKey and LoadBalancerSubnetsKey hold sorted []string.
+	LoadBalancerSecurityGroupsKey = "security_groups"
+	LoadBalancerSubnetsKey        = "subnets"
+	// Load balancer attributes. The idle timeout, HTTP/2 and invalid header
+	// settings only apply to application load balancers and are absent for
+	// the other types.
+	LoadBalancerIdleTimeoutKey        = "idle_timeout"
+	LoadBalancerDeletionProtectionKey = "enable_deletion_protection"
+	LoadBalancerHTTP2Key              = "enable_http2"
+	LoadBalancerCrossZoneKey          = "enable_cross_zone_load_balancing"
+	LoadBalancerDropInvalidHeadersKey = "drop_invalid_header_fields"
+	// LoadBalancerAccessLogsKey holds the access log settings as a map with
+	// "bucket" and "prefix", absent when access logs are off.
+	LoadBalancerAccessLogsKey = "access_logs"
+
+	ListenerLoadBalancerARNKey = "load_balancer_arn"
+	ListenerPortKey            = "port"
+	ListenerProtocolKey        = "protocol"
+	ListenerCertificateARNKey  = "certificate_arn"
+	ListenerALPNPolicyKey      = "alpn_policy"
+	// ListenerDefaultActionsKey holds the default actions in order. Each
+	// action is a flat map with its "type" and the settings of that type, e.g.
+	// "target_groups" (maps with "arn" and, for weighted forwards, "weight",
+	// sorted by ARN) for forward actions or "status_code", "host", "path",
+	// "port", "protocol" and "query" for redirects.
+	ListenerDefaultActionsKey = "default_action"
+	// ListenerRulesKey holds the listener's non-default rules as maps with
+	// an int64 "priority", "conditions" (maps with "field", sorted "values"
+	// and, for header conditions, "http_header_name") and "actions" shaped
+	// like the default actions. Rules are sorted by priority.
+	ListenerRulesKey = "rule"
+
+	TargetGroupPortKey            = "port"
+	TargetGroupProtocolKey        = "protocol"
+	TargetGroupProtocolVersionKey = "protocol_version"
+	TargetGroupTargetTypeKey      = "target_type"
+	TargetGroupVPCIDKey           = "vpc_id"
+	TargetGroupSlowStartKey       = "slow_start"
+	TargetGroupAlgorithmKey       = "load_balancing_algorithm_type"
+	// TargetGroupDeregistrationDelayKey holds the deregistration delay in
+	// seconds as an int64.
+	TargetGroupDeregistrationDelayKey = "deregistration_delay"
+	// TargetGroupHealthCheckKey holds the health check as a map with
+	// "enabled", "path", "port", "protocol", "matcher", "interval", "timeout",
+	// "healthy_threshold" and "unhealthy_threshold"; unset fields are left out.
+	TargetGroupHealthCheckKey = "health_check"
+	// TargetGroupStickinessKey holds the stickiness settings as a map with
+	// "type" and, for cookie stickiness, "cookie_duration" and "cookie_name";
+	// absent when stickiness is off.
+	TargetGroupStickinessKey = "stickiness"
+
+	AutoScalingGroupMinSizeKey             = "min_size"
+	AutoScalingGroupMaxSizeKey             = "max_size"
+	AutoScalingGroupDesiredCapacityKey     = "desired_capacity"
+	AutoScalingGroupLaunchConfigurationKey = "launch_configuration"
+	AutoScalingGroupHealthCheckTypeKey     = "health_check_type"
+	AutoScalingGroupHealthCheckGraceKey    = "health_check_grace_period"
+	AutoScalingGroupDefaultCooldownKey     = "default_cooldown"
+	AutoScalingGroupCapacityRebalanceKey   = "capacity_rebalance"
+	AutoScalingGroupMaxInstanceLifetimeKey = "max_instance_lifetime"
+	// AutoScalingGroupLaunchTemplateKey holds the launch template reference as
+	// a map with "id", "name" and "version" (a number, "$Latest" or "$Default").
+	AutoScalingGroupLaunchTemplateKey = "launch_template"
+	// AutoScalingGroupSubnetsKey holds the sorted subnet IDs of the group.
+	AutoScalingGroupSubnetsKey         = "vpc_zone_identifier"
+	AutoScalingGroupTargetGroupARNsKey = "target_group_arns"
+	// AutoScalingGroupTerminationPoliciesKey holds the termination policies in
+	// the order they are applied; the implicit ["Default"] is left out.
+	AutoScalingGroupTerminationPoliciesKey = "termination_policies"
+	// AutoScalingGroupPropagatedTagsKey holds the sorted keys of the tags that
+	// are propagated to launched instances. The tags themselves are KeyTags.
+	AutoScalingGroupPropagatedTagsKey = "tags_propagated_at_launch"
+
+	// Launch template attributes. The template data is that of the latest
+	// version, the one Terraform tracks, and reuses the compute instance keys
+	// (image_id, instance_type, security_groups, user_data, ...) where the
+	// setting means the same.
+	LaunchTemplateDescriptionKey = "description"
+	LaunchTemplateKeyNameKey     = "key_name"
+	// LaunchTemplateDefaultVersionKey is the version number launches use when
+	// they reference the template without a version or with "$Default".
+	LaunchTemplateDefaultVersionKey = "default_version"
+	LaunchTemplateLatestVersionKey  = "latest_version"
+	// LaunchTemplateBlockDeviceMappingsKey holds the block device mappings
+	// sorted by "device_name", each with an "ebs" map of the volume settings.
+	LaunchTemplateBlockDeviceMappingsKey = "block_device_mappings"
+	// LaunchTemplateTagSpecificationsKey holds the tags applied at launch as a
+	// list of maps with "resource_type" and "tags", sorted by resource type.
+	LaunchTemplateTagSpecificationsKey = "tag_specifications"
+	// LaunchTemplateDefaultVersionDataKey holds, when the default version is
+	// not the latest, the template data of the default version under the same
+	// keys. No desired state sets it; it explains default version drift.
+	LaunchTemplateDefaultVersionDataKey = "default_version_data"
+
+	// Elastic IP attributes. The ID is the allocation ID.
+	ElasticIPPublicIPKey = "public_ip"
+	ElasticIPDomainKey   = "domain"
+	// ElasticIPInstanceKey and ElasticIPNetworkInterfaceKey are what the
+	// address is associated with. Both are absent when it is not associated.
+	ElasticIPInstanceKey         = "instance"
+	ElasticIPNetworkInterfaceKey = "network_interface"
+	ElasticIPPrivateIPKey        = "private_ip"
+	// ElasticIPAssociationIDKey changes on every association, so it tells
+	// whether the address was re-associated rather than where to.
+	ElasticIPAssociationIDKey = "association_id"
+
+	NetworkInterfaceSubnetIDKey        = "subnet_id"
+	NetworkInterfaceDescriptionKey     = "description"
+	NetworkInterfacePrivateIPKey       = "private_ip"
+	NetworkInterfaceSourceDestCheckKey = "source_dest_check"
+	NetworkInterfaceTypeKey            = "interface_type"
+	// NetworkInterfacePrivateIPsKey holds all private IPv4 addresses, sorted.
+	NetworkInterfacePrivateIPsKey = "private_ips"
+	// NetworkInterfaceSecurityGroupsKey holds the IDs of the attached
+	// security groups.
+	NetworkInterfaceSecurityGroupsKey = "security_groups"
+	// NetworkInterfaceAttachmentKey holds the attachment as a map with
+	// "instance" and "device_index"; absent when the interface is detached.
+	NetworkInterfaceAttachmentKey = "attachment"
+
+	EncryptionKeyDescriptionKey = "description"
+	EncryptionKeyEnabledKey     = "is_enabled"
+	EncryptionKeyUsageKey       = "key_usage"
+	EncryptionKeySpecKey        = "customer_master_key_spec"
+	EncryptionKeyMultiRegionKey = "multi_region"
+	// EncryptionKeyPolicyKey holds the key policy as a JSON document.
+	EncryptionKeyPolicyKey = "policy"
+	// EncryptionKeyRotationKey reports whether automatic rotation is enabled;
+	// the rotation period in days is EncryptionKeyRotationPeriodKey.
+	EncryptionKeyRotationKey       = "enable_key_rotation"
+	EncryptionKeyRotationPeriodKey = "rotation_period_in_days"
+	// EncryptionKeyDeletionWindowKey holds the waiting period in days before a
+	// key is deleted. AWS only reports it once the deletion is scheduled.
+	EncryptionKeyDeletionWindowKey = "deletion_window_in_days"
+	// EncryptionKeyAliasesKey holds the sorted alias names ("alias/...") that
+	// point to the key.
+	EncryptionKeyAliasesKey = "aliases"
+
+	// ContainerClusterSettingsKey holds the cluster settings, such as
+	// containerInsights, as a map of setting name to value.
+	ContainerClusterSettingsKey = "setting"
+
+	// ContainerServiceClusterKey holds the name of the service's cluster, and
+	// ContainerServiceTaskDefinitionKey its task definition as "family:revision".
+	ContainerServiceClusterKey            = "cluster"
+	ContainerServiceTaskDefinitionKey     = "task_definition"
+	ContainerServiceDesiredCountKey       = "desired_count"
+	ContainerServiceLaunchTypeKey         = "launch_type"
+	ContainerServicePlatformVersionKey    = "platform_version"
+	ContainerServiceSchedulingStrategyKey = "scheduling_strategy"
+	ContainerServicePropagateTagsKey      = "propagate_tags"
+	ContainerServiceExecuteCommandKey     = "enable_execute_command"
+	ContainerServiceHealthCheckGraceKey   = "health_check_grace_period_seconds"
+	ContainerServiceMinHealthyPercentKey  = "deployment_minimum_healthy_percent"
+	ContainerServiceMaxPercentKey         = "deployment_maximum_percent"
+	// ContainerServiceNetworkConfigurationKey holds the awsvpc configuration as
+	// a map with sorted "subnets" and "security_groups" and "assign_public_ip".
+	ContainerServiceNetworkConfigurationKey = "network_configuration"
+	// ContainerServiceCapacityProvidersKey holds the capacity provider strategy
+	// as maps with "capacity_provider", "weight" and "base", sorted by provider.
+	ContainerServiceCapacityProvidersKey = "capacity_provider_strategy"
+	// ContainerServiceLoadBalancersKey holds the load balancers as maps with
+	// "target_group_arn" or "elb_name", "container_name" and "container_port".
+	ContainerServiceLoadBalancersKey = "load_balancer"
+
+	// TaskDefinitionRevisionKey holds the revision of the family's latest
+	// active task definition.
+	TaskDefinitionRevisionKey = "revision"
+	// TaskDefinitionContainerDefinitionsKey holds the container definitions as
+	// a JSON document, see compare.NormalizeContainerDefinitions.
+	TaskDefinitionContainerDefinitionsKey = "container_definitions"
+	TaskDefinitionCPUKey                  = "cpu"
+	TaskDefinitionMemoryKey               = "memory"
+	TaskDefinitionNetworkModeKey          = "network_mode"
+	TaskDefinitionCompatibilitiesKey      = "requires_compatibilities"
+	TaskDefinitionExecutionRoleKey        = "execution_role_arn"
+	TaskDefinitionTaskRoleKey             = "task_role_arn"
+	// TaskDefinitionRuntimePlatformKey holds a map with
+	// "operating_system_family" and "cpu_architecture".
+	TaskDefinitionRuntimePlatformKey = "runtime_platform"
+
+	// TLS / security policy attributes shared across kinds.
+	KeySSLPolicy              = "ssl_policy"
+	KeyMinimumProtocolVersion = "minimum_protocol_version"
+
+	// Resilience (replication and backup) attributes shared across kinds.
+	KeyBackupRetentionPeriod = "backup_retention_period"
+	KeyBackupWindow          = "backup_window"
+	KeyBackupPolicy          = "backup_policy"
+	KeyPointInTimeRecovery   = "point_in_time_recovery"
 )
@@ -3,11 +3,72 @@ package domain
 type ResourceKind string
 
 const (
-	KindComputeInstance  ResourceKind = "ComputeInstance"
-	KindStorageBucket    ResourceKind = "StorageBucket"
-	KindDatabaseInstance ResourceKind = "DatabaseInstance"
+	KindComputeInstance      ResourceKind = "ComputeInstance"
+	KindStorageBucket        ResourceKind = "StorageBucket"
+	KindDatabaseInstance     ResourceKind = "DatabaseInstance"
+	KindServerlessFunction   ResourceKind = "ServerlessFunction"
+	KindIAMRole              ResourceKind = "IAMRole"
+	KindIAMPolicy            ResourceKind = "IAMPolicy"
+	KindNetworkSecurityGroup ResourceKind = "NetworkSecurityGroup"
+	KindDatabaseTable        ResourceKind = "DatabaseTable"
+	KindCDNDistribution      ResourceKind = "CDNDistribution"
+	KindAutoScalingGroup     ResourceKind = "AutoScalingGroup"
+	KindLaunchTemplate       ResourceKind = "LaunchTemplate"
+	KindEncryptionKey        ResourceKind = "EncryptionKey"
+	KindElasticIP            ResourceKind = "ElasticIP"
+	KindNetworkInterface     ResourceKind = "NetworkInterface"
+
+	// Elastic Load Balancing (v2) application, network and gateway load
+	// balancers, with their listeners and target groups.
+	KindLoadBalancer            ResourceKind = "LoadBalancer"
+	KindLoadBalancerListener    ResourceKind = "LoadBalancerListener"
+	KindLoadBalancerTargetGroup ResourceKind = "LoadBalancerTargetGroup"
+
+	// Amazon ECS clusters, services and task definitions.
+	KindContainerCluster        ResourceKind = "ContainerCluster"
+	KindContainerService        ResourceKind = "ContainerService"
+	KindContainerTaskDefinition ResourceKind = "ContainerTaskDefinition"
+
+	// Kubernetes objects, compared between manifests and a cluster.
+	KindKubernetesDeployment ResourceKind = "KubernetesDeployment"
+	KindKubernetesService    ResourceKind = "KubernetesService"
+	KindKubernetesConfigMap  ResourceKind = "KubernetesConfigMap"
 )
 
 func (rk ResourceKind) String() string {
 	return string(rk)
 }
+
+// defaultKindPriorities ranks built-in kinds by how security-sensitive drift in
+// them tends to be. Kinds without an entry default to zero.
+var defaultKindPriorities = map[ResourceKind]int{
+	KindStorageBucket:           20,
+	KindDatabaseInstance:        10,
+	KindComputeInstance:         10,
+	KindServerlessFunction:      10,
+	KindIAMRole:                 20,
+	KindIAMPolicy:               20,
+	KindNetworkSecurityGroup:    20,
+	KindDatabaseTable:           10,
+	KindCDNDistribution:         10,
+	KindAutoScalingGroup:        10,
+	KindLaunchTemplate:          10,
+	KindEncryptionKey:           20,
+	KindElasticIP:               10,
+	KindNetworkInterface:        10,
+	KindLoadBalancer:            10,
+	KindLoadBalancerListener:    20,
+	KindLoadBalancerTargetGroup: 5,
+	KindContainerCluster:        5,
+	KindContainerService:        10,
+	KindContainerTaskDefinition: 10,
+	KindKubernetesDeployment:    10,
+	KindKubernetesService:       10,
+	KindKubernetesConfigMap:     5,
+}
+
+// DefaultKindPriority returns the built-in priority of a kind. Higher values are
+// listed, compared and reported first.
+func DefaultKindPriority(kind ResourceKind) int {
+	return defaultKindPriorities[kind]
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// ValueNormalization is how the string values of an attribute are normalized
+// before the default comparison, for values the platform and the desired state
+// spell differently, such as availability zone names or ARNs in another case.
+type ValueNormalization struct {
+	// CaseInsensitive compares values ignoring case.
+	CaseInsensitive bool
+	// TrimWhitespace ignores leading and trailing whitespace.
+	TrimWhitespace bool
+	// CollapseWhitespace treats runs of whitespace inside values as a single space.
+	CollapseWhitespace bool
+}
+
+// IsZero reports whether the normalization leaves values unchanged.
+func (n ValueNormalization) IsZero() bool {
+	return n == ValueNormalization{}
+}
+
+// Apply normalizes a single string value.
+func (n ValueNormalization) Apply(s string) string {
+	if n.CollapseWhitespace {
+		s = strings.Join(strings.Fields(s), " ")
+	} else if n.TrimWhitespace {
+		s = strings.TrimSpace(s)
+	}
+	if n.CaseInsensitive {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// String describes the enabled normalizations, e.g. "case-insensitive, trimmed".
+func (n ValueNormalization) String() string {
+	var parts []string
+	if n.CaseInsensitive {
+		parts = append(parts, "case-insensitive")
+	}
+	if n.CollapseWhitespace {
+		parts = append(parts, "whitespace collapsed")
+	} else if n.TrimWhitespace {
+		parts = append(parts, "whitespace trimmed")
+	}
+	return strings.Join(parts, ", ")
+}
+
+type normalizationsKey struct{}
+
+type attributeNormalizationKey struct{}
+
+// WithNormalizations returns a context carrying the value normalizations of a
+// kind, keyed by attribute name.
+func WithNormalizations(ctx context.Context, normalizations map[string]ValueNormalization) context.Context {
+	if len(normalizations) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, normalizationsKey{}, normalizations)
+}
+
+// NormalizationFor returns the normalization configured for the attribute in
+// the context, or the zero normalization.
+func NormalizationFor(ctx context.Context, attribute string) ValueNormalization {
+	if ctx == nil {
+		return ValueNormalization{}
+	}
+	normalizations, _ := ctx.Value(normalizationsKey{}).(map[string]ValueNormalization)
+	return normalizations[attribute]
+}
+
+// WithAttributeNormalization returns a context carrying the normalization of
+// the attribute being compared.
+func WithAttributeNormalization(ctx context.Context, n ValueNormalization) context.Context {
+	if n.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, attributeNormalizationKey{}, n)
+}
+
+// AttributeNormalizationFrom returns the normalization of the attribute being
+// compared, or the zero normalization.
+func AttributeNormalizationFrom(ctx context.Context) ValueNormalization {
+	if ctx == nil {
+		return ValueNormalization{}
+	}
+	n, _ := ctx.Value(attributeNormalizationKey{}).(ValueNormalization)
+	return n
+}
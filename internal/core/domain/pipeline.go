@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// BufferStats describes how a bounded pipeline buffer behaved during a run.
+// Blocked sends mean the producer outpaced the consumer and had to wait for
+// free capacity, which points at the stage where the pipeline stalls.
+type BufferStats struct {
+	Name     string
+	Capacity int
+	Sent     int64
+	// PeakLength is the highest number of items observed queued in the buffer.
+	PeakLength int
+	// BlockedSends counts sends that found the buffer full.
+	BlockedSends int64
+	// BlockedTime is the total time producers spent waiting on a full buffer.
+	BlockedTime time.Duration
+	// BlockedTimeByKind breaks BlockedTime down by the kind of the queued item.
+	BlockedTimeByKind map[ResourceKind]time.Duration
+}
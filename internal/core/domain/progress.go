@@ -0,0 +1,34 @@
+package domain
+
+// ProgressStage identifies what a ProgressEvent reports.
+type ProgressStage string
+
+const (
+	// ProgressRunStarted is sent once the run passed its self-test and starts
+	// listing resources, with the kinds of the run.
+	ProgressRunStarted ProgressStage = "run_started"
+	// ProgressListedDesired is sent when the desired resources of a kind are
+	// listed, with their count.
+	ProgressListedDesired ProgressStage = "listed_desired"
+	// ProgressListedActual is sent for every actual resource listed.
+	ProgressListedActual ProgressStage = "listed_actual"
+	// ProgressMatched is sent when resources of a kind are matched, with the
+	// number of matched pairs.
+	ProgressMatched ProgressStage = "matched"
+	// ProgressResult is sent for every result of the run, with its status.
+	ProgressResult ProgressStage = "result"
+	// ProgressRunFinished is sent when the pipeline stops, successfully or
+	// not, before the results are reported.
+	ProgressRunFinished ProgressStage = "run_finished"
+)
+
+// ProgressEvent reports the progress of a run.
+type ProgressEvent struct {
+	Stage ProgressStage
+	// Kinds are the kinds of the run; only set for ProgressRunStarted.
+	Kinds []ResourceKind
+	Kind  ResourceKind
+	Count int
+	// Status is the status of the result; only set for ProgressResult.
+	Status ComparisonStatus
+}
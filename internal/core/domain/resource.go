@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ResourceMetadata struct {
 	Kind               ResourceKind
@@ -8,8 +11,32 @@ type ResourceMetadata struct {
 	ProviderAssignedID string
 	InternalID         string
 	SourceIdentifier   string // e.g., Terraform resource address like aws_instance.my_app
+	SourceFile         string // File declaring the resource, relative to the source root, if known
+	SourceLine         int
 	Region             string
 	AccountID          string
+	// PendingDeletion is set when the platform still lists the resource but is
+	// about to remove it or holds it in a recycle or retention state.
+	PendingDeletion *PendingDeletion
+	// PlatformDefault is set, to a short reason such as "default security
+	// group", when the platform created the resource on its own rather than
+	// anyone managing the account.
+	PlatformDefault string
+	// UnknownAttributes lists the desired attributes whose values are only
+	// known after apply, such as references to data sources or to attributes
+	// of other resources. Unknown-tolerant runs do not compare them.
+	UnknownAttributes []string
+}
+
+// PendingDeletion describes the transitional state of a resource on its way
+// out, such as a KMS key pending deletion or a database being deleted.
+type PendingDeletion struct {
+	// State is the platform's name for the state, e.g. "PendingDeletion" or
+	// "deleting".
+	State string
+	// DeletionDate is when the platform removes the resource for good, or zero
+	// when the platform does not say.
+	DeletionDate time.Time
 }
 
 //go:generate mockery --name=PlatformResource --output=./mocks --outpkg=mocks --case underscore
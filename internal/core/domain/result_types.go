@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"sort"
+	"time"
+)
+
 type ComparisonStatus string
 
 const (
@@ -8,6 +13,25 @@ const (
 	StatusUnmanaged ComparisonStatus = "UNMANAGED"
 	StatusMissing   ComparisonStatus = "MISSING"
 	StatusError     ComparisonStatus = "ERROR"
+	// StatusRecentlyDeleted marks a resource that was on the platform in a previous
+	// run but has since disappeared, within the tombstone grace period.
+	StatusRecentlyDeleted ComparisonStatus = "RECENTLY_DELETED"
+	// StatusDeadLettered marks a resource whose comparison failed in several
+	// consecutive runs. It is reported in a separate dead-letter list with its
+	// last error instead of as a fresh error in every run.
+	StatusDeadLettered ComparisonStatus = "DEAD_LETTERED"
+	// StatusUnapprovedImage marks an instance running a machine image that the
+	// image approval source (e.g. a golden AMI manifest) does not list for it.
+	// It is reported in addition to the instance's drift result.
+	StatusUnapprovedImage ComparisonStatus = "UNAPPROVED_IMAGE"
+	// StatusPendingDeletion marks a resource the platform is deleting or holds
+	// in a recycle or retention state. Its attributes are not compared, since
+	// the resource is going away whether or not it drifted.
+	StatusPendingDeletion ComparisonStatus = "PENDING_DELETION"
+	// StatusAmbiguous marks resources the matcher could not pair because
+	// several desired resources match the same actual resource, or the
+	// reverse. The candidates are listed instead of one being picked.
+	StatusAmbiguous ComparisonStatus = "AMBIGUOUS"
 )
 
 type AttributeDiff struct {
@@ -15,6 +39,56 @@ type AttributeDiff struct {
 	ExpectedValue any
 	ActualValue   any
 	Details       string
+	Severity      Severity
+	// Group is the configured attribute group (e.g. "security", "cost") the
+	// attribute belongs to, or empty when no groups are configured.
+	Group string
+	// PlatformManaged explains why the difference was caused by the platform on
+	// its own, e.g. a certificate renewal, rather than being actionable drift.
+	// Such differences have info severity. Empty for actionable drift.
+	PlatformManaged string
+	// Changes are the paths at which the values differ, for attributes holding
+	// JSON documents such as policies. Empty for other attributes.
+	Changes []JSONChange
+}
+
+// JSONChangeOp is the kind of a JSONChange.
+type JSONChangeOp string
+
+const (
+	JSONChangeAdded    JSONChangeOp = "added"
+	JSONChangeRemoved  JSONChangeOp = "removed"
+	JSONChangeModified JSONChangeOp = "changed"
+)
+
+// JSONChange is one difference between the expected and actual JSON document
+// of an attribute.
+type JSONChange struct {
+	// Path locates the value in the document, e.g. Statement[0].Action[1].
+	// Empty when the documents differ at the top level.
+	Path string
+	Op   JSONChangeOp
+	// Expected is the value in the expected document, nil when it was added.
+	Expected any
+	// Actual is the value in the actual document, nil when it was removed.
+	Actual any
+}
+
+// UngroupedAttributes is the group of differences in attributes that are not
+// listed in any configured attribute group.
+const UngroupedAttributes = "other"
+
+// GroupCount is the number of differences in one attribute group.
+type GroupCount struct {
+	Group string
+	Count int
+}
+
+// ResourceLink is a deep link attached to a finding, such as the platform console
+// page of the resource or the source file that declares it.
+type ResourceLink struct {
+	Name string
+	URL  string
 }
 
 type ComparisonResult struct {
@@ -23,6 +97,111 @@ type ComparisonResult struct {
 	SourceIdentifier   string
 	ProviderType       string
 	ProviderAssignedID string
-	Differences        []AttributeDiff
-	Error              error
+	// SourceFile and SourceLine locate the declaration of the resource in the
+	// desired state source, when the source records it.
+	SourceFile  string
+	SourceLine  int
+	Differences []AttributeDiff
+	Error       error
+	// Priority is the priority of ResourceKind for this run; reporters list
+	// higher priority results first.
+	Priority int
+	Links    []ResourceLink
+	// DeletionWindow bounds when a recently deleted resource disappeared: between
+	// the last run that saw it and the current run.
+	DeletionWindow *TimeWindow
+	// FailureStreak counts the consecutive runs in which the comparison of this
+	// resource failed. It is only set for failed and dead-lettered results.
+	FailureStreak *FailureStreak
+	// Trace explains how the comparison reached its verdict. It is only set in
+	// explain mode.
+	Trace *ComparisonTrace
+	// PendingDeletion describes the transitional state of a pending deletion
+	// result.
+	PendingDeletion *PendingDeletion
+	// NotAsserted lists the attributes left out of the comparison because
+	// their desired values are only known after apply. It is only set in
+	// unknown-tolerant runs.
+	NotAsserted []string
+	// Match describes how the desired resource was paired with the actual
+	// one, when the matcher reports it.
+	Match *MatchInfo
+	// Candidates lists the resources of an ambiguous result that match one
+	// another, the desired ones by source identifier and the actual ones by
+	// provider assigned ID.
+	Candidates []MatchCandidate
+}
+
+// MatchCandidate is one of the resources of an ambiguous match.
+type MatchCandidate struct {
+	SourceIdentifier   string
+	ProviderAssignedID string
+}
+
+// MatchInfo is the matching strategy that paired a desired and an actual
+// resource and its confidence in the pairing, from 0 to 1.
+type MatchInfo struct {
+	Strategy   string
+	Confidence float64
+}
+
+// MaxSeverity returns the highest severity among the result's differences, or
+// an empty severity when there are none.
+func (r ComparisonResult) MaxSeverity() Severity {
+	var highest Severity
+	for _, diff := range r.Differences {
+		if diff.Severity.Rank() > highest.Rank() {
+			highest = diff.Severity
+		}
+	}
+	return highest
+}
+
+// DriftByGroup counts the result's differences per attribute group, largest
+// group first. Differences without a group are not counted.
+func (r ComparisonResult) DriftByGroup() []GroupCount {
+	return countGroups(r.Differences)
+}
+
+// CountDriftByGroup counts the differences of all results per attribute group,
+// largest group first.
+func CountDriftByGroup(results []ComparisonResult) []GroupCount {
+	var diffs []AttributeDiff
+	for _, res := range results {
+		diffs = append(diffs, res.Differences...)
+	}
+	return countGroups(diffs)
+}
+
+func countGroups(diffs []AttributeDiff) []GroupCount {
+	counts := make(map[string]int)
+	for _, diff := range diffs {
+		if diff.Group != "" {
+			counts[diff.Group]++
+		}
+	}
+	groups := make([]GroupCount, 0, len(counts))
+	for group, count := range counts {
+		groups = append(groups, GroupCount{Group: group, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Group < groups[j].Group
+	})
+	return groups
+}
+
+// FailureStreak describes consecutive failed runs for one resource.
+type FailureStreak struct {
+	Runs int
+	// Since is the start of the first run of the streak.
+	Since time.Time
+}
+
+// TimeWindow is an interval between two points in time.
+type TimeWindow struct {
+	From time.Time
+	To   time.Time
 }
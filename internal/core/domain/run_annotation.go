@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RunAnnotation is a notable event of a run that did not change any result but
+// that readers of the report should know about, such as a provider switching to
+// fallback credentials halfway through the scan.
+type RunAnnotation struct {
+	// Source names the component that raised the annotation, e.g. "aws".
+	Source  string
+	Message string
+	Time    time.Time
+}
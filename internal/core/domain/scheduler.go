@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// SchedulerStatus is a snapshot of the daemon mode scheduler, as reported by its
+// health endpoints.
+type SchedulerStatus struct {
+	// Running is true while the scheduling loop runs.
+	Running   bool
+	StartedAt time.Time
+	// Scanning is true while a scheduled scan is in progress.
+	Scanning      bool
+	ScanStartedAt time.Time
+	// LastRunAt is when the latest scan finished, successfully or not.
+	LastRunAt     time.Time
+	LastSuccessAt time.Time
+	// LastError is the error of the latest scan, empty when it succeeded.
+	LastError           string
+	ConsecutiveFailures int
+	NextScanAt          time.Time
+}
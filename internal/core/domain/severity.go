@@ -0,0 +1,39 @@
+package domain
+
+import "strings"
+
+// Severity expresses how urgently a difference needs attention.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Rank orders severities from least to most severe. Unknown or empty severities rank lowest.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (s Severity) String() string {
+	return string(s)
+}
+
+// ParseSeverity converts a case-insensitive severity name into a Severity.
+func ParseSeverity(value string) (Severity, bool) {
+	sev := Severity(strings.ToLower(strings.TrimSpace(value)))
+	if sev.Rank() == 0 {
+		return "", false
+	}
+	return sev, true
+}
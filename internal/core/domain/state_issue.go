@@ -0,0 +1,16 @@
+package domain
+
+// StateIssue is a problem found while parsing or evaluating the desired state
+// that did not stop the run, such as an undefined variable in a tfvars file or
+// a resource block that failed to evaluate and was skipped.
+type StateIssue struct {
+	Severity Severity
+	Summary  string
+	Detail   string
+	// Address is the resource address the issue belongs to, if any.
+	Address string
+	// Location is the "file:line:col" position of the offending source, if known.
+	Location string
+	// Skipped is true when the resource was left out of the analysis.
+	Skipped bool
+}
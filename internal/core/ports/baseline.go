@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+//go:generate mockery --name=BaselineStore --output=./mocks --outpkg=mocks --case underscore
+
+// BaselineStore persists the baseline of acknowledged findings between runs.
+type BaselineStore interface {
+	// Load returns the stored baseline, or nil if none has been saved yet.
+	Load(ctx context.Context) (*domain.Baseline, error)
+	Save(ctx context.Context, baseline domain.Baseline) error
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// AttributeCacheKey identifies the cached attributes of a platform resource.
+// ContentHash is a digest of what the cheap listing call returned for the
+// resource, so a resource recreated under the same ID misses the cache.
+type AttributeCacheKey struct {
+	AccountID   string
+	Region      string
+	Kind        domain.ResourceKind
+	ID          string
+	ContentHash string
+}
+
+// AttributeCache keeps the attributes of platform resources that are
+// expensive to fetch between runs. A cache never fails a run: entries that
+// cannot be read or written are treated as misses.
+type AttributeCache interface {
+	// Get returns the cached attributes of the resource, or false when they
+	// are missing or expired.
+	Get(ctx context.Context, key AttributeCacheKey) (map[string]any, bool)
+	Put(ctx context.Context, key AttributeCacheKey, attributes map[string]any)
+}
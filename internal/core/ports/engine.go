@@ -1,8 +1,34 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
 
 //go:generate mockery --name DriftAnalysisEngine --output ./mocks --outpkg mocks --case underscore
 type DriftAnalysisEngine interface {
 	Run(ctx context.Context) error
 }
+
+// KindScopedRunner runs the drift analysis for a subset of the configured kinds,
+// as needed when kinds are scanned on different schedules.
+type KindScopedRunner interface {
+	RunKinds(ctx context.Context, kinds []domain.ResourceKind) error
+}
+
+// SchedulerStatusSource reports the state of the daemon mode scheduler.
+type SchedulerStatusSource interface {
+	Status() domain.SchedulerStatus
+}
+
+// EngineEventSource lets consumers embedding the engine follow its runs, e.g.
+// to build a custom UI, metrics or persistence, without implementing a
+// Reporter. Handlers are called concurrently from the pipeline and must
+// return quickly; each subscription returns the function cancelling it.
+type EngineEventSource interface {
+	OnResourceListed(handle func(domain.ResourceListedEvent)) (unsubscribe func())
+	OnMatchCompleted(handle func(domain.MatchCompletedEvent)) (unsubscribe func())
+	OnComparisonResult(handle func(domain.ComparisonResult)) (unsubscribe func())
+	OnRunCompleted(handle func(domain.RunCompletedEvent)) (unsubscribe func())
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+//go:generate mockery --name=HistoryStore --output=./mocks --outpkg=mocks --case underscore
+
+// HistoryStore persists run records so later runs can reason about earlier ones.
+type HistoryStore interface {
+	SaveRun(ctx context.Context, run domain.RunRecord) error
+	// LatestRun returns the most recent run, or nil if no run has been stored yet.
+	LatestRun(ctx context.Context) (*domain.RunRecord, error)
+	// LatestRunOfKind returns the most recent run that analysed kind, or nil if
+	// no stored run did. Runs limited to other kinds are skipped.
+	LatestRunOfKind(ctx context.Context, kind domain.ResourceKind) (*domain.RunRecord, error)
+}
+
+// HistoryReader is implemented by history stores that can return every stored
+// run, for queries across runs such as the drift history of a resource.
+type HistoryReader interface {
+	// Runs returns the runs started at or after since, oldest first. A zero
+	// since returns every run.
+	Runs(ctx context.Context, since time.Time) ([]domain.RunRecord, error)
+}
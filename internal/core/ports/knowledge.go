@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// DiffClassifier recognizes differences caused by changes the platform makes on
+// its own, such as certificate renewals or autoscaling, so that they are
+// reported as platform-managed instead of actionable drift.
+type DiffClassifier interface {
+	// PlatformManaged returns why the difference is platform-managed, or an
+	// empty string when it is actionable drift.
+	PlatformManaged(kind domain.ResourceKind, diff domain.AttributeDiff) string
+}
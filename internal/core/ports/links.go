@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+//go:generate mockery --name=LinkBuilder --output=./mocks --outpkg=mocks --case underscore
+
+// LinkBuilder produces deep links for a finding from the metadata of its desired
+// and actual resources. Either metadata may be empty for unmatched resources.
+type LinkBuilder interface {
+	Links(kind domain.ResourceKind, desired, actual domain.ResourceMetadata) []domain.ResourceLink
+}
@@ -8,15 +8,75 @@ import (
 type MatchedPair struct {
 	Desired domain.StateResource
 	Actual  domain.PlatformResource
+	// Strategy names the matching strategy that paired the resources and
+	// Confidence, from 0 to 1, how sure it is of the pairing. Both are unset
+	// by matchers that pair resources by a single exact key.
+	Strategy   string
+	Confidence float64
 }
 
 type MatchingResult struct {
 	Matched          []MatchedPair
 	UnmatchedDesired []domain.StateResource    // Only in state
 	UnmatchedActual  []domain.PlatformResource // Only on platform (unmanaged)
+	// Ambiguous holds the resources that match more than one resource on the
+	// other side. They are neither matched nor unmatched.
+	Ambiguous []AmbiguousMatch
+}
+
+// AmbiguousMatch is a group of desired and actual resources that all match
+// one another, with more than one resource on at least one side, so no pair
+// can be picked.
+type AmbiguousMatch struct {
+	Desired []domain.StateResource
+	Actual  []domain.PlatformResource
+	// Strategy names the matching strategy that found the conflict, when the
+	// matcher has several.
+	Strategy string
 }
 
 //go:generate mockery --name=Matcher --output=./mocks --outpkg=mocks --case underscore
 type Matcher interface {
 	Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) (MatchingResult, error)
 }
+
+// MatchStrategy is one way of pairing desired and actual resources. A
+// strategy matcher tries its strategies in priority order, each on the
+// resources the strategies before it left unmatched, so custom strategies can
+// be combined with the built-in ones.
+type MatchStrategy interface {
+	// Name identifies the strategy in configuration and in matching results.
+	Name() string
+	// Match returns the pairs the strategy is confident about, with their
+	// confidence, and the groups of resources it found matching more than one
+	// resource on the other side. Each resource may appear in at most one pair
+	// or group.
+	Match(ctx context.Context, desired []domain.StateResource, actual []domain.PlatformResource) ([]MatchedPair, []AmbiguousMatch, error)
+}
+
+// StreamingMatcher is implemented by matchers that can match resources
+// incrementally, so that the engine does not have to hold every listed actual
+// resource in memory before matching.
+//
+//go:generate mockery --name=StreamingMatcher --output=./mocks --outpkg=mocks --case underscore
+type StreamingMatcher interface {
+	Matcher
+	// NewIndex returns an empty index of desired resources for one run.
+	NewIndex() MatchIndex
+}
+
+// MatchIndex indexes desired resources by their identity key and matches actual
+// resources against them one at a time. It is used from a single goroutine.
+//
+//go:generate mockery --name=MatchIndex --output=./mocks --outpkg=mocks --case underscore
+type MatchIndex interface {
+	// AddDesired indexes a desired resource. Resources without an identity key
+	// are not indexed and are returned by Unmatched.
+	AddDesired(ctx context.Context, res domain.StateResource)
+	// MatchActual returns the indexed desired resource the actual resource
+	// matches. Each desired resource is matched at most once.
+	MatchActual(ctx context.Context, res domain.PlatformResource) (domain.StateResource, bool)
+	// Unmatched returns the desired resources no actual resource matched, in
+	// the order they were added.
+	Unmatched() []domain.StateResource
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// ScanMetrics records the outcome of every scan, so monitoring systems can
+// alert on drift and on failing scans.
+type ScanMetrics interface {
+	// ObserveScan records a scan of the kinds that took duration. results are
+	// the findings of the scan, before any baseline suppression; err is nil for
+	// a successful scan.
+	ObserveScan(kinds []domain.ResourceKind, duration time.Duration, results []domain.ComparisonResult, err error)
+}
+
+// APICallMetrics records the calls a platform provider makes to its cloud API.
+type APICallMetrics interface {
+	// ObserveAPICall records one API operation that took attempts attempts,
+	// throttles of which were throttled. failed reports whether the operation
+	// failed after its last attempt.
+	ObserveAPICall(service, operation string, attempts, throttles int, failed bool)
+}
@@ -44,6 +44,36 @@ func (_m *PlatformProvider) GetResource(ctx context.Context, kind domain.Resourc
 	return r0, r1
 }
 
+// GetResources provides a mock function with given fields: ctx, kind, ids
+func (_m *PlatformProvider) GetResources(ctx context.Context, kind domain.ResourceKind, ids []string) (map[string]domain.PlatformResource, error) {
+	ret := _m.Called(ctx, kind, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetResources")
+	}
+
+	var r0 map[string]domain.PlatformResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ResourceKind, []string) (map[string]domain.PlatformResource, error)); ok {
+		return rf(ctx, kind, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ResourceKind, []string) map[string]domain.PlatformResource); ok {
+		r0 = rf(ctx, kind, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.PlatformResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ResourceKind, []string) error); ok {
+		r1 = rf(ctx, kind, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListResources provides a mock function with given fields: ctx, requestedKinds, filters, out
 func (_m *PlatformProvider) ListResources(ctx context.Context, requestedKinds []domain.ResourceKind, filters map[string]string, out chan<- domain.PlatformResource) error {
 	ret := _m.Called(ctx, requestedKinds, filters, out)
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// Notifier tells people or systems about the results of a run after the
+// report is written, e.g. by posting a summary to a chat channel. A notifier
+// decides on its own whether the results are worth a notification.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, results []domain.ComparisonResult) error
+}
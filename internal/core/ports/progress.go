@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/olusolaa/infra-drift-detector/internal/core/domain"
+
+// ProgressObserver follows a run as it progresses, e.g. to show a progress
+// view. Progress is called concurrently from the pipeline stages and must not
+// block.
+type ProgressObserver interface {
+	Progress(event domain.ProgressEvent)
+}
@@ -10,6 +10,10 @@ type PlatformProvider interface {
 	Type() string
 	ListResources(ctx context.Context, requestedKinds []domain.ResourceKind, filters map[string]string, out chan<- domain.PlatformResource) error
 	GetResource(ctx context.Context, kind domain.ResourceKind, id string) (domain.PlatformResource, error)
+	// GetResources fetches several resources of one kind, batching the API
+	// calls where the platform allows it. The result is keyed by ID; IDs that
+	// do not exist on the platform are left out.
+	GetResources(ctx context.Context, kind domain.ResourceKind, ids []string) (map[string]domain.PlatformResource, error)
 }
 
 //go:generate mockery --name=StateProvider --output=./mocks --outpkg=mocks --case underscore
@@ -18,3 +22,58 @@ type StateProvider interface {
 	ListResources(ctx context.Context, kind domain.ResourceKind) ([]domain.StateResource, error)
 	GetResource(ctx context.Context, kind domain.ResourceKind, identifier string) (domain.StateResource, error)
 }
+
+// StateIssueSource is implemented by state providers that collect non-fatal
+// parse and evaluation issues instead of failing the listing.
+type StateIssueSource interface {
+	StateIssues() []domain.StateIssue
+}
+
+// RunAnnotationSource is implemented by providers that record notable events of
+// a run, such as failing over to fallback credentials.
+type RunAnnotationSource interface {
+	RunAnnotations() []domain.RunAnnotation
+}
+
+// ImageApprovalSource is an optional desired-state source listing the machine
+// images instances are allowed to run, such as a golden AMI manifest.
+// ApprovedImages returns false when the source has no entry covering an
+// instance with the given region and tags.
+type ImageApprovalSource interface {
+	Type() string
+	ApprovedImages(ctx context.Context, region string, tags map[string]string) ([]string, bool, error)
+}
+
+// StateRefresher is implemented by state providers that cache the desired
+// state. Refresh makes the next listing pick up changes to the state, so that
+// every scan of a long-running process sees the current state.
+type StateRefresher interface {
+	Refresh(ctx context.Context)
+}
+
+// SelfTester is implemented by providers that can verify connectivity and
+// permissions for the requested kinds with a few cheap calls before a run.
+type SelfTester interface {
+	SelfTest(ctx context.Context, kinds []domain.ResourceKind) error
+}
+
+// APICallEstimate is the number of platform API calls a scan of one kind is
+// expected to make.
+type APICallEstimate struct {
+	// ListCalls are the paginated calls listing the resources.
+	ListCalls int
+	// DetailCalls are the calls fetching attributes resource by resource.
+	DetailCalls int
+	// Modeled is false when the provider has no call model for the kind and
+	// assumed one list call per page and one detail call per resource.
+	Modeled bool
+}
+
+// APICallEstimator is implemented by platform providers that can estimate the
+// API calls of a scan without making any, used to plan a scan before running
+// it.
+type APICallEstimator interface {
+	EstimateAPICalls(kind domain.ResourceKind, resources int) APICallEstimate
+	// RequestsPerSecond is the rate the provider limits its API calls to.
+	RequestsPerSecond() int
+}
@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"io"
+
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 )
 
@@ -9,3 +11,21 @@ import (
 type Reporter interface {
 	Report(ctx context.Context, results []domain.ComparisonResult) error
 }
+
+// StateIssueReporter is implemented by reporters that render a "state source
+// issues" section. The engine hands over the issues before calling Report.
+type StateIssueReporter interface {
+	SetStateIssues(issues []domain.StateIssue)
+}
+
+// RunAnnotationReporter is implemented by reporters that render the run
+// annotations next to the summary. The engine hands them over before calling Report.
+type RunAnnotationReporter interface {
+	SetRunAnnotations(annotations []domain.RunAnnotation)
+}
+
+// WriterReporter is implemented by reporters whose output can be redirected
+// from stdout, e.g. to write a report split across several files.
+type WriterReporter interface {
+	SetWriter(w io.Writer)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// WithBaseline suppresses the findings acknowledged in the store's baseline so
+// that only drift found since then is reported. With update set, the run's
+// findings are saved as the new baseline instead and nothing is suppressed.
+func WithBaseline(store ports.BaselineStore, update bool) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if store != nil {
+			e.baselineStore = store
+			e.updateBaseline = update
+		}
+	}
+}
+
+// applyBaseline returns the results to report for this run. The results
+// recorded in the history store are left as they are.
+func (e *DriftAnalysisEngine) applyBaseline(ctx context.Context, now time.Time, results []domain.ComparisonResult) []domain.ComparisonResult {
+	if e.baselineStore == nil {
+		return results
+	}
+	if e.updateBaseline {
+		baseline := domain.NewBaseline(now, results)
+		if err := e.baselineStore.Save(ctx, baseline); err != nil {
+			e.logger.Errorf(ctx, err, "Failed to save baseline")
+			return results
+		}
+		e.logger.Infof(ctx, "Saved baseline with %d acknowledged findings", len(baseline.Findings))
+		return results
+	}
+
+	baseline, err := e.baselineStore.Load(ctx)
+	if err != nil {
+		e.logger.Warnf(ctx, "Failed to load baseline, reporting all findings: %v", err)
+		return results
+	}
+	if baseline == nil {
+		e.logger.Debugf(ctx, "No baseline saved yet, reporting all findings")
+		return results
+	}
+	remaining, suppressed := baseline.Suppress(results)
+	e.logger.Infof(ctx, "Suppressed %d findings acknowledged in the baseline from %s", suppressed, baseline.CreatedAt.Format(time.RFC3339))
+	return remaining
+}
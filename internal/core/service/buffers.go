@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+const defaultChannelBufferSize = 100
+
+// streamingMatchBatchSize is the number of actual resources matched before the
+// streaming matcher hands the batch on for comparison.
+const streamingMatchBatchSize = 100
+
+// ChannelBufferSizes bounds the buffers between pipeline stages. Larger buffers
+// let fast stages run further ahead at the cost of holding more resources in
+// memory; zero selects the default size.
+type ChannelBufferSizes struct {
+	// Desired buffers resources listed from the state provider.
+	Desired int
+	// Actual buffers resources listed from the platform provider.
+	Actual int
+	// Compare buffers matched pairs waiting for a comparison worker.
+	Compare int
+	// Results buffers comparison results waiting to be aggregated.
+	Results int
+}
+
+func (s ChannelBufferSizes) withDefaults() ChannelBufferSizes {
+	for _, size := range []*int{&s.Desired, &s.Actual, &s.Compare, &s.Results} {
+		if *size <= 0 {
+			*size = defaultChannelBufferSize
+		}
+	}
+	return s
+}
+
+// bufferMeter records backpressure on one pipeline buffer.
+type bufferMeter struct {
+	mu    sync.Mutex
+	stats domain.BufferStats
+}
+
+func newBufferMeter(name string, capacity int) *bufferMeter {
+	return &bufferMeter{stats: domain.BufferStats{
+		Name:              name,
+		Capacity:          capacity,
+		BlockedTimeByKind: make(map[domain.ResourceKind]time.Duration),
+	}}
+}
+
+func (m *bufferMeter) record(kind domain.ResourceKind, queued int, sent, blocked bool, waited time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sent {
+		m.stats.Sent++
+	}
+	if queued > m.stats.PeakLength {
+		m.stats.PeakLength = queued
+	}
+	if blocked {
+		m.stats.BlockedSends++
+		m.stats.BlockedTime += waited
+		m.stats.BlockedTimeByKind[kind] += waited
+	}
+}
+
+func (m *bufferMeter) snapshot() domain.BufferStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := m.stats
+	out.BlockedTimeByKind = make(map[domain.ResourceKind]time.Duration, len(m.stats.BlockedTimeByKind))
+	for k, v := range m.stats.BlockedTimeByKind {
+		out.BlockedTimeByKind[k] = v
+	}
+	return out
+}
+
+// sendMetered sends item on ch, recording whether the send had to wait for
+// buffer capacity and for how long. It returns the context error if the run is
+// cancelled while waiting.
+func sendMetered[T any](ctx context.Context, ch chan<- T, item T, kind domain.ResourceKind, meter *bufferMeter) error {
+	select {
+	case ch <- item:
+		meter.record(kind, len(ch), true, false, 0)
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	select {
+	case ch <- item:
+		meter.record(kind, len(ch), true, true, time.Since(start))
+		return nil
+	case <-ctx.Done():
+		meter.record(kind, len(ch), false, true, time.Since(start))
+		return ctx.Err()
+	}
+}
+
+// pipelineMeters groups the meters of the buffers of a single run.
+type pipelineMeters struct {
+	desired, actual, compare, results *bufferMeter
+}
+
+func newPipelineMeters(sizes ChannelBufferSizes) *pipelineMeters {
+	return &pipelineMeters{
+		desired: newBufferMeter("desired", sizes.Desired),
+		actual:  newBufferMeter("actual", sizes.Actual),
+		compare: newBufferMeter("compare", sizes.Compare),
+		results: newBufferMeter("results", sizes.Results),
+	}
+}
+
+func (p *pipelineMeters) snapshot() []domain.BufferStats {
+	return []domain.BufferStats{
+		p.desired.snapshot(),
+		p.actual.snapshot(),
+		p.compare.snapshot(),
+		p.results.snapshot(),
+	}
+}
+
+// PipelineStats returns the buffer backpressure statistics of the last run.
+func (e *DriftAnalysisEngine) PipelineStats() []domain.BufferStats {
+	e.statsMu.Lock()
+	defer e.statsMu.Unlock()
+	return e.pipelineStats
+}
+
+// recordPipelineStats stores the statistics of the finished run and logs them,
+// highlighting the buffers whose producers had to wait.
+func (e *DriftAnalysisEngine) recordPipelineStats(ctx context.Context, meters *pipelineMeters) {
+	stats := meters.snapshot()
+	e.statsMu.Lock()
+	e.pipelineStats = stats
+	e.statsMu.Unlock()
+
+	for _, s := range stats {
+		if s.BlockedSends == 0 {
+			e.logger.Debugf(ctx, "[Pipeline] %s buffer: capacity %d, peak %d, %d sends, no backpressure",
+				s.Name, s.Capacity, s.PeakLength, s.Sent)
+			continue
+		}
+		e.logger.Infof(ctx, "[Pipeline] %s buffer: capacity %d, peak %d, %d of %d sends blocked for %s (%s)",
+			s.Name, s.Capacity, s.PeakLength, s.BlockedSends, s.Sent, s.BlockedTime.Round(time.Millisecond), formatBlockedByKind(s.BlockedTimeByKind))
+	}
+}
+
+func formatBlockedByKind(byKind map[domain.ResourceKind]time.Duration) string {
+	kinds := make([]domain.ResourceKind, 0, len(byKind))
+	for k := range byKind {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return byKind[kinds[i]] > byKind[kinds[j]] })
+	parts := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		parts = append(parts, string(k)+"="+byKind[k].Round(time.Millisecond).String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// compareQueue holds the matched pairs waiting for a comparison worker. Pairs
+// are handed out highest kind priority first, in the order they were pushed
+// within a priority, whether they were matched in one batch or streamed in
+// several. A pair whose kind already runs its KindConcurrency limit of
+// comparisons is skipped until a slot frees up, so an idle worker picks up
+// another kind rather than waiting for the capped one.
+type compareQueue struct {
+	mu       sync.Mutex
+	pending  []queuedPair
+	running  map[domain.ResourceKind]int
+	limits   map[domain.ResourceKind]int
+	capacity int
+	closed   bool
+	// changed is closed and replaced whenever a pair is pushed or taken, a
+	// slot is released or the queue is closed, waking the waiting callers.
+	changed chan struct{}
+	meter   *bufferMeter
+}
+
+type queuedPair struct {
+	pair     ports.MatchedPair
+	kind     domain.ResourceKind
+	priority int
+}
+
+func newCompareQueue(capacity int, limits map[domain.ResourceKind]int, meter *bufferMeter) *compareQueue {
+	return &compareQueue{
+		running:  make(map[domain.ResourceKind]int),
+		limits:   limits,
+		capacity: capacity,
+		changed:  make(chan struct{}),
+		meter:    meter,
+	}
+}
+
+// push queues a pair behind the pairs of the same or a higher priority,
+// waiting while the queue is full. It returns the context error if the run is
+// cancelled while waiting.
+func (q *compareQueue) push(ctx context.Context, pair ports.MatchedPair, priority int) error {
+	kind := pair.Desired.Metadata().Kind
+	var start time.Time
+	for {
+		q.mu.Lock()
+		if len(q.pending) < q.capacity {
+			at := len(q.pending)
+			for at > 0 && q.pending[at-1].priority < priority {
+				at--
+			}
+			q.pending = append(q.pending, queuedPair{})
+			copy(q.pending[at+1:], q.pending[at:])
+			q.pending[at] = queuedPair{pair: pair, kind: kind, priority: priority}
+			q.meter.record(kind, len(q.pending), true, !start.IsZero(), waitedSince(start))
+			q.signalLocked()
+			q.mu.Unlock()
+			return nil
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		wait := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			q.meter.record(kind, q.len(), false, true, time.Since(start))
+			return ctx.Err()
+		}
+	}
+}
+
+// next waits for a pair whose kind is below its concurrency limit and takes
+// the kind's slot, returning the function releasing it. It returns false once
+// the queue is closed and drained, or when the context is cancelled.
+func (q *compareQueue) next(ctx context.Context) (ports.MatchedPair, func(), bool) {
+	for {
+		q.mu.Lock()
+		for i, queued := range q.pending {
+			limit, capped := q.limits[queued.kind]
+			if capped && q.running[queued.kind] >= limit {
+				continue
+			}
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			q.running[queued.kind]++
+			q.signalLocked()
+			q.mu.Unlock()
+			return queued.pair, q.releaser(queued.kind), true
+		}
+		if q.closed && len(q.pending) == 0 {
+			q.mu.Unlock()
+			return ports.MatchedPair{}, nil, false
+		}
+		wait := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ports.MatchedPair{}, nil, false
+		}
+	}
+}
+
+// close marks the end of the pairs; next drains the pairs still queued.
+func (q *compareQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.signalLocked()
+}
+
+func (q *compareQueue) releaser(kind domain.ResourceKind) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.running[kind]--
+			q.signalLocked()
+		})
+	}
+}
+
+func (q *compareQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+func (q *compareQueue) signalLocked() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func waitedSince(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+func TestCompareQueue_HandsOutHighestPriorityFirstAcrossPushes(t *testing.T) {
+	q := newCompareQueue(10, nil, newBufferMeter("compare", 10))
+	ctx := context.Background()
+	pair := func(kind domain.ResourceKind, id string) ports.MatchedPair {
+		return ports.MatchedPair{Desired: stateResource{domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id}}}
+	}
+
+	// The pairs of a later streamed batch are compared before the waiting
+	// pairs of lower priority kinds from earlier batches.
+	require.NoError(t, q.push(ctx, pair(domain.KindComputeInstance, "i-1"), 10))
+	require.NoError(t, q.push(ctx, pair(domain.KindComputeInstance, "i-2"), 10))
+	require.NoError(t, q.push(ctx, pair(domain.KindIAMRole, "role-1"), 90))
+	require.NoError(t, q.push(ctx, pair(domain.KindStorageBucket, "bucket-1"), 50))
+	require.NoError(t, q.push(ctx, pair(domain.KindIAMRole, "role-2"), 90))
+	q.close()
+
+	var order []string
+	for {
+		p, release, ok := q.next(ctx)
+		if !ok {
+			break
+		}
+		order = append(order, p.Desired.Metadata().ProviderAssignedID)
+		release()
+	}
+	assert.Equal(t, []string{"role-1", "role-2", "bucket-1", "i-1", "i-2"}, order)
+}
+
+func TestCompareQueue_SkipsSaturatedKind(t *testing.T) {
+	q := newCompareQueue(10, map[domain.ResourceKind]int{domain.KindStorageBucket: 1}, newBufferMeter("compare", 10))
+	ctx := context.Background()
+	pair := func(kind domain.ResourceKind, id string) ports.MatchedPair {
+		return ports.MatchedPair{Desired: stateResource{domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id}}}
+	}
+	require.NoError(t, q.push(ctx, pair(domain.KindStorageBucket, "bucket-1"), 50))
+	require.NoError(t, q.push(ctx, pair(domain.KindStorageBucket, "bucket-2"), 50))
+	require.NoError(t, q.push(ctx, pair(domain.KindComputeInstance, "i-1"), 10))
+
+	first, releaseFirst, ok := q.next(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "bucket-1", first.Desired.Metadata().ProviderAssignedID)
+
+	second, releaseSecond, ok := q.next(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "i-1", second.Desired.Metadata().ProviderAssignedID, "the second bucket waits for the slot of the first")
+	releaseSecond()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, _, ok = q.next(waitCtx)
+	assert.False(t, ok, "no pair is handed out while the only queued kind is saturated")
+
+	releaseFirst()
+	third, _, ok := q.next(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "bucket-2", third.Desired.Metadata().ProviderAssignedID)
+}
+
+func TestCompareQueue_PushWaitsForCapacity(t *testing.T) {
+	q := newCompareQueue(1, nil, newBufferMeter("compare", 1))
+	ctx := context.Background()
+	pair := ports.MatchedPair{Desired: stateResource{domain.ResourceMetadata{Kind: domain.KindComputeInstance}}}
+	require.NoError(t, q.push(ctx, pair, 10))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.push(waitCtx, pair, 10), context.DeadlineExceeded)
+
+	pushed := make(chan error, 1)
+	go func() { pushed <- q.push(ctx, pair, 10) }()
+	_, release, ok := q.next(ctx)
+	require.True(t, ok)
+	release()
+	require.NoError(t, <-pushed)
+
+	stats := q.meter.snapshot()
+	assert.Equal(t, int64(2), stats.Sent)
+	assert.GreaterOrEqual(t, stats.BlockedSends, int64(1), "the cancelled push found the queue full")
+}
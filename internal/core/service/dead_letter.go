@@ -0,0 +1,57 @@
+package service
+
+import (
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// defaultDeadLetterThreshold is the number of consecutive failed runs after
+// which a resource is moved to the dead-letter list.
+const defaultDeadLetterThreshold = 3
+
+// applyDeadLetters records the failure streak of every failed result, continuing
+// the streak of the same resource in the previous run that analysed its kind,
+// and rewrites ERROR results whose streak reached the threshold to
+// DEAD_LETTERED. A resource leaves the dead-letter list as soon as its
+// comparison succeeds again. It returns the number of dead-lettered results.
+func applyDeadLetters(previous map[domain.ResourceKind]*domain.RunRecord, results []domain.ComparisonResult, now time.Time, threshold int) int {
+	type key struct {
+		kind   domain.ResourceKind
+		source string
+	}
+	prior := make(map[key]domain.ComparisonResult)
+	for kind, run := range previous {
+		for _, res := range run.Results {
+			if res.ResourceKind != kind || res.SourceIdentifier == "" {
+				continue
+			}
+			if res.Status == domain.StatusError || res.Status == domain.StatusDeadLettered {
+				prior[key{res.ResourceKind, res.SourceIdentifier}] = res
+			}
+		}
+	}
+
+	dead := 0
+	for i := range results {
+		res := &results[i]
+		if res.Status != domain.StatusError {
+			continue
+		}
+		streak := &domain.FailureStreak{Runs: 1, Since: now}
+		if prev, ok := prior[key{res.ResourceKind, res.SourceIdentifier}]; ok && res.SourceIdentifier != "" {
+			if prev.FailureStreak != nil {
+				streak = &domain.FailureStreak{Runs: prev.FailureStreak.Runs + 1, Since: prev.FailureStreak.Since}
+			} else {
+				// Recorded before streaks were tracked.
+				streak = &domain.FailureStreak{Runs: 2, Since: previous[res.ResourceKind].StartedAt}
+			}
+		}
+		res.FailureStreak = streak
+		if streak.Runs >= threshold {
+			res.Status = domain.StatusDeadLettered
+			dead++
+		}
+	}
+	return dead
+}
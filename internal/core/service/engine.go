@@ -2,11 +2,17 @@ package service
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/filter"
+	"github.com/olusolaa/infra-drift-detector/internal/transform"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -16,6 +22,64 @@ type EngineRunConfig struct {
 	ResourceKindsToProcess []domain.ResourceKind
 	AttributesToCheck      map[domain.ResourceKind][]string
 	Concurrency            int
+	// KindConcurrency caps how many comparisons of a kind run at once, below
+	// Concurrency, so that kinds needing many API calls per resource (e.g. S3
+	// buckets) can be throttled without slowing down cheap kinds.
+	KindConcurrency map[domain.ResourceKind]int
+	// AttributeParallelism is how many attributes of a single resource of the
+	// kind are compared at once, for kinds with many attributes and expensive
+	// comparers such as policies or lifecycle rules.
+	AttributeParallelism map[domain.ResourceKind]int
+	// Transforms holds the per-kind attribute transformation pipelines applied
+	// to desired and actual resources before comparison.
+	Transforms map[domain.ResourceKind]*transform.Pipeline
+	// Normalizations holds the per-kind string value normalizations, keyed by
+	// attribute, honored by the default attribute comparison.
+	Normalizations map[domain.ResourceKind]map[string]domain.ValueNormalization
+	// KindPriorities ranks kinds so that higher priority kinds are compared and
+	// reported first. Kinds without an entry fall back to their built-in priority.
+	KindPriorities map[domain.ResourceKind]int
+	// TombstoneGracePeriod is how long a resource that disappeared since the
+	// previous run is reported as recently deleted instead of missing.
+	TombstoneGracePeriod time.Duration
+	// AttributeGroups maps attribute names to the report group they belong to.
+	// When set, differences in attributes outside every group are assigned to
+	// domain.UngroupedAttributes.
+	AttributeGroups map[string]string
+	// DeadLetterThreshold is the number of consecutive failed runs after which
+	// a resource is moved to the dead-letter list.
+	DeadLetterThreshold int
+	// StrictStateParsing fails the run on any state parse or evaluation issue
+	// instead of reporting it in the state source issues section.
+	StrictStateParsing bool
+	// ChannelBuffers bounds the buffers between pipeline stages.
+	ChannelBuffers ChannelBufferSizes
+	// Explain records the normalization steps and decision path of every
+	// comparison and attaches them to the findings.
+	Explain bool
+	// SkipSelfTest disables the provider connectivity and permission checks
+	// that run before listing starts.
+	SkipSelfTest bool
+	// StreamingMatch matches actual resources against an index of the desired
+	// resources as they are listed instead of collecting both sides first, so
+	// memory no longer grows with the number of actual resources. Matched pairs
+	// are prioritized by kind among the pairs waiting for a worker, rather than
+	// across all pairs of the run. It requires a matcher implementing
+	// ports.StreamingMatcher.
+	StreamingMatch bool
+	// IgnorePlatformDefaults leaves out of the unmanaged results the resources
+	// the platform created on its own, such as default VPCs, default security
+	// groups and service-linked roles, which no one is expected to manage.
+	IgnorePlatformDefaults bool
+	// Filter scopes the run to part of the infrastructure. It is applied to
+	// desired and actual resources as they are listed, and the part of it the
+	// platform can apply is passed to the platform provider. Nil keeps every
+	// resource.
+	Filter *filter.Filter
+	// UnknownTolerant leaves out of the comparison the desired attributes
+	// whose values are only known after apply, listing them as not asserted
+	// in the result instead of reporting them as drift.
+	UnknownTolerant bool
 }
 
 // DriftAnalysisEngine orchestrates the drift detection process.
@@ -29,6 +93,53 @@ type DriftAnalysisEngine struct {
 	runConfig        EngineRunConfig
 	stateProvider    ports.StateProvider
 	platformProvider ports.PlatformProvider
+	linkBuilder      ports.LinkBuilder
+	historyStore     ports.HistoryStore
+	scanMetrics      ports.ScanMetrics
+	notifiers        []ports.Notifier
+	baselineStore    ports.BaselineStore
+	updateBaseline   bool
+	imageSource      ports.ImageApprovalSource
+	diffClassifier   ports.DiffClassifier
+	tracer           trace.Tracer
+	progressObserver ports.ProgressObserver
+	events           engineEvents
+	meters           *pipelineMeters
+	kindLimits       map[domain.ResourceKind]int
+	statsMu          sync.Mutex
+	pipelineStats    []domain.BufferStats
+}
+
+// EngineOption configures optional engine dependencies.
+type EngineOption func(*DriftAnalysisEngine)
+
+// WithLinkBuilder attaches deep links produced by the given builder to every finding.
+func WithLinkBuilder(builder ports.LinkBuilder) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if builder != nil {
+			e.linkBuilder = builder
+		}
+	}
+}
+
+// WithHistoryStore records every run in the given store and uses the previous run
+// of each kind to detect recently deleted resources.
+func WithHistoryStore(store ports.HistoryStore) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if store != nil {
+			e.historyStore = store
+		}
+	}
+}
+
+// WithDiffClassifier reports differences the classifier recognizes as caused
+// by the platform itself as platform-managed, with info severity.
+func WithDiffClassifier(classifier ports.DiffClassifier) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if classifier != nil {
+			e.diffClassifier = classifier
+		}
+	}
 }
 
 // NewDriftAnalysisEngine creates a new engine instance, injecting dependencies.
@@ -40,11 +151,19 @@ func NewDriftAnalysisEngine(
 	runConfig EngineRunConfig,
 	stateProvider ports.StateProvider,
 	platformProvider ports.PlatformProvider,
+	opts ...EngineOption,
 ) (*DriftAnalysisEngine, error) {
 	// Apply default concurrency if not set or invalid
 	if runConfig.Concurrency <= 0 {
 		runConfig.Concurrency = 10
 	}
+	if runConfig.TombstoneGracePeriod <= 0 {
+		runConfig.TombstoneGracePeriod = defaultTombstoneGracePeriod
+	}
+	if runConfig.DeadLetterThreshold <= 0 {
+		runConfig.DeadLetterThreshold = defaultDeadLetterThreshold
+	}
+	runConfig.ChannelBuffers = runConfig.ChannelBuffers.withDefaults()
 	// Validate essential dependencies
 	if stateProvider == nil {
 		return nil, errors.New(errors.CodeConfigValidation, "state provider cannot be nil")
@@ -56,7 +175,7 @@ func NewDriftAnalysisEngine(
 		return nil, errors.New(errors.CodeConfigValidation, "no resource kinds specified for processing")
 	}
 
-	return &DriftAnalysisEngine{
+	e := &DriftAnalysisEngine{
 		registry:         registry,
 		matcher:          matcher,
 		reporter:         reporter,
@@ -64,45 +183,95 @@ func NewDriftAnalysisEngine(
 		runConfig:        runConfig,
 		stateProvider:    stateProvider,
 		platformProvider: platformProvider,
-	}, nil
+		tracer:           noopTracer,
+		kindLimits:       make(map[domain.ResourceKind]int),
+	}
+	for kind, limit := range runConfig.KindConcurrency {
+		if limit > 0 && limit < runConfig.Concurrency {
+			e.kindLimits[kind] = limit
+		}
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e, nil
 }
 
-// Run executes the multi-stage drift analysis workflow concurrently.
-// It sets up a pipeline using channels and manages goroutines with an errgroup.
+// Run executes the multi-stage drift analysis workflow concurrently for all
+// configured kinds.
 func (e *DriftAnalysisEngine) Run(ctx context.Context) error {
-	e.logger.Infof(ctx, "Starting drift analysis run using %s state and %s platform providers",
-		e.stateProvider.Type(), e.platformProvider.Type())
+	return e.RunKinds(ctx, e.runConfig.ResourceKindsToProcess)
+}
+
+// RunKinds executes the drift analysis workflow for the given subset of the
+// configured kinds. It sets up a pipeline using channels and manages goroutines
+// with an errgroup.
+func (e *DriftAnalysisEngine) RunKinds(ctx context.Context, kinds []domain.ResourceKind) (err error) {
+	if len(kinds) == 0 {
+		return errors.New(errors.CodeConfigValidation, "no resource kinds specified for processing")
+	}
+	startedAt := time.Now()
+	var finalResults []domain.ComparisonResult
+	defer func() {
+		e.observeScan(kinds, startedAt, finalResults, err)
+		e.events.runCompleted.publish(domain.RunCompletedEvent{
+			Kinds:     kinds,
+			StartedAt: startedAt,
+			Duration:  time.Since(startedAt),
+			Results:   finalResults,
+			Err:       err,
+		})
+	}()
+	ctx, span := e.startSpan(ctx, "drift.run", attrKinds.StringSlice(kindNames(kinds)))
+	defer func() {
+		span.SetAttributes(attrResults.Int(len(finalResults)))
+		endSpan(span, err)
+	}()
+	e.logger.Infof(ctx, "Starting drift analysis run for %d kind(s) using %s state and %s platform providers",
+		len(kinds), e.stateProvider.Type(), e.platformProvider.Type())
+
+	if refresher, ok := e.stateProvider.(ports.StateRefresher); ok {
+		refresher.Refresh(ctx)
+	}
+	if err := e.runSelfTest(ctx, kinds); err != nil {
+		return err
+	}
+
+	e.progress(domain.ProgressEvent{Stage: domain.ProgressRunStarted, Kinds: kinds})
 
 	// --- Setup Workflow Channels ---
-	desiredChan := make(chan domain.StateResource, 100)
-	actualChan := make(chan domain.PlatformResource, 100)
+	buffers := e.runConfig.ChannelBuffers
+	e.meters = newPipelineMeters(buffers)
+	defer e.recordPipelineStats(ctx, e.meters)
+	desiredChan := make(chan domain.StateResource, buffers.Desired)
+	actualChan := make(chan domain.PlatformResource, buffers.Actual)
 	matchResultChan := make(chan ports.MatchingResult, 1) // Only one result expected
-	compareInputChan := make(chan ports.MatchedPair, 100)
-	comparisonResultChan := make(chan domain.ComparisonResult, 100)
+	compareQueue := newCompareQueue(buffers.Compare, e.kindLimits, e.meters.compare)
+	comparisonResultChan := make(chan domain.ComparisonResult, buffers.Results)
 
 	// --- Setup Concurrency Management ---
 	g, childCtx := errgroup.WithContext(ctx) // Use errgroup for context cancellation propagation
-	var finalResults []domain.ComparisonResult
+
 	var finalResultsMutex sync.Mutex // Protect concurrent writes to finalResults
 
 	// --- Launch Workflow Stages as Goroutines ---
 
 	// Stage 1a: List desired resources. Reads from state provider, sends to desiredChan.
-	g.Go(func() error { return e.stageListDesired(childCtx, desiredChan) })
+	g.Go(func() error { return e.stageListDesired(childCtx, kinds, desiredChan) })
 
 	// Stage 1b: List actual resources. Reads from platform provider, sends to actualChan.
-	g.Go(func() error { return e.stageListActual(childCtx, actualChan) })
+	g.Go(func() error { return e.stageListActual(childCtx, kinds, actualChan) })
 
 	// Stage 2: Match resources. Collects from desiredChan & actualChan, sends results to matchResultChan.
 	g.Go(func() error { return e.stageMatchResources(childCtx, desiredChan, actualChan, matchResultChan) })
 
-	// Stage 3: Dispatch comparisons. Reads match results, processes unmatched, sends matched pairs to compareInputChan.
+	// Stage 3: Dispatch comparisons. Reads match results, processes unmatched, queues matched pairs in compareQueue.
 	g.Go(func() error {
-		return e.stageDispatchComparisons(childCtx, matchResultChan, compareInputChan, &finalResults, &finalResultsMutex)
+		return e.stageDispatchComparisons(childCtx, matchResultChan, compareQueue, &finalResults, &finalResultsMutex)
 	})
 
-	// Stage 4: Compare resources. Launches worker pool reading from compareInputChan, sends results to comparisonResultChan.
-	g.Go(func() error { return e.stageCompareResources(childCtx, compareInputChan, comparisonResultChan) })
+	// Stage 4: Compare resources. Launches worker pool taking pairs from compareQueue, sends results to comparisonResultChan.
+	g.Go(func() error { return e.stageCompareResources(childCtx, compareQueue, comparisonResultChan) })
 
 	// Stage 5: Aggregate results. Reads from comparisonResultChan, appends to finalResults.
 	g.Go(func() error {
@@ -110,7 +279,9 @@ func (e *DriftAnalysisEngine) Run(ctx context.Context) error {
 	})
 
 	// --- Wait for all stages and handle potential errors ---
-	if runErr := g.Wait(); runErr != nil {
+	runErr := g.Wait()
+	e.progress(domain.ProgressEvent{Stage: domain.ProgressRunFinished})
+	if runErr != nil {
 		// Log appropriately depending on whether it was cancellation or another error
 		if runErr == context.Canceled || runErr == context.DeadlineExceeded {
 			e.logger.Warnf(ctx, "Drift analysis workflow cancelled or timed out: %v", runErr)
@@ -126,10 +297,14 @@ func (e *DriftAnalysisEngine) Run(ctx context.Context) error {
 
 	// --- Stage 6: Report Final Results if workflow completed successfully ---
 	e.logger.Infof(ctx, "Drift analysis workflow completed successfully.")
-	reportErr := e.reportResults(ctx, finalResults) // Use helper
+	e.applyHistory(ctx, kinds, startedAt, finalResults)
+	reported := e.applyBaseline(ctx, startedAt, finalResults)
+	reportErr := e.reportResults(ctx, reported) // Use helper
 	if reportErr != nil {
 		return reportErr // Return reporting error
 	}
+	e.notify(ctx, reported)
+	e.recordRun(ctx, kinds, startedAt, finalResults)
 
 	e.logger.Infof(ctx, "Drift analysis run finished successfully.")
 	return nil
@@ -137,41 +312,77 @@ func (e *DriftAnalysisEngine) Run(ctx context.Context) error {
 
 // --- Stage Helper Functions ---
 
-// stageListDesired lists resources from the configured state provider for all configured kinds.
-func (e *DriftAnalysisEngine) stageListDesired(ctx context.Context, desiredChan chan<- domain.StateResource) error {
+// stageListDesired lists resources from the configured state provider for the kinds of the run.
+// Kinds are listed concurrently, at most runConfig.Concurrency at a time, and each kind's
+// resources are sent as soon as its listing completes. The first failing kind cancels the rest.
+func (e *DriftAnalysisEngine) stageListDesired(ctx context.Context, kinds []domain.ResourceKind, desiredChan chan<- domain.StateResource) (err error) {
 	defer close(desiredChan) // Ensure channel is closed when listing is done or errors out
-	for _, kind := range e.runConfig.ResourceKindsToProcess {
-		// Check for context cancellation before processing each kind
-		if ctx.Err() != nil {
-			return ctx.Err()
+	ctx, span := e.startSpan(ctx, "drift.list_desired")
+	defer func() { endSpan(span, err) }()
+	g, listCtx := errgroup.WithContext(ctx)
+	g.SetLimit(e.runConfig.Concurrency)
+	for _, kind := range kinds {
+		// Stop scheduling further kinds once the run is cancelled or a listing failed
+		if listCtx.Err() != nil {
+			break
 		}
-		e.logger.Debugf(ctx, "[Stage 1a] Listing desired resources of kind: %s", kind)
-		resources, err := e.stateProvider.ListResources(ctx, kind)
-		if err != nil {
-			// Wrap and log provider error, then return to signal errgroup
-			wrappedErr := errors.Wrap(err, errors.CodeStateReadError, "failed listing desired resources")
-			e.logger.Errorf(ctx, wrappedErr, "error listing desired kind %s", kind)
-			return wrappedErr
-		}
-		e.logger.Debugf(ctx, "[Stage 1a] Found %d desired resources of kind: %s", len(resources), kind)
-		// Send found resources to the channel, checking for cancellation
+		g.Go(func() error { return e.listDesiredKind(listCtx, kind, desiredChan) })
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	e.logger.Debugf(ctx, "[Stage 1a] Finished listing all desired resources")
+	return e.checkStateIssues(ctx)
+}
+
+// listDesiredKind lists the desired resources of one kind and sends them to desiredChan.
+func (e *DriftAnalysisEngine) listDesiredKind(ctx context.Context, kind domain.ResourceKind, desiredChan chan<- domain.StateResource) (err error) {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	ctx, span := e.startSpan(ctx, "drift.list_desired_kind", attrKind.String(string(kind)))
+	defer func() { endSpan(span, err) }()
+	e.logger.Debugf(ctx, "[Stage 1a] Listing desired resources of kind: %s", kind)
+	resources, err := e.stateProvider.ListResources(ctx, kind)
+	if err != nil {
+		// Wrap and log provider error, then return to signal errgroup
+		wrappedErr := errors.Wrap(err, errors.CodeStateReadError, "failed listing desired resources")
+		e.logger.Errorf(ctx, wrappedErr, "error listing desired kind %s", kind)
+		return wrappedErr
+	}
+	e.logger.Debugf(ctx, "[Stage 1a] Found %d desired resources of kind: %s", len(resources), kind)
+	if e.runConfig.Filter != nil {
+		kept := make([]domain.StateResource, 0, len(resources))
 		for _, res := range resources {
-			select {
-			case desiredChan <- res:
-			case <-ctx.Done():
-				return ctx.Err()
+			if e.runConfig.Filter.KeepDesired(res) {
+				kept = append(kept, res)
 			}
 		}
+		e.logger.Debugf(ctx, "[Stage 1a] Filter kept %d of %d desired resources of kind: %s", len(kept), len(resources), kind)
+		resources = kept
+	}
+	span.SetAttributes(attrResources.Int(len(resources)))
+	e.progress(domain.ProgressEvent{Stage: domain.ProgressListedDesired, Kind: kind, Count: len(resources)})
+	// Send found resources to the channel, checking for cancellation
+	for _, res := range resources {
+		e.listed(domain.SideDesired, res.Metadata())
+		if err := sendMetered(ctx, desiredChan, res, kind, e.meters.desired); err != nil {
+			return err
+		}
 	}
-	e.logger.Debugf(ctx, "[Stage 1a] Finished listing all desired resources")
 	return nil
 }
 
-// stageListActual lists resources from the configured platform provider for all configured kinds.
+// stageListActual lists resources from the configured platform provider for the kinds of the run.
 // It uses an intermediate channel and goroutine to avoid blocking the provider on downstream processing.
-func (e *DriftAnalysisEngine) stageListActual(ctx context.Context, actualChan chan<- domain.PlatformResource) error {
-	defer close(actualChan)                                         // Ensure output channel is closed eventually
-	platformResourceChan := make(chan domain.PlatformResource, 100) // Intermediate channel
+func (e *DriftAnalysisEngine) stageListActual(ctx context.Context, kinds []domain.ResourceKind, actualChan chan<- domain.PlatformResource) (err error) {
+	defer close(actualChan) // Ensure output channel is closed eventually
+	ctx, span := e.startSpan(ctx, "drift.list_actual", attrKinds.StringSlice(kindNames(kinds)))
+	defer func() { endSpan(span, err) }()
+	platformResourceChan := make(chan domain.PlatformResource, e.runConfig.ChannelBuffers.Actual) // Intermediate channel
 	var wg sync.WaitGroup
 	wg.Add(1)
 
@@ -179,9 +390,13 @@ func (e *DriftAnalysisEngine) stageListActual(ctx context.Context, actualChan ch
 	go func() {
 		defer wg.Done()
 		for res := range platformResourceChan {
-			select {
-			case actualChan <- res:
-			case <-ctx.Done():
+			if !e.runConfig.Filter.KeepActual(ctx, res) {
+				continue
+			}
+			meta := res.Metadata()
+			e.progress(domain.ProgressEvent{Stage: domain.ProgressListedActual, Kind: meta.Kind, Count: 1})
+			e.listed(domain.SideActual, meta)
+			if err := sendMetered(ctx, actualChan, res, meta.Kind, e.meters.actual); err != nil {
 				e.logger.Warnf(ctx, "[Stage 1b] Context cancelled while forwarding platform resource")
 				return
 			}
@@ -189,9 +404,10 @@ func (e *DriftAnalysisEngine) stageListActual(ctx context.Context, actualChan ch
 	}()
 
 	e.logger.Debugf(ctx, "[Stage 1b] Initiating listing of actual resources")
-	platformFilters := make(map[string]string) // Placeholder, filters loaded from config if needed by provider
+	// Push down the part of the run's filter the provider can apply while listing
+	platformFilters := e.runConfig.Filter.PlatformFilters()
 	// Call the platform provider's ListResources method. This blocks until the provider is done listing.
-	err := e.platformProvider.ListResources(ctx, e.runConfig.ResourceKindsToProcess, platformFilters, platformResourceChan)
+	err = e.platformProvider.ListResources(ctx, kinds, platformFilters, platformResourceChan)
 	// Close the intermediate channel *after* the provider finishes or errors out
 	close(platformResourceChan)
 	// Wait for the forwarding goroutine to finish processing all items from the intermediate channel
@@ -206,14 +422,23 @@ func (e *DriftAnalysisEngine) stageListActual(ctx context.Context, actualChan ch
 	return nil
 }
 
-// stageMatchResources collects all listed resources and passes them to the matcher.
+// stageMatchResources collects all listed resources and passes them to the matcher,
+// or matches them as they arrive when streaming matching is enabled.
 func (e *DriftAnalysisEngine) stageMatchResources(
 	ctx context.Context,
 	desiredChan <-chan domain.StateResource,
 	actualChan <-chan domain.PlatformResource,
 	matchResultChan chan<- ports.MatchingResult,
-) error {
+) (err error) {
 	defer close(matchResultChan) // Ensure channel is closed
+	ctx, span := e.startSpan(ctx, "drift.match", attrStreaming.Bool(e.runConfig.StreamingMatch))
+	defer func() { endSpan(span, err) }()
+	if e.runConfig.StreamingMatch {
+		if streaming, ok := e.matcher.(ports.StreamingMatcher); ok {
+			return e.streamMatchResources(ctx, streaming.NewIndex(), desiredChan, actualChan, matchResultChan)
+		}
+		e.logger.Warnf(ctx, "[Stage 2] Matcher %T does not support streaming matching, collecting all resources instead", e.matcher)
+	}
 	e.logger.Debugf(ctx, "[Stage 2] Waiting to collect resources from listing stages...")
 	// Collect all results from the input channels first
 	desired, actual, err := e.collectResources(ctx, desiredChan, actualChan) // Uses helper
@@ -235,7 +460,7 @@ func (e *DriftAnalysisEngine) stageMatchResources(
 		e.logger.Errorf(ctx, err, "[Stage 2] Resource matching failed")
 		return errors.Wrap(err, errors.CodeMatchingError, "resource matching failed")
 	}
-	e.logger.Debugf(ctx, "[Stage 2] Matching complete: %d matched, %d missing, %d unmanaged", len(matchResult.Matched), len(matchResult.UnmatchedDesired), len(matchResult.UnmatchedActual))
+	e.logger.Debugf(ctx, "[Stage 2] Matching complete: %d matched, %d missing, %d unmanaged, %d ambiguous", len(matchResult.Matched), len(matchResult.UnmatchedDesired), len(matchResult.UnmatchedActual), len(matchResult.Ambiguous))
 
 	// Send the single matching result object
 	select {
@@ -247,52 +472,129 @@ func (e *DriftAnalysisEngine) stageMatchResources(
 	}
 }
 
+// streamMatchResources indexes all desired resources, then matches actual resources
+// one by one as the platform lists them. Results are sent in batches of at most
+// streamingMatchBatchSize actual resources, followed by one result holding the
+// desired resources that stayed unmatched.
+func (e *DriftAnalysisEngine) streamMatchResources(
+	ctx context.Context,
+	index ports.MatchIndex,
+	desiredChan <-chan domain.StateResource,
+	actualChan <-chan domain.PlatformResource,
+	matchResultChan chan<- ports.MatchingResult,
+) error {
+	e.logger.Debugf(ctx, "[Stage 2] Indexing desired resources for streaming matching...")
+	desiredCount := 0
+	for res := range desiredChan {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		index.AddDesired(ctx, res)
+		desiredCount++
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	e.logger.Debugf(ctx, "[Stage 2] Indexed %d desired resources, matching actual resources as they arrive", desiredCount)
+
+	send := func(result ports.MatchingResult) error {
+		select {
+		case matchResultChan <- result:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var batch ports.MatchingResult
+	matched, unmanaged := 0, 0
+	for res := range actualChan {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if desired, ok := index.MatchActual(ctx, res); ok {
+			batch.Matched = append(batch.Matched, ports.MatchedPair{Desired: desired, Actual: res})
+			matched++
+		} else {
+			batch.UnmatchedActual = append(batch.UnmatchedActual, res)
+			unmanaged++
+		}
+		if len(batch.Matched)+len(batch.UnmatchedActual) >= streamingMatchBatchSize {
+			if err := send(batch); err != nil {
+				return err
+			}
+			batch = ports.MatchingResult{}
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	batch.UnmatchedDesired = index.Unmatched()
+	e.logger.Debugf(ctx, "[Stage 2] Streaming matching complete: %d matched, %d missing, %d unmanaged", matched, len(batch.UnmatchedDesired), unmanaged)
+	return send(batch)
+}
+
 // stageDispatchComparisons processes the matching results, handles unmatched resources,
-// and sends matched pairs to the comparison workers.
+// and queues matched pairs for the comparison workers.
 func (e *DriftAnalysisEngine) stageDispatchComparisons(
 	ctx context.Context,
 	matchResultChan <-chan ports.MatchingResult,
-	compareInputChan chan<- ports.MatchedPair,
+	queue *compareQueue,
 	finalResults *[]domain.ComparisonResult,
 	finalResultsMutex *sync.Mutex,
 ) error {
-	defer close(compareInputChan) // Ensure comparison input channel is closed
+	defer queue.close() // Let the workers finish once the queued pairs are compared
 	e.logger.Debugf(ctx, "[Stage 3] Waiting for match results...")
-	select {
-	case matchResult, ok := <-matchResultChan:
-		if !ok { // Channel closed without sending a result (e.g., matcher errored)
-			if ctx.Err() == nil {
-				e.logger.Warnf(ctx, "[Stage 3] Matcher did not produce a result, compare stage potentially skipped")
-			}
-			return nil // Not an error for this stage if context is okay
+	// The matcher sends one result, or one per batch when matching is streamed
+	var imageChecks []<-chan struct{}
+	defer func() {
+		for _, imagesChecked := range imageChecks {
+			<-imagesChecked
 		}
-		e.logger.Debugf(ctx, "[Stage 3] Received match results, processing unmatched...")
-		// Process resources found only in state or only on platform
-		e.processUnmatched(ctx, matchResult, finalResults, finalResultsMutex)
+	}()
+	received := false
+	for {
+		select {
+		case matchResult, ok := <-matchResultChan:
+			if !ok { // Channel closed, either after the last result or because the matcher errored
+				if !received && ctx.Err() == nil {
+					e.logger.Warnf(ctx, "[Stage 3] Matcher did not produce a result, compare stage potentially skipped")
+				}
+				if received {
+					e.logger.Debugf(ctx, "[Stage 3] Finished dispatching matched pairs")
+				}
+				return nil // Not an error for this stage if context is okay
+			}
+			received = true
+			e.logger.Debugf(ctx, "[Stage 3] Received match results, processing unmatched...")
+			// Process resources found only in state or only on platform
+			e.processUnmatched(ctx, matchResult, finalResults, finalResultsMutex)
+			e.matched(matchResult)
+			imageChecks = append(imageChecks, e.startImageCompliance(ctx, matchResult, finalResults, finalResultsMutex))
 
-		e.logger.Debugf(ctx, "[Stage 3] Dispatching %d matched pairs for comparison...", len(matchResult.Matched))
-		// Send matched pairs to the comparison workers
-		for _, pair := range matchResult.Matched {
-			select {
-			case compareInputChan <- pair:
-			case <-ctx.Done():
-				return ctx.Err()
+			e.logger.Debugf(ctx, "[Stage 3] Dispatching %d matched pairs for comparison...", len(matchResult.Matched))
+			// Queue matched pairs for the comparison workers, which take the highest priority kinds first
+			for _, pair := range matchResult.Matched {
+				if err := queue.push(ctx, pair, e.kindPriority(pair.Desired.Metadata().Kind)); err != nil {
+					return err
+				}
 			}
+		case <-ctx.Done():
+			return ctx.Err() // Context cancelled while waiting for match result
 		}
-		e.logger.Debugf(ctx, "[Stage 3] Finished dispatching matched pairs")
-		return nil
-	case <-ctx.Done():
-		return ctx.Err() // Context cancelled while waiting for match result
 	}
 }
 
 // stageCompareResources manages a pool of workers to perform comparisons concurrently.
 func (e *DriftAnalysisEngine) stageCompareResources(
 	ctx context.Context,
-	compareInputChan <-chan ports.MatchedPair,
+	queue *compareQueue,
 	comparisonResultChan chan<- domain.ComparisonResult,
 ) error {
 	defer close(comparisonResultChan) // Ensure result channel is closed when all workers finish
+	ctx, span := e.startSpan(ctx, "drift.compare", attrWorkers.Int(e.runConfig.Concurrency))
+	defer span.End()
 	var compareWG sync.WaitGroup
 	e.logger.Debugf(ctx, "[Stage 4] Starting %d comparison workers...", e.runConfig.Concurrency)
 
@@ -303,10 +605,10 @@ func (e *DriftAnalysisEngine) stageCompareResources(
 			defer compareWG.Done()
 			workerLogger := e.logger.WithFields(map[string]any{"worker_id": workerID})
 			// Pass the map of attributes to check for all kinds down to the worker
-			e.compareWorker(ctx, compareInputChan, comparisonResultChan, e.runConfig.AttributesToCheck, workerLogger)
+			e.compareWorker(ctx, queue, comparisonResultChan, e.runConfig.AttributesToCheck, workerLogger)
 		}(i)
 	}
-	compareWG.Wait() // Wait for all workers to drain the input channel and finish
+	compareWG.Wait() // Wait for all workers to drain the queue and finish
 	e.logger.Debugf(ctx, "[Stage 4] All comparison workers finished")
 	return nil
 }
@@ -330,6 +632,7 @@ func (e *DriftAnalysisEngine) stageAggregateResults(
 		finalResultsMutex.Lock()
 		*finalResults = append(*finalResults, result)
 		count++
+		e.produced(result)
 		finalResultsMutex.Unlock()
 	}
 	e.logger.Debugf(ctx, "[Stage 5] Finished aggregating %d comparison results", count)
@@ -337,8 +640,13 @@ func (e *DriftAnalysisEngine) stageAggregateResults(
 }
 
 // reportResults calls the configured reporter to output the final results.
-func (e *DriftAnalysisEngine) reportResults(ctx context.Context, results []domain.ComparisonResult) error {
+func (e *DriftAnalysisEngine) reportResults(ctx context.Context, results []domain.ComparisonResult) (err error) {
+	ctx, span := e.startSpan(ctx, "drift.report", attrResults.Int(len(results)))
+	defer func() { endSpan(span, err) }()
 	e.logger.Infof(ctx, "[Stage 6] Reporting %d results...", len(results))
+	e.prioritizeResults(results)
+	e.attachStateIssues()
+	e.attachRunAnnotations()
 	reportErr := e.reporter.Report(ctx, results)
 	if reportErr != nil {
 		e.logger.Errorf(ctx, reportErr, "[Stage 6] Failed to generate final report")
@@ -350,24 +658,47 @@ func (e *DriftAnalysisEngine) reportResults(ctx context.Context, results []domai
 
 // --- Worker and Helper Functions ---
 
-// compareWorker processes comparison tasks read from the input channel.
+// kindPriority returns the configured priority for a kind, falling back to the built-in default.
+func (e *DriftAnalysisEngine) kindPriority(kind domain.ResourceKind) int {
+	if p, ok := e.runConfig.KindPriorities[kind]; ok {
+		return p
+	}
+	return domain.DefaultKindPriority(kind)
+}
+
+// prioritizeResults stamps each result with its kind priority and orders them so
+// that higher priority kinds are reported first.
+func (e *DriftAnalysisEngine) prioritizeResults(results []domain.ComparisonResult) {
+	for i := range results {
+		results[i].Priority = e.kindPriority(results[i].ResourceKind)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Priority > results[j].Priority
+	})
+}
+
+// compareWorker processes the pairs taken from the queue. The queue only hands
+// out a pair once its kind is below its KindConcurrency limit, and the kind's
+// slot is released when the comparison is done.
 func (e *DriftAnalysisEngine) compareWorker(
 	ctx context.Context,
-	inputChan <-chan ports.MatchedPair,
+	queue *compareQueue,
 	resultChan chan<- domain.ComparisonResult,
 	attributesToCheckMap map[domain.ResourceKind][]string,
 	logger ports.Logger,
 ) {
 	logger.Debugf(ctx, "Comparison worker started")
-	for pair := range inputChan {
-		// Check context before processing each pair
-		select {
-		case <-ctx.Done():
-			logger.Warnf(ctx, "Comparison worker shutting down due to context cancellation")
-			return
-		default:
-			e.processSingleComparison(ctx, pair, attributesToCheckMap, resultChan, logger)
+	for {
+		pair, release, ok := queue.next(ctx)
+		if !ok {
+			break
 		}
+		e.processSingleComparison(ctx, pair, attributesToCheckMap, resultChan, logger)
+		release()
+	}
+	if ctx.Err() != nil {
+		logger.Warnf(ctx, "Comparison worker shutting down due to context cancellation")
+		return
 	}
 	logger.Debugf(ctx, "Comparison worker finished")
 }
@@ -383,6 +714,11 @@ func (e *DriftAnalysisEngine) processSingleComparison(
 	desiredMeta := pair.Desired.Metadata()
 	actualMeta := pair.Actual.Metadata()
 	kind := desiredMeta.Kind // Assume Kind matches as they were paired
+	ctx, span := e.startSpan(ctx, "drift.compare_resource",
+		attrKind.String(string(kind)),
+		attrSourceID.String(desiredMeta.SourceIdentifier),
+		attrPlatformID.String(actualMeta.ProviderAssignedID))
+	defer span.End()
 
 	log := logger.WithFields(map[string]any{
 		"resource_kind": kind,
@@ -390,6 +726,14 @@ func (e *DriftAnalysisEngine) processSingleComparison(
 		"source_id":     desiredMeta.SourceIdentifier,
 	})
 
+	if actualMeta.PendingDeletion != nil {
+		log.Infof(ctx, "Resource is pending deletion (%s), skipping comparison", actualMeta.PendingDeletion.State)
+		result := e.pendingDeletionResult(kind, desiredMeta, actualMeta)
+		result.Match = pairMatchInfo(pair)
+		e.sendResult(ctx, result, resultChan, log)
+		return
+	}
+
 	log.Debugf(ctx, "Comparing resource pair")
 
 	comparer, err := e.getComparerForKind(ctx, kind, log)
@@ -405,20 +749,88 @@ func (e *DriftAnalysisEngine) processSingleComparison(
 			Status:             domain.StatusNoDrift,
 			ResourceKind:       kind,
 			SourceIdentifier:   desiredMeta.SourceIdentifier,
+			SourceFile:         desiredMeta.SourceFile,
+			SourceLine:         desiredMeta.SourceLine,
 			ProviderType:       actualMeta.ProviderType,
 			ProviderAssignedID: actualMeta.ProviderAssignedID,
+			Links:              e.buildLinks(kind, desiredMeta, actualMeta),
+			Match:              pairMatchInfo(pair),
 		}
 		e.sendResult(ctx, result, resultChan, log)
 		return
 	}
 
+	var explanation *domain.Explanation
+	compareCtx := domain.WithNormalizations(ctx, e.runConfig.Normalizations[kind])
+	compareCtx = domain.WithAttributeParallelism(compareCtx, e.runConfig.AttributeParallelism[kind])
+	if e.runConfig.Explain {
+		explanation = domain.NewExplanation()
+		compareCtx = domain.WithExplanation(compareCtx, explanation)
+		if pair.Strategy != "" {
+			explanation.Step("matched %s to %s via %s (confidence %.2f)", desiredMeta.SourceIdentifier, actualMeta.ProviderAssignedID, pair.Strategy, pair.Confidence)
+		} else {
+			explanation.Step("matched %s to %s", desiredMeta.SourceIdentifier, actualMeta.ProviderAssignedID)
+		}
+		explanation.Step("compared with %T", comparer)
+	}
+
+	var notAsserted []string
+	if e.runConfig.UnknownTolerant {
+		attributesForThisKind, notAsserted = withoutUnknownAttributes(attributesForThisKind, desiredMeta.UnknownAttributes)
+		if len(notAsserted) > 0 {
+			explanation.Step("did not assert %s, only known after apply", strings.Join(notAsserted, ", "))
+		}
+	}
+
+	desired, actual := pair.Desired, pair.Actual
+	if pipeline := e.runConfig.Transforms[kind]; pipeline != nil {
+		log.Debugf(ctx, "Applying attribute transforms")
+		explanation.Step("applied the configured attribute transforms to both sides")
+		desired, actual = pipeline.WrapDesired(desired), pipeline.WrapActual(actual)
+	}
+
 	log.Debugf(ctx, "Comparing attributes: %v", attributesForThisKind)
-	diffs, cmpErr := comparer.Compare(ctx, pair.Desired, pair.Actual, attributesForThisKind)
+	diffs, cmpErr := comparer.Compare(compareCtx, desired, actual, attributesForThisKind)
 
 	result := e.createComparisonResult(kind, desiredMeta, actualMeta, diffs, cmpErr, log)
+	result.NotAsserted = notAsserted
+	result.Trace = explanation.Trace()
+	result.Match = pairMatchInfo(pair)
+	span.SetAttributes(attrStatus.String(string(result.Status)))
+	markSpanFailed(span, cmpErr)
 	e.sendResult(ctx, result, resultChan, log)
 }
 
+// pairMatchInfo returns how the pair was matched, or nil when the matcher
+// does not report it.
+func pairMatchInfo(pair ports.MatchedPair) *domain.MatchInfo {
+	if pair.Strategy == "" {
+		return nil
+	}
+	return &domain.MatchInfo{Strategy: pair.Strategy, Confidence: pair.Confidence}
+}
+
+// withoutUnknownAttributes removes the attributes whose desired values are
+// unknown from the attributes to compare, returning the ones it removed.
+func withoutUnknownAttributes(attributes, unknown []string) (kept, removed []string) {
+	if len(unknown) == 0 {
+		return attributes, nil
+	}
+	isUnknown := make(map[string]bool, len(unknown))
+	for _, name := range unknown {
+		isUnknown[name] = true
+	}
+	kept = make([]string, 0, len(attributes))
+	for _, name := range attributes {
+		if isUnknown[name] {
+			removed = append(removed, name)
+			continue
+		}
+		kept = append(kept, name)
+	}
+	return kept, removed
+}
+
 // getComparerForKind retrieves the specific comparer implementation from the registry.
 func (e *DriftAnalysisEngine) getComparerForKind(ctx context.Context, kind domain.ResourceKind, logger ports.Logger) (ports.ResourceComparer, error) {
 	comparer, err := e.registry.GetResourceComparer(kind)
@@ -441,10 +853,26 @@ func (e *DriftAnalysisEngine) createComparisonResult(
 	result := domain.ComparisonResult{
 		ResourceKind:       kind,
 		SourceIdentifier:   desiredMeta.SourceIdentifier,
+		SourceFile:         desiredMeta.SourceFile,
+		SourceLine:         desiredMeta.SourceLine,
 		ProviderType:       actualMeta.ProviderType,
 		ProviderAssignedID: actualMeta.ProviderAssignedID,
 		Differences:        diffs,
 		Error:              cmpErr,
+		Links:              e.buildLinks(kind, desiredMeta, actualMeta),
+	}
+
+	for i := range result.Differences {
+		if e.diffClassifier != nil {
+			if reason := e.diffClassifier.PlatformManaged(kind, result.Differences[i]); reason != "" {
+				result.Differences[i].PlatformManaged = reason
+				result.Differences[i].Severity = domain.SeverityInfo
+			}
+		}
+		if result.Differences[i].Severity == "" {
+			result.Differences[i].Severity = domain.SeverityWarning
+		}
+		result.Differences[i].Group = e.attributeGroup(result.Differences[i].AttributeName)
 	}
 
 	if cmpErr != nil {
@@ -460,6 +888,18 @@ func (e *DriftAnalysisEngine) createComparisonResult(
 	return result
 }
 
+// attributeGroup returns the report group of an attribute, or an empty group
+// when no attribute groups are configured.
+func (e *DriftAnalysisEngine) attributeGroup(attribute string) string {
+	if len(e.runConfig.AttributeGroups) == 0 {
+		return ""
+	}
+	if group, ok := e.runConfig.AttributeGroups[attribute]; ok {
+		return group
+	}
+	return domain.UngroupedAttributes
+}
+
 // sendComparisonError is a helper to create and send an error result.
 func (e *DriftAnalysisEngine) sendComparisonError(
 	ctx context.Context,
@@ -474,13 +914,40 @@ func (e *DriftAnalysisEngine) sendComparisonError(
 		Status:             domain.StatusError,
 		ResourceKind:       kind,
 		SourceIdentifier:   desiredMeta.SourceIdentifier,
+		SourceFile:         desiredMeta.SourceFile,
+		SourceLine:         desiredMeta.SourceLine,
 		ProviderType:       actualMeta.ProviderType,
 		ProviderAssignedID: actualMeta.ProviderAssignedID,
 		Error:              err,
+		Links:              e.buildLinks(kind, desiredMeta, actualMeta),
 	}
 	e.sendResult(ctx, result, resultChan, logger)
 }
 
+// pendingDeletionResult reports a resource the platform is deleting, or holds
+// in a recycle or retention state, instead of comparing it.
+func (e *DriftAnalysisEngine) pendingDeletionResult(kind domain.ResourceKind, desiredMeta, actualMeta domain.ResourceMetadata) domain.ComparisonResult {
+	return domain.ComparisonResult{
+		Status:             domain.StatusPendingDeletion,
+		ResourceKind:       kind,
+		SourceIdentifier:   desiredMeta.SourceIdentifier,
+		SourceFile:         desiredMeta.SourceFile,
+		SourceLine:         desiredMeta.SourceLine,
+		ProviderType:       actualMeta.ProviderType,
+		ProviderAssignedID: actualMeta.ProviderAssignedID,
+		Links:              e.buildLinks(kind, desiredMeta, actualMeta),
+		PendingDeletion:    actualMeta.PendingDeletion,
+	}
+}
+
+// buildLinks returns the deep links for a finding, or nil when no link builder is configured.
+func (e *DriftAnalysisEngine) buildLinks(kind domain.ResourceKind, desiredMeta, actualMeta domain.ResourceMetadata) []domain.ResourceLink {
+	if e.linkBuilder == nil {
+		return nil
+	}
+	return e.linkBuilder.Links(kind, desiredMeta, actualMeta)
+}
+
 // sendResult sends a ComparisonResult to the channel, handling context cancellation.
 func (e *DriftAnalysisEngine) sendResult(
 	ctx context.Context,
@@ -488,9 +955,7 @@ func (e *DriftAnalysisEngine) sendResult(
 	resultChan chan<- domain.ComparisonResult,
 	logger ports.Logger,
 ) {
-	select {
-	case resultChan <- result:
-	case <-ctx.Done():
+	if err := sendMetered(ctx, resultChan, result, result.ResourceKind, e.meters.results); err != nil {
 		logger.Warnf(ctx, "Context cancelled before sending comparison result for %s", result.SourceIdentifier)
 	}
 }
@@ -575,10 +1040,13 @@ func (e *DriftAnalysisEngine) drainPlatformChannel(ctx context.Context, in <-cha
 	}
 }
 
-// processUnmatched creates ComparisonResult entries for unmatched resources.
+// processUnmatched creates ComparisonResult entries for unmatched and
+// ambiguously matched resources.
 func (e *DriftAnalysisEngine) processUnmatched(ctx context.Context, matchResult ports.MatchingResult, finalResults *[]domain.ComparisonResult, mutex *sync.Mutex) {
 	mutex.Lock()
 	defer mutex.Unlock()
+	start := len(*finalResults)
+	defer func() { e.produced((*finalResults)[start:]...) }()
 
 	for _, res := range matchResult.UnmatchedDesired {
 		meta := res.Metadata()
@@ -586,19 +1054,80 @@ func (e *DriftAnalysisEngine) processUnmatched(ctx context.Context, matchResult
 			Status:           domain.StatusMissing,
 			ResourceKind:     meta.Kind,
 			SourceIdentifier: meta.SourceIdentifier,
+			SourceFile:       meta.SourceFile,
+			SourceLine:       meta.SourceLine,
 			ProviderType:     meta.ProviderType, // From state source
+			Links:            e.buildLinks(meta.Kind, meta, domain.ResourceMetadata{}),
 		})
 		e.logger.Warnf(ctx, "Resource missing on platform: [%s] %s", meta.Kind, meta.SourceIdentifier)
 	}
 
+	for _, conflict := range matchResult.Ambiguous {
+		result := ambiguousResult(conflict)
+		*finalResults = append(*finalResults, result)
+		e.logger.Warnf(ctx, "Ambiguous match of %d desired and %d actual resources, none compared: [%s] %s%s",
+			len(conflict.Desired), len(conflict.Actual), result.ResourceKind, result.SourceIdentifier, result.ProviderAssignedID)
+	}
+
+	ignoredDefaults := 0
 	for _, res := range matchResult.UnmatchedActual {
 		meta := res.Metadata()
+		if meta.PlatformDefault != "" && e.runConfig.IgnorePlatformDefaults {
+			ignoredDefaults++
+			e.logger.Debugf(ctx, "Ignoring unmanaged platform default resource (%s): [%s] %s", meta.PlatformDefault, meta.Kind, meta.ProviderAssignedID)
+			continue
+		}
+		if meta.PendingDeletion != nil {
+			*finalResults = append(*finalResults, e.pendingDeletionResult(meta.Kind, domain.ResourceMetadata{}, meta))
+			e.logger.Infof(ctx, "Unmanaged resource is pending deletion (%s): [%s] %s", meta.PendingDeletion.State, meta.Kind, meta.ProviderAssignedID)
+			continue
+		}
 		*finalResults = append(*finalResults, domain.ComparisonResult{
 			Status:             domain.StatusUnmanaged,
 			ResourceKind:       meta.Kind,
 			ProviderType:       meta.ProviderType, // From platform
 			ProviderAssignedID: meta.ProviderAssignedID,
+			Links:              e.buildLinks(meta.Kind, domain.ResourceMetadata{}, meta),
 		})
+		if meta.PlatformDefault != "" {
+			e.logger.Infof(ctx, "Unmanaged platform default resource found (%s): [%s] %s", meta.PlatformDefault, meta.Kind, meta.ProviderAssignedID)
+			continue
+		}
 		e.logger.Warnf(ctx, "Unmanaged resource found on platform: [%s] %s", meta.Kind, meta.ProviderAssignedID)
 	}
+	if ignoredDefaults > 0 {
+		e.logger.Infof(ctx, "Ignored %d unmanaged resource(s) created by the platform itself", ignoredDefaults)
+	}
+}
+
+// ambiguousResult reports a group of resources the matcher could not pair,
+// listing every one of them as a candidate. The source identifier or provider
+// assigned ID is set when a single resource on that side is contested.
+func ambiguousResult(conflict ports.AmbiguousMatch) domain.ComparisonResult {
+	result := domain.ComparisonResult{Status: domain.StatusAmbiguous}
+	if conflict.Strategy != "" {
+		result.Match = &domain.MatchInfo{Strategy: conflict.Strategy}
+	}
+	for _, res := range conflict.Desired {
+		meta := res.Metadata()
+		result.Candidates = append(result.Candidates, domain.MatchCandidate{SourceIdentifier: meta.SourceIdentifier})
+		if result.ResourceKind == "" {
+			result.ResourceKind, result.ProviderType = meta.Kind, meta.ProviderType
+		}
+	}
+	for _, res := range conflict.Actual {
+		meta := res.Metadata()
+		result.Candidates = append(result.Candidates, domain.MatchCandidate{ProviderAssignedID: meta.ProviderAssignedID})
+		if result.ResourceKind == "" {
+			result.ResourceKind, result.ProviderType = meta.Kind, meta.ProviderType
+		}
+	}
+	if len(conflict.Desired) == 1 {
+		meta := conflict.Desired[0].Metadata()
+		result.SourceIdentifier, result.SourceFile, result.SourceLine = meta.SourceIdentifier, meta.SourceFile, meta.SourceLine
+	}
+	if len(conflict.Actual) == 1 {
+		result.ProviderAssignedID = conflict.Actual[0].Metadata().ProviderAssignedID
+	}
+	return result
 }
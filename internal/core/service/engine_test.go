@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(context.Context, string, ...any)        {}
+func (nopLogger) Infof(context.Context, string, ...any)         {}
+func (nopLogger) Warnf(context.Context, string, ...any)         {}
+func (nopLogger) Errorf(context.Context, error, string, ...any) {}
+func (l nopLogger) WithFields(map[string]any) ports.Logger      { return l }
+
+type stateResource struct{ meta domain.ResourceMetadata }
+
+func (r stateResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r stateResource) Attributes() map[string]any        { return nil }
+
+type platformResource struct{ meta domain.ResourceMetadata }
+
+func (r platformResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r platformResource) Attributes(context.Context) (map[string]any, error) {
+	return nil, nil
+}
+
+// fakeState lists one desired resource per ID of every kind.
+type fakeState struct {
+	ids map[domain.ResourceKind][]string
+}
+
+func (s fakeState) Type() string { return "fake" }
+func (s fakeState) ListResources(_ context.Context, kind domain.ResourceKind) ([]domain.StateResource, error) {
+	var out []domain.StateResource
+	for _, id := range s.ids[kind] {
+		out = append(out, stateResource{domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id, SourceIdentifier: id}})
+	}
+	return out, nil
+}
+func (s fakeState) GetResource(context.Context, domain.ResourceKind, string) (domain.StateResource, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// fakePlatform lists one actual resource per ID of every requested kind.
+type fakePlatform struct {
+	ids map[domain.ResourceKind][]string
+}
+
+func (p fakePlatform) Type() string { return "fake" }
+func (p fakePlatform) ListResources(ctx context.Context, kinds []domain.ResourceKind, _ map[string]string, out chan<- domain.PlatformResource) error {
+	for _, kind := range kinds {
+		for _, id := range p.ids[kind] {
+			select {
+			case out <- platformResource{domain.ResourceMetadata{Kind: kind, ProviderAssignedID: id}}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+func (p fakePlatform) GetResource(context.Context, domain.ResourceKind, string) (domain.PlatformResource, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (p fakePlatform) GetResources(context.Context, domain.ResourceKind, []string) (map[string]domain.PlatformResource, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// idMatcher pairs resources of the same kind and ID.
+type idMatcher struct{}
+
+func (idMatcher) Match(_ context.Context, desired []domain.StateResource, actual []domain.PlatformResource) (ports.MatchingResult, error) {
+	type key struct {
+		kind domain.ResourceKind
+		id   string
+	}
+	byKey := make(map[key]domain.StateResource, len(desired))
+	for _, d := range desired {
+		byKey[key{d.Metadata().Kind, d.Metadata().ProviderAssignedID}] = d
+	}
+	var result ports.MatchingResult
+	for _, a := range actual {
+		k := key{a.Metadata().Kind, a.Metadata().ProviderAssignedID}
+		if d, ok := byKey[k]; ok {
+			result.Matched = append(result.Matched, ports.MatchedPair{Desired: d, Actual: a})
+			delete(byKey, k)
+			continue
+		}
+		result.UnmatchedActual = append(result.UnmatchedActual, a)
+	}
+	for _, d := range byKey {
+		result.UnmatchedDesired = append(result.UnmatchedDesired, d)
+	}
+	return result, nil
+}
+
+// funcComparer compares with compare, reporting no drift.
+type funcComparer struct {
+	kind    domain.ResourceKind
+	compare func(ctx context.Context) error
+}
+
+func (c funcComparer) Kind() domain.ResourceKind { return c.kind }
+func (c funcComparer) Compare(ctx context.Context, _ domain.StateResource, _ domain.PlatformResource, _ []string) ([]domain.AttributeDiff, error) {
+	return nil, c.compare(ctx)
+}
+
+type captureReporter struct {
+	mu      sync.Mutex
+	results []domain.ComparisonResult
+}
+
+func (r *captureReporter) Report(_ context.Context, results []domain.ComparisonResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append([]domain.ComparisonResult(nil), results...)
+	return nil
+}
+
+func ids(prefix string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return out
+}
+
+func newTestEngine(t *testing.T, cfg EngineRunConfig, resources map[domain.ResourceKind][]string, comparers ...ports.ResourceComparer) (*DriftAnalysisEngine, *captureReporter) {
+	t.Helper()
+	registry := NewComponentRegistry()
+	for _, c := range comparers {
+		require.NoError(t, registry.RegisterResourceComparer(c))
+	}
+	cfg.SkipSelfTest = true
+	if cfg.AttributesToCheck == nil {
+		cfg.AttributesToCheck = make(map[domain.ResourceKind][]string)
+		for _, kind := range cfg.ResourceKindsToProcess {
+			cfg.AttributesToCheck[kind] = []string{"tags"}
+		}
+	}
+	reporter := &captureReporter{}
+	engine, err := NewDriftAnalysisEngine(registry, idMatcher{}, reporter, nopLogger{}, cfg, fakeState{resources}, fakePlatform{resources})
+	require.NoError(t, err)
+	return engine, reporter
+}
+
+func TestEngine_CappedKindDoesNotStarveOtherKinds(t *testing.T) {
+	const buckets, instances = 4, 3
+	instancesDone := make(chan struct{})
+	var compared atomic.Int32
+	var runningBuckets, peakBuckets atomic.Int32
+
+	bucketComparer := funcComparer{kind: domain.KindStorageBucket, compare: func(ctx context.Context) error {
+		running := runningBuckets.Add(1)
+		defer runningBuckets.Add(-1)
+		for {
+			peak := peakBuckets.Load()
+			if running <= peak || peakBuckets.CompareAndSwap(peak, running) {
+				break
+			}
+		}
+		// Buckets only finish once every instance was compared, which can only
+		// happen if the capped buckets leave a worker to the instances.
+		select {
+		case <-instancesDone:
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("instances were starved by queued buckets")
+		}
+	}}
+	instanceComparer := funcComparer{kind: domain.KindComputeInstance, compare: func(context.Context) error {
+		if compared.Add(1) == instances {
+			close(instancesDone)
+		}
+		return nil
+	}}
+	engine, reporter := newTestEngine(t, EngineRunConfig{
+		ResourceKindsToProcess: []domain.ResourceKind{domain.KindStorageBucket, domain.KindComputeInstance},
+		Concurrency:            2,
+		KindConcurrency:        map[domain.ResourceKind]int{domain.KindStorageBucket: 1},
+		// Buckets are queued ahead of the instances.
+		KindPriorities: map[domain.ResourceKind]int{domain.KindStorageBucket: 100, domain.KindComputeInstance: 1},
+	}, map[domain.ResourceKind][]string{
+		domain.KindStorageBucket:   ids("bucket", buckets),
+		domain.KindComputeInstance: ids("i", instances),
+	}, bucketComparer, instanceComparer)
+
+	require.NoError(t, engine.Run(context.Background()))
+
+	require.Len(t, reporter.results, buckets+instances)
+	for _, res := range reporter.results {
+		assert.Equal(t, domain.StatusNoDrift, res.Status, "%s %s: %v", res.ResourceKind, res.ProviderAssignedID, res.Error)
+	}
+	assert.Equal(t, int32(1), peakBuckets.Load(), "buckets stay within their concurrency limit")
+}
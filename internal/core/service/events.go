@@ -0,0 +1,108 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+var _ ports.EngineEventSource = (*DriftAnalysisEngine)(nil)
+
+// subscribers holds the handlers of one kind of engine event.
+type subscribers[T any] struct {
+	mu       sync.Mutex
+	nextID   int
+	handlers []subscriber[T]
+}
+
+type subscriber[T any] struct {
+	id     int
+	handle func(T)
+}
+
+// add registers handle and returns the function removing it.
+func (s *subscribers[T]) add(handle func(T)) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID++
+	s.handlers = append(s.handlers, subscriber[T]{id: id, handle: handle})
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, sub := range s.handlers {
+			if sub.id == id {
+				s.handlers = append(s.handlers[:i:i], s.handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publish calls every handler registered when it is called, in the order
+// they subscribed. Handlers may subscribe or unsubscribe while called.
+func (s *subscribers[T]) publish(event T) {
+	s.mu.Lock()
+	handlers := s.handlers
+	s.mu.Unlock()
+	for _, sub := range handlers {
+		sub.handle(event)
+	}
+}
+
+// engineEvents holds the subscribers of every engine event. Handlers are
+// called synchronously from the goroutines of the pipeline stages,
+// concurrently with each other, so they must be safe for concurrent use and
+// return quickly.
+type engineEvents struct {
+	resourceListed   subscribers[domain.ResourceListedEvent]
+	matchCompleted   subscribers[domain.MatchCompletedEvent]
+	comparisonResult subscribers[domain.ComparisonResult]
+	runCompleted     subscribers[domain.RunCompletedEvent]
+}
+
+// OnResourceListed calls handle for every resource listed from the desired
+// state source or the platform.
+func (e *DriftAnalysisEngine) OnResourceListed(handle func(domain.ResourceListedEvent)) (unsubscribe func()) {
+	return e.events.resourceListed.add(handle)
+}
+
+// OnMatchCompleted calls handle for every matching result.
+func (e *DriftAnalysisEngine) OnMatchCompleted(handle func(domain.MatchCompletedEvent)) (unsubscribe func()) {
+	return e.events.matchCompleted.add(handle)
+}
+
+// OnComparisonResult calls handle for every result of a run as it is
+// produced: comparisons as well as missing and unmanaged resources.
+func (e *DriftAnalysisEngine) OnComparisonResult(handle func(domain.ComparisonResult)) (unsubscribe func()) {
+	return e.events.comparisonResult.add(handle)
+}
+
+// OnRunCompleted calls handle when a run ends, successfully or not.
+func (e *DriftAnalysisEngine) OnRunCompleted(handle func(domain.RunCompletedEvent)) (unsubscribe func()) {
+	return e.events.runCompleted.add(handle)
+}
+
+// listed publishes a listed resource.
+func (e *DriftAnalysisEngine) listed(side domain.ResourceSide, meta domain.ResourceMetadata) {
+	e.events.resourceListed.publish(domain.ResourceListedEvent{Side: side, Metadata: meta})
+}
+
+// matched publishes a matching result.
+func (e *DriftAnalysisEngine) matched(result ports.MatchingResult) {
+	e.progressMatched(result.Matched)
+	e.events.matchCompleted.publish(domain.MatchCompletedEvent{
+		Matched:          len(result.Matched),
+		UnmatchedDesired: len(result.UnmatchedDesired),
+		UnmatchedActual:  len(result.UnmatchedActual),
+	})
+}
+
+// produced publishes results of the run as they are produced.
+func (e *DriftAnalysisEngine) produced(results ...domain.ComparisonResult) {
+	for _, res := range results {
+		e.progress(domain.ProgressEvent{Stage: domain.ProgressResult, Kind: res.ResourceKind, Status: res.Status})
+		e.events.comparisonResult.publish(res)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// defaultTombstoneGracePeriod is how long a resource that disappeared from the
+// platform is reported as recently deleted before it counts as missing.
+const defaultTombstoneGracePeriod = 24 * time.Hour
+
+// applyHistory compares this run's results with the previous run of each kind
+// from the history store, marks missing resources that were recently seen as
+// recently deleted and moves resources that keep failing to the dead-letter
+// list. The previous run of a kind is the latest run that analysed it, so runs
+// limited to other kinds, such as scheduled runs of a subset, are skipped.
+func (e *DriftAnalysisEngine) applyHistory(ctx context.Context, kinds []domain.ResourceKind, now time.Time, results []domain.ComparisonResult) {
+	if e.historyStore == nil {
+		return
+	}
+	previous := make(map[domain.ResourceKind]*domain.RunRecord, len(kinds))
+	for _, kind := range kinds {
+		run, err := e.historyStore.LatestRunOfKind(ctx, kind)
+		if err != nil {
+			e.logger.Warnf(ctx, "Failed to load previous run from history store, skipping tombstone and dead-letter tracking: %v", err)
+			return
+		}
+		if run == nil {
+			e.logger.Debugf(ctx, "No previous run of %s in history store, skipping tombstone tracking for it", kind)
+			continue
+		}
+		previous[kind] = run
+	}
+	if marked := applyTombstones(previous, results, now, e.runConfig.TombstoneGracePeriod); marked > 0 {
+		e.logger.Debugf(ctx, "Marked %d resources as recently deleted based on previous runs", marked)
+	}
+	if dead := applyDeadLetters(previous, results, now, e.runConfig.DeadLetterThreshold); dead > 0 {
+		e.logger.Warnf(ctx, "%d resources failed in %d or more consecutive runs and were moved to the dead-letter list", dead, e.runConfig.DeadLetterThreshold)
+	}
+}
+
+// recordRun persists this run's kinds and results to the history store.
+func (e *DriftAnalysisEngine) recordRun(ctx context.Context, kinds []domain.ResourceKind, startedAt time.Time, results []domain.ComparisonResult) {
+	if e.historyStore == nil {
+		return
+	}
+	run := domain.RunRecord{
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Kinds:      kinds,
+		Results:    results,
+	}
+	if err := e.historyStore.SaveRun(ctx, run); err != nil {
+		e.logger.Errorf(ctx, err, "Failed to save run to history store")
+	}
+}
+
+// applyTombstones rewrites MISSING results whose resource was on the platform in
+// the previous run of its kind, or was already a tombstone there, to
+// RECENTLY_DELETED while the deletion window started less than the grace period
+// ago. It returns the number of results marked.
+func applyTombstones(previous map[domain.ResourceKind]*domain.RunRecord, results []domain.ComparisonResult, now time.Time, grace time.Duration) int {
+	type key struct {
+		kind   domain.ResourceKind
+		source string
+	}
+	prior := make(map[key]domain.ComparisonResult)
+	for kind, run := range previous {
+		for _, res := range run.Results {
+			if res.ResourceKind == kind && res.SourceIdentifier != "" {
+				prior[key{res.ResourceKind, res.SourceIdentifier}] = res
+			}
+		}
+	}
+
+	marked := 0
+	for i := range results {
+		res := &results[i]
+		if res.Status != domain.StatusMissing || res.SourceIdentifier == "" {
+			continue
+		}
+		prev, ok := prior[key{res.ResourceKind, res.SourceIdentifier}]
+		if !ok {
+			continue
+		}
+
+		var lastSeen time.Time
+		switch prev.Status {
+		case domain.StatusNoDrift, domain.StatusDrifted, domain.StatusError, domain.StatusDeadLettered, domain.StatusPendingDeletion, domain.StatusAmbiguous:
+			lastSeen = previous[res.ResourceKind].StartedAt
+		case domain.StatusRecentlyDeleted:
+			if prev.DeletionWindow == nil {
+				continue
+			}
+			lastSeen = prev.DeletionWindow.From
+		default:
+			continue
+		}
+
+		if now.Sub(lastSeen) > grace {
+			continue
+		}
+		res.Status = domain.StatusRecentlyDeleted
+		res.DeletionWindow = &domain.TimeWindow{From: lastSeen, To: now}
+		marked++
+	}
+	return marked
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// memoryHistory keeps the saved runs in memory, oldest first.
+type memoryHistory struct {
+	mu   sync.Mutex
+	runs []domain.RunRecord
+}
+
+func (h *memoryHistory) SaveRun(_ context.Context, run domain.RunRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	run.Results = append([]domain.ComparisonResult(nil), run.Results...)
+	h.runs = append(h.runs, run)
+	return nil
+}
+
+func (h *memoryHistory) LatestRun(context.Context) (*domain.RunRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.runs) == 0 {
+		return nil, nil
+	}
+	run := h.runs[len(h.runs)-1]
+	return &run, nil
+}
+
+func (h *memoryHistory) LatestRunOfKind(_ context.Context, kind domain.ResourceKind) (*domain.RunRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := len(h.runs) - 1; i >= 0; i-- {
+		if h.runs[i].Covers(kind) {
+			run := h.runs[i]
+			return &run, nil
+		}
+	}
+	return nil, nil
+}
+
+func newHistoryTestEngine(t *testing.T, desired, actual map[domain.ResourceKind][]string, bucketErr error) (*DriftAnalysisEngine, *captureReporter) {
+	t.Helper()
+	registry := NewComponentRegistry()
+	require.NoError(t, registry.RegisterResourceComparer(funcComparer{kind: domain.KindStorageBucket, compare: func(context.Context) error { return bucketErr }}))
+	require.NoError(t, registry.RegisterResourceComparer(funcComparer{kind: domain.KindComputeInstance, compare: func(context.Context) error { return nil }}))
+	reporter := &captureReporter{}
+	engine, err := NewDriftAnalysisEngine(registry, idMatcher{}, reporter, nopLogger{}, EngineRunConfig{
+		ResourceKindsToProcess: []domain.ResourceKind{domain.KindStorageBucket, domain.KindComputeInstance},
+		AttributesToCheck: map[domain.ResourceKind][]string{
+			domain.KindStorageBucket:   {"tags"},
+			domain.KindComputeInstance: {"tags"},
+		},
+		SkipSelfTest: true,
+	}, fakeState{desired}, fakePlatform{actual}, WithHistoryStore(&memoryHistory{}))
+	require.NoError(t, err)
+	return engine, reporter
+}
+
+func resultOf(t *testing.T, results []domain.ComparisonResult, kind domain.ResourceKind) domain.ComparisonResult {
+	t.Helper()
+	for _, res := range results {
+		if res.ResourceKind == kind {
+			return res
+		}
+	}
+	require.Failf(t, "missing result", "no %s result in %v", kind, results)
+	return domain.ComparisonResult{}
+}
+
+func TestEngine_TombstoneUsesLatestRunOfKind(t *testing.T) {
+	ctx := context.Background()
+	desired := map[domain.ResourceKind][]string{
+		domain.KindStorageBucket:   {"logs"},
+		domain.KindComputeInstance: {"web"},
+	}
+	actual := map[domain.ResourceKind][]string{
+		domain.KindStorageBucket:   {"logs"},
+		domain.KindComputeInstance: {"web"},
+	}
+	engine, reporter := newHistoryTestEngine(t, desired, actual, nil)
+
+	require.NoError(t, engine.RunKinds(ctx, []domain.ResourceKind{domain.KindStorageBucket, domain.KindComputeInstance}))
+	delete(actual, domain.KindStorageBucket)
+	// A run of the other kind in between must not hide that the bucket was seen.
+	require.NoError(t, engine.RunKinds(ctx, []domain.ResourceKind{domain.KindComputeInstance}))
+	require.NoError(t, engine.RunKinds(ctx, []domain.ResourceKind{domain.KindStorageBucket}))
+
+	bucket := resultOf(t, reporter.results, domain.KindStorageBucket)
+	assert.Equal(t, domain.StatusRecentlyDeleted, bucket.Status)
+	assert.NotNil(t, bucket.DeletionWindow)
+}
+
+func TestEngine_DeadLetterStreakSpansRunsOfOtherKinds(t *testing.T) {
+	ctx := context.Background()
+	resources := map[domain.ResourceKind][]string{
+		domain.KindStorageBucket:   {"logs"},
+		domain.KindComputeInstance: {"web"},
+	}
+	engine, reporter := newHistoryTestEngine(t, resources, resources, fmt.Errorf("access denied"))
+
+	for run := 1; run <= defaultDeadLetterThreshold; run++ {
+		require.NoError(t, engine.RunKinds(ctx, []domain.ResourceKind{domain.KindStorageBucket}))
+		bucket := resultOf(t, reporter.results, domain.KindStorageBucket)
+		require.NotNil(t, bucket.FailureStreak)
+		assert.Equal(t, run, bucket.FailureStreak.Runs, "bucket failure streak after run %d", run)
+
+		require.NoError(t, engine.RunKinds(ctx, []domain.ResourceKind{domain.KindComputeInstance}))
+		assert.Equal(t, domain.StatusNoDrift, resultOf(t, reporter.results, domain.KindComputeInstance).Status)
+	}
+	// The last bucket run before the instance run reached the threshold.
+	runs := engine.historyStore.(*memoryHistory).runs
+	bucket := resultOf(t, runs[len(runs)-2].Results, domain.KindStorageBucket)
+	assert.Equal(t, domain.StatusDeadLettered, bucket.Status)
+}
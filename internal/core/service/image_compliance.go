@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"golang.org/x/sync/errgroup"
+)
+
+// WithImageApprovalSource checks every compute instance of a run against the
+// images approved by the given source and reports the others as unapproved
+// image findings.
+func WithImageApprovalSource(source ports.ImageApprovalSource) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if source != nil {
+			e.imageSource = source
+		}
+	}
+}
+
+// startImageCompliance checks the compute instances of the match result in the
+// background, appending a finding for each instance running an unapproved image
+// to finalResults. The returned channel is closed when the check is done.
+func (e *DriftAnalysisEngine) startImageCompliance(
+	ctx context.Context,
+	matchResult ports.MatchingResult,
+	finalResults *[]domain.ComparisonResult,
+	finalResultsMutex *sync.Mutex,
+) <-chan struct{} {
+	done := make(chan struct{})
+	if e.imageSource == nil {
+		close(done)
+		return done
+	}
+
+	type instance struct {
+		actual   domain.PlatformResource
+		sourceID string
+	}
+	var instances []instance
+	for _, pair := range matchResult.Matched {
+		if pair.Actual.Metadata().Kind == domain.KindComputeInstance {
+			instances = append(instances, instance{actual: pair.Actual, sourceID: pair.Desired.Metadata().SourceIdentifier})
+		}
+	}
+	for _, res := range matchResult.UnmatchedActual {
+		if res.Metadata().Kind == domain.KindComputeInstance {
+			instances = append(instances, instance{actual: res})
+		}
+	}
+
+	go func() {
+		defer close(done)
+		e.logger.Debugf(ctx, "[Stage 3] Checking %d instance(s) against the %s image approval source", len(instances), e.imageSource.Type())
+		g, gCtx := errgroup.WithContext(ctx)
+		g.SetLimit(e.runConfig.Concurrency)
+		for _, inst := range instances {
+			inst := inst
+			g.Go(func() error {
+				finding, ok := e.checkInstanceImage(gCtx, inst.actual, inst.sourceID)
+				if ok {
+					finalResultsMutex.Lock()
+					*finalResults = append(*finalResults, finding)
+					finalResultsMutex.Unlock()
+					e.events.comparisonResult.publish(finding)
+				}
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}()
+	return done
+}
+
+// checkInstanceImage returns an unapproved image finding when the instance runs
+// an image the approval source does not list for it. Instances the source does
+// not cover, and instances whose attributes cannot be read, are not reported.
+func (e *DriftAnalysisEngine) checkInstanceImage(ctx context.Context, actual domain.PlatformResource, sourceID string) (domain.ComparisonResult, bool) {
+	if ctx.Err() != nil {
+		return domain.ComparisonResult{}, false
+	}
+	meta := actual.Metadata()
+	attrs, err := actual.Attributes(ctx)
+	if err != nil && attrs == nil {
+		e.logger.Warnf(ctx, "Skipping image approval check for %s: %v", meta.ProviderAssignedID, err)
+		return domain.ComparisonResult{}, false
+	}
+	imageID, _ := attrs[domain.ComputeImageIDKey].(string)
+	if imageID == "" {
+		return domain.ComparisonResult{}, false
+	}
+	tags, _ := attrs[domain.KeyTags].(map[string]string)
+
+	approved, covered, err := e.imageSource.ApprovedImages(ctx, meta.Region, tags)
+	if err != nil {
+		e.logger.Warnf(ctx, "Skipping image approval check for %s: %v", meta.ProviderAssignedID, err)
+		return domain.ComparisonResult{}, false
+	}
+	if !covered {
+		e.logger.Debugf(ctx, "No approved images listed for instance %s in %s, skipping image check", meta.ProviderAssignedID, meta.Region)
+		return domain.ComparisonResult{}, false
+	}
+	for _, id := range approved {
+		if id == imageID {
+			return domain.ComparisonResult{}, false
+		}
+	}
+
+	e.logger.Warnf(ctx, "Instance %s runs unapproved image %s", meta.ProviderAssignedID, imageID)
+	desiredMeta := domain.ResourceMetadata{SourceIdentifier: sourceID}
+	return domain.ComparisonResult{
+		Status:             domain.StatusUnapprovedImage,
+		ResourceKind:       meta.Kind,
+		SourceIdentifier:   sourceID,
+		ProviderType:       meta.ProviderType,
+		ProviderAssignedID: meta.ProviderAssignedID,
+		Differences: []domain.AttributeDiff{{
+			AttributeName: domain.ComputeImageIDKey,
+			ExpectedValue: approved,
+			ActualValue:   imageID,
+			Details: fmt.Sprintf("Image %s is not approved by the %s source (approved: %s)",
+				imageID, e.imageSource.Type(), strings.Join(approved, ", ")),
+			Severity: domain.SeverityWarning,
+		}},
+		Links: e.buildLinks(meta.Kind, desiredMeta, meta),
+	}, true
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// MergingReporter keeps the latest results of every kind across scheduled runs
+// that each cover only some kinds, and hands the merged set to the wrapped
+// reporter, so every report shows the whole estate rather than just the kinds
+// that were due.
+type MergingReporter struct {
+	inner ports.Reporter
+
+	mu      sync.Mutex
+	latest  map[domain.ResourceKind][]domain.ComparisonResult
+	scanned []domain.ResourceKind
+}
+
+func NewMergingReporter(inner ports.Reporter) *MergingReporter {
+	return &MergingReporter{
+		inner:  inner,
+		latest: make(map[domain.ResourceKind][]domain.ComparisonResult),
+	}
+}
+
+// BeginRun records the kinds covered by the next report. Their previous results
+// are replaced even if the run produces none for a kind.
+func (m *MergingReporter) BeginRun(kinds []domain.ResourceKind) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scanned = append([]domain.ResourceKind(nil), kinds...)
+}
+
+// SetStateIssues forwards the state source issues to the wrapped reporter.
+func (m *MergingReporter) SetStateIssues(issues []domain.StateIssue) {
+	if r, ok := m.inner.(ports.StateIssueReporter); ok {
+		r.SetStateIssues(issues)
+	}
+}
+
+// SetRunAnnotations forwards the run annotations to the wrapped reporter.
+func (m *MergingReporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	if r, ok := m.inner.(ports.RunAnnotationReporter); ok {
+		r.SetRunAnnotations(annotations)
+	}
+}
+
+func (m *MergingReporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	return m.inner.Report(ctx, m.merge(results))
+}
+
+func (m *MergingReporter) merge(results []domain.ComparisonResult) []domain.ComparisonResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fresh := make(map[domain.ResourceKind][]domain.ComparisonResult, len(m.scanned))
+	for _, kind := range m.scanned {
+		fresh[kind] = nil
+	}
+	for _, res := range results {
+		fresh[res.ResourceKind] = append(fresh[res.ResourceKind], res)
+	}
+	for kind, kindResults := range fresh {
+		m.latest[kind] = kindResults
+	}
+
+	merged := make([]domain.ComparisonResult, 0, len(results))
+	for _, kindResults := range m.latest {
+		merged = append(merged, kindResults...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Priority != merged[j].Priority {
+			return merged[i].Priority > merged[j].Priority
+		}
+		return merged[i].ResourceKind < merged[j].ResourceKind
+	})
+	return merged
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// WithScanMetrics records the outcome of every run in the given metrics.
+func WithScanMetrics(metrics ports.ScanMetrics) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if metrics != nil {
+			e.scanMetrics = metrics
+		}
+	}
+}
+
+func (e *DriftAnalysisEngine) observeScan(kinds []domain.ResourceKind, startedAt time.Time, results []domain.ComparisonResult, err error) {
+	if e.scanMetrics == nil {
+		return
+	}
+	e.scanMetrics.ObserveScan(kinds, time.Since(startedAt), results, err)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// WithNotifier sends the reported results of every run to the notifier.
+func WithNotifier(notifier ports.Notifier) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if notifier != nil {
+			e.notifiers = append(e.notifiers, notifier)
+		}
+	}
+}
+
+// notify hands the reported results to every notifier. A failed notification
+// is logged and does not fail the run, whose report has already been written.
+func (e *DriftAnalysisEngine) notify(ctx context.Context, results []domain.ComparisonResult) {
+	for _, notifier := range e.notifiers {
+		notifyCtx, span := e.startSpan(ctx, "drift.notify", attrNotifier.String(notifier.Name()))
+		err := notifier.Notify(notifyCtx, results)
+		endSpan(span, err)
+		if err != nil {
+			e.logger.Errorf(ctx, err, "Failed to send %s notification", notifier.Name())
+		}
+	}
+}
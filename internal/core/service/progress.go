@@ -0,0 +1,35 @@
+package service
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// WithProgressObserver reports the progress of every run to the observer.
+func WithProgressObserver(observer ports.ProgressObserver) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if observer != nil {
+			e.progressObserver = observer
+		}
+	}
+}
+
+func (e *DriftAnalysisEngine) progress(event domain.ProgressEvent) {
+	if e.progressObserver != nil {
+		e.progressObserver.Progress(event)
+	}
+}
+
+// progressMatched reports the matched pairs of a matching result per kind.
+func (e *DriftAnalysisEngine) progressMatched(pairs []ports.MatchedPair) {
+	if e.progressObserver == nil {
+		return
+	}
+	counts := make(map[domain.ResourceKind]int)
+	for _, pair := range pairs {
+		counts[pair.Desired.Metadata().Kind]++
+	}
+	for kind, count := range counts {
+		e.progress(domain.ProgressEvent{Stage: domain.ProgressMatched, Kind: kind, Count: count})
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// runAnnotations returns the annotations recorded by the state and platform
+// providers, oldest first.
+func (e *DriftAnalysisEngine) runAnnotations() []domain.RunAnnotation {
+	var annotations []domain.RunAnnotation
+	for _, provider := range []any{e.stateProvider, e.platformProvider} {
+		if source, ok := provider.(ports.RunAnnotationSource); ok {
+			annotations = append(annotations, source.RunAnnotations()...)
+		}
+	}
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].Time.Before(annotations[j].Time)
+	})
+	return annotations
+}
+
+// attachRunAnnotations hands the providers' annotations to reporters that render them.
+func (e *DriftAnalysisEngine) attachRunAnnotations() {
+	annotationReporter, ok := e.reporter.(ports.RunAnnotationReporter)
+	if !ok {
+		return
+	}
+	annotationReporter.SetRunAnnotations(e.runAnnotations())
+}
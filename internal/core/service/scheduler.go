@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// KindSchedule is how often a kind is scanned in daemon mode.
+type KindSchedule struct {
+	Kind     domain.ResourceKind
+	Interval time.Duration
+}
+
+// Scheduler runs the engine in daemon mode, scanning each kind on its own
+// cadence. Kinds that fall due together are scanned in a single run, and runs
+// never overlap.
+type Scheduler struct {
+	runner    ports.KindScopedRunner
+	logger    ports.Logger
+	schedules []KindSchedule
+	merger    *MergingReporter
+	now       func() time.Time
+	after     func(time.Duration) <-chan time.Time
+
+	statusMu sync.RWMutex
+	status   domain.SchedulerStatus
+}
+
+// SchedulerOption configures optional scheduler dependencies.
+type SchedulerOption func(*Scheduler)
+
+// WithMergingReporter tells the reporter which kinds each run covers, so that
+// every report merges them with the latest results of the other kinds.
+func WithMergingReporter(merger *MergingReporter) SchedulerOption {
+	return func(s *Scheduler) {
+		if merger != nil {
+			s.merger = merger
+		}
+	}
+}
+
+// NewScheduler creates a scheduler for the given per-kind schedules, in the
+// order kinds should be scanned when several are due at once.
+func NewScheduler(runner ports.KindScopedRunner, schedules []KindSchedule, logger ports.Logger, opts ...SchedulerOption) (*Scheduler, error) {
+	if runner == nil {
+		return nil, errors.New(errors.CodeConfigValidation, "scheduler runner cannot be nil")
+	}
+	if len(schedules) == 0 {
+		return nil, errors.New(errors.CodeConfigValidation, "no resource kinds scheduled for daemon mode")
+	}
+	for _, sched := range schedules {
+		if sched.Interval <= 0 {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("invalid scan interval %s for kind '%s'", sched.Interval, sched.Kind),
+				"Set a positive 'scan_interval' for the resource or 'daemon.default_interval'.")
+		}
+	}
+	s := &Scheduler{
+		runner:    runner,
+		logger:    logger,
+		schedules: schedules,
+		now:       time.Now,
+		after:     time.After,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Run scans every kind immediately and then whenever its interval has elapsed,
+// until the context is cancelled. A failed run is logged and the kinds are
+// scanned again at their next due time.
+func (s *Scheduler) Run(ctx context.Context) error {
+	next := make(map[domain.ResourceKind]time.Time, len(s.schedules))
+	start := s.now()
+	s.updateStatus(func(st *domain.SchedulerStatus) {
+		st.Running = true
+		st.StartedAt = start
+	})
+	defer s.updateStatus(func(st *domain.SchedulerStatus) {
+		st.Running = false
+		st.NextScanAt = time.Time{}
+	})
+	for _, sched := range s.schedules {
+		next[sched.Kind] = start
+		s.logger.Infof(ctx, "[Scheduler] Scanning kind %s every %s", sched.Kind, sched.Interval)
+	}
+
+	for {
+		now := s.now()
+		due := s.dueKinds(next, now)
+		if len(due) > 0 {
+			s.runOnce(ctx, due)
+			if ctx.Err() != nil {
+				s.logger.Infof(ctx, "[Scheduler] Stopping daemon")
+				return nil
+			}
+			for _, sched := range s.schedules {
+				if !next[sched.Kind].After(now) {
+					next[sched.Kind] = now.Add(sched.Interval)
+				}
+			}
+			continue
+		}
+
+		wait := s.untilNext(next, now)
+		s.updateStatus(func(st *domain.SchedulerStatus) { st.NextScanAt = now.Add(wait) })
+		s.logger.Debugf(ctx, "[Scheduler] Next scan in %s", wait.Round(time.Second))
+		select {
+		case <-ctx.Done():
+			s.logger.Infof(ctx, "[Scheduler] Stopping daemon")
+			return nil
+		case <-s.after(wait):
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, kinds []domain.ResourceKind) {
+	s.logger.Infof(ctx, "[Scheduler] Starting scheduled scan of %v", kinds)
+	s.updateStatus(func(st *domain.SchedulerStatus) {
+		st.Scanning = true
+		st.ScanStartedAt = s.now()
+	})
+	if s.merger != nil {
+		s.merger.BeginRun(kinds)
+	}
+	err := s.runner.RunKinds(ctx, kinds)
+	if err != nil && ctx.Err() != nil {
+		s.updateStatus(func(st *domain.SchedulerStatus) { st.Scanning = false })
+		return
+	}
+
+	finished := s.now()
+	s.updateStatus(func(st *domain.SchedulerStatus) {
+		st.Scanning = false
+		st.LastRunAt = finished
+		if err != nil {
+			st.LastError = err.Error()
+			st.ConsecutiveFailures++
+			return
+		}
+		st.LastSuccessAt = finished
+		st.LastError = ""
+		st.ConsecutiveFailures = 0
+	})
+	if err != nil {
+		s.logger.Errorf(ctx, err, "[Scheduler] Scheduled scan of %v failed, retrying at the next due time", kinds)
+		return
+	}
+	s.logger.Infof(ctx, "[Scheduler] Scheduled scan of %v finished", kinds)
+}
+
+// Status returns a snapshot of the scheduler state. It is safe to call while
+// the scheduler runs.
+func (s *Scheduler) Status() domain.SchedulerStatus {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+	return s.status
+}
+
+// Kinds returns the scheduled kinds.
+func (s *Scheduler) Kinds() []domain.ResourceKind {
+	kinds := make([]domain.ResourceKind, len(s.schedules))
+	for i, sched := range s.schedules {
+		kinds[i] = sched.Kind
+	}
+	return kinds
+}
+
+// LongestInterval returns the longest scan interval of the scheduled kinds.
+func (s *Scheduler) LongestInterval() time.Duration {
+	var longest time.Duration
+	for _, sched := range s.schedules {
+		if sched.Interval > longest {
+			longest = sched.Interval
+		}
+	}
+	return longest
+}
+
+func (s *Scheduler) updateStatus(update func(*domain.SchedulerStatus)) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	update(&s.status)
+}
+
+func (s *Scheduler) dueKinds(next map[domain.ResourceKind]time.Time, now time.Time) []domain.ResourceKind {
+	var due []domain.ResourceKind
+	for _, sched := range s.schedules {
+		if !next[sched.Kind].After(now) {
+			due = append(due, sched.Kind)
+		}
+	}
+	return due
+}
+
+func (s *Scheduler) untilNext(next map[domain.ResourceKind]time.Time, now time.Time) time.Duration {
+	var earliest time.Time
+	for _, t := range next {
+		if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+	}
+	return earliest.Sub(now)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+// runSelfTest asks each provider that supports it to verify connectivity and
+// permissions for the kinds of the run before any listing starts.
+func (e *DriftAnalysisEngine) runSelfTest(ctx context.Context, kinds []domain.ResourceKind) error {
+	if e.runConfig.SkipSelfTest {
+		e.logger.Debugf(ctx, "[Stage 0] Provider self-test skipped")
+		return nil
+	}
+	providers := []struct {
+		name     string
+		provider any
+	}{
+		{name: e.stateProvider.Type(), provider: e.stateProvider},
+		{name: e.platformProvider.Type(), provider: e.platformProvider},
+	}
+	for _, p := range providers {
+		tester, ok := p.provider.(ports.SelfTester)
+		if !ok {
+			continue
+		}
+		e.logger.Debugf(ctx, "[Stage 0] Running self-test for %s provider", p.name)
+		if err := tester.SelfTest(ctx, kinds); err != nil {
+			e.logger.Errorf(ctx, err, "[Stage 0] Self-test failed for %s provider", p.name)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// stateIssues returns the non-fatal issues collected by the state provider, if
+// it reports any.
+func (e *DriftAnalysisEngine) stateIssues() []domain.StateIssue {
+	source, ok := e.stateProvider.(ports.StateIssueSource)
+	if !ok {
+		return nil
+	}
+	return source.StateIssues()
+}
+
+// checkStateIssues fails the run in strict mode when the state provider reported
+// any parse or evaluation issue. In lenient mode the issues are only logged here
+// and reported alongside the results.
+func (e *DriftAnalysisEngine) checkStateIssues(ctx context.Context) error {
+	issues := e.stateIssues()
+	if len(issues) == 0 {
+		return nil
+	}
+	if !e.runConfig.StrictStateParsing {
+		e.logger.Warnf(ctx, "[Stage 1a] State source reported %d issue(s); see the state source issues section of the report", len(issues))
+		return nil
+	}
+	err := errors.NewUserFacing(errors.CodeStateParseError,
+		fmt.Sprintf("state source reported %d issue(s) and strict mode is enabled", len(issues)),
+		"Fix the state source issues listed above, or run without --strict to report them without failing")
+	err.InternalDetails = formatStateIssues(issues)
+	e.logger.Errorf(ctx, err, "[Stage 1a] Failing run due to state source issues in strict mode")
+	return err
+}
+
+// attachStateIssues hands the state provider's issues to reporters that render them.
+func (e *DriftAnalysisEngine) attachStateIssues() {
+	issueReporter, ok := e.reporter.(ports.StateIssueReporter)
+	if !ok {
+		return
+	}
+	issueReporter.SetStateIssues(e.stateIssues())
+}
+
+func formatStateIssues(issues []domain.StateIssue) string {
+	var b strings.Builder
+	for _, issue := range issues {
+		b.WriteString("- ")
+		if issue.Location != "" {
+			b.WriteString(issue.Location + ": ")
+		}
+		b.WriteString(issue.Summary)
+		if issue.Address != "" {
+			b.WriteString(" (" + issue.Address + ")")
+		}
+		if issue.Detail != "" {
+			b.WriteString(": " + issue.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// tracerName is the instrumentation scope of the engine's spans.
+const tracerName = "github.com/olusolaa/infra-drift-detector/internal/core/service"
+
+// Span attribute keys shared by the engine's spans.
+const (
+	attrKinds      = attribute.Key("drift.kinds")
+	attrKind       = attribute.Key("drift.kind")
+	attrResources  = attribute.Key("drift.resources")
+	attrResults    = attribute.Key("drift.results")
+	attrSourceID   = attribute.Key("drift.source_id")
+	attrPlatformID = attribute.Key("drift.platform_id")
+	attrStatus     = attribute.Key("drift.status")
+	attrStreaming  = attribute.Key("drift.streaming")
+	attrWorkers    = attribute.Key("drift.workers")
+	attrNotifier   = attribute.Key("drift.notifier")
+)
+
+var noopTracer = noop.NewTracerProvider().Tracer(tracerName)
+
+// WithTracerProvider records a span for every run, its pipeline stages and
+// each resource comparison. Platform API calls made within a stage become
+// children of its span when the provider is instrumented with the same
+// tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) EngineOption {
+	return func(e *DriftAnalysisEngine) {
+		if provider != nil {
+			e.tracer = provider.Tracer(tracerName)
+		}
+	}
+}
+
+func (e *DriftAnalysisEngine) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return e.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends the span, marking it failed when err is set.
+func endSpan(span trace.Span, err error) {
+	markSpanFailed(span, err)
+	span.End()
+}
+
+// markSpanFailed records err on the span and sets its status to error. It
+// does nothing when err is nil.
+func markSpanFailed(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func kindNames(kinds []domain.ResourceKind) []string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return names
+}
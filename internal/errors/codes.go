@@ -13,6 +13,7 @@ const (
 	CodeStateParseError    Code = "STATE_PARSE_ERROR"
 	CodePlatformAPIError   Code = "PLATFORM_API_ERROR"
 	CodePlatformAuthError  Code = "PLATFORM_AUTH_ERROR"
+	CodePlatformThrottled  Code = "PLATFORM_THROTTLED"
 	CodeResourceNotFound   Code = "RESOURCE_NOT_FOUND"
 	CodeMatchingError      Code = "MATCHING_ERROR"
 	CodeComparisonError    Code = "COMPARISON_ERROR"
@@ -30,6 +31,34 @@ const (
 	CodeMappingError            Code = "MAPPING_ERROR"
 	CodeNotImplementedError     Code = "NOT_IMPLEMENTED_ERROR"
 	CodeUnsupportedStateVersion Code = "UNSUPPORTED_STATE_VERSION"
+	CodeStateDecryptError       Code = "STATE_DECRYPT_ERROR"
+
+	// History store error codes
+	CodeHistoryReadError  Code = "HISTORY_READ_ERROR"
+	CodeHistoryWriteError Code = "HISTORY_WRITE_ERROR"
+
+	// Baseline store error codes
+	CodeBaselineReadError  Code = "BASELINE_READ_ERROR"
+	CodeBaselineWriteError Code = "BASELINE_WRITE_ERROR"
+
+	// Startup self-test error codes
+	CodeSelfTestFailed Code = "SELF_TEST_FAILED"
+
+	// Result query error codes
+	CodeQueryParseError Code = "QUERY_PARSE_ERROR"
+
+	// Daemon health endpoint error codes
+	CodeHealthServerError Code = "HEALTH_SERVER_ERROR"
+
+	// Daemon metrics endpoint error codes
+	CodeMetricsServerError Code = "METRICS_SERVER_ERROR"
+
+	// Notification error codes
+	CodeNotificationError Code = "NOTIFICATION_ERROR"
+
+	// Terraform external data source error codes
+	CodeExternalQueryError Code = "EXTERNAL_QUERY_ERROR"
+	CodeDriftGateFailed    Code = "DRIFT_GATE_FAILED"
 	// Add more specific codes as needed
 )
 
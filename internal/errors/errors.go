@@ -149,6 +149,22 @@ func GetUserFacingMessage(err error) (string, string, bool) {
 	return "An unexpected error occurred.", "Check logs for more details.", false
 }
 
+// GetUserFacingDetails returns the InternalDetails of the first user-facing
+// AppError in the chain, e.g. rendered source snippets for configuration errors.
+func GetUserFacingDetails(err error) string {
+	for err != nil {
+		var appErr *AppError
+		if !errors.As(err, &appErr) {
+			return ""
+		}
+		if appErr.IsUserFacing {
+			return appErr.InternalDetails
+		}
+		err = errors.Unwrap(appErr)
+	}
+	return ""
+}
+
 func Unwrap(err error) error {
 	if err == nil {
 		return nil
@@ -0,0 +1,169 @@
+// Package estimate plans a scan before running it: from the resources of the
+// desired state it estimates how many platform API calls the scan makes and
+// how long it takes under the configured rate limit and concurrency, e.g.
+//
+//	drift-analyser --estimate
+//
+// so the limits can be tuned without spending a scan on it. The platform is
+// not called; resources missing from the desired state, which the scan also
+// lists as unmanaged, are not counted.
+package estimate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/filter"
+)
+
+// DefaultCallLatency is the typical duration of one platform API call, used
+// when none is configured.
+const DefaultCallLatency = 150 * time.Millisecond
+
+// Bottleneck is what bounds the duration of a scan.
+type Bottleneck string
+
+const (
+	// BottleneckRateLimit means the calls wait on the API rate limit.
+	BottleneckRateLimit Bottleneck = "rate limit"
+	// BottleneckConcurrency means the calls wait on the API responses of the
+	// concurrent workers.
+	BottleneckConcurrency Bottleneck = "concurrency"
+)
+
+// Options are the limits a scan runs under.
+type Options struct {
+	// Concurrency is the number of resources processed concurrently.
+	Concurrency int
+	// CallLatency is the typical duration of one API call. Zero uses
+	// DefaultCallLatency.
+	CallLatency time.Duration
+	// Filter leaves out the desired resources a scan would not process.
+	Filter *filter.Filter
+}
+
+// KindEstimate is the estimate for one resource kind.
+type KindEstimate struct {
+	Kind      domain.ResourceKind
+	Resources int
+	ports.APICallEstimate
+}
+
+// Calls returns the API calls of the kind.
+func (k KindEstimate) Calls() int {
+	return k.ListCalls + k.DetailCalls
+}
+
+// Estimate is the estimate for a whole scan.
+type Estimate struct {
+	Kinds             []KindEstimate
+	RequestsPerSecond int
+	Concurrency       int
+	CallLatency       time.Duration
+	// Duration is the longer of the time the rate limit lets the calls
+	// through in and the time the concurrent workers take to make them.
+	Duration   time.Duration
+	Bottleneck Bottleneck
+}
+
+// Calls returns the API calls of the scan.
+func (e *Estimate) Calls() int {
+	total := 0
+	for _, k := range e.Kinds {
+		total += k.Calls()
+	}
+	return total
+}
+
+// Plan estimates a scan of kinds from the resources the state provider lists
+// and the call model of the platform provider.
+func Plan(ctx context.Context, state ports.StateProvider, platform ports.APICallEstimator, kinds []domain.ResourceKind, opts Options) (*Estimate, error) {
+	est := &Estimate{
+		RequestsPerSecond: platform.RequestsPerSecond(),
+		Concurrency:       max(opts.Concurrency, 1),
+		CallLatency:       opts.CallLatency,
+	}
+	if est.CallLatency <= 0 {
+		est.CallLatency = DefaultCallLatency
+	}
+
+	for _, kind := range kinds {
+		resources, err := state.ListResources(ctx, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list desired %s resources: %w", kind, err)
+		}
+		count := 0
+		for _, res := range resources {
+			if opts.Filter == nil || opts.Filter.KeepDesired(res) {
+				count++
+			}
+		}
+		est.Kinds = append(est.Kinds, KindEstimate{
+			Kind:            kind,
+			Resources:       count,
+			APICallEstimate: platform.EstimateAPICalls(kind, count),
+		})
+	}
+
+	calls := float64(est.Calls())
+	byConcurrency := time.Duration(calls * float64(est.CallLatency) / float64(est.Concurrency))
+	est.Duration, est.Bottleneck = byConcurrency, BottleneckConcurrency
+	if est.RequestsPerSecond > 0 {
+		byRate := time.Duration(calls / float64(est.RequestsPerSecond) * float64(time.Second))
+		if byRate >= byConcurrency {
+			est.Duration, est.Bottleneck = byRate, BottleneckRateLimit
+		}
+	}
+	return est, nil
+}
+
+// Write prints the estimate as a table with one row per kind, followed by
+// the totals and the limit to raise for a faster scan.
+func (e *Estimate) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tRESOURCES\tLIST CALLS\tDETAIL CALLS\tTOTAL")
+	approximate := false
+	for _, k := range e.Kinds {
+		total := fmt.Sprint(k.Calls())
+		if !k.Modeled {
+			total += " *"
+			approximate = true
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\n", k.Kind, k.Resources, k.ListCalls, k.DetailCalls, total)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if approximate {
+		fmt.Fprintln(w, "* no call model for this kind; assumed one list call per 100 resources and one detail call per resource")
+	}
+
+	fmt.Fprintf(w, "\nEstimated API calls: %d\n", e.Calls())
+	fmt.Fprintf(w, "Estimated duration: %s (bound by the %s: %d requests/s, %d workers at ~%s per call)\n",
+		roundDuration(e.Duration), e.Bottleneck, e.RequestsPerSecond, e.Concurrency, e.CallLatency)
+	switch e.Bottleneck {
+	case BottleneckRateLimit:
+		fmt.Fprintln(w, "Raise the platform's api_rps for a faster scan, within the API quotas of the account.")
+	case BottleneckConcurrency:
+		fmt.Fprintln(w, "Raise settings.concurrency for a faster scan; api_rps has headroom.")
+	}
+	_, err := fmt.Fprintln(w, "Resources only on the platform are not counted; the scan lists them as well.")
+	return err
+}
+
+// roundDuration rounds d to a precision that reads well at its magnitude.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second)
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond)
+	default:
+		return d.Round(time.Millisecond)
+	}
+}
@@ -0,0 +1,91 @@
+package estimate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports/mocks"
+	"github.com/olusolaa/infra-drift-detector/internal/filter"
+)
+
+type stateResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r stateResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r stateResource) Attributes() map[string]any        { return r.attrs }
+
+// callModel models buckets with ten calls each and leaves instances unmodeled.
+type callModel struct{ rps int }
+
+func (m callModel) EstimateAPICalls(kind domain.ResourceKind, resources int) ports.APICallEstimate {
+	if kind == domain.KindStorageBucket {
+		return ports.APICallEstimate{ListCalls: 1, DetailCalls: resources * 10, Modeled: true}
+	}
+	return ports.APICallEstimate{ListCalls: 1, DetailCalls: resources}
+}
+
+func (m callModel) RequestsPerSecond() int { return m.rps }
+
+func resources(n int, region string) []domain.StateResource {
+	out := make([]domain.StateResource, n)
+	for i := range out {
+		out[i] = stateResource{meta: domain.ResourceMetadata{Region: region}, attrs: map[string]any{}}
+	}
+	return out
+}
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+	state := mocks.NewStateProvider(t)
+	state.On("ListResources", ctx, domain.KindStorageBucket).Return(resources(30, "eu-west-1"), nil)
+	state.On("ListResources", ctx, domain.KindComputeInstance).Return(append(resources(8, "eu-west-1"), resources(2, "us-east-1")...), nil)
+	f, err := filter.New(filter.Config{Include: filter.Selector{Regions: []string{"eu-west-1"}}})
+	require.NoError(t, err)
+	kinds := []domain.ResourceKind{domain.KindStorageBucket, domain.KindComputeInstance}
+
+	t.Run("rate limited", func(t *testing.T) {
+		est, err := Plan(ctx, state, callModel{rps: 10}, kinds, Options{Concurrency: 10, Filter: f})
+		require.NoError(t, err)
+		require.Len(t, est.Kinds, 2)
+		assert.Equal(t, 30, est.Kinds[0].Resources)
+		assert.Equal(t, 301, est.Kinds[0].Calls())
+		assert.Equal(t, 8, est.Kinds[1].Resources, "filtered out resources are not counted")
+		assert.Equal(t, 310, est.Calls())
+		assert.Equal(t, 31*time.Second, est.Duration)
+		assert.Equal(t, BottleneckRateLimit, est.Bottleneck)
+	})
+
+	t.Run("concurrency bound", func(t *testing.T) {
+		est, err := Plan(ctx, state, callModel{rps: 100}, kinds, Options{Concurrency: 2, CallLatency: 200 * time.Millisecond, Filter: f})
+		require.NoError(t, err)
+		assert.Equal(t, 31*time.Second, est.Duration)
+		assert.Equal(t, BottleneckConcurrency, est.Bottleneck)
+
+		var out bytes.Buffer
+		require.NoError(t, est.Write(&out))
+		assert.Contains(t, out.String(), "Estimated API calls: 310")
+		assert.Contains(t, out.String(), "Estimated duration: 31s (bound by the concurrency: 100 requests/s, 2 workers at ~200ms per call)")
+		assert.Contains(t, out.String(), "9 *\n", "unmodeled kinds are marked")
+		assert.Contains(t, out.String(), "Raise settings.concurrency")
+	})
+}
+
+func TestPlan_StateError(t *testing.T) {
+	ctx := context.Background()
+	state := mocks.NewStateProvider(t)
+	state.On("ListResources", ctx, domain.KindStorageBucket).Return(nil, errors.New("state unreadable"))
+
+	_, err := Plan(ctx, state, callModel{rps: 10}, []domain.ResourceKind{domain.KindStorageBucket}, Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "state unreadable")
+}
@@ -0,0 +1,151 @@
+// Package exitpolicy decides the process exit code of a scan from its results,
+// so CI pipelines can gate on the conditions they care about, e.g.
+//
+//	drift-analyser --fail-on=drift,missing
+//
+// exits with the code of drift when a resource drifted and with the code of
+// missing when a resource is missing, while unmanaged resources and errors
+// are reported without failing the scan.
+package exitpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Condition is a kind of finding a scan can fail on.
+type Condition string
+
+const (
+	// ConditionDrift is a drifted resource or an instance running an
+	// unapproved image.
+	ConditionDrift Condition = "drift"
+	// ConditionMissing is a resource of the desired state not found on the
+	// platform.
+	ConditionMissing Condition = "missing"
+	// ConditionUnmanaged is a platform resource not in the desired state.
+	ConditionUnmanaged Condition = "unmanaged"
+	// ConditionError is a resource that could not be compared, including
+	// dead-lettered ones and ones matched ambiguously.
+	ConditionError Condition = "error"
+)
+
+// precedence orders the conditions from the most to the least severe. When
+// a scan meets several conditions it exits with the code of the first one.
+var precedence = []Condition{ConditionError, ConditionMissing, ConditionDrift, ConditionUnmanaged}
+
+// DefaultCodes are the exit codes of the conditions not overridden in the
+// configuration. Exit code 1 is left to failed runs.
+var DefaultCodes = map[Condition]int{
+	ConditionDrift:     2,
+	ConditionMissing:   3,
+	ConditionUnmanaged: 4,
+	ConditionError:     5,
+}
+
+var statusConditions = map[domain.ComparisonStatus]Condition{
+	domain.StatusDrifted:         ConditionDrift,
+	domain.StatusUnapprovedImage: ConditionDrift,
+	domain.StatusMissing:         ConditionMissing,
+	domain.StatusUnmanaged:       ConditionUnmanaged,
+	domain.StatusError:           ConditionError,
+	domain.StatusDeadLettered:    ConditionError,
+	domain.StatusAmbiguous:       ConditionError,
+}
+
+// Config selects the conditions that fail a scan and their exit codes.
+type Config struct {
+	// FailOn lists the conditions that fail the scan. Empty never fails it.
+	FailOn []string `yaml:"fail_on" mapstructure:"fail_on"`
+	// Codes overrides the exit code of a condition.
+	Codes map[string]int `yaml:"codes" mapstructure:"codes"`
+}
+
+// Policy maps the results of a scan to an exit code.
+type Policy struct {
+	failOn map[Condition]bool
+	codes  map[Condition]int
+}
+
+// NewPolicy validates the configuration. A nil configuration never fails.
+func NewPolicy(cfg *Config) (*Policy, error) {
+	p := &Policy{failOn: make(map[Condition]bool), codes: make(map[Condition]int, len(DefaultCodes))}
+	for cond, code := range DefaultCodes {
+		p.codes[cond] = code
+	}
+	if cfg == nil {
+		return p, nil
+	}
+
+	for _, raw := range cfg.FailOn {
+		for _, name := range strings.Split(raw, ",") {
+			if strings.TrimSpace(name) == "" {
+				continue
+			}
+			cond, err := parseCondition(name)
+			if err != nil {
+				return nil, err
+			}
+			p.failOn[cond] = true
+		}
+	}
+	for name, code := range cfg.Codes {
+		cond, err := parseCondition(name)
+		if err != nil {
+			return nil, err
+		}
+		if code < 2 || code > 125 {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("exit code %d of condition '%s' is out of range", code, cond),
+				"Use exit codes from 2 to 125; 0 means success and 1 a failed run.")
+		}
+		p.codes[cond] = code
+	}
+	return p, nil
+}
+
+func parseCondition(name string) (Condition, error) {
+	cond := Condition(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := DefaultCodes[cond]; !ok {
+		return "", errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("unsupported fail-on condition '%s'", name),
+			"Supported: drift, missing, unmanaged, error")
+	}
+	return cond, nil
+}
+
+// Enabled reports whether any condition fails the scan.
+func (p *Policy) Enabled() bool {
+	return len(p.failOn) > 0
+}
+
+// FailOn returns the conditions that fail the scan, sorted.
+func (p *Policy) FailOn() []Condition {
+	conds := make([]Condition, 0, len(p.failOn))
+	for cond := range p.failOn {
+		conds = append(conds, cond)
+	}
+	sort.Slice(conds, func(i, j int) bool { return conds[i] < conds[j] })
+	return conds
+}
+
+// Evaluate returns the exit code for the results and the condition that
+// decided it, or 0 and an empty condition when no selected condition is met.
+func (p *Policy) Evaluate(results []domain.ComparisonResult) (int, Condition) {
+	met := make(map[Condition]bool)
+	for _, res := range results {
+		if cond, ok := statusConditions[res.Status]; ok && p.failOn[cond] {
+			met[cond] = true
+		}
+	}
+	for _, cond := range precedence {
+		if met[cond] {
+			return p.codes[cond], cond
+		}
+	}
+	return 0, ""
+}
@@ -0,0 +1,51 @@
+package exitpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	drifted := domain.ComparisonResult{Status: domain.StatusDrifted}
+	missing := domain.ComparisonResult{Status: domain.StatusMissing}
+	unmanaged := domain.ComparisonResult{Status: domain.StatusUnmanaged}
+	deadLettered := domain.ComparisonResult{Status: domain.StatusDeadLettered}
+	clean := domain.ComparisonResult{Status: domain.StatusNoDrift}
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		results  []domain.ComparisonResult
+		wantCode int
+		wantCond Condition
+	}{
+		{"no policy never fails", nil, []domain.ComparisonResult{drifted, missing}, 0, ""},
+		{"clean run", &Config{FailOn: []string{"drift"}}, []domain.ComparisonResult{clean}, 0, ""},
+		{"unselected condition", &Config{FailOn: []string{"drift,missing"}}, []domain.ComparisonResult{unmanaged}, 0, ""},
+		{"drift", &Config{FailOn: []string{"drift,missing"}}, []domain.ComparisonResult{clean, drifted}, 2, ConditionDrift},
+		{"missing takes precedence over drift", &Config{FailOn: []string{"drift", "missing"}}, []domain.ComparisonResult{drifted, missing}, 3, ConditionMissing},
+		{"dead-lettered counts as error", &Config{FailOn: []string{"error"}}, []domain.ComparisonResult{deadLettered}, 5, ConditionError},
+		{"overridden code", &Config{FailOn: []string{" Unmanaged "}, Codes: map[string]int{"unmanaged": 10}}, []domain.ComparisonResult{unmanaged}, 10, ConditionUnmanaged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewPolicy(tt.cfg)
+			require.NoError(t, err)
+			code, cond := policy.Evaluate(tt.results)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantCond, cond)
+		})
+	}
+}
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	_, err := NewPolicy(&Config{FailOn: []string{"drift,changes"}})
+	assert.ErrorContains(t, err, "unsupported fail-on condition 'changes'")
+
+	_, err = NewPolicy(&Config{Codes: map[string]int{"drift": 1}})
+	assert.ErrorContains(t, err, "out of range")
+}
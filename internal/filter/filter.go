@@ -0,0 +1,253 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Selector selects resources by tags, name, ID and region as written in the
+// config file. Criteria left empty select every resource.
+type Selector struct {
+	// Tags maps tag keys to a glob pattern the tag value must match. An empty
+	// pattern only requires the tag to be set.
+	Tags map[string]string `yaml:"tags" mapstructure:"tags"`
+	// Names are path.Match globs, one of which the resource name must match.
+	Names []string `yaml:"names" mapstructure:"names"`
+	// IDPattern is a regular expression the platform ID must match.
+	IDPattern string `yaml:"id_pattern" mapstructure:"id_pattern"`
+	// Regions are the regions, one of which the resource must be in.
+	Regions []string `yaml:"regions" mapstructure:"regions"`
+}
+
+func (s Selector) isZero() bool {
+	return len(s.Tags) == 0 && len(s.Names) == 0 && s.IDPattern == "" && len(s.Regions) == 0
+}
+
+// Config scopes a run to the resources matching Include and none of the
+// criteria of Exclude, e.g. the resources of one service or team.
+type Config struct {
+	Include Selector `yaml:"include" mapstructure:"include"`
+	Exclude Selector `yaml:"exclude" mapstructure:"exclude"`
+}
+
+// Filter decides which desired and actual resources take part in a run. Both
+// sides are filtered alike, so that a filtered out resource is reported
+// neither as missing nor as unmanaged.
+//
+// A resource is kept when it matches every criterion of the include selector
+// and none of the criteria of the exclude selector. A criterion whose value is
+// unknown for a resource, such as the region or ID of a desired resource not
+// created yet, neither includes nor excludes it.
+type Filter struct {
+	include   Selector
+	exclude   Selector
+	includeID *regexp.Regexp
+	excludeID *regexp.Regexp
+}
+
+// New validates the config and builds a filter. A nil filter is returned when
+// no criteria are set, which callers can treat as keeping every resource.
+func New(cfg Config) (*Filter, error) {
+	if cfg.Include.isZero() && cfg.Exclude.isZero() {
+		return nil, nil
+	}
+	f := &Filter{include: cfg.Include, exclude: cfg.Exclude}
+	var err error
+	if f.includeID, err = compileSelector("include", cfg.Include); err != nil {
+		return nil, err
+	}
+	if f.excludeID, err = compileSelector("exclude", cfg.Exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// compileSelector validates the globs of the selector and compiles its ID pattern.
+func compileSelector(side string, s Selector) (*regexp.Regexp, error) {
+	for key, pattern := range s.Tags {
+		if key == "" {
+			return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("filter %s: tag key is required", side))
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("filter %s: invalid pattern %q for tag %q", side, pattern, key))
+		}
+	}
+	for _, pattern := range s.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("filter %s: invalid name pattern %q", side, pattern))
+		}
+	}
+	if s.IDPattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(s.IDPattern)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeConfigValidation, fmt.Sprintf("filter %s: invalid ID pattern %q", side, s.IDPattern))
+	}
+	return re, nil
+}
+
+// KeepDesired reports whether the desired resource takes part in the run.
+func (f *Filter) KeepDesired(res domain.StateResource) bool {
+	if f == nil || res == nil {
+		return true
+	}
+	var attrs map[string]any
+	if f.needsAttributes() {
+		attrs = res.Attributes()
+	}
+	return f.keep(res.Metadata(), attrs)
+}
+
+// KeepActual reports whether the actual resource takes part in the run. Its
+// attributes are only fetched when a tag or name criterion is set; a resource
+// whose attributes cannot be fetched is kept so the comparison reports the error.
+func (f *Filter) KeepActual(ctx context.Context, res domain.PlatformResource) bool {
+	if f == nil || res == nil {
+		return true
+	}
+	var attrs map[string]any
+	if f.needsAttributes() {
+		var err error
+		if attrs, err = res.Attributes(ctx); err != nil {
+			return true
+		}
+	}
+	return f.keep(res.Metadata(), attrs)
+}
+
+// PlatformFilters returns the part of the include selector platform
+// providers can apply while listing, as generic "tag:<key>" and name filters:
+// tags with a literal value and a single literal name. It never selects more
+// than the filter does, so the resources listed are still filtered afterwards.
+func (f *Filter) PlatformFilters() map[string]string {
+	filters := make(map[string]string)
+	if f == nil {
+		return filters
+	}
+	for key, pattern := range f.include.Tags {
+		if pattern != "" && !isGlob(pattern) {
+			filters[domain.TagPrefix+key] = pattern
+		}
+	}
+	if len(f.include.Names) == 1 && !isGlob(f.include.Names[0]) {
+		filters[domain.KeyName] = f.include.Names[0]
+	}
+	return filters
+}
+
+func (f *Filter) needsAttributes() bool {
+	return len(f.include.Tags) > 0 || len(f.include.Names) > 0 ||
+		len(f.exclude.Tags) > 0 || len(f.exclude.Names) > 0
+}
+
+func (f *Filter) keep(meta domain.ResourceMetadata, attrs map[string]any) bool {
+	r := resource{meta: meta, tags: tagsOf(attrs), name: nameOf(meta, attrs)}
+	return r.matchesAll(f.include, f.includeID) && !r.matchesAny(f.exclude, f.excludeID)
+}
+
+// resource holds the values of a resource the criteria are checked against.
+// Empty name, ID and region values are unknown.
+type resource struct {
+	meta domain.ResourceMetadata
+	tags map[string]string
+	name string
+}
+
+func (r resource) matchesAll(s Selector, id *regexp.Regexp) bool {
+	for key, pattern := range s.Tags {
+		if !r.matchesTag(key, pattern) {
+			return false
+		}
+	}
+	if len(s.Names) > 0 && r.name != "" && !matchesGlob(s.Names, r.name) {
+		return false
+	}
+	if id != nil && r.meta.ProviderAssignedID != "" && !id.MatchString(r.meta.ProviderAssignedID) {
+		return false
+	}
+	if len(s.Regions) > 0 && r.meta.Region != "" && !containsFold(s.Regions, r.meta.Region) {
+		return false
+	}
+	return true
+}
+
+func (r resource) matchesAny(s Selector, id *regexp.Regexp) bool {
+	for key, pattern := range s.Tags {
+		if r.matchesTag(key, pattern) {
+			return true
+		}
+	}
+	if r.name != "" && matchesGlob(s.Names, r.name) {
+		return true
+	}
+	if id != nil && r.meta.ProviderAssignedID != "" && id.MatchString(r.meta.ProviderAssignedID) {
+		return true
+	}
+	return r.meta.Region != "" && containsFold(s.Regions, r.meta.Region)
+}
+
+func (r resource) matchesTag(key, pattern string) bool {
+	value, ok := r.tags[key]
+	if !ok {
+		return false
+	}
+	if pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
+
+// tagsOf returns the tags attribute as strings, whichever map type the
+// provider used.
+func tagsOf(attrs map[string]any) map[string]string {
+	switch tags := attrs[domain.KeyTags].(type) {
+	case map[string]string:
+		return tags
+	case map[string]any:
+		out := make(map[string]string, len(tags))
+		for k, v := range tags {
+			out[k] = fmt.Sprint(v)
+		}
+		return out
+	}
+	return nil
+}
+
+// nameOf returns the name attribute of the resource, falling back to its
+// platform ID for kinds identified by name such as buckets.
+func nameOf(meta domain.ResourceMetadata, attrs map[string]any) string {
+	if name, ok := attrs[domain.KeyName].(string); ok && name != "" {
+		return name
+	}
+	return meta.ProviderAssignedID
+}
+
+func matchesGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
@@ -0,0 +1,129 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stateResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+}
+
+func (r stateResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r stateResource) Attributes() map[string]any        { return r.attrs }
+
+type platformResource struct {
+	meta  domain.ResourceMetadata
+	attrs map[string]any
+	err   error
+	calls *int
+}
+
+func (r platformResource) Metadata() domain.ResourceMetadata { return r.meta }
+func (r platformResource) Attributes(context.Context) (map[string]any, error) {
+	if r.calls != nil {
+		*r.calls++
+	}
+	return r.attrs, r.err
+}
+
+func TestNew_Validation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         Config
+		expectNil   bool
+		expectError bool
+	}{
+		{"no criteria", Config{}, true, false},
+		{"valid", Config{Include: Selector{Tags: map[string]string{"team": "pay*"}, Names: []string{"api-*"}, IDPattern: "^i-"}}, false, false},
+		{"bad name glob", Config{Include: Selector{Names: []string{"api-["}}}, false, true},
+		{"bad tag glob", Config{Exclude: Selector{Tags: map[string]string{"team": "["}}}, false, true},
+		{"empty tag key", Config{Include: Selector{Tags: map[string]string{"": "x"}}}, false, true},
+		{"bad ID pattern", Config{Exclude: Selector{IDPattern: "("}}, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := New(tc.cfg)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectNil, f == nil)
+		})
+	}
+}
+
+func TestFilter_KeepDesired(t *testing.T) {
+	f, err := New(Config{
+		Include: Selector{Tags: map[string]string{"team": "payments"}, Regions: []string{"us-east-1"}},
+		Exclude: Selector{Names: []string{"*-legacy"}},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		res  stateResource
+		keep bool
+	}{
+		{"matching tags", stateResource{attrs: map[string]any{domain.KeyTags: map[string]any{"team": "payments"}}}, true},
+		{"other team", stateResource{attrs: map[string]any{domain.KeyTags: map[string]any{"team": "search"}}}, false},
+		{"untagged", stateResource{attrs: map[string]any{}}, false},
+		{"excluded name", stateResource{attrs: map[string]any{domain.KeyName: "api-legacy", domain.KeyTags: map[string]string{"team": "payments"}}}, false},
+		{"other region", stateResource{meta: domain.ResourceMetadata{Region: "eu-west-1"}, attrs: map[string]any{domain.KeyTags: map[string]string{"team": "payments"}}}, false},
+		{"unknown region", stateResource{meta: domain.ResourceMetadata{}, attrs: map[string]any{domain.KeyTags: map[string]string{"team": "payments"}}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.keep, f.KeepDesired(tc.res))
+		})
+	}
+}
+
+func TestFilter_KeepActual(t *testing.T) {
+	f, err := New(Config{Include: Selector{IDPattern: "^i-0a"}, Exclude: Selector{Regions: []string{"EU-WEST-1"}}})
+	require.NoError(t, err)
+
+	calls := 0
+	keep := func(id, region string) bool {
+		return f.KeepActual(context.Background(), platformResource{
+			meta:  domain.ResourceMetadata{ProviderAssignedID: id, Region: region},
+			calls: &calls,
+		})
+	}
+	assert.True(t, keep("i-0a12", "us-east-1"))
+	assert.False(t, keep("i-0b34", "us-east-1"))
+	assert.False(t, keep("i-0a12", "eu-west-1"))
+	assert.Zero(t, calls, "attributes must not be fetched without tag or name criteria")
+
+	f, err = New(Config{Include: Selector{Names: []string{"web-*"}}})
+	require.NoError(t, err)
+	assert.True(t, f.KeepActual(context.Background(), platformResource{err: errors.New("boom")}),
+		"resources whose attributes fail must reach the comparison")
+	assert.True(t, f.KeepActual(context.Background(), platformResource{meta: domain.ResourceMetadata{ProviderAssignedID: "web-assets"}}),
+		"the platform ID stands in for a missing name")
+}
+
+func TestFilter_PlatformFilters(t *testing.T) {
+	f, err := New(Config{
+		Include: Selector{
+			Tags:  map[string]string{"team": "payments", "env": "prod-*", "owner": ""},
+			Names: []string{"api"},
+		},
+		Exclude: Selector{Tags: map[string]string{"legacy": "true"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tag:team": "payments", domain.KeyName: "api"}, f.PlatformFilters())
+
+	var none *Filter
+	assert.Empty(t, none.PlatformFilters())
+	assert.True(t, none.KeepDesired(stateResource{}))
+}
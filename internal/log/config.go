@@ -1,5 +1,7 @@
 package log
 
+import "io"
+
 type Level string
 
 const (
@@ -19,7 +21,9 @@ const (
 type Config struct {
 	Level  Level  `yaml:"level"`
 	Format Format `yaml:"format"`
-	// OutputPath string `yaml:"output_path"` // Future: support file output
+	// Output receives the log records. Defaults to stderr; use OpenFile for a
+	// log file that can be reopened after rotation.
+	Output io.Writer `yaml:"-"`
 }
 
 func DefaultConfig() Config {
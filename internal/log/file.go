@@ -0,0 +1,58 @@
+package log
+
+import (
+	"os"
+	"sync"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// File is an append-only log file that can be reopened at the same path, so
+// that external rotation (e.g. logrotate renaming the file and sending SIGHUP)
+// does not leave the process writing to the rotated file.
+type File struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFile opens path for appending, creating it if needed.
+func OpenFile(path string) (*File, error) {
+	f := &File{path: path}
+	if err := f.Reopen(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Write(p)
+}
+
+// Reopen closes the current file and opens the path again.
+func (f *File) Reopen() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to open log file "+f.path)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = file
+	return nil
+}
+
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// Path returns the path of the log file.
+func (f *File) Path() string {
+	return f.path
+}
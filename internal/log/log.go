@@ -37,6 +37,9 @@ func NewLogger(cfg Config) (ports.Logger, error) {
 
 	var handler slog.Handler
 	outputWriter := io.Writer(os.Stderr) // Default to stderr
+	if cfg.Output != nil {
+		outputWriter = cfg.Output
+	}
 
 	switch cfg.Format {
 	case FormatJSON:
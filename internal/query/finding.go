@@ -0,0 +1,56 @@
+package query
+
+import (
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// Finding is one row a query is evaluated against: a single attribute
+// difference of a result, or the result itself when it has no differences.
+type Finding struct {
+	Status    domain.ComparisonStatus `json:"status"`
+	Kind      domain.ResourceKind     `json:"resource_kind"`
+	Source    string                  `json:"source_identifier,omitempty"`
+	ID        string                  `json:"provider_assigned_id,omitempty"`
+	Provider  string                  `json:"provider_type,omitempty"`
+	Attribute string                  `json:"attribute,omitempty"`
+	Group     string                  `json:"group,omitempty"`
+	Severity  domain.Severity         `json:"severity,omitempty"`
+	Expected  any                     `json:"expected_value,omitempty"`
+	Actual    any                     `json:"actual_value,omitempty"`
+	Details   string                  `json:"details,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+}
+
+// Flatten turns results into findings, one per attribute difference and one
+// per result without differences.
+func Flatten(results []domain.ComparisonResult) []Finding {
+	findings := make([]Finding, 0, len(results))
+	for _, res := range results {
+		base := Finding{
+			Status:   res.Status,
+			Kind:     res.ResourceKind,
+			Source:   res.SourceIdentifier,
+			ID:       res.ProviderAssignedID,
+			Provider: res.ProviderType,
+			Severity: res.MaxSeverity(),
+		}
+		if res.Error != nil {
+			base.Error = res.Error.Error()
+		}
+		if len(res.Differences) == 0 {
+			findings = append(findings, base)
+			continue
+		}
+		for _, diff := range res.Differences {
+			finding := base
+			finding.Attribute = diff.AttributeName
+			finding.Group = diff.Group
+			finding.Severity = diff.Severity
+			finding.Expected = diff.ExpectedValue
+			finding.Actual = diff.ActualValue
+			finding.Details = diff.Details
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+type operator struct {
+	text string
+	kind tokenKind
+}
+
+// operators lists the symbols of the language, longest first so that "<="
+// is not read as "<" followed by "=".
+var operators = []operator{
+	{"&&", tokenAnd}, {"||", tokenOr},
+	{"==", tokenOp}, {"!=", tokenOp}, {"=~", tokenOp}, {"!~", tokenOp}, {"<=", tokenOp}, {">=", tokenOp},
+	{"<", tokenOp}, {">", tokenOp}, {"!", tokenNot}, {"(", tokenLParen}, {")", tokenRParen},
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		ch := rune(input[i])
+		if unicode.IsSpace(ch) {
+			i++
+			continue
+		}
+
+		if ch == '"' {
+			text, end, err := readString(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end
+			continue
+		}
+
+		if op, ok := matchOperator(input[i:]); ok {
+			tokens = append(tokens, token{kind: op.kind, text: op.text, pos: i})
+			i += len(op.text)
+			continue
+		}
+
+		if isWordChar(ch) {
+			start := i
+			for i < len(input) && isWordChar(rune(input[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: input[start:i], pos: start})
+			continue
+		}
+
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", ch, i)
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+func matchOperator(input string) (operator, bool) {
+	for _, op := range operators {
+		if strings.HasPrefix(input, op.text) {
+			return op, true
+		}
+	}
+	return operator{}, false
+}
+
+// readString reads a double-quoted string starting at start. Only \" and \\ are
+// escapes; other backslashes are kept so regular expressions like "a\.b" read
+// as written.
+func readString(input string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			if i+1 < len(input) && (input[i+1] == '"' || input[i+1] == '\\') {
+				i++
+			}
+			b.WriteByte(input[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(input[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+func isWordChar(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || strings.ContainsRune("_-.:/*", ch)
+}
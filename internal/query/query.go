@@ -0,0 +1,277 @@
+// Package query implements the filter expressions of the "query" command, e.g.
+//
+//	kind == "storage_bucket" && severity >= warning && attribute =~ "encryption"
+//
+// Comparisons are combined with &&, || and !, and grouped with parentheses.
+// Values are double-quoted strings or bare words.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Fields lists the finding fields a query can reference.
+var Fields = []string{"kind", "status", "severity", "attribute", "group", "source", "id", "provider", "details", "error"}
+
+// Query is a parsed filter expression.
+type Query struct {
+	expr node
+}
+
+// Parse parses a filter expression. The empty expression matches every finding.
+func Parse(expression string) (*Query, error) {
+	if strings.TrimSpace(expression) == "" {
+		return &Query{expr: matchAll{}}, nil
+	}
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, parseError(expression, err)
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, parseError(expression, err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, parseError(expression, fmt.Errorf("unexpected %s at position %d", tok, tok.pos))
+	}
+	return &Query{expr: expr}, nil
+}
+
+func parseError(expression string, err error) error {
+	return errors.WrapUserFacing(err, errors.CodeQueryParseError,
+		fmt.Sprintf("invalid query '%s': %v", expression, err),
+		fmt.Sprintf("Compare the fields %s with ==, !=, =~, !~ (regular expressions) or, for severity, <, <=, >, >=.", strings.Join(Fields, ", ")))
+}
+
+// Match reports whether the finding satisfies the query.
+func (q *Query) Match(f Finding) bool {
+	return q.expr.eval(f)
+}
+
+// Filter returns the findings that satisfy the query, in order.
+func (q *Query) Filter(findings []Finding) []Finding {
+	matched := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if q.Match(f) {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+type node interface {
+	eval(f Finding) bool
+}
+
+type matchAll struct{}
+
+func (matchAll) eval(Finding) bool { return true }
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(f Finding) bool { return n.left.eval(f) && n.right.eval(f) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(f Finding) bool { return n.left.eval(f) || n.right.eval(f) }
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(f Finding) bool { return !n.operand.eval(f) }
+
+// comparison compares one field of a finding with a literal value.
+type comparison struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (c comparison) eval(f Finding) bool {
+	actual := fieldValue(f, c.field)
+	switch c.op {
+	case "=~":
+		return c.re.MatchString(actual)
+	case "!~":
+		return !c.re.MatchString(actual)
+	case "==":
+		return normalizeValue(c.field, actual) == normalizeValue(c.field, c.value)
+	case "!=":
+		return normalizeValue(c.field, actual) != normalizeValue(c.field, c.value)
+	}
+
+	// Ordering operators are only accepted for severity by the parser.
+	have, want := domain.Severity(strings.ToLower(actual)).Rank(), domain.Severity(strings.ToLower(c.value)).Rank()
+	switch c.op {
+	case "<":
+		return have < want
+	case "<=":
+		return have <= want
+	case ">":
+		return have > want
+	case ">=":
+		return have >= want
+	}
+	return false
+}
+
+func fieldValue(f Finding, field string) string {
+	switch field {
+	case "kind":
+		return string(f.Kind)
+	case "status":
+		return string(f.Status)
+	case "severity":
+		return string(f.Severity)
+	case "attribute":
+		return f.Attribute
+	case "group":
+		return f.Group
+	case "source":
+		return f.Source
+	case "id":
+		return f.ID
+	case "provider":
+		return f.Provider
+	case "details":
+		return f.Details
+	case "error":
+		return f.Error
+	}
+	return ""
+}
+
+// normalizeValue makes kind, status and severity comparisons insensitive to
+// case and separators, so "storage_bucket" matches StorageBucket and "no-drift"
+// matches NO_DRIFT.
+func normalizeValue(field, value string) string {
+	switch field {
+	case "kind", "status", "severity":
+		return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(value))
+	}
+	return value
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch tok := p.peek(); tok.kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case tokenLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ')' at position %d, got %s", closing.pos, closing)
+		}
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseComparison() (node, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, fmt.Errorf("expected a field name at position %d, got %s", fieldTok.pos, fieldTok)
+	}
+	field := strings.ToLower(fieldTok.text)
+	if !isField(field) {
+		return nil, fmt.Errorf("unknown field '%s' at position %d", fieldTok.text, fieldTok.pos)
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokenOp {
+		return nil, fmt.Errorf("expected an operator after '%s' at position %d, got %s", fieldTok.text, opTok.pos, opTok)
+	}
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, fmt.Errorf("expected a value after '%s' at position %d, got %s", opTok.text, valueTok.pos, valueTok)
+	}
+
+	c := comparison{field: field, op: opTok.text, value: valueTok.text}
+	switch c.op {
+	case "=~", "!~":
+		re, err := regexp.Compile(c.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", c.value, err)
+		}
+		c.re = re
+	case "<", "<=", ">", ">=":
+		if field != "severity" {
+			return nil, fmt.Errorf("operator '%s' only applies to severity, not '%s'", c.op, fieldTok.text)
+		}
+		if _, ok := domain.ParseSeverity(c.value); !ok {
+			return nil, fmt.Errorf("unknown severity '%s' (expected info, warning or critical)", c.value)
+		}
+	}
+	return c, nil
+}
+
+func isField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
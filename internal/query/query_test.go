@@ -0,0 +1,101 @@
+package query
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+func testFindings() []Finding {
+	return Flatten([]domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs", ProviderAssignedID: "logs",
+			Differences: []domain.AttributeDiff{
+				{AttributeName: "server_side_encryption_configuration", Severity: domain.SeverityCritical, Group: "security"},
+				{AttributeName: "tags", Severity: domain.SeverityInfo},
+			},
+		},
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web", ProviderAssignedID: "i-123",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", Severity: domain.SeverityWarning, Group: "cost"}},
+		},
+		{Status: domain.StatusUnmanaged, ResourceKind: domain.KindStorageBucket, ProviderAssignedID: "scratch"},
+		{Status: domain.StatusError, ResourceKind: domain.KindDatabaseInstance, SourceIdentifier: "aws_db_instance.orders", Error: stderrors.New("AccessDenied")},
+	})
+}
+
+func matchedIDs(t *testing.T, expression string) []string {
+	t.Helper()
+	q, err := Parse(expression)
+	require.NoError(t, err)
+	var ids []string
+	for _, f := range q.Filter(testFindings()) {
+		ids = append(ids, f.ID+"/"+f.Attribute)
+	}
+	return ids
+}
+
+func TestFlatten(t *testing.T) {
+	findings := testFindings()
+
+	require.Len(t, findings, 5)
+	assert.Equal(t, "server_side_encryption_configuration", findings[0].Attribute)
+	assert.Equal(t, domain.SeverityCritical, findings[0].Severity)
+	assert.Equal(t, "scratch", findings[3].ID)
+	assert.Empty(t, findings[3].Attribute)
+	assert.Equal(t, "AccessDenied", findings[4].Error)
+}
+
+func TestQuery_Match(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+	}{
+		{"empty matches all", "", []string{"logs/server_side_encryption_configuration", "logs/tags", "i-123/instance_type", "scratch/", "/"}},
+		{"request example", `kind == "storage_bucket" && severity >= warning && attribute =~ "encryption"`, []string{"logs/server_side_encryption_configuration"}},
+		{"kind matches declared name", "kind == StorageBucket && status == unmanaged", []string{"scratch/"}},
+		{"severity below", "severity < warning && status == drifted", []string{"logs/tags"}},
+		{"or and parentheses", `(group == cost || group == security) && !(kind == compute_instance)`, []string{"logs/server_side_encryption_configuration"}},
+		{"regex negation", `status == "DRIFTED" && attribute !~ "^(tags|instance_type)$"`, []string{"logs/server_side_encryption_configuration"}},
+		{"error field", `error =~ "(?i)access"`, []string{"/"}},
+		{"and binds tighter than or", "id == i-123 || id == logs && attribute == tags", []string{"logs/tags", "i-123/instance_type"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchedIDs(t, tt.expression))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		contains   string
+	}{
+		{"unknown field", "colour == red", "unknown field 'colour'"},
+		{"missing operator", "kind storage_bucket", "expected an operator"},
+		{"missing value", "kind ==", "expected a value"},
+		{"ordering on non severity", "kind > compute", "only applies to severity"},
+		{"unknown severity", "severity >= high", "unknown severity 'high'"},
+		{"bad regex", `attribute =~ "("`, "invalid regular expression"},
+		{"unclosed paren", "(kind == x", "expected ')'"},
+		{"unterminated string", `kind == "x`, "unterminated string"},
+		{"trailing tokens", "kind == x y", "unexpected 'y'"},
+		{"unexpected character", "kind == x; drop", "unexpected character ';'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.CodeQueryParseError))
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
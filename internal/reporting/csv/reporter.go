@@ -0,0 +1,169 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+)
+
+const (
+	ReporterTypeCSV = "csv"
+	ReporterTypeTSV = "tsv"
+)
+
+// Config is shared by the csv and tsv reporters.
+type Config struct {
+	// NoHeader leaves out the header row, e.g. when the rows of every run are
+	// appended to one file.
+	NoHeader bool `yaml:"no_header" mapstructure:"no_header"`
+}
+
+// header names the columns of every row.
+var header = []string{
+	"run_time", "resource_kind", "source_identifier", "provider_assigned_id", "status",
+	"attribute", "expected", "actual", "severity", "group", "details",
+}
+
+// Reporter writes one row per attribute difference, and one row without an
+// attribute for every result without differences, so that the results can be
+// loaded into spreadsheets and BI tools. Every row carries the time of the run,
+// so the rows of several runs can be appended and analysed as a trend.
+//
+// Lists and maps are written as compact JSON. The csv format quotes fields as
+// RFC 4180 describes; the tsv format never quotes and escapes tabs, line breaks
+// and backslashes as \t, \n, \r and \\ instead, as database bulk loaders do.
+type Reporter struct {
+	config Config
+	format string
+	writer io.Writer
+	logger ports.Logger
+	times  *localize.Formatter
+	now    func() time.Time
+}
+
+// NewReporter returns a reporter writing format, ReporterTypeCSV or
+// ReporterTypeTSV.
+func NewReporter(cfg Config, format string, logger ports.Logger) (*Reporter, error) {
+	if format != ReporterTypeCSV && format != ReporterTypeTSV {
+		return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("unsupported delimited format %q", format))
+	}
+	return &Reporter{
+		config: cfg,
+		format: format,
+		writer: os.Stdout,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetTimeFormatter writes the run time in the formatter's zone. The format
+// stays RFC 3339, which spreadsheets and BI tools parse.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	if ctx.Err() != nil {
+		r.logger.Warnf(ctx, "%s report generation cancelled.", strings.ToUpper(r.format))
+		return ctx.Err()
+	}
+	runTime := r.times.In(r.now()).Format(time.RFC3339)
+
+	var rows [][]string
+	if !r.config.NoHeader {
+		rows = append(rows, header)
+	}
+	for _, res := range results {
+		resource := []string{runTime, string(res.ResourceKind), res.SourceIdentifier, res.ProviderAssignedID, string(res.Status)}
+		if len(res.Differences) == 0 {
+			details := ""
+			if res.Error != nil {
+				details = res.Error.Error()
+			}
+			rows = append(rows, append(resource, "", "", "", string(res.MaxSeverity()), "", details))
+			continue
+		}
+		for _, d := range res.Differences {
+			row := append(append([]string(nil), resource...),
+				d.AttributeName, formatValue(d.ExpectedValue), formatValue(d.ActualValue), string(d.Severity), d.Group, d.Details)
+			rows = append(rows, row)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := r.write(&buf, rows); err != nil {
+		r.logger.Errorf(ctx, err, "Failed to encode %s report", strings.ToUpper(r.format))
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to encode %s report", r.format))
+	}
+	if _, err := r.writer.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s report: %w", strings.ToUpper(r.format), err)
+	}
+	r.logger.Debugf(ctx, "%s report generated with %d row(s).", strings.ToUpper(r.format), len(rows))
+	return nil
+}
+
+func (r *Reporter) write(w io.Writer, rows [][]string) error {
+	if r.format == ReporterTypeTSV {
+		for _, row := range rows {
+			fields := make([]string, len(row))
+			for i, field := range row {
+				fields[i] = tsvEscaper.Replace(field)
+			}
+			if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// formatValue renders scalars as they are and lists and maps as compact JSON,
+// so that every value fits in one cell. A missing value is an empty cell.
+func formatValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		var b bytes.Buffer
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(v.Interface()); err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		return strings.TrimSuffix(b.String(), "\n")
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
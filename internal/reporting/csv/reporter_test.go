@@ -0,0 +1,84 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config, format string) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, format, reportingtest.Logger())
+	require.NoError(t, err)
+	r.now = func() time.Time { return reportingtest.Now }
+	var buf bytes.Buffer
+	r.SetWriter(&buf)
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	for _, format := range []string{ReporterTypeCSV, ReporterTypeTSV} {
+		t.Run(format, func(t *testing.T) {
+			r, buf := newTestReporter(t, Config{}, format)
+
+			require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+			reportingtest.AssertGolden(t, "report."+format, buf.Bytes())
+		})
+	}
+}
+
+func TestReporter_CSVRoundTrip(t *testing.T) {
+	r, buf := newTestReporter(t, Config{NoHeader: true}, ReporterTypeCSV)
+	results := []domain.ComparisonResult{{
+		Status:           domain.StatusDrifted,
+		ResourceKind:     domain.KindComputeInstance,
+		SourceIdentifier: "aws_instance.web",
+		Differences: []domain.AttributeDiff{{
+			AttributeName: "user_data",
+			ExpectedValue: "#!/bin/sh\necho \"a,b\"",
+			ActualValue:   map[string]any{"k": "<v>"},
+		}},
+	}}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, []string{
+		"2024-06-01T12:00:00Z", "ComputeInstance", "aws_instance.web", "", "DRIFTED",
+		"user_data", "#!/bin/sh\necho \"a,b\"", `{"k":"<v>"}`, "", "", "",
+	}, records[0])
+}
+
+func TestReporter_TSVEscapesFields(t *testing.T) {
+	r, buf := newTestReporter(t, Config{NoHeader: true}, ReporterTypeTSV)
+	results := []domain.ComparisonResult{{
+		Status:           domain.StatusDrifted,
+		ResourceKind:     domain.KindComputeInstance,
+		SourceIdentifier: "aws_instance.web",
+		Differences: []domain.AttributeDiff{{
+			AttributeName: "user_data",
+			ExpectedValue: "a\tb\nc\\d",
+			ActualValue:   `"quoted"`,
+		}},
+	}}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	assert.Equal(t, "2024-06-01T12:00:00Z\tComputeInstance\taws_instance.web\t\tDRIFTED\tuser_data\ta\\tb\\nc\\\\d\t\"quoted\"\t\t\t\n", buf.String())
+}
+
+func TestNewReporter_UnsupportedFormat(t *testing.T) {
+	_, err := NewReporter(Config{}, "xlsx", reportingtest.Logger())
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package html
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+)
+
+// formatValue renders an attribute value for a diff panel. Lists, maps and
+// strings holding a JSON document, such as IAM policies, are pretty-printed
+// and highlighted; other values are shown as they are. The result is escaped.
+func formatValue(value any) htmltemplate.HTML {
+	switch v := value.(type) {
+	case nil:
+		return `<span class="j-lit">null</span>`
+	case string:
+		if doc, ok := jsonDocument(v); ok {
+			return highlightJSON(doc)
+		}
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(v))
+	case bool, int, int32, int64, float32, float64:
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(fmt.Sprint(v)))
+	}
+	indented, err := marshalIndent(value)
+	if err != nil {
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(fmt.Sprintf("%v", value)))
+	}
+	return highlightJSON(indented)
+}
+
+// jsonDocument returns s pretty-printed when it is a JSON object or array.
+func jsonDocument(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+func marshalIndent(value any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// highlightJSON wraps the keys, strings, numbers and literals of a valid JSON
+// document in spans whose classes the report styles, escaping everything.
+func highlightJSON(doc string) htmltemplate.HTML {
+	var out strings.Builder
+	span := func(class, token string) {
+		out.WriteString(`<span class="` + class + `">`)
+		out.WriteString(htmltemplate.HTMLEscapeString(token))
+		out.WriteString(`</span>`)
+	}
+	for i := 0; i < len(doc); {
+		switch c := doc[i]; {
+		case c == '"':
+			end := i + 1
+			for end < len(doc) && doc[end] != '"' {
+				if doc[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(doc))
+			class := "j-str"
+			if rest := strings.TrimLeft(doc[end:], " \t\r\n"); strings.HasPrefix(rest, ":") {
+				class = "j-key"
+			}
+			span(class, doc[i:end])
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(doc) && strings.IndexByte("0123456789.eE+-", doc[end]) >= 0 {
+				end++
+			}
+			span("j-num", doc[i:end])
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := i
+			for end < len(doc) && doc[end] >= 'a' && doc[end] <= 'z' {
+				end++
+			}
+			span("j-lit", doc[i:end])
+			i = end
+		default:
+			out.WriteString(htmltemplate.HTMLEscapeString(doc[i : i+1]))
+			i++
+		}
+	}
+	return htmltemplate.HTML(out.String())
+}
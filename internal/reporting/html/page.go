@@ -0,0 +1,240 @@
+package html
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// page is the data the report template is executed with.
+type page struct {
+	Title          string
+	Cards          []card
+	Kinds          []kindSection
+	DeadLetters    []row
+	StateIssues    []domain.StateIssue
+	RunAnnotations []annotation
+}
+
+// card is one status count of the summary.
+type card struct {
+	Label string
+	Count int
+	Class string
+}
+
+// kindSection is the table of the results of one resource kind.
+type kindSection struct {
+	Kind domain.ResourceKind
+	Rows []row
+}
+
+type row struct {
+	Status     domain.ComparisonStatus
+	Class      string
+	Label      string
+	PlatformID string
+	Severity   domain.Severity
+	// Notes are the lines of the expandable panel above the differences, such
+	// as the error of a failed comparison.
+	Notes       []string
+	Diffs       []diff
+	Candidates  []string
+	NotAsserted []string
+	Links       []domain.ResourceLink
+	Explain     []string
+}
+
+// Expandable reports whether the row has a panel of details.
+func (r row) Expandable() bool {
+	return len(r.Notes)+len(r.Diffs)+len(r.Candidates)+len(r.NotAsserted)+len(r.Links)+len(r.Explain) > 0
+}
+
+type diff struct {
+	Attribute       string
+	Severity        domain.Severity
+	Group           string
+	Details         string
+	PlatformManaged string
+	Expected        htmltemplate.HTML
+	Actual          htmltemplate.HTML
+}
+
+type annotation struct {
+	Source  string
+	Message string
+	Time    string
+}
+
+// statusStyles are the card label and CSS class of every status, in the order
+// of the summary cards.
+var statusStyles = []struct {
+	status domain.ComparisonStatus
+	label  string
+	class  string
+	// always shows the card when its count is zero.
+	always bool
+}{
+	{domain.StatusNoDrift, "No drift", "ok", true},
+	{domain.StatusDrifted, "Drifted", "drift", true},
+	{domain.StatusMissing, "Missing", "missing", true},
+	{domain.StatusRecentlyDeleted, "Recently deleted", "missing", false},
+	{domain.StatusPendingDeletion, "Pending deletion", "missing", false},
+	{domain.StatusUnmanaged, "Unmanaged", "unmanaged", true},
+	{domain.StatusAmbiguous, "Ambiguous", "missing", false},
+	{domain.StatusError, "Errors", "error", true},
+	{domain.StatusDeadLettered, "Dead-lettered", "error", false},
+	{domain.StatusUnapprovedImage, "Unapproved images", "drift", false},
+}
+
+func statusClass(status domain.ComparisonStatus) string {
+	for _, style := range statusStyles {
+		if style.status == status {
+			return style.class
+		}
+	}
+	return "error"
+}
+
+func (r *Reporter) newPage(results []domain.ComparisonResult) page {
+	p := page{Title: r.config.Title, StateIssues: r.stateIssues}
+	if p.Title == "" {
+		p.Title = DefaultTitle
+	}
+
+	counts := make(map[domain.ComparisonStatus]int)
+	total := 0
+	for _, res := range results {
+		counts[res.Status]++
+		// Unapproved images accompany the instance's own result, so they are
+		// not part of the total.
+		if res.Status != domain.StatusUnapprovedImage {
+			total++
+		}
+	}
+	p.Cards = append(p.Cards, card{Label: "Processed", Count: total, Class: "total"})
+	for _, style := range statusStyles {
+		if count := counts[style.status]; count > 0 || style.always {
+			p.Cards = append(p.Cards, card{Label: style.label, Count: count, Class: style.class})
+		}
+	}
+
+	ordered := make([]domain.ComparisonResult, len(results))
+	copy(ordered, results)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		if ordered[i].ResourceKind != ordered[j].ResourceKind {
+			return ordered[i].ResourceKind < ordered[j].ResourceKind
+		}
+		return label(ordered[i]) < label(ordered[j])
+	})
+	for _, res := range ordered {
+		if res.Status == domain.StatusDeadLettered {
+			p.DeadLetters = append(p.DeadLetters, r.newRow(res))
+			continue
+		}
+		if n := len(p.Kinds); n == 0 || p.Kinds[n-1].Kind != res.ResourceKind {
+			p.Kinds = append(p.Kinds, kindSection{Kind: res.ResourceKind})
+		}
+		section := &p.Kinds[len(p.Kinds)-1]
+		section.Rows = append(section.Rows, r.newRow(res))
+	}
+
+	for _, a := range r.annotations {
+		p.RunAnnotations = append(p.RunAnnotations, annotation{Source: a.Source, Message: a.Message, Time: r.times.Format(a.Time)})
+	}
+	return p
+}
+
+func (r *Reporter) newRow(res domain.ComparisonResult) row {
+	rw := row{
+		Status:      res.Status,
+		Class:       statusClass(res.Status),
+		Label:       label(res),
+		PlatformID:  res.ProviderAssignedID,
+		Severity:    res.MaxSeverity(),
+		NotAsserted: res.NotAsserted,
+		Links:       res.Links,
+	}
+	if rw.Label == "" {
+		rw.Label = "<unknown>"
+	}
+
+	switch res.Status {
+	case domain.StatusMissing:
+		rw.Notes = append(rw.Notes, "Resource defined in the desired state but not found on the platform.")
+	case domain.StatusUnmanaged:
+		rw.Notes = append(rw.Notes, "Resource found on the platform but not defined in the desired state.")
+	case domain.StatusRecentlyDeleted:
+		rw.Notes = append(rw.Notes, "Resource deleted from the platform since the previous run.")
+		if w := res.DeletionWindow; w != nil {
+			rw.Notes = append(rw.Notes, fmt.Sprintf("Deleted between %s and %s.", r.times.Format(w.From), r.times.Format(w.To)))
+		}
+	case domain.StatusPendingDeletion:
+		rw.Notes = append(rw.Notes, "Resource is being deleted by the platform or held for deletion; its attributes were not compared.")
+		if pd := res.PendingDeletion; pd != nil {
+			state := fmt.Sprintf("State: %s", pd.State)
+			if !pd.DeletionDate.IsZero() {
+				state += fmt.Sprintf(", scheduled for deletion on %s", r.times.Format(pd.DeletionDate))
+			}
+			rw.Notes = append(rw.Notes, state+".")
+		}
+	case domain.StatusAmbiguous:
+		rw.Notes = append(rw.Notes, "Several resources match one another, so none was paired or compared.")
+		for _, candidate := range res.Candidates {
+			if candidate.SourceIdentifier != "" {
+				rw.Candidates = append(rw.Candidates, "state: "+candidate.SourceIdentifier)
+			} else {
+				rw.Candidates = append(rw.Candidates, "platform: "+candidate.ProviderAssignedID)
+			}
+		}
+	case domain.StatusDeadLettered:
+		if fs := res.FailureStreak; fs != nil {
+			rw.Notes = append(rw.Notes, fmt.Sprintf("Failed in %d consecutive runs since %s.", fs.Runs, r.times.Format(fs.Since)))
+		}
+	}
+	if res.Error != nil {
+		rw.Notes = append(rw.Notes, res.Error.Error())
+	}
+	if res.Match != nil && res.Match.Confidence > 0 {
+		rw.Notes = append(rw.Notes, fmt.Sprintf("Matched via %s (confidence %.2f).", res.Match.Strategy, res.Match.Confidence))
+	}
+
+	for _, d := range res.Differences {
+		rw.Diffs = append(rw.Diffs, diff{
+			Attribute:       d.AttributeName,
+			Severity:        d.Severity,
+			Group:           d.Group,
+			Details:         d.Details,
+			PlatformManaged: d.PlatformManaged,
+			Expected:        formatValue(d.ExpectedValue),
+			Actual:          formatValue(d.ActualValue),
+		})
+	}
+
+	if res.Trace != nil {
+		rw.Explain = append(rw.Explain, res.Trace.Steps...)
+		for _, attr := range res.Trace.Attributes {
+			verdict := "equal"
+			switch {
+			case attr.Skipped:
+				verdict = "skipped"
+			case !attr.Equal:
+				verdict = "different"
+			}
+			rw.Explain = append(rw.Explain, fmt.Sprintf("%s: %s", attr.Attribute, verdict))
+		}
+	}
+	return rw
+}
+
+func label(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
@@ -0,0 +1,101 @@
+package html
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+)
+
+const ReporterTypeHTML = "html"
+
+type Config struct {
+	// Title heads the report, e.g. the environment or the change ticket it is
+	// attached to. Defaults to DefaultTitle.
+	Title string `yaml:"title" mapstructure:"title"`
+}
+
+// DefaultTitle heads the report when the configuration does not set a title.
+const DefaultTitle = "Infrastructure Drift Report"
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+// Reporter writes a standalone HTML page, with its styles inlined and no
+// scripts, that can be opened offline or attached to a change ticket. It shows
+// summary cards of the status counts, a table per resource kind and, for
+// every resource with details, an expandable panel with its expected and
+// actual values side by side. Lists, maps and JSON documents such as IAM
+// policies are pretty-printed and highlighted.
+type Reporter struct {
+	config      Config
+	tmpl        *htmltemplate.Template
+	writer      io.Writer
+	logger      ports.Logger
+	stateIssues []domain.StateIssue
+	annotations []domain.RunAnnotation
+	times       *localize.Formatter
+}
+
+func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
+	tmpl, err := htmltemplate.New("report").Parse(reportTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "invalid built-in HTML report template")
+	}
+	return &Reporter{
+		config: cfg,
+		tmpl:   tmpl,
+		writer: os.Stdout,
+		logger: logger,
+	}, nil
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetStateIssues sets the state source issues listed after the results.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	r.stateIssues = issues
+}
+
+// SetRunAnnotations sets the run annotations listed after the results.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	r.annotations = annotations
+}
+
+// SetTimeFormatter renders timestamps in the formatter's zone and locale
+// instead of RFC 3339.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	if ctx.Err() != nil {
+		r.logger.Warnf(ctx, "HTML report generation cancelled.")
+		return ctx.Err()
+	}
+	page := r.newPage(results)
+
+	// Render fully before writing, so that a failing template does not leave
+	// a truncated page behind.
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, page); err != nil {
+		r.logger.Errorf(ctx, err, "Failed to render HTML report")
+		return errors.Wrap(err, errors.CodeInternal, "failed to render HTML report")
+	}
+	if _, err := r.writer.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	r.logger.Debugf(ctx, "HTML report generated with %d result(s).", len(results))
+	return nil
+}
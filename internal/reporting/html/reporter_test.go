@@ -0,0 +1,86 @@
+package html
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.SetWriter(&buf)
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t, Config{Title: "Drift report for CHG-1042"})
+	r.SetStateIssues(reportingtest.StateIssues())
+	r.SetRunAnnotations(reportingtest.RunAnnotations())
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
+
+func TestReporter_EscapesValues(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+	results := []domain.ComparisonResult{{
+		Status:           domain.StatusDrifted,
+		ResourceKind:     domain.KindComputeInstance,
+		SourceIdentifier: `aws_instance.x["<script>"]`,
+		Differences: []domain.AttributeDiff{{
+			AttributeName: "tags",
+			ExpectedValue: map[string]any{"Name": "<b>web</b>"},
+			ActualValue:   "</pre><script>alert(1)</script>",
+		}},
+	}}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	out := buf.String()
+	assert.NotContains(t, out, "<script>")
+	assert.NotContains(t, out, "<b>web</b>")
+	assert.Contains(t, out, "&lt;script&gt;alert(1)&lt;/script&gt;")
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"nil", nil, `<span class="j-lit">null</span>`},
+		{"plain string", "t3.micro", "t3.micro"},
+		{"number", 42, "42"},
+		{"list", []any{"sg-1", 2, true}, "[\n  <span class=\"j-str\">&#34;sg-1&#34;</span>,\n  <span class=\"j-num\">2</span>,\n  <span class=\"j-lit\">true</span>\n]"},
+		{
+			"policy document string",
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow"}]}`,
+			"{\n  <span class=\"j-key\">&#34;Version&#34;</span>: <span class=\"j-str\">&#34;2012-10-17&#34;</span>,\n" +
+				"  <span class=\"j-key\">&#34;Statement&#34;</span>: [\n    {\n      <span class=\"j-key\">&#34;Effect&#34;</span>: <span class=\"j-str\">&#34;Allow&#34;</span>\n    }\n  ]\n}",
+		},
+		{"invalid JSON string", "{not json", "{not json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(formatValue(tt.value)))
+		})
+	}
+}
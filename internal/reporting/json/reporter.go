@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
 )
 
 const ReporterTypeJSON = "json"
@@ -19,9 +21,12 @@ type Config struct {
 }
 
 type Reporter struct {
-	config Config
-	writer io.Writer
-	logger ports.Logger
+	config      Config
+	writer      io.Writer
+	logger      ports.Logger
+	stateIssues []domain.StateIssue
+	annotations []domain.RunAnnotation
+	times       *localize.Formatter
 }
 
 func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
@@ -33,8 +38,38 @@ func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
 }
 
 type jsonReport struct {
-	Summary jsonSummary      `json:"summary"`
-	Results []jsonResultItem `json:"results"`
+	Summary        jsonSummary         `json:"summary"`
+	Results        []jsonResultItem    `json:"results"`
+	DeadLetter     []jsonDeadLetter    `json:"dead_letter,omitempty"`
+	RunAnnotations []jsonRunAnnotation `json:"run_annotations,omitempty"`
+	StateIssues    []jsonStateIssue    `json:"state_source_issues,omitempty"`
+}
+
+type jsonRunAnnotation struct {
+	Source  string    `json:"source"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// jsonDeadLetter is a resource that failed in several consecutive runs. Dead
+// letters are kept out of the results so the same errors do not repeat in every
+// report.
+type jsonDeadLetter struct {
+	ResourceKind       domain.ResourceKind `json:"resource_kind"`
+	SourceIdentifier   string              `json:"source_identifier,omitempty"`
+	ProviderAssignedID string              `json:"provider_assigned_id,omitempty"`
+	LastError          string              `json:"last_error,omitempty"`
+	FailedRuns         int                 `json:"failed_runs,omitempty"`
+	FailingSince       *time.Time          `json:"failing_since,omitempty"`
+}
+
+type jsonStateIssue struct {
+	Severity domain.Severity `json:"severity"`
+	Summary  string          `json:"summary"`
+	Detail   string          `json:"detail,omitempty"`
+	Address  string          `json:"address,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Skipped  bool            `json:"skipped,omitempty"`
 }
 
 type jsonSummary struct {
@@ -42,8 +77,18 @@ type jsonSummary struct {
 	NoDrift                 int `json:"no_drift"`
 	Drifted                 int `json:"drifted"`
 	Missing                 int `json:"missing"`
+	RecentlyDeleted         int `json:"recently_deleted"`
 	Unmanaged               int `json:"unmanaged"`
 	Errors                  int `json:"errors"`
+	DeadLettered            int `json:"dead_lettered,omitempty"`
+	PendingDeletion         int `json:"pending_deletion,omitempty"`
+	Ambiguous               int `json:"ambiguous,omitempty"`
+	// UnapprovedImages counts instances running images outside the approved
+	// set. These findings accompany the instance's own result, so they are not
+	// part of the total.
+	UnapprovedImages int `json:"unapproved_images,omitempty"`
+	// DriftByGroup counts differences per configured attribute group.
+	DriftByGroup map[string]int `json:"drift_by_group,omitempty"`
 }
 
 type jsonResultItem struct {
@@ -54,13 +99,92 @@ type jsonResultItem struct {
 	ProviderAssignedID string                  `json:"provider_assigned_id,omitempty"`
 	Differences        []jsonAttributeDiff     `json:"differences,omitempty"`
 	ErrorMessage       string                  `json:"error_message,omitempty"`
+	Severity           domain.Severity         `json:"severity,omitempty"`
+	Links              map[string]string       `json:"links,omitempty"`
+	DeletionWindow     *jsonTimeWindow         `json:"deletion_window,omitempty"`
+	PendingDeletion    *jsonPendingDeletion    `json:"pending_deletion,omitempty"`
+	Explain            *jsonTrace              `json:"explain,omitempty"`
+	DriftByGroup       map[string]int          `json:"drift_by_group,omitempty"`
+	NotAsserted        []string                `json:"not_asserted,omitempty"`
+	Match              *jsonMatch              `json:"match,omitempty"`
+	Candidates         []jsonMatchCandidate    `json:"candidates,omitempty"`
+}
+
+type jsonMatch struct {
+	Strategy   string  `json:"strategy"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// jsonMatchCandidate is one of the resources of an ambiguous match, desired
+// ones by source identifier and actual ones by provider assigned ID.
+type jsonMatchCandidate struct {
+	SourceIdentifier   string `json:"source_identifier,omitempty"`
+	ProviderAssignedID string `json:"provider_assigned_id,omitempty"`
+}
+
+type jsonTrace struct {
+	Steps      []string             `json:"steps,omitempty"`
+	Attributes []jsonAttributeTrace `json:"attributes,omitempty"`
+}
+
+type jsonAttributeTrace struct {
+	Attribute string   `json:"attribute"`
+	Comparer  string   `json:"comparer,omitempty"`
+	Steps     []string `json:"steps,omitempty"`
+	Equal     bool     `json:"equal"`
+	Reason    string   `json:"reason,omitempty"`
+	Skipped   bool     `json:"skipped,omitempty"`
+}
+
+type jsonTimeWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type jsonPendingDeletion struct {
+	State        string     `json:"state"`
+	DeletionDate *time.Time `json:"deletion_date,omitempty"`
 }
 
 type jsonAttributeDiff struct {
-	AttributeName string `json:"attribute_name"`
-	ExpectedValue any    `json:"expected_value"`
-	ActualValue   any    `json:"actual_value"`
-	Details       string `json:"details,omitempty"`
+	AttributeName string          `json:"attribute_name"`
+	ExpectedValue any             `json:"expected_value"`
+	ActualValue   any             `json:"actual_value"`
+	Details       string          `json:"details,omitempty"`
+	Severity      domain.Severity `json:"severity,omitempty"`
+	Group         string          `json:"group,omitempty"`
+	// PlatformManaged explains why the difference was caused by the platform itself.
+	PlatformManaged string `json:"platform_managed,omitempty"`
+	// Diff lists the paths at which JSON document values, such as policies, differ.
+	Diff []jsonChange `json:"diff,omitempty"`
+}
+
+type jsonChange struct {
+	Path     string              `json:"path"`
+	Op       domain.JSONChangeOp `json:"op"`
+	Expected any                 `json:"expected,omitempty"`
+	Actual   any                 `json:"actual,omitempty"`
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetStateIssues sets the state source issues included in the report.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	r.stateIssues = issues
+}
+
+// SetRunAnnotations sets the run annotations included in the report.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	r.annotations = annotations
+}
+
+// SetTimeFormatter converts timestamps to the formatter's zone. They stay in
+// RFC 3339 so the report remains machine readable.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
 }
 
 func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
@@ -82,10 +206,23 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 			report.Summary.Drifted++
 		case domain.StatusMissing:
 			report.Summary.Missing++
+		case domain.StatusRecentlyDeleted:
+			report.Summary.RecentlyDeleted++
 		case domain.StatusUnmanaged:
 			report.Summary.Unmanaged++
+		case domain.StatusPendingDeletion:
+			report.Summary.PendingDeletion++
+		case domain.StatusAmbiguous:
+			report.Summary.Ambiguous++
 		case domain.StatusError:
 			report.Summary.Errors++
+		case domain.StatusDeadLettered:
+			report.Summary.DeadLettered++
+			report.DeadLetter = append(report.DeadLetter, r.toJSONDeadLetter(res))
+			continue
+		case domain.StatusUnapprovedImage:
+			report.Summary.UnapprovedImages++
+			report.Summary.TotalResourcesProcessed--
 		}
 
 		item := jsonResultItem{
@@ -94,22 +231,75 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 			SourceIdentifier:   res.SourceIdentifier,
 			ProviderType:       res.ProviderType,
 			ProviderAssignedID: res.ProviderAssignedID,
+			Severity:           res.MaxSeverity(),
+			NotAsserted:        res.NotAsserted,
 		}
 
 		if res.Error != nil {
 			item.ErrorMessage = res.Error.Error()
 		}
 
+		if res.Match != nil {
+			item.Match = &jsonMatch{Strategy: res.Match.Strategy, Confidence: res.Match.Confidence}
+		}
+
+		for _, candidate := range res.Candidates {
+			item.Candidates = append(item.Candidates, jsonMatchCandidate{
+				SourceIdentifier:   candidate.SourceIdentifier,
+				ProviderAssignedID: candidate.ProviderAssignedID,
+			})
+		}
+
+		if res.DeletionWindow != nil {
+			item.DeletionWindow = &jsonTimeWindow{From: r.times.In(res.DeletionWindow.From), To: r.times.In(res.DeletionWindow.To)}
+		}
+
+		if pd := res.PendingDeletion; pd != nil {
+			item.PendingDeletion = &jsonPendingDeletion{State: pd.State}
+			if !pd.DeletionDate.IsZero() {
+				deletionDate := r.times.In(pd.DeletionDate)
+				item.PendingDeletion.DeletionDate = &deletionDate
+			}
+		}
+
+		if res.Trace != nil {
+			item.Explain = &jsonTrace{Steps: res.Trace.Steps}
+			for _, attr := range res.Trace.Attributes {
+				item.Explain.Attributes = append(item.Explain.Attributes, jsonAttributeTrace{
+					Attribute: attr.Attribute,
+					Comparer:  attr.Comparer,
+					Steps:     attr.Steps,
+					Equal:     attr.Equal,
+					Reason:    attr.Reason,
+					Skipped:   attr.Skipped,
+				})
+			}
+		}
+
+		if len(res.Links) > 0 {
+			item.Links = make(map[string]string, len(res.Links))
+			for _, link := range res.Links {
+				item.Links[link.Name] = link.URL
+			}
+		}
+
 		if len(res.Differences) > 0 {
 			item.Differences = make([]jsonAttributeDiff, len(res.Differences))
 			for i, diff := range res.Differences {
 				item.Differences[i] = jsonAttributeDiff{
-					AttributeName: diff.AttributeName,
-					ExpectedValue: diff.ExpectedValue,
-					ActualValue:   diff.ActualValue,
-					Details:       diff.Details,
+					AttributeName:   diff.AttributeName,
+					ExpectedValue:   diff.ExpectedValue,
+					ActualValue:     diff.ActualValue,
+					Details:         diff.Details,
+					Severity:        diff.Severity,
+					Group:           diff.Group,
+					PlatformManaged: diff.PlatformManaged,
+				}
+				for _, c := range diff.Changes {
+					item.Differences[i].Diff = append(item.Differences[i].Diff, jsonChange(c))
 				}
 			}
+			item.DriftByGroup = groupCountMap(res.DriftByGroup())
 		}
 
 		if item.SourceIdentifier == "" {
@@ -125,6 +315,27 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 		report.Results = append(report.Results, item)
 	}
 
+	report.Summary.DriftByGroup = groupCountMap(domain.CountDriftByGroup(results))
+
+	for _, annotation := range r.annotations {
+		report.RunAnnotations = append(report.RunAnnotations, jsonRunAnnotation{
+			Source:  annotation.Source,
+			Message: annotation.Message,
+			Time:    r.times.In(annotation.Time),
+		})
+	}
+
+	for _, issue := range r.stateIssues {
+		report.StateIssues = append(report.StateIssues, jsonStateIssue{
+			Severity: issue.Severity,
+			Summary:  issue.Summary,
+			Detail:   issue.Detail,
+			Address:  issue.Address,
+			Location: issue.Location,
+			Skipped:  issue.Skipped,
+		})
+	}
+
 	encoder := json.NewEncoder(r.writer)
 	encoder.SetIndent("", "  ")
 
@@ -138,3 +349,31 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 	r.logger.Debugf(ctx, "JSON report successfully generated.")
 	return nil
 }
+
+func (r *Reporter) toJSONDeadLetter(res domain.ComparisonResult) jsonDeadLetter {
+	item := jsonDeadLetter{
+		ResourceKind:       res.ResourceKind,
+		SourceIdentifier:   res.SourceIdentifier,
+		ProviderAssignedID: res.ProviderAssignedID,
+	}
+	if res.Error != nil {
+		item.LastError = res.Error.Error()
+	}
+	if res.FailureStreak != nil {
+		since := r.times.In(res.FailureStreak.Since)
+		item.FailedRuns = res.FailureStreak.Runs
+		item.FailingSince = &since
+	}
+	return item
+}
+
+func groupCountMap(groups []domain.GroupCount) map[string]int {
+	if len(groups) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		counts[group.Group] = group.Count
+	}
+	return counts
+}
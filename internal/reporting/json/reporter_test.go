@@ -0,0 +1,51 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(Config{}, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.writer = &buf
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t)
+	r.SetStateIssues(reportingtest.StateIssues())
+	r.SetRunAnnotations(reportingtest.RunAnnotations())
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_GoldenLocalized(t *testing.T) {
+	r, buf := newTestReporter(t)
+	times, err := localize.NewFormatter(localize.Config{Timezone: "Asia/Tokyo"})
+	require.NoError(t, err)
+	r.SetTimeFormatter(times)
+	r.SetRunAnnotations(reportingtest.RunAnnotations())
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report_localized", buf.Bytes())
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t)
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
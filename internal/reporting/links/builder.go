@@ -0,0 +1,179 @@
+package links
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+const (
+	LinkNameConsole = "console"
+	LinkNameSource  = "source"
+)
+
+// Config controls the deep links attached to findings. URL templates accept the
+// placeholders {region}, {account}, {id}, {id_path}, {kind}, {address}, {file}
+// and {line}. {id} is escaped as one path segment; {id_path} keeps the slashes
+// of IDs that are paths, such as Azure resource IDs.
+type Config struct {
+	// RepositoryURL links a finding to the file declaring the resource, e.g.
+	// "https://github.com/acme/infra/blob/main/{file}#L{line}".
+	RepositoryURL string `yaml:"repository_url" mapstructure:"repository_url"`
+	// Console overrides or extends the built-in console URL templates per
+	// provider and kind.
+	Console []ConsoleTemplate `yaml:"console" mapstructure:"console" validate:"omitempty,dive"`
+}
+
+type ConsoleTemplate struct {
+	// Provider is the platform provider type the template applies to (aws,
+	// gcp, azure or kubernetes). Defaults to aws.
+	Provider string              `yaml:"provider" mapstructure:"provider"`
+	Kind     domain.ResourceKind `yaml:"kind" mapstructure:"kind" validate:"required"`
+	URL      string              `yaml:"url" mapstructure:"url" validate:"required"`
+}
+
+const defaultProvider = "aws"
+
+// providerAliases maps the Terraform provider names desired resources carry to
+// the platform provider types.
+var providerAliases = map[string]string{
+	"google":      "gcp",
+	"google-beta": "gcp",
+	"azurerm":     "azure",
+}
+
+var defaultConsoleTemplates = map[string]map[domain.ResourceKind]string{
+	"aws": {
+		domain.KindComputeInstance:         "https://{region}.console.aws.amazon.com/ec2/home?region={region}#InstanceDetails:instanceId={id}",
+		domain.KindStorageBucket:           "https://s3.console.aws.amazon.com/s3/buckets/{id}?region={region}",
+		domain.KindDatabaseInstance:        "https://{region}.console.aws.amazon.com/rds/home?region={region}#database:id={id}",
+		domain.KindServerlessFunction:      "https://{region}.console.aws.amazon.com/lambda/home?region={region}#/functions/{id}",
+		domain.KindIAMRole:                 "https://console.aws.amazon.com/iam/home#/roles/details/{id}",
+		domain.KindIAMPolicy:               "https://console.aws.amazon.com/iam/home#/policies/details/{id}",
+		domain.KindEncryptionKey:           "https://{region}.console.aws.amazon.com/kms/home?region={region}#/kms/keys/{id}",
+		domain.KindNetworkSecurityGroup:    "https://{region}.console.aws.amazon.com/ec2/home?region={region}#SecurityGroup:groupId={id}",
+		domain.KindDatabaseTable:           "https://{region}.console.aws.amazon.com/dynamodbv2/home?region={region}#table?name={id}",
+		domain.KindCDNDistribution:         "https://console.aws.amazon.com/cloudfront/v4/home#/distributions/{id}",
+		domain.KindAutoScalingGroup:        "https://{region}.console.aws.amazon.com/ec2/home?region={region}#AutoScalingGroupDetails:id={id}",
+		domain.KindLaunchTemplate:          "https://{region}.console.aws.amazon.com/ec2/home?region={region}#LaunchTemplateDetails:launchTemplateId={id}",
+		domain.KindElasticIP:               "https://{region}.console.aws.amazon.com/ec2/home?region={region}#ElasticIpDetails:AllocationId={id}",
+		domain.KindNetworkInterface:        "https://{region}.console.aws.amazon.com/ec2/home?region={region}#NetworkInterface:networkInterfaceId={id}",
+		domain.KindLoadBalancer:            "https://{region}.console.aws.amazon.com/ec2/home?region={region}#LoadBalancer:loadBalancerArn={id}",
+		domain.KindLoadBalancerListener:    "https://{region}.console.aws.amazon.com/ec2/home?region={region}#ListenerDetails:listenerArn={id}",
+		domain.KindLoadBalancerTargetGroup: "https://{region}.console.aws.amazon.com/ec2/home?region={region}#TargetGroup:targetGroupArn={id}",
+		domain.KindContainerTaskDefinition: "https://{region}.console.aws.amazon.com/ecs/v2/task-definitions/{id}?region={region}",
+	},
+	"gcp": {
+		domain.KindStorageBucket: "https://console.cloud.google.com/storage/browser/{id}?project={account}",
+	},
+	"azure": {
+		domain.KindComputeInstance: "https://portal.azure.com/#@/resource{id_path}",
+		domain.KindStorageBucket:   "https://portal.azure.com/#@/resource{id_path}",
+	},
+}
+
+type templateKey struct {
+	provider string
+	kind     domain.ResourceKind
+}
+
+// Builder renders console and repository links for findings.
+type Builder struct {
+	console       map[templateKey]string
+	repositoryURL string
+}
+
+func NewBuilder(cfg Config) *Builder {
+	console := make(map[templateKey]string)
+	for provider, templates := range defaultConsoleTemplates {
+		for kind, tmpl := range templates {
+			console[templateKey{provider, kind}] = tmpl
+		}
+	}
+	for _, c := range cfg.Console {
+		provider := normalizeProvider(c.Provider)
+		if provider == "" {
+			provider = defaultProvider
+		}
+		console[templateKey{provider, c.Kind}] = c.URL
+	}
+	return &Builder{console: console, repositoryURL: cfg.RepositoryURL}
+}
+
+// Links returns the console link of the resource, when there is a template for
+// its provider and kind, and the link to its source when a repository URL is
+// configured. The provider is taken from the actual resource, or from the
+// desired one when the resource is missing from the platform.
+func (b *Builder) Links(kind domain.ResourceKind, desired, actual domain.ResourceMetadata) []domain.ResourceLink {
+	var result []domain.ResourceLink
+
+	id := firstNonEmpty(actual.ProviderAssignedID, desired.ProviderAssignedID)
+	region := firstNonEmpty(actual.Region, desired.Region)
+	account := firstNonEmpty(actual.AccountID, desired.AccountID)
+	provider := normalizeProvider(firstNonEmpty(actual.ProviderType, desired.ProviderType))
+
+	if tmpl, ok := b.console[templateKey{provider, kind}]; ok && id != "" {
+		// Console pages need a region; skip rather than emit a broken link.
+		if region != "" || !strings.Contains(tmpl, "{region}") {
+			result = append(result, domain.ResourceLink{
+				Name: LinkNameConsole,
+				URL:  render(tmpl, kind, id, region, account, desired),
+			})
+		}
+	}
+
+	if b.repositoryURL != "" && desired.SourceFile != "" {
+		result = append(result, domain.ResourceLink{
+			Name: LinkNameSource,
+			URL:  render(b.repositoryURL, kind, id, region, account, desired),
+		})
+	}
+
+	return result
+}
+
+func normalizeProvider(provider string) string {
+	// Terraform state addresses providers as provider["registry.terraform.io/hashicorp/aws"].
+	provider = strings.ToLower(strings.Trim(provider, ` "[]`))
+	if alias, ok := providerAliases[provider]; ok {
+		return alias
+	}
+	return provider
+}
+
+func render(tmpl string, kind domain.ResourceKind, id, region, account string, desired domain.ResourceMetadata) string {
+	line := ""
+	if desired.SourceLine > 0 {
+		line = strconv.Itoa(desired.SourceLine)
+	}
+	return strings.NewReplacer(
+		"{region}", region,
+		"{account}", account,
+		"{id_path}", escapePath(id),
+		"{id}", url.PathEscape(id),
+		"{kind}", string(kind),
+		"{address}", desired.SourceIdentifier,
+		"{file}", desired.SourceFile,
+		"{line}", line,
+	).Replace(tmpl)
+}
+
+// escapePath escapes every segment of a slash-separated ID.
+func escapePath(id string) string {
+	segments := strings.Split(id, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package links
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func TestBuilder_ConsoleLinkForEveryAWSKind(t *testing.T) {
+	b := NewBuilder(Config{})
+	for kind := range defaultConsoleTemplates["aws"] {
+		t.Run(string(kind), func(t *testing.T) {
+			actual := domain.ResourceMetadata{ProviderType: "aws", ProviderAssignedID: "res/1", Region: "eu-west-1"}
+
+			got := b.Links(kind, domain.ResourceMetadata{}, actual)
+
+			require.Len(t, got, 1)
+			assert.Equal(t, LinkNameConsole, got[0].Name)
+			assert.Contains(t, got[0].URL, "res%2F1")
+			assert.NotContains(t, got[0].URL, "{")
+			if strings.Contains(defaultConsoleTemplates["aws"][kind], "{region}") {
+				assert.Contains(t, got[0].URL, "eu-west-1")
+			}
+		})
+	}
+}
+
+func TestBuilder_Links(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		kind    domain.ResourceKind
+		desired domain.ResourceMetadata
+		actual  domain.ResourceMetadata
+		want    []domain.ResourceLink
+	}{
+		{
+			name:   "aws instance",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "aws", ProviderAssignedID: "i-0abc", Region: "us-east-1"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://us-east-1.console.aws.amazon.com/ec2/home?region=us-east-1#InstanceDetails:instanceId=i-0abc",
+			}},
+		},
+		{
+			name:    "provider of a missing resource taken from its terraform state address",
+			kind:    domain.KindStorageBucket,
+			desired: domain.ResourceMetadata{ProviderType: `aws"]`, ProviderAssignedID: "logs", Region: "eu-west-1"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://s3.console.aws.amazon.com/s3/buckets/logs?region=eu-west-1",
+			}},
+		},
+		{
+			name:   "gcp bucket",
+			kind:   domain.KindStorageBucket,
+			actual: domain.ResourceMetadata{ProviderType: "gcp", ProviderAssignedID: "assets", AccountID: "acme-prod", Region: "eu"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://console.cloud.google.com/storage/browser/assets?project=acme-prod",
+			}},
+		},
+		{
+			name:    "gcp bucket known by its terraform provider name",
+			kind:    domain.KindStorageBucket,
+			desired: domain.ResourceMetadata{ProviderType: "google", ProviderAssignedID: "assets", AccountID: "acme-prod"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://console.cloud.google.com/storage/browser/assets?project=acme-prod",
+			}},
+		},
+		{
+			name:   "gcp instance has no console template",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "gcp", ProviderAssignedID: "projects/p/zones/europe-west1-b/instances/web", Region: "europe-west1"},
+		},
+		{
+			name:   "azure virtual machine keeps the slashes of its resource ID",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "azure", ProviderAssignedID: "/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/web vm", Region: "westeurope"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://portal.azure.com/#@/resource/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/web%20vm",
+			}},
+		},
+		{
+			name:    "azure storage account known by its terraform provider name",
+			kind:    domain.KindStorageBucket,
+			desired: domain.ResourceMetadata{ProviderType: "azurerm", ProviderAssignedID: "/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/logs"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://portal.azure.com/#@/resource/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/logs",
+			}},
+		},
+		{
+			name:   "kubernetes has no console template",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "kubernetes", ProviderAssignedID: "default/web"},
+		},
+		{
+			name:   "unknown provider has no console link",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderAssignedID: "i-0abc", Region: "us-east-1"},
+		},
+		{
+			name:   "missing region skips a regional console link",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "aws", ProviderAssignedID: "i-0abc"},
+		},
+		{
+			name:   "missing region keeps a global console link",
+			kind:   domain.KindIAMRole,
+			actual: domain.ResourceMetadata{ProviderType: "aws", ProviderAssignedID: "deployer"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://console.aws.amazon.com/iam/home#/roles/details/deployer",
+			}},
+		},
+		{
+			name:   "missing ID has no console link",
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "aws", Region: "us-east-1"},
+		},
+		{
+			name: "user template overrides an aws default",
+			config: Config{Console: []ConsoleTemplate{
+				{Kind: domain.KindComputeInstance, URL: "https://console.example.com/{account}/{region}/{kind}/{id}"},
+			}},
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "aws", ProviderAssignedID: "i-0abc", Region: "us-east-1", AccountID: "123456789012"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://console.example.com/123456789012/us-east-1/ComputeInstance/i-0abc",
+			}},
+		},
+		{
+			name: "user template adds a provider",
+			config: Config{Console: []ConsoleTemplate{
+				{Provider: "GCP", Kind: domain.KindComputeInstance, URL: "https://console.cloud.google.com/compute/instancesDetail/{id_path}"},
+			}},
+			kind:   domain.KindComputeInstance,
+			actual: domain.ResourceMetadata{ProviderType: "gcp", ProviderAssignedID: "projects/p/zones/europe-west1-b/instances/web"},
+			want: []domain.ResourceLink{{
+				Name: LinkNameConsole,
+				URL:  "https://console.cloud.google.com/compute/instancesDetail/projects/p/zones/europe-west1-b/instances/web",
+			}},
+		},
+		{
+			name:   "source link",
+			config: Config{RepositoryURL: "https://github.com/acme/infra/blob/main/{file}#L{line}"},
+			kind:   domain.KindComputeInstance,
+			desired: domain.ResourceMetadata{
+				ProviderType: "aws", SourceIdentifier: "aws_instance.web", SourceFile: "compute.tf", SourceLine: 12,
+			},
+			want: []domain.ResourceLink{{
+				Name: LinkNameSource,
+				URL:  "https://github.com/acme/infra/blob/main/compute.tf#L12",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewBuilder(tt.config).Links(tt.kind, tt.desired, tt.actual)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package localize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Config controls how timestamps are rendered in reports.
+type Config struct {
+	// Timezone is an IANA zone name, e.g. "Europe/Berlin". Empty means UTC.
+	Timezone string `yaml:"timezone" mapstructure:"timezone"`
+	// Locale selects the date format of human readable reports, e.g. "en-US"
+	// or "de-DE". Empty keeps RFC 3339.
+	Locale string `yaml:"locale" mapstructure:"locale"`
+	// TimeFormat is a Go time layout that overrides the locale's format, e.g.
+	// "02 Jan 2006 15:04".
+	TimeFormat string `yaml:"time_format" mapstructure:"time_format"`
+}
+
+// localeLayouts are the date and time layouts of the supported locales. Month
+// names are only used by English locales since Go formats them in English.
+var localeLayouts = map[string]string{
+	"en-us": "Jan 2, 2006 3:04:05 PM MST",
+	"en-gb": "2 Jan 2006 15:04:05 MST",
+	"de-de": "02.01.2006 15:04:05 MST",
+	"fr-fr": "02/01/2006 15:04:05 MST",
+	"es-es": "02/01/2006 15:04:05 MST",
+	"it-it": "02/01/2006 15:04:05 MST",
+	"nl-nl": "02-01-2006 15:04:05 MST",
+	"pt-br": "02/01/2006 15:04:05 MST",
+	"ja-jp": "2006/01/02 15:04:05 MST",
+	"zh-cn": "2006-01-02 15:04:05 MST",
+}
+
+// languageDefaults picks the locale of a language given without a region
+// when several locales share it.
+var languageDefaults = map[string]string{
+	"en": "en-us",
+}
+
+// Formatter renders timestamps in the configured zone and format. A nil
+// Formatter renders them as RFC 3339 in their own zone.
+type Formatter struct {
+	location *time.Location
+	layout   string
+}
+
+// NewFormatter validates the configuration and returns its formatter.
+func NewFormatter(cfg Config) (*Formatter, error) {
+	f := &Formatter{location: time.UTC, layout: time.RFC3339}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation,
+				fmt.Sprintf("unknown report timezone '%s'", cfg.Timezone),
+				"Use an IANA zone name such as 'UTC' or 'Europe/Berlin'.")
+		}
+		f.location = loc
+	}
+	if cfg.Locale != "" {
+		layout, ok := layoutForLocale(cfg.Locale)
+		if !ok {
+			return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+				fmt.Sprintf("unsupported report locale '%s'", cfg.Locale),
+				fmt.Sprintf("Supported: %s, or set a Go layout in time_format.", strings.Join(SupportedLocales(), ", ")))
+		}
+		f.layout = layout
+	}
+	if cfg.TimeFormat != "" {
+		f.layout = cfg.TimeFormat
+	}
+	return f, nil
+}
+
+// layoutForLocale looks up a locale such as "de-DE" or "de_DE", falling back
+// to the first supported locale of the same language, e.g. "de".
+func layoutForLocale(locale string) (string, bool) {
+	key := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if layout, ok := localeLayouts[key]; ok {
+		return layout, true
+	}
+	language, _, _ := strings.Cut(key, "-")
+	if fallback, ok := languageDefaults[language]; ok {
+		return localeLayouts[fallback], true
+	}
+	for _, candidate := range SupportedLocales() {
+		if strings.HasPrefix(strings.ToLower(candidate), language+"-") {
+			return localeLayouts[strings.ToLower(candidate)], true
+		}
+	}
+	return "", false
+}
+
+// SupportedLocales returns the locales with a built-in date format, sorted.
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(localeLayouts))
+	for key := range localeLayouts {
+		language, region, _ := strings.Cut(key, "-")
+		locales = append(locales, language+"-"+strings.ToUpper(region))
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Format renders t in the configured zone and format.
+func (f *Formatter) Format(t time.Time) string {
+	if f == nil {
+		return t.Format(time.RFC3339)
+	}
+	return t.In(f.location).Format(f.layout)
+}
+
+// In returns t in the configured zone, for machine readable reports that keep
+// their own timestamp format.
+func (f *Formatter) In(t time.Time) time.Time {
+	if f == nil || t.IsZero() {
+		return t
+	}
+	return t.In(f.location)
+}
@@ -0,0 +1,61 @@
+package localize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+var sample = time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+
+func TestFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"defaults to RFC 3339 in UTC", Config{}, "2024-03-05T14:07:09Z"},
+		{"timezone only", Config{Timezone: "Europe/Berlin"}, "2024-03-05T15:07:09+01:00"},
+		{"US locale", Config{Timezone: "America/New_York", Locale: "en-US"}, "Mar 5, 2024 9:07:09 AM EST"},
+		{"German locale with underscore", Config{Timezone: "Europe/Berlin", Locale: "de_DE"}, "05.03.2024 15:07:09 CET"},
+		{"language only", Config{Locale: "ja"}, "2024/03/05 14:07:09 UTC"},
+		{"English defaults to US", Config{Locale: "en"}, "Mar 5, 2024 2:07:09 PM UTC"},
+		{"time format overrides locale", Config{Locale: "en-GB", TimeFormat: "2006-01-02 15:04"}, "2024-03-05 14:07"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFormatter(tc.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, f.Format(sample))
+		})
+	}
+}
+
+func TestFormatter_Nil(t *testing.T) {
+	var f *Formatter
+	local := sample.In(time.FixedZone("X", 3600))
+	assert.Equal(t, "2024-03-05T15:07:09+01:00", f.Format(local))
+	assert.Equal(t, local, f.In(local))
+}
+
+func TestFormatter_In(t *testing.T) {
+	f, err := NewFormatter(Config{Timezone: "Asia/Tokyo"})
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", f.In(sample).Location().String())
+	assert.True(t, f.In(time.Time{}).IsZero())
+}
+
+func TestNewFormatter_Invalid(t *testing.T) {
+	_, err := NewFormatter(Config{Timezone: "Mars/Olympus"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+
+	_, err = NewFormatter(Config{Locale: "xx-YY"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeConfigValidation))
+	assert.Contains(t, err.Error(), "xx-YY")
+}
@@ -0,0 +1,120 @@
+package markdown
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffLines returns the lines of a diff code fence from the expected to the
+// actual value, at most maxLines of them (no limit when maxLines is negative),
+// and the number of lines left out.
+func diffLines(expected, actual any, maxLines int) ([]string, int) {
+	expectedLines := valueLines(expected)
+	actualLines := valueLines(actual)
+
+	var lines []string
+	if len(expectedLines) == 1 && len(actualLines) == 1 {
+		lines = []string{"- " + expectedLines[0], "+ " + actualLines[0]}
+	} else {
+		unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:       difflib.SplitLines(strings.Join(expectedLines, "\n")),
+			B:       difflib.SplitLines(strings.Join(actualLines, "\n")),
+			Context: 3,
+		})
+		if err != nil || unified == "" {
+			lines = prefixed("- ", expectedLines)
+			lines = append(lines, prefixed("+ ", actualLines)...)
+		} else {
+			for _, line := range strings.Split(strings.TrimSuffix(unified, "\n"), "\n") {
+				if strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") {
+					continue
+				}
+				// difflib marks lines with a single character; the space keeps
+				// the values aligned with the "- " and "+ " of short diffs.
+				if line != "" && strings.IndexByte("-+ ", line[0]) >= 0 {
+					line = line[:1] + " " + line[1:]
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	if maxLines >= 0 && len(lines) > maxLines {
+		return lines[:maxLines], len(lines) - maxLines
+	}
+	return lines, 0
+}
+
+func prefixed(prefix string, lines []string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = prefix + line
+	}
+	return out
+}
+
+// valueLines renders an attribute value as the lines it is diffed by. Lists,
+// maps and strings holding a JSON document, such as IAM policies, are
+// pretty-printed so that a change shows up on the line it affects.
+func valueLines(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{"null"}
+	case string:
+		if doc, ok := jsonDocument(v); ok {
+			return strings.Split(doc, "\n")
+		}
+		return strings.Split(v, "\n")
+	case bool, int, int32, int64, float32, float64:
+		return []string{fmt.Sprint(v)}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return []string{fmt.Sprintf("%v", value)}
+	}
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+// jsonDocument returns s pretty-printed when it is a JSON object or array.
+func jsonDocument(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// fence returns a code fence longer than any run of backticks in lines, so that
+// values containing backticks cannot close it early.
+func fence(lines []string) string {
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, backtickRun(line))
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// backtickRun returns the length of the longest run of backticks in s.
+func backtickRun(s string) int {
+	longest, run := 0, 0
+	for _, c := range s {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
@@ -0,0 +1,384 @@
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+)
+
+const ReporterTypeMarkdown = "markdown"
+
+type Config struct {
+	// MaxDiffLines caps the lines shown of every attribute diff, so that a
+	// large policy or list does not push the comment past the size limit of
+	// the code host. Zero uses DefaultMaxDiffLines; a negative value shows
+	// whole diffs.
+	MaxDiffLines int `yaml:"max_diff_lines" mapstructure:"max_diff_lines"`
+}
+
+// DefaultMaxDiffLines is the diff length used when the configuration does not
+// set one.
+const DefaultMaxDiffLines = 50
+
+// reportTitle heads the comment.
+const reportTitle = "Infrastructure Drift Report"
+
+// Reporter writes GitHub/GitLab flavoured Markdown meant to be posted as a
+// pull request comment: a table of the status counts, then a collapsible
+// section per finding with its details and a diff code fence per attribute.
+// Resources without drift are listed in a single collapsed section.
+type Reporter struct {
+	config      Config
+	writer      io.Writer
+	logger      ports.Logger
+	stateIssues []domain.StateIssue
+	annotations []domain.RunAnnotation
+	times       *localize.Formatter
+}
+
+func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
+	if cfg.MaxDiffLines == 0 {
+		cfg.MaxDiffLines = DefaultMaxDiffLines
+	}
+	return &Reporter{
+		config: cfg,
+		writer: os.Stdout,
+		logger: logger,
+	}, nil
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetStateIssues sets the state source issues listed after the results.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	r.stateIssues = issues
+}
+
+// SetRunAnnotations sets the run annotations listed after the results.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	r.annotations = annotations
+}
+
+// SetTimeFormatter renders timestamps in the formatter's zone and locale
+// instead of RFC 3339.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
+}
+
+// statusLabels are the summary table label of every status, in table order.
+var statusLabels = []struct {
+	status domain.ComparisonStatus
+	label  string
+	// always shows the row when its count is zero.
+	always bool
+}{
+	{domain.StatusNoDrift, "No drift", true},
+	{domain.StatusDrifted, "Drifted", true},
+	{domain.StatusMissing, "Missing", true},
+	{domain.StatusRecentlyDeleted, "Recently deleted", false},
+	{domain.StatusPendingDeletion, "Pending deletion", false},
+	{domain.StatusUnmanaged, "Unmanaged", true},
+	{domain.StatusAmbiguous, "Ambiguous", false},
+	{domain.StatusError, "Errors", true},
+	{domain.StatusDeadLettered, "Dead-lettered", false},
+	{domain.StatusUnapprovedImage, "Unapproved images", false},
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	if ctx.Err() != nil {
+		r.logger.Warnf(ctx, "Markdown report generation cancelled.")
+		return ctx.Err()
+	}
+
+	var b strings.Builder
+	b.WriteString("## " + reportTitle + "\n\n")
+	r.writeSummary(&b, results)
+
+	ordered := make([]domain.ComparisonResult, len(results))
+	copy(ordered, results)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		if ordered[i].ResourceKind != ordered[j].ResourceKind {
+			return ordered[i].ResourceKind < ordered[j].ResourceKind
+		}
+		return label(ordered[i]) < label(ordered[j])
+	})
+	var findings, noDrift, deadLetters []domain.ComparisonResult
+	for _, res := range ordered {
+		switch {
+		case res.Status == domain.StatusDeadLettered:
+			deadLetters = append(deadLetters, res)
+		case res.Status == domain.StatusNoDrift && len(res.Differences) == 0:
+			noDrift = append(noDrift, res)
+		default:
+			findings = append(findings, res)
+		}
+	}
+
+	if len(results) == 0 {
+		b.WriteString("No resources found or processed.\n")
+	}
+	if len(findings) > 0 {
+		b.WriteString("### Findings\n\n")
+		for _, res := range findings {
+			r.writeResult(&b, res)
+		}
+	}
+	if len(noDrift) > 0 {
+		fmt.Fprintf(&b, "<details>\n<summary>%d resource(s) without drift</summary>\n\n", len(noDrift))
+		for _, res := range noDrift {
+			fmt.Fprintf(&b, "- %s (%s)\n", code(label(res)), res.ResourceKind)
+		}
+		b.WriteString("\n</details>\n\n")
+	}
+	if len(deadLetters) > 0 {
+		b.WriteString("### Dead letters\n\nResources whose comparison failed in several consecutive runs.\n\n")
+		for _, res := range deadLetters {
+			r.writeResult(&b, res)
+		}
+	}
+	if len(r.annotations) > 0 {
+		b.WriteString("### Run annotations\n\n")
+		for _, a := range r.annotations {
+			fmt.Fprintf(&b, "- %s [%s] %s\n", r.times.Format(a.Time), escape(a.Source), escape(a.Message))
+		}
+		b.WriteString("\n")
+	}
+	if len(r.stateIssues) > 0 {
+		b.WriteString("### State source issues\n\n| Severity | Issue | Address | Location |\n| --- | --- | --- | --- |\n")
+		for _, issue := range r.stateIssues {
+			summary := escape(issue.Summary)
+			if issue.Detail != "" {
+				summary += "<br>" + escape(issue.Detail)
+			}
+			if issue.Skipped {
+				summary += "<br>Resource left out of the analysis."
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", issue.Severity, summary, code(issue.Address), code(issue.Location))
+		}
+		b.WriteString("\n")
+	}
+
+	if _, err := io.WriteString(r.writer, strings.TrimSuffix(b.String(), "\n")+"\n"); err != nil {
+		return fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+	r.logger.Debugf(ctx, "Markdown report generated with %d result(s).", len(results))
+	return nil
+}
+
+func (r *Reporter) writeSummary(b *strings.Builder, results []domain.ComparisonResult) {
+	counts := make(map[domain.ComparisonStatus]int)
+	total := 0
+	for _, res := range results {
+		counts[res.Status]++
+		// Unapproved images accompany the instance's own result, so they are
+		// not part of the total.
+		if res.Status != domain.StatusUnapprovedImage {
+			total++
+		}
+	}
+	b.WriteString("| Status | Count |\n| :--- | ---: |\n")
+	fmt.Fprintf(b, "| Processed | %d |\n", total)
+	for _, s := range statusLabels {
+		if count := counts[s.status]; count > 0 || s.always {
+			fmt.Fprintf(b, "| %s | %d |\n", s.label, count)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// writeResult writes the collapsible section of one result. Its summary line
+// is HTML, since Markdown is not rendered inside <summary>.
+func (r *Reporter) writeResult(b *strings.Builder, res domain.ComparisonResult) {
+	name := label(res)
+	if name == "" {
+		name = "<unknown>"
+	}
+	summary := fmt.Sprintf("<b>%s</b> <code>%s</code> (%s)", res.Status, html.EscapeString(name), html.EscapeString(string(res.ResourceKind)))
+	if n := len(res.Differences); n > 0 {
+		summary += fmt.Sprintf(" · %d attribute(s) differ", n)
+	}
+	fmt.Fprintf(b, "<details>\n<summary>%s</summary>\n\n", summary)
+
+	var facts []string
+	if res.ProviderAssignedID != "" && res.ProviderAssignedID != name {
+		facts = append(facts, "Platform ID: "+code(res.ProviderAssignedID))
+	}
+	if res.SourceFile != "" {
+		location := res.SourceFile
+		if res.SourceLine > 0 {
+			location = fmt.Sprintf("%s:%d", location, res.SourceLine)
+		}
+		facts = append(facts, "Declared in "+code(location))
+	}
+	if severity := res.MaxSeverity(); severity != "" {
+		facts = append(facts, fmt.Sprintf("Severity: **%s**", severity))
+	}
+	if len(facts) > 0 {
+		b.WriteString(strings.Join(facts, " · ") + "\n\n")
+	}
+
+	for _, note := range r.notes(res) {
+		b.WriteString(escape(note) + "\n\n")
+	}
+	if res.Status == domain.StatusAmbiguous {
+		for _, candidate := range res.Candidates {
+			if candidate.SourceIdentifier != "" {
+				b.WriteString("- state: " + code(candidate.SourceIdentifier) + "\n")
+			} else {
+				b.WriteString("- platform: " + code(candidate.ProviderAssignedID) + "\n")
+			}
+		}
+		if len(res.Candidates) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	for _, d := range res.Differences {
+		r.writeDiff(b, d)
+	}
+
+	if len(res.NotAsserted) > 0 {
+		attrs := make([]string, len(res.NotAsserted))
+		for i, attr := range res.NotAsserted {
+			attrs[i] = code(attr)
+		}
+		b.WriteString("Not asserted (known after apply): " + strings.Join(attrs, ", ") + "\n\n")
+	}
+	if len(res.Links) > 0 {
+		links := make([]string, len(res.Links))
+		for i, link := range res.Links {
+			links[i] = fmt.Sprintf("[%s](%s)", escape(link.Name), link.URL)
+		}
+		b.WriteString(strings.Join(links, " · ") + "\n\n")
+	}
+	if res.Trace != nil {
+		b.WriteString("Explain:\n\n")
+		for _, step := range res.Trace.Steps {
+			b.WriteString("- " + escape(step) + "\n")
+		}
+		for _, attr := range res.Trace.Attributes {
+			verdict := "equal"
+			switch {
+			case attr.Skipped:
+				verdict = "skipped"
+			case !attr.Equal:
+				verdict = "different"
+			}
+			fmt.Fprintf(b, "- %s: %s\n", code(attr.Attribute), verdict)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("</details>\n\n")
+}
+
+func (r *Reporter) writeDiff(b *strings.Builder, d domain.AttributeDiff) {
+	head := "**" + escape(d.AttributeName) + "**"
+	if d.Severity != "" {
+		head += " · " + string(d.Severity)
+	}
+	if d.Group != "" {
+		head += " · group " + escape(d.Group)
+	}
+	b.WriteString(head + "\n\n")
+	if d.Details != "" {
+		b.WriteString(escape(d.Details) + "\n\n")
+	}
+	if d.PlatformManaged != "" {
+		b.WriteString("Platform-managed: " + escape(d.PlatformManaged) + "\n\n")
+	}
+
+	lines, omitted := diffLines(d.ExpectedValue, d.ActualValue, r.config.MaxDiffLines)
+	marker := fence(lines)
+	b.WriteString(marker + "diff\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(marker + "\n\n")
+	if omitted > 0 {
+		fmt.Fprintf(b, "_%d more line(s) not shown._\n\n", omitted)
+	}
+}
+
+// notes are the explanatory lines of a result above its differences.
+func (r *Reporter) notes(res domain.ComparisonResult) []string {
+	var notes []string
+	switch res.Status {
+	case domain.StatusMissing:
+		notes = append(notes, "Resource defined in the desired state but not found on the platform.")
+	case domain.StatusUnmanaged:
+		notes = append(notes, "Resource found on the platform but not defined in the desired state.")
+	case domain.StatusRecentlyDeleted:
+		notes = append(notes, "Resource deleted from the platform since the previous run.")
+		if w := res.DeletionWindow; w != nil {
+			notes = append(notes, fmt.Sprintf("Deleted between %s and %s.", r.times.Format(w.From), r.times.Format(w.To)))
+		}
+	case domain.StatusPendingDeletion:
+		notes = append(notes, "Resource is being deleted by the platform or held for deletion; its attributes were not compared.")
+		if pd := res.PendingDeletion; pd != nil {
+			state := fmt.Sprintf("State: %s", pd.State)
+			if !pd.DeletionDate.IsZero() {
+				state += fmt.Sprintf(", scheduled for deletion on %s", r.times.Format(pd.DeletionDate))
+			}
+			notes = append(notes, state+".")
+		}
+	case domain.StatusAmbiguous:
+		notes = append(notes, "Several resources match one another, so none was paired or compared.")
+	case domain.StatusDeadLettered:
+		if fs := res.FailureStreak; fs != nil {
+			notes = append(notes, fmt.Sprintf("Failed in %d consecutive runs since %s.", fs.Runs, r.times.Format(fs.Since)))
+		}
+	}
+	if res.Error != nil {
+		notes = append(notes, res.Error.Error())
+	}
+	if res.Match != nil && res.Match.Confidence > 0 {
+		notes = append(notes, fmt.Sprintf("Matched via %s (confidence %.2f).", res.Match.Strategy, res.Match.Confidence))
+	}
+	return notes
+}
+
+func label(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
+
+// markdownEscaper backslash-escapes the characters that Markdown or the HTML
+// it allows would interpret in free text such as error messages.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", "&lt;", ">", "&gt;", "|", `\|`, "#", `\#`,
+)
+
+func escape(s string) string {
+	return markdownEscaper.Replace(strings.ReplaceAll(s, "\n", " "))
+}
+
+// code renders s as inline code, with a delimiter longer than any run of
+// backticks in it. Pipes are escaped so that the span can sit in a table.
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	delimiter := strings.Repeat("`", backtickRun(s)+1)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return delimiter + s + delimiter
+}
@@ -0,0 +1,132 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.SetWriter(&buf)
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t, Config{MaxDiffLines: 8})
+	r.SetStateIssues(reportingtest.StateIssues())
+	r.SetRunAnnotations(reportingtest.RunAnnotations())
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
+
+func TestReporter_EscapesValues(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+	results := []domain.ComparisonResult{{
+		Status:           domain.StatusError,
+		ResourceKind:     domain.KindComputeInstance,
+		SourceIdentifier: `aws_instance.x["<script>"]`,
+		Error:            fmt.Errorf("denied for *all* <img src=x> | see [docs](http://evil)"),
+		Differences: []domain.AttributeDiff{{
+			AttributeName: "user_data",
+			ExpectedValue: "echo ```\n</details>",
+			ActualValue:   "echo",
+		}},
+	}}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	out := buf.String()
+	assert.Contains(t, out, "<code>aws_instance.x[&#34;&lt;script&gt;&#34;]</code>")
+	assert.Contains(t, out, `denied for \*all\* &lt;img src=x&gt; \| see \[docs\](http://evil)`)
+	assert.Contains(t, out, "````diff\n@@ -1,2 +1 @@\n- echo ```\n- </details>\n+ echo\n````")
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected any
+		actual   any
+		max      int
+		want     []string
+		omitted  int
+	}{
+		{
+			name:     "scalar values",
+			expected: "t3.micro",
+			actual:   "t3.large",
+			max:      10,
+			want:     []string{"- t3.micro", "+ t3.large"},
+		},
+		{
+			name:     "missing value",
+			expected: true,
+			actual:   nil,
+			max:      10,
+			want:     []string{"- true", "+ null"},
+		},
+		{
+			name:     "list",
+			expected: []any{"sg-1"},
+			actual:   []any{"sg-1", "sg-2"},
+			max:      10,
+			want:     []string{"@@ -1,3 +1,4 @@", "  [", "-   \"sg-1\"", "+   \"sg-1\",", "+   \"sg-2\"", "  ]"},
+		},
+		{
+			name:     "policy document string",
+			expected: `{"Effect":"Allow","Action":"s3:GetObject"}`,
+			actual:   `{"Effect":"Allow","Action":"s3:*"}`,
+			max:      10,
+			want:     []string{"@@ -1,4 +1,4 @@", "  {", "    \"Effect\": \"Allow\",", "-   \"Action\": \"s3:GetObject\"", "+   \"Action\": \"s3:*\"", "  }"},
+		},
+		{
+			name:     "truncated",
+			expected: []any{"sg-1"},
+			actual:   []any{"sg-1", "sg-2"},
+			max:      3,
+			want:     []string{"@@ -1,3 +1,4 @@", "  [", "-   \"sg-1\""},
+			omitted:  3,
+		},
+		{
+			name:     "no limit",
+			expected: "a\nb\nc",
+			actual:   "a\nb\nd",
+			max:      -1,
+			want:     []string{"@@ -1,3 +1,3 @@", "  a", "  b", "- c", "+ d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, omitted := diffLines(tt.expected, tt.actual, tt.max)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.omitted, omitted)
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "`aws_instance.web`", code("aws_instance.web"))
+	assert.Equal(t, "``a`b``", code("a`b"))
+	assert.Equal(t, "``` ``x ```", code("``x"))
+	assert.Equal(t, "`a\\|b`", code("a|b"))
+}
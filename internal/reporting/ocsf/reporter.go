@@ -0,0 +1,350 @@
+package ocsf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+const ReporterTypeOCSF = "ocsf"
+
+// OCSF Detection Finding class constants (schema 1.1.0).
+const (
+	schemaVersion     = "1.1.0"
+	categoryUID       = 2
+	categoryName      = "Findings"
+	classUID          = 2004
+	className         = "Detection Finding"
+	activityCreate    = 1
+	activityName      = "Create"
+	statusNew         = 1
+	statusName        = "New"
+	findingType       = "Configuration Drift"
+	productName       = "infra-drift-detector"
+	productVendorName = "olusolaa"
+)
+
+// OCSF severity_id values.
+const (
+	severityInformational = 1
+	severityLow           = 2
+	severityMedium        = 3
+	severityHigh          = 4
+	severityCritical      = 5
+)
+
+type Config struct {
+	// AccountID and Region describe the scanned cloud account. They fill the
+	// OCSF cloud object, since findings do not carry them.
+	AccountID string `yaml:"account_id" mapstructure:"account_id"`
+	Region    string `yaml:"region" mapstructure:"region"`
+}
+
+// Reporter writes drift findings as OCSF Detection Finding events, one JSON
+// event per line, for ingestion into Security Lake or SIEM pipelines. Results
+// without drift and comparison errors produce no event.
+type Reporter struct {
+	config Config
+	writer io.Writer
+	logger ports.Logger
+	now    func() time.Time
+}
+
+func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
+	return &Reporter{
+		config: cfg,
+		writer: os.Stdout,
+		logger: logger,
+		now:    time.Now,
+	}, nil
+}
+
+type event struct {
+	ActivityID   int            `json:"activity_id"`
+	ActivityName string         `json:"activity_name"`
+	CategoryUID  int            `json:"category_uid"`
+	CategoryName string         `json:"category_name"`
+	ClassUID     int            `json:"class_uid"`
+	ClassName    string         `json:"class_name"`
+	TypeUID      int            `json:"type_uid"`
+	TypeName     string         `json:"type_name"`
+	SeverityID   int            `json:"severity_id"`
+	Severity     string         `json:"severity"`
+	StatusID     int            `json:"status_id"`
+	Status       string         `json:"status"`
+	Time         int64          `json:"time"`
+	Message      string         `json:"message"`
+	Metadata     metadata       `json:"metadata"`
+	FindingInfo  findingInfo    `json:"finding_info"`
+	Cloud        cloud          `json:"cloud"`
+	Resources    []resource     `json:"resources"`
+	Unmapped     map[string]any `json:"unmapped,omitempty"`
+}
+
+type metadata struct {
+	Version string  `json:"version"`
+	Product product `json:"product"`
+}
+
+type product struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name"`
+}
+
+type findingInfo struct {
+	UID         string   `json:"uid"`
+	Title       string   `json:"title"`
+	Desc        string   `json:"desc,omitempty"`
+	Types       []string `json:"types"`
+	CreatedTime int64    `json:"created_time"`
+	SrcURL      string   `json:"src_url,omitempty"`
+}
+
+type cloud struct {
+	Provider string   `json:"provider"`
+	Region   string   `json:"region,omitempty"`
+	Account  *account `json:"account,omitempty"`
+}
+
+type account struct {
+	UID string `json:"uid"`
+}
+
+type resource struct {
+	UID    string `json:"uid,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type"`
+	Region string `json:"region,omitempty"`
+}
+
+type difference struct {
+	Attribute string `json:"attribute"`
+	Expected  any    `json:"expected"`
+	Actual    any    `json:"actual"`
+	Details   string `json:"details,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Group     string `json:"group,omitempty"`
+	// PlatformManaged explains why the difference was caused by the platform itself.
+	PlatformManaged string `json:"platform_managed,omitempty"`
+	// Diff lists the paths at which JSON document values, such as policies, differ.
+	Diff []change `json:"diff,omitempty"`
+}
+
+type change struct {
+	Path     string              `json:"path"`
+	Op       domain.JSONChangeOp `json:"op"`
+	Expected any                 `json:"expected,omitempty"`
+	Actual   any                 `json:"actual,omitempty"`
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	now := r.now()
+	encoder := json.NewEncoder(r.writer)
+	emitted := 0
+	for _, res := range results {
+		if ctx.Err() != nil {
+			r.logger.Warnf(ctx, "OCSF report generation cancelled.")
+			return ctx.Err()
+		}
+		evt, ok := r.buildEvent(res, now)
+		if !ok {
+			continue
+		}
+		if err := encoder.Encode(evt); err != nil {
+			r.logger.Errorf(ctx, err, "Failed to encode OCSF event")
+			return fmt.Errorf("failed to encode OCSF event: %w", err)
+		}
+		emitted++
+	}
+	r.logger.Debugf(ctx, "OCSF report generated with %d finding event(s).", emitted)
+	return nil
+}
+
+func (r *Reporter) buildEvent(res domain.ComparisonResult, now time.Time) (event, bool) {
+	title, ok := findingTitle(res)
+	if !ok {
+		return event{}, false
+	}
+	severityID := severityIDFor(res)
+	millis := now.UnixMilli()
+
+	evt := event{
+		ActivityID:   activityCreate,
+		ActivityName: activityName,
+		CategoryUID:  categoryUID,
+		CategoryName: categoryName,
+		ClassUID:     classUID,
+		ClassName:    className,
+		TypeUID:      classUID*100 + activityCreate,
+		TypeName:     className + ": " + activityName,
+		SeverityID:   severityID,
+		Severity:     severityName(severityID),
+		StatusID:     statusNew,
+		Status:       statusName,
+		Time:         millis,
+		Message:      title,
+		Metadata: metadata{
+			Version: schemaVersion,
+			Product: product{Name: productName, VendorName: productVendorName},
+		},
+		FindingInfo: findingInfo{
+			UID:         findingUID(res),
+			Title:       title,
+			Desc:        findingDescription(res),
+			Types:       []string{findingType},
+			CreatedTime: millis,
+		},
+		Cloud: cloud{
+			Provider: strings.ToUpper(res.ProviderType),
+			Region:   r.config.Region,
+		},
+		Resources: []resource{{
+			UID:    res.ProviderAssignedID,
+			Name:   res.SourceIdentifier,
+			Type:   string(res.ResourceKind),
+			Region: r.config.Region,
+		}},
+		Unmapped: map[string]any{"drift_status": res.Status},
+	}
+	if r.config.AccountID != "" {
+		evt.Cloud.Account = &account{UID: r.config.AccountID}
+	}
+	if len(res.Links) > 0 {
+		evt.FindingInfo.SrcURL = res.Links[0].URL
+	}
+	if len(res.Differences) > 0 {
+		diffs := make([]difference, len(res.Differences))
+		for i, d := range res.Differences {
+			diffs[i] = difference{
+				Attribute:       d.AttributeName,
+				Expected:        d.ExpectedValue,
+				Actual:          d.ActualValue,
+				Details:         d.Details,
+				Severity:        d.Severity.String(),
+				Group:           d.Group,
+				PlatformManaged: d.PlatformManaged,
+			}
+			for _, c := range d.Changes {
+				diffs[i].Diff = append(diffs[i].Diff, change(c))
+			}
+		}
+		evt.Unmapped["differences"] = diffs
+	}
+	return evt, true
+}
+
+func findingTitle(res domain.ComparisonResult) (string, bool) {
+	switch res.Status {
+	case domain.StatusDrifted:
+		return fmt.Sprintf("Configuration drift detected on %s %s", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusMissing:
+		return fmt.Sprintf("Managed %s %s is missing from the platform", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusRecentlyDeleted:
+		return fmt.Sprintf("Managed %s %s was recently deleted from the platform", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusUnmanaged:
+		return fmt.Sprintf("Unmanaged %s %s found on the platform", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusUnapprovedImage:
+		return fmt.Sprintf("%s %s runs an unapproved image", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusPendingDeletion:
+		return fmt.Sprintf("%s %s is pending deletion on the platform", res.ResourceKind, resourceLabel(res)), true
+	case domain.StatusAmbiguous:
+		return fmt.Sprintf("%s %s matches several resources and was not compared", res.ResourceKind, resourceLabel(res)), true
+	default:
+		return "", false
+	}
+}
+
+func findingDescription(res domain.ComparisonResult) string {
+	if pd := res.PendingDeletion; pd != nil {
+		desc := fmt.Sprintf("The platform reports the resource as %s; its attributes were not compared.", pd.State)
+		if !pd.DeletionDate.IsZero() {
+			desc += fmt.Sprintf(" It will be deleted on %s.", pd.DeletionDate.UTC().Format(time.RFC3339))
+		}
+		return desc
+	}
+	if res.Status == domain.StatusAmbiguous {
+		labels := make([]string, len(res.Candidates))
+		for i, candidate := range res.Candidates {
+			labels[i] = candidate.SourceIdentifier
+			if labels[i] == "" {
+				labels[i] = candidate.ProviderAssignedID
+			}
+		}
+		return fmt.Sprintf("These resources match one another: %s.", strings.Join(labels, ", "))
+	}
+	if len(res.Differences) == 0 {
+		return ""
+	}
+	if res.Status == domain.StatusUnapprovedImage {
+		return res.Differences[0].Details
+	}
+	names := make([]string, len(res.Differences))
+	for i, d := range res.Differences {
+		names[i] = d.AttributeName
+	}
+	return fmt.Sprintf("%d attribute(s) differ from the desired state: %s", len(names), strings.Join(names, ", "))
+}
+
+func resourceLabel(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
+
+// findingUID derives a stable identifier so that repeated runs reporting the
+// same drift produce the same finding, letting SIEMs deduplicate them.
+func findingUID(res domain.ComparisonResult) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(res.ResourceKind), res.SourceIdentifier, res.ProviderAssignedID, string(res.Status),
+	}, "|")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func severityIDFor(res domain.ComparisonResult) int {
+	switch res.MaxSeverity() {
+	case domain.SeverityCritical:
+		return severityCritical
+	case domain.SeverityWarning:
+		return severityMedium
+	case domain.SeverityInfo:
+		return severityLow
+	}
+	switch res.Status {
+	case domain.StatusMissing, domain.StatusRecentlyDeleted:
+		return severityHigh
+	case domain.StatusUnmanaged:
+		return severityMedium
+	default:
+		return severityInformational
+	}
+}
+
+func severityName(id int) string {
+	switch id {
+	case severityLow:
+		return "Low"
+	case severityMedium:
+		return "Medium"
+	case severityHigh:
+		return "High"
+	case severityCritical:
+		return "Critical"
+	default:
+		return "Informational"
+	}
+}
@@ -0,0 +1,38 @@
+package ocsf
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.writer = &buf
+	r.now = func() time.Time { return reportingtest.Now }
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t, Config{AccountID: "123456789012", Region: "eu-west-3"})
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
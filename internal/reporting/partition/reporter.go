@@ -0,0 +1,252 @@
+// Package partition splits very large reports into several files with an
+// index, so that no single report file becomes too large to open.
+package partition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+const (
+	// ByKind writes one file per resource kind, paged when a kind has more
+	// than MaxResults results.
+	ByKind = "kind"
+	// ByShard orders all results by resource identifier and cuts them into
+	// alphabetical shards of MaxResults results.
+	ByShard = "shard"
+
+	// DefaultMaxResults bounds the results written to one file.
+	DefaultMaxResults = 50000
+
+	// IndexFile names the partitions of the last report in the directory.
+	IndexFile = "index.json"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Config enables writing the report as partitioned files.
+type Config struct {
+	// Directory receives the partition files and the index.
+	Directory string `yaml:"directory" mapstructure:"directory" validate:"required"`
+	// By selects how results are partitioned: kind (default) or shard.
+	By string `yaml:"by" mapstructure:"by" validate:"omitempty,oneof=kind shard"`
+	// MaxResults bounds the results per file. Zero uses DefaultMaxResults.
+	MaxResults int `yaml:"max_results" mapstructure:"max_results" validate:"omitempty,min=1"`
+}
+
+// Reporter renders each partition with the configured reporter into its own
+// file, then writes an index of the files.
+type Reporter struct {
+	config    Config
+	inner     ports.Reporter
+	writer    ports.WriterReporter
+	format    string
+	extension string
+	logger    ports.Logger
+}
+
+// NewReporter wraps inner, whose output must be redirectable. format names the
+// inner report format in the index and extension is the partition file
+// extension, including the dot.
+func NewReporter(cfg Config, inner ports.Reporter, format, extension string, logger ports.Logger) (*Reporter, error) {
+	writer, ok := inner.(ports.WriterReporter)
+	if !ok {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation,
+			fmt.Sprintf("the %s reporter cannot write partitioned reports", format),
+			"Remove settings.reporter_config.partition or choose another reporter.")
+	}
+	if cfg.Directory == "" {
+		return nil, errors.New(errors.CodeConfigValidation, "partitioned reports require a directory")
+	}
+	if cfg.By == "" {
+		cfg.By = ByKind
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = DefaultMaxResults
+	}
+	return &Reporter{config: cfg, inner: inner, writer: writer, format: format, extension: extension, logger: logger}, nil
+}
+
+// SetStateIssues hands the state source issues to the inner reporter, which
+// includes them in every partition.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	if inner, ok := r.inner.(ports.StateIssueReporter); ok {
+		inner.SetStateIssues(issues)
+	}
+}
+
+// SetRunAnnotations hands the run annotations to the inner reporter, which
+// includes them in every partition.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	if inner, ok := r.inner.(ports.RunAnnotationReporter); ok {
+		inner.SetRunAnnotations(annotations)
+	}
+}
+
+type index struct {
+	Format        string      `json:"format"`
+	PartitionedBy string      `json:"partitioned_by"`
+	TotalResults  int         `json:"total_results"`
+	Files         []indexFile `json:"files"`
+}
+
+type indexFile struct {
+	File         string                          `json:"file"`
+	Kind         domain.ResourceKind             `json:"kind,omitempty"`
+	Page         int                             `json:"page"`
+	Results      int                             `json:"results"`
+	First        string                          `json:"first,omitempty"`
+	Last         string                          `json:"last,omitempty"`
+	StatusCounts map[domain.ComparisonStatus]int `json:"status_counts"`
+}
+
+type part struct {
+	kind    domain.ResourceKind
+	page    int
+	pages   int
+	results []domain.ComparisonResult
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	if err := os.MkdirAll(r.config.Directory, 0o755); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to create report directory %s", r.config.Directory))
+	}
+	r.removePreviousFiles(ctx)
+
+	parts := r.partition(results)
+	idx := index{Format: r.format, PartitionedBy: r.config.By, TotalResults: len(results), Files: make([]indexFile, 0, len(parts))}
+	for _, p := range parts {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		name := r.fileName(p)
+		if err := r.writePart(ctx, name, p.results); err != nil {
+			return err
+		}
+		entry := indexFile{File: name, Kind: p.kind, Page: p.page, Results: len(p.results), StatusCounts: map[domain.ComparisonStatus]int{}}
+		if len(p.results) > 0 {
+			entry.First = identifier(p.results[0])
+			entry.Last = identifier(p.results[len(p.results)-1])
+		}
+		for _, res := range p.results {
+			entry.StatusCounts[res.Status]++
+		}
+		idx.Files = append(idx.Files, entry)
+	}
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to encode report index")
+	}
+	indexPath := filepath.Join(r.config.Directory, IndexFile)
+	if err := os.WriteFile(indexPath, append(data, '\n'), 0o644); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to write report index %s", indexPath))
+	}
+	r.logger.Infof(ctx, "Wrote %d result(s) to %d report file(s), indexed in %s", len(results), len(parts), indexPath)
+	return nil
+}
+
+// partition orders the results by identifier and cuts them into parts. A run
+// without results still produces one empty part, so the report is never missing.
+func (r *Reporter) partition(results []domain.ComparisonResult) []part {
+	sorted := make([]domain.ComparisonResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if r.config.By == ByKind && sorted[i].ResourceKind != sorted[j].ResourceKind {
+			return sorted[i].ResourceKind < sorted[j].ResourceKind
+		}
+		return identifier(sorted[i]) < identifier(sorted[j])
+	})
+
+	if r.config.By == ByShard || len(sorted) == 0 {
+		return r.pages("", sorted)
+	}
+	var parts []part
+	start := 0
+	for i := 1; i <= len(sorted); i++ {
+		if i == len(sorted) || sorted[i].ResourceKind != sorted[start].ResourceKind {
+			parts = append(parts, r.pages(sorted[start].ResourceKind, sorted[start:i])...)
+			start = i
+		}
+	}
+	return parts
+}
+
+func (r *Reporter) pages(kind domain.ResourceKind, results []domain.ComparisonResult) []part {
+	count := max(1, (len(results)+r.config.MaxResults-1)/r.config.MaxResults)
+	parts := make([]part, 0, count)
+	for page := 0; page < count; page++ {
+		start := page * r.config.MaxResults
+		end := min(start+r.config.MaxResults, len(results))
+		parts = append(parts, part{kind: kind, page: page + 1, pages: count, results: results[start:end]})
+	}
+	return parts
+}
+
+func (r *Reporter) fileName(p part) string {
+	if p.kind == "" {
+		return fmt.Sprintf("shard-%03d%s", p.page, r.extension)
+	}
+	base := unsafeFileChars.ReplaceAllString(string(p.kind), "_")
+	if p.pages == 1 {
+		return base + r.extension
+	}
+	return fmt.Sprintf("%s-%03d%s", base, p.page, r.extension)
+}
+
+func (r *Reporter) writePart(ctx context.Context, name string, results []domain.ComparisonResult) error {
+	path := filepath.Join(r.config.Directory, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to create report file %s", path))
+	}
+	r.writer.SetWriter(file)
+	reportErr := r.inner.Report(ctx, results)
+	closeErr := file.Close()
+	if reportErr != nil {
+		return reportErr
+	}
+	if closeErr != nil {
+		return errors.Wrap(closeErr, errors.CodeInternal, fmt.Sprintf("failed to write report file %s", path))
+	}
+	r.logger.Debugf(ctx, "Wrote %d result(s) to %s", len(results), path)
+	return nil
+}
+
+// removePreviousFiles deletes the files of the previous report named in its
+// index, so that partitions that no longer exist do not linger.
+func (r *Reporter) removePreviousFiles(ctx context.Context) {
+	data, err := os.ReadFile(filepath.Join(r.config.Directory, IndexFile))
+	if err != nil {
+		return
+	}
+	var previous index
+	if err := json.Unmarshal(data, &previous); err != nil {
+		r.logger.Warnf(ctx, "Ignoring unreadable report index in %s: %v", r.config.Directory, err)
+		return
+	}
+	for _, f := range previous.Files {
+		if f.File == "" || filepath.Base(f.File) != f.File {
+			continue
+		}
+		if err := os.Remove(filepath.Join(r.config.Directory, f.File)); err != nil && !os.IsNotExist(err) {
+			r.logger.Warnf(ctx, "Failed to remove previous report file %s: %v", f.File, err)
+		}
+	}
+}
+
+func identifier(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
@@ -0,0 +1,103 @@
+package partition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	jsonreport "github.com/olusolaa/infra-drift-detector/internal/reporting/json"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config) *Reporter {
+	t.Helper()
+	inner, err := jsonreport.NewReporter(jsonreport.Config{}, reportingtest.Logger())
+	require.NoError(t, err)
+	r, err := NewReporter(cfg, inner, jsonreport.ReporterTypeJSON, ".json", reportingtest.Logger())
+	require.NoError(t, err)
+	return r
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestReporter_GoldenIndexByKind(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestReporter(t, Config{Directory: dir, MaxResults: 2})
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	index, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	require.NoError(t, err)
+	reportingtest.AssertGolden(t, "index_by_kind", index)
+}
+
+func TestReporter_ShardsPartitionsAllResults(t *testing.T) {
+	dir := t.TempDir()
+	results := reportingtest.Results()
+	r := newTestReporter(t, Config{Directory: dir, By: ByShard, MaxResults: 3})
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	require.NoError(t, err)
+	var idx index
+	require.NoError(t, json.Unmarshal(data, &idx))
+	require.Len(t, idx.Files, (len(results)+2)/3)
+	total := 0
+	for i, f := range idx.Files {
+		assert.Equal(t, fmt.Sprintf("shard-%03d.json", i+1), f.File)
+		assert.FileExists(t, filepath.Join(dir, f.File))
+		if i > 0 {
+			assert.LessOrEqual(t, idx.Files[i-1].Last, f.First)
+		}
+		total += f.Results
+	}
+	assert.Equal(t, len(results), total)
+}
+
+func TestReporter_RemovesPreviousPartitions(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestReporter(t, Config{Directory: dir})
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("kept"), 0o644))
+
+	onlyBuckets := []domain.ComparisonResult{{Status: domain.StatusNoDrift, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs"}}
+	require.NoError(t, r.Report(context.Background(), onlyBuckets))
+
+	assert.ElementsMatch(t, []string{IndexFile, "StorageBucket.json", "notes.txt"}, listFiles(t, dir))
+}
+
+func TestReporter_EmptyRunWritesOneFile(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestReporter(t, Config{Directory: dir})
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	assert.ElementsMatch(t, []string{IndexFile, "shard-001.json"}, listFiles(t, dir))
+}
+
+func TestNewReporter_RequiresRedirectableReporter(t *testing.T) {
+	_, err := NewReporter(Config{Directory: t.TempDir()}, fixedReporter{}, "custom", ".out", reportingtest.Logger())
+
+	require.Error(t, err)
+}
+
+type fixedReporter struct{}
+
+func (fixedReporter) Report(context.Context, []domain.ComparisonResult) error { return nil }
@@ -0,0 +1,276 @@
+// Package progress shows the progress of a scan on the terminal while it
+// runs: the resources listed, matched and compared so far, and a progress
+// bar per kind. On a terminal the view is redrawn in place; otherwise, as in
+// CI logs, a one-line status is printed periodically.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+const (
+	// refreshInterval is how often the live view is redrawn.
+	refreshInterval = 200 * time.Millisecond
+	// statusInterval is how often a status line is printed when the output
+	// is not a terminal.
+	statusInterval = 30 * time.Second
+	barWidth       = 24
+)
+
+// kindProgress counts the progress of one kind.
+type kindProgress struct {
+	desired int
+	actual  int
+	matched int
+	results int
+	drifted int
+	// unmanaged counts results without a desired resource, which add to the
+	// results expected for the kind.
+	unmanaged int
+}
+
+// total is the number of results expected for the kind so far: one per
+// desired resource and one per unmanaged resource found.
+func (k *kindProgress) total() int {
+	return max(k.desired+k.unmanaged, k.results)
+}
+
+// Display renders the progress of a run. It implements
+// ports.ProgressObserver, and io.Writer so that logs written through it do not
+// garble the live view.
+type Display struct {
+	out         io.Writer
+	interactive bool
+	interval    time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	kinds     []domain.ResourceKind
+	progress  map[domain.ResourceKind]*kindProgress
+	startedAt time.Time
+	running   bool
+	drawn     int // lines of the live view currently on screen
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewDisplay returns a display writing to out. The view is redrawn in place
+// when out is a terminal.
+func NewDisplay(out *os.File) *Display {
+	interactive := isatty.IsTerminal(out.Fd()) || isatty.IsCygwinTerminal(out.Fd())
+	return newDisplay(out, interactive)
+}
+
+func newDisplay(out io.Writer, interactive bool) *Display {
+	d := &Display{
+		out:         out,
+		interactive: interactive,
+		interval:    statusInterval,
+		now:         time.Now,
+		progress:    make(map[domain.ResourceKind]*kindProgress),
+	}
+	if interactive {
+		d.interval = refreshInterval
+	}
+	return d
+}
+
+// Progress records an event of the run, starting the view when the run
+// starts and drawing it a last time when the run finishes.
+func (d *Display) Progress(event domain.ProgressEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch event.Stage {
+	case domain.ProgressRunStarted:
+		d.start(event.Kinds)
+		return
+	case domain.ProgressRunFinished:
+		d.finish()
+		return
+	}
+	k := d.kind(event.Kind)
+	switch event.Stage {
+	case domain.ProgressListedDesired:
+		k.desired += event.Count
+	case domain.ProgressListedActual:
+		k.actual += event.Count
+	case domain.ProgressMatched:
+		k.matched += event.Count
+	case domain.ProgressResult:
+		k.results++
+		switch event.Status {
+		case domain.StatusDrifted:
+			k.drifted++
+		case domain.StatusUnmanaged:
+			k.unmanaged++
+		}
+	}
+}
+
+// Write writes p, typically a log record, above the live view.
+func (d *Display) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.running || !d.interactive {
+		return d.out.Write(p)
+	}
+	d.clear()
+	n, err := d.out.Write(p)
+	d.draw()
+	return n, err
+}
+
+// Close stops the view if a run is still in progress.
+func (d *Display) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.finish()
+}
+
+func (d *Display) kind(kind domain.ResourceKind) *kindProgress {
+	k, ok := d.progress[kind]
+	if !ok {
+		k = &kindProgress{}
+		d.progress[kind] = k
+		d.kinds = append(d.kinds, kind)
+	}
+	return k
+}
+
+// start resets the counters for a new run and starts redrawing. Called with
+// mu held.
+func (d *Display) start(kinds []domain.ResourceKind) {
+	d.finish()
+	d.kinds = nil
+	d.progress = make(map[domain.ResourceKind]*kindProgress)
+	sorted := append([]domain.ResourceKind(nil), kinds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, kind := range sorted {
+		d.kind(kind)
+	}
+	d.startedAt = d.now()
+	d.running = true
+	d.stop, d.done = make(chan struct{}), make(chan struct{})
+	go d.loop(d.stop, d.done)
+}
+
+// finish stops redrawing and leaves the final view on screen. Called with mu
+// held.
+func (d *Display) finish() {
+	if !d.running {
+		return
+	}
+	d.running = false
+	close(d.stop)
+	// The loop takes mu to redraw, so release it while waiting for the loop
+	// to exit.
+	d.mu.Unlock()
+	<-d.done
+	d.mu.Lock()
+	if d.interactive {
+		d.clear()
+		d.draw()
+		d.drawn = 0
+	} else {
+		d.printStatus()
+	}
+}
+
+func (d *Display) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			d.mu.Lock()
+			if d.interactive {
+				d.clear()
+				d.draw()
+			} else {
+				d.printStatus()
+			}
+			d.mu.Unlock()
+		}
+	}
+}
+
+// clear erases the live view by moving the cursor up over its lines.
+func (d *Display) clear() {
+	if d.drawn == 0 {
+		return
+	}
+	fmt.Fprintf(d.out, "\x1b[%dA\x1b[J", d.drawn)
+	d.drawn = 0
+}
+
+func (d *Display) draw() {
+	lines := d.render()
+	for _, line := range lines {
+		fmt.Fprintln(d.out, line)
+	}
+	d.drawn = len(lines)
+}
+
+func (d *Display) printStatus() {
+	fmt.Fprintln(d.out, d.summary())
+}
+
+func (d *Display) totals() kindProgress {
+	var t kindProgress
+	for _, k := range d.progress {
+		t.desired += k.desired
+		t.actual += k.actual
+		t.matched += k.matched
+		t.results += k.results
+		t.drifted += k.drifted
+		t.unmanaged += k.unmanaged
+	}
+	return t
+}
+
+// summary is the one-line status of the run.
+func (d *Display) summary() string {
+	t := d.totals()
+	elapsed := d.now().Sub(d.startedAt).Truncate(time.Second)
+	return fmt.Sprintf("Scanning %d kind(s) [%s] listed %d desired, %d actual · matched %d · compared %d/%d · drifted %d",
+		len(d.kinds), elapsed, t.desired, t.actual, t.matched, t.results, t.total(), t.drifted)
+}
+
+// render returns the lines of the live view: the summary and a progress bar
+// per kind.
+func (d *Display) render() []string {
+	lines := []string{d.summary()}
+	width := 0
+	for _, kind := range d.kinds {
+		width = max(width, len(kind))
+	}
+	for _, kind := range d.kinds {
+		k := d.progress[kind]
+		total := k.total()
+		lines = append(lines, fmt.Sprintf("  %-*s %s %d/%d drifted %d",
+			width, kind, bar(k.results, total), k.results, total, k.drifted))
+	}
+	return lines
+}
+
+// bar renders done out of total as a bar of barWidth cells.
+func bar(done, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done*barWidth/total, barWidth)
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + "]"
+}
@@ -0,0 +1,75 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+func runEvents(d *Display) {
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressRunStarted, Kinds: []domain.ResourceKind{domain.KindStorageBucket, domain.KindComputeInstance}})
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressListedDesired, Kind: domain.KindComputeInstance, Count: 4})
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressListedDesired, Kind: domain.KindStorageBucket, Count: 1})
+	for i := 0; i < 3; i++ {
+		d.Progress(domain.ProgressEvent{Stage: domain.ProgressListedActual, Kind: domain.KindComputeInstance, Count: 1})
+	}
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressMatched, Kind: domain.KindComputeInstance, Count: 2})
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressResult, Kind: domain.KindComputeInstance, Status: domain.StatusDrifted})
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressResult, Kind: domain.KindComputeInstance, Status: domain.StatusUnmanaged})
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressResult, Kind: domain.KindStorageBucket, Status: domain.StatusNoDrift})
+}
+
+func newTestDisplay(out *bytes.Buffer, interactive bool) *Display {
+	d := newDisplay(out, interactive)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+	d.now = func() time.Time {
+		calls++
+		if calls == 1 {
+			return start
+		}
+		return start.Add(42 * time.Second)
+	}
+	return d
+}
+
+func TestDisplay_Interactive(t *testing.T) {
+	var out bytes.Buffer
+	d := newTestDisplay(&out, true)
+	runEvents(d)
+	_, _ = d.Write([]byte("a log line\n"))
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressRunFinished})
+
+	final := out.String()[strings.LastIndex(out.String(), "\x1b[J")+len("\x1b[J"):]
+	assert.Equal(t, "Scanning 2 kind(s) [42s] listed 5 desired, 3 actual · matched 2 · compared 3/6 · drifted 1\n"+
+		"  ComputeInstance ["+strings.Repeat("█", 9)+strings.Repeat("░", 15)+"] 2/5 drifted 1\n"+
+		"  StorageBucket   ["+strings.Repeat("█", 24)+"] 1/1 drifted 0\n", final)
+	assert.Contains(t, out.String(), "a log line\n")
+
+	out.Reset()
+	_, _ = d.Write([]byte("after the run\n"))
+	assert.Equal(t, "after the run\n", out.String(), "logs pass through once the run finished")
+}
+
+func TestDisplay_NonInteractive(t *testing.T) {
+	var out bytes.Buffer
+	d := newTestDisplay(&out, false)
+	runEvents(d)
+	_, _ = d.Write([]byte("a log line\n"))
+	d.Progress(domain.ProgressEvent{Stage: domain.ProgressRunFinished})
+
+	assert.Equal(t, "a log line\n"+
+		"Scanning 2 kind(s) [42s] listed 5 desired, 3 actual · matched 2 · compared 3/6 · drifted 1\n", out.String())
+	assert.NotContains(t, out.String(), "\x1b[", "no escape sequences outside a terminal")
+}
+
+func TestBar(t *testing.T) {
+	assert.Equal(t, "["+strings.Repeat("░", barWidth)+"]", bar(0, 0))
+	assert.Equal(t, "["+strings.Repeat("█", barWidth/2)+strings.Repeat("░", barWidth/2)+"]", bar(5, 10))
+	assert.Equal(t, "["+strings.Repeat("█", barWidth)+"]", bar(12, 10))
+}
@@ -0,0 +1,265 @@
+// Package reportingtest holds the canonical result set and golden-file helpers
+// shared by the reporter snapshot tests. Every reporter renders the same
+// results, so a change to one format shows up as a reviewed golden diff.
+package reportingtest
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Now is the fixed time of the synthetic run.
+var Now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// Results returns the canonical result set: every comparison status, flat and
+// nested differences, plain and user-facing errors, links, an explain trace,
+// attributes not asserted, a match strategy, ambiguous match candidates,
+// attribute groups and non-ASCII identifiers and values. It returns a fresh
+// copy on each call since reporters may sort the slice in place.
+func Results() []domain.ComparisonResult {
+	return []domain.ComparisonResult{
+		{
+			Status:             domain.StatusNoDrift,
+			ResourceKind:       domain.KindComputeInstance,
+			SourceIdentifier:   "aws_instance.web",
+			ProviderType:       "aws",
+			ProviderAssignedID: "i-0123456789abcdef0",
+			Priority:           10,
+			NotAsserted:        []string{domain.ComputeImageIDKey},
+			Match:              &domain.MatchInfo{Strategy: "name_tag", Confidence: 0.8},
+		},
+		{
+			Status:             domain.StatusDrifted,
+			ResourceKind:       domain.KindComputeInstance,
+			SourceIdentifier:   "aws_instance.api",
+			SourceFile:         "compute.tf",
+			SourceLine:         12,
+			ProviderType:       "aws",
+			ProviderAssignedID: "i-0fedcba9876543210",
+			Priority:           10,
+			Differences: []domain.AttributeDiff{
+				{
+					AttributeName: domain.ComputeInstanceTypeKey,
+					ExpectedValue: "t3.micro",
+					ActualValue:   "t3.large",
+					Severity:      domain.SeverityWarning,
+					Group:         "cost",
+				},
+				{
+					AttributeName: domain.KeyTags,
+					ExpectedValue: map[string]any{"Name": "api", "Owner": "Zoë Müller", "Team": "plateforme"},
+					ActualValue:   map[string]any{"Name": "api", "Owner": "Zoë Müller", "Cost-Centre": "北京"},
+					Details:       "Map contents differ",
+					Severity:      domain.SeverityInfo,
+				},
+				{
+					AttributeName: domain.ComputeSecurityGroupsKey,
+					ExpectedValue: []any{"sg-1"},
+					ActualValue:   []any{"sg-1", "sg-2"},
+					Details:       "Unexpected security group sg-2",
+					Severity:      domain.SeverityCritical,
+					Group:         "security",
+				},
+			},
+			Links: []domain.ResourceLink{
+				{Name: "console", URL: "https://us-east-1.console.aws.amazon.com/ec2/home?region=us-east-1#InstanceDetails:instanceId=i-0fedcba9876543210"},
+				{Name: "source", URL: "https://github.com/example/infra/blob/main/compute.tf#L12"},
+			},
+			Trace: &domain.ComparisonTrace{
+				Steps: []string{"matched by tag Name=api"},
+				Attributes: []domain.AttributeTrace{
+					{Attribute: domain.ComputeInstanceTypeKey, Comparer: "helper.DefaultAttributeCompare", Steps: []string{"desired is string, actual is string"}, Reason: "values differ"},
+					{Attribute: "user_data", Skipped: true, Reason: "not returned by the platform"},
+					{Attribute: domain.ComputeImageIDKey, Comparer: "helper.DefaultAttributeCompare", Equal: true},
+				},
+			},
+		},
+		{
+			Status:             domain.StatusDrifted,
+			ResourceKind:       domain.KindStorageBucket,
+			SourceIdentifier:   `aws_s3_bucket.données["é"]`,
+			ProviderType:       "aws",
+			ProviderAssignedID: "données-bucket",
+			Priority:           30,
+			Differences: []domain.AttributeDiff{
+				{
+					AttributeName: "server_side_encryption_configuration",
+					ExpectedValue: []any{
+						map[string]any{"rule": []any{map[string]any{
+							"apply_server_side_encryption_by_default": []any{map[string]any{"sse_algorithm": "aws:kms", "kms_master_key_id": "alias/données"}},
+							"bucket_key_enabled":                      true,
+						}}},
+					},
+					ActualValue: []any{
+						map[string]any{"rule": []any{map[string]any{
+							"apply_server_side_encryption_by_default": []any{map[string]any{"sse_algorithm": "AES256"}},
+							"bucket_key_enabled":                      false,
+						}}},
+					},
+					Details:  "Encryption downgraded from aws:kms to AES256",
+					Severity: domain.SeverityCritical,
+					Group:    "security",
+				},
+				{
+					AttributeName: "versioning",
+					ExpectedValue: map[string]any{"enabled": true, "mfa_delete": false},
+					ActualValue:   nil,
+					Severity:      domain.SeverityWarning,
+					Group:         "resilience",
+				},
+				{
+					AttributeName: "policy",
+					ExpectedValue: `{"Version":"2012-10-17","Statement":[{"Sid":"ReadOnly","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::données-bucket/*"}]}`,
+					ActualValue:   `{"Version":"2012-10-17","Statement":[{"Sid":"ReadOnly","Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:PutObject"],"Resource":"arn:aws:s3:::données-bucket/*"}]}`,
+					Details:       `Policy differs: Sid "ReadOnly" changed: Action[1] added ("s3:putobject")`,
+					Severity:      domain.SeverityCritical,
+					Group:         "security",
+					Changes: []domain.JSONChange{
+						{Path: "Statement[0].Action[1]", Op: domain.JSONChangeAdded, Actual: "s3:putobject"},
+					},
+				},
+			},
+		},
+		{
+			Status:             domain.StatusDrifted,
+			ResourceKind:       domain.KindDatabaseInstance,
+			ProviderType:       "aws",
+			ProviderAssignedID: "orders-db",
+			Priority:           20,
+		},
+		{
+			Status:           domain.StatusMissing,
+			ResourceKind:     domain.KindDatabaseInstance,
+			SourceIdentifier: "aws_db_instance.analytics",
+			ProviderType:     "aws",
+			Priority:         20,
+			Links:            []domain.ResourceLink{{Name: "source", URL: "https://github.com/example/infra/blob/main/db.tf#L3"}},
+		},
+		{
+			Status:             domain.StatusRecentlyDeleted,
+			ResourceKind:       domain.KindServerlessFunction,
+			SourceIdentifier:   "aws_lambda_function.résumé",
+			ProviderType:       "aws",
+			ProviderAssignedID: "résumé-parser",
+			Priority:           10,
+			DeletionWindow:     &domain.TimeWindow{From: Now.Add(-6 * time.Hour), To: Now},
+		},
+		{
+			Status:             domain.StatusPendingDeletion,
+			ResourceKind:       domain.KindDatabaseTable,
+			SourceIdentifier:   "aws_dynamodb_table.sessions",
+			ProviderType:       "aws",
+			ProviderAssignedID: "sessions",
+			Priority:           10,
+			PendingDeletion:    &domain.PendingDeletion{State: "DELETING", DeletionDate: Now.Add(24 * time.Hour)},
+		},
+		{
+			Status:             domain.StatusUnmanaged,
+			ResourceKind:       domain.KindStorageBucket,
+			ProviderType:       "aws",
+			ProviderAssignedID: "scratch-バケット",
+			Priority:           30,
+		},
+		{
+			Status:           domain.StatusAmbiguous,
+			ResourceKind:     domain.KindComputeInstance,
+			SourceIdentifier: "aws_instance.worker",
+			ProviderType:     "aws",
+			Priority:         10,
+			Match:            &domain.MatchInfo{Strategy: "name_tag"},
+			Candidates: []domain.MatchCandidate{
+				{SourceIdentifier: "aws_instance.worker"},
+				{ProviderAssignedID: "i-0bbbbbbbbbbbbbbbb"},
+				{ProviderAssignedID: "i-0cccccccccccccccc"},
+			},
+		},
+		{
+			Status:           domain.StatusError,
+			ResourceKind:     domain.KindIAMRole,
+			SourceIdentifier: "aws_iam_role.deployer",
+			ProviderType:     "aws",
+			Priority:         20,
+			Error:            stderrors.New("AccessDenied: iam:GetRole on role/deployer"),
+		},
+		{
+			Status:             domain.StatusError,
+			ResourceKind:       domain.KindComputeInstance,
+			ProviderType:       "aws",
+			ProviderAssignedID: "i-0aaaaaaaaaaaaaaaa",
+			Priority:           10,
+			Error: errors.NewUserFacing(errors.CodePlatformAPIError,
+				"the EC2 API rejected the request", "Check the region and the instance ID."),
+		},
+		{
+			Status:             domain.StatusDeadLettered,
+			ResourceKind:       domain.KindStorageBucket,
+			SourceIdentifier:   "aws_s3_bucket.legacy",
+			ProviderType:       "aws",
+			ProviderAssignedID: "legacy-bucket",
+			Priority:           30,
+			Error:              stderrors.New("timeout after 30s"),
+			FailureStreak:      &domain.FailureStreak{Runs: 5, Since: Now.Add(-72 * time.Hour)},
+		},
+		{
+			Status:             domain.StatusUnapprovedImage,
+			ResourceKind:       domain.KindComputeInstance,
+			SourceIdentifier:   "aws_instance.api",
+			ProviderType:       "aws",
+			ProviderAssignedID: "i-0fedcba9876543210",
+			Priority:           10,
+			Differences: []domain.AttributeDiff{{
+				AttributeName: domain.ComputeImageIDKey,
+				ExpectedValue: []any{"ami-0approved"},
+				ActualValue:   "ami-0rogue",
+				Details:       "Image ami-0rogue is not approved for role api (approved: ami-0approved)",
+				Severity:      domain.SeverityCritical,
+				Group:         "security",
+			}},
+		},
+	}
+}
+
+// StateIssues returns the state source issues reported alongside Results.
+func StateIssues() []domain.StateIssue {
+	return []domain.StateIssue{
+		{
+			Severity: domain.SeverityWarning,
+			Summary:  "Undefined variable \"région\"",
+			Detail:   "The variable has no default and no value in any tfvars file.",
+			Address:  "aws_instance.api",
+			Location: "main.tf:14:3",
+		},
+		{
+			Severity: domain.SeverityCritical,
+			Summary:  "Resource block could not be evaluated",
+			Address:  "aws_s3_bucket.archive",
+			Location: "storage.tf:40:1",
+			Skipped:  true,
+		},
+	}
+}
+
+// RunAnnotations returns the run annotations reported alongside Results.
+func RunAnnotations() []domain.RunAnnotation {
+	return []domain.RunAnnotation{
+		{Source: "aws", Message: "Switched to fallback credentials after 3 throttled calls", Time: Now.Add(-time.Minute)},
+	}
+}
+
+// Logger returns a logger that discards everything.
+func Logger() ports.Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(context.Context, string, ...any)        {}
+func (nopLogger) Infof(context.Context, string, ...any)         {}
+func (nopLogger) Warnf(context.Context, string, ...any)         {}
+func (nopLogger) Errorf(context.Context, error, string, ...any) {}
+func (l nopLogger) WithFields(map[string]any) ports.Logger      { return l }
@@ -0,0 +1,32 @@
+package reportingtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the current output instead of
+// comparing against them: go test ./internal/reporting/... -update
+var update = flag.Bool("update", false, "rewrite reporter golden files")
+
+// AssertGolden compares got with testdata/<name>.golden in the calling test's
+// package directory.
+func AssertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run the test with -update to create it")
+	assert.Equal(t, string(want), string(got), "output differs from %s; rerun with -update if the change is intended", path)
+}
@@ -0,0 +1,426 @@
+package sarif
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+)
+
+const ReporterTypeSARIF = "sarif"
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName       = "infra-drift-detector"
+	toolInfoURI    = "https://github.com/olusolaa/infra-drift-detector"
+	fingerprintKey = "driftFinding/v1"
+)
+
+// SARIF result levels.
+const (
+	levelError   = "error"
+	levelWarning = "warning"
+	levelNote    = "note"
+)
+
+// Rule ids of findings that are not about a single attribute. Attribute drift
+// uses one rule per attribute, "drift/attribute/<name>".
+const (
+	ruleAttributePrefix = "drift/attribute/"
+	ruleResourceDrifted = "drift/resource-drifted"
+	ruleMissing         = "drift/missing"
+	ruleRecentlyDeleted = "drift/recently-deleted"
+	ruleUnmanaged       = "drift/unmanaged"
+	ruleUnapprovedImage = "drift/unapproved-image"
+	rulePendingDeletion = "drift/pending-deletion"
+	ruleAmbiguous       = "drift/ambiguous-match"
+)
+
+type Config struct {
+	// SourceRoot prefixes the file paths recorded by the state source, which are
+	// relative to the source root, so that they resolve from the repository
+	// root, e.g. "infra/terraform".
+	SourceRoot string `yaml:"source_root" mapstructure:"source_root"`
+	// ArtifactURI is the file findings point to when the state source does not
+	// record where a resource is declared, e.g. the Terraform state file. Code
+	// scanning dashboards require a file location on every result.
+	ArtifactURI string `yaml:"artifact_uri" mapstructure:"artifact_uri"`
+}
+
+// Reporter writes drift findings as a SARIF 2.1.0 log for code scanning
+// dashboards such as GitHub Code Scanning or Azure DevOps. Every attribute
+// difference becomes a result of the rule for that attribute; missing,
+// recently deleted, pending deletion and unmanaged resources use one rule per
+// status. Results
+// without drift and comparison errors produce no result.
+type Reporter struct {
+	config Config
+	writer io.Writer
+	logger ports.Logger
+}
+
+func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
+	return &Reporter{
+		config: cfg,
+		writer: os.Stdout,
+		logger: logger,
+	}, nil
+}
+
+type sarifLog struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Rules          []rule `json:"rules"`
+}
+
+type rule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	ShortDescription     message        `json:"shortDescription"`
+	DefaultConfiguration configuration  `json:"defaultConfiguration"`
+	Properties           map[string]any `json:"properties,omitempty"`
+}
+
+type configuration struct {
+	Level string `json:"level"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             message           `json:"message"`
+	Locations           []location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type location struct {
+	PhysicalLocation *physicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []logicalLocation `json:"logicalLocations"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           *region          `json:"region,omitempty"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type region struct {
+	StartLine int `json:"startLine"`
+}
+
+type logicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	b := &logBuilder{config: r.config, ruleIndex: map[string]int{}}
+	for _, res := range results {
+		if ctx.Err() != nil {
+			r.logger.Warnf(ctx, "SARIF report generation cancelled.")
+			return ctx.Err()
+		}
+		b.add(res)
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(b.log()); err != nil {
+		r.logger.Errorf(ctx, err, "Failed to encode SARIF log")
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+	r.logger.Debugf(ctx, "SARIF report generated with %d result(s) for %d rule(s).", len(b.results), len(b.rules))
+	return nil
+}
+
+// logBuilder collects the rules in order of first use, so that each result can
+// reference its rule by index.
+type logBuilder struct {
+	config    Config
+	rules     []rule
+	ruleIndex map[string]int
+	results   []result
+}
+
+func (b *logBuilder) log() sarifLog {
+	rules := b.rules
+	if rules == nil {
+		rules = []rule{}
+	}
+	results := b.results
+	if results == nil {
+		results = []result{}
+	}
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []run{{
+			Tool:    tool{Driver: driver{Name: toolName, InformationURI: toolInfoURI, Rules: rules}},
+			Results: results,
+		}},
+	}
+}
+
+func (b *logBuilder) add(res domain.ComparisonResult) {
+	switch res.Status {
+	case domain.StatusDrifted:
+		if len(res.Differences) == 0 {
+			b.addResult(res, ruleResourceDrifted, levelWarning,
+				fmt.Sprintf("%s %s drifted from the desired state.", res.ResourceKind, resourceLabel(res)), nil)
+			return
+		}
+		for _, diff := range res.Differences {
+			b.addDiff(res, ruleAttributePrefix+diff.AttributeName, diff)
+		}
+	case domain.StatusUnapprovedImage:
+		for _, diff := range res.Differences {
+			b.addDiff(res, ruleUnapprovedImage, diff)
+		}
+	case domain.StatusMissing:
+		b.addResult(res, ruleMissing, levelError,
+			fmt.Sprintf("Managed %s %s is missing from the platform.", res.ResourceKind, resourceLabel(res)), nil)
+	case domain.StatusRecentlyDeleted:
+		b.addResult(res, ruleRecentlyDeleted, levelError,
+			fmt.Sprintf("Managed %s %s was recently deleted from the platform.", res.ResourceKind, resourceLabel(res)), nil)
+	case domain.StatusUnmanaged:
+		b.addResult(res, ruleUnmanaged, levelWarning,
+			fmt.Sprintf("Unmanaged %s %s found on the platform.", res.ResourceKind, resourceLabel(res)), nil)
+	case domain.StatusPendingDeletion:
+		text := fmt.Sprintf("%s %s is pending deletion on the platform.", res.ResourceKind, resourceLabel(res))
+		var props map[string]any
+		if pd := res.PendingDeletion; pd != nil {
+			text = fmt.Sprintf("%s %s is pending deletion on the platform (state %s).", res.ResourceKind, resourceLabel(res), pd.State)
+			props = map[string]any{"state": pd.State}
+			if !pd.DeletionDate.IsZero() {
+				props["deletion_date"] = pd.DeletionDate.UTC().Format(time.RFC3339)
+			}
+		}
+		b.addResult(res, rulePendingDeletion, levelNote, text, props)
+	case domain.StatusAmbiguous:
+		candidates := candidateLabels(res.Candidates)
+		b.addResult(res, ruleAmbiguous, levelWarning,
+			fmt.Sprintf("%s resources %s match one another and were not compared.", res.ResourceKind, strings.Join(candidates, ", ")),
+			map[string]any{"candidates": candidates})
+	}
+}
+
+// candidateLabels names the resources of an ambiguous match.
+func candidateLabels(candidates []domain.MatchCandidate) []string {
+	labels := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		labels[i] = candidate.SourceIdentifier
+		if labels[i] == "" {
+			labels[i] = candidate.ProviderAssignedID
+		}
+	}
+	return labels
+}
+
+func (b *logBuilder) addDiff(res domain.ComparisonResult, ruleID string, diff domain.AttributeDiff) {
+	text := fmt.Sprintf("Attribute '%s' of %s %s differs from the desired state.", diff.AttributeName, res.ResourceKind, resourceLabel(res))
+	if diff.Details != "" {
+		text += " " + diff.Details
+	}
+	if diff.PlatformManaged != "" {
+		text += fmt.Sprintf(" Platform-managed: %s.", diff.PlatformManaged)
+	}
+	props := map[string]any{
+		"attribute": diff.AttributeName,
+		"expected":  diff.ExpectedValue,
+		"actual":    diff.ActualValue,
+	}
+	if diff.Severity != "" {
+		props["severity"] = diff.Severity
+	}
+	if diff.Group != "" {
+		props["group"] = diff.Group
+	}
+	if diff.PlatformManaged != "" {
+		props["platform_managed"] = diff.PlatformManaged
+	}
+	if len(diff.Changes) > 0 {
+		changes := make([]map[string]any, len(diff.Changes))
+		for i, c := range diff.Changes {
+			changes[i] = map[string]any{"path": c.Path, "op": c.Op}
+			if c.Expected != nil {
+				changes[i]["expected"] = c.Expected
+			}
+			if c.Actual != nil {
+				changes[i]["actual"] = c.Actual
+			}
+		}
+		props["diff"] = changes
+	}
+	b.addResult(res, ruleID, levelFor(diff.Severity), text, props)
+}
+
+func (b *logBuilder) addResult(res domain.ComparisonResult, ruleID, level, text string, props map[string]any) {
+	if props == nil {
+		props = map[string]any{}
+	}
+	props["drift_status"] = res.Status
+	props["resource_kind"] = res.ResourceKind
+	if res.ProviderAssignedID != "" {
+		props["provider_assigned_id"] = res.ProviderAssignedID
+	}
+	for _, link := range res.Links {
+		props[link.Name+"_url"] = link.URL
+	}
+
+	b.results = append(b.results, result{
+		RuleID:              ruleID,
+		RuleIndex:           b.rule(ruleID, level),
+		Level:               level,
+		Message:             message{Text: text},
+		Locations:           []location{b.location(res)},
+		PartialFingerprints: map[string]string{fingerprintKey: fingerprint(res, ruleID)},
+		Properties:          props,
+	})
+}
+
+// rule returns the index of the rule, adding it on first use. The default
+// level of a rule is the level of its first result.
+func (b *logBuilder) rule(id, level string) int {
+	if i, ok := b.ruleIndex[id]; ok {
+		return i
+	}
+	r := rule{
+		ID:                   id,
+		Name:                 ruleName(id),
+		ShortDescription:     message{Text: ruleDescription(id)},
+		DefaultConfiguration: configuration{Level: level},
+		Properties:           map[string]any{"tags": []string{"drift"}},
+	}
+	b.ruleIndex[id] = len(b.rules)
+	b.rules = append(b.rules, r)
+	return b.ruleIndex[id]
+}
+
+func (b *logBuilder) location(res domain.ComparisonResult) location {
+	loc := location{LogicalLocations: []logicalLocation{{
+		Name:               resourceLabel(res),
+		FullyQualifiedName: string(res.ResourceKind) + "/" + resourceLabel(res),
+		Kind:               "resource",
+	}}}
+	switch {
+	case res.SourceFile != "":
+		uri := res.SourceFile
+		if b.config.SourceRoot != "" {
+			uri = path.Join(b.config.SourceRoot, uri)
+		}
+		loc.PhysicalLocation = &physicalLocation{ArtifactLocation: artifactLocation{URI: uri}}
+		if res.SourceLine > 0 {
+			loc.PhysicalLocation.Region = &region{StartLine: res.SourceLine}
+		}
+	case b.config.ArtifactURI != "":
+		loc.PhysicalLocation = &physicalLocation{ArtifactLocation: artifactLocation{URI: b.config.ArtifactURI}}
+	}
+	return loc
+}
+
+func levelFor(sev domain.Severity) string {
+	switch sev {
+	case domain.SeverityCritical:
+		return levelError
+	case domain.SeverityInfo:
+		return levelNote
+	default:
+		return levelWarning
+	}
+}
+
+// ruleName turns a rule id into the PascalCase name SARIF viewers display,
+// e.g. "drift/attribute/instance_type" into "AttributeDriftInstanceType".
+func ruleName(id string) string {
+	var subject string
+	prefix := "Drift"
+	if strings.HasPrefix(id, ruleAttributePrefix) {
+		prefix = "AttributeDrift"
+		subject = strings.TrimPrefix(id, ruleAttributePrefix)
+	} else {
+		subject = strings.TrimPrefix(id, "drift/")
+	}
+	var name strings.Builder
+	name.WriteString(prefix)
+	for _, word := range strings.FieldsFunc(subject, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+func ruleDescription(id string) string {
+	switch id {
+	case ruleResourceDrifted:
+		return "Resource drifted from the desired state"
+	case ruleMissing:
+		return "Managed resource missing from the platform"
+	case ruleRecentlyDeleted:
+		return "Managed resource recently deleted from the platform"
+	case ruleUnmanaged:
+		return "Resource not managed by the desired state"
+	case ruleUnapprovedImage:
+		return "Instance runs an image not approved by the golden AMI manifest"
+	case rulePendingDeletion:
+		return "Resource scheduled for deletion or held in a recycle or retention state by the platform"
+	case ruleAmbiguous:
+		return "Several resources match one another, so none could be paired"
+	default:
+		return fmt.Sprintf("Attribute '%s' differs from the desired state", strings.TrimPrefix(id, ruleAttributePrefix))
+	}
+}
+
+func resourceLabel(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
+
+// fingerprint identifies a finding across runs, so that code scanning tracks
+// the same drift as one alert instead of opening a new one on every upload.
+func fingerprint(res domain.ComparisonResult, ruleID string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(res.ResourceKind), res.SourceIdentifier, res.ProviderAssignedID, ruleID,
+	}, "|")))
+	return hex.EncodeToString(sum[:16])
+}
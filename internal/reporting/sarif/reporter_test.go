@@ -0,0 +1,76 @@
+package sarif
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T, cfg Config) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.writer = &buf
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t, Config{SourceRoot: "infra", ArtifactURI: "infra/terraform.tfstate"})
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
+
+func TestReporter_RulesAndLevels(t *testing.T) {
+	r, buf := newTestReporter(t, Config{})
+	results := []domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.a",
+			Differences: []domain.AttributeDiff{
+				{AttributeName: "instance_type", Severity: domain.SeverityWarning},
+				{AttributeName: "tags", Severity: domain.SeverityInfo, PlatformManaged: "tags added by AWS Backup"},
+			},
+		},
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.b",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", Severity: domain.SeverityCritical}},
+		},
+		{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.c"},
+	}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	rules := log.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 2)
+	assert.Equal(t, "drift/attribute/instance_type", rules[0].ID)
+	assert.Equal(t, "AttributeDriftInstanceType", rules[0].Name)
+	assert.Equal(t, "drift/attribute/tags", rules[1].ID)
+
+	got := log.Runs[0].Results
+	require.Len(t, got, 3)
+	assert.Equal(t, []int{0, 1, 0}, []int{got[0].RuleIndex, got[1].RuleIndex, got[2].RuleIndex})
+	assert.Equal(t, []string{levelWarning, levelNote, levelError}, []string{got[0].Level, got[1].Level, got[2].Level})
+	assert.Contains(t, got[1].Message.Text, "Platform-managed: tags added by AWS Backup.")
+	assert.NotEqual(t, got[0].PartialFingerprints[fingerprintKey], got[2].PartialFingerprints[fingerprintKey])
+	assert.Nil(t, got[0].Locations[0].PhysicalLocation)
+}
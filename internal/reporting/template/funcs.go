@@ -0,0 +1,210 @@
+package template
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// Group is a set of results sharing the value of the field they were grouped by.
+type Group struct {
+	Key     string
+	Results []domain.ComparisonResult
+}
+
+// funcs returns the helper functions available to templates:
+//
+//	groupBy FIELD RESULTS   groups results by field, ordered by key
+//	sortBy FIELD RESULTS    sorts results by field; prefix the field with "-" to reverse
+//	withStatus STATUSES RESULTS
+//	                        keeps results with one of the comma-separated statuses
+//	label RESULT            the resource's source identifier, or its platform ID
+//	formatValue VALUE       renders an attribute value on one line
+//	formatDiff DIFF         renders a difference as "name: expected -> actual"
+//	formatTime TIME         renders a timestamp in the configured zone and format
+//	csv VALUES...           renders the values as one CSV record
+//	join SEP LIST, lower, upper, trim, replace OLD NEW S
+//
+// Fields are kind, status, severity, source_file, provider_type, id and priority.
+func (r *Reporter) funcs() texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"groupBy":     groupBy,
+		"sortBy":      sortBy,
+		"withStatus":  withStatus,
+		"label":       label,
+		"formatValue": formatValue,
+		"formatDiff":  formatDiff,
+		"formatTime":  r.formatTime,
+		"csv":         csvRecord,
+		"join":        join,
+		"lower":       strings.ToLower,
+		"upper":       strings.ToUpper,
+		"trim":        strings.TrimSpace,
+		"replace": func(old, new, s string) string {
+			return strings.ReplaceAll(s, old, new)
+		},
+	}
+}
+
+// fieldValue returns the value of a groupBy or sortBy field of a result.
+func fieldValue(field string, res domain.ComparisonResult) (string, error) {
+	switch field {
+	case "kind":
+		return string(res.ResourceKind), nil
+	case "status":
+		return string(res.Status), nil
+	case "severity":
+		return string(res.MaxSeverity()), nil
+	case "source_file":
+		return res.SourceFile, nil
+	case "provider_type":
+		return res.ProviderType, nil
+	case "id":
+		return label(res), nil
+	default:
+		return "", fmt.Errorf("unknown field %q, expected one of kind, status, severity, source_file, provider_type, id", field)
+	}
+}
+
+func groupBy(field string, results []domain.ComparisonResult) ([]Group, error) {
+	index := make(map[string]int)
+	var groups []Group
+	for _, res := range results {
+		key, err := fieldValue(field, res)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, Group{Key: key})
+		}
+		groups[i].Results = append(groups[i].Results, res)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+	return groups, nil
+}
+
+// sortBy returns a sorted copy of results. Severity sorts by rank and priority
+// numerically, both highest first unless reversed; other fields sort
+// alphabetically.
+func sortBy(field string, results []domain.ComparisonResult) ([]domain.ComparisonResult, error) {
+	reverse := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	var less func(a, b domain.ComparisonResult) bool
+	switch field {
+	case "severity":
+		less = func(a, b domain.ComparisonResult) bool {
+			return a.MaxSeverity().Rank() > b.MaxSeverity().Rank()
+		}
+	case "priority":
+		less = func(a, b domain.ComparisonResult) bool {
+			return a.Priority > b.Priority
+		}
+	default:
+		if _, err := fieldValue(field, domain.ComparisonResult{}); err != nil {
+			return nil, err
+		}
+		less = func(a, b domain.ComparisonResult) bool {
+			av, _ := fieldValue(field, a)
+			bv, _ := fieldValue(field, b)
+			return av < bv
+		}
+	}
+
+	sorted := make([]domain.ComparisonResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if reverse {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted, nil
+}
+
+func withStatus(statuses string, results []domain.ComparisonResult) []domain.ComparisonResult {
+	wanted := make(map[domain.ComparisonStatus]bool)
+	for _, status := range strings.Split(statuses, ",") {
+		wanted[domain.ComparisonStatus(strings.ToUpper(strings.TrimSpace(status)))] = true
+	}
+	var filtered []domain.ComparisonResult
+	for _, res := range results {
+		if wanted[res.Status] {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
+
+func label(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
+
+// formatValue renders scalars as they are and lists and maps as compact JSON,
+// so that every value fits on one line or in one table cell.
+func formatValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+func formatDiff(diff domain.AttributeDiff) string {
+	return fmt.Sprintf("%s: %s -> %s", diff.AttributeName, formatValue(diff.ExpectedValue), formatValue(diff.ActualValue))
+}
+
+func (r *Reporter) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return r.times.Format(t)
+}
+
+func csvRecord(values ...any) (string, error) {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatValue(value)
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.Write(record); err != nil {
+		return "", err
+	}
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n"), w.Error()
+}
+
+func join(sep string, values []string) string {
+	return strings.Join(values, sep)
+}
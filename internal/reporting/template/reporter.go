@@ -0,0 +1,177 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+)
+
+const ReporterTypeTemplate = "template"
+
+// DefaultExtension is the extension of partitioned report files when the
+// configuration does not set one.
+const DefaultExtension = ".txt"
+
+type Config struct {
+	// Path is the Go text/template file rendering the report.
+	Path string `yaml:"path" mapstructure:"path"`
+	// Inline is the template itself, for short templates kept in the
+	// configuration file. Path takes precedence when both are set.
+	Inline string `yaml:"inline" mapstructure:"inline"`
+	// Extension is the file extension of partitioned report files, e.g. ".csv".
+	Extension string `yaml:"extension" mapstructure:"extension"`
+}
+
+// Reporter renders results through a user-supplied Go text/template, so that
+// teams can produce their own formats, such as Confluence wiki markup or CSV,
+// without writing a reporter. The template is executed with a Report and can
+// use the helper functions listed in funcs.go.
+type Reporter struct {
+	config      Config
+	tmpl        *texttemplate.Template
+	writer      io.Writer
+	logger      ports.Logger
+	stateIssues []domain.StateIssue
+	annotations []domain.RunAnnotation
+	times       *localize.Formatter
+}
+
+func NewReporter(cfg Config, logger ports.Logger) (*Reporter, error) {
+	name, source := "inline", cfg.Inline
+	if cfg.Path != "" {
+		content, err := os.ReadFile(cfg.Path)
+		if err != nil {
+			return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation,
+				fmt.Sprintf("failed to read report template '%s'", cfg.Path),
+				"Check settings.reporter_config.template.path.")
+		}
+		name, source = filepath.Base(cfg.Path), string(content)
+	}
+	if source == "" {
+		return nil, errors.NewUserFacing(errors.CodeConfigValidation, "the template reporter requires a template",
+			"Set settings.reporter_config.template.path or settings.reporter_config.template.inline.")
+	}
+
+	r := &Reporter{config: cfg, writer: os.Stdout, logger: logger}
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Funcs(r.funcs()).Parse(source)
+	if err != nil {
+		return nil, errors.WrapUserFacing(err, errors.CodeConfigValidation, "invalid report template",
+			"Fix the template syntax; see the text/template package documentation.")
+	}
+	r.tmpl = tmpl
+	return r, nil
+}
+
+// Report is the data a template is executed with.
+type Report struct {
+	// Results are the comparison results, except dead letters, in the order
+	// the engine reported them.
+	Results []domain.ComparisonResult
+	// DeadLetters are the resources that failed in several consecutive runs.
+	DeadLetters    []domain.ComparisonResult
+	Summary        Summary
+	StateIssues    []domain.StateIssue
+	RunAnnotations []domain.RunAnnotation
+}
+
+// Summary counts the results by status, like the summary of the other reports.
+type Summary struct {
+	Total           int
+	NoDrift         int
+	Drifted         int
+	Missing         int
+	RecentlyDeleted int
+	PendingDeletion int
+	Unmanaged       int
+	Ambiguous       int
+	Errors          int
+	DeadLettered    int
+	// UnapprovedImages counts instances running images outside the approved
+	// set. These findings accompany the instance's own result, so they are not
+	// part of the total.
+	UnapprovedImages int
+}
+
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetStateIssues sets the state source issues passed to the template.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	r.stateIssues = issues
+}
+
+// SetRunAnnotations sets the run annotations passed to the template.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	r.annotations = annotations
+}
+
+// SetTimeFormatter sets the zone and format of the formatTime helper.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
+}
+
+func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
+	report := Report{
+		Summary:        Summary{Total: len(results)},
+		Results:        make([]domain.ComparisonResult, 0, len(results)),
+		StateIssues:    r.stateIssues,
+		RunAnnotations: r.annotations,
+	}
+	for _, res := range results {
+		if ctx.Err() != nil {
+			r.logger.Warnf(ctx, "Template report generation cancelled.")
+			return ctx.Err()
+		}
+		switch res.Status {
+		case domain.StatusNoDrift:
+			report.Summary.NoDrift++
+		case domain.StatusDrifted:
+			report.Summary.Drifted++
+		case domain.StatusMissing:
+			report.Summary.Missing++
+		case domain.StatusRecentlyDeleted:
+			report.Summary.RecentlyDeleted++
+		case domain.StatusPendingDeletion:
+			report.Summary.PendingDeletion++
+		case domain.StatusUnmanaged:
+			report.Summary.Unmanaged++
+		case domain.StatusAmbiguous:
+			report.Summary.Ambiguous++
+		case domain.StatusError:
+			report.Summary.Errors++
+		case domain.StatusDeadLettered:
+			report.Summary.DeadLettered++
+			report.DeadLetters = append(report.DeadLetters, res)
+			continue
+		case domain.StatusUnapprovedImage:
+			report.Summary.UnapprovedImages++
+			report.Summary.Total--
+		}
+		report.Results = append(report.Results, res)
+	}
+
+	// Render fully before writing, so that a failing template does not leave
+	// a truncated report behind.
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, report); err != nil {
+		r.logger.Errorf(ctx, err, "Failed to render report template")
+		return errors.WrapUserFacing(err, errors.CodeInternal, "failed to render report template",
+			"Check that the template only uses fields of the report data and the documented helper functions.")
+	}
+	if _, err := r.writer.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write template report: %w", err)
+	}
+	r.logger.Debugf(ctx, "Template report generated with %d result(s).", len(report.Results))
+	return nil
+}
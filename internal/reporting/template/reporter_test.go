@@ -0,0 +1,118 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+// examplesDir holds the example templates shipped with the repository.
+var examplesDir = filepath.Join("..", "..", "..", "examples", "templates")
+
+func newTestReporter(t *testing.T, cfg Config) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(cfg, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.writer = &buf
+	return r, &buf
+}
+
+func TestReporter_GoldenExamples(t *testing.T) {
+	for _, name := range []string{"confluence", "drift.csv"} {
+		t.Run(name, func(t *testing.T) {
+			r, buf := newTestReporter(t, Config{Path: filepath.Join(examplesDir, name+".tmpl")})
+			r.SetStateIssues(reportingtest.StateIssues())
+
+			require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+			reportingtest.AssertGolden(t, name, buf.Bytes())
+		})
+	}
+}
+
+func TestReporter_Summary(t *testing.T) {
+	r, buf := newTestReporter(t, Config{Inline: "{{ .Summary.Total }} {{ .Summary.Drifted }} {{ len .Results }} {{ len .DeadLetters }}"})
+	results := []domain.ComparisonResult{
+		{Status: domain.StatusDrifted},
+		{Status: domain.StatusNoDrift},
+		{Status: domain.StatusDeadLettered},
+		{Status: domain.StatusUnapprovedImage},
+	}
+
+	require.NoError(t, r.Report(context.Background(), results))
+
+	assert.Equal(t, "3 1 3 1", buf.String(), "unapproved images are not counted and dead letters are listed apart")
+}
+
+func TestReporter_Helpers(t *testing.T) {
+	results := []domain.ComparisonResult{
+		{Status: domain.StatusDrifted, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "b",
+			Differences: []domain.AttributeDiff{{AttributeName: "tags", ExpectedValue: map[string]any{"a": "1"}, ActualValue: nil, Severity: domain.SeverityInfo}}},
+		{Status: domain.StatusMissing, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "a"},
+		{Status: domain.StatusUnmanaged, ResourceKind: domain.KindComputeInstance, ProviderAssignedID: "i-c",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", Severity: domain.SeverityCritical}}},
+	}
+	testCases := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"group by kind", `{{ range groupBy "kind" .Results }}{{ .Key }}={{ len .Results }};{{ end }}`, "ComputeInstance=2;StorageBucket=1;"},
+		{"sort by id", `{{ range sortBy "id" .Results }}{{ label . }}{{ end }}`, "abi-c"},
+		{"sort by id reversed", `{{ range sortBy "-id" .Results }}{{ label . }}{{ end }}`, "i-cba"},
+		{"sort by severity", `{{ range sortBy "severity" .Results }}{{ label . }}{{ end }}`, "i-cba"},
+		{"filter by status", `{{ range withStatus "missing, UNMANAGED" .Results }}{{ label . }}{{ end }}`, "ai-c"},
+		{"format diff", `{{ range .Results }}{{ range .Differences }}{{ formatDiff . }}{{ end }}{{ end }}`, `tags: {"a":"1"} -> instance_type:  -> `},
+		{"csv quoting", `{{ csv "a,b" "say \"hi\"" 3 }}`, `"a,b","say ""hi""",3`},
+		{"string helpers", `{{ " A|b " | trim | replace "|" "\\|" | lower }} {{ upper "x" }}`, `a\|b X`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, buf := newTestReporter(t, Config{Inline: tc.template})
+
+			require.NoError(t, r.Report(context.Background(), results))
+
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestNewReporter_InvalidTemplates(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  Config
+	}{
+		{"no template", Config{}},
+		{"missing file", Config{Path: filepath.Join(t.TempDir(), "missing.tmpl")}},
+		{"syntax error", Config{Inline: "{{ range .Results }}"}},
+		{"unknown function", Config{Inline: "{{ toYAML .Results }}"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReporter(tc.cfg, reportingtest.Logger())
+
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, errors.CodeConfigValidation, appErr.Code)
+		})
+	}
+}
+
+func TestReporter_ExecutionErrorWritesNothing(t *testing.T) {
+	r, buf := newTestReporter(t, Config{Inline: `header {{ range groupBy "owner" .Results }}{{ end }}`})
+
+	err := r.Report(context.Background(), reportingtest.Results())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "owner"`)
+	assert.Empty(t, buf.String())
+}
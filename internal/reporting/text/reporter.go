@@ -9,6 +9,7 @@ import (
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/core/ports"
 	apperrors "github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
 	"github.com/pmezard/go-difflib/difflib"
 	"io"
 	"os"
@@ -29,6 +30,10 @@ type Reporter struct {
 	writer io.Writer
 	logger ports.Logger
 
+	stateIssues []domain.StateIssue
+	annotations []domain.RunAnnotation
+	times       *localize.Formatter
+
 	red     func(...interface{}) string
 	yellow  func(...interface{}) string
 	green   func(...interface{}) string
@@ -67,13 +72,39 @@ func isTerminal(f *os.File) bool {
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
+// SetWriter redirects the report output, which defaults to stdout.
+func (r *Reporter) SetWriter(w io.Writer) {
+	r.writer = w
+}
+
+// SetStateIssues sets the state source issues printed after the summary.
+func (r *Reporter) SetStateIssues(issues []domain.StateIssue) {
+	r.stateIssues = issues
+}
+
+// SetRunAnnotations sets the run annotations printed after the summary.
+func (r *Reporter) SetRunAnnotations(annotations []domain.RunAnnotation) {
+	r.annotations = annotations
+}
+
+// SetTimeFormatter renders timestamps in the formatter's zone and locale
+// instead of RFC 3339.
+func (r *Reporter) SetTimeFormatter(f *localize.Formatter) {
+	r.times = f
+}
+
 func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult) error {
 	if len(results) == 0 {
 		fmt.Fprintln(r.writer, r.yellow("No resources found or processed."))
+		r.printRunAnnotations()
+		r.printStateIssues()
 		return nil
 	}
 
 	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Priority != results[j].Priority {
+			return results[i].Priority > results[j].Priority
+		}
 		if results[i].ResourceKind != results[j].ResourceKind {
 			return results[i].ResourceKind < results[j].ResourceKind
 		}
@@ -101,15 +132,24 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 	fmt.Fprintln(tw, r.bold("Status\tKind\tIdentifier"))
 	fmt.Fprintln(tw, r.bold("------\t----\t----------"))
 
-	driftCount, errorCount, missingCount, unmanagedCount, noDriftCount := 0, 0, 0, 0, 0
+	driftCount, errorCount, missingCount, unmanagedCount, noDriftCount, deletedCount, pendingDeletionCount, ambiguousCount := 0, 0, 0, 0, 0, 0, 0, 0
+	var deadLetters, unapprovedImages []domain.ComparisonResult
 
 	for _, res := range results {
+		if res.Status == domain.StatusDeadLettered {
+			deadLetters = append(deadLetters, res)
+			continue
+		}
+		if res.Status == domain.StatusUnapprovedImage {
+			unapprovedImages = append(unapprovedImages, res)
+			continue
+		}
 		if ctx.Err() != nil {
 			_ = tw.Flush()
 			return ctx.Err()
 		}
 
-		identifier, statusStr, detailsToPrintSeparately := r.processResultLine(res, &driftCount, &errorCount, &missingCount, &unmanagedCount, &noDriftCount)
+		identifier, statusStr, detailsToPrintSeparately := r.processResultLine(res, &driftCount, &errorCount, &missingCount, &unmanagedCount, &noDriftCount, &deletedCount, &pendingDeletionCount, &ambiguousCount)
 
 		fmt.Fprintf(tw, "%s\t%s\t%s\n", statusStr, res.ResourceKind, identifier)
 
@@ -122,12 +162,17 @@ func (r *Reporter) Report(ctx context.Context, results []domain.ComparisonResult
 
 	_ = tw.Flush()
 
-	r.printSummary(len(results), noDriftCount, driftCount, missingCount, unmanagedCount, errorCount)
+	r.printSummary(len(results)-len(unapprovedImages), noDriftCount, driftCount, missingCount, deletedCount, pendingDeletionCount, unmanagedCount, ambiguousCount, errorCount, len(deadLetters), len(unapprovedImages))
+	r.printGroupSummary(results)
+	r.printUnapprovedImages(unapprovedImages)
+	r.printDeadLetters(deadLetters)
+	r.printRunAnnotations()
+	r.printStateIssues()
 
 	return nil
 }
 
-func (r *Reporter) processResultLine(res domain.ComparisonResult, driftCount, errorCount, missingCount, unmanagedCount, noDriftCount *int) (string, string, string) {
+func (r *Reporter) processResultLine(res domain.ComparisonResult, driftCount, errorCount, missingCount, unmanagedCount, noDriftCount, deletedCount, pendingDeletionCount, ambiguousCount *int) (string, string, string) {
 	identifier := res.SourceIdentifier
 	statusStr := ""
 	details := ""
@@ -156,11 +201,47 @@ func (r *Reporter) processResultLine(res domain.ComparisonResult, driftCount, er
 		*missingCount++
 		statusStr = r.yellow("[MISSING]")
 		details = r.yellow("Resource defined in state source but not found on platform.")
+	case domain.StatusRecentlyDeleted:
+		*deletedCount++
+		statusStr = r.yellow("[DELETED]")
+		details = r.yellow("Resource defined in state source was deleted from the platform since the previous run.")
+		if res.DeletionWindow != nil {
+			details += "\n" + r.yellow(fmt.Sprintf("Deleted between %s and %s.",
+				r.times.Format(res.DeletionWindow.From), r.times.Format(res.DeletionWindow.To)))
+		}
+	case domain.StatusPendingDeletion:
+		*pendingDeletionCount++
+		statusStr = r.yellow("[PENDING-DELETION]")
+		if identifier == "" {
+			identifier = res.ProviderAssignedID
+		}
+		details = r.yellow("Resource is being deleted by the platform or held for deletion; attributes were not compared.")
+		if pd := res.PendingDeletion; pd != nil {
+			state := fmt.Sprintf("State: %s", pd.State)
+			if !pd.DeletionDate.IsZero() {
+				state += fmt.Sprintf(", scheduled for deletion on %s", r.times.Format(pd.DeletionDate))
+			}
+			details += "\n" + r.yellow(state+".")
+		}
 	case domain.StatusUnmanaged:
 		*unmanagedCount++
 		statusStr = r.cyan("[UNMANAGED]")
 		identifier = res.ProviderAssignedID
 		details = r.cyan("Resource found on platform but not defined in state source.")
+	case domain.StatusAmbiguous:
+		*ambiguousCount++
+		statusStr = r.yellow("[AMBIGUOUS]")
+		if identifier == "" {
+			identifier = res.ProviderAssignedID
+		}
+		details = r.yellow("Several resources match one another, so none was paired or compared. Candidates:")
+		for _, candidate := range res.Candidates {
+			if candidate.SourceIdentifier != "" {
+				details += "\n" + r.yellow("- state: "+candidate.SourceIdentifier)
+			} else {
+				details += "\n" + r.yellow("- platform: "+candidate.ProviderAssignedID)
+			}
+		}
 	case domain.StatusNoDrift:
 		*noDriftCount++
 		statusStr = r.green("[OK]")
@@ -179,9 +260,67 @@ func (r *Reporter) processResultLine(res domain.ComparisonResult, driftCount, er
 		identifier = "<unknown>"
 	}
 
+	if len(res.NotAsserted) > 0 {
+		if details != "" {
+			details += "\n"
+		}
+		details += r.yellow("Not asserted (known after apply): " + strings.Join(res.NotAsserted, ", "))
+	}
+
+	if details != "" && len(res.Links) > 0 {
+		details += "\n" + r.formatLinks(res.Links)
+	}
+
+	if res.Trace != nil {
+		if details != "" {
+			details += "\n"
+		}
+		details += r.formatTrace(res.Trace)
+	}
+
 	return identifier, statusStr, details
 }
 
+// formatTrace renders the explain mode decision path of a comparison.
+func (r *Reporter) formatTrace(trace *domain.ComparisonTrace) string {
+	var builder strings.Builder
+	builder.WriteString(r.bold("Explain:"))
+	for _, step := range trace.Steps {
+		builder.WriteString("\n  - " + step)
+	}
+	for _, attr := range trace.Attributes {
+		verdict := r.green("equal")
+		switch {
+		case attr.Skipped:
+			verdict = r.yellow("skipped")
+		case !attr.Equal:
+			verdict = r.red("different")
+		}
+		builder.WriteString(fmt.Sprintf("\n  %s: %s", attr.Attribute, verdict))
+		if attr.Comparer != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", attr.Comparer))
+		}
+		for _, step := range attr.Steps {
+			builder.WriteString("\n    - " + step)
+		}
+		if attr.Reason != "" {
+			builder.WriteString("\n    => " + strings.ReplaceAll(attr.Reason, "\n", "\n       "))
+		}
+	}
+	return builder.String()
+}
+
+func (r *Reporter) formatLinks(links []domain.ResourceLink) string {
+	var builder strings.Builder
+	for i, link := range links {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("%s: %s", link.Name, link.URL))
+	}
+	return builder.String()
+}
+
 func (r *Reporter) printIndentedDetails(details string) {
 	lines := strings.Split(details, "\n")
 	for _, line := range lines {
@@ -196,19 +335,61 @@ func (r *Reporter) formatDriftDetails(diffs []domain.AttributeDiff) string {
 
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("%s attributes differ:", r.bold(len(diffs))))
+	if groups := (domain.ComparisonResult{Differences: diffs}).DriftByGroup(); len(groups) > 0 {
+		builder.WriteString(fmt.Sprintf("\nBy group: %s", formatGroupCounts(groups)))
+	}
 
 	for i, diff := range diffs {
 		builder.WriteString(fmt.Sprintf("\n[%d] Attribute: %s", i+1, r.bold(diff.AttributeName)))
+		if diff.Severity == domain.SeverityCritical {
+			builder.WriteString(" " + r.red("[CRITICAL]"))
+		}
+		if diff.PlatformManaged != "" {
+			builder.WriteString(" " + r.cyan(fmt.Sprintf("[PLATFORM-MANAGED: %s]", diff.PlatformManaged)))
+		}
 		if diff.Details != "" && !isGenericMapSliceDetail(diff.Details) {
 			builder.WriteString(fmt.Sprintf(" (%s)", diff.Details))
 		}
 
+		if len(diff.Changes) > 0 {
+			builder.WriteString(r.formatJSONChanges(diff.Changes))
+			continue
+		}
 		diffOutput := r.generateSpecificDiff(diff.ExpectedValue, diff.ActualValue)
 		builder.WriteString(diffOutput)
 	}
 	return builder.String()
 }
 
+// formatJSONChanges lists the paths at which two JSON documents differ, one
+// per line, instead of the documents themselves.
+func (r *Reporter) formatJSONChanges(changes []domain.JSONChange) string {
+	var builder strings.Builder
+	for _, c := range changes {
+		path := c.Path
+		if path == "" {
+			path = "(document)"
+		}
+		switch c.Op {
+		case domain.JSONChangeAdded:
+			builder.WriteString(fmt.Sprintf("\n  %s %s: %s", r.diffAdd("+"), path, formatJSONValue(c.Actual)))
+		case domain.JSONChangeRemoved:
+			builder.WriteString(fmt.Sprintf("\n  %s %s: %s", r.diffDel("-"), path, formatJSONValue(c.Expected)))
+		default:
+			builder.WriteString(fmt.Sprintf("\n  %s %s: %s -> %s", r.yellow("~"), path, formatJSONValue(c.Expected), formatJSONValue(c.Actual)))
+		}
+	}
+	return builder.String()
+}
+
+func formatJSONValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return formatValueSimple(value)
+	}
+	return string(encoded)
+}
+
 func isGenericMapSliceDetail(detail string) bool {
 	return detail == "Slice contents differ" || detail == "Map contents differ" || strings.HasPrefix(detail, "Differences by")
 }
@@ -413,7 +594,7 @@ func valueToDiffLines(value any) ([]string, error) {
 	return strings.Split(string(jsonBytes), "\n"), nil
 }
 
-func (r *Reporter) printSummary(total, ok, drifted, missing, unmanaged, errored int) {
+func (r *Reporter) printSummary(total, ok, drifted, missing, deleted, pendingDeletion, unmanaged, ambiguous, errored, deadLettered, unapprovedImages int) {
 	fmt.Fprintln(r.writer)
 	fmt.Fprintln(r.writer, r.bold("Summary:"))
 	fmt.Fprintln(r.writer, r.bold("-------"))
@@ -423,7 +604,139 @@ func (r *Reporter) printSummary(total, ok, drifted, missing, unmanaged, errored
 	fmt.Fprintf(summaryTw, "No Drift:\t%s\n", r.green(ok))
 	fmt.Fprintf(summaryTw, "Drifted:\t%s\n", r.red(drifted))
 	fmt.Fprintf(summaryTw, "Missing (State Only):\t%s\n", r.yellow(missing))
+	if deleted > 0 {
+		fmt.Fprintf(summaryTw, "Recently Deleted:\t%s\n", r.yellow(deleted))
+	}
+	if pendingDeletion > 0 {
+		fmt.Fprintf(summaryTw, "Pending Deletion:\t%s\n", r.yellow(pendingDeletion))
+	}
 	fmt.Fprintf(summaryTw, "Unmanaged (Platform Only):\t%s\n", r.cyan(unmanaged))
+	if ambiguous > 0 {
+		fmt.Fprintf(summaryTw, "Ambiguous Matches:\t%s\n", r.yellow(ambiguous))
+	}
 	fmt.Fprintf(summaryTw, "Errors:\t%s\n", r.magenta(errored))
+	if deadLettered > 0 {
+		fmt.Fprintf(summaryTw, "Dead-Lettered:\t%s\n", r.magenta(deadLettered))
+	}
+	if unapprovedImages > 0 {
+		fmt.Fprintf(summaryTw, "Unapproved Images:\t%s\n", r.yellow(unapprovedImages))
+	}
 	_ = summaryTw.Flush()
 }
+
+// printGroupSummary prints the drift count of every attribute group across all
+// resources, when attribute groups are configured.
+func (r *Reporter) printGroupSummary(results []domain.ComparisonResult) {
+	groups := domain.CountDriftByGroup(results)
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, r.bold("Drift by Group:"))
+	fmt.Fprintln(r.writer, r.bold("--------------"))
+	groupTw := tabwriter.NewWriter(r.writer, 0, 8, 1, ' ', 0)
+	for _, group := range groups {
+		fmt.Fprintf(groupTw, "%s:\t%s\n", group.Group, r.red(group.Count))
+	}
+	_ = groupTw.Flush()
+}
+
+// formatGroupCounts renders group counts as "3 security, 1 networking".
+func formatGroupCounts(groups []domain.GroupCount) string {
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = fmt.Sprintf("%d %s", group.Count, group.Group)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printUnapprovedImages lists instances running images the image approval
+// source does not list for them. These findings accompany the instances' own
+// results, so they are kept out of the main table and the total.
+func (r *Reporter) printUnapprovedImages(findings []domain.ComparisonResult) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, r.bold("Unapproved Images:"))
+	fmt.Fprintln(r.writer, r.bold("------------------"))
+	for _, res := range findings {
+		identifier := res.SourceIdentifier
+		if identifier == "" {
+			identifier = res.ProviderAssignedID
+		}
+		fmt.Fprintf(r.writer, "%s %s %s\n", r.yellow("[UNAPPROVED-IMAGE]"), res.ResourceKind, identifier)
+		for _, diff := range res.Differences {
+			details := r.yellow(diff.Details)
+			if len(res.Links) > 0 {
+				details += "\n" + r.formatLinks(res.Links)
+			}
+			r.printIndentedDetails(details)
+		}
+	}
+}
+
+// printDeadLetters lists resources that failed in several consecutive runs,
+// once each with their last error, after the summary.
+func (r *Reporter) printDeadLetters(deadLetters []domain.ComparisonResult) {
+	if len(deadLetters) == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, r.bold("Dead-Letter Resources:"))
+	fmt.Fprintln(r.writer, r.bold("----------------------"))
+	for _, res := range deadLetters {
+		identifier := res.SourceIdentifier
+		if identifier == "" {
+			identifier = res.ProviderAssignedID
+		}
+		line := fmt.Sprintf("%s %s %s", r.magenta("[DEAD-LETTER]"), res.ResourceKind, identifier)
+		if streak := res.FailureStreak; streak != nil {
+			line += fmt.Sprintf(" (failed %d consecutive runs since %s)", streak.Runs, r.times.Format(streak.Since))
+		}
+		fmt.Fprintln(r.writer, line)
+		r.printIndentedDetails(r.magenta(fmt.Sprintf("Last error: %v", res.Error)))
+	}
+}
+
+func (r *Reporter) printRunAnnotations() {
+	if len(r.annotations) == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, r.bold("Run Notes:"))
+	fmt.Fprintln(r.writer, r.bold("----------"))
+	for _, annotation := range r.annotations {
+		fmt.Fprintf(r.writer, "%s [%s] %s\n", r.yellow("[NOTE]"), annotation.Source, annotation.Message)
+	}
+}
+
+func (r *Reporter) printStateIssues() {
+	if len(r.stateIssues) == 0 {
+		return
+	}
+	fmt.Fprintln(r.writer)
+	fmt.Fprintln(r.writer, r.bold("State Source Issues:"))
+	fmt.Fprintln(r.writer, r.bold("--------------------"))
+	for _, issue := range r.stateIssues {
+		label := r.yellow("[WARNING]")
+		if issue.Severity == domain.SeverityCritical {
+			label = r.red("[ERROR]")
+		}
+		line := label + " "
+		if issue.Location != "" {
+			line += issue.Location + ": "
+		}
+		line += issue.Summary
+		if issue.Address != "" {
+			line += fmt.Sprintf(" (%s)", issue.Address)
+		}
+		fmt.Fprintln(r.writer, line)
+		if issue.Detail != "" {
+			r.printIndentedDetails(issue.Detail)
+		}
+		if issue.Skipped {
+			r.printIndentedDetails(r.yellow("Resource was skipped and is not part of this analysis."))
+		}
+	}
+}
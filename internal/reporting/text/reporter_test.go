@@ -0,0 +1,52 @@
+package text
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/localize"
+	"github.com/olusolaa/infra-drift-detector/internal/reporting/reportingtest"
+)
+
+func newTestReporter(t *testing.T) (*Reporter, *bytes.Buffer) {
+	t.Helper()
+	r, err := NewReporter(Config{NoColor: true}, reportingtest.Logger())
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	r.writer = &buf
+	return r, &buf
+}
+
+func TestReporter_Golden(t *testing.T) {
+	r, buf := newTestReporter(t)
+	r.SetStateIssues(reportingtest.StateIssues())
+	r.SetRunAnnotations(reportingtest.RunAnnotations())
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	reportingtest.AssertGolden(t, "report", buf.Bytes())
+}
+
+func TestReporter_LocalizedTimes(t *testing.T) {
+	r, buf := newTestReporter(t)
+	times, err := localize.NewFormatter(localize.Config{Timezone: "Europe/Berlin", Locale: "de-DE"})
+	require.NoError(t, err)
+	r.SetTimeFormatter(times)
+
+	require.NoError(t, r.Report(context.Background(), reportingtest.Results()))
+
+	out := buf.String()
+	require.Contains(t, out, "Deleted between 01.06.2024 08:00:00 CEST and 01.06.2024 14:00:00 CEST.")
+	require.Contains(t, out, "(failed 5 consecutive runs since 29.05.2024 14:00:00 CEST)")
+}
+
+func TestReporter_GoldenEmpty(t *testing.T) {
+	r, buf := newTestReporter(t)
+
+	require.NoError(t, r.Report(context.Background(), nil))
+
+	reportingtest.AssertGolden(t, "empty", buf.Bytes())
+}
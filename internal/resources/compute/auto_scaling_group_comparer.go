@@ -0,0 +1,166 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// DesiredCapacityMode selects how drift in an Auto Scaling group's desired
+// capacity is reported. Scaling policies and scheduled actions change the
+// desired capacity at run time, so a difference is usually expected.
+type DesiredCapacityMode string
+
+const (
+	// DesiredCapacityCompare reports any difference in desired capacity.
+	DesiredCapacityCompare DesiredCapacityMode = "compare"
+	// DesiredCapacityWithinBounds only reports an actual desired capacity
+	// outside the desired min_size and max_size. It is the default.
+	DesiredCapacityWithinBounds DesiredCapacityMode = "within_bounds"
+	// DesiredCapacityIgnore never reports desired capacity drift.
+	DesiredCapacityIgnore DesiredCapacityMode = "ignore"
+)
+
+// AutoScalingGroupConfig configures the Auto Scaling group comparer.
+type AutoScalingGroupConfig struct {
+	DesiredCapacity DesiredCapacityMode `yaml:"desired_capacity" mapstructure:"desired_capacity" validate:"omitempty,oneof=compare within_bounds ignore"`
+}
+
+// AutoScalingGroupComparer compares EC2 Auto Scaling groups. Subnets and
+// target groups are compared as sets, termination policies in order, and the
+// desired capacity as configured by AutoScalingGroupConfig.
+type AutoScalingGroupComparer struct {
+	desiredCapacity DesiredCapacityMode
+	compareFuncs    map[string]helper.AttributeComparerFunc
+}
+
+// NewAutoScalingGroupComparer returns the comparer for Auto Scaling groups.
+func NewAutoScalingGroupComparer(cfg AutoScalingGroupConfig) *AutoScalingGroupComparer {
+	c := &AutoScalingGroupComparer{desiredCapacity: cfg.DesiredCapacity}
+	if c.desiredCapacity == "" {
+		c.desiredCapacity = DesiredCapacityWithinBounds
+	}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                            c.compareTags,
+		domain.AutoScalingGroupSubnetsKey:         helper.CompareStringSlicesUnordered,
+		domain.AutoScalingGroupTargetGroupARNsKey: helper.CompareStringSlicesUnordered,
+		domain.AutoScalingGroupPropagatedTagsKey:  helper.CompareStringSlicesUnordered,
+	}
+	return c
+}
+
+func (c *AutoScalingGroupComparer) Kind() domain.ResourceKind {
+	return domain.KindAutoScalingGroup
+}
+
+func (c *AutoScalingGroupComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "auto scaling group compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if attrKey == domain.AutoScalingGroupDesiredCapacityKey {
+			compareFunc, ok = c.desiredCapacityCompareFunc(desiredAttrs), true
+		}
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      helper.SeverityForAttribute(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      helper.SeverityForAttribute(attrKey),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *AutoScalingGroupComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// desiredCapacityCompareFunc compares the desired capacity according to the
+// configured mode. Within bounds, the desired min_size and max_size of the
+// group are the accepted range; without both it falls back to an exact
+// comparison.
+func (c *AutoScalingGroupComparer) desiredCapacityCompareFunc(desiredAttrs map[string]any) helper.AttributeComparerFunc {
+	return func(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+		switch c.desiredCapacity {
+		case DesiredCapacityIgnore:
+			helper.ExplainStep(ctx, "desired capacity drift is ignored by configuration")
+			return true, "", nil
+		case DesiredCapacityWithinBounds:
+			minSize, minOk := asInt64(desiredAttrs[domain.AutoScalingGroupMinSizeKey])
+			maxSize, maxOk := asInt64(desiredAttrs[domain.AutoScalingGroupMaxSizeKey])
+			capacity, capOk := asInt64(actual)
+			if minOk && maxOk && capOk && aExists {
+				helper.ExplainStep(ctx, "accepted any actual desired capacity within the desired bounds [%d, %d]", minSize, maxSize)
+				if capacity >= minSize && capacity <= maxSize {
+					return true, "", nil
+				}
+				return false, fmt.Sprintf("Desired capacity %d is outside the desired bounds [%d, %d]", capacity, minSize, maxSize), nil
+			}
+		}
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+}
+
+func asInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		if n == float64(int64(n)) {
+			return int64(n), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,158 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// containerCriticalAttributes decide what a service's tasks can reach and
+// which permissions they run with, so drift in them is always reported as
+// critical.
+var containerCriticalAttributes = map[string]struct{}{
+	domain.ContainerServiceNetworkConfigurationKey: {},
+	domain.TaskDefinitionExecutionRoleKey:          {},
+	domain.TaskDefinitionTaskRoleKey:               {},
+}
+
+// ContainerComparer compares ECS clusters, services or task definitions.
+// Container definitions are compared after normalizing the order of
+// containers and their lists and dropping the defaults ECS fills in, and a
+// service's task definition matches any revision when the desired state only
+// names the family.
+type ContainerComparer struct {
+	kind         domain.ResourceKind
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewContainerClusterComparer returns the comparer for ECS clusters.
+func NewContainerClusterComparer() *ContainerComparer {
+	return newContainerComparer(domain.KindContainerCluster)
+}
+
+// NewContainerServiceComparer returns the comparer for ECS services.
+func NewContainerServiceComparer() *ContainerComparer {
+	return newContainerComparer(domain.KindContainerService)
+}
+
+// NewContainerTaskDefinitionComparer returns the comparer for ECS task
+// definitions.
+func NewContainerTaskDefinitionComparer() *ContainerComparer {
+	return newContainerComparer(domain.KindContainerTaskDefinition)
+}
+
+func newContainerComparer(kind domain.ResourceKind) *ContainerComparer {
+	c := &ContainerComparer{kind: kind}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags: c.compareTags,
+	}
+	switch kind {
+	case domain.KindContainerService:
+		c.compareFuncs[domain.ContainerServiceTaskDefinitionKey] = compareTaskDefinitionRef
+		c.compareFuncs[domain.ContainerServiceCapacityProvidersKey] = c.compareCapacityProviders
+	case domain.KindContainerTaskDefinition:
+		c.compareFuncs[domain.TaskDefinitionContainerDefinitionsKey] = helper.CompareContainerDefinitions
+		c.compareFuncs[domain.TaskDefinitionCompatibilitiesKey] = helper.CompareStringSlicesUnordered
+	}
+	return c
+}
+
+func (c *ContainerComparer) Kind() domain.ResourceKind {
+	return c.kind
+}
+
+func (c *ContainerComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "container compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      containerSeverityFor(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      containerSeverityFor(attrKey),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func containerSeverityFor(attrKey string) domain.Severity {
+	if _, ok := containerCriticalAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *ContainerComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *ContainerComparer) compareCapacityProviders(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "capacity_provider", "capacity provider")
+}
+
+// compareTaskDefinitionRef compares a service's task definition. A desired
+// task definition without a revision tracks the latest revision of its
+// family, so only the family is compared.
+func compareTaskDefinitionRef(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	desiredRef, _ := desired.(string)
+	actualRef, _ := actual.(string)
+	if dExists && aExists && desiredRef != "" && !strings.Contains(desiredRef, ":") {
+		actualFamily, _, _ := strings.Cut(actualRef, ":")
+		helper.ExplainStep(ctx, "desired task definition names only the family %q, so any revision of it matches", desiredRef)
+		if actualFamily == desiredRef {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("Service runs task definition family %q instead of %q", actualFamily, desiredRef), nil
+	}
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
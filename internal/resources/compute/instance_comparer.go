@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/olusolaa/infra-drift-detector/pkg/compare"
+	"strings"
 
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
@@ -17,11 +18,15 @@ type InstanceComparer struct {
 func NewInstanceComparer() *InstanceComparer {
 	c := &InstanceComparer{}
 	c.compareFuncs = map[string]helper.AttributeComparerFunc{
-		domain.KeyTags:                   c.compareTags,
-		domain.ComputeSecurityGroupsKey:  helper.CompareStringSlicesUnordered, // Use generic helper directly
-		domain.ComputeRootBlockDeviceKey: c.compareRootBlockDevice,
-		domain.ComputeEBSBlockDevicesKey: c.compareEBSBlockDevices,
-		domain.ComputeUserDataKey:        helper.DefaultAttributeCompare, // Default is suitable
+		domain.KeyTags:                       c.compareTags,
+		domain.ComputeSecurityGroupsKey:      helper.CompareStringSlicesUnordered, // Use generic helper directly
+		domain.ComputeRootBlockDeviceKey:     c.compareRootBlockDevice,
+		domain.ComputeEBSBlockDevicesKey:     c.compareEBSBlockDevices,
+		domain.ComputeUserDataKey:            helper.DefaultAttributeCompare, // Default is suitable
+		domain.ComputeTenancyKey:             c.compareTenancy,
+		domain.ComputeCreditSpecificationKey: c.compareCreditSpecification,
+		domain.ComputeNetworkTagsKey:         helper.CompareStringSlicesUnordered,
+		domain.ComputeMetadataOptionsKey:     compareMetadataOptions,
 	}
 	return c
 }
@@ -60,9 +65,9 @@ func (c *InstanceComparer) Compare(
 		var compareErr error
 
 		if compareFunc, ok := c.compareFuncs[attrKey]; ok {
-			isEqual, details, compareErr = compareFunc(ctx, desiredVal, actualVal, dExists, aExists)
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
 		} else {
-			isEqual, details, compareErr = helper.DefaultAttributeCompare(ctx, desiredVal, actualVal, dExists, aExists)
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, helper.DefaultAttributeCompare, desiredVal, actualVal, dExists, aExists)
 		}
 
 		if ctx.Err() != nil {
@@ -80,12 +85,16 @@ func (c *InstanceComparer) Compare(
 		}
 
 		if !isEqual {
-			diffs = append(diffs, domain.AttributeDiff{
+			diff := domain.AttributeDiff{
 				AttributeName: attrKey,
 				ExpectedValue: desiredVal,
 				ActualValue:   actualVal,
 				Details:       details,
-			})
+			}
+			if attrKey == domain.ComputeMetadataOptionsKey {
+				diff.Severity = metadataOptionsSeverity(desiredVal, actualVal)
+			}
+			diffs = append(diffs, diff)
 		}
 	}
 
@@ -124,6 +133,7 @@ func (c *InstanceComparer) compareRootBlockDevice(ctx context.Context, desired,
 		return false, "Root block device configuration mismatch (actual is nil)", nil
 	}
 
+	helper.ExplainStep(ctx, "compared root block device settings key by key")
 	details := compare.GenerateDetailedMapDiff(ctx, normDesired, normActual)
 	if ctx.Err() != nil {
 		return false, "", ctx.Err()
@@ -136,3 +146,102 @@ func (c *InstanceComparer) compareRootBlockDevice(ctx context.Context, desired,
 func (c *InstanceComparer) compareEBSBlockDevices(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
 	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "device_name", "EBS Block Device")
 }
+
+// compareTenancy treats an unset tenancy as "default", which is what AWS
+// reports for instances launched on shared hardware.
+func (c *InstanceComparer) compareTenancy(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dTenancy, aTenancy := tenancyOrDefault(desired), tenancyOrDefault(actual)
+	if dTenancy == aTenancy {
+		return true, "", nil
+	}
+	return false, fmt.Sprintf("Tenancy differs: desired '%s', actual '%s'", dTenancy, aTenancy), nil
+}
+
+// compareCreditSpecification compares the CPU credit mode of burstable
+// instances. When the desired state does not set one AWS applies the instance
+// family default, so only an explicit desired mode is compared.
+func (c *InstanceComparer) compareCreditSpecification(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dCredits, _ := desired.(string)
+	if dCredits == "" {
+		helper.ExplainStep(ctx, "no CPU credit mode in desired state, the instance family default applies")
+		return true, "", nil
+	}
+	aCredits, _ := actual.(string)
+	if strings.EqualFold(dCredits, aCredits) {
+		return true, "", nil
+	}
+	return false, fmt.Sprintf("CPU credit mode differs: desired '%s', actual '%s'", dCredits, aCredits), nil
+}
+
+// metadataOptionKeys are the instance metadata settings, in the order they
+// are reported.
+var metadataOptionKeys = []string{"http_tokens", "http_endpoint", "http_put_response_hop_limit", "http_protocol_ipv6", "instance_metadata_tags"}
+
+// compareMetadataOptions compares the instance metadata settings the desired
+// state sets. Settings it leaves out take the AMI or account default, which
+// differs between AMIs, so they are not compared.
+func compareMetadataOptions(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dOpts, dOk := metadataOptionsMap(desired)
+	aOpts, aOk := metadataOptionsMap(actual)
+	if !dOk || !aOk {
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	if len(dOpts) == 0 {
+		helper.ExplainStep(ctx, "no metadata options in desired state, the AMI defaults apply")
+		return true, "", nil
+	}
+	var mismatches []string
+	for _, key := range metadataOptionKeys {
+		dValue, ok := dOpts[key]
+		if !ok {
+			continue
+		}
+		if aValue := aOpts[key]; !strings.EqualFold(fmt.Sprint(dValue), fmt.Sprint(aValue)) {
+			mismatches = append(mismatches, fmt.Sprintf("%s (desired: %v, actual: %v)", key, dValue, aValue))
+		}
+	}
+	if len(mismatches) > 0 {
+		return false, "Metadata options differ: " + strings.Join(mismatches, ", "), nil
+	}
+	return true, "", nil
+}
+
+// metadataOptionsSeverity is critical when the desired state requires IMDSv2
+// session tokens and the instance still accepts IMDSv1 requests, or the
+// desired state disables the metadata endpoint and the instance serves it.
+// Other differences take the default severity.
+func metadataOptionsSeverity(desired, actual any) domain.Severity {
+	dOpts, _ := metadataOptionsMap(desired)
+	aOpts, _ := metadataOptionsMap(actual)
+	if strings.EqualFold(fmt.Sprint(dOpts["http_tokens"]), "required") && !strings.EqualFold(fmt.Sprint(aOpts["http_tokens"]), "required") {
+		return domain.SeverityCritical
+	}
+	if strings.EqualFold(fmt.Sprint(dOpts["http_endpoint"]), "disabled") && !strings.EqualFold(fmt.Sprint(aOpts["http_endpoint"]), "disabled") {
+		return domain.SeverityCritical
+	}
+	return ""
+}
+
+// metadataOptionsMap returns the metadata options as a map, unwrapping a
+// block held as a single element list. Nil is an empty map.
+func metadataOptionsMap(v any) (map[string]any, bool) {
+	switch opts := v.(type) {
+	case nil:
+		return map[string]any{}, true
+	case map[string]any:
+		return opts, true
+	case []any:
+		if len(opts) == 1 {
+			m, ok := opts[0].(map[string]any)
+			return m, ok
+		}
+	}
+	return nil, false
+}
+
+func tenancyOrDefault(v any) string {
+	if s, ok := v.(string); ok && s != "" {
+		return strings.ToLower(s)
+	}
+	return "default"
+}
@@ -0,0 +1,178 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// launchTemplateDataKeys are the template data settings, in the order they
+// are reported when explaining default version drift.
+var launchTemplateDataKeys = []string{
+	domain.LaunchTemplateDescriptionKey,
+	domain.ComputeImageIDKey,
+	domain.ComputeInstanceTypeKey,
+	domain.LaunchTemplateKeyNameKey,
+	domain.ComputeSecurityGroupsKey,
+	domain.ComputeUserDataKey,
+	domain.ComputeIAMInstanceProfileKey,
+	domain.ComputeMetadataOptionsKey,
+	domain.ComputeMonitoringKey,
+	domain.ComputeEBSOptimizedKey,
+	domain.LaunchTemplateBlockDeviceMappingsKey,
+	domain.LaunchTemplateTagSpecificationsKey,
+}
+
+// LaunchTemplateComparer compares EC2 launch templates. The template data is
+// that of the latest version; security groups, block device mappings and tag
+// specifications are compared as sets, and metadata options as the instance
+// comparer does. A default version that moved away from the desired one is
+// reported with the settings launches now get from it.
+type LaunchTemplateComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewLaunchTemplateComparer returns the comparer for launch templates.
+func NewLaunchTemplateComparer() *LaunchTemplateComparer {
+	c := &LaunchTemplateComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                              c.compareTags,
+		domain.ComputeSecurityGroupsKey:             helper.CompareStringSlicesUnordered,
+		domain.ComputeMetadataOptionsKey:            compareMetadataOptions,
+		domain.LaunchTemplateBlockDeviceMappingsKey: c.compareBlockDeviceMappings,
+		domain.LaunchTemplateTagSpecificationsKey:   c.compareTagSpecifications,
+	}
+	return c
+}
+
+func (c *LaunchTemplateComparer) Kind() domain.ResourceKind {
+	return domain.KindLaunchTemplate
+}
+
+func (c *LaunchTemplateComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "launch template compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if attrKey == domain.LaunchTemplateDefaultVersionKey {
+			compareFunc, ok = c.defaultVersionCompareFunc(desiredAttrs, actualAttrs), true
+		}
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diff := domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+			}
+			switch attrKey {
+			case domain.ComputeMetadataOptionsKey:
+				diff.Severity = metadataOptionsSeverity(desiredVal, actualVal)
+			case domain.LaunchTemplateDefaultVersionKey:
+				diff.Severity = domain.SeverityWarning
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *LaunchTemplateComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *LaunchTemplateComparer) compareBlockDeviceMappings(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "device_name", "Block Device Mapping")
+}
+
+func (c *LaunchTemplateComparer) compareTagSpecifications(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "resource_type", "Tag Specification")
+}
+
+// defaultVersionCompareFunc compares the default version number. Launches
+// that do not pin a version, such as Auto Scaling groups using "$Default",
+// use the default version, so when it moved to a version other than the
+// latest the details name the settings that version launches with that differ
+// from the desired template.
+func (c *LaunchTemplateComparer) defaultVersionCompareFunc(desiredAttrs, actualAttrs map[string]any) helper.AttributeComparerFunc {
+	return func(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+		dVersion, dOk := asInt64(desired)
+		aVersion, aOk := asInt64(actual)
+		if !dOk || !aOk {
+			return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+		}
+		if dVersion == aVersion {
+			return true, "", nil
+		}
+		details := fmt.Sprintf("Default version is %d, desired %d", aVersion, dVersion)
+		data, ok := actualAttrs[domain.LaunchTemplateDefaultVersionDataKey].(map[string]any)
+		if !ok {
+			return false, details, nil
+		}
+		helper.ExplainStep(ctx, "compared the settings of default version %d with the desired template", aVersion)
+		var differing []string
+		for _, key := range launchTemplateDataKeys {
+			desiredVal, dHas := desiredAttrs[key]
+			dataVal, aHas := data[key]
+			compareFunc, ok := c.compareFuncs[key]
+			if !ok {
+				compareFunc = helper.DefaultAttributeCompare
+			}
+			equal, _, err := compareFunc(ctx, desiredVal, dataVal, dHas, aHas)
+			if err != nil {
+				return false, "", err
+			}
+			if !equal {
+				differing = append(differing, key)
+			}
+		}
+		if len(differing) > 0 {
+			details += fmt.Sprintf("; launches without a version use version %d, which differs in %s", aVersion, strings.Join(differing, ", "))
+		}
+		return false, details, nil
+	}
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+type InstanceComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+func NewInstanceComparer() *InstanceComparer {
+	c := &InstanceComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                   c.compareTags,
+		domain.DatabaseSecurityGroupsKey: helper.CompareStringSlicesUnordered,
+		domain.DatabaseEngineVersionKey:  c.compareEngineVersion,
+		domain.KeyBackupRetentionPeriod:  helper.CompareResilience,
+		domain.KeyBackupWindow:           helper.CompareResilience,
+	}
+	return c
+}
+
+func (c *InstanceComparer) Kind() domain.ResourceKind {
+	return domain.KindDatabaseInstance
+}
+
+func (c *InstanceComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "database compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      helper.SeverityForAttribute(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      helper.SeverityForDifference(attrKey, desiredVal, actualVal),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *InstanceComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// compareEngineVersion treats a desired version as a prefix of the actual one,
+// since Terraform commonly pins only the major version ("15") and lets RDS apply
+// minor upgrades ("15.4").
+func (c *InstanceComparer) compareEngineVersion(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dStr, dOk := desired.(string)
+	aStr, aOk := actual.(string)
+	if dOk && aOk && dStr != "" && strings.HasPrefix(aStr, dStr+".") {
+		helper.ExplainStep(ctx, "desired version %s pins a prefix of actual version %s", dStr, aStr)
+		return true, "", nil
+	}
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
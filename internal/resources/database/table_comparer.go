@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// TableComparer compares DynamoDB tables. Attribute definitions and secondary
+// indexes are matched by name regardless of order, and disabled TTL or
+// encryption settings equal absent ones.
+type TableComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewTableComparer returns the comparer for DynamoDB tables.
+func NewTableComparer() *TableComparer {
+	c := &TableComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                        c.compareTags,
+		domain.TableAttributesKey:             c.compareAttributeDefinitions,
+		domain.TableGlobalSecondaryIndexesKey: c.compareIndexes,
+		domain.TableLocalSecondaryIndexesKey:  c.compareIndexes,
+		domain.TableTTLKey:                    c.compareTTL,
+		domain.TableServerSideEncryptionKey:   c.compareEncryption,
+		domain.KeyPointInTimeRecovery:         helper.CompareResilience,
+	}
+	return c
+}
+
+func (c *TableComparer) Kind() domain.ResourceKind {
+	return domain.KindDatabaseTable
+}
+
+func (c *TableComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "table compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      tableSeverityFor(attrKey, desiredVal, actualVal),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      tableSeverityFor(attrKey, desiredVal, actualVal),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+// tableSeverityFor reports encryption drift as critical; other attributes use
+// the shared severities, so point-in-time recovery follows the resilience group.
+func tableSeverityFor(attrKey string, desired, actual any) domain.Severity {
+	if attrKey == domain.TableServerSideEncryptionKey {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForDifference(attrKey, desired, actual)
+}
+
+func (c *TableComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *TableComparer) compareAttributeDefinitions(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "name", "attribute")
+}
+
+func (c *TableComparer) compareIndexes(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "name", "index")
+}
+
+// compareTTL treats TTL disabled on both sides as equal, whatever attribute
+// name either side still records.
+func (c *TableComparer) compareTTL(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !settingEnabled(desired) && !settingEnabled(actual) {
+		helper.ExplainStep(ctx, "TTL disabled on both sides")
+		return true, "", nil
+	}
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
+
+// compareEncryption treats encryption disabled and no encryption block as
+// equal, since both mean the AWS owned key. The KMS key is only compared when
+// the desired state names one; Terraform leaves it empty for the AWS managed key.
+func (c *TableComparer) compareEncryption(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dEnabled, aEnabled := settingEnabled(desired), settingEnabled(actual)
+	if dEnabled != aEnabled {
+		return false, fmt.Sprintf("Server-side encryption with a KMS key differs: desired enabled=%t, actual enabled=%t", dEnabled, aEnabled), nil
+	}
+	if !dEnabled {
+		helper.ExplainStep(ctx, "encryption with a KMS key disabled on both sides")
+		return true, "", nil
+	}
+
+	dMap, _ := desired.(map[string]any)
+	aMap, _ := actual.(map[string]any)
+	dKey, _ := dMap["kms_key_arn"].(string)
+	aKey, _ := aMap["kms_key_arn"].(string)
+	if dKey == "" || dKey == aKey {
+		return true, "", nil
+	}
+	return false, fmt.Sprintf("KMS key differs: desired '%s', actual '%s'", dKey, aKey), nil
+}
+
+// settingEnabled reports whether a {enabled: ...} setting map is enabled.
+func settingEnabled(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	enabled, _ := m["enabled"].(bool)
+	return enabled
+}
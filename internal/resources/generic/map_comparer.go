@@ -0,0 +1,137 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// MapComparer compares arbitrary attribute maps for user-defined kinds that have
+// no dedicated comparer. Tags ignore the reserved "aws:" prefix, TLS policy
+// attributes are reported as critical, replication and backup settings are
+// compared as the resilience attribute group, policy and container definition
+// documents are compared in canonical JSON form, other JSON documents held as
+// strings on one side are decoded before comparison, and everything else goes
+// through the default robust comparison.
+type MapComparer struct {
+	kind domain.ResourceKind
+}
+
+func NewMapComparer(kind domain.ResourceKind) *MapComparer {
+	return &MapComparer{kind: kind}
+}
+
+func (c *MapComparer) Kind() domain.ResourceKind {
+	return c.kind
+}
+
+func (c *MapComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, fmt.Sprintf("%s Compare called with nil desired or actual resource", c.kind))
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		var isEqual bool
+		var details string
+		var compareErr error
+		if attrKey == domain.KeyTags {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, c.compareTags, desiredVal, actualVal, dExists, aExists)
+		} else if helper.IsTLSAttribute(attrKey) {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, helper.CompareTLSPolicy, desiredVal, actualVal, dExists, aExists)
+		} else if helper.IsResilienceAttribute(attrKey) {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, helper.CompareResilience, desiredVal, actualVal, dExists, aExists)
+		} else if helper.IsJSONDocumentAttribute(attrKey) {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, c.compareJSONDocument, desiredVal, actualVal, dExists, aExists)
+		} else {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, c.compareDecoded, desiredVal, actualVal, dExists, aExists)
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey, ExpectedValue: desiredVal, ActualValue: actualVal,
+				Details:  fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity: helper.SeverityForAttribute(attrKey),
+			})
+			continue
+		}
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      helper.SeverityForDifference(attrKey, desiredVal, actualVal),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *MapComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *MapComparer) compareJSONDocument(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareJSONDocuments(ctx, desired, actual, dExists, aExists, "Document")
+}
+
+// compareDecoded compares values after decoding JSON documents held as strings.
+func (c *MapComparer) compareDecoded(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	decodedDesired, decodedActual := decodeJSONString(desired), decodeJSONString(actual)
+	if _, wasString := desired.(string); wasString && !isString(decodedDesired) {
+		helper.ExplainStep(ctx, "decoded desired JSON string into %T", decodedDesired)
+	}
+	if _, wasString := actual.(string); wasString && !isString(decodedActual) {
+		helper.ExplainStep(ctx, "decoded actual JSON string into %T", decodedActual)
+	}
+	return helper.DefaultAttributeCompare(ctx, decodedDesired, decodedActual, dExists, aExists)
+}
+
+func isString(v any) bool {
+	_, ok := v.(string)
+	return ok
+}
+
+// decodeJSONString turns a string holding a JSON object or array into its decoded
+// form, since Terraform stores documents (policies, redrive configs) as strings
+// while Cloud Control returns them as nested objects.
+func decodeJSONString(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return v
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return v
+	}
+	return decoded
+}
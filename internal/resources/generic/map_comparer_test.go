@@ -0,0 +1,141 @@
+package generic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+type stateResource map[string]any
+
+func (r stateResource) Metadata() domain.ResourceMetadata { return domain.ResourceMetadata{} }
+func (r stateResource) Attributes() map[string]any        { return r }
+
+type platformResource map[string]any
+
+func (r platformResource) Metadata() domain.ResourceMetadata { return domain.ResourceMetadata{} }
+func (r platformResource) Attributes(context.Context) (map[string]any, error) {
+	return r, nil
+}
+
+func TestMapComparer_Compare(t *testing.T) {
+	tests := []struct {
+		name         string
+		attribute    string
+		desired      map[string]any
+		actual       map[string]any
+		wantDrift    bool
+		wantSeverity domain.Severity
+	}{
+		{
+			name:      "tags ignore the reserved aws prefix",
+			attribute: domain.KeyTags,
+			desired:   map[string]any{domain.KeyTags: map[string]any{"Env": "prod"}},
+			actual:    map[string]any{domain.KeyTags: map[string]any{"Env": "prod", "aws:cloudformation:stack-name": "queues"}},
+		},
+		{
+			name:      "changed tag",
+			attribute: domain.KeyTags,
+			desired:   map[string]any{domain.KeyTags: map[string]any{"Env": "prod"}},
+			actual:    map[string]any{domain.KeyTags: map[string]any{"Env": "dev"}},
+			wantDrift: true,
+		},
+		{
+			name:      "policy documents compared in canonical form",
+			attribute: "policy",
+			desired:   map[string]any{"policy": `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "sqs:SendMessage"}]}`},
+			actual:    map[string]any{"policy": map[string]any{"Statement": []any{map[string]any{"Action": "sqs:SendMessage", "Effect": "Allow"}}, "Version": "2012-10-17"}},
+		},
+		{
+			name:      "changed policy statement",
+			attribute: "redrive_allow_policy",
+			desired:   map[string]any{"redrive_allow_policy": `{"redrivePermission": "denyAll"}`},
+			actual:    map[string]any{"redrive_allow_policy": `{"redrivePermission": "allowAll"}`},
+			wantDrift: true,
+		},
+		{
+			name:      "JSON string decoded before comparison",
+			attribute: "redrive_config",
+			desired:   map[string]any{"redrive_config": `{"max_receive_count": 5}`},
+			actual:    map[string]any{"redrive_config": map[string]any{"max_receive_count": float64(5)}},
+		},
+		{
+			name:      "plain string that only looks like JSON",
+			attribute: "description",
+			desired:   map[string]any{"description": "{draft"},
+			actual:    map[string]any{"description": "{draft"},
+		},
+		{
+			name:         "TLS policy drift is critical",
+			attribute:    domain.KeySSLPolicy,
+			desired:      map[string]any{domain.KeySSLPolicy: "ELBSecurityPolicy-TLS13-1-2-2021-06"},
+			actual:       map[string]any{domain.KeySSLPolicy: "ELBSecurityPolicy-2016-08"},
+			wantDrift:    true,
+			wantSeverity: domain.SeverityCritical,
+		},
+		{
+			name:         "shorter backup retention is critical",
+			attribute:    domain.KeyBackupRetentionPeriod,
+			desired:      map[string]any{domain.KeyBackupRetentionPeriod: 7},
+			actual:       map[string]any{domain.KeyBackupRetentionPeriod: 1},
+			wantDrift:    true,
+			wantSeverity: domain.SeverityCritical,
+		},
+		{
+			name:         "longer backup retention is a warning",
+			attribute:    domain.KeyBackupRetentionPeriod,
+			desired:      map[string]any{domain.KeyBackupRetentionPeriod: 7},
+			actual:       map[string]any{domain.KeyBackupRetentionPeriod: 14},
+			wantDrift:    true,
+			wantSeverity: domain.SeverityWarning,
+		},
+		{
+			name:      "attribute missing on the platform",
+			attribute: "visibility_timeout",
+			desired:   map[string]any{"visibility_timeout": 30},
+			actual:    map[string]any{},
+			wantDrift: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparer := NewMapComparer("Queue")
+
+			diffs, err := comparer.Compare(context.Background(), stateResource(tt.desired), platformResource(tt.actual), []string{tt.attribute})
+
+			require.NoError(t, err)
+			if !tt.wantDrift {
+				assert.Empty(t, diffs)
+				return
+			}
+			require.Len(t, diffs, 1)
+			assert.Equal(t, tt.attribute, diffs[0].AttributeName)
+			assert.Equal(t, tt.desired[tt.attribute], diffs[0].ExpectedValue)
+			assert.Equal(t, tt.actual[tt.attribute], diffs[0].ActualValue)
+			assert.Equal(t, tt.wantSeverity, diffs[0].Severity)
+		})
+	}
+}
+
+func TestMapComparer_ComparesOnlyRequestedAttributes(t *testing.T) {
+	desired := stateResource{"queue_name": "orders", "delay_seconds": 0}
+	actual := platformResource{"queue_name": "orders", "delay_seconds": 15}
+
+	diffs, err := NewMapComparer("Queue").Compare(context.Background(), desired, actual, []string{"queue_name"})
+
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestMapComparer_NilResource(t *testing.T) {
+	comparer := NewMapComparer("Queue")
+	assert.Equal(t, domain.ResourceKind("Queue"), comparer.Kind())
+
+	_, err := comparer.Compare(context.Background(), nil, platformResource{}, []string{domain.KeyTags})
+	assert.Error(t, err)
+}
@@ -11,15 +11,21 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
 )
 
 // AttributeComparerFunc defines the signature for specific attribute comparison functions.
 type AttributeComparerFunc func(ctx context.Context, desired, actual any, dExists, aExists bool) (isEqual bool, details string, err error)
 
-// DefaultAttributeCompare uses the drift-specific RobustCompare.
+// DefaultAttributeCompare uses the drift-specific RobustCompare. String values
+// are first normalized as configured for the attribute being compared.
 func DefaultAttributeCompare(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
-	// Context is not directly used by RobustCompare but passed for consistency
+	if n := domain.AttributeNormalizationFrom(ctx); !n.IsZero() {
+		ExplainStep(ctx, "normalized string values: %s", n)
+		desired, actual = compare.MapStrings(desired, n.Apply), compare.MapStrings(actual, n.Apply)
+	}
+	ExplainStep(ctx, "robust comparison: absent and empty values are equivalent, pointers are dereferenced")
 	isEqual, err := compare.RobustCompare(desired, actual, dExists, aExists)
 	details := ""
 	if !isEqual && err == nil {
@@ -71,6 +77,10 @@ func CompareTags(ctx context.Context, desired, actual any, dExists, aExists bool
 		}
 	}
 
+	if ignorePrefix != "" {
+		ExplainStep(ctx, "ignored %d desired and %d actual tag(s) with prefix %q", len(dMap)-len(filteredDesired), len(aMap)-len(filteredActual), ignorePrefix)
+	}
+	ExplainStep(ctx, "compared %d desired and %d actual tag(s) key by key", len(filteredDesired), len(filteredActual))
 	details := compare.GenerateDetailedMapDiff(ctx, filteredDesired, filteredActual)
 	if ctx.Err() != nil {
 		return false, "", ctx.Err()
@@ -105,6 +115,7 @@ func CompareStringSlicesUnordered(ctx context.Context, desired, actual any, dExi
 	}
 
 	// Use the generic Set equality checker
+	ExplainStep(ctx, "compared as unordered sets of %d desired and %d actual item(s)", len(dSlice), len(aSlice))
 	isEqual, details := compare.Sets(dSlice, aSlice)
 	// Check context error immediately after potential blocking call
 	if ctx.Err() != nil {
@@ -113,31 +124,9 @@ func CompareStringSlicesUnordered(ctx context.Context, desired, actual any, dExi
 	return isEqual, details, nil // No specific error generation here, only conversion or context errors
 }
 
-// CompareJSONStrings adapts the generic pkgcompare.JSONStrings for the AttributeComparerFunc signature.
+// CompareJSONStrings adapts CompareJSONDocuments for the AttributeComparerFunc signature.
 func CompareJSONStrings(ctx context.Context, desired, actual any, dExists, aExists bool, fieldName string) (bool, string, error) {
-	if !dExists && !aExists {
-		return true, "", nil
-	}
-	if !dExists {
-		return false, fmt.Sprintf("%s missing in desired state", fieldName), nil
-	}
-	if !aExists {
-		return false, fmt.Sprintf("%s missing in actual state", fieldName), nil
-	}
-
-	ds, dOk := desired.(string)
-	as, aOk := actual.(string)
-	if !dOk || !aOk {
-		// Fallback uses RobustCompare which doesn't need context
-		return DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
-	}
-
-	// Pass context to generic JSON string equality checker
-	isEqual, details := compare.JSONStrings(ds, as)
-	if !isEqual && details == "" {
-		details = fmt.Sprintf("%s differ", fieldName)
-	}
-	return isEqual, details, nil
+	return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, fieldName)
 }
 
 // CompareSliceOfMapsUnordered compares slices of maps using a key, generating detailed drift diffs.
@@ -205,6 +194,7 @@ func CompareSliceOfMapsUnordered(ctx context.Context, desired, actual any, dExis
 		actualMap[keyValStr] = item
 	}
 
+	ExplainStep(ctx, "matched %d desired and %d actual %s item(s) by key %q", len(desiredMap), len(actualMap), itemType, keyField)
 	var diffMutex sync.Mutex
 	subDiffs := make(map[string]string) // Map to store diff details per key
 	g, childCtx := errgroup.WithContext(ctx)
@@ -305,3 +295,17 @@ func getStringKey(item map[string]any, keyField string) (string, bool) {
 	}
 	return keyValStr, true
 }
+
+// SkippedAttributes returns the set of attributes the platform adapter left
+// unfetched, as listed under domain.KeySkippedAttributes.
+func SkippedAttributes(actualAttrs map[string]any) map[string]bool {
+	names, _ := actualAttrs[domain.KeySkippedAttributes].([]string)
+	if len(names) == 0 {
+		return nil
+	}
+	skipped := make(map[string]bool, len(names))
+	for _, name := range names {
+		skipped[name] = true
+	}
+	return skipped
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// CompareAttribute runs compareFunc for one attribute and, in explain mode,
+// records which function decided it, how the values were present and the
+// resulting verdict. Comparers call it instead of invoking compareFunc directly.
+// The value normalization configured for the attribute is passed on to
+// compareFunc through the context.
+func CompareAttribute(
+	ctx context.Context,
+	attrKey string,
+	compareFunc AttributeComparerFunc,
+	desired, actual any,
+	dExists, aExists bool,
+) (bool, string, error) {
+	ctx = domain.WithAttributeNormalization(ctx, domain.NormalizationFor(ctx, attrKey))
+	x := domain.ExplanationFrom(ctx)
+	if x == nil {
+		return compareFunc(ctx, desired, actual, dExists, aExists)
+	}
+
+	x.StartAttribute(attrKey, funcName(compareFunc))
+	switch {
+	case !dExists && !aExists:
+		x.Step("attribute absent on both sides")
+	case !dExists:
+		x.Step("attribute absent in desired state")
+	case !aExists:
+		x.Step("attribute absent in actual state")
+	default:
+		x.Step("desired is %T, actual is %T", desired, actual)
+	}
+
+	isEqual, details, err := compareFunc(ctx, desired, actual, dExists, aExists)
+	reason := details
+	switch {
+	case err != nil:
+		reason = "comparison error: " + err.Error()
+	case isEqual && reason == "":
+		reason = "values considered equal"
+	}
+	x.FinishAttribute(isEqual && err == nil, reason)
+	return isEqual, details, err
+}
+
+// ExplainStep records a normalization step or decision in explain mode.
+func ExplainStep(ctx context.Context, format string, args ...any) {
+	domain.ExplanationFrom(ctx).Step(format, args...)
+}
+
+// funcName returns a short name for a compare function, e.g.
+// "BucketComparer.comparePolicy" or "helper.DefaultAttributeCompare".
+func funcName(fn AttributeComparerFunc) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	if parts := strings.Split(name, "."); len(parts) > 2 && strings.HasPrefix(parts[len(parts)-1], "func") {
+		// Closures returned by factories, e.g. compareSimpleBlockMap.func1.
+		name = strings.Join(parts[:len(parts)-1], ".")
+	}
+	if idx := strings.Index(name, "."); idx >= 0 && strings.Count(name, ".") > 1 {
+		name = name[idx+1:]
+	}
+	return name
+}
@@ -0,0 +1,165 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/pkg/compare"
+)
+
+// jsonDocumentAttributes are user-supplied JSON documents that are not named
+// with the _policy suffix.
+var jsonDocumentAttributes = map[string]struct{}{
+	"policy":                {},
+	"container_definitions": {},
+}
+
+// maxJSONChanges bounds the changes attached to one difference, so that a
+// rewritten document does not bloat the report.
+const maxJSONChanges = 100
+
+// IsJSONDocumentAttribute reports whether the attribute holds a user-supplied
+// JSON document (bucket/queue policies, assume_role_policy, ECS container
+// definitions) that must be compared in canonical form.
+func IsJSONDocumentAttribute(attrKey string) bool {
+	if _, ok := jsonDocumentAttributes[attrKey]; ok {
+		return true
+	}
+	return strings.HasSuffix(attrKey, "_policy") && !IsTLSAttribute(attrKey) && !IsResilienceAttribute(attrKey)
+}
+
+// CompareJSONDocuments compares two JSON documents ignoring cosmetic differences
+// (key order, whitespace, numeric formats such as 1 vs 1.0, unicode escapes).
+// Either side may be a JSON string or an already decoded value, since Terraform
+// stores documents as strings while some APIs return nested objects.
+func CompareJSONDocuments(ctx context.Context, desired, actual any, dExists, aExists bool, fieldName string) (bool, string, error) {
+	if ctx.Err() != nil {
+		return false, "", ctx.Err()
+	}
+	if !dExists && !aExists {
+		return true, "", nil
+	}
+	if !dExists {
+		return false, fmt.Sprintf("%s missing in desired state", fieldName), nil
+	}
+	if !aExists {
+		return false, fmt.Sprintf("%s missing in actual state", fieldName), nil
+	}
+
+	ExplainStep(ctx, "canonicalized both documents: sorted keys, dropped whitespace, normalized numbers and unicode escapes")
+	ds, dIsString := desired.(string)
+	as, aIsString := actual.(string)
+	if dIsString && aIsString {
+		isEqual, details := compare.JSONStrings(ds, as)
+		if !isEqual && details == "" {
+			details = fmt.Sprintf("%s differ", fieldName)
+		}
+		return isEqual, details, nil
+	}
+	if isEmptyDocument(desired) || isEmptyDocument(actual) {
+		return DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+
+	isEqual, details := compare.CanonicalJSONEqual(desired, actual)
+	if !isEqual && details == "" {
+		details = fmt.Sprintf("%s differ", fieldName)
+	}
+	return isEqual, details, nil
+}
+
+func isEmptyDocument(v any) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// ComparePolicyDocuments compares two IAM-style policy documents after
+// normalizing them (statement order, single values vs lists, principal formats,
+// condition value types), so that only changes IAM would evaluate differently
+// are reported. Documents that are not valid policies are compared as plain
+// JSON documents.
+func ComparePolicyDocuments(ctx context.Context, desired, actual any, dExists, aExists bool, fieldName string) (bool, string, error) {
+	if ctx.Err() != nil {
+		return false, "", ctx.Err()
+	}
+	if !dExists || !aExists || isEmptyDocument(desired) || isEmptyDocument(actual) {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, fieldName)
+	}
+	if _, err := compare.NormalizePolicy(desired); err != nil {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, fieldName)
+	}
+	if _, err := compare.NormalizePolicy(actual); err != nil {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, fieldName)
+	}
+
+	ExplainStep(ctx, "normalized both policies: sorted statements, actions, resources and principals; expanded account IDs to root ARNs; stringified condition values")
+	isEqual, details := compare.PolicyDocumentsEqual(desired, actual)
+	if !isEqual && details == "" {
+		details = fmt.Sprintf("%s differ", fieldName)
+	}
+	return isEqual, details, nil
+}
+
+// CompareContainerDefinitions compares two sets of ECS container definitions
+// after normalizing them (container and list order, empty values and the
+// defaults ECS fills in), so that only changes to the containers are
+// reported. Documents that are not valid container definitions are compared
+// as plain JSON documents.
+func CompareContainerDefinitions(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if ctx.Err() != nil {
+		return false, "", ctx.Err()
+	}
+	if !dExists || !aExists || isEmptyDocument(desired) || isEmptyDocument(actual) {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, "Container definitions")
+	}
+	if _, err := compare.NormalizeContainerDefinitions(desired); err != nil {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, "Container definitions")
+	}
+	if _, err := compare.NormalizeContainerDefinitions(actual); err != nil {
+		return CompareJSONDocuments(ctx, desired, actual, dExists, aExists, "Container definitions")
+	}
+
+	ExplainStep(ctx, "normalized both container definitions: sorted containers and lists, dropped empty values and defaults filled in by ECS")
+	isEqual, details := compare.ContainerDefinitionsEqual(desired, actual)
+	return isEqual, details, nil
+}
+
+// JSONDocumentChanges returns the paths at which two JSON document values
+// differ, after the normalization their comparison applies, so that the
+// changes match the drift details. It returns nil when either value is missing
+// or not a JSON document.
+func JSONDocumentChanges(attrKey string, desired, actual any) []domain.JSONChange {
+	if isEmptyDocument(desired) || isEmptyDocument(actual) {
+		return nil
+	}
+	if attrKey == "container_definitions" {
+		desiredDefs, errD := compare.NormalizeContainerDefinitions(desired)
+		actualDefs, errA := compare.NormalizeContainerDefinitions(actual)
+		if errD == nil && errA == nil {
+			desired, actual = desiredDefs, actualDefs
+		}
+	} else {
+		desiredPolicy, errD := compare.NormalizePolicy(desired)
+		actualPolicy, errA := compare.NormalizePolicy(actual)
+		if errD == nil && errA == nil {
+			desired, actual = desiredPolicy, actualPolicy
+		}
+	}
+
+	changes, err := compare.DiffJSON(desired, actual)
+	if err != nil || len(changes) == 0 {
+		return nil
+	}
+	if len(changes) > maxJSONChanges {
+		changes = changes[:maxJSONChanges]
+	}
+	out := make([]domain.JSONChange, len(changes))
+	for i, c := range changes {
+		out[i] = domain.JSONChange{Path: c.Path, Op: domain.JSONChangeOp(c.Op), Expected: c.Old, Actual: c.New}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// AttributeDiffFunc compares one attribute and returns its difference, or nil
+// when the attribute has not drifted.
+type AttributeDiffFunc func(ctx context.Context, attrKey string) (*domain.AttributeDiff, error)
+
+// CompareAttributes runs compareOne for every attribute and returns the
+// differences in attribute order. When the context allows it (see
+// domain.WithAttributeParallelism), attributes are compared by a pool of that
+// many workers, so one slow policy or lifecycle rule comparison does not hold
+// up the others; the first error cancels the comparisons still running.
+// Attributes are always compared one at a time in explain mode, whose trace
+// records a single attribute at a time. Differences in JSON document
+// attributes get the paths at which the documents differ as their Changes.
+func CompareAttributes(ctx context.Context, attributes []string, compareOne AttributeDiffFunc) ([]domain.AttributeDiff, error) {
+	compareOne = withJSONChanges(compareOne)
+	workers := domain.AttributeParallelismFrom(ctx)
+	if workers > len(attributes) {
+		workers = len(attributes)
+	}
+	if workers <= 1 || domain.ExplanationFrom(ctx) != nil {
+		return compareAttributesSequentially(ctx, attributes, compareOne)
+	}
+
+	results := make([]*domain.AttributeDiff, len(attributes))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	for i, attrKey := range attributes {
+		if gCtx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if gCtx.Err() != nil {
+				return gCtx.Err()
+			}
+			diff, err := compareOne(gCtx, attrKey)
+			if err != nil {
+				return err
+			}
+			results[i] = diff
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	diffs := make([]domain.AttributeDiff, 0)
+	for _, diff := range results {
+		if diff != nil {
+			diffs = append(diffs, *diff)
+		}
+	}
+	return diffs, nil
+}
+
+func compareAttributesSequentially(ctx context.Context, attributes []string, compareOne AttributeDiffFunc) ([]domain.AttributeDiff, error) {
+	diffs := make([]domain.AttributeDiff, 0)
+	for _, attrKey := range attributes {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		diff, err := compareOne(ctx, attrKey)
+		if err != nil {
+			return nil, err
+		}
+		if diff != nil {
+			diffs = append(diffs, *diff)
+		}
+	}
+	return diffs, nil
+}
+
+func withJSONChanges(compareOne AttributeDiffFunc) AttributeDiffFunc {
+	return func(ctx context.Context, attrKey string) (*domain.AttributeDiff, error) {
+		diff, err := compareOne(ctx, attrKey)
+		if err != nil || diff == nil || len(diff.Changes) > 0 || !IsJSONDocumentAttribute(diff.AttributeName) {
+			return diff, err
+		}
+		diff.Changes = JSONDocumentChanges(diff.AttributeName, diff.ExpectedValue, diff.ActualValue)
+		return diff, nil
+	}
+}
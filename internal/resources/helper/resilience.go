@@ -0,0 +1,169 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// resilienceAttributes are replication and backup settings. Together they form
+// the resilience attribute group: drift in them is reported as a warning, and as
+// critical when the platform is less protected than the desired state.
+var resilienceAttributes = map[string]struct{}{
+	domain.StorageBucketReplicationKey: {},
+	domain.StorageBucketObjectLockKey:  {},
+	domain.KeyBackupRetentionPeriod:    {},
+	domain.KeyBackupWindow:             {},
+	domain.KeyBackupPolicy:             {},
+	domain.KeyPointInTimeRecovery:      {},
+}
+
+// IsResilienceAttribute reports whether the attribute belongs to the resilience
+// attribute group (S3 replication and Object Lock, RDS automated backups, EFS
+// backup policy, DynamoDB point-in-time recovery).
+func IsResilienceAttribute(attrKey string) bool {
+	_, ok := resilienceAttributes[attrKey]
+	return ok
+}
+
+// ResilienceSeverity returns the severity of a resilience difference: critical
+// when the actual value removes or weakens protection the desired state asks
+// for (replication or backups disabled, shorter retention), warning otherwise.
+func ResilienceSeverity(desired, actual any) domain.Severity {
+	desired, actual = normalizeResilienceValue(desired), normalizeResilienceValue(actual)
+	if resilienceEnabled(desired) && !resilienceEnabled(actual) {
+		return domain.SeverityCritical
+	}
+	if d, ok := toFloat(desired); ok {
+		if a, ok := toFloat(actual); ok && a < d {
+			return domain.SeverityCritical
+		}
+	}
+	return domain.SeverityWarning
+}
+
+// CompareResilience compares replication and backup settings. Terraform blocks
+// held as single element lists are unwrapped, JSON documents held as strings are
+// decoded, and replication rules are matched by ID regardless of order.
+func CompareResilience(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !dExists && !aExists {
+		return true, "", nil
+	}
+	desired, actual = normalizeResilienceValue(desired), normalizeResilienceValue(actual)
+	ExplainStep(ctx, "unwrapped single element blocks and decoded JSON strings")
+
+	if !resilienceEnabled(desired) && !resilienceEnabled(actual) {
+		ExplainStep(ctx, "protection disabled on both sides")
+		return true, "", nil
+	}
+	if resilienceEnabled(desired) != resilienceEnabled(actual) {
+		return false, fmt.Sprintf("Protection differs (desired enabled: %t, actual enabled: %t)",
+			resilienceEnabled(desired), resilienceEnabled(actual)), nil
+	}
+
+	dMap, dIsMap := desired.(map[string]any)
+	aMap, aIsMap := actual.(map[string]any)
+	if !dIsMap || !aIsMap {
+		return DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+
+	dRules, dHasRules := dMap["rules"]
+	aRules, aHasRules := aMap["rules"]
+	if dHasRules || aHasRules {
+		ExplainStep(ctx, "matched replication rules by id")
+		isEqual, details, err := CompareSliceOfMapsUnordered(ctx, dRules, aRules, dHasRules, aHasRules, "id", "Replication Rule")
+		if err != nil || !isEqual {
+			return isEqual, details, err
+		}
+		dMap, aMap = withoutKey(dMap, "rules"), withoutKey(aMap, "rules")
+	}
+	return DefaultAttributeCompare(ctx, dMap, aMap, true, true)
+}
+
+// normalizeResilienceValue decodes JSON strings and unwraps Terraform blocks,
+// which state files hold as lists with a single map.
+func normalizeResilienceValue(v any) any {
+	if s, ok := v.(string); ok {
+		trimmed := strings.TrimSpace(s)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded any
+			if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+				v = decoded
+			}
+		}
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Slice && val.Len() == 1 {
+		if m, ok := val.Index(0).Interface().(map[string]any); ok {
+			return m
+		}
+	}
+	return v
+}
+
+// resilienceEnabled reports whether a normalized value turns protection on.
+func resilienceEnabled(v any) bool {
+	switch typed := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return typed
+	case string:
+		switch strings.ToLower(strings.TrimSpace(typed)) {
+		case "", "disabled", "disabling", "false":
+			return false
+		}
+		return true
+	case map[string]any:
+		if enabled, ok := typed["enabled"]; ok {
+			return resilienceEnabled(enabled)
+		}
+		if status, ok := typed["status"]; ok {
+			return resilienceEnabled(status)
+		}
+		if rules, ok := typed["rules"]; ok {
+			return resilienceEnabled(rules)
+		}
+		return len(typed) > 0
+	}
+	if n, ok := toFloat(v); ok {
+		return n > 0
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Slice {
+		for i := 0; i < val.Len(); i++ {
+			if resilienceEnabled(val.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func toFloat(v any) (float64, bool) {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), true
+	}
+	return 0, false
+}
+
+func withoutKey(m map[string]any, key string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// tlsAttributes are encryption-in-transit settings. Drift in them is a
+// compliance finding, so it is always reported as critical.
+var tlsAttributes = map[string]struct{}{
+	domain.KeySSLPolicy:                    {},
+	domain.KeyMinimumProtocolVersion:       {},
+	domain.StorageBucketSecureTransportKey: {},
+}
+
+// IsTLSAttribute reports whether the attribute holds a TLS / security policy setting.
+func IsTLSAttribute(attrKey string) bool {
+	_, ok := tlsAttributes[attrKey]
+	return ok
+}
+
+// SeverityForAttribute returns the severity comparers attach to a difference in
+// the attribute, or an empty severity to let the engine apply its default.
+func SeverityForAttribute(attrKey string) domain.Severity {
+	if IsTLSAttribute(attrKey) {
+		return domain.SeverityCritical
+	}
+	if IsResilienceAttribute(attrKey) {
+		return domain.SeverityWarning
+	}
+	return ""
+}
+
+// SeverityForDifference is SeverityForAttribute refined by the values: resilience
+// drift that weakens protection is escalated to critical.
+func SeverityForDifference(attrKey string, desired, actual any) domain.Severity {
+	if IsResilienceAttribute(attrKey) {
+		return ResilienceSeverity(desired, actual)
+	}
+	return SeverityForAttribute(attrKey)
+}
+
+// CompareTLSPolicy compares named TLS policies such as ELB security policies
+// ("ELBSecurityPolicy-TLS13-1-2-2021-06") or CloudFront minimum protocol
+// versions ("TLSv1.2_2021"). Names are compared exactly after trimming.
+func CompareTLSPolicy(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !dExists && !aExists {
+		return true, "", nil
+	}
+	ds, dOk := desired.(string)
+	as, aOk := actual.(string)
+	if !dOk || !aOk {
+		return DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	ds, as = strings.TrimSpace(ds), strings.TrimSpace(as)
+	ExplainStep(ctx, "compared TLS policy names exactly after trimming whitespace")
+	if ds == as {
+		return true, "", nil
+	}
+	return false, fmt.Sprintf("TLS policy differs (desired: %q, actual: %q)", ds, as), nil
+}
+
+// SecureTransportEnforced reports whether an IAM-style policy document denies
+// requests made over plain HTTP, i.e. has a Deny statement conditioned on
+// aws:SecureTransport being false. The policy may be a JSON string or a decoded map.
+func SecureTransportEnforced(policy any) (bool, error) {
+	var doc map[string]any
+	switch typed := policy.(type) {
+	case nil:
+		return false, nil
+	case string:
+		if strings.TrimSpace(typed) == "" {
+			return false, nil
+		}
+		if err := json.Unmarshal([]byte(typed), &doc); err != nil {
+			return false, errors.Wrap(err, errors.CodeComparisonError, "policy is not valid JSON")
+		}
+	case map[string]any:
+		doc = typed
+	default:
+		return false, errors.New(errors.CodeTypeAssertionError, fmt.Sprintf("unexpected policy type %T", policy))
+	}
+
+	var statements []any
+	switch st := doc["Statement"].(type) {
+	case []any:
+		statements = st
+	case map[string]any:
+		statements = []any{st}
+	}
+
+	for _, raw := range statements {
+		stmt, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if effect, _ := stmt["Effect"].(string); !strings.EqualFold(effect, "Deny") {
+			continue
+		}
+		conditions, _ := stmt["Condition"].(map[string]any)
+		for operator, block := range conditions {
+			if !strings.EqualFold(operator, "Bool") {
+				continue
+			}
+			keys, _ := block.(map[string]any)
+			for key, value := range keys {
+				if strings.EqualFold(key, "aws:SecureTransport") && isFalse(value) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func isFalse(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return !v
+	case string:
+		return strings.EqualFold(v, "false")
+	case []any:
+		for _, item := range v {
+			if isFalse(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
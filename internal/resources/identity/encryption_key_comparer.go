@@ -0,0 +1,118 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// encryptionKeyCriticalAttributes decide who can use a key and whether it can
+// still decrypt data, so drift in them is always reported as critical.
+var encryptionKeyCriticalAttributes = map[string]struct{}{
+	domain.EncryptionKeyPolicyKey:   {},
+	domain.EncryptionKeyEnabledKey:  {},
+	domain.EncryptionKeyRotationKey: {},
+}
+
+// EncryptionKeyComparer compares KMS keys. The key policy is normalized like
+// an IAM policy document and aliases are compared as a set.
+type EncryptionKeyComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewEncryptionKeyComparer returns the comparer for KMS keys.
+func NewEncryptionKeyComparer() *EncryptionKeyComparer {
+	c := &EncryptionKeyComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                        c.compareTags,
+		domain.EncryptionKeyPolicyKey:         c.comparePolicy,
+		domain.EncryptionKeyAliasesKey:        helper.CompareStringSlicesUnordered,
+		domain.EncryptionKeyDeletionWindowKey: c.compareDeletionWindow,
+	}
+	return c
+}
+
+func (c *EncryptionKeyComparer) Kind() domain.ResourceKind {
+	return domain.KindEncryptionKey
+}
+
+func (c *EncryptionKeyComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "encryption key compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	return helper.CompareAttributes(ctx, attributesToCheck, func(ctx context.Context, attrKey string) (*domain.AttributeDiff, error) {
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			return &domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      encryptionKeySeverityFor(attrKey),
+			}, nil
+		}
+
+		if !isEqual {
+			return &domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      encryptionKeySeverityFor(attrKey),
+			}, nil
+		}
+		return nil, nil
+	})
+}
+
+func encryptionKeySeverityFor(attrKey string) domain.Severity {
+	if _, ok := encryptionKeyCriticalAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *EncryptionKeyComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *EncryptionKeyComparer) comparePolicy(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.ComparePolicyDocuments(ctx, desired, actual, dExists, aExists, "Key policy")
+}
+
+// compareDeletionWindow only compares the deletion window of keys pending
+// deletion, the only keys AWS reports it for. A scheduled deletion itself
+// shows as drift in is_enabled.
+func (c *EncryptionKeyComparer) compareDeletionWindow(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !aExists {
+		helper.ExplainStep(ctx, "key is not pending deletion, so AWS does not report a deletion window")
+		return true, "", nil
+	}
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
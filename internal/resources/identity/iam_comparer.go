@@ -0,0 +1,188 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+// policyAttributes grant or restrict permissions. Drift in them is a compliance
+// finding, so it is always reported as critical.
+var policyAttributes = map[string]struct{}{
+	domain.IAMAssumeRolePolicyKey:    {},
+	domain.IAMInlinePoliciesKey:      {},
+	domain.IAMManagedPolicyARNsKey:   {},
+	domain.IAMPermissionsBoundaryKey: {},
+	domain.IAMPolicyDocumentKey:      {},
+}
+
+// IAMComparer compares IAM roles or customer managed policies. Policy
+// documents are normalized before diffing, so statement order, single values
+// vs lists and equivalent principal formats do not show as drift.
+type IAMComparer struct {
+	kind         domain.ResourceKind
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewRoleComparer returns the comparer for IAM roles.
+func NewRoleComparer() *IAMComparer {
+	return newIAMComparer(domain.KindIAMRole)
+}
+
+// NewPolicyComparer returns the comparer for customer managed IAM policies.
+func NewPolicyComparer() *IAMComparer {
+	return newIAMComparer(domain.KindIAMPolicy)
+}
+
+func newIAMComparer(kind domain.ResourceKind) *IAMComparer {
+	c := &IAMComparer{kind: kind}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                 c.compareTags,
+		domain.IAMAssumeRolePolicyKey:  c.comparePolicy,
+		domain.IAMPolicyDocumentKey:    c.comparePolicy,
+		domain.IAMInlinePoliciesKey:    c.compareInlinePolicies,
+		domain.IAMManagedPolicyARNsKey: c.compareManagedPolicyARNs,
+	}
+	return c
+}
+
+func (c *IAMComparer) Kind() domain.ResourceKind {
+	return c.kind
+}
+
+func (c *IAMComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "iam compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	return helper.CompareAttributes(ctx, attributesToCheck, func(ctx context.Context, attrKey string) (*domain.AttributeDiff, error) {
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			return &domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      severityFor(attrKey),
+			}, nil
+		}
+
+		if !isEqual {
+			return &domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      severityFor(attrKey),
+			}, nil
+		}
+		return nil, nil
+	})
+}
+
+func severityFor(attrKey string) domain.Severity {
+	if _, ok := policyAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *IAMComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *IAMComparer) comparePolicy(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.ComparePolicyDocuments(ctx, desired, actual, dExists, aExists, "Policy")
+}
+
+// compareManagedPolicyARNs compares the attached managed policies as a set. A
+// role without attachments equals an empty list.
+func (c *IAMComparer) compareManagedPolicyARNs(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareStringSlicesUnordered(ctx, desired, actual, dExists && !isEmptyList(desired), aExists && !isEmptyList(actual))
+}
+
+// compareInlinePolicies compares inline policies by name, normalizing each
+// document. The details name the missing, unexpected and changed policies.
+func (c *IAMComparer) compareInlinePolicies(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dMap, err := convert.ToStringMap(desired)
+	if err != nil {
+		return false, "Invalid type for desired inline policies", errors.Wrap(err, errors.CodeComparisonError, "desired inline policies not map[string]string")
+	}
+	aMap, err := convert.ToStringMap(actual)
+	if err != nil {
+		return false, "Invalid type for actual inline policies", errors.Wrap(err, errors.CodeComparisonError, "actual inline policies not map[string]string")
+	}
+
+	var missing, unexpected, changed []string
+	for name, dDoc := range dMap {
+		aDoc, ok := aMap[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		isEqual, details, err := helper.ComparePolicyDocuments(ctx, dDoc, aDoc, true, true, "Policy")
+		if err != nil {
+			return false, "", err
+		}
+		if !isEqual {
+			changed = append(changed, fmt.Sprintf("%s (%s)", name, details))
+		}
+	}
+	for name := range aMap {
+		if _, ok := dMap[name]; !ok {
+			unexpected = append(unexpected, name)
+		}
+	}
+	helper.ExplainStep(ctx, "compared %d desired and %d actual inline policies by name and normalized document", len(dMap), len(aMap))
+
+	var parts []string
+	for _, group := range []struct {
+		label string
+		names []string
+	}{{"missing", missing}, {"unexpected", unexpected}, {"changed", changed}} {
+		if len(group.names) > 0 {
+			sort.Strings(group.names)
+			parts = append(parts, fmt.Sprintf("%s: %s", group.label, strings.Join(group.names, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return true, "", nil
+	}
+	return false, "Inline policies differ (" + strings.Join(parts, "; ") + ")", nil
+}
+
+func isEmptyList(v any) bool {
+	if v == nil {
+		return true
+	}
+	list, err := convert.ToSliceOfString(v)
+	return err == nil && len(list) == 0
+}
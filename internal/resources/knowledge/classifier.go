@@ -0,0 +1,172 @@
+package knowledge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+// Config tunes which differences are recognized as changes AWS made on its own.
+type Config struct {
+	// Disabled reports every difference as actionable drift.
+	Disabled bool `yaml:"disabled" mapstructure:"disabled"`
+	// ManagedTagPrefixes adds tag key prefixes written by platform services,
+	// mapped to the service named in the findings, e.g. "karpenter.sh/": "Karpenter".
+	ManagedTagPrefixes map[string]string `yaml:"managed_tag_prefixes" mapstructure:"managed_tag_prefixes"`
+}
+
+// tagPrefix is a tag key prefix written by a platform service.
+type tagPrefix struct {
+	prefix  string
+	manager string
+}
+
+// defaultTagPrefixes lists the tag prefixes of AWS services that tag resources
+// they create or manage. The generic "aws:" prefix comes last so that the
+// specific services are named first.
+var defaultTagPrefixes = []tagPrefix{
+	{prefix: "aws:backup:", manager: "AWS Backup"},
+	{prefix: "aws:cloudformation:", manager: "CloudFormation"},
+	{prefix: "aws:autoscaling:", manager: "EC2 Auto Scaling"},
+	{prefix: "aws:ecs:", manager: "Amazon ECS"},
+	{prefix: "AmazonECSManaged", manager: "Amazon ECS"},
+	{prefix: "elasticbeanstalk:", manager: "Elastic Beanstalk"},
+	{prefix: "kubernetes.io/cluster/", manager: "Amazon EKS"},
+	{prefix: "aws:", manager: "AWS"},
+}
+
+// capacityAttributes are adjusted by autoscaling policies at runtime.
+var capacityAttributes = map[string]bool{
+	"desired_count":    true,
+	"desired_capacity": true,
+}
+
+// Classifier recognizes differences caused by AWS-initiated changes:
+// certificate renewals that rotate ARNs, autoscaling that changes the desired
+// capacity and tags that AWS services add to the resources they manage.
+type Classifier struct {
+	tagPrefixes []tagPrefix
+}
+
+// NewClassifier returns the classifier for the configured tag prefixes.
+func NewClassifier(cfg Config) *Classifier {
+	extra := make([]tagPrefix, 0, len(cfg.ManagedTagPrefixes))
+	for prefix, manager := range cfg.ManagedTagPrefixes {
+		if prefix == "" {
+			continue
+		}
+		if manager == "" {
+			manager = "platform"
+		}
+		extra = append(extra, tagPrefix{prefix: prefix, manager: manager})
+	}
+	// Longer prefixes first, so the most specific configured prefix wins.
+	sort.Slice(extra, func(i, j int) bool {
+		if len(extra[i].prefix) != len(extra[j].prefix) {
+			return len(extra[i].prefix) > len(extra[j].prefix)
+		}
+		return extra[i].prefix < extra[j].prefix
+	})
+	return &Classifier{tagPrefixes: append(extra, defaultTagPrefixes...)}
+}
+
+// PlatformManaged returns why the difference was caused by AWS, or an empty
+// string when it is actionable drift.
+func (c *Classifier) PlatformManaged(kind domain.ResourceKind, diff domain.AttributeDiff) string {
+	switch {
+	case diff.AttributeName == domain.KeyTags:
+		return c.platformTags(diff.ExpectedValue, diff.ActualValue)
+	case capacityAttributes[diff.AttributeName]:
+		return fmt.Sprintf("%s is adjusted by autoscaling", diff.AttributeName)
+	case strings.Contains(diff.AttributeName, "certificate_arn"):
+		return certificateRotation(diff.ExpectedValue, diff.ActualValue)
+	}
+	return ""
+}
+
+// platformTags recognizes tag differences made only of tags that platform
+// services added. Any tag removed or changed from the desired state is drift.
+func (c *Classifier) platformTags(desired, actual any) string {
+	dMap, err := convert.ToStringMap(desired)
+	if err != nil {
+		return ""
+	}
+	aMap, err := convert.ToStringMap(actual)
+	if err != nil {
+		return ""
+	}
+
+	managers := make(map[string]bool)
+	for key, dVal := range dMap {
+		if aVal, ok := aMap[key]; !ok || aVal != dVal {
+			return ""
+		}
+	}
+	for key := range aMap {
+		if _, ok := dMap[key]; ok {
+			continue
+		}
+		manager := c.tagManager(key)
+		if manager == "" {
+			return ""
+		}
+		managers[manager] = true
+	}
+	if len(managers) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(managers))
+	for manager := range managers {
+		names = append(names, manager)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("tags added by %s", strings.Join(names, ", "))
+}
+
+func (c *Classifier) tagManager(key string) string {
+	for _, p := range c.tagPrefixes {
+		if strings.HasPrefix(key, p.prefix) {
+			return p.manager
+		}
+	}
+	return ""
+}
+
+// certificateRotation recognizes an ARN replaced by another certificate of the
+// same service, account and region, as happens when a certificate is renewed.
+func certificateRotation(desired, actual any) string {
+	dARN, dOK := desired.(string)
+	aARN, aOK := actual.(string)
+	if !dOK || !aOK || dARN == aARN {
+		return ""
+	}
+	dScope, dService, dOK := certificateScope(dARN)
+	aScope, _, aOK := certificateScope(aARN)
+	if !dOK || !aOK || dScope != aScope {
+		return ""
+	}
+	return fmt.Sprintf("certificate rotated by %s renewal", dService)
+}
+
+// certificateScope returns the partition, service, region and account of an
+// ACM certificate or IAM server certificate ARN.
+func certificateScope(arn string) (string, string, bool) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", "", false
+	}
+	var service string
+	switch {
+	case parts[2] == "acm" && strings.HasPrefix(parts[5], "certificate/"):
+		service = "ACM"
+	case parts[2] == "iam" && strings.HasPrefix(parts[5], "server-certificate/"):
+		service = "IAM"
+	default:
+		return "", "", false
+	}
+	return strings.Join(parts[:5], ":"), service, true
+}
@@ -0,0 +1,206 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	"github.com/olusolaa/infra-drift-detector/pkg/compare"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+// distributionCriticalAttributes control who can reach a distribution and how
+// viewers are served, so drift in them is always reported as critical.
+var distributionCriticalAttributes = map[string]struct{}{
+	domain.DistributionViewerCertificateKey: {},
+	domain.DistributionWebACLIDKey:          {},
+	domain.DistributionGeoRestrictionKey:    {},
+}
+
+// DistributionComparer compares CloudFront distributions. Origins, origin
+// groups and custom error responses are matched by key regardless of order,
+// while ordered cache behaviors are compared by position, since their order
+// is their precedence.
+type DistributionComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewDistributionComparer returns the comparer for CloudFront distributions.
+func NewDistributionComparer() *DistributionComparer {
+	c := &DistributionComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                              c.compareTags,
+		domain.DistributionAliasesKey:               helper.CompareStringSlicesUnordered,
+		domain.DistributionOriginsKey:               c.compareOrigins,
+		domain.DistributionOriginGroupsKey:          c.compareOriginGroups,
+		domain.DistributionCustomErrorResponsesKey:  c.compareErrorResponses,
+		domain.DistributionDefaultCacheBehaviorKey:  compareBlock,
+		domain.DistributionViewerCertificateKey:     compareBlock,
+		domain.DistributionLoggingKey:               compareBlock,
+		domain.DistributionGeoRestrictionKey:        compareBlock,
+		domain.DistributionOrderedCacheBehaviorsKey: c.compareOrderedCacheBehaviors,
+		domain.KeyMinimumProtocolVersion:            helper.CompareTLSPolicy,
+	}
+	return c
+}
+
+func (c *DistributionComparer) Kind() domain.ResourceKind {
+	return domain.KindCDNDistribution
+}
+
+func (c *DistributionComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "distribution compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      distributionSeverityFor(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      distributionSeverityFor(attrKey),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func distributionSeverityFor(attrKey string) domain.Severity {
+	if _, ok := distributionCriticalAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *DistributionComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+func (c *DistributionComparer) compareOrigins(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "origin_id", "origin")
+}
+
+func (c *DistributionComparer) compareOriginGroups(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "origin_id", "origin group")
+}
+
+func (c *DistributionComparer) compareErrorResponses(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "error_code", "custom error response")
+}
+
+// compareOrderedCacheBehaviors compares the cache behaviors position by
+// position. When both sides hold the same path patterns in a different order
+// the details report the changed precedence rather than every field.
+func (c *DistributionComparer) compareOrderedCacheBehaviors(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !dExists && !aExists {
+		return true, "", nil
+	}
+	desiredBehaviors, err := toBehaviorList(desired, dExists)
+	if err != nil {
+		return false, "Invalid desired cache behaviors", errors.Wrap(err, errors.CodeComparisonError, "desired cache behaviors not a list of maps")
+	}
+	actualBehaviors, err := toBehaviorList(actual, aExists)
+	if err != nil {
+		return false, "Invalid actual cache behaviors", errors.Wrap(err, errors.CodeComparisonError, "actual cache behaviors not a list of maps")
+	}
+
+	desiredPatterns, actualPatterns := pathPatterns(desiredBehaviors), pathPatterns(actualBehaviors)
+	helper.ExplainStep(ctx, "compared %d desired and %d actual cache behaviors in precedence order", len(desiredBehaviors), len(actualBehaviors))
+	if strings.Join(desiredPatterns, "\x00") != strings.Join(actualPatterns, "\x00") {
+		if sameSet, _ := compare.Sets(desiredPatterns, actualPatterns); sameSet && len(desiredPatterns) == len(actualPatterns) {
+			return false, fmt.Sprintf("Cache behavior precedence differs: desired [%s], actual [%s]", strings.Join(desiredPatterns, ", "), strings.Join(actualPatterns, ", ")), nil
+		}
+		return false, fmt.Sprintf("Cache behavior path patterns differ: desired [%s], actual [%s]", strings.Join(desiredPatterns, ", "), strings.Join(actualPatterns, ", ")), nil
+	}
+
+	var parts []string
+	for i := range desiredBehaviors {
+		if ctx.Err() != nil {
+			return false, "", ctx.Err()
+		}
+		if detail := compare.GenerateDetailedMapDiff(ctx, desiredBehaviors[i], actualBehaviors[i]); detail != "" {
+			parts = append(parts, fmt.Sprintf("cache behavior '%s' differs: %s", desiredPatterns[i], detail))
+		}
+	}
+	if len(parts) == 0 {
+		return true, "", nil
+	}
+	return false, strings.Join(parts, "; "), nil
+}
+
+func toBehaviorList(value any, exists bool) ([]map[string]any, error) {
+	if !exists || value == nil {
+		return nil, nil
+	}
+	return convert.ToSliceOfMap(value)
+}
+
+func pathPatterns(behaviors []map[string]any) []string {
+	patterns := make([]string, 0, len(behaviors))
+	for _, behavior := range behaviors {
+		patterns = append(patterns, fmt.Sprint(behavior["path_pattern"]))
+	}
+	return patterns
+}
+
+// compareBlock compares a single nested block field by field, naming the
+// fields that differ. Values other than maps fall back to the default comparison.
+func compareBlock(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dMap, dOk := desired.(map[string]any)
+	aMap, aOk := actual.(map[string]any)
+	if (dExists && !dOk) || (aExists && !aOk) {
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	helper.ExplainStep(ctx, "compared the block field by field")
+	details := compare.GenerateDetailedMapDiff(ctx, dMap, aMap)
+	if ctx.Err() != nil {
+		return false, "", ctx.Err()
+	}
+	return details == "", details, nil
+}
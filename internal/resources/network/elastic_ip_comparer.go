@@ -0,0 +1,130 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// elasticIPAssociationKeys are the attributes naming what an address is
+// associated with.
+var elasticIPAssociationKeys = map[string]struct{}{
+	domain.ElasticIPInstanceKey:         {},
+	domain.ElasticIPNetworkInterfaceKey: {},
+	domain.ElasticIPPrivateIPKey:        {},
+}
+
+// ElasticIPComparer compares Elastic IP addresses. An address whose
+// association is gone or points elsewhere is critical drift: traffic to the
+// public IP no longer reaches the desired instance or interface.
+type ElasticIPComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewElasticIPComparer returns the comparer for Elastic IP addresses.
+func NewElasticIPComparer() *ElasticIPComparer {
+	c := &ElasticIPComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags: c.compareTags,
+	}
+	return c
+}
+
+func (c *ElasticIPComparer) Kind() domain.ResourceKind {
+	return domain.KindElasticIP
+}
+
+func (c *ElasticIPComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "Elastic IP compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diff := domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+			}
+			if _, ok := elasticIPAssociationKeys[attrKey]; ok {
+				diff.Details, diff.Severity = associationDrift("Elastic IP association", desiredVal, actualVal)
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *ElasticIPComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// associationDrift describes drift in an association or attachment. Losing
+// or moving a desired association is critical; one made outside the desired
+// state is a warning.
+func associationDrift(what string, desired, actual any) (string, domain.Severity) {
+	switch {
+	case isUnset(desired):
+		return what + " added outside the desired state", domain.SeverityWarning
+	case isUnset(actual):
+		return what + " removed: the resource is no longer associated", domain.SeverityCritical
+	default:
+		return fmt.Sprintf("%s moved from %v to %v", what, desired, actual), domain.SeverityCritical
+	}
+}
+
+func isUnset(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case map[string]any:
+		return len(value) == 0
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	"github.com/olusolaa/infra-drift-detector/pkg/compare"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+// loadBalancerCriticalAttributes decide who can reach a load balancer and
+// where its traffic goes, so drift in them is always reported as critical.
+var loadBalancerCriticalAttributes = map[string]struct{}{
+	domain.LoadBalancerInternalKey:       {},
+	domain.LoadBalancerSecurityGroupsKey: {},
+	domain.ListenerCertificateARNKey:     {},
+	domain.ListenerDefaultActionsKey:     {},
+	domain.ListenerRulesKey:              {},
+}
+
+// LoadBalancerComparer compares ELBv2 load balancers, listeners or target
+// groups. Listener rules are matched by priority regardless of order, while
+// actions are compared in their order of execution.
+type LoadBalancerComparer struct {
+	kind         domain.ResourceKind
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewLoadBalancerComparer returns the comparer for ELBv2 load balancers.
+func NewLoadBalancerComparer() *LoadBalancerComparer {
+	return newLoadBalancerComparer(domain.KindLoadBalancer)
+}
+
+// NewListenerComparer returns the comparer for ELBv2 listeners.
+func NewListenerComparer() *LoadBalancerComparer {
+	return newLoadBalancerComparer(domain.KindLoadBalancerListener)
+}
+
+// NewTargetGroupComparer returns the comparer for ELBv2 target groups.
+func NewTargetGroupComparer() *LoadBalancerComparer {
+	return newLoadBalancerComparer(domain.KindLoadBalancerTargetGroup)
+}
+
+func newLoadBalancerComparer(kind domain.ResourceKind) *LoadBalancerComparer {
+	c := &LoadBalancerComparer{kind: kind}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:      c.compareTags,
+		domain.KeySSLPolicy: helper.CompareTLSPolicy,
+	}
+	switch kind {
+	case domain.KindLoadBalancer:
+		c.compareFuncs[domain.LoadBalancerSecurityGroupsKey] = helper.CompareStringSlicesUnordered
+		c.compareFuncs[domain.LoadBalancerSubnetsKey] = helper.CompareStringSlicesUnordered
+		c.compareFuncs[domain.LoadBalancerAccessLogsKey] = compareBlock
+	case domain.KindLoadBalancerListener:
+		c.compareFuncs[domain.ListenerDefaultActionsKey] = c.compareActions
+		c.compareFuncs[domain.ListenerRulesKey] = c.compareRules
+	case domain.KindLoadBalancerTargetGroup:
+		c.compareFuncs[domain.TargetGroupHealthCheckKey] = compareBlock
+		c.compareFuncs[domain.TargetGroupStickinessKey] = compareBlock
+	}
+	return c
+}
+
+func (c *LoadBalancerComparer) Kind() domain.ResourceKind {
+	return c.kind
+}
+
+func (c *LoadBalancerComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "load balancer compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      loadBalancerSeverityFor(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      loadBalancerSeverityFor(attrKey),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func loadBalancerSeverityFor(attrKey string) domain.Severity {
+	if _, ok := loadBalancerCriticalAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *LoadBalancerComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// compareRules matches listener rules by priority, so rules created in a
+// different order but with the same priorities do not show as drift.
+func (c *LoadBalancerComparer) compareRules(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareSliceOfMapsUnordered(ctx, desired, actual, dExists, aExists, "priority", "listener rule")
+}
+
+// compareActions compares actions position by position, as they run in that
+// order. When the action types differ the details list both sequences rather
+// than every field.
+func (c *LoadBalancerComparer) compareActions(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !dExists && !aExists {
+		return true, "", nil
+	}
+	desiredActions, err := toActionList(desired, dExists)
+	if err != nil {
+		return false, "Invalid desired actions", errors.Wrap(err, errors.CodeComparisonError, "desired actions not a list of maps")
+	}
+	actualActions, err := toActionList(actual, aExists)
+	if err != nil {
+		return false, "Invalid actual actions", errors.Wrap(err, errors.CodeComparisonError, "actual actions not a list of maps")
+	}
+
+	desiredTypes, actualTypes := actionTypes(desiredActions), actionTypes(actualActions)
+	helper.ExplainStep(ctx, "compared %d desired and %d actual actions in order of execution", len(desiredActions), len(actualActions))
+	if strings.Join(desiredTypes, "\x00") != strings.Join(actualTypes, "\x00") {
+		return false, fmt.Sprintf("Action types differ: desired [%s], actual [%s]", strings.Join(desiredTypes, ", "), strings.Join(actualTypes, ", ")), nil
+	}
+
+	var parts []string
+	for i := range desiredActions {
+		if ctx.Err() != nil {
+			return false, "", ctx.Err()
+		}
+		if detail := compare.GenerateDetailedMapDiff(ctx, desiredActions[i], actualActions[i]); detail != "" {
+			parts = append(parts, fmt.Sprintf("action %d (%s) differs: %s", i+1, desiredTypes[i], detail))
+		}
+	}
+	if len(parts) == 0 {
+		return true, "", nil
+	}
+	return false, strings.Join(parts, "; "), nil
+}
+
+func toActionList(value any, exists bool) ([]map[string]any, error) {
+	if !exists || value == nil {
+		return nil, nil
+	}
+	return convert.ToSliceOfMap(value)
+}
+
+func actionTypes(actions []map[string]any) []string {
+	types := make([]string, 0, len(actions))
+	for _, action := range actions {
+		types = append(types, fmt.Sprint(action["type"]))
+	}
+	return types
+}
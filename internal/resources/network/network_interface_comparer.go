@@ -0,0 +1,102 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+)
+
+// NetworkInterfaceComparer compares EC2 network interfaces. Private IPs and
+// security groups are compared as sets. A desired attachment that is gone or
+// moved to another instance is critical drift, as is a changed source/dest
+// check, which breaks NAT and routing appliances relying on it.
+type NetworkInterfaceComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewNetworkInterfaceComparer returns the comparer for network interfaces.
+func NewNetworkInterfaceComparer() *NetworkInterfaceComparer {
+	c := &NetworkInterfaceComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                           c.compareTags,
+		domain.NetworkInterfacePrivateIPsKey:     helper.CompareStringSlicesUnordered,
+		domain.NetworkInterfaceSecurityGroupsKey: helper.CompareStringSlicesUnordered,
+	}
+	return c
+}
+
+func (c *NetworkInterfaceComparer) Kind() domain.ResourceKind {
+	return domain.KindNetworkInterface
+}
+
+func (c *NetworkInterfaceComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "network interface compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diff := domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+			}
+			switch attrKey {
+			case domain.NetworkInterfaceAttachmentKey:
+				diff.Details, diff.Severity = associationDrift("Network interface attachment", desiredVal, actualVal)
+			case domain.NetworkInterfaceSourceDestCheckKey:
+				diff.Severity = domain.SeverityCritical
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *NetworkInterfaceComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
@@ -0,0 +1,239 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+// ruleAttributes decide what traffic reaches the group's members, so drift in
+// them is always reported as critical.
+var ruleAttributes = map[string]struct{}{
+	domain.SecurityGroupIngressKey: {},
+	domain.SecurityGroupEgressKey:  {},
+}
+
+// protocolNames maps the protocol numbers EC2 and Terraform accept to the names
+// they are otherwise written with.
+var protocolNames = map[string]string{
+	"-1": "all",
+	"6":  "tcp",
+	"17": "udp",
+	"1":  "icmp",
+	"58": "icmpv6",
+}
+
+// SecurityGroupComparer compares EC2 security groups. Ingress and egress rules
+// are compared as unordered sets of canonical rules, so rule order, protocol
+// numbers vs names and unmasked CIDR blocks do not show as drift.
+type SecurityGroupComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+// NewSecurityGroupComparer returns the comparer for EC2 security groups.
+func NewSecurityGroupComparer() *SecurityGroupComparer {
+	c := &SecurityGroupComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                 c.compareTags,
+		domain.SecurityGroupIngressKey: c.compareRules,
+		domain.SecurityGroupEgressKey:  c.compareRules,
+	}
+	return c
+}
+
+func (c *SecurityGroupComparer) Kind() domain.ResourceKind {
+	return domain.KindNetworkSecurityGroup
+}
+
+func (c *SecurityGroupComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "security group compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      severityFor(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      severityFor(attrKey),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func severityFor(attrKey string) domain.Severity {
+	if _, ok := ruleAttributes[attrKey]; ok {
+		return domain.SeverityCritical
+	}
+	return helper.SeverityForAttribute(attrKey)
+}
+
+func (c *SecurityGroupComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// compareRules compares flattened rules as sets. A group without rules equals
+// an empty list, and the details name the rules only present on one side.
+func (c *SecurityGroupComparer) compareRules(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	desiredRules, err := canonicalRules(desired)
+	if err != nil {
+		return false, "Invalid desired rules", errors.Wrap(err, errors.CodeComparisonError, "desired rules not a list of rule maps")
+	}
+	actualRules, err := canonicalRules(actual)
+	if err != nil {
+		return false, "Invalid actual rules", errors.Wrap(err, errors.CodeComparisonError, "actual rules not a list of rule maps")
+	}
+	helper.ExplainStep(ctx, "compared %d desired and %d actual rules as sets of canonical rules", len(desiredRules), len(actualRules))
+
+	var missing, unexpected []string
+	for rule := range desiredRules {
+		if _, ok := actualRules[rule]; !ok {
+			missing = append(missing, rule)
+		}
+	}
+	for rule := range actualRules {
+		if _, ok := desiredRules[rule]; !ok {
+			unexpected = append(unexpected, rule)
+		}
+	}
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return true, "", nil
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		parts = append(parts, fmt.Sprintf("rules only in desired: %s", strings.Join(missing, ", ")))
+	}
+	if len(unexpected) > 0 {
+		sort.Strings(unexpected)
+		parts = append(parts, fmt.Sprintf("rules only in actual: %s", strings.Join(unexpected, ", ")))
+	}
+	return false, "Rules differ: " + strings.Join(parts, "; "), nil
+}
+
+// canonicalRules returns the set of rules written as "protocol ports source",
+// e.g. "tcp 443 cidr_block=10.0.0.0/16".
+func canonicalRules(value any) (map[string]struct{}, error) {
+	rules, err := convert.ToSliceOfMap(value)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		set[canonicalRule(rule)] = struct{}{}
+	}
+	return set, nil
+}
+
+func canonicalRule(rule map[string]any) string {
+	protocol := strings.ToLower(fmt.Sprint(rule["protocol"]))
+	if name, ok := protocolNames[protocol]; ok {
+		protocol = name
+	}
+
+	// Ports do not apply to rules covering all protocols; EC2 omits them and
+	// Terraform writes 0.
+	ports := ""
+	if protocol != "all" {
+		from, to := portString(rule["from_port"]), portString(rule["to_port"])
+		ports = from
+		if from != to {
+			ports = from + "-" + to
+		}
+	}
+
+	source := "unknown"
+	switch {
+	case rule["cidr_block"] != nil:
+		source = "cidr_block=" + canonicalCIDR(fmt.Sprint(rule["cidr_block"]))
+	case rule["ipv6_cidr_block"] != nil:
+		source = "ipv6_cidr_block=" + canonicalCIDR(fmt.Sprint(rule["ipv6_cidr_block"]))
+	case rule["prefix_list_id"] != nil:
+		source = "prefix_list_id=" + fmt.Sprint(rule["prefix_list_id"])
+	case rule["security_group"] != nil:
+		source = "security_group=" + fmt.Sprint(rule["security_group"])
+	case rule["self"] == true:
+		source = "self"
+	}
+
+	return strings.Join(strings.Fields(protocol+" "+ports+" "+source), " ")
+}
+
+func portString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "0"
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// canonicalCIDR masks the host bits of a block, so 10.0.0.1/16 and
+// 10.0.0.0/16 are the same source. Unparseable values are kept as written.
+func canonicalCIDR(cidr string) string {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return cidr
+	}
+	return network.String()
+}
@@ -0,0 +1,173 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	"github.com/olusolaa/infra-drift-detector/pkg/convert"
+)
+
+type LambdaComparer struct {
+	compareFuncs map[string]helper.AttributeComparerFunc
+}
+
+func NewLambdaComparer() *LambdaComparer {
+	c := &LambdaComparer{}
+	c.compareFuncs = map[string]helper.AttributeComparerFunc{
+		domain.KeyTags:                  c.compareTags,
+		domain.FunctionArchitecturesKey: helper.CompareStringSlicesUnordered,
+		domain.FunctionEnvironmentKey:   c.compareEnvironment,
+		domain.FunctionVPCConfigKey:     c.compareVPCConfig,
+	}
+	return c
+}
+
+func (c *LambdaComparer) Kind() domain.ResourceKind {
+	return domain.KindServerlessFunction
+}
+
+func (c *LambdaComparer) Compare(
+	ctx context.Context,
+	desired domain.StateResource,
+	actual domain.PlatformResource,
+	attributesToCheck []string,
+) ([]domain.AttributeDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New(errors.CodeInternal, "lambda compare called with nil desired or actual resource")
+	}
+
+	desiredAttrs := desired.Attributes()
+	actualAttrs, err := actual.Attributes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
+	}
+	diffs := make([]domain.AttributeDiff, 0)
+
+	for _, attrKey := range attributesToCheck {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		desiredVal, dExists := desiredAttrs[attrKey]
+		actualVal, aExists := actualAttrs[attrKey]
+
+		compareFunc, ok := c.compareFuncs[attrKey]
+		if !ok {
+			compareFunc = helper.DefaultAttributeCompare
+		}
+		isEqual, details, compareErr := helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if compareErr != nil {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity:      helper.SeverityForAttribute(attrKey),
+			})
+			continue
+		}
+
+		if !isEqual {
+			diffs = append(diffs, domain.AttributeDiff{
+				AttributeName: attrKey,
+				ExpectedValue: desiredVal,
+				ActualValue:   actualVal,
+				Details:       details,
+				Severity:      helper.SeverityForDifference(attrKey, desiredVal, actualVal),
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func (c *LambdaComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareTags(ctx, desired, actual, dExists, aExists, "aws:")
+}
+
+// compareEnvironment compares environment variables key by key. Variables often
+// hold credentials, so the details name the differing variables without their
+// values.
+func (c *LambdaComparer) compareEnvironment(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dMap, err := convert.ToStringMap(desired)
+	if err != nil {
+		return false, "Invalid type for desired environment", errors.Wrap(err, errors.CodeComparisonError, "desired environment not map[string]string")
+	}
+	aMap, err := convert.ToStringMap(actual)
+	if err != nil {
+		return false, "Invalid type for actual environment", errors.Wrap(err, errors.CodeComparisonError, "actual environment not map[string]string")
+	}
+
+	var missing, unexpected, changed []string
+	for key, dVal := range dMap {
+		aVal, ok := aMap[key]
+		switch {
+		case !ok:
+			missing = append(missing, key)
+		case aVal != dVal:
+			changed = append(changed, key)
+		}
+	}
+	for key := range aMap {
+		if _, ok := dMap[key]; !ok {
+			unexpected = append(unexpected, key)
+		}
+	}
+	helper.ExplainStep(ctx, "compared %d desired and %d actual environment variable(s) by name and value", len(dMap), len(aMap))
+
+	var parts []string
+	for _, group := range []struct {
+		label string
+		keys  []string
+	}{{"missing", missing}, {"unexpected", unexpected}, {"changed", changed}} {
+		if len(group.keys) > 0 {
+			sort.Strings(group.keys)
+			parts = append(parts, fmt.Sprintf("%s: %s", group.label, strings.Join(group.keys, ", ")))
+		}
+	}
+	if len(parts) == 0 {
+		return true, "", nil
+	}
+	return false, "Environment variables differ (" + strings.Join(parts, "; ") + ")", nil
+}
+
+// compareVPCConfig compares the subnets and security groups of the VPC
+// attachment as unordered sets. A missing attachment equals an empty one.
+func (c *LambdaComparer) compareVPCConfig(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	dMap, _ := desired.(map[string]any)
+	aMap, _ := actual.(map[string]any)
+	if (!dExists || desired == nil) && (!aExists || actual == nil) {
+		return true, "", nil
+	}
+
+	var details []string
+	for _, key := range []string{"subnet_ids", "security_group_ids"} {
+		dVal, aVal := dMap[key], aMap[key]
+		isEqual, detail, err := helper.CompareStringSlicesUnordered(ctx, dVal, aVal, !isEmptyList(dVal), !isEmptyList(aVal))
+		if err != nil {
+			return false, detail, err
+		}
+		if !isEqual {
+			details = append(details, fmt.Sprintf("%s: %s", key, detail))
+		}
+	}
+	if len(details) == 0 {
+		return true, "", nil
+	}
+	return false, strings.Join(details, "; "), nil
+}
+
+func isEmptyList(v any) bool {
+	list, err := convert.ToSliceOfString(v)
+	return err == nil && len(list) == 0
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
 	"github.com/olusolaa/infra-drift-detector/internal/errors"
@@ -20,15 +21,25 @@ type BucketComparer struct {
 func NewBucketComparer() *BucketComparer {
 	c := &BucketComparer{}
 	c.compareFuncs = map[string]helper.AttributeComparerFunc{
-		domain.KeyTags:                        c.compareTags,
-		domain.StorageBucketACLKey:            c.compareACLGransts,
-		domain.StorageBucketLifecycleRulesKey: c.compareLifecycleRules,
-		domain.StorageBucketCorsRulesKey:      c.compareCorsRules,
-		domain.StorageBucketPolicyKey:         c.comparePolicy,
-		domain.StorageBucketLoggingKey:        c.compareSimpleBlockMap("Logging"),
-		domain.StorageBucketWebsiteKey:        c.compareSimpleBlockMap("Website"),
-		domain.StorageBucketEncryptionKey:     c.compareEncryption,
-		domain.StorageBucketVersioningKey:     helper.DefaultAttributeCompare, // Bool comparison is fine
+		domain.KeyTags:                            c.compareTags,
+		domain.StorageBucketACLKey:                c.compareACLGransts,
+		domain.StorageBucketLifecycleRulesKey:     c.compareLifecycleRules,
+		domain.StorageBucketCorsRulesKey:          c.compareCorsRules,
+		domain.StorageBucketPolicyKey:             c.comparePolicy,
+		domain.StorageBucketLoggingKey:            c.compareSimpleBlockMap("Logging"),
+		domain.StorageBucketWebsiteKey:            c.compareSimpleBlockMap("Website"),
+		domain.StorageBucketEncryptionKey:         c.compareEncryption,
+		domain.StorageBucketVersioningKey:         helper.DefaultAttributeCompare, // Bool comparison is fine
+		domain.StorageBucketReplicationKey:        c.compareReplication,
+		domain.StorageBucketObjectLockKey:         c.compareObjectLock,
+		domain.StorageBucketNotificationKey:       c.compareNotifications,
+		domain.StorageBucketPublicAccessBlockKey:  c.comparePublicAccessBlock,
+		domain.StorageBucketObjectOwnershipKey:    c.compareObjectOwnership,
+		domain.StorageBucketAccelerationStatusKey: helper.DefaultAttributeCompare, // Absent and "" both mean never enabled
+		domain.StorageBucketIntelligentTieringKey: c.compareConfigurations("Intelligent-Tiering Configuration"),
+		domain.StorageBucketAnalyticsKey:          c.compareConfigurations("Analytics Configuration"),
+		domain.StorageBucketInventoryKey:          c.compareConfigurations("Inventory Configuration"),
+		domain.StorageBucketMetricsKey:            c.compareConfigurations("Metrics Configuration"),
 	}
 	return c
 }
@@ -52,12 +63,12 @@ func (c *BucketComparer) Compare(
 	if err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get attributes from actual resource")
 	}
-	diffs := make([]domain.AttributeDiff, 0)
+	skipped := helper.SkippedAttributes(actualAttrs)
 
-	for _, attrKey := range attributesToCheck {
-		// Check context at the beginning of each attribute comparison
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
+	return helper.CompareAttributes(ctx, attributesToCheck, func(ctx context.Context, attrKey string) (*domain.AttributeDiff, error) {
+		if skipped[attrKey] {
+			domain.ExplanationFrom(ctx).SkipAttribute(attrKey, "not fetched within the enrichment budget")
+			return nil, nil
 		}
 
 		desiredVal, dExists := desiredAttrs[attrKey]
@@ -67,10 +78,17 @@ func (c *BucketComparer) Compare(
 		var details string
 		var compareErr error
 
-		if compareFunc, ok := c.compareFuncs[attrKey]; ok {
-			isEqual, details, compareErr = compareFunc(ctx, desiredVal, actualVal, dExists, aExists)
+		if attrKey == domain.StorageBucketSecureTransportKey {
+			// Derived from the policy on both sides rather than stored as an attribute.
+			desiredVal, actualVal, compareErr = deriveSecureTransport(desiredAttrs, actualAttrs)
+			dExists, aExists = true, true
+			if compareErr == nil {
+				isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, c.compareSecureTransport, desiredVal, actualVal, dExists, aExists)
+			}
+		} else if compareFunc, ok := c.compareFuncs[attrKey]; ok {
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, compareFunc, desiredVal, actualVal, dExists, aExists)
 		} else {
-			isEqual, details, compareErr = helper.DefaultAttributeCompare(ctx, desiredVal, actualVal, dExists, aExists)
+			isEqual, details, compareErr = helper.CompareAttribute(ctx, attrKey, helper.DefaultAttributeCompare, desiredVal, actualVal, dExists, aExists)
 		}
 
 		// Check context again after potentially long comparison function
@@ -79,24 +97,47 @@ func (c *BucketComparer) Compare(
 		}
 
 		if compareErr != nil {
-			diffs = append(diffs, domain.AttributeDiff{
+			return &domain.AttributeDiff{
 				AttributeName: attrKey, ExpectedValue: desiredVal, ActualValue: actualVal,
-				Details: fmt.Sprintf("Comparison error: %v", compareErr),
-			})
-			continue
+				Details:  fmt.Sprintf("Comparison error: %v", compareErr),
+				Severity: helper.SeverityForAttribute(attrKey),
+			}, nil
 		}
 
 		if !isEqual {
-			diffs = append(diffs, domain.AttributeDiff{
+			severity := helper.SeverityForDifference(attrKey, desiredVal, actualVal)
+			switch attrKey {
+			case domain.StorageBucketPublicAccessBlockKey:
+				severity = publicAccessBlockSeverity(desiredVal, actualVal)
+			case domain.StorageBucketObjectLockKey:
+				severity = objectLockSeverity(desiredVal, actualVal)
+			case domain.StorageBucketNotificationKey:
+				severity = notificationSeverity(desiredVal, actualVal)
+			}
+			return &domain.AttributeDiff{
 				AttributeName: attrKey,
 				ExpectedValue: desiredVal,
 				ActualValue:   actualVal,
 				Details:       details,
-			})
+				Severity:      severity,
+			}, nil
 		}
-	}
+		return nil, nil
+	})
+}
 
-	return diffs, nil
+// deriveSecureTransport evaluates whether the desired and actual bucket policies
+// enforce TLS-only access.
+func deriveSecureTransport(desiredAttrs, actualAttrs map[string]any) (bool, bool, error) {
+	desired, err := helper.SecureTransportEnforced(desiredAttrs[domain.StorageBucketPolicyKey])
+	if err != nil {
+		return false, false, errors.Wrap(err, errors.CodeComparisonError, "failed to evaluate desired bucket policy")
+	}
+	actual, err := helper.SecureTransportEnforced(actualAttrs[domain.StorageBucketPolicyKey])
+	if err != nil {
+		return false, false, errors.Wrap(err, errors.CodeComparisonError, "failed to evaluate actual bucket policy")
+	}
+	return desired, actual, nil
 }
 
 func (c *BucketComparer) compareTags(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
@@ -373,8 +414,13 @@ func (c *BucketComparer) compareCorsRules(ctx context.Context, desired, actual a
 	return true, "", nil
 }
 
+func (c *BucketComparer) compareSecureTransport(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	helper.ExplainStep(ctx, "derived from the aws:SecureTransport deny statement of each bucket policy (desired: %v, actual: %v)", desired, actual)
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
+
 func (c *BucketComparer) comparePolicy(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
-	return helper.CompareJSONStrings(ctx, desired, actual, dExists, aExists, "Policy")
+	return helper.CompareJSONDocuments(ctx, desired, actual, dExists, aExists, "Policy")
 }
 
 func (c *BucketComparer) compareSimpleBlockMap(blockName string) helper.AttributeComparerFunc {
@@ -482,3 +528,94 @@ func (c *BucketComparer) compareEncryption(ctx context.Context, desired, actual
 	isEqual := details == ""
 	return isEqual, details, nil
 }
+
+// publicAccessBlockSettings are the Block Public Access settings, in the order
+// differences are reported.
+var publicAccessBlockSettings = []string{
+	"block_public_acls",
+	"block_public_policy",
+	"ignore_public_acls",
+	"restrict_public_buckets",
+}
+
+// normalizePublicAccessBlock reads the Block Public Access settings from a map,
+// or a Terraform block held as a single element list, with settings that are
+// not set as false.
+func normalizePublicAccessBlock(input any) (map[string]bool, bool) {
+	if list, ok := input.([]any); ok {
+		if len(list) == 0 {
+			return nil, true
+		}
+		input = list[0]
+	}
+	if input == nil {
+		return nil, true
+	}
+	m, ok := input.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	settings := make(map[string]bool, len(publicAccessBlockSettings))
+	for _, name := range publicAccessBlockSettings {
+		switch v := m[name].(type) {
+		case bool:
+			settings[name] = v
+		case string:
+			settings[name] = v == "true"
+		}
+	}
+	return settings, true
+}
+
+// comparePublicAccessBlock compares the Block Public Access settings one by
+// one. A desired state without a public access block does not manage it, so
+// whatever the platform applies is accepted.
+func (c *BucketComparer) comparePublicAccessBlock(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	desiredSettings, dOk := normalizePublicAccessBlock(desired)
+	actualSettings, aOk := normalizePublicAccessBlock(actual)
+	if !dOk || !aOk {
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	if !dExists || desiredSettings == nil {
+		helper.ExplainStep(ctx, "public access block not managed by the desired state")
+		return true, "", nil
+	}
+	if actualSettings == nil {
+		actualSettings = map[string]bool{}
+	}
+
+	var mismatches []string
+	for _, name := range publicAccessBlockSettings {
+		if desiredSettings[name] != actualSettings[name] {
+			mismatches = append(mismatches, fmt.Sprintf("%s (desired: %t, actual: %t)", name, desiredSettings[name], actualSettings[name]))
+		}
+	}
+	if len(mismatches) > 0 {
+		return false, "Public access block settings differ: " + strings.Join(mismatches, ", "), nil
+	}
+	return true, "", nil
+}
+
+// publicAccessBlockSeverity is critical when the platform lifts a block the
+// desired state turns on, exposing the bucket, and a warning otherwise.
+func publicAccessBlockSeverity(desired, actual any) domain.Severity {
+	desiredSettings, _ := normalizePublicAccessBlock(desired)
+	actualSettings, _ := normalizePublicAccessBlock(actual)
+	for _, name := range publicAccessBlockSettings {
+		if desiredSettings[name] && !actualSettings[name] {
+			return domain.SeverityCritical
+		}
+	}
+	return domain.SeverityWarning
+}
+
+// compareObjectOwnership compares the Object Ownership setting. A desired state
+// without ownership controls does not manage them, so the platform default is
+// accepted.
+func (c *BucketComparer) compareObjectOwnership(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	if !dExists || desired == nil || desired == "" {
+		helper.ExplainStep(ctx, "object ownership not managed by the desired state")
+		return true, "", nil
+	}
+	return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+}
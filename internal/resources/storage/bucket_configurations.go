@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	localconvert "github.com/olusolaa/infra-drift-detector/internal/resources/helper/convert"
+)
+
+// compareConfigurations compares a list of bucket configurations (inventories,
+// metrics, ...) as a set keyed by configuration id, so configurations listed
+// in another order are equal. Lists within a configuration that S3 treats as
+// sets, the inventory optional fields and the Intelligent-Tiering tierings,
+// are sorted first.
+func (c *BucketComparer) compareConfigurations(itemType string) helper.AttributeComparerFunc {
+	return func(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+		return helper.CompareSliceOfMapsUnordered(ctx, normalizeConfigurations(desired), normalizeConfigurations(actual), dExists, aExists, "id", itemType)
+	}
+}
+
+func normalizeConfigurations(v any) any {
+	configs, err := localconvert.ToSliceOfMap(v)
+	if err != nil {
+		return v
+	}
+	out := make([]map[string]any, 0, len(configs))
+	for _, cfg := range configs {
+		normalized := make(map[string]any, len(cfg))
+		for k, val := range cfg {
+			switch k {
+			case "optional_fields":
+				if fields, err := localconvert.ToSliceOfString(val); err == nil {
+					sort.Strings(fields)
+					val = fields
+				}
+			case "tierings":
+				if tierings, err := localconvert.ToSliceOfMap(val); err == nil {
+					sort.Slice(tierings, func(i, j int) bool {
+						return fmt.Sprint(tierings[i]["access_tier"]) < fmt.Sprint(tierings[j]["access_tier"])
+					})
+					val = tierings
+				}
+			default:
+				val = unwrapBlock(val)
+			}
+			normalized[k] = val
+		}
+		out = append(out, normalized)
+	}
+	return out
+}
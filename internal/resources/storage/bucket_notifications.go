@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	localconvert "github.com/olusolaa/infra-drift-detector/internal/resources/helper/convert"
+	"github.com/olusolaa/infra-drift-detector/pkg/compare"
+)
+
+// notificationTargetKinds are the notification target lists, with the name
+// used in difference details.
+var notificationTargetKinds = []struct{ key, name string }{
+	{"lambda_functions", "Lambda function"},
+	{"queues", "queue"},
+	{"topics", "topic"},
+}
+
+// notifications is an event notification configuration brought to one shape:
+// each target list as a set of canonical target descriptions.
+type notifications struct {
+	eventbridge bool
+	targets     map[string][]string
+}
+
+// normalizeNotifications reads the targets of a notification configuration.
+// A target is described by its ARN, its events sorted and deduplicated, and
+// its key filter. Ids are left out: S3 generates one for targets configured
+// without, so they say nothing about where events are delivered.
+func normalizeNotifications(v any) (notifications, bool) {
+	n := notifications{targets: map[string][]string{}}
+	if v == nil {
+		return n, true
+	}
+	m, ok := unwrapBlock(v).(map[string]any)
+	if !ok {
+		return n, false
+	}
+	n.eventbridge, _ = m["eventbridge"].(bool)
+	for _, kind := range notificationTargetKinds {
+		raw, ok := m[kind.key]
+		if !ok || raw == nil {
+			continue
+		}
+		targets, err := localconvert.ToSliceOfMap(raw)
+		if err != nil {
+			return n, false
+		}
+		for _, target := range targets {
+			n.targets[kind.key] = append(n.targets[kind.key], describeNotificationTarget(target))
+		}
+		sort.Strings(n.targets[kind.key])
+	}
+	return n, true
+}
+
+func describeNotificationTarget(target map[string]any) string {
+	events, _ := localconvert.ToSliceOfString(target["events"])
+	seen := make(map[string]bool, len(events))
+	unique := make([]string, 0, len(events))
+	for _, e := range events {
+		if !seen[e] {
+			seen[e] = true
+			unique = append(unique, e)
+		}
+	}
+	sort.Strings(unique)
+	desc := fmt.Sprintf("%v events=[%s]", target["arn"], strings.Join(unique, ","))
+	for _, key := range []string{"filter_prefix", "filter_suffix"} {
+		if value, _ := target[key].(string); value != "" {
+			desc += fmt.Sprintf(" %s=%s", strings.TrimPrefix(key, "filter_"), value)
+		}
+	}
+	return desc
+}
+
+// compareNotifications compares the event notification targets of each kind
+// as sets, so targets listed in another order, events in another order and
+// generated ids are equal, and whether events are sent to EventBridge.
+func (c *BucketComparer) compareNotifications(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	desiredN, dOk := normalizeNotifications(desired)
+	actualN, aOk := normalizeNotifications(actual)
+	if !dOk || !aOk {
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	helper.ExplainStep(ctx, "compared notification targets by ARN, events and key filter, ignoring ids")
+
+	var mismatches []string
+	if desiredN.eventbridge != actualN.eventbridge {
+		mismatches = append(mismatches, fmt.Sprintf("EventBridge (desired: %t, actual: %t)", desiredN.eventbridge, actualN.eventbridge))
+	}
+	for _, kind := range notificationTargetKinds {
+		if equal, details := compare.Sets(desiredN.targets[kind.key], actualN.targets[kind.key]); !equal {
+			mismatches = append(mismatches, fmt.Sprintf("%s targets: %s", kind.name, details))
+		}
+	}
+	if len(mismatches) > 0 {
+		return false, "Event notifications differ: " + strings.Join(mismatches, "; "), nil
+	}
+	return true, "", nil
+}
+
+// notificationSeverity is critical when the platform no longer delivers
+// events the desired state sends, a target missing or EventBridge disabled,
+// since downstream consumers silently stop receiving them. Extra targets are
+// warnings.
+func notificationSeverity(desired, actual any) domain.Severity {
+	desiredN, _ := normalizeNotifications(desired)
+	actualN, _ := normalizeNotifications(actual)
+	if desiredN.eventbridge && !actualN.eventbridge {
+		return domain.SeverityCritical
+	}
+	for _, kind := range notificationTargetKinds {
+		present := make(map[string]bool, len(actualN.targets[kind.key]))
+		for _, target := range actualN.targets[kind.key] {
+			present[target] = true
+		}
+		for _, target := range desiredN.targets[kind.key] {
+			if !present[target] {
+				return domain.SeverityCritical
+			}
+		}
+	}
+	return domain.SeverityWarning
+}
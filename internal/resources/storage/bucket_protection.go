@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/resources/helper"
+	localconvert "github.com/olusolaa/infra-drift-detector/internal/resources/helper/convert"
+)
+
+// compareReplication compares replication configurations after bringing the
+// rules to one shape: Terraform blocks held as single element lists are
+// unwrapped and the filter prefix is folded into the rule. Rules are then
+// matched by id regardless of order.
+func (c *BucketComparer) compareReplication(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	return helper.CompareResilience(ctx, normalizeReplication(desired), normalizeReplication(actual), dExists, aExists)
+}
+
+func normalizeReplication(v any) any {
+	cfg, ok := unwrapBlock(v).(map[string]any)
+	if !ok {
+		return v
+	}
+	rulesRaw, hasRules := cfg["rules"]
+	if !hasRules {
+		rulesRaw, hasRules = cfg["rule"]
+	}
+	out := make(map[string]any, len(cfg))
+	for k, val := range cfg {
+		if k != "rule" && k != "rules" && !isEmptyString(val) {
+			out[k] = val
+		}
+	}
+	if !hasRules {
+		return out
+	}
+	rules, err := localconvert.ToSliceOfMap(rulesRaw)
+	if err != nil {
+		out["rules"] = rulesRaw
+		return out
+	}
+	normalized := make([]any, 0, len(rules))
+	for _, rule := range rules {
+		normalized = append(normalized, normalizeReplicationRule(rule))
+	}
+	out["rules"] = normalized
+	return out
+}
+
+func normalizeReplicationRule(rule map[string]any) map[string]any {
+	out := make(map[string]any, len(rule))
+	for k, v := range rule {
+		switch k {
+		case "filter":
+			filter, _ := unwrapBlock(v).(map[string]any)
+			if and, ok := unwrapBlock(filter["and"]).(map[string]any); ok {
+				filter = and
+			}
+			if prefix, _ := filter["prefix"].(string); prefix != "" {
+				out["prefix"] = prefix
+			}
+			if tags, ok := filter["tags"]; ok {
+				out["tags"] = tags
+			}
+		case "destination":
+			dest, ok := unwrapBlock(v).(map[string]any)
+			if !ok {
+				out[k] = v
+				continue
+			}
+			cleaned := make(map[string]any, len(dest))
+			for dk, dv := range dest {
+				if !isEmptyString(dv) {
+					cleaned[dk] = dv
+				}
+			}
+			out[k] = cleaned
+		case "delete_marker_replication":
+			if block, ok := unwrapBlock(v).(map[string]any); ok {
+				v = block["status"]
+			}
+			if !isEmptyString(v) {
+				out[k] = v
+			}
+		default:
+			if !isEmptyString(v) {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// objectLock is Object Lock brought to one shape, with retention periods of
+// zero meaning unset.
+type objectLock struct {
+	enabled bool
+	mode    string
+	days    int64
+	years   int64
+}
+
+func (o objectLock) retention() string {
+	if o.mode == "" && o.days == 0 && o.years == 0 {
+		return "none"
+	}
+	var period []string
+	if o.days > 0 {
+		period = append(period, fmt.Sprintf("%d days", o.days))
+	}
+	if o.years > 0 {
+		period = append(period, fmt.Sprintf("%d years", o.years))
+	}
+	return strings.TrimSpace(o.mode + " " + strings.Join(period, " "))
+}
+
+// retentionDays approximates the default retention in days, to tell a
+// shorter retention from a longer one.
+func (o objectLock) retentionDays() int64 {
+	return o.days + o.years*365
+}
+
+// normalizeObjectLock reads Object Lock from the domain shape ("enabled",
+// "default_retention") or the Terraform one ("object_lock_enabled", and the
+// retention nested in rule and default_retention blocks).
+func normalizeObjectLock(v any) (objectLock, bool) {
+	if v == nil {
+		return objectLock{}, true
+	}
+	m, ok := unwrapBlock(v).(map[string]any)
+	if !ok {
+		return objectLock{}, false
+	}
+	var lock objectLock
+	switch enabled := m["enabled"].(type) {
+	case bool:
+		lock.enabled = enabled
+	case string:
+		lock.enabled = strings.EqualFold(enabled, "Enabled") || enabled == "true"
+	}
+	if status, ok := m["object_lock_enabled"].(string); ok {
+		lock.enabled = strings.EqualFold(status, "Enabled")
+	}
+
+	retention, _ := unwrapBlock(m["default_retention"]).(map[string]any)
+	if rule, ok := unwrapBlock(m["rule"]).(map[string]any); ok && retention == nil {
+		retention, _ = unwrapBlock(rule["default_retention"]).(map[string]any)
+	}
+	if retention != nil {
+		lock.mode, _ = retention["mode"].(string)
+		lock.days = toInt64(retention["days"])
+		lock.years = toInt64(retention["years"])
+	}
+	return lock, true
+}
+
+// compareObjectLock compares whether Object Lock is enabled and its default
+// retention. Object Lock disabled on both sides is equal whatever retention
+// is left configured, since none applies.
+func (c *BucketComparer) compareObjectLock(ctx context.Context, desired, actual any, dExists, aExists bool) (bool, string, error) {
+	desiredLock, dOk := normalizeObjectLock(desired)
+	actualLock, aOk := normalizeObjectLock(actual)
+	if !dOk || !aOk {
+		return helper.DefaultAttributeCompare(ctx, desired, actual, dExists, aExists)
+	}
+	helper.ExplainStep(ctx, "unwrapped the default retention from the rule blocks")
+
+	if desiredLock.enabled != actualLock.enabled {
+		return false, fmt.Sprintf("Object Lock differs (desired enabled: %t, actual enabled: %t)", desiredLock.enabled, actualLock.enabled), nil
+	}
+	if !desiredLock.enabled {
+		return true, "", nil
+	}
+	if desiredLock.mode != actualLock.mode || desiredLock.days != actualLock.days || desiredLock.years != actualLock.years {
+		return false, fmt.Sprintf("Object Lock default retention differs (desired: %s, actual: %s)", desiredLock.retention(), actualLock.retention()), nil
+	}
+	return true, "", nil
+}
+
+// objectLockSeverity is critical when the platform protects objects less than
+// the desired state: Object Lock disabled, the default retention removed or
+// shortened, or compliance mode relaxed to governance. Other differences are
+// warnings.
+func objectLockSeverity(desired, actual any) domain.Severity {
+	desiredLock, _ := normalizeObjectLock(desired)
+	actualLock, _ := normalizeObjectLock(actual)
+	switch {
+	case desiredLock.enabled && !actualLock.enabled:
+		return domain.SeverityCritical
+	case actualLock.retentionDays() < desiredLock.retentionDays():
+		return domain.SeverityCritical
+	case strings.EqualFold(desiredLock.mode, "COMPLIANCE") && !strings.EqualFold(actualLock.mode, "COMPLIANCE"):
+		return domain.SeverityCritical
+	}
+	return domain.SeverityWarning
+}
+
+// unwrapBlock returns the map of a Terraform block held as a single element
+// list, or v unchanged.
+func unwrapBlock(v any) any {
+	if list, ok := v.([]any); ok && len(list) == 1 {
+		return list[0]
+	}
+	if list, ok := v.([]map[string]any); ok && len(list) == 1 {
+		return list[0]
+	}
+	return v
+}
+
+func isEmptyString(v any) bool {
+	s, ok := v.(string)
+	return ok && s == ""
+}
+
+func toInt64(v any) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	}
+	return 0
+}
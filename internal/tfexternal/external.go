@@ -0,0 +1,250 @@
+// Package tfexternal implements the protocol of Terraform's "external" data
+// source, so a Terraform configuration can run a drift scan and fail its plan
+// when the resources it depends on have drifted, e.g.
+//
+//	data "external" "drift_gate" {
+//	  program = ["drift-analyser", "terraform-external"]
+//	  query = {
+//	    filter  = "kind == \"database_instance\""
+//	    fail_on = "warning"
+//	  }
+//	}
+//
+// The program reads a JSON object of strings from stdin and writes a JSON
+// object of strings to stdout. Exiting with an error fails the data source,
+// and with it the plan.
+package tfexternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/query"
+)
+
+const (
+	// FailOnNever reports findings without ever failing the data source.
+	FailOnNever = "never"
+
+	// DefaultFailOn is the severity that fails the data source when the
+	// request does not set one.
+	DefaultFailOn = domain.SeverityCritical
+
+	// maxListedFindings bounds the findings named in the gate error.
+	maxListedFindings = 10
+)
+
+// Request keys accepted in the data source query.
+const (
+	KeyConfig = "config"
+	KeyFilter = "filter"
+	KeyFailOn = "fail_on"
+)
+
+// Result keys written to stdout. Every value is a string, as the protocol
+// requires; findings is a JSON-encoded list.
+const (
+	ResultStatus      = "status"
+	ResultDrifted     = "drifted"
+	ResultMissing     = "missing"
+	ResultUnmanaged   = "unmanaged"
+	ResultErrors      = "errors"
+	ResultMaxSeverity = "max_severity"
+	ResultFindings    = "findings"
+)
+
+// Status values of the result. A scan with blocking findings fails the data
+// source instead of returning a result.
+const (
+	StatusClean   = "clean"
+	StatusDrifted = "drifted"
+)
+
+// Request is the parsed data source query.
+type Request struct {
+	// ConfigPath overrides the configuration file of the scan.
+	ConfigPath string
+	// Filter selects the findings the gate considers, in the syntax of the
+	// "query" command. Empty considers every finding.
+	Filter *query.Query
+	// FailOn is the lowest severity that fails the data source, or empty when
+	// the data source never fails.
+	FailOn domain.Severity
+}
+
+// ReadRequest parses the JSON query Terraform writes to stdin. An empty input
+// uses the defaults.
+func ReadRequest(r io.Reader) (Request, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return Request{}, errors.Wrap(err, errors.CodeExternalQueryError, "failed to read external data source query")
+	}
+	values := map[string]string{}
+	if strings.TrimSpace(string(raw)) != "" {
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return Request{}, errors.WrapUserFacing(err, errors.CodeExternalQueryError,
+				fmt.Sprintf("invalid external data source query: %v", err),
+				"Pass a 'query' map of strings to the external data source.")
+		}
+	}
+
+	for key := range values {
+		switch key {
+		case KeyConfig, KeyFilter, KeyFailOn:
+		default:
+			return Request{}, errors.NewUserFacing(errors.CodeExternalQueryError,
+				fmt.Sprintf("unsupported external data source query key '%s'", key),
+				fmt.Sprintf("Supported keys: %s, %s, %s", KeyConfig, KeyFilter, KeyFailOn))
+		}
+	}
+
+	filter, err := query.Parse(values[KeyFilter])
+	if err != nil {
+		return Request{}, err
+	}
+	req := Request{ConfigPath: values[KeyConfig], Filter: filter, FailOn: DefaultFailOn}
+	if failOn := strings.TrimSpace(values[KeyFailOn]); failOn != "" {
+		if strings.EqualFold(failOn, FailOnNever) {
+			req.FailOn = ""
+		} else if sev, ok := domain.ParseSeverity(failOn); ok {
+			req.FailOn = sev
+		} else {
+			return Request{}, errors.NewUserFacing(errors.CodeExternalQueryError,
+				fmt.Sprintf("unsupported fail_on '%s'", failOn),
+				"Supported: info, warning, critical, never")
+		}
+	}
+	return req, nil
+}
+
+// Evaluation is the outcome of a scan for the data source.
+type Evaluation struct {
+	// Findings are the drift findings selected by the filter; findings without
+	// drift are left out.
+	Findings []query.Finding
+	// Blocking are the findings that fail the data source.
+	Blocking []query.Finding
+}
+
+// Evaluate selects the findings of the results and decides which of them fail
+// the data source. Differences block at the requested severity; a missing
+// resource has no differences and always blocks unless the filter leaves it out.
+func Evaluate(req Request, results []domain.ComparisonResult) Evaluation {
+	filter := req.Filter
+	if filter == nil {
+		filter, _ = query.Parse("")
+	}
+	var eval Evaluation
+	for _, f := range filter.Filter(query.Flatten(results)) {
+		if f.Status == domain.StatusNoDrift {
+			continue
+		}
+		eval.Findings = append(eval.Findings, f)
+		if blocks(req.FailOn, f) {
+			eval.Blocking = append(eval.Blocking, f)
+		}
+	}
+	return eval
+}
+
+func blocks(failOn domain.Severity, f query.Finding) bool {
+	if failOn == "" {
+		return false
+	}
+	switch f.Status {
+	case domain.StatusMissing:
+		return true
+	case domain.StatusUnmanaged, domain.StatusError:
+		return false
+	}
+	return f.Severity.Rank() >= failOn.Rank()
+}
+
+// Outputs renders the evaluation as the string map returned to Terraform.
+func (e Evaluation) Outputs() (map[string]string, error) {
+	counts := map[domain.ComparisonStatus]map[string]bool{}
+	var maxSeverity domain.Severity
+	for _, f := range e.Findings {
+		if counts[f.Status] == nil {
+			counts[f.Status] = map[string]bool{}
+		}
+		counts[f.Status][resourceKey(f)] = true
+		if f.Severity.Rank() > maxSeverity.Rank() {
+			maxSeverity = f.Severity
+		}
+	}
+
+	findings := e.Findings
+	if findings == nil {
+		findings = []query.Finding{}
+	}
+	encoded, err := json.Marshal(findings)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode drift findings")
+	}
+
+	status := StatusClean
+	if len(e.Findings) > 0 {
+		status = StatusDrifted
+	}
+	return map[string]string{
+		ResultStatus:      status,
+		ResultDrifted:     strconv.Itoa(len(counts[domain.StatusDrifted])),
+		ResultMissing:     strconv.Itoa(len(counts[domain.StatusMissing])),
+		ResultUnmanaged:   strconv.Itoa(len(counts[domain.StatusUnmanaged])),
+		ResultErrors:      strconv.Itoa(len(counts[domain.StatusError])),
+		ResultMaxSeverity: maxSeverity.String(),
+		ResultFindings:    string(encoded),
+	}, nil
+}
+
+// GateError returns the error that fails the data source, or nil when no
+// finding blocks. Terraform shows the message as the data source error.
+func (e Evaluation) GateError(failOn domain.Severity) error {
+	if len(e.Blocking) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, min(len(e.Blocking), maxListedFindings)+1)
+	for i, f := range e.Blocking {
+		if i == maxListedFindings {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(e.Blocking)-maxListedFindings))
+			break
+		}
+		lines = append(lines, "- "+describe(f))
+	}
+	err := errors.NewUserFacing(errors.CodeDriftGateFailed,
+		fmt.Sprintf("%d drift finding(s) block the plan (fail_on: %s)", len(e.Blocking), failOn),
+		"Reconcile the drifted resources, or narrow the gate with the 'filter' and 'fail_on' query keys.")
+	err.InternalDetails = strings.Join(lines, "\n")
+	return err
+}
+
+// WriteOutputs writes the result object for Terraform.
+func WriteOutputs(w io.Writer, outputs map[string]string) error {
+	if err := json.NewEncoder(w).Encode(outputs); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to write external data source result")
+	}
+	return nil
+}
+
+func resourceKey(f query.Finding) string {
+	return string(f.Kind) + "/" + f.Source + "/" + f.ID
+}
+
+func describe(f query.Finding) string {
+	identifier := f.Source
+	if identifier == "" {
+		identifier = f.ID
+	}
+	switch {
+	case f.Attribute != "":
+		return fmt.Sprintf("%s %s: %s differs (%s)", f.Kind, identifier, f.Attribute, f.Severity)
+	default:
+		return fmt.Sprintf("%s %s: %s", f.Kind, identifier, f.Status)
+	}
+}
@@ -0,0 +1,159 @@
+package tfexternal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+	"github.com/olusolaa/infra-drift-detector/internal/query"
+)
+
+func testResults() []domain.ComparisonResult {
+	return []domain.ComparisonResult{
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindDatabaseInstance, SourceIdentifier: "aws_db_instance.orders", ProviderAssignedID: "orders",
+			Differences: []domain.AttributeDiff{
+				{AttributeName: "backup_retention_period", Severity: domain.SeverityCritical},
+				{AttributeName: "tags", Severity: domain.SeverityInfo},
+			},
+		},
+		{
+			Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web", ProviderAssignedID: "i-123",
+			Differences: []domain.AttributeDiff{{AttributeName: "instance_type", Severity: domain.SeverityWarning}},
+		},
+		{Status: domain.StatusNoDrift, ResourceKind: domain.KindStorageBucket, SourceIdentifier: "aws_s3_bucket.logs", ProviderAssignedID: "logs"},
+		{Status: domain.StatusUnmanaged, ResourceKind: domain.KindStorageBucket, ProviderAssignedID: "scratch"},
+	}
+}
+
+func TestReadRequest_Defaults(t *testing.T) {
+	for _, input := range []string{"", "{}"} {
+		req, err := ReadRequest(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, DefaultFailOn, req.FailOn)
+		assert.Empty(t, req.ConfigPath)
+		require.NotNil(t, req.Filter)
+	}
+}
+
+func TestReadRequest_Values(t *testing.T) {
+	req, err := ReadRequest(strings.NewReader(`{"config":"drift.yaml","filter":"kind == \"compute_instance\"","fail_on":"Warning"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "drift.yaml", req.ConfigPath)
+	assert.Equal(t, domain.SeverityWarning, req.FailOn)
+	assert.True(t, req.Filter.Match(query.Finding{Kind: domain.KindComputeInstance}))
+
+	req, err = ReadRequest(strings.NewReader(`{"fail_on":"never"}`))
+	require.NoError(t, err)
+	assert.Empty(t, req.FailOn)
+}
+
+func TestReadRequest_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		code  errors.Code
+	}{
+		{"not json", `fail_on=warning`, errors.CodeExternalQueryError},
+		{"non-string value", `{"fail_on": 2}`, errors.CodeExternalQueryError},
+		{"unknown key", `{"severity":"warning"}`, errors.CodeExternalQueryError},
+		{"unknown severity", `{"fail_on":"urgent"}`, errors.CodeExternalQueryError},
+		{"invalid filter", `{"filter":"kind =="}`, errors.CodeQueryParseError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadRequest(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.code), "got %v", err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      string
+		wantFindings int
+		wantBlocking []string
+	}{
+		{"default fails on critical", `{}`, 4, []string{"backup_retention_period"}},
+		{"warning", `{"fail_on":"warning"}`, 4, []string{"backup_retention_period", "instance_type"}},
+		{"never", `{"fail_on":"never"}`, 4, nil},
+		{"filter", `{"filter":"kind == compute_instance","fail_on":"info"}`, 1, []string{"instance_type"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ReadRequest(strings.NewReader(tt.request))
+			require.NoError(t, err)
+
+			eval := Evaluate(req, testResults())
+
+			assert.Len(t, eval.Findings, tt.wantFindings)
+			var blocking []string
+			for _, f := range eval.Blocking {
+				blocking = append(blocking, f.Attribute)
+			}
+			assert.Equal(t, tt.wantBlocking, blocking)
+		})
+	}
+}
+
+func TestEvaluate_MissingBlocks(t *testing.T) {
+	results := []domain.ComparisonResult{{Status: domain.StatusMissing, ResourceKind: domain.KindIAMRole, SourceIdentifier: "aws_iam_role.app"}}
+
+	eval := Evaluate(Request{FailOn: domain.SeverityCritical}, results)
+
+	require.Len(t, eval.Blocking, 1)
+	err := eval.GateError(domain.SeverityCritical)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.CodeDriftGateFailed))
+	assert.Contains(t, errors.GetUserFacingDetails(err), "IAMRole aws_iam_role.app: MISSING")
+}
+
+func TestEvaluation_Outputs(t *testing.T) {
+	req, err := ReadRequest(strings.NewReader(`{"fail_on":"warning"}`))
+	require.NoError(t, err)
+	eval := Evaluate(req, testResults())
+
+	outputs, err := eval.Outputs()
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusDrifted, outputs[ResultStatus])
+	assert.Equal(t, "2", outputs[ResultDrifted])
+	assert.Equal(t, "0", outputs[ResultMissing])
+	assert.Equal(t, "1", outputs[ResultUnmanaged])
+	assert.Equal(t, "0", outputs[ResultErrors])
+	assert.Equal(t, "critical", outputs[ResultMaxSeverity])
+	var findings []query.Finding
+	require.NoError(t, json.Unmarshal([]byte(outputs[ResultFindings]), &findings))
+	assert.Len(t, findings, 4)
+
+	err = eval.GateError(req.FailOn)
+	require.Error(t, err)
+	details := errors.GetUserFacingDetails(err)
+	assert.Contains(t, details, "backup_retention_period differs (critical)")
+	assert.Contains(t, details, "instance_type differs (warning)")
+}
+
+func TestEvaluation_CleanOutputs(t *testing.T) {
+	eval := Evaluate(Request{FailOn: DefaultFailOn}, testResults()[2:3])
+
+	outputs, err := eval.Outputs()
+	require.NoError(t, err)
+	assert.Equal(t, StatusClean, outputs[ResultStatus])
+	assert.Equal(t, "[]", outputs[ResultFindings])
+	assert.Empty(t, outputs[ResultMaxSeverity])
+	assert.NoError(t, eval.GateError(DefaultFailOn))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteOutputs(&buf, outputs))
+	var decoded map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, outputs, decoded)
+}
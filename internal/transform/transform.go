@@ -0,0 +1,227 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+	"github.com/olusolaa/infra-drift-detector/internal/errors"
+)
+
+// Operation identifies a single transformation step.
+type Operation string
+
+const (
+	OpRename    Operation = "rename"
+	OpFlatten   Operation = "flatten"
+	OpMapValues Operation = "map_values"
+)
+
+// Target selects which side of a comparison a rule applies to.
+type Target string
+
+const (
+	TargetDesired Target = "desired"
+	TargetActual  Target = "actual"
+	TargetBoth    Target = "both"
+)
+
+// Rule is a single declarative transformation as written in the config file.
+//
+//   - rename:     moves Attribute to To.
+//   - flatten:    unwraps a single-element list block at Attribute and lifts its
+//     keys to the top level, prefixed with To (defaults to "<attribute>_").
+//   - map_values: replaces the value of Attribute using the Values lookup table.
+type Rule struct {
+	Op        Operation         `yaml:"op" mapstructure:"op" validate:"required,oneof=rename flatten map_values"`
+	Target    Target            `yaml:"target" mapstructure:"target" validate:"omitempty,oneof=desired actual both"`
+	Attribute string            `yaml:"attribute" mapstructure:"attribute" validate:"required"`
+	To        string            `yaml:"to" mapstructure:"to" validate:"required_if=Op rename"`
+	Values    map[string]string `yaml:"values" mapstructure:"values" validate:"required_if=Op map_values"`
+}
+
+func (r Rule) appliesTo(target Target) bool {
+	return r.Target == "" || r.Target == TargetBoth || r.Target == target
+}
+
+// Pipeline applies an ordered list of rules to resource attributes.
+type Pipeline struct {
+	rules []Rule
+}
+
+// NewPipeline validates the rules and builds a pipeline. A nil pipeline is
+// returned when there are no rules, which callers can treat as a no-op.
+func NewPipeline(rules []Rule) (*Pipeline, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	for i, r := range rules {
+		if r.Attribute == "" {
+			return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("transform rule %d: attribute is required", i))
+		}
+		switch r.Op {
+		case OpRename:
+			if r.To == "" {
+				return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("transform rule %d: rename requires 'to'", i))
+			}
+		case OpFlatten:
+		case OpMapValues:
+			if len(r.Values) == 0 {
+				return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("transform rule %d: map_values requires 'values'", i))
+			}
+		default:
+			return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("transform rule %d: unsupported op '%s'", i, r.Op))
+		}
+		switch r.Target {
+		case "", TargetDesired, TargetActual, TargetBoth:
+		default:
+			return nil, errors.New(errors.CodeConfigValidation, fmt.Sprintf("transform rule %d: unsupported target '%s'", i, r.Target))
+		}
+	}
+	copied := make([]Rule, len(rules))
+	copy(copied, rules)
+	return &Pipeline{rules: copied}, nil
+}
+
+// Apply returns a transformed copy of attrs for the given target. The input map
+// is never modified.
+func (p *Pipeline) Apply(attrs map[string]any, target Target) map[string]any {
+	if p == nil || attrs == nil {
+		return attrs
+	}
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		out[k] = v
+	}
+	for _, r := range p.rules {
+		if !r.appliesTo(target) {
+			continue
+		}
+		switch r.Op {
+		case OpRename:
+			applyRename(out, r)
+		case OpFlatten:
+			applyFlatten(out, r)
+		case OpMapValues:
+			applyMapValues(out, r)
+		}
+	}
+	return out
+}
+
+// WrapDesired returns a StateResource whose attributes pass through the pipeline.
+func (p *Pipeline) WrapDesired(res domain.StateResource) domain.StateResource {
+	if p == nil || res == nil {
+		return res
+	}
+	return &stateResource{StateResource: res, pipeline: p}
+}
+
+// WrapActual returns a PlatformResource whose attributes pass through the pipeline.
+func (p *Pipeline) WrapActual(res domain.PlatformResource) domain.PlatformResource {
+	if p == nil || res == nil {
+		return res
+	}
+	return &platformResource{PlatformResource: res, pipeline: p}
+}
+
+type stateResource struct {
+	domain.StateResource
+	pipeline *Pipeline
+}
+
+func (r *stateResource) Attributes() map[string]any {
+	return r.pipeline.Apply(r.StateResource.Attributes(), TargetDesired)
+}
+
+type platformResource struct {
+	domain.PlatformResource
+	pipeline *Pipeline
+}
+
+func (r *platformResource) Attributes(ctx context.Context) (map[string]any, error) {
+	attrs, err := r.PlatformResource.Attributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.pipeline.Apply(attrs, TargetActual), nil
+}
+
+func applyRename(attrs map[string]any, r Rule) {
+	v, ok := attrs[r.Attribute]
+	if !ok {
+		return
+	}
+	delete(attrs, r.Attribute)
+	attrs[r.To] = v
+}
+
+func applyFlatten(attrs map[string]any, r Rule) {
+	v, ok := attrs[r.Attribute]
+	if !ok {
+		return
+	}
+	block := unwrapSingle(v)
+	nested, isMap := block.(map[string]any)
+	if !isMap {
+		attrs[r.Attribute] = block
+		return
+	}
+	prefix := r.To
+	if prefix == "" {
+		prefix = r.Attribute + "_"
+	}
+	delete(attrs, r.Attribute)
+	for k, nv := range nested {
+		attrs[prefix+k] = nv
+	}
+}
+
+func applyMapValues(attrs map[string]any, r Rule) {
+	v, ok := attrs[r.Attribute]
+	if !ok || v == nil {
+		return
+	}
+	switch typed := v.(type) {
+	case []any:
+		mapped := make([]any, len(typed))
+		for i, item := range typed {
+			mapped[i] = mapValue(item, r.Values)
+		}
+		attrs[r.Attribute] = mapped
+	case []string:
+		mapped := make([]string, len(typed))
+		for i, item := range typed {
+			mapped[i] = fmt.Sprint(mapValue(item, r.Values))
+		}
+		attrs[r.Attribute] = mapped
+	default:
+		attrs[r.Attribute] = mapValue(v, r.Values)
+	}
+}
+
+func mapValue(v any, values map[string]string) any {
+	key := fmt.Sprint(v)
+	if mapped, ok := values[key]; ok {
+		return mapped
+	}
+	if mapped, ok := values[strings.ToLower(key)]; ok {
+		return mapped
+	}
+	return v
+}
+
+func unwrapSingle(v any) any {
+	switch typed := v.(type) {
+	case []any:
+		if len(typed) == 1 {
+			return typed[0]
+		}
+	case []map[string]any:
+		if len(typed) == 1 {
+			return typed[0]
+		}
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPipeline_Validation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rules       []Rule
+		expectError bool
+	}{
+		{"no rules", nil, false},
+		{"valid rename", []Rule{{Op: OpRename, Attribute: "a", To: "b"}}, false},
+		{"rename without to", []Rule{{Op: OpRename, Attribute: "a"}}, true},
+		{"map_values without values", []Rule{{Op: OpMapValues, Attribute: "a"}}, true},
+		{"unknown op", []Rule{{Op: "explode", Attribute: "a"}}, true},
+		{"unknown target", []Rule{{Op: OpFlatten, Attribute: "a", Target: "both_sides"}}, true},
+		{"missing attribute", []Rule{{Op: OpFlatten}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPipeline(tc.rules)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPipeline_Apply(t *testing.T) {
+	p, err := NewPipeline([]Rule{
+		{Op: OpFlatten, Attribute: "versioning", To: "versioning_", Target: TargetDesired},
+		{Op: OpRename, Attribute: "acl_grant", To: "acl"},
+		{Op: OpMapValues, Attribute: "status", Values: map[string]string{"Enabled": "true", "Suspended": "false"}, Target: TargetActual},
+	})
+	require.NoError(t, err)
+
+	desired := map[string]any{
+		"versioning": []any{map[string]any{"enabled": true}},
+		"acl_grant":  "private",
+	}
+	out := p.Apply(desired, TargetDesired)
+	assert.Equal(t, map[string]any{"versioning_enabled": true, "acl": "private"}, out)
+	assert.Contains(t, desired, "versioning", "input map must not be modified")
+
+	actual := map[string]any{"status": "Enabled", "acl_grant": "private"}
+	out = p.Apply(actual, TargetActual)
+	assert.Equal(t, map[string]any{"status": "true", "acl": "private"}, out)
+}
+
+func TestPipeline_NilIsNoop(t *testing.T) {
+	var p *Pipeline
+	attrs := map[string]any{"a": 1}
+	assert.Equal(t, attrs, p.Apply(attrs, TargetBoth))
+	assert.Nil(t, p.WrapDesired(nil))
+}
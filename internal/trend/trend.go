@@ -0,0 +1,176 @@
+// Package trend derives the drift history of resources from the runs recorded
+// in the history store, to show how a resource fared over time and which
+// resources drift again and again.
+package trend
+
+import (
+	"sort"
+	"time"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+// Entry is the outcome of one resource in one run.
+type Entry struct {
+	RunID      string                  `json:"run_id"`
+	Time       time.Time               `json:"time"`
+	Status     domain.ComparisonStatus `json:"status"`
+	Kind       domain.ResourceKind     `json:"resource_kind"`
+	Source     string                  `json:"source_identifier,omitempty"`
+	ID         string                  `json:"provider_assigned_id,omitempty"`
+	Severity   domain.Severity         `json:"severity,omitempty"`
+	Attributes []string                `json:"attributes,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// Drifter sums up the drift of one resource across runs.
+type Drifter struct {
+	Kind   domain.ResourceKind `json:"resource_kind"`
+	Source string              `json:"source_identifier,omitempty"`
+	ID     string              `json:"provider_assigned_id,omitempty"`
+	// Runs is the number of runs the resource was reported in.
+	Runs int `json:"runs"`
+	// DriftedRuns is the number of those runs in which it drifted.
+	DriftedRuns int `json:"drifted_runs"`
+	// Streak is the number of runs in a row, up to the latest run reporting
+	// the resource, in which it drifted.
+	Streak     int       `json:"streak"`
+	FirstDrift time.Time `json:"first_drift"`
+	LastDrift  time.Time `json:"last_drift"`
+	// Attributes are the attributes that drifted, most often drifted first.
+	Attributes []string `json:"attributes,omitempty"`
+}
+
+// ResourceHistory returns the entries of the resource whose source identifier
+// or platform ID is id, in run order.
+func ResourceHistory(runs []domain.RunRecord, id string) []Entry {
+	var entries []Entry
+	for _, run := range runs {
+		for _, res := range run.Results {
+			if id == "" || (res.SourceIdentifier != id && res.ProviderAssignedID != id) {
+				continue
+			}
+			entry := Entry{
+				RunID:    run.ID,
+				Time:     run.StartedAt,
+				Status:   res.Status,
+				Kind:     res.ResourceKind,
+				Source:   res.SourceIdentifier,
+				ID:       res.ProviderAssignedID,
+				Severity: res.MaxSeverity(),
+			}
+			for _, diff := range res.Differences {
+				entry.Attributes = append(entry.Attributes, diff.AttributeName)
+			}
+			if res.Error != nil {
+				entry.Error = res.Error.Error()
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Drifters returns the resources that drifted in at least one of runs, which
+// are in run order, most chronic first: by drifted runs, then by streak.
+func Drifters(runs []domain.RunRecord) []Drifter {
+	type key struct {
+		kind  domain.ResourceKind
+		label string
+	}
+	drifters := make(map[key]*Drifter)
+	attributeCounts := make(map[key]map[string]int)
+	var order []key
+
+	for _, run := range runs {
+		// A resource can have several results in one run, such as its drift
+		// and an unapproved image; it counts once per run.
+		drifted := make(map[key]bool)
+		for _, res := range run.Results {
+			k := key{res.ResourceKind, label(res)}
+			d, ok := drifters[k]
+			if !ok {
+				d = &Drifter{Kind: res.ResourceKind}
+				drifters[k] = d
+				attributeCounts[k] = make(map[string]int)
+				order = append(order, k)
+			}
+			if res.SourceIdentifier != "" {
+				d.Source = res.SourceIdentifier
+			}
+			if res.ProviderAssignedID != "" {
+				d.ID = res.ProviderAssignedID
+			}
+			if _, seen := drifted[k]; !seen {
+				drifted[k] = false
+			}
+			if IsDrift(res.Status) {
+				drifted[k] = true
+				for _, diff := range res.Differences {
+					attributeCounts[k][diff.AttributeName]++
+				}
+			}
+		}
+		for k, didDrift := range drifted {
+			d := drifters[k]
+			d.Runs++
+			if !didDrift {
+				d.Streak = 0
+				continue
+			}
+			d.DriftedRuns++
+			d.Streak++
+			if d.FirstDrift.IsZero() {
+				d.FirstDrift = run.StartedAt
+			}
+			d.LastDrift = run.StartedAt
+		}
+	}
+
+	var out []Drifter
+	for _, k := range order {
+		d := drifters[k]
+		if d.DriftedRuns == 0 {
+			continue
+		}
+		d.Attributes = byCount(attributeCounts[k])
+		out = append(out, *d)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].DriftedRuns != out[j].DriftedRuns {
+			return out[i].DriftedRuns > out[j].DriftedRuns
+		}
+		if out[i].Streak != out[j].Streak {
+			return out[i].Streak > out[j].Streak
+		}
+		return out[i].LastDrift.After(out[j].LastDrift)
+	})
+	return out
+}
+
+// IsDrift reports whether a status counts as drift, as it does for the exit
+// policy.
+func IsDrift(status domain.ComparisonStatus) bool {
+	return status == domain.StatusDrifted || status == domain.StatusUnapprovedImage
+}
+
+func byCount(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func label(res domain.ComparisonResult) string {
+	if res.SourceIdentifier != "" {
+		return res.SourceIdentifier
+	}
+	return res.ProviderAssignedID
+}
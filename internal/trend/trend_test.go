@@ -0,0 +1,100 @@
+package trend
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/olusolaa/infra-drift-detector/internal/core/domain"
+)
+
+var day0 = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func drifted(source, id string, attrs ...string) domain.ComparisonResult {
+	res := domain.ComparisonResult{Status: domain.StatusDrifted, ResourceKind: domain.KindComputeInstance, SourceIdentifier: source, ProviderAssignedID: id}
+	for _, attr := range attrs {
+		res.Differences = append(res.Differences, domain.AttributeDiff{AttributeName: attr, Severity: domain.SeverityWarning})
+	}
+	return res
+}
+
+func clean(source, id string) domain.ComparisonResult {
+	return domain.ComparisonResult{Status: domain.StatusNoDrift, ResourceKind: domain.KindComputeInstance, SourceIdentifier: source, ProviderAssignedID: id}
+}
+
+func runs(results ...[]domain.ComparisonResult) []domain.RunRecord {
+	out := make([]domain.RunRecord, len(results))
+	for i, res := range results {
+		out[i] = domain.RunRecord{ID: day0.AddDate(0, 0, i).Format("20060102"), StartedAt: day0.AddDate(0, 0, i), Results: res}
+	}
+	return out
+}
+
+func TestResourceHistory(t *testing.T) {
+	history := runs(
+		[]domain.ComparisonResult{clean("aws_instance.web", "i-1"), clean("aws_instance.api", "i-2")},
+		[]domain.ComparisonResult{drifted("aws_instance.web", "i-1", "instance_type", "tags")},
+		[]domain.ComparisonResult{{Status: domain.StatusError, ResourceKind: domain.KindComputeInstance, ProviderAssignedID: "i-1", Error: stderrors.New("throttled")}},
+	)
+
+	entries := ResourceHistory(history, "i-1")
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, domain.StatusNoDrift, entries[0].Status)
+	assert.Equal(t, "20240502", entries[1].RunID)
+	assert.Equal(t, []string{"instance_type", "tags"}, entries[1].Attributes)
+	assert.Equal(t, domain.SeverityWarning, entries[1].Severity)
+	assert.Equal(t, "throttled", entries[2].Error)
+	assert.True(t, entries[2].Time.Equal(day0.AddDate(0, 0, 2)))
+
+	assert.Len(t, ResourceHistory(history, "aws_instance.api"), 1)
+	assert.Empty(t, ResourceHistory(history, "aws_instance.unknown"))
+}
+
+func TestDrifters(t *testing.T) {
+	unapproved := domain.ComparisonResult{
+		Status: domain.StatusUnapprovedImage, ResourceKind: domain.KindComputeInstance, SourceIdentifier: "aws_instance.web", ProviderAssignedID: "i-1",
+		Differences: []domain.AttributeDiff{{AttributeName: "image_id"}},
+	}
+	history := runs(
+		[]domain.ComparisonResult{drifted("aws_instance.web", "i-1", "tags"), drifted("aws_instance.api", "i-2", "instance_type")},
+		[]domain.ComparisonResult{drifted("aws_instance.web", "i-1", "tags", "instance_type"), unapproved, clean("aws_instance.api", "i-2")},
+		[]domain.ComparisonResult{clean("aws_instance.web", "i-1"), drifted("aws_instance.api", "i-2", "instance_type"), clean("aws_instance.db", "i-3")},
+		[]domain.ComparisonResult{drifted("aws_instance.web", "i-1", "tags"), drifted("aws_instance.api", "i-2", "instance_type")},
+	)
+
+	drifters := Drifters(history)
+
+	require.Len(t, drifters, 2, "resources that never drifted are left out")
+	api, web := drifters[0], drifters[1]
+
+	assert.Equal(t, "aws_instance.web", web.Source)
+	assert.Equal(t, "i-1", web.ID)
+	assert.Equal(t, 4, web.Runs)
+	assert.Equal(t, 3, web.DriftedRuns, "the unapproved image of run 2 counts with its drift once")
+	assert.Equal(t, 1, web.Streak)
+	assert.True(t, web.FirstDrift.Equal(day0))
+	assert.True(t, web.LastDrift.Equal(day0.AddDate(0, 0, 3)))
+	assert.Equal(t, []string{"tags", "image_id", "instance_type"}, web.Attributes)
+
+	assert.Equal(t, "aws_instance.api", api.Source)
+	assert.Equal(t, 3, api.DriftedRuns)
+	assert.Equal(t, 2, api.Streak)
+	assert.Equal(t, []string{"instance_type"}, api.Attributes)
+}
+
+func TestDrifters_OrderByStreakOnTie(t *testing.T) {
+	history := runs(
+		[]domain.ComparisonResult{drifted("aws_instance.a", "", "tags"), clean("aws_instance.b", "")},
+		[]domain.ComparisonResult{clean("aws_instance.a", ""), drifted("aws_instance.b", "", "tags")},
+	)
+
+	drifters := Drifters(history)
+
+	require.Len(t, drifters, 2)
+	assert.Equal(t, "aws_instance.b", drifters[0].Source)
+	assert.Equal(t, "aws_instance.a", drifters[1].Source)
+}
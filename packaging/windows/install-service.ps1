@@ -0,0 +1,16 @@
+# Registers drift-analyser as a Windows service running in daemon mode.
+# The service name must match daemon.service.service_name (default: drift-analyser).
+param(
+    [string]$Binary = "C:\Program Files\drift-analyser\drift-analyser.exe",
+    [string]$Config = "C:\ProgramData\drift-analyser\config.yaml",
+    [string]$Name = "drift-analyser"
+)
+
+New-Service -Name $Name `
+    -BinaryPathName "`"$Binary`" daemon --config `"$Config`"" `
+    -DisplayName "Infra Drift Detector" `
+    -Description "Continuously scans cloud resources for drift from Terraform state." `
+    -StartupType Automatic
+
+# Restart the service after failures, like Restart=on-failure under systemd.
+sc.exe failure $Name reset= 86400 actions= restart/10000/restart/10000/restart/60000
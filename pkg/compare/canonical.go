@@ -0,0 +1,98 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// CanonicalJSON returns the canonical encoding of a JSON document so that
+// documents differing only cosmetically encode identically: object keys are
+// sorted, insignificant whitespace is dropped, unicode escapes are decoded and
+// numbers are normalized (1, 1.0 and 1e0 all become 1). The document may be
+// given as a string, a byte slice, or an already decoded value.
+func CanonicalJSON(doc any) (string, error) {
+	var raw []byte
+	switch typed := doc.(type) {
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return "", fmt.Errorf("cannot encode value as JSON: %w", err)
+		}
+		raw = encoded
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(canonicalNumbers(value)); err != nil {
+		return "", fmt.Errorf("cannot encode canonical JSON: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// CanonicalJSONEqual reports whether two JSON documents are equal after
+// canonicalization. Details list the paths at which they differ.
+func CanonicalJSONEqual(docA, docB any) (bool, string) {
+	canonA, errA := CanonicalJSON(docA)
+	if errA != nil {
+		return false, fmt.Sprintf("first document is not valid JSON: %v", errA)
+	}
+	canonB, errB := CanonicalJSON(docB)
+	if errB != nil {
+		return false, fmt.Sprintf("second document is not valid JSON: %v", errB)
+	}
+	if canonA != canonB {
+		changes, err := DiffJSON(canonA, canonB)
+		if err != nil || len(changes) == 0 {
+			return false, "JSON documents differ"
+		}
+		return false, "JSON documents differ: " + DescribeChanges(changes, maxDescribedChanges)
+	}
+	return true, ""
+}
+
+// canonicalNumbers rewrites every json.Number in a decoded document to its
+// canonical form. Integers keep full precision; other values use the shortest
+// float64 representation.
+func canonicalNumbers(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for k, v := range typed {
+			typed[k] = canonicalNumbers(v)
+		}
+		return typed
+	case []any:
+		for i, v := range typed {
+			typed[i] = canonicalNumbers(v)
+		}
+		return typed
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(typed.String()); ok && r.IsInt() {
+			return json.Number(r.Num().String())
+		}
+		if f, err := typed.Float64(); err == nil {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return typed
+	default:
+		return value
+	}
+}
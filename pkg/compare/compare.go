@@ -2,10 +2,7 @@ package compare
 
 import (
 	"context" // Keep context for potential future use, even if not checked everywhere here
-	"encoding/json"
 	"fmt"
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"reflect"
 	"sort"
 	"strconv"
@@ -274,26 +271,7 @@ func JSONStrings(jsonStrA, jsonStrB string) (bool, string) {
 	if jsonStrB == "" {
 		return false, "JSON differs (second empty, first not)"
 	}
-
-	var dataA, dataB interface{}
-	errA := json.Unmarshal([]byte(jsonStrA), &dataA)
-	if errA != nil {
-		if jsonStrA != jsonStrB {
-			return false, fmt.Sprintf("Strings differ (first is not valid JSON: %v)", errA)
-		}
-		return true, "" // Strings identical, even if not valid JSON
-	}
-
-	errB := json.Unmarshal([]byte(jsonStrB), &dataB)
-	if errB != nil {
-		return false, fmt.Sprintf("JSON differs (second is not valid JSON: %v)", errB)
-	}
-
-	if !cmp.Equal(dataA, dataB, cmpopts.EquateEmpty()) {
-		return false, "JSON structures differ"
-	}
-
-	return true, ""
+	return CanonicalJSONEqual(jsonStrA, jsonStrB)
 }
 
 func generateSetDiffDetails(countsA, countsB map[string]int) string {
@@ -337,3 +315,44 @@ func generateSetDiffDetails(countsA, countsB map[string]int) string {
 	}
 	return strings.Join(parts, "; ")
 }
+
+// MapStrings returns a copy of value with fn applied to every string in it,
+// including strings held by pointers and nested in slices and maps. Other
+// values are returned unchanged.
+func MapStrings(value any, fn func(string) string) any {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case *string:
+		if v == nil {
+			return v
+		}
+		return fn(*v)
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = fn(s)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = MapStrings(item, fn)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for k, s := range v {
+			out[k] = fn(s)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = MapStrings(item, fn)
+		}
+		return out
+	default:
+		return value
+	}
+}
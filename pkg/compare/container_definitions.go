@@ -0,0 +1,242 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// containerListSortKeys names the field that orders the elements of container
+// definition lists whose order ECS does not preserve or does not care about.
+var containerListSortKeys = map[string]string{
+	"environment":      "name",
+	"secrets":          "name",
+	"environmentFiles": "value",
+	"portMappings":     "containerPort",
+	"mountPoints":      "containerPath",
+	"volumesFrom":      "sourceContainer",
+	"ulimits":          "name",
+	"extraHosts":       "hostname",
+	"dependsOn":        "containerName",
+	"systemControls":   "namespace",
+}
+
+// NormalizeContainerDefinitions rewrites ECS container definitions into a
+// canonical form so that definitions ECS treats as identical compare equal:
+//   - containers are sorted by name, and environment variables, secrets, port
+//     mappings, mount points and similar lists by their identifying field
+//   - null values, empty strings, lists and objects are dropped
+//   - defaults ECS fills in are dropped: "essential": true, a container
+//     "cpu" of 0, the "tcp" port mapping protocol and a host port equal to
+//     the container port
+//
+// The definitions may be given as a JSON string, a byte slice, or an already
+// decoded value.
+func NormalizeContainerDefinitions(doc any) ([]any, error) {
+	decoded, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	containers, ok := decoded.([]any)
+	if !ok {
+		return nil, fmt.Errorf("container definitions must be a JSON array, got %T", decoded)
+	}
+
+	normalized := make([]any, 0, len(containers))
+	for i, raw := range containers {
+		container, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("container definition at index %d is not an object, got %T", i, raw)
+		}
+		pruned, _ := pruneEmpty(container).(map[string]any)
+		if pruned == nil {
+			pruned = map[string]any{}
+		}
+		normalizeContainer(pruned)
+		normalized = append(normalized, pruned)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return containerName(normalized[i]) < containerName(normalized[j])
+	})
+	return normalized, nil
+}
+
+// ContainerDefinitionsEqual reports whether two sets of container definitions
+// are equal after NormalizeContainerDefinitions. Details name the containers
+// only present on one side and those that differ.
+func ContainerDefinitionsEqual(docA, docB any) (bool, string) {
+	containersA, errA := NormalizeContainerDefinitions(docA)
+	if errA != nil {
+		return false, fmt.Sprintf("first document is not valid container definitions: %v", errA)
+	}
+	containersB, errB := NormalizeContainerDefinitions(docB)
+	if errB != nil {
+		return false, fmt.Sprintf("second document is not valid container definitions: %v", errB)
+	}
+
+	byNameA, byNameB := containersByName(containersA), containersByName(containersB)
+	var onlyA, onlyB, changed []string
+	for name, container := range byNameA {
+		other, ok := byNameB[name]
+		if !ok {
+			onlyA = append(onlyA, name)
+			continue
+		}
+		canonA, errA := CanonicalJSON(container)
+		canonB, errB := CanonicalJSON(other)
+		if errA != nil || errB != nil || canonA != canonB {
+			changed = append(changed, describeContainerChange(name, container, other))
+		}
+	}
+	for name := range byNameB {
+		if _, ok := byNameA[name]; !ok {
+			onlyB = append(onlyB, name)
+		}
+	}
+	if len(onlyA) == 0 && len(onlyB) == 0 && len(changed) == 0 {
+		return true, ""
+	}
+
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	sort.Strings(changed)
+	var details []string
+	if len(onlyA) > 0 {
+		details = append(details, fmt.Sprintf("containers only in desired: %s", strings.Join(onlyA, ", ")))
+	}
+	if len(onlyB) > 0 {
+		details = append(details, fmt.Sprintf("containers only in actual: %s", strings.Join(onlyB, ", ")))
+	}
+	details = append(details, changed...)
+	return false, "Container definitions differ: " + strings.Join(details, "; ")
+}
+
+func normalizeContainer(container map[string]any) {
+	if essential, ok := container["essential"].(bool); ok && essential {
+		delete(container, "essential")
+	}
+	if cpu, ok := container["cpu"].(json.Number); ok && cpu.String() == "0" {
+		delete(container, "cpu")
+	}
+	if mappings, ok := container["portMappings"].([]any); ok {
+		for _, raw := range mappings {
+			mapping, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			if protocol, _ := mapping["protocol"].(string); strings.EqualFold(protocol, "tcp") {
+				delete(mapping, "protocol")
+			}
+			if host, ok := mapping["hostPort"].(json.Number); ok && host == mapping["containerPort"] {
+				delete(mapping, "hostPort")
+			}
+		}
+	}
+	for field, sortKey := range containerListSortKeys {
+		list, ok := container[field].([]any)
+		if !ok {
+			continue
+		}
+		sort.SliceStable(list, func(i, j int) bool {
+			return sortValue(list[i], sortKey) < sortValue(list[j], sortKey)
+		})
+	}
+}
+
+// pruneEmpty drops null values, empty strings and empty lists and objects,
+// recursively. It returns nil when nothing is left.
+func pruneEmpty(value any) any {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if typed == "" {
+			return nil
+		}
+		return typed
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, v := range typed {
+			if pruned := pruneEmpty(v); pruned != nil {
+				out[key] = pruned
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(typed))
+		for _, v := range typed {
+			if pruned := pruneEmpty(v); pruned != nil {
+				out = append(out, pruned)
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	default:
+		return typed
+	}
+}
+
+// sortValue returns the field of a list element used for ordering. Numbers
+// are zero padded so that they sort numerically.
+func sortValue(element any, key string) string {
+	m, ok := element.(map[string]any)
+	if !ok {
+		canon, _ := CanonicalJSON(element)
+		return canon
+	}
+	switch value := m[key].(type) {
+	case json.Number:
+		return fmt.Sprintf("%020s", value.String())
+	case nil:
+		canon, _ := CanonicalJSON(m)
+		return canon
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func containerName(container any) string {
+	if m, ok := container.(map[string]any); ok {
+		name, _ := m["name"].(string)
+		return name
+	}
+	return ""
+}
+
+func containersByName(containers []any) map[string]map[string]any {
+	byName := make(map[string]map[string]any, len(containers))
+	for _, raw := range containers {
+		if container, ok := raw.(map[string]any); ok {
+			byName[containerName(container)] = container
+		}
+	}
+	return byName
+}
+
+// describeContainerChange names the top-level fields that differ between two
+// versions of a container.
+func describeContainerChange(name string, a, b map[string]any) string {
+	fields := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		fields[key] = struct{}{}
+	}
+	for key := range b {
+		fields[key] = struct{}{}
+	}
+	var differing []string
+	for key := range fields {
+		canonA, _ := CanonicalJSON(a[key])
+		canonB, _ := CanonicalJSON(b[key])
+		if canonA != canonB {
+			differing = append(differing, key)
+		}
+	}
+	sort.Strings(differing)
+	return fmt.Sprintf("container %q differs in %s", name, strings.Join(differing, ", "))
+}
@@ -0,0 +1,225 @@
+package compare
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeOp is the kind of a change between two JSON documents.
+type ChangeOp string
+
+const (
+	ChangeAdded    ChangeOp = "added"
+	ChangeRemoved  ChangeOp = "removed"
+	ChangeModified ChangeOp = "changed"
+)
+
+// maxDescribedChanges bounds the number of changes quoted in drift details.
+const maxDescribedChanges = 5
+
+// maxChangeValueLabel bounds the length of a value quoted in drift details.
+const maxChangeValueLabel = 60
+
+// plainKeyPattern matches object keys that can be written as .key in a path.
+var plainKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Change is one addition, removal or change of a value between two JSON
+// documents.
+type Change struct {
+	// Path locates the value, e.g. Statement[0].Condition.StringEquals["aws:SourceVpc"].
+	// It is empty when the documents differ at the top level.
+	Path string
+	Op   ChangeOp
+	// Old is the value in the first document, nil for an addition.
+	Old any
+	// New is the value in the second document, nil for a removal.
+	New any
+}
+
+// DiffJSON returns the changes that turn the first JSON document into the
+// second, with the canonical numbers of CanonicalJSON. Object members are
+// compared by key. List elements are aligned on the elements both lists share,
+// so inserting a statement reports one addition rather than a change to every
+// statement after it; elements in between are compared in pairs. The documents
+// may be given as JSON strings, byte slices, or already decoded values.
+func DiffJSON(docA, docB any) ([]Change, error) {
+	a, err := decodeDocument(docA)
+	if err != nil {
+		return nil, fmt.Errorf("first document: %w", err)
+	}
+	b, err := decodeDocument(docB)
+	if err != nil {
+		return nil, fmt.Errorf("second document: %w", err)
+	}
+	var changes []Change
+	diffValues("", canonicalNumbers(a), canonicalNumbers(b), &changes)
+	return changes, nil
+}
+
+// DescribeChanges renders changes on one line for drift details, quoting at
+// most limit of them; a limit of zero or less quotes all.
+func DescribeChanges(changes []Change, limit int) string {
+	shown := changes
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	parts := make([]string, 0, len(shown)+1)
+	for _, c := range shown {
+		path := c.Path
+		if path == "" {
+			path = "document"
+		}
+		switch c.Op {
+		case ChangeAdded:
+			parts = append(parts, fmt.Sprintf("%s added (%s)", path, changeValueLabel(c.New)))
+		case ChangeRemoved:
+			parts = append(parts, fmt.Sprintf("%s removed (%s)", path, changeValueLabel(c.Old)))
+		default:
+			parts = append(parts, fmt.Sprintf("%s changed (%s -> %s)", path, changeValueLabel(c.Old), changeValueLabel(c.New)))
+		}
+	}
+	if hidden := len(changes) - len(shown); hidden > 0 {
+		parts = append(parts, fmt.Sprintf("and %d more change(s)", hidden))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func diffValues(path string, a, b any, changes *[]Change) {
+	switch typedA := a.(type) {
+	case map[string]any:
+		if typedB, ok := b.(map[string]any); ok {
+			diffObjects(path, typedA, typedB, changes)
+			return
+		}
+	case []any:
+		if typedB, ok := b.([]any); ok {
+			diffLists(path, typedA, typedB, changes)
+			return
+		}
+	}
+	if canonicalKey(a) != canonicalKey(b) {
+		*changes = append(*changes, Change{Path: path, Op: ChangeModified, Old: a, New: b})
+	}
+}
+
+func diffObjects(path string, a, b map[string]any, changes *[]Change) {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		valueA, inA := a[key]
+		valueB, inB := b[key]
+		memberPath := joinKey(path, key)
+		switch {
+		case !inB:
+			*changes = append(*changes, Change{Path: memberPath, Op: ChangeRemoved, Old: valueA})
+		case !inA:
+			*changes = append(*changes, Change{Path: memberPath, Op: ChangeAdded, New: valueB})
+		default:
+			diffValues(memberPath, valueA, valueB, changes)
+		}
+	}
+}
+
+// diffLists aligns the elements of a and b on their longest common
+// subsequence. Between two aligned elements, the elements of a and b are
+// compared in pairs and the rest are removals or additions. Removed and
+// changed elements are located by their index in a, added ones by their
+// index in b.
+func diffLists(path string, a, b []any, changes *[]Change) {
+	keysA := make([]string, len(a))
+	for i, v := range a {
+		keysA[i] = canonicalKey(v)
+	}
+	keysB := make([]string, len(b))
+	for j, v := range b {
+		keysB[j] = canonicalKey(v)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if keysA[i] == keysB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	gap := func(fromA, toA, fromB, toB int) {
+		for fromA < toA && fromB < toB {
+			diffValues(joinIndex(path, fromA), a[fromA], b[fromB], changes)
+			fromA++
+			fromB++
+		}
+		for ; fromA < toA; fromA++ {
+			*changes = append(*changes, Change{Path: joinIndex(path, fromA), Op: ChangeRemoved, Old: a[fromA]})
+		}
+		for ; fromB < toB; fromB++ {
+			*changes = append(*changes, Change{Path: joinIndex(path, fromB), Op: ChangeAdded, New: b[fromB]})
+		}
+	}
+
+	i, j, startA, startB := 0, 0, 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case keysA[i] == keysB[j]:
+			gap(startA, i, startB, j)
+			i++
+			j++
+			startA, startB = i, j
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	gap(startA, len(a), startB, len(b))
+}
+
+func joinKey(path, key string) string {
+	if plainKeyPattern.MatchString(key) {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+func joinIndex(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+// canonicalKey is the canonical encoding of a decoded value, used to compare
+// values regardless of type.
+func canonicalKey(value any) string {
+	canon, err := CanonicalJSON(value)
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+	return canon
+}
+
+func changeValueLabel(value any) string {
+	label := canonicalKey(value)
+	if len(label) > maxChangeValueLabel {
+		return label[:maxChangeValueLabel] + "..."
+	}
+	return label
+}
@@ -0,0 +1,371 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// accountIDPattern matches a bare AWS account ID, which IAM accepts as a
+// principal and stores as the account's root ARN.
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// maxStatementLabel bounds the length of a statement without a Sid when it is
+// quoted in drift details.
+const maxStatementLabel = 80
+
+// NormalizePolicy rewrites an IAM-style policy document into a canonical form
+// so that documents IAM treats as identical compare equal:
+//   - a single Statement object becomes a one-element list, and statements are sorted
+//   - Action, NotAction, Resource and NotResource become sorted lists without
+//     duplicates; actions are lowercased since IAM matches them case-insensitively
+//   - Principal "*" becomes {"AWS": ["*"]}, principal values become sorted lists
+//     and bare account IDs become "arn:aws:iam::<id>:root"
+//   - condition values become sorted lists of strings, so true and "true" match
+//
+// The document may be given as a JSON string, a byte slice, or an already
+// decoded value.
+func NormalizePolicy(doc any) (map[string]any, error) {
+	decoded, err := decodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	policy, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("policy document must be a JSON object, got %T", decoded)
+	}
+
+	statements, err := normalizeStatements(policy["Statement"])
+	if err != nil {
+		return nil, err
+	}
+	if statements != nil {
+		policy["Statement"] = statements
+	}
+	return policy, nil
+}
+
+// PolicyDocumentsEqual reports whether two policy documents are equal after
+// NormalizePolicy. Details name the statements only present on one side and
+// the paths at which statements with the same Sid differ, or the paths at which
+// the documents differ when their statements are the same.
+func PolicyDocumentsEqual(docA, docB any) (bool, string) {
+	policyA, errA := NormalizePolicy(docA)
+	if errA != nil {
+		return false, fmt.Sprintf("first policy is not a valid policy document: %v", errA)
+	}
+	policyB, errB := NormalizePolicy(docB)
+	if errB != nil {
+		return false, fmt.Sprintf("second policy is not a valid policy document: %v", errB)
+	}
+
+	canonA, errA := CanonicalJSON(policyA)
+	canonB, errB := CanonicalJSON(policyB)
+	if errA != nil || errB != nil {
+		return false, "policy documents could not be encoded"
+	}
+	if canonA == canonB {
+		return true, ""
+	}
+
+	var details []string
+	removed, added := statementDiff(policyA["Statement"], policyB["Statement"])
+	removed, added, changed := changedStatements(policyA["Statement"], policyB["Statement"], removed, added)
+	if len(removed) > 0 {
+		details = append(details, fmt.Sprintf("statements only in desired: %s", strings.Join(removed, ", ")))
+	}
+	if len(added) > 0 {
+		details = append(details, fmt.Sprintf("statements only in actual: %s", strings.Join(added, ", ")))
+	}
+	details = append(details, changed...)
+	for _, key := range []string{"Version", "Id"} {
+		if fmt.Sprint(policyA[key]) != fmt.Sprint(policyB[key]) {
+			details = append(details, fmt.Sprintf("%s differs (%v vs %v)", key, policyA[key], policyB[key]))
+		}
+	}
+	if len(details) == 0 {
+		changes, err := DiffJSON(policyA, policyB)
+		if err != nil || len(changes) == 0 {
+			return false, "policy documents differ"
+		}
+		return false, "Policy differs: " + DescribeChanges(changes, maxDescribedChanges)
+	}
+	return false, "Policy differs: " + strings.Join(details, "; ")
+}
+
+func decodeDocument(doc any) (any, error) {
+	var raw []byte
+	switch typed := doc.(type) {
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode value as JSON: %w", err)
+		}
+		raw = encoded
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return value, nil
+}
+
+func normalizeStatements(raw any) ([]any, error) {
+	var statements []any
+	switch typed := raw.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		statements = []any{typed}
+	case []any:
+		statements = typed
+	default:
+		return nil, fmt.Errorf("Statement must be an object or a list, got %T", raw)
+	}
+
+	keyed := make([]struct {
+		canon     string
+		statement any
+	}, 0, len(statements))
+	for _, rawStatement := range statements {
+		statement, ok := rawStatement.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("statement must be a JSON object, got %T", rawStatement)
+		}
+		if err := normalizeStatement(statement); err != nil {
+			return nil, err
+		}
+		canon, err := CanonicalJSON(statement)
+		if err != nil {
+			return nil, err
+		}
+		keyed = append(keyed, struct {
+			canon     string
+			statement any
+		}{canon, statement})
+	}
+	sort.SliceStable(keyed, func(i, j int) bool { return keyed[i].canon < keyed[j].canon })
+
+	normalized := make([]any, len(keyed))
+	for i, entry := range keyed {
+		normalized[i] = entry.statement
+	}
+	return normalized, nil
+}
+
+func normalizeStatement(statement map[string]any) error {
+	if sid, ok := statement["Sid"].(string); ok && sid == "" {
+		delete(statement, "Sid")
+	}
+	for _, key := range []string{"Action", "NotAction"} {
+		if value, ok := statement[key]; ok {
+			statement[key] = sortedUnique(toStrings(value), strings.ToLower)
+		}
+	}
+	for _, key := range []string{"Resource", "NotResource"} {
+		if value, ok := statement[key]; ok {
+			statement[key] = sortedUnique(toStrings(value), nil)
+		}
+	}
+	for _, key := range []string{"Principal", "NotPrincipal"} {
+		if value, ok := statement[key]; ok {
+			principal, err := normalizePrincipal(value)
+			if err != nil {
+				return err
+			}
+			statement[key] = principal
+		}
+	}
+	if value, ok := statement["Condition"]; ok {
+		condition, err := normalizeCondition(value)
+		if err != nil {
+			return err
+		}
+		statement["Condition"] = condition
+	}
+	return nil
+}
+
+func normalizePrincipal(value any) (map[string]any, error) {
+	switch typed := value.(type) {
+	case string:
+		if typed == "*" {
+			return map[string]any{"AWS": []any{"*"}}, nil
+		}
+		return nil, fmt.Errorf("principal must be \"*\" or an object, got %q", typed)
+	case map[string]any:
+		normalized := make(map[string]any, len(typed))
+		for principalType, principals := range typed {
+			transform := func(s string) string { return s }
+			if principalType == "AWS" {
+				transform = func(s string) string {
+					if accountIDPattern.MatchString(s) {
+						return "arn:aws:iam::" + s + ":root"
+					}
+					return s
+				}
+			}
+			normalized[principalType] = sortedUnique(toStrings(principals), transform)
+		}
+		return normalized, nil
+	default:
+		return nil, fmt.Errorf("principal must be \"*\" or an object, got %T", value)
+	}
+}
+
+func normalizeCondition(value any) (map[string]any, error) {
+	operators, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("Condition must be an object, got %T", value)
+	}
+	normalized := make(map[string]any, len(operators))
+	for operator, rawKeys := range operators {
+		keys, ok := rawKeys.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("condition operator %s must map to an object, got %T", operator, rawKeys)
+		}
+		normalizedKeys := make(map[string]any, len(keys))
+		for key, values := range keys {
+			normalizedKeys[key] = sortedUnique(toStrings(values), nil)
+		}
+		normalized[operator] = normalizedKeys
+	}
+	return normalized, nil
+}
+
+// toStrings turns a policy value, either a scalar or a list, into strings.
+func toStrings(value any) []string {
+	switch typed := value.(type) {
+	case []any:
+		out := make([]string, 0, len(typed))
+		for _, item := range typed {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(typed)}
+	}
+}
+
+func sortedUnique(values []string, transform func(string) string) []any {
+	seen := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if transform != nil {
+			v = transform(v)
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		unique = append(unique, v)
+	}
+	sort.Strings(unique)
+	out := make([]any, len(unique))
+	for i, v := range unique {
+		out[i] = v
+	}
+	return out
+}
+
+// statementDiff returns labels of the statements only present in a and only
+// present in b.
+func statementDiff(a, b any) (onlyA, onlyB []string) {
+	canonA, labelsA := statementIndex(a)
+	canonB, labelsB := statementIndex(b)
+	for canon, label := range labelsA {
+		if _, ok := canonB[canon]; !ok {
+			onlyA = append(onlyA, label)
+		}
+	}
+	for canon, label := range labelsB {
+		if _, ok := canonA[canon]; !ok {
+			onlyB = append(onlyB, label)
+		}
+	}
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	return onlyA, onlyB
+}
+
+// changedStatements takes the statements with the same Sid out of the labels
+// of the statements only in a and only in b, and describes how they changed.
+func changedStatements(a, b any, onlyA, onlyB []string) (removed, added, changed []string) {
+	statementsA, statementsB := statementsByLabel(a), statementsByLabel(b)
+	paired := make(map[string]bool)
+	for _, label := range onlyA {
+		statementB, ok := statementsB[label]
+		if !ok || !strings.HasPrefix(label, "Sid ") {
+			continue
+		}
+		changes, err := DiffJSON(statementsA[label], statementB)
+		if err != nil || len(changes) == 0 {
+			continue
+		}
+		paired[label] = true
+		changed = append(changed, fmt.Sprintf("%s changed: %s", label, DescribeChanges(changes, maxDescribedChanges)))
+	}
+	for _, label := range onlyA {
+		if !paired[label] {
+			removed = append(removed, label)
+		}
+	}
+	for _, label := range onlyB {
+		if !paired[label] {
+			added = append(added, label)
+		}
+	}
+	return removed, added, changed
+}
+
+func statementsByLabel(raw any) map[string]any {
+	statements, _ := raw.([]any)
+	byLabel := make(map[string]any, len(statements))
+	for _, statement := range statements {
+		canon, err := CanonicalJSON(statement)
+		if err != nil {
+			continue
+		}
+		byLabel[statementLabel(statement, canon)] = statement
+	}
+	return byLabel
+}
+
+func statementIndex(raw any) (map[string]struct{}, map[string]string) {
+	statements, _ := raw.([]any)
+	present := make(map[string]struct{}, len(statements))
+	labels := make(map[string]string, len(statements))
+	for _, statement := range statements {
+		canon, err := CanonicalJSON(statement)
+		if err != nil {
+			continue
+		}
+		present[canon] = struct{}{}
+		labels[canon] = statementLabel(statement, canon)
+	}
+	return present, labels
+}
+
+func statementLabel(statement any, canon string) string {
+	if m, ok := statement.(map[string]any); ok {
+		if sid, ok := m["Sid"].(string); ok && sid != "" {
+			return fmt.Sprintf("Sid %q", sid)
+		}
+	}
+	if len(canon) > maxStatementLabel {
+		return canon[:maxStatementLabel] + "..."
+	}
+	return canon
+}